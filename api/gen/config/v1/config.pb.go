@@ -7,6 +7,7 @@
 package configv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -869,11 +870,890 @@ func (x *SaveConfigResponse) GetSuccess() bool {
 	return false
 }
 
+// RotateCallbackSecretRequest represents the request for RotateCallbackSecret method
+type RotateCallbackSecretRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the business ID, platform admins may rotate any business, others only their own
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// grace_period_seconds keeps signing with the previous secret for this long, defaults to 24 hours
+	GracePeriodSeconds int64 `protobuf:"varint,2,opt,name=grace_period_seconds,json=gracePeriodSeconds,proto3" json:"grace_period_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RotateCallbackSecretRequest) Reset() {
+	*x = RotateCallbackSecretRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateCallbackSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateCallbackSecretRequest) ProtoMessage() {}
+
+func (x *RotateCallbackSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateCallbackSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateCallbackSecretRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RotateCallbackSecretRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RotateCallbackSecretRequest) GetGracePeriodSeconds() int64 {
+	if x != nil {
+		return x.GracePeriodSeconds
+	}
+	return 0
+}
+
+// RotateCallbackSecretResponse represents the response for RotateCallbackSecret method
+type RotateCallbackSecretResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// secret is the new signing secret, it is only returned once
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// previous_expire_time is when the previous secret stops signing, in milliseconds
+	PreviousExpireTime int64 `protobuf:"varint,2,opt,name=previous_expire_time,json=previousExpireTime,proto3" json:"previous_expire_time,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RotateCallbackSecretResponse) Reset() {
+	*x = RotateCallbackSecretResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateCallbackSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateCallbackSecretResponse) ProtoMessage() {}
+
+func (x *RotateCallbackSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateCallbackSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateCallbackSecretResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RotateCallbackSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *RotateCallbackSecretResponse) GetPreviousExpireTime() int64 {
+	if x != nil {
+		return x.PreviousExpireTime
+	}
+	return 0
+}
+
+// CallbackEndpointHealth represents the health of a business callback endpoint
+type CallbackEndpointHealth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// endpoint is scheme://host of the callback URL, all paths on the same host share one health record
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// score is the exponentially weighted success rate, between 0 and 1
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// state is CLOSED, OPEN or HALF_OPEN, deliveries are paused unless CLOSED
+	State               string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	ConsecutiveFailures int64  `protobuf:"varint,4,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	Total               int64  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Failed              int64  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	// next_probe_time is when the next probe is allowed while OPEN, in milliseconds
+	NextProbeTime   int64  `protobuf:"varint,7,opt,name=next_probe_time,json=nextProbeTime,proto3" json:"next_probe_time,omitempty"`
+	LastFailureTime int64  `protobuf:"varint,8,opt,name=last_failure_time,json=lastFailureTime,proto3" json:"last_failure_time,omitempty"`
+	LastError       string `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CallbackEndpointHealth) Reset() {
+	*x = CallbackEndpointHealth{}
+	mi := &file_config_v1_config_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallbackEndpointHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallbackEndpointHealth) ProtoMessage() {}
+
+func (x *CallbackEndpointHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallbackEndpointHealth.ProtoReflect.Descriptor instead.
+func (*CallbackEndpointHealth) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CallbackEndpointHealth) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *CallbackEndpointHealth) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *CallbackEndpointHealth) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CallbackEndpointHealth) GetConsecutiveFailures() int64 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *CallbackEndpointHealth) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CallbackEndpointHealth) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *CallbackEndpointHealth) GetNextProbeTime() int64 {
+	if x != nil {
+		return x.NextProbeTime
+	}
+	return 0
+}
+
+func (x *CallbackEndpointHealth) GetLastFailureTime() int64 {
+	if x != nil {
+		return x.LastFailureTime
+	}
+	return 0
+}
+
+func (x *CallbackEndpointHealth) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+// ListCallbackEndpointHealthRequest represents the request for ListCallbackEndpointHealth method
+type ListCallbackEndpointHealthRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unhealthy_only only returns endpoints whose deliveries are paused
+	UnhealthyOnly bool `protobuf:"varint,1,opt,name=unhealthy_only,json=unhealthyOnly,proto3" json:"unhealthy_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCallbackEndpointHealthRequest) Reset() {
+	*x = ListCallbackEndpointHealthRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCallbackEndpointHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCallbackEndpointHealthRequest) ProtoMessage() {}
+
+func (x *ListCallbackEndpointHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCallbackEndpointHealthRequest.ProtoReflect.Descriptor instead.
+func (*ListCallbackEndpointHealthRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListCallbackEndpointHealthRequest) GetUnhealthyOnly() bool {
+	if x != nil {
+		return x.UnhealthyOnly
+	}
+	return false
+}
+
+// ListCallbackEndpointHealthResponse represents the response for ListCallbackEndpointHealth method
+type ListCallbackEndpointHealthResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Endpoints     []*CallbackEndpointHealth `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCallbackEndpointHealthResponse) Reset() {
+	*x = ListCallbackEndpointHealthResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCallbackEndpointHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCallbackEndpointHealthResponse) ProtoMessage() {}
+
+func (x *ListCallbackEndpointHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCallbackEndpointHealthResponse.ProtoReflect.Descriptor instead.
+func (*ListCallbackEndpointHealthResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListCallbackEndpointHealthResponse) GetEndpoints() []*CallbackEndpointHealth {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+// BizChannel represents whether a business may send through a channel
+type BizChannel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// channel is SMS, EMAIL or IN_APP
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// enabled is false when notifications on this channel are rejected with CHANNEL_DISABLED
+	Enabled bool `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// operator and reason of the last change, empty if the channel was never changed
+	Operator string `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	Reason   string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// utime is when the channel was last changed, in milliseconds, 0 if never changed
+	Utime         int64 `protobuf:"varint,5,opt,name=utime,proto3" json:"utime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BizChannel) Reset() {
+	*x = BizChannel{}
+	mi := &file_config_v1_config_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BizChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BizChannel) ProtoMessage() {}
+
+func (x *BizChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BizChannel.ProtoReflect.Descriptor instead.
+func (*BizChannel) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BizChannel) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *BizChannel) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *BizChannel) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *BizChannel) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BizChannel) GetUtime() int64 {
+	if x != nil {
+		return x.Utime
+	}
+	return 0
+}
+
+// ListBizChannelsRequest represents the request for ListBizChannels method
+type ListBizChannelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the business ID
+	Id            int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBizChannelsRequest) Reset() {
+	*x = ListBizChannelsRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBizChannelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBizChannelsRequest) ProtoMessage() {}
+
+func (x *ListBizChannelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBizChannelsRequest.ProtoReflect.Descriptor instead.
+func (*ListBizChannelsRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListBizChannelsRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ListBizChannelsResponse represents the response for ListBizChannels method
+type ListBizChannelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// channels contains every channel, channels that were never changed are enabled
+	Channels      []*BizChannel `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBizChannelsResponse) Reset() {
+	*x = ListBizChannelsResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBizChannelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBizChannelsResponse) ProtoMessage() {}
+
+func (x *ListBizChannelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBizChannelsResponse.ProtoReflect.Descriptor instead.
+func (*ListBizChannelsResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListBizChannelsResponse) GetChannels() []*BizChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+// SetBizChannelRequest represents the request for SetBizChannel method
+type SetBizChannelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the business ID
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// channel is SMS, EMAIL or IN_APP
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Enabled bool   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// reason is recorded with the change, at most 256 characters
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBizChannelRequest) Reset() {
+	*x = SetBizChannelRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBizChannelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBizChannelRequest) ProtoMessage() {}
+
+func (x *SetBizChannelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBizChannelRequest.ProtoReflect.Descriptor instead.
+func (*SetBizChannelRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetBizChannelRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetBizChannelRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *SetBizChannelRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *SetBizChannelRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// SetBizChannelResponse represents the response for SetBizChannel method
+type SetBizChannelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       *BizChannel            `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBizChannelResponse) Reset() {
+	*x = SetBizChannelResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBizChannelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBizChannelResponse) ProtoMessage() {}
+
+func (x *SetBizChannelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBizChannelResponse.ProtoReflect.Descriptor instead.
+func (*SetBizChannelResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetBizChannelResponse) GetChannel() *BizChannel {
+	if x != nil {
+		return x.Channel
+	}
+	return nil
+}
+
+// KeyPolicy represents the rules notification keys of a business must follow, checked when notifications are sent
+type KeyPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// max_length is the maximum number of characters, 0 means 256
+	MaxLength int32 `protobuf:"varint,1,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	// charset is ANY (no control characters), PRINTABLE (printable ASCII without spaces)
+	// or SAFE (letters, digits and - _ . : /), empty means ANY
+	Charset string `protobuf:"bytes,2,opt,name=charset,proto3" json:"charset,omitempty"`
+	// required_prefix is the prefix every key must start with, at most 64 characters, empty means no prefix
+	RequiredPrefix string `protobuf:"bytes,3,opt,name=required_prefix,json=requiredPrefix,proto3" json:"required_prefix,omitempty"`
+	// operator of the last change, empty if the policy was never changed
+	Operator string `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`
+	// utime is when the policy was last changed, in milliseconds, 0 if never changed
+	Utime         int64 `protobuf:"varint,5,opt,name=utime,proto3" json:"utime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyPolicy) Reset() {
+	*x = KeyPolicy{}
+	mi := &file_config_v1_config_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPolicy) ProtoMessage() {}
+
+func (x *KeyPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyPolicy.ProtoReflect.Descriptor instead.
+func (*KeyPolicy) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *KeyPolicy) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *KeyPolicy) GetCharset() string {
+	if x != nil {
+		return x.Charset
+	}
+	return ""
+}
+
+func (x *KeyPolicy) GetRequiredPrefix() string {
+	if x != nil {
+		return x.RequiredPrefix
+	}
+	return ""
+}
+
+func (x *KeyPolicy) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *KeyPolicy) GetUtime() int64 {
+	if x != nil {
+		return x.Utime
+	}
+	return 0
+}
+
+// GetKeyPolicyRequest represents the request for GetKeyPolicy method
+type GetKeyPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the business ID
+	Id            int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyPolicyRequest) Reset() {
+	*x = GetKeyPolicyRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyPolicyRequest) ProtoMessage() {}
+
+func (x *GetKeyPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetKeyPolicyRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetKeyPolicyResponse represents the response for GetKeyPolicy method
+type GetKeyPolicyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// policy has the defaults filled in when the business never set one
+	Policy        *KeyPolicy `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetKeyPolicyResponse) Reset() {
+	*x = GetKeyPolicyResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetKeyPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyPolicyResponse) ProtoMessage() {}
+
+func (x *GetKeyPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetKeyPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetKeyPolicyResponse) GetPolicy() *KeyPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// SetKeyPolicyRequest represents the request for SetKeyPolicy method
+type SetKeyPolicyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the business ID
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// policy replaces the whole policy, operator and utime are ignored
+	Policy        *KeyPolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyPolicyRequest) Reset() {
+	*x = SetKeyPolicyRequest{}
+	mi := &file_config_v1_config_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyPolicyRequest) ProtoMessage() {}
+
+func (x *SetKeyPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetKeyPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SetKeyPolicyRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetKeyPolicyRequest) GetPolicy() *KeyPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// SetKeyPolicyResponse represents the response for SetKeyPolicy method
+type SetKeyPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *KeyPolicy             `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyPolicyResponse) Reset() {
+	*x = SetKeyPolicyResponse{}
+	mi := &file_config_v1_config_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyPolicyResponse) ProtoMessage() {}
+
+func (x *SetKeyPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_config_v1_config_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetKeyPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_config_v1_config_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SetKeyPolicyResponse) GetPolicy() *KeyPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
 var File_config_v1_config_proto protoreflect.FileDescriptor
 
 const file_config_v1_config_proto_rawDesc = "" +
 	"\n" +
-	"\x16config/v1/config.proto\x12\tconfig.v1\"\xb3\x01\n" +
+	"\x16config/v1/config.proto\x12\tconfig.v1\x1a\x1cgoogle/api/annotations.proto\"\xb3\x01\n" +
 	"\vRetryConfig\x12!\n" +
 	"\fmax_attempts\x18\x01 \x01(\x05R\vmaxAttempts\x12,\n" +
 	"\x12initial_backoff_ms\x18\x02 \x01(\x05R\x10initialBackoffMs\x12$\n" +
@@ -927,13 +1807,74 @@ const file_config_v1_config_proto_rawDesc = "" +
 	"\x11SaveConfigRequest\x121\n" +
 	"\x06config\x18\x01 \x01(\v2\x19.config.v1.BusinessConfigR\x06config\".\n" +
 	"\x12SaveConfigResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xb0\x02\n" +
-	"\x15BusinessConfigService\x12E\n" +
-	"\bGetByIDs\x12\x1a.config.v1.GetByIDsRequest\x1a\x1b.config.v1.GetByIDsResponse\"\x00\x12B\n" +
-	"\aGetByID\x12\x19.config.v1.GetByIDRequest\x1a\x1a.config.v1.GetByIDResponse\"\x00\x12?\n" +
-	"\x06Delete\x12\x18.config.v1.DeleteRequest\x1a\x19.config.v1.DeleteResponse\"\x00\x12K\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"_\n" +
+	"\x1bRotateCallbackSecretRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x120\n" +
+	"\x14grace_period_seconds\x18\x02 \x01(\x03R\x12gracePeriodSeconds\"h\n" +
+	"\x1cRotateCallbackSecretResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x120\n" +
+	"\x14previous_expire_time\x18\x02 \x01(\x03R\x12previousExpireTime\"\xb4\x02\n" +
+	"\x16CallbackEndpointHealth\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x121\n" +
+	"\x14consecutive_failures\x18\x04 \x01(\x03R\x13consecutiveFailures\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x03R\x05total\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x03R\x06failed\x12&\n" +
+	"\x0fnext_probe_time\x18\a \x01(\x03R\rnextProbeTime\x12*\n" +
+	"\x11last_failure_time\x18\b \x01(\x03R\x0flastFailureTime\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\"J\n" +
+	"!ListCallbackEndpointHealthRequest\x12%\n" +
+	"\x0eunhealthy_only\x18\x01 \x01(\bR\runhealthyOnly\"e\n" +
+	"\"ListCallbackEndpointHealthResponse\x12?\n" +
+	"\tendpoints\x18\x01 \x03(\v2!.config.v1.CallbackEndpointHealthR\tendpoints\"\x8a\x01\n" +
+	"\n" +
+	"BizChannel\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05utime\x18\x05 \x01(\x03R\x05utime\"(\n" +
+	"\x16ListBizChannelsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"L\n" +
+	"\x17ListBizChannelsResponse\x121\n" +
+	"\bchannels\x18\x01 \x03(\v2\x15.config.v1.BizChannelR\bchannels\"r\n" +
+	"\x14SetBizChannelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"H\n" +
+	"\x15SetBizChannelResponse\x12/\n" +
+	"\achannel\x18\x01 \x01(\v2\x15.config.v1.BizChannelR\achannel\"\x9f\x01\n" +
+	"\tKeyPolicy\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x01 \x01(\x05R\tmaxLength\x12\x18\n" +
+	"\acharset\x18\x02 \x01(\tR\acharset\x12'\n" +
+	"\x0frequired_prefix\x18\x03 \x01(\tR\x0erequiredPrefix\x12\x1a\n" +
+	"\boperator\x18\x04 \x01(\tR\boperator\x12\x14\n" +
+	"\x05utime\x18\x05 \x01(\x03R\x05utime\"%\n" +
+	"\x13GetKeyPolicyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"D\n" +
+	"\x14GetKeyPolicyResponse\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.config.v1.KeyPolicyR\x06policy\"S\n" +
+	"\x13SetKeyPolicyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12,\n" +
+	"\x06policy\x18\x02 \x01(\v2\x14.config.v1.KeyPolicyR\x06policy\"D\n" +
+	"\x14SetKeyPolicyResponse\x12,\n" +
+	"\x06policy\x18\x01 \x01(\v2\x14.config.v1.KeyPolicyR\x06policy2\xeb\t\n" +
+	"\x15BusinessConfigService\x12h\n" +
+	"\bGetByIDs\x12\x1a.config.v1.GetByIDsRequest\x1a\x1b.config.v1.GetByIDsResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/biz-configs:batchGet\x12^\n" +
+	"\aGetByID\x12\x19.config.v1.GetByIDRequest\x1a\x1a.config.v1.GetByIDResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/biz-configs/{id}\x12[\n" +
+	"\x06Delete\x12\x18.config.v1.DeleteRequest\x1a\x19.config.v1.DeleteResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/v1/biz-configs/{id}\x12e\n" +
 	"\n" +
-	"SaveConfig\x12\x1c.config.v1.SaveConfigRequest\x1a\x1d.config.v1.SaveConfigResponse\"\x00BRZPgithub.com/serendipityConfusion/notification-platform/api/gen/config/v1;configv1b\x06proto3"
+	"SaveConfig\x12\x1c.config.v1.SaveConfigRequest\x1a\x1d.config.v1.SaveConfigResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\x1a\x0f/v1/biz-configs\x12\x9f\x01\n" +
+	"\x14RotateCallbackSecret\x12&.config.v1.RotateCallbackSecretRequest\x1a'.config.v1.RotateCallbackSecretResponse\"6\x82\xd3\xe4\x93\x020:\x01*\"+/v1/biz-configs/{id}/callback-secret:rotate\x12\xa0\x01\n" +
+	"\x1aListCallbackEndpointHealth\x12,.config.v1.ListCallbackEndpointHealthRequest\x1a-.config.v1.ListCallbackEndpointHealthResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/callback-endpoints/health\x12\x7f\n" +
+	"\x0fListBizChannels\x12!.config.v1.ListBizChannelsRequest\x1a\".config.v1.ListBizChannelsResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/biz-configs/{id}/channels\x12\x86\x01\n" +
+	"\rSetBizChannel\x12\x1f.config.v1.SetBizChannelRequest\x1a .config.v1.SetBizChannelResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\x1a'/v1/biz-configs/{id}/channels/{channel}\x12x\n" +
+	"\fGetKeyPolicy\x12\x1e.config.v1.GetKeyPolicyRequest\x1a\x1f.config.v1.GetKeyPolicyResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/biz-configs/{id}/key-policy\x12{\n" +
+	"\fSetKeyPolicy\x12\x1e.config.v1.SetKeyPolicyRequest\x1a\x1f.config.v1.SetKeyPolicyResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\x1a\x1f/v1/biz-configs/{id}/key-policyBRZPgithub.com/serendipityConfusion/notification-platform/api/gen/config/v1;configv1b\x06proto3"
 
 var (
 	file_config_v1_config_proto_rawDescOnce sync.Once
@@ -947,25 +1888,40 @@ func file_config_v1_config_proto_rawDescGZIP() []byte {
 	return file_config_v1_config_proto_rawDescData
 }
 
-var file_config_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_config_v1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_config_v1_config_proto_goTypes = []any{
-	(*RetryConfig)(nil),        // 0: config.v1.RetryConfig
-	(*ChannelItem)(nil),        // 1: config.v1.ChannelItem
-	(*ChannelConfig)(nil),      // 2: config.v1.ChannelConfig
-	(*TxnConfig)(nil),          // 3: config.v1.TxnConfig
-	(*MonthlyConfig)(nil),      // 4: config.v1.MonthlyConfig
-	(*QuotaConfig)(nil),        // 5: config.v1.QuotaConfig
-	(*CallbackConfig)(nil),     // 6: config.v1.CallbackConfig
-	(*BusinessConfig)(nil),     // 7: config.v1.BusinessConfig
-	(*GetByIDsRequest)(nil),    // 8: config.v1.GetByIDsRequest
-	(*GetByIDsResponse)(nil),   // 9: config.v1.GetByIDsResponse
-	(*GetByIDRequest)(nil),     // 10: config.v1.GetByIDRequest
-	(*GetByIDResponse)(nil),    // 11: config.v1.GetByIDResponse
-	(*DeleteRequest)(nil),      // 12: config.v1.DeleteRequest
-	(*DeleteResponse)(nil),     // 13: config.v1.DeleteResponse
-	(*SaveConfigRequest)(nil),  // 14: config.v1.SaveConfigRequest
-	(*SaveConfigResponse)(nil), // 15: config.v1.SaveConfigResponse
-	nil,                        // 16: config.v1.GetByIDsResponse.ConfigsEntry
+	(*RetryConfig)(nil),                        // 0: config.v1.RetryConfig
+	(*ChannelItem)(nil),                        // 1: config.v1.ChannelItem
+	(*ChannelConfig)(nil),                      // 2: config.v1.ChannelConfig
+	(*TxnConfig)(nil),                          // 3: config.v1.TxnConfig
+	(*MonthlyConfig)(nil),                      // 4: config.v1.MonthlyConfig
+	(*QuotaConfig)(nil),                        // 5: config.v1.QuotaConfig
+	(*CallbackConfig)(nil),                     // 6: config.v1.CallbackConfig
+	(*BusinessConfig)(nil),                     // 7: config.v1.BusinessConfig
+	(*GetByIDsRequest)(nil),                    // 8: config.v1.GetByIDsRequest
+	(*GetByIDsResponse)(nil),                   // 9: config.v1.GetByIDsResponse
+	(*GetByIDRequest)(nil),                     // 10: config.v1.GetByIDRequest
+	(*GetByIDResponse)(nil),                    // 11: config.v1.GetByIDResponse
+	(*DeleteRequest)(nil),                      // 12: config.v1.DeleteRequest
+	(*DeleteResponse)(nil),                     // 13: config.v1.DeleteResponse
+	(*SaveConfigRequest)(nil),                  // 14: config.v1.SaveConfigRequest
+	(*SaveConfigResponse)(nil),                 // 15: config.v1.SaveConfigResponse
+	(*RotateCallbackSecretRequest)(nil),        // 16: config.v1.RotateCallbackSecretRequest
+	(*RotateCallbackSecretResponse)(nil),       // 17: config.v1.RotateCallbackSecretResponse
+	(*CallbackEndpointHealth)(nil),             // 18: config.v1.CallbackEndpointHealth
+	(*ListCallbackEndpointHealthRequest)(nil),  // 19: config.v1.ListCallbackEndpointHealthRequest
+	(*ListCallbackEndpointHealthResponse)(nil), // 20: config.v1.ListCallbackEndpointHealthResponse
+	(*BizChannel)(nil),                         // 21: config.v1.BizChannel
+	(*ListBizChannelsRequest)(nil),             // 22: config.v1.ListBizChannelsRequest
+	(*ListBizChannelsResponse)(nil),            // 23: config.v1.ListBizChannelsResponse
+	(*SetBizChannelRequest)(nil),               // 24: config.v1.SetBizChannelRequest
+	(*SetBizChannelResponse)(nil),              // 25: config.v1.SetBizChannelResponse
+	(*KeyPolicy)(nil),                          // 26: config.v1.KeyPolicy
+	(*GetKeyPolicyRequest)(nil),                // 27: config.v1.GetKeyPolicyRequest
+	(*GetKeyPolicyResponse)(nil),               // 28: config.v1.GetKeyPolicyResponse
+	(*SetKeyPolicyRequest)(nil),                // 29: config.v1.SetKeyPolicyRequest
+	(*SetKeyPolicyResponse)(nil),               // 30: config.v1.SetKeyPolicyResponse
+	nil,                                        // 31: config.v1.GetByIDsResponse.ConfigsEntry
 }
 var file_config_v1_config_proto_depIdxs = []int32{
 	1,  // 0: config.v1.ChannelConfig.channels:type_name -> config.v1.ChannelItem
@@ -977,23 +1933,41 @@ var file_config_v1_config_proto_depIdxs = []int32{
 	3,  // 6: config.v1.BusinessConfig.txn_config:type_name -> config.v1.TxnConfig
 	5,  // 7: config.v1.BusinessConfig.quota:type_name -> config.v1.QuotaConfig
 	6,  // 8: config.v1.BusinessConfig.callback_config:type_name -> config.v1.CallbackConfig
-	16, // 9: config.v1.GetByIDsResponse.configs:type_name -> config.v1.GetByIDsResponse.ConfigsEntry
+	31, // 9: config.v1.GetByIDsResponse.configs:type_name -> config.v1.GetByIDsResponse.ConfigsEntry
 	7,  // 10: config.v1.GetByIDResponse.config:type_name -> config.v1.BusinessConfig
 	7,  // 11: config.v1.SaveConfigRequest.config:type_name -> config.v1.BusinessConfig
-	7,  // 12: config.v1.GetByIDsResponse.ConfigsEntry.value:type_name -> config.v1.BusinessConfig
-	8,  // 13: config.v1.BusinessConfigService.GetByIDs:input_type -> config.v1.GetByIDsRequest
-	10, // 14: config.v1.BusinessConfigService.GetByID:input_type -> config.v1.GetByIDRequest
-	12, // 15: config.v1.BusinessConfigService.Delete:input_type -> config.v1.DeleteRequest
-	14, // 16: config.v1.BusinessConfigService.SaveConfig:input_type -> config.v1.SaveConfigRequest
-	9,  // 17: config.v1.BusinessConfigService.GetByIDs:output_type -> config.v1.GetByIDsResponse
-	11, // 18: config.v1.BusinessConfigService.GetByID:output_type -> config.v1.GetByIDResponse
-	13, // 19: config.v1.BusinessConfigService.Delete:output_type -> config.v1.DeleteResponse
-	15, // 20: config.v1.BusinessConfigService.SaveConfig:output_type -> config.v1.SaveConfigResponse
-	17, // [17:21] is the sub-list for method output_type
-	13, // [13:17] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	18, // 12: config.v1.ListCallbackEndpointHealthResponse.endpoints:type_name -> config.v1.CallbackEndpointHealth
+	21, // 13: config.v1.ListBizChannelsResponse.channels:type_name -> config.v1.BizChannel
+	21, // 14: config.v1.SetBizChannelResponse.channel:type_name -> config.v1.BizChannel
+	26, // 15: config.v1.GetKeyPolicyResponse.policy:type_name -> config.v1.KeyPolicy
+	26, // 16: config.v1.SetKeyPolicyRequest.policy:type_name -> config.v1.KeyPolicy
+	26, // 17: config.v1.SetKeyPolicyResponse.policy:type_name -> config.v1.KeyPolicy
+	7,  // 18: config.v1.GetByIDsResponse.ConfigsEntry.value:type_name -> config.v1.BusinessConfig
+	8,  // 19: config.v1.BusinessConfigService.GetByIDs:input_type -> config.v1.GetByIDsRequest
+	10, // 20: config.v1.BusinessConfigService.GetByID:input_type -> config.v1.GetByIDRequest
+	12, // 21: config.v1.BusinessConfigService.Delete:input_type -> config.v1.DeleteRequest
+	14, // 22: config.v1.BusinessConfigService.SaveConfig:input_type -> config.v1.SaveConfigRequest
+	16, // 23: config.v1.BusinessConfigService.RotateCallbackSecret:input_type -> config.v1.RotateCallbackSecretRequest
+	19, // 24: config.v1.BusinessConfigService.ListCallbackEndpointHealth:input_type -> config.v1.ListCallbackEndpointHealthRequest
+	22, // 25: config.v1.BusinessConfigService.ListBizChannels:input_type -> config.v1.ListBizChannelsRequest
+	24, // 26: config.v1.BusinessConfigService.SetBizChannel:input_type -> config.v1.SetBizChannelRequest
+	27, // 27: config.v1.BusinessConfigService.GetKeyPolicy:input_type -> config.v1.GetKeyPolicyRequest
+	29, // 28: config.v1.BusinessConfigService.SetKeyPolicy:input_type -> config.v1.SetKeyPolicyRequest
+	9,  // 29: config.v1.BusinessConfigService.GetByIDs:output_type -> config.v1.GetByIDsResponse
+	11, // 30: config.v1.BusinessConfigService.GetByID:output_type -> config.v1.GetByIDResponse
+	13, // 31: config.v1.BusinessConfigService.Delete:output_type -> config.v1.DeleteResponse
+	15, // 32: config.v1.BusinessConfigService.SaveConfig:output_type -> config.v1.SaveConfigResponse
+	17, // 33: config.v1.BusinessConfigService.RotateCallbackSecret:output_type -> config.v1.RotateCallbackSecretResponse
+	20, // 34: config.v1.BusinessConfigService.ListCallbackEndpointHealth:output_type -> config.v1.ListCallbackEndpointHealthResponse
+	23, // 35: config.v1.BusinessConfigService.ListBizChannels:output_type -> config.v1.ListBizChannelsResponse
+	25, // 36: config.v1.BusinessConfigService.SetBizChannel:output_type -> config.v1.SetBizChannelResponse
+	28, // 37: config.v1.BusinessConfigService.GetKeyPolicy:output_type -> config.v1.GetKeyPolicyResponse
+	30, // 38: config.v1.BusinessConfigService.SetKeyPolicy:output_type -> config.v1.SetKeyPolicyResponse
+	29, // [29:39] is the sub-list for method output_type
+	19, // [19:29] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_config_v1_config_proto_init() }
@@ -1007,7 +1981,7 @@ func file_config_v1_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_v1_config_proto_rawDesc), len(file_config_v1_config_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BusinessConfigService_GetByIDs_FullMethodName   = "/config.v1.BusinessConfigService/GetByIDs"
-	BusinessConfigService_GetByID_FullMethodName    = "/config.v1.BusinessConfigService/GetByID"
-	BusinessConfigService_Delete_FullMethodName     = "/config.v1.BusinessConfigService/Delete"
-	BusinessConfigService_SaveConfig_FullMethodName = "/config.v1.BusinessConfigService/SaveConfig"
+	BusinessConfigService_GetByIDs_FullMethodName                   = "/config.v1.BusinessConfigService/GetByIDs"
+	BusinessConfigService_GetByID_FullMethodName                    = "/config.v1.BusinessConfigService/GetByID"
+	BusinessConfigService_Delete_FullMethodName                     = "/config.v1.BusinessConfigService/Delete"
+	BusinessConfigService_SaveConfig_FullMethodName                 = "/config.v1.BusinessConfigService/SaveConfig"
+	BusinessConfigService_RotateCallbackSecret_FullMethodName       = "/config.v1.BusinessConfigService/RotateCallbackSecret"
+	BusinessConfigService_ListCallbackEndpointHealth_FullMethodName = "/config.v1.BusinessConfigService/ListCallbackEndpointHealth"
+	BusinessConfigService_ListBizChannels_FullMethodName            = "/config.v1.BusinessConfigService/ListBizChannels"
+	BusinessConfigService_SetBizChannel_FullMethodName              = "/config.v1.BusinessConfigService/SetBizChannel"
+	BusinessConfigService_GetKeyPolicy_FullMethodName               = "/config.v1.BusinessConfigService/GetKeyPolicy"
+	BusinessConfigService_SetKeyPolicy_FullMethodName               = "/config.v1.BusinessConfigService/SetKeyPolicy"
 )
 
 // BusinessConfigServiceClient is the client API for BusinessConfigService service.
@@ -39,6 +45,19 @@ type BusinessConfigServiceClient interface {
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	// SaveConfig saves non-zero fields of a business configuration
 	SaveConfig(ctx context.Context, in *SaveConfigRequest, opts ...grpc.CallOption) (*SaveConfigResponse, error)
+	// RotateCallbackSecret generates a new callback signing secret, the previous one keeps signing during the grace period
+	RotateCallbackSecret(ctx context.Context, in *RotateCallbackSecretRequest, opts ...grpc.CallOption) (*RotateCallbackSecretResponse, error)
+	// ListCallbackEndpointHealth lists the health of callback endpoints seen by this instance, platform admins only
+	ListCallbackEndpointHealth(ctx context.Context, in *ListCallbackEndpointHealthRequest, opts ...grpc.CallOption) (*ListCallbackEndpointHealthResponse, error)
+	// ListBizChannels lists whether each channel is enabled for a business, platform admins only
+	ListBizChannels(ctx context.Context, in *ListBizChannelsRequest, opts ...grpc.CallOption) (*ListBizChannelsResponse, error)
+	// SetBizChannel enables or disables a channel for a business, takes effect on all instances immediately, platform admins only
+	SetBizChannel(ctx context.Context, in *SetBizChannelRequest, opts ...grpc.CallOption) (*SetBizChannelResponse, error)
+	// GetKeyPolicy gets the notification key policy of a business, platform admins only
+	GetKeyPolicy(ctx context.Context, in *GetKeyPolicyRequest, opts ...grpc.CallOption) (*GetKeyPolicyResponse, error)
+	// SetKeyPolicy replaces the notification key policy of a business, takes effect on all instances immediately,
+	// notifications already created are not affected, platform admins only
+	SetKeyPolicy(ctx context.Context, in *SetKeyPolicyRequest, opts ...grpc.CallOption) (*SetKeyPolicyResponse, error)
 }
 
 type businessConfigServiceClient struct {
@@ -89,6 +108,66 @@ func (c *businessConfigServiceClient) SaveConfig(ctx context.Context, in *SaveCo
 	return out, nil
 }
 
+func (c *businessConfigServiceClient) RotateCallbackSecret(ctx context.Context, in *RotateCallbackSecretRequest, opts ...grpc.CallOption) (*RotateCallbackSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateCallbackSecretResponse)
+	err := c.cc.Invoke(ctx, BusinessConfigService_RotateCallbackSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessConfigServiceClient) ListCallbackEndpointHealth(ctx context.Context, in *ListCallbackEndpointHealthRequest, opts ...grpc.CallOption) (*ListCallbackEndpointHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCallbackEndpointHealthResponse)
+	err := c.cc.Invoke(ctx, BusinessConfigService_ListCallbackEndpointHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessConfigServiceClient) ListBizChannels(ctx context.Context, in *ListBizChannelsRequest, opts ...grpc.CallOption) (*ListBizChannelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBizChannelsResponse)
+	err := c.cc.Invoke(ctx, BusinessConfigService_ListBizChannels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessConfigServiceClient) SetBizChannel(ctx context.Context, in *SetBizChannelRequest, opts ...grpc.CallOption) (*SetBizChannelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetBizChannelResponse)
+	err := c.cc.Invoke(ctx, BusinessConfigService_SetBizChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessConfigServiceClient) GetKeyPolicy(ctx context.Context, in *GetKeyPolicyRequest, opts ...grpc.CallOption) (*GetKeyPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetKeyPolicyResponse)
+	err := c.cc.Invoke(ctx, BusinessConfigService_GetKeyPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessConfigServiceClient) SetKeyPolicy(ctx context.Context, in *SetKeyPolicyRequest, opts ...grpc.CallOption) (*SetKeyPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetKeyPolicyResponse)
+	err := c.cc.Invoke(ctx, BusinessConfigService_SetKeyPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BusinessConfigServiceServer is the server API for BusinessConfigService service.
 // All implementations must embed UnimplementedBusinessConfigServiceServer
 // for forward compatibility.
@@ -103,6 +182,19 @@ type BusinessConfigServiceServer interface {
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	// SaveConfig saves non-zero fields of a business configuration
 	SaveConfig(context.Context, *SaveConfigRequest) (*SaveConfigResponse, error)
+	// RotateCallbackSecret generates a new callback signing secret, the previous one keeps signing during the grace period
+	RotateCallbackSecret(context.Context, *RotateCallbackSecretRequest) (*RotateCallbackSecretResponse, error)
+	// ListCallbackEndpointHealth lists the health of callback endpoints seen by this instance, platform admins only
+	ListCallbackEndpointHealth(context.Context, *ListCallbackEndpointHealthRequest) (*ListCallbackEndpointHealthResponse, error)
+	// ListBizChannels lists whether each channel is enabled for a business, platform admins only
+	ListBizChannels(context.Context, *ListBizChannelsRequest) (*ListBizChannelsResponse, error)
+	// SetBizChannel enables or disables a channel for a business, takes effect on all instances immediately, platform admins only
+	SetBizChannel(context.Context, *SetBizChannelRequest) (*SetBizChannelResponse, error)
+	// GetKeyPolicy gets the notification key policy of a business, platform admins only
+	GetKeyPolicy(context.Context, *GetKeyPolicyRequest) (*GetKeyPolicyResponse, error)
+	// SetKeyPolicy replaces the notification key policy of a business, takes effect on all instances immediately,
+	// notifications already created are not affected, platform admins only
+	SetKeyPolicy(context.Context, *SetKeyPolicyRequest) (*SetKeyPolicyResponse, error)
 	mustEmbedUnimplementedBusinessConfigServiceServer()
 }
 
@@ -125,6 +217,24 @@ func (UnimplementedBusinessConfigServiceServer) Delete(context.Context, *DeleteR
 func (UnimplementedBusinessConfigServiceServer) SaveConfig(context.Context, *SaveConfigRequest) (*SaveConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveConfig not implemented")
 }
+func (UnimplementedBusinessConfigServiceServer) RotateCallbackSecret(context.Context, *RotateCallbackSecretRequest) (*RotateCallbackSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateCallbackSecret not implemented")
+}
+func (UnimplementedBusinessConfigServiceServer) ListCallbackEndpointHealth(context.Context, *ListCallbackEndpointHealthRequest) (*ListCallbackEndpointHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCallbackEndpointHealth not implemented")
+}
+func (UnimplementedBusinessConfigServiceServer) ListBizChannels(context.Context, *ListBizChannelsRequest) (*ListBizChannelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBizChannels not implemented")
+}
+func (UnimplementedBusinessConfigServiceServer) SetBizChannel(context.Context, *SetBizChannelRequest) (*SetBizChannelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBizChannel not implemented")
+}
+func (UnimplementedBusinessConfigServiceServer) GetKeyPolicy(context.Context, *GetKeyPolicyRequest) (*GetKeyPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyPolicy not implemented")
+}
+func (UnimplementedBusinessConfigServiceServer) SetKeyPolicy(context.Context, *SetKeyPolicyRequest) (*SetKeyPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyPolicy not implemented")
+}
 func (UnimplementedBusinessConfigServiceServer) mustEmbedUnimplementedBusinessConfigServiceServer() {}
 func (UnimplementedBusinessConfigServiceServer) testEmbeddedByValue()                               {}
 
@@ -218,6 +328,114 @@ func _BusinessConfigService_SaveConfig_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BusinessConfigService_RotateCallbackSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateCallbackSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessConfigServiceServer).RotateCallbackSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessConfigService_RotateCallbackSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessConfigServiceServer).RotateCallbackSecret(ctx, req.(*RotateCallbackSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessConfigService_ListCallbackEndpointHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCallbackEndpointHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessConfigServiceServer).ListCallbackEndpointHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessConfigService_ListCallbackEndpointHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessConfigServiceServer).ListCallbackEndpointHealth(ctx, req.(*ListCallbackEndpointHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessConfigService_ListBizChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBizChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessConfigServiceServer).ListBizChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessConfigService_ListBizChannels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessConfigServiceServer).ListBizChannels(ctx, req.(*ListBizChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessConfigService_SetBizChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBizChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessConfigServiceServer).SetBizChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessConfigService_SetBizChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessConfigServiceServer).SetBizChannel(ctx, req.(*SetBizChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessConfigService_GetKeyPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessConfigServiceServer).GetKeyPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessConfigService_GetKeyPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessConfigServiceServer).GetKeyPolicy(ctx, req.(*GetKeyPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessConfigService_SetKeyPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessConfigServiceServer).SetKeyPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessConfigService_SetKeyPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessConfigServiceServer).SetKeyPolicy(ctx, req.(*SetKeyPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BusinessConfigService_ServiceDesc is the grpc.ServiceDesc for BusinessConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +459,30 @@ var BusinessConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SaveConfig",
 			Handler:    _BusinessConfigService_SaveConfig_Handler,
 		},
+		{
+			MethodName: "RotateCallbackSecret",
+			Handler:    _BusinessConfigService_RotateCallbackSecret_Handler,
+		},
+		{
+			MethodName: "ListCallbackEndpointHealth",
+			Handler:    _BusinessConfigService_ListCallbackEndpointHealth_Handler,
+		},
+		{
+			MethodName: "ListBizChannels",
+			Handler:    _BusinessConfigService_ListBizChannels_Handler,
+		},
+		{
+			MethodName: "SetBizChannel",
+			Handler:    _BusinessConfigService_SetBizChannel_Handler,
+		},
+		{
+			MethodName: "GetKeyPolicy",
+			Handler:    _BusinessConfigService_GetKeyPolicy_Handler,
+		},
+		{
+			MethodName: "SetKeyPolicy",
+			Handler:    _BusinessConfigService_SetKeyPolicy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "config/v1/config.proto",
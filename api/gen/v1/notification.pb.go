@@ -7,8 +7,11 @@
 package notificationpb
 
 import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -79,6 +82,59 @@ func (Channel) EnumDescriptor() ([]byte, []int) {
 	return file_notification_v1_notification_proto_rawDescGZIP(), []int{0}
 }
 
+// 通知类别，额度用完时业务方的透支策略按类别决定是否允许发送
+type NotificationCategory int32
+
+const (
+	// 未指定，按事务类处理
+	NotificationCategory_NOTIFICATION_CATEGORY_UNSPECIFIED NotificationCategory = 0
+	// 事务类，比如验证码、订单状态
+	NotificationCategory_TRANSACTIONAL NotificationCategory = 1
+	// 营销类
+	NotificationCategory_MARKETING NotificationCategory = 2
+)
+
+// Enum value maps for NotificationCategory.
+var (
+	NotificationCategory_name = map[int32]string{
+		0: "NOTIFICATION_CATEGORY_UNSPECIFIED",
+		1: "TRANSACTIONAL",
+		2: "MARKETING",
+	}
+	NotificationCategory_value = map[string]int32{
+		"NOTIFICATION_CATEGORY_UNSPECIFIED": 0,
+		"TRANSACTIONAL":                     1,
+		"MARKETING":                         2,
+	}
+)
+
+func (x NotificationCategory) Enum() *NotificationCategory {
+	p := new(NotificationCategory)
+	*p = x
+	return p
+}
+
+func (x NotificationCategory) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationCategory) Descriptor() protoreflect.EnumDescriptor {
+	return file_notification_v1_notification_proto_enumTypes[1].Descriptor()
+}
+
+func (NotificationCategory) Type() protoreflect.EnumType {
+	return &file_notification_v1_notification_proto_enumTypes[1]
+}
+
+func (x NotificationCategory) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationCategory.Descriptor instead.
+func (NotificationCategory) EnumDescriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{1}
+}
+
 // 通知发送状态枚举
 type SendStatus int32
 
@@ -95,6 +151,8 @@ const (
 	SendStatus_SUCCEEDED SendStatus = 4
 	// 发送失败
 	SendStatus_FAILED SendStatus = 5
+	// 发送中
+	SendStatus_SENDING SendStatus = 6
 )
 
 // Enum value maps for SendStatus.
@@ -106,6 +164,7 @@ var (
 		3: "PENDING",
 		4: "SUCCEEDED",
 		5: "FAILED",
+		6: "SENDING",
 	}
 	SendStatus_value = map[string]int32{
 		"SEND_STATUS_UNSPECIFIED": 0,
@@ -114,6 +173,7 @@ var (
 		"PENDING":                 3,
 		"SUCCEEDED":               4,
 		"FAILED":                  5,
+		"SENDING":                 6,
 	}
 )
 
@@ -128,11 +188,11 @@ func (x SendStatus) String() string {
 }
 
 func (SendStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_notification_v1_notification_proto_enumTypes[1].Descriptor()
+	return file_notification_v1_notification_proto_enumTypes[2].Descriptor()
 }
 
 func (SendStatus) Type() protoreflect.EnumType {
-	return &file_notification_v1_notification_proto_enumTypes[1]
+	return &file_notification_v1_notification_proto_enumTypes[2]
 }
 
 func (x SendStatus) Number() protoreflect.EnumNumber {
@@ -141,7 +201,7 @@ func (x SendStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SendStatus.Descriptor instead.
 func (SendStatus) EnumDescriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{1}
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{2}
 }
 
 // 错误代码枚举
@@ -182,6 +242,10 @@ const (
 	ErrorCode_PROVIDER_NOT_FOUND ErrorCode = 15
 	// 未知渠道类型
 	ErrorCode_UNKNOWN_CHANNEL ErrorCode = 16
+	// 模板版本未审核通过（平台内部审核或者供应商审核）
+	ErrorCode_TEMPLATE_NOT_APPROVED ErrorCode = 17
+	// 计划发送时间已经结束，没有调用供应商
+	ErrorCode_SEND_WINDOW_CLOSED ErrorCode = 18
 )
 
 // Enum value maps for ErrorCode.
@@ -204,6 +268,8 @@ var (
 		14: "QUOTA_NOT_FOUND",
 		15: "PROVIDER_NOT_FOUND",
 		16: "UNKNOWN_CHANNEL",
+		17: "TEMPLATE_NOT_APPROVED",
+		18: "SEND_WINDOW_CLOSED",
 	}
 	ErrorCode_value = map[string]int32{
 		"ERROR_CODE_UNSPECIFIED":     0,
@@ -223,6 +289,8 @@ var (
 		"QUOTA_NOT_FOUND":            14,
 		"PROVIDER_NOT_FOUND":         15,
 		"UNKNOWN_CHANNEL":            16,
+		"TEMPLATE_NOT_APPROVED":      17,
+		"SEND_WINDOW_CLOSED":         18,
 	}
 )
 
@@ -237,11 +305,11 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
-	return file_notification_v1_notification_proto_enumTypes[2].Descriptor()
+	return file_notification_v1_notification_proto_enumTypes[3].Descriptor()
 }
 
 func (ErrorCode) Type() protoreflect.EnumType {
-	return &file_notification_v1_notification_proto_enumTypes[2]
+	return &file_notification_v1_notification_proto_enumTypes[3]
 }
 
 func (x ErrorCode) Number() protoreflect.EnumNumber {
@@ -250,7 +318,7 @@ func (x ErrorCode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ErrorCode.Descriptor instead.
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{2}
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{3}
 }
 
 // 通知发送策略定义
@@ -263,6 +331,8 @@ type SendStrategy struct {
 	//	*SendStrategy_Scheduled
 	//	*SendStrategy_TimeWindow
 	//	*SendStrategy_Deadline
+	//	*SendStrategy_LocalTime
+	//	*SendStrategy_Paced
 	StrategyType  isSendStrategy_StrategyType `protobuf_oneof:"strategy_type"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -350,6 +420,24 @@ func (x *SendStrategy) GetDeadline() *SendStrategy_DeadlineStrategy {
 	return nil
 }
 
+func (x *SendStrategy) GetLocalTime() *SendStrategy_LocalTimeStrategy {
+	if x != nil {
+		if x, ok := x.StrategyType.(*SendStrategy_LocalTime); ok {
+			return x.LocalTime
+		}
+	}
+	return nil
+}
+
+func (x *SendStrategy) GetPaced() *SendStrategy_PacedStrategy {
+	if x != nil {
+		if x, ok := x.StrategyType.(*SendStrategy_Paced); ok {
+			return x.Paced
+		}
+	}
+	return nil
+}
+
 type isSendStrategy_StrategyType interface {
 	isSendStrategy_StrategyType()
 }
@@ -379,6 +467,16 @@ type SendStrategy_Deadline struct {
 	Deadline *SendStrategy_DeadlineStrategy `protobuf:"bytes,5,opt,name=deadline,proto3,oneof"`
 }
 
+type SendStrategy_LocalTime struct {
+	// 按接收者本地时间发送，只支持异步发送
+	LocalTime *SendStrategy_LocalTimeStrategy `protobuf:"bytes,6,opt,name=local_time,json=localTime,proto3,oneof"`
+}
+
+type SendStrategy_Paced struct {
+	// 按活动限速发送，只支持异步发送
+	Paced *SendStrategy_PacedStrategy `protobuf:"bytes,7,opt,name=paced,proto3,oneof"`
+}
+
 func (*SendStrategy_Immediate) isSendStrategy_StrategyType() {}
 
 func (*SendStrategy_Delayed) isSendStrategy_StrategyType() {}
@@ -389,6 +487,10 @@ func (*SendStrategy_TimeWindow) isSendStrategy_StrategyType() {}
 
 func (*SendStrategy_Deadline) isSendStrategy_StrategyType() {}
 
+func (*SendStrategy_LocalTime) isSendStrategy_StrategyType() {}
+
+func (*SendStrategy_Paced) isSendStrategy_StrategyType() {}
+
 // 通知
 type Notification struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -411,9 +513,29 @@ type Notification struct {
 	// string field2 = 8;
 	// 重要，并且几乎大家都要传
 	// string importantField = 2;
-	Receiver      string `protobuf:"bytes,7,opt,name=receiver,proto3" json:"receiver,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Receiver string `protobuf:"bytes,7,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	// 可合并发送，只支持异步发送。同一个接收者在业务方配置的合并窗口内的可合并通知，
+	// 窗口结束后合并成一条摘要消息，使用业务方配置的摘要模板发送
+	Digest *DigestOptions `protobuf:"bytes,8,opt,name=digest,proto3" json:"digest,omitempty"`
+	// 通知类别，不指定时按事务类处理
+	Category NotificationCategory `protobuf:"varint,9,opt,name=category,proto3,enum=notification.v1.NotificationCategory" json:"category,omitempty"`
+	// 这条通知的回调设置，不传时发送成功后回调业务方配置的默认地址。只有同步发送会回调
+	Callback *CallbackOptions `protobuf:"bytes,10,opt,name=callback,proto3" json:"callback,omitempty"`
+	// 标签，可以按标签查询，会带在回调里。最多 10 个，键由小写字母、数字和 _ . - 组成，最长 63，值最长 128
+	// 明文保存，不要放手机号、邮箱等敏感信息
+	Labels map[string]string `protobuf:"bytes,11,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 渠道降级分组，最长 128，只支持异步发送。同一个分组里的通知（比如同一个事件的短信和邮件）按业务方声明的渠道顺序依次发送，
+	// 一个渠道发送成功后，其余还没有发送的通知被取消
+	FallbackGroup string `protobuf:"bytes,12,opt,name=fallback_group,json=fallbackGroup,proto3" json:"fallback_group,omitempty"`
+	// 业务方透传数据（例如内部订单号），平台不解析，回调、查询和导出时原样返回。最长 1024 字节，明文保存
+	BizPayload string `protobuf:"bytes,13,opt,name=biz_payload,json=bizPayload,proto3" json:"biz_payload,omitempty"`
+	// 发送失败之后的重试策略，不传时使用平台默认值。对延迟敏感的通知（比如验证码）可以减少重试次数
+	RetryPolicy *RetryPolicy `protobuf:"bytes,14,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// 按接收者覆盖的模板参数，同名参数优先于 template_params，用于个性化的批量发送（比如每个人的称呼、金额）。
+	// 只能给 receivers 里的接收者设置，合并发送和按本地时间发送不支持
+	ReceiverParams []*ReceiverParams `protobuf:"bytes,15,rep,name=receiver_params,json=receiverParams,proto3" json:"receiver_params,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Notification) Reset() {
@@ -495,28 +617,85 @@ func (x *Notification) GetReceiver() string {
 	return ""
 }
 
-// 同步单条发送通知请求
-type SendNotificationRequest struct {
+func (x *Notification) GetDigest() *DigestOptions {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *Notification) GetCategory() NotificationCategory {
+	if x != nil {
+		return x.Category
+	}
+	return NotificationCategory_NOTIFICATION_CATEGORY_UNSPECIFIED
+}
+
+func (x *Notification) GetCallback() *CallbackOptions {
+	if x != nil {
+		return x.Callback
+	}
+	return nil
+}
+
+func (x *Notification) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Notification) GetFallbackGroup() string {
+	if x != nil {
+		return x.FallbackGroup
+	}
+	return ""
+}
+
+func (x *Notification) GetBizPayload() string {
+	if x != nil {
+		return x.BizPayload
+	}
+	return ""
+}
+
+func (x *Notification) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *Notification) GetReceiverParams() []*ReceiverParams {
+	if x != nil {
+		return x.ReceiverParams
+	}
+	return nil
+}
+
+// 一个接收者的模板参数
+type ReceiverParams struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	Receiver      string                 `protobuf:"bytes,1,opt,name=receiver,proto3" json:"receiver,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendNotificationRequest) Reset() {
-	*x = SendNotificationRequest{}
+func (x *ReceiverParams) Reset() {
+	*x = ReceiverParams{}
 	mi := &file_notification_v1_notification_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendNotificationRequest) String() string {
+func (x *ReceiverParams) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendNotificationRequest) ProtoMessage() {}
+func (*ReceiverParams) ProtoMessage() {}
 
-func (x *SendNotificationRequest) ProtoReflect() protoreflect.Message {
+func (x *ReceiverParams) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_v1_notification_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -528,47 +707,54 @@ func (x *SendNotificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendNotificationRequest.ProtoReflect.Descriptor instead.
-func (*SendNotificationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReceiverParams.ProtoReflect.Descriptor instead.
+func (*ReceiverParams) Descriptor() ([]byte, []int) {
 	return file_notification_v1_notification_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SendNotificationRequest) GetNotification() *Notification {
+func (x *ReceiverParams) GetReceiver() string {
 	if x != nil {
-		return x.Notification
+		return x.Receiver
 	}
-	return nil
+	return ""
 }
 
-// 同步单条发送通知响应
-type SendNotificationResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// 通知平台生成的通知ID
-	NotificationId uint64 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	// 发送状态
-	Status SendStatus `protobuf:"varint,2,opt,name=status,proto3,enum=notification.v1.SendStatus" json:"status,omitempty"`
-	// 失败时的错误代码
-	ErrorCode ErrorCode `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=notification.v1.ErrorCode" json:"error_code,omitempty"`
-	// 错误详情
-	ErrorMessage  string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ReceiverParams) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
 }
 
-func (x *SendNotificationResponse) Reset() {
-	*x = SendNotificationResponse{}
+// 发送失败之后的重试策略，字段为 0 时使用平台默认值，超出平台限制时拒绝请求。
+// 第 n 次重试前等待 initial_backoff_seconds * 2^(n-1) 秒，不超过 max_backoff_seconds，重试不会超过计划发送结束时间。
+// 供应商明确返回接收者、模板问题等不值得重试的失败时，不论策略都不再重试
+type RetryPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 最多发送的次数，包括第一次，1 表示不重试。默认 3，最多 10
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// 第一次重试前等待的秒数，默认 10，范围 1 到 3600
+	InitialBackoffSeconds int64 `protobuf:"varint,2,opt,name=initial_backoff_seconds,json=initialBackoffSeconds,proto3" json:"initial_backoff_seconds,omitempty"`
+	// 重试等待秒数的上限，默认 600，范围 1 到 3600，不能小于 initial_backoff_seconds
+	MaxBackoffSeconds int64 `protobuf:"varint,3,opt,name=max_backoff_seconds,json=maxBackoffSeconds,proto3" json:"max_backoff_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
 	mi := &file_notification_v1_notification_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendNotificationResponse) String() string {
+func (x *RetryPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendNotificationResponse) ProtoMessage() {}
+func (*RetryPolicy) ProtoMessage() {}
 
-func (x *SendNotificationResponse) ProtoReflect() protoreflect.Message {
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_v1_notification_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -580,61 +766,59 @@ func (x *SendNotificationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendNotificationResponse.ProtoReflect.Descriptor instead.
-func (*SendNotificationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
 	return file_notification_v1_notification_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SendNotificationResponse) GetNotificationId() uint64 {
+func (x *RetryPolicy) GetMaxAttempts() int32 {
 	if x != nil {
-		return x.NotificationId
+		return x.MaxAttempts
 	}
 	return 0
 }
 
-func (x *SendNotificationResponse) GetStatus() SendStatus {
-	if x != nil {
-		return x.Status
-	}
-	return SendStatus_SEND_STATUS_UNSPECIFIED
-}
-
-func (x *SendNotificationResponse) GetErrorCode() ErrorCode {
+func (x *RetryPolicy) GetInitialBackoffSeconds() int64 {
 	if x != nil {
-		return x.ErrorCode
+		return x.InitialBackoffSeconds
 	}
-	return ErrorCode_ERROR_CODE_UNSPECIFIED
+	return 0
 }
 
-func (x *SendNotificationResponse) GetErrorMessage() string {
+func (x *RetryPolicy) GetMaxBackoffSeconds() int64 {
 	if x != nil {
-		return x.ErrorMessage
+		return x.MaxBackoffSeconds
 	}
-	return ""
+	return 0
 }
 
-// 异步单条发送通知请求
-type SendNotificationAsyncRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+// 单条通知的回调设置，请求体和默认回调一样使用业务方的回调密钥签名
+type CallbackOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 覆盖业务方配置的默认回调地址，只支持 http 和 https，最长 512
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// 回调时额外带上的请求头，最多 10 个，不能覆盖 Content-Type 和签名相关的请求头
+	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 哪些发送结果需要回调，只能是 SUCCEEDED 或者 FAILED，为空时只回调 SUCCEEDED
+	OnStatus      []SendStatus `protobuf:"varint,3,rep,packed,name=on_status,json=onStatus,proto3,enum=notification.v1.SendStatus" json:"on_status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendNotificationAsyncRequest) Reset() {
-	*x = SendNotificationAsyncRequest{}
+func (x *CallbackOptions) Reset() {
+	*x = CallbackOptions{}
 	mi := &file_notification_v1_notification_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendNotificationAsyncRequest) String() string {
+func (x *CallbackOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendNotificationAsyncRequest) ProtoMessage() {}
+func (*CallbackOptions) ProtoMessage() {}
 
-func (x *SendNotificationAsyncRequest) ProtoReflect() protoreflect.Message {
+func (x *CallbackOptions) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_v1_notification_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -646,45 +830,55 @@ func (x *SendNotificationAsyncRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendNotificationAsyncRequest.ProtoReflect.Descriptor instead.
-func (*SendNotificationAsyncRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CallbackOptions.ProtoReflect.Descriptor instead.
+func (*CallbackOptions) Descriptor() ([]byte, []int) {
 	return file_notification_v1_notification_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SendNotificationAsyncRequest) GetNotification() *Notification {
+func (x *CallbackOptions) GetUrl() string {
 	if x != nil {
-		return x.Notification
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CallbackOptions) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
 	}
 	return nil
 }
 
-// 异步单条发送通知响应
-type SendNotificationAsyncResponse struct {
+func (x *CallbackOptions) GetOnStatus() []SendStatus {
+	if x != nil {
+		return x.OnStatus
+	}
+	return nil
+}
+
+// 合并发送参数
+type DigestOptions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 通知平台生成的通知ID
-	NotificationId uint64 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
-	// 失败时的错误代码
-	ErrorCode ErrorCode `protobuf:"varint,4,opt,name=error_code,json=errorCode,proto3,enum=notification.v1.ErrorCode" json:"error_code,omitempty"`
-	// 错误详情
-	ErrorMessage  string `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// 这条通知在摘要消息里的一行，最长 200
+	Summary       string `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendNotificationAsyncResponse) Reset() {
-	*x = SendNotificationAsyncResponse{}
+func (x *DigestOptions) Reset() {
+	*x = DigestOptions{}
 	mi := &file_notification_v1_notification_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendNotificationAsyncResponse) String() string {
+func (x *DigestOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendNotificationAsyncResponse) ProtoMessage() {}
+func (*DigestOptions) ProtoMessage() {}
 
-func (x *SendNotificationAsyncResponse) ProtoReflect() protoreflect.Message {
+func (x *DigestOptions) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_v1_notification_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -696,54 +890,42 @@ func (x *SendNotificationAsyncResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendNotificationAsyncResponse.ProtoReflect.Descriptor instead.
-func (*SendNotificationAsyncResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DigestOptions.ProtoReflect.Descriptor instead.
+func (*DigestOptions) Descriptor() ([]byte, []int) {
 	return file_notification_v1_notification_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SendNotificationAsyncResponse) GetNotificationId() uint64 {
-	if x != nil {
-		return x.NotificationId
-	}
-	return 0
-}
-
-func (x *SendNotificationAsyncResponse) GetErrorCode() ErrorCode {
-	if x != nil {
-		return x.ErrorCode
-	}
-	return ErrorCode_ERROR_CODE_UNSPECIFIED
-}
-
-func (x *SendNotificationAsyncResponse) GetErrorMessage() string {
+func (x *DigestOptions) GetSummary() string {
 	if x != nil {
-		return x.ErrorMessage
+		return x.Summary
 	}
 	return ""
 }
 
-// 同步批量发送通知请求
-type BatchSendNotificationsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+// 同步单条发送通知请求
+type SendNotificationRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Notification *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	// 试运行：校验模板和参数、渲染模板、检查额度、选择供应商，不保存也不发送，结果在 dry_run_result 里
+	DryRun        bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchSendNotificationsRequest) Reset() {
-	*x = BatchSendNotificationsRequest{}
+func (x *SendNotificationRequest) Reset() {
+	*x = SendNotificationRequest{}
 	mi := &file_notification_v1_notification_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchSendNotificationsRequest) String() string {
+func (x *SendNotificationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchSendNotificationsRequest) ProtoMessage() {}
+func (*SendNotificationRequest) ProtoMessage() {}
 
-func (x *BatchSendNotificationsRequest) ProtoReflect() protoreflect.Message {
+func (x *SendNotificationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_v1_notification_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,45 +937,62 @@ func (x *BatchSendNotificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchSendNotificationsRequest.ProtoReflect.Descriptor instead.
-func (*BatchSendNotificationsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendNotificationRequest.ProtoReflect.Descriptor instead.
+func (*SendNotificationRequest) Descriptor() ([]byte, []int) {
 	return file_notification_v1_notification_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *BatchSendNotificationsRequest) GetNotifications() []*Notification {
+func (x *SendNotificationRequest) GetNotification() *Notification {
 	if x != nil {
-		return x.Notifications
+		return x.Notification
 	}
 	return nil
 }
 
-// 同步批量发送通知响应
-type BatchSendNotificationsResponse struct {
+func (x *SendNotificationRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// 同步单条发送通知响应
+type SendNotificationResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 所有结果
-	Results []*SendNotificationResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
-	// 总数
-	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	// 成功数
-	SuccessCount  int32 `protobuf:"varint,3,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	// 通知平台生成的通知ID
+	NotificationId uint64 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	// 发送状态
+	Status SendStatus `protobuf:"varint,2,opt,name=status,proto3,enum=notification.v1.SendStatus" json:"status,omitempty"`
+	// 失败时的错误代码
+	ErrorCode ErrorCode `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=notification.v1.ErrorCode" json:"error_code,omitempty"`
+	// 错误详情
+	ErrorMessage string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// 业务方已经用同一个 key 发送过，返回的是已有通知的ID和状态，这次请求没有创建新通知
+	Duplicate bool `protobuf:"varint,5,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	// 试运行的结果，只有试运行并且通过了所有检查时才有
+	DryRunResult *DryRunResult `protobuf:"bytes,6,opt,name=dry_run_result,json=dryRunResult,proto3" json:"dry_run_result,omitempty"`
+	// 沙箱凭证发送的通知，只发给模拟供应商，不扣减额度
+	Sandbox bool `protobuf:"varint,7,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+	// 发送时传的透传数据，只在查询结果里返回
+	BizPayload    string `protobuf:"bytes,8,opt,name=biz_payload,json=bizPayload,proto3" json:"biz_payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchSendNotificationsResponse) Reset() {
-	*x = BatchSendNotificationsResponse{}
+func (x *SendNotificationResponse) Reset() {
+	*x = SendNotificationResponse{}
 	mi := &file_notification_v1_notification_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchSendNotificationsResponse) String() string {
+func (x *SendNotificationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchSendNotificationsResponse) ProtoMessage() {}
+func (*SendNotificationResponse) ProtoMessage() {}
 
-func (x *BatchSendNotificationsResponse) ProtoReflect() protoreflect.Message {
+func (x *SendNotificationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_notification_v1_notification_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -805,55 +1004,189 @@ func (x *BatchSendNotificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchSendNotificationsResponse.ProtoReflect.Descriptor instead.
-func (*BatchSendNotificationsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SendNotificationResponse.ProtoReflect.Descriptor instead.
+func (*SendNotificationResponse) Descriptor() ([]byte, []int) {
 	return file_notification_v1_notification_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BatchSendNotificationsResponse) GetResults() []*SendNotificationResponse {
+func (x *SendNotificationResponse) GetNotificationId() uint64 {
 	if x != nil {
-		return x.Results
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *SendNotificationResponse) GetStatus() SendStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SendStatus_SEND_STATUS_UNSPECIFIED
+}
+
+func (x *SendNotificationResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *SendNotificationResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SendNotificationResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *SendNotificationResponse) GetDryRunResult() *DryRunResult {
+	if x != nil {
+		return x.DryRunResult
 	}
 	return nil
 }
 
-func (x *BatchSendNotificationsResponse) GetTotalCount() int32 {
+func (x *SendNotificationResponse) GetSandbox() bool {
 	if x != nil {
-		return x.TotalCount
+		return x.Sandbox
+	}
+	return false
+}
+
+func (x *SendNotificationResponse) GetBizPayload() string {
+	if x != nil {
+		return x.BizPayload
+	}
+	return ""
+}
+
+// 试运行结果，真正发送时会使用的模板、额度和供应商
+type DryRunResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 生效的模板版本
+	TemplateVersionId int64 `protobuf:"varint,1,opt,name=template_version_id,json=templateVersionId,proto3" json:"template_version_id,omitempty"`
+	// 短信签名
+	Signature string `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// 用参数渲染之后的模板内容
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// 可以使用的供应商，按优先级排列，真正发送时跳过熔断中的供应商
+	Providers []string `protobuf:"bytes,4,rep,name=providers,proto3" json:"providers,omitempty"`
+	// 扣减之前的剩余额度，批量试运行时扣除了前面的通知，透支时为负数
+	QuotaRemaining int32 `protobuf:"varint,5,opt,name=quota_remaining,json=quotaRemaining,proto3" json:"quota_remaining,omitempty"`
+	// 计划发送时间范围，毫秒时间戳
+	ScheduledStartTime int64 `protobuf:"varint,6,opt,name=scheduled_start_time,json=scheduledStartTime,proto3" json:"scheduled_start_time,omitempty"`
+	ScheduledEndTime   int64 `protobuf:"varint,7,opt,name=scheduled_end_time,json=scheduledEndTime,proto3" json:"scheduled_end_time,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DryRunResult) Reset() {
+	*x = DryRunResult{}
+	mi := &file_notification_v1_notification_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DryRunResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunResult) ProtoMessage() {}
+
+func (x *DryRunResult) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunResult.ProtoReflect.Descriptor instead.
+func (*DryRunResult) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DryRunResult) GetTemplateVersionId() int64 {
+	if x != nil {
+		return x.TemplateVersionId
 	}
 	return 0
 }
 
-func (x *BatchSendNotificationsResponse) GetSuccessCount() int32 {
+func (x *DryRunResult) GetSignature() string {
 	if x != nil {
-		return x.SuccessCount
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *DryRunResult) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *DryRunResult) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *DryRunResult) GetQuotaRemaining() int32 {
+	if x != nil {
+		return x.QuotaRemaining
 	}
 	return 0
 }
 
-// 异步批量发送通知请求
-type BatchSendNotificationsAsyncRequest struct {
+func (x *DryRunResult) GetScheduledStartTime() int64 {
+	if x != nil {
+		return x.ScheduledStartTime
+	}
+	return 0
+}
+
+func (x *DryRunResult) GetScheduledEndTime() int64 {
+	if x != nil {
+		return x.ScheduledEndTime
+	}
+	return 0
+}
+
+// 异步单条发送通知请求
+type SendNotificationAsyncRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchSendNotificationsAsyncRequest) Reset() {
-	*x = BatchSendNotificationsAsyncRequest{}
-	mi := &file_notification_v1_notification_proto_msgTypes[8]
+func (x *SendNotificationAsyncRequest) Reset() {
+	*x = SendNotificationAsyncRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchSendNotificationsAsyncRequest) String() string {
+func (x *SendNotificationAsyncRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchSendNotificationsAsyncRequest) ProtoMessage() {}
+func (*SendNotificationAsyncRequest) ProtoMessage() {}
 
-func (x *BatchSendNotificationsAsyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[8]
+func (x *SendNotificationAsyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -864,42 +1197,52 @@ func (x *BatchSendNotificationsAsyncRequest) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchSendNotificationsAsyncRequest.ProtoReflect.Descriptor instead.
-func (*BatchSendNotificationsAsyncRequest) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use SendNotificationAsyncRequest.ProtoReflect.Descriptor instead.
+func (*SendNotificationAsyncRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *BatchSendNotificationsAsyncRequest) GetNotifications() []*Notification {
+func (x *SendNotificationAsyncRequest) GetNotification() *Notification {
 	if x != nil {
-		return x.Notifications
+		return x.Notification
 	}
 	return nil
 }
 
-// 异步批量发送通知响应
-type BatchSendNotificationsAsyncResponse struct {
+// 异步单条发送通知响应
+type SendNotificationAsyncResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// 通知平台生成的通知ID
-	NotificationIds []uint64 `protobuf:"varint,1,rep,packed,name=notification_ids,json=notificationIds,proto3" json:"notification_ids,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	NotificationId uint64 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	// 失败时的错误代码
+	ErrorCode ErrorCode `protobuf:"varint,4,opt,name=error_code,json=errorCode,proto3,enum=notification.v1.ErrorCode" json:"error_code,omitempty"`
+	// 错误详情
+	ErrorMessage string `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// 业务方已经用同一个 key 发送过，返回的是已有通知的ID，这次请求没有创建新通知
+	Duplicate bool `protobuf:"varint,6,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	// 可合并的通知进入了合并窗口，窗口结束后合并成一条摘要消息发送，notification_id 为 0
+	Digested bool `protobuf:"varint,7,opt,name=digested,proto3" json:"digested,omitempty"`
+	// 按本地时间发送时每个时区的发送时间，快到发送时间时才创建通知，notification_id 为 0
+	LocalTimeCohorts []*LocalTimeCohort `protobuf:"bytes,8,rep,name=local_time_cohorts,json=localTimeCohorts,proto3" json:"local_time_cohorts,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *BatchSendNotificationsAsyncResponse) Reset() {
-	*x = BatchSendNotificationsAsyncResponse{}
-	mi := &file_notification_v1_notification_proto_msgTypes[9]
+func (x *SendNotificationAsyncResponse) Reset() {
+	*x = SendNotificationAsyncResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchSendNotificationsAsyncResponse) String() string {
+func (x *SendNotificationAsyncResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchSendNotificationsAsyncResponse) ProtoMessage() {}
+func (*SendNotificationAsyncResponse) ProtoMessage() {}
 
-func (x *BatchSendNotificationsAsyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[9]
+func (x *SendNotificationAsyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,41 +1253,759 @@ func (x *BatchSendNotificationsAsyncResponse) ProtoReflect() protoreflect.Messag
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchSendNotificationsAsyncResponse.ProtoReflect.Descriptor instead.
-func (*BatchSendNotificationsAsyncResponse) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SendNotificationAsyncResponse.ProtoReflect.Descriptor instead.
+func (*SendNotificationAsyncResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *BatchSendNotificationsAsyncResponse) GetNotificationIds() []uint64 {
+func (x *SendNotificationAsyncResponse) GetNotificationId() uint64 {
 	if x != nil {
-		return x.NotificationIds
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *SendNotificationAsyncResponse) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *SendNotificationAsyncResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SendNotificationAsyncResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *SendNotificationAsyncResponse) GetDigested() bool {
+	if x != nil {
+		return x.Digested
+	}
+	return false
+}
+
+func (x *SendNotificationAsyncResponse) GetLocalTimeCohorts() []*LocalTimeCohort {
+	if x != nil {
+		return x.LocalTimeCohorts
 	}
 	return nil
 }
 
-// 准备事务请求
-type TxPrepareRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+// 按本地时间发送拆分出的一个时区
+type LocalTimeCohort struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Timezone string                 `protobuf:"bytes,1,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// 这个时区的接收者数量
+	ReceiverCount int32 `protobuf:"varint,2,opt,name=receiver_count,json=receiverCount,proto3" json:"receiver_count,omitempty"`
+	// 发送窗口，毫秒时间戳
+	SendTime      int64 `protobuf:"varint,3,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`
+	EndTime       int64 `protobuf:"varint,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxPrepareRequest) Reset() {
-	*x = TxPrepareRequest{}
-	mi := &file_notification_v1_notification_proto_msgTypes[10]
+func (x *LocalTimeCohort) Reset() {
+	*x = LocalTimeCohort{}
+	mi := &file_notification_v1_notification_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxPrepareRequest) String() string {
+func (x *LocalTimeCohort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalTimeCohort) ProtoMessage() {}
+
+func (x *LocalTimeCohort) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalTimeCohort.ProtoReflect.Descriptor instead.
+func (*LocalTimeCohort) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LocalTimeCohort) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *LocalTimeCohort) GetReceiverCount() int32 {
+	if x != nil {
+		return x.ReceiverCount
+	}
+	return 0
+}
+
+func (x *LocalTimeCohort) GetSendTime() int64 {
+	if x != nil {
+		return x.SendTime
+	}
+	return 0
+}
+
+func (x *LocalTimeCohort) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+// 同步批量发送通知请求
+type BatchSendNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	// 试运行，额度按这一批累计检查
+	DryRun        bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSendNotificationsRequest) Reset() {
+	*x = BatchSendNotificationsRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSendNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSendNotificationsRequest) ProtoMessage() {}
+
+func (x *BatchSendNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSendNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*BatchSendNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchSendNotificationsRequest) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *BatchSendNotificationsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// 同步批量发送通知响应
+type BatchSendNotificationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 所有结果
+	Results []*SendNotificationResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// 总数
+	TotalCount int32 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// 成功数
+	SuccessCount  int32 `protobuf:"varint,3,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSendNotificationsResponse) Reset() {
+	*x = BatchSendNotificationsResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSendNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSendNotificationsResponse) ProtoMessage() {}
+
+func (x *BatchSendNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSendNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*BatchSendNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BatchSendNotificationsResponse) GetResults() []*SendNotificationResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchSendNotificationsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *BatchSendNotificationsResponse) GetSuccessCount() int32 {
+	if x != nil {
+		return x.SuccessCount
+	}
+	return 0
+}
+
+// 异步批量发送通知请求
+type BatchSendNotificationsAsyncRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSendNotificationsAsyncRequest) Reset() {
+	*x = BatchSendNotificationsAsyncRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSendNotificationsAsyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSendNotificationsAsyncRequest) ProtoMessage() {}
+
+func (x *BatchSendNotificationsAsyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSendNotificationsAsyncRequest.ProtoReflect.Descriptor instead.
+func (*BatchSendNotificationsAsyncRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchSendNotificationsAsyncRequest) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+// 异步批量发送通知响应
+type BatchSendNotificationsAsyncResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 通知平台生成的通知ID，只包括创建成功和重复发送的通知，不包括失败、合并发送和按本地时间发送的通知
+	NotificationIds []uint64 `protobuf:"varint,1,rep,packed,name=notification_ids,json=notificationIds,proto3" json:"notification_ids,omitempty"`
+	// 每条通知的结果，和请求里的 notifications 一一对应，顺序相同
+	Results       []*BatchSendNotificationsAsyncResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSendNotificationsAsyncResponse) Reset() {
+	*x = BatchSendNotificationsAsyncResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSendNotificationsAsyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSendNotificationsAsyncResponse) ProtoMessage() {}
+
+func (x *BatchSendNotificationsAsyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSendNotificationsAsyncResponse.ProtoReflect.Descriptor instead.
+func (*BatchSendNotificationsAsyncResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchSendNotificationsAsyncResponse) GetNotificationIds() []uint64 {
+	if x != nil {
+		return x.NotificationIds
+	}
+	return nil
+}
+
+func (x *BatchSendNotificationsAsyncResponse) GetResults() []*BatchSendNotificationsAsyncResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// 异步批量发送中一条通知的结果，error_code 为 ERROR_CODE_UNSPECIFIED 时表示成功
+type BatchSendNotificationsAsyncResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 在请求 notifications 里的下标，从 0 开始
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// 通知平台生成的通知ID，失败、合并发送和按本地时间发送时为 0
+	NotificationId uint64 `protobuf:"varint,2,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	// 失败时的错误代码
+	ErrorCode ErrorCode `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=notification.v1.ErrorCode" json:"error_code,omitempty"`
+	// 错误详情
+	ErrorMessage string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// 业务方已经用同一个 key 发送过，返回的是已有通知的ID
+	Duplicate bool `protobuf:"varint,5,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	// 可合并的通知进入了合并窗口
+	Digested bool `protobuf:"varint,6,opt,name=digested,proto3" json:"digested,omitempty"`
+	// 按本地时间发送时每个时区的发送时间
+	LocalTimeCohorts []*LocalTimeCohort `protobuf:"bytes,7,rep,name=local_time_cohorts,json=localTimeCohorts,proto3" json:"local_time_cohorts,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BatchSendNotificationsAsyncResult) Reset() {
+	*x = BatchSendNotificationsAsyncResult{}
+	mi := &file_notification_v1_notification_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSendNotificationsAsyncResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSendNotificationsAsyncResult) ProtoMessage() {}
+
+func (x *BatchSendNotificationsAsyncResult) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSendNotificationsAsyncResult.ProtoReflect.Descriptor instead.
+func (*BatchSendNotificationsAsyncResult) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchSendNotificationsAsyncResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchSendNotificationsAsyncResult) GetNotificationId() uint64 {
+	if x != nil {
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *BatchSendNotificationsAsyncResult) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *BatchSendNotificationsAsyncResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *BatchSendNotificationsAsyncResult) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *BatchSendNotificationsAsyncResult) GetDigested() bool {
+	if x != nil {
+		return x.Digested
+	}
+	return false
+}
+
+func (x *BatchSendNotificationsAsyncResult) GetLocalTimeCohorts() []*LocalTimeCohort {
+	if x != nil {
+		return x.LocalTimeCohorts
+	}
+	return nil
+}
+
+// 准备事务请求
+type TxPrepareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxPrepareRequest) Reset() {
+	*x = TxPrepareRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxPrepareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPrepareRequest) ProtoMessage() {}
+
+func (x *TxPrepareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPrepareRequest.ProtoReflect.Descriptor instead.
+func (*TxPrepareRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TxPrepareRequest) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+// 准备事务响应
+type TxPrepareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxPrepareResponse) Reset() {
+	*x = TxPrepareResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxPrepareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPrepareResponse) ProtoMessage() {}
+
+func (x *TxPrepareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPrepareResponse.ProtoReflect.Descriptor instead.
+func (*TxPrepareResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{18}
+}
+
+// 提交事务请求
+type TxCommitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // 事务唯一标识
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxCommitRequest) Reset() {
+	*x = TxCommitRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxCommitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxCommitRequest) ProtoMessage() {}
+
+func (x *TxCommitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxCommitRequest.ProtoReflect.Descriptor instead.
+func (*TxCommitRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TxCommitRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// 提交事务响应
+type TxCommitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxCommitResponse) Reset() {
+	*x = TxCommitResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxCommitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxCommitResponse) ProtoMessage() {}
+
+func (x *TxCommitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxCommitResponse.ProtoReflect.Descriptor instead.
+func (*TxCommitResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{20}
+}
+
+// 回滚事务请求
+type TxCancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // 事务唯一标识
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxCancelRequest) Reset() {
+	*x = TxCancelRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxCancelRequest) ProtoMessage() {}
+
+func (x *TxCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxCancelRequest.ProtoReflect.Descriptor instead.
+func (*TxCancelRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TxCancelRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// 回滚事务响应
+type TxCancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxCancelResponse) Reset() {
+	*x = TxCancelResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxCancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxCancelResponse) ProtoMessage() {}
+
+func (x *TxCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxCancelResponse.ProtoReflect.Descriptor instead.
+func (*TxCancelResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{22}
+}
+
+// 取消通知请求
+type CancelNotificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // 业务内唯一标识
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelNotificationRequest) Reset() {
+	*x = CancelNotificationRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelNotificationRequest) ProtoMessage() {}
+
+func (x *CancelNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelNotificationRequest.ProtoReflect.Descriptor instead.
+func (*CancelNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelNotificationRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// 取消通知响应
+type CancelNotificationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 通知平台生成的通知ID
+	NotificationId uint64 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	// 取消后的状态
+	Status        SendStatus `protobuf:"varint,2,opt,name=status,proto3,enum=notification.v1.SendStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelNotificationResponse) Reset() {
+	*x = CancelNotificationResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelNotificationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxPrepareRequest) ProtoMessage() {}
+func (*CancelNotificationResponse) ProtoMessage() {}
 
-func (x *TxPrepareRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[10]
+func (x *CancelNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,40 +2016,60 @@ func (x *TxPrepareRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxPrepareRequest.ProtoReflect.Descriptor instead.
-func (*TxPrepareRequest) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CancelNotificationResponse.ProtoReflect.Descriptor instead.
+func (*CancelNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *TxPrepareRequest) GetNotification() *Notification {
+func (x *CancelNotificationResponse) GetNotificationId() uint64 {
 	if x != nil {
-		return x.Notification
+		return x.NotificationId
 	}
-	return nil
+	return 0
 }
 
-// 准备事务响应
-type TxPrepareResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *CancelNotificationResponse) GetStatus() SendStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SendStatus_SEND_STATUS_UNSPECIFIED
+}
+
+// 修改通知请求
+type UpdateNotificationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // 业务内唯一标识
+	// 期望的版本号，不为 0 时只有版本号一致才会修改
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// 需要修改的字段，支持 receivers、template_params、strategy
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// 接收者
+	Receivers []string `protobuf:"bytes,4,rep,name=receivers,proto3" json:"receivers,omitempty"`
+	// 模板参数，整体替换
+	TemplateParams map[string]string `protobuf:"bytes,5,rep,name=template_params,json=templateParams,proto3" json:"template_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 发送策略，用于修改发送时间窗口
+	Strategy *SendStrategy `protobuf:"bytes,6,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	// 操作人，记录到审计日志
+	Operator      string `protobuf:"bytes,7,opt,name=operator,proto3" json:"operator,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxPrepareResponse) Reset() {
-	*x = TxPrepareResponse{}
-	mi := &file_notification_v1_notification_proto_msgTypes[11]
+func (x *UpdateNotificationRequest) Reset() {
+	*x = UpdateNotificationRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxPrepareResponse) String() string {
+func (x *UpdateNotificationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxPrepareResponse) ProtoMessage() {}
+func (*UpdateNotificationRequest) ProtoMessage() {}
 
-func (x *TxPrepareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[11]
+func (x *UpdateNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,78 +2080,86 @@ func (x *TxPrepareResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxPrepareResponse.ProtoReflect.Descriptor instead.
-func (*TxPrepareResponse) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use UpdateNotificationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{25}
 }
 
-// 提交事务请求
-type TxCommitRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // 事务唯一标识
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *UpdateNotificationRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
 }
 
-func (x *TxCommitRequest) Reset() {
-	*x = TxCommitRequest{}
-	mi := &file_notification_v1_notification_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *UpdateNotificationRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
-func (x *TxCommitRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UpdateNotificationRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
 }
 
-func (*TxCommitRequest) ProtoMessage() {}
+func (x *UpdateNotificationRequest) GetReceivers() []string {
+	if x != nil {
+		return x.Receivers
+	}
+	return nil
+}
 
-func (x *TxCommitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[12]
+func (x *UpdateNotificationRequest) GetTemplateParams() map[string]string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TemplateParams
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use TxCommitRequest.ProtoReflect.Descriptor instead.
-func (*TxCommitRequest) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{12}
+func (x *UpdateNotificationRequest) GetStrategy() *SendStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return nil
 }
 
-func (x *TxCommitRequest) GetKey() string {
+func (x *UpdateNotificationRequest) GetOperator() string {
 	if x != nil {
-		return x.Key
+		return x.Operator
 	}
 	return ""
 }
 
-// 提交事务响应
-type TxCommitResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// 修改通知响应
+type UpdateNotificationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 通知平台生成的通知ID
+	NotificationId uint64 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	// 修改后的版本号
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxCommitResponse) Reset() {
-	*x = TxCommitResponse{}
-	mi := &file_notification_v1_notification_proto_msgTypes[13]
+func (x *UpdateNotificationResponse) Reset() {
+	*x = UpdateNotificationResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxCommitResponse) String() string {
+func (x *UpdateNotificationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxCommitResponse) ProtoMessage() {}
+func (*UpdateNotificationResponse) ProtoMessage() {}
 
-func (x *TxCommitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[13]
+func (x *UpdateNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1081,34 +2170,48 @@ func (x *TxCommitResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxCommitResponse.ProtoReflect.Descriptor instead.
-func (*TxCommitResponse) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use UpdateNotificationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{26}
 }
 
-// 回滚事务请求
-type TxCancelRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // 事务唯一标识
+func (x *UpdateNotificationResponse) GetNotificationId() uint64 {
+	if x != nil {
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *UpdateNotificationResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GenerateNotificationKeysRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 生成的数量，1 到 100，不传时生成 1 个
+	Count         int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxCancelRequest) Reset() {
-	*x = TxCancelRequest{}
-	mi := &file_notification_v1_notification_proto_msgTypes[14]
+func (x *GenerateNotificationKeysRequest) Reset() {
+	*x = GenerateNotificationKeysRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxCancelRequest) String() string {
+func (x *GenerateNotificationKeysRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxCancelRequest) ProtoMessage() {}
+func (*GenerateNotificationKeysRequest) ProtoMessage() {}
 
-func (x *TxCancelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[14]
+func (x *GenerateNotificationKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1119,40 +2222,41 @@ func (x *TxCancelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxCancelRequest.ProtoReflect.Descriptor instead.
-func (*TxCancelRequest) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GenerateNotificationKeysRequest.ProtoReflect.Descriptor instead.
+func (*GenerateNotificationKeysRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *TxCancelRequest) GetKey() string {
+func (x *GenerateNotificationKeysRequest) GetCount() int32 {
 	if x != nil {
-		return x.Key
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-// 回滚事务响应
-type TxCancelResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type GenerateNotificationKeysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 生成的 key，带有业务方要求的前缀，符合业务方的长度和字符集规则
+	Keys          []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TxCancelResponse) Reset() {
-	*x = TxCancelResponse{}
-	mi := &file_notification_v1_notification_proto_msgTypes[15]
+func (x *GenerateNotificationKeysResponse) Reset() {
+	*x = GenerateNotificationKeysResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TxCancelResponse) String() string {
+func (x *GenerateNotificationKeysResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxCancelResponse) ProtoMessage() {}
+func (*GenerateNotificationKeysResponse) ProtoMessage() {}
 
-func (x *TxCancelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[15]
+func (x *GenerateNotificationKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1163,9 +2267,16 @@ func (x *TxCancelResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxCancelResponse.ProtoReflect.Descriptor instead.
-func (*TxCancelResponse) Descriptor() ([]byte, []int) {
-	return file_notification_v1_notification_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GenerateNotificationKeysResponse.ProtoReflect.Descriptor instead.
+func (*GenerateNotificationKeysResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GenerateNotificationKeysResponse) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
 }
 
 // 空结构表示立即发送
@@ -1177,7 +2288,7 @@ type SendStrategy_ImmediateStrategy struct {
 
 func (x *SendStrategy_ImmediateStrategy) Reset() {
 	*x = SendStrategy_ImmediateStrategy{}
-	mi := &file_notification_v1_notification_proto_msgTypes[16]
+	mi := &file_notification_v1_notification_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1189,7 +2300,7 @@ func (x *SendStrategy_ImmediateStrategy) String() string {
 func (*SendStrategy_ImmediateStrategy) ProtoMessage() {}
 
 func (x *SendStrategy_ImmediateStrategy) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[16]
+	mi := &file_notification_v1_notification_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1215,7 +2326,7 @@ type SendStrategy_DelayedStrategy struct {
 
 func (x *SendStrategy_DelayedStrategy) Reset() {
 	*x = SendStrategy_DelayedStrategy{}
-	mi := &file_notification_v1_notification_proto_msgTypes[17]
+	mi := &file_notification_v1_notification_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1227,7 +2338,7 @@ func (x *SendStrategy_DelayedStrategy) String() string {
 func (*SendStrategy_DelayedStrategy) ProtoMessage() {}
 
 func (x *SendStrategy_DelayedStrategy) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[17]
+	mi := &file_notification_v1_notification_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1260,7 +2371,7 @@ type SendStrategy_ScheduledStrategy struct {
 
 func (x *SendStrategy_ScheduledStrategy) Reset() {
 	*x = SendStrategy_ScheduledStrategy{}
-	mi := &file_notification_v1_notification_proto_msgTypes[18]
+	mi := &file_notification_v1_notification_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1272,7 +2383,7 @@ func (x *SendStrategy_ScheduledStrategy) String() string {
 func (*SendStrategy_ScheduledStrategy) ProtoMessage() {}
 
 func (x *SendStrategy_ScheduledStrategy) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[18]
+	mi := &file_notification_v1_notification_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1307,7 +2418,7 @@ type SendStrategy_TimeWindowStrategy struct {
 
 func (x *SendStrategy_TimeWindowStrategy) Reset() {
 	*x = SendStrategy_TimeWindowStrategy{}
-	mi := &file_notification_v1_notification_proto_msgTypes[19]
+	mi := &file_notification_v1_notification_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1319,7 +2430,7 @@ func (x *SendStrategy_TimeWindowStrategy) String() string {
 func (*SendStrategy_TimeWindowStrategy) ProtoMessage() {}
 
 func (x *SendStrategy_TimeWindowStrategy) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[19]
+	mi := &file_notification_v1_notification_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1359,7 +2470,7 @@ type SendStrategy_DeadlineStrategy struct {
 
 func (x *SendStrategy_DeadlineStrategy) Reset() {
 	*x = SendStrategy_DeadlineStrategy{}
-	mi := &file_notification_v1_notification_proto_msgTypes[20]
+	mi := &file_notification_v1_notification_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1371,7 +2482,7 @@ func (x *SendStrategy_DeadlineStrategy) String() string {
 func (*SendStrategy_DeadlineStrategy) ProtoMessage() {}
 
 func (x *SendStrategy_DeadlineStrategy) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_v1_notification_proto_msgTypes[20]
+	mi := &file_notification_v1_notification_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1394,18 +2505,158 @@ func (x *SendStrategy_DeadlineStrategy) GetDeadline() *timestamppb.Timestamp {
 	return nil
 }
 
+// 按接收者所在时区拆分，每个时区在当地的 date time 开始发送
+type SendStrategy_LocalTimeStrategy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 本地日期，格式 2006-01-02
+	Date string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// 本地时间，格式 15:04
+	Time string `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// 本地时间之后多少秒内发送，默认 3600，最多一天
+	WindowSeconds int64 `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	// 接收者的时区，IANA 名称，例如 Asia/Shanghai；没有指定的接收者查询时区服务
+	ReceiverTimezones map[string]string `protobuf:"bytes,4,rep,name=receiver_timezones,json=receiverTimezones,proto3" json:"receiver_timezones,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 既没有指定也查不到时区的接收者使用的时区，默认 UTC
+	DefaultTimezone string `protobuf:"bytes,5,opt,name=default_timezone,json=defaultTimezone,proto3" json:"default_timezone,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SendStrategy_LocalTimeStrategy) Reset() {
+	*x = SendStrategy_LocalTimeStrategy{}
+	mi := &file_notification_v1_notification_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendStrategy_LocalTimeStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendStrategy_LocalTimeStrategy) ProtoMessage() {}
+
+func (x *SendStrategy_LocalTimeStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendStrategy_LocalTimeStrategy.ProtoReflect.Descriptor instead.
+func (*SendStrategy_LocalTimeStrategy) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *SendStrategy_LocalTimeStrategy) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *SendStrategy_LocalTimeStrategy) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *SendStrategy_LocalTimeStrategy) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *SendStrategy_LocalTimeStrategy) GetReceiverTimezones() map[string]string {
+	if x != nil {
+		return x.ReceiverTimezones
+	}
+	return nil
+}
+
+func (x *SendStrategy_LocalTimeStrategy) GetDefaultTimezone() string {
+	if x != nil {
+		return x.DefaultTimezone
+	}
+	return ""
+}
+
+type SendStrategy_PacedStrategy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 活动名称，同一个业务方的同一个活动共用发送速度，最长 64
+	Campaign string `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	// 每分钟最多发送的通知数量，以最后一次发送时指定的为准
+	PerMinute     int32 `protobuf:"varint,2,opt,name=per_minute,json=perMinute,proto3" json:"per_minute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendStrategy_PacedStrategy) Reset() {
+	*x = SendStrategy_PacedStrategy{}
+	mi := &file_notification_v1_notification_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendStrategy_PacedStrategy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendStrategy_PacedStrategy) ProtoMessage() {}
+
+func (x *SendStrategy_PacedStrategy) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendStrategy_PacedStrategy.ProtoReflect.Descriptor instead.
+func (*SendStrategy_PacedStrategy) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{0, 6}
+}
+
+func (x *SendStrategy_PacedStrategy) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *SendStrategy_PacedStrategy) GetPerMinute() int32 {
+	if x != nil {
+		return x.PerMinute
+	}
+	return 0
+}
+
 var File_notification_v1_notification_proto protoreflect.FileDescriptor
 
 const file_notification_v1_notification_proto_rawDesc = "" +
 	"\n" +
-	"\"notification/v1/notification.proto\x12\x0fnotification.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x99\x06\n" +
+	"\"notification/v1/notification.proto\x12\x0fnotification.v1\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc9\n" +
+	"\n" +
 	"\fSendStrategy\x12O\n" +
 	"\timmediate\x18\x01 \x01(\v2/.notification.v1.SendStrategy.ImmediateStrategyH\x00R\timmediate\x12I\n" +
 	"\adelayed\x18\x02 \x01(\v2-.notification.v1.SendStrategy.DelayedStrategyH\x00R\adelayed\x12O\n" +
 	"\tscheduled\x18\x03 \x01(\v2/.notification.v1.SendStrategy.ScheduledStrategyH\x00R\tscheduled\x12S\n" +
 	"\vtime_window\x18\x04 \x01(\v20.notification.v1.SendStrategy.TimeWindowStrategyH\x00R\n" +
 	"timeWindow\x12L\n" +
-	"\bdeadline\x18\x05 \x01(\v2..notification.v1.SendStrategy.DeadlineStrategyH\x00R\bdeadline\x1a\x13\n" +
+	"\bdeadline\x18\x05 \x01(\v2..notification.v1.SendStrategy.DeadlineStrategyH\x00R\bdeadline\x12P\n" +
+	"\n" +
+	"local_time\x18\x06 \x01(\v2/.notification.v1.SendStrategy.LocalTimeStrategyH\x00R\tlocalTime\x12C\n" +
+	"\x05paced\x18\a \x01(\v2+.notification.v1.SendStrategy.PacedStrategyH\x00R\x05paced\x1a\x13\n" +
 	"\x11ImmediateStrategy\x1a6\n" +
 	"\x0fDelayedStrategy\x12#\n" +
 	"\rdelay_seconds\x18\x01 \x01(\x03R\fdelaySeconds\x1aL\n" +
@@ -1415,8 +2666,21 @@ const file_notification_v1_notification_proto_rawDesc = "" +
 	"\x17start_time_milliseconds\x18\x01 \x01(\x03R\x15startTimeMilliseconds\x122\n" +
 	"\x15end_time_milliseconds\x18\x02 \x01(\x03R\x13endTimeMilliseconds\x1aJ\n" +
 	"\x10DeadlineStrategy\x126\n" +
-	"\bdeadline\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadlineB\x0f\n" +
-	"\rstrategy_type\"\x89\x03\n" +
+	"\bdeadline\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x1a\xca\x02\n" +
+	"\x11LocalTimeStrategy\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12%\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\x12u\n" +
+	"\x12receiver_timezones\x18\x04 \x03(\v2F.notification.v1.SendStrategy.LocalTimeStrategy.ReceiverTimezonesEntryR\x11receiverTimezones\x12)\n" +
+	"\x10default_timezone\x18\x05 \x01(\tR\x0fdefaultTimezone\x1aD\n" +
+	"\x16ReceiverTimezonesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aJ\n" +
+	"\rPacedStrategy\x12\x1a\n" +
+	"\bcampaign\x18\x01 \x01(\tR\bcampaign\x12\x1d\n" +
+	"\n" +
+	"per_minute\x18\x02 \x01(\x05R\tperMinuteB\x0f\n" +
+	"\rstrategy_type\"\x93\a\n" +
 	"\fNotification\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1c\n" +
 	"\treceivers\x18\x02 \x03(\tR\treceivers\x122\n" +
@@ -1425,36 +2689,101 @@ const file_notification_v1_notification_proto_rawDesc = "" +
 	"templateId\x12Z\n" +
 	"\x0ftemplate_params\x18\x05 \x03(\v21.notification.v1.Notification.TemplateParamsEntryR\x0etemplateParams\x129\n" +
 	"\bstrategy\x18\x06 \x01(\v2\x1d.notification.v1.SendStrategyR\bstrategy\x12\x1a\n" +
-	"\breceiver\x18\a \x01(\tR\breceiver\x1aA\n" +
+	"\breceiver\x18\a \x01(\tR\breceiver\x126\n" +
+	"\x06digest\x18\b \x01(\v2\x1e.notification.v1.DigestOptionsR\x06digest\x12A\n" +
+	"\bcategory\x18\t \x01(\x0e2%.notification.v1.NotificationCategoryR\bcategory\x12<\n" +
+	"\bcallback\x18\n" +
+	" \x01(\v2 .notification.v1.CallbackOptionsR\bcallback\x12A\n" +
+	"\x06labels\x18\v \x03(\v2).notification.v1.Notification.LabelsEntryR\x06labels\x12%\n" +
+	"\x0efallback_group\x18\f \x01(\tR\rfallbackGroup\x12\x1f\n" +
+	"\vbiz_payload\x18\r \x01(\tR\n" +
+	"bizPayload\x12?\n" +
+	"\fretry_policy\x18\x0e \x01(\v2\x1c.notification.v1.RetryPolicyR\vretryPolicy\x12H\n" +
+	"\x0freceiver_params\x18\x0f \x03(\v2\x1f.notification.v1.ReceiverParamsR\x0ereceiverParams\x1aA\n" +
 	"\x13TemplateParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xac\x01\n" +
+	"\x0eReceiverParams\x12\x1a\n" +
+	"\breceiver\x18\x01 \x01(\tR\breceiver\x12C\n" +
+	"\x06params\x18\x02 \x03(\v2+.notification.v1.ReceiverParams.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x98\x01\n" +
+	"\vRetryPolicy\x12!\n" +
+	"\fmax_attempts\x18\x01 \x01(\x05R\vmaxAttempts\x126\n" +
+	"\x17initial_backoff_seconds\x18\x02 \x01(\x03R\x15initialBackoffSeconds\x12.\n" +
+	"\x13max_backoff_seconds\x18\x03 \x01(\x03R\x11maxBackoffSeconds\"\xe2\x01\n" +
+	"\x0fCallbackOptions\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12G\n" +
+	"\aheaders\x18\x02 \x03(\v2-.notification.v1.CallbackOptions.HeadersEntryR\aheaders\x128\n" +
+	"\ton_status\x18\x03 \x03(\x0e2\x1b.notification.v1.SendStatusR\bonStatus\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\")\n" +
+	"\rDigestOptions\x12\x18\n" +
+	"\asummary\x18\x01 \x01(\tR\asummary\"u\n" +
 	"\x17SendNotificationRequest\x12A\n" +
-	"\fnotification\x18\x01 \x01(\v2\x1d.notification.v1.NotificationR\fnotification\"\xd8\x01\n" +
+	"\fnotification\x18\x01 \x01(\v2\x1d.notification.v1.NotificationR\fnotification\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\xf6\x02\n" +
 	"\x18SendNotificationResponse\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\x04R\x0enotificationId\x123\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x1b.notification.v1.SendStatusR\x06status\x129\n" +
 	"\n" +
 	"error_code\x18\x03 \x01(\x0e2\x1a.notification.v1.ErrorCodeR\terrorCode\x12#\n" +
-	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"a\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x1c\n" +
+	"\tduplicate\x18\x05 \x01(\bR\tduplicate\x12C\n" +
+	"\x0edry_run_result\x18\x06 \x01(\v2\x1d.notification.v1.DryRunResultR\fdryRunResult\x12\x18\n" +
+	"\asandbox\x18\a \x01(\bR\asandbox\x12\x1f\n" +
+	"\vbiz_payload\x18\b \x01(\tR\n" +
+	"bizPayload\"\x9d\x02\n" +
+	"\fDryRunResult\x12.\n" +
+	"\x13template_version_id\x18\x01 \x01(\x03R\x11templateVersionId\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1c\n" +
+	"\tproviders\x18\x04 \x03(\tR\tproviders\x12'\n" +
+	"\x0fquota_remaining\x18\x05 \x01(\x05R\x0equotaRemaining\x120\n" +
+	"\x14scheduled_start_time\x18\x06 \x01(\x03R\x12scheduledStartTime\x12,\n" +
+	"\x12scheduled_end_time\x18\a \x01(\x03R\x10scheduledEndTime\"a\n" +
 	"\x1cSendNotificationAsyncRequest\x12A\n" +
-	"\fnotification\x18\x01 \x01(\v2\x1d.notification.v1.NotificationR\fnotification\"\xa8\x01\n" +
+	"\fnotification\x18\x01 \x01(\v2\x1d.notification.v1.NotificationR\fnotification\"\xb2\x02\n" +
 	"\x1dSendNotificationAsyncResponse\x12'\n" +
 	"\x0fnotification_id\x18\x01 \x01(\x04R\x0enotificationId\x129\n" +
 	"\n" +
 	"error_code\x18\x04 \x01(\x0e2\x1a.notification.v1.ErrorCodeR\terrorCode\x12#\n" +
-	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"d\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x12\x1c\n" +
+	"\tduplicate\x18\x06 \x01(\bR\tduplicate\x12\x1a\n" +
+	"\bdigested\x18\a \x01(\bR\bdigested\x12N\n" +
+	"\x12local_time_cohorts\x18\b \x03(\v2 .notification.v1.LocalTimeCohortR\x10localTimeCohorts\"\x8c\x01\n" +
+	"\x0fLocalTimeCohort\x12\x1a\n" +
+	"\btimezone\x18\x01 \x01(\tR\btimezone\x12%\n" +
+	"\x0ereceiver_count\x18\x02 \x01(\x05R\rreceiverCount\x12\x1b\n" +
+	"\tsend_time\x18\x03 \x01(\x03R\bsendTime\x12\x19\n" +
+	"\bend_time\x18\x04 \x01(\x03R\aendTime\"}\n" +
 	"\x1dBatchSendNotificationsRequest\x12C\n" +
-	"\rnotifications\x18\x01 \x03(\v2\x1d.notification.v1.NotificationR\rnotifications\"\xab\x01\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1d.notification.v1.NotificationR\rnotifications\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\xab\x01\n" +
 	"\x1eBatchSendNotificationsResponse\x12C\n" +
 	"\aresults\x18\x01 \x03(\v2).notification.v1.SendNotificationResponseR\aresults\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12#\n" +
 	"\rsuccess_count\x18\x03 \x01(\x05R\fsuccessCount\"i\n" +
 	"\"BatchSendNotificationsAsyncRequest\x12C\n" +
-	"\rnotifications\x18\x01 \x03(\v2\x1d.notification.v1.NotificationR\rnotifications\"P\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1d.notification.v1.NotificationR\rnotifications\"\x9e\x01\n" +
 	"#BatchSendNotificationsAsyncResponse\x12)\n" +
-	"\x10notification_ids\x18\x01 \x03(\x04R\x0fnotificationIds\"U\n" +
+	"\x10notification_ids\x18\x01 \x03(\x04R\x0fnotificationIds\x12L\n" +
+	"\aresults\x18\x02 \x03(\v22.notification.v1.BatchSendNotificationsAsyncResultR\aresults\"\xcc\x02\n" +
+	"!BatchSendNotificationsAsyncResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12'\n" +
+	"\x0fnotification_id\x18\x02 \x01(\x04R\x0enotificationId\x129\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x1a.notification.v1.ErrorCodeR\terrorCode\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x1c\n" +
+	"\tduplicate\x18\x05 \x01(\bR\tduplicate\x12\x1a\n" +
+	"\bdigested\x18\x06 \x01(\bR\bdigested\x12N\n" +
+	"\x12local_time_cohorts\x18\a \x03(\v2 .notification.v1.LocalTimeCohortR\x10localTimeCohorts\"U\n" +
 	"\x10TxPrepareRequest\x12A\n" +
 	"\fnotification\x18\x01 \x01(\v2\x1d.notification.v1.NotificationR\fnotification\"\x13\n" +
 	"\x11TxPrepareResponse\"#\n" +
@@ -1463,13 +2792,41 @@ const file_notification_v1_notification_proto_rawDesc = "" +
 	"\x10TxCommitResponse\"#\n" +
 	"\x0fTxCancelRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"\x12\n" +
-	"\x10TxCancelResponse*B\n" +
+	"\x10TxCancelResponse\"-\n" +
+	"\x19CancelNotificationRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"z\n" +
+	"\x1aCancelNotificationResponse\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\x04R\x0enotificationId\x123\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1b.notification.v1.SendStatusR\x06status\"\xa5\x03\n" +
+	"\x19UpdateNotificationRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x1c\n" +
+	"\treceivers\x18\x04 \x03(\tR\treceivers\x12g\n" +
+	"\x0ftemplate_params\x18\x05 \x03(\v2>.notification.v1.UpdateNotificationRequest.TemplateParamsEntryR\x0etemplateParams\x129\n" +
+	"\bstrategy\x18\x06 \x01(\v2\x1d.notification.v1.SendStrategyR\bstrategy\x12\x1a\n" +
+	"\boperator\x18\a \x01(\tR\boperator\x1aA\n" +
+	"\x13TemplateParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"_\n" +
+	"\x1aUpdateNotificationResponse\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\x04R\x0enotificationId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"7\n" +
+	"\x1fGenerateNotificationKeysRequest\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"6\n" +
+	" GenerateNotificationKeysResponse\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys*B\n" +
 	"\aChannel\x12\x17\n" +
 	"\x13CHANNEL_UNSPECIFIED\x10\x00\x12\a\n" +
 	"\x03SMS\x10\x01\x12\t\n" +
 	"\x05EMAIL\x10\x02\x12\n" +
 	"\n" +
-	"\x06IN_APP\x10\x03*l\n" +
+	"\x06IN_APP\x10\x03*_\n" +
+	"\x14NotificationCategory\x12%\n" +
+	"!NOTIFICATION_CATEGORY_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rTRANSACTIONAL\x10\x01\x12\r\n" +
+	"\tMARKETING\x10\x02*y\n" +
 	"\n" +
 	"SendStatus\x12\x1b\n" +
 	"\x17SEND_STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
@@ -1478,7 +2835,8 @@ const file_notification_v1_notification_proto_rawDesc = "" +
 	"\aPENDING\x10\x03\x12\r\n" +
 	"\tSUCCEEDED\x10\x04\x12\n" +
 	"\n" +
-	"\x06FAILED\x10\x05*\x9e\x03\n" +
+	"\x06FAILED\x10\x05\x12\v\n" +
+	"\aSENDING\x10\x06*\xd1\x03\n" +
 	"\tErrorCode\x12\x1a\n" +
 	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11INVALID_PARAMETER\x10\x01\x12\x10\n" +
@@ -1497,15 +2855,26 @@ const file_notification_v1_notification_proto_rawDesc = "" +
 	"\bNO_QUOTA\x10\r\x12\x13\n" +
 	"\x0fQUOTA_NOT_FOUND\x10\x0e\x12\x16\n" +
 	"\x12PROVIDER_NOT_FOUND\x10\x0f\x12\x13\n" +
-	"\x0fUNKNOWN_CHANNEL\x10\x102\xf2\x05\n" +
-	"\x13NotificationService\x12g\n" +
-	"\x10SendNotification\x12(.notification.v1.SendNotificationRequest\x1a).notification.v1.SendNotificationResponse\x12v\n" +
-	"\x15SendNotificationAsync\x12-.notification.v1.SendNotificationAsyncRequest\x1a..notification.v1.SendNotificationAsyncResponse\x12y\n" +
-	"\x16BatchSendNotifications\x12..notification.v1.BatchSendNotificationsRequest\x1a/.notification.v1.BatchSendNotificationsResponse\x12\x88\x01\n" +
-	"\x1bBatchSendNotificationsAsync\x123.notification.v1.BatchSendNotificationsAsyncRequest\x1a4.notification.v1.BatchSendNotificationsAsyncResponse\x12R\n" +
-	"\tTxPrepare\x12!.notification.v1.TxPrepareRequest\x1a\".notification.v1.TxPrepareResponse\x12O\n" +
-	"\bTxCommit\x12 .notification.v1.TxCommitRequest\x1a!.notification.v1.TxCommitResponse\x12O\n" +
-	"\bTxCancel\x12 .notification.v1.TxCancelRequest\x1a!.notification.v1.TxCancelResponseBQZOgithub.com/serendipityConfusion/notification-platform/api/gen/v1;notificationpbb\x06proto3"
+	"\x0fUNKNOWN_CHANNEL\x10\x10\x12\x19\n" +
+	"\x15TEMPLATE_NOT_APPROVED\x10\x11\x12\x16\n" +
+	"\x12SEND_WINDOW_CLOSED\x10\x122\xe1\v\n" +
+	"\x13NotificationService\x12\x8a\x01\n" +
+	"\x10SendNotification\x12(.notification.v1.SendNotificationRequest\x1a).notification.v1.SendNotificationResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/notifications:send\x12\x9e\x01\n" +
+	"\x15SendNotificationAsync\x12-.notification.v1.SendNotificationAsyncRequest\x1a..notification.v1.SendNotificationAsyncResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/notifications:sendAsync\x12\xa1\x01\n" +
+	"\x16BatchSendNotifications\x12..notification.v1.BatchSendNotificationsRequest\x1a/.notification.v1.BatchSendNotificationsResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/notifications:batchSend\x12\xb5\x01\n" +
+	"\x1bBatchSendNotificationsAsync\x123.notification.v1.BatchSendNotificationsAsyncRequest\x1a4.notification.v1.BatchSendNotificationsAsyncResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v1/notifications:batchSendAsync\x12w\n" +
+	"\tTxPrepare\x12!.notification.v1.TxPrepareRequest\x1a\".notification.v1.TxPrepareResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/transactions:prepare\x12v\n" +
+	"\bTxCommit\x12 .notification.v1.TxCommitRequest\x1a!.notification.v1.TxCommitResponse\"%\x82\xd3\xe4\x93\x02\x1f\"\x1d/v1/transactions/{key}:commit\x12v\n" +
+	"\bTxCancel\x12 .notification.v1.TxCancelRequest\x1a!.notification.v1.TxCancelResponse\"%\x82\xd3\xe4\x93\x02\x1f\"\x1d/v1/transactions/{key}:cancel\x12\x95\x01\n" +
+	"\x12CancelNotification\x12*.notification.v1.CancelNotificationRequest\x1a+.notification.v1.CancelNotificationResponse\"&\x82\xd3\xe4\x93\x02 \"\x1e/v1/notifications/{key}:cancel\x12\x91\x01\n" +
+	"\x12UpdateNotification\x12*.notification.v1.UpdateNotificationRequest\x1a+.notification.v1.UpdateNotificationResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*2\x17/v1/notifications/{key}\x12\xaa\x01\n" +
+	"\x18GenerateNotificationKeys\x120.notification.v1.GenerateNotificationKeysRequest\x1a1.notification.v1.GenerateNotificationKeysResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/notifications:generateKeysB\xb2\x01\x92A^\x12\x1f\n" +
+	"\x19Notification Platform API2\x02v1Z-\n" +
+	"+\n" +
+	"\x06bearer\x12!\b\x02\x12\fBearer <JWT>\x1a\rAuthorization \x02b\f\n" +
+	"\n" +
+	"\n" +
+	"\x06bearer\x12\x00ZOgithub.com/serendipityConfusion/notification-platform/api/gen/v1;notificationpbb\x06proto3"
 
 var (
 	file_notification_v1_notification_proto_rawDescOnce sync.Once
@@ -1519,75 +2888,124 @@ func file_notification_v1_notification_proto_rawDescGZIP() []byte {
 	return file_notification_v1_notification_proto_rawDescData
 }
 
-var file_notification_v1_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_notification_v1_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_notification_v1_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_notification_v1_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_notification_v1_notification_proto_goTypes = []any{
 	(Channel)(0),                                // 0: notification.v1.Channel
-	(SendStatus)(0),                             // 1: notification.v1.SendStatus
-	(ErrorCode)(0),                              // 2: notification.v1.ErrorCode
-	(*SendStrategy)(nil),                        // 3: notification.v1.SendStrategy
-	(*Notification)(nil),                        // 4: notification.v1.Notification
-	(*SendNotificationRequest)(nil),             // 5: notification.v1.SendNotificationRequest
-	(*SendNotificationResponse)(nil),            // 6: notification.v1.SendNotificationResponse
-	(*SendNotificationAsyncRequest)(nil),        // 7: notification.v1.SendNotificationAsyncRequest
-	(*SendNotificationAsyncResponse)(nil),       // 8: notification.v1.SendNotificationAsyncResponse
-	(*BatchSendNotificationsRequest)(nil),       // 9: notification.v1.BatchSendNotificationsRequest
-	(*BatchSendNotificationsResponse)(nil),      // 10: notification.v1.BatchSendNotificationsResponse
-	(*BatchSendNotificationsAsyncRequest)(nil),  // 11: notification.v1.BatchSendNotificationsAsyncRequest
-	(*BatchSendNotificationsAsyncResponse)(nil), // 12: notification.v1.BatchSendNotificationsAsyncResponse
-	(*TxPrepareRequest)(nil),                    // 13: notification.v1.TxPrepareRequest
-	(*TxPrepareResponse)(nil),                   // 14: notification.v1.TxPrepareResponse
-	(*TxCommitRequest)(nil),                     // 15: notification.v1.TxCommitRequest
-	(*TxCommitResponse)(nil),                    // 16: notification.v1.TxCommitResponse
-	(*TxCancelRequest)(nil),                     // 17: notification.v1.TxCancelRequest
-	(*TxCancelResponse)(nil),                    // 18: notification.v1.TxCancelResponse
-	(*SendStrategy_ImmediateStrategy)(nil),      // 19: notification.v1.SendStrategy.ImmediateStrategy
-	(*SendStrategy_DelayedStrategy)(nil),        // 20: notification.v1.SendStrategy.DelayedStrategy
-	(*SendStrategy_ScheduledStrategy)(nil),      // 21: notification.v1.SendStrategy.ScheduledStrategy
-	(*SendStrategy_TimeWindowStrategy)(nil),     // 22: notification.v1.SendStrategy.TimeWindowStrategy
-	(*SendStrategy_DeadlineStrategy)(nil),       // 23: notification.v1.SendStrategy.DeadlineStrategy
-	nil,                                         // 24: notification.v1.Notification.TemplateParamsEntry
-	(*timestamppb.Timestamp)(nil),               // 25: google.protobuf.Timestamp
+	(NotificationCategory)(0),                   // 1: notification.v1.NotificationCategory
+	(SendStatus)(0),                             // 2: notification.v1.SendStatus
+	(ErrorCode)(0),                              // 3: notification.v1.ErrorCode
+	(*SendStrategy)(nil),                        // 4: notification.v1.SendStrategy
+	(*Notification)(nil),                        // 5: notification.v1.Notification
+	(*ReceiverParams)(nil),                      // 6: notification.v1.ReceiverParams
+	(*RetryPolicy)(nil),                         // 7: notification.v1.RetryPolicy
+	(*CallbackOptions)(nil),                     // 8: notification.v1.CallbackOptions
+	(*DigestOptions)(nil),                       // 9: notification.v1.DigestOptions
+	(*SendNotificationRequest)(nil),             // 10: notification.v1.SendNotificationRequest
+	(*SendNotificationResponse)(nil),            // 11: notification.v1.SendNotificationResponse
+	(*DryRunResult)(nil),                        // 12: notification.v1.DryRunResult
+	(*SendNotificationAsyncRequest)(nil),        // 13: notification.v1.SendNotificationAsyncRequest
+	(*SendNotificationAsyncResponse)(nil),       // 14: notification.v1.SendNotificationAsyncResponse
+	(*LocalTimeCohort)(nil),                     // 15: notification.v1.LocalTimeCohort
+	(*BatchSendNotificationsRequest)(nil),       // 16: notification.v1.BatchSendNotificationsRequest
+	(*BatchSendNotificationsResponse)(nil),      // 17: notification.v1.BatchSendNotificationsResponse
+	(*BatchSendNotificationsAsyncRequest)(nil),  // 18: notification.v1.BatchSendNotificationsAsyncRequest
+	(*BatchSendNotificationsAsyncResponse)(nil), // 19: notification.v1.BatchSendNotificationsAsyncResponse
+	(*BatchSendNotificationsAsyncResult)(nil),   // 20: notification.v1.BatchSendNotificationsAsyncResult
+	(*TxPrepareRequest)(nil),                    // 21: notification.v1.TxPrepareRequest
+	(*TxPrepareResponse)(nil),                   // 22: notification.v1.TxPrepareResponse
+	(*TxCommitRequest)(nil),                     // 23: notification.v1.TxCommitRequest
+	(*TxCommitResponse)(nil),                    // 24: notification.v1.TxCommitResponse
+	(*TxCancelRequest)(nil),                     // 25: notification.v1.TxCancelRequest
+	(*TxCancelResponse)(nil),                    // 26: notification.v1.TxCancelResponse
+	(*CancelNotificationRequest)(nil),           // 27: notification.v1.CancelNotificationRequest
+	(*CancelNotificationResponse)(nil),          // 28: notification.v1.CancelNotificationResponse
+	(*UpdateNotificationRequest)(nil),           // 29: notification.v1.UpdateNotificationRequest
+	(*UpdateNotificationResponse)(nil),          // 30: notification.v1.UpdateNotificationResponse
+	(*GenerateNotificationKeysRequest)(nil),     // 31: notification.v1.GenerateNotificationKeysRequest
+	(*GenerateNotificationKeysResponse)(nil),    // 32: notification.v1.GenerateNotificationKeysResponse
+	(*SendStrategy_ImmediateStrategy)(nil),      // 33: notification.v1.SendStrategy.ImmediateStrategy
+	(*SendStrategy_DelayedStrategy)(nil),        // 34: notification.v1.SendStrategy.DelayedStrategy
+	(*SendStrategy_ScheduledStrategy)(nil),      // 35: notification.v1.SendStrategy.ScheduledStrategy
+	(*SendStrategy_TimeWindowStrategy)(nil),     // 36: notification.v1.SendStrategy.TimeWindowStrategy
+	(*SendStrategy_DeadlineStrategy)(nil),       // 37: notification.v1.SendStrategy.DeadlineStrategy
+	(*SendStrategy_LocalTimeStrategy)(nil),      // 38: notification.v1.SendStrategy.LocalTimeStrategy
+	(*SendStrategy_PacedStrategy)(nil),          // 39: notification.v1.SendStrategy.PacedStrategy
+	nil,                                         // 40: notification.v1.SendStrategy.LocalTimeStrategy.ReceiverTimezonesEntry
+	nil,                                         // 41: notification.v1.Notification.TemplateParamsEntry
+	nil,                                         // 42: notification.v1.Notification.LabelsEntry
+	nil,                                         // 43: notification.v1.ReceiverParams.ParamsEntry
+	nil,                                         // 44: notification.v1.CallbackOptions.HeadersEntry
+	nil,                                         // 45: notification.v1.UpdateNotificationRequest.TemplateParamsEntry
+	(*fieldmaskpb.FieldMask)(nil),               // 46: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil),               // 47: google.protobuf.Timestamp
 }
 var file_notification_v1_notification_proto_depIdxs = []int32{
-	19, // 0: notification.v1.SendStrategy.immediate:type_name -> notification.v1.SendStrategy.ImmediateStrategy
-	20, // 1: notification.v1.SendStrategy.delayed:type_name -> notification.v1.SendStrategy.DelayedStrategy
-	21, // 2: notification.v1.SendStrategy.scheduled:type_name -> notification.v1.SendStrategy.ScheduledStrategy
-	22, // 3: notification.v1.SendStrategy.time_window:type_name -> notification.v1.SendStrategy.TimeWindowStrategy
-	23, // 4: notification.v1.SendStrategy.deadline:type_name -> notification.v1.SendStrategy.DeadlineStrategy
-	0,  // 5: notification.v1.Notification.channel:type_name -> notification.v1.Channel
-	24, // 6: notification.v1.Notification.template_params:type_name -> notification.v1.Notification.TemplateParamsEntry
-	3,  // 7: notification.v1.Notification.strategy:type_name -> notification.v1.SendStrategy
-	4,  // 8: notification.v1.SendNotificationRequest.notification:type_name -> notification.v1.Notification
-	1,  // 9: notification.v1.SendNotificationResponse.status:type_name -> notification.v1.SendStatus
-	2,  // 10: notification.v1.SendNotificationResponse.error_code:type_name -> notification.v1.ErrorCode
-	4,  // 11: notification.v1.SendNotificationAsyncRequest.notification:type_name -> notification.v1.Notification
-	2,  // 12: notification.v1.SendNotificationAsyncResponse.error_code:type_name -> notification.v1.ErrorCode
-	4,  // 13: notification.v1.BatchSendNotificationsRequest.notifications:type_name -> notification.v1.Notification
-	6,  // 14: notification.v1.BatchSendNotificationsResponse.results:type_name -> notification.v1.SendNotificationResponse
-	4,  // 15: notification.v1.BatchSendNotificationsAsyncRequest.notifications:type_name -> notification.v1.Notification
-	4,  // 16: notification.v1.TxPrepareRequest.notification:type_name -> notification.v1.Notification
-	25, // 17: notification.v1.SendStrategy.ScheduledStrategy.send_time:type_name -> google.protobuf.Timestamp
-	25, // 18: notification.v1.SendStrategy.DeadlineStrategy.deadline:type_name -> google.protobuf.Timestamp
-	5,  // 19: notification.v1.NotificationService.SendNotification:input_type -> notification.v1.SendNotificationRequest
-	7,  // 20: notification.v1.NotificationService.SendNotificationAsync:input_type -> notification.v1.SendNotificationAsyncRequest
-	9,  // 21: notification.v1.NotificationService.BatchSendNotifications:input_type -> notification.v1.BatchSendNotificationsRequest
-	11, // 22: notification.v1.NotificationService.BatchSendNotificationsAsync:input_type -> notification.v1.BatchSendNotificationsAsyncRequest
-	13, // 23: notification.v1.NotificationService.TxPrepare:input_type -> notification.v1.TxPrepareRequest
-	15, // 24: notification.v1.NotificationService.TxCommit:input_type -> notification.v1.TxCommitRequest
-	17, // 25: notification.v1.NotificationService.TxCancel:input_type -> notification.v1.TxCancelRequest
-	6,  // 26: notification.v1.NotificationService.SendNotification:output_type -> notification.v1.SendNotificationResponse
-	8,  // 27: notification.v1.NotificationService.SendNotificationAsync:output_type -> notification.v1.SendNotificationAsyncResponse
-	10, // 28: notification.v1.NotificationService.BatchSendNotifications:output_type -> notification.v1.BatchSendNotificationsResponse
-	12, // 29: notification.v1.NotificationService.BatchSendNotificationsAsync:output_type -> notification.v1.BatchSendNotificationsAsyncResponse
-	14, // 30: notification.v1.NotificationService.TxPrepare:output_type -> notification.v1.TxPrepareResponse
-	16, // 31: notification.v1.NotificationService.TxCommit:output_type -> notification.v1.TxCommitResponse
-	18, // 32: notification.v1.NotificationService.TxCancel:output_type -> notification.v1.TxCancelResponse
-	26, // [26:33] is the sub-list for method output_type
-	19, // [19:26] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	33, // 0: notification.v1.SendStrategy.immediate:type_name -> notification.v1.SendStrategy.ImmediateStrategy
+	34, // 1: notification.v1.SendStrategy.delayed:type_name -> notification.v1.SendStrategy.DelayedStrategy
+	35, // 2: notification.v1.SendStrategy.scheduled:type_name -> notification.v1.SendStrategy.ScheduledStrategy
+	36, // 3: notification.v1.SendStrategy.time_window:type_name -> notification.v1.SendStrategy.TimeWindowStrategy
+	37, // 4: notification.v1.SendStrategy.deadline:type_name -> notification.v1.SendStrategy.DeadlineStrategy
+	38, // 5: notification.v1.SendStrategy.local_time:type_name -> notification.v1.SendStrategy.LocalTimeStrategy
+	39, // 6: notification.v1.SendStrategy.paced:type_name -> notification.v1.SendStrategy.PacedStrategy
+	0,  // 7: notification.v1.Notification.channel:type_name -> notification.v1.Channel
+	41, // 8: notification.v1.Notification.template_params:type_name -> notification.v1.Notification.TemplateParamsEntry
+	4,  // 9: notification.v1.Notification.strategy:type_name -> notification.v1.SendStrategy
+	9,  // 10: notification.v1.Notification.digest:type_name -> notification.v1.DigestOptions
+	1,  // 11: notification.v1.Notification.category:type_name -> notification.v1.NotificationCategory
+	8,  // 12: notification.v1.Notification.callback:type_name -> notification.v1.CallbackOptions
+	42, // 13: notification.v1.Notification.labels:type_name -> notification.v1.Notification.LabelsEntry
+	7,  // 14: notification.v1.Notification.retry_policy:type_name -> notification.v1.RetryPolicy
+	6,  // 15: notification.v1.Notification.receiver_params:type_name -> notification.v1.ReceiverParams
+	43, // 16: notification.v1.ReceiverParams.params:type_name -> notification.v1.ReceiverParams.ParamsEntry
+	44, // 17: notification.v1.CallbackOptions.headers:type_name -> notification.v1.CallbackOptions.HeadersEntry
+	2,  // 18: notification.v1.CallbackOptions.on_status:type_name -> notification.v1.SendStatus
+	5,  // 19: notification.v1.SendNotificationRequest.notification:type_name -> notification.v1.Notification
+	2,  // 20: notification.v1.SendNotificationResponse.status:type_name -> notification.v1.SendStatus
+	3,  // 21: notification.v1.SendNotificationResponse.error_code:type_name -> notification.v1.ErrorCode
+	12, // 22: notification.v1.SendNotificationResponse.dry_run_result:type_name -> notification.v1.DryRunResult
+	5,  // 23: notification.v1.SendNotificationAsyncRequest.notification:type_name -> notification.v1.Notification
+	3,  // 24: notification.v1.SendNotificationAsyncResponse.error_code:type_name -> notification.v1.ErrorCode
+	15, // 25: notification.v1.SendNotificationAsyncResponse.local_time_cohorts:type_name -> notification.v1.LocalTimeCohort
+	5,  // 26: notification.v1.BatchSendNotificationsRequest.notifications:type_name -> notification.v1.Notification
+	11, // 27: notification.v1.BatchSendNotificationsResponse.results:type_name -> notification.v1.SendNotificationResponse
+	5,  // 28: notification.v1.BatchSendNotificationsAsyncRequest.notifications:type_name -> notification.v1.Notification
+	20, // 29: notification.v1.BatchSendNotificationsAsyncResponse.results:type_name -> notification.v1.BatchSendNotificationsAsyncResult
+	3,  // 30: notification.v1.BatchSendNotificationsAsyncResult.error_code:type_name -> notification.v1.ErrorCode
+	15, // 31: notification.v1.BatchSendNotificationsAsyncResult.local_time_cohorts:type_name -> notification.v1.LocalTimeCohort
+	5,  // 32: notification.v1.TxPrepareRequest.notification:type_name -> notification.v1.Notification
+	2,  // 33: notification.v1.CancelNotificationResponse.status:type_name -> notification.v1.SendStatus
+	46, // 34: notification.v1.UpdateNotificationRequest.update_mask:type_name -> google.protobuf.FieldMask
+	45, // 35: notification.v1.UpdateNotificationRequest.template_params:type_name -> notification.v1.UpdateNotificationRequest.TemplateParamsEntry
+	4,  // 36: notification.v1.UpdateNotificationRequest.strategy:type_name -> notification.v1.SendStrategy
+	47, // 37: notification.v1.SendStrategy.ScheduledStrategy.send_time:type_name -> google.protobuf.Timestamp
+	47, // 38: notification.v1.SendStrategy.DeadlineStrategy.deadline:type_name -> google.protobuf.Timestamp
+	40, // 39: notification.v1.SendStrategy.LocalTimeStrategy.receiver_timezones:type_name -> notification.v1.SendStrategy.LocalTimeStrategy.ReceiverTimezonesEntry
+	10, // 40: notification.v1.NotificationService.SendNotification:input_type -> notification.v1.SendNotificationRequest
+	13, // 41: notification.v1.NotificationService.SendNotificationAsync:input_type -> notification.v1.SendNotificationAsyncRequest
+	16, // 42: notification.v1.NotificationService.BatchSendNotifications:input_type -> notification.v1.BatchSendNotificationsRequest
+	18, // 43: notification.v1.NotificationService.BatchSendNotificationsAsync:input_type -> notification.v1.BatchSendNotificationsAsyncRequest
+	21, // 44: notification.v1.NotificationService.TxPrepare:input_type -> notification.v1.TxPrepareRequest
+	23, // 45: notification.v1.NotificationService.TxCommit:input_type -> notification.v1.TxCommitRequest
+	25, // 46: notification.v1.NotificationService.TxCancel:input_type -> notification.v1.TxCancelRequest
+	27, // 47: notification.v1.NotificationService.CancelNotification:input_type -> notification.v1.CancelNotificationRequest
+	29, // 48: notification.v1.NotificationService.UpdateNotification:input_type -> notification.v1.UpdateNotificationRequest
+	31, // 49: notification.v1.NotificationService.GenerateNotificationKeys:input_type -> notification.v1.GenerateNotificationKeysRequest
+	11, // 50: notification.v1.NotificationService.SendNotification:output_type -> notification.v1.SendNotificationResponse
+	14, // 51: notification.v1.NotificationService.SendNotificationAsync:output_type -> notification.v1.SendNotificationAsyncResponse
+	17, // 52: notification.v1.NotificationService.BatchSendNotifications:output_type -> notification.v1.BatchSendNotificationsResponse
+	19, // 53: notification.v1.NotificationService.BatchSendNotificationsAsync:output_type -> notification.v1.BatchSendNotificationsAsyncResponse
+	22, // 54: notification.v1.NotificationService.TxPrepare:output_type -> notification.v1.TxPrepareResponse
+	24, // 55: notification.v1.NotificationService.TxCommit:output_type -> notification.v1.TxCommitResponse
+	26, // 56: notification.v1.NotificationService.TxCancel:output_type -> notification.v1.TxCancelResponse
+	28, // 57: notification.v1.NotificationService.CancelNotification:output_type -> notification.v1.CancelNotificationResponse
+	30, // 58: notification.v1.NotificationService.UpdateNotification:output_type -> notification.v1.UpdateNotificationResponse
+	32, // 59: notification.v1.NotificationService.GenerateNotificationKeys:output_type -> notification.v1.GenerateNotificationKeysResponse
+	50, // [50:60] is the sub-list for method output_type
+	40, // [40:50] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_notification_v1_notification_proto_init() }
@@ -1601,14 +3019,16 @@ func file_notification_v1_notification_proto_init() {
 		(*SendStrategy_Scheduled)(nil),
 		(*SendStrategy_TimeWindow)(nil),
 		(*SendStrategy_Deadline)(nil),
+		(*SendStrategy_LocalTime)(nil),
+		(*SendStrategy_Paced)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_v1_notification_proto_rawDesc), len(file_notification_v1_notification_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   22,
+			NumEnums:      4,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
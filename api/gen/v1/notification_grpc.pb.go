@@ -26,6 +26,9 @@ const (
 	NotificationService_TxPrepare_FullMethodName                   = "/notification.v1.NotificationService/TxPrepare"
 	NotificationService_TxCommit_FullMethodName                    = "/notification.v1.NotificationService/TxCommit"
 	NotificationService_TxCancel_FullMethodName                    = "/notification.v1.NotificationService/TxCancel"
+	NotificationService_CancelNotification_FullMethodName          = "/notification.v1.NotificationService/CancelNotification"
+	NotificationService_UpdateNotification_FullMethodName          = "/notification.v1.NotificationService/UpdateNotification"
+	NotificationService_GenerateNotificationKeys_FullMethodName    = "/notification.v1.NotificationService/GenerateNotificationKeys"
 )
 
 // NotificationServiceClient is the client API for NotificationService service.
@@ -46,6 +49,12 @@ type NotificationServiceClient interface {
 	TxCommit(ctx context.Context, in *TxCommitRequest, opts ...grpc.CallOption) (*TxCommitResponse, error)
 	// 取消事务
 	TxCancel(ctx context.Context, in *TxCancelRequest, opts ...grpc.CallOption) (*TxCancelResponse, error)
+	// 取消待发送的通知，只有 PENDING 状态的通知可以取消
+	CancelNotification(ctx context.Context, in *CancelNotificationRequest, opts ...grpc.CallOption) (*CancelNotificationResponse, error)
+	// 修改待发送的通知，只有 PENDING 状态的通知可以修改
+	UpdateNotification(ctx context.Context, in *UpdateNotificationRequest, opts ...grpc.CallOption) (*UpdateNotificationResponse, error)
+	// 生成符合业务方 key 规则的唯一 key，给不想自己管理 key 的业务方使用，平台不记录生成过的 key
+	GenerateNotificationKeys(ctx context.Context, in *GenerateNotificationKeysRequest, opts ...grpc.CallOption) (*GenerateNotificationKeysResponse, error)
 }
 
 type notificationServiceClient struct {
@@ -126,6 +135,36 @@ func (c *notificationServiceClient) TxCancel(ctx context.Context, in *TxCancelRe
 	return out, nil
 }
 
+func (c *notificationServiceClient) CancelNotification(ctx context.Context, in *CancelNotificationRequest, opts ...grpc.CallOption) (*CancelNotificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelNotificationResponse)
+	err := c.cc.Invoke(ctx, NotificationService_CancelNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) UpdateNotification(ctx context.Context, in *UpdateNotificationRequest, opts ...grpc.CallOption) (*UpdateNotificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateNotificationResponse)
+	err := c.cc.Invoke(ctx, NotificationService_UpdateNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) GenerateNotificationKeys(ctx context.Context, in *GenerateNotificationKeysRequest, opts ...grpc.CallOption) (*GenerateNotificationKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateNotificationKeysResponse)
+	err := c.cc.Invoke(ctx, NotificationService_GenerateNotificationKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility.
@@ -144,6 +183,12 @@ type NotificationServiceServer interface {
 	TxCommit(context.Context, *TxCommitRequest) (*TxCommitResponse, error)
 	// 取消事务
 	TxCancel(context.Context, *TxCancelRequest) (*TxCancelResponse, error)
+	// 取消待发送的通知，只有 PENDING 状态的通知可以取消
+	CancelNotification(context.Context, *CancelNotificationRequest) (*CancelNotificationResponse, error)
+	// 修改待发送的通知，只有 PENDING 状态的通知可以修改
+	UpdateNotification(context.Context, *UpdateNotificationRequest) (*UpdateNotificationResponse, error)
+	// 生成符合业务方 key 规则的唯一 key，给不想自己管理 key 的业务方使用，平台不记录生成过的 key
+	GenerateNotificationKeys(context.Context, *GenerateNotificationKeysRequest) (*GenerateNotificationKeysResponse, error)
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -175,6 +220,15 @@ func (UnimplementedNotificationServiceServer) TxCommit(context.Context, *TxCommi
 func (UnimplementedNotificationServiceServer) TxCancel(context.Context, *TxCancelRequest) (*TxCancelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TxCancel not implemented")
 }
+func (UnimplementedNotificationServiceServer) CancelNotification(context.Context, *CancelNotificationRequest) (*CancelNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelNotification not implemented")
+}
+func (UnimplementedNotificationServiceServer) UpdateNotification(context.Context, *UpdateNotificationRequest) (*UpdateNotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotification not implemented")
+}
+func (UnimplementedNotificationServiceServer) GenerateNotificationKeys(context.Context, *GenerateNotificationKeysRequest) (*GenerateNotificationKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateNotificationKeys not implemented")
+}
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 func (UnimplementedNotificationServiceServer) testEmbeddedByValue()                             {}
 
@@ -322,6 +376,60 @@ func _NotificationService_TxCancel_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_CancelNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).CancelNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_CancelNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).CancelNotification(ctx, req.(*CancelNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_UpdateNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).UpdateNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_UpdateNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).UpdateNotification(ctx, req.(*UpdateNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_GenerateNotificationKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateNotificationKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).GenerateNotificationKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_GenerateNotificationKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).GenerateNotificationKeys(ctx, req.(*GenerateNotificationKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -357,6 +465,18 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TxCancel",
 			Handler:    _NotificationService_TxCancel_Handler,
 		},
+		{
+			MethodName: "CancelNotification",
+			Handler:    _NotificationService_CancelNotification_Handler,
+		},
+		{
+			MethodName: "UpdateNotification",
+			Handler:    _NotificationService_UpdateNotification_Handler,
+		},
+		{
+			MethodName: "GenerateNotificationKeys",
+			Handler:    _NotificationService_GenerateNotificationKeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification/v1/notification.proto",
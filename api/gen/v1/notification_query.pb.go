@@ -7,6 +7,7 @@
 package notificationpb
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -21,6 +22,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 渠道降级分组的状态
+type FallbackGroupStatus int32
+
+const (
+	FallbackGroupStatus_FALLBACK_GROUP_STATUS_UNSPECIFIED FallbackGroupStatus = 0
+	// 还有通知没有发送完
+	FallbackGroupStatus_FALLBACK_GROUP_IN_PROGRESS FallbackGroupStatus = 1
+	// 有一个渠道发送成功
+	FallbackGroupStatus_FALLBACK_GROUP_DELIVERED FallbackGroupStatus = 2
+	// 所有渠道都发送失败或者被取消
+	FallbackGroupStatus_FALLBACK_GROUP_EXHAUSTED FallbackGroupStatus = 3
+)
+
+// Enum value maps for FallbackGroupStatus.
+var (
+	FallbackGroupStatus_name = map[int32]string{
+		0: "FALLBACK_GROUP_STATUS_UNSPECIFIED",
+		1: "FALLBACK_GROUP_IN_PROGRESS",
+		2: "FALLBACK_GROUP_DELIVERED",
+		3: "FALLBACK_GROUP_EXHAUSTED",
+	}
+	FallbackGroupStatus_value = map[string]int32{
+		"FALLBACK_GROUP_STATUS_UNSPECIFIED": 0,
+		"FALLBACK_GROUP_IN_PROGRESS":        1,
+		"FALLBACK_GROUP_DELIVERED":          2,
+		"FALLBACK_GROUP_EXHAUSTED":          3,
+	}
+)
+
+func (x FallbackGroupStatus) Enum() *FallbackGroupStatus {
+	p := new(FallbackGroupStatus)
+	*p = x
+	return p
+}
+
+func (x FallbackGroupStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FallbackGroupStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_notification_v1_notification_query_proto_enumTypes[0].Descriptor()
+}
+
+func (FallbackGroupStatus) Type() protoreflect.EnumType {
+	return &file_notification_v1_notification_query_proto_enumTypes[0]
+}
+
+func (x FallbackGroupStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FallbackGroupStatus.Descriptor instead.
+func (FallbackGroupStatus) EnumDescriptor() ([]byte, []int) {
+	return file_notification_v1_notification_query_proto_rawDescGZIP(), []int{0}
+}
+
 // 单条查询请求
 type QueryNotificationRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -203,11 +260,364 @@ func (x *BatchQueryNotificationsResponse) GetResults() []*SendNotificationRespon
 	return nil
 }
 
+// 分页查询请求
+type ListNotificationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 为空不过滤
+	Status  SendStatus `protobuf:"varint,1,opt,name=status,proto3,enum=notification.v1.SendStatus" json:"status,omitempty"`
+	Channel Channel    `protobuf:"varint,2,opt,name=channel,proto3,enum=notification.v1.Channel" json:"channel,omitempty"`
+	// 通知必须包含所有这些标签
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 上一页的 next_before_id，为 0 从最新的开始
+	BeforeId uint64 `protobuf:"varint,4,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
+	// 默认 20，最大 100
+	PageSize      int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_notification_v1_notification_query_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_query_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListNotificationsRequest) GetStatus() SendStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SendStatus_SEND_STATUS_UNSPECIFIED
+}
+
+func (x *ListNotificationsRequest) GetChannel() Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return Channel_CHANNEL_UNSPECIFIED
+}
+
+func (x *ListNotificationsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetBeforeId() uint64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListNotificationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 分页查询到的通知
+type NotificationSummary struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NotificationId uint64                 `protobuf:"varint,1,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	Key            string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Channel        Channel                `protobuf:"varint,3,opt,name=channel,proto3,enum=notification.v1.Channel" json:"channel,omitempty"`
+	Status         SendStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=notification.v1.SendStatus" json:"status,omitempty"`
+	Labels         map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 沙箱凭证发送的通知
+	Sandbox bool `protobuf:"varint,6,opt,name=sandbox,proto3" json:"sandbox,omitempty"`
+	// FAILED 或者 CANCELED 的原因，平台原因例如 EXPIRED、SUPPRESSED，供应商发送失败时是统一的错误分类，例如 VENDOR_BLACKLISTED、VENDOR_TEMPLATE_MISMATCH
+	FailReason string `protobuf:"bytes,7,opt,name=fail_reason,json=failReason,proto3" json:"fail_reason,omitempty"`
+	// 发送时传的透传数据
+	BizPayload    string `protobuf:"bytes,8,opt,name=biz_payload,json=bizPayload,proto3" json:"biz_payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationSummary) Reset() {
+	*x = NotificationSummary{}
+	mi := &file_notification_v1_notification_query_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationSummary) ProtoMessage() {}
+
+func (x *NotificationSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_query_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationSummary.ProtoReflect.Descriptor instead.
+func (*NotificationSummary) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NotificationSummary) GetNotificationId() uint64 {
+	if x != nil {
+		return x.NotificationId
+	}
+	return 0
+}
+
+func (x *NotificationSummary) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *NotificationSummary) GetChannel() Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return Channel_CHANNEL_UNSPECIFIED
+}
+
+func (x *NotificationSummary) GetStatus() SendStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SendStatus_SEND_STATUS_UNSPECIFIED
+}
+
+func (x *NotificationSummary) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *NotificationSummary) GetSandbox() bool {
+	if x != nil {
+		return x.Sandbox
+	}
+	return false
+}
+
+func (x *NotificationSummary) GetFailReason() string {
+	if x != nil {
+		return x.FailReason
+	}
+	return ""
+}
+
+func (x *NotificationSummary) GetBizPayload() string {
+	if x != nil {
+		return x.BizPayload
+	}
+	return ""
+}
+
+// 分页查询响应
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*NotificationSummary `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	// 下一页的 before_id，为 0 表示没有下一页
+	NextBeforeId  uint64 `protobuf:"varint,2,opt,name=next_before_id,json=nextBeforeId,proto3" json:"next_before_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_notification_v1_notification_query_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_query_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_query_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*NotificationSummary {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetNextBeforeId() uint64 {
+	if x != nil {
+		return x.NextBeforeId
+	}
+	return 0
+}
+
+type GetFallbackGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFallbackGroupRequest) Reset() {
+	*x = GetFallbackGroupRequest{}
+	mi := &file_notification_v1_notification_query_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFallbackGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFallbackGroupRequest) ProtoMessage() {}
+
+func (x *GetFallbackGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_query_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFallbackGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetFallbackGroupRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_query_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetFallbackGroupRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type GetFallbackGroupResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Group  string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Status FallbackGroupStatus    `protobuf:"varint,2,opt,name=status,proto3,enum=notification.v1.FallbackGroupStatus" json:"status,omitempty"`
+	// 发送成功的通知，没有时为 0
+	DeliveredNotificationId uint64 `protobuf:"varint,3,opt,name=delivered_notification_id,json=deliveredNotificationId,proto3" json:"delivered_notification_id,omitempty"`
+	// 分组里的通知，按ID升序
+	Members       []*NotificationSummary `protobuf:"bytes,4,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFallbackGroupResponse) Reset() {
+	*x = GetFallbackGroupResponse{}
+	mi := &file_notification_v1_notification_query_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFallbackGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFallbackGroupResponse) ProtoMessage() {}
+
+func (x *GetFallbackGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_query_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFallbackGroupResponse.ProtoReflect.Descriptor instead.
+func (*GetFallbackGroupResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_query_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetFallbackGroupResponse) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GetFallbackGroupResponse) GetStatus() FallbackGroupStatus {
+	if x != nil {
+		return x.Status
+	}
+	return FallbackGroupStatus_FALLBACK_GROUP_STATUS_UNSPECIFIED
+}
+
+func (x *GetFallbackGroupResponse) GetDeliveredNotificationId() uint64 {
+	if x != nil {
+		return x.DeliveredNotificationId
+	}
+	return 0
+}
+
+func (x *GetFallbackGroupResponse) GetMembers() []*NotificationSummary {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
 var File_notification_v1_notification_query_proto protoreflect.FileDescriptor
 
 const file_notification_v1_notification_query_proto_rawDesc = "" +
 	"\n" +
-	"(notification/v1/notification_query.proto\x12\x0fnotification.v1\x1a\"notification/v1/notification.proto\",\n" +
+	"(notification/v1/notification_query.proto\x12\x0fnotification.v1\x1a\"notification/v1/notification.proto\x1a\x1cgoogle/api/annotations.proto\",\n" +
 	"\x18QueryNotificationRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"^\n" +
 	"\x19QueryNotificationResponse\x12A\n" +
@@ -215,10 +625,50 @@ const file_notification_v1_notification_query_proto_rawDesc = "" +
 	"\x1eBatchQueryNotificationsRequest\x12\x12\n" +
 	"\x04keys\x18\x01 \x03(\tR\x04keys\"f\n" +
 	"\x1fBatchQueryNotificationsResponse\x12C\n" +
-	"\aresults\x18\x01 \x03(\v2).notification.v1.SendNotificationResponseR\aresults2\x84\x02\n" +
-	"\x18NotificationQueryService\x12j\n" +
-	"\x11QueryNotification\x12).notification.v1.QueryNotificationRequest\x1a*.notification.v1.QueryNotificationResponse\x12|\n" +
-	"\x17BatchQueryNotifications\x12/.notification.v1.BatchQueryNotificationsRequest\x1a0.notification.v1.BatchQueryNotificationsResponseBQZOgithub.com/serendipityConfusion/notification-platform/api/gen/v1;notificationpbb\x06proto3"
+	"\aresults\x18\x01 \x03(\v2).notification.v1.SendNotificationResponseR\aresults\"\xc7\x02\n" +
+	"\x18ListNotificationsRequest\x123\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1b.notification.v1.SendStatusR\x06status\x122\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x18.notification.v1.ChannelR\achannel\x12M\n" +
+	"\x06labels\x18\x03 \x03(\v25.notification.v1.ListNotificationsRequest.LabelsEntryR\x06labels\x12\x1b\n" +
+	"\tbefore_id\x18\x04 \x01(\x04R\bbeforeId\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9a\x03\n" +
+	"\x13NotificationSummary\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\x04R\x0enotificationId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x122\n" +
+	"\achannel\x18\x03 \x01(\x0e2\x18.notification.v1.ChannelR\achannel\x123\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1b.notification.v1.SendStatusR\x06status\x12H\n" +
+	"\x06labels\x18\x05 \x03(\v20.notification.v1.NotificationSummary.LabelsEntryR\x06labels\x12\x18\n" +
+	"\asandbox\x18\x06 \x01(\bR\asandbox\x12\x1f\n" +
+	"\vfail_reason\x18\a \x01(\tR\n" +
+	"failReason\x12\x1f\n" +
+	"\vbiz_payload\x18\b \x01(\tR\n" +
+	"bizPayload\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x01\n" +
+	"\x19ListNotificationsResponse\x12J\n" +
+	"\rnotifications\x18\x01 \x03(\v2$.notification.v1.NotificationSummaryR\rnotifications\x12$\n" +
+	"\x0enext_before_id\x18\x02 \x01(\x04R\fnextBeforeId\"/\n" +
+	"\x17GetFallbackGroupRequest\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\"\xea\x01\n" +
+	"\x18GetFallbackGroupResponse\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12<\n" +
+	"\x06status\x18\x02 \x01(\x0e2$.notification.v1.FallbackGroupStatusR\x06status\x12:\n" +
+	"\x19delivered_notification_id\x18\x03 \x01(\x04R\x17deliveredNotificationId\x12>\n" +
+	"\amembers\x18\x04 \x03(\v2$.notification.v1.NotificationSummaryR\amembers*\x98\x01\n" +
+	"\x13FallbackGroupStatus\x12%\n" +
+	"!FALLBACK_GROUP_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aFALLBACK_GROUP_IN_PROGRESS\x10\x01\x12\x1c\n" +
+	"\x18FALLBACK_GROUP_DELIVERED\x10\x02\x12\x1c\n" +
+	"\x18FALLBACK_GROUP_EXHAUSTED\x10\x032\xef\x04\n" +
+	"\x18NotificationQueryService\x12\x8b\x01\n" +
+	"\x11QueryNotification\x12).notification.v1.QueryNotificationRequest\x1a*.notification.v1.QueryNotificationResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/notifications/{key}\x12\xa5\x01\n" +
+	"\x17BatchQueryNotifications\x12/.notification.v1.BatchQueryNotificationsRequest\x1a0.notification.v1.BatchQueryNotificationsResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/notifications:batchQuery\x12\x8d\x01\n" +
+	"\x11ListNotifications\x12).notification.v1.ListNotificationsRequest\x1a*.notification.v1.ListNotificationsResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/notifications:list\x12\x8c\x01\n" +
+	"\x10GetFallbackGroup\x12(.notification.v1.GetFallbackGroupRequest\x1a).notification.v1.GetFallbackGroupResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/fallback-groups/{group}BQZOgithub.com/serendipityConfusion/notification-platform/api/gen/v1;notificationpbb\x06proto3"
 
 var (
 	file_notification_v1_notification_query_proto_rawDescOnce sync.Once
@@ -232,26 +682,50 @@ func file_notification_v1_notification_query_proto_rawDescGZIP() []byte {
 	return file_notification_v1_notification_query_proto_rawDescData
 }
 
-var file_notification_v1_notification_query_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_notification_v1_notification_query_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_notification_v1_notification_query_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_notification_v1_notification_query_proto_goTypes = []any{
-	(*QueryNotificationRequest)(nil),        // 0: notification.v1.QueryNotificationRequest
-	(*QueryNotificationResponse)(nil),       // 1: notification.v1.QueryNotificationResponse
-	(*BatchQueryNotificationsRequest)(nil),  // 2: notification.v1.BatchQueryNotificationsRequest
-	(*BatchQueryNotificationsResponse)(nil), // 3: notification.v1.BatchQueryNotificationsResponse
-	(*SendNotificationResponse)(nil),        // 4: notification.v1.SendNotificationResponse
+	(FallbackGroupStatus)(0),                // 0: notification.v1.FallbackGroupStatus
+	(*QueryNotificationRequest)(nil),        // 1: notification.v1.QueryNotificationRequest
+	(*QueryNotificationResponse)(nil),       // 2: notification.v1.QueryNotificationResponse
+	(*BatchQueryNotificationsRequest)(nil),  // 3: notification.v1.BatchQueryNotificationsRequest
+	(*BatchQueryNotificationsResponse)(nil), // 4: notification.v1.BatchQueryNotificationsResponse
+	(*ListNotificationsRequest)(nil),        // 5: notification.v1.ListNotificationsRequest
+	(*NotificationSummary)(nil),             // 6: notification.v1.NotificationSummary
+	(*ListNotificationsResponse)(nil),       // 7: notification.v1.ListNotificationsResponse
+	(*GetFallbackGroupRequest)(nil),         // 8: notification.v1.GetFallbackGroupRequest
+	(*GetFallbackGroupResponse)(nil),        // 9: notification.v1.GetFallbackGroupResponse
+	nil,                                     // 10: notification.v1.ListNotificationsRequest.LabelsEntry
+	nil,                                     // 11: notification.v1.NotificationSummary.LabelsEntry
+	(*SendNotificationResponse)(nil),        // 12: notification.v1.SendNotificationResponse
+	(SendStatus)(0),                         // 13: notification.v1.SendStatus
+	(Channel)(0),                            // 14: notification.v1.Channel
 }
 var file_notification_v1_notification_query_proto_depIdxs = []int32{
-	4, // 0: notification.v1.QueryNotificationResponse.result:type_name -> notification.v1.SendNotificationResponse
-	4, // 1: notification.v1.BatchQueryNotificationsResponse.results:type_name -> notification.v1.SendNotificationResponse
-	0, // 2: notification.v1.NotificationQueryService.QueryNotification:input_type -> notification.v1.QueryNotificationRequest
-	2, // 3: notification.v1.NotificationQueryService.BatchQueryNotifications:input_type -> notification.v1.BatchQueryNotificationsRequest
-	1, // 4: notification.v1.NotificationQueryService.QueryNotification:output_type -> notification.v1.QueryNotificationResponse
-	3, // 5: notification.v1.NotificationQueryService.BatchQueryNotifications:output_type -> notification.v1.BatchQueryNotificationsResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	12, // 0: notification.v1.QueryNotificationResponse.result:type_name -> notification.v1.SendNotificationResponse
+	12, // 1: notification.v1.BatchQueryNotificationsResponse.results:type_name -> notification.v1.SendNotificationResponse
+	13, // 2: notification.v1.ListNotificationsRequest.status:type_name -> notification.v1.SendStatus
+	14, // 3: notification.v1.ListNotificationsRequest.channel:type_name -> notification.v1.Channel
+	10, // 4: notification.v1.ListNotificationsRequest.labels:type_name -> notification.v1.ListNotificationsRequest.LabelsEntry
+	14, // 5: notification.v1.NotificationSummary.channel:type_name -> notification.v1.Channel
+	13, // 6: notification.v1.NotificationSummary.status:type_name -> notification.v1.SendStatus
+	11, // 7: notification.v1.NotificationSummary.labels:type_name -> notification.v1.NotificationSummary.LabelsEntry
+	6,  // 8: notification.v1.ListNotificationsResponse.notifications:type_name -> notification.v1.NotificationSummary
+	0,  // 9: notification.v1.GetFallbackGroupResponse.status:type_name -> notification.v1.FallbackGroupStatus
+	6,  // 10: notification.v1.GetFallbackGroupResponse.members:type_name -> notification.v1.NotificationSummary
+	1,  // 11: notification.v1.NotificationQueryService.QueryNotification:input_type -> notification.v1.QueryNotificationRequest
+	3,  // 12: notification.v1.NotificationQueryService.BatchQueryNotifications:input_type -> notification.v1.BatchQueryNotificationsRequest
+	5,  // 13: notification.v1.NotificationQueryService.ListNotifications:input_type -> notification.v1.ListNotificationsRequest
+	8,  // 14: notification.v1.NotificationQueryService.GetFallbackGroup:input_type -> notification.v1.GetFallbackGroupRequest
+	2,  // 15: notification.v1.NotificationQueryService.QueryNotification:output_type -> notification.v1.QueryNotificationResponse
+	4,  // 16: notification.v1.NotificationQueryService.BatchQueryNotifications:output_type -> notification.v1.BatchQueryNotificationsResponse
+	7,  // 17: notification.v1.NotificationQueryService.ListNotifications:output_type -> notification.v1.ListNotificationsResponse
+	9,  // 18: notification.v1.NotificationQueryService.GetFallbackGroup:output_type -> notification.v1.GetFallbackGroupResponse
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_notification_v1_notification_query_proto_init() }
@@ -265,13 +739,14 @@ func file_notification_v1_notification_query_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_v1_notification_query_proto_rawDesc), len(file_notification_v1_notification_query_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_notification_v1_notification_query_proto_goTypes,
 		DependencyIndexes: file_notification_v1_notification_query_proto_depIdxs,
+		EnumInfos:         file_notification_v1_notification_query_proto_enumTypes,
 		MessageInfos:      file_notification_v1_notification_query_proto_msgTypes,
 	}.Build()
 	File_notification_v1_notification_query_proto = out.File
@@ -21,6 +21,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	NotificationQueryService_QueryNotification_FullMethodName       = "/notification.v1.NotificationQueryService/QueryNotification"
 	NotificationQueryService_BatchQueryNotifications_FullMethodName = "/notification.v1.NotificationQueryService/BatchQueryNotifications"
+	NotificationQueryService_ListNotifications_FullMethodName       = "/notification.v1.NotificationQueryService/ListNotifications"
+	NotificationQueryService_GetFallbackGroup_FullMethodName        = "/notification.v1.NotificationQueryService/GetFallbackGroup"
 )
 
 // NotificationQueryServiceClient is the client API for NotificationQueryService service.
@@ -33,6 +35,10 @@ type NotificationQueryServiceClient interface {
 	QueryNotification(ctx context.Context, in *QueryNotificationRequest, opts ...grpc.CallOption) (*QueryNotificationResponse, error)
 	// 批量查询
 	BatchQueryNotifications(ctx context.Context, in *BatchQueryNotificationsRequest, opts ...grpc.CallOption) (*BatchQueryNotificationsResponse, error)
+	// 按ID倒序分页查询通知，可以按状态、渠道和标签过滤
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	// 查询渠道降级分组的状态和分组里的通知
+	GetFallbackGroup(ctx context.Context, in *GetFallbackGroupRequest, opts ...grpc.CallOption) (*GetFallbackGroupResponse, error)
 }
 
 type notificationQueryServiceClient struct {
@@ -63,6 +69,26 @@ func (c *notificationQueryServiceClient) BatchQueryNotifications(ctx context.Con
 	return out, nil
 }
 
+func (c *notificationQueryServiceClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, NotificationQueryService_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationQueryServiceClient) GetFallbackGroup(ctx context.Context, in *GetFallbackGroupRequest, opts ...grpc.CallOption) (*GetFallbackGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFallbackGroupResponse)
+	err := c.cc.Invoke(ctx, NotificationQueryService_GetFallbackGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationQueryServiceServer is the server API for NotificationQueryService service.
 // All implementations must embed UnimplementedNotificationQueryServiceServer
 // for forward compatibility.
@@ -73,6 +99,10 @@ type NotificationQueryServiceServer interface {
 	QueryNotification(context.Context, *QueryNotificationRequest) (*QueryNotificationResponse, error)
 	// 批量查询
 	BatchQueryNotifications(context.Context, *BatchQueryNotificationsRequest) (*BatchQueryNotificationsResponse, error)
+	// 按ID倒序分页查询通知，可以按状态、渠道和标签过滤
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	// 查询渠道降级分组的状态和分组里的通知
+	GetFallbackGroup(context.Context, *GetFallbackGroupRequest) (*GetFallbackGroupResponse, error)
 	mustEmbedUnimplementedNotificationQueryServiceServer()
 }
 
@@ -89,6 +119,12 @@ func (UnimplementedNotificationQueryServiceServer) QueryNotification(context.Con
 func (UnimplementedNotificationQueryServiceServer) BatchQueryNotifications(context.Context, *BatchQueryNotificationsRequest) (*BatchQueryNotificationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchQueryNotifications not implemented")
 }
+func (UnimplementedNotificationQueryServiceServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedNotificationQueryServiceServer) GetFallbackGroup(context.Context, *GetFallbackGroupRequest) (*GetFallbackGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFallbackGroup not implemented")
+}
 func (UnimplementedNotificationQueryServiceServer) mustEmbedUnimplementedNotificationQueryServiceServer() {
 }
 func (UnimplementedNotificationQueryServiceServer) testEmbeddedByValue() {}
@@ -147,6 +183,42 @@ func _NotificationQueryService_BatchQueryNotifications_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationQueryService_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationQueryServiceServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationQueryService_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationQueryServiceServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationQueryService_GetFallbackGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFallbackGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationQueryServiceServer).GetFallbackGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationQueryService_GetFallbackGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationQueryServiceServer).GetFallbackGroup(ctx, req.(*GetFallbackGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationQueryService_ServiceDesc is the grpc.ServiceDesc for NotificationQueryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -162,6 +234,14 @@ var NotificationQueryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchQueryNotifications",
 			Handler:    _NotificationQueryService_BatchQueryNotifications_Handler,
 		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _NotificationQueryService_ListNotifications_Handler,
+		},
+		{
+			MethodName: "GetFallbackGroup",
+			Handler:    _NotificationQueryService_GetFallbackGroup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification/v1/notification_query.proto",
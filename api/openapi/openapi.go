@@ -0,0 +1,7 @@
+// Package openapi 由 protoc-gen-openapiv2 根据 proto 上的 google.api.http 注解生成，HTTP 网关通过 /openapi.json 对外提供
+package openapi
+
+import _ "embed"
+
+//go:embed notification-platform.swagger.json
+var Spec []byte
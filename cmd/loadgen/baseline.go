@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Baseline 性能基线，零值的阈值不检查
+type Baseline struct {
+	// MaxP50 等延迟阈值使用 Go 的时长格式，例如 "20ms"
+	MaxP50 string `json:"max_p50,omitempty"`
+	MaxP90 string `json:"max_p90,omitempty"`
+	MaxP99 string `json:"max_p99,omitempty"`
+	// MaxErrorRate 错误和丢弃的请求占所有请求的比例
+	MaxErrorRate float64 `json:"max_error_rate,omitempty"`
+	// MinQPSRatio 实际 QPS 至少达到目标 QPS 的这个比例
+	MinQPSRatio float64 `json:"min_qps_ratio,omitempty"`
+	// MaxDBWaitCount 压测期间等待数据库连接的次数，需要配置 -metrics-url
+	MaxDBWaitCount *float64 `json:"max_db_wait_count,omitempty"`
+	// MaxRedisTimeouts 压测期间等待 Redis 连接超时的次数，需要配置 -metrics-url
+	MaxRedisTimeouts *float64 `json:"max_redis_timeouts,omitempty"`
+}
+
+func loadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b := &Baseline{}
+	if err = json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", path, err)
+	}
+	// 阈值写错时直接报错，不要悄悄跳过检查
+	for _, d := range []string{b.MaxP50, b.MaxP90, b.MaxP99} {
+		if d == "" {
+			continue
+		}
+		if _, err = time.ParseDuration(d); err != nil {
+			return nil, fmt.Errorf("%s 的延迟阈值 %q 不合法", path, d)
+		}
+	}
+	return b, nil
+}
+
+// check 返回所有超过阈值的项，为空表示没有回退
+func (b *Baseline) check(r *Result) []string {
+	var violations []string
+	latency := func(name, limit string, actual time.Duration) {
+		if limit == "" {
+			return
+		}
+		if d, _ := time.ParseDuration(limit); actual > d {
+			violations = append(violations, fmt.Sprintf("%s %s > %s", name, actual, d))
+		}
+	}
+	latency("p50", b.MaxP50, r.P50)
+	latency("p90", b.MaxP90, r.P90)
+	latency("p99", b.MaxP99, r.P99)
+	if b.MaxErrorRate > 0 && r.ErrorRate > b.MaxErrorRate {
+		violations = append(violations, fmt.Sprintf("error rate %.4f > %.4f", r.ErrorRate, b.MaxErrorRate))
+	}
+	if b.MinQPSRatio > 0 && r.ActualQPS < float64(r.TargetQPS)*b.MinQPSRatio {
+		violations = append(violations, fmt.Sprintf("qps %.1f < %.1f", r.ActualQPS, float64(r.TargetQPS)*b.MinQPSRatio))
+	}
+	if b.MaxDBWaitCount == nil && b.MaxRedisTimeouts == nil {
+		return violations
+	}
+	if r.Saturation == nil {
+		return append(violations, "baseline checks connection pools but -metrics-url is not set")
+	}
+	if b.MaxDBWaitCount != nil && r.Saturation.DBWaitCount > *b.MaxDBWaitCount {
+		violations = append(violations, fmt.Sprintf("db wait count %.0f > %.0f", r.Saturation.DBWaitCount, *b.MaxDBWaitCount))
+	}
+	if b.MaxRedisTimeouts != nil && r.Saturation.RedisTimeouts > *b.MaxRedisTimeouts {
+		violations = append(violations, fmt.Sprintf("redis timeouts %.0f > %.0f", r.Saturation.RedisTimeouts, *b.MaxRedisTimeouts))
+	}
+	return violations
+}
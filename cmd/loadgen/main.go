@@ -0,0 +1,163 @@
+// loadgen 按固定 QPS 调用 SendNotification 或 BatchSendNotifications，统计延迟分位数、错误和数据库、Redis 连接池的使用情况
+// 可以压测一个运行中的实例，也可以用 -inprocess 压测进程内的模拟服务，和基线比较发现性能回退
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const usage = `用法: loadgen [flags]
+
+压测运行中的实例（本地可以用 platform devserver 启动，它会打印演示凭证和模板ID）:
+  loadgen -addr 127.0.0.1:8080 -token <凭证> -template-id <模板ID> -qps 200 -duration 30s
+  loadgen -mode batch -batch-size 50 -qps 20 ...
+  配置了 -metrics-url（平台 debug-server 的 /metrics）时同时统计数据库和 Redis 连接池的使用情况
+
+压测进程内的模拟服务，只衡量 gRPC 和压测工具自身的开销:
+  loadgen -inprocess -mock-latency 2ms
+
+和基线比较，超过阈值时退出码为 1:
+  loadgen -baseline cmd/loadgen/baselines/inprocess-send.json ...
+
+参数:`
+
+func main() {
+	var (
+		addr        = flag.String("addr", "127.0.0.1:8080", "平台 gRPC 地址")
+		token       = flag.String("token", os.Getenv("LOADGEN_TOKEN"), "调用凭证，默认读取环境变量 LOADGEN_TOKEN")
+		mode        = flag.String("mode", modeSend, "压测的接口: send 或 batch")
+		qps         = flag.Int("qps", 100, "每秒发起的请求数，batch 模式下每个请求包含 batch-size 条通知")
+		duration    = flag.Duration("duration", 30*time.Second, "压测时长")
+		concurrency = flag.Int("concurrency", 64, "最多同时进行的请求数，达到上限时丢弃这一次请求并计入 dropped")
+		timeout     = flag.Duration("timeout", 5*time.Second, "单个请求的超时时间")
+		batchSize   = flag.Int("batch-size", 100, "batch 模式下每个请求的通知数")
+		channel     = flag.String("channel", "sms", "渠道: sms, email, in_app")
+		templateID  = flag.String("template-id", "", "模板ID")
+		params      = flag.String("params", "code=123456", "模板参数，格式 k1=v1,k2=v2")
+		receiver    = flag.String("receiver", "138%08d", "接收者，%d 替换成请求序号")
+		metricsURL  = flag.String("metrics-url", "", "平台的 /metrics 地址，例如 http://127.0.0.1:6060/metrics，为空时不统计连接池")
+		baseline    = flag.String("baseline", "", "基线文件，结果超过阈值时退出码为 1")
+		output      = flag.String("output", "", "把结果以 JSON 写到这个文件，可以用来更新基线")
+		inprocess   = flag.Bool("inprocess", false, "压测进程内的模拟服务，忽略 addr 和 token")
+		mockLatency = flag.Duration("mock-latency", time.Millisecond, "inprocess 模式下模拟服务每个请求的处理时间")
+	)
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *mode != modeSend && *mode != modeBatch {
+		log.Fatalf("[Loadgen] Unknown mode: %s", *mode)
+	}
+	if *qps <= 0 || *concurrency <= 0 || *batchSize <= 0 || *duration <= 0 {
+		log.Fatalf("[Loadgen] qps, concurrency, batch-size and duration must be positive")
+	}
+	ch, ok := channels[*channel]
+	if !ok {
+		log.Fatalf("[Loadgen] Unknown channel: %s", *channel)
+	}
+	tplParams, err := parseParams(*params)
+	if err != nil {
+		log.Fatalf("[Loadgen] Invalid params: %v", err)
+	}
+
+	var conn *grpc.ClientConn
+	if *inprocess {
+		var stop func()
+		conn, stop, err = startMockServer(*mockLatency)
+		if err != nil {
+			log.Fatalf("[Loadgen] Failed to start mock server: %v", err)
+		}
+		defer stop()
+	} else {
+		if *templateID == "" {
+			log.Fatalf("[Loadgen] -template-id is required")
+		}
+		conn, err = grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Fatalf("[Loadgen] Failed to connect %s: %v", *addr, err)
+		}
+		defer conn.Close()
+	}
+
+	ctx := context.Background()
+	if *token != "" && !*inprocess {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+	r := &runner{
+		client:      notificationpb.NewNotificationServiceClient(conn),
+		mode:        *mode,
+		qps:         *qps,
+		duration:    *duration,
+		concurrency: *concurrency,
+		timeout:     *timeout,
+		batchSize:   *batchSize,
+		channel:     ch,
+		templateID:  *templateID,
+		params:      tplParams,
+		receiver:    *receiver,
+		runID:       time.Now().Format("20060102150405"),
+	}
+	var sampler *saturationSampler
+	if *metricsURL != "" {
+		sampler = newSaturationSampler(*metricsURL)
+	}
+
+	log.Printf("[Loadgen] Running %s at %d qps for %s", *mode, *qps, *duration)
+	res := r.run(ctx, sampler)
+	res.print(os.Stdout)
+
+	if *output != "" {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			log.Fatalf("[Loadgen] Failed to encode result: %v", err)
+		}
+		if err = os.WriteFile(*output, data, 0o644); err != nil {
+			log.Fatalf("[Loadgen] Failed to write %s: %v", *output, err)
+		}
+	}
+	if *baseline != "" {
+		b, err := loadBaseline(*baseline)
+		if err != nil {
+			log.Fatalf("[Loadgen] Failed to load baseline: %v", err)
+		}
+		if violations := b.check(res); len(violations) > 0 {
+			log.Fatalf("[Loadgen] Regression against %s:\n  %s", *baseline, strings.Join(violations, "\n  "))
+		}
+		log.Printf("[Loadgen] Within baseline %s", *baseline)
+	}
+}
+
+var channels = map[string]notificationpb.Channel{
+	"sms":    notificationpb.Channel_SMS,
+	"email":  notificationpb.Channel_EMAIL,
+	"in_app": notificationpb.Channel_IN_APP,
+}
+
+func parseParams(s string) (map[string]string, error) {
+	res := make(map[string]string)
+	if s == "" {
+		return res, nil
+	}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%q 不是 k=v 格式", kv)
+		}
+		res[k] = v
+	}
+	return res, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// mockServer 进程内的模拟服务，每个请求等待 latency 后返回成功，用来衡量 gRPC 和压测工具自身的开销
+type mockServer struct {
+	notificationpb.UnimplementedNotificationServiceServer
+	latency time.Duration
+	id      atomic.Uint64
+}
+
+func (s *mockServer) SendNotification(ctx context.Context, _ *notificationpb.SendNotificationRequest) (*notificationpb.SendNotificationResponse, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &notificationpb.SendNotificationResponse{
+		NotificationId: s.id.Add(1),
+		Status:         notificationpb.SendStatus_SUCCEEDED,
+	}, nil
+}
+
+func (s *mockServer) BatchSendNotifications(ctx context.Context, req *notificationpb.BatchSendNotificationsRequest) (*notificationpb.BatchSendNotificationsResponse, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	results := make([]*notificationpb.SendNotificationResponse, 0, len(req.GetNotifications()))
+	for range req.GetNotifications() {
+		results = append(results, &notificationpb.SendNotificationResponse{
+			NotificationId: s.id.Add(1),
+			Status:         notificationpb.SendStatus_SUCCEEDED,
+		})
+	}
+	return &notificationpb.BatchSendNotificationsResponse{
+		Results:      results,
+		TotalCount:   int32(len(results)),
+		SuccessCount: int32(len(results)),
+	}, nil
+}
+
+func (s *mockServer) wait(ctx context.Context) error {
+	if s.latency <= 0 {
+		return nil
+	}
+	t := time.NewTimer(s.latency)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// startMockServer 通过内存连接启动模拟服务，返回的 stop 关闭连接和服务
+func startMockServer(latency time.Duration) (*grpc.ClientConn, func(), error) {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	notificationpb.RegisterNotificationServiceServer(server, &mockServer{latency: latency})
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		server.Stop()
+		return nil, nil, err
+	}
+	return conn, func() {
+		_ = conn.Close()
+		server.Stop()
+	}, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Result 一次压测的结果，延迟是从发起请求到收到响应的时间
+type Result struct {
+	Mode      string `json:"mode"`
+	TargetQPS int    `json:"target_qps"`
+	BatchSize int    `json:"batch_size,omitempty"`
+	Requests  int    `json:"requests"`
+	Errors    int    `json:"errors"`
+	Dropped   int    `json:"dropped"`
+	// FailedItems 请求成功但是发送失败的通知数
+	FailedItems int            `json:"failed_items"`
+	ErrorCodes  map[string]int `json:"error_codes,omitempty"`
+	ActualQPS   float64        `json:"actual_qps"`
+	ErrorRate   float64        `json:"error_rate"`
+	P50         time.Duration  `json:"p50"`
+	P90         time.Duration  `json:"p90"`
+	P99         time.Duration  `json:"p99"`
+	Max         time.Duration  `json:"max"`
+	Saturation  *Saturation    `json:"saturation,omitempty"`
+}
+
+type recorder struct {
+	mu        sync.Mutex
+	mode      string
+	qps       int
+	batchSize int
+	latencies []time.Duration
+	errors    int
+	dropped   int
+	failed    int
+	codes     map[string]int
+}
+
+func newRecorder(mode string, qps, batchSize int) *recorder {
+	return &recorder{mode: mode, qps: qps, batchSize: batchSize, codes: make(map[string]int)}
+}
+
+// record code 为空表示请求成功
+func (r *recorder) record(latency time.Duration, code string, failed int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies = append(r.latencies, latency)
+	r.failed += failed
+	if code != "" {
+		r.errors++
+		r.codes[code]++
+	}
+}
+
+func (r *recorder) drop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dropped++
+}
+
+func (r *recorder) result(elapsed time.Duration) *Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := &Result{
+		Mode:        r.mode,
+		TargetQPS:   r.qps,
+		Requests:    len(r.latencies),
+		Errors:      r.errors,
+		Dropped:     r.dropped,
+		FailedItems: r.failed,
+		ErrorCodes:  r.codes,
+	}
+	if r.mode == modeBatch {
+		res.BatchSize = r.batchSize
+	}
+	if elapsed > 0 {
+		res.ActualQPS = float64(res.Requests) / elapsed.Seconds()
+	}
+	if total := res.Requests + res.Dropped; total > 0 {
+		// 丢弃的请求说明平台跟不上，和错误一起计入错误率
+		res.ErrorRate = float64(res.Errors+res.Dropped) / float64(total)
+	}
+	sorted := slices.Clone(r.latencies)
+	slices.Sort(sorted)
+	res.P50, res.P90, res.P99 = percentile(sorted, 50), percentile(sorted, 90), percentile(sorted, 99)
+	if len(sorted) > 0 {
+		res.Max = sorted[len(sorted)-1]
+	}
+	return res
+}
+
+// percentile sorted 已经排好序
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[(len(sorted)*p-1)/100]
+}
+
+func (r *Result) print(w io.Writer) {
+	fmt.Fprintf(w, "mode:         %s (target %d qps", r.Mode, r.TargetQPS)
+	if r.BatchSize > 0 {
+		fmt.Fprintf(w, ", batch %d", r.BatchSize)
+	}
+	fmt.Fprintln(w, ")")
+	fmt.Fprintf(w, "requests:     %d (%.1f qps), errors %d, dropped %d, failed items %d\n",
+		r.Requests, r.ActualQPS, r.Errors, r.Dropped, r.FailedItems)
+	fmt.Fprintf(w, "error rate:   %.4f\n", r.ErrorRate)
+	fmt.Fprintf(w, "latency:      p50 %s, p90 %s, p99 %s, max %s\n", r.P50, r.P90, r.P99, r.Max)
+	for _, code := range slices.Sorted(maps.Keys(r.ErrorCodes)) {
+		fmt.Fprintf(w, "  error %-30s %d\n", code, r.ErrorCodes[code])
+	}
+	if s := r.Saturation; s != nil {
+		fmt.Fprintf(w, "db pool:      in use peak %.0f / max open %.0f, waits %.0f (%.3fs)\n",
+			s.DBInUsePeak, s.DBMaxOpen, s.DBWaitCount, s.DBWaitSeconds)
+		fmt.Fprintf(w, "redis pool:   connections peak %.0f, misses %.0f, timeouts %.0f\n",
+			s.RedisConnsPeak, s.RedisMisses, s.RedisTimeouts)
+		if s.ScrapeErrors > 0 {
+			fmt.Fprintf(w, "  %d scrapes of the metrics endpoint failed\n", s.ScrapeErrors)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	modeSend  = "send"
+	modeBatch = "batch"
+)
+
+type runner struct {
+	client      notificationpb.NotificationServiceClient
+	mode        string
+	qps         int
+	duration    time.Duration
+	concurrency int
+	timeout     time.Duration
+	batchSize   int
+	channel     notificationpb.Channel
+	templateID  string
+	params      map[string]string
+	receiver    string
+	// runID 放在业务 key 里，多次压测不会因为 key 重复被平台去重
+	runID string
+
+	seq atomic.Int64
+}
+
+// run 按固定间隔发起请求，请求数达到并发上限时丢弃这一次，避免压测工具自己排队让延迟失真
+func (r *runner) run(ctx context.Context, sampler *saturationSampler) *Result {
+	rec := newRecorder(r.mode, r.qps, r.batchSize)
+	if sampler != nil {
+		sampler.start()
+	}
+	sem := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+	ticker := time.NewTicker(time.Second / time.Duration(r.qps))
+	defer ticker.Stop()
+	deadline := time.After(r.duration)
+	start := time.Now()
+loop:
+	for {
+		select {
+		case <-deadline:
+			break loop
+		case <-ticker.C:
+			select {
+			case sem <- struct{}{}:
+			default:
+				rec.drop()
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				r.call(ctx, rec)
+			}()
+		}
+	}
+	wg.Wait()
+	res := rec.result(time.Since(start))
+	if sampler != nil {
+		res.Saturation = sampler.stop()
+	}
+	return res
+}
+
+func (r *runner) call(ctx context.Context, rec *recorder) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	start := time.Now()
+	var (
+		failed int
+		err    error
+	)
+	switch r.mode {
+	case modeBatch:
+		ns := make([]*notificationpb.Notification, 0, r.batchSize)
+		for range r.batchSize {
+			ns = append(ns, r.notification())
+		}
+		var resp *notificationpb.BatchSendNotificationsResponse
+		resp, err = r.client.BatchSendNotifications(ctx, &notificationpb.BatchSendNotificationsRequest{Notifications: ns})
+		if err == nil {
+			failed = int(resp.GetTotalCount() - resp.GetSuccessCount())
+		}
+	default:
+		var resp *notificationpb.SendNotificationResponse
+		resp, err = r.client.SendNotification(ctx, &notificationpb.SendNotificationRequest{Notification: r.notification()})
+		if err == nil && resp.GetStatus() == notificationpb.SendStatus_FAILED {
+			failed = 1
+			err = fmt.Errorf("%s", resp.GetErrorCode())
+		}
+	}
+	code := ""
+	if err != nil {
+		code = status.Code(err).String()
+		if _, ok := status.FromError(err); !ok || code == "Unknown" {
+			code = err.Error()
+		}
+	}
+	rec.record(time.Since(start), code, failed)
+}
+
+func (r *runner) notification() *notificationpb.Notification {
+	n := r.seq.Add(1)
+	return &notificationpb.Notification{
+		Key:            fmt.Sprintf("loadgen-%s-%d", r.runID, n),
+		Receivers:      []string{fmt.Sprintf(r.receiver, n)},
+		Channel:        r.channel,
+		TemplateId:     r.templateID,
+		TemplateParams: r.params,
+		Strategy: &notificationpb.SendStrategy{
+			StrategyType: &notificationpb.SendStrategy_Immediate{Immediate: &notificationpb.SendStrategy_ImmediateStrategy{}},
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 平台导出的连接池指标，go_sql_* 来自数据库连接池，redis_pool_* 来自 Redis 连接池
+const (
+	metricDBInUse        = "go_sql_in_use_connections"
+	metricDBMaxOpen      = "go_sql_max_open_connections"
+	metricDBWaitCount    = "go_sql_wait_count_total"
+	metricDBWaitSeconds  = "go_sql_wait_duration_seconds_total"
+	metricRedisConns     = "redis_pool_connections"
+	metricRedisMisses    = "redis_pool_misses_total"
+	metricRedisTimeouts  = "redis_pool_timeouts_total"
+	saturationSampleRate = time.Second
+)
+
+// Saturation 压测期间连接池的使用情况，计数器是压测期间的增量，其余是峰值
+// 多个实例时只统计 metrics-url 对应的实例
+type Saturation struct {
+	DBInUsePeak    float64 `json:"db_in_use_peak"`
+	DBMaxOpen      float64 `json:"db_max_open"`
+	DBWaitCount    float64 `json:"db_wait_count"`
+	DBWaitSeconds  float64 `json:"db_wait_seconds"`
+	RedisConnsPeak float64 `json:"redis_conns_peak"`
+	RedisMisses    float64 `json:"redis_misses"`
+	RedisTimeouts  float64 `json:"redis_timeouts"`
+	ScrapeErrors   int     `json:"scrape_errors,omitempty"`
+}
+
+// saturationSampler 压测期间每秒抓取一次平台的 /metrics
+type saturationSampler struct {
+	url    string
+	client *http.Client
+
+	mu     sync.Mutex
+	first  map[string]float64
+	last   map[string]float64
+	res    Saturation
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newSaturationSampler(url string) *saturationSampler {
+	return &saturationSampler{url: url, client: &http.Client{Timeout: saturationSampleRate}}
+}
+
+func (s *saturationSampler) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel, s.done = cancel, make(chan struct{})
+	s.sample(ctx)
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(saturationSampleRate)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.sample(ctx)
+			}
+		}
+	}()
+}
+
+func (s *saturationSampler) stop() *Saturation {
+	s.cancel()
+	<-s.done
+	// 最后一次抓取不受压测结束的取消影响
+	s.sample(context.Background())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delta := func(name string) float64 {
+		return s.last[name] - s.first[name]
+	}
+	res := s.res
+	res.DBWaitCount = delta(metricDBWaitCount)
+	res.DBWaitSeconds = delta(metricDBWaitSeconds)
+	res.RedisMisses = delta(metricRedisMisses)
+	res.RedisTimeouts = delta(metricRedisTimeouts)
+	return &res
+}
+
+func (s *saturationSampler) sample(ctx context.Context) {
+	values, err := s.scrape(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		if ctx.Err() == nil {
+			s.res.ScrapeErrors++
+		}
+		return
+	}
+	if s.first == nil {
+		s.first = values
+	}
+	s.last = values
+	s.res.DBInUsePeak = max(s.res.DBInUsePeak, values[metricDBInUse])
+	s.res.DBMaxOpen = max(s.res.DBMaxOpen, values[metricDBMaxOpen])
+	s.res.RedisConnsPeak = max(s.res.RedisConnsPeak, values[metricRedisConns])
+}
+
+// scrape 读取 Prometheus 文本格式，同名指标的不同标签累加
+func (s *saturationSampler) scrape(ctx context.Context) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	values := make(map[string]float64)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		if i < 0 {
+			continue
+		}
+		v, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			continue
+		}
+		name := line[:i]
+		if j := strings.IndexAny(name, "{ "); j >= 0 {
+			name = name[:j]
+		}
+		values[name] += v
+	}
+	return values, scanner.Err()
+}
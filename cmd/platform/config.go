@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/serendipityConfusion/notification-platform/internal/ioc"
+	"github.com/spf13/viper"
+)
+
+const configUsage = `用法: platform config <command> [flags]
+
+命令:
+  validate       校验配置文件，一次列出所有错误的配置项，不连接任何依赖
+                 -file 只校验指定的配置文件，不指定时校验按 --config、--env 合并之后的配置`
+
+// runConfig 执行 config 子命令
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "validate" {
+		fmt.Println(configUsage)
+		return errors.New("缺少或者未知的 config 命令")
+	}
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), configUsage)
+	}
+	file := fs.String("file", "", "配置文件路径")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	v := viper.GetViper()
+	if *file != "" {
+		v = viper.New()
+		v.SetConfigFile(*file)
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("读取配置文件失败: %w", err)
+		}
+	}
+	if err := ioc.ValidateConfig(v); err != nil {
+		return err
+	}
+	if *file != "" {
+		log.Printf("[Config] %s is valid", *file)
+	} else {
+		log.Println("[Config] Merged config is valid")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/auth"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/ioc"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"github.com/spf13/viper"
+)
+
+const devserverUsage = `用法: platform devserver [flags]
+
+本地开发使用：执行数据库迁移，初始化演示业务方（调用凭证、每个渠道一个模板和额度），然后开启鉴权和 HTTP 网关启动服务
+依赖的 MySQL、Redis、etcd 可以通过 docker compose -f deploy/docker-compose.yaml up -d 启动
+重复执行不会重复创建模板，额度会被重置
+
+参数:`
+
+// devTokenTTL 演示凭证的有效期
+const devTokenTTL = 30 * 24 * time.Hour
+
+// demoTemplate 演示模板，每个渠道一个
+type demoTemplate struct {
+	channel domain.Channel
+	name    string
+	content string
+	params  domain.TemplateParamSchema
+}
+
+var demoTemplates = []demoTemplate{
+	{
+		channel: domain.ChannelSMS,
+		name:    "demo-sms",
+		content: "您的验证码是 ${code}，5 分钟内有效",
+		params:  domain.TemplateParamSchema{{Name: "code", Type: domain.TemplateParamTypeString, Required: true}},
+	},
+	{
+		channel: domain.ChannelEmail,
+		name:    "demo-email",
+		content: "${name}，你好：你的订单 ${order} 已经发货",
+		params: domain.TemplateParamSchema{
+			{Name: "name", Type: domain.TemplateParamTypeString, Required: true},
+			{Name: "order", Type: domain.TemplateParamTypeString, Required: true},
+		},
+	},
+	{
+		channel: domain.ChannelInApp,
+		name:    "demo-in-app",
+		content: "${name}，你有一条新消息",
+		params:  domain.TemplateParamSchema{{Name: "name", Type: domain.TemplateParamTypeString, Required: true}},
+	},
+}
+
+// runDevserver 执行 devserver 子命令，返回是否需要继续启动服务
+func runDevserver(args []string) (bool, error) {
+	fs := flag.NewFlagSet("devserver", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), devserverUsage)
+		fs.PrintDefaults()
+	}
+	bizID := fs.Int64("biz-id", 1, "演示业务方ID")
+	principal := fs.String("principal", "demo-app", "演示调用方，分配业务方管理员角色")
+	quota := fs.Int("quota", 100000, "每个渠道的额度")
+	seedOnly := fs.Bool("seed-only", false, "只初始化数据，不启动服务")
+	if err := fs.Parse(args); err != nil {
+		return false, err
+	}
+	if *bizID <= 0 || *quota <= 0 {
+		return false, errors.New("biz-id 和 quota 必须大于 0")
+	}
+	jwtKey := viper.GetString("auth.jwt-key")
+	if jwtKey == "" {
+		return false, errors.New("没有配置 auth.jwt-key，无法签发演示凭证")
+	}
+
+	// 启动时自动执行迁移，调用方通过演示凭证鉴权
+	viper.Set("database.auto-migrate", true)
+	viper.Set("auth.enabled", true)
+	viper.Set("gateway.enabled", true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	db := ioc.InitDB()
+	clk := ioc.InitClock()
+	templateDAO := dao.NewChannelTemplateDAO(db, clk)
+	for _, t := range demoTemplates {
+		schema, _ := json.Marshal(t.params)
+		created, err := templateDAO.CreateWithActiveVersion(ctx, dao.ChannelTemplate{
+			OwnerID:     *bizID,
+			Name:        t.name,
+			Description: "devserver 创建的演示模板",
+			Channel:     t.channel.String(),
+		}, dao.ChannelTemplateVersion{
+			Name:        "v1",
+			Signature:   "通知平台",
+			Content:     t.content,
+			Remark:      "devserver",
+			ParamSchema: string(schema),
+			AuditStatus: domain.AuditStatusApproved.String(),
+		})
+		if err != nil {
+			return false, fmt.Errorf("创建演示模板 %s 失败: %w", t.name, err)
+		}
+		log.Printf("[Devserver] Template %s: channel=%s templateId=%d", t.name, t.channel, created.ID)
+	}
+
+	quotaRepo := repository.NewQuotaRepository(ioc.InitQuotaCache(ioc.InitRedis(), dao.NewQuotaDAO(db, clk), clk), dao.NewQuotaDAO(db, clk))
+	quotas := make([]domain.Quota, 0, len(demoTemplates))
+	for _, t := range demoTemplates {
+		quotas = append(quotas, domain.Quota{BizID: *bizID, Channel: t.channel, Quota: int32(*quota)})
+	}
+	if err := quotaRepo.CreateOrUpdate(ctx, quotas...); err != nil {
+		return false, fmt.Errorf("初始化演示额度失败: %w", err)
+	}
+
+	roleRepo := repository.NewRoleAssignmentRepository(dao.NewRoleAssignmentDAO(db))
+	err := roleRepo.Assign(ctx, domain.RoleAssignment{
+		Principal: *principal,
+		BizID:     *bizID,
+		Role:      domain.RoleBizAdmin,
+		Operator:  "devserver",
+	})
+	if err != nil {
+		return false, fmt.Errorf("分配演示角色失败: %w", err)
+	}
+
+	production, err := signDevToken(jwtKey, *principal, *bizID, domain.EnvironmentProduction)
+	if err != nil {
+		return false, err
+	}
+	sandbox, err := signDevToken(jwtKey, *principal, *bizID, domain.EnvironmentSandbox)
+	if err != nil {
+		return false, err
+	}
+	log.Printf("[Devserver] Biz %d seeded, principal %s has role %s, quota %d per channel",
+		*bizID, *principal, domain.RoleBizAdmin, *quota)
+	log.Printf("[Devserver] Production token: %s", production)
+	// 沙箱凭证发送的通知只交给模拟供应商，不扣减额度
+	log.Printf("[Devserver] Sandbox token (mock provider, no quota): %s", sandbox)
+	log.Printf(`[Devserver] Try: curl -X POST http://localhost%s/v1/notifications:sendAsync -H 'Authorization: Bearer <token>' `+
+		`-d '{"notification": {"key": "demo-1", "receivers": ["13800138000"], "channel": "SMS", "templateId": "<SMS templateId>", "templateParams": {"code": "123456"}}}'`,
+		viper.GetString("gateway.addr"))
+	return !*seedOnly, nil
+}
+
+// signDevToken 签发演示凭证，和鉴权拦截器使用同一个密钥
+func signDevToken(key, subject string, bizID int64, env domain.Environment) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		BizID: bizID,
+		Env:   env.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(devTokenTTL)),
+		},
+	})
+	signed, err := token.SignedString([]byte(key))
+	if err != nil {
+		return "", fmt.Errorf("签发演示凭证失败: %w", err)
+	}
+	return signed, nil
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/registry"
 	"github.com/serendipityConfusion/notification-platform/internal/repository"
-	"github.com/serendipityConfusion/notification-platform/internal/repository/cache/redis"
 	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
 	"github.com/serendipityConfusion/notification-platform/internal/service"
 )
@@ -19,10 +18,17 @@ var (
 		ioc.InitDB,
 		ioc.InitRedis,
 		ioc.InitIDGenerator,
+		ioc.InitMachineIDAllocator,
 		ioc.InitDistributedLock,
 		ioc.InitEtcdClient,
 		ioc.InitJeagerTracer,
+		ioc.InitLogLevels,
 		ioc.InitLogger,
+		ioc.InitFieldCipher,
+		ioc.InitBlindIndexer,
+		ioc.InitClock,
+		ioc.InitFeatureFlags,
+		ioc.InitFeatureFlagWatcher,
 	)
 
 	// RegistrySet 服务注册相关依赖
@@ -36,9 +42,177 @@ var (
 
 	notificationSvcSet = wire.NewSet(
 		service.NewNotificationService,
-		repository.NewNotificationRepository,
-		dao.NewNotificationDAO,
-		redis.NewQuotaCache,
+		ioc.InitNotificationRepository,
+		ioc.InitNotificationDAO,
+		ioc.InitQuotaCache,
+		repository.NewQuotaRepository,
+		repository.NewQuotaReservationRepository,
+		dao.NewQuotaDAO,
+		service.NewQuotaPrecheckService,
+	)
+
+	dataRetentionSvcSet = wire.NewSet(
+		ioc.InitDataRetentionService,
+		ioc.InitNotificationExportService,
+		repository.NewDataRetentionRepository,
+		dao.NewDataRetentionDAO,
+	)
+
+	rbacSvcSet = wire.NewSet(
+		ioc.InitRBACService,
+		repository.NewRoleAssignmentRepository,
+		dao.NewRoleAssignmentDAO,
+	)
+
+	callbackSecretSvcSet = wire.NewSet(
+		service.NewCallbackSecretService,
+		repository.NewCallbackSecretRepository,
+		dao.NewCallbackSecretDAO,
+		ioc.InitCallbackHealthTracker,
+		ioc.InitProviderHealthTracker,
+		ioc.InitVendorHTTPClients,
+	)
+
+	templateSvcSet = wire.NewSet(
+		service.NewChannelTemplateService,
+		ioc.InitTemplateReviewService,
+		ioc.InitTemplateAuditHandler,
+		repository.NewChannelTemplateRepository,
+		dao.NewChannelTemplateDAO,
+		service.NewTemplateUsageService,
+		repository.NewTemplateUsageRepository,
+		dao.NewTemplateUsageDAO,
+	)
+
+	statisticsSvcSet = wire.NewSet(
+		service.NewStatisticsService,
+		repository.NewStatisticsRepository,
+		dao.NewStatisticsDAO,
+		ioc.InitSLOService,
+		repository.NewSLORepository,
+		dao.NewSLODAO,
+	)
+
+	exportSet = wire.NewSet(
+		repository.NewExportRepository,
+		dao.NewExportDAO,
+	)
+
+	readReceiptSvcSet = wire.NewSet(
+		ioc.InitReadReceiptService,
+		repository.NewReadReceiptRepository,
+		dao.NewReadReceiptDAO,
+		ioc.InitCallbackClient,
+	)
+
+	pushSet = wire.NewSet(
+		ioc.InitInAppBus,
+		ioc.InitPushTokenSigner,
+		ioc.InitPushHandler,
+		ioc.InitInboxHandler,
+		service.NewInboxService,
+		repository.NewInboxRepository,
+		dao.NewInboxDAO,
+	)
+
+	escalationSvcSet = wire.NewSet(
+		service.NewEscalationService,
+		repository.NewEscalationRepository,
+		dao.NewEscalationDAO,
+	)
+
+	digestSvcSet = wire.NewSet(
+		ioc.InitDigestService,
+		repository.NewDigestRepository,
+		dao.NewDigestDAO,
+	)
+
+	localTimeSvcSet = wire.NewSet(
+		ioc.InitLocalTimeService,
+		repository.NewLocalTimeRepository,
+		dao.NewLocalTimeDAO,
+	)
+
+	pacingSvcSet = wire.NewSet(
+		service.NewPacingService,
+		repository.NewPacingRepository,
+		dao.NewPacingDAO,
+	)
+
+	vendorBalanceSvcSet = wire.NewSet(
+		ioc.InitVendorBalanceService,
+		repository.NewVendorBalanceRepository,
+		dao.NewVendorBalanceDAO,
+	)
+
+	// sendAttemptSet 发送尝试，运维接口按通知、供应商、消息ID查询
+	sendAttemptSet = wire.NewSet(
+		repository.NewSendAttemptRepository,
+		dao.NewSendAttemptDAO,
+	)
+
+	// blacklistSet 接收者黑名单，运维接口查询和删除
+	blacklistSet = wire.NewSet(
+		repository.NewBlacklistRepository,
+		dao.NewBlacklistDAO,
+	)
+
+	// bizChannelSet 业务方渠道开关，发送时校验，运维接口修改
+	bizChannelSet = wire.NewSet(
+		service.NewBizChannelService,
+		repository.NewBizChannelRepository,
+		dao.NewBizChannelDAO,
+		ioc.InitBizChannelCache,
+	)
+
+	// bizKeyPolicySet 业务方通知 key 规则，发送时校验，运维接口修改，业务方可以让平台生成 key
+	bizKeyPolicySet = wire.NewSet(
+		service.NewBizKeyPolicyService,
+		repository.NewBizKeyPolicyRepository,
+		dao.NewBizKeyPolicyDAO,
+		ioc.InitBizKeyPolicyCache,
+	)
+
+	// callbackDeadLetterSet 发送结果回调死信，运维接口查询和重新回调，定时汇总告警
+	callbackDeadLetterSet = wire.NewSet(
+		ioc.InitCallbackDeadLetterService,
+	)
+
+	// watchSet 运维观察通知，通知仓储发布变化，运维接口订阅
+	watchSet = wire.NewSet(
+		ioc.InitWatchBus,
+		ioc.InitNotificationWatchService,
+	)
+
+	// unsubscribeSet 退订链接和短信退订关键字
+	unsubscribeSet = wire.NewSet(
+		ioc.InitUnsubscribeTokenSigner,
+		ioc.InitUnsubscribeService,
+		ioc.InitUnsubscribeHandler,
+		ioc.InitSMSReplyHandler,
+		repository.NewOptOutRepository,
+		dao.NewOptOutDAO,
+	)
+
+	// schedulerSet 分区调度：扫描到期的通知，按渠道和供应商分配到协程池，按供应商路由调用供应商发送
+	schedulerSet = wire.NewSet(
+		ioc.InitScheduler,
+		ioc.InitSchedulerMembership,
+		ioc.InitPooledDispatcher,
+		wire.Bind(new(service.Dispatcher), new(*service.PooledDispatcher)),
+		service.NewNotificationSender,
+		ioc.InitProviderSelector,
+		ioc.InitProviders,
+		ioc.InitProviderBreaker,
+		ioc.InitAnomalyDetector,
+		ioc.InitShadowReporter,
+	)
+
+	// graphqlSet 只读 GraphQL 接口额外需要的仓储
+	graphqlSet = wire.NewSet(
+		ioc.InitGraphQL,
+		repository.NewCallbackLogRepository,
+		dao.NewCallbackLogDAO,
 	)
 )
 
@@ -47,9 +221,169 @@ func InitGrpcServer() *ioc.App {
 		BaseSet,
 		RegistrySet,
 		notificationSvcSet,
+		templateSvcSet,
+		dataRetentionSvcSet,
+		rbacSvcSet,
+		graphqlSet,
+		statisticsSvcSet,
+		exportSet,
+		readReceiptSvcSet,
+		pushSet,
+		escalationSvcSet,
+		digestSvcSet,
+		localTimeSvcSet,
+		pacingSvcSet,
+		callbackSecretSvcSet,
+		vendorBalanceSvcSet,
+		sendAttemptSet,
+		blacklistSet,
+		bizChannelSet,
+		bizKeyPolicySet,
+		callbackDeadLetterSet,
+		watchSet,
+		unsubscribeSet,
+		schedulerSet,
 		grpcapi.NewServer,
+		ioc.InitFallbackService,
+		ioc.InitLabelMetrics,
+		ioc.InitDryRunService,
+		grpcapi.NewTemplateServer,
+		grpcapi.NewDataPrivacyServer,
+		grpcapi.NewRoleServer,
+		grpcapi.NewBizConfigServer,
+		grpcapi.NewStatisticsServer,
+		grpcapi.NewReadReceiptServer,
+		grpcapi.NewPushServer,
+		grpcapi.NewEscalationServer,
+		grpcapi.NewAdminServer,
+		grpcapi.NewUnsubscribeServer,
+		grpcapi.NewDebugServer,
 		ioc.InitGrpc,
+		ioc.InitTasks,
+		ioc.InitGateway,
+		ioc.InitDebugServer,
 		wire.Struct(new(ioc.App), "*"),
 	)
 	return &ioc.App{}
 }
+
+// InitAPIServer 接口角色：发送、管理和查询接口，后台任务只有每个实例都要运行的任务
+func InitAPIServer() *ioc.App {
+	wire.Build(
+		BaseSet,
+		RegistrySet,
+		notificationSvcSet,
+		templateSvcSet,
+		dataRetentionSvcSet,
+		rbacSvcSet,
+		graphqlSet,
+		statisticsSvcSet,
+		readReceiptSvcSet,
+		pushSet,
+		escalationSvcSet,
+		digestSvcSet,
+		localTimeSvcSet,
+		pacingSvcSet,
+		callbackSecretSvcSet,
+		sendAttemptSet,
+		blacklistSet,
+		bizChannelSet,
+		bizKeyPolicySet,
+		callbackDeadLetterSet,
+		watchSet,
+		unsubscribeSet,
+		grpcapi.NewServer,
+		ioc.InitFallbackService,
+		ioc.InitLabelMetrics,
+		ioc.InitDryRunService,
+		grpcapi.NewTemplateServer,
+		grpcapi.NewDataPrivacyServer,
+		grpcapi.NewRoleServer,
+		grpcapi.NewBizConfigServer,
+		grpcapi.NewStatisticsServer,
+		grpcapi.NewReadReceiptServer,
+		grpcapi.NewPushServer,
+		grpcapi.NewEscalationServer,
+		grpcapi.NewAdminServer,
+		grpcapi.NewUnsubscribeServer,
+		grpcapi.NewDebugServer,
+		ioc.InitGrpc,
+		ioc.InitAPITasks,
+		ioc.InitGateway,
+		ioc.InitDebugServer,
+		wire.Struct(new(ioc.App), "*"),
+	)
+	return &ioc.App{}
+}
+
+// InitQueryServer 只读查询角色：只有通知查询、统计接口和 GraphQL，不生成ID，注册在单独的服务名下
+func InitQueryServer() *ioc.App {
+	wire.Build(
+		BaseSet,
+		RegistrySet,
+		notificationSvcSet,
+		rbacSvcSet,
+		graphqlSet,
+		statisticsSvcSet,
+		watchSet,
+		ioc.InitFallbackService,
+		grpcapi.NewQueryServer,
+		grpcapi.NewStatisticsServer,
+		grpcapi.NewDebugServer,
+		ioc.InitQueryGrpc,
+		ioc.InitQueryTasks,
+		ioc.InitQueryGateway,
+		ioc.InitDebugServer,
+		wire.Struct(new(ioc.App), "GrpcServer", "Registry", "ConfigLoader", "ServiceInfo", "Tasks", "Gateway",
+			"DebugServer", "TracerProvider"),
+	)
+	return &ioc.App{}
+}
+
+// InitSchedulerWorker 定时任务角色：分区调度发送和定时任务，不提供 gRPC 服务，也不注册到注册中心
+func InitSchedulerWorker() *ioc.App {
+	wire.Build(
+		BaseSet,
+		notificationSvcSet,
+		templateSvcSet,
+		dataRetentionSvcSet,
+		statisticsSvcSet,
+		exportSet,
+		pushSet,
+		escalationSvcSet,
+		digestSvcSet,
+		localTimeSvcSet,
+		callbackSecretSvcSet,
+		vendorBalanceSvcSet,
+		pacingSvcSet,
+		sendAttemptSet,
+		blacklistSet,
+		watchSet,
+		schedulerSet,
+		ioc.InitFallbackService,
+		repository.NewOptOutRepository,
+		dao.NewOptOutDAO,
+		ioc.InitSchedulerTasks,
+		ioc.InitDebugServer,
+		wire.Struct(new(ioc.App), "MachineIDAllocator", "Tasks", "DebugServer", "TracerProvider"),
+	)
+	return &ioc.App{}
+}
+
+// InitCallbackWorker 回调角色：发送结果回调、已读回执回调和死信汇总，不提供 gRPC 服务
+func InitCallbackWorker() *ioc.App {
+	wire.Build(
+		BaseSet,
+		notificationSvcSet,
+		templateSvcSet,
+		graphqlSet,
+		readReceiptSvcSet,
+		callbackSecretSvcSet,
+		callbackDeadLetterSet,
+		watchSet,
+		ioc.InitCallbackWorkerTasks,
+		ioc.InitDebugServer,
+		wire.Struct(new(ioc.App), "MachineIDAllocator", "Tasks", "DebugServer", "TracerProvider"),
+	)
+	return &ioc.App{}
+}
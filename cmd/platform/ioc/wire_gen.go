@@ -13,7 +13,6 @@ import (
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/registry"
 	"github.com/serendipityConfusion/notification-platform/internal/repository"
-	"github.com/serendipityConfusion/notification-platform/internal/repository/cache/redis"
 	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
 	"github.com/serendipityConfusion/notification-platform/internal/service"
 )
@@ -22,22 +21,458 @@ import (
 
 func InitGrpcServer() *ioc.App {
 	db := ioc.InitDB()
-	notificationDAO := dao.NewNotificationDAO(db)
+	clock := ioc.InitClock()
+	notificationDAO := ioc.InitNotificationDAO(db, clock)
 	client := ioc.InitRedis()
-	quotaCache := redis.NewQuotaCache(client)
-	notificationRepository := repository.NewNotificationRepository(notificationDAO, quotaCache)
-	loggerInterface := ioc.InitLogger()
-	notificationServer := grpc.NewServer(notificationRepository, loggerInterface)
-	server := ioc.InitGrpc(notificationServer)
+	quotaDAO := dao.NewQuotaDAO(db, clock)
+	quotaCache := ioc.InitQuotaCache(client, quotaDAO, clock)
+	cipher := ioc.InitFieldCipher()
+	blindIndexer := ioc.InitBlindIndexer()
+	flags := ioc.InitFeatureFlags()
+	watchBus := ioc.InitWatchBus(client)
+	notificationRepository := ioc.InitNotificationRepository(notificationDAO, quotaCache, cipher, blindIndexer, client, flags, watchBus, clock)
+	channelTemplateDAO := dao.NewChannelTemplateDAO(db, clock)
+	channelTemplateRepository := repository.NewChannelTemplateRepository(channelTemplateDAO)
+	channelTemplateService := service.NewChannelTemplateService(channelTemplateRepository)
+	digestDAO := dao.NewDigestDAO(db, clock)
+	digestRepository := repository.NewDigestRepository(digestDAO, cipher, blindIndexer)
 	clientv3Client := ioc.InitEtcdClient()
+	allocator := ioc.InitMachineIDAllocator(clientv3Client, client)
+	generator := ioc.InitIDGenerator(allocator, client, clock)
+	digestService := ioc.InitDigestService(digestRepository, notificationRepository, channelTemplateService, generator, clock)
+	localTimeDAO := dao.NewLocalTimeDAO(db, clock)
+	localTimeRepository := repository.NewLocalTimeRepository(localTimeDAO, cipher)
+	localTimeService := ioc.InitLocalTimeService(localTimeRepository, notificationRepository, channelTemplateService, generator, clock)
+	pacingDAO := dao.NewPacingDAO(db)
+	pacingRepository := repository.NewPacingRepository(pacingDAO, clock)
+	pacingService := service.NewPacingService(pacingRepository, notificationRepository)
+	fallbackService := ioc.InitFallbackService(notificationRepository)
+	quotaRepository := repository.NewQuotaRepository(quotaCache, quotaDAO)
+	dryRunService := ioc.InitDryRunService(notificationRepository, channelTemplateService, quotaRepository, clock)
+	quotaPrecheckService := service.NewQuotaPrecheckService(quotaRepository)
+	bizChannelCache := ioc.InitBizChannelCache(client)
+	bizChannelDAO := dao.NewBizChannelDAO(db)
+	bizChannelRepository := repository.NewBizChannelRepository(bizChannelCache, bizChannelDAO)
+	bizChannelService := service.NewBizChannelService(bizChannelRepository)
+	bizKeyPolicyCache := ioc.InitBizKeyPolicyCache(client)
+	bizKeyPolicyDAO := dao.NewBizKeyPolicyDAO(db)
+	bizKeyPolicyRepository := repository.NewBizKeyPolicyRepository(bizKeyPolicyCache, bizKeyPolicyDAO)
+	bizKeyPolicyService := service.NewBizKeyPolicyService(bizKeyPolicyRepository, generator)
+	labelMetrics := ioc.InitLabelMetrics()
+	levels := ioc.InitLogLevels()
+	loggerInterface := ioc.InitLogger(levels)
+	notificationServer := grpc.NewServer(notificationRepository, channelTemplateService, digestService, localTimeService, pacingService, fallbackService, generator, dryRunService, quotaPrecheckService, bizChannelService, bizKeyPolicyService, labelMetrics, clock, loggerInterface)
+	factory := ioc.InitVendorHTTPClients()
+	templateReviewService := ioc.InitTemplateReviewService(channelTemplateRepository, notificationRepository, channelTemplateService, generator, clock, factory)
+	templateUsageDAO := dao.NewTemplateUsageDAO(db)
+	templateUsageRepository := repository.NewTemplateUsageRepository(templateUsageDAO)
+	templateUsageService := service.NewTemplateUsageService(templateUsageRepository)
+	templateServer := grpc.NewTemplateServer(channelTemplateService, templateReviewService, templateUsageService, loggerInterface)
+	dataRetentionDAO := dao.NewDataRetentionDAO(db, clock)
+	dataRetentionRepository := repository.NewDataRetentionRepository(dataRetentionDAO)
+	dataRetentionService := ioc.InitDataRetentionService(notificationRepository, dataRetentionRepository, blindIndexer, clock)
+	notificationExportService := ioc.InitNotificationExportService(notificationRepository)
+	dataPrivacyServer := grpc.NewDataPrivacyServer(dataRetentionService, notificationExportService, loggerInterface)
+	roleAssignmentDAO := dao.NewRoleAssignmentDAO(db)
+	roleAssignmentRepository := repository.NewRoleAssignmentRepository(roleAssignmentDAO)
+	rbacService := ioc.InitRBACService(roleAssignmentRepository)
+	roleServer := grpc.NewRoleServer(rbacService, loggerInterface)
+	callbackSecretDAO := dao.NewCallbackSecretDAO(db)
+	callbackSecretRepository := repository.NewCallbackSecretRepository(callbackSecretDAO, cipher)
+	callbackSecretService := service.NewCallbackSecretService(callbackSecretRepository)
+	healthTracker := ioc.InitCallbackHealthTracker()
+	bizConfigServer := grpc.NewBizConfigServer(callbackSecretService, healthTracker, bizChannelService, bizKeyPolicyService, loggerInterface)
+	statisticsDAO := dao.NewStatisticsDAO(db)
+	statisticsRepository := repository.NewStatisticsRepository(statisticsDAO)
+	statisticsService := service.NewStatisticsService(statisticsRepository)
+	statisticsServer := grpc.NewStatisticsServer(statisticsService, loggerInterface)
+	readReceiptDAO := dao.NewReadReceiptDAO(db)
+	readReceiptRepository := repository.NewReadReceiptRepository(readReceiptDAO, cipher, blindIndexer)
+	readReceiptService := ioc.InitReadReceiptService(readReceiptRepository, notificationRepository)
+	readReceiptServer := grpc.NewReadReceiptServer(readReceiptService, loggerInterface)
+	tokenSigner := ioc.InitPushTokenSigner()
+	pushServer := grpc.NewPushServer(tokenSigner, loggerInterface)
+	escalationDAO := dao.NewEscalationDAO(db, clock)
+	escalationRepository := repository.NewEscalationRepository(escalationDAO, cipher, clock)
+	escalationService := service.NewEscalationService(escalationRepository, notificationRepository, channelTemplateService, generator, clock)
+	escalationServer := grpc.NewEscalationServer(escalationService, loggerInterface)
+	sendAttemptDAO := dao.NewSendAttemptDAO(db)
+	sendAttemptRepository := repository.NewSendAttemptRepository(sendAttemptDAO)
+	blacklistDAO := dao.NewBlacklistDAO(db)
+	blacklistRepository := repository.NewBlacklistRepository(blacklistDAO, cipher, blindIndexer)
+	providerHealthTracker := ioc.InitProviderHealthTracker()
+	callbackLogDAO := dao.NewCallbackLogDAO(db, clock)
+	callbackLogRepository := repository.NewCallbackLogRepository(callbackLogDAO, clock)
+	callbackDeadLetterService := ioc.InitCallbackDeadLetterService(callbackLogRepository, notificationRepository, channelTemplateService, generator, clock)
+	notificationWatchService := ioc.InitNotificationWatchService(notificationRepository, watchBus)
+	adminServer := grpc.NewAdminServer(levels, sendAttemptRepository, blacklistRepository, providerHealthTracker, callbackDeadLetterService, notificationWatchService, loggerInterface)
+	unsubscribeTokenSigner := ioc.InitUnsubscribeTokenSigner()
+	unsubscribeServer := grpc.NewUnsubscribeServer(unsubscribeTokenSigner, loggerInterface)
+	debugServer := grpc.NewDebugServer()
+	server := ioc.InitGrpc(notificationServer, templateServer, dataPrivacyServer, roleServer, bizConfigServer, statisticsServer, readReceiptServer, pushServer, escalationServer, adminServer, unsubscribeServer, debugServer, rbacService, flags)
 	etcdRegistry := ioc.InitRegistry(clientv3Client)
 	viperConfigLoader := ioc.InitConfigLoader()
 	serviceInfo := ioc.InitServiceInfo()
+	slodao := dao.NewSLODAO(db)
+	sloRepository := repository.NewSLORepository(slodao)
+	sloService := ioc.InitSLOService(sloRepository)
+	exportDAO := dao.NewExportDAO(db)
+	exportRepository := repository.NewExportRepository(exportDAO)
+	inboxDAO := dao.NewInboxDAO(db)
+	inboxRepository := repository.NewInboxRepository(inboxDAO, blindIndexer)
+	callbackClient := ioc.InitCallbackClient(callbackSecretService, healthTracker)
+	inAppBus := ioc.InitInAppBus(client)
+	handler := ioc.InitPushHandler(inAppBus, tokenSigner, notificationRepository)
+	vendorBalanceDAO := dao.NewVendorBalanceDAO(db)
+	vendorBalanceRepository := repository.NewVendorBalanceRepository(vendorBalanceDAO)
+	vendorBalanceService := ioc.InitVendorBalanceService(vendorBalanceRepository, factory)
+	quotaReservationRepository := repository.NewQuotaReservationRepository(quotaCache, notificationDAO)
+	serviceService := service.NewNotificationService(notificationRepository)
+	membership := ioc.InitSchedulerMembership(clientv3Client)
+	breaker := ioc.InitProviderBreaker()
+	detector := ioc.InitAnomalyDetector(breaker, loggerInterface)
+	v := ioc.InitProviders(inAppBus, factory, detector, breaker)
+	shadowReporter := ioc.InitShadowReporter()
+	optOutDAO := dao.NewOptOutDAO(db)
+	optOutRepository := repository.NewOptOutRepository(optOutDAO, cipher, blindIndexer)
+	selector := ioc.InitProviderSelector(v, breaker, shadowReporter, providerHealthTracker, sendAttemptRepository, blacklistRepository, optOutRepository, flags, clock, loggerInterface)
+	notificationSender := service.NewNotificationSender(notificationRepository, channelTemplateService, selector)
+	pooledDispatcher := ioc.InitPooledDispatcher(notificationRepository, notificationSender, selector, clock)
+	scheduler := ioc.InitScheduler(serviceService, membership, pooledDispatcher, fallbackService, pacingService, clock)
+	distribute_lockClient := ioc.InitDistributedLock(client)
+	etcdWatcher := ioc.InitFeatureFlagWatcher(clientv3Client, flags)
+	v2 := ioc.InitTasks(dataRetentionService, statisticsService, templateUsageService, sloService, notificationRepository, exportRepository, inboxRepository, readReceiptRepository, callbackLogRepository, callbackClient, callbackDeadLetterService, handler, escalationService, digestService, localTimeService, templateReviewService, vendorBalanceService, quotaRepository, quotaReservationRepository, scheduler, distribute_lockClient, clock, etcdWatcher, watchBus)
+	graphqlHandler := ioc.InitGraphQL(notificationRepository, callbackLogRepository, quotaRepository, rbacService)
+	inboxService := service.NewInboxService(inboxRepository, notificationRepository)
+	inboxHandler := ioc.InitInboxHandler(tokenSigner, inboxService)
+	auditHandler := ioc.InitTemplateAuditHandler(templateReviewService, factory)
+	unsubscribeService := ioc.InitUnsubscribeService(optOutRepository, factory, loggerInterface)
+	unsubscribeHandler := ioc.InitUnsubscribeHandler(unsubscribeTokenSigner, unsubscribeService)
+	smsReplyHandler := ioc.InitSMSReplyHandler(unsubscribeService, factory)
+	gatewayServer := ioc.InitGateway(graphqlHandler, handler, inboxHandler, auditHandler, unsubscribeHandler, smsReplyHandler)
+	server2 := ioc.InitDebugServer()
+	tracerProvider := ioc.InitJeagerTracer()
 	app := &ioc.App{
-		GrpcServer:   server,
-		Registry:     etcdRegistry,
-		ConfigLoader: viperConfigLoader,
-		ServiceInfo:  serviceInfo,
+		GrpcServer:         server,
+		Registry:           etcdRegistry,
+		ConfigLoader:       viperConfigLoader,
+		ServiceInfo:        serviceInfo,
+		MachineIDAllocator: allocator,
+		Tasks:              v2,
+		Gateway:            gatewayServer,
+		DebugServer:        server2,
+		TracerProvider:     tracerProvider,
+	}
+	return app
+}
+
+// InitAPIServer 接口角色：发送、管理和查询接口，后台任务只有每个实例都要运行的任务
+func InitAPIServer() *ioc.App {
+	db := ioc.InitDB()
+	clock := ioc.InitClock()
+	notificationDAO := ioc.InitNotificationDAO(db, clock)
+	client := ioc.InitRedis()
+	quotaDAO := dao.NewQuotaDAO(db, clock)
+	quotaCache := ioc.InitQuotaCache(client, quotaDAO, clock)
+	cipher := ioc.InitFieldCipher()
+	blindIndexer := ioc.InitBlindIndexer()
+	flags := ioc.InitFeatureFlags()
+	watchBus := ioc.InitWatchBus(client)
+	notificationRepository := ioc.InitNotificationRepository(notificationDAO, quotaCache, cipher, blindIndexer, client, flags, watchBus, clock)
+	channelTemplateDAO := dao.NewChannelTemplateDAO(db, clock)
+	channelTemplateRepository := repository.NewChannelTemplateRepository(channelTemplateDAO)
+	channelTemplateService := service.NewChannelTemplateService(channelTemplateRepository)
+	digestDAO := dao.NewDigestDAO(db, clock)
+	digestRepository := repository.NewDigestRepository(digestDAO, cipher, blindIndexer)
+	clientv3Client := ioc.InitEtcdClient()
+	allocator := ioc.InitMachineIDAllocator(clientv3Client, client)
+	generator := ioc.InitIDGenerator(allocator, client, clock)
+	digestService := ioc.InitDigestService(digestRepository, notificationRepository, channelTemplateService, generator, clock)
+	localTimeDAO := dao.NewLocalTimeDAO(db, clock)
+	localTimeRepository := repository.NewLocalTimeRepository(localTimeDAO, cipher)
+	localTimeService := ioc.InitLocalTimeService(localTimeRepository, notificationRepository, channelTemplateService, generator, clock)
+	pacingDAO := dao.NewPacingDAO(db)
+	pacingRepository := repository.NewPacingRepository(pacingDAO, clock)
+	pacingService := service.NewPacingService(pacingRepository, notificationRepository)
+	fallbackService := ioc.InitFallbackService(notificationRepository)
+	quotaRepository := repository.NewQuotaRepository(quotaCache, quotaDAO)
+	dryRunService := ioc.InitDryRunService(notificationRepository, channelTemplateService, quotaRepository, clock)
+	quotaPrecheckService := service.NewQuotaPrecheckService(quotaRepository)
+	bizChannelCache := ioc.InitBizChannelCache(client)
+	bizChannelDAO := dao.NewBizChannelDAO(db)
+	bizChannelRepository := repository.NewBizChannelRepository(bizChannelCache, bizChannelDAO)
+	bizChannelService := service.NewBizChannelService(bizChannelRepository)
+	bizKeyPolicyCache := ioc.InitBizKeyPolicyCache(client)
+	bizKeyPolicyDAO := dao.NewBizKeyPolicyDAO(db)
+	bizKeyPolicyRepository := repository.NewBizKeyPolicyRepository(bizKeyPolicyCache, bizKeyPolicyDAO)
+	bizKeyPolicyService := service.NewBizKeyPolicyService(bizKeyPolicyRepository, generator)
+	labelMetrics := ioc.InitLabelMetrics()
+	levels := ioc.InitLogLevels()
+	loggerInterface := ioc.InitLogger(levels)
+	notificationServer := grpc.NewServer(notificationRepository, channelTemplateService, digestService, localTimeService, pacingService, fallbackService, generator, dryRunService, quotaPrecheckService, bizChannelService, bizKeyPolicyService, labelMetrics, clock, loggerInterface)
+	factory := ioc.InitVendorHTTPClients()
+	templateReviewService := ioc.InitTemplateReviewService(channelTemplateRepository, notificationRepository, channelTemplateService, generator, clock, factory)
+	templateUsageDAO := dao.NewTemplateUsageDAO(db)
+	templateUsageRepository := repository.NewTemplateUsageRepository(templateUsageDAO)
+	templateUsageService := service.NewTemplateUsageService(templateUsageRepository)
+	templateServer := grpc.NewTemplateServer(channelTemplateService, templateReviewService, templateUsageService, loggerInterface)
+	dataRetentionDAO := dao.NewDataRetentionDAO(db, clock)
+	dataRetentionRepository := repository.NewDataRetentionRepository(dataRetentionDAO)
+	dataRetentionService := ioc.InitDataRetentionService(notificationRepository, dataRetentionRepository, blindIndexer, clock)
+	notificationExportService := ioc.InitNotificationExportService(notificationRepository)
+	dataPrivacyServer := grpc.NewDataPrivacyServer(dataRetentionService, notificationExportService, loggerInterface)
+	roleAssignmentDAO := dao.NewRoleAssignmentDAO(db)
+	roleAssignmentRepository := repository.NewRoleAssignmentRepository(roleAssignmentDAO)
+	rbacService := ioc.InitRBACService(roleAssignmentRepository)
+	roleServer := grpc.NewRoleServer(rbacService, loggerInterface)
+	callbackSecretDAO := dao.NewCallbackSecretDAO(db)
+	callbackSecretRepository := repository.NewCallbackSecretRepository(callbackSecretDAO, cipher)
+	callbackSecretService := service.NewCallbackSecretService(callbackSecretRepository)
+	healthTracker := ioc.InitCallbackHealthTracker()
+	bizConfigServer := grpc.NewBizConfigServer(callbackSecretService, healthTracker, bizChannelService, bizKeyPolicyService, loggerInterface)
+	statisticsDAO := dao.NewStatisticsDAO(db)
+	statisticsRepository := repository.NewStatisticsRepository(statisticsDAO)
+	statisticsService := service.NewStatisticsService(statisticsRepository)
+	statisticsServer := grpc.NewStatisticsServer(statisticsService, loggerInterface)
+	readReceiptDAO := dao.NewReadReceiptDAO(db)
+	readReceiptRepository := repository.NewReadReceiptRepository(readReceiptDAO, cipher, blindIndexer)
+	readReceiptService := ioc.InitReadReceiptService(readReceiptRepository, notificationRepository)
+	readReceiptServer := grpc.NewReadReceiptServer(readReceiptService, loggerInterface)
+	tokenSigner := ioc.InitPushTokenSigner()
+	pushServer := grpc.NewPushServer(tokenSigner, loggerInterface)
+	escalationDAO := dao.NewEscalationDAO(db, clock)
+	escalationRepository := repository.NewEscalationRepository(escalationDAO, cipher, clock)
+	escalationService := service.NewEscalationService(escalationRepository, notificationRepository, channelTemplateService, generator, clock)
+	escalationServer := grpc.NewEscalationServer(escalationService, loggerInterface)
+	sendAttemptDAO := dao.NewSendAttemptDAO(db)
+	sendAttemptRepository := repository.NewSendAttemptRepository(sendAttemptDAO)
+	blacklistDAO := dao.NewBlacklistDAO(db)
+	blacklistRepository := repository.NewBlacklistRepository(blacklistDAO, cipher, blindIndexer)
+	providerHealthTracker := ioc.InitProviderHealthTracker()
+	callbackLogDAO := dao.NewCallbackLogDAO(db, clock)
+	callbackLogRepository := repository.NewCallbackLogRepository(callbackLogDAO, clock)
+	callbackDeadLetterService := ioc.InitCallbackDeadLetterService(callbackLogRepository, notificationRepository, channelTemplateService, generator, clock)
+	notificationWatchService := ioc.InitNotificationWatchService(notificationRepository, watchBus)
+	adminServer := grpc.NewAdminServer(levels, sendAttemptRepository, blacklistRepository, providerHealthTracker, callbackDeadLetterService, notificationWatchService, loggerInterface)
+	unsubscribeTokenSigner := ioc.InitUnsubscribeTokenSigner()
+	unsubscribeServer := grpc.NewUnsubscribeServer(unsubscribeTokenSigner, loggerInterface)
+	debugServer := grpc.NewDebugServer()
+	server := ioc.InitGrpc(notificationServer, templateServer, dataPrivacyServer, roleServer, bizConfigServer, statisticsServer, readReceiptServer, pushServer, escalationServer, adminServer, unsubscribeServer, debugServer, rbacService, flags)
+	etcdRegistry := ioc.InitRegistry(clientv3Client)
+	viperConfigLoader := ioc.InitConfigLoader()
+	serviceInfo := ioc.InitServiceInfo()
+	etcdWatcher := ioc.InitFeatureFlagWatcher(clientv3Client, flags)
+	inAppBus := ioc.InitInAppBus(client)
+	handler := ioc.InitPushHandler(inAppBus, tokenSigner, notificationRepository)
+	v := ioc.InitAPITasks(etcdWatcher, watchBus, handler)
+	graphqlHandler := ioc.InitGraphQL(notificationRepository, callbackLogRepository, quotaRepository, rbacService)
+	inboxDAO := dao.NewInboxDAO(db)
+	inboxRepository := repository.NewInboxRepository(inboxDAO, blindIndexer)
+	inboxService := service.NewInboxService(inboxRepository, notificationRepository)
+	inboxHandler := ioc.InitInboxHandler(tokenSigner, inboxService)
+	auditHandler := ioc.InitTemplateAuditHandler(templateReviewService, factory)
+	optOutDAO := dao.NewOptOutDAO(db)
+	optOutRepository := repository.NewOptOutRepository(optOutDAO, cipher, blindIndexer)
+	unsubscribeService := ioc.InitUnsubscribeService(optOutRepository, factory, loggerInterface)
+	unsubscribeHandler := ioc.InitUnsubscribeHandler(unsubscribeTokenSigner, unsubscribeService)
+	smsReplyHandler := ioc.InitSMSReplyHandler(unsubscribeService, factory)
+	gatewayServer := ioc.InitGateway(graphqlHandler, handler, inboxHandler, auditHandler, unsubscribeHandler, smsReplyHandler)
+	server2 := ioc.InitDebugServer()
+	tracerProvider := ioc.InitJeagerTracer()
+	app := &ioc.App{
+		GrpcServer:         server,
+		Registry:           etcdRegistry,
+		ConfigLoader:       viperConfigLoader,
+		ServiceInfo:        serviceInfo,
+		MachineIDAllocator: allocator,
+		Tasks:              v,
+		Gateway:            gatewayServer,
+		DebugServer:        server2,
+		TracerProvider:     tracerProvider,
+	}
+	return app
+}
+
+// InitQueryServer 只读查询角色：只有通知查询、统计接口和 GraphQL，不生成ID，注册在单独的服务名下
+func InitQueryServer() *ioc.App {
+	db := ioc.InitDB()
+	clock := ioc.InitClock()
+	notificationDAO := ioc.InitNotificationDAO(db, clock)
+	client := ioc.InitRedis()
+	quotaDAO := dao.NewQuotaDAO(db, clock)
+	quotaCache := ioc.InitQuotaCache(client, quotaDAO, clock)
+	cipher := ioc.InitFieldCipher()
+	blindIndexer := ioc.InitBlindIndexer()
+	flags := ioc.InitFeatureFlags()
+	watchBus := ioc.InitWatchBus(client)
+	notificationRepository := ioc.InitNotificationRepository(notificationDAO, quotaCache, cipher, blindIndexer, client, flags, watchBus, clock)
+	fallbackService := ioc.InitFallbackService(notificationRepository)
+	levels := ioc.InitLogLevels()
+	loggerInterface := ioc.InitLogger(levels)
+	notificationServer := grpc.NewQueryServer(notificationRepository, fallbackService, loggerInterface)
+	statisticsDAO := dao.NewStatisticsDAO(db)
+	statisticsRepository := repository.NewStatisticsRepository(statisticsDAO)
+	statisticsService := service.NewStatisticsService(statisticsRepository)
+	statisticsServer := grpc.NewStatisticsServer(statisticsService, loggerInterface)
+	debugServer := grpc.NewDebugServer()
+	roleAssignmentDAO := dao.NewRoleAssignmentDAO(db)
+	roleAssignmentRepository := repository.NewRoleAssignmentRepository(roleAssignmentDAO)
+	rbacService := ioc.InitRBACService(roleAssignmentRepository)
+	server := ioc.InitQueryGrpc(notificationServer, statisticsServer, debugServer, rbacService, flags)
+	clientv3Client := ioc.InitEtcdClient()
+	etcdRegistry := ioc.InitRegistry(clientv3Client)
+	viperConfigLoader := ioc.InitConfigLoader()
+	serviceInfo := ioc.InitServiceInfo()
+	etcdWatcher := ioc.InitFeatureFlagWatcher(clientv3Client, flags)
+	v := ioc.InitQueryTasks(etcdWatcher)
+	callbackLogDAO := dao.NewCallbackLogDAO(db, clock)
+	callbackLogRepository := repository.NewCallbackLogRepository(callbackLogDAO, clock)
+	quotaRepository := repository.NewQuotaRepository(quotaCache, quotaDAO)
+	handler := ioc.InitGraphQL(notificationRepository, callbackLogRepository, quotaRepository, rbacService)
+	gatewayServer := ioc.InitQueryGateway(handler)
+	server2 := ioc.InitDebugServer()
+	tracerProvider := ioc.InitJeagerTracer()
+	app := &ioc.App{
+		GrpcServer:     server,
+		Registry:       etcdRegistry,
+		ConfigLoader:   viperConfigLoader,
+		ServiceInfo:    serviceInfo,
+		Tasks:          v,
+		Gateway:        gatewayServer,
+		DebugServer:    server2,
+		TracerProvider: tracerProvider,
+	}
+	return app
+}
+
+// InitSchedulerWorker 定时任务角色：分区调度发送和定时任务，不提供 gRPC 服务，也不注册到注册中心
+func InitSchedulerWorker() *ioc.App {
+	client := ioc.InitEtcdClient()
+	redisClient := ioc.InitRedis()
+	allocator := ioc.InitMachineIDAllocator(client, redisClient)
+	db := ioc.InitDB()
+	clock := ioc.InitClock()
+	notificationDAO := ioc.InitNotificationDAO(db, clock)
+	quotaDAO := dao.NewQuotaDAO(db, clock)
+	quotaCache := ioc.InitQuotaCache(redisClient, quotaDAO, clock)
+	cipher := ioc.InitFieldCipher()
+	blindIndexer := ioc.InitBlindIndexer()
+	flags := ioc.InitFeatureFlags()
+	watchBus := ioc.InitWatchBus(redisClient)
+	notificationRepository := ioc.InitNotificationRepository(notificationDAO, quotaCache, cipher, blindIndexer, redisClient, flags, watchBus, clock)
+	dataRetentionDAO := dao.NewDataRetentionDAO(db, clock)
+	dataRetentionRepository := repository.NewDataRetentionRepository(dataRetentionDAO)
+	dataRetentionService := ioc.InitDataRetentionService(notificationRepository, dataRetentionRepository, blindIndexer, clock)
+	statisticsDAO := dao.NewStatisticsDAO(db)
+	statisticsRepository := repository.NewStatisticsRepository(statisticsDAO)
+	statisticsService := service.NewStatisticsService(statisticsRepository)
+	templateUsageDAO := dao.NewTemplateUsageDAO(db)
+	templateUsageRepository := repository.NewTemplateUsageRepository(templateUsageDAO)
+	templateUsageService := service.NewTemplateUsageService(templateUsageRepository)
+	slodao := dao.NewSLODAO(db)
+	sloRepository := repository.NewSLORepository(slodao)
+	sloService := ioc.InitSLOService(sloRepository)
+	exportDAO := dao.NewExportDAO(db)
+	exportRepository := repository.NewExportRepository(exportDAO)
+	inboxDAO := dao.NewInboxDAO(db)
+	inboxRepository := repository.NewInboxRepository(inboxDAO, blindIndexer)
+	escalationDAO := dao.NewEscalationDAO(db, clock)
+	escalationRepository := repository.NewEscalationRepository(escalationDAO, cipher, clock)
+	channelTemplateDAO := dao.NewChannelTemplateDAO(db, clock)
+	channelTemplateRepository := repository.NewChannelTemplateRepository(channelTemplateDAO)
+	channelTemplateService := service.NewChannelTemplateService(channelTemplateRepository)
+	generator := ioc.InitIDGenerator(allocator, redisClient, clock)
+	escalationService := service.NewEscalationService(escalationRepository, notificationRepository, channelTemplateService, generator, clock)
+	digestDAO := dao.NewDigestDAO(db, clock)
+	digestRepository := repository.NewDigestRepository(digestDAO, cipher, blindIndexer)
+	digestService := ioc.InitDigestService(digestRepository, notificationRepository, channelTemplateService, generator, clock)
+	localTimeDAO := dao.NewLocalTimeDAO(db, clock)
+	localTimeRepository := repository.NewLocalTimeRepository(localTimeDAO, cipher)
+	localTimeService := ioc.InitLocalTimeService(localTimeRepository, notificationRepository, channelTemplateService, generator, clock)
+	factory := ioc.InitVendorHTTPClients()
+	templateReviewService := ioc.InitTemplateReviewService(channelTemplateRepository, notificationRepository, channelTemplateService, generator, clock, factory)
+	vendorBalanceDAO := dao.NewVendorBalanceDAO(db)
+	vendorBalanceRepository := repository.NewVendorBalanceRepository(vendorBalanceDAO)
+	vendorBalanceService := ioc.InitVendorBalanceService(vendorBalanceRepository, factory)
+	quotaRepository := repository.NewQuotaRepository(quotaCache, quotaDAO)
+	quotaReservationRepository := repository.NewQuotaReservationRepository(quotaCache, notificationDAO)
+	serviceService := service.NewNotificationService(notificationRepository)
+	membership := ioc.InitSchedulerMembership(client)
+	inAppBus := ioc.InitInAppBus(redisClient)
+	breaker := ioc.InitProviderBreaker()
+	levels := ioc.InitLogLevels()
+	loggerInterface := ioc.InitLogger(levels)
+	detector := ioc.InitAnomalyDetector(breaker, loggerInterface)
+	v := ioc.InitProviders(inAppBus, factory, detector, breaker)
+	shadowReporter := ioc.InitShadowReporter()
+	healthTracker := ioc.InitProviderHealthTracker()
+	sendAttemptDAO := dao.NewSendAttemptDAO(db)
+	sendAttemptRepository := repository.NewSendAttemptRepository(sendAttemptDAO)
+	blacklistDAO := dao.NewBlacklistDAO(db)
+	blacklistRepository := repository.NewBlacklistRepository(blacklistDAO, cipher, blindIndexer)
+	optOutDAO := dao.NewOptOutDAO(db)
+	optOutRepository := repository.NewOptOutRepository(optOutDAO, cipher, blindIndexer)
+	selector := ioc.InitProviderSelector(v, breaker, shadowReporter, healthTracker, sendAttemptRepository, blacklistRepository, optOutRepository, flags, clock, loggerInterface)
+	notificationSender := service.NewNotificationSender(notificationRepository, channelTemplateService, selector)
+	pooledDispatcher := ioc.InitPooledDispatcher(notificationRepository, notificationSender, selector, clock)
+	fallbackService := ioc.InitFallbackService(notificationRepository)
+	pacingDAO := dao.NewPacingDAO(db)
+	pacingRepository := repository.NewPacingRepository(pacingDAO, clock)
+	pacingService := service.NewPacingService(pacingRepository, notificationRepository)
+	scheduler := ioc.InitScheduler(serviceService, membership, pooledDispatcher, fallbackService, pacingService, clock)
+	distribute_lockClient := ioc.InitDistributedLock(redisClient)
+	etcdWatcher := ioc.InitFeatureFlagWatcher(client, flags)
+	v2 := ioc.InitSchedulerTasks(dataRetentionService, statisticsService, templateUsageService, sloService, notificationRepository, exportRepository, inboxRepository, escalationService, digestService, localTimeService, templateReviewService, vendorBalanceService, quotaRepository, quotaReservationRepository, scheduler, distribute_lockClient, clock, etcdWatcher, watchBus)
+	server := ioc.InitDebugServer()
+	tracerProvider := ioc.InitJeagerTracer()
+	app := &ioc.App{
+		MachineIDAllocator: allocator,
+		Tasks:              v2,
+		DebugServer:        server,
+		TracerProvider:     tracerProvider,
+	}
+	return app
+}
+
+// InitCallbackWorker 回调角色：发送结果回调、已读回执回调和死信汇总，不提供 gRPC 服务
+func InitCallbackWorker() *ioc.App {
+	client := ioc.InitEtcdClient()
+	redisClient := ioc.InitRedis()
+	allocator := ioc.InitMachineIDAllocator(client, redisClient)
+	db := ioc.InitDB()
+	clock := ioc.InitClock()
+	callbackLogDAO := dao.NewCallbackLogDAO(db, clock)
+	callbackLogRepository := repository.NewCallbackLogRepository(callbackLogDAO, clock)
+	notificationDAO := ioc.InitNotificationDAO(db, clock)
+	quotaDAO := dao.NewQuotaDAO(db, clock)
+	quotaCache := ioc.InitQuotaCache(redisClient, quotaDAO, clock)
+	cipher := ioc.InitFieldCipher()
+	blindIndexer := ioc.InitBlindIndexer()
+	flags := ioc.InitFeatureFlags()
+	watchBus := ioc.InitWatchBus(redisClient)
+	notificationRepository := ioc.InitNotificationRepository(notificationDAO, quotaCache, cipher, blindIndexer, redisClient, flags, watchBus, clock)
+	readReceiptDAO := dao.NewReadReceiptDAO(db)
+	readReceiptRepository := repository.NewReadReceiptRepository(readReceiptDAO, cipher, blindIndexer)
+	callbackSecretDAO := dao.NewCallbackSecretDAO(db)
+	callbackSecretRepository := repository.NewCallbackSecretRepository(callbackSecretDAO, cipher)
+	callbackSecretService := service.NewCallbackSecretService(callbackSecretRepository)
+	healthTracker := ioc.InitCallbackHealthTracker()
+	callbackClient := ioc.InitCallbackClient(callbackSecretService, healthTracker)
+	channelTemplateDAO := dao.NewChannelTemplateDAO(db, clock)
+	channelTemplateRepository := repository.NewChannelTemplateRepository(channelTemplateDAO)
+	channelTemplateService := service.NewChannelTemplateService(channelTemplateRepository)
+	generator := ioc.InitIDGenerator(allocator, redisClient, clock)
+	callbackDeadLetterService := ioc.InitCallbackDeadLetterService(callbackLogRepository, notificationRepository, channelTemplateService, generator, clock)
+	distribute_lockClient := ioc.InitDistributedLock(redisClient)
+	etcdWatcher := ioc.InitFeatureFlagWatcher(client, flags)
+	v := ioc.InitCallbackWorkerTasks(callbackLogRepository, notificationRepository, readReceiptRepository, callbackClient, callbackDeadLetterService, distribute_lockClient, clock, etcdWatcher)
+	server := ioc.InitDebugServer()
+	tracerProvider := ioc.InitJeagerTracer()
+	app := &ioc.App{
+		MachineIDAllocator: allocator,
+		Tasks:              v,
+		DebugServer:        server,
+		TracerProvider:     tracerProvider,
 	}
 	return app
 }
@@ -45,10 +480,63 @@ func InitGrpcServer() *ioc.App {
 // wire.go:
 
 var (
-	BaseSet = wire.NewSet(ioc.InitDB, ioc.InitRedis, ioc.InitIDGenerator, ioc.InitDistributedLock, ioc.InitEtcdClient, ioc.InitJeagerTracer, ioc.InitLogger)
+	BaseSet = wire.NewSet(ioc.InitDB, ioc.InitRedis, ioc.InitIDGenerator, ioc.InitMachineIDAllocator, ioc.InitDistributedLock, ioc.InitEtcdClient, ioc.InitJeagerTracer, ioc.InitLogLevels, ioc.InitLogger, ioc.InitFieldCipher, ioc.InitBlindIndexer, ioc.InitClock, ioc.InitFeatureFlags, ioc.InitFeatureFlagWatcher)
 
 	// RegistrySet 服务注册相关依赖
 	RegistrySet = wire.NewSet(ioc.InitRegistry, ioc.InitConfigLoader, ioc.InitServiceInfo, wire.Bind(new(registry.Registry), new(*registry.EtcdRegistry)), wire.Bind(new(config.ConfigLoader), new(*config.ViperConfigLoader)))
 
-	notificationSvcSet = wire.NewSet(service.NewNotificationService, repository.NewNotificationRepository, dao.NewNotificationDAO, redis.NewQuotaCache)
+	notificationSvcSet = wire.NewSet(service.NewNotificationService, ioc.InitNotificationRepository, ioc.InitNotificationDAO, ioc.InitQuotaCache, repository.NewQuotaRepository, repository.NewQuotaReservationRepository, dao.NewQuotaDAO, service.NewQuotaPrecheckService)
+
+	dataRetentionSvcSet = wire.NewSet(ioc.InitDataRetentionService, ioc.InitNotificationExportService, repository.NewDataRetentionRepository, dao.NewDataRetentionDAO)
+
+	rbacSvcSet = wire.NewSet(ioc.InitRBACService, repository.NewRoleAssignmentRepository, dao.NewRoleAssignmentDAO)
+
+	callbackSecretSvcSet = wire.NewSet(service.NewCallbackSecretService, repository.NewCallbackSecretRepository, dao.NewCallbackSecretDAO, ioc.InitCallbackHealthTracker, ioc.InitProviderHealthTracker, ioc.InitVendorHTTPClients)
+
+	templateSvcSet = wire.NewSet(service.NewChannelTemplateService, ioc.InitTemplateReviewService, ioc.InitTemplateAuditHandler, repository.NewChannelTemplateRepository, dao.NewChannelTemplateDAO, service.NewTemplateUsageService, repository.NewTemplateUsageRepository, dao.NewTemplateUsageDAO)
+
+	statisticsSvcSet = wire.NewSet(service.NewStatisticsService, repository.NewStatisticsRepository, dao.NewStatisticsDAO, ioc.InitSLOService, repository.NewSLORepository, dao.NewSLODAO)
+
+	exportSet = wire.NewSet(repository.NewExportRepository, dao.NewExportDAO)
+
+	readReceiptSvcSet = wire.NewSet(ioc.InitReadReceiptService, repository.NewReadReceiptRepository, dao.NewReadReceiptDAO, ioc.InitCallbackClient)
+
+	pushSet = wire.NewSet(ioc.InitInAppBus, ioc.InitPushTokenSigner, ioc.InitPushHandler, ioc.InitInboxHandler, service.NewInboxService, repository.NewInboxRepository, dao.NewInboxDAO)
+
+	escalationSvcSet = wire.NewSet(service.NewEscalationService, repository.NewEscalationRepository, dao.NewEscalationDAO)
+
+	digestSvcSet = wire.NewSet(ioc.InitDigestService, repository.NewDigestRepository, dao.NewDigestDAO)
+
+	localTimeSvcSet = wire.NewSet(ioc.InitLocalTimeService, repository.NewLocalTimeRepository, dao.NewLocalTimeDAO)
+
+	pacingSvcSet = wire.NewSet(service.NewPacingService, repository.NewPacingRepository, dao.NewPacingDAO)
+
+	vendorBalanceSvcSet = wire.NewSet(ioc.InitVendorBalanceService, repository.NewVendorBalanceRepository, dao.NewVendorBalanceDAO)
+
+	// sendAttemptSet 发送尝试，运维接口按通知、供应商、消息ID查询
+	sendAttemptSet = wire.NewSet(repository.NewSendAttemptRepository, dao.NewSendAttemptDAO)
+
+	// blacklistSet 接收者黑名单，运维接口查询和删除
+	blacklistSet = wire.NewSet(repository.NewBlacklistRepository, dao.NewBlacklistDAO)
+
+	// bizChannelSet 业务方渠道开关，发送时校验，运维接口修改
+	bizChannelSet = wire.NewSet(service.NewBizChannelService, repository.NewBizChannelRepository, dao.NewBizChannelDAO, ioc.InitBizChannelCache)
+
+	// bizKeyPolicySet 业务方通知 key 规则，发送时校验，运维接口修改，业务方可以让平台生成 key
+	bizKeyPolicySet = wire.NewSet(service.NewBizKeyPolicyService, repository.NewBizKeyPolicyRepository, dao.NewBizKeyPolicyDAO, ioc.InitBizKeyPolicyCache)
+
+	// callbackDeadLetterSet 发送结果回调死信，运维接口查询和重新回调，定时汇总告警
+	callbackDeadLetterSet = wire.NewSet(ioc.InitCallbackDeadLetterService)
+
+	// watchSet 运维观察通知，通知仓储发布变化，运维接口订阅
+	watchSet = wire.NewSet(ioc.InitWatchBus, ioc.InitNotificationWatchService)
+
+	// unsubscribeSet 退订链接和短信退订关键字
+	unsubscribeSet = wire.NewSet(ioc.InitUnsubscribeTokenSigner, ioc.InitUnsubscribeService, ioc.InitUnsubscribeHandler, ioc.InitSMSReplyHandler, repository.NewOptOutRepository, dao.NewOptOutDAO)
+
+	// schedulerSet 分区调度：扫描到期的通知，按渠道和供应商分配到协程池，按供应商路由调用供应商发送
+	schedulerSet = wire.NewSet(ioc.InitScheduler, ioc.InitSchedulerMembership, ioc.InitPooledDispatcher, wire.Bind(new(service.Dispatcher), new(*service.PooledDispatcher)), service.NewNotificationSender, ioc.InitProviderSelector, ioc.InitProviders, ioc.InitProviderBreaker, ioc.InitAnomalyDetector, ioc.InitShadowReporter)
+
+	// graphqlSet 只读 GraphQL 接口额外需要的仓储
+	graphqlSet = wire.NewSet(ioc.InitGraphQL, repository.NewCallbackLogRepository, dao.NewCallbackLogDAO)
 )
@@ -1,22 +1,119 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/serendipityConfusion/notification-platform/cmd/platform/ioc"
+	internalioc "github.com/serendipityConfusion/notification-platform/internal/ioc"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/spf13/viper"
 )
 
+const usage = `用法: platform [--config file]... [--env env] [--role role] [command] [args]
+
+命令:
+  (无)        启动服务
+  devserver   初始化演示数据后按开发配置启动服务
+  migrate     数据库迁移
+  config      校验配置
+
+配置按顺序合并，后面的覆盖前面的:
+  1. 基础配置: 第一个 --config，没有指定时查找 config/platform/config.yaml
+  2. 环境配置: 基础配置同目录下的 config.<env>.yaml，env 来自 --env 或者环境变量 APP_ENV
+  3. 本地配置: 基础配置同目录下的 config.local.yaml
+  4. 其余的 --config
+
+角色:
+  all              默认，一个进程运行所有接口和后台任务
+  api              发送、管理和查询接口，不运行定时任务和回调
+  scheduler        定时任务，不提供 gRPC 服务
+  callback-worker  发送结果回调、已读回执回调和死信汇总，不提供 gRPC 服务
+  query            只读查询接口，注册在 <服务名>-query 下
+
+参数:`
+
+// configFiles 可以重复指定的 --config
+type configFiles []string
+
+func (f *configFiles) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *configFiles) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
 func main() {
+	var files configFiles
+	flag.Var(&files, "config", "配置文件，可以指定多个，后面的覆盖前面的")
+	env := flag.String("env", os.Getenv("APP_ENV"), "运行环境，例如 dev、staging、prod，默认读取环境变量 APP_ENV")
+	roleName := flag.String("role", os.Getenv("APP_ROLE"), "运行角色，默认读取环境变量 APP_ROLE，都没有时为 all")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	cmd, args := flag.Arg(0), flag.Args()[min(1, flag.NArg()):]
+	if !slices.Contains([]string{"", "devserver", "migrate", "config"}, cmd) {
+		flag.Usage()
+		log.Fatalf("[Main] Unknown command: %s", cmd)
+	}
+	role, err := internalioc.ParseRole(*roleName)
+	if err != nil {
+		flag.Usage()
+		log.Fatalf("[Main] %v", err)
+	}
+
 	// 1. 初始化配置
-	if err := initConfig(); err != nil {
+	if err := initConfig(files, *env); err != nil {
 		log.Fatalf("[Main] Failed to initialize config: %v", err)
 	}
 	log.Println("[Main] Configuration loaded successfully")
 
+	// 子命令
+	if cmd == "config" {
+		if err := runConfig(args); err != nil {
+			log.Fatalf("[Main] Config check failed: %v", err)
+		}
+		return
+	}
+	if cmd == "migrate" {
+		if err := runMigrate(args); err != nil {
+			log.Fatalf("[Main] Migrate failed: %v", err)
+		}
+		return
+	}
+	// 启动前一次性列出所有配置错误，不在依赖初始化的某一步 panic
+	if err := internalioc.ValidateConfig(viper.GetViper()); err != nil {
+		log.Fatalf("[Main] Invalid config: %v", err)
+	}
+	// 部署时依赖可能还没有就绪，等待一段时间，超时后一次列出所有不可用的依赖
+	if err := internalioc.WaitForDependencies(); err != nil {
+		log.Fatalf("[Main] Dependencies unavailable: %v", err)
+	}
+
+	// 本地开发：初始化演示数据之后按开发配置启动
+	if cmd == "devserver" {
+		serve, err := runDevserver(args)
+		if err != nil {
+			log.Fatalf("[Main] Devserver failed: %v", err)
+		}
+		if !serve {
+			return
+		}
+	}
+
 	// 2. 通过 wire 初始化应用（依赖注入）
-	app := ioc.InitGrpcServer()
-	log.Println("[Main] Application initialized successfully")
+	app := initApp(role)
+	app.Role = role
+	log.Printf("[Main] Application initialized successfully (role %s)", role)
 
 	// 3. 运行应用
 	if err := app.Run(); err != nil {
@@ -26,12 +123,37 @@ func main() {
 	log.Println("[Main] Application exited successfully")
 }
 
-// initConfig 初始化配置
-func initConfig() error {
+// initApp 按角色只初始化需要的组件
+func initApp(role internalioc.Role) *internalioc.App {
+	switch role {
+	case internalioc.RoleAPI:
+		return ioc.InitAPIServer()
+	case internalioc.RoleQuery:
+		return ioc.InitQueryServer()
+	case internalioc.RoleScheduler:
+		return ioc.InitSchedulerWorker()
+	case internalioc.RoleCallbackWorker:
+		return ioc.InitCallbackWorker()
+	default:
+		return ioc.InitGrpcServer()
+	}
+}
+
+// initConfig 初始化分层配置，并把配置里的密钥引用替换成环境变量或者 Vault 里的密钥
+func initConfig(files []string, env string) error {
 	// 使用配置加载器的辅助函数初始化 Viper
-	return config.InitViperConfig(
-		"./config/platform",     // 生产环境路径
-		"../../config/platform", // 开发/测试环境路径
-		".",                     // 当前目录
-	)
+	loaded, err := config.InitViperConfig(config.LayerOptions{
+		Files: files,
+		Env:   env,
+		SearchPaths: []string{
+			"./config/platform",     // 生产环境路径
+			"../../config/platform", // 开发/测试环境路径
+			".",                     // 当前目录
+		},
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[Main] Config files (env %q): %s", env, strings.Join(loaded, ", "))
+	return config.NewViperConfigLoader().ResolveSecrets(context.Background(), internalioc.InitSecretResolvers())
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/serendipityConfusion/notification-platform/internal/ioc"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao/migrations"
+)
+
+const migrateUsage = `用法: platform migrate <command> [args]
+
+命令:
+  up             执行所有未执行的迁移
+  down <n>       回滚 n 个版本
+  goto <version> 迁移到指定版本
+  force <version>
+                 强制设置版本号并清除脏状态，不执行迁移
+  version        查看当前版本`
+
+// runMigrate 执行 migrate 子命令
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), migrateUsage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("缺少 migrate 命令")
+	}
+
+	conf, err := ioc.LoadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.New(conf)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = migrator.Close()
+	}()
+
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	switch cmd {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		var steps int
+		if steps, err = intArg(cmdArgs); err == nil {
+			err = migrator.Down(steps)
+		}
+	case "goto":
+		var version int
+		if version, err = intArg(cmdArgs); err == nil {
+			if version < 0 {
+				return fmt.Errorf("版本号不能为负数: %d", version)
+			}
+			err = migrator.Goto(uint(version))
+		}
+	case "force":
+		var version int
+		if version, err = intArg(cmdArgs); err == nil {
+			err = migrator.Force(version)
+		}
+	case "version":
+	default:
+		fs.Usage()
+		return fmt.Errorf("未知的 migrate 命令: %s", cmd)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	log.Printf("[Migrate] Current version: %d, dirty: %t, latest: %d", version, dirty, migrator.LatestVersion())
+	return nil
+}
+
+func intArg(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, errors.New("需要一个整数参数")
+	}
+	return strconv.Atoi(args[0])
+}
@@ -0,0 +1,105 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server 调试用的 HTTP 服务，提供 pprof 和 Prometheus 指标（包括 GC、协程数、堆内存等运行时指标）
+// 只接受 allowed 里的来源地址，其他请求返回 403
+type Server struct {
+	server  *http.Server
+	allowed []*net.IPNet
+}
+
+// NewServer addr 是监听地址，allowed 为空时只允许本机访问
+func NewServer(addr string, allowed []*net.IPNet) *Server {
+	if len(allowed) == 0 {
+		allowed = loopbackNets()
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("GET /metrics", promhttp.Handler())
+	s := &Server{allowed: allowed}
+	s.server = &http.Server{Addr: addr, Handler: s.restrict(mux)}
+	return s
+}
+
+// Start 阻塞运行，Shutdown 之后返回 http.ErrServerClosed
+func (s *Server) Start() error {
+	return s.server.ListenAndServe()
+}
+
+// Shutdown 等待处理中的请求结束，CPU profile 这类长请求超过 ctx 的时间后直接断开
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Server) restrict(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		ip := net.ParseIP(host)
+		if err != nil || ip == nil || !s.allow(ip) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) allow(ip net.IP) bool {
+	for _, n := range s.allowed {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCIDRs 解析来源地址白名单，单个 IP 按 /32 或者 /128 处理
+func ParseCIDRs(values []string) ([]*net.IPNet, error) {
+	res := make([]*net.IPNet, 0, len(values))
+	for _, v := range values {
+		if ip := net.ParseIP(v); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			res = append(res, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, fmt.Errorf("来源地址 %q 不合法: %w", v, err)
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+// IsLoopbackAddr 监听地址是否只绑定在本机回环地址上，主机名只认 localhost
+func IsLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func loopbackNets() []*net.IPNet {
+	nets, _ := ParseCIDRs([]string{"127.0.0.0/8", "::1"})
+	return nets
+}
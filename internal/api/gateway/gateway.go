@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	configv1 "github.com/serendipityConfusion/notification-platform/api/gen/config/v1"
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/api/openapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// forwardedHeaders 除了 Authorization 之外需要转成 gRPC metadata 的请求头
+var forwardedHeaders = map[string]string{
+	"x-request-id": "x-request-id",
+	"x-priority":   "x-priority",
+}
+
+// Server HTTP/JSON 网关，请求转成 gRPC 调用本实例的 gRPC 服务，
+// 和直接调用 gRPC 一样经过鉴权、指标、日志和链路拦截器
+type Server struct {
+	server *http.Server
+	mux    *http.ServeMux
+	conn   *grpc.ClientConn
+}
+
+// NewServer addr 是 HTTP 监听地址，grpcEndpoint 是本实例 gRPC 服务的地址，callOpts 是调用 gRPC 服务的默认选项
+func NewServer(addr, grpcEndpoint string, callOpts ...grpc.CallOption) (*Server, error) {
+	conn, err := grpc.NewClient(grpcEndpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(callOpts...))
+	if err != nil {
+		return nil, fmt.Errorf("连接 gRPC 服务 %s 失败: %w", grpcEndpoint, err)
+	}
+	mux := runtime.NewServeMux(
+		// 字段名和 proto、OpenAPI 文档保持一致，使用下划线风格
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
+	ctx := context.Background()
+	registers := []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		notificationpb.RegisterNotificationServiceHandler,
+		notificationpb.RegisterNotificationQueryServiceHandler,
+		notificationpb.RegisterTemplateServiceHandler,
+		notificationpb.RegisterDataPrivacyServiceHandler,
+		notificationpb.RegisterRoleServiceHandler,
+		notificationpb.RegisterStatisticsServiceHandler,
+		notificationpb.RegisterReadReceiptServiceHandler,
+		notificationpb.RegisterPushServiceHandler,
+		notificationpb.RegisterEscalationServiceHandler,
+		notificationpb.RegisterAdminServiceHandler,
+		notificationpb.RegisterUnsubscribeServiceHandler,
+		notificationpb.RegisterDebugServiceHandler,
+		configv1.RegisterBusinessConfigServiceHandler,
+	}
+	for _, register := range registers {
+		if err = register(ctx, mux, conn); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openapi.Spec)
+	})
+	handler.Handle("/", mux)
+	return &Server{
+		server: &http.Server{Addr: addr, Handler: handler},
+		mux:    handler,
+		conn:   conn,
+	}, nil
+}
+
+// Handle 在网关上挂载其他 HTTP 接口，必须在 Start 之前调用
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+}
+
+// Start 阻塞运行，Shutdown 之后返回 http.ErrServerClosed
+func (s *Server) Start() error {
+	return s.server.ListenAndServe()
+}
+
+// Shutdown 等待处理中的请求结束，再断开和 gRPC 服务的连接
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.server.Shutdown(ctx)
+	_ = s.conn.Close()
+	return err
+}
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	if md, ok := forwardedHeaders[strings.ToLower(key)]; ok {
+		return md, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher gRPC 响应里的请求ID原样放到 HTTP 响应头，方便调用方排查问题
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if _, ok := forwardedHeaders[key]; ok {
+		return key, true
+	}
+	return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+}
@@ -0,0 +1,114 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+const (
+	maxDepth     = 8
+	maxBodyBytes = 1 << 20
+)
+
+// Authenticator 根据 Authorization 请求头确定调用方
+type Authenticator interface {
+	Authenticate(ctx context.Context, authorization string) (domain.Principal, error)
+}
+
+// ProviderRoute 一个渠道的供应商路由，来自配置
+type ProviderRoute struct {
+	Channel       domain.Channel
+	Providers     []string
+	Shadow        string
+	ShadowPercent uint64
+}
+
+// Handler 只读 GraphQL 接口，每个请求创建自己的 DataLoader，同一个请求里的关联查询合并成批量查询
+type Handler struct {
+	schema        *graphqlgo.Schema
+	authenticator Authenticator
+	repos         repositories
+}
+
+type repositories struct {
+	notification repository.NotificationRepository
+	callbackLog  repository.CallbackLogRepository
+	quota        repository.QuotaRepository
+	routes       []ProviderRoute
+}
+
+// NewHandler authenticator 为 nil 表示没有开启鉴权，此时查询必须带上 bizId
+func NewHandler(notificationRepo repository.NotificationRepository,
+	callbackLogRepo repository.CallbackLogRepository,
+	quotaRepo repository.QuotaRepository,
+	routes []ProviderRoute,
+	authenticator Authenticator,
+) *Handler {
+	h := &Handler{
+		authenticator: authenticator,
+		repos: repositories{
+			notification: notificationRepo,
+			callbackLog:  callbackLogRepo,
+			quota:        quotaRepo,
+			routes:       routes,
+		},
+	}
+	h.schema = graphqlgo.MustParseSchema(schemaString, &queryResolver{repos: &h.repos},
+		graphqlgo.MaxDepth(maxDepth))
+	return h
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "只支持 POST 请求", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	if h.authenticator != nil {
+		principal, err := h.authenticator.Authenticate(ctx, r.Header.Get("Authorization"))
+		switch {
+		case errors.Is(err, domain.ErrUnauthenticated):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		case errors.Is(err, domain.ErrPermissionDenied):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case err != nil:
+			http.Error(w, "鉴权失败", http.StatusInternalServerError)
+			return
+		}
+		if !principal.Role.HasPermission(domain.PermissionNotificationRead) {
+			http.Error(w, domain.ErrPermissionDenied.Error(), http.StatusForbidden)
+			return
+		}
+		ctx = ctxkit.WithCaller(ctx, principal)
+	}
+
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		http.Error(w, "请求体不是合法的 JSON", http.StatusBadRequest)
+		return
+	}
+	ctx = withLoaders(ctx, newLoaders(&h.repos))
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
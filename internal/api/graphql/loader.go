@@ -0,0 +1,42 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/dataloader"
+)
+
+type loadersKey struct{}
+
+// loaders 一个请求内共用的批量查询
+type loaders struct {
+	notification *dataloader.Loader[uint64, domain.Notification]
+	callbackLog  *dataloader.Loader[uint64, domain.CallbackLog]
+}
+
+func newLoaders(repos *repositories) *loaders {
+	return &loaders{
+		notification: dataloader.New(repos.notification.BatchGetByIDs, 0, 0),
+		callbackLog: dataloader.New(func(ctx context.Context, ids []uint64) (map[uint64]domain.CallbackLog, error) {
+			logs, err := repos.callbackLog.FindByNotificationIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			res := make(map[uint64]domain.CallbackLog, len(logs))
+			for _, l := range logs {
+				res[l.Notification.ID] = l
+			}
+			return res, nil
+		}, 0, 0),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFromContext Handler 每个请求都会设置，取不到说明调用方式不对，直接 panic
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
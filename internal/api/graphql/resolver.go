@@ -0,0 +1,439 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+)
+
+const maxPageSize = 100
+
+// channels 查询额度时遍历的渠道
+var channels = []domain.Channel{domain.ChannelSMS, domain.ChannelEmail, domain.ChannelInApp}
+
+// authorize 调用方是否有权限，没有开启鉴权时 ctx 里没有调用方，一律放行
+func authorize(ctx context.Context, perm domain.Permission) error {
+	caller, ok := ctxkit.CallerFromContext(ctx)
+	if !ok || caller.Role.HasPermission(perm) {
+		return nil
+	}
+	return fmt.Errorf("%w: 角色 %s 没有权限 %s", domain.ErrPermissionDenied, caller.Role, perm)
+}
+
+// canAccess 调用方能否查看 bizID 的数据，平台管理员和没有开启鉴权时不受限制
+func canAccess(ctx context.Context, bizID int64) bool {
+	caller, ok := ctxkit.CallerFromContext(ctx)
+	return !ok || caller.IsPlatformAdmin() || caller.BizID == bizID
+}
+
+// resolveBizID 没有传 bizId 时使用调用方所属业务方，不能查询其他业务方
+func resolveBizID(ctx context.Context, id *graphqlgo.ID) (int64, error) {
+	caller, authenticated := ctxkit.CallerFromContext(ctx)
+	if id == nil {
+		if !authenticated || caller.BizID <= 0 {
+			return 0, fmt.Errorf("%w: 必须指定 bizId", domain.ErrInvalidParameter)
+		}
+		return caller.BizID, nil
+	}
+	bizID, err := strconv.ParseInt(string(*id), 10, 64)
+	if err != nil || bizID <= 0 {
+		return 0, fmt.Errorf("%w: bizId = %s", domain.ErrInvalidParameter, *id)
+	}
+	if !canAccess(ctx, bizID) {
+		return 0, fmt.Errorf("%w: 不能查询业务方 %d 的数据", domain.ErrPermissionDenied, bizID)
+	}
+	return bizID, nil
+}
+
+type queryResolver struct {
+	repos *repositories
+}
+
+func (r *queryResolver) Notification(ctx context.Context, args struct{ ID graphqlgo.ID }) (*notificationResolver, error) {
+	id, err := strconv.ParseUint(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: id = %s", domain.ErrInvalidParameter, args.ID)
+	}
+	return loadNotification(ctx, id)
+}
+
+func (r *queryResolver) NotificationByKey(ctx context.Context, args struct {
+	BizID *graphqlgo.ID
+	Key   string
+},
+) (*notificationResolver, error) {
+	bizID, err := resolveBizID(ctx, args.BizID)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := r.repos.notification.GetByKeys(ctx, bizID, args.Key)
+	if err != nil || len(notifications) == 0 {
+		return nil, err
+	}
+	return &notificationResolver{n: notifications[0]}, nil
+}
+
+func (r *queryResolver) Notifications(ctx context.Context, args struct {
+	BizID   *graphqlgo.ID
+	Status  *string
+	Channel *string
+	Labels  *[]labelInput
+	First   int32
+	After   *string
+},
+) (*connectionResolver, error) {
+	bizID, err := resolveBizID(ctx, args.BizID)
+	if err != nil {
+		return nil, err
+	}
+	// first 在 schema 里有默认值
+	if args.First <= 0 || args.First > maxPageSize {
+		return nil, fmt.Errorf("%w: first 必须在 1 到 %d 之间", domain.ErrInvalidParameter, maxPageSize)
+	}
+	filter := domain.NotificationFilter{BizID: bizID, Limit: int(args.First)}
+	if args.Status != nil {
+		filter.Status = domain.SendStatus(*args.Status)
+	}
+	if args.Channel != nil {
+		filter.Channel = domain.Channel(*args.Channel)
+	}
+	if args.Labels != nil {
+		filter.Labels = make(domain.Labels, len(*args.Labels))
+		for _, l := range *args.Labels {
+			filter.Labels[l.Key] = l.Value
+		}
+		if err = filter.Labels.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if args.After != nil {
+		if filter.BeforeID, err = decodeCursor(*args.After); err != nil {
+			return nil, err
+		}
+	}
+	limit := filter.Limit
+	// 多查一条判断有没有下一页
+	filter.Limit++
+	notifications, err := r.repos.notification.ListByBiz(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	conn := &connectionResolver{hasNext: len(notifications) > limit}
+	if conn.hasNext {
+		notifications = notifications[:limit]
+	}
+	for _, n := range notifications {
+		conn.edges = append(conn.edges, &edgeResolver{n: &notificationResolver{n: n}})
+	}
+	return conn, nil
+}
+
+func (r *queryResolver) Quotas(ctx context.Context, args struct{ BizID *graphqlgo.ID }) ([]*quotaResolver, error) {
+	bizID, err := resolveBizID(ctx, args.BizID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*quotaResolver, 0, len(channels))
+	for _, ch := range channels {
+		q, err := r.repos.quota.Find(ctx, bizID, ch)
+		if errors.Is(err, domain.ErrQuotaNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &quotaResolver{q: q})
+	}
+	return res, nil
+}
+
+func (r *queryResolver) Providers(ctx context.Context) ([]*providerRouteResolver, error) {
+	if err := authorize(ctx, domain.PermissionAdminRead); err != nil {
+		return nil, err
+	}
+	res := make([]*providerRouteResolver, 0, len(r.repos.routes))
+	for _, route := range r.repos.routes {
+		res = append(res, &providerRouteResolver{r: route})
+	}
+	return res, nil
+}
+
+// loadNotification 通过 DataLoader 查询通知，不属于调用方业务方的通知当作不存在，不暴露其他业务方的数据
+func loadNotification(ctx context.Context, id uint64) (*notificationResolver, error) {
+	n, found, err := loadersFromContext(ctx).notification.Load(ctx, id)
+	if err != nil || !found || !canAccess(ctx, n.BizID) {
+		return nil, err
+	}
+	return &notificationResolver{n: n}, nil
+}
+
+func encodeCursor(id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
+
+func decodeCursor(cursor string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		var id uint64
+		if id, err = strconv.ParseUint(string(raw), 10, 64); err == nil {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: after = %s", domain.ErrInvalidParameter, cursor)
+}
+
+type connectionResolver struct {
+	edges   []*edgeResolver
+	hasNext bool
+}
+
+func (c *connectionResolver) Edges() []*edgeResolver {
+	return c.edges
+}
+
+func (c *connectionResolver) PageInfo() *pageInfoResolver {
+	p := &pageInfoResolver{hasNext: c.hasNext}
+	if len(c.edges) > 0 {
+		cursor := c.edges[len(c.edges)-1].Cursor()
+		p.endCursor = &cursor
+	}
+	return p
+}
+
+type edgeResolver struct {
+	n *notificationResolver
+}
+
+func (e *edgeResolver) Cursor() string {
+	return encodeCursor(e.n.n.ID)
+}
+
+func (e *edgeResolver) Node() *notificationResolver {
+	return e.n
+}
+
+type pageInfoResolver struct {
+	hasNext   bool
+	endCursor *string
+}
+
+func (p *pageInfoResolver) HasNextPage() bool {
+	return p.hasNext
+}
+
+func (p *pageInfoResolver) EndCursor() *string {
+	return p.endCursor
+}
+
+type notificationResolver struct {
+	n domain.Notification
+}
+
+func (r *notificationResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.FormatUint(r.n.ID, 10))
+}
+
+func (r *notificationResolver) BizID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.FormatInt(r.n.BizID, 10))
+}
+
+func (r *notificationResolver) Key() string {
+	return r.n.Key
+}
+
+func (r *notificationResolver) Receivers(ctx context.Context) (*[]string, error) {
+	if err := authorize(ctx, domain.PermissionPIIRead); err != nil {
+		return nil, err
+	}
+	return &r.n.Receivers, nil
+}
+
+func (r *notificationResolver) Channel() string {
+	return r.n.Channel.String()
+}
+
+func (r *notificationResolver) TemplateID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.FormatInt(r.n.Template.ID, 10))
+}
+
+func (r *notificationResolver) TemplateVersionID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.FormatInt(r.n.Template.VersionID, 10))
+}
+
+func (r *notificationResolver) TemplateParams(ctx context.Context) (*[]*templateParamResolver, error) {
+	if err := authorize(ctx, domain.PermissionPIIRead); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(r.n.Template.Params))
+	for name := range r.n.Template.Params {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	res := make([]*templateParamResolver, 0, len(names))
+	for _, name := range names {
+		res = append(res, &templateParamResolver{name: name, value: r.n.Template.Params[name]})
+	}
+	return &res, nil
+}
+
+func (r *notificationResolver) Labels() []*labelResolver {
+	keys := slices.Sorted(maps.Keys(r.n.Labels))
+	res := make([]*labelResolver, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, &labelResolver{key: k, value: r.n.Labels[k]})
+	}
+	return res
+}
+
+func (r *notificationResolver) Status() string {
+	return r.n.Status.String()
+}
+
+func (r *notificationResolver) FailReason() *string {
+	if r.n.FailReason == "" {
+		return nil
+	}
+	reason := r.n.FailReason.String()
+	return &reason
+}
+
+func (r *notificationResolver) BizPayload() *string {
+	if r.n.BizPayload == "" {
+		return nil
+	}
+	return &r.n.BizPayload
+}
+
+func (r *notificationResolver) ScheduledStartTime() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.n.ScheduledSTime}
+}
+
+func (r *notificationResolver) ScheduledEndTime() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.n.ScheduledETime}
+}
+
+func (r *notificationResolver) Version() int32 {
+	return int32(r.n.Version)
+}
+
+func (r *notificationResolver) CallbackLog(ctx context.Context) (*callbackLogResolver, error) {
+	l, found, err := loadersFromContext(ctx).callbackLog.Load(ctx, r.n.ID)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &callbackLogResolver{l: l}, nil
+}
+
+type templateParamResolver struct {
+	name  string
+	value string
+}
+
+func (p *templateParamResolver) Name() string {
+	return p.name
+}
+
+func (p *templateParamResolver) Value() string {
+	return p.value
+}
+
+type labelInput struct {
+	Key   string
+	Value string
+}
+
+type labelResolver struct {
+	key   string
+	value string
+}
+
+func (l *labelResolver) Key() string {
+	return l.key
+}
+
+func (l *labelResolver) Value() string {
+	return l.value
+}
+
+type callbackLogResolver struct {
+	l domain.CallbackLog
+}
+
+func (r *callbackLogResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.FormatInt(r.l.ID, 10))
+}
+
+func (r *callbackLogResolver) Status() string {
+	return r.l.Status.String()
+}
+
+func (r *callbackLogResolver) RetryCount() int32 {
+	return r.l.RetryCount
+}
+
+func (r *callbackLogResolver) NextRetryTime() *graphqlgo.Time {
+	if r.l.NextRetryTime <= 0 {
+		return nil
+	}
+	return &graphqlgo.Time{Time: time.UnixMilli(r.l.NextRetryTime)}
+}
+
+func (r *callbackLogResolver) LastError() string {
+	return r.l.LastError
+}
+
+func (r *callbackLogResolver) Notification(ctx context.Context) (*notificationResolver, error) {
+	return loadNotification(ctx, r.l.Notification.ID)
+}
+
+type quotaResolver struct {
+	q domain.Quota
+}
+
+func (r *quotaResolver) BizID() graphqlgo.ID {
+	return graphqlgo.ID(strconv.FormatInt(r.q.BizID, 10))
+}
+
+func (r *quotaResolver) Channel() string {
+	return r.q.Channel.String()
+}
+
+func (r *quotaResolver) Remaining() int32 {
+	return r.q.Quota
+}
+
+func (r *quotaResolver) Overdraft() int32 {
+	return r.q.Overdraft
+}
+
+type providerRouteResolver struct {
+	r ProviderRoute
+}
+
+func (r *providerRouteResolver) Channel() string {
+	return r.r.Channel.String()
+}
+
+func (r *providerRouteResolver) Providers() []string {
+	return r.r.Providers
+}
+
+func (r *providerRouteResolver) Shadow() *string {
+	if r.r.Shadow == "" {
+		return nil
+	}
+	return &r.r.Shadow
+}
+
+func (r *providerRouteResolver) ShadowPercent() int32 {
+	return int32(r.r.ShadowPercent)
+}
@@ -0,0 +1,408 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxLogLevelReset 临时调整日志级别最长多久
+const maxLogLevelReset = 24 * time.Hour
+
+// AdminServer 平台运维接口
+type AdminServer struct {
+	notificationpb.UnimplementedAdminServiceServer
+
+	levels      *log.Levels
+	attempts    repository.SendAttemptRepository
+	blacklist   repository.BlacklistRepository
+	health      *provider.HealthTracker
+	deadLetters service.CallbackDeadLetterService
+	// watchSvc 没有开启 watch 时为 nil
+	watchSvc service.NotificationWatchService
+	logger   log.LoggerInterface
+
+	mu sync.Mutex
+	// resets 每个模块等待恢复的定时器，再次调整时取消
+	resets map[string]*time.Timer
+}
+
+func NewAdminServer(levels *log.Levels, attempts repository.SendAttemptRepository,
+	blacklist repository.BlacklistRepository, health *provider.HealthTracker,
+	deadLetters service.CallbackDeadLetterService, watchSvc service.NotificationWatchService, logger log.LoggerInterface,
+) *AdminServer {
+	return &AdminServer{
+		levels:      levels,
+		attempts:    attempts,
+		blacklist:   blacklist,
+		health:      health,
+		deadLetters: deadLetters,
+		watchSvc:    watchSvc,
+		logger:      log.Named(logger, "grpc.admin"),
+		resets:      make(map[string]*time.Timer),
+	}
+}
+
+// GetLogLevels 查询日志级别
+func (s *AdminServer) GetLogLevels(_ context.Context, _ *notificationpb.GetLogLevelsRequest) (*notificationpb.GetLogLevelsResponse, error) {
+	return s.snapshot(), nil
+}
+
+// SetLogLevel 调整日志级别，设置了 reset_after_seconds 时到期恢复成调整之前的级别
+func (s *AdminServer) SetLogLevel(_ context.Context, req *notificationpb.SetLogLevelRequest) (*notificationpb.SetLogLevelResponse, error) {
+	module := req.GetModule()
+	level, ok := convertLogLevel(req.GetLevel())
+	if !ok && module == "" {
+		return nil, status.Error(codes.InvalidArgument, "level is required for the root logger")
+	}
+	resetAfter := time.Duration(req.GetResetAfterSeconds()) * time.Second
+	if resetAfter < 0 || resetAfter > maxLogLevelReset {
+		return nil, status.Errorf(codes.InvalidArgument, "reset_after_seconds must be between 0 and %d", int64(maxLogLevelReset.Seconds()))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	root, modules := s.levels.Snapshot()
+	previous, hadPrevious := modules[module]
+	if module == "" {
+		previous, hadPrevious = root, true
+	}
+	if t, ok := s.resets[module]; ok {
+		t.Stop()
+		delete(s.resets, module)
+	}
+	if ok {
+		s.levels.SetLevel(module, level)
+	} else {
+		s.levels.ResetLevel(module)
+	}
+	s.logger.Warn("log level changed",
+		zap.String("module", module),
+		zap.String("level", req.GetLevel().String()),
+		zap.Duration("reset_after", resetAfter))
+
+	if resetAfter > 0 {
+		s.resets[module] = time.AfterFunc(resetAfter, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.resets, module)
+			if hadPrevious {
+				s.levels.SetLevel(module, previous)
+			} else {
+				s.levels.ResetLevel(module)
+			}
+			s.logger.Warn("log level reset", zap.String("module", module))
+		})
+	}
+	return &notificationpb.SetLogLevelResponse{Levels: s.snapshot()}, nil
+}
+
+// ListSendAttempts 按ID倒序分页查询发送尝试
+func (s *AdminServer) ListSendAttempts(ctx context.Context, req *notificationpb.ListSendAttemptsRequest) (*notificationpb.ListSendAttemptsResponse, error) {
+	if req.GetNotificationId() == 0 && req.GetProvider() == "" && req.GetMessageId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "one of notification_id, provider and message_id is required")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	if pageSize < 0 || pageSize > maxListPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxListPageSize)
+	}
+	filter := domain.SendAttemptFilter{
+		NotificationID: req.GetNotificationId(),
+		Provider:       req.GetProvider(),
+		MessageID:      req.GetMessageId(),
+		StartTime:      req.GetStartTime(),
+		EndTime:        req.GetEndTime(),
+		BeforeID:       req.GetBeforeId(),
+		// 多查一条判断有没有下一页
+		Limit: pageSize + 1,
+	}
+	if req.GetStatus() != notificationpb.SendAttemptStatus_SEND_ATTEMPT_STATUS_UNSPECIFIED {
+		filter.Status = domain.SendAttemptStatus(strings.TrimPrefix(req.GetStatus().String(), "SEND_ATTEMPT_STATUS_"))
+	}
+
+	attempts, err := s.attempts.List(ctx, filter)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("list send attempts failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list send attempts")
+	}
+	resp := &notificationpb.ListSendAttemptsResponse{}
+	if len(attempts) > pageSize {
+		attempts = attempts[:pageSize]
+		resp.NextBeforeId = attempts[pageSize-1].ID
+	}
+	resp.Attempts = make([]*notificationpb.SendAttempt, 0, len(attempts))
+	for _, a := range attempts {
+		resp.Attempts = append(resp.Attempts, &notificationpb.SendAttempt{
+			Id:                  a.ID,
+			NotificationId:      a.NotificationID,
+			Attempt:             int32(a.Attempt),
+			IdempotencyKey:      a.IdempotencyKey,
+			Status:              convertSendAttemptStatus(a.Status),
+			Provider:            a.Provider,
+			MessageId:           a.MessageID,
+			RequestDigest:       a.RequestDigest,
+			ResponseCode:        a.ResponseCode,
+			LatencyMilliseconds: a.Latency.Milliseconds(),
+			Error:               a.Error,
+			Ctime:               a.Ctime,
+			Utime:               a.Utime,
+		})
+	}
+	return resp, nil
+}
+
+// ListBlacklist 按ID倒序分页查询接收者黑名单
+func (s *AdminServer) ListBlacklist(ctx context.Context, req *notificationpb.ListBlacklistRequest) (*notificationpb.ListBlacklistResponse, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	if pageSize < 0 || pageSize > maxListPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxListPageSize)
+	}
+	filter := domain.BlacklistFilter{
+		Receiver:       req.GetReceiver(),
+		IncludeExpired: req.GetIncludeExpired(),
+		BeforeID:       req.GetBeforeId(),
+		// 多查一条判断有没有下一页
+		Limit: pageSize + 1,
+	}
+	if req.GetChannel() != notificationpb.Channel_CHANNEL_UNSPECIFIED {
+		filter.Channel = domain.Channel(req.GetChannel().String())
+	}
+
+	entries, err := s.blacklist.List(ctx, filter)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("list blacklist failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list blacklist")
+	}
+	resp := &notificationpb.ListBlacklistResponse{}
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		resp.NextBeforeId = entries[pageSize-1].ID
+	}
+	resp.Entries = make([]*notificationpb.BlacklistEntry, 0, len(entries))
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &notificationpb.BlacklistEntry{
+			Id:             e.ID,
+			Channel:        convertChannel(e.Channel),
+			Receiver:       e.Receiver,
+			Category:       e.Category.String(),
+			Vendor:         e.Vendor,
+			Code:           e.Code,
+			NotificationId: e.NotificationID,
+			ExpireTime:     e.ExpireTime.UnixMilli(),
+			Ctime:          e.Ctime,
+			Utime:          e.Utime,
+		})
+	}
+	return resp, nil
+}
+
+// DeleteBlacklistEntry 从渠道黑名单里删除接收者
+func (s *AdminServer) DeleteBlacklistEntry(ctx context.Context, req *notificationpb.DeleteBlacklistEntryRequest) (*notificationpb.DeleteBlacklistEntryResponse, error) {
+	if req.GetChannel() == notificationpb.Channel_CHANNEL_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "channel is required")
+	}
+	if req.GetReceiver() == "" {
+		return nil, status.Error(codes.InvalidArgument, "receiver is required")
+	}
+	channel := domain.Channel(req.GetChannel().String())
+	err := s.blacklist.Delete(ctx, channel, req.GetReceiver())
+	if errors.Is(err, domain.ErrBlacklistEntryNotFound) {
+		return nil, status.Error(codes.NotFound, "receiver is not in the blacklist")
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("delete blacklist entry failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to delete blacklist entry")
+	}
+	s.logger.WithContext(ctx).Warn("blacklist entry deleted", zap.String("channel", channel.String()))
+	return &notificationpb.DeleteBlacklistEntryResponse{}, nil
+}
+
+func convertSendAttemptStatus(s domain.SendAttemptStatus) notificationpb.SendAttemptStatus {
+	return notificationpb.SendAttemptStatus(notificationpb.SendAttemptStatus_value["SEND_ATTEMPT_STATUS_"+s.String()])
+}
+
+func (s *AdminServer) snapshot() *notificationpb.GetLogLevelsResponse {
+	root, modules := s.levels.Snapshot()
+	resp := &notificationpb.GetLogLevelsResponse{
+		Root:    convertLogLevelToAPI(root),
+		Modules: make([]*notificationpb.ModuleLogLevel, 0, len(modules)),
+	}
+	for module, level := range modules {
+		resp.Modules = append(resp.Modules, &notificationpb.ModuleLogLevel{
+			Module: module,
+			Level:  convertLogLevelToAPI(level),
+		})
+	}
+	slices.SortFunc(resp.Modules, func(a, b *notificationpb.ModuleLogLevel) int {
+		return strings.Compare(a.GetModule(), b.GetModule())
+	})
+	return resp
+}
+
+func convertLogLevel(level notificationpb.LogLevel) (zapcore.Level, bool) {
+	switch level {
+	case notificationpb.LogLevel_LOG_LEVEL_DEBUG:
+		return zapcore.DebugLevel, true
+	case notificationpb.LogLevel_LOG_LEVEL_INFO:
+		return zapcore.InfoLevel, true
+	case notificationpb.LogLevel_LOG_LEVEL_WARN:
+		return zapcore.WarnLevel, true
+	case notificationpb.LogLevel_LOG_LEVEL_ERROR:
+		return zapcore.ErrorLevel, true
+	default:
+		return 0, false
+	}
+}
+
+func convertLogLevelToAPI(level zapcore.Level) notificationpb.LogLevel {
+	switch {
+	case level <= zapcore.DebugLevel:
+		return notificationpb.LogLevel_LOG_LEVEL_DEBUG
+	case level == zapcore.InfoLevel:
+		return notificationpb.LogLevel_LOG_LEVEL_INFO
+	case level == zapcore.WarnLevel:
+		return notificationpb.LogLevel_LOG_LEVEL_WARN
+	default:
+		return notificationpb.LogLevel_LOG_LEVEL_ERROR
+	}
+}
+
+// ListProviderHealth 查询供应商最近的延迟和错误率
+func (s *AdminServer) ListProviderHealth(_ context.Context, _ *notificationpb.ListProviderHealthRequest) (*notificationpb.ListProviderHealthResponse, error) {
+	snapshot := s.health.Snapshot()
+	resp := &notificationpb.ListProviderHealthResponse{
+		Providers: make([]*notificationpb.ProviderHealth, 0, len(snapshot)),
+	}
+	for _, h := range snapshot {
+		resp.Providers = append(resp.Providers, &notificationpb.ProviderHealth{
+			Name:           h.Name,
+			Samples:        h.Samples,
+			ErrorRate:      h.ErrorRate,
+			LatencyEwmaMs:  h.LatencyEWMA.Milliseconds(),
+			LatencyP95Ms:   h.LatencyP95.Milliseconds(),
+			Healthy:        h.Healthy,
+			Stale:          h.Stale,
+			LastSampleTime: h.LastSampleTime.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
+
+// ListDeadLetterCallbacks 按ID升序分页查询进入死信的回调记录
+func (s *AdminServer) ListDeadLetterCallbacks(ctx context.Context, req *notificationpb.ListDeadLetterCallbacksRequest) (*notificationpb.ListDeadLetterCallbacksResponse, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	if pageSize < 0 || pageSize > maxListPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxListPageSize)
+	}
+	logs, err := s.deadLetters.List(ctx, domain.CallbackDeadLetterFilter{
+		BizID:   req.GetBizId(),
+		AfterID: req.GetAfterId(),
+		// 多查一条判断有没有下一页
+		Limit: pageSize + 1,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("list dead letter callbacks failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list dead letter callbacks")
+	}
+	resp := &notificationpb.ListDeadLetterCallbacksResponse{}
+	if len(logs) > pageSize {
+		logs = logs[:pageSize]
+		resp.NextAfterId = logs[pageSize-1].ID
+	}
+	resp.Callbacks = make([]*notificationpb.DeadLetterCallback, 0, len(logs))
+	for _, l := range logs {
+		resp.Callbacks = append(resp.Callbacks, &notificationpb.DeadLetterCallback{
+			Id:             l.ID,
+			NotificationId: l.Notification.ID,
+			BizId:          l.Notification.BizID,
+			RetryCount:     l.RetryCount,
+			LastError:      l.LastError,
+			Utime:          l.Utime,
+		})
+	}
+	return resp, nil
+}
+
+// ResendDeadLetterCallbacks 死信记录重新开始回调
+func (s *AdminServer) ResendDeadLetterCallbacks(ctx context.Context, req *notificationpb.ResendDeadLetterCallbacksRequest) (*notificationpb.ResendDeadLetterCallbacksResponse, error) {
+	if req.GetBizId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "biz_id is required")
+	}
+	resent, err := s.deadLetters.Resend(ctx, req.GetBizId(), req.GetNotificationIds())
+	if errors.Is(err, domain.ErrInvalidParameter) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("resend dead letter callbacks failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to resend dead letter callbacks")
+	}
+	s.logger.WithContext(ctx).Warn("dead letter callbacks resent",
+		zap.Int64("biz_id", req.GetBizId()), zap.Int64("resent", resent))
+	return &notificationpb.ResendDeadLetterCallbacksResponse{Resent: resent}, nil
+}
+
+// WatchNotification 推送业务方某个 key 的通知状态变化
+func (s *AdminServer) WatchNotification(req *notificationpb.WatchNotificationRequest,
+	stream grpc.ServerStreamingServer[notificationpb.WatchNotificationResponse],
+) error {
+	if s.watchSvc == nil {
+		return status.Error(codes.Unimplemented, "notification watch is disabled")
+	}
+	if req.GetBizId() <= 0 || req.GetKey() == "" {
+		return status.Error(codes.InvalidArgument, "biz_id and key are required")
+	}
+	ctx := stream.Context()
+	err := s.watchSvc.Watch(ctx, req.GetBizId(), req.GetKey(), func(n domain.Notification) error {
+		return stream.Send(&notificationpb.WatchNotificationResponse{
+			NotificationId:     n.ID,
+			BizId:              n.BizID,
+			Key:                n.Key,
+			Channel:            convertChannel(n.Channel),
+			TemplateId:         n.Template.ID,
+			Status:             convertSendStatus(n.Status),
+			FailReason:         n.FailReason.String(),
+			Version:            int32(n.Version),
+			Attempts:           n.Attempts,
+			ScheduledStartTime: n.ScheduledSTime.UnixMilli(),
+			ScheduledEndTime:   n.ScheduledETime.UnixMilli(),
+			ObserveTime:        time.Now().UnixMilli(),
+		})
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrInvalidParameter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrTooManyWatchers):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	default:
+		s.logger.WithContext(ctx).Error("watch notification failed", zap.Int64("biz_id", req.GetBizId()), zap.Error(err))
+		return status.Error(codes.Internal, "failed to watch notification")
+	}
+}
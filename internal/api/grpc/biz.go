@@ -0,0 +1,19 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+)
+
+// defaultBizID 未开启鉴权时使用的业务方ID，仅用于本地开发
+const defaultBizID int64 = 1
+
+// getBizIDFromContext 从上下文中获取 bizID
+// 开启鉴权时使用调用方凭证里的业务方，未开启时返回默认值
+func getBizIDFromContext(ctx context.Context) int64 {
+	if bizID, ok := ctxkit.BizIDFromContext(ctx); ok {
+		return bizID
+	}
+	return defaultBizID
+}
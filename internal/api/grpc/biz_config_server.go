@@ -0,0 +1,192 @@
+package grpc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"time"
+
+	configv1 "github.com/serendipityConfusion/notification-platform/api/gen/config/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/callback"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BizConfigServer 业务方配置，目前只支持回调签名密钥的轮换、回调地址健康状况查询、渠道开关和通知 key 规则
+type BizConfigServer struct {
+	configv1.UnimplementedBusinessConfigServiceServer
+
+	callbackSecretSvc service.CallbackSecretService
+	callbackHealth    *callback.HealthTracker
+	channelSvc        service.BizChannelService
+	keyPolicySvc      service.BizKeyPolicyService
+	logger            log.LoggerInterface
+}
+
+func NewBizConfigServer(callbackSecretSvc service.CallbackSecretService,
+	callbackHealth *callback.HealthTracker,
+	channelSvc service.BizChannelService,
+	keyPolicySvc service.BizKeyPolicyService,
+	logger log.LoggerInterface,
+) *BizConfigServer {
+	return &BizConfigServer{
+		callbackSecretSvc: callbackSecretSvc,
+		callbackHealth:    callbackHealth,
+		channelSvc:        channelSvc,
+		keyPolicySvc:      keyPolicySvc,
+		logger:            log.Named(logger, "grpc.config"),
+	}
+}
+
+// RotateCallbackSecret 轮换业务方的回调签名密钥
+func (s *BizConfigServer) RotateCallbackSecret(ctx context.Context, req *configv1.RotateCallbackSecretRequest) (*configv1.RotateCallbackSecretResponse, error) {
+	bizID := req.GetId()
+	if bizID == 0 {
+		bizID = getBizIDFromContext(ctx)
+	}
+	if caller, ok := ctxkit.CallerFromContext(ctx); ok && !caller.IsPlatformAdmin() && caller.BizID != bizID {
+		return nil, status.Error(codes.PermissionDenied, "cannot rotate callback secret of other biz")
+	}
+	grace := service.DefaultCallbackSecretGracePeriod
+	if req.GracePeriodSeconds != 0 {
+		grace = time.Duration(req.GetGracePeriodSeconds()) * time.Second
+	}
+	secret, err := s.callbackSecretSvc.Rotate(ctx, bizID, grace)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidParameter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithContext(ctx).Error("rotate callback secret failed", zap.Int64("biz_id", bizID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to rotate callback secret")
+	}
+	return &configv1.RotateCallbackSecretResponse{
+		Secret:             secret.Secret,
+		PreviousExpireTime: secret.PreviousExpireAt.UnixMilli(),
+	}, nil
+}
+
+// ListCallbackEndpointHealth 当前实例统计的回调地址健康状况
+func (s *BizConfigServer) ListCallbackEndpointHealth(_ context.Context, req *configv1.ListCallbackEndpointHealthRequest) (*configv1.ListCallbackEndpointHealthResponse, error) {
+	snapshot := s.callbackHealth.Snapshot()
+	resp := &configv1.ListCallbackEndpointHealthResponse{
+		Endpoints: make([]*configv1.CallbackEndpointHealth, 0, len(snapshot)),
+	}
+	for _, e := range snapshot {
+		if req.GetUnhealthyOnly() && e.State == callback.EndpointStateClosed {
+			continue
+		}
+		resp.Endpoints = append(resp.Endpoints, &configv1.CallbackEndpointHealth{
+			Endpoint:            e.Endpoint,
+			Score:               e.Score,
+			State:               string(e.State),
+			ConsecutiveFailures: e.ConsecutiveFailures,
+			Total:               e.Total,
+			Failed:              e.Failed,
+			NextProbeTime:       unixMilliOrZero(e.NextProbeTime),
+			LastFailureTime:     unixMilliOrZero(e.LastFailureTime),
+			LastError:           e.LastError,
+		})
+	}
+	return resp, nil
+}
+
+// ListBizChannels 业务方每个渠道的开关
+func (s *BizConfigServer) ListBizChannels(ctx context.Context, req *configv1.ListBizChannelsRequest) (*configv1.ListBizChannelsResponse, error) {
+	settings, err := s.channelSvc.List(ctx, req.GetId())
+	if err != nil {
+		return nil, s.channelStatus(ctx, err, req.GetId(), "failed to list biz channels")
+	}
+	resp := &configv1.ListBizChannelsResponse{Channels: make([]*configv1.BizChannel, 0, len(settings))}
+	for _, setting := range settings {
+		resp.Channels = append(resp.Channels, s.toBizChannel(setting))
+	}
+	return resp, nil
+}
+
+// SetBizChannel 打开或者关闭业务方的渠道
+func (s *BizConfigServer) SetBizChannel(ctx context.Context, req *configv1.SetBizChannelRequest) (*configv1.SetBizChannelResponse, error) {
+	setting := domain.BizChannelSetting{
+		BizID:   req.GetId(),
+		Channel: domain.Channel(req.GetChannel()),
+		Enabled: req.GetEnabled(),
+		Reason:  req.GetReason(),
+	}
+	if caller, ok := ctxkit.CallerFromContext(ctx); ok {
+		setting.Operator = caller.Subject
+	}
+	if err := s.channelSvc.SetEnabled(ctx, setting); err != nil {
+		return nil, s.channelStatus(ctx, err, req.GetId(), "failed to set biz channel")
+	}
+	setting.Utime = time.Now().UnixMilli()
+	return &configv1.SetBizChannelResponse{Channel: s.toBizChannel(setting)}, nil
+}
+
+// GetKeyPolicy 业务方通知 key 的规则
+func (s *BizConfigServer) GetKeyPolicy(ctx context.Context, req *configv1.GetKeyPolicyRequest) (*configv1.GetKeyPolicyResponse, error) {
+	policy, err := s.keyPolicySvc.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, s.channelStatus(ctx, err, req.GetId(), "failed to get key policy")
+	}
+	return &configv1.GetKeyPolicyResponse{Policy: s.toKeyPolicy(policy)}, nil
+}
+
+// SetKeyPolicy 修改业务方通知 key 的规则，整条规则覆盖
+func (s *BizConfigServer) SetKeyPolicy(ctx context.Context, req *configv1.SetKeyPolicyRequest) (*configv1.SetKeyPolicyResponse, error) {
+	policy := domain.NotificationKeyPolicy{
+		BizID:          req.GetId(),
+		MaxLength:      int(req.GetPolicy().GetMaxLength()),
+		Charset:        domain.KeyCharset(req.GetPolicy().GetCharset()),
+		RequiredPrefix: req.GetPolicy().GetRequiredPrefix(),
+	}
+	if caller, ok := ctxkit.CallerFromContext(ctx); ok {
+		policy.Operator = caller.Subject
+	}
+	if err := s.keyPolicySvc.Set(ctx, policy); err != nil {
+		return nil, s.channelStatus(ctx, err, req.GetId(), "failed to set key policy")
+	}
+	// 和 GetKeyPolicy 一样返回补全默认值之后的规则
+	policy.MaxLength = policy.Limit()
+	policy.Charset = cmp.Or(policy.Charset, domain.KeyCharsetAny)
+	policy.Utime = time.Now().UnixMilli()
+	return &configv1.SetKeyPolicyResponse{Policy: s.toKeyPolicy(policy)}, nil
+}
+
+func (s *BizConfigServer) toKeyPolicy(policy domain.NotificationKeyPolicy) *configv1.KeyPolicy {
+	return &configv1.KeyPolicy{
+		MaxLength:      int32(policy.MaxLength),
+		Charset:        policy.Charset.String(),
+		RequiredPrefix: policy.RequiredPrefix,
+		Operator:       policy.Operator,
+		Utime:          policy.Utime,
+	}
+}
+
+func (s *BizConfigServer) channelStatus(ctx context.Context, err error, bizID int64, msg string) error {
+	if errors.Is(err, domain.ErrInvalidParameter) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.logger.WithContext(ctx).Error(msg, zap.Int64("biz_id", bizID), zap.Error(err))
+	return status.Error(codes.Internal, msg)
+}
+
+func (s *BizConfigServer) toBizChannel(setting domain.BizChannelSetting) *configv1.BizChannel {
+	return &configv1.BizChannel{
+		Channel:  setting.Channel.String(),
+		Enabled:  setting.Enabled,
+		Operator: setting.Operator,
+		Reason:   setting.Reason,
+		Utime:    setting.Utime,
+	}
+}
+
+func unixMilliOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DataPrivacyServer struct {
+	notificationpb.UnimplementedDataPrivacyServiceServer
+
+	retentionSvc service.DataRetentionService
+	exportSvc    service.NotificationExportService
+	logger       log.LoggerInterface
+}
+
+func NewDataPrivacyServer(retentionSvc service.DataRetentionService, exportSvc service.NotificationExportService,
+	logger log.LoggerInterface,
+) *DataPrivacyServer {
+	return &DataPrivacyServer{
+		retentionSvc: retentionSvc,
+		exportSvc:    exportSvc,
+		logger:       log.Named(logger, "grpc.privacy"),
+	}
+}
+
+// EraseReceiverData 擦除业务方名下某个接收者的全部通知数据
+func (s *DataPrivacyServer) EraseReceiverData(ctx context.Context, req *notificationpb.EraseReceiverDataRequest) (*notificationpb.EraseReceiverDataResponse, error) {
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	erasure, err := s.retentionSvc.EraseReceiverData(ctx, bizID, req.GetReceiver(), req.GetOperator(), req.GetReason())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidParameter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		// 不记录接收者本身，避免擦除的数据出现在日志里
+		s.logger.WithContext(ctx).Error("erase receiver data failed",
+			zap.Int64("biz_id", bizID),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to erase receiver data")
+	}
+	return &notificationpb.EraseReceiverDataResponse{
+		ErasureId:             erasure.ID,
+		AffectedNotifications: erasure.AffectedNotifications,
+	}, nil
+}
+
+// ExportNotifications 流式导出业务方的通知
+func (s *DataPrivacyServer) ExportNotifications(req *notificationpb.ExportNotificationsRequest,
+	stream grpc.ServerStreamingServer[notificationpb.ExportNotificationsResponse],
+) error {
+	ctx := stream.Context()
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	query, err := convertExportQuery(bizID, req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	// 明文导出个人信息需要 pii:read 权限，没有开启鉴权时不限制
+	if caller, ok := ctxkit.CallerFromContext(ctx); ok && query.Redaction == domain.ExportRedactionNone &&
+		!caller.Role.HasPermission(domain.PermissionPIIRead) {
+		return status.Errorf(codes.PermissionDenied, "role %s has no permission %s", caller.Role, domain.PermissionPIIRead)
+	}
+	err = s.exportSvc.Export(ctx, query, func(chunk domain.ExportChunk) error {
+		return stream.Send(&notificationpb.ExportNotificationsResponse{
+			Data:   chunk.Data,
+			Rows:   int32(chunk.Rows),
+			Cursor: chunk.Cursor,
+		})
+	})
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, domain.ErrInvalidParameter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrTooManyExports):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err()
+	default:
+		s.logger.WithContext(ctx).Error("export notifications failed", zap.Int64("biz_id", bizID), zap.Error(err))
+		return status.Error(codes.Internal, "failed to export notifications")
+	}
+}
+
+func convertExportQuery(bizID int64, req *notificationpb.ExportNotificationsRequest) (domain.NotificationExportQuery, error) {
+	cursor, err := domain.DecodeExportCursor(req.GetCursor())
+	if err != nil {
+		return domain.NotificationExportQuery{}, err
+	}
+	query := domain.NotificationExportQuery{
+		BizID:     bizID,
+		Start:     time.UnixMilli(req.GetStartTime()),
+		End:       time.UnixMilli(req.GetEndTime()),
+		Format:    domain.ExportFormatCSV,
+		Redaction: domain.ExportRedactionMask,
+		Cursor:    cursor,
+	}
+	switch req.GetFormat() {
+	case notificationpb.ExportFormat_EXPORT_FORMAT_UNSPECIFIED, notificationpb.ExportFormat_EXPORT_FORMAT_CSV:
+	case notificationpb.ExportFormat_EXPORT_FORMAT_JSONL:
+		query.Format = domain.ExportFormatJSONL
+	default:
+		query.Format = domain.ExportFormat(req.GetFormat().String())
+	}
+	switch req.GetRedaction() {
+	case notificationpb.ExportRedaction_EXPORT_REDACTION_UNSPECIFIED, notificationpb.ExportRedaction_EXPORT_REDACTION_MASK:
+	case notificationpb.ExportRedaction_EXPORT_REDACTION_OMIT:
+		query.Redaction = domain.ExportRedactionOmit
+	case notificationpb.ExportRedaction_EXPORT_REDACTION_NONE:
+		query.Redaction = domain.ExportRedactionNone
+	default:
+		query.Redaction = domain.ExportRedaction(req.GetRedaction().String())
+	}
+	return query, query.Validate()
+}
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/database/failover"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/workpool"
+)
+
+// DebugServer 线上排查问题用的运行时信息
+type DebugServer struct {
+	notificationpb.UnimplementedDebugServiceServer
+
+	instance  string
+	startTime time.Time
+}
+
+func NewDebugServer() *DebugServer {
+	host, _ := os.Hostname()
+	return &DebugServer{
+		instance:  fmt.Sprintf("%s-%d", host, os.Getpid()),
+		startTime: time.Now(),
+	}
+}
+
+// GetRuntimeInfo 进程的运行时信息、发送协程池的队列情况和数据库连接状态
+func (s *DebugServer) GetRuntimeInfo(_ context.Context, _ *notificationpb.GetRuntimeInfoRequest) (*notificationpb.GetRuntimeInfoResponse, error) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	resp := &notificationpb.GetRuntimeInfoResponse{
+		Instance:       s.instance,
+		GoVersion:      runtime.Version(),
+		StartTime:      s.startTime.UnixMilli(),
+		Goroutines:     int32(runtime.NumGoroutine()),
+		Gomaxprocs:     int32(runtime.GOMAXPROCS(0)),
+		NumCpu:         int32(runtime.NumCPU()),
+		HeapAllocBytes: mem.HeapAlloc,
+		SysBytes:       mem.Sys,
+		NumGc:          mem.NumGC,
+	}
+	if mem.NumGC > 0 {
+		resp.LastGcPauseUs = mem.PauseNs[(mem.NumGC+255)%256] / 1000
+	}
+	for _, p := range workpool.Snapshot() {
+		resp.WorkPools = append(resp.WorkPools, &notificationpb.WorkPoolState{
+			Name:          p.Name,
+			Workers:       int32(p.Workers),
+			BusyWorkers:   p.BusyWorkers,
+			QueueDepth:    int32(p.QueueDepth),
+			QueueCapacity: int32(p.QueueCapacity),
+		})
+	}
+	for _, d := range failover.Snapshot() {
+		state := &notificationpb.DatabaseState{
+			Name:             d.Name,
+			Degraded:         d.Degraded,
+			ActiveDsn:        int32(d.ActiveDSN),
+			DsnCount:         int32(d.DSNCount),
+			Failovers:        d.Failovers,
+			ConnectionErrors: d.ConnectionErrors,
+			LastError:        d.LastError,
+		}
+		if !d.LastErrorTime.IsZero() {
+			state.LastErrorTime = d.LastErrorTime.UnixMilli()
+		}
+		resp.Databases = append(resp.Databases, state)
+	}
+	return resp, nil
+}
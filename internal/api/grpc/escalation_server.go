@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EscalationServer 跨渠道升级链
+type EscalationServer struct {
+	notificationpb.UnimplementedEscalationServiceServer
+
+	escalationSvc service.EscalationService
+	logger        log.LoggerInterface
+}
+
+func NewEscalationServer(escalationSvc service.EscalationService, logger log.LoggerInterface) *EscalationServer {
+	return &EscalationServer{
+		escalationSvc: escalationSvc,
+		logger:        log.Named(logger, "grpc.escalation"),
+	}
+}
+
+// CreateEscalation 创建升级链并立即发送第一步
+func (s *EscalationServer) CreateEscalation(ctx context.Context, req *notificationpb.CreateEscalationRequest) (*notificationpb.CreateEscalationResponse, error) {
+	if ctxkit.EnvironmentFromContext(ctx).IsSandbox() {
+		return nil, status.Error(codes.InvalidArgument, "sandbox credentials cannot create escalations")
+	}
+	e := domain.Escalation{
+		BizID: getBizIDFromContext(ctx),
+		Key:   req.GetKey(),
+		Steps: make([]domain.EscalationStep, 0, len(req.GetSteps())),
+	}
+	for _, step := range req.GetSteps() {
+		e.Steps = append(e.Steps, domain.EscalationStep{
+			Channel:        domain.Channel(step.GetChannel().String()),
+			Receivers:      step.GetReceivers(),
+			TemplateID:     step.GetTemplateId(),
+			TemplateParams: step.GetTemplateParams(),
+			Wait:           time.Duration(step.GetWaitSeconds()) * time.Second,
+		})
+	}
+	res, created, err := s.escalationSvc.Create(ctx, e)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to create escalation")
+	}
+	return &notificationpb.CreateEscalationResponse{
+		Escalation: convertEscalation(res),
+		Duplicate:  !created,
+	}, nil
+}
+
+// AcknowledgeEscalation 确认，之后不再升级
+func (s *EscalationServer) AcknowledgeEscalation(ctx context.Context, req *notificationpb.AcknowledgeEscalationRequest) (*notificationpb.AcknowledgeEscalationResponse, error) {
+	res, err := s.escalationSvc.Acknowledge(ctx, getBizIDFromContext(ctx), req.GetKey(), req.GetBy())
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to acknowledge escalation")
+	}
+	return &notificationpb.AcknowledgeEscalationResponse{Escalation: convertEscalation(res)}, nil
+}
+
+// GetEscalation 查询升级链的进度
+func (s *EscalationServer) GetEscalation(ctx context.Context, req *notificationpb.GetEscalationRequest) (*notificationpb.GetEscalationResponse, error) {
+	res, err := s.escalationSvc.Get(ctx, getBizIDFromContext(ctx), req.GetKey())
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get escalation")
+	}
+	return &notificationpb.GetEscalationResponse{Escalation: convertEscalation(res)}, nil
+}
+
+// convertEscalation 不返回接收者和模板参数
+func convertEscalation(e domain.Escalation) *notificationpb.Escalation {
+	steps := make([]*notificationpb.EscalationStep, 0, len(e.Steps))
+	for _, step := range e.Steps {
+		steps = append(steps, &notificationpb.EscalationStep{
+			Channel:     convertChannel(step.Channel),
+			TemplateId:  step.TemplateID,
+			WaitSeconds: int64(step.Wait / time.Second),
+		})
+	}
+	res := &notificationpb.Escalation{
+		Key:         e.Key,
+		Steps:       steps,
+		CurrentStep: int32(e.CurrentStep),
+		Status:      convertEscalationStatus(e.Status),
+		AckBy:       e.AckBy,
+	}
+	if !e.IsFinished() {
+		res.NextStepTime = e.NextStepTime.UnixMilli()
+	}
+	if !e.AckTime.IsZero() {
+		res.AckTime = e.AckTime.UnixMilli()
+	}
+	return res
+}
+
+func convertEscalationStatus(s domain.EscalationStatus) notificationpb.EscalationStatus {
+	switch s {
+	case domain.EscalationStatusActive:
+		return notificationpb.EscalationStatus_ACTIVE
+	case domain.EscalationStatusAcknowledged:
+		return notificationpb.EscalationStatus_ACKNOWLEDGED
+	case domain.EscalationStatusExhausted:
+		return notificationpb.EscalationStatus_EXHAUSTED
+	default:
+		return notificationpb.EscalationStatus_ESCALATION_STATUS_UNSPECIFIED
+	}
+}
+
+func (s *EscalationServer) toStatus(ctx context.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidParameter),
+		errors.Is(err, domain.ErrTemplateNotFound),
+		errors.Is(err, domain.ErrTemplateVersionNotFound):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrEscalationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrEscalationFinished),
+		errors.Is(err, domain.ErrTemplateVersionNotApprovedByPlatform),
+		errors.Is(err, domain.ErrTemplateVersionNotApprovedByProvider):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrEscalationChanged):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	s.logger.WithContext(ctx).Error(msg, zap.Int64("biz_id", getBizIDFromContext(ctx)), zap.Error(err))
+	return status.Error(codes.Internal, msg)
+}
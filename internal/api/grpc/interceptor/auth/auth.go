@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	bearerPrefix     = "Bearer "
+)
+
+// Claims 调用方凭证，sub 是调用方唯一标识，biz_id 是调用方所属业务方
+// env 是凭证所属的环境，PRODUCTION 或 SANDBOX，为空是生产环境
+type Claims struct {
+	BizID int64  `json:"biz_id"`
+	Env   string `json:"env,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Environment 凭证所属的环境
+func (c *Claims) Environment() domain.Environment {
+	if c.Env == "" {
+		return domain.EnvironmentProduction
+	}
+	return domain.Environment(c.Env)
+}
+
+// Resolver 根据凭证里的身份确定调用方的角色
+type Resolver interface {
+	Resolve(ctx context.Context, subject string, bizID int64) (domain.Principal, error)
+}
+
+// Builder 鉴权拦截器构建器
+type Builder struct {
+	key         []byte
+	resolver    Resolver
+	permissions map[string]domain.Permission
+	logger      log.LoggerInterface
+}
+
+// New 创建鉴权拦截器构建器
+// key 是 HS256 签名密钥，permissions 是接口全名到权限的映射，没有配置权限的接口一律拒绝
+func New(key []byte, resolver Resolver, permissions map[string]domain.Permission) *Builder {
+	return &Builder{
+		key:         key,
+		resolver:    resolver,
+		permissions: permissions,
+		logger:      log.Named(log.DefaultLogger(), "grpc.auth"),
+	}
+}
+
+// WithLogger 设置日志组件
+func (b *Builder) WithLogger(logger log.LoggerInterface) *Builder {
+	b.logger = logger
+	return b
+}
+
+// Build 构建 gRPC 一元拦截器
+func (b *Builder) Build() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		principal, err := b.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctxkit.WithCaller(ctx, principal), req)
+	}
+}
+
+// BuildStream 构建 gRPC 流式拦截器，和一元拦截器使用同一份权限配置
+func (b *Builder) BuildStream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		principal, err := b.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &callerStream{ServerStream: ss, ctx: ctxkit.WithCaller(ss.Context(), principal)})
+	}
+}
+
+// authorize 校验凭证和接口权限，返回 gRPC 状态错误
+func (b *Builder) authorize(ctx context.Context, method string) (domain.Principal, error) {
+	perm, ok := b.permissions[method]
+	if !ok {
+		return domain.Principal{}, status.Errorf(codes.PermissionDenied, "method %s is not allowed", method)
+	}
+	principal, err := b.Authenticate(ctx, authorization(ctx))
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return domain.Principal{}, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			return domain.Principal{}, status.Error(codes.PermissionDenied, "no role assigned")
+		}
+		return domain.Principal{}, status.Error(codes.Internal, "failed to authorize")
+	}
+	if !principal.Role.HasPermission(perm) {
+		return domain.Principal{}, status.Errorf(codes.PermissionDenied, "role %s has no permission %s", principal.Role, perm)
+	}
+	return principal, nil
+}
+
+// callerStream 把调用方放进流的上下文
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// Authenticate 校验 Bearer 凭证并确定调用方的角色，HTTP 入口也用它鉴权
+// 凭证无效返回 domain.ErrUnauthenticated，没有分配角色返回 domain.ErrPermissionDenied
+func (b *Builder) Authenticate(ctx context.Context, authorization string) (domain.Principal, error) {
+	claims, err := b.parse(authorization)
+	if err != nil {
+		return domain.Principal{}, err
+	}
+	principal, err := b.resolver.Resolve(ctx, claims.Subject, claims.BizID)
+	if err != nil && !errors.Is(err, domain.ErrPermissionDenied) {
+		b.logger.WithContext(ctx).Error("resolve principal failed",
+			zap.String("subject", claims.Subject),
+			zap.Int64("biz_id", claims.BizID),
+			zap.Error(err))
+	}
+	principal.Environment = claims.Environment()
+	return principal, err
+}
+
+func authorization(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (b *Builder) parse(authorization string) (*Claims, error) {
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return nil, domain.ErrUnauthenticated
+	}
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(authorization, bearerPrefix), claims,
+		func(*jwt.Token) (any, error) { return b.key, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+	)
+	if err != nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	if claims.Subject == "" || !claims.Environment().IsValid() {
+		return nil, domain.ErrUnauthenticated
+	}
+	return claims, nil
+}
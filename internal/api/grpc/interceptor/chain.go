@@ -0,0 +1,104 @@
+package interceptor
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/grpc"
+)
+
+// Factory 创建一个拦截器，没有流式版本时 stream 返回 nil
+type Factory func() (unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor)
+
+type entry struct {
+	name    string
+	factory Factory
+}
+
+// ChainBuilder 按配置的顺序组装拦截器链，拦截器先登记，再按名称选择和排序
+type ChainBuilder struct {
+	entries  []entry
+	required []string
+	first    string
+}
+
+func NewChainBuilder() *ChainBuilder {
+	return &ChainBuilder{}
+}
+
+// Register 登记拦截器，登记的顺序就是没有配置顺序时的默认顺序
+func (b *ChainBuilder) Register(name string, factory Factory) *ChainBuilder {
+	b.entries = append(b.entries, entry{name: name, factory: factory})
+	return b
+}
+
+// Require 配置的顺序里必须包含这些拦截器
+func (b *ChainBuilder) Require(names ...string) *ChainBuilder {
+	b.required = append(b.required, names...)
+	return b
+}
+
+// First 配置的顺序里这个拦截器必须排在第一个，后面的拦截器依赖它准备的上下文
+func (b *ChainBuilder) First(name string) *ChainBuilder {
+	b.first = name
+	return b
+}
+
+// Names 登记的所有拦截器，按默认顺序
+func (b *ChainBuilder) Names() []string {
+	names := make([]string, 0, len(b.entries))
+	for _, e := range b.entries {
+		names = append(names, e.name)
+	}
+	return names
+}
+
+// Validate 校验配置的顺序：名称必须登记过、不能重复、必须包含必需的拦截器，order 为空时使用默认顺序
+func (b *ChainBuilder) Validate(order []string) error {
+	if len(order) == 0 {
+		return nil
+	}
+	known := b.Names()
+	for i, name := range order {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("拦截器 %q 不存在，可选的拦截器: %v", name, known)
+		}
+		if slices.Contains(order[:i], name) {
+			return fmt.Errorf("拦截器 %q 重复配置", name)
+		}
+	}
+	for _, name := range b.required {
+		if !slices.Contains(order, name) {
+			return fmt.Errorf("缺少必需的拦截器 %q", name)
+		}
+	}
+	if b.first != "" && order[0] != b.first {
+		return fmt.Errorf("拦截器 %q 必须排在第一个", b.first)
+	}
+	return nil
+}
+
+// Build 按 order 创建拦截器链，order 为空时使用默认顺序
+func (b *ChainBuilder) Build(order []string) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	if err := b.Validate(order); err != nil {
+		return nil, nil, err
+	}
+	if len(order) == 0 {
+		order = b.Names()
+	}
+	var (
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	)
+	for _, name := range order {
+		i := slices.IndexFunc(b.entries, func(e entry) bool { return e.name == name })
+		u, s := b.entries[i].factory()
+		if u != nil {
+			unary = append(unary, u)
+		}
+		if s != nil {
+			stream = append(stream, s)
+		}
+	}
+	return unary, stream, nil
+}
@@ -3,6 +3,8 @@ package log
 import (
 	"context"
 	"encoding/json"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
 	"go.uber.org/zap"
 	"time"
@@ -15,12 +17,13 @@ import (
 // Builder 日志拦截器构建器
 type Builder struct {
 	logger log.LoggerInterface
+	flags  *featureflag.Flags
 }
 
 // New 创建日志拦截器构建器
 func New() *Builder {
 	return &Builder{
-		logger: log.DefaultLogger(),
+		logger: log.Named(log.DefaultLogger(), "grpc.access"),
 	}
 }
 
@@ -30,6 +33,22 @@ func (b *Builder) WithLogger(logger log.LoggerInterface) *Builder {
 	return b
 }
 
+// WithFlags 按功能开关 access-log-payload 决定是否记录请求和响应的内容，没有设置时都记录
+// 鉴权之前上下文里没有业务方，只有把 auth 排在 log 前面时才能按业务方定向
+func (b *Builder) WithFlags(flags *featureflag.Flags) *Builder {
+	b.flags = flags
+	return b
+}
+
+// payload 不记录内容时返回空字符串
+func (b *Builder) payload(ctx context.Context, v any) string {
+	if !b.flags.EnabledContext(ctx, featureflag.AccessLogPayload) {
+		return ""
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
 // Build 构建 gRPC 一元拦截器
 func (b *Builder) Build() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
@@ -37,10 +56,12 @@ func (b *Builder) Build() grpc.UnaryServerInterceptor {
 		startTime := time.Now()
 
 		// 将请求对象转为 JSON 字符串进行记录
-		reqJSON, _ := json.Marshal(req)
-		b.logger.Info("gRPC request",
+		reqJSON := b.payload(ctx, req)
+		requestID, _ := ctxkit.RequestIDFromContext(ctx)
+		b.logger.WithContext(ctx).Info("gRPC request",
 			zap.String("method", info.FullMethod),
-			zap.String("request", string(reqJSON)),
+			zap.String("request_id", requestID),
+			zap.String("request", reqJSON),
 			zap.Any("start_time", startTime))
 
 		// 处理请求
@@ -54,22 +75,24 @@ func (b *Builder) Build() grpc.UnaryServerInterceptor {
 		statusCode := st.Code()
 
 		// 将响应对象转为 JSON 字符串进行记录
-		respJSON, _ := json.Marshal(resp)
+		respJSON := b.payload(ctx, resp)
 
 		if err != nil {
 			// 如果有错误，记录错误日志
-			b.logger.Error("gRPC response with error",
+			b.logger.WithContext(ctx).Error("gRPC response with error",
 				zap.String("method", info.FullMethod),
+				zap.String("request_id", requestID),
 				zap.String("status_code", statusCode.String()),
-				zap.String("response", string(respJSON)),
+				zap.String("response", respJSON),
 				zap.Duration("duration", duration),
 				zap.Any("error", err))
 		} else {
 			// 记录成功响应日志
-			b.logger.Info("gRPC response",
+			b.logger.WithContext(ctx).Info("gRPC response",
 				zap.String("method", info.FullMethod),
+				zap.String("request_id", requestID),
 				zap.String("status_code", codes.OK.String()),
-				zap.String("response", string(respJSON)),
+				zap.String("response", respJSON),
 				zap.Duration("duration", duration))
 		}
 
@@ -0,0 +1,142 @@
+package querylimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var rejectedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "grpc_query_limit_rejected_total",
+	Help: "Total number of query requests rejected by the per-biz method limits",
+}, []string{"method", "reason"})
+
+func init() {
+	prometheus.MustRegister(rejectedCounter)
+}
+
+// Counter 请求要查询的条数，例如批量查询的 key 数量、分页大小
+type Counter func(req any) int
+
+// Limit 一个查询接口的限制，为 0 的限制不生效
+type Limit struct {
+	// Rate 每个业务方每秒的请求数
+	Rate float64
+	// Burst 允许的突发请求数，为 0 时使用 Rate 向上取整
+	Burst int
+	// MaxItems 单个请求最多查询的条数，只对有 Counter 的接口生效
+	MaxItems int
+}
+
+type limitKey struct {
+	method string
+	bizID  int64
+}
+
+// Builder 查询接口的限流和单次查询条数上限，和发送额度分开，避免看板这类大量查询影响发送
+// 按业务方和接口分别限流，限流状态只在当前实例的内存里，集群总的限制是单个实例的限制乘以实例数
+type Builder struct {
+	counters map[string]Counter
+	limits   map[limitKey]Limit
+	now      func() time.Time
+
+	mu      sync.Mutex
+	buckets map[limitKey]*bucket
+}
+
+// New counters 是可以限制查询条数的接口，key 是完整方法名
+func New(counters map[string]Counter) *Builder {
+	return &Builder{
+		counters: counters,
+		limits:   make(map[limitKey]Limit),
+		now:      time.Now,
+		buckets:  make(map[limitKey]*bucket),
+	}
+}
+
+// WithLimit 设置接口的限制，bizID 为 0 对所有业务方生效，单独配置了业务方的优先
+// 每个业务方有自己的令牌桶，不会互相影响
+func (b *Builder) WithLimit(method string, bizID int64, limit Limit) *Builder {
+	b.limits[limitKey{method: method, bizID: bizID}] = limit
+	return b
+}
+
+// Limit 业务方调用接口时生效的限制
+func (b *Builder) Limit(method string, bizID int64) (Limit, bool) {
+	if l, ok := b.limits[limitKey{method: method, bizID: bizID}]; ok {
+		return l, true
+	}
+	l, ok := b.limits[limitKey{method: method}]
+	return l, ok
+}
+
+func (b *Builder) Build() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		// 没有开启鉴权时所有请求共用一个令牌桶
+		bizID, _ := ctxkit.BizIDFromContext(ctx)
+		limit, ok := b.Limit(info.FullMethod, bizID)
+		if !ok {
+			return handler(ctx, req)
+		}
+		if counter := b.counters[info.FullMethod]; limit.MaxItems > 0 && counter != nil {
+			if n := counter(req); n > limit.MaxItems {
+				rejectedCounter.WithLabelValues(info.FullMethod, "items").Inc()
+				return nil, status.Errorf(codes.InvalidArgument, "at most %d items per request, got %d", limit.MaxItems, n)
+			}
+		}
+		if limit.Rate > 0 && !b.allow(limitKey{method: info.FullMethod, bizID: bizID}, limit) {
+			rejectedCounter.WithLabelValues(info.FullMethod, "rate").Inc()
+			return nil, status.Errorf(codes.ResourceExhausted, "query rate limit exceeded, at most %g requests per second", limit.Rate)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (b *Builder) allow(key limitKey, limit Limit) bool {
+	b.mu.Lock()
+	bk, ok := b.buckets[key]
+	if !ok {
+		bk = newBucket(limit, b.now())
+		b.buckets[key] = bk
+	}
+	b.mu.Unlock()
+	return bk.allow(b.now())
+}
+
+// bucket 令牌桶，按经过的时间补充令牌
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit Limit, now time.Time) *bucket {
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = math.Ceil(limit.Rate)
+	}
+	return &bucket{rate: limit.Rate, burst: burst, tokens: burst, last: now}
+}
+
+func (b *bucket) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,64 @@
+package requestctx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RequestIDKey 请求ID的元数据键，调用方没有传时生成一个，并通过响应头返回
+	RequestIDKey = "x-request-id"
+	// PriorityKey 请求优先级的元数据键，取值 high 或 normal
+	PriorityKey = "x-priority"
+)
+
+// UnaryServerInterceptor 把请求ID和优先级从元数据放进上下文，需要放在其他拦截器前面
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, requestID := withRequest(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 流式接口的版本
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequest(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(RequestIDKey, requestID))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withRequest(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := first(md, RequestIDKey)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	ctx = ctxkit.WithRequestID(ctx, requestID)
+	if p := ctxkit.Priority(first(md, PriorityKey)); p.IsValid() {
+		ctx = ctxkit.WithPriority(ctx, p)
+	}
+	return ctx, requestID
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
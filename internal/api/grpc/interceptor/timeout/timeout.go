@@ -0,0 +1,54 @@
+package timeout
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Builder 服务端超时拦截器，调用方没有传截止时间或者截止时间比服务端的超时时间还长时，使用服务端的超时时间
+// 避免调用方不设置截止时间时，慢查询一直占着数据库连接
+type Builder struct {
+	defaultTimeout time.Duration
+	methods        map[string]time.Duration
+}
+
+// New defaultTimeout 小于等于 0 时，没有单独配置的方法不设置超时
+func New(defaultTimeout time.Duration) *Builder {
+	return &Builder{
+		defaultTimeout: defaultTimeout,
+		methods:        make(map[string]time.Duration),
+	}
+}
+
+// WithMethod 单独设置某个方法的超时时间，method 是完整方法名，例如 /notification.v1.NotificationService/SendNotification
+// timeout 小于等于 0 表示这个方法不设置超时
+func (b *Builder) WithMethod(method string, timeout time.Duration) *Builder {
+	b.methods[method] = timeout
+	return b
+}
+
+// Timeout 方法的超时时间，小于等于 0 表示不设置
+func (b *Builder) Timeout(method string) time.Duration {
+	if t, ok := b.methods[method]; ok {
+		return t
+	}
+	return b.defaultTimeout
+}
+
+func (b *Builder) Build() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		t := b.Timeout(info.FullMethod)
+		if t <= 0 {
+			return handler(ctx, req)
+		}
+		// 调用方的截止时间更早时保留调用方的
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= t {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, t)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
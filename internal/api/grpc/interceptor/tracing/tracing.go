@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -25,6 +26,11 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	tracer := otel.GetTracerProvider().Tracer(instrumentationName)
 
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// 客户端通过 metadata 传过来的链路上下文，有的话这次调用的 span 挂在客户端的 span 下面
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+		}
+
 		// 从gRPC方法名称中提取服务和方法名
 		fullMethod := info.FullMethod
 		serviceName, methodName := extractNames(fullMethod)
@@ -42,6 +48,9 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 			),
 		)
 		defer span.End()
+		if requestID, ok := ctxkit.RequestIDFromContext(ctx); ok {
+			span.SetAttributes(attribute.String("rpc.request_id", requestID))
+		}
 
 		// 添加请求元数据作为span的属性
 		if md, ok := metadata.FromIncomingContext(ctx); ok {
@@ -91,3 +100,25 @@ func isTracingRelevantMetadata(key string) bool {
 
 	return relevantKeys[key]
 }
+
+// metadataCarrier 让 propagator 读取 gRPC metadata，键都是小写
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
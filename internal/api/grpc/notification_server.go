@@ -2,29 +2,82 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
 	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
 	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// errDigestNotSupported、errLocalTimeNotSupported、errPacedNotSupported、errFallbackNotSupported 同步发送和事务消息只支持普通的发送策略
+var (
+	errDigestNotSupported        = fmt.Errorf("%w: 合并发送只支持异步发送", domain.ErrInvalidParameter)
+	errSandboxDigestNotSupported = fmt.Errorf("%w: 沙箱环境不支持合并发送", domain.ErrInvalidParameter)
+	errLocalTimeNotSupported     = fmt.Errorf("%w: 按本地时间发送只支持异步发送", domain.ErrInvalidParameter)
+	errPacedNotSupported         = fmt.Errorf("%w: 限速发送只支持异步发送", domain.ErrInvalidParameter)
+	errFallbackNotSupported      = fmt.Errorf("%w: 渠道降级分组只支持异步发送", domain.ErrInvalidParameter)
+)
+
 type NotificationServer struct {
 	notificationpb.UnimplementedNotificationServiceServer
 	notificationpb.UnimplementedNotificationQueryServiceServer
 
-	repo   repository.NotificationRepository
-	logger log.LoggerInterface
+	repo         repository.NotificationRepository
+	templateSvc  service.ChannelTemplateService
+	digestSvc    service.DigestService
+	localTimeSvc service.LocalTimeService
+	pacingSvc    service.PacingService
+	fallbackSvc  service.FallbackService
+	idGenerator  idgen.Generator
+	dryRunSvc    service.DryRunService
+	quotaSvc     service.QuotaPrecheckService
+	channelSvc   service.BizChannelService
+	keyPolicySvc service.BizKeyPolicyService
+	// labelMetrics 按标签统计，为 nil 不统计
+	labelMetrics *service.LabelMetrics
+	clock        clock.Clock
+	logger       log.LoggerInterface
 }
 
-func NewServer(repo repository.NotificationRepository, logger log.LoggerInterface) *NotificationServer {
+func NewServer(repo repository.NotificationRepository, templateSvc service.ChannelTemplateService,
+	digestSvc service.DigestService, localTimeSvc service.LocalTimeService, pacingSvc service.PacingService,
+	fallbackSvc service.FallbackService, idGenerator idgen.Generator, dryRunSvc service.DryRunService,
+	quotaSvc service.QuotaPrecheckService, channelSvc service.BizChannelService, keyPolicySvc service.BizKeyPolicyService,
+	labelMetrics *service.LabelMetrics, clk clock.Clock, logger log.LoggerInterface,
+) *NotificationServer {
 	return &NotificationServer{
-		repo:   repo,
-		logger: logger,
+		repo:         repo,
+		templateSvc:  templateSvc,
+		digestSvc:    digestSvc,
+		localTimeSvc: localTimeSvc,
+		pacingSvc:    pacingSvc,
+		fallbackSvc:  fallbackSvc,
+		idGenerator:  idGenerator,
+		dryRunSvc:    dryRunSvc,
+		quotaSvc:     quotaSvc,
+		channelSvc:   channelSvc,
+		keyPolicySvc: keyPolicySvc,
+		labelMetrics: labelMetrics,
+		clock:        clk,
+		logger:       log.Named(logger, "grpc.notification"),
+	}
+}
+
+// NewQueryServer 只读查询角色使用，只有查询接口需要的依赖，只能注册 NotificationQueryService
+func NewQueryServer(repo repository.NotificationRepository, fallbackSvc service.FallbackService, logger log.LoggerInterface) *NotificationServer {
+	return &NotificationServer{
+		repo:        repo,
+		fallbackSvc: fallbackSvc,
+		logger:      log.Named(logger, "grpc.notification.query"),
 	}
 }
 
@@ -34,48 +87,58 @@ func (s *NotificationServer) SendNotification(ctx context.Context, req *notifica
 	if req.GetNotification() == nil {
 		return nil, status.Error(codes.InvalidArgument, "notification is required")
 	}
+	if req.GetDryRun() {
+		return s.dryRun(ctx, []*notificationpb.Notification{req.Notification})[0], nil
+	}
 
 	// 转换为领域模型
 	notification, err := s.convertToDomainNotification(ctx, req.Notification)
 	if err != nil {
-		s.logger.Error("convert to domain notification failed", zap.Error(err))
-		return s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, err.Error()), nil
+		s.logger.WithContext(ctx).Error("convert to domain notification failed", zap.Error(err))
+		return s.buildErrorResponse(0, s.convertErrorCode(err, notificationpb.ErrorCode_INVALID_PARAMETER), err.Error()), nil
 	}
 
 	// 验证通知
-	if err := notification.Validate(); err != nil {
-		s.logger.Error("validate notification failed", zap.Error(err))
+	if err := notification.Validate(s.clock.Now()); err != nil {
+		s.logger.WithContext(ctx).Error("validate notification failed", zap.Error(err))
 		return s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, err.Error()), nil
 	}
+	if notification.IsDigest() {
+		return s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errDigestNotSupported.Error()), nil
+	}
+	if notification.IsLocalTime() {
+		return s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errLocalTimeNotSupported.Error()), nil
+	}
+	if notification.IsPaced() {
+		return s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errPacedNotSupported.Error()), nil
+	}
+	if notification.InFallbackGroup() {
+		return s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errFallbackNotSupported.Error()), nil
+	}
 
 	// 设置发送时间
-	notification.SetSendTime()
+	notification.SetSendTime(s.clock.Now())
 	notification.Status = domain.SendStatusPending
 
 	// 创建通知记录（带回调日志）
 	createdNotification, err := s.repo.CreateWithCallbackLog(ctx, notification)
 	if err != nil {
-		s.logger.Error("create notification failed", zap.Error(err))
+		if existing, ok := s.existingOnDuplicate(ctx, notification, err); ok {
+			return s.buildDuplicateResponse(existing), nil
+		}
+		s.logger.WithContext(ctx).Error("create notification failed", zap.Error(err))
 		return s.buildErrorResponse(0, notificationpb.ErrorCode_CREATE_NOTIFICATION_FAILED, err.Error()), nil
 	}
+	s.labelMetrics.Observe(createdNotification.Status, createdNotification.Labels)
 
-	// 同步发送：如果是立即发送，则尝试发送
-	// TODO: 集成实际的发送逻辑（调用发送服务）
-	sendStatus := notificationpb.SendStatus_PENDING
-	if notification.IsImmediate() {
-		// 这里应该调用实际的发送服务
-		// sendErr := s.sendService.Send(ctx, createdNotification)
-		// 暂时标记为成功
-		sendStatus = notificationpb.SendStatus_SUCCEEDED
-		createdNotification.Status = domain.SendStatusSucceeded
-		_ = s.repo.MarkSuccess(ctx, createdNotification)
-	}
-
+	// 立即发送的通知也是 PENDING，由调度器 CAS 到 SENDING 之后发送，只有发送方能把它改成成功或者失败
+	// 这里返回的状态和数据库里的一致，调用方通过查询或者回调获取最终结果
 	return &notificationpb.SendNotificationResponse{
 		NotificationId: createdNotification.ID,
-		Status:         sendStatus,
+		Status:         s.convertStatus(createdNotification.Status),
 		ErrorCode:      notificationpb.ErrorCode_ERROR_CODE_UNSPECIFIED,
 		ErrorMessage:   "",
+		Sandbox:        createdNotification.IsSandbox(),
 	}, nil
 }
 
@@ -89,33 +152,67 @@ func (s *NotificationServer) SendNotificationAsync(ctx context.Context, req *not
 	// 转换为领域模型
 	notification, err := s.convertToDomainNotification(ctx, req.Notification)
 	if err != nil {
-		s.logger.Error("convert to domain notification failed", zap.Error(err))
+		s.logger.WithContext(ctx).Error("convert to domain notification failed", zap.Error(err))
 		return &notificationpb.SendNotificationAsyncResponse{
 			NotificationId: 0,
-			ErrorCode:      notificationpb.ErrorCode_INVALID_PARAMETER,
+			ErrorCode:      s.convertErrorCode(err, notificationpb.ErrorCode_INVALID_PARAMETER),
 			ErrorMessage:   err.Error(),
 		}, nil
 	}
 
 	// 验证通知
-	if err := notification.Validate(); err != nil {
-		s.logger.Error("validate notification failed", zap.Error(err))
+	if err := notification.Validate(s.clock.Now()); err != nil {
+		s.logger.WithContext(ctx).Error("validate notification failed", zap.Error(err))
 		return &notificationpb.SendNotificationAsyncResponse{
 			NotificationId: 0,
 			ErrorCode:      notificationpb.ErrorCode_INVALID_PARAMETER,
 			ErrorMessage:   err.Error(),
 		}, nil
 	}
+	if err := s.fallbackSvc.Validate(notification); err != nil {
+		return &notificationpb.SendNotificationAsyncResponse{
+			ErrorCode:    notificationpb.ErrorCode_INVALID_PARAMETER,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	// 可合并的通知进入合并窗口，窗口结束后由合并发送任务发送摘要消息
+	if notification.IsDigest() {
+		return s.addDigest(ctx, notification), nil
+	}
+	// 按本地时间发送的通知按时区拆分，快到本地发送时间时由任务创建通知
+	if notification.IsLocalTime() {
+		return s.addLocalTime(ctx, notification), nil
+	}
 
 	// 异步发送：如果是立即发送策略，替换为默认截止时间策略
-	notification.ReplaceAsyncImmediate()
-	notification.SetSendTime()
+	notification.ReplaceAsyncImmediate(s.clock.Now())
+	notification.SetSendTime(s.clock.Now())
 	notification.Status = domain.SendStatusPending
+	// 限速发送按活动进度分配发送时间
+	if notification.IsPaced() {
+		paced := []domain.Notification{notification}
+		if err = s.pacingSvc.Assign(ctx, paced); err != nil {
+			s.logger.WithContext(ctx).Error("assign paced send time failed", zap.Error(err))
+			return &notificationpb.SendNotificationAsyncResponse{
+				ErrorCode:    notificationpb.ErrorCode_CREATE_NOTIFICATION_FAILED,
+				ErrorMessage: err.Error(),
+			}, nil
+		}
+		notification = paced[0]
+	}
 
 	// 创建通知记录（不带回调日志，异步发送由调度器处理）
 	createdNotification, err := s.repo.Create(ctx, notification)
 	if err != nil {
-		s.logger.Error("create notification failed", zap.Error(err))
+		if existing, ok := s.existingOnDuplicate(ctx, notification, err); ok {
+			return &notificationpb.SendNotificationAsyncResponse{
+				NotificationId: existing.ID,
+				ErrorCode:      notificationpb.ErrorCode_ERROR_CODE_UNSPECIFIED,
+				Duplicate:      true,
+			}, nil
+		}
+		s.logger.WithContext(ctx).Error("create notification failed", zap.Error(err))
 		return &notificationpb.SendNotificationAsyncResponse{
 			NotificationId: 0,
 			ErrorCode:      notificationpb.ErrorCode_CREATE_NOTIFICATION_FAILED,
@@ -123,7 +220,8 @@ func (s *NotificationServer) SendNotificationAsync(ctx context.Context, req *not
 		}, nil
 	}
 
-	s.logger.Info("notification created for async send",
+	s.labelMetrics.Observe(createdNotification.Status, createdNotification.Labels)
+	s.logger.WithContext(ctx).Info("notification created for async send",
 		zap.Uint64("notification_id", createdNotification.ID),
 		zap.String("key", createdNotification.Key))
 
@@ -139,6 +237,24 @@ func (s *NotificationServer) BatchSendNotifications(ctx context.Context, req *no
 	if len(req.GetNotifications()) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "notifications cannot be empty")
 	}
+	if req.GetDryRun() {
+		results := s.dryRun(ctx, req.Notifications)
+		successCount := int32(0)
+		for _, r := range results {
+			if r.ErrorCode == notificationpb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+				successCount++
+			}
+		}
+		return &notificationpb.BatchSendNotificationsResponse{
+			Results:      results,
+			TotalCount:   int32(len(req.Notifications)),
+			SuccessCount: successCount,
+		}, nil
+	}
+
+	if err := s.precheckQuota(ctx, req.Notifications); err != nil {
+		return nil, err
+	}
 
 	var results []*notificationpb.SendNotificationResponse
 	successCount := int32(0)
@@ -148,22 +264,38 @@ func (s *NotificationServer) BatchSendNotifications(ctx context.Context, req *no
 	for i, pbNotification := range req.Notifications {
 		notification, err := s.convertToDomainNotification(ctx, pbNotification)
 		if err != nil {
-			s.logger.Error("convert notification failed",
+			s.logger.WithContext(ctx).Error("convert notification failed",
 				zap.Int("index", i),
 				zap.Error(err))
-			results = append(results, s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, err.Error()))
+			results = append(results, s.buildErrorResponse(0, s.convertErrorCode(err, notificationpb.ErrorCode_INVALID_PARAMETER), err.Error()))
 			continue
 		}
 
-		if err := notification.Validate(); err != nil {
-			s.logger.Error("validate notification failed",
+		if err := notification.Validate(s.clock.Now()); err != nil {
+			s.logger.WithContext(ctx).Error("validate notification failed",
 				zap.Int("index", i),
 				zap.Error(err))
 			results = append(results, s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, err.Error()))
 			continue
 		}
+		if notification.IsDigest() {
+			results = append(results, s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errDigestNotSupported.Error()))
+			continue
+		}
+		if notification.IsLocalTime() {
+			results = append(results, s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errLocalTimeNotSupported.Error()))
+			continue
+		}
+		if notification.IsPaced() {
+			results = append(results, s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errPacedNotSupported.Error()))
+			continue
+		}
+		if notification.InFallbackGroup() {
+			results = append(results, s.buildErrorResponse(0, notificationpb.ErrorCode_INVALID_PARAMETER, errFallbackNotSupported.Error()))
+			continue
+		}
 
-		notification.SetSendTime()
+		notification.SetSendTime(s.clock.Now())
 		notification.Status = domain.SendStatusPending
 		notifications = append(notifications, notification)
 	}
@@ -178,8 +310,18 @@ func (s *NotificationServer) BatchSendNotifications(ctx context.Context, req *no
 
 	// 批量创建
 	createdNotifications, err := s.repo.BatchCreateWithCallbackLog(ctx, notifications)
+	if errors.Is(err, domain.ErrNotificationDuplicate) {
+		var others []*notificationpb.SendNotificationResponse
+		createdNotifications, others = s.createOneByOne(ctx, notifications, s.repo.CreateWithCallbackLog)
+		for _, r := range others {
+			if r.Duplicate {
+				successCount++
+			}
+		}
+		results, err = append(results, others...), nil
+	}
 	if err != nil {
-		s.logger.Error("batch create notifications failed", zap.Error(err))
+		s.logger.WithContext(ctx).Error("batch create notifications failed", zap.Error(err))
 		// 所有通知都失败
 		for range notifications {
 			results = append(results, s.buildErrorResponse(0, notificationpb.ErrorCode_CREATE_NOTIFICATION_FAILED, err.Error()))
@@ -191,35 +333,19 @@ func (s *NotificationServer) BatchSendNotifications(ctx context.Context, req *no
 		}, nil
 	}
 
-	// 构建响应
-	succeededNotifications := make([]domain.Notification, 0)
+	// 构建响应，和单条发送一样返回创建时的 PENDING，由调度器发送
 	for _, notification := range createdNotifications {
-		sendStatus := notificationpb.SendStatus_PENDING
-
-		// 同步发送：如果是立即发送，则尝试发送
-		if notification.IsImmediate() {
-			// TODO: 集成实际的发送逻辑
-			sendStatus = notificationpb.SendStatus_SUCCEEDED
-			notification.Status = domain.SendStatusSucceeded
-			succeededNotifications = append(succeededNotifications, notification)
-			successCount++
-		} else {
-			successCount++
-		}
-
+		s.labelMetrics.Observe(notification.Status, notification.Labels)
+		successCount++
 		results = append(results, &notificationpb.SendNotificationResponse{
 			NotificationId: notification.ID,
-			Status:         sendStatus,
+			Status:         s.convertStatus(notification.Status),
 			ErrorCode:      notificationpb.ErrorCode_ERROR_CODE_UNSPECIFIED,
 			ErrorMessage:   "",
+			Sandbox:        notification.IsSandbox(),
 		})
 	}
 
-	// 批量更新发送成功的通知状态
-	if len(succeededNotifications) > 0 {
-		_ = s.repo.BatchUpdateStatusSucceededOrFailed(ctx, succeededNotifications, nil)
-	}
-
 	return &notificationpb.BatchSendNotificationsResponse{
 		Results:      results,
 		TotalCount:   int32(len(req.Notifications)),
@@ -228,62 +354,157 @@ func (s *NotificationServer) BatchSendNotifications(ctx context.Context, req *no
 }
 
 // BatchSendNotificationsAsync 异步批量发送通知
+// 每条通知的结果按请求里的顺序返回，一条通知不合法不影响其他通知
 func (s *NotificationServer) BatchSendNotificationsAsync(ctx context.Context, req *notificationpb.BatchSendNotificationsAsyncRequest) (*notificationpb.BatchSendNotificationsAsyncResponse, error) {
 	if len(req.GetNotifications()) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "notifications cannot be empty")
 	}
+	if err := s.precheckQuota(ctx, req.Notifications); err != nil {
+		return nil, err
+	}
 
-	// 批量转换和验证
+	results := make([]*notificationpb.BatchSendNotificationsAsyncResult, len(req.Notifications))
+	fail := func(i int, code notificationpb.ErrorCode, err error) {
+		results[i] = &notificationpb.BatchSendNotificationsAsyncResult{
+			Index:        int32(i),
+			ErrorCode:    code,
+			ErrorMessage: err.Error(),
+		}
+	}
+
+	// 批量转换和验证，indexes 是 notifications 里每条通知在请求里的下标
 	notifications := make([]domain.Notification, 0, len(req.Notifications))
+	indexes := make([]int, 0, len(req.Notifications))
 	for i, pbNotification := range req.Notifications {
 		notification, err := s.convertToDomainNotification(ctx, pbNotification)
 		if err != nil {
-			s.logger.Error("convert notification failed",
+			s.logger.WithContext(ctx).Error("convert notification failed",
 				zap.Int("index", i),
 				zap.Error(err))
+			fail(i, s.convertErrorCode(err, notificationpb.ErrorCode_INVALID_PARAMETER), err)
 			continue
 		}
 
-		if err := notification.Validate(); err != nil {
-			s.logger.Error("validate notification failed",
+		if err := notification.Validate(s.clock.Now()); err != nil {
+			s.logger.WithContext(ctx).Error("validate notification failed",
+				zap.Int("index", i),
+				zap.Error(err))
+			fail(i, notificationpb.ErrorCode_INVALID_PARAMETER, err)
+			continue
+		}
+		if err := s.fallbackSvc.Validate(notification); err != nil {
+			s.logger.WithContext(ctx).Error("validate fallback group failed",
 				zap.Int("index", i),
 				zap.Error(err))
+			fail(i, s.convertErrorCode(err, notificationpb.ErrorCode_INVALID_PARAMETER), err)
 			continue
 		}
 
-		notification.ReplaceAsyncImmediate()
-		notification.SetSendTime()
+		// 可合并的通知、按本地时间发送的通知没有通知ID，不出现在 NotificationIds 里
+		if notification.IsDigest() {
+			results[i] = toBatchAsyncResult(i, s.addDigest(ctx, notification))
+			continue
+		}
+		if notification.IsLocalTime() {
+			results[i] = toBatchAsyncResult(i, s.addLocalTime(ctx, notification))
+			continue
+		}
+
+		notification.ReplaceAsyncImmediate(s.clock.Now())
+		notification.SetSendTime(s.clock.Now())
 		notification.Status = domain.SendStatusPending
 		notifications = append(notifications, notification)
+		indexes = append(indexes, i)
 	}
 
 	if len(notifications) == 0 {
 		return &notificationpb.BatchSendNotificationsAsyncResponse{
 			NotificationIds: []uint64{},
+			Results:         results,
 		}, nil
 	}
+	if err := s.pacingSvc.Assign(ctx, notifications); err != nil {
+		s.logger.WithContext(ctx).Error("assign paced send time failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to assign paced send time")
+	}
 
 	// 批量创建（异步发送不需要回调日志）
 	createdNotifications, err := s.repo.BatchCreate(ctx, notifications)
+	var others []*notificationpb.SendNotificationResponse
+	if errors.Is(err, domain.ErrNotificationDuplicate) {
+		createdNotifications, others = s.createOneByOne(ctx, notifications, s.repo.Create)
+		err = nil
+	}
 	if err != nil {
-		s.logger.Error("batch create notifications failed", zap.Error(err))
+		s.logger.WithContext(ctx).Error("batch create notifications failed", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to create notifications")
 	}
 
-	// 收集通知ID
-	notificationIDs := make([]uint64, 0, len(createdNotifications))
+	// 创建时使用调用前生成的通知ID，没有创建成功的通知按顺序对应 others
+	created := make(map[uint64]struct{}, len(createdNotifications))
 	for _, notification := range createdNotifications {
-		notificationIDs = append(notificationIDs, notification.ID)
+		s.labelMetrics.Observe(notification.Status, notification.Labels)
+		created[notification.ID] = struct{}{}
+	}
+	// 收集通知ID，重复发送的返回已有通知的ID
+	notificationIDs := make([]uint64, 0, len(notifications))
+	for j, notification := range notifications {
+		i := indexes[j]
+		if _, ok := created[notification.ID]; ok {
+			notificationIDs = append(notificationIDs, notification.ID)
+			results[i] = &notificationpb.BatchSendNotificationsAsyncResult{Index: int32(i), NotificationId: notification.ID}
+			continue
+		}
+		r := others[0]
+		others = others[1:]
+		if r.Duplicate {
+			notificationIDs = append(notificationIDs, r.NotificationId)
+		}
+		results[i] = &notificationpb.BatchSendNotificationsAsyncResult{
+			Index:          int32(i),
+			NotificationId: r.NotificationId,
+			ErrorCode:      r.ErrorCode,
+			ErrorMessage:   r.ErrorMessage,
+			Duplicate:      r.Duplicate,
+		}
 	}
 
-	s.logger.Info("batch notifications created for async send",
+	s.logger.WithContext(ctx).Info("batch notifications created for async send",
 		zap.Int("count", len(notificationIDs)))
 
 	return &notificationpb.BatchSendNotificationsAsyncResponse{
 		NotificationIds: notificationIDs,
+		Results:         results,
 	}, nil
 }
 
+// precheckQuota 批量发送前粗略检查额度，额度明显用完时整批拒绝，沙箱通知不扣减额度，不检查
+func (s *NotificationServer) precheckQuota(ctx context.Context, notifications []*notificationpb.Notification) error {
+	if ctxkit.EnvironmentFromContext(ctx).IsSandbox() {
+		return nil
+	}
+	err := s.quotaSvc.Precheck(ctx, getBizIDFromContext(ctx), domain.NewQuotaDemandsFromAPI(notifications))
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("额度预检不通过，拒绝整批通知", zap.Error(err),
+			zap.Int("count", len(notifications)))
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+// toBatchAsyncResult 单条异步发送的结果转成批量异步发送里的一条结果
+func toBatchAsyncResult(index int, r *notificationpb.SendNotificationAsyncResponse) *notificationpb.BatchSendNotificationsAsyncResult {
+	return &notificationpb.BatchSendNotificationsAsyncResult{
+		Index:            int32(index),
+		NotificationId:   r.NotificationId,
+		ErrorCode:        r.ErrorCode,
+		ErrorMessage:     r.ErrorMessage,
+		Duplicate:        r.Duplicate,
+		Digested:         r.Digested,
+		LocalTimeCohorts: r.LocalTimeCohorts,
+	}
+}
+
 // TxPrepare 准备事务消息
 func (s *NotificationServer) TxPrepare(ctx context.Context, req *notificationpb.TxPrepareRequest) (*notificationpb.TxPrepareResponse, error) {
 	if req.GetNotification() == nil {
@@ -293,28 +514,51 @@ func (s *NotificationServer) TxPrepare(ctx context.Context, req *notificationpb.
 	// 转换为领域模型
 	notification, err := s.convertToDomainNotification(ctx, req.Notification)
 	if err != nil {
-		s.logger.Error("convert to domain notification failed", zap.Error(err))
+		s.logger.WithContext(ctx).Error("convert to domain notification failed", zap.Error(err))
+		if errors.Is(err, domain.ErrChannelDisabled) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	// 验证通知
-	if err := notification.Validate(); err != nil {
-		s.logger.Error("validate notification failed", zap.Error(err))
+	if err := notification.Validate(s.clock.Now()); err != nil {
+		s.logger.WithContext(ctx).Error("validate notification failed", zap.Error(err))
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if notification.IsDigest() {
+		return nil, status.Error(codes.InvalidArgument, errDigestNotSupported.Error())
+	}
+	if notification.IsLocalTime() {
+		return nil, status.Error(codes.InvalidArgument, errLocalTimeNotSupported.Error())
+	}
+	if notification.IsPaced() {
+		return nil, status.Error(codes.InvalidArgument, errPacedNotSupported.Error())
+	}
+	if notification.InFallbackGroup() {
+		return nil, status.Error(codes.InvalidArgument, errFallbackNotSupported.Error())
+	}
 
 	// 设置事务状态为准备中
 	notification.Status = domain.SendStatusPrepare
-	notification.SetSendTime()
+	notification.SetSendTime(s.clock.Now())
 
 	// 创建通知记录
 	createdNotification, err := s.repo.Create(ctx, notification)
 	if err != nil {
-		s.logger.Error("create tx notification failed", zap.Error(err))
+		// 重复准备同一个事务消息，直接当作成功
+		if existing, ok := s.existingOnDuplicate(ctx, notification, err); ok {
+			s.logger.WithContext(ctx).Info("transaction notification already prepared",
+				zap.Uint64("notification_id", existing.ID),
+				zap.String("key", existing.Key))
+			return &notificationpb.TxPrepareResponse{}, nil
+		}
+		s.logger.WithContext(ctx).Error("create tx notification failed", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to prepare transaction")
 	}
 
-	s.logger.Info("transaction notification prepared",
+	s.labelMetrics.Observe(createdNotification.Status, createdNotification.Labels)
+	s.logger.WithContext(ctx).Info("transaction notification prepared",
 		zap.Uint64("notification_id", createdNotification.ID),
 		zap.String("key", createdNotification.Key))
 
@@ -330,7 +574,7 @@ func (s *NotificationServer) TxCommit(ctx context.Context, req *notificationpb.T
 	// TODO: 从上下文或请求中获取 bizID
 	// 这里需要扩展 proto 定义或使用其他方式传递 bizID
 	// 暂时使用一个默认值或从 metadata 获取
-	bizID := s.getBizIDFromContext(ctx)
+	bizID := getBizIDFromContext(ctx)
 	if bizID == 0 {
 		return nil, status.Error(codes.InvalidArgument, "bizID is required")
 	}
@@ -338,7 +582,7 @@ func (s *NotificationServer) TxCommit(ctx context.Context, req *notificationpb.T
 	// 查询通知
 	notification, err := s.repo.GetByKey(ctx, bizID, req.Key)
 	if err != nil {
-		s.logger.Error("get notification by key failed",
+		s.logger.WithContext(ctx).Error("get notification by key failed",
 			zap.String("key", req.Key),
 			zap.Error(err))
 		return nil, status.Error(codes.NotFound, "notification not found")
@@ -346,7 +590,7 @@ func (s *NotificationServer) TxCommit(ctx context.Context, req *notificationpb.T
 
 	// 检查状态
 	if notification.Status != domain.SendStatusPrepare {
-		s.logger.Warn("notification status is not PREPARE",
+		s.logger.WithContext(ctx).Warn("notification status is not PREPARE",
 			zap.Uint64("notification_id", notification.ID),
 			zap.String("status", string(notification.Status)))
 		return nil, status.Error(codes.FailedPrecondition, "notification is not in PREPARE status")
@@ -355,13 +599,14 @@ func (s *NotificationServer) TxCommit(ctx context.Context, req *notificationpb.T
 	// 更新状态为待发送
 	notification.Status = domain.SendStatusPending
 	if err := s.repo.UpdateStatus(ctx, notification); err != nil {
-		s.logger.Error("update notification status failed",
+		s.logger.WithContext(ctx).Error("update notification status failed",
 			zap.Uint64("notification_id", notification.ID),
 			zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to commit transaction")
 	}
 
-	s.logger.Info("transaction notification committed",
+	s.labelMetrics.Observe(notification.Status, notification.Labels)
+	s.logger.WithContext(ctx).Info("transaction notification committed",
 		zap.Uint64("notification_id", notification.ID),
 		zap.String("key", notification.Key))
 
@@ -375,7 +620,7 @@ func (s *NotificationServer) TxCancel(ctx context.Context, req *notificationpb.T
 	}
 
 	// TODO: 从上下文或请求中获取 bizID
-	bizID := s.getBizIDFromContext(ctx)
+	bizID := getBizIDFromContext(ctx)
 	if bizID == 0 {
 		return nil, status.Error(codes.InvalidArgument, "bizID is required")
 	}
@@ -383,7 +628,7 @@ func (s *NotificationServer) TxCancel(ctx context.Context, req *notificationpb.T
 	// 查询通知
 	notification, err := s.repo.GetByKey(ctx, bizID, req.Key)
 	if err != nil {
-		s.logger.Error("get notification by key failed",
+		s.logger.WithContext(ctx).Error("get notification by key failed",
 			zap.String("key", req.Key),
 			zap.Error(err))
 		return nil, status.Error(codes.NotFound, "notification not found")
@@ -391,7 +636,7 @@ func (s *NotificationServer) TxCancel(ctx context.Context, req *notificationpb.T
 
 	// 检查状态
 	if notification.Status != domain.SendStatusPrepare {
-		s.logger.Warn("notification status is not PREPARE",
+		s.logger.WithContext(ctx).Warn("notification status is not PREPARE",
 			zap.Uint64("notification_id", notification.ID),
 			zap.String("status", string(notification.Status)))
 		return nil, status.Error(codes.FailedPrecondition, "notification is not in PREPARE status")
@@ -400,33 +645,184 @@ func (s *NotificationServer) TxCancel(ctx context.Context, req *notificationpb.T
 	// 更新状态为已取消
 	notification.Status = domain.SendStatusCanceled
 	if err := s.repo.UpdateStatus(ctx, notification); err != nil {
-		s.logger.Error("update notification status failed",
+		s.logger.WithContext(ctx).Error("update notification status failed",
 			zap.Uint64("notification_id", notification.ID),
 			zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to cancel transaction")
 	}
 
-	s.logger.Info("transaction notification canceled",
+	s.logger.WithContext(ctx).Info("transaction notification canceled",
 		zap.Uint64("notification_id", notification.ID),
 		zap.String("key", notification.Key))
 
 	return &notificationpb.TxCancelResponse{}, nil
 }
 
+// CancelNotification 取消待发送的通知
+// 调度器只会捞取 PENDING 状态的通知，状态变成 CANCELED 之后就不会再被发送
+func (s *NotificationServer) CancelNotification(ctx context.Context, req *notificationpb.CancelNotificationRequest) (*notificationpb.CancelNotificationResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+
+	notification, err := s.repo.GetByKey(ctx, bizID, req.Key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, "notification not found")
+		}
+		s.logger.WithContext(ctx).Error("get notification by key failed",
+			zap.String("key", req.Key),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get notification")
+	}
+
+	// 重复取消直接返回成功
+	if notification.Status == domain.SendStatusCanceled {
+		return &notificationpb.CancelNotificationResponse{
+			NotificationId: notification.ID,
+			Status:         notificationpb.SendStatus_CANCELED,
+		}, nil
+	}
+	if notification.Status != domain.SendStatusPending {
+		s.logger.WithContext(ctx).Warn("notification status is not PENDING",
+			zap.Uint64("notification_id", notification.ID),
+			zap.String("status", string(notification.Status)))
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: %s", domain.ErrNotificationNotCancelable.Error(), notification.Status)
+	}
+
+	if err := s.repo.CancelPending(ctx, notification); err != nil {
+		if errors.Is(err, domain.ErrNotificationVersionMismatch) {
+			// 查询之后状态被调度器或者其他请求修改了
+			s.logger.WithContext(ctx).Warn("notification changed while canceling",
+				zap.Uint64("notification_id", notification.ID),
+				zap.Error(err))
+			return nil, status.Error(codes.Aborted, domain.ErrNotificationNotCancelable.Error())
+		}
+		s.logger.WithContext(ctx).Error("cancel notification failed",
+			zap.Uint64("notification_id", notification.ID),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to cancel notification")
+	}
+
+	s.logger.WithContext(ctx).Info("notification canceled",
+		zap.Uint64("notification_id", notification.ID),
+		zap.String("key", notification.Key))
+
+	return &notificationpb.CancelNotificationResponse{
+		NotificationId: notification.ID,
+		Status:         notificationpb.SendStatus_CANCELED,
+	}, nil
+}
+
+// UpdateNotification 修改待发送的通知
+func (s *NotificationServer) UpdateNotification(ctx context.Context, req *notificationpb.UpdateNotificationRequest) (*notificationpb.UpdateNotificationResponse, error) {
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is required")
+	}
+	update, err := s.convertToDomainUpdate(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+
+	notification, err := s.repo.GetByKey(ctx, bizID, req.Key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, "notification not found")
+		}
+		s.logger.WithContext(ctx).Error("get notification by key failed",
+			zap.String("key", req.Key),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get notification")
+	}
+	if notification.Status != domain.SendStatusPending {
+		s.logger.WithContext(ctx).Warn("notification status is not PENDING",
+			zap.Uint64("notification_id", notification.ID),
+			zap.String("status", string(notification.Status)))
+		return nil, status.Error(codes.FailedPrecondition, "notification is not in PENDING status")
+	}
+	if req.GetVersion() != 0 && int(req.GetVersion()) != notification.Version {
+		return nil, status.Errorf(codes.Aborted, "%s: current version %d", domain.ErrNotificationVersionMismatch.Error(), notification.Version)
+	}
+
+	before := domain.NewNotificationSnapshot(notification)
+	if err := notification.ApplyUpdate(update, s.clock.Now()); err != nil {
+		s.logger.WithContext(ctx).Error("validate notification update failed",
+			zap.Uint64("notification_id", notification.ID),
+			zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if update.TemplateParams != nil {
+		// 参数变了需要按照模板当前生效版本的参数定义重新校验
+		if err := s.templateSvc.PrepareTemplate(ctx, &notification); err != nil {
+			s.logger.WithContext(ctx).Error("validate template params failed",
+				zap.Uint64("notification_id", notification.ID),
+				zap.Error(err))
+			if errors.Is(err, domain.ErrInvalidParameter) {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+	after := domain.NewNotificationSnapshot(notification)
+	after.Version = notification.Version + 1
+
+	updated, err := s.repo.UpdatePending(ctx, notification, domain.NotificationAuditLog{
+		NotificationID: notification.ID,
+		BizID:          notification.BizID,
+		Action:         domain.NotificationAuditActionUpdate,
+		Operator:       req.GetOperator(),
+		Before:         before,
+		After:          after,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotificationVersionMismatch) {
+			s.logger.WithContext(ctx).Warn("notification changed while updating",
+				zap.Uint64("notification_id", notification.ID),
+				zap.Error(err))
+			return nil, status.Error(codes.Aborted, domain.ErrNotificationVersionMismatch.Error())
+		}
+		s.logger.WithContext(ctx).Error("update notification failed",
+			zap.Uint64("notification_id", notification.ID),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to update notification")
+	}
+
+	s.logger.WithContext(ctx).Info("notification updated",
+		zap.Uint64("notification_id", updated.ID),
+		zap.String("key", updated.Key),
+		zap.Int("version", updated.Version),
+		zap.String("operator", req.GetOperator()))
+
+	return &notificationpb.UpdateNotificationResponse{
+		NotificationId: updated.ID,
+		Version:        int32(updated.Version),
+	}, nil
+}
+
 // QueryNotification 查询单条通知
 func (s *NotificationServer) QueryNotification(ctx context.Context, req *notificationpb.QueryNotificationRequest) (*notificationpb.QueryNotificationResponse, error) {
 	if req.GetKey() == "" {
 		return nil, status.Error(codes.InvalidArgument, "key is required")
 	}
 
-	bizID := s.getBizIDFromContext(ctx)
+	bizID := getBizIDFromContext(ctx)
 	if bizID == 0 {
 		return nil, status.Error(codes.InvalidArgument, "bizID is required")
 	}
 
 	notification, err := s.repo.GetByKey(ctx, bizID, req.Key)
 	if err != nil {
-		s.logger.Error("get notification by key failed",
+		s.logger.WithContext(ctx).Error("get notification by key failed",
 			zap.String("key", req.Key),
 			zap.Error(err))
 		return nil, status.Error(codes.NotFound, "notification not found")
@@ -443,14 +839,14 @@ func (s *NotificationServer) BatchQueryNotifications(ctx context.Context, req *n
 		return nil, status.Error(codes.InvalidArgument, "keys cannot be empty")
 	}
 
-	bizID := s.getBizIDFromContext(ctx)
+	bizID := getBizIDFromContext(ctx)
 	if bizID == 0 {
 		return nil, status.Error(codes.InvalidArgument, "bizID is required")
 	}
 
 	notifications, err := s.repo.GetByKeys(ctx, bizID, req.Keys...)
 	if err != nil {
-		s.logger.Error("get notifications by keys failed",
+		s.logger.WithContext(ctx).Error("get notifications by keys failed",
 			zap.Strings("keys", req.Keys),
 			zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to query notifications")
@@ -466,6 +862,137 @@ func (s *NotificationServer) BatchQueryNotifications(ctx context.Context, req *n
 	}, nil
 }
 
+const (
+	defaultListPageSize = 20
+	maxListPageSize     = 100
+)
+
+// ListNotifications 按ID倒序分页查询通知
+func (s *NotificationServer) ListNotifications(ctx context.Context, req *notificationpb.ListNotificationsRequest) (*notificationpb.ListNotificationsResponse, error) {
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	if pageSize < 0 || pageSize > maxListPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxListPageSize)
+	}
+	labels := domain.Labels(req.GetLabels())
+	if err := labels.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter := domain.NotificationFilter{
+		BizID:    bizID,
+		Labels:   labels,
+		BeforeID: req.GetBeforeId(),
+		// 多查一条判断有没有下一页
+		Limit: pageSize + 1,
+	}
+	if req.GetStatus() != notificationpb.SendStatus_SEND_STATUS_UNSPECIFIED {
+		filter.Status = domain.SendStatus(req.GetStatus().String())
+	}
+	if req.GetChannel() != notificationpb.Channel_CHANNEL_UNSPECIFIED {
+		filter.Channel = domain.Channel(req.GetChannel().String())
+	}
+
+	notifications, err := s.repo.ListByBiz(ctx, filter)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("list notifications failed", zap.Int64("biz_id", bizID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to list notifications")
+	}
+	resp := &notificationpb.ListNotificationsResponse{}
+	if len(notifications) > pageSize {
+		notifications = notifications[:pageSize]
+		resp.NextBeforeId = notifications[pageSize-1].ID
+	}
+	resp.Notifications = make([]*notificationpb.NotificationSummary, 0, len(notifications))
+	for _, n := range notifications {
+		resp.Notifications = append(resp.Notifications, convertSummary(n))
+	}
+	return resp, nil
+}
+
+// GetFallbackGroup 查询渠道降级分组的状态和分组里的通知
+func (s *NotificationServer) GetFallbackGroup(ctx context.Context, req *notificationpb.GetFallbackGroupRequest) (*notificationpb.GetFallbackGroupResponse, error) {
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	if req.GetGroup() == "" {
+		return nil, status.Error(codes.InvalidArgument, "group is required")
+	}
+	g, err := s.fallbackSvc.Group(ctx, bizID, req.GetGroup())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		s.logger.WithContext(ctx).Error("get fallback group failed", zap.Int64("biz_id", bizID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to get fallback group")
+	}
+	resp := &notificationpb.GetFallbackGroupResponse{
+		Group:   g.Group,
+		Status:  convertFallbackGroupStatus(g.Status()),
+		Members: make([]*notificationpb.NotificationSummary, 0, len(g.Members)),
+	}
+	if delivered, ok := g.Delivered(); ok {
+		resp.DeliveredNotificationId = delivered.ID
+	}
+	for _, n := range g.Members {
+		resp.Members = append(resp.Members, convertSummary(n))
+	}
+	return resp, nil
+}
+
+func convertSummary(n domain.Notification) *notificationpb.NotificationSummary {
+	return &notificationpb.NotificationSummary{
+		NotificationId: n.ID,
+		Key:            n.Key,
+		Channel:        convertChannel(n.Channel),
+		Status:         convertSendStatus(n.Status),
+		Labels:         n.Labels,
+		Sandbox:        n.IsSandbox(),
+		FailReason:     n.FailReason.String(),
+		BizPayload:     n.BizPayload,
+	}
+}
+
+func convertFallbackGroupStatus(s domain.FallbackGroupStatus) notificationpb.FallbackGroupStatus {
+	switch s {
+	case domain.FallbackGroupStatusInProgress:
+		return notificationpb.FallbackGroupStatus_FALLBACK_GROUP_IN_PROGRESS
+	case domain.FallbackGroupStatusDelivered:
+		return notificationpb.FallbackGroupStatus_FALLBACK_GROUP_DELIVERED
+	case domain.FallbackGroupStatusExhausted:
+		return notificationpb.FallbackGroupStatus_FALLBACK_GROUP_EXHAUSTED
+	default:
+		return notificationpb.FallbackGroupStatus_FALLBACK_GROUP_STATUS_UNSPECIFIED
+	}
+}
+
+// GenerateNotificationKeys 生成符合业务方 key 规则的唯一 key
+func (s *NotificationServer) GenerateNotificationKeys(ctx context.Context, req *notificationpb.GenerateNotificationKeysRequest) (*notificationpb.GenerateNotificationKeysResponse, error) {
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	count := int(req.GetCount())
+	if count == 0 {
+		count = 1
+	}
+	keys, err := s.keyPolicySvc.GenerateKeys(ctx, bizID, count)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidParameter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithContext(ctx).Error("generate notification keys failed", zap.Int64("biz_id", bizID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate notification keys")
+	}
+	return &notificationpb.GenerateNotificationKeysResponse{Keys: keys}, nil
+}
+
 // Helper methods
 
 // convertToDomainNotification 将 proto 通知转换为领域模型
@@ -476,14 +1003,60 @@ func (s *NotificationServer) convertToDomainNotification(ctx context.Context, pb
 	}
 
 	// 从上下文获取 bizID
-	notification.BizID = s.getBizIDFromContext(ctx)
+	notification.BizID = getBizIDFromContext(ctx)
 	if notification.BizID == 0 {
 		return domain.Notification{}, fmt.Errorf("bizID is required")
 	}
+	notification.Environment = ctxkit.EnvironmentFromContext(ctx)
+
+	// 业务方被关闭的渠道直接拒绝，不再校验模板
+	if err := s.channelSvc.Check(ctx, notification.BizID, notification.Channel); err != nil {
+		return domain.Notification{}, err
+	}
+	if err := s.keyPolicySvc.Check(ctx, notification.BizID, notification.Key); err != nil {
+		return domain.Notification{}, err
+	}
+
+	// 校验模板并按照模板的参数定义校验参数
+	if err := s.templateSvc.PrepareTemplate(ctx, &notification); err != nil {
+		return domain.Notification{}, err
+	}
+
+	// 生成通知ID
+	id, err := s.idGenerator.NextID()
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf("生成通知ID失败: %w", err)
+	}
+	notification.ID = id
 
 	return notification, nil
 }
 
+// convertToDomainUpdate 根据 update_mask 转换需要修改的字段
+func (s *NotificationServer) convertToDomainUpdate(req *notificationpb.UpdateNotificationRequest) (domain.NotificationUpdate, error) {
+	var update domain.NotificationUpdate
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "receivers":
+			update.Receivers = append([]string{}, req.GetReceivers()...)
+		case "template_params":
+			update.TemplateParams = make(map[string]string, len(req.GetTemplateParams()))
+			for k, v := range req.GetTemplateParams() {
+				update.TemplateParams[k] = v
+			}
+		case "strategy":
+			strategy := domain.NewSendStrategyConfigFromAPI(req.GetStrategy())
+			update.SendStrategyConfig = &strategy
+		default:
+			return domain.NotificationUpdate{}, fmt.Errorf("%w: 不支持修改字段 %s", domain.ErrInvalidParameter, path)
+		}
+	}
+	if update.IsEmpty() {
+		return domain.NotificationUpdate{}, fmt.Errorf("%w: update_mask 不能为空", domain.ErrInvalidParameter)
+	}
+	return update, nil
+}
+
 // convertToProtoResponse 将领域模型转换为 proto 响应
 func (s *NotificationServer) convertToProtoResponse(notification domain.Notification) *notificationpb.SendNotificationResponse {
 	return &notificationpb.SendNotificationResponse{
@@ -491,11 +1064,17 @@ func (s *NotificationServer) convertToProtoResponse(notification domain.Notifica
 		Status:         s.convertStatus(notification.Status),
 		ErrorCode:      notificationpb.ErrorCode_ERROR_CODE_UNSPECIFIED,
 		ErrorMessage:   "",
+		Sandbox:        notification.IsSandbox(),
+		BizPayload:     notification.BizPayload,
 	}
 }
 
 // convertStatus 转换发送状态
 func (s *NotificationServer) convertStatus(status domain.SendStatus) notificationpb.SendStatus {
+	return convertSendStatus(status)
+}
+
+func convertSendStatus(status domain.SendStatus) notificationpb.SendStatus {
 	switch status {
 	case domain.SendStatusPrepare:
 		return notificationpb.SendStatus_PREPARE
@@ -507,11 +1086,209 @@ func (s *NotificationServer) convertStatus(status domain.SendStatus) notificatio
 		return notificationpb.SendStatus_SUCCEEDED
 	case domain.SendStatusFailed:
 		return notificationpb.SendStatus_FAILED
+	case domain.SendStatusSending:
+		return notificationpb.SendStatus_SENDING
 	default:
 		return notificationpb.SendStatus_SEND_STATUS_UNSPECIFIED
 	}
 }
 
+// convertErrorCode 将领域错误转换为错误码，无法识别的错误使用 defaultCode
+func (s *NotificationServer) convertErrorCode(err error, defaultCode notificationpb.ErrorCode) notificationpb.ErrorCode {
+	switch {
+	case errors.Is(err, domain.ErrTemplateNotFound), errors.Is(err, domain.ErrTemplateVersionNotFound):
+		return notificationpb.ErrorCode_TEMPLATE_NOT_FOUND
+	case errors.Is(err, domain.ErrTemplateVersionNotApprovedByPlatform),
+		errors.Is(err, domain.ErrTemplateVersionNotApprovedByProvider):
+		return notificationpb.ErrorCode_TEMPLATE_NOT_APPROVED
+	case errors.Is(err, domain.ErrUnknownChannel):
+		return notificationpb.ErrorCode_UNKNOWN_CHANNEL
+	case errors.Is(err, domain.ErrChannelDisabled):
+		return notificationpb.ErrorCode_CHANNEL_DISABLED
+	case errors.Is(err, domain.ErrNoAvailableProvider):
+		return notificationpb.ErrorCode_NO_AVAILABLE_PROVIDER
+	case errors.Is(err, domain.ErrNoQuota):
+		return notificationpb.ErrorCode_NO_QUOTA
+	case errors.Is(err, domain.ErrSendWindowClosed):
+		return notificationpb.ErrorCode_SEND_WINDOW_CLOSED
+	case errors.Is(err, domain.ErrInvalidParameter), errors.Is(err, domain.ErrTemplateRenderFailed):
+		return notificationpb.ErrorCode_INVALID_PARAMETER
+	default:
+		return defaultCode
+	}
+}
+
+// addDigest 可合并的通知加入合并窗口，重复加入当作成功
+func (s *NotificationServer) addDigest(ctx context.Context, notification domain.Notification) *notificationpb.SendNotificationAsyncResponse {
+	if notification.IsSandbox() {
+		return &notificationpb.SendNotificationAsyncResponse{
+			ErrorCode:    notificationpb.ErrorCode_INVALID_PARAMETER,
+			ErrorMessage: errSandboxDigestNotSupported.Error(),
+		}
+	}
+	err := s.digestSvc.Add(ctx, notification)
+	switch {
+	case err == nil:
+		return &notificationpb.SendNotificationAsyncResponse{Digested: true}
+	case errors.Is(err, domain.ErrNotificationDuplicate):
+		return &notificationpb.SendNotificationAsyncResponse{Digested: true, Duplicate: true}
+	default:
+		s.logger.WithContext(ctx).Error("add digest notification failed",
+			zap.Int64("biz_id", notification.BizID),
+			zap.String("key", notification.Key),
+			zap.Error(err))
+		return &notificationpb.SendNotificationAsyncResponse{
+			ErrorCode:    s.convertErrorCode(err, notificationpb.ErrorCode_CREATE_NOTIFICATION_FAILED),
+			ErrorMessage: err.Error(),
+		}
+	}
+}
+
+// addLocalTime 按本地时间发送的通知按时区拆分，返回每个时区的分组，重复添加当作成功
+func (s *NotificationServer) addLocalTime(ctx context.Context, notification domain.Notification) *notificationpb.SendNotificationAsyncResponse {
+	cohorts, err := s.localTimeSvc.Add(ctx, notification)
+	duplicate := errors.Is(err, domain.ErrNotificationDuplicate)
+	if duplicate {
+		cohorts, err = s.localTimeSvc.Cohorts(ctx, notification.BizID, notification.Key)
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).Error("add local time notification failed",
+			zap.Int64("biz_id", notification.BizID),
+			zap.String("key", notification.Key),
+			zap.Error(err))
+		return &notificationpb.SendNotificationAsyncResponse{
+			ErrorCode:    s.convertErrorCode(err, notificationpb.ErrorCode_CREATE_NOTIFICATION_FAILED),
+			ErrorMessage: err.Error(),
+		}
+	}
+	res := &notificationpb.SendNotificationAsyncResponse{
+		Duplicate:        duplicate,
+		LocalTimeCohorts: make([]*notificationpb.LocalTimeCohort, 0, len(cohorts)),
+	}
+	for _, c := range cohorts {
+		res.LocalTimeCohorts = append(res.LocalTimeCohorts, &notificationpb.LocalTimeCohort{
+			Timezone:      c.Timezone,
+			ReceiverCount: c.ReceiverCount,
+			SendTime:      c.SendTime.UnixMilli(),
+			EndTime:       c.EndTime.UnixMilli(),
+		})
+	}
+	return res
+}
+
+// dryRun 试运行，结果和 pbNotifications 一一对应，不生成通知ID
+func (s *NotificationServer) dryRun(ctx context.Context, pbNotifications []*notificationpb.Notification) []*notificationpb.SendNotificationResponse {
+	results := make([]*notificationpb.SendNotificationResponse, len(pbNotifications))
+	notifications := make([]domain.Notification, 0, len(pbNotifications))
+	indexes := make([]int, 0, len(pbNotifications))
+	bizID := getBizIDFromContext(ctx)
+	for i, pbNotification := range pbNotifications {
+		notification, err := domain.NewNotificationFromAPI(pbNotification)
+		switch {
+		case err != nil:
+		case bizID == 0:
+			err = fmt.Errorf("bizID is required")
+		case notification.IsDigest():
+			err = errDigestNotSupported
+		case notification.IsLocalTime():
+			err = errLocalTimeNotSupported
+		case notification.IsPaced():
+			err = errPacedNotSupported
+		case notification.InFallbackGroup():
+			err = errFallbackNotSupported
+		default:
+			err = s.channelSvc.Check(ctx, bizID, notification.Channel)
+			if err == nil {
+				err = s.keyPolicySvc.Check(ctx, bizID, notification.Key)
+			}
+		}
+		if err != nil {
+			results[i] = s.buildErrorResponse(0, s.convertErrorCode(err, notificationpb.ErrorCode_INVALID_PARAMETER), err.Error())
+			continue
+		}
+		notification.BizID = bizID
+		notification.Environment = ctxkit.EnvironmentFromContext(ctx)
+		notifications = append(notifications, notification)
+		indexes = append(indexes, i)
+	}
+	for j, outcome := range s.dryRunSvc.DryRun(ctx, notifications) {
+		i := indexes[j]
+		if outcome.Err != nil {
+			results[i] = s.buildErrorResponse(0,
+				s.convertErrorCode(outcome.Err, notificationpb.ErrorCode_SEND_NOTIFICATION_FAILED), outcome.Err.Error())
+			continue
+		}
+		res := outcome.Result
+		if res.Existing != nil {
+			results[i] = s.buildDuplicateResponse(*res.Existing)
+			continue
+		}
+		results[i] = &notificationpb.SendNotificationResponse{
+			Status:  notificationpb.SendStatus_PENDING,
+			Sandbox: notifications[j].IsSandbox(),
+			DryRunResult: &notificationpb.DryRunResult{
+				TemplateVersionId:  res.TemplateVersionID,
+				Signature:          res.Signature,
+				Content:            res.Content,
+				Providers:          res.Providers,
+				QuotaRemaining:     res.QuotaRemaining,
+				ScheduledStartTime: res.ScheduledSTime.UnixMilli(),
+				ScheduledEndTime:   res.ScheduledETime.UnixMilli(),
+			},
+		}
+	}
+	return results
+}
+
+// existingOnDuplicate 创建时 (bizID, key) 唯一索引冲突，说明业务方重复发送，查出已有的通知
+// 查不到说明冲突的不是 key（比如ID冲突），ok 为 false，按创建失败处理
+func (s *NotificationServer) existingOnDuplicate(ctx context.Context, notification domain.Notification, err error) (domain.Notification, bool) {
+	if !errors.Is(err, domain.ErrNotificationDuplicate) {
+		return domain.Notification{}, false
+	}
+	existing, gerr := s.repo.GetByKey(ctx, notification.BizID, notification.Key)
+	if gerr != nil {
+		s.logger.WithContext(ctx).Error("get duplicate notification failed",
+			zap.Int64("biz_id", notification.BizID),
+			zap.String("key", notification.Key),
+			zap.Error(gerr))
+		return domain.Notification{}, false
+	}
+	return existing, true
+}
+
+// createOneByOne 批量插入在同一个事务里，有一条重复整批都会回滚，这时逐条重新创建
+// 返回创建成功的通知，以及重复发送和创建失败的响应
+func (s *NotificationServer) createOneByOne(ctx context.Context, notifications []domain.Notification,
+	create func(ctx context.Context, notification domain.Notification) (domain.Notification, error),
+) ([]domain.Notification, []*notificationpb.SendNotificationResponse) {
+	created := make([]domain.Notification, 0, len(notifications))
+	var others []*notificationpb.SendNotificationResponse
+	for i := range notifications {
+		n, err := create(ctx, notifications[i])
+		if err == nil {
+			created = append(created, n)
+			continue
+		}
+		if existing, ok := s.existingOnDuplicate(ctx, notifications[i], err); ok {
+			others = append(others, s.buildDuplicateResponse(existing))
+			continue
+		}
+		s.logger.WithContext(ctx).Error("create notification failed",
+			zap.String("key", notifications[i].Key),
+			zap.Error(err))
+		others = append(others, s.buildErrorResponse(0, notificationpb.ErrorCode_CREATE_NOTIFICATION_FAILED, err.Error()))
+	}
+	return created, others
+}
+
+// buildDuplicateResponse 重复发送时返回已有通知的ID和状态
+func (s *NotificationServer) buildDuplicateResponse(existing domain.Notification) *notificationpb.SendNotificationResponse {
+	resp := s.convertToProtoResponse(existing)
+	resp.Duplicate = true
+	return resp
+}
+
 // buildErrorResponse 构建错误响应
 func (s *NotificationServer) buildErrorResponse(id uint64, errorCode notificationpb.ErrorCode, message string) *notificationpb.SendNotificationResponse {
 	return &notificationpb.SendNotificationResponse{
@@ -522,21 +1299,6 @@ func (s *NotificationServer) buildErrorResponse(id uint64, errorCode notificatio
 	}
 }
 
-// getBizIDFromContext 从上下文中获取 bizID
-// TODO: 实现从 metadata 或其他方式获取 bizID 的逻辑
-func (s *NotificationServer) getBizIDFromContext(ctx context.Context) int64 {
-	// 这里应该从 gRPC metadata 或其他认证信息中获取
-	// 暂时返回一个默认值用于演示
-	// 实际使用时需要实现真实的逻辑，比如：
-	// md, ok := metadata.FromIncomingContext(ctx)
-	// if !ok {
-	//     return 0
-	// }
-	// bizIDStr := md.Get("biz-id")
-	// return parseBizID(bizIDStr)
-	return 1 // 临时返回默认值
-}
-
 // 确保实现了接口
 var _ notificationpb.NotificationServiceServer = (*NotificationServer)(nil)
 var _ notificationpb.NotificationQueryServiceServer = (*NotificationServer)(nil)
@@ -0,0 +1,178 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNotificationServer_CancelNotification(t *testing.T) {
+	testCases := []struct {
+		name       string
+		stored     *domain.Notification
+		cancelErr  error
+		wantCode   codes.Code
+		wantCancel int
+	}{
+		{name: "PENDING", stored: &domain.Notification{ID: 1, Key: "k", Status: domain.SendStatusPending, Version: 1},
+			wantCode: codes.OK, wantCancel: 1},
+		{name: "SENDING", stored: &domain.Notification{ID: 1, Key: "k", Status: domain.SendStatusSending, Version: 2},
+			wantCode: codes.FailedPrecondition},
+		{name: "重复取消", stored: &domain.Notification{ID: 1, Key: "k", Status: domain.SendStatusCanceled, Version: 2},
+			wantCode: codes.OK},
+		{name: "查询之后被调度", stored: &domain.Notification{ID: 1, Key: "k", Status: domain.SendStatusPending, Version: 1},
+			cancelErr: domain.ErrNotificationVersionMismatch, wantCode: codes.Aborted, wantCancel: 1},
+		{name: "不存在", wantCode: codes.NotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &cancelRepo{stored: tc.stored, cancelErr: tc.cancelErr}
+			s := NewServer(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, clock.Real(), log.DefaultLogger())
+			ctx := ctxkit.WithBizID(context.Background(), 7)
+			resp, err := s.CancelNotification(ctx, &notificationpb.CancelNotificationRequest{Key: "k"})
+			if code := status.Code(err); code != tc.wantCode {
+				t.Fatalf("返回 %v, 应该是 %s", err, tc.wantCode)
+			}
+			if repo.canceled != tc.wantCancel {
+				t.Fatalf("调用了 %d 次取消, 应该是 %d 次", repo.canceled, tc.wantCancel)
+			}
+			if tc.wantCode == codes.OK && (resp.GetNotificationId() != 1 || resp.GetStatus() != notificationpb.SendStatus_CANCELED) {
+				t.Fatalf("返回 %v", resp)
+			}
+			if repo.bizID != 7 {
+				t.Fatalf("按业务方 %d 查询", repo.bizID)
+			}
+		})
+	}
+}
+
+// 同步发送立即发送的通知，返回的状态和数据库里保存的状态一致，都由调度器发送
+func TestNotificationServer_SendImmediateStatusMatchesStored(t *testing.T) {
+	repo := &storingRepo{stored: map[uint64]domain.Notification{}}
+	s := NewServer(repo, preparingTemplateSvc{}, nil, nil, nil, nil, &seqIDGenerator{}, nil,
+		passingQuotaSvc{}, passingChannelSvc{}, passingKeyPolicySvc{}, nil, clock.Real(), log.DefaultLogger())
+	ctx := ctxkit.WithBizID(context.Background(), 7)
+	newReq := func(key string) *notificationpb.Notification {
+		return &notificationpb.Notification{Key: key, Receivers: []string{"13800000000"}, Channel: notificationpb.Channel_SMS,
+			TemplateId: "1", TemplateParams: map[string]string{"code": "1234"}}
+	}
+
+	resp, err := s.SendNotification(ctx, &notificationpb.SendNotificationRequest{Notification: newReq("single")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []*notificationpb.SendNotificationResponse{resp}
+	batch, err := s.BatchSendNotifications(ctx, &notificationpb.BatchSendNotificationsRequest{
+		Notifications: []*notificationpb.Notification{newReq("batch-1"), newReq("batch-2")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, batch.GetResults()...)
+
+	for _, r := range got {
+		if r.GetErrorCode() != notificationpb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+			t.Fatalf("发送失败 %v", r)
+		}
+		stored, ok := repo.stored[r.GetNotificationId()]
+		if !ok {
+			t.Fatalf("通知 %d 没有保存", r.GetNotificationId())
+		}
+		if stored.Status != domain.SendStatusPending || r.GetStatus() != notificationpb.SendStatus_PENDING {
+			t.Fatalf("通知 %d 保存的状态 %s, 返回的状态 %s, 都应该是 PENDING", stored.ID, stored.Status, r.GetStatus())
+		}
+	}
+}
+
+// cancelRepo 只实现取消用到的查询和取消
+type cancelRepo struct {
+	repository.NotificationRepository
+	stored    *domain.Notification
+	cancelErr error
+	bizID     int64
+	canceled  int
+}
+
+func (r *cancelRepo) GetByKey(_ context.Context, bizID int64, key string) (domain.Notification, error) {
+	r.bizID = bizID
+	if r.stored == nil || r.stored.Key != key {
+		return domain.Notification{}, fmt.Errorf("%w: bizID=%d, key=%s", domain.ErrNotificationNotFound, bizID, key)
+	}
+	return *r.stored, nil
+}
+
+func (r *cancelRepo) CancelPending(_ context.Context, n domain.Notification) error {
+	r.canceled++
+	if n.Version != r.stored.Version {
+		return domain.ErrNotificationVersionMismatch
+	}
+	return r.cancelErr
+}
+
+// storingRepo 保存创建的通知，同步发送之后不应该再修改状态
+type storingRepo struct {
+	repository.NotificationRepository
+	stored map[uint64]domain.Notification
+}
+
+func (r *storingRepo) CreateWithCallbackLog(_ context.Context, n domain.Notification) (domain.Notification, error) {
+	r.stored[n.ID] = n
+	return n, nil
+}
+
+func (r *storingRepo) BatchCreateWithCallbackLog(_ context.Context, ns []domain.Notification) ([]domain.Notification, error) {
+	for _, n := range ns {
+		r.stored[n.ID] = n
+	}
+	return ns, nil
+}
+
+type preparingTemplateSvc struct {
+	service.ChannelTemplateService
+}
+
+func (preparingTemplateSvc) PrepareTemplate(_ context.Context, n *domain.Notification) error {
+	n.Template.VersionID = 1
+	return nil
+}
+
+type seqIDGenerator struct {
+	next uint64
+}
+
+func (g *seqIDGenerator) NextID() (uint64, error) {
+	g.next++
+	return g.next, nil
+}
+
+type passingQuotaSvc struct{}
+
+func (passingQuotaSvc) Precheck(context.Context, int64, []domain.QuotaDemand) error {
+	return nil
+}
+
+type passingChannelSvc struct {
+	service.BizChannelService
+}
+
+func (passingChannelSvc) Check(context.Context, int64, domain.Channel) error {
+	return nil
+}
+
+type passingKeyPolicySvc struct {
+	service.BizKeyPolicyService
+}
+
+func (passingKeyPolicySvc) Check(context.Context, int64, string) error {
+	return nil
+}
@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	configv1 "github.com/serendipityConfusion/notification-platform/api/gen/config/v1"
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"google.golang.org/grpc/channelz/grpc_channelz_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// MethodPermissions 每个接口需要的权限，新增接口时必须在这里登记，否则开启鉴权后会被拒绝
+var MethodPermissions = map[string]domain.Permission{
+	notificationpb.NotificationService_SendNotification_FullMethodName:            domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_SendNotificationAsync_FullMethodName:       domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_BatchSendNotifications_FullMethodName:      domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_BatchSendNotificationsAsync_FullMethodName: domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_TxPrepare_FullMethodName:                   domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_TxCommit_FullMethodName:                    domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_TxCancel_FullMethodName:                    domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_CancelNotification_FullMethodName:          domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_UpdateNotification_FullMethodName:          domain.PermissionNotificationWrite,
+	notificationpb.NotificationService_GenerateNotificationKeys_FullMethodName:    domain.PermissionNotificationWrite,
+
+	notificationpb.NotificationQueryService_QueryNotification_FullMethodName:       domain.PermissionNotificationRead,
+	notificationpb.NotificationQueryService_BatchQueryNotifications_FullMethodName: domain.PermissionNotificationRead,
+	notificationpb.NotificationQueryService_ListNotifications_FullMethodName:       domain.PermissionNotificationRead,
+	notificationpb.NotificationQueryService_GetFallbackGroup_FullMethodName:        domain.PermissionNotificationRead,
+
+	notificationpb.TemplateService_DescribeTemplate_FullMethodName:        domain.PermissionTemplateRead,
+	notificationpb.TemplateService_DescribeTemplateVersion_FullMethodName: domain.PermissionTemplateRead,
+	notificationpb.TemplateService_ReviewTemplateVersion_FullMethodName:   domain.PermissionTemplateReview,
+	notificationpb.TemplateService_ListUnusedTemplates_FullMethodName:     domain.PermissionTemplateRead,
+	notificationpb.TemplateService_ListTemplateVersions_FullMethodName:    domain.PermissionTemplateRead,
+	notificationpb.TemplateService_DiffTemplateVersions_FullMethodName:    domain.PermissionTemplateRead,
+	notificationpb.TemplateService_RollbackTemplateVersion_FullMethodName: domain.PermissionTemplateWrite,
+
+	notificationpb.StatisticsService_GetDailySendStats_FullMethodName:       domain.PermissionNotificationRead,
+	notificationpb.StatisticsService_GetTopFailingTemplates_FullMethodName:  domain.PermissionNotificationRead,
+	notificationpb.StatisticsService_GetProviderSuccessRates_FullMethodName: domain.PermissionNotificationRead,
+	notificationpb.StatisticsService_GetHourlyTrend_FullMethodName:          domain.PermissionNotificationRead,
+
+	notificationpb.ReadReceiptService_MarkRead_FullMethodName:                 domain.PermissionNotificationWrite,
+	notificationpb.ReadReceiptService_GetNotificationReadStats_FullMethodName: domain.PermissionNotificationRead,
+	notificationpb.ReadReceiptService_GetTemplateReadStats_FullMethodName:     domain.PermissionNotificationRead,
+
+	notificationpb.PushService_IssuePushToken_FullMethodName:              domain.PermissionNotificationWrite,
+	notificationpb.UnsubscribeService_IssueUnsubscribeLink_FullMethodName: domain.PermissionNotificationWrite,
+
+	notificationpb.EscalationService_CreateEscalation_FullMethodName:      domain.PermissionNotificationWrite,
+	notificationpb.EscalationService_AcknowledgeEscalation_FullMethodName: domain.PermissionNotificationWrite,
+	notificationpb.EscalationService_GetEscalation_FullMethodName:         domain.PermissionNotificationRead,
+
+	notificationpb.DataPrivacyService_EraseReceiverData_FullMethodName:   domain.PermissionPrivacyErase,
+	notificationpb.DataPrivacyService_ExportNotifications_FullMethodName: domain.PermissionNotificationRead,
+
+	notificationpb.RoleService_AssignRole_FullMethodName:          domain.PermissionRoleManage,
+	notificationpb.RoleService_RevokeRole_FullMethodName:          domain.PermissionRoleManage,
+	notificationpb.RoleService_ListRoleAssignments_FullMethodName: domain.PermissionRoleRead,
+
+	notificationpb.AdminService_GetLogLevels_FullMethodName:              domain.PermissionAdminRead,
+	notificationpb.AdminService_SetLogLevel_FullMethodName:               domain.PermissionAdminManage,
+	notificationpb.AdminService_ListSendAttempts_FullMethodName:          domain.PermissionAdminRead,
+	notificationpb.AdminService_ListBlacklist_FullMethodName:             domain.PermissionAdminRead,
+	notificationpb.AdminService_DeleteBlacklistEntry_FullMethodName:      domain.PermissionAdminManage,
+	notificationpb.AdminService_ListProviderHealth_FullMethodName:        domain.PermissionAdminRead,
+	notificationpb.AdminService_ListDeadLetterCallbacks_FullMethodName:   domain.PermissionAdminRead,
+	notificationpb.AdminService_ResendDeadLetterCallbacks_FullMethodName: domain.PermissionAdminManage,
+	notificationpb.AdminService_WatchNotification_FullMethodName:         domain.PermissionAdminRead,
+	notificationpb.DebugService_GetRuntimeInfo_FullMethodName:            domain.PermissionAdminRead,
+
+	// gRPC 反射和 channelz，配置开启时才注册
+	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName:      domain.PermissionAdminRead,
+	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: domain.PermissionAdminRead,
+	grpc_channelz_v1.Channelz_GetTopChannels_FullMethodName:                      domain.PermissionAdminRead,
+	grpc_channelz_v1.Channelz_GetServers_FullMethodName:                          domain.PermissionAdminRead,
+	grpc_channelz_v1.Channelz_GetServer_FullMethodName:                           domain.PermissionAdminRead,
+	grpc_channelz_v1.Channelz_GetServerSockets_FullMethodName:                    domain.PermissionAdminRead,
+	grpc_channelz_v1.Channelz_GetChannel_FullMethodName:                          domain.PermissionAdminRead,
+	grpc_channelz_v1.Channelz_GetSubchannel_FullMethodName:                       domain.PermissionAdminRead,
+	grpc_channelz_v1.Channelz_GetSocket_FullMethodName:                           domain.PermissionAdminRead,
+
+	configv1.BusinessConfigService_RotateCallbackSecret_FullMethodName:       domain.PermissionCallbackManage,
+	configv1.BusinessConfigService_ListCallbackEndpointHealth_FullMethodName: domain.PermissionAdminRead,
+	configv1.BusinessConfigService_ListBizChannels_FullMethodName:            domain.PermissionAdminRead,
+	configv1.BusinessConfigService_SetBizChannel_FullMethodName:              domain.PermissionAdminManage,
+	configv1.BusinessConfigService_GetKeyPolicy_FullMethodName:               domain.PermissionAdminRead,
+	configv1.BusinessConfigService_SetKeyPolicy_FullMethodName:               domain.PermissionAdminManage,
+}
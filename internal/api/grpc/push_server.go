@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/api/push"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PushServer 签发站内信推送凭证
+type PushServer struct {
+	notificationpb.UnimplementedPushServiceServer
+
+	signer *push.TokenSigner
+	logger log.LoggerInterface
+}
+
+// NewPushServer signer 为 nil 表示没有开启推送网关
+func NewPushServer(signer *push.TokenSigner, logger log.LoggerInterface) *PushServer {
+	return &PushServer{
+		signer: signer,
+		logger: log.Named(logger, "grpc.push"),
+	}
+}
+
+// IssuePushToken 为调用方业务方的接收者签发推送凭证
+func (s *PushServer) IssuePushToken(ctx context.Context, req *notificationpb.IssuePushTokenRequest) (*notificationpb.IssuePushTokenResponse, error) {
+	if s.signer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "push gateway is disabled")
+	}
+	bizID := getBizIDFromContext(ctx)
+	token, expire, err := s.signer.Issue(bizID, req.GetReceiver(), time.Duration(req.GetTtlSeconds())*time.Second)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidParameter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithContext(ctx).Error("issue push token failed", zap.Int64("biz_id", bizID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to issue push token")
+	}
+	return &notificationpb.IssuePushTokenResponse{
+		Token:      token,
+		ExpireTime: expire.UnixMilli(),
+	}, nil
+}
@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/querylimit"
+)
+
+// QueryMethods 可以按业务方限流的查询接口，发送接口由额度控制，不在这里
+// 值是请求要查询的条数，为 nil 的接口不能限制查询条数
+var QueryMethods = map[string]querylimit.Counter{
+	notificationpb.NotificationQueryService_QueryNotification_FullMethodName: nil,
+	notificationpb.NotificationQueryService_BatchQueryNotifications_FullMethodName: func(req any) int {
+		return len(req.(*notificationpb.BatchQueryNotificationsRequest).GetKeys())
+	},
+	notificationpb.NotificationQueryService_ListNotifications_FullMethodName: func(req any) int {
+		// 不传分页大小时使用默认值
+		if size := int(req.(*notificationpb.ListNotificationsRequest).GetPageSize()); size > 0 {
+			return size
+		}
+		return defaultListPageSize
+	},
+	notificationpb.NotificationQueryService_GetFallbackGroup_FullMethodName: nil,
+
+	notificationpb.StatisticsService_GetDailySendStats_FullMethodName:       nil,
+	notificationpb.StatisticsService_GetTopFailingTemplates_FullMethodName:  nil,
+	notificationpb.StatisticsService_GetProviderSuccessRates_FullMethodName: nil,
+	notificationpb.StatisticsService_GetHourlyTrend_FullMethodName:          nil,
+
+	notificationpb.ReadReceiptService_GetNotificationReadStats_FullMethodName: nil,
+	notificationpb.ReadReceiptService_GetTemplateReadStats_FullMethodName:     nil,
+}
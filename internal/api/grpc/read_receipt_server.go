@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReadReceiptServer 站内信已读回执
+type ReadReceiptServer struct {
+	notificationpb.UnimplementedReadReceiptServiceServer
+
+	readReceiptSvc service.ReadReceiptService
+	logger         log.LoggerInterface
+}
+
+func NewReadReceiptServer(readReceiptSvc service.ReadReceiptService, logger log.LoggerInterface) *ReadReceiptServer {
+	return &ReadReceiptServer{
+		readReceiptSvc: readReceiptSvc,
+		logger:         log.Named(logger, "grpc.read_receipt"),
+	}
+}
+
+// MarkRead 标记已读
+func (s *ReadReceiptServer) MarkRead(ctx context.Context, req *notificationpb.MarkReadRequest) (*notificationpb.MarkReadResponse, error) {
+	receipt, firstRead, err := s.readReceiptSvc.MarkRead(ctx, getBizIDFromContext(ctx), req.GetKey(), req.GetReceiver())
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to mark notification as read")
+	}
+	return &notificationpb.MarkReadResponse{
+		FirstRead: firstRead,
+		ReadTime:  receipt.ReadTime.UnixMilli(),
+	}, nil
+}
+
+// GetNotificationReadStats 一条通知的已读率
+func (s *ReadReceiptServer) GetNotificationReadStats(ctx context.Context, req *notificationpb.GetNotificationReadStatsRequest) (*notificationpb.GetNotificationReadStatsResponse, error) {
+	stats, err := s.readReceiptSvc.NotificationReadStats(ctx, getBizIDFromContext(ctx), req.GetKey())
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get notification read stats")
+	}
+	return &notificationpb.GetNotificationReadStatsResponse{Stats: convertReadStats(stats)}, nil
+}
+
+// GetTemplateReadStats 一个模板在时间范围内发出的站内信的已读率
+func (s *ReadReceiptServer) GetTemplateReadStats(ctx context.Context, req *notificationpb.GetTemplateReadStatsRequest) (*notificationpb.GetTemplateReadStatsResponse, error) {
+	query := domain.StatsQuery{
+		BizID: getBizIDFromContext(ctx),
+		Start: time.UnixMilli(req.GetStartTime()),
+		End:   time.UnixMilli(req.GetEndTime()),
+	}
+	stats, err := s.readReceiptSvc.TemplateReadStats(ctx, query, req.GetTemplateId())
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get template read stats")
+	}
+	return &notificationpb.GetTemplateReadStatsResponse{Stats: convertReadStats(stats)}, nil
+}
+
+func convertReadStats(stats domain.ReadStats) *notificationpb.ReadStats {
+	return &notificationpb.ReadStats{
+		Receivers: stats.Receivers,
+		Reads:     stats.Reads,
+		ReadRate:  stats.ReadRate(),
+	}
+}
+
+func (s *ReadReceiptServer) toStatus(ctx context.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidParameter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotificationNotFound), errors.Is(err, domain.ErrReceiverNotInNotification):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrNotificationNotReadable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	s.logger.WithContext(ctx).Error(msg, zap.Int64("biz_id", getBizIDFromContext(ctx)), zap.Error(err))
+	return status.Error(codes.Internal, msg)
+}
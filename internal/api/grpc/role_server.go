@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RoleServer struct {
+	notificationpb.UnimplementedRoleServiceServer
+
+	rbacSvc service.RBACService
+	logger  log.LoggerInterface
+}
+
+func NewRoleServer(rbacSvc service.RBACService, logger log.LoggerInterface) *RoleServer {
+	return &RoleServer{
+		rbacSvc: rbacSvc,
+		logger:  log.Named(logger, "grpc.role"),
+	}
+}
+
+// AssignRole 分配角色
+func (s *RoleServer) AssignRole(ctx context.Context, req *notificationpb.AssignRoleRequest) (*notificationpb.AssignRoleResponse, error) {
+	operator, ok := ctxkit.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "role management requires authentication")
+	}
+	err := s.rbacSvc.AssignRole(ctx, operator, domain.RoleAssignment{
+		Principal: req.GetPrincipal(),
+		BizID:     req.GetBizId(),
+		Role:      convertRole(req.GetRole()),
+	})
+	if err != nil {
+		return nil, s.toStatus(err, "failed to assign role")
+	}
+	return &notificationpb.AssignRoleResponse{}, nil
+}
+
+// RevokeRole 撤销角色
+func (s *RoleServer) RevokeRole(ctx context.Context, req *notificationpb.RevokeRoleRequest) (*notificationpb.RevokeRoleResponse, error) {
+	operator, ok := ctxkit.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "role management requires authentication")
+	}
+	if err := s.rbacSvc.RevokeRole(ctx, operator, req.GetPrincipal(), req.GetBizId()); err != nil {
+		return nil, s.toStatus(err, "failed to revoke role")
+	}
+	return &notificationpb.RevokeRoleResponse{}, nil
+}
+
+// ListRoleAssignments 查询业务方下的全部角色分配
+func (s *RoleServer) ListRoleAssignments(ctx context.Context, req *notificationpb.ListRoleAssignmentsRequest) (*notificationpb.ListRoleAssignmentsResponse, error) {
+	operator, ok := ctxkit.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "role management requires authentication")
+	}
+	assignments, err := s.rbacSvc.ListRoleAssignments(ctx, operator, req.GetBizId())
+	if err != nil {
+		return nil, s.toStatus(err, "failed to list role assignments")
+	}
+	resp := &notificationpb.ListRoleAssignmentsResponse{
+		Assignments: make([]*notificationpb.RoleAssignment, 0, len(assignments)),
+	}
+	for _, a := range assignments {
+		resp.Assignments = append(resp.Assignments, &notificationpb.RoleAssignment{
+			Principal: a.Principal,
+			BizId:     a.BizID,
+			Role:      convertRoleToAPI(a.Role),
+			Operator:  a.Operator,
+			Utime:     a.Utime.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *RoleServer) toStatus(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidParameter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrRoleAssignmentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		s.logger.Error(msg, zap.Error(err))
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+func convertRole(role notificationpb.Role) domain.Role {
+	switch role {
+	case notificationpb.Role_PLATFORM_ADMIN:
+		return domain.RolePlatformAdmin
+	case notificationpb.Role_BIZ_ADMIN:
+		return domain.RoleBizAdmin
+	case notificationpb.Role_READ_ONLY:
+		return domain.RoleReadOnly
+	default:
+		return ""
+	}
+}
+
+func convertRoleToAPI(role domain.Role) notificationpb.Role {
+	switch role {
+	case domain.RolePlatformAdmin:
+		return notificationpb.Role_PLATFORM_ADMIN
+	case domain.RoleBizAdmin:
+		return notificationpb.Role_BIZ_ADMIN
+	case domain.RoleReadOnly:
+		return notificationpb.Role_READ_ONLY
+	default:
+		return notificationpb.Role_ROLE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type StatisticsServer struct {
+	notificationpb.UnimplementedStatisticsServiceServer
+
+	statsSvc service.StatisticsService
+	logger   log.LoggerInterface
+}
+
+func NewStatisticsServer(statsSvc service.StatisticsService, logger log.LoggerInterface) *StatisticsServer {
+	return &StatisticsServer{
+		statsSvc: statsSvc,
+		logger:   log.Named(logger, "grpc.statistics"),
+	}
+}
+
+// GetDailySendStats 按天、渠道、状态统计通知数量
+func (s *StatisticsServer) GetDailySendStats(ctx context.Context, req *notificationpb.GetDailySendStatsRequest) (*notificationpb.GetDailySendStatsResponse, error) {
+	stats, err := s.statsSvc.DailySendCounts(ctx, s.statsQuery(ctx, req.GetStartTime(), req.GetEndTime()))
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get daily send stats")
+	}
+	resp := &notificationpb.GetDailySendStatsResponse{
+		Stats: make([]*notificationpb.DailySendStat, 0, len(stats)),
+	}
+	for _, st := range stats {
+		resp.Stats = append(resp.Stats, &notificationpb.DailySendStat{
+			Date:    st.Date,
+			Channel: convertChannel(st.Channel),
+			Status:  convertSendStatus(st.Status),
+			Count:   st.Count,
+		})
+	}
+	return resp, nil
+}
+
+// GetTopFailingTemplates 失败数量最多的模板
+func (s *StatisticsServer) GetTopFailingTemplates(ctx context.Context, req *notificationpb.GetTopFailingTemplatesRequest) (*notificationpb.GetTopFailingTemplatesResponse, error) {
+	templates, err := s.statsSvc.TopFailingTemplates(ctx,
+		s.statsQuery(ctx, req.GetStartTime(), req.GetEndTime()), int(req.GetLimit()))
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get top failing templates")
+	}
+	resp := &notificationpb.GetTopFailingTemplatesResponse{
+		Templates: make([]*notificationpb.TemplateFailureStat, 0, len(templates)),
+	}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, &notificationpb.TemplateFailureStat{
+			TemplateId:  t.TemplateID,
+			Total:       t.Total,
+			Failed:      t.Failed,
+			FailureRate: t.FailureRate(),
+		})
+	}
+	return resp, nil
+}
+
+// GetProviderSuccessRates 各个供应商的发送成功率
+func (s *StatisticsServer) GetProviderSuccessRates(ctx context.Context, req *notificationpb.GetProviderSuccessRatesRequest) (*notificationpb.GetProviderSuccessRatesResponse, error) {
+	rates, err := s.statsSvc.ProviderSuccessRates(ctx, s.statsQuery(ctx, req.GetStartTime(), req.GetEndTime()))
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get provider success rates")
+	}
+	resp := &notificationpb.GetProviderSuccessRatesResponse{
+		Providers: make([]*notificationpb.ProviderSuccessRate, 0, len(rates)),
+	}
+	for _, r := range rates {
+		resp.Providers = append(resp.Providers, &notificationpb.ProviderSuccessRate{
+			Provider:    r.Provider,
+			Channel:     convertChannel(r.Channel),
+			Dispatched:  r.Dispatched,
+			Failed:      r.Failed,
+			SuccessRate: r.SuccessRate(),
+		})
+	}
+	return resp, nil
+}
+
+// GetHourlyTrend 按小时、渠道、状态统计通知数量
+func (s *StatisticsServer) GetHourlyTrend(ctx context.Context, req *notificationpb.GetHourlyTrendRequest) (*notificationpb.GetHourlyTrendResponse, error) {
+	query := s.statsQuery(ctx, req.GetStartTime(), req.GetEndTime())
+	if req.GetChannel() != notificationpb.Channel_CHANNEL_UNSPECIFIED {
+		query.Channel = domain.Channel(req.GetChannel().String())
+	}
+	points, err := s.statsSvc.HourlyTrend(ctx, query)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to get hourly trend")
+	}
+	resp := &notificationpb.GetHourlyTrendResponse{
+		Points: make([]*notificationpb.HourlySendStat, 0, len(points)),
+	}
+	for _, p := range points {
+		resp.Points = append(resp.Points, &notificationpb.HourlySendStat{
+			Hour:    p.Hour.UnixMilli(),
+			Channel: convertChannel(p.Channel),
+			Status:  convertSendStatus(p.Status),
+			Count:   p.Count,
+		})
+	}
+	return resp, nil
+}
+
+func (s *StatisticsServer) statsQuery(ctx context.Context, start, end int64) domain.StatsQuery {
+	return domain.StatsQuery{
+		BizID:       getBizIDFromContext(ctx),
+		Start:       time.UnixMilli(start),
+		End:         time.UnixMilli(end),
+		Environment: ctxkit.EnvironmentFromContext(ctx),
+	}
+}
+
+func (s *StatisticsServer) toStatus(ctx context.Context, err error, msg string) error {
+	if errors.Is(err, domain.ErrInvalidParameter) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.logger.WithContext(ctx).Error(msg, zap.Int64("biz_id", getBizIDFromContext(ctx)), zap.Error(err))
+	return status.Error(codes.Internal, msg)
+}
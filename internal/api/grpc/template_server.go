@@ -0,0 +1,411 @@
+package grpc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TemplateServer struct {
+	notificationpb.UnimplementedTemplateServiceServer
+
+	templateSvc service.ChannelTemplateService
+	reviewSvc   service.TemplateReviewService
+	usageSvc    service.TemplateUsageService
+	logger      log.LoggerInterface
+}
+
+func NewTemplateServer(templateSvc service.ChannelTemplateService, reviewSvc service.TemplateReviewService,
+	usageSvc service.TemplateUsageService, logger log.LoggerInterface,
+) *TemplateServer {
+	return &TemplateServer{
+		templateSvc: templateSvc,
+		reviewSvc:   reviewSvc,
+		usageSvc:    usageSvc,
+		logger:      log.Named(logger, "grpc.template"),
+	}
+}
+
+// DescribeTemplate 查询模板及当前生效版本的参数定义
+func (s *TemplateServer) DescribeTemplate(ctx context.Context, req *notificationpb.DescribeTemplateRequest) (*notificationpb.DescribeTemplateResponse, error) {
+	templateID, err := strconv.ParseInt(req.GetTemplateId(), 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid template_id: %s", req.GetTemplateId())
+	}
+
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+
+	template, err := s.templateSvc.GetTemplateByID(ctx, bizID, templateID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTemplateNotFound) {
+			return nil, status.Error(codes.NotFound, "template not found")
+		}
+		s.logger.WithContext(ctx).Error("get template failed",
+			zap.Int64("template_id", templateID),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to describe template")
+	}
+
+	resp := &notificationpb.DescribeTemplateResponse{
+		TemplateId:  strconv.FormatInt(template.ID, 10),
+		Name:        template.Name,
+		Description: template.Description,
+		Channel:     convertChannel(template.Channel),
+	}
+	usage, err := s.usageSvc.GetUsage(ctx, bizID, template.ID)
+	if err != nil {
+		// 使用情况只是参考信息，查询失败不影响查询模板
+		s.logger.WithContext(ctx).Warn("get template usage failed",
+			zap.Int64("template_id", templateID),
+			zap.Error(err))
+	} else {
+		resp.Usage = convertTemplateUsage(usage)
+	}
+	version, err := template.ActiveVersion()
+	if err != nil {
+		// 没有生效版本时只返回模板基本信息
+		return resp, nil
+	}
+	resp.ActiveVersionId = strconv.FormatInt(version.ID, 10)
+	resp.Content = version.Content
+	resp.Params = make([]*notificationpb.TemplateParam, 0, len(version.ParamSchema))
+	for _, p := range version.ParamSchema {
+		resp.Params = append(resp.Params, convertTemplateParam(&p))
+	}
+	return resp, nil
+}
+
+// DescribeTemplateVersion 查询模板版本的内部审核和供应商审核状态
+func (s *TemplateServer) DescribeTemplateVersion(ctx context.Context, req *notificationpb.DescribeTemplateVersionRequest) (*notificationpb.DescribeTemplateVersionResponse, error) {
+	templateID, versionID, err := parseTemplateVersionID(req.GetTemplateId(), req.GetVersionId())
+	if err != nil {
+		return nil, err
+	}
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	template, err := s.templateSvc.GetTemplateByID(ctx, bizID, templateID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to describe template version")
+	}
+	version, err := template.FindVersion(versionID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to describe template version")
+	}
+	return convertTemplateVersion(template.ID, version), nil
+}
+
+// ReviewTemplateVersion 平台内部审核，审核通过后由后台任务提交给供应商审核
+func (s *TemplateServer) ReviewTemplateVersion(ctx context.Context, req *notificationpb.ReviewTemplateVersionRequest) (*notificationpb.ReviewTemplateVersionResponse, error) {
+	templateID, versionID, err := parseTemplateVersionID(req.GetTemplateId(), req.GetVersionId())
+	if err != nil {
+		return nil, err
+	}
+	// 没有开启鉴权时不记录审核人
+	caller, _ := ctxkit.CallerFromContext(ctx)
+	version, err := s.reviewSvc.ReviewVersion(ctx, templateID, versionID, caller.Subject, req.GetApproved(), req.GetReason())
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to review template version")
+	}
+	s.logger.WithContext(ctx).Info("template version reviewed",
+		zap.Int64("template_id", templateID),
+		zap.Int64("version_id", versionID),
+		zap.String("audit_status", version.AuditStatus.String()))
+	return &notificationpb.ReviewTemplateVersionResponse{Version: convertTemplateVersion(templateID, version)}, nil
+}
+
+// ListTemplateVersions 查询模板的版本历史，按版本ID降序
+func (s *TemplateServer) ListTemplateVersions(ctx context.Context, req *notificationpb.ListTemplateVersionsRequest) (*notificationpb.ListTemplateVersionsResponse, error) {
+	templateID, err := strconv.ParseInt(req.GetTemplateId(), 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid template_id: %s", req.GetTemplateId())
+	}
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	template, err := s.templateSvc.GetTemplateByID(ctx, bizID, templateID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to list template versions")
+	}
+	versions := slices.SortedFunc(slices.Values(template.Versions), func(a, b domain.ChannelTemplateVersion) int {
+		return cmp.Compare(b.ID, a.ID)
+	})
+	resp := &notificationpb.ListTemplateVersionsResponse{
+		Versions: make([]*notificationpb.TemplateVersionSummary, 0, len(versions)),
+	}
+	for _, v := range versions {
+		resp.Versions = append(resp.Versions, &notificationpb.TemplateVersionSummary{
+			VersionId:           strconv.FormatInt(v.ID, 10),
+			Name:                v.Name,
+			AuditStatus:         convertAuditStatus(v.AuditStatus),
+			Reviewer:            v.Reviewer,
+			ReviewTime:          v.ReviewTime,
+			ForkedFromVersionId: formatOptionalID(v.ForkedFromVersionID),
+			Active:              v.ID == template.ActiveVersionID,
+			Sendable:            v.CheckApproved() == nil,
+			Ctime:               v.Ctime,
+		})
+	}
+	return resp, nil
+}
+
+// DiffTemplateVersions 比较同一个模板的两个版本
+func (s *TemplateServer) DiffTemplateVersions(ctx context.Context, req *notificationpb.DiffTemplateVersionsRequest) (*notificationpb.DiffTemplateVersionsResponse, error) {
+	templateID, fromVersionID, err := parseTemplateVersionID(req.GetTemplateId(), req.GetFromVersionId())
+	if err != nil {
+		return nil, err
+	}
+	toVersionID, err := strconv.ParseInt(req.GetToVersionId(), 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid to_version_id: %s", req.GetToVersionId())
+	}
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	diff, err := s.templateSvc.DiffVersions(ctx, bizID, templateID, fromVersionID, toVersionID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to diff template versions")
+	}
+	resp := &notificationpb.DiffTemplateVersionsResponse{
+		Fields:  make([]*notificationpb.TemplateFieldChange, 0, len(diff.Fields)),
+		Content: make([]*notificationpb.TemplateDiffLine, 0, len(diff.Content)),
+		Params:  make([]*notificationpb.TemplateParamChange, 0, len(diff.Params)),
+	}
+	for _, f := range diff.Fields {
+		resp.Fields = append(resp.Fields, &notificationpb.TemplateFieldChange{Field: f.Field, From: f.From, To: f.To})
+	}
+	for _, line := range diff.Content {
+		resp.Content = append(resp.Content, &notificationpb.TemplateDiffLine{Op: convertDiffOp(line.Op), Text: line.Text})
+	}
+	for _, p := range diff.Params {
+		resp.Params = append(resp.Params, &notificationpb.TemplateParamChange{
+			Name: p.Name,
+			From: convertTemplateParam(p.From),
+			To:   convertTemplateParam(p.To),
+		})
+	}
+	return resp, nil
+}
+
+// RollbackTemplateVersion 拷贝审核通过的历史版本作为新的生效版本
+func (s *TemplateServer) RollbackTemplateVersion(ctx context.Context, req *notificationpb.RollbackTemplateVersionRequest) (*notificationpb.RollbackTemplateVersionResponse, error) {
+	templateID, versionID, err := parseTemplateVersionID(req.GetTemplateId(), req.GetVersionId())
+	if err != nil {
+		return nil, err
+	}
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	version, err := s.templateSvc.RollbackVersion(ctx, bizID, templateID, versionID)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to rollback template version")
+	}
+	s.logger.WithContext(ctx).Info("template version rolled back",
+		zap.Int64("template_id", templateID),
+		zap.Int64("forked_from_version_id", versionID),
+		zap.Int64("version_id", version.ID))
+	return &notificationpb.RollbackTemplateVersionResponse{Version: convertTemplateVersion(templateID, version)}, nil
+}
+
+// ListUnusedTemplates 查询超过指定天数没有用过的模板，按模板ID升序翻页
+func (s *TemplateServer) ListUnusedTemplates(ctx context.Context, req *notificationpb.ListUnusedTemplatesRequest) (*notificationpb.ListUnusedTemplatesResponse, error) {
+	bizID := getBizIDFromContext(ctx)
+	if bizID == 0 {
+		return nil, status.Error(codes.InvalidArgument, "bizID is required")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultListPageSize
+	}
+	if pageSize < 0 || pageSize > maxListPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxListPageSize)
+	}
+	templates, err := s.usageSvc.FindUnused(ctx, domain.UnusedTemplateQuery{
+		BizID:      bizID,
+		UnusedDays: int(req.GetUnusedDays()),
+		AfterID:    req.GetAfterId(),
+		// 多查一条判断有没有下一页
+		Limit: pageSize + 1,
+	})
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "failed to list unused templates")
+	}
+	resp := &notificationpb.ListUnusedTemplatesResponse{}
+	if len(templates) > pageSize {
+		templates = templates[:pageSize]
+		resp.NextAfterId = templates[pageSize-1].Template.ID
+	}
+	resp.Templates = make([]*notificationpb.UnusedTemplate, 0, len(templates))
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, &notificationpb.UnusedTemplate{
+			TemplateId: strconv.FormatInt(t.Template.ID, 10),
+			Name:       t.Template.Name,
+			Channel:    convertChannel(t.Template.Channel),
+			Ctime:      t.Template.Ctime,
+			Usage:      convertTemplateUsage(t.Usage),
+		})
+	}
+	return resp, nil
+}
+
+func (s *TemplateServer) toStatus(ctx context.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidParameter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrTemplateNotFound), errors.Is(err, domain.ErrTemplateVersionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrUpdateTemplateVersionAuditStatusFailed),
+		errors.Is(err, domain.ErrTemplateVersionNotApprovedByPlatform),
+		errors.Is(err, domain.ErrTemplateVersionNotApprovedByProvider):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrForkVersionFailed):
+		// 并发回滚时生效版本已经变了，重新查询版本历史之后再决定
+		return status.Error(codes.Aborted, err.Error())
+	}
+	s.logger.WithContext(ctx).Error(msg, zap.Int64("biz_id", getBizIDFromContext(ctx)), zap.Error(err))
+	return status.Error(codes.Internal, msg)
+}
+
+func parseTemplateVersionID(rawTemplateID, rawVersionID string) (templateID, versionID int64, err error) {
+	templateID, err = strconv.ParseInt(rawTemplateID, 10, 64)
+	if err != nil {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid template_id: %s", rawTemplateID)
+	}
+	versionID, err = strconv.ParseInt(rawVersionID, 10, 64)
+	if err != nil {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid version_id: %s", rawVersionID)
+	}
+	return templateID, versionID, nil
+}
+
+func convertTemplateVersion(templateID int64, version domain.ChannelTemplateVersion) *notificationpb.DescribeTemplateVersionResponse {
+	resp := &notificationpb.DescribeTemplateVersionResponse{
+		TemplateId:          strconv.FormatInt(templateID, 10),
+		VersionId:           strconv.FormatInt(version.ID, 10),
+		Name:                version.Name,
+		Content:             version.Content,
+		AuditStatus:         convertAuditStatus(version.AuditStatus),
+		RejectReason:        version.RejectReason,
+		Providers:           make([]*notificationpb.TemplateProviderReview, 0, len(version.Providers)),
+		Sendable:            version.CheckApproved() == nil,
+		Reviewer:            version.Reviewer,
+		ReviewTime:          version.ReviewTime,
+		ForkedFromVersionId: formatOptionalID(version.ForkedFromVersionID),
+	}
+	for _, p := range version.Providers {
+		resp.Providers = append(resp.Providers, &notificationpb.TemplateProviderReview{
+			ProviderName:       p.ProviderName,
+			ProviderTemplateId: p.ProviderTemplateID,
+			AuditStatus:        convertAuditStatus(p.AuditStatus),
+			RejectReason:       p.RejectReason,
+			Utime:              p.Utime,
+		})
+	}
+	return resp
+}
+
+// formatOptionalID 0 表示没有，返回空字符串
+func formatOptionalID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+func convertTemplateParam(p *domain.TemplateParam) *notificationpb.TemplateParam {
+	if p == nil {
+		return nil
+	}
+	return &notificationpb.TemplateParam{
+		Name:        p.Name,
+		Type:        convertTemplateParamType(p.Type),
+		Required:    p.Required,
+		Description: p.Description,
+		Format:      p.Format,
+	}
+}
+
+func convertDiffOp(op domain.DiffOp) notificationpb.TemplateDiffLine_Op {
+	switch op {
+	case domain.DiffOpEqual:
+		return notificationpb.TemplateDiffLine_EQUAL
+	case domain.DiffOpDelete:
+		return notificationpb.TemplateDiffLine_DELETE
+	case domain.DiffOpInsert:
+		return notificationpb.TemplateDiffLine_INSERT
+	default:
+		return notificationpb.TemplateDiffLine_OP_UNSPECIFIED
+	}
+}
+
+func convertTemplateUsage(usage domain.TemplateUsage) *notificationpb.TemplateUsage {
+	return &notificationpb.TemplateUsage{
+		SendCount:    usage.SendCount,
+		LastUsedTime: usage.LastUsedTime,
+	}
+}
+
+func convertAuditStatus(s domain.AuditStatus) notificationpb.AuditStatus {
+	switch s {
+	case domain.AuditStatusPending:
+		return notificationpb.AuditStatus_AUDIT_PENDING
+	case domain.AuditStatusInReview:
+		return notificationpb.AuditStatus_AUDIT_IN_REVIEW
+	case domain.AuditStatusApproved:
+		return notificationpb.AuditStatus_AUDIT_APPROVED
+	case domain.AuditStatusRejected:
+		return notificationpb.AuditStatus_AUDIT_REJECTED
+	default:
+		return notificationpb.AuditStatus_AUDIT_STATUS_UNSPECIFIED
+	}
+}
+
+func convertChannel(channel domain.Channel) notificationpb.Channel {
+	switch channel {
+	case domain.ChannelSMS:
+		return notificationpb.Channel_SMS
+	case domain.ChannelEmail:
+		return notificationpb.Channel_EMAIL
+	case domain.ChannelInApp:
+		return notificationpb.Channel_IN_APP
+	default:
+		return notificationpb.Channel_CHANNEL_UNSPECIFIED
+	}
+}
+
+func convertTemplateParamType(t domain.TemplateParamType) notificationpb.TemplateParamType {
+	switch t {
+	case domain.TemplateParamTypeString:
+		return notificationpb.TemplateParamType_STRING
+	case domain.TemplateParamTypeNumber:
+		return notificationpb.TemplateParamType_NUMBER
+	case domain.TemplateParamTypeCurrency:
+		return notificationpb.TemplateParamType_CURRENCY
+	case domain.TemplateParamTypeDate:
+		return notificationpb.TemplateParamType_DATE
+	case domain.TemplateParamTypeList:
+		return notificationpb.TemplateParamType_LIST
+	default:
+		return notificationpb.TemplateParamType_TEMPLATE_PARAM_TYPE_UNSPECIFIED
+	}
+}
+
+var _ notificationpb.TemplateServiceServer = (*TemplateServer)(nil)
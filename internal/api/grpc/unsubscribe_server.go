@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/api/unsubscribe"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnsubscribeServer 签发退订链接
+type UnsubscribeServer struct {
+	notificationpb.UnimplementedUnsubscribeServiceServer
+
+	signer *unsubscribe.TokenSigner
+	logger log.LoggerInterface
+}
+
+// NewUnsubscribeServer signer 为 nil 表示没有开启退订
+func NewUnsubscribeServer(signer *unsubscribe.TokenSigner, logger log.LoggerInterface) *UnsubscribeServer {
+	return &UnsubscribeServer{
+		signer: signer,
+		logger: log.Named(logger, "grpc.unsubscribe"),
+	}
+}
+
+// IssueUnsubscribeLink 为调用方业务方的接收者签发退订链接
+func (s *UnsubscribeServer) IssueUnsubscribeLink(ctx context.Context, req *notificationpb.IssueUnsubscribeLinkRequest) (*notificationpb.IssueUnsubscribeLinkResponse, error) {
+	if s.signer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "unsubscribe is disabled")
+	}
+	bizID := getBizIDFromContext(ctx)
+	channel := domain.Channel(req.GetChannel().String())
+	link, token, expire, err := s.signer.Issue(bizID, channel, req.GetReceiver())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidParameter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithContext(ctx).Error("issue unsubscribe link failed", zap.Int64("biz_id", bizID), zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to issue unsubscribe link")
+	}
+	return &notificationpb.IssueUnsubscribeLinkResponse{
+		Url:        link,
+		Token:      token,
+		ExpireTime: expire.UnixMilli(),
+	}, nil
+}
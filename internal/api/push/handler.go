@@ -0,0 +1,192 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	writeTimeout     = 10 * time.Second
+	resubscribeDelay = time.Second
+	bearerPrefix     = "Bearer "
+)
+
+// Inbox 站内信收件箱，目前直接读取 IN_APP 渠道的通知
+type Inbox interface {
+	// FindByReceiver 按ID倒序查询接收者的通知
+	FindByReceiver(ctx context.Context, bizID int64, receiver string, limit int) ([]domain.Notification, error)
+}
+
+// Options 推送网关参数
+type Options struct {
+	// MaxConnsPerReceiver 同一个接收者在一个实例上最多多少个连接
+	MaxConnsPerReceiver int
+	// SendBuffer 每个连接待发送消息的缓冲，满了断开连接
+	SendBuffer int
+	// ReplayLimit 连接建立时从收件箱补发的最多条数
+	ReplayLimit int
+	// PingInterval 心跳间隔，中间的代理通常会断开长时间没有数据的连接
+	PingInterval time.Duration
+}
+
+// frame 发给客户端的消息，type 是 notification 或者 ping
+type frame struct {
+	Type string               `json:"type"`
+	Data *domain.InAppMessage `json:"data,omitempty"`
+}
+
+// Handler WebSocket 推送网关，持有用户的长连接，订阅事件总线把站内信实时推给在线用户
+// 客户端连接 /v1/push/ws?token=<推送凭证>&after=<最后收到的通知ID>，连接建立时先从收件箱补发 after 之后的站内信，
+// 不在线期间的站内信就这样在重新连接时补上
+type Handler struct {
+	bus    eventbus.InAppBus
+	signer *TokenSigner
+	inbox  Inbox
+	hub    *hub
+	opts   Options
+	logger log.LoggerInterface
+}
+
+func NewHandler(bus eventbus.InAppBus, signer *TokenSigner, inbox Inbox, opts Options) *Handler {
+	return &Handler{
+		bus:    bus,
+		signer: signer,
+		inbox:  inbox,
+		hub:    newHub(opts.MaxConnsPerReceiver, opts.SendBuffer),
+		opts:   opts,
+		logger: log.Named(log.DefaultLogger(), "http.push"),
+	}
+}
+
+// Start 订阅事件总线，阻塞运行直到 ctx 被取消，订阅断开时自动重新订阅
+// 退出时关闭所有连接
+func (h *Handler) Start(ctx context.Context) {
+	defer h.hub.closeAll()
+	for {
+		err := h.bus.Subscribe(ctx, h.hub.deliver)
+		if ctx.Err() != nil {
+			return
+		}
+		h.logger.WithContext(ctx).Error("站内信事件订阅断开，稍后重新订阅", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bizID, receiver, err := h.signer.Verify(requestToken(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var after uint64
+	if v := r.URL.Query().Get("after"); v != "" {
+		if after, err = strconv.ParseUint(v, 10, 64); err != nil {
+			http.Error(w, "after 必须是通知ID", http.StatusBadRequest)
+			return
+		}
+	}
+	sub := subscriber{bizID: bizID, receiver: receiver}
+	c, ok := h.hub.register(sub)
+	if !ok {
+		http.Error(w, "连接数超过上限", http.StatusTooManyRequests)
+		return
+	}
+	defer h.hub.unregister(sub, c)
+	websocket.Server{
+		// 凭证已经校验过，客户端可能来自业务方的任意域名，不校验 Origin
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			h.serve(r.Context(), ws, c, sub, after)
+		},
+	}.ServeHTTP(w, r)
+}
+
+// requestToken 推送凭证优先从 Authorization 头读取，浏览器建立 WebSocket 不能设置请求头，也可以放在 token 参数里
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, bearerPrefix) {
+		return strings.TrimPrefix(auth, bearerPrefix)
+	}
+	return r.URL.Query().Get("token")
+}
+
+func (h *Handler) serve(ctx context.Context, ws *websocket.Conn, c *conn, sub subscriber, after uint64) {
+	defer ws.Close()
+	// 客户端不需要发消息，读只是为了发现连接断开
+	go func() {
+		var discard []byte
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+		c.close()
+	}()
+
+	replayed, err := h.replay(ctx, ws, sub, after)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			h.logger.WithContext(ctx).Warn("补发站内信失败", zap.Int64("biz_id", sub.bizID), zap.Error(err))
+		}
+		return
+	}
+	ticker := time.NewTicker(h.opts.PingInterval)
+	defer ticker.Stop()
+	for {
+		var f frame
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			f = frame{Type: "ping"}
+		case msg := <-c.send:
+			// 注册连接之后、补发完成之前到达的消息可能已经补发过
+			if _, ok := replayed[msg.NotificationID]; ok {
+				continue
+			}
+			f = frame{Type: "notification", Data: &msg}
+		}
+		if err = h.send(ws, f); err != nil {
+			return
+		}
+	}
+}
+
+// replay 从收件箱补发 after 之后发送成功的站内信，按ID升序，返回补发的通知ID
+func (h *Handler) replay(ctx context.Context, ws *websocket.Conn, sub subscriber, after uint64) (map[uint64]struct{}, error) {
+	notifications, err := h.inbox.FindByReceiver(ctx, sub.bizID, sub.receiver, h.opts.ReplayLimit)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(notifications)
+	replayed := make(map[uint64]struct{}, len(notifications))
+	for _, n := range notifications {
+		if n.ID <= after || n.Channel != domain.ChannelInApp || n.Status != domain.SendStatusSucceeded {
+			continue
+		}
+		msg := domain.NewInAppMessage(n, sub.receiver)
+		if err = h.send(ws, frame{Type: "notification", Data: &msg}); err != nil {
+			return nil, err
+		}
+		replayed[n.ID] = struct{}{}
+	}
+	return replayed, nil
+}
+
+func (h *Handler) send(ws *websocket.Conn, f frame) error {
+	if err := ws.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(ws, f)
+}
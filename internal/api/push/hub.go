@@ -0,0 +1,123 @@
+package push
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+var (
+	connectionsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "notification_push_connections",
+		Help: "Number of in-app push connections held by this instance",
+	})
+	deliveredCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_push_messages_total",
+		Help: "In-app messages received from the event bus by result (delivered, offline, dropped)",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(connectionsGauge, deliveredCounter)
+}
+
+type subscriber struct {
+	bizID    int64
+	receiver string
+}
+
+// conn 一个长连接，send 满了说明客户端太慢，直接断开，客户端重连之后从收件箱补发
+type conn struct {
+	send      chan domain.InAppMessage
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *conn) close() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+// hub 本实例持有的长连接，同一个接收者可以有多个连接（多个设备）
+type hub struct {
+	mu         sync.Mutex
+	conns      map[subscriber]map[*conn]struct{}
+	maxPerSub  int
+	bufferSize int
+}
+
+func newHub(maxPerSub, bufferSize int) *hub {
+	return &hub{
+		conns:      make(map[subscriber]map[*conn]struct{}),
+		maxPerSub:  maxPerSub,
+		bufferSize: bufferSize,
+	}
+}
+
+// register 超过单个接收者的连接数上限时返回 false
+func (h *hub) register(sub subscriber) (*conn, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set := h.conns[sub]
+	if len(set) >= h.maxPerSub {
+		return nil, false
+	}
+	if set == nil {
+		set = make(map[*conn]struct{})
+		h.conns[sub] = set
+	}
+	c := &conn{
+		send: make(chan domain.InAppMessage, h.bufferSize),
+		done: make(chan struct{}),
+	}
+	set[c] = struct{}{}
+	connectionsGauge.Inc()
+	return c, true
+}
+
+func (h *hub) unregister(sub subscriber, c *conn) {
+	c.close()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set := h.conns[sub]
+	if _, ok := set[c]; !ok {
+		return
+	}
+	delete(set, c)
+	if len(set) == 0 {
+		delete(h.conns, sub)
+	}
+	connectionsGauge.Dec()
+}
+
+// deliver 推给接收者在本实例上的所有连接，不阻塞
+func (h *hub) deliver(msg domain.InAppMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set := h.conns[subscriber{bizID: msg.BizID, receiver: msg.Receiver}]
+	if len(set) == 0 {
+		// 接收者不在本实例上，或者不在线，上线之后从收件箱补发
+		deliveredCounter.WithLabelValues("offline").Inc()
+		return
+	}
+	for c := range set {
+		select {
+		case c.send <- msg:
+			deliveredCounter.WithLabelValues("delivered").Inc()
+		default:
+			deliveredCounter.WithLabelValues("dropped").Inc()
+			c.close()
+		}
+	}
+}
+
+// closeAll 关闭所有连接，网关退出时调用，http.Server.Shutdown 不会关闭已经升级的连接
+func (h *hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, set := range h.conns {
+		for c := range set {
+			c.close()
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+)
+
+// inboxResponse 收件箱的一页，nextBefore 为空表示没有下一页
+type inboxResponse struct {
+	Notifications []domain.InAppMessage `json:"notifications"`
+	NextBefore    string                `json:"nextBefore,omitempty"`
+}
+
+// InboxHandler 用户查询自己的站内信收件箱 GET /v1/push/inbox?before=<通知ID>&limit=<条数>
+// 和推送网关使用同一个推送凭证，只能查询凭证里的接收者，按通知ID倒序，用上一页返回的 nextBefore 翻页
+type InboxHandler struct {
+	signer *TokenSigner
+	svc    service.InboxService
+	logger log.LoggerInterface
+}
+
+func NewInboxHandler(signer *TokenSigner, svc service.InboxService) *InboxHandler {
+	return &InboxHandler{
+		signer: signer,
+		svc:    svc,
+		logger: log.Named(log.DefaultLogger(), "http.push.inbox"),
+	}
+}
+
+func (h *InboxHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bizID, receiver, err := h.signer.Verify(requestToken(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	query := domain.InboxQuery{BizID: bizID, Receiver: receiver}
+	if v := r.URL.Query().Get("before"); v != "" {
+		if query.BeforeID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			http.Error(w, "before 必须是通知ID", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "limit 必须是整数", http.StatusBadRequest)
+			return
+		}
+	}
+	page, err := h.svc.List(r.Context(), query)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Error("查询收件箱失败", zap.Int64("biz_id", bizID), zap.Error(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	resp := inboxResponse{Notifications: make([]domain.InAppMessage, 0, len(page.Notifications))}
+	for _, n := range page.Notifications {
+		resp.Notifications = append(resp.Notifications, domain.NewInAppMessage(n, receiver))
+	}
+	if page.NextBeforeID > 0 {
+		resp.NextBefore = strconv.FormatUint(page.NextBeforeID, 10)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
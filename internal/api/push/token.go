@@ -0,0 +1,71 @@
+package push
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// MaxTokenTTL 推送凭证最长有效期
+const MaxTokenTTL = 24 * time.Hour
+
+// tokenAudience 推送凭证的受众，和调用平台接口的凭证区分开，即使误用了同一个密钥也不能混用
+const tokenAudience = "notification-push"
+
+// tokenClaims 推送凭证，sub 是接收者
+type tokenClaims struct {
+	BizID int64 `json:"biz_id"`
+	jwt.RegisteredClaims
+}
+
+// TokenSigner 签发和校验推送凭证
+// 业务方后端调用 IssuePushToken 为自己的用户换取凭证，用户的客户端带着凭证连接推送网关，只能收到自己的站内信
+type TokenSigner struct {
+	key        []byte
+	defaultTTL time.Duration
+	now        func() time.Time
+}
+
+func NewTokenSigner(key []byte, defaultTTL time.Duration) *TokenSigner {
+	return &TokenSigner{key: key, defaultTTL: defaultTTL, now: time.Now}
+}
+
+// Issue 签发推送凭证，ttl 小于等于 0 使用默认有效期
+func (s *TokenSigner) Issue(bizID int64, receiver string, ttl time.Duration) (string, time.Time, error) {
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+	if receiver == "" || ttl > MaxTokenTTL {
+		return "", time.Time{}, fmt.Errorf("%w: receiver 不能为空，有效期不能超过 %s", domain.ErrInvalidParameter, MaxTokenTTL)
+	}
+	now := s.now()
+	expire := now.Add(ttl)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims{
+		BizID: bizID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   receiver,
+			Audience:  jwt.ClaimStrings{tokenAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expire),
+		},
+	}).SignedString(s.key)
+	return token, expire, err
+}
+
+// Verify 校验推送凭证，返回业务方和接收者，凭证无效返回 domain.ErrUnauthenticated
+func (s *TokenSigner) Verify(token string) (int64, string, error) {
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims,
+		func(*jwt.Token) (any, error) { return s.key, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(tokenAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil || claims.Subject == "" || claims.BizID <= 0 {
+		return 0, "", fmt.Errorf("%w: 推送凭证无效", domain.ErrUnauthenticated)
+	}
+	return claims.BizID, claims.Subject, nil
+}
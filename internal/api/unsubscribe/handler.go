@@ -0,0 +1,80 @@
+package unsubscribe
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+)
+
+// page 退订页面，GET 时是确认按钮，POST 之后是结果
+var page = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>退订</title></head>
+<body>
+{{if .Done}}<p>已退订，之后不会再收到营销类消息。</p>
+{{else if .Invalid}}<p>退订链接无效或者已经过期。</p>
+{{else}}<form method="post"><p>确认退订营销类消息？验证码、账单等通知不受影响。</p><button type="submit">退订</button></form>
+{{end}}
+</body>
+</html>
+`))
+
+type pageData struct {
+	Done    bool
+	Invalid bool
+}
+
+// Handler 退订链接 /v1/unsubscribe?token=<退订凭证>
+// GET 显示确认页面，避免邮件客户端、安全网关预先访问链接时误退订；
+// POST 记录退订，同时支持邮件的一键退订（RFC 8058，List-Unsubscribe-Post 头）
+type Handler struct {
+	signer *TokenSigner
+	svc    service.UnsubscribeService
+	logger log.LoggerInterface
+}
+
+func NewHandler(signer *TokenSigner, svc service.UnsubscribeService) *Handler {
+	return &Handler{
+		signer: signer,
+		svc:    svc,
+		logger: log.Named(log.DefaultLogger(), "http.unsubscribe"),
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	optOut, err := h.signer.Verify(r.URL.Query().Get("token"))
+	if err != nil {
+		h.render(w, http.StatusBadRequest, pageData{Invalid: true})
+		return
+	}
+	if r.Method == http.MethodGet {
+		h.render(w, http.StatusOK, pageData{})
+		return
+	}
+	err = h.svc.Unsubscribe(r.Context(), optOut)
+	switch {
+	case err == nil:
+		h.render(w, http.StatusOK, pageData{Done: true})
+	case errors.Is(err, domain.ErrInvalidParameter):
+		h.render(w, http.StatusBadRequest, pageData{Invalid: true})
+	default:
+		h.logger.WithContext(r.Context()).Error("记录退订失败", zap.Int64("biz_id", optOut.BizID), zap.Error(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) render(w http.ResponseWriter, code int, data pageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	_ = page.Execute(w, data)
+}
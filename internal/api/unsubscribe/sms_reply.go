@@ -0,0 +1,66 @@
+package unsubscribe
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"go.uber.org/zap"
+)
+
+// maxBodySize 一次推送的请求体最大 1MB
+const maxBodySize = 1 << 20
+
+// SMSReplyHandler 供应商推送上行短信的地址是 /v1/providers/{provider}/sms-reply?token=<推送令牌>，
+// 和模板审核结果推送使用同一个令牌；回复内容是退订关键字时退订营销短信
+type SMSReplyHandler struct {
+	svc service.UnsubscribeService
+	// tokens 供应商名称 → 推送令牌，没有配置令牌的供应商不接收推送
+	tokens map[string]string
+	logger log.LoggerInterface
+}
+
+func NewSMSReplyHandler(svc service.UnsubscribeService, tokens map[string]string) *SMSReplyHandler {
+	return &SMSReplyHandler{
+		svc:    svc,
+		tokens: tokens,
+		logger: log.Named(log.DefaultLogger(), "http.unsubscribe.sms_reply"),
+	}
+}
+
+func (h *SMSReplyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("provider")
+	token, ok := h.tokens[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(token)) != 1 {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "read body failed", http.StatusBadRequest)
+		return
+	}
+	ack, err := h.svc.HandleSMSReply(r.Context(), name, body)
+	switch {
+	case err == nil:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(ack)
+	case errors.Is(err, domain.ErrProviderNotFound):
+		http.NotFound(w, r)
+	case errors.Is(err, domain.ErrInvalidParameter):
+		h.logger.Warn("上行短信推送格式错误", zap.String("provider", name), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		// 返回错误让供应商重新推送
+		h.logger.Error("处理上行短信推送失败", zap.String("provider", name), zap.Error(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
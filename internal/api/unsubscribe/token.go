@@ -0,0 +1,84 @@
+// Package unsubscribe 退订链接和供应商推送的上行短信
+package unsubscribe
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// tokenAudience 退订凭证的受众，和推送凭证、调用平台接口的凭证区分开
+const tokenAudience = "notification-unsubscribe"
+
+// Path 退订链接在网关上的路径
+const Path = "/v1/unsubscribe"
+
+// tokenClaims 退订凭证，sub 是接收者
+type tokenClaims struct {
+	BizID   int64  `json:"biz_id"`
+	Channel string `json:"channel"`
+	jwt.RegisteredClaims
+}
+
+// TokenSigner 签发和校验退订凭证
+// 业务方发送邮件、站内信之前为每个接收者换取退订链接放在页脚，接收者打开链接就退订了这个业务方在这个渠道的营销类通知
+type TokenSigner struct {
+	key     []byte
+	ttl     time.Duration
+	baseURL string
+	now     func() time.Time
+}
+
+// NewTokenSigner baseURL 是网关对外的地址，例如 https://notify.example.com
+func NewTokenSigner(key []byte, ttl time.Duration, baseURL string) *TokenSigner {
+	return &TokenSigner{key: key, ttl: ttl, baseURL: strings.TrimRight(baseURL, "/"), now: time.Now}
+}
+
+// Issue 签发退订凭证，返回退订链接
+func (s *TokenSigner) Issue(bizID int64, channel domain.Channel, receiver string) (link, token string, expire time.Time, err error) {
+	if receiver == "" || !channel.IsValid() {
+		return "", "", time.Time{}, fmt.Errorf("%w: receiver 不能为空，channel 必须合法", domain.ErrInvalidParameter)
+	}
+	now := s.now()
+	expire = now.Add(s.ttl)
+	token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims{
+		BizID:   bizID,
+		Channel: channel.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   receiver,
+			Audience:  jwt.ClaimStrings{tokenAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expire),
+		},
+	}).SignedString(s.key)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return s.baseURL + Path + "?token=" + url.QueryEscape(token), token, expire, nil
+}
+
+// Verify 校验退订凭证，返回退订记录，凭证无效返回 domain.ErrUnauthenticated
+func (s *TokenSigner) Verify(token string) (domain.OptOut, error) {
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims,
+		func(*jwt.Token) (any, error) { return s.key, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(tokenAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	channel := domain.Channel(claims.Channel)
+	if err != nil || claims.Subject == "" || claims.BizID <= 0 || !channel.IsValid() {
+		return domain.OptOut{}, fmt.Errorf("%w: 退订凭证无效", domain.ErrUnauthenticated)
+	}
+	return domain.OptOut{
+		BizID:    claims.BizID,
+		Channel:  channel,
+		Receiver: claims.Subject,
+		Source:   domain.OptOutSourceLink,
+	}, nil
+}
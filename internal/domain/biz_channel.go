@@ -0,0 +1,45 @@
+package domain
+
+import "fmt"
+
+// BizChannelSetting 业务方的渠道开关，由平台运维修改
+// 没有设置过的渠道默认允许，只有明确关闭的渠道才拒绝发送
+type BizChannelSetting struct {
+	BizID   int64
+	Channel Channel
+	Enabled bool
+	// Operator、Reason 最后一次修改的操作人和原因
+	Operator string
+	Reason   string
+	Ctime    int64
+	Utime    int64
+}
+
+// BizChannels 一个业务方所有设置过的渠道开关
+type BizChannels struct {
+	BizID    int64
+	Settings []BizChannelSetting
+}
+
+// Setting 渠道的开关，没有设置过时返回默认允许的开关
+func (c BizChannels) Setting(channel Channel) BizChannelSetting {
+	for _, s := range c.Settings {
+		if s.Channel == channel {
+			return s
+		}
+	}
+	return BizChannelSetting{BizID: c.BizID, Channel: channel, Enabled: true}
+}
+
+// Enabled 渠道是否允许发送
+func (c BizChannels) Enabled(channel Channel) bool {
+	return c.Setting(channel).Enabled
+}
+
+// Check 渠道被关闭时返回 ErrChannelDisabled
+func (c BizChannels) Check(channel Channel) error {
+	if !c.Enabled(channel) {
+		return fmt.Errorf("%w: 业务方 %d 不允许使用渠道 %s", ErrChannelDisabled, c.BizID, channel)
+	}
+	return nil
+}
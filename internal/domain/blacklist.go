@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// DefaultBlacklistTTL 供应商拒收的接收者默认在黑名单里保留多久
+const DefaultBlacklistTTL = 30 * 24 * time.Hour
+
+// BlacklistEntry 渠道级别的接收者黑名单，所有业务方共用
+// 供应商明确拒收某个接收者（空号、拉黑、退订）时自动加入，过期之后自动失效，运维可以手动删除
+type BlacklistEntry struct {
+	ID       int64
+	Channel  Channel
+	Receiver string
+	// Category 加入黑名单的原因，供应商错误的统一分类
+	Category VendorErrorCategory
+	// Vendor、Code 返回拒收的供应商和供应商原始错误码
+	Vendor string
+	Code   string
+	// NotificationID 触发加入黑名单的通知
+	NotificationID uint64
+	ExpireTime     time.Time
+	Ctime          int64
+	Utime          int64
+}
+
+// Expired 到了过期时间之后不再拦截
+func (e BlacklistEntry) Expired(now time.Time) bool {
+	return !e.ExpireTime.After(now)
+}
+
+// BlacklistFilter 运维查询黑名单的条件，为空的条件不过滤
+type BlacklistFilter struct {
+	Channel  Channel
+	Receiver string
+	// IncludeExpired 是否包括已经过期的记录
+	IncludeExpired bool
+	// BeforeID 上一页最后一条的ID，为 0 从最新的开始
+	BeforeID int64
+	Limit    int
+}
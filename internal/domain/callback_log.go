@@ -1,5 +1,18 @@
 package domain
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	sdkcallback "github.com/serendipityConfusion/notification-platform/sdk/callback"
+)
+
 type CallbackLogStatus string
 
 const (
@@ -7,6 +20,8 @@ const (
 	CallbackLogStatusPending CallbackLogStatus = "PENDING"
 	CallbackLogStatusSuccess CallbackLogStatus = "SUCCEEDED"
 	CallbackLogStatusFailed  CallbackLogStatus = "FAILED"
+	// CallbackLogStatusDeadLetter 重试次数用完或者没有可用的回调地址，等平台管理员排查之后重新回调
+	CallbackLogStatusDeadLetter CallbackLogStatus = "DEAD_LETTER"
 )
 
 func (c CallbackLogStatus) String() string {
@@ -19,4 +34,177 @@ type CallbackLog struct {
 	RetryCount    int32
 	NextRetryTime int64
 	Status        CallbackLogStatus
+	Callback      CallbackOptions
+	// LastError 最后一次回调失败的原因
+	LastError string
+	Utime     int64
+}
+
+// CallbackDeadLetterFilter 查询死信回调记录的条件，零值字段不过滤
+type CallbackDeadLetterFilter struct {
+	BizID int64
+	// UpdatedSince 进入死信的时间不早于这个毫秒时间戳
+	UpdatedSince int64
+	// AfterID 上一页最后一条的ID，按ID升序翻页
+	AfterID int64
+	Limit   int
 }
+
+const (
+	// 回调死信汇总通知的模板参数
+	CallbackDeadLetterParamCount           = "count"
+	CallbackDeadLetterParamNotificationIDs = "notification_ids"
+	CallbackDeadLetterParamDate            = "date"
+
+	// callbackDeadLetterMaxListed 汇总通知里最多列出多少条通知ID
+	callbackDeadLetterMaxListed = 50
+)
+
+// CallbackDeadLetterNotice 每天汇总进入死信的回调记录，平台用自己的业务方和模板通知对应的业务方
+type CallbackDeadLetterNotice struct {
+	BizID      int64
+	Channel    Channel
+	TemplateID int64
+	// Receivers 业务方ID → 接收者，没有配置的业务方不通知
+	Receivers map[int64][]string
+}
+
+// Notification 业务方 bizID 在 date 这一天的汇总通知，同一天只通知一次，模板版本、ID 和发送时间由调用方补齐
+func (n CallbackDeadLetterNotice) Notification(bizID int64, date string, logs []CallbackLog) (Notification, bool) {
+	receivers := n.Receivers[bizID]
+	if len(receivers) == 0 || len(logs) == 0 {
+		return Notification{}, false
+	}
+	return Notification{
+		BizID:     n.BizID,
+		Key:       fmt.Sprintf("callback-dead-letter:%d:%s", bizID, date),
+		Receivers: receivers,
+		Channel:   n.Channel,
+		Template: Template{
+			ID: n.TemplateID,
+			Params: map[string]string{
+				CallbackDeadLetterParamCount:           strconv.Itoa(len(logs)),
+				CallbackDeadLetterParamNotificationIDs: CallbackDeadLetterIDs(logs),
+				CallbackDeadLetterParamDate:            date,
+			},
+		},
+		SendStrategyConfig: SendStrategyConfig{Type: SendStrategyImmediate},
+	}, true
+}
+
+// CallbackDeadLetterIDs 逗号分隔的通知ID，超过 50 条时只列出前 50 条
+func CallbackDeadLetterIDs(logs []CallbackLog) string {
+	ids := make([]string, 0, min(len(logs), callbackDeadLetterMaxListed))
+	for _, l := range logs[:min(len(logs), callbackDeadLetterMaxListed)] {
+		ids = append(ids, strconv.FormatUint(l.Notification.ID, 10))
+	}
+	res := strings.Join(ids, ",")
+	if len(logs) > callbackDeadLetterMaxListed {
+		res += fmt.Sprintf(" 等 %d 条", len(logs))
+	}
+	return res
+}
+
+const (
+	callbackURLMaxLen     = 512
+	callbackMaxHeaders    = 10
+	callbackHeadersMaxLen = 4096
+)
+
+// callbackReservedHeaders 平台自己设置的请求头，业务方不能覆盖
+var callbackReservedHeaders = map[string]struct{}{
+	"Content-Type":              {},
+	"Content-Length":            {},
+	"Host":                      {},
+	sdkcallback.TimestampHeader: {},
+	sdkcallback.SignatureHeader: {},
+}
+
+// CallbackOptions 单条通知的回调设置，零值表示发送成功后回调业务方配置的默认地址
+type CallbackOptions struct {
+	// URL 覆盖业务方的默认回调地址
+	URL string
+	// Headers 回调时额外带上的请求头
+	Headers map[string]string
+	// OnStatus 哪些发送结果需要回调，为空只回调 SUCCEEDED
+	OnStatus []SendStatus
+}
+
+func (o CallbackOptions) Validate() error {
+	if o.URL != "" {
+		if len(o.URL) > callbackURLMaxLen {
+			return fmt.Errorf("%w: 回调地址超过 %d", ErrInvalidParameter, callbackURLMaxLen)
+		}
+		u, err := url.Parse(o.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: 回调地址 %q 不合法", ErrInvalidParameter, o.URL)
+		}
+	}
+	if len(o.Headers) > callbackMaxHeaders {
+		return fmt.Errorf("%w: 回调请求头最多 %d 个", ErrInvalidParameter, callbackMaxHeaders)
+	}
+	for k, v := range o.Headers {
+		if !validHeaderName(k) || strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("%w: 回调请求头 %q 不合法", ErrInvalidParameter, k)
+		}
+		if _, ok := callbackReservedHeaders[http.CanonicalHeaderKey(k)]; ok {
+			return fmt.Errorf("%w: 回调请求头 %q 不能覆盖", ErrInvalidParameter, k)
+		}
+	}
+	if headers, _ := o.MarshalHeaders(); len(headers) > callbackHeadersMaxLen {
+		return fmt.Errorf("%w: 回调请求头总长度超过 %d", ErrInvalidParameter, callbackHeadersMaxLen)
+	}
+	for _, s := range o.OnStatus {
+		if s != SendStatusSucceeded && s != SendStatusFailed {
+			return fmt.Errorf("%w: 回调状态只能是 SUCCEEDED 或者 FAILED: %q", ErrInvalidParameter, s)
+		}
+	}
+	return nil
+}
+
+// Wants 发送结果是否需要回调
+func (o CallbackOptions) Wants(status SendStatus) bool {
+	if len(o.OnStatus) == 0 {
+		return status == SendStatusSucceeded
+	}
+	return slices.Contains(o.OnStatus, status)
+}
+
+// MarshalHeaders 没有请求头时返回空字符串
+func (o CallbackOptions) MarshalHeaders() (string, error) {
+	if len(o.Headers) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(o.Headers)
+	return string(b), err
+}
+
+// validHeaderName 请求头名称只能由 RFC 7230 的 token 字符组成
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if c > unicode.MaxASCII || !(unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("!#$%&'*+-.^_`|~", c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// NotificationCallbackEvent 推送给业务方的发送结果事件，请求体使用业务方的回调密钥签名
+type NotificationCallbackEvent struct {
+	Event          string `json:"event"`
+	NotificationID uint64 `json:"notificationId"`
+	Key            string `json:"key"`
+	Status         string `json:"status"`
+	// FailReason 失败原因，例如过期、发送超时，供应商发送失败时是统一的错误分类，例如 VENDOR_BLACKLISTED
+	FailReason string `json:"failReason,omitempty"`
+	// Labels 发送时给通知打的标签
+	Labels Labels `json:"labels,omitempty"`
+	// BizPayload 发送时业务方传的透传数据
+	BizPayload string `json:"bizPayload,omitempty"`
+}
+
+// NotificationCallbackEventName 发送结果事件的名称
+const NotificationCallbackEventName = "notification.status"
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// CallbackSecret 业务方的回调签名密钥
+// 轮换后旧密钥在 PreviousExpireAt 之前依旧参与签名，业务方可以在宽限期内更新自己的密钥
+type CallbackSecret struct {
+	BizID            int64
+	Secret           string
+	PreviousSecret   string
+	PreviousExpireAt time.Time
+	Utime            time.Time
+}
+
+// ActiveSecrets 当前需要参与签名的密钥，新密钥在前
+func (s CallbackSecret) ActiveSecrets(now time.Time) []string {
+	secrets := []string{s.Secret}
+	if s.PreviousSecret != "" && now.Before(s.PreviousExpireAt) {
+		secrets = append(secrets, s.PreviousSecret)
+	}
+	return secrets
+}
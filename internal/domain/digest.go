@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DigestParamCount 摘要模板的参数：合并的通知数量
+	DigestParamCount = "count"
+	// DigestParamItems 摘要模板的参数：每条通知的摘要，换行分隔，最多 MaxDigestLines 行
+	DigestParamItems = "items"
+	// MaxDigestLines 摘要消息里最多列出的行数，超出的只计入数量
+	MaxDigestLines = 20
+	// MaxDigestSummaryLen 每条通知的摘要最长的字符数
+	MaxDigestSummaryLen = 200
+)
+
+// Digest 通知的合并发送参数
+type Digest struct {
+	Summary string `json:"summary"`
+}
+
+func (d Digest) Validate() error {
+	if d.Summary == "" || utf8.RuneCountInString(d.Summary) > MaxDigestSummaryLen {
+		return fmt.Errorf("%w: 摘要不能为空，最长 %d", ErrInvalidParameter, MaxDigestSummaryLen)
+	}
+	return nil
+}
+
+// DigestPolicy 业务方某个渠道的合并策略
+type DigestPolicy struct {
+	BizID   int64
+	Channel Channel
+	// Window 合并窗口，按窗口长度对齐，同一个接收者在同一个窗口内的通知合并成一条
+	Window time.Duration
+	// TemplateID 摘要模板，参数是 DigestParamCount 和 DigestParamItems
+	TemplateID int64
+}
+
+// WindowOf t 所在的合并窗口
+func (p DigestPolicy) WindowOf(t time.Time) (start, end time.Time) {
+	start = t.Truncate(p.Window)
+	return start, start.Add(p.Window)
+}
+
+// DigestGroupStatus 合并分组的状态
+type DigestGroupStatus string
+
+const (
+	DigestGroupStatusOpen DigestGroupStatus = "OPEN"
+	DigestGroupStatusSent DigestGroupStatus = "SENT"
+)
+
+func (s DigestGroupStatus) String() string {
+	return string(s)
+}
+
+// DigestGroup 同一个接收者在同一个合并窗口内的通知
+type DigestGroup struct {
+	ID          int64
+	BizID       int64
+	Channel     Channel
+	TemplateID  int64
+	Receiver    string
+	WindowStart time.Time
+	WindowEnd   time.Time
+	Status      DigestGroupStatus
+	// ItemCount 分组内通知的总数，摘要里最多列出 MaxDigestLines 条
+	ItemCount int
+	// NotificationID 摘要消息的通知ID，发送之后才有
+	NotificationID uint64
+}
+
+// DigestItem 进入合并窗口的一条通知，一个接收者一行
+type DigestItem struct {
+	ID       int64
+	GroupID  int64
+	BizID    int64
+	Key      string
+	Receiver string
+	Summary  string
+}
+
+// DigestKey 摘要消息的通知 key，同一个分组重复发送时用唯一索引去重
+func DigestKey(groupID int64) string {
+	return "digest:" + strconv.FormatInt(groupID, 10)
+}
+
+// DigestParams 摘要模板的参数，total 是分组内通知的总数，items 只取前 MaxDigestLines 条
+func DigestParams(total int, items []DigestItem) map[string]string {
+	lines := make([]string, 0, min(len(items), MaxDigestLines))
+	for i := 0; i < len(items) && i < MaxDigestLines; i++ {
+		lines = append(lines, items[i].Summary)
+	}
+	return map[string]string{
+		DigestParamCount: strconv.Itoa(total),
+		DigestParamItems: strings.Join(lines, "\n"),
+	}
+}
+
+// NewDigestNotification 分组的摘要消息，模板版本、ID 和发送时间由调用方补齐
+func NewDigestNotification(group DigestGroup, total int, items []DigestItem) Notification {
+	return Notification{
+		BizID:     group.BizID,
+		Key:       DigestKey(group.ID),
+		Receivers: []string{group.Receiver},
+		Channel:   group.Channel,
+		Template: Template{
+			ID:     group.TemplateID,
+			Params: DigestParams(total, items),
+		},
+		SendStrategyConfig: SendStrategyConfig{Type: SendStrategyImmediate},
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// DryRunResult 试运行的结果，通知真正发送时会得到的模板、额度和供应商
+type DryRunResult struct {
+	// Existing 业务方已经用同一个 key 发送过时是已有的通知，真正发送时不会创建新通知，其他字段为空
+	Existing *Notification
+	// TemplateVersionID 生效的模板版本
+	TemplateVersionID int64
+	Signature         string
+	// Content 用参数渲染之后的模板内容
+	Content string
+	// Providers 可以使用的供应商，按优先级排列
+	Providers []string
+	// QuotaRemaining 扣减之前的剩余额度，透支时为负数
+	QuotaRemaining int32
+	ScheduledSTime time.Time
+	ScheduledETime time.Time
+}
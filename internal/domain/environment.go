@@ -0,0 +1,23 @@
+package domain
+
+// Environment 调用方凭证所属的环境
+// 沙箱环境的通知只发给模拟供应商，不扣减额度，不计入生产环境的统计
+type Environment string
+
+const (
+	EnvironmentProduction Environment = "PRODUCTION"
+	EnvironmentSandbox    Environment = "SANDBOX"
+)
+
+func (e Environment) String() string {
+	return string(e)
+}
+
+func (e Environment) IsValid() bool {
+	return e == EnvironmentProduction || e == EnvironmentSandbox
+}
+
+// IsSandbox 空值按生产环境处理
+func (e Environment) IsSandbox() bool {
+	return e == EnvironmentSandbox
+}
@@ -15,6 +15,8 @@ var (
 	ErrTemplateVersionNotApprovedByPlatform = errors.New("模板版本未被内部审核通过")
 	ErrTemplateVersionNotApprovedByProvider = errors.New("模板版本未被供应商审核通过")
 	ErrTemplateAndVersionMisMatch           = errors.New("模板和版本不匹配")
+	ErrTemplateProviderNotFound             = errors.New("模板供应商审核记录不存在")
+	ErrTemplateRenderFailed                 = errors.New("模板渲染失败")
 	ErrChannelDisabled                      = errors.New("渠道已禁用")
 	ErrRateLimited                          = errors.New("请求频率受限")
 	ErrCircuitBreaker                       = errors.New("服务熔断，请稍后重试")
@@ -23,10 +25,26 @@ var (
 	ErrConfigNotFound                       = errors.New("业务配置不存在")
 	ErrNoQuotaConfig                        = errors.New("没有提供 Quota 有关的配置")
 	ErrNoQuota                              = errors.New("额度已经用完")
+	ErrQuotaStoreUnavailable                = errors.New("额度存储不可用")
 	ErrQuotaNotFound                        = errors.New("额度记录不存在")
 	ErrProviderNotFound                     = errors.New("供应商记录不存在")
 	ErrUnknownChannel                       = errors.New("未知渠道类型")
 	ErrInvalidOperation                     = errors.New("无效的操作")
+	ErrNotificationNotCancelable            = errors.New("通知当前状态不允许取消")
+	ErrUnauthenticated                      = errors.New("调用方身份无效")
+	ErrPermissionDenied                     = errors.New("没有权限")
+	ErrRoleAssignmentNotFound               = errors.New("角色分配记录不存在")
+	ErrCallbackSecretNotFound               = errors.New("回调签名密钥不存在")
+	ErrNotificationNotReadable              = errors.New("只有发送成功的站内信可以标记已读")
+	ErrReceiverNotInNotification            = errors.New("接收者不是这条通知的接收者")
+	ErrEscalationNotFound                   = errors.New("升级链不存在")
+	ErrEscalationFinished                   = errors.New("升级链已经结束")
+	ErrTooManyExports                       = errors.New("同时进行的导出太多，请稍后再试")
+	ErrTooManyWatchers                      = errors.New("同时观察的通知太多，请稍后再试")
+	ErrSendWindowClosed                     = errors.New("计划发送时间已经结束")
+	ErrReceiverBlacklisted                  = errors.New("接收者在黑名单里")
+	ErrBlacklistEntryNotFound               = errors.New("黑名单记录不存在")
+	ErrReceiverOptedOut                     = errors.New("接收者已经退订")
 
 	ErrCreateTemplateFailed                    = errors.New("创建模版失败")
 	ErrUpdateTemplateFailed                    = errors.New("更新模版失败")
@@ -39,11 +57,18 @@ var (
 
 	ErrNoAvailableFailoverService = errors.New("没有需要接管的故障服务")
 
+	ErrSendAttemptUncertain = errors.New("上一次发送结果未知，供应商不支持幂等，不能重复发送")
+
 	// 系统错误
 	ErrNotificationDuplicate       = errors.New("通知记录主键冲突")
 	ErrNotificationVersionMismatch = errors.New("通知记录版本不匹配")
 	ErrCreateCallbackLogFailed     = errors.New("创建回调记录失败")
+	ErrSendAttemptDuplicate        = errors.New("发送尝试记录冲突")
+	ErrSendAttemptNotFound         = errors.New("发送尝试记录不存在")
+	ErrSendAttemptStatusChanged    = errors.New("发送尝试状态已变化")
 	ErrDatabaseError               = errors.New("数据库错误")
 	ErrExternalServiceError        = errors.New("外部服务调用错误")
 	ErrBatchSizeOverLimit          = errors.New("批量大小超过限制")
+	ErrEscalationDuplicate         = errors.New("升级链记录冲突")
+	ErrEscalationChanged           = errors.New("升级链已被修改")
 )
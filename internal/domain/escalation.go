@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// EscalationStatus 升级链的状态
+type EscalationStatus string
+
+const (
+	// EscalationStatusActive 还在按步骤升级
+	EscalationStatusActive EscalationStatus = "ACTIVE"
+	// EscalationStatusAcknowledged 已经有人确认，不再升级
+	EscalationStatusAcknowledged EscalationStatus = "ACKNOWLEDGED"
+	// EscalationStatusExhausted 所有步骤都发送了，最后一步等待结束依旧没有确认
+	EscalationStatusExhausted EscalationStatus = "EXHAUSTED"
+)
+
+func (s EscalationStatus) String() string {
+	return string(s)
+}
+
+const (
+	// MaxEscalationSteps 一条升级链最多的步骤数
+	MaxEscalationSteps = 10
+	// MaxEscalationWait 每一步最长的等待时间
+	MaxEscalationWait = 7 * 24 * time.Hour
+	// maxEscalationKeyLen 每一步的通知 key 在升级链的 key 后面加后缀，要给后缀留出长度
+	maxEscalationKeyLen = 200
+)
+
+// EscalationStep 升级链的一步，发送一条通知之后等待 Wait，没有确认就进入下一步
+type EscalationStep struct {
+	Channel        Channel           `json:"channel"`
+	Receivers      []string          `json:"receivers"`
+	TemplateID     int64             `json:"templateId"`
+	TemplateParams map[string]string `json:"templateParams"`
+	Wait           time.Duration     `json:"wait"`
+}
+
+// Escalation 跨渠道升级链，比如先发站内信，没人确认再发短信，再没人确认发邮件
+type Escalation struct {
+	ID    int64
+	BizID int64
+	Key   string
+	Steps []EscalationStep
+	// CurrentStep 已经发送的最后一步，还没有发送第一步时为 -1
+	CurrentStep  int
+	Status       EscalationStatus
+	NextStepTime time.Time
+	AckTime      time.Time
+	AckBy        string
+	Version      int
+}
+
+func (e *Escalation) Validate() error {
+	if e.BizID <= 0 {
+		return fmt.Errorf("%w: BizID = %d", ErrInvalidParameter, e.BizID)
+	}
+	if e.Key == "" || len(e.Key) > maxEscalationKeyLen {
+		return fmt.Errorf("%w: Key 不能为空，最长 %d", ErrInvalidParameter, maxEscalationKeyLen)
+	}
+	if len(e.Steps) == 0 || len(e.Steps) > MaxEscalationSteps {
+		return fmt.Errorf("%w: 步骤数量必须在 1 到 %d 之间", ErrInvalidParameter, MaxEscalationSteps)
+	}
+	for i, step := range e.Steps {
+		if !step.Channel.IsValid() {
+			return fmt.Errorf("%w: Steps[%d].Channel = %q", ErrInvalidParameter, i, step.Channel)
+		}
+		if len(step.Receivers) == 0 {
+			return fmt.Errorf("%w: Steps[%d].Receivers 不能为空", ErrInvalidParameter, i)
+		}
+		if step.TemplateID <= 0 {
+			return fmt.Errorf("%w: Steps[%d].TemplateID = %d", ErrInvalidParameter, i, step.TemplateID)
+		}
+		if step.Wait < 0 || step.Wait > MaxEscalationWait {
+			return fmt.Errorf("%w: Steps[%d].Wait = %s", ErrInvalidParameter, i, step.Wait)
+		}
+	}
+	return nil
+}
+
+// EscalationStepKey 第 step 步发送的通知的 key，同一步重复发送时用唯一索引去重
+func EscalationStepKey(key string, step int) string {
+	return fmt.Sprintf("%s:escalation-%d", key, step)
+}
+
+// StepNotification 第 step 步要发送的通知，模板版本和发送时间由调用方补齐
+func (e *Escalation) StepNotification(step int) Notification {
+	s := e.Steps[step]
+	return Notification{
+		BizID:     e.BizID,
+		Key:       EscalationStepKey(e.Key, step),
+		Receivers: s.Receivers,
+		Channel:   s.Channel,
+		Template: Template{
+			ID:     s.TemplateID,
+			Params: s.TemplateParams,
+		},
+		SendStrategyConfig: SendStrategyConfig{Type: SendStrategyImmediate},
+	}
+}
+
+// IsFinished 确认或者耗尽之后不会再变化
+func (e *Escalation) IsFinished() bool {
+	return e.Status != EscalationStatusActive
+}
+
+// Finish 结束升级链，接收者和模板参数不再需要，清掉不再保存
+func (e *Escalation) Finish(status EscalationStatus) {
+	e.Status = status
+	for i := range e.Steps {
+		e.Steps[i].Receivers = nil
+		e.Steps[i].TemplateParams = nil
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+// NotificationEvent 导出到分析库的通知生命周期事件，每次通知变化（版本号或更新时间变化）导出一行
+// 不包含接收者和模板参数，分析库里没有个人信息
+type NotificationEvent struct {
+	NotificationID uint64
+	BizID          int64
+	Key            string
+	Channel        Channel
+	TemplateID     int64
+	Status         SendStatus
+	FailReason     FailReason
+	Version        int
+	// Ctime、Utime 毫秒时间戳，Utime 就是事件发生的时间
+	Ctime int64
+	Utime int64
+	// Environment 分析时按环境区分沙箱和生产环境的通知
+	Environment Environment
+}
+
+// ExportCheckpoint 导出进度，已经导出了 (Utime, ID) 及之前的所有变化
+type ExportCheckpoint struct {
+	Name  string
+	Utime int64
+	ID    uint64
+}
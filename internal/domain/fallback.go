@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxFallbackGroupLength 渠道降级分组名称的最大长度
+const MaxFallbackGroupLength = 128
+
+// FallbackPolicy 业务方声明的渠道降级顺序，同一个分组里的通知按这个顺序依次发送，
+// 排在前面的渠道发送成功之后，后面的渠道不再发送
+type FallbackPolicy struct {
+	BizID    int64
+	Channels []Channel
+}
+
+// Rank 渠道在降级顺序里的位置，不在顺序里时 ok 为 false
+func (p FallbackPolicy) Rank(channel Channel) (int, bool) {
+	i := slices.Index(p.Channels, channel)
+	return i, i >= 0
+}
+
+// FallbackGroupStatus 渠道降级分组的状态
+type FallbackGroupStatus string
+
+const (
+	// FallbackGroupStatusInProgress 还有通知没有发送完
+	FallbackGroupStatusInProgress FallbackGroupStatus = "IN_PROGRESS"
+	// FallbackGroupStatusDelivered 有一个渠道发送成功
+	FallbackGroupStatusDelivered FallbackGroupStatus = "DELIVERED"
+	// FallbackGroupStatusExhausted 所有渠道都发送失败或者被取消
+	FallbackGroupStatusExhausted FallbackGroupStatus = "EXHAUSTED"
+)
+
+func (s FallbackGroupStatus) String() string {
+	return string(s)
+}
+
+// FallbackDecision 调度器对分组里一条到了发送时间的通知的处理
+type FallbackDecision int
+
+const (
+	// FallbackSend 前面的渠道都失败了，发送
+	FallbackSend FallbackDecision = iota
+	// FallbackWait 前面的渠道还没有结果，等下一轮调度
+	FallbackWait
+	// FallbackSuppress 分组里已经有渠道发送成功，不再发送
+	FallbackSuppress
+)
+
+// FallbackGroup 同一个业务方同一个渠道降级分组里的通知，只有状态，没有接收者和模板参数
+type FallbackGroup struct {
+	BizID   int64
+	Group   string
+	Members []Notification
+}
+
+// Delivered 发送成功的那条通知
+func (g FallbackGroup) Delivered() (Notification, bool) {
+	for _, m := range g.Members {
+		if m.Status == SendStatusSucceeded {
+			return m, true
+		}
+	}
+	return Notification{}, false
+}
+
+func (g FallbackGroup) Status() FallbackGroupStatus {
+	if _, ok := g.Delivered(); ok {
+		return FallbackGroupStatusDelivered
+	}
+	for _, m := range g.Members {
+		if !m.Status.IsTerminal() {
+			return FallbackGroupStatusInProgress
+		}
+	}
+	return FallbackGroupStatusExhausted
+}
+
+// Decide 按降级顺序决定通知 n 现在能不能发送，渠道不在降级顺序里的通知直接发送
+func (g FallbackGroup) Decide(n Notification, policy FallbackPolicy) FallbackDecision {
+	if _, ok := g.Delivered(); ok {
+		return FallbackSuppress
+	}
+	rank, ok := policy.Rank(n.Channel)
+	if !ok {
+		return FallbackSend
+	}
+	for _, m := range g.Members {
+		if m.ID == n.ID || m.Status.IsTerminal() {
+			continue
+		}
+		if r, ok := policy.Rank(m.Channel); ok && r < rank {
+			return FallbackWait
+		}
+	}
+	return FallbackSend
+}
+
+// InFallbackGroup 是否加入了渠道降级分组
+func (n *Notification) InFallbackGroup() bool {
+	return n.FallbackGroup != ""
+}
+
+// validateFallbackGroup 渠道降级只对单独保存的通知有效
+func (n *Notification) validateFallbackGroup() error {
+	if n.FallbackGroup == "" {
+		return nil
+	}
+	if len(n.FallbackGroup) > MaxFallbackGroupLength {
+		return fmt.Errorf("%w: 渠道降级分组最长 %d: %q", ErrInvalidParameter, MaxFallbackGroupLength, n.FallbackGroup)
+	}
+	if n.Digest != nil || n.IsLocalTime() {
+		return fmt.Errorf("%w: 合并发送和按本地时间发送的通知不能加入渠道降级分组", ErrInvalidParameter)
+	}
+	return nil
+}
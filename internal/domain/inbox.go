@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// InboxEntry 接收者收件箱里的一条站内信
+type InboxEntry struct {
+	NotificationID uint64
+	BizID          int64
+	Receiver       string
+	Ctime          time.Time
+}
+
+// InboxQuery 按通知ID倒序翻页查询接收者的收件箱，BeforeID 为 0 表示从最新的开始
+type InboxQuery struct {
+	BizID    int64
+	Receiver string
+	BeforeID uint64
+	Limit    int
+}
+
+// InboxPage 收件箱的一页，NextBeforeID 为 0 表示没有下一页
+type InboxPage struct {
+	Notifications []Notification
+	NextBeforeID  uint64
+}
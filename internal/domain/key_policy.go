@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxNotificationKeyLength 数据库里 key 的长度上限，所有业务方都不能超过
+	MaxNotificationKeyLength = 256
+	// MaxKeyPrefixLength 要求的前缀最长 64 个字符，给后面的部分留出空间
+	MaxKeyPrefixLength = 64
+)
+
+// KeyCharset key 允许使用的字符
+type KeyCharset string
+
+const (
+	// KeyCharsetAny 不限制，控制字符除外，没有设置时使用
+	KeyCharsetAny KeyCharset = "ANY"
+	// KeyCharsetPrintable 可打印的 ASCII 字符，不能有空格
+	KeyCharsetPrintable KeyCharset = "PRINTABLE"
+	// KeyCharsetSafe 字母、数字和 - _ . : /，可以直接放进 URL 路径和日志
+	KeyCharsetSafe KeyCharset = "SAFE"
+)
+
+func (c KeyCharset) String() string {
+	return string(c)
+}
+
+func (c KeyCharset) IsValid() bool {
+	switch c {
+	case "", KeyCharsetAny, KeyCharsetPrintable, KeyCharsetSafe:
+		return true
+	}
+	return false
+}
+
+func (c KeyCharset) allows(r rune) bool {
+	switch c {
+	case KeyCharsetPrintable:
+		return r > ' ' && r < unicode.MaxASCII
+	case KeyCharsetSafe:
+		return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_.:/", r))
+	default:
+		return !unicode.IsControl(r)
+	}
+}
+
+// 不符合 key 规则的原因，用于指标
+const (
+	KeyViolationLength  = "length"
+	KeyViolationCharset = "charset"
+	KeyViolationPrefix  = "prefix"
+)
+
+// NotificationKeyPolicy 业务方的 key 规则，发送时校验，由平台运维修改
+// 没有设置过的业务方只限制长度不超过 MaxNotificationKeyLength，字符不能有控制字符
+type NotificationKeyPolicy struct {
+	BizID int64
+	// MaxLength 按字符计算，0 表示 MaxNotificationKeyLength
+	MaxLength int
+	Charset   KeyCharset
+	// RequiredPrefix 不为空时 key 必须以它开头，用来区分同一个业务方的不同系统
+	RequiredPrefix string
+	// Operator 最后一次修改的操作人
+	Operator string
+	Ctime    int64
+	Utime    int64
+}
+
+// Limit 生效的长度上限
+func (p NotificationKeyPolicy) Limit() int {
+	if p.MaxLength <= 0 {
+		return MaxNotificationKeyLength
+	}
+	return min(p.MaxLength, MaxNotificationKeyLength)
+}
+
+// Validate 校验规则本身，前缀必须符合字符集并且比长度上限短
+func (p NotificationKeyPolicy) Validate() error {
+	if p.MaxLength < 0 || p.MaxLength > MaxNotificationKeyLength {
+		return fmt.Errorf("%w: 长度上限必须在 0 到 %d 之间: %d", ErrInvalidParameter, MaxNotificationKeyLength, p.MaxLength)
+	}
+	if !p.Charset.IsValid() {
+		return fmt.Errorf("%w: charset = %q", ErrInvalidParameter, p.Charset)
+	}
+	if n := utf8.RuneCountInString(p.RequiredPrefix); n > MaxKeyPrefixLength || (n > 0 && n >= p.Limit()) {
+		return fmt.Errorf("%w: 前缀不能超过 %d 个字符，并且要比长度上限短", ErrInvalidParameter, MaxKeyPrefixLength)
+	}
+	if p.Violation(p.RequiredPrefix+"0") == KeyViolationCharset {
+		return fmt.Errorf("%w: 前缀 %q 不符合字符集 %s", ErrInvalidParameter, p.RequiredPrefix, p.Charset)
+	}
+	return nil
+}
+
+// Violation key 不符合规则的原因，符合时返回空字符串，空的 key 由 Notification.Validate 校验
+func (p NotificationKeyPolicy) Violation(key string) string {
+	if utf8.RuneCountInString(key) > p.Limit() {
+		return KeyViolationLength
+	}
+	if !utf8.ValidString(key) || strings.IndexFunc(key, func(r rune) bool { return !p.Charset.allows(r) }) >= 0 {
+		return KeyViolationCharset
+	}
+	if !strings.HasPrefix(key, p.RequiredPrefix) {
+		return KeyViolationPrefix
+	}
+	return ""
+}
+
+// Check key 不符合规则时返回 ErrInvalidParameter
+func (p NotificationKeyPolicy) Check(key string) error {
+	switch p.Violation(key) {
+	case KeyViolationLength:
+		return fmt.Errorf("%w: key 不能超过 %d 个字符", ErrInvalidParameter, p.Limit())
+	case KeyViolationCharset:
+		return fmt.Errorf("%w: key 含有字符集 %s 之外的字符", ErrInvalidParameter, cmp.Or(p.Charset, KeyCharsetAny))
+	case KeyViolationPrefix:
+		return fmt.Errorf("%w: key 必须以 %q 开头", ErrInvalidParameter, p.RequiredPrefix)
+	}
+	return nil
+}
+
+// GenerateKey 用平台生成的唯一ID生成符合规则的 key：前缀加上 36 进制的ID
+func (p NotificationKeyPolicy) GenerateKey(id uint64) (string, error) {
+	key := p.RequiredPrefix + strconv.FormatUint(id, 36)
+	if err := p.Check(key); err != nil {
+		return "", fmt.Errorf("生成的 key 不符合业务方的规则: %w", err)
+	}
+	return key, nil
+}
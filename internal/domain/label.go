@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLabels 每条通知最多的标签数
+	MaxLabels        = 10
+	labelValueMaxLen = 128
+	// MaxBizPayloadLen 业务方透传数据的最大字节数
+	MaxBizPayloadLen = 1024
+)
+
+// labelKeyPattern 标签键只能是小写字母、数字和 _ . -，字母或数字开头，最长 63
+var labelKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,62}$`)
+
+// Labels 业务方给通知打的标签，用于查询、回调和统计，明文保存，不能放接收者等敏感信息
+type Labels map[string]string
+
+func (l Labels) Validate() error {
+	if len(l) > MaxLabels {
+		return fmt.Errorf("%w: 标签最多 %d 个", ErrInvalidParameter, MaxLabels)
+	}
+	for k, v := range l {
+		if !labelKeyPattern.MatchString(k) {
+			return fmt.Errorf("%w: 标签键 %q 不合法", ErrInvalidParameter, k)
+		}
+		if utf8.RuneCountInString(v) > labelValueMaxLen {
+			return fmt.Errorf("%w: 标签 %s 的值超过 %d", ErrInvalidParameter, k, labelValueMaxLen)
+		}
+		for _, c := range v {
+			if unicode.IsControl(c) {
+				return fmt.Errorf("%w: 标签 %s 的值包含控制字符", ErrInvalidParameter, k)
+			}
+		}
+	}
+	return nil
+}
+
+// validateBizPayload 透传数据平台不解析，只限制长度，必须是合法的 UTF-8
+func validateBizPayload(payload string) error {
+	if len(payload) > MaxBizPayloadLen {
+		return fmt.Errorf("%w: biz_payload 超过 %d 字节", ErrInvalidParameter, MaxBizPayloadLen)
+	}
+	if !utf8.ValidString(payload) {
+		return fmt.Errorf("%w: biz_payload 不是合法的 UTF-8", ErrInvalidParameter)
+	}
+	return nil
+}
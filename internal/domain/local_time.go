@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultLocalTimeWindow 本地发送时间之后多久之内发送
+	DefaultLocalTimeWindow = time.Hour
+	// MaxLocalTimeWindow 发送窗口最长一天
+	MaxLocalTimeWindow = 24 * time.Hour
+
+	localDateLayout  = "2006-01-02"
+	localClockLayout = "15:04"
+)
+
+// LocalTimeSend 按接收者所在时区的本地时间发送，例如每个接收者当地的早上 9 点
+// 接收者的时区优先使用 Timezones，没有的通过时区查询服务查询，都没有时使用 DefaultTimezone
+type LocalTimeSend struct {
+	// Date 本地日期，格式 2006-01-02
+	Date string `json:"date"`
+	// Clock 本地时间，格式 15:04
+	Clock string `json:"clock"`
+	// Window 本地时间之后多久之内发送
+	Window time.Duration `json:"window"`
+	// Timezones 接收者到 IANA 时区名称，例如 Asia/Shanghai
+	Timezones map[string]string `json:"timezones,omitempty"`
+	// DefaultTimezone 为空时是 UTC
+	DefaultTimezone string `json:"defaultTimezone,omitempty"`
+}
+
+func (l LocalTimeSend) Validate() error {
+	if _, err := time.Parse(localDateLayout, l.Date); err != nil {
+		return fmt.Errorf("%w: 本地日期格式是 2006-01-02: %q", ErrInvalidParameter, l.Date)
+	}
+	if _, err := time.Parse(localClockLayout, l.Clock); err != nil {
+		return fmt.Errorf("%w: 本地时间格式是 15:04: %q", ErrInvalidParameter, l.Clock)
+	}
+	if l.Window <= 0 || l.Window > MaxLocalTimeWindow {
+		return fmt.Errorf("%w: 发送窗口必须在 0 到 %s 之间: %s", ErrInvalidParameter, MaxLocalTimeWindow, l.Window)
+	}
+	if _, err := time.LoadLocation(l.DefaultTimezone); err != nil {
+		return fmt.Errorf("%w: 默认时区 %q 不存在", ErrInvalidParameter, l.DefaultTimezone)
+	}
+	for receiver, tz := range l.Timezones {
+		if _, err := time.LoadLocation(tz); err != nil || tz == "" {
+			return fmt.Errorf("%w: 接收者 %s 的时区 %q 不存在", ErrInvalidParameter, receiver, tz)
+		}
+	}
+	return nil
+}
+
+// SendTimeIn 在时区 tz 的本地发送时间和发送窗口的结束时间
+func (l LocalTimeSend) SendTimeIn(tz string) (start, end time.Time, err error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: 时区 %q 不存在", ErrInvalidParameter, tz)
+	}
+	start, err = time.ParseInLocation(localDateLayout+" "+localClockLayout, l.Date+" "+l.Clock, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: 本地时间 %s %s", ErrInvalidParameter, l.Date, l.Clock)
+	}
+	return start, start.Add(l.Window), nil
+}
+
+// LocalTimeCohortStatus 时区分组的状态
+type LocalTimeCohortStatus string
+
+const (
+	// LocalTimeCohortStatusPending 还没有到创建通知的时间
+	LocalTimeCohortStatusPending LocalTimeCohortStatus = "PENDING"
+	// LocalTimeCohortStatusCreated 已经创建了通知，由调度器按发送窗口发送
+	LocalTimeCohortStatusCreated LocalTimeCohortStatus = "CREATED"
+)
+
+func (s LocalTimeCohortStatus) String() string {
+	return string(s)
+}
+
+// LocalTimeCohort 一条按本地时间发送的通知里同一个时区的接收者
+// 快到发送时间时由调度任务创建成一条时间窗口发送的通知
+type LocalTimeCohort struct {
+	ID       int64
+	BizID    int64
+	Key      string
+	Timezone string
+	// SendTime、EndTime 发送窗口
+	SendTime  time.Time
+	EndTime   time.Time
+	Receivers []string
+	// ReceiverCount 接收者数量，创建通知之后接收者被清空，数量保留
+	ReceiverCount  int32
+	Channel        Channel
+	TemplateID     int64
+	TemplateParams map[string]string
+	Category       NotificationCategory
+	Labels         Labels
+	BizPayload     string
+	Environment    Environment
+	Status         LocalTimeCohortStatus
+	// NotificationID 创建的通知ID，创建之后才有
+	NotificationID uint64
+	RetryPolicy    RetryPolicy
+}
+
+// LocalTimeKey 时区分组创建的通知的 key，同一个分组重复创建时用唯一索引去重
+func LocalTimeKey(key, tz string) string {
+	return key + "@" + tz
+}
+
+// Notification 时区分组的通知，模板版本、ID 由调用方补齐
+func (c LocalTimeCohort) Notification() Notification {
+	return Notification{
+		BizID:     c.BizID,
+		Key:       LocalTimeKey(c.Key, c.Timezone),
+		Receivers: c.Receivers,
+		Channel:   c.Channel,
+		Template: Template{
+			ID:     c.TemplateID,
+			Params: c.TemplateParams,
+		},
+		SendStrategyConfig: SendStrategyConfig{
+			Type:      SendStrategyTimeWindow,
+			StartTime: c.SendTime,
+			EndTime:   c.EndTime,
+		},
+		Category:    c.Category,
+		Labels:      c.Labels,
+		BizPayload:  c.BizPayload,
+		Environment: c.Environment,
+		RetryPolicy: c.RetryPolicy,
+	}
+}
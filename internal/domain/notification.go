@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"maps"
+	"slices"
 	"strconv"
 	"time"
+	"unicode/utf8"
 )
 
 type SendStatus string
@@ -19,19 +22,74 @@ const (
 	SendStatusFailed    SendStatus = "FAILED"    // 发送失败
 )
 
+// FailReason FAILED、CANCELED 的原因，供应商明确返回失败时是 VendorErrorCategory，其他是平台原因
+type FailReason string
+
+const (
+	// FailReasonExpired 过了计划发送结束时间依旧没有发送
+	FailReasonExpired FailReason = "EXPIRED"
+	// FailReasonSendTimeout 发送中超时，结果未知
+	FailReasonSendTimeout FailReason = "SEND_TIMEOUT"
+	// FailReasonWindowClosed 调度之后在队列里等太久，调用供应商之前计划发送时间已经结束，没有发送
+	FailReasonWindowClosed FailReason = "WINDOW_CLOSED"
+	// FailReasonBlacklisted 所有接收者都在平台黑名单里，没有调用供应商
+	FailReasonBlacklisted FailReason = "BLACKLISTED"
+	// FailReasonOptedOut 营销类通知的接收者都已经退订，没有调用供应商
+	FailReasonOptedOut FailReason = "OPTED_OUT"
+	// FailReasonSuppressed CANCELED 的原因，同一个渠道降级分组里排在前面的渠道已经发送成功
+	FailReasonSuppressed FailReason = "SUPPRESSED"
+	// FailReasonRetryExhausted 调用供应商失败，用完了重试策略允许的发送次数，供应商没有明确返回失败原因
+	FailReasonRetryExhausted FailReason = "RETRY_EXHAUSTED"
+)
+
+func (r FailReason) String() string {
+	return string(r)
+}
+
+// NotificationCategory 通知类别，额度用完时透支策略按类别决定是否允许发送，为空按事务类处理
+type NotificationCategory string
+
+const (
+	NotificationCategoryTransactional NotificationCategory = "TRANSACTIONAL"
+	NotificationCategoryMarketing     NotificationCategory = "MARKETING"
+)
+
+func (c NotificationCategory) String() string {
+	return string(c)
+}
+
 func (s SendStatus) String() string {
 	return string(s)
 }
 
+// IsTerminal 是否是最终状态，之后不会再变化
+func (s SendStatus) IsTerminal() bool {
+	return s == SendStatusSucceeded || s == SendStatusFailed || s == SendStatusCanceled
+}
+
 type Template struct {
 	ID        int64             `json:"id"`        // 模板ID
 	VersionID int64             `json:"versionId"` // 版本ID
 	Params    map[string]string `json:"params"`    // 渲染模版时使用的参数
+	// ReceiverParams 按接收者覆盖的参数，同名参数优先于 Params，个性化的批量发送不用拆成每个接收者一条通知
+	ReceiverParams map[string]map[string]string `json:"receiverParams,omitempty"`
 
 	// 只做版本兼容演示代码用，其余忽略
 	Version string `json:"version"`
 }
 
+// ParamsFor 渲染发给 receiver 的内容时使用的参数
+func (t Template) ParamsFor(receiver string) map[string]string {
+	override, ok := t.ReceiverParams[receiver]
+	if !ok {
+		return t.Params
+	}
+	params := make(map[string]string, len(t.Params)+len(override))
+	maps.Copy(params, t.Params)
+	maps.Copy(params, override)
+	return params
+}
+
 // Notification 通知领域模型
 type Notification struct {
 	ID                 uint64             `json:"id"`             // 通知唯一标识
@@ -44,11 +102,47 @@ type Notification struct {
 	ScheduledSTime     time.Time          `json:"scheduledSTime"` // 计划发送开始时间
 	ScheduledETime     time.Time          `json:"scheduledETime"` // 计划发送结束时间
 	Version            int                `json:"version"`        // 版本号
+	FailReason         FailReason         `json:"failReason"`     // 失败原因，供应商发送失败时是统一的错误分类
 	SendStrategyConfig SendStrategyConfig `json:"sendStrategyConfig"`
+	// Digest 不为空时合并发送，不单独保存为通知
+	Digest *Digest `json:"digest,omitempty"`
+	// Category 只在创建时扣减额度用，不保存
+	Category NotificationCategory `json:"category,omitempty"`
+	// Callback 回调设置，只在创建时保存到回调记录
+	Callback CallbackOptions `json:"-"`
+	// Labels 标签，创建后不能修改
+	Labels Labels `json:"labels,omitempty"`
+	// Environment 由调用方凭证决定，沙箱通知发给模拟供应商，不扣减额度
+	Environment Environment `json:"environment,omitempty"`
+	// Campaign 限速发送的活动，调度器按活动限速
+	Campaign string `json:"campaign,omitempty"`
+	// FallbackGroup 渠道降级分组，同一个分组里的通知按业务方声明的渠道顺序依次发送，一个渠道发送成功后其余的不再发送
+	FallbackGroup string `json:"fallbackGroup,omitempty"`
+	// BizPayload 业务方透传数据，平台不解析，回调、查询和导出时原样返回
+	BizPayload string `json:"bizPayload,omitempty"`
+	// RetryPolicy 业务方指定的重试策略，零值字段使用平台默认值
+	RetryPolicy RetryPolicy `json:"retryPolicy"`
+	// Attempts 已经失败的发送次数
+	Attempts int32 `json:"attempts"`
+	// Ctime 创建时间，额度在创建时扣减，归还时抵扣创建当月的透支
+	Ctime time.Time `json:"ctime"`
+}
+
+// NotificationFilter 按业务方分页查询通知的条件，按ID倒序
+// BeforeID 是上一页最后一条的ID，为 0 从最新的开始；Status、Channel 为空不过滤
+type NotificationFilter struct {
+	BizID   int64
+	Status  SendStatus
+	Channel Channel
+	// Labels 必须包含所有这些标签
+	Labels   Labels
+	BeforeID uint64
+	Limit    int
 }
 
-func (n *Notification) SetSendTime() {
-	stime, etime := n.SendStrategyConfig.SendTimeWindow()
+// SetSendTime 以 now 为当前时间按发送策略设置发送时间窗口
+func (n *Notification) SetSendTime(now time.Time) {
+	stime, etime := n.SendStrategyConfig.SendTimeWindow(now)
 	n.ScheduledSTime = stime
 	n.ScheduledETime = etime
 }
@@ -57,15 +151,16 @@ func (n *Notification) IsImmediate() bool {
 	return n.SendStrategyConfig.Type == SendStrategyImmediate
 }
 
-// ReplaceAsyncImmediate 如果是是立刻发送，就修改为默认的策略
-func (n *Notification) ReplaceAsyncImmediate() {
+// ReplaceAsyncImmediate 如果是是立刻发送，就修改为默认的策略，截止时间是 now 之后一分钟
+func (n *Notification) ReplaceAsyncImmediate(now time.Time) {
 	if n.IsImmediate() {
-		n.SendStrategyConfig.DeadlineTime = time.Now().Add(time.Minute)
+		n.SendStrategyConfig.DeadlineTime = now.Add(time.Minute)
 		n.SendStrategyConfig.Type = SendStrategyDeadline
 	}
 }
 
-func (n *Notification) Validate() error {
+// Validate 以 now 为当前时间校验通知
+func (n *Notification) Validate(now time.Time) error {
 	if n.BizID <= 0 {
 		return fmt.Errorf("%w: BizID = %d", ErrInvalidParameter, n.BizID)
 	}
@@ -74,6 +169,10 @@ func (n *Notification) Validate() error {
 		return fmt.Errorf("%w: Key = %q", ErrInvalidParameter, n.Key)
 	}
 
+	if utf8.RuneCountInString(n.Key) > MaxNotificationKeyLength {
+		return fmt.Errorf("%w: Key 不能超过 %d 个字符", ErrInvalidParameter, MaxNotificationKeyLength)
+	}
+
 	if len(n.Receivers) == 0 {
 		return fmt.Errorf("%w: Receivers= %v", ErrInvalidParameter, n.Receivers)
 	}
@@ -90,17 +189,101 @@ func (n *Notification) Validate() error {
 		return fmt.Errorf("%w: Template.VersionID = %d", ErrInvalidParameter, n.Template.VersionID)
 	}
 
-	if len(n.Template.Params) == 0 {
+	if len(n.Template.Params) == 0 && len(n.Template.ReceiverParams) == 0 {
 		return fmt.Errorf("%w: Template.Params = %q", ErrInvalidParameter, n.Template.Params)
 	}
 
-	if err := n.SendStrategyConfig.Validate(); err != nil {
+	if err := n.validateReceiverParams(); err != nil {
 		return err
 	}
 
+	if err := n.SendStrategyConfig.Validate(now); err != nil {
+		return err
+	}
+
+	if n.Digest != nil {
+		if err := n.Digest.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := n.Callback.Validate(); err != nil {
+		return err
+	}
+
+	if err := n.Labels.Validate(); err != nil {
+		return err
+	}
+
+	if err := validateBizPayload(n.BizPayload); err != nil {
+		return err
+	}
+
+	if err := n.RetryPolicy.Validate(); err != nil {
+		return err
+	}
+
+	return n.validateFallbackGroup()
+}
+
+// validateReceiverParams 按接收者覆盖的参数只能给这条通知的接收者，合并发送和按本地时间发送不支持
+func (n *Notification) validateReceiverParams() error {
+	if len(n.Template.ReceiverParams) == 0 {
+		return nil
+	}
+	if n.IsDigest() || n.IsLocalTime() {
+		return fmt.Errorf("%w: 合并发送和按本地时间发送不支持按接收者设置模板参数", ErrInvalidParameter)
+	}
+	for receiver, params := range n.Template.ReceiverParams {
+		if !slices.Contains(n.Receivers, receiver) {
+			return fmt.Errorf("%w: Template.ReceiverParams 里的 %q 不是接收者", ErrInvalidParameter, receiver)
+		}
+		if len(params) == 0 {
+			return fmt.Errorf("%w: 接收者 %q 的模板参数为空", ErrInvalidParameter, receiver)
+		}
+	}
 	return nil
 }
 
+// IsLocalTime 是否按接收者本地时间发送
+func (n *Notification) IsLocalTime() bool {
+	return n.SendStrategyConfig.Type == SendStrategyLocalTime
+}
+
+// SendWindowClosed 在 now 时计划发送时间是否已经结束，结束之后不能再调用供应商
+func (n *Notification) SendWindowClosed(now time.Time) bool {
+	return !n.ScheduledETime.IsZero() && now.After(n.ScheduledETime)
+}
+
+// NextRetry 以 now 为当前时间，发送失败之后按重试策略计算下一次发送的时间
+// 用完了发送次数，或者等待之后计划发送时间已经结束时返回 false
+func (n *Notification) NextRetry(now time.Time) (time.Time, bool) {
+	attempts := n.Attempts + 1
+	if attempts >= n.RetryPolicy.WithDefaults().MaxAttempts {
+		return time.Time{}, false
+	}
+	next := now.Add(n.RetryPolicy.Backoff(attempts))
+	if !n.ScheduledETime.IsZero() && next.After(n.ScheduledETime) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// IsPaced 是否按活动限速发送
+func (n *Notification) IsPaced() bool {
+	return n.SendStrategyConfig.Type == SendStrategyPaced
+}
+
+// IsSandbox 是否是沙箱通知
+func (n *Notification) IsSandbox() bool {
+	return n.Environment.IsSandbox()
+}
+
+// IsDigest 是否合并发送
+func (n *Notification) IsDigest() bool {
+	return n.Digest != nil
+}
+
 func (n *Notification) IsValidBizID() error {
 	if n.BizID <= 0 {
 		return fmt.Errorf("%w: BizID = %d", ErrInvalidParameter, n.BizID)
@@ -116,6 +299,14 @@ func (n *Notification) MarshalTemplateParams() (string, error) {
 	return n.marshal(n.Template.Params)
 }
 
+// MarshalReceiverParams 没有按接收者覆盖的参数时返回空字符串
+func (n *Notification) MarshalReceiverParams() (string, error) {
+	if len(n.Template.ReceiverParams) == 0 {
+		return "", nil
+	}
+	return n.marshal(n.Template.ReceiverParams)
+}
+
 func (n *Notification) marshal(v any) (string, error) {
 	jsonBytes, err := json.Marshal(v)
 	if err != nil {
@@ -144,13 +335,73 @@ func NewNotificationFromAPI(n *notificationpb.Notification) (Notification, error
 		Receivers: n.FindReceivers(),
 		Channel:   channel,
 		Template: Template{
-			ID:     tid,
-			Params: n.TemplateParams,
+			ID:             tid,
+			Params:         n.TemplateParams,
+			ReceiverParams: newReceiverParamsFromAPI(n.ReceiverParams),
 		},
-		SendStrategyConfig: getDomainSendStrategyConfig(n),
+		SendStrategyConfig: NewSendStrategyConfigFromAPI(n.Strategy),
+		Digest:             newDigestFromAPI(n.Digest),
+		Category:           newCategoryFromAPI(n.Category),
+		Callback:           newCallbackFromAPI(n.Callback),
+		Labels:             n.Labels,
+		FallbackGroup:      n.FallbackGroup,
+		BizPayload:         n.BizPayload,
+		RetryPolicy:        newRetryPolicyFromAPI(n.RetryPolicy),
 	}, nil
 }
 
+// newReceiverParamsFromAPI 同一个接收者出现多次时合并，后面的优先
+func newReceiverParamsFromAPI(ps []*notificationpb.ReceiverParams) map[string]map[string]string {
+	if len(ps) == 0 {
+		return nil
+	}
+	res := make(map[string]map[string]string, len(ps))
+	for _, p := range ps {
+		if res[p.GetReceiver()] == nil {
+			res[p.GetReceiver()] = make(map[string]string, len(p.GetParams()))
+		}
+		maps.Copy(res[p.GetReceiver()], p.GetParams())
+	}
+	return res
+}
+
+func newRetryPolicyFromAPI(p *notificationpb.RetryPolicy) RetryPolicy {
+	if p == nil {
+		return RetryPolicy{}
+	}
+	return RetryPolicy{
+		MaxAttempts:    p.GetMaxAttempts(),
+		InitialBackoff: time.Duration(p.GetInitialBackoffSeconds()) * time.Second,
+		MaxBackoff:     time.Duration(p.GetMaxBackoffSeconds()) * time.Second,
+	}
+}
+
+func newCallbackFromAPI(c *notificationpb.CallbackOptions) CallbackOptions {
+	if c == nil {
+		return CallbackOptions{}
+	}
+	opts := CallbackOptions{URL: c.GetUrl(), Headers: c.GetHeaders()}
+	for _, s := range c.GetOnStatus() {
+		// 不合法的状态原样保留，由 Validate 拒绝
+		opts.OnStatus = append(opts.OnStatus, SendStatus(s.String()))
+	}
+	return opts
+}
+
+func newCategoryFromAPI(c notificationpb.NotificationCategory) NotificationCategory {
+	if c == notificationpb.NotificationCategory_MARKETING {
+		return NotificationCategoryMarketing
+	}
+	return NotificationCategoryTransactional
+}
+
+func newDigestFromAPI(d *notificationpb.DigestOptions) *Digest {
+	if d == nil {
+		return nil
+	}
+	return &Digest{Summary: d.GetSummary()}
+}
+
 func getDomainChannel(n *notificationpb.Notification) (Channel, error) {
 	switch n.Channel {
 	case notificationpb.Channel_SMS:
@@ -164,7 +415,8 @@ func getDomainChannel(n *notificationpb.Notification) (Channel, error) {
 	}
 }
 
-func getDomainSendStrategyConfig(n *notificationpb.Notification) SendStrategyConfig {
+// NewSendStrategyConfigFromAPI 转换发送策略，没有指定时为立即发送
+func NewSendStrategyConfigFromAPI(strategy *notificationpb.SendStrategy) SendStrategyConfig {
 	// 构建发送策略
 	sendStrategyType := SendStrategyImmediate // 默认为立即发送
 	var delaySeconds int64
@@ -172,10 +424,12 @@ func getDomainSendStrategyConfig(n *notificationpb.Notification) SendStrategyCon
 	var startTimeMilliseconds int64
 	var endTimeMilliseconds int64
 	var deadlineTime time.Time
+	var localTime *LocalTimeSend
+	var pacing *Pacing
 
 	// 处理发送策略
-	if n.Strategy != nil {
-		switch s := n.Strategy.StrategyType.(type) {
+	if strategy != nil {
+		switch s := strategy.StrategyType.(type) {
 		case *notificationpb.SendStrategy_Immediate:
 			sendStrategyType = SendStrategyImmediate
 		case *notificationpb.SendStrategy_Delayed:
@@ -199,6 +453,28 @@ func getDomainSendStrategyConfig(n *notificationpb.Notification) SendStrategyCon
 				sendStrategyType = SendStrategyDeadline
 				deadlineTime = s.Deadline.Deadline.AsTime()
 			}
+		case *notificationpb.SendStrategy_LocalTime:
+			if s.LocalTime != nil {
+				sendStrategyType = SendStrategyLocalTime
+				localTime = &LocalTimeSend{
+					Date:            s.LocalTime.GetDate(),
+					Clock:           s.LocalTime.GetTime(),
+					Window:          time.Duration(s.LocalTime.GetWindowSeconds()) * time.Second,
+					Timezones:       s.LocalTime.GetReceiverTimezones(),
+					DefaultTimezone: s.LocalTime.GetDefaultTimezone(),
+				}
+				if localTime.Window == 0 {
+					localTime.Window = DefaultLocalTimeWindow
+				}
+			}
+		case *notificationpb.SendStrategy_Paced:
+			if s.Paced != nil {
+				sendStrategyType = SendStrategyPaced
+				pacing = &Pacing{
+					Campaign:  s.Paced.GetCampaign(),
+					PerMinute: s.Paced.GetPerMinute(),
+				}
+			}
 		}
 	}
 	return SendStrategyConfig{
@@ -208,5 +484,7 @@ func getDomainSendStrategyConfig(n *notificationpb.Notification) SendStrategyCon
 		StartTime:     time.Unix(startTimeMilliseconds, 0),
 		EndTime:       time.Unix(endTimeMilliseconds, 0),
 		DeadlineTime:  deadlineTime,
+		LocalTime:     localTime,
+		Pacing:        pacing,
 	}
 }
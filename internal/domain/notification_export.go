@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormat 导出文件格式
+type ExportFormat string
+
+const (
+	ExportFormatCSV   ExportFormat = "CSV"
+	ExportFormatJSONL ExportFormat = "JSONL"
+)
+
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatCSV || f == ExportFormatJSONL
+}
+
+// ExportRedaction 导出时接收者和模板参数的脱敏方式
+type ExportRedaction string
+
+const (
+	// ExportRedactionMask 接收者打码，模板参数只保留参数名，默认方式
+	ExportRedactionMask ExportRedaction = "MASK"
+	// ExportRedactionOmit 不导出接收者和模板参数
+	ExportRedactionOmit ExportRedaction = "OMIT"
+	// ExportRedactionNone 明文导出，需要 pii:read 权限
+	ExportRedactionNone ExportRedaction = "NONE"
+)
+
+func (r ExportRedaction) IsValid() bool {
+	return r == ExportRedactionMask || r == ExportRedactionOmit || r == ExportRedactionNone
+}
+
+// MaxExportRange 一次导出的最长时间范围
+const MaxExportRange = 90 * 24 * time.Hour
+
+// NotificationExportQuery 导出业务方在 [Start, End) 内创建的通知，按 (创建时间, ID) 升序
+// Cursor 是上一次导出中断时最后一块的游标，为空从头开始
+type NotificationExportQuery struct {
+	BizID     int64
+	Start     time.Time
+	End       time.Time
+	Format    ExportFormat
+	Redaction ExportRedaction
+	Cursor    ExportCursor
+}
+
+func (q NotificationExportQuery) Validate() error {
+	if q.BizID <= 0 {
+		return fmt.Errorf("%w: BizID = %d", ErrInvalidParameter, q.BizID)
+	}
+	if !q.Start.Before(q.End) {
+		return fmt.Errorf("%w: 开始时间必须早于结束时间", ErrInvalidParameter)
+	}
+	if q.End.Sub(q.Start) > MaxExportRange {
+		return fmt.Errorf("%w: 时间范围不能超过 %d 天", ErrInvalidParameter, int(MaxExportRange/(24*time.Hour)))
+	}
+	if !q.Format.IsValid() {
+		return fmt.Errorf("%w: Format = %q", ErrInvalidParameter, q.Format)
+	}
+	if !q.Redaction.IsValid() {
+		return fmt.Errorf("%w: Redaction = %q", ErrInvalidParameter, q.Redaction)
+	}
+	return nil
+}
+
+// ExportCursor 导出进度，已经导出了 (Ctime, ID) 及之前的所有通知
+type ExportCursor struct {
+	Ctime int64
+	ID    uint64
+}
+
+func (c ExportCursor) IsZero() bool {
+	return c.Ctime == 0 && c.ID == 0
+}
+
+// Encode 编码成不透明的字符串交给调用方
+func (c ExportCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(strconv.FormatInt(c.Ctime, 10) + ":" + strconv.FormatUint(c.ID, 10)))
+}
+
+// DecodeExportCursor 空字符串返回零值
+func DecodeExportCursor(s string) (ExportCursor, error) {
+	if s == "" {
+		return ExportCursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		ctime, id, ok := strings.Cut(string(raw), ":")
+		if ok {
+			c := ExportCursor{}
+			if c.Ctime, err = strconv.ParseInt(ctime, 10, 64); err == nil {
+				if c.ID, err = strconv.ParseUint(id, 10, 64); err == nil {
+					return c, nil
+				}
+			}
+		}
+	}
+	return ExportCursor{}, fmt.Errorf("%w: cursor = %s", ErrInvalidParameter, s)
+}
+
+// ExportedNotification 导出的一条通知，带上创建和更新时间（毫秒时间戳）
+type ExportedNotification struct {
+	Notification
+	Ctime int64
+	Utime int64
+}
+
+// ExportChunk 导出的一块数据，Cursor 是这一块最后一条通知的游标，中断后用它继续导出
+type ExportChunk struct {
+	Data   []byte
+	Rows   int
+	Cursor string
+}
+
+// MaskReceiver 接收者打码：邮箱只保留用户名第一个字符和域名，其他只保留前 3 位和后 4 位
+func MaskReceiver(receiver string) string {
+	if name, host, ok := strings.Cut(receiver, "@"); ok {
+		if name == "" {
+			return "***@" + host
+		}
+		r := []rune(name)
+		return string(r[0]) + "***@" + host
+	}
+	r := []rune(receiver)
+	if len(r) <= 7 {
+		return strings.Repeat("*", len(r))
+	}
+	return string(r[:3]) + strings.Repeat("*", len(r)-7) + string(r[len(r)-4:])
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// NotificationUpdate 待发送通知的修改内容，字段为 nil 表示不修改
+type NotificationUpdate struct {
+	Receivers          []string
+	TemplateParams     map[string]string
+	SendStrategyConfig *SendStrategyConfig
+}
+
+func (u NotificationUpdate) IsEmpty() bool {
+	return u.Receivers == nil && u.TemplateParams == nil && u.SendStrategyConfig == nil
+}
+
+// ApplyUpdate 修改通知并以 now 为当前时间重新校验被修改的字段
+func (n *Notification) ApplyUpdate(update NotificationUpdate, now time.Time) error {
+	if update.IsEmpty() {
+		return fmt.Errorf("%w: 没有需要修改的字段", ErrInvalidParameter)
+	}
+	if update.Receivers != nil {
+		if len(update.Receivers) == 0 {
+			return fmt.Errorf("%w: Receivers= %v", ErrInvalidParameter, update.Receivers)
+		}
+		n.Receivers = update.Receivers
+		// 不再是接收者的不保留按接收者覆盖的参数
+		maps.DeleteFunc(n.Template.ReceiverParams, func(receiver string, _ map[string]string) bool {
+			return !slices.Contains(n.Receivers, receiver)
+		})
+	}
+	if update.TemplateParams != nil {
+		if len(update.TemplateParams) == 0 {
+			return fmt.Errorf("%w: Template.Params = %q", ErrInvalidParameter, update.TemplateParams)
+		}
+		n.Template.Params = update.TemplateParams
+	}
+	if update.SendStrategyConfig != nil {
+		if update.SendStrategyConfig.Type == SendStrategyLocalTime {
+			return fmt.Errorf("%w: 待发送的通知不能改成按本地时间发送", ErrInvalidParameter)
+		}
+		if update.SendStrategyConfig.Type == SendStrategyPaced || n.Campaign != "" {
+			return fmt.Errorf("%w: 限速发送的发送时间由活动进度决定，不能修改发送策略", ErrInvalidParameter)
+		}
+		if err := update.SendStrategyConfig.Validate(now); err != nil {
+			return err
+		}
+		n.SendStrategyConfig = *update.SendStrategyConfig
+		// 待发送的通知不会再走同步发送，立即发送同样替换成默认的截止时间策略
+		n.ReplaceAsyncImmediate(now)
+		n.SetSendTime(now)
+	}
+	return nil
+}
+
+type NotificationAuditAction string
+
+const (
+	NotificationAuditActionUpdate NotificationAuditAction = "UPDATE"
+)
+
+func (a NotificationAuditAction) String() string {
+	return string(a)
+}
+
+// NotificationSnapshot 审计日志中记录的通知可修改字段
+type NotificationSnapshot struct {
+	Receivers      []string          `json:"receivers"`
+	TemplateParams map[string]string `json:"templateParams"`
+	ScheduledSTime int64             `json:"scheduledSTime"`
+	ScheduledETime int64             `json:"scheduledETime"`
+	Version        int               `json:"version"`
+}
+
+func NewNotificationSnapshot(n Notification) NotificationSnapshot {
+	return NotificationSnapshot{
+		Receivers:      n.Receivers,
+		TemplateParams: n.Template.Params,
+		ScheduledSTime: n.ScheduledSTime.UnixMilli(),
+		ScheduledETime: n.ScheduledETime.UnixMilli(),
+		Version:        n.Version,
+	}
+}
+
+func (s NotificationSnapshot) Marshal() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// NotificationAuditLog 通知变更审计日志
+type NotificationAuditLog struct {
+	ID             int64
+	NotificationID uint64
+	BizID          int64
+	Action         NotificationAuditAction
+	Operator       string
+	Before         NotificationSnapshot
+	After          NotificationSnapshot
+	Ctime          time.Time
+}
@@ -0,0 +1,13 @@
+package domain
+
+// NotificationChange 通知创建或者状态变化的信号，只用来唤醒观察者，不包含状态，观察者收到之后重新查询通知
+type NotificationChange struct {
+	NotificationID uint64
+	BizID          int64
+	Key            string
+}
+
+// Change 通知变化的信号
+func (n Notification) Change() NotificationChange {
+	return NotificationChange{NotificationID: n.ID, BizID: n.BizID, Key: n.Key}
+}
@@ -0,0 +1,26 @@
+package domain
+
+// OptOutSource 接收者退订的来源
+type OptOutSource string
+
+const (
+	// OptOutSourceLink 点击邮件、站内信里的退订链接
+	OptOutSourceLink OptOutSource = "UNSUBSCRIBE_LINK"
+	// OptOutSourceSMSStop 回复短信退订关键字，例如 TD、STOP
+	OptOutSourceSMSStop OptOutSource = "SMS_STOP"
+)
+
+func (s OptOutSource) String() string {
+	return string(s)
+}
+
+// OptOut 接收者退订了某个渠道的营销类通知，事务类通知（验证码、账单）不受影响
+// 回复短信退订时不知道是哪个业务方的短信，BizID 为 0，对所有业务方生效
+type OptOut struct {
+	ID       int64
+	BizID    int64
+	Channel  Channel
+	Receiver string
+	Source   OptOutSource
+	Ctime    int64
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// PacingSlotWindow 限速发送的通知分到的发送时间之后多久之内发送，过了之后按过期处理
+	PacingSlotWindow = time.Hour
+	// MaxCampaignLength 活动名称的最大长度
+	MaxCampaignLength = 64
+	// MaxPacingPerMinute 每分钟最多 60000 条，相邻两条通知至少间隔 1ms
+	MaxPacingPerMinute = 60000
+)
+
+// Pacing 限速发送，同一个业务方的同一个活动每分钟最多发送 PerMinute 条通知
+// 大批量的活动按顺序分到之后每分钟的发送时间，避免压垮供应商和下游服务
+type Pacing struct {
+	Campaign  string `json:"campaign"`
+	PerMinute int32  `json:"perMinute"`
+}
+
+func (p Pacing) Validate() error {
+	if p.Campaign == "" || len(p.Campaign) > MaxCampaignLength {
+		return fmt.Errorf("%w: 活动名称不能为空，最长 %d: %q", ErrInvalidParameter, MaxCampaignLength, p.Campaign)
+	}
+	if p.PerMinute <= 0 || p.PerMinute > MaxPacingPerMinute {
+		return fmt.Errorf("%w: 每分钟发送数量必须在 1 到 %d 之间: %d", ErrInvalidParameter, MaxPacingPerMinute, p.PerMinute)
+	}
+	return nil
+}
+
+// Interval 同一个活动相邻两条通知的发送间隔，精确到毫秒
+func (p Pacing) Interval() time.Duration {
+	return time.Duration(time.Minute.Milliseconds()/int64(p.PerMinute)) * time.Millisecond
+}
+
+// PacingCampaign 限速发送的活动进度，保存在数据库里，重启之后按进度继续
+type PacingCampaign struct {
+	BizID     int64
+	Campaign  string
+	PerMinute int32
+	// NextSlot 下一条通知的发送时间，创建通知时往后推
+	NextSlot time.Time
+	// SentMinute、SentCount 当前这一分钟调度器已经放行的数量
+	SentMinute time.Time
+	SentCount  int32
+	// SentTotal 调度器一共放行的数量
+	SentTotal int64
+}
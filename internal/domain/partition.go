@@ -0,0 +1,36 @@
+package domain
+
+// partitionShift 雪花ID的低 16 位是机器ID，同一个实例生成的ID低位完全一样，直接取模会严重倾斜，
+// 所以先去掉机器ID，再按照时间和序列号取模
+const partitionShift = 16
+
+// Partition 调度分区，实例只扫描 (id >> 16) % Total == Slot 的通知
+// Total 小于等于 1 表示不分区，扫描全部
+type Partition struct {
+	Slot  int
+	Total int
+}
+
+// AllPartitions 不分区
+func AllPartitions() Partition {
+	return Partition{Slot: 0, Total: 1}
+}
+
+// Partitioned 是否真的分区
+func (p Partition) Partitioned() bool {
+	return p.Total > 1
+}
+
+// Owns 通知是否属于这个分区，和 DAO 里的 SQL 条件保持一致
+func (p Partition) Owns(id uint64) bool {
+	if !p.Partitioned() {
+		return true
+	}
+	return int((id>>partitionShift)%uint64(p.Total)) == p.Slot
+}
+
+// Bounds 不用除法表达分区条件：id % mod 落在 [lower, upper) 等价于 (id >> 16) % Total == Slot
+func (p Partition) Bounds() (mod, lower, upper uint64) {
+	unit := uint64(1) << partitionShift
+	return unit * uint64(p.Total), unit * uint64(p.Slot), unit * uint64(p.Slot+1)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "testing"
+
+// DAO 里的 SQL 用 MOD(id, 2^16*Total) 落在 [2^16*Slot, 2^16*(Slot+1)) 表达分区，必须和 Owns 完全一致，
+// 否则同一条通知会被两个实例调度，或者没有实例调度
+func TestPartition_BoundsMatchOwns(t *testing.T) {
+	ids := []uint64{0, 1, 1<<16 - 1, 1 << 16, 1<<16 + 1, 3<<16 - 1, 3 << 16, 1<<63 - 1, 1 << 63, ^uint64(0)}
+	// 雪花ID：高位是时间，低 16 位是机器ID，同一个机器ID生成的ID要分散到不同的分区
+	for seq := uint64(0); seq < 256; seq++ {
+		ids = append(ids, (1_700_000_000_000+seq)<<16|42)
+	}
+	for _, total := range []int{2, 3, 5, 7, 16, 100} {
+		owned := make([]int, total)
+		for _, id := range ids {
+			owners := 0
+			for slot := range total {
+				p := Partition{Slot: slot, Total: total}
+				mod, lower, upper := p.Bounds()
+				inBounds := id%mod >= lower && id%mod < upper
+				if inBounds != p.Owns(id) {
+					t.Fatalf("id %d 分区 %d/%d: SQL 条件 %v, Owns %v", id, slot, total, inBounds, p.Owns(id))
+				}
+				if inBounds {
+					owners++
+					owned[slot]++
+				}
+			}
+			if owners != 1 {
+				t.Fatalf("id %d 在 %d 个分区里属于 %d 个分区", id, total, owners)
+			}
+		}
+		for slot, n := range owned {
+			if n == 0 {
+				t.Fatalf("%d 个分区时分区 %d 没有分到任何通知", total, slot)
+			}
+		}
+	}
+}
+
+func TestPartition_Unpartitioned(t *testing.T) {
+	for _, p := range []Partition{AllPartitions(), {Slot: 0, Total: 0}} {
+		if p.Partitioned() {
+			t.Fatalf("%+v 不应该分区", p)
+		}
+		for _, id := range []uint64{0, 1 << 16, ^uint64(0)} {
+			if !p.Owns(id) {
+				t.Fatalf("不分区时 %+v 应该拥有 id %d", p, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+// InAppMessage 推送给在线用户的站内信，每个接收者一条，客户端按 NotificationID 去重
+// 平台不渲染站内信内容，客户端按模板和参数自己渲染，参数已经合并了这个接收者覆盖的参数
+type InAppMessage struct {
+	NotificationID    uint64            `json:"notificationId"`
+	BizID             int64             `json:"bizId"`
+	Key               string            `json:"key"`
+	Receiver          string            `json:"receiver"`
+	TemplateID        int64             `json:"templateId"`
+	TemplateVersionID int64             `json:"templateVersionId"`
+	TemplateParams    map[string]string `json:"templateParams"`
+}
+
+// NewInAppMessage 站内信发给其中一个接收者的消息
+func NewInAppMessage(n Notification, receiver string) InAppMessage {
+	return InAppMessage{
+		NotificationID:    n.ID,
+		BizID:             n.BizID,
+		Key:               n.Key,
+		Receiver:          receiver,
+		TemplateID:        n.Template.ID,
+		TemplateVersionID: n.Template.VersionID,
+		TemplateParams:    n.Template.ParamsFor(receiver),
+	}
+}
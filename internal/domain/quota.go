@@ -1,7 +1,142 @@
 package domain
 
+import (
+	"fmt"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+)
+
 type Quota struct {
 	BizID   int64
 	Quota   int32
 	Channel Channel
+	// Overdraft 本月透支的条数，透支部分按这个补计费
+	Overdraft int32
+}
+
+// OverdraftMode 额度用完之后的处理方式
+type OverdraftMode string
+
+const (
+	// OverdraftModeReject 额度用完直接拒绝，默认
+	OverdraftModeReject OverdraftMode = "REJECT"
+	// OverdraftModeBurst 允许透支配置额度的 BurstPercent%
+	OverdraftModeBurst OverdraftMode = "BURST"
+	// OverdraftModeBillLater 不限制透支，透支部分之后补计费
+	OverdraftModeBillLater OverdraftMode = "BILL_LATER"
+	// OverdraftModeDegradeMarketing 额度用完只拒绝营销类通知，其余不限制透支
+	OverdraftModeDegradeMarketing OverdraftMode = "DEGRADE_MARKETING"
+)
+
+// UnlimitedOverdraft Allowance 返回这个值表示不限制透支
+const UnlimitedOverdraft = -1
+
+// OverdraftPolicy 业务方的透支策略，Channel 为空表示所有渠道
+type OverdraftPolicy struct {
+	BizID        int64
+	Channel      Channel
+	Mode         OverdraftMode
+	BurstPercent int
+}
+
+func (p OverdraftPolicy) Validate() error {
+	if p.BizID <= 0 {
+		return fmt.Errorf("%w: 透支策略的 BizID = %d", ErrInvalidParameter, p.BizID)
+	}
+	if p.Channel != "" && !p.Channel.IsValid() {
+		return fmt.Errorf("%w: 透支策略的 Channel = %q", ErrInvalidParameter, p.Channel)
+	}
+	switch p.Mode {
+	case OverdraftModeReject, OverdraftModeBillLater, OverdraftModeDegradeMarketing:
+	case OverdraftModeBurst:
+		if p.BurstPercent <= 0 {
+			return fmt.Errorf("%w: BURST 透支策略的 BurstPercent 必须大于0", ErrInvalidParameter)
+		}
+	default:
+		return fmt.Errorf("%w: 透支策略的 Mode = %q", ErrInvalidParameter, p.Mode)
+	}
+	return nil
+}
+
+// Allowance 允许透支配置额度的百分比，0 表示不允许透支，UnlimitedOverdraft 表示不限制
+func (p OverdraftPolicy) Allowance(category NotificationCategory) int {
+	switch p.Mode {
+	case OverdraftModeBurst:
+		return p.BurstPercent
+	case OverdraftModeBillLater:
+		return UnlimitedOverdraft
+	case OverdraftModeDegradeMarketing:
+		if category == NotificationCategoryMarketing {
+			return 0
+		}
+		return UnlimitedOverdraft
+	default:
+		return 0
+	}
+}
+
+type overdraftPolicyKey struct {
+	bizID   int64
+	channel Channel
+}
+
+// OverdraftPolicies 按业务方和渠道查找透支策略
+type OverdraftPolicies struct {
+	policies map[overdraftPolicyKey]OverdraftPolicy
+}
+
+// NewOverdraftPolicies 同一个业务方和渠道只能配置一条策略
+func NewOverdraftPolicies(policies []OverdraftPolicy) (OverdraftPolicies, error) {
+	m := make(map[overdraftPolicyKey]OverdraftPolicy, len(policies))
+	for _, p := range policies {
+		if err := p.Validate(); err != nil {
+			return OverdraftPolicies{}, err
+		}
+		key := overdraftPolicyKey{bizID: p.BizID, channel: p.Channel}
+		if _, ok := m[key]; ok {
+			return OverdraftPolicies{}, fmt.Errorf("%w: 业务方 %d 渠道 %q 的透支策略重复配置", ErrInvalidParameter, p.BizID, p.Channel)
+		}
+		m[key] = p
+	}
+	return OverdraftPolicies{policies: m}, nil
+}
+
+// Find 优先使用业务方+渠道的策略，其次是业务方所有渠道的策略，都没有时不允许透支
+func (ps OverdraftPolicies) Find(bizID int64, channel Channel) OverdraftPolicy {
+	if p, ok := ps.policies[overdraftPolicyKey{bizID: bizID, channel: channel}]; ok {
+		return p
+	}
+	if p, ok := ps.policies[overdraftPolicyKey{bizID: bizID}]; ok {
+		return p
+	}
+	return OverdraftPolicy{BizID: bizID, Channel: channel, Mode: OverdraftModeReject}
+}
+
+// QuotaDemand 一批通知要扣减额度的渠道和类别，类别决定能不能透支
+type QuotaDemand struct {
+	Channel  Channel
+	Category NotificationCategory
+}
+
+// NewQuotaDemandsFromAPI 一批通知要扣减额度的渠道和类别，去重
+// 渠道不合法的通知会在转换时被拒绝，合并发送和按本地时间发送的通知不在这时扣减额度，都跳过
+func NewQuotaDemandsFromAPI(ns []*notificationpb.Notification) []QuotaDemand {
+	seen := make(map[QuotaDemand]struct{}, len(ns))
+	demands := make([]QuotaDemand, 0, len(ns))
+	for _, n := range ns {
+		if n == nil || n.GetDigest() != nil || n.GetStrategy().GetLocalTime() != nil {
+			continue
+		}
+		channel, err := getDomainChannel(n)
+		if err != nil {
+			continue
+		}
+		d := QuotaDemand{Channel: channel, Category: newCategoryFromAPI(n.GetCategory())}
+		if _, ok := seen[d]; ok {
+			continue
+		}
+		seen[d] = struct{}{}
+		demands = append(demands, d)
+	}
+	return demands
 }
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Role 角色
+type Role string
+
+const (
+	// RolePlatformAdmin 平台管理员，可以操作所有业务方的数据
+	RolePlatformAdmin Role = "PLATFORM_ADMIN"
+	// RoleBizAdmin 业务方管理员，可以操作自己业务方的数据，管理本业务方的角色
+	RoleBizAdmin Role = "BIZ_ADMIN"
+	// RoleReadOnly 只读，只能查询自己业务方的数据
+	RoleReadOnly Role = "READ_ONLY"
+)
+
+func (r Role) String() string {
+	return string(r)
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permission 权限，每个接口对应一个权限
+type Permission string
+
+const (
+	PermissionNotificationWrite Permission = "notification:write"
+	PermissionNotificationRead  Permission = "notification:read"
+	PermissionTemplateRead      Permission = "template:read"
+	PermissionPrivacyErase      Permission = "privacy:erase"
+	PermissionRoleRead          Permission = "role:read"
+	PermissionRoleManage        Permission = "role:manage"
+	PermissionCallbackManage    Permission = "callback:manage"
+	// PermissionPIIRead 查看接收者、模板参数等个人信息，只读角色没有
+	PermissionPIIRead Permission = "pii:read"
+	// PermissionAdminRead 平台运维查询，只有平台管理员有
+	PermissionAdminRead Permission = "admin:read"
+	// PermissionTemplateWrite 修改自己业务方的模板，例如回滚版本，只读角色没有
+	PermissionTemplateWrite Permission = "template:write"
+	// PermissionTemplateReview 模板内部审核，只有平台管理员有
+	PermissionTemplateReview Permission = "template:review"
+	// PermissionAdminManage 平台运维操作，例如调整日志级别，只有平台管理员有
+	PermissionAdminManage Permission = "admin:manage"
+)
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+var rolePermissions = map[Role]map[Permission]struct{}{
+	RolePlatformAdmin: permissionSet(
+		PermissionNotificationWrite, PermissionNotificationRead, PermissionTemplateRead,
+		PermissionPrivacyErase, PermissionRoleRead, PermissionRoleManage, PermissionCallbackManage,
+		PermissionAdminRead, PermissionPIIRead, PermissionTemplateReview, PermissionAdminManage,
+		PermissionTemplateWrite,
+	),
+	RoleBizAdmin: permissionSet(
+		PermissionNotificationWrite, PermissionNotificationRead, PermissionTemplateRead,
+		PermissionPrivacyErase, PermissionRoleRead, PermissionRoleManage, PermissionCallbackManage,
+		PermissionPIIRead, PermissionTemplateWrite,
+	),
+	RoleReadOnly: permissionSet(
+		PermissionNotificationRead, PermissionTemplateRead, PermissionRoleRead,
+	),
+}
+
+func permissionSet(perms ...Permission) map[Permission]struct{} {
+	set := make(map[Permission]struct{}, len(perms))
+	for _, p := range perms {
+		set[p] = struct{}{}
+	}
+	return set
+}
+
+// HasPermission 角色是否拥有权限
+func (r Role) HasPermission(p Permission) bool {
+	_, ok := rolePermissions[r][p]
+	return ok
+}
+
+// Principal 调用方身份，来自调用方凭证
+type Principal struct {
+	// Subject 调用方唯一标识
+	Subject string
+	// BizID 调用方所属业务方，平台管理员可以为 0
+	BizID int64
+	// Role 调用方在 BizID 下的角色，鉴权通过后填充
+	Role Role
+	// Environment 凭证所属的环境，沙箱凭证发送的通知不会真正投递
+	Environment Environment
+}
+
+// IsPlatformAdmin 平台管理员不受业务方限制
+func (p Principal) IsPlatformAdmin() bool {
+	return p.Role == RolePlatformAdmin
+}
+
+// CanManage 调用方能否给 bizID 分配 role
+// 业务方管理员只能在自己的业务方内分配业务方管理员和只读角色
+func (p Principal) CanManage(bizID int64, role Role) bool {
+	if p.IsPlatformAdmin() {
+		return true
+	}
+	return p.Role.HasPermission(PermissionRoleManage) && p.BizID == bizID && role != RolePlatformAdmin
+}
+
+// RoleAssignment 角色分配，平台管理员的 BizID 为 0
+type RoleAssignment struct {
+	ID        int64
+	Principal string
+	BizID     int64
+	Role      Role
+	Operator  string
+	Ctime     time.Time
+	Utime     time.Time
+}
+
+func (a RoleAssignment) Validate() error {
+	if a.Principal == "" {
+		return fmt.Errorf("%w: principal 不能为空", ErrInvalidParameter)
+	}
+	if !a.Role.IsValid() {
+		return fmt.Errorf("%w: role = %s", ErrInvalidParameter, a.Role)
+	}
+	if a.Role == RolePlatformAdmin && a.BizID != 0 {
+		return fmt.Errorf("%w: 平台管理员不属于任何业务方，biz_id 必须为 0", ErrInvalidParameter)
+	}
+	if a.Role != RolePlatformAdmin && a.BizID <= 0 {
+		return fmt.Errorf("%w: biz_id = %d", ErrInvalidParameter, a.BizID)
+	}
+	return nil
+}
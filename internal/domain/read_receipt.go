@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// ReadCallbackStatus 已读回执推送给业务方的状态
+type ReadCallbackStatus string
+
+const (
+	// ReadCallbackStatusNone 业务方没有配置已读回执地址，不推送
+	ReadCallbackStatusNone      ReadCallbackStatus = "NONE"
+	ReadCallbackStatusPending   ReadCallbackStatus = "PENDING"
+	ReadCallbackStatusSucceeded ReadCallbackStatus = "SUCCEEDED"
+	ReadCallbackStatusFailed    ReadCallbackStatus = "FAILED"
+)
+
+func (s ReadCallbackStatus) String() string {
+	return string(s)
+}
+
+// ReadReceipt 站内信已读回执，同一个接收者对同一条通知只记录第一次阅读
+type ReadReceipt struct {
+	ID             int64
+	NotificationID uint64
+	BizID          int64
+	Key            string
+	TemplateID     int64
+	Receiver       string
+	ReadTime       time.Time
+
+	CallbackStatus ReadCallbackStatus
+	RetryCount     int32
+	NextRetryTime  int64
+}
+
+// ReadStats 已读统计，Receivers 是收到通知的接收者数量，Reads 是其中已读的数量
+type ReadStats struct {
+	Receivers int64
+	Reads     int64
+}
+
+// ReadRate 已读率，没有接收者时为 0
+func (s ReadStats) ReadRate() float64 {
+	if s.Receivers == 0 {
+		return 0
+	}
+	return float64(s.Reads) / float64(s.Receivers)
+}
+
+// ReadReceiptEvent 推送给业务方的已读事件，请求体使用业务方的回调密钥签名
+type ReadReceiptEvent struct {
+	Event          string `json:"event"`
+	NotificationID uint64 `json:"notificationId"`
+	Key            string `json:"key"`
+	Receiver       string `json:"receiver"`
+	// ReadTime 毫秒时间戳
+	ReadTime int64 `json:"readTime"`
+}
+
+// ReadReceiptEventName 已读事件的名称
+const ReadReceiptEventName = "notification.read"
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetentionAction 数据超过保留期限后的处理方式
+type RetentionAction string
+
+const (
+	// RetentionActionAnonymize 清空接收者和模板参数，保留通知记录用于统计
+	RetentionActionAnonymize RetentionAction = "ANONYMIZE"
+	// RetentionActionPurge 删除通知记录以及关联的回调、发送尝试、审计记录
+	RetentionActionPurge RetentionAction = "PURGE"
+)
+
+func (a RetentionAction) String() string {
+	return string(a)
+}
+
+func (a RetentionAction) IsValid() bool {
+	return a == RetentionActionAnonymize || a == RetentionActionPurge
+}
+
+// RetentionPolicy 数据保留策略，BizID 为 0 表示所有业务，Channel 为空表示所有渠道
+// 同一条通知命中多条策略时使用最具体的那条：业务+渠道 > 业务 > 渠道 > 默认
+type RetentionPolicy struct {
+	BizID         int64
+	Channel       Channel
+	RetentionDays int
+	Action        RetentionAction
+}
+
+func (p RetentionPolicy) Validate() error {
+	if p.BizID < 0 {
+		return fmt.Errorf("%w: 保留策略 BizID = %d", ErrInvalidParameter, p.BizID)
+	}
+	if p.Channel != "" && !p.Channel.IsValid() {
+		return fmt.Errorf("%w: 保留策略 Channel = %s", ErrInvalidParameter, p.Channel)
+	}
+	if p.RetentionDays <= 0 {
+		return fmt.Errorf("%w: 保留策略 RetentionDays = %d", ErrInvalidParameter, p.RetentionDays)
+	}
+	if !p.Action.IsValid() {
+		return fmt.Errorf("%w: 保留策略 Action = %s", ErrInvalidParameter, p.Action)
+	}
+	return nil
+}
+
+// Cutoff 创建时间早于这个时间点的通知已经过期
+func (p RetentionPolicy) Cutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -p.RetentionDays)
+}
+
+// rank 策略的具体程度，越大越具体
+func (p RetentionPolicy) rank() int {
+	switch {
+	case p.BizID != 0 && p.Channel != "":
+		return 3
+	case p.BizID != 0:
+		return 2
+	case p.Channel != "":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// overlaps 两条策略覆盖的范围是否有交集
+func (p RetentionPolicy) overlaps(o RetentionPolicy) bool {
+	bizOverlap := p.BizID == 0 || o.BizID == 0 || p.BizID == o.BizID
+	channelOverlap := p.Channel == "" || o.Channel == "" || p.Channel == o.Channel
+	return bizOverlap && channelOverlap
+}
+
+// RetentionScope 一条策略实际生效的范围：策略本身覆盖的范围，去掉被更具体的策略接管的部分
+type RetentionScope struct {
+	Policy RetentionPolicy
+	// Excludes 被更具体的策略接管的范围，只用到 BizID 和 Channel
+	Excludes []RetentionPolicy
+}
+
+// BuildRetentionScopes 校验策略并计算每条策略的生效范围
+func BuildRetentionScopes(policies []RetentionPolicy) ([]RetentionScope, error) {
+	scopes := make([]RetentionScope, 0, len(policies))
+	for i, p := range policies {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+		scope := RetentionScope{Policy: p}
+		for j, o := range policies {
+			if i == j || !p.overlaps(o) {
+				continue
+			}
+			if o.BizID == p.BizID && o.Channel == p.Channel {
+				return nil, fmt.Errorf("%w: 保留策略重复 biz_id=%d channel=%s", ErrInvalidParameter, p.BizID, p.Channel)
+			}
+			if o.rank() > p.rank() {
+				scope.Excludes = append(scope.Excludes, o)
+			}
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+// ReceiverErasure 接收者数据擦除记录
+// 只保存接收者的盲索引，不保存接收者本身，否则擦除就没有意义了
+type ReceiverErasure struct {
+	ID            int64
+	BizID         int64
+	ReceiverIndex string
+	Operator      string
+	Reason        string
+	// AffectedNotifications 被擦除的通知数量
+	AffectedNotifications int64
+	Ctime                 time.Time
+}
+
+// EraseReceivers 删除匹配的接收者，接收者全部删除后一并清空模板参数，还没有发送的通知改为取消
+// 返回通知是否因此被取消，被取消的通知需要归还额度
+func (n *Notification) EraseReceivers(match func(receiver string) bool) (canceled bool) {
+	remaining := make([]string, 0, len(n.Receivers))
+	for _, receiver := range n.Receivers {
+		if !match(receiver) {
+			remaining = append(remaining, receiver)
+			continue
+		}
+		delete(n.Template.ReceiverParams, receiver)
+	}
+	n.Receivers = remaining
+	if len(remaining) > 0 {
+		return false
+	}
+	n.Template.Params = map[string]string{}
+	n.Template.ReceiverParams = nil
+	if n.Status == SendStatusPending || n.Status == SendStatusPrepare {
+		n.Status = SendStatusCanceled
+		return true
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// 平台的重试限制，业务方的重试策略只能在这个范围内调整
+const (
+	// DefaultRetryMaxAttempts 没有指定时最多发送的次数，包括第一次
+	DefaultRetryMaxAttempts int32 = 3
+	// MaxRetryMaxAttempts 业务方最多可以指定的发送次数
+	MaxRetryMaxAttempts int32 = 10
+	// DefaultRetryInitialBackoff 没有指定时第一次重试前等待的时间
+	DefaultRetryInitialBackoff = 10 * time.Second
+	// DefaultRetryMaxBackoff 没有指定时重试等待时间的上限
+	DefaultRetryMaxBackoff = 10 * time.Minute
+	// MinRetryBackoff、MaxRetryBackoff 业务方可以指定的等待时间范围
+	MinRetryBackoff = time.Second
+	MaxRetryBackoff = time.Hour
+)
+
+// RetryPolicy 通知发送失败之后的重试策略，零值字段使用平台默认值
+// 第 n 次重试前等待 InitialBackoff * 2^(n-1)，不超过 MaxBackoff
+type RetryPolicy struct {
+	// MaxAttempts 最多发送的次数，包括第一次，1 表示不重试
+	MaxAttempts    int32
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Validate 校验业务方指定的值是否在平台限制以内
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 || p.MaxAttempts > MaxRetryMaxAttempts {
+		return fmt.Errorf("%w: RetryPolicy.MaxAttempts = %d，必须在 0 到 %d 之间", ErrInvalidParameter, p.MaxAttempts, MaxRetryMaxAttempts)
+	}
+	if p.InitialBackoff != 0 && (p.InitialBackoff < MinRetryBackoff || p.InitialBackoff > MaxRetryBackoff) {
+		return fmt.Errorf("%w: RetryPolicy.InitialBackoff = %s，必须在 %s 到 %s 之间", ErrInvalidParameter, p.InitialBackoff, MinRetryBackoff, MaxRetryBackoff)
+	}
+	if p.MaxBackoff != 0 && (p.MaxBackoff < MinRetryBackoff || p.MaxBackoff > MaxRetryBackoff) {
+		return fmt.Errorf("%w: RetryPolicy.MaxBackoff = %s，必须在 %s 到 %s 之间", ErrInvalidParameter, p.MaxBackoff, MinRetryBackoff, MaxRetryBackoff)
+	}
+	if d := p.WithDefaults(); d.MaxBackoff < d.InitialBackoff {
+		return fmt.Errorf("%w: RetryPolicy.MaxBackoff = %s 小于 InitialBackoff = %s", ErrInvalidParameter, d.MaxBackoff, d.InitialBackoff)
+	}
+	return nil
+}
+
+// WithDefaults 零值字段替换成平台默认值
+func (p RetryPolicy) WithDefaults() RetryPolicy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = min(DefaultRetryInitialBackoff, max(p.MaxBackoff, MinRetryBackoff))
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = max(DefaultRetryMaxBackoff, p.InitialBackoff)
+	}
+	return p
+}
+
+// Backoff 第 attempt 次失败之后等待多久重试，attempt 从 1 开始
+func (p RetryPolicy) Backoff(attempt int32) time.Duration {
+	p = p.WithDefaults()
+	backoff := p.InitialBackoff
+	for i := int32(1); i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, p.MaxBackoff)
+}
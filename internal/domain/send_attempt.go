@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+// SendAttemptStatus 发送尝试状态
+type SendAttemptStatus string
+
+const (
+	// SendAttemptStatusDispatching 已经落库，正在调用供应商，调用超时的尝试也会停留在这个状态
+	SendAttemptStatusDispatching SendAttemptStatus = "DISPATCHING"
+	// SendAttemptStatusDispatched 供应商已经受理
+	SendAttemptStatusDispatched SendAttemptStatus = "DISPATCHED"
+	// SendAttemptStatusFailed 供应商明确返回失败
+	SendAttemptStatusFailed SendAttemptStatus = "FAILED"
+)
+
+func (s SendAttemptStatus) String() string {
+	return string(s)
+}
+
+// SendAttempt 一次调用供应商的发送尝试
+type SendAttempt struct {
+	ID             int64
+	NotificationID uint64
+	// Attempt 第几次尝试，超时重试复用同一个尝试次数，只有明确失败后的重试才会加一
+	Attempt        int
+	IdempotencyKey string
+	Status         SendAttemptStatus
+	// MessageID 供应商返回的消息ID
+	MessageID string
+	// Provider 供应商名称，用于统计成功率
+	Provider string
+	// RequestDigest 发送内容（渠道、接收者、模板和参数）的 SHA-256，和供应商核对账单时证明发送的内容
+	RequestDigest string
+	// ResponseCode 供应商返回的状态码，没有时为空
+	ResponseCode string
+	// Latency 调用供应商的耗时
+	Latency time.Duration
+	// Error 调用失败的原因
+	Error string
+	Ctime int64
+	Utime int64
+}
+
+// SendAttemptResult 调用供应商的结果，更新发送尝试状态时一并记录
+type SendAttemptResult struct {
+	MessageID string
+	// ResponseCode 供应商返回的状态码，没有时为空
+	ResponseCode string
+	Latency      time.Duration
+	// Error 调用失败的原因
+	Error string
+}
+
+// SendAttemptFilter 查询发送尝试的条件，按ID倒序，为空的条件不过滤
+// BeforeID 是上一页最后一条的ID，StartTime、EndTime 是创建时间的毫秒时间戳，[StartTime, EndTime)
+type SendAttemptFilter struct {
+	NotificationID uint64
+	Provider       string
+	MessageID      string
+	Status         SendAttemptStatus
+	StartTime      int64
+	EndTime        int64
+	BeforeID       int64
+	Limit          int
+}
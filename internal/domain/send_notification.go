@@ -14,6 +14,8 @@ const (
 	SendStrategyScheduled  SendStrategyType = "SCHEDULED"   // 定时发送
 	SendStrategyTimeWindow SendStrategyType = "TIME_WINDOW" // 时间窗口发送
 	SendStrategyDeadline   SendStrategyType = "DEADLINE"    // 截止日期发送
+	SendStrategyLocalTime  SendStrategyType = "LOCAL_TIME"  // 按接收者本地时间发送
+	SendStrategyPaced      SendStrategyType = "PACED"       // 按活动限速发送
 )
 
 // SendStrategyConfig 发送策略配置
@@ -24,20 +26,21 @@ type SendStrategyConfig struct {
 	StartTime     time.Time        `json:"startTime"`     // 窗口发送策略使用，开始时间（毫秒）
 	EndTime       time.Time        `json:"endTime"`       // 窗口发送策略使用，结束时间（毫秒）
 	DeadlineTime  time.Time        `json:"deadlineTime"`  // 截止日期策略使用，截止日期
+	// LocalTime 按本地时间发送策略使用，按接收者的时区拆分成多条时间窗口发送的通知
+	LocalTime *LocalTimeSend `json:"localTime,omitempty"`
+	// Pacing 限速发送策略使用，创建时按活动的进度分配发送时间
+	Pacing *Pacing `json:"pacing,omitempty"`
 }
 
-// SendTimeWindow 计算最早发送时间和最晚发送时间
-func (e SendStrategyConfig) SendTimeWindow() (stime, etime time.Time) {
+// SendTimeWindow 以 now 为当前时间计算最早发送时间和最晚发送时间
+func (e SendStrategyConfig) SendTimeWindow(now time.Time) (stime, etime time.Time) {
 	switch e.Type {
 	case SendStrategyImmediate:
-		now := time.Now()
 		const defaultEndDuration = 30 * time.Minute
 		return now, now.Add(defaultEndDuration)
 	case SendStrategyDelayed:
-		now := time.Now()
 		return now, now.Add(e.Delay)
 	case SendStrategyDeadline:
-		now := time.Now()
 		return now, e.DeadlineTime
 	case SendStrategyTimeWindow:
 		return e.StartTime, e.EndTime
@@ -45,14 +48,17 @@ func (e SendStrategyConfig) SendTimeWindow() (stime, etime time.Time) {
 		// 无法精确控制，所以允许一些误差
 		const scheduledTimeTolerance = 3 * time.Second
 		return e.ScheduledTime.Add(-scheduledTimeTolerance), e.ScheduledTime
+	case SendStrategyPaced:
+		// 创建时会按活动的进度重新分配
+		return now, now.Add(PacingSlotWindow)
 	default:
 		// 假定一定检测过了，所以这里随便返回一个就可以
-		now := time.Now()
 		return now, now
 	}
 }
 
-func (e SendStrategyConfig) Validate() error {
+// Validate 以 now 为当前时间校验策略，定时发送和截止日期必须晚于 now
+func (e SendStrategyConfig) Validate(now time.Time) error {
 	// 校验策略相关字段
 	switch e.Type {
 	case SendStrategyImmediate:
@@ -62,7 +68,7 @@ func (e SendStrategyConfig) Validate() error {
 			return fmt.Errorf("%w: 延迟发送策略需要指定正数的延迟秒数", ErrInvalidParameter)
 		}
 	case SendStrategyScheduled:
-		if e.ScheduledTime.IsZero() || e.ScheduledTime.Before(time.Now()) {
+		if e.ScheduledTime.IsZero() || e.ScheduledTime.Before(now) {
 			return fmt.Errorf("%w: 定时发送策略需要指定未来的发送时间", ErrInvalidParameter)
 		}
 	case SendStrategyTimeWindow:
@@ -70,9 +76,19 @@ func (e SendStrategyConfig) Validate() error {
 			return fmt.Errorf("%w: 时间窗口发送策略需要指定有效的开始和结束时间", ErrInvalidParameter)
 		}
 	case SendStrategyDeadline:
-		if e.DeadlineTime.IsZero() || e.DeadlineTime.Before(time.Now()) {
+		if e.DeadlineTime.IsZero() || e.DeadlineTime.Before(now) {
 			return fmt.Errorf("%w: 截止日期发送策略需要指定未来的发送时间", ErrInvalidParameter)
 		}
+	case SendStrategyLocalTime:
+		if e.LocalTime == nil {
+			return fmt.Errorf("%w: 按本地时间发送策略需要指定本地时间", ErrInvalidParameter)
+		}
+		return e.LocalTime.Validate()
+	case SendStrategyPaced:
+		if e.Pacing == nil {
+			return fmt.Errorf("%w: 限速发送策略需要指定活动和每分钟发送数量", ErrInvalidParameter)
+		}
+		return e.Pacing.Validate()
 	}
 	return nil
 }
@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// SLOKind 服务等级目标的种类
+type SLOKind string
+
+const (
+	// SLOKindSend 生产环境通知最终发送成功的比例，按业务方统计
+	SLOKindSend SLOKind = "send"
+	// SLOKindProvider 供应商受理发送尝试的比例，按供应商统计
+	SLOKindProvider SLOKind = "provider"
+	// SLOKindCallback 回调业务方成功的比例，整体统计
+	SLOKindCallback SLOKind = "callback"
+)
+
+func (k SLOKind) String() string {
+	return string(k)
+}
+
+// SLOObjective 一种服务等级目标，Target 是好事件占比的目标，例如 0.99
+type SLOObjective struct {
+	Kind   SLOKind
+	Target float64
+}
+
+// SLOCount 一个统计对象在时间窗口内的好事件和总事件数量，Key 是业务方ID、供应商名称等
+type SLOCount struct {
+	Key   string
+	Good  int64
+	Total int64
+}
+
+// SLOReport 一个统计对象在一个时间窗口内的达标情况
+type SLOReport struct {
+	Kind   SLOKind
+	Key    string
+	Window time.Duration
+	Target float64
+	Good   int64
+	Total  int64
+}
+
+// Compliance 好事件占比，窗口内没有事件时是 1
+func (r SLOReport) Compliance() float64 {
+	if r.Total <= 0 {
+		return 1
+	}
+	return float64(r.Good) / float64(r.Total)
+}
+
+// BurnRate 错误预算的消耗速度，坏事件占比除以允许的坏事件占比
+// 1 表示按这个速度正好在整个 SLO 周期内用完错误预算，窗口内没有事件时是 0
+func (r SLOReport) BurnRate() float64 {
+	budget := 1 - r.Target
+	if r.Total <= 0 || budget <= 0 {
+		return 0
+	}
+	return (1 - r.Compliance()) / budget
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxStatsRange 统计查询最长的时间范围
+const MaxStatsRange = 92 * 24 * time.Hour
+
+// StatsQuery 统计查询条件，时间范围 [Start, End)，Channel 为空不过滤
+// Environment 为空查询生产环境，沙箱环境只有通知统计，没有供应商统计
+type StatsQuery struct {
+	BizID       int64
+	Start       time.Time
+	End         time.Time
+	Channel     Channel
+	Environment Environment
+}
+
+func (q StatsQuery) Validate() error {
+	if q.BizID <= 0 {
+		return fmt.Errorf("%w: BizID = %d", ErrInvalidParameter, q.BizID)
+	}
+	if !q.Start.Before(q.End) {
+		return fmt.Errorf("%w: 开始时间必须早于结束时间", ErrInvalidParameter)
+	}
+	if q.End.Sub(q.Start) > MaxStatsRange {
+		return fmt.Errorf("%w: 时间范围不能超过 %d 天", ErrInvalidParameter, int(MaxStatsRange/(24*time.Hour)))
+	}
+	if q.Channel != "" && !q.Channel.IsValid() {
+		return fmt.Errorf("%w: Channel = %q", ErrInvalidParameter, q.Channel)
+	}
+	return nil
+}
+
+// SendStat 一个小时内创建的通知按渠道、状态、模板统计的数量
+type SendStat struct {
+	BizID      int64
+	Hour       time.Time
+	Channel    Channel
+	Status     SendStatus
+	TemplateID int64
+	Count      int64
+}
+
+// ProviderStat 一个小时内发送尝试按供应商统计的结果
+type ProviderStat struct {
+	BizID      int64
+	Hour       time.Time
+	Channel    Channel
+	Provider   string
+	Dispatched int64
+	Failed     int64
+}
+
+// DailySendStat 按天统计的通知数量，Date 格式 2006-01-02
+type DailySendStat struct {
+	Date    string
+	Channel Channel
+	Status  SendStatus
+	Count   int64
+}
+
+// HourlySendStat 按小时统计的通知数量
+type HourlySendStat struct {
+	Hour    time.Time
+	Channel Channel
+	Status  SendStatus
+	Count   int64
+}
+
+// TemplateFailureStat 模板的失败统计
+type TemplateFailureStat struct {
+	TemplateID int64
+	Total      int64
+	Failed     int64
+}
+
+// FailureRate 失败率，没有通知时为 0
+func (s TemplateFailureStat) FailureRate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Failed) / float64(s.Total)
+}
+
+// ProviderSuccessRate 供应商在一个渠道上的成功率
+type ProviderSuccessRate struct {
+	Provider   string
+	Channel    Channel
+	Dispatched int64
+	Failed     int64
+}
+
+// SuccessRate 成功率，没有发送尝试时为 0
+func (r ProviderSuccessRate) SuccessRate() float64 {
+	total := r.Dispatched + r.Failed
+	if total == 0 {
+		return 0
+	}
+	return float64(r.Dispatched) / float64(total)
+}
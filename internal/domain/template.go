@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ChannelTemplate 渠道模板
+type ChannelTemplate struct {
+	ID          int64   // 模板ID
+	OwnerID     int64   // 拥有者，即业务方的 BizID
+	Name        string  // 模板名称
+	Description string  // 模板描述
+	Channel     Channel // 渠道
+
+	ActiveVersionID int64                    // 当前生效的版本ID，0 表示没有生效的版本
+	Versions        []ChannelTemplateVersion // 所有版本
+
+	Ctime int64
+	Utime int64
+}
+
+// ActiveVersion 返回当前生效的版本
+func (t ChannelTemplate) ActiveVersion() (ChannelTemplateVersion, error) {
+	if t.ActiveVersionID == 0 {
+		return ChannelTemplateVersion{}, fmt.Errorf("%w: 模板 %d 没有生效的版本", ErrTemplateVersionNotFound, t.ID)
+	}
+	for i := range t.Versions {
+		if t.Versions[i].ID == t.ActiveVersionID {
+			return t.Versions[i], nil
+		}
+	}
+	return ChannelTemplateVersion{}, fmt.Errorf("%w: 模板 %d 版本 %d", ErrTemplateVersionNotFound, t.ID, t.ActiveVersionID)
+}
+
+// ChannelTemplateVersion 渠道模板版本
+type ChannelTemplateVersion struct {
+	ID                int64  // 版本ID
+	ChannelTemplateID int64  // 模板ID
+	Name              string // 版本名称
+	Signature         string // 签名，短信使用
+	Content           string // 模板内容
+	Remark            string // 备注
+	// ParamSchema 模板参数定义，为空时不校验参数
+	ParamSchema TemplateParamSchema
+	// AuditStatus 平台内部审核状态
+	AuditStatus  AuditStatus
+	RejectReason string
+	// Reviewer 内部审核人，ReviewTime 内部审核时间（毫秒时间戳），还没有审核时为零值
+	Reviewer   string
+	ReviewTime int64
+	// ForkedFromVersionID 回滚时从哪个版本拷贝，0 表示不是回滚创建的
+	ForkedFromVersionID int64
+	// Providers 需要供应商审核的渠道（短信）在内部审核通过后，每个供应商一条审核记录
+	Providers []ChannelTemplateProvider
+
+	Ctime int64
+	Utime int64
+}
+
+// templateVariable 模板内容里的变量 ${name}
+var templateVariable = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+
+// ApprovedProviders 审核通过的供应商，没有供应商审核记录时返回 nil，表示不限制供应商
+func (v ChannelTemplateVersion) ApprovedProviders() []string {
+	if len(v.Providers) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(v.Providers))
+	for _, p := range v.Providers {
+		if p.AuditStatus == AuditStatusApproved {
+			names = append(names, p.ProviderName)
+		}
+	}
+	return names
+}
+
+// CheckApproved 发送前校验版本是否审核通过：内部审核必须通过，有供应商审核记录时至少一个供应商审核通过
+func (v ChannelTemplateVersion) CheckApproved() error {
+	if v.AuditStatus != AuditStatusApproved {
+		return fmt.Errorf("%w: 版本 %d 审核状态 %s", ErrTemplateVersionNotApprovedByPlatform, v.ID, v.AuditStatus)
+	}
+	if len(v.Providers) == 0 {
+		return nil
+	}
+	for _, p := range v.Providers {
+		if p.AuditStatus == AuditStatusApproved {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: 版本 %d", ErrTemplateVersionNotApprovedByProvider, v.ID)
+}
+
+// FindVersion 按ID查找版本
+func (t ChannelTemplate) FindVersion(versionID int64) (ChannelTemplateVersion, error) {
+	for i := range t.Versions {
+		if t.Versions[i].ID == versionID {
+			return t.Versions[i], nil
+		}
+	}
+	return ChannelTemplateVersion{}, fmt.Errorf("%w: 模板 %d 版本 %d", ErrTemplateVersionNotFound, t.ID, versionID)
+}
+
+// Fork 回滚到 versionID：拷贝这个版本作为新的版本，审核结果一起拷贝，不需要重新审核
+// 只能回滚到当前生效版本以外、审核通过可以发送的版本，供应商审核记录只拷贝审核通过的
+func (t ChannelTemplate) Fork(versionID int64) (ChannelTemplateVersion, error) {
+	source, err := t.FindVersion(versionID)
+	if err != nil {
+		return ChannelTemplateVersion{}, err
+	}
+	if source.ID == t.ActiveVersionID {
+		return ChannelTemplateVersion{}, fmt.Errorf("%w: 版本 %d 已经是生效版本", ErrInvalidParameter, versionID)
+	}
+	if err = source.CheckApproved(); err != nil {
+		return ChannelTemplateVersion{}, err
+	}
+	fork := source
+	fork.ID = 0
+	fork.ForkedFromVersionID = source.ID
+	fork.Providers = make([]ChannelTemplateProvider, 0, len(source.Providers))
+	for _, p := range source.Providers {
+		if p.AuditStatus != AuditStatusApproved {
+			continue
+		}
+		p.ID = 0
+		p.TemplateVersionID = 0
+		p.RetryCount = 0
+		p.NextCheckTime = 0
+		fork.Providers = append(fork.Providers, p)
+	}
+	fork.Ctime, fork.Utime = 0, 0
+	return fork, nil
+}
+
+// AuditStatus 审核状态
+type AuditStatus string
+
+const (
+	// AuditStatusPending 待审核，供应商审核记录处于这个状态时表示还没有提交给供应商
+	AuditStatusPending  AuditStatus = "PENDING"
+	AuditStatusInReview AuditStatus = "IN_REVIEW"
+	AuditStatusApproved AuditStatus = "APPROVED"
+	AuditStatusRejected AuditStatus = "REJECTED"
+)
+
+func (s AuditStatus) String() string {
+	return string(s)
+}
+
+// ChannelTemplateProvider 模板版本在某个供应商的审核记录
+type ChannelTemplateProvider struct {
+	ID                int64
+	TemplateID        int64
+	TemplateVersionID int64
+	ProviderName      string
+	// ProviderTemplateID 供应商返回的模板ID（阿里云的 TemplateCode、腾讯云的 TemplateId），提交之后才有
+	ProviderTemplateID string
+	AuditStatus        AuditStatus
+	RejectReason       string
+	// RetryCount 连续提交或者查询失败的次数
+	RetryCount int32
+	// NextCheckTime 下一次提交或者查询审核结果的时间，毫秒时间戳
+	NextCheckTime int64
+
+	Ctime int64
+	Utime int64
+}
+
+const (
+	// 审核结果通知模板的参数
+	TemplateReviewParamTemplateName = "template_name"
+	TemplateReviewParamVersionName  = "version_name"
+	TemplateReviewParamProvider     = "provider"
+	TemplateReviewParamStatus       = "status"
+	TemplateReviewParamReason       = "reason"
+)
+
+// TemplateReviewNotice 供应商审核有结果之后，平台用自己的业务方和模板通知模板所属的业务方
+type TemplateReviewNotice struct {
+	BizID      int64
+	Channel    Channel
+	TemplateID int64
+	// Receivers 模板所属的业务方ID → 接收者，没有配置的业务方不通知
+	Receivers map[int64][]string
+}
+
+// Notification 审核结果通知，同一条审核记录的同一个结果只通知一次，模板版本、ID 和发送时间由调用方补齐
+func (n TemplateReviewNotice) Notification(template ChannelTemplate, version ChannelTemplateVersion,
+	record ChannelTemplateProvider,
+) (Notification, bool) {
+	receivers := n.Receivers[template.OwnerID]
+	if len(receivers) == 0 {
+		return Notification{}, false
+	}
+	return Notification{
+		BizID:     n.BizID,
+		Key:       fmt.Sprintf("template-review:%d:%s", record.ID, record.AuditStatus),
+		Receivers: receivers,
+		Channel:   n.Channel,
+		Template: Template{
+			ID: n.TemplateID,
+			Params: map[string]string{
+				TemplateReviewParamTemplateName: template.Name,
+				TemplateReviewParamVersionName:  version.Name,
+				TemplateReviewParamProvider:     record.ProviderName,
+				TemplateReviewParamStatus:       record.AuditStatus.String(),
+				TemplateReviewParamReason:       record.RejectReason,
+			},
+		},
+		SendStrategyConfig: SendStrategyConfig{Type: SendStrategyImmediate},
+	}, true
+}
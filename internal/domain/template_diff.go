@@ -0,0 +1,158 @@
+package domain
+
+import "strings"
+
+// maxDiffCells 逐行比较内容时 LCS 表的大小上限，超过时中间不同的部分整体按删除再插入处理
+const maxDiffCells = 1 << 22
+
+// DiffOp 内容差异的一行是保留、删除还是插入
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "EQUAL"
+	DiffOpDelete DiffOp = "DELETE"
+	DiffOpInsert DiffOp = "INSERT"
+)
+
+// DiffLine 内容差异的一行
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// TemplateFieldChange 版本名称、签名、备注这些单值字段的变化
+type TemplateFieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// TemplateParamChange 参数定义的变化，新增时 From 为 nil，删除时 To 为 nil
+type TemplateParamChange struct {
+	Name string
+	From *TemplateParam
+	To   *TemplateParam
+}
+
+// TemplateVersionDiff 从 From 版本到 To 版本的差异
+type TemplateVersionDiff struct {
+	From   ChannelTemplateVersion
+	To     ChannelTemplateVersion
+	Fields []TemplateFieldChange
+	// Content 逐行比较内容的结果，内容相同时为空
+	Content []DiffLine
+	Params  []TemplateParamChange
+}
+
+// DiffVersions 比较同一个模板的两个版本
+func DiffVersions(from, to ChannelTemplateVersion) TemplateVersionDiff {
+	diff := TemplateVersionDiff{From: from, To: to}
+	for _, f := range []TemplateFieldChange{
+		{Field: "name", From: from.Name, To: to.Name},
+		{Field: "signature", From: from.Signature, To: to.Signature},
+		{Field: "remark", From: from.Remark, To: to.Remark},
+	} {
+		if f.From != f.To {
+			diff.Fields = append(diff.Fields, f)
+		}
+	}
+	if from.Content != to.Content {
+		diff.Content = diffLines(strings.Split(from.Content, "\n"), strings.Split(to.Content, "\n"))
+	}
+	diff.Params = diffParams(from.ParamSchema, to.ParamSchema)
+	return diff
+}
+
+// diffParams 按 From 的顺序列出删除和修改的参数，再按 To 的顺序列出新增的参数
+func diffParams(from, to TemplateParamSchema) []TemplateParamChange {
+	toByName := make(map[string]TemplateParam, len(to))
+	for _, p := range to {
+		toByName[p.Name] = p
+	}
+	fromNames := make(map[string]struct{}, len(from))
+	var changes []TemplateParamChange
+	for _, p := range from {
+		fromNames[p.Name] = struct{}{}
+		q, ok := toByName[p.Name]
+		switch {
+		case !ok:
+			changes = append(changes, TemplateParamChange{Name: p.Name, From: &p})
+		case p != q:
+			changes = append(changes, TemplateParamChange{Name: p.Name, From: &p, To: &q})
+		}
+	}
+	for _, q := range to {
+		if _, ok := fromNames[q.Name]; !ok {
+			changes = append(changes, TemplateParamChange{Name: q.Name, To: &q})
+		}
+	}
+	return changes
+}
+
+// diffLines 去掉相同的开头和结尾之后按最长公共子序列比较
+func diffLines(a, b []string) []DiffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	res := make([]DiffLine, 0, len(a)+len(b)-prefix-suffix)
+	for _, line := range a[:prefix] {
+		res = append(res, DiffLine{Op: DiffOpEqual, Text: line})
+	}
+	res = append(res, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		res = append(res, DiffLine{Op: DiffOpEqual, Text: line})
+	}
+	return res
+}
+
+func diffMiddle(a, b []string) []DiffLine {
+	res := make([]DiffLine, 0, len(a)+len(b))
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, line := range a {
+			res = append(res, DiffLine{Op: DiffOpDelete, Text: line})
+		}
+		for _, line := range b {
+			res = append(res, DiffLine{Op: DiffOpInsert, Text: line})
+		}
+		return res
+	}
+	// lcs[i][j] 是 a[i:] 和 b[j:] 的最长公共子序列长度
+	width := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*width)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			res = append(res, DiffLine{Op: DiffOpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			res = append(res, DiffLine{Op: DiffOpDelete, Text: a[i]})
+			i++
+		default:
+			res = append(res, DiffLine{Op: DiffOpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		res = append(res, DiffLine{Op: DiffOpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		res = append(res, DiffLine{Op: DiffOpInsert, Text: b[j]})
+	}
+	return res
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TemplateParamType 模板参数类型
+type TemplateParamType string
+
+const (
+	TemplateParamTypeString   TemplateParamType = "string"
+	TemplateParamTypeNumber   TemplateParamType = "number"
+	TemplateParamTypeCurrency TemplateParamType = "currency"
+	TemplateParamTypeDate     TemplateParamType = "date"
+	// TemplateParamTypeList JSON 数组，只有 text/template 模板可以使用，在模板里 range
+	TemplateParamTypeList TemplateParamType = "list"
+)
+
+// DefaultTemplateParamDateLayout 日期参数默认格式
+const DefaultTemplateParamDateLayout = time.DateOnly
+
+// currencyPattern 金额，最多两位小数
+var currencyPattern = regexp.MustCompile(`^-?\d+(\.\d{1,2})?$`)
+
+func (t TemplateParamType) String() string {
+	return string(t)
+}
+
+func (t TemplateParamType) IsValid() bool {
+	switch t {
+	case TemplateParamTypeString, TemplateParamTypeNumber, TemplateParamTypeCurrency, TemplateParamTypeDate,
+		TemplateParamTypeList:
+		return true
+	default:
+		return false
+	}
+}
+
+// TemplateParam 模板参数定义
+type TemplateParam struct {
+	Name        string            `json:"name"`
+	Type        TemplateParamType `json:"type"`
+	Required    bool              `json:"required"`
+	Description string            `json:"description,omitempty"`
+	// Format 日期参数的格式，Go 的时间格式，为空时使用 DefaultTemplateParamDateLayout
+	Format string `json:"format,omitempty"`
+}
+
+func (p TemplateParam) dateLayout() string {
+	if p.Format != "" {
+		return p.Format
+	}
+	return DefaultTemplateParamDateLayout
+}
+
+// check 校验参数值，返回不合法的原因
+func (p TemplateParam) check(value string) string {
+	switch p.Type {
+	case TemplateParamTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("期望 number 类型, 实际值 %q", value)
+		}
+	case TemplateParamTypeCurrency:
+		if !currencyPattern.MatchString(value) {
+			return fmt.Sprintf("期望 currency 类型（最多两位小数）, 实际值 %q", value)
+		}
+	case TemplateParamTypeDate:
+		if _, err := time.Parse(p.dateLayout(), value); err != nil {
+			return fmt.Sprintf("期望 date 类型（格式 %s）, 实际值 %q", p.dateLayout(), value)
+		}
+	case TemplateParamTypeList:
+		if _, err := parseListParam(value); err != nil {
+			return fmt.Sprintf("期望 list 类型（JSON 数组）, 实际值 %q", value)
+		}
+	}
+	return ""
+}
+
+// TemplateParamSchema 模板参数定义列表
+type TemplateParamSchema []TemplateParam
+
+// Validate 校验参数定义本身是否合法
+func (s TemplateParamSchema) Validate() error {
+	names := make(map[string]struct{}, len(s))
+	for _, p := range s {
+		if p.Name == "" {
+			return fmt.Errorf("%w: 模板参数名不能为空", ErrInvalidParameter)
+		}
+		if !p.Type.IsValid() {
+			return fmt.Errorf("%w: 模板参数 %s 类型 %q 不支持", ErrInvalidParameter, p.Name, p.Type)
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("%w: 模板参数 %s 重复定义", ErrInvalidParameter, p.Name)
+		}
+		names[p.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Check 按照参数定义校验发送时传入的参数，返回所有不合法的参数
+func (s TemplateParamSchema) Check(params map[string]string) error {
+	if len(s) == 0 {
+		return nil
+	}
+	var violations []TemplateParamViolation
+	defined := make(map[string]struct{}, len(s))
+	for _, p := range s {
+		defined[p.Name] = struct{}{}
+		value, ok := params[p.Name]
+		if !ok || value == "" {
+			if p.Required {
+				violations = append(violations, TemplateParamViolation{Name: p.Name, Reason: "缺少必填参数"})
+			}
+			continue
+		}
+		if reason := p.check(value); reason != "" {
+			violations = append(violations, TemplateParamViolation{Name: p.Name, Reason: reason})
+		}
+	}
+	unknown := make([]string, 0)
+	for name := range params {
+		if _, ok := defined[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		violations = append(violations, TemplateParamViolation{Name: name, Reason: "模板未定义该参数"})
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &TemplateParamError{Violations: violations}
+}
+
+// TemplateParamViolation 单个参数的校验失败原因
+type TemplateParamViolation struct {
+	Name   string
+	Reason string
+}
+
+// TemplateParamError 模板参数校验失败，errors.Is(err, ErrInvalidParameter) 为 true
+type TemplateParamError struct {
+	Violations []TemplateParamViolation
+}
+
+func (e *TemplateParamError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", v.Name, v.Reason))
+	}
+	return fmt.Sprintf("%s: 模板参数校验失败: %s", ErrInvalidParameter.Error(), strings.Join(reasons, "; "))
+}
+
+func (e *TemplateParamError) Unwrap() error {
+	return ErrInvalidParameter
+}
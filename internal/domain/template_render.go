@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+)
+
+// RenderBudget 渲染 Go text/template 模板的资源限制，模板内容由业务方编写，不能信任
+type RenderBudget struct {
+	// MaxOutputBytes 渲染结果的最大字节数
+	MaxOutputBytes int
+	// MaxListItems list 参数最多多少个元素
+	MaxListItems int
+	// MaxRangeDepth range 最多嵌套几层
+	MaxRangeDepth int
+	// Timeout 渲染最长时间
+	Timeout time.Duration
+}
+
+// DefaultRenderBudget 默认的渲染限制
+var DefaultRenderBudget = RenderBudget{
+	MaxOutputBytes: 64 << 10,
+	MaxListItems:   100,
+	MaxRangeDepth:  2,
+	Timeout:        100 * time.Millisecond,
+}
+
+var errRenderBudgetExceeded = errors.New("超出渲染限制")
+
+// templateFuncs 模板可以使用的辅助函数，除了 text/template 内置的函数之外只有这些
+var templateFuncs = template.FuncMap{
+	"currency": formatCurrency,
+	"date":     formatDate,
+	"default": func(def string, value any) any {
+		if s, ok := value.(string); (ok && s == "") || value == nil {
+			return def
+		}
+		return value
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"join": func(sep string, items []any) string {
+		s := make([]string, 0, len(items))
+		for _, item := range items {
+			s = append(s, fmt.Sprint(item))
+		}
+		return strings.Join(s, sep)
+	},
+	"truncate": func(n int, s string) string {
+		if utf8.RuneCountInString(s) <= n {
+			return s
+		}
+		return string([]rune(s)[:n]) + "…"
+	},
+}
+
+// UsesTextTemplate 模板内容包含 {{ 时按 Go text/template 渲染，否则只替换 ${name}
+func (v ChannelTemplateVersion) UsesTextTemplate() bool {
+	return strings.Contains(v.Content, "{{")
+}
+
+// CheckSyntax 校验 text/template 模板的语法，并且不能使用 define、template、block，range 的嵌套层数不能超过限制
+func (v ChannelTemplateVersion) CheckSyntax() error {
+	if !v.UsesTextTemplate() {
+		return nil
+	}
+	_, err := parseTemplate(v.Content, DefaultRenderBudget)
+	return err
+}
+
+// Render 渲染模板内容
+// text/template 模板：参数按名称引用，例如 {{.name}}，list 参数可以 range，出错时返回 ErrTemplateRenderFailed
+// 其他模板：用参数替换 ${name}，没有传的参数保留原样
+func (v ChannelTemplateVersion) Render(params map[string]string) (string, error) {
+	if !v.UsesTextTemplate() {
+		return templateVariable.ReplaceAllStringFunc(v.Content, func(m string) string {
+			if value, ok := params[templateVariable.FindStringSubmatch(m)[1]]; ok {
+				return value
+			}
+			return m
+		}), nil
+	}
+	return renderTemplate(v.Content, v.ParamSchema, params, DefaultRenderBudget)
+}
+
+func parseTemplate(content string, budget RenderBudget) (*template.Template, error) {
+	t, err := template.New("content").Option("missingkey=zero").Funcs(templateFuncs).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 模板语法错误: %w", ErrInvalidParameter, err)
+	}
+	if len(t.Templates()) > 1 {
+		return nil, fmt.Errorf("%w: 模板不能使用 define、block", ErrInvalidParameter)
+	}
+	if err = checkNodes(t.Tree.Root, 0, budget.MaxRangeDepth); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// checkNodes 遍历语法树，禁止 template 调用，限制 range 的嵌套层数，不能 range 数字
+func checkNodes(node parse.Node, depth, maxDepth int) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkNodes(c, depth, maxDepth); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("%w: 模板不能使用 template", ErrInvalidParameter)
+	case *parse.RangeNode:
+		if depth+1 > maxDepth {
+			return fmt.Errorf("%w: range 最多嵌套 %d 层", ErrInvalidParameter, maxDepth)
+		}
+		for _, cmd := range n.Pipe.Cmds {
+			for _, arg := range cmd.Args {
+				if _, ok := arg.(*parse.NumberNode); ok {
+					return fmt.Errorf("%w: range 只能遍历模板参数", ErrInvalidParameter)
+				}
+			}
+		}
+		if err := checkNodes(n.List, depth+1, maxDepth); err != nil {
+			return err
+		}
+		return checkNodes(n.ElseList, depth, maxDepth)
+	case *parse.IfNode:
+		if err := checkNodes(n.List, depth, maxDepth); err != nil {
+			return err
+		}
+		return checkNodes(n.ElseList, depth, maxDepth)
+	case *parse.WithNode:
+		if err := checkNodes(n.List, depth, maxDepth); err != nil {
+			return err
+		}
+		return checkNodes(n.ElseList, depth, maxDepth)
+	}
+	return nil
+}
+
+func renderTemplate(content string, schema TemplateParamSchema, params map[string]string, budget RenderBudget) (string, error) {
+	t, err := parseTemplate(content, budget)
+	if err != nil {
+		return "", err
+	}
+	data, err := templateData(schema, params, budget)
+	if err != nil {
+		return "", err
+	}
+	// 输出超过限制或者超时之后写入失败，模板停止执行；range 只能遍历参数，次数受嵌套层数和 list 长度限制
+	w := &budgetWriter{max: budget.MaxOutputBytes, deadline: time.Now().Add(budget.Timeout)}
+	if err = t.Execute(w, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrTemplateRenderFailed, err)
+	}
+	return w.buf.String(), nil
+}
+
+// templateData list 参数解析成切片，其他参数保持字符串
+func templateData(schema TemplateParamSchema, params map[string]string, budget RenderBudget) (map[string]any, error) {
+	data := make(map[string]any, len(params))
+	for name, value := range params {
+		data[name] = value
+	}
+	for _, p := range schema {
+		value, ok := params[p.Name]
+		if p.Type != TemplateParamTypeList || !ok || value == "" {
+			continue
+		}
+		items, err := parseListParam(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: 模板参数 %s: %w", ErrInvalidParameter, p.Name, err)
+		}
+		if len(items) > budget.MaxListItems {
+			return nil, fmt.Errorf("%w: 模板参数 %s 最多 %d 个元素", ErrInvalidParameter, p.Name, budget.MaxListItems)
+		}
+		data[p.Name] = items
+	}
+	return data, nil
+}
+
+// parseListParam list 参数是 JSON 数组，元素是字符串、数字或者对象
+func parseListParam(value string) ([]any, error) {
+	var items []any
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return nil, fmt.Errorf("期望 JSON 数组: %w", err)
+	}
+	return items, nil
+}
+
+type budgetWriter struct {
+	buf      bytes.Buffer
+	max      int
+	deadline time.Time
+}
+
+func (w *budgetWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.max || time.Now().After(w.deadline) {
+		return 0, errRenderBudgetExceeded
+	}
+	return w.buf.Write(p)
+}
+
+// formatCurrency 金额加千分位、保留两位小数，可以带货币符号，例如 {{currency .amount "¥"}}
+func formatCurrency(value any, symbol ...string) (string, error) {
+	f, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+	if err != nil {
+		return "", fmt.Errorf("金额格式错误: %v", value)
+	}
+	s := strconv.FormatFloat(f, 'f', 2, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return sign + strings.Join(symbol, "") + b.String() + "." + frac, nil
+}
+
+// dateLayouts date 函数可以识别的日期参数格式
+var dateLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+// formatDate 按 Go 时间格式重新格式化日期参数，例如 {{date "2006年1月2日" .due}}
+func formatDate(layout string, value any) (string, error) {
+	s := fmt.Sprint(value)
+	for _, l := range dateLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t.Format(layout), nil
+		}
+	}
+	return "", fmt.Errorf("日期格式错误: %q", s)
+}
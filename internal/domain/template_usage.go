@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultTemplateUnusedDays 默认超过多少天没有用过算作没有用过的模板
+	DefaultTemplateUnusedDays = 90
+	// MaxTemplateUnusedDays 最多按多少天查询没有用过的模板
+	MaxTemplateUnusedDays = 3650
+)
+
+// TemplateUsage 模板使用情况，由统计任务定时汇总生产环境的通知，沙箱通知不计入
+type TemplateUsage struct {
+	TemplateID int64
+	// SendCount 累计创建的通知数量
+	SendCount int64
+	// LastUsedTime 最后一次创建通知的时间，毫秒时间戳，0 表示没有用过
+	LastUsedTime int64
+}
+
+// UnusedTemplate 超过一定天数没有用过的模板
+type UnusedTemplate struct {
+	Template ChannelTemplate
+	Usage    TemplateUsage
+}
+
+// UnusedTemplateQuery 查询业务方超过 UnusedDays 天没有用过的模板，从没用过的模板按创建时间计算
+// 按模板ID升序翻页，AfterID 为上一页最后一个模板ID
+type UnusedTemplateQuery struct {
+	BizID      int64
+	UnusedDays int
+	AfterID    int64
+	Limit      int
+}
+
+func (q UnusedTemplateQuery) Validate() error {
+	if q.BizID <= 0 {
+		return fmt.Errorf("%w: BizID = %d", ErrInvalidParameter, q.BizID)
+	}
+	if q.UnusedDays <= 0 || q.UnusedDays > MaxTemplateUnusedDays {
+		return fmt.Errorf("%w: 天数必须在 1 到 %d 之间", ErrInvalidParameter, MaxTemplateUnusedDays)
+	}
+	if q.AfterID < 0 {
+		return fmt.Errorf("%w: AfterID = %d", ErrInvalidParameter, q.AfterID)
+	}
+	return nil
+}
+
+// Before 在这个时间之后没有用过的模板算作没有用过
+func (q UnusedTemplateQuery) Before(now time.Time) time.Time {
+	return now.AddDate(0, 0, -q.UnusedDays)
+}
@@ -0,0 +1,31 @@
+package domain
+
+// VendorBalanceUnit 供应商余额的单位
+type VendorBalanceUnit string
+
+const (
+	// VendorBalanceUnitCent 按量付费的账户余额，单位是分
+	VendorBalanceUnitCent VendorBalanceUnit = "CENT"
+	// VendorBalanceUnitMessage 套餐包的剩余条数
+	VendorBalanceUnitMessage VendorBalanceUnit = "MESSAGE"
+)
+
+func (u VendorBalanceUnit) String() string {
+	return string(u)
+}
+
+// VendorBalance 供应商账户余额的一次快照
+type VendorBalance struct {
+	ID        int64
+	Provider  string
+	Unit      VendorBalanceUnit
+	Remaining int64
+	// Total 套餐包的总条数，账户余额没有总量，为 0
+	Total int64
+	Ctime int64
+}
+
+// Below 余额是否低于告警阈值，阈值的单位和 Unit 一致，不大于 0 时不告警
+func (b VendorBalance) Below(threshold int64) bool {
+	return threshold > 0 && b.Remaining < threshold
+}
@@ -0,0 +1,60 @@
+package domain
+
+// VendorErrorCategory 供应商失败原因的统一分类，不同供应商的错误码映射到同一个分类
+// 分类同时作为通知的失败原因，出现在查询结果和回调里
+type VendorErrorCategory string
+
+const (
+	// VendorErrorBlacklisted 接收者在供应商的黑名单里或者已经退订
+	VendorErrorBlacklisted VendorErrorCategory = "VENDOR_BLACKLISTED"
+	// VendorErrorInvalidReceiver 手机号、邮箱格式不对或者不存在
+	VendorErrorInvalidReceiver VendorErrorCategory = "VENDOR_INVALID_RECEIVER"
+	// VendorErrorTemplateMismatch 模板、签名不存在或者没有审核通过，参数和模板不匹配
+	VendorErrorTemplateMismatch VendorErrorCategory = "VENDOR_TEMPLATE_MISMATCH"
+	// VendorErrorInsufficientBalance 供应商账户余额或者套餐包不足
+	VendorErrorInsufficientBalance VendorErrorCategory = "VENDOR_INSUFFICIENT_BALANCE"
+	// VendorErrorRateLimited 触发供应商的频率限制
+	VendorErrorRateLimited VendorErrorCategory = "VENDOR_RATE_LIMITED"
+	// VendorErrorAuth 密钥错误、账号没有权限
+	VendorErrorAuth VendorErrorCategory = "VENDOR_AUTH"
+	// VendorErrorUnavailable 供应商内部错误或者服务不可用
+	VendorErrorUnavailable VendorErrorCategory = "VENDOR_UNAVAILABLE"
+	// VendorErrorUnknown 没有配置映射的错误码
+	VendorErrorUnknown VendorErrorCategory = "VENDOR_UNKNOWN"
+)
+
+func (c VendorErrorCategory) String() string {
+	return string(c)
+}
+
+// IsValid 是否是合法的分类
+func (c VendorErrorCategory) IsValid() bool {
+	switch c {
+	case VendorErrorBlacklisted, VendorErrorInvalidReceiver, VendorErrorTemplateMismatch,
+		VendorErrorInsufficientBalance, VendorErrorRateLimited, VendorErrorAuth,
+		VendorErrorUnavailable, VendorErrorUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// Retryable 稍后用同一个供应商重试是否可能成功，接收者和模板的问题重试多少次都一样
+func (c VendorErrorCategory) Retryable() bool {
+	return c == VendorErrorRateLimited || c == VendorErrorUnavailable || c == VendorErrorUnknown
+}
+
+// ProviderFault 是否是供应商自身的问题，只有这类失败计入供应商失败率，接收者和模板的问题不影响熔断
+func (c VendorErrorCategory) ProviderFault() bool {
+	switch c {
+	case VendorErrorInsufficientBalance, VendorErrorRateLimited, VendorErrorAuth, VendorErrorUnavailable, VendorErrorUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// FailReason 作为通知的失败原因
+func (c VendorErrorCategory) FailReason() FailReason {
+	return FailReason(c)
+}
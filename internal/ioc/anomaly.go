@@ -0,0 +1,54 @@
+package ioc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/anomaly"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const defaultBreakerDuration = time.Minute
+
+// InitProviderBreaker 供应商熔断器，所有供应商共用
+func InitProviderBreaker() *provider.Breaker {
+	return provider.NewBreaker()
+}
+
+// InitAnomalyDetector 发送失败率异常检测，按配置发送告警和熔断供应商
+func InitAnomalyDetector(breaker *provider.Breaker, logger log.LoggerInterface) *anomaly.Detector {
+	conf := config.AnomalyConfig{}
+	if err := viper.UnmarshalKey("anomaly", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	logger = log.Named(logger, "anomaly")
+	var handlers []anomaly.Handler
+	if conf.IMBotURL != "" {
+		handlers = append(handlers, anomaly.AlertHandler(anomaly.NewIMBotAlerter(conf.IMBotURL, client),
+			logger.With(zap.String("alerter", "im-bot"))))
+	}
+	if conf.WebhookURL != "" {
+		handlers = append(handlers, anomaly.AlertHandler(anomaly.NewWebhookAlerter(conf.WebhookURL, client),
+			logger.With(zap.String("alerter", "webhook"))))
+	}
+	if conf.TripBreaker {
+		d := conf.BreakerDuration
+		if d <= 0 {
+			d = defaultBreakerDuration
+		}
+		handlers = append(handlers, provider.TripBreakerHandler(breaker, d))
+	}
+	return anomaly.NewDetector(anomaly.Options{
+		Window:         conf.Window,
+		Buckets:        conf.Buckets,
+		MinSamples:     conf.MinSamples,
+		MinFailureRate: conf.MinFailureRate,
+		SpikeRatio:     conf.SpikeRatio,
+		Cooldown:       conf.Cooldown,
+	}, handlers...)
+}
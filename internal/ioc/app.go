@@ -2,29 +2,129 @@ package ioc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/serendipityConfusion/notification-platform/internal/api/debug"
+	"github.com/serendipityConfusion/notification-platform/internal/api/gateway"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/machineid"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/registry"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 )
 
 // App 应用结构体
+// 后台任务角色没有 gRPC 服务，GrpcServer、Registry 为 nil；只读查询角色不生成ID，MachineIDAllocator 为 nil
 type App struct {
 	GrpcServer   *grpc.Server          // gRPC 服务器
 	Registry     registry.Registry     // 服务注册器（抽象接口）
 	ConfigLoader config.ConfigLoader   // 配置加载器（抽象接口）
 	ServiceInfo  *registry.ServiceInfo // 服务信息
+	// MachineIDAllocator 机器ID分配器，退出时释放机器ID
+	MachineIDAllocator machineid.Allocator
+	// Tasks 后台任务，gRPC 服务启动后运行，关闭时先停止，等所有任务返回之后再关闭其他组件
+	Tasks []Task
+	// Gateway HTTP/JSON 网关，没有开启时为 nil
+	Gateway *gateway.Server
+	// DebugServer pprof 和运行时指标，没有开启时为 nil
+	DebugServer *debug.Server
+	// TracerProvider 退出时上报剩余的 span
+	TracerProvider *sdktrace.TracerProvider
+	// Role 部署角色，由启动参数决定，决定注册到注册中心的服务名
+	Role Role `wire:"-"`
 }
 
 // Run 运行应用
 func (a *App) Run() error {
+	log.Printf("[App] Running as role %q", a.role())
+	errCh := make(chan error, 3)
+	if a.GrpcServer != nil {
+		if err := a.serveGrpc(errCh); err != nil {
+			return err
+		}
+	}
+
+	if a.Gateway != nil {
+		go func() {
+			if err := a.Gateway.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("failed to serve gateway: %w", err)
+			}
+		}()
+		log.Println("[App] HTTP gateway started")
+	}
+
+	if a.DebugServer != nil {
+		go func() {
+			if err := a.DebugServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("failed to serve debug server: %w", err)
+			}
+		}()
+		log.Println("[App] Debug server started")
+	}
+
+	// 5. 启动后台任务
+	taskCtx, cancelTasks := context.WithCancel(context.Background())
+	defer cancelTasks()
+	var tasks sync.WaitGroup
+	for _, task := range a.Tasks {
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			task.Start(taskCtx)
+		}()
+	}
+	// 调度器退出前要等协程池里的通知发送完，发送结果还要写数据库，所以等任务都返回之后再关闭其他组件
+	stopTasks := func() {
+		cancelTasks()
+		tasks.Wait()
+		log.Println("[App] Background tasks stopped")
+	}
+
+	// 6. 等待中断信号
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	// 没有机器ID分配器时 lost 是 nil，永远不会触发
+	var lost <-chan struct{}
+	if a.MachineIDAllocator != nil {
+		lost = a.MachineIDAllocator.Lost()
+	}
+
+	select {
+	case <-quit:
+		log.Println("[App] Shutting down server...")
+		stopTasks()
+	case err := <-errCh:
+		return err
+	case <-lost:
+		// 机器ID已被其他实例占用，继续生成ID会产生冲突，只能退出
+		log.Println("[App] Machine id ownership lost, shutting down server...")
+		stopTasks()
+		_ = a.shutdown()
+		return machineid.ErrMachineIDConflict
+	}
+
+	// 7. 优雅关闭
+	return a.shutdown()
+}
+
+func (a *App) role() Role {
+	if a.Role == "" {
+		return RoleAll
+	}
+	return a.Role
+}
+
+// serveGrpc 注册服务并在后台启动 gRPC 服务器
+func (a *App) serveGrpc(errCh chan<- error) error {
 	// 1. 从配置加载器获取 gRPC 配置
 	grpcConf := &config.GrpcConfig{}
 	if err := a.ConfigLoader.Load("notification-server", grpcConf); err != nil {
@@ -32,16 +132,17 @@ func (a *App) Run() error {
 	}
 
 	// 2. 构造服务信息
+	name := a.role().ServiceName(grpcConf.Name)
 	if a.ServiceInfo == nil {
 		a.ServiceInfo = &registry.ServiceInfo{
-			Name:      grpcConf.Name,
+			Name:      name,
 			Addr:      grpcConf.Addr,
 			TTL:       10 * time.Second, // 默认 10 秒心跳
 			Namespace: "/services",
 		}
 	} else {
 		// 如果已经注入了 ServiceInfo，则更新配置中的值
-		a.ServiceInfo.Name = grpcConf.Name
+		a.ServiceInfo.Name = name
 		a.ServiceInfo.Addr = grpcConf.Addr
 	}
 
@@ -61,26 +162,12 @@ func (a *App) Run() error {
 	log.Printf("[App] gRPC server listening on %s", a.ServiceInfo.Addr)
 
 	// 在 goroutine 中启动服务器
-	errCh := make(chan error, 1)
 	go func() {
 		if err := a.GrpcServer.Serve(listener); err != nil {
 			errCh <- fmt.Errorf("failed to serve: %w", err)
 		}
 	}()
-
-	// 5. 等待中断信号
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	select {
-	case <-quit:
-		log.Println("[App] Shutting down server...")
-	case err := <-errCh:
-		return err
-	}
-
-	// 6. 优雅关闭
-	return a.shutdown()
+	return nil
 }
 
 // shutdown 优雅关闭应用
@@ -91,19 +178,54 @@ func (a *App) shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := a.Registry.Deregister(ctx, a.ServiceInfo); err != nil {
-		log.Printf("[App] Failed to deregister service: %v", err)
-		// 不返回错误，继续关闭流程
+	if a.Registry != nil && a.ServiceInfo != nil {
+		if err := a.Registry.Deregister(ctx, a.ServiceInfo); err != nil {
+			log.Printf("[App] Failed to deregister service: %v", err)
+			// 不返回错误，继续关闭流程
+		}
 	}
 
-	// 2. 关闭注册器
-	if err := a.Registry.Close(); err != nil {
-		log.Printf("[App] Failed to close registry: %v", err)
+	// 2. 先停止网关，网关的请求最终也要经过 gRPC 服务器
+	if a.Gateway != nil {
+		if err := a.Gateway.Shutdown(ctx); err != nil {
+			log.Printf("[App] Failed to shutdown gateway: %v", err)
+		}
+	}
+
+	if a.DebugServer != nil {
+		if err := a.DebugServer.Shutdown(ctx); err != nil {
+			log.Printf("[App] Failed to shutdown debug server: %v", err)
+		}
 	}
 
 	// 3. 优雅停止 gRPC 服务器
-	a.GrpcServer.GracefulStop()
-	log.Println("[App] Server stopped gracefully")
+	if a.GrpcServer != nil {
+		a.GrpcServer.GracefulStop()
+		log.Println("[App] Server stopped gracefully")
+	}
+
+	// 4. 服务器停止后不会再生成ID，释放机器ID（需要在注册器关闭 etcd 客户端之前）
+	if a.MachineIDAllocator != nil {
+		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer releaseCancel()
+		if err := a.MachineIDAllocator.Release(releaseCtx); err != nil {
+			log.Printf("[App] Failed to release machine id: %v", err)
+		}
+	}
+
+	// 5. 关闭注册器
+	if a.Registry != nil {
+		if err := a.Registry.Close(); err != nil {
+			log.Printf("[App] Failed to close registry: %v", err)
+		}
+	}
+
+	// 6. 最后上报剩余的 span，关闭过程中的链路也不会丢
+	if a.TracerProvider != nil {
+		if err := ShutdownTracer(a.TracerProvider); err != nil {
+			log.Printf("[App] Failed to shutdown tracer provider: %v", err)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,22 @@
+package ioc
+
+import (
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+func loadAuthConfig() config.AuthConfig {
+	conf := config.AuthConfig{}
+	if err := viper.UnmarshalKey("auth", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+// InitRBACService 初始化角色服务
+func InitRBACService(repo repository.RoleAssignmentRepository) service.RBACService {
+	conf := loadAuthConfig()
+	return service.NewRBACService(repo, conf.BootstrapAdmins)
+}
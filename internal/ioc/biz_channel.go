@@ -0,0 +1,12 @@
+package ioc
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	rediscache "github.com/serendipityConfusion/notification-platform/internal/repository/cache/redis"
+)
+
+// InitBizChannelCache 业务方渠道开关缓存，修改开关时删除，所有实例共享
+func InitBizChannelCache(client *redis.Client) cache.BizChannelCache {
+	return rediscache.NewBizChannelCache(client)
+}
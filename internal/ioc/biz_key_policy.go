@@ -0,0 +1,12 @@
+package ioc
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	rediscache "github.com/serendipityConfusion/notification-platform/internal/repository/cache/redis"
+)
+
+// InitBizKeyPolicyCache 业务方 key 规则缓存，修改规则时删除，所有实例共享
+func InitBizKeyPolicyCache(client *redis.Client) cache.BizKeyPolicyCache {
+	return rediscache.NewBizKeyPolicyCache(client)
+}
@@ -0,0 +1,182 @@
+package ioc
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/anomaly"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/callback"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultCallbackTimeout = 5 * time.Second
+
+	defaultCallbackDeliveryInterval    = 5 * time.Second
+	defaultCallbackDeliveryBatchSize   = 100
+	defaultCallbackDeliveryMaxRetries  = 10
+	defaultCallbackDeliveryBaseBackoff = 5 * time.Second
+	defaultCallbackDeliveryMaxBackoff  = 10 * time.Minute
+
+	defaultCallbackDeadLetterSummaryInterval = 24 * time.Hour
+	defaultCallbackDeadLetterSummaryLimit    = 1000
+)
+
+func loadCallbackConfig() config.CallbackConfig {
+	conf := config.CallbackConfig{}
+	if err := viper.UnmarshalKey("callback", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultCallbackTimeout
+	}
+	d := &conf.Delivery
+	if d.Interval <= 0 {
+		d.Interval = defaultCallbackDeliveryInterval
+	}
+	if d.BatchSize <= 0 {
+		d.BatchSize = defaultCallbackDeliveryBatchSize
+	}
+	if d.MaxRetries <= 0 {
+		d.MaxRetries = defaultCallbackDeliveryMaxRetries
+	}
+	if d.BaseBackoff <= 0 {
+		d.BaseBackoff = defaultCallbackDeliveryBaseBackoff
+	}
+	if d.MaxBackoff <= 0 {
+		d.MaxBackoff = defaultCallbackDeliveryMaxBackoff
+	}
+	dl := &conf.DeadLetter
+	if dl.SummaryInterval <= 0 {
+		dl.SummaryInterval = defaultCallbackDeadLetterSummaryInterval
+	}
+	if dl.SummaryLimit <= 0 {
+		dl.SummaryLimit = defaultCallbackDeadLetterSummaryLimit
+	}
+	return conf
+}
+
+// callbackEndpoints 业务方接收发送结果的默认地址，配置错误直接 panic
+func callbackEndpoints(conf config.CallbackConfig) map[int64]string {
+	endpoints := make(map[int64]string, len(conf.Endpoints))
+	for _, e := range conf.Endpoints {
+		if e.BizID <= 0 {
+			panic(fmt.Errorf("回调地址的 biz-id 不合法: %d", e.BizID))
+		}
+		if _, ok := endpoints[e.BizID]; ok {
+			panic(fmt.Errorf("业务方 %d 配置了多个回调地址", e.BizID))
+		}
+		u, err := url.Parse(e.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			panic(fmt.Errorf("业务方 %d 的回调地址不合法: %q", e.BizID, e.URL))
+		}
+		endpoints[e.BizID] = e.URL
+	}
+	return endpoints
+}
+
+// initNotificationCallbackTask 发送结果回调任务，没有开启时返回 nil
+func initNotificationCallbackTask(repo repository.CallbackLogRepository,
+	notificationRepo repository.NotificationRepository,
+	client callback.Client,
+	lock distribute_lock.Client,
+	clk clock.Clock,
+) Task {
+	conf := loadCallbackConfig()
+	if !conf.Delivery.Enabled {
+		return nil
+	}
+	d := conf.Delivery
+	return service.NewNotificationCallbackTask(repo, notificationRepo, client, callbackEndpoints(conf), lock,
+		service.NotificationCallbackOptions{
+			Interval:     d.Interval,
+			BatchSize:    d.BatchSize,
+			MaxRetries:   d.MaxRetries,
+			BaseBackoff:  d.BaseBackoff,
+			MaxBackoff:   d.MaxBackoff,
+			AllowedHosts: d.AllowedHosts,
+		}, clk)
+}
+
+func initCallbackDeadLetterSummaryTask(svc service.CallbackDeadLetterService, lock distribute_lock.Client) Task {
+	conf := loadCallbackConfig().DeadLetter
+	if !conf.SummaryEnabled {
+		return nil
+	}
+	return service.NewCallbackDeadLetterSummaryTask(svc, lock, conf.SummaryInterval)
+}
+
+// InitCallbackDeadLetterService 回调死信的查询、重新回调和汇总告警
+func InitCallbackDeadLetterService(repo repository.CallbackLogRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc service.ChannelTemplateService,
+	idGenerator idgen.Generator, clk clock.Clock,
+) service.CallbackDeadLetterService {
+	conf := loadCallbackConfig().DeadLetter
+	client := &http.Client{Timeout: 5 * time.Second}
+	var alerters []anomaly.TextAlerter
+	if conf.IMBotURL != "" {
+		alerters = append(alerters, anomaly.NewIMBotTextAlerter(conf.IMBotURL, client))
+	}
+	if conf.WebhookURL != "" {
+		alerters = append(alerters, anomaly.NewWebhookTextAlerter(conf.WebhookURL, client))
+	}
+	return service.NewCallbackDeadLetterService(repo, notificationRepo, templateSvc, idGenerator, clk,
+		alerters, callbackDeadLetterNotice(conf.Notify), conf.SummaryLimit)
+}
+
+// callbackDeadLetterNotice 死信汇总通知，没有配置模板时返回 nil
+func callbackDeadLetterNotice(conf config.CallbackDeadLetterNotifyConfig) *domain.CallbackDeadLetterNotice {
+	if conf.TemplateID <= 0 {
+		return nil
+	}
+	channel := domain.Channel(conf.Channel)
+	if conf.BizID <= 0 || !channel.IsValid() {
+		panic(fmt.Errorf("回调死信汇总通知的 biz-id 或 channel 不合法: %d %q", conf.BizID, conf.Channel))
+	}
+	receivers := make(map[int64][]string, len(conf.Receivers))
+	for _, r := range conf.Receivers {
+		receivers[r.OwnerID] = append(receivers[r.OwnerID], r.Receivers...)
+	}
+	return &domain.CallbackDeadLetterNotice{
+		BizID:      conf.BizID,
+		Channel:    channel,
+		TemplateID: conf.TemplateID,
+		Receivers:  receivers,
+	}
+}
+
+// InitCallbackHealthTracker 回调地址健康度统计，所有回调共用
+func InitCallbackHealthTracker() *callback.HealthTracker {
+	conf := loadCallbackConfig().Breaker
+	return callback.NewHealthTracker(callback.HealthOptions{
+		MinScore:         conf.MinScore,
+		MinSamples:       conf.MinSamples,
+		FailureThreshold: conf.FailureThreshold,
+		BaseBackoff:      conf.BaseBackoff,
+		MaxBackoff:       conf.MaxBackoff,
+	})
+}
+
+// InitCallbackClient 回调业务方的客户端，请求体使用业务方的密钥签名，不健康的地址暂停回调，证书配置错误直接 panic
+func InitCallbackClient(secretSvc service.CallbackSecretService, tracker *callback.HealthTracker) callback.Client {
+	conf := loadCallbackConfig()
+	httpClient, err := callback.NewHTTPClient(callback.TLSOptions{
+		CertFile: conf.TLS.CertFile,
+		KeyFile:  conf.TLS.KeyFile,
+		CAFile:   conf.TLS.CAFile,
+	}, conf.Timeout)
+	if err != nil {
+		panic(err)
+	}
+	return callback.NewBreakerClient(callback.NewClient(httpClient, secretSvc), tracker)
+}
@@ -0,0 +1,8 @@
+package ioc
+
+import "github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+
+// InitClock 时间来源，生产环境使用系统时钟
+func InitClock() clock.Clock {
+	return clock.Real()
+}
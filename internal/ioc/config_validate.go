@@ -0,0 +1,538 @@
+package ioc
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/api/push"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/database/tracing"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+)
+
+// configSection 一段配置的解析和校验
+type configSection struct {
+	key   string
+	check func(v *viper.Viper, r *config.Report)
+}
+
+// section 严格解析 key 对应的配置，再执行 check
+func section[T any](key string, check func(v *viper.Viper, conf T, r *config.Report)) configSection {
+	return configSection{key: key, check: func(v *viper.Viper, r *config.Report) {
+		var conf T
+		r.Decode(v, key, &conf)
+		if check != nil {
+			check(v, conf, r)
+		}
+	}}
+}
+
+// looseConfigKeys 没有对应结构体、按单个值读取的配置
+var looseConfigKeys = []string{"mysql"}
+
+var configSections = []configSection{
+	section("database", func(v *viper.Viper, c config.DatabaseConfig, r *config.Report) {
+		r.OneOf("database.driver", c.Driver, "", config.DriverMySQL, config.DriverPostgres)
+		if c.DSN == "" && v.GetString("mysql.dsn") == "" {
+			r.Add("database.dsn", "必须配置")
+		}
+		if c.BatchQuery.ChunkSize < 0 || c.BatchQuery.Concurrency < 0 {
+			r.Add("database.batch-query", "chunk-size 和 concurrency 不能小于 0")
+		}
+		r.OneOf("database.tracing.args", c.Tracing.Args, "", tracing.ArgsNone, tracing.ArgsRedacted, tracing.ArgsRaw)
+		if c.Tracing.MaxStatementLength < 0 {
+			r.Add("database.tracing.max-statement-length", "不能小于 0")
+		}
+		for i, dsn := range c.Failover.DSNs {
+			// 不输出地址，里面有密码
+			if dsn == "" {
+				r.Add(fmt.Sprintf("database.failover.dsns[%d]", i), "不能为空")
+			} else if dsn == c.DSN {
+				r.Add(fmt.Sprintf("database.failover.dsns[%d]", i), "和 dsn 相同")
+			}
+		}
+		if c.Failover.ReadRetries > maxDatabaseReadRetries {
+			r.Add("database.failover.read-retries", "不能超过 %d", maxDatabaseReadRetries)
+		}
+		if c.Failover.RetryBackoff < 0 || c.Failover.DegradedWindow < 0 {
+			r.Add("database.failover", "retry-backoff 和 degraded-window 不能小于 0")
+		}
+	}),
+	section("redis", func(_ *viper.Viper, c config.RedisConfig, r *config.Report) {
+		r.Required("redis.addr", c.Addr)
+	}),
+	section("etcd", func(_ *viper.Viper, c config.EtcdConfig, r *config.Report) {
+		if len(c.Endpoints) == 0 {
+			r.Add("etcd.endpoints", "至少配置一个地址")
+		}
+		if c.DialTimeout < 0 {
+			r.Add("etcd.dial-timeout", "不能小于 0")
+		}
+		for _, d := range []struct {
+			key   string
+			value time.Duration
+		}{
+			{"etcd.auto-sync-interval", c.AutoSyncInterval},
+			{"etcd.keepalive-time", c.KeepaliveTime},
+			{"etcd.keepalive-timeout", c.KeepaliveTimeout},
+			{"etcd.request-timeout", c.RequestTimeout},
+		} {
+			if d.value < 0 {
+				r.Add(d.key, "不能小于 0")
+			}
+		}
+		if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+			r.Add("etcd.tls", "cert-file 和 key-file 必须同时配置")
+		}
+	}),
+	section("notification-server", func(v *viper.Viper, c config.GrpcConfig, r *config.Report) {
+		r.Required("notification-server.addr", c.Addr)
+		if err := validateGrpcConfig(c); err != nil {
+			r.Add("notification-server", "%s", err)
+		}
+		for i, m := range c.Timeout.Methods {
+			if !strings.HasPrefix(m.Method, "/") {
+				r.Add(fmt.Sprintf("notification-server.timeout.methods[%d].method", i), "必须是完整方法名: %q", m.Method)
+			}
+		}
+		if err := newInterceptorChain(c, config.AuthConfig{Enabled: v.GetBool("auth.enabled")}, nil, nil).Validate(c.Interceptors); err != nil {
+			r.Add("notification-server.interceptors", "%s", err)
+		}
+	}),
+	section("log", func(_ *viper.Viper, c config.LogConfig, r *config.Report) {
+		if c.Level != "" {
+			if _, err := zapcore.ParseLevel(c.Level); err != nil {
+				r.Add("log.level", "取值 %q 不合法，可选值: debug, info, warn, error", c.Level)
+			}
+		}
+		for module, level := range c.Modules {
+			if _, err := zapcore.ParseLevel(level); err != nil {
+				r.Add("log.modules."+module, "取值 %q 不合法，可选值: debug, info, warn, error", level)
+			}
+		}
+		checkFile := func(key string, f config.LogFileConfig) {
+			if f.MaxSize < 0 || f.MaxAge < 0 || f.MaxBackups < 0 {
+				r.Add(key, "max-size、max-age、max-backups 不能是负数")
+			}
+		}
+		checkFile("log.file", c.File)
+		checkFile("log.error-file", c.ErrorFile)
+		if c.File.Path != "" && c.File.Path == c.ErrorFile.Path {
+			r.Add("log.error-file.path", "不能和 log.file.path 相同")
+		}
+	}),
+	section("trace", func(_ *viper.Viper, c config.TraceConfig, r *config.Report) {
+		r.OneOf("trace.sampler.type", c.Sampler.Type, "", config.SamplerAlways, config.SamplerNever, config.SamplerRatio)
+		if c.Sampler.Ratio < 0 || c.Sampler.Ratio > 1 {
+			r.Add("trace.sampler.ratio", "必须在 0 到 1 之间: %v", c.Sampler.Ratio)
+		}
+		for i, m := range c.Sampler.Methods {
+			if !strings.HasPrefix(m.Method, "/") {
+				r.Add(fmt.Sprintf("trace.sampler.methods[%d].method", i), "必须是完整方法名: %q", m.Method)
+			}
+			if m.Ratio < 0 || m.Ratio > 1 {
+				r.Add(fmt.Sprintf("trace.sampler.methods[%d].ratio", i), "必须在 0 到 1 之间: %v", m.Ratio)
+			}
+		}
+		if strings.Contains(c.Jeager.Endpoint, "://") {
+			r.Add("trace.jeager.endpoint", "只写 host:port，不带协议: %q", c.Jeager.Endpoint)
+		}
+	}),
+	section("secrets", func(_ *viper.Viper, c config.SecretsConfig, r *config.Report) {
+		if c.Vault.Addr == "" {
+			return
+		}
+		if u, err := url.Parse(c.Vault.Addr); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			r.Add("secrets.vault.addr", "不合法: %q", c.Vault.Addr)
+		}
+		if c.Vault.KVVersion != 0 && c.Vault.KVVersion != 1 && c.Vault.KVVersion != 2 {
+			r.Add("secrets.vault.kv-version", "只能是 1 或 2: %d", c.Vault.KVVersion)
+		}
+	}),
+	section("auth", func(_ *viper.Viper, c config.AuthConfig, r *config.Report) {
+		if c.Enabled && c.JWTKey == "" {
+			r.Add("auth.jwt-key", "开启鉴权时必须配置")
+		}
+	}),
+	section("id-generator", func(_ *viper.Viper, c config.IDGeneratorConfig, r *config.Report) {
+		r.OneOf("id-generator.allocator", c.Allocator, "", config.MachineIDAllocatorEtcd, config.MachineIDAllocatorRedis)
+		r.OneOf("id-generator.clock-skew.policy", c.ClockSkew.Policy,
+			"", string(idgen.ClockSkewPolicyWait), string(idgen.ClockSkewPolicyFail))
+		if c.Epoch != "" {
+			if _, err := time.Parse(time.RFC3339, c.Epoch); err != nil {
+				r.Add("id-generator.epoch", "必须是 RFC3339 格式: %q", c.Epoch)
+			}
+		}
+		if c.Fallback.Enabled && c.Fallback.Key == "" {
+			r.Add("id-generator.fallback.key", "开启降级时必须配置")
+		}
+	}),
+	section("encryption", func(_ *viper.Viper, c config.EncryptionConfig, r *config.Report) {
+		if !c.Enabled {
+			return
+		}
+		ids := make([]string, 0, len(c.Keys))
+		for i, k := range c.Keys {
+			if k.ID == "" || slices.Contains(ids, k.ID) {
+				r.Add(fmt.Sprintf("encryption.keys[%d].id", i), "为空或者重复: %q", k.ID)
+			}
+			if _, err := base64.StdEncoding.DecodeString(k.Secret); err != nil || k.Secret == "" {
+				r.Add(fmt.Sprintf("encryption.keys[%d].secret", i), "必须是 base64 编码的密钥")
+			}
+			ids = append(ids, k.ID)
+		}
+		if !slices.Contains(ids, c.ActiveKey) {
+			r.Add("encryption.active-key", "必须是 keys 里的一个: %q", c.ActiveKey)
+		}
+		if key, err := base64.StdEncoding.DecodeString(c.BlindIndexKey); err != nil || len(key) < 32 {
+			r.Add("encryption.blind-index-key", "必须是 base64 编码的至少 32 字节的密钥")
+		}
+	}),
+	section("retention", func(_ *viper.Viper, c config.RetentionConfig, r *config.Report) {
+		nonNegative(r, "retention.batch-size", c.BatchSize)
+		nonNegative(r, "retention.callback-log-days", c.CallbackLogDays)
+		for i, p := range c.Policies {
+			err := domain.RetentionPolicy{
+				BizID:         p.BizID,
+				Channel:       domain.Channel(p.Channel),
+				RetentionDays: p.Days,
+				Action:        domain.RetentionAction(p.Action),
+			}.Validate()
+			if err != nil {
+				r.Add(fmt.Sprintf("retention.policies[%d]", i), "%s", err)
+			}
+		}
+	}),
+	section("anomaly", func(_ *viper.Viper, c config.AnomalyConfig, r *config.Report) {
+		if c.MinFailureRate < 0 || c.MinFailureRate > 1 {
+			r.Add("anomaly.min-failure-rate", "必须在 0 到 1 之间: %v", c.MinFailureRate)
+		}
+		if c.SpikeRatio < 0 {
+			r.Add("anomaly.spike-ratio", "不能小于 0: %v", c.SpikeRatio)
+		}
+	}),
+	section("provider", func(_ *viper.Viper, c config.ProviderRoutingConfig, r *config.Report) {
+		seen := make(map[string]struct{}, len(c.Routes))
+		for i, route := range c.Routes {
+			key := fmt.Sprintf("provider.routes[%d]", i)
+			checkChannel(r, key+".channel", route.Channel)
+			if _, ok := seen[route.Channel]; ok {
+				r.Add(key+".channel", "渠道 %s 重复配置", route.Channel)
+			}
+			seen[route.Channel] = struct{}{}
+			if len(route.Providers) == 0 {
+				r.Add(key+".providers", "至少配置一个供应商")
+			}
+			if route.ShadowPercent > 100 {
+				r.Add(key+".shadow-percent", "必须在 0 到 100 之间: %d", route.ShadowPercent)
+			}
+		}
+		for i, e := range c.ErrorCodes {
+			key := fmt.Sprintf("provider.error-codes[%d]", i)
+			r.Required(key+".vendor", e.Vendor)
+			r.Required(key+".code", e.Code)
+			if !domain.VendorErrorCategory(e.Category).IsValid() {
+				r.Add(key+".category", "取值 %q 不合法", e.Category)
+			}
+		}
+		if c.Blacklist.TTL < 0 {
+			r.Add("provider.blacklist.ttl", "不能小于 0")
+		}
+		for i, category := range c.Blacklist.Categories {
+			if !domain.VendorErrorCategory(category).IsValid() {
+				r.Add(fmt.Sprintf("provider.blacklist.categories[%d]", i), "取值 %q 不合法", category)
+			}
+		}
+		h := c.Health
+		if h.Alpha < 0 || h.Alpha > 1 {
+			r.Add("provider.health.alpha", "必须在 0 到 1 之间: %v", h.Alpha)
+		}
+		nonNegative(r, "provider.health.window", h.Window)
+		if h.MinSamples < 0 {
+			r.Add("provider.health.min-samples", "不能小于 0")
+		}
+		if h.MaxErrorRate < 0 || h.MaxErrorRate > 1 {
+			r.Add("provider.health.max-error-rate", "必须在 0 到 1 之间: %v", h.MaxErrorRate)
+		}
+		if h.LatencyTolerance < 0 || h.LatencyTolerance >= 1 {
+			r.Add("provider.health.latency-tolerance", "必须大于等于 0 并且小于 1: %v", h.LatencyTolerance)
+		}
+		if h.StaleAfter < 0 {
+			r.Add("provider.health.stale-after", "不能小于 0")
+		}
+		m := c.Mock
+		if m.FailPercent < 0 || m.FailPercent > 100 {
+			r.Add("provider.mock.fail-percent", "必须在 0 到 100 之间: %d", m.FailPercent)
+		}
+		if m.FailCategory != "" && !domain.VendorErrorCategory(m.FailCategory).IsValid() {
+			r.Add("provider.mock.fail-category", "取值 %q 不合法", m.FailCategory)
+		}
+		if m.Delay < 0 {
+			r.Add("provider.mock.delay", "不能小于 0")
+		}
+	}),
+	section("scheduler", func(_ *viper.Viper, c config.SchedulerConfig, r *config.Report) {
+		nonNegative(r, "scheduler.batch-size", c.BatchSize)
+		a := c.Adaptive
+		if a.Enabled && a.MaxBatchSize > 0 && a.MaxBatchSize < a.MinBatchSize {
+			r.Add("scheduler.adaptive.max-batch-size", "不能小于 min-batch-size")
+		}
+		if a.Enabled && a.MaxInterval > 0 && a.MaxInterval < a.MinInterval {
+			r.Add("scheduler.adaptive.max-interval", "不能小于 min-interval")
+		}
+	}),
+	section("callback", func(_ *viper.Viper, c config.CallbackConfig, r *config.Report) {
+		recoverProblem(r, "callback.endpoints", func() { callbackEndpoints(c) })
+		recoverProblem(r, "callback.dead-letter.notify", func() { callbackDeadLetterNotice(c.DeadLetter.Notify) })
+		nonNegative(r, "callback.dead-letter.summary-limit", c.DeadLetter.SummaryLimit)
+		if c.Breaker.MinScore < 0 || c.Breaker.MinScore > 1 {
+			r.Add("callback.breaker.min-score", "必须在 0 到 1 之间: %v", c.Breaker.MinScore)
+		}
+	}),
+	section("quota", func(_ *viper.Viper, c config.QuotaConfig, r *config.Report) {
+		policies := make([]domain.OverdraftPolicy, 0, len(c.Overdraft))
+		for _, p := range c.Overdraft {
+			policies = append(policies, domain.OverdraftPolicy{
+				BizID:        p.BizID,
+				Channel:      domain.Channel(p.Channel),
+				Mode:         domain.OverdraftMode(p.Mode),
+				BurstPercent: p.BurstPercent,
+			})
+		}
+		if _, err := domain.NewOverdraftPolicies(policies); err != nil {
+			r.Add("quota.overdraft", "%s", err)
+		}
+		r.OneOf("quota.degraded.mode", c.Degraded.Mode, "",
+			repository.QuotaDegradedReject, repository.QuotaDegradedDB, repository.QuotaDegradedAllow)
+		nonNegative(r, "quota.reservation.batch-size", c.Reservation.BatchSize)
+		if c.Reservation.Grace > 0 && c.Reservation.Grace < time.Minute {
+			r.Add("quota.reservation.grace", "不能小于 1m，写入慢的通知会被归还额度: %s", c.Reservation.Grace)
+		}
+	}),
+	section("duplicate-check", func(_ *viper.Viper, c config.DuplicateCheckConfig, r *config.Report) {
+		if c.Bits > 1<<32 {
+			r.Add("duplicate-check.bits", "不能超过 %d: %d", uint64(1<<32), c.Bits)
+		}
+		nonNegative(r, "duplicate-check.hashes", c.Hashes)
+	}),
+	section("create-batch", func(_ *viper.Viper, c config.CreateBatchConfig, r *config.Report) {
+		nonNegative(r, "create-batch.max-size", c.MaxSize)
+	}),
+	section[config.ExpiryConfig]("expiry", nil),
+	section[config.SendingTimeoutConfig]("sending-timeout", nil),
+	section[config.StatsConfig]("stats", nil),
+	section("feature-flags", func(_ *viper.Viper, c config.FeatureFlagConfig, r *config.Report) {
+		for name, rule := range c.Flags {
+			key := "feature-flags.flags." + name
+			if !slices.ContainsFunc(featureflag.Known(), func(f featureflag.Flag) bool { return f.Name == name }) {
+				r.Add(key, "开关不存在")
+				continue
+			}
+			if rule.Percent < 0 || rule.Percent > 100 {
+				r.Add(key+".percent", "必须在 0 到 100 之间: %d", rule.Percent)
+			}
+		}
+	}),
+	section("slo", func(_ *viper.Viper, c config.SLOConfig, r *config.Report) {
+		if c.Interval < 0 || c.Period < 0 {
+			r.Add("slo", "interval 和 period 不能小于 0")
+		}
+		for _, w := range c.Windows {
+			if w <= 0 {
+				r.Add("slo.windows", "窗口必须大于 0")
+				break
+			}
+		}
+		for _, t := range []struct {
+			key   string
+			value float64
+		}{
+			{"slo.send-target", c.SendTarget},
+			{"slo.provider-target", c.ProviderTarget},
+			{"slo.callback-target", c.CallbackTarget},
+		} {
+			if t.value < 0 || t.value >= 1 {
+				r.Add(t.key, "必须在 [0, 1) 之间，0 使用默认值")
+			}
+		}
+	}),
+	section("export", func(_ *viper.Viper, c config.ExportConfig, r *config.Report) {
+		if c.Enabled {
+			r.Required("export.clickhouse.endpoint", c.ClickHouse.Endpoint)
+		}
+		if c.Kafka.Enabled {
+			r.Required("export.kafka.endpoint", c.Kafka.Endpoint)
+		}
+		nonNegative(r, "export.stream.rows-per-second", c.Stream.RowsPerSecond)
+	}),
+	section("read-receipt", func(_ *viper.Viper, c config.ReadReceiptConfig, r *config.Report) {
+		recoverProblem(r, "read-receipt.endpoints", func() { readReceiptEndpoints(c) })
+	}),
+	section("dispatcher", func(_ *viper.Viper, c config.DispatcherConfig, r *config.Report) {
+		for i, ch := range c.Channels {
+			checkChannel(r, fmt.Sprintf("dispatcher.channels[%d].channel", i), ch.Channel)
+		}
+		for i, p := range c.Providers {
+			r.Required(fmt.Sprintf("dispatcher.providers[%d].provider", i), p.Provider)
+		}
+	}),
+	section("gateway", func(_ *viper.Viper, c config.GatewayConfig, r *config.Report) {
+		if c.Enabled {
+			r.Required("gateway.addr", c.Addr)
+		}
+	}),
+	section("graphql", func(v *viper.Viper, c config.GraphQLConfig, r *config.Report) {
+		if c.Enabled && !v.GetBool("gateway.enabled") {
+			r.Add("graphql.enabled", "开启 GraphQL 时必须同时开启 gateway")
+		}
+	}),
+	section("push", func(v *viper.Viper, c config.PushConfig, r *config.Report) {
+		if c.Inbox.Enabled && !c.Enabled {
+			r.Add("push.inbox.enabled", "开启收件箱时必须同时开启 push")
+		}
+		nonNegative(r, "push.inbox.batch-size", c.Inbox.BatchSize)
+		if !c.Enabled {
+			return
+		}
+		r.Required("push.token-key", c.TokenKey)
+		if c.TokenTTL > push.MaxTokenTTL {
+			r.Add("push.token-ttl", "不能超过 %s: %s", push.MaxTokenTTL, c.TokenTTL)
+		}
+		if !v.GetBool("gateway.enabled") {
+			r.Add("push.enabled", "开启推送网关时必须同时开启 gateway")
+		}
+	}),
+	section("watch", func(_ *viper.Viper, c config.WatchConfig, r *config.Report) {
+		nonNegative(r, "watch.max-concurrent", c.MaxConcurrent)
+		if c.MaxDuration > 0 && c.PollInterval >= c.MaxDuration {
+			r.Add("watch.poll-interval", "必须小于 max-duration: %s", c.MaxDuration)
+		}
+	}),
+	section("unsubscribe", func(v *viper.Viper, c config.UnsubscribeConfig, r *config.Report) {
+		if !c.Enabled {
+			return
+		}
+		r.Required("unsubscribe.token-key", c.TokenKey)
+		r.Required("unsubscribe.base-url", c.BaseURL)
+		if c.TokenTTL < 0 {
+			r.Add("unsubscribe.token-ttl", "不能小于 0")
+		}
+		if !v.GetBool("gateway.enabled") {
+			r.Add("unsubscribe.enabled", "开启退订时必须同时开启 gateway")
+		}
+	}),
+	section[config.EscalationConfig]("escalation", nil),
+	section("digest", func(_ *viper.Viper, c config.DigestConfig, r *config.Report) {
+		recoverProblem(r, "digest.policies", func() { digestPolicies(c) })
+	}),
+	section("local-time", func(_ *viper.Viper, c config.LocalTimeConfig, r *config.Report) {
+		if c.Lookup.URL == "" {
+			return
+		}
+		if u, err := url.Parse(c.Lookup.URL); err != nil || u.Host == "" {
+			r.Add("local-time.lookup.url", "不合法: %q", c.Lookup.URL)
+		}
+	}),
+	section("fallback", func(_ *viper.Viper, c config.FallbackConfig, r *config.Report) {
+		recoverProblem(r, "fallback.policies", func() { fallbackPolicies(c) })
+	}),
+	section("startup", func(_ *viper.Viper, c config.StartupConfig, r *config.Report) {
+		for _, d := range []struct {
+			key   string
+			value time.Duration
+		}{
+			{"startup.max-wait", c.MaxWait},
+			{"startup.initial-backoff", c.InitialBackoff},
+			{"startup.max-backoff", c.MaxBackoff},
+			{"startup.attempt-timeout", c.AttemptTimeout},
+		} {
+			if d.value < 0 {
+				r.Add(d.key, "不能小于 0")
+			}
+		}
+	}),
+	section("debug-server", func(_ *viper.Viper, c config.DebugServerConfig, r *config.Report) {
+		if c.Addr == "" {
+			c.Addr = defaultDebugServerAddr
+		}
+		recoverProblem(r, "debug-server", func() { debugServerAllowed(c) })
+	}),
+	section("sms-vendors", func(_ *viper.Viper, confs []config.SMSVendorConfig, r *config.Report) {
+		names := make([]string, 0, len(confs))
+		for i, c := range confs {
+			key := fmt.Sprintf("sms-vendors[%d]", i)
+			if c.Name == "" || slices.Contains(names, c.Name) {
+				r.Add(key+".name", "为空或者重复: %q", c.Name)
+			}
+			names = append(names, c.Name)
+			r.OneOf(key+".type", c.Type, "aliyun", "tencent")
+			nonNegative(r, key+".http.max-idle-conns", c.HTTP.MaxIdleConns)
+			nonNegative(r, key+".http.max-conns", c.HTTP.MaxConns)
+			if c.HTTP.Proxy != "" {
+				if u, err := url.Parse(c.HTTP.Proxy); err != nil || u.Host == "" {
+					r.Add(key+".http.proxy", "代理地址不合法: %q", c.HTTP.Proxy)
+				}
+			}
+		}
+	}),
+	section("template-review", func(_ *viper.Viper, c config.TemplateReviewConfig, r *config.Report) {
+		recoverProblem(r, "template-review.notify", func() { templateReviewNotice(c.Notify) })
+	}),
+	section[config.VendorBalanceConfig]("vendor-balance", nil),
+	section("label-metrics", func(_ *viper.Viper, c config.LabelMetricsConfig, r *config.Report) {
+		nonNegative(r, "label-metrics.max-values", c.MaxValues)
+	}),
+}
+
+// ValidateConfig 启动前校验整个配置：必填项、取值范围、枚举值和拼写错误的配置项，
+// 一次返回所有错误，返回的错误是 *config.ValidationError
+func ValidateConfig(v *viper.Viper) error {
+	r := &config.Report{}
+	known := slices.Clone(looseConfigKeys)
+	for _, s := range configSections {
+		known = append(known, s.key)
+		s.check(v, r)
+	}
+	top := make([]string, 0)
+	for k := range v.AllSettings() {
+		top = append(top, k)
+	}
+	slices.Sort(top)
+	for _, k := range top {
+		if !slices.Contains(known, k) {
+			r.Add(k, "未知的配置项")
+		}
+	}
+	return r.Err()
+}
+
+func checkChannel(r *config.Report, key, channel string) {
+	if !domain.Channel(channel).IsValid() {
+		r.Add(key, "渠道 %q 不合法", channel)
+	}
+}
+
+func nonNegative(r *config.Report, key string, value int) {
+	if value < 0 {
+		r.Add(key, "不能小于 0: %d", value)
+	}
+}
+
+// recoverProblem 复用初始化时 panic 的校验逻辑，panic 记录成 key 下的错误
+func recoverProblem(r *config.Report, key string, fn func()) {
+	defer func() {
+		if e := recover(); e != nil {
+			r.Add(key, "%v", e)
+		}
+	}()
+	fn()
+}
@@ -1,25 +1,178 @@
 package ioc
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/database/failover"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/database/metrics"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/database/tracing"
 	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao/migrations"
 	"github.com/spf13/viper"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+const (
+	defaultDatabaseReadRetries    = 2
+	defaultDatabaseRetryBackoff   = 50 * time.Millisecond
+	defaultDatabaseDegradedWindow = time.Minute
+	// maxDatabaseReadRetries 重试太多会让请求在数据库不可用时卡住很久
+	maxDatabaseReadRetries = 10
+)
+
 func InitDB() *gorm.DB {
-	db, err := gorm.Open(mysql.Open(viper.GetString("mysql.dsn")), &gorm.Config{})
+	conf, err := LoadDatabaseConfig()
 	if err != nil {
 		panic(err)
 	}
-	dao.InitTable(db)
+	health := failover.NewHealth("notification", len(conf.Failover.DSNs)+1, conf.Failover.DegradedWindow)
+	dialector, err := newDialector(conf, health)
+	if err != nil {
+		panic(err)
+	}
+	// TranslateError 让驱动把唯一索引冲突等错误统一翻译成 gorm 的错误
+	db, err := gorm.Open(dialector, &gorm.Config{TranslateError: true})
+	if err != nil {
+		panic(err)
+	}
+	// 打开时已经连上了某个地址，主地址不可用时在正在使用的备用地址上校验结构版本
+	checkSchema(activeDatabaseConfig(conf, health.Active()))
 	if err = db.Use(metrics.NewGormMetricsPlugin()); err != nil {
 		panic(err)
 	}
-	if err = db.Use(tracing.NewGormTracingPlugin()); err != nil {
+	// 连接池使用情况，压测时用来判断连接池是否打满
+	sqlDB, err := db.DB()
+	if err != nil {
 		panic(err)
 	}
+	_ = prometheus.Register(collectors.NewDBStatsCollector(sqlDB, "notification"))
+	if err = db.Use(tracing.NewGormTracingPlugin(tracing.StatementOptions{
+		Disabled:      conf.Tracing.DisableStatement,
+		MaxLength:     conf.Tracing.MaxStatementLength,
+		Args:          conf.Tracing.Args,
+		SlowThreshold: conf.Tracing.SlowThreshold,
+	})); err != nil {
+		panic(err)
+	}
+	// 最后替换连接池，前面的插件只注册回调，不关心连接池的类型
+	pool := failover.NewPool(sqlDB, health, failover.PoolOptions{
+		ReadRetries:  conf.Failover.ReadRetries,
+		RetryBackoff: conf.Failover.RetryBackoff,
+	})
+	db.ConnPool, db.Statement.ConnPool = pool, pool
+	failover.Register(health)
 	return db
 }
+
+// InitNotificationDAO 通知DAO，按ID批量查询的分片参数来自 database.batch-query
+func InitNotificationDAO(db *gorm.DB, clk clock.Clock) dao.NotificationDAO {
+	conf, err := LoadDatabaseConfig()
+	if err != nil {
+		panic(err)
+	}
+	return dao.NewNotificationDAOWithChunk(db, clk, conf.BatchQuery.ChunkSize, conf.BatchQuery.Concurrency)
+}
+
+// LoadDatabaseConfig 读取数据库配置
+func LoadDatabaseConfig() (config.DatabaseConfig, error) {
+	conf := config.DatabaseConfig{}
+	if err := viper.UnmarshalKey("database", &conf, config.TagName("yaml")); err != nil {
+		return conf, err
+	}
+	// 兼容旧配置
+	if conf.DSN == "" {
+		conf.DSN = viper.GetString("mysql.dsn")
+	}
+	switch {
+	case conf.Failover.ReadRetries == 0:
+		conf.Failover.ReadRetries = defaultDatabaseReadRetries
+	case conf.Failover.ReadRetries < 0:
+		conf.Failover.ReadRetries = 0
+	}
+	if conf.Failover.RetryBackoff <= 0 {
+		conf.Failover.RetryBackoff = defaultDatabaseRetryBackoff
+	}
+	if conf.Failover.DegradedWindow <= 0 {
+		conf.Failover.DegradedWindow = defaultDatabaseDegradedWindow
+	}
+	return conf, nil
+}
+
+// activeDatabaseConfig 把 dsn 换成第 idx 个地址，0 是 dsn 本身
+func activeDatabaseConfig(conf config.DatabaseConfig, idx int) config.DatabaseConfig {
+	if idx > 0 && idx <= len(conf.Failover.DSNs) {
+		conf.DSN = conf.Failover.DSNs[idx-1]
+	}
+	return conf
+}
+
+// checkSchema 启动时校验数据库结构版本，开启 auto-migrate 时先执行迁移
+func checkSchema(conf config.DatabaseConfig) {
+	migrator, err := migrations.New(conf)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		_ = migrator.Close()
+	}()
+	if conf.AutoMigrate {
+		if err = migrator.Up(); err != nil {
+			panic(fmt.Errorf("执行数据库迁移失败: %w", err))
+		}
+	}
+	if err = migrator.Check(); err != nil {
+		panic(fmt.Errorf("%w，请先执行 migrate up", err))
+	}
+}
+
+// newDialector 配置了备用地址时通过 failover.Connector 连接，每次建立连接按顺序尝试各个地址
+func newDialector(conf config.DatabaseConfig, health *failover.Health) (gorm.Dialector, error) {
+	switch conf.Driver {
+	case "", config.DriverMySQL:
+		if len(conf.Failover.DSNs) == 0 {
+			return mysql.Open(conf.DSN), nil
+		}
+		sqlDB, err := openFailoverDB(gomysql.MySQLDriver{}, conf, health)
+		if err != nil {
+			return nil, err
+		}
+		return mysql.New(mysql.Config{DSN: conf.DSN, Conn: sqlDB}), nil
+	case config.DriverPostgres:
+		if len(conf.Failover.DSNs) == 0 {
+			return postgres.Open(conf.DSN), nil
+		}
+		sqlDB, err := openFailoverDB(stdlib.GetDefaultDriver().(driver.DriverContext), conf, health)
+		if err != nil {
+			return nil, err
+		}
+		return postgres.New(postgres.Config{DSN: conf.DSN, Conn: sqlDB}), nil
+	default:
+		return nil, fmt.Errorf("不支持的数据库驱动: %s", conf.Driver)
+	}
+}
+
+func openFailoverDB(drv driver.DriverContext, conf config.DatabaseConfig, health *failover.Health) (*sql.DB, error) {
+	dsns := append([]string{conf.DSN}, conf.Failover.DSNs...)
+	connectors := make([]driver.Connector, 0, len(dsns))
+	for i, dsn := range dsns {
+		c, err := drv.OpenConnector(dsn)
+		if err != nil {
+			// 不输出 DSN，里面有密码
+			return nil, fmt.Errorf("解析第 %d 个数据库地址失败: %w", i, err)
+		}
+		connectors = append(connectors, c)
+	}
+	return sql.OpenDB(failover.NewConnector(connectors, health)), nil
+}
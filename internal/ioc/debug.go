@@ -0,0 +1,50 @@
+package ioc
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/serendipityConfusion/notification-platform/internal/api/debug"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/spf13/viper"
+)
+
+const defaultDebugServerAddr = "127.0.0.1:6060"
+
+// InitDebugServer pprof 和运行时指标，没有开启时返回 nil
+func InitDebugServer() *debug.Server {
+	conf := loadDebugServerConfig()
+	if !conf.Enabled {
+		return nil
+	}
+	return debug.NewServer(conf.Addr, debugServerAllowed(conf))
+}
+
+func loadDebugServerConfig() config.DebugServerConfig {
+	conf := config.DebugServerConfig{}
+	if err := viper.UnmarshalKey("debug-server", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Addr == "" {
+		conf.Addr = defaultDebugServerAddr
+	}
+	return conf
+}
+
+// debugServerAllowed pprof 可以读取内存里的数据，默认只监听本机回环地址，对外监听时必须限制来源地址，配置错误直接 panic
+func debugServerAllowed(conf config.DebugServerConfig) []*net.IPNet {
+	allowed, err := debug.ParseCIDRs(conf.AllowedCIDRs)
+	if err != nil {
+		panic(fmt.Errorf("debug-server.allowed-cidrs 配置错误: %w", err))
+	}
+	if debug.IsLoopbackAddr(conf.Addr) {
+		return allowed
+	}
+	if !conf.AllowNonLoopback {
+		panic(fmt.Errorf("debug-server.addr %s 不是本机回环地址，需要开启 allow-non-loopback", conf.Addr))
+	}
+	if len(allowed) == 0 {
+		panic(fmt.Errorf("debug-server 监听在 %s 时必须配置 allowed-cidrs", conf.Addr))
+	}
+	return allowed
+}
@@ -0,0 +1,74 @@
+package ioc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultDigestInterval  = 10 * time.Second
+	defaultDigestBatchSize = 100
+	// maxDigestWindow 合并窗口最长一天，再长就失去了通知的意义
+	maxDigestWindow = 24 * time.Hour
+)
+
+func loadDigestConfig() config.DigestConfig {
+	conf := config.DigestConfig{}
+	if err := viper.UnmarshalKey("digest", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultDigestInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultDigestBatchSize
+	}
+	return conf
+}
+
+// digestPolicies 业务方的合并策略，配置错误直接 panic
+func digestPolicies(conf config.DigestConfig) []domain.DigestPolicy {
+	policies := make([]domain.DigestPolicy, 0, len(conf.Policies))
+	seen := make(map[string]struct{}, len(conf.Policies))
+	for _, p := range conf.Policies {
+		channel := domain.Channel(p.Channel)
+		if p.BizID <= 0 || !channel.IsValid() {
+			panic(fmt.Errorf("合并策略的 biz-id 或 channel 不合法: %d %q", p.BizID, p.Channel))
+		}
+		if p.Window < time.Minute || p.Window > maxDigestWindow {
+			panic(fmt.Errorf("业务方 %d 的 %s 渠道合并窗口必须在 1m 到 %s 之间: %s", p.BizID, channel, maxDigestWindow, p.Window))
+		}
+		if p.TemplateID <= 0 {
+			panic(fmt.Errorf("业务方 %d 的 %s 渠道没有配置摘要模板", p.BizID, channel))
+		}
+		k := fmt.Sprintf("%d:%s", p.BizID, channel)
+		if _, ok := seen[k]; ok {
+			panic(fmt.Errorf("业务方 %d 的 %s 渠道配置了多个合并策略", p.BizID, channel))
+		}
+		seen[k] = struct{}{}
+		policies = append(policies, domain.DigestPolicy{
+			BizID:      p.BizID,
+			Channel:    channel,
+			Window:     p.Window,
+			TemplateID: p.TemplateID,
+		})
+	}
+	return policies
+}
+
+// InitDigestService 合并发送
+func InitDigestService(repo repository.DigestRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc service.ChannelTemplateService,
+	idGenerator idgen.Generator, clk clock.Clock,
+) service.DigestService {
+	return service.NewDigestService(repo, notificationRepo, templateSvc, idGenerator, clk, digestPolicies(loadDigestConfig()))
+}
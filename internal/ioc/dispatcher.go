@@ -0,0 +1,62 @@
+package ioc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/workpool"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultPoolWorkers   = 8
+	defaultPoolQueueSize = 256
+	defaultSendTimeout   = time.Minute
+)
+
+// InitPooledDispatcher 按渠道和供应商创建发送协程池，配置错误直接 panic
+func InitPooledDispatcher(repo repository.NotificationRepository, sender service.NotificationSender,
+	selector provider.Selector, clk clock.Clock,
+) *service.PooledDispatcher {
+	conf := config.DispatcherConfig{}
+	if err := viper.UnmarshalKey("dispatcher", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	channelConf := make(map[domain.Channel]config.ChannelPoolConfig, len(conf.Channels))
+	for _, c := range conf.Channels {
+		ch := domain.Channel(c.Channel)
+		if !ch.IsValid() {
+			panic(fmt.Errorf("发送协程池配置错误: 渠道 %s 不合法", c.Channel))
+		}
+		channelConf[ch] = c
+	}
+	channelPools := make(map[domain.Channel]*workpool.Pool)
+	for _, ch := range []domain.Channel{domain.ChannelSMS, domain.ChannelEmail, domain.ChannelInApp} {
+		c := channelConf[ch]
+		channelPools[ch] = newPool("channel:"+ch.String(), c.Workers, c.QueueSize)
+	}
+	providerPools := make(map[string]*workpool.Pool, len(conf.Providers))
+	for _, p := range conf.Providers {
+		providerPools[p.Provider] = newPool("provider:"+p.Provider, p.Workers, p.QueueSize)
+	}
+	if conf.SendTimeout <= 0 {
+		conf.SendTimeout = defaultSendTimeout
+	}
+	return service.NewPooledDispatcher(repo, sender, selector, channelPools, providerPools, conf.SendTimeout, clk)
+}
+
+func newPool(name string, workers, queueSize int) *workpool.Pool {
+	if workers <= 0 {
+		workers = defaultPoolWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = defaultPoolQueueSize
+	}
+	return workpool.New(name, workers, queueSize)
+}
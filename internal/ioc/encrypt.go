@@ -0,0 +1,52 @@
+package ioc
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/spf13/viper"
+)
+
+func loadEncryptionConfig() config.EncryptionConfig {
+	conf := config.EncryptionConfig{}
+	if err := viper.UnmarshalKey("encryption", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+// InitFieldCipher 敏感字段加密，未开启时明文存储
+func InitFieldCipher() encrypt.Cipher {
+	conf := loadEncryptionConfig()
+	if !conf.Enabled {
+		return encrypt.NewNoopCipher()
+	}
+	keys := make([]encrypt.Key, 0, len(conf.Keys))
+	for _, k := range conf.Keys {
+		secret, err := base64.StdEncoding.DecodeString(k.Secret)
+		if err != nil {
+			panic(fmt.Errorf("解析加密密钥 %s 失败: %w", k.ID, err))
+		}
+		keys = append(keys, encrypt.Key{ID: k.ID, Secret: secret})
+	}
+	provider, err := encrypt.NewStaticKeyProvider(conf.ActiveKey, keys)
+	if err != nil {
+		panic(err)
+	}
+	return encrypt.NewAESGCMCipher(provider)
+}
+
+// InitBlindIndexer 盲索引，开启加密时必须配置盲索引密钥
+func InitBlindIndexer() encrypt.BlindIndexer {
+	conf := loadEncryptionConfig()
+	key, err := base64.StdEncoding.DecodeString(conf.BlindIndexKey)
+	if err != nil {
+		panic(fmt.Errorf("解析盲索引密钥失败: %w", err))
+	}
+	if conf.Enabled && len(key) < 32 {
+		panic(fmt.Errorf("盲索引密钥至少 32 字节，当前 %d 字节", len(key)))
+	}
+	return encrypt.NewHMACBlindIndexer(key)
+}
@@ -4,13 +4,22 @@ import (
 	"time"
 
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/registry"
 	"github.com/spf13/viper"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 func InitEtcdClient() *clientv3.Client {
-	cfg := &config.EtcdConfig{}
-	err := viper.UnmarshalKey("etcd", cfg, viper.DecodeHook(viper.DecoderConfigOption(config.TagName("yaml"))))
+	client, err := newEtcdClient(loadEtcdConfig())
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func loadEtcdConfig() config.EtcdConfig {
+	cfg := config.EtcdConfig{}
+	err := viper.UnmarshalKey("etcd", &cfg, config.TagName("yaml"))
 	if err != nil {
 		panic(err)
 	}
@@ -19,15 +28,32 @@ func InitEtcdClient() *clientv3.Client {
 	if cfg.DialTimeout == 0 {
 		cfg.DialTimeout = 5 * time.Second
 	}
+	return cfg
+}
 
-	client, err := clientv3.New(clientv3.Config{
-		Endpoints:   cfg.Endpoints,
-		DialTimeout: cfg.DialTimeout,
-		Username:    cfg.Username,
-		Password:    cfg.Password,
-	})
-	if err != nil {
-		panic(err)
+// newEtcdClient 服务注册、发现以及其他使用 etcd 的组件共用同样的连接配置
+func newEtcdClient(cfg config.EtcdConfig) (*clientv3.Client, error) {
+	return registry.NewEtcdClient(etcdRegistryConfig(cfg))
+}
+
+func etcdRegistryConfig(cfg config.EtcdConfig) *registry.EtcdConfig {
+	res := &registry.EtcdConfig{
+		Endpoints:        cfg.Endpoints,
+		DialTimeout:      cfg.DialTimeout,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		AutoSyncInterval: cfg.AutoSyncInterval,
+		KeepaliveTime:    cfg.KeepaliveTime,
+		KeepaliveTimeout: cfg.KeepaliveTimeout,
+		RequestTimeout:   cfg.RequestTimeout,
 	}
-	return client
+	if cfg.TLS.Enabled() {
+		res.TLS = &registry.EtcdTLSConfig{
+			CertFile:   cfg.TLS.CertFile,
+			KeyFile:    cfg.TLS.KeyFile,
+			CAFile:     cfg.TLS.CAFile,
+			ServerName: cfg.TLS.ServerName,
+		}
+	}
+	return res
 }
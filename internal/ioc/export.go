@@ -0,0 +1,141 @@
+package ioc
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/olap"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultExportName      = "clickhouse"
+	defaultExportInterval  = 10 * time.Second
+	defaultExportBatchSize = 1000
+	defaultExportDelay     = 5 * time.Second
+	defaultClickHouseTable = "notification_events"
+	defaultClickHouseDB    = "notification"
+	defaultClickHouseTTL   = 30 * time.Second
+	defaultKafkaExportName = "kafka"
+	defaultKafkaTopic      = "notification-lifecycle"
+	defaultKafkaTimeout    = 10 * time.Second
+
+	defaultExportStreamBatchSize     = 500
+	defaultExportStreamRowsPerSecond = 5000
+	defaultExportStreamMaxConcurrent = 4
+)
+
+func loadExportConfig() config.ExportConfig {
+	conf := config.ExportConfig{}
+	if err := viper.UnmarshalKey("export", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Name == "" {
+		conf.Name = defaultExportName
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultExportInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultExportBatchSize
+	}
+	if conf.Delay <= 0 {
+		conf.Delay = defaultExportDelay
+	}
+	return conf
+}
+
+// initExportTask 导出任务，没有开启时返回 nil，分析库配置错误直接 panic
+func initExportTask(repo repository.ExportRepository, lock distribute_lock.Client) Task {
+	conf := loadExportConfig()
+	if !conf.Enabled {
+		return nil
+	}
+	ch := conf.ClickHouse
+	if ch.Database == "" {
+		ch.Database = defaultClickHouseDB
+	}
+	if ch.Table == "" {
+		ch.Table = defaultClickHouseTable
+	}
+	if ch.Timeout <= 0 {
+		ch.Timeout = defaultClickHouseTTL
+	}
+	sink, err := olap.NewClickHouseSink(&http.Client{Timeout: ch.Timeout}, olap.ClickHouseOptions{
+		Endpoint: ch.Endpoint,
+		Database: ch.Database,
+		Table:    ch.Table,
+		Username: ch.Username,
+		Password: ch.Password,
+	})
+	if err != nil {
+		panic(fmt.Errorf("初始化分析库导出失败: %w", err))
+	}
+	return service.NewExportTask(repo, sink, lock, service.ExportOptions{
+		Name:      conf.Name,
+		Interval:  conf.Interval,
+		BatchSize: conf.BatchSize,
+		Delay:     conf.Delay,
+	})
+}
+
+// initKafkaExportTask 把通知状态变化发布到 Kafka，和分析库导出是两个独立的任务，各自保存进度
+func initKafkaExportTask(repo repository.ExportRepository, lock distribute_lock.Client) Task {
+	conf := loadExportConfig()
+	kc := conf.Kafka
+	if !kc.Enabled {
+		return nil
+	}
+	if kc.Name == "" {
+		kc.Name = defaultKafkaExportName
+	}
+	if kc.Topic == "" {
+		kc.Topic = defaultKafkaTopic
+	}
+	if kc.Timeout <= 0 {
+		kc.Timeout = defaultKafkaTimeout
+	}
+	publisher, err := eventbus.NewLifecyclePublisher(&http.Client{Timeout: kc.Timeout}, eventbus.KafkaOptions{
+		Endpoint: kc.Endpoint,
+		Topic:    kc.Topic,
+		Username: kc.Username,
+		Password: kc.Password,
+	})
+	if err != nil {
+		panic(fmt.Errorf("初始化 Kafka 事件发布失败: %w", err))
+	}
+	return service.NewExportTask(repo, publisher, lock, service.ExportOptions{
+		Name:      kc.Name,
+		Interval:  conf.Interval,
+		BatchSize: conf.BatchSize,
+		Delay:     conf.Delay,
+	})
+}
+
+// InitNotificationExportService 业务方流式导出通知
+func InitNotificationExportService(repo repository.NotificationRepository) service.NotificationExportService {
+	conf := config.ExportStreamConfig{}
+	if err := viper.UnmarshalKey("export.stream", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultExportStreamBatchSize
+	}
+	if conf.RowsPerSecond == 0 {
+		conf.RowsPerSecond = defaultExportStreamRowsPerSecond
+	}
+	if conf.MaxConcurrent <= 0 {
+		conf.MaxConcurrent = defaultExportStreamMaxConcurrent
+	}
+	return service.NewNotificationExportService(repo, service.NotificationExportOptions{
+		BatchSize:     conf.BatchSize,
+		RowsPerSecond: max(conf.RowsPerSecond, 0),
+		MaxConcurrent: conf.MaxConcurrent,
+	})
+}
@@ -0,0 +1,48 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+// InitFallbackService 渠道降级分组，配置错误直接 panic
+func InitFallbackService(repo repository.NotificationRepository) service.FallbackService {
+	conf := config.FallbackConfig{}
+	if err := viper.UnmarshalKey("fallback", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	return service.NewFallbackService(repo, fallbackPolicies(conf))
+}
+
+func fallbackPolicies(conf config.FallbackConfig) map[int64]domain.FallbackPolicy {
+	policies := make(map[int64]domain.FallbackPolicy, len(conf.Policies))
+	for _, p := range conf.Policies {
+		if p.BizID <= 0 {
+			panic(fmt.Errorf("渠道降级顺序的 biz-id 不合法: %d", p.BizID))
+		}
+		if _, ok := policies[p.BizID]; ok {
+			panic(fmt.Errorf("业务方 %d 配置了多个渠道降级顺序", p.BizID))
+		}
+		if len(p.Channels) < 2 {
+			panic(fmt.Errorf("业务方 %d 的渠道降级顺序至少要有两个渠道", p.BizID))
+		}
+		policy := domain.FallbackPolicy{BizID: p.BizID}
+		for _, c := range p.Channels {
+			ch := domain.Channel(c)
+			if !ch.IsValid() {
+				panic(fmt.Errorf("业务方 %d 的渠道降级顺序里有未知渠道: %q", p.BizID, c))
+			}
+			if _, ok := policy.Rank(ch); ok {
+				panic(fmt.Errorf("业务方 %d 的渠道降级顺序里渠道 %s 重复", p.BizID, c))
+			}
+			policy.Channels = append(policy.Channels, ch)
+		}
+		policies[p.BizID] = policy
+	}
+	return policies
+}
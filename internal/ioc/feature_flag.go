@@ -0,0 +1,45 @@
+package ioc
+
+import (
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const defaultFeatureFlagEtcdPrefix = "/notification-platform/feature-flags/"
+
+func loadFeatureFlagConfig() config.FeatureFlagConfig {
+	conf := config.FeatureFlagConfig{}
+	if err := viper.UnmarshalKey("feature-flags", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Etcd.Prefix == "" {
+		conf.Etcd.Prefix = defaultFeatureFlagEtcdPrefix
+	}
+	return conf
+}
+
+// InitFeatureFlags 功能开关，配置文件里的规则
+func InitFeatureFlags() *featureflag.Flags {
+	conf := loadFeatureFlagConfig()
+	rules := make(map[string]featureflag.Rule, len(conf.Flags))
+	for name, r := range conf.Flags {
+		rules[name] = featureflag.Rule{
+			Enabled:     r.Enabled,
+			Percent:     r.Percent,
+			AllowBizIDs: r.AllowBizIDs,
+			DenyBizIDs:  r.DenyBizIDs,
+		}
+	}
+	return featureflag.New(rules)
+}
+
+// InitFeatureFlagWatcher 没有开启 feature-flags.etcd 时返回 nil
+func InitFeatureFlagWatcher(client *clientv3.Client, flags *featureflag.Flags) *featureflag.EtcdWatcher {
+	conf := loadFeatureFlagConfig().Etcd
+	if !conf.Enabled {
+		return nil
+	}
+	return featureflag.NewEtcdWatcher(client, conf.Prefix, flags)
+}
@@ -0,0 +1,84 @@
+package ioc
+
+import (
+	"net"
+
+	"github.com/serendipityConfusion/notification-platform/internal/api/audit"
+	"github.com/serendipityConfusion/notification-platform/internal/api/gateway"
+	"github.com/serendipityConfusion/notification-platform/internal/api/graphql"
+	"github.com/serendipityConfusion/notification-platform/internal/api/push"
+	"github.com/serendipityConfusion/notification-platform/internal/api/unsubscribe"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+const defaultGatewayAddr = ":8081"
+
+// InitGateway HTTP/JSON 网关，没有开启时返回 nil
+// graphqlHandler 不为 nil 时挂载在 /graphql，pushHandler 不为 nil 时挂载在 /v1/push/ws，inboxHandler 不为 nil 时挂载在 /v1/push/inbox，
+// auditHandler 不为 nil 时挂载在 /v1/providers/{provider}/template-audit，
+// unsubscribeHandler 不为 nil 时挂载在 /v1/unsubscribe，smsReplyHandler 不为 nil 时挂载在 /v1/providers/{provider}/sms-reply
+func InitGateway(graphqlHandler *graphql.Handler, pushHandler *push.Handler, inboxHandler *push.InboxHandler, auditHandler *audit.Handler,
+	unsubscribeHandler *unsubscribe.Handler, smsReplyHandler *unsubscribe.SMSReplyHandler,
+) *gateway.Server {
+	conf := config.GatewayConfig{}
+	if err := viper.UnmarshalKey("gateway", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if !conf.Enabled {
+		return nil
+	}
+	if conf.Addr == "" {
+		conf.Addr = defaultGatewayAddr
+	}
+	grpcConf := loadGrpcConfig()
+	// 网关转发的请求和响应大小限制和 gRPC 服务保持一致
+	var callOpts []grpc.CallOption
+	if grpcConf.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(grpcConf.MaxRecvMsgSize))
+	}
+	if grpcConf.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(grpcConf.MaxSendMsgSize))
+	}
+	server, err := gateway.NewServer(conf.Addr, loopbackEndpoint(grpcConf.Addr), callOpts...)
+	if err != nil {
+		panic(err)
+	}
+	if graphqlHandler != nil {
+		server.Handle("/graphql", graphqlHandler)
+	}
+	if pushHandler != nil {
+		server.Handle("GET /v1/push/ws", pushHandler)
+	}
+	if inboxHandler != nil {
+		server.Handle("GET /v1/push/inbox", inboxHandler)
+	}
+	if auditHandler != nil {
+		server.Handle("POST /v1/providers/{provider}/template-audit", auditHandler)
+	}
+	if unsubscribeHandler != nil {
+		server.Handle(unsubscribe.Path, unsubscribeHandler)
+	}
+	if smsReplyHandler != nil {
+		server.Handle("POST /v1/providers/{provider}/sms-reply", smsReplyHandler)
+	}
+	return server
+}
+
+// InitQueryGateway 只读查询角色的网关，只挂载 GraphQL，推送、回调这类写入的入口由接口角色提供
+func InitQueryGateway(graphqlHandler *graphql.Handler) *gateway.Server {
+	return InitGateway(graphqlHandler, nil, nil, nil, nil, nil)
+}
+
+// loopbackEndpoint gRPC 监听在所有网卡上时，网关通过本机回环地址访问
+func loopbackEndpoint(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
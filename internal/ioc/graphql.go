@@ -0,0 +1,50 @@
+package ioc
+
+import (
+	"github.com/serendipityConfusion/notification-platform/internal/api/graphql"
+	grpcapi "github.com/serendipityConfusion/notification-platform/internal/api/grpc"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/auth"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+// InitGraphQL 只读 GraphQL 接口，没有开启时返回 nil，开启时必须同时开启网关
+func InitGraphQL(notificationRepo repository.NotificationRepository,
+	callbackLogRepo repository.CallbackLogRepository,
+	quotaRepo repository.QuotaRepository,
+	rbacSvc service.RBACService,
+) *graphql.Handler {
+	conf := config.GraphQLConfig{}
+	if err := viper.UnmarshalKey("graphql", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if !conf.Enabled {
+		return nil
+	}
+	gatewayConf := config.GatewayConfig{}
+	if err := viper.UnmarshalKey("gateway", &gatewayConf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if !gatewayConf.Enabled {
+		panic("开启 graphql 时必须开启 gateway")
+	}
+	routeConf := loadProviderRoutingConfig()
+	routes := make([]graphql.ProviderRoute, 0, len(routeConf.Routes))
+	for _, r := range routeConf.Routes {
+		routes = append(routes, graphql.ProviderRoute{
+			Channel:       domain.Channel(r.Channel),
+			Providers:     r.Providers,
+			Shadow:        r.Shadow,
+			ShadowPercent: r.ShadowPercent,
+		})
+	}
+	// 接口 nil 和指针 nil 不同，没有开启鉴权时必须传字面量 nil
+	var authenticator graphql.Authenticator
+	if authConf := loadAuthConfig(); authConf.Enabled {
+		authenticator = auth.New([]byte(authConf.JWTKey), rbacSvc, grpcapi.MethodPermissions)
+	}
+	return graphql.NewHandler(notificationRepo, callbackLogRepo, quotaRepo, routes, authenticator)
+}
@@ -1,15 +1,232 @@
 package ioc
 
 import (
+	"fmt"
+	"time"
+
+	configv1 "github.com/serendipityConfusion/notification-platform/api/gen/config/v1"
 	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
 	grpcapi "github.com/serendipityConfusion/notification-platform/internal/api/grpc"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/auth"
 	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/log"
 	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/metrics"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/querylimit"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/requestctx"
+	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/timeout"
 	"github.com/serendipityConfusion/notification-platform/internal/api/grpc/interceptor/tracing"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	channelzsvc "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 )
 
-func InitGrpc(noserver *grpcapi.NotificationServer) *grpc.Server {
+const defaultGrpcTimeout = 5 * time.Second
+
+func loadGrpcConfig() config.GrpcConfig {
+	conf := config.GrpcConfig{}
+	if err := viper.UnmarshalKey("notification-server", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Timeout.Default == 0 {
+		conf.Timeout.Default = defaultGrpcTimeout
+	}
+	return conf
+}
+
+// grpcServerOptions 消息大小、并发和保活配置，配置不合法直接 panic
+func grpcServerOptions(conf config.GrpcConfig) []grpc.ServerOption {
+	if err := validateGrpcConfig(conf); err != nil {
+		panic(fmt.Errorf("notification-server 配置错误: %w", err))
+	}
+	var opts []grpc.ServerOption
+	if conf.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(conf.MaxRecvMsgSize))
+	}
+	if conf.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(conf.MaxSendMsgSize))
+	}
+	if conf.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(conf.MaxConcurrentStreams))
+	}
+	k := conf.Keepalive
+	params := keepalive.ServerParameters{
+		MaxConnectionIdle:     k.MaxConnectionIdle,
+		MaxConnectionAge:      k.MaxConnectionAge,
+		MaxConnectionAgeGrace: k.MaxConnectionAgeGrace,
+		Time:                  k.Time,
+		Timeout:               k.Timeout,
+	}
+	// 连接到期时给进行中的请求留足时间，客户端收到 GOAWAY 后在新连接上重试的请求不会和旧请求重叠
+	if params.MaxConnectionAge > 0 && params.MaxConnectionAgeGrace == 0 {
+		params.MaxConnectionAgeGrace = maxGrpcTimeout(conf.Timeout)
+	}
+	opts = append(opts, grpc.KeepaliveParams(params))
+	if k.MinTime > 0 || k.PermitWithoutStream {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             k.MinTime,
+			PermitWithoutStream: k.PermitWithoutStream,
+		}))
+	}
+	return opts
+}
+
+func validateGrpcConfig(conf config.GrpcConfig) error {
+	if conf.MaxRecvMsgSize < 0 || conf.MaxSendMsgSize < 0 {
+		return fmt.Errorf("max-recv-msg-size 和 max-send-msg-size 不能小于 0")
+	}
+	k := conf.Keepalive
+	for name, d := range map[string]time.Duration{
+		"time":                     k.Time,
+		"timeout":                  k.Timeout,
+		"min-time":                 k.MinTime,
+		"max-connection-idle":      k.MaxConnectionIdle,
+		"max-connection-age":       k.MaxConnectionAge,
+		"max-connection-age-grace": k.MaxConnectionAgeGrace,
+	} {
+		if d < 0 {
+			return fmt.Errorf("keepalive.%s 不能小于 0", name)
+		}
+	}
+	// gRPC 会把小于 1s 的值悄悄改成 1s
+	if k.Time > 0 && k.Time < time.Second {
+		return fmt.Errorf("keepalive.time 不能小于 1s")
+	}
+	if k.MaxConnectionAgeGrace > 0 && k.MaxConnectionAge == 0 {
+		return fmt.Errorf("配置了 keepalive.max-connection-age-grace 时必须配置 keepalive.max-connection-age")
+	}
+	return validateQueryLimits(conf.QueryLimits)
+}
+
+// validateQueryLimits 只能限制登记过的查询接口，同一个接口和业务方只能配置一次
+func validateQueryLimits(limits []config.GrpcQueryLimitConfig) error {
+	seen := make(map[string]struct{}, len(limits))
+	for i, l := range limits {
+		counter, ok := grpcapi.QueryMethods[l.Method]
+		if !ok {
+			return fmt.Errorf("query-limits[%d].method %q 不是可以限流的查询接口", i, l.Method)
+		}
+		if l.Rate < 0 || l.Burst < 0 || l.MaxItems < 0 {
+			return fmt.Errorf("query-limits[%d] 的 rate、burst、max-items 不能小于 0", i)
+		}
+		if l.MaxItems > 0 && counter == nil {
+			return fmt.Errorf("query-limits[%d].method %q 不能限制查询条数", i, l.Method)
+		}
+		key := fmt.Sprintf("%s#%d", l.Method, l.BizID)
+		if _, ok = seen[key]; ok {
+			return fmt.Errorf("query-limits[%d] 重复配置了接口 %q 业务方 %d", i, l.Method, l.BizID)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// maxGrpcTimeout 所有接口里最长的服务端超时时间
+func maxGrpcTimeout(conf config.GrpcTimeoutConfig) time.Duration {
+	longest := max(conf.Default, 0)
+	for _, m := range conf.Methods {
+		longest = max(longest, m.Timeout)
+	}
+	return longest
+}
+
+// newTimeoutInterceptor 单独配置超时的方法必须是已经登记的接口，避免方法名写错后配置悄悄不生效
+func newTimeoutInterceptor(conf config.GrpcTimeoutConfig) grpc.UnaryServerInterceptor {
+	b := timeout.New(conf.Default)
+	for _, m := range conf.Methods {
+		if _, ok := grpcapi.MethodPermissions[m.Method]; !ok {
+			panic(fmt.Errorf("notification-server.timeout.methods 配置错误: 接口 %q 不存在", m.Method))
+		}
+		b.WithMethod(m.Method, m.Timeout)
+	}
+	return b.Build()
+}
+
+// 拦截器名称，notification-server.interceptors 按名称配置顺序
+const (
+	interceptorRequestContext = "request-context"
+	interceptorTimeout        = "timeout"
+	interceptorMetrics        = "metrics"
+	interceptorLog            = "log"
+	interceptorTracing        = "tracing"
+	interceptorAuth           = "auth"
+	interceptorQueryLimit     = "query-limit"
+)
+
+// newInterceptorChain 登记所有拦截器，登记的顺序就是默认顺序
+// 拦截器在 Build 时才创建，校验配置时 rbacSvc 和 flags 可以是 nil
+func newInterceptorChain(conf config.GrpcConfig, authConf config.AuthConfig, rbacSvc service.RBACService,
+	flags *featureflag.Flags,
+) *interceptor.ChainBuilder {
+	b := interceptor.NewChainBuilder().
+		// 请求ID和优先级放在最前面，后面的拦截器都能拿到
+		Register(interceptorRequestContext, func() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+			return requestctx.UnaryServerInterceptor(), requestctx.StreamServerInterceptor()
+		}).
+		// 调用方没有传截止时间时由服务端兜底，后面的拦截器和处理函数都带着截止时间
+		// 流式接口没有服务端超时，导出这类长连接由服务端自己限速
+		Register(interceptorTimeout, func() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+			return newTimeoutInterceptor(conf.Timeout), nil
+		}).
+		Register(interceptorMetrics, func() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+			return metrics.New().Build(), nil
+		}).
+		Register(interceptorLog, func() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+			return log.New().WithFlags(flags).Build(), nil
+		}).
+		Register(interceptorTracing, func() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+			return tracing.UnaryServerInterceptor(), nil
+		}).
+		// 鉴权默认放在最后，被拒绝的请求依旧有指标、日志和链路；没有开启鉴权时配置了也不生效
+		Register(interceptorAuth, func() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+			if !authConf.Enabled {
+				return nil, nil
+			}
+			if authConf.JWTKey == "" {
+				panic("开启鉴权时必须配置 auth.jwt-key")
+			}
+			authBuilder := auth.New([]byte(authConf.JWTKey), rbacSvc, grpcapi.MethodPermissions)
+			return authBuilder.Build(), authBuilder.BuildStream()
+		}).
+		// 查询限流按调用方的业务方计算，放在鉴权后面；没有配置限制时不生效
+		Register(interceptorQueryLimit, func() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+			if len(conf.QueryLimits) == 0 {
+				return nil, nil
+			}
+			b := querylimit.New(grpcapi.QueryMethods)
+			for _, l := range conf.QueryLimits {
+				b.WithLimit(l.Method, l.BizID, querylimit.Limit{Rate: l.Rate, Burst: l.Burst, MaxItems: l.MaxItems})
+			}
+			return b.Build(), nil
+		}).
+		First(interceptorRequestContext).
+		Require(interceptorRequestContext, interceptorTimeout)
+	// 开启鉴权时不能通过配置悄悄关掉鉴权
+	if authConf.Enabled {
+		b.Require(interceptorAuth)
+	}
+	return b
+}
+
+func InitGrpc(noserver *grpcapi.NotificationServer,
+	tplServer *grpcapi.TemplateServer,
+	privacyServer *grpcapi.DataPrivacyServer,
+	roleServer *grpcapi.RoleServer,
+	bizConfigServer *grpcapi.BizConfigServer,
+	statsServer *grpcapi.StatisticsServer,
+	readReceiptServer *grpcapi.ReadReceiptServer,
+	pushServer *grpcapi.PushServer,
+	escalationServer *grpcapi.EscalationServer,
+	adminServer *grpcapi.AdminServer,
+	unsubscribeServer *grpcapi.UnsubscribeServer,
+	debugServer *grpcapi.DebugServer,
+	rbacSvc service.RBACService,
+	flags *featureflag.Flags,
+) *grpc.Server {
 	// conf := &config.GrpcConfig{}
 	// err := viper.UnmarshalKey("notification-server", conf, viper.DecodeHook(viper.DecoderConfigOption(config.TagName("yaml"))))
 	// if err != nil {
@@ -19,20 +236,60 @@ func InitGrpc(noserver *grpcapi.NotificationServer) *grpc.Server {
 	// if eerr != nil {
 	// 	panic(eerr)
 	// }
-	// 创建observability拦截器
-	metricsInterceptor := metrics.New().Build()
-	logInterceptor := log.New().Build()
-	// 拦截器定义
-	traceInterceptor := tracing.UnaryServerInterceptor()
-	server := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			metricsInterceptor,
-			logInterceptor,
-			traceInterceptor,
-		),
-	)
+	conf := loadGrpcConfig()
+	server := newGrpcServer(conf, rbacSvc, flags)
 	//server.RegisterService(&notificationpb.NotificationService_ServiceDesc, noserver)
 	notificationpb.RegisterNotificationServiceServer(server, noserver)
 	notificationpb.RegisterNotificationQueryServiceServer(server, noserver)
+	notificationpb.RegisterTemplateServiceServer(server, tplServer)
+	notificationpb.RegisterDataPrivacyServiceServer(server, privacyServer)
+	notificationpb.RegisterRoleServiceServer(server, roleServer)
+	configv1.RegisterBusinessConfigServiceServer(server, bizConfigServer)
+	notificationpb.RegisterStatisticsServiceServer(server, statsServer)
+	notificationpb.RegisterReadReceiptServiceServer(server, readReceiptServer)
+	notificationpb.RegisterPushServiceServer(server, pushServer)
+	notificationpb.RegisterEscalationServiceServer(server, escalationServer)
+	notificationpb.RegisterAdminServiceServer(server, adminServer)
+	notificationpb.RegisterUnsubscribeServiceServer(server, unsubscribeServer)
+	notificationpb.RegisterDebugServiceServer(server, debugServer)
+	registerGrpcDebug(server, conf)
+	return server
+}
+
+// InitQueryGrpc 只读查询角色的 gRPC 服务，只注册查询接口，发送和管理接口返回 Unimplemented
+func InitQueryGrpc(queryServer *grpcapi.NotificationServer,
+	statsServer *grpcapi.StatisticsServer,
+	debugServer *grpcapi.DebugServer,
+	rbacSvc service.RBACService,
+	flags *featureflag.Flags,
+) *grpc.Server {
+	conf := loadGrpcConfig()
+	server := newGrpcServer(conf, rbacSvc, flags)
+	notificationpb.RegisterNotificationQueryServiceServer(server, queryServer)
+	notificationpb.RegisterStatisticsServiceServer(server, statsServer)
+	notificationpb.RegisterDebugServiceServer(server, debugServer)
+	registerGrpcDebug(server, conf)
 	return server
 }
+
+// newGrpcServer 按配置组装拦截器和服务端参数，还没有注册服务
+func newGrpcServer(conf config.GrpcConfig, rbacSvc service.RBACService, flags *featureflag.Flags) *grpc.Server {
+	interceptors, streamInterceptors, err := newInterceptorChain(conf, loadAuthConfig(), rbacSvc, flags).Build(conf.Interceptors)
+	if err != nil {
+		panic(fmt.Errorf("notification-server.interceptors 配置错误: %w", err))
+	}
+	opts := append(grpcServerOptions(conf),
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...))
+	return grpc.NewServer(opts...)
+}
+
+// registerGrpcDebug 反射和 channelz 同样经过鉴权拦截器，开启鉴权时 grpcurl 需要带上平台管理员的令牌
+func registerGrpcDebug(server *grpc.Server, conf config.GrpcConfig) {
+	if conf.Debug.Reflection {
+		reflection.Register(server)
+	}
+	if conf.Debug.Channelz {
+		channelzsvc.RegisterChannelzServiceToServer(server)
+	}
+}
@@ -1,26 +1,85 @@
 package ioc
 
 import (
+	"context"
+	"fmt"
 	"time"
 
-	"github.com/sony/sonyflake"
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/machineid"
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 /*
-MachineID 这个函数用于生成机器ID，确保在分布式环境中每个实例有唯一的标识符，从而避免ID冲突。
-方案：redis自增，etcd分布式锁等方式均可实现机器ID的唯一分配，环境变量
+MachineID 通过 etcd 租约或者 redis 占用记录分配，确保在分布式环境中每个实例有唯一的标识符，从而避免ID冲突。
 	39 bits for time in units of 10 msec
 	 8 bits for a sequence number
 	16 bits for a machine id
 */
 
+// InitMachineIDAllocator 初始化机器ID分配器
+func InitMachineIDAllocator(etcdClient *clientv3.Client, rdb *redis.Client) machineid.Allocator {
+	conf := config.IDGeneratorConfig{}
+	if err := viper.UnmarshalKey("id-generator", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	opts := machineid.Options{
+		Prefix:       conf.Prefix,
+		TTL:          conf.TTL,
+		MaxMachineID: conf.MaxMachineID,
+	}
+	switch conf.Allocator {
+	case "", config.MachineIDAllocatorEtcd:
+		return machineid.NewEtcdAllocator(etcdClient, opts)
+	case config.MachineIDAllocatorRedis:
+		return machineid.NewRedisAllocator(rdb, opts)
+	default:
+		panic(fmt.Errorf("不支持的机器ID分配方式: %s", conf.Allocator))
+	}
+}
+
+// defaultIDEpoch 未配置起始时间时使用的默认值，和配置文件保持一致
+const defaultIDEpoch = "2025-01-01T00:00:00Z"
+
 // InitIDGenerator ID生成器初始化
-func InitIDGenerator() *sonyflake.Sonyflake {
-	// 使用固定设置的ID生成器
-	return sonyflake.NewSonyflake(sonyflake.Settings{
-		StartTime: time.Now(),
-		MachineID: func() (uint16, error) {
-			return 1, nil
-		},
-	})
+func InitIDGenerator(allocator machineid.Allocator, rdb *redis.Client, clk clock.Clock) idgen.Generator {
+	conf := config.IDGeneratorConfig{}
+	if err := viper.UnmarshalKey("id-generator", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Epoch == "" {
+		conf.Epoch = defaultIDEpoch
+	}
+	epoch, err := time.Parse(time.RFC3339, conf.Epoch)
+	if err != nil {
+		panic(fmt.Errorf("解析ID生成器起始时间失败: %w", err))
+	}
+
+	const allocateTimeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), allocateTimeout)
+	defer cancel()
+	machineID, err := allocator.Allocate(ctx)
+	if err != nil {
+		panic(fmt.Errorf("分配机器ID失败: %w", err))
+	}
+
+	settings := idgen.Settings{
+		Epoch:     epoch,
+		MachineID: machineID,
+		Policy:    idgen.ClockSkewPolicy(conf.ClockSkew.Policy),
+		MaxWait:   conf.ClockSkew.MaxWait,
+		Clock:     clk,
+	}
+	if conf.Fallback.Enabled {
+		settings.Fallback = idgen.NewRedisGenerator(rdb, conf.Fallback.Key)
+	}
+	generator, err := idgen.NewSnowflakeGenerator(settings)
+	if err != nil {
+		panic(fmt.Errorf("初始化ID生成器失败: %w", err))
+	}
+	return generator
 }
@@ -0,0 +1,29 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const defaultLabelMetricsMaxValues = 50
+
+// InitLabelMetrics 按标签统计通知数量，没有配置标签键时返回 nil，不统计
+func InitLabelMetrics() *service.LabelMetrics {
+	conf := config.LabelMetricsConfig{}
+	if err := viper.UnmarshalKey("label-metrics", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.MaxValues <= 0 {
+		conf.MaxValues = defaultLabelMetricsMaxValues
+	}
+	for _, k := range conf.Keys {
+		if err := (domain.Labels{k: ""}).Validate(); err != nil {
+			panic(fmt.Errorf("label-metrics.keys 配置错误: %w", err))
+		}
+	}
+	return service.NewLabelMetrics(conf.Keys, conf.MaxValues)
+}
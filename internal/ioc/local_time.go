@@ -0,0 +1,59 @@
+package ioc
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/profile"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultLocalTimeInterval     = 30 * time.Second
+	defaultLocalTimeBatchSize    = 100
+	defaultLocalTimeAhead        = 5 * time.Minute
+	defaultTimezoneLookupTimeout = 3 * time.Second
+)
+
+func loadLocalTimeConfig() config.LocalTimeConfig {
+	conf := config.LocalTimeConfig{}
+	if err := viper.UnmarshalKey("local-time", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultLocalTimeInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultLocalTimeBatchSize
+	}
+	if conf.Ahead <= 0 {
+		conf.Ahead = defaultLocalTimeAhead
+	}
+	if conf.Ahead <= conf.Interval {
+		panic(fmt.Errorf("local-time.ahead 必须大于 local-time.interval: %s <= %s", conf.Ahead, conf.Interval))
+	}
+	if conf.Lookup.Timeout <= 0 {
+		conf.Lookup.Timeout = defaultTimezoneLookupTimeout
+	}
+	return conf
+}
+
+// InitLocalTimeService 按接收者本地时间发送
+func InitLocalTimeService(repo repository.LocalTimeRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc service.ChannelTemplateService,
+	idGenerator idgen.Generator, clk clock.Clock,
+) service.LocalTimeService {
+	conf := loadLocalTimeConfig()
+	lookup := profile.NewNopTimezoneLookup()
+	if conf.Lookup.URL != "" {
+		lookup = profile.NewHTTPTimezoneLookup(&http.Client{Timeout: conf.Lookup.Timeout}, conf.Lookup.URL)
+	}
+	return service.NewLocalTimeService(repo, notificationRepo, templateSvc, idGenerator, clk, lookup, conf.Ahead)
+}
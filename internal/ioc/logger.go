@@ -1,13 +1,48 @@
 package ioc
 
 import (
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-// InitLogger 初始化日志记录器
-func InitLogger() log.LoggerInterface {
+// InitLogLevels 按配置设置进程内共用的日志级别，配置错误直接 panic
+func InitLogLevels() *log.Levels {
+	conf := config.LogConfig{}
+	if err := viper.UnmarshalKey("log", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	levels := log.DefaultLevels()
+	if conf.Level != "" {
+		level, err := zapcore.ParseLevel(conf.Level)
+		if err != nil {
+			panic(fmt.Errorf("log.level 不合法: %w", err))
+		}
+		levels.SetLevel("", level)
+	}
+	for module, l := range conf.Modules {
+		level, err := zapcore.ParseLevel(l)
+		if err != nil {
+			panic(fmt.Errorf("log.modules.%s 不合法: %w", module, err))
+		}
+		levels.SetLevel(module, level)
+	}
+	return levels
+}
+
+// InitLogger 初始化日志记录器，级别由 levels 控制，运行时可以调整
+// 配置了 log.file、log.error-file 时同时写入滚动的日志文件，文件打不开直接 panic
+// 同时作为进程默认的输出，log.DefaultLogger 创建的组件 logger 不论先后都会写到这里
+func InitLogger(levels *log.Levels) log.LoggerInterface {
+	conf := config.LogConfig{}
+	if err := viper.UnmarshalKey("log", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+
 	// 根据环境配置日志级别
 	// 开发环境使用 Development 配置，生产环境使用 Production 配置
 	config := zap.NewProductionConfig()
@@ -15,8 +50,8 @@ func InitLogger() log.LoggerInterface {
 	// 配置日志编码
 	config.Encoding = "json"
 
-	// 配置日志级别
-	config.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	// 配置日志级别，真正的过滤由 levels 完成
+	config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
 
 	// 配置输出路径
 	config.OutputPaths = []string{"stdout"}
@@ -30,36 +65,77 @@ func InitLogger() log.LoggerInterface {
 	config.EncoderConfig.CallerKey = "caller"
 	config.EncoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
 
-	// 构建 logger
+	// 构建 logger，文件输出和标准输出使用同样的编码
 	logger, err := config.Build(
 		zap.AddCaller(),
 		zap.AddCallerSkip(1),
 		zap.AddStacktrace(zapcore.ErrorLevel),
+		fileOutputs(conf, zapcore.NewJSONEncoder(config.EncoderConfig)),
+		levels.Option(),
 	)
 	if err != nil {
 		// 如果构建失败，使用默认 logger
 		return log.DefaultLogger()
 	}
 
+	// 没有注入 logger、使用 log.DefaultLogger 的组件也写到这里配置的输出
+	log.SetDefaultCore(logger.Core())
 	return &log.Logger{Logger: logger}
 }
 
-// InitDevelopmentLogger 初始化开发环境日志记录器
-func InitDevelopmentLogger() log.LoggerInterface {
+// fileOutputs 把配置的日志文件加到标准输出旁边，error-file 只接收 error 及以上级别
+func fileOutputs(conf config.LogConfig, enc zapcore.Encoder) zap.Option {
+	var cores []zapcore.Core
+	if conf.File.Path != "" {
+		cores = append(cores, zapcore.NewCore(enc, zapcore.AddSync(newRotateWriter(conf.File)), zapcore.DebugLevel))
+	}
+	if conf.ErrorFile.Path != "" {
+		cores = append(cores, zapcore.NewCore(enc.Clone(), zapcore.AddSync(newRotateWriter(conf.ErrorFile)), zapcore.ErrorLevel))
+	}
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if len(cores) == 0 {
+			return core
+		}
+		return zapcore.NewTee(append([]zapcore.Core{core}, cores...)...)
+	})
+}
+
+func newRotateWriter(conf config.LogFileConfig) *log.RotateWriter {
+	w, err := log.NewRotateWriter(log.RotateOptions{
+		Filename:   conf.Path,
+		MaxSize:    conf.MaxSize,
+		MaxAge:     conf.MaxAge,
+		MaxBackups: conf.MaxBackups,
+		Compress:   conf.Compress,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// InitDevelopmentLogger 初始化开发环境日志记录器，级别和 InitLogger 一样由 levels 控制
+func InitDevelopmentLogger(levels *log.Levels) log.LoggerInterface {
 	config := zap.NewDevelopmentConfig()
 
 	// 开发环境使用 console 编码，便于阅读
 	config.Encoding = "console"
 	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
 
+	// 日志经过 log.Logger 转发，跳过一层调用栈才能定位到调用方
 	logger, err := config.Build(
 		zap.AddCaller(),
+		zap.AddCallerSkip(1),
 		zap.AddStacktrace(zapcore.WarnLevel),
+		levels.Option(),
 	)
 	if err != nil {
 		return log.DefaultLogger()
 	}
 
+	// 没有注入 logger、使用 log.DefaultLogger 的组件也写到这里配置的输出
+	log.SetDefaultCore(logger.Core())
 	return &log.Logger{Logger: logger}
 }
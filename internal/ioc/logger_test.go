@@ -0,0 +1,64 @@
+package ioc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+// 组件在 InitLogger 之前还是之后创建，日志都写到配置的文件，error 级别的日志同时写到 error-file
+func TestInitLogger_ComponentWritesToFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "platform.log")
+	errorFile := filepath.Join(dir, "error.log")
+	viper.Set("log", map[string]any{
+		"file":       map[string]any{"path": file},
+		"error-file": map[string]any{"path": errorFile},
+	})
+	t.Cleanup(viper.Reset)
+
+	// wire 不保证先初始化 logger，组件可能先用 log.DefaultLogger 创建好
+	before := service.NewBizChannelService(failingBizChannelRepo{})
+	InitLogger(log.DefaultLevels())
+	after := service.NewBizChannelService(failingBizChannelRepo{})
+
+	for _, svc := range []service.BizChannelService{before, after} {
+		if err := svc.Check(context.Background(), 1, domain.ChannelSMS); err != nil {
+			t.Fatalf("查询开关失败时应该放行，得到 %v", err)
+		}
+	}
+
+	for _, path := range []string{file, errorFile} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("%s 应该有 2 行日志，得到 %d 行: %s", path, len(lines), data)
+		}
+		for _, line := range lines {
+			if !strings.Contains(line, `"logger":"service.biz_channel"`) || !strings.Contains(line, "查询渠道开关失败") {
+				t.Errorf("%s 的日志不是组件写的: %s", path, line)
+			}
+		}
+	}
+}
+
+type failingBizChannelRepo struct{}
+
+func (failingBizChannelRepo) Get(context.Context, int64) (domain.BizChannels, error) {
+	return domain.BizChannels{}, errors.New("redis 不可用")
+}
+
+func (failingBizChannelRepo) Set(context.Context, domain.BizChannelSetting) error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package ioc
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	rediscache "github.com/serendipityConfusion/notification-platform/internal/repository/cache/redis"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"github.com/spf13/viper"
+)
+
+func loadDuplicateCheckConfig() config.DuplicateCheckConfig {
+	conf := config.DuplicateCheckConfig{}
+	if err := viper.UnmarshalKey("duplicate-check", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func loadCreateBatchConfig() config.CreateBatchConfig {
+	conf := config.CreateBatchConfig{}
+	if err := viper.UnmarshalKey("create-batch", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+// InitNotificationRepository 通知仓储，开启 create-batch 时合并并发的单条创建，
+// 开启 duplicate-check 时创建之前先用 Redis 布隆过滤器预判重复，预判在合并之前，重复的通知不会拖累同一批
+// 合并创建可以通过功能开关 create-batch 按业务方逐步放量；开启 watch 时创建和状态变化之后发布给观察者
+// 开启 quota.reservation 时扣减额度的同时记录扣减意图
+func InitNotificationRepository(d dao.NotificationDAO, quotaCache cache.QuotaCache,
+	cipher encrypt.Cipher, indexer encrypt.BlindIndexer, client *redis.Client, flags *featureflag.Flags,
+	watchBus eventbus.WatchBus, clk clock.Clock,
+) repository.NotificationRepository {
+	var repo repository.NotificationRepository
+	if loadQuotaConfig().Reservation.Enabled {
+		repo = repository.NewNotificationRepositoryWithReservation(d, quotaCache, cipher, indexer, clk)
+	} else {
+		repo = repository.NewNotificationRepository(d, quotaCache, cipher, indexer, clk)
+	}
+	if batchConf := loadCreateBatchConfig(); batchConf.Enabled {
+		repo = repository.NewBatchingNotificationRepository(repo, repository.CreateBatchOptions{
+			Wait:    batchConf.Wait,
+			MaxSize: batchConf.MaxSize,
+			Timeout: batchConf.Timeout,
+		}, flags)
+	}
+	if conf := loadDuplicateCheckConfig(); conf.Enabled {
+		keys := rediscache.NewNotificationKeyBloom(client, rediscache.NotificationKeyBloomOptions{
+			Window: conf.Window,
+			Bits:   conf.Bits,
+			Hashes: conf.Hashes,
+		}, clk)
+		repo = repository.NewDuplicateCheckNotificationRepository(repo, keys)
+	}
+	if watchBus != nil {
+		repo = repository.NewWatchedNotificationRepository(repo, watchBus)
+	}
+	return repo
+}
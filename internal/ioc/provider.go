@@ -0,0 +1,178 @@
+package ioc
+
+import (
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/anomaly"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/httpclient"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"github.com/spf13/viper"
+)
+
+// InitProviderSelector 按配置组装各个渠道的供应商路由，配置了不存在的供应商直接 panic
+// 沙箱通知不走路由，都发给模拟供应商；所有供应商调用前都检查计划发送时间有没有结束
+// 路由里的供应商每次调用都记录发送尝试，影子流量不真正投递，不记录
+// 路由里的供应商调用前检查接收者黑名单，供应商拒收的接收者自动加入黑名单；营销类通知还要跳过已经退订的接收者
+// 路由里的供应商每次真正调用都统计延迟和错误率，开启健康度路由时按统计结果调整顺序；所有供应商调用都记录链路
+func InitProviderSelector(providers map[string]provider.Provider, breaker *provider.Breaker,
+	reporter *provider.ShadowReporter, health *provider.HealthTracker, attempts repository.SendAttemptRepository,
+	blacklist repository.BlacklistRepository, optOuts repository.OptOutRepository, flags *featureflag.Flags,
+	clk clock.Clock, logger log.LoggerInterface,
+) provider.Selector {
+	conf := loadProviderRoutingConfig()
+	blacklistOpts := loadBlacklistOptions(conf.Blacklist)
+	lookup := func(name string) provider.Provider {
+		p, ok := providers[name]
+		if !ok {
+			panic(fmt.Errorf("供应商路由配置错误: 供应商 %s 不存在", name))
+		}
+		return provider.NewTracingProvider(name, p)
+	}
+	named := func(name string) provider.Named {
+		return provider.Named{Name: name, Provider: provider.NewWindowGuard(lookup(name), clk)}
+	}
+	recorded := func(name string) provider.Named {
+		p := provider.Named{
+			Name:     name,
+			Provider: provider.NewWindowGuard(provider.NewHealthRecorder(name, lookup(name), health), clk),
+		}
+		p.Provider = provider.NewExactlyOnceProvider(p, attempts, logger)
+		if !conf.Blacklist.Disabled {
+			p.Provider = provider.NewBlacklistGuard(p.Provider, blacklist, blacklistOpts, logger)
+		}
+		p.Provider = provider.NewOptOutGuard(p.Provider, optOuts, logger)
+		return p
+	}
+	routes := make(map[domain.Channel]provider.Route, len(conf.Routes))
+	for _, r := range conf.Routes {
+		ch := domain.Channel(r.Channel)
+		if !ch.IsValid() {
+			panic(fmt.Errorf("供应商路由配置错误: 渠道 %s 不合法", r.Channel))
+		}
+		route := provider.Route{}
+		for _, name := range r.Providers {
+			route.Providers = append(route.Providers, recorded(name))
+		}
+		if r.Shadow != "" {
+			if r.ShadowPercent > 100 {
+				panic(fmt.Errorf("供应商路由配置错误: 渠道 %s 的影子流量比例 %d 超过 100", r.Channel, r.ShadowPercent))
+			}
+			route.Shadow = &provider.ShadowRoute{Provider: named(r.Shadow), Percent: r.ShadowPercent}
+		}
+		routes[ch] = route
+	}
+	var ranker *provider.HealthTracker
+	if conf.Health.Enabled {
+		ranker = health
+	}
+	return provider.NewSandboxSelector(provider.NewSelector(routes, breaker, reporter, ranker, flags),
+		provider.Named{
+			Name:     provider.MockProviderName,
+			Provider: provider.NewWindowGuard(provider.NewTracingProvider(provider.MockProviderName, loadMockProvider(conf.Mock)), clk),
+		})
+}
+
+// InitProviders 按名称索引的供应商：sms-vendors 里的短信账号和站内信推送，供应商路由按名称引用
+// 每个供应商都统计失败率用于异常检测，检测到异常时由熔断器跳过
+func InitProviders(bus eventbus.InAppBus, clients *httpclient.Factory, detector *anomaly.Detector,
+	breaker *provider.Breaker,
+) map[string]provider.Provider {
+	providers := map[string]provider.Provider{
+		provider.InAppPushProviderName: provider.NewInAppPushProvider(bus),
+	}
+	for _, v := range loadSMSVendors(clients, 0) {
+		if _, ok := providers[v.conf.Name]; ok {
+			panic(fmt.Errorf("短信供应商账号 %s 和内置供应商重名", v.conf.Name))
+		}
+		providers[v.conf.Name] = v.client
+	}
+	for name, p := range providers {
+		providers[name] = provider.NewMonitoredProvider(name, p, detector, breaker)
+	}
+	return providers
+}
+
+// loadMockProvider 模拟供应商，分类不合法时直接 panic
+func loadMockProvider(conf config.ProviderMockConfig) provider.Provider {
+	category := domain.VendorErrorCategory(conf.FailCategory)
+	if conf.FailCategory != "" && !category.IsValid() {
+		panic(fmt.Errorf("模拟供应商配置错误: 分类 %s 不合法", conf.FailCategory))
+	}
+	return provider.NewMockProvider(provider.MockOptions{
+		FailPercent:  conf.FailPercent,
+		FailCategory: category,
+		Delay:        conf.Delay,
+	})
+}
+
+// InitProviderHealthTracker 供应商健康度统计，路由和运维接口共用
+func InitProviderHealthTracker() *provider.HealthTracker {
+	conf := loadProviderRoutingConfig().Health
+	return provider.NewHealthTracker(provider.HealthOptions{
+		Alpha:            conf.Alpha,
+		Window:           conf.Window,
+		MinSamples:       conf.MinSamples,
+		MaxErrorRate:     conf.MaxErrorRate,
+		LatencyTolerance: conf.LatencyTolerance,
+		StaleAfter:       conf.StaleAfter,
+	})
+}
+
+// InitShadowReporter 影子流量对比报告，所有渠道共用
+func InitShadowReporter() *provider.ShadowReporter {
+	return provider.NewShadowReporter(loadProviderRoutingConfig().ShadowTimeout)
+}
+
+// InitDryRunService 试运行只需要供应商路由里各个渠道的供应商名称
+func InitDryRunService(repo repository.NotificationRepository, templateSvc service.ChannelTemplateService,
+	quotaRepo repository.QuotaRepository, clk clock.Clock,
+) service.DryRunService {
+	conf := loadProviderRoutingConfig()
+	routes := make(map[domain.Channel][]string, len(conf.Routes))
+	for _, r := range conf.Routes {
+		routes[domain.Channel(r.Channel)] = r.Providers
+	}
+	return service.NewDryRunService(repo, templateSvc, quotaRepo, routes, clk)
+}
+
+func loadBlacklistOptions(conf config.ProviderBlacklistConfig) provider.BlacklistOptions {
+	opts := provider.BlacklistOptions{TTL: conf.TTL}
+	for _, c := range conf.Categories {
+		category := domain.VendorErrorCategory(c)
+		if !category.IsValid() {
+			panic(fmt.Errorf("接收者黑名单配置错误: 分类 %s 不合法", c))
+		}
+		opts.Categories = append(opts.Categories, category)
+	}
+	return opts
+}
+
+// loadErrorCodeMapper 配置的错误码映射加上内置规则
+func loadErrorCodeMapper() *provider.ErrorCodeMapper {
+	conf := loadProviderRoutingConfig()
+	rules := make([]provider.ErrorCodeRule, 0, len(conf.ErrorCodes))
+	for _, c := range conf.ErrorCodes {
+		category := domain.VendorErrorCategory(c.Category)
+		if !category.IsValid() {
+			panic(fmt.Errorf("供应商错误码配置错误: 分类 %s 不合法", c.Category))
+		}
+		rules = append(rules, provider.ErrorCodeRule{Vendor: c.Vendor, Code: c.Code, Category: category})
+	}
+	return provider.NewErrorCodeMapper(rules)
+}
+
+func loadProviderRoutingConfig() config.ProviderRoutingConfig {
+	conf := config.ProviderRoutingConfig{}
+	if err := viper.UnmarshalKey("provider", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	return conf
+}
@@ -0,0 +1,129 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/api/push"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultPushTokenTTL            = time.Hour
+	defaultPushChannel             = "notification:push:inapp"
+	defaultPushMaxConnsPerReceiver = 5
+	defaultPushSendBuffer          = 64
+	defaultPushReplayLimit         = 50
+	defaultPushPingInterval        = 30 * time.Second
+	defaultInboxName               = "inbox"
+	defaultInboxInterval           = 5 * time.Second
+	defaultInboxBatchSize          = 1000
+	defaultInboxDelay              = 2 * time.Second
+)
+
+func loadPushConfig() config.PushConfig {
+	conf := config.PushConfig{}
+	if err := viper.UnmarshalKey("push", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.TokenTTL <= 0 {
+		conf.TokenTTL = defaultPushTokenTTL
+	}
+	if conf.Channel == "" {
+		conf.Channel = defaultPushChannel
+	}
+	if conf.MaxConnsPerReceiver <= 0 {
+		conf.MaxConnsPerReceiver = defaultPushMaxConnsPerReceiver
+	}
+	if conf.SendBuffer <= 0 {
+		conf.SendBuffer = defaultPushSendBuffer
+	}
+	if conf.ReplayLimit <= 0 {
+		conf.ReplayLimit = defaultPushReplayLimit
+	}
+	if conf.PingInterval <= 0 {
+		conf.PingInterval = defaultPushPingInterval
+	}
+	inbox := &conf.Inbox
+	if inbox.Name == "" {
+		inbox.Name = defaultInboxName
+	}
+	if inbox.Interval <= 0 {
+		inbox.Interval = defaultInboxInterval
+	}
+	if inbox.BatchSize <= 0 {
+		inbox.BatchSize = defaultInboxBatchSize
+	}
+	if inbox.Delay <= 0 {
+		inbox.Delay = defaultInboxDelay
+	}
+	return conf
+}
+
+// InitInAppBus 站内信事件总线，发送流程发布，推送网关订阅
+func InitInAppBus(client *redis.Client) eventbus.InAppBus {
+	return eventbus.NewRedisInAppBus(client, loadPushConfig().Channel)
+}
+
+// InitPushTokenSigner 推送凭证签发，没有开启推送网关时返回 nil
+func InitPushTokenSigner() *push.TokenSigner {
+	conf := loadPushConfig()
+	if !conf.Enabled {
+		return nil
+	}
+	if conf.TokenKey == "" {
+		panic("开启推送网关时必须配置 push.token-key")
+	}
+	if conf.TokenTTL > push.MaxTokenTTL {
+		panic("push.token-ttl 不能超过 24h")
+	}
+	return push.NewTokenSigner([]byte(conf.TokenKey), conf.TokenTTL)
+}
+
+// InitPushHandler WebSocket 推送网关，没有开启时返回 nil，开启时必须同时开启网关
+func InitPushHandler(bus eventbus.InAppBus, signer *push.TokenSigner, notificationRepo repository.NotificationRepository) *push.Handler {
+	if signer == nil {
+		return nil
+	}
+	gatewayConf := config.GatewayConfig{}
+	if err := viper.UnmarshalKey("gateway", &gatewayConf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if !gatewayConf.Enabled {
+		panic("开启 push 时必须开启 gateway")
+	}
+	conf := loadPushConfig()
+	return push.NewHandler(bus, signer, notificationRepo, push.Options{
+		MaxConnsPerReceiver: conf.MaxConnsPerReceiver,
+		SendBuffer:          conf.SendBuffer,
+		ReplayLimit:         conf.ReplayLimit,
+		PingInterval:        conf.PingInterval,
+	})
+}
+
+// InitInboxHandler 收件箱查询，没有开启推送网关或者收件箱时返回 nil
+func InitInboxHandler(signer *push.TokenSigner, svc service.InboxService) *push.InboxHandler {
+	if signer == nil || !loadPushConfig().Inbox.Enabled {
+		return nil
+	}
+	return push.NewInboxHandler(signer, svc)
+}
+
+// initInboxProjectionTask 收件箱投影任务，和导出任务共用通知变化的读取和进度，没有开启时返回 nil
+func initInboxProjectionTask(exportRepo repository.ExportRepository, inboxRepo repository.InboxRepository, lock distribute_lock.Client) Task {
+	conf := loadPushConfig().Inbox
+	if !conf.Enabled {
+		return nil
+	}
+	return service.NewExportTask(exportRepo, service.NewInboxProjection(inboxRepo), lock, service.ExportOptions{
+		Name:      conf.Name,
+		Interval:  conf.Interval,
+		BatchSize: conf.BatchSize,
+		Delay:     conf.Delay,
+	})
+}
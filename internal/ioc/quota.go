@@ -0,0 +1,78 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	rediscache "github.com/serendipityConfusion/notification-platform/internal/repository/cache/redis"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultQuotaWarmupBatchSize = 500
+	defaultQuotaProbeInterval   = 5 * time.Second
+
+	defaultQuotaReservationInterval  = time.Minute
+	defaultQuotaReservationGrace     = 5 * time.Minute
+	defaultQuotaReservationBatchSize = 200
+)
+
+func loadQuotaConfig() config.QuotaConfig {
+	conf := config.QuotaConfig{}
+	if err := viper.UnmarshalKey("quota", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.WarmupBatchSize <= 0 {
+		conf.WarmupBatchSize = defaultQuotaWarmupBatchSize
+	}
+	if conf.Degraded.Mode == "" {
+		conf.Degraded.Mode = repository.QuotaDegradedReject
+	}
+	if conf.Degraded.ProbeInterval <= 0 {
+		conf.Degraded.ProbeInterval = defaultQuotaProbeInterval
+	}
+	if conf.Reservation.Interval <= 0 {
+		conf.Reservation.Interval = defaultQuotaReservationInterval
+	}
+	if conf.Reservation.Grace <= 0 {
+		conf.Reservation.Grace = defaultQuotaReservationGrace
+	}
+	if conf.Reservation.BatchSize <= 0 {
+		conf.Reservation.BatchSize = defaultQuotaReservationBatchSize
+	}
+	return conf
+}
+
+// InitQuotaCache 额度缓存，Redis 不可用时按降级策略扣减，透支策略配置错误直接 panic
+func InitQuotaCache(client *redis.Client, quotaDAO dao.QuotaDAO, clk clock.Clock) cache.QuotaCache {
+	conf := loadQuotaConfig()
+	policies := make([]domain.OverdraftPolicy, 0, len(conf.Overdraft))
+	for _, p := range conf.Overdraft {
+		policies = append(policies, domain.OverdraftPolicy{
+			BizID:        p.BizID,
+			Channel:      domain.Channel(p.Channel),
+			Mode:         domain.OverdraftMode(p.Mode),
+			BurstPercent: p.BurstPercent,
+		})
+	}
+	overdraft, err := domain.NewOverdraftPolicies(policies)
+	if err != nil {
+		panic(fmt.Errorf("初始化透支策略失败: %w", err))
+	}
+	return repository.NewDegradedQuotaCache(rediscache.NewQuotaCache(client, overdraft, clk), quotaDAO,
+		repository.DegradedQuotaOptions{
+			Mode:          conf.Degraded.Mode,
+			ProbeInterval: conf.Degraded.ProbeInterval,
+			Ping: func(ctx context.Context) error {
+				return client.Ping(ctx).Err()
+			},
+		})
+}
@@ -0,0 +1,92 @@
+package ioc
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/callback"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultReadReceiptInterval    = 5 * time.Second
+	defaultReadReceiptBatchSize   = 100
+	defaultReadReceiptMaxRetries  = 10
+	defaultReadReceiptBaseBackoff = 5 * time.Second
+	defaultReadReceiptMaxBackoff  = 10 * time.Minute
+)
+
+func loadReadReceiptConfig() config.ReadReceiptConfig {
+	conf := config.ReadReceiptConfig{}
+	if err := viper.UnmarshalKey("read-receipt", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	cb := &conf.Callback
+	if cb.Interval <= 0 {
+		cb.Interval = defaultReadReceiptInterval
+	}
+	if cb.BatchSize <= 0 {
+		cb.BatchSize = defaultReadReceiptBatchSize
+	}
+	if cb.MaxRetries <= 0 {
+		cb.MaxRetries = defaultReadReceiptMaxRetries
+	}
+	if cb.BaseBackoff <= 0 {
+		cb.BaseBackoff = defaultReadReceiptBaseBackoff
+	}
+	if cb.MaxBackoff <= 0 {
+		cb.MaxBackoff = defaultReadReceiptMaxBackoff
+	}
+	return conf
+}
+
+// readReceiptEndpoints 业务方的已读事件推送地址，配置错误直接 panic
+func readReceiptEndpoints(conf config.ReadReceiptConfig) map[int64]string {
+	endpoints := make(map[int64]string, len(conf.Endpoints))
+	for _, e := range conf.Endpoints {
+		if e.BizID <= 0 {
+			panic(fmt.Errorf("已读回执推送地址的 biz-id 不合法: %d", e.BizID))
+		}
+		if _, ok := endpoints[e.BizID]; ok {
+			panic(fmt.Errorf("业务方 %d 配置了多个已读回执推送地址", e.BizID))
+		}
+		u, err := url.Parse(e.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			panic(fmt.Errorf("业务方 %d 的已读回执推送地址不合法: %q", e.BizID, e.URL))
+		}
+		endpoints[e.BizID] = e.URL
+	}
+	return endpoints
+}
+
+// InitReadReceiptService 站内信已读回执
+func InitReadReceiptService(repo repository.ReadReceiptRepository,
+	notificationRepo repository.NotificationRepository,
+) service.ReadReceiptService {
+	return service.NewReadReceiptService(repo, notificationRepo, readReceiptEndpoints(loadReadReceiptConfig()))
+}
+
+// initReadReceiptCallbackTask 已读事件推送任务，没有业务方配置推送地址时返回 nil
+func initReadReceiptCallbackTask(repo repository.ReadReceiptRepository,
+	client callback.Client,
+	lock distribute_lock.Client,
+) Task {
+	conf := loadReadReceiptConfig()
+	endpoints := readReceiptEndpoints(conf)
+	if len(endpoints) == 0 {
+		return nil
+	}
+	cb := conf.Callback
+	return service.NewReadReceiptCallbackTask(repo, client, endpoints, lock, service.ReadReceiptCallbackOptions{
+		Interval:    cb.Interval,
+		BatchSize:   cb.BatchSize,
+		MaxRetries:  cb.MaxRetries,
+		BaseBackoff: cb.BaseBackoff,
+		MaxBackoff:  cb.MaxBackoff,
+	})
+}
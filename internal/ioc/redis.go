@@ -9,17 +9,25 @@ import (
 )
 
 func InitRedis() *redis.Client {
+	client := newRedisClient(loadRedisConfig())
+	client = tracing.WithTracing(client)
+	client = metrics.WithMetrics(client)
+	return client
+}
+
+func loadRedisConfig() config.RedisConfig {
 	conf := config.RedisConfig{}
-	err := viper.UnmarshalKey("redis", &conf, viper.DecodeHook(viper.DecoderConfigOption(config.TagName("yaml"))))
+	err := viper.UnmarshalKey("redis", &conf, config.TagName("yaml"))
 	if err != nil {
 		panic(err)
 	}
-	client := redis.NewClient(&redis.Options{
+	return conf
+}
+
+func newRedisClient(conf config.RedisConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
 		Addr:     conf.Addr,
 		Password: conf.Password,
 		Username: conf.UserName,
 	})
-	client = tracing.WithTracing(client)
-	client = metrics.WithMetrics(client)
-	return client
 }
@@ -6,9 +6,9 @@ import (
 )
 
 // InitRegistry 初始化服务注册器
-// 使用已有的 etcd 客户端创建注册器
+// 使用已有的 etcd 客户端创建注册器，请求超时时间来自 etcd.request-timeout
 func InitRegistry(etcdClient *clientv3.Client) *registry.EtcdRegistry {
-	return registry.NewEtcdRegistry(etcdClient)
+	return registry.NewEtcdRegistry(etcdClient, registry.WithRequestTimeout(loadEtcdConfig().RequestTimeout))
 }
 
 // InitServiceInfo 初始化服务信息
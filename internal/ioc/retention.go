@@ -0,0 +1,51 @@
+package ioc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const defaultRetentionInterval = time.Hour
+
+func loadRetentionConfig() config.RetentionConfig {
+	conf := config.RetentionConfig{}
+	if err := viper.UnmarshalKey("retention", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultRetentionInterval
+	}
+	return conf
+}
+
+// InitDataRetentionService 初始化数据保留服务，策略配置错误直接 panic
+func InitDataRetentionService(notificationRepo repository.NotificationRepository,
+	repo repository.DataRetentionRepository,
+	indexer encrypt.BlindIndexer,
+	clk clock.Clock,
+) service.DataRetentionService {
+	conf := loadRetentionConfig()
+	policies := make([]domain.RetentionPolicy, 0, len(conf.Policies))
+	for _, p := range conf.Policies {
+		policies = append(policies, domain.RetentionPolicy{
+			BizID:         p.BizID,
+			Channel:       domain.Channel(p.Channel),
+			RetentionDays: p.Days,
+			Action:        domain.RetentionAction(p.Action),
+		})
+	}
+	svc, err := service.NewDataRetentionService(notificationRepo, repo, indexer, policies, conf.BatchSize,
+		time.Duration(conf.CallbackLogDays)*24*time.Hour, clk)
+	if err != nil {
+		panic(fmt.Errorf("初始化数据保留策略失败: %w", err))
+	}
+	return svc
+}
@@ -0,0 +1,45 @@
+package ioc
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Role 部署角色，同一个二进制按角色只创建需要的组件，读流量和后台任务可以分别扩容
+type Role string
+
+const (
+	// RoleAll 一个实例运行所有组件，本地开发和小规模部署使用
+	RoleAll Role = "all"
+	// RoleAPI 发送、管理和查询接口，HTTP 网关、站内信推送
+	RoleAPI Role = "api"
+	// RoleScheduler 定时任务：过期、升级链、合并发送、本地时间发送、统计汇总、数据清理等
+	RoleScheduler Role = "scheduler"
+	// RoleCallbackWorker 发送结果回调、已读回执回调和回调死信汇总
+	RoleCallbackWorker Role = "callback-worker"
+	// RoleQuery 只读查询接口，不创建发送相关的组件，注册在单独的服务名下
+	RoleQuery Role = "query"
+)
+
+// Roles 所有角色
+var Roles = []Role{RoleAll, RoleAPI, RoleScheduler, RoleCallbackWorker, RoleQuery}
+
+// ParseRole 为空时是 RoleAll
+func ParseRole(s string) (Role, error) {
+	if s == "" {
+		return RoleAll, nil
+	}
+	r := Role(s)
+	if !slices.Contains(Roles, r) {
+		return "", fmt.Errorf("未知的角色 %q，可选值: %v", s, Roles)
+	}
+	return r, nil
+}
+
+// ServiceName 注册到注册中心的服务名，只读实例单独注册，调用方按服务名区分读写流量
+func (r Role) ServiceName(name string) string {
+	if r == RoleQuery {
+		return name + "-query"
+	}
+	return name
+}
@@ -0,0 +1,98 @@
+package ioc
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/partition"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	defaultSchedulerInterval     = time.Second
+	defaultSchedulerBatchSize    = 100
+	defaultSchedulerBatchTimeout = 30 * time.Second
+	defaultPartitionPrefix       = "/notification-platform/scheduler/members"
+
+	defaultAdaptiveMinBatchSize  = 10
+	defaultAdaptiveMaxBatchSize  = 1000
+	defaultAdaptiveMinInterval   = 100 * time.Millisecond
+	defaultAdaptiveMaxInterval   = 10 * time.Second
+	defaultAdaptiveTargetLatency = time.Second
+)
+
+func loadSchedulerConfig() config.SchedulerConfig {
+	conf := config.SchedulerConfig{}
+	if err := viper.UnmarshalKey("scheduler", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultSchedulerInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultSchedulerBatchSize
+	}
+	if conf.BatchTimeout <= 0 {
+		conf.BatchTimeout = defaultSchedulerBatchTimeout
+	}
+	if conf.Partition.Prefix == "" {
+		conf.Partition.Prefix = defaultPartitionPrefix
+	}
+	if conf.Partition.InstanceID == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			panic(fmt.Errorf("获取主机名失败: %w", err))
+		}
+		conf.Partition.InstanceID = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return conf
+}
+
+// InitSchedulerMembership 调度分区成员关系
+func InitSchedulerMembership(client *clientv3.Client) partition.Membership {
+	conf := loadSchedulerConfig().Partition
+	return partition.NewEtcdMembership(client, conf.Prefix, conf.InstanceID, conf.TTL)
+}
+
+// InitScheduler 分区调度器
+func InitScheduler(svc service.Service, membership partition.Membership, dispatcher service.Dispatcher,
+	fallback service.FallbackService, pacer service.PacingService, clk clock.Clock,
+) *service.Scheduler {
+	conf := loadSchedulerConfig()
+	return service.NewScheduler(svc, membership, dispatcher, newBatchController(conf), fallback, pacer,
+		conf.BatchTimeout, clk)
+}
+
+func newBatchController(conf config.SchedulerConfig) service.BatchController {
+	if !conf.Adaptive.Enabled {
+		return service.NewFixedBatchController(conf.BatchSize, conf.Interval)
+	}
+	a := conf.Adaptive
+	if a.MinBatchSize <= 0 {
+		a.MinBatchSize = defaultAdaptiveMinBatchSize
+	}
+	if a.MaxBatchSize < a.MinBatchSize {
+		a.MaxBatchSize = max(defaultAdaptiveMaxBatchSize, a.MinBatchSize)
+	}
+	if a.MinInterval <= 0 {
+		a.MinInterval = defaultAdaptiveMinInterval
+	}
+	if a.MaxInterval < a.MinInterval {
+		a.MaxInterval = max(defaultAdaptiveMaxInterval, a.MinInterval)
+	}
+	if a.TargetLatency <= 0 {
+		a.TargetLatency = defaultAdaptiveTargetLatency
+	}
+	return service.NewAdaptiveBatchController(service.AdaptiveBatchOptions{
+		MinBatchSize:  a.MinBatchSize,
+		MaxBatchSize:  a.MaxBatchSize,
+		MinInterval:   a.MinInterval,
+		MaxInterval:   a.MaxInterval,
+		TargetLatency: a.TargetLatency,
+	}, conf.BatchSize, conf.Interval)
+}
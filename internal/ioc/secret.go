@@ -0,0 +1,54 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/secret"
+	"github.com/spf13/viper"
+)
+
+const defaultVaultTimeout = 5 * time.Second
+
+// InitSecretResolvers 配置里密钥引用的解析器，总是支持 env，配置了 secrets.vault.addr 时支持 vault
+// vault 令牌按 renew-interval 在后台续期，直到进程退出
+func InitSecretResolvers() secret.Resolvers {
+	conf := config.SecretsConfig{}
+	if err := viper.UnmarshalKey("secrets", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	resolvers := secret.Resolvers{secret.SchemeEnv: secret.EnvResolver{}}
+	vc := conf.Vault
+	if vc.Addr == "" {
+		return resolvers
+	}
+	// 令牌本身只能来自环境变量
+	token, err := resolvers.Expand(context.Background(), vc.Token)
+	if err != nil {
+		panic(fmt.Errorf("解析 secrets.vault.token 失败: %w", err))
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if token == "" {
+		panic("配置了 secrets.vault.addr 时必须配置 secrets.vault.token 或者环境变量 VAULT_TOKEN")
+	}
+	if vc.Timeout <= 0 {
+		vc.Timeout = defaultVaultTimeout
+	}
+	vault := secret.NewVaultResolver(&http.Client{Timeout: vc.Timeout}, secret.VaultOptions{
+		Addr:          vc.Addr,
+		Token:         token,
+		Mount:         vc.Mount,
+		KVVersion:     vc.KVVersion,
+		CacheTTL:      vc.CacheTTL,
+		RenewInterval: vc.RenewInterval,
+	})
+	vault.StartRenewal(context.Background())
+	resolvers[secret.SchemeVault] = vault
+	return resolvers
+}
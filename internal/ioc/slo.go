@@ -0,0 +1,57 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultSLOInterval       = time.Minute
+	defaultSLOPeriod         = 7 * 24 * time.Hour
+	defaultSLOSendTarget     = 0.99
+	defaultSLOProviderTarget = 0.995
+	defaultSLOCallbackTarget = 0.99
+)
+
+var defaultSLOWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 72 * time.Hour}
+
+func loadSLOConfig() config.SLOConfig {
+	conf := config.SLOConfig{}
+	if err := viper.UnmarshalKey("slo", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultSLOInterval
+	}
+	if conf.Period <= 0 {
+		conf.Period = defaultSLOPeriod
+	}
+	if len(conf.Windows) == 0 {
+		conf.Windows = defaultSLOWindows
+	}
+	if conf.SendTarget <= 0 {
+		conf.SendTarget = defaultSLOSendTarget
+	}
+	if conf.ProviderTarget <= 0 {
+		conf.ProviderTarget = defaultSLOProviderTarget
+	}
+	if conf.CallbackTarget <= 0 {
+		conf.CallbackTarget = defaultSLOCallbackTarget
+	}
+	return conf
+}
+
+// InitSLOService 服务等级目标的计算
+func InitSLOService(repo repository.SLORepository) service.SLOService {
+	conf := loadSLOConfig()
+	return service.NewSLOService(repo, []domain.SLOObjective{
+		{Kind: domain.SLOKindSend, Target: conf.SendTarget},
+		{Kind: domain.SLOKindProvider, Target: conf.ProviderTarget},
+		{Kind: domain.SLOKindCallback, Target: conf.CallbackTarget},
+	}, conf.Windows, conf.Period)
+}
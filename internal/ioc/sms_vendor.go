@@ -0,0 +1,103 @@
+package ioc
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/httpclient"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider/sms"
+	"github.com/spf13/viper"
+)
+
+// smsVendorClient 短信供应商账号的发送和管理接口
+type smsVendorClient interface {
+	provider.Provider
+	provider.TemplateReviewer
+	provider.BalanceQuerier
+	provider.SMSReplyParser
+}
+
+// smsVendor 配置的短信供应商账号
+type smsVendor struct {
+	conf   config.SMSVendorConfig
+	client smsVendorClient
+}
+
+// InitVendorHTTPClients 供应商的 HTTP 客户端，同一个供应商共用连接池
+func InitVendorHTTPClients() *httpclient.Factory {
+	return httpclient.NewFactory()
+}
+
+// loadSMSVendors 短信供应商账号，配置错误直接 panic
+// timeout 大于 0 时覆盖账号配置的整体超时时间
+func loadSMSVendors(clients *httpclient.Factory, timeout time.Duration) []smsVendor {
+	var confs []config.SMSVendorConfig
+	if err := viper.UnmarshalKey("sms-vendors", &confs, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	errs := loadErrorCodeMapper()
+	vendors := make([]smsVendor, 0, len(confs))
+	seen := make(map[string]struct{}, len(confs))
+	for _, c := range confs {
+		if c.Name == "" {
+			panic(fmt.Errorf("短信供应商账号没有配置 name"))
+		}
+		if _, ok := seen[c.Name]; ok {
+			panic(fmt.Errorf("短信供应商账号 %s 重复配置", c.Name))
+		}
+		seen[c.Name] = struct{}{}
+		httpClient, err := clients.Client(c.Name, vendorHTTPOptions(c.HTTP), timeout)
+		if err != nil {
+			panic(err)
+		}
+		client, err := newSMSVendorClient(httpClient, c, errs)
+		if err != nil {
+			panic(fmt.Errorf("初始化短信供应商账号 %s 失败: %w", c.Name, err))
+		}
+		vendors = append(vendors, smsVendor{conf: c, client: client})
+	}
+	return vendors
+}
+
+func newSMSVendorClient(httpClient *http.Client, c config.SMSVendorConfig, errs *provider.ErrorCodeMapper) (smsVendorClient, error) {
+	switch c.Type {
+	case sms.AliyunVendor:
+		return sms.NewAliyunClient(httpClient, sms.AliyunOptions{
+			Endpoint:        c.Endpoint,
+			AccessKeyID:     c.AccessKeyID,
+			AccessKeySecret: c.AccessKeySecret,
+			TemplateType:    c.TemplateType,
+			Errors:          errs,
+			Name:            c.Name,
+		})
+	case sms.TencentVendor:
+		return sms.NewTencentClient(httpClient, sms.TencentOptions{
+			Endpoint:  c.Endpoint,
+			SecretID:  c.AccessKeyID,
+			SecretKey: c.AccessKeySecret,
+			Region:    c.Region,
+			SdkAppID:  c.SdkAppID,
+			SmsType:   c.TemplateType,
+			Errors:    errs,
+			Name:      c.Name,
+		})
+	default:
+		return nil, fmt.Errorf("不支持的供应商类型 %q", c.Type)
+	}
+}
+
+func vendorHTTPOptions(c config.VendorHTTPConfig) httpclient.Options {
+	return httpclient.Options{
+		Timeout:               c.Timeout,
+		DialTimeout:           c.DialTimeout,
+		TLSHandshakeTimeout:   c.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: c.ResponseHeaderTimeout,
+		IdleConnTimeout:       c.IdleConnTimeout,
+		MaxIdleConnsPerHost:   c.MaxIdleConns,
+		MaxConnsPerHost:       c.MaxConns,
+		Proxy:                 c.Proxy,
+	}
+}
@@ -0,0 +1,164 @@
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/database/failover"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultStartupInitialBackoff = 500 * time.Millisecond
+	defaultStartupMaxBackoff     = 5 * time.Second
+	defaultStartupAttemptTimeout = 5 * time.Second
+)
+
+// DependencyFailure 等待超时之后依旧不可用的依赖
+type DependencyFailure struct {
+	Name     string
+	Attempts int
+	Err      error
+}
+
+// StartupError 启动时没有就绪的所有依赖
+type StartupError struct {
+	Waited   time.Duration
+	Failures []DependencyFailure
+}
+
+func (e *StartupError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d 个依赖在 %s 内没有就绪:", len(e.Failures), e.Waited)
+	for _, f := range e.Failures {
+		fmt.Fprintf(&b, "\n  - %s（尝试 %d 次）: %v", f.Name, f.Attempts, f.Err)
+	}
+	return b.String()
+}
+
+// dependencyProbe 探测一个依赖是否可用
+type dependencyProbe struct {
+	name  string
+	probe func(ctx context.Context) error
+}
+
+// WaitForDependencies 启动前并发等待数据库、Redis、etcd 就绪，部署时依赖短暂不可用不会直接退出
+// 超过 startup.max-wait 依旧不可用时一次返回所有不可用的依赖，返回的错误是 *StartupError
+func WaitForDependencies() error {
+	conf := loadStartupConfig()
+	probes := []dependencyProbe{
+		{name: "database", probe: probeDatabase},
+		{name: "redis", probe: probeRedis},
+		{name: "etcd", probe: probeEtcd},
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []DependencyFailure
+	)
+	for _, p := range probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if f, ok := waitFor(conf, p); !ok {
+				mu.Lock()
+				failures = append(failures, f)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(failures) == 0 {
+		return nil
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Name < failures[j].Name })
+	return &StartupError{Waited: conf.MaxWait, Failures: failures}
+}
+
+// waitFor 按指数退避重试，直到成功或者超过 MaxWait
+func waitFor(conf config.StartupConfig, p dependencyProbe) (DependencyFailure, bool) {
+	deadline := time.Now().Add(conf.MaxWait)
+	backoff := conf.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), conf.AttemptTimeout)
+		err := p.probe(ctx)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("[Startup] %s is ready after %d attempts", p.name, attempt)
+			}
+			return DependencyFailure{}, true
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return DependencyFailure{Name: p.name, Attempts: attempt, Err: err}, false
+		}
+		wait := min(backoff, remaining)
+		log.Printf("[Startup] %s is not ready (attempt %d), retrying in %s: %v", p.name, attempt, wait, err)
+		time.Sleep(wait)
+		backoff = min(backoff*2, conf.MaxBackoff)
+	}
+}
+
+func loadStartupConfig() config.StartupConfig {
+	conf := config.StartupConfig{}
+	if err := viper.UnmarshalKey("startup", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.InitialBackoff <= 0 {
+		conf.InitialBackoff = defaultStartupInitialBackoff
+	}
+	if conf.MaxBackoff < conf.InitialBackoff {
+		conf.MaxBackoff = max(defaultStartupMaxBackoff, conf.InitialBackoff)
+	}
+	if conf.AttemptTimeout <= 0 {
+		conf.AttemptTimeout = defaultStartupAttemptTimeout
+	}
+	return conf
+}
+
+func probeDatabase(ctx context.Context) error {
+	conf, err := LoadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+	// 配置了备用地址时任意一个地址可用即可
+	dialector, err := newDialector(conf, failover.NewHealth("startup", len(conf.Failover.DSNs)+1, conf.Failover.DegradedWindow))
+	if err != nil {
+		return err
+	}
+	// 不让 gorm 在 Open 里 ping，ping 需要带上每次探测的超时时间
+	db, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	return sqlDB.PingContext(ctx)
+}
+
+func probeRedis(ctx context.Context) error {
+	client := newRedisClient(loadRedisConfig())
+	defer client.Close()
+	return client.Ping(ctx).Err()
+}
+
+func probeEtcd(ctx context.Context) error {
+	client, err := newEtcdClient(loadEtcdConfig())
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	_, err = client.MemberList(ctx)
+	return err
+}
@@ -0,0 +1,278 @@
+package ioc
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/api/push"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/callback"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+// Task 后台任务，Start 阻塞运行直到 ctx 被取消
+type Task interface {
+	Start(ctx context.Context)
+}
+
+const (
+	defaultExpiryInterval  = time.Minute
+	defaultExpiryBatchSize = 100
+	defaultStatsInterval   = 5 * time.Minute
+	defaultStatsLookback   = 3 * time.Hour
+
+	defaultSendingTimeoutInterval  = 30 * time.Second
+	defaultSendingTimeoutBatchSize = 100
+
+	defaultEscalationInterval  = 5 * time.Second
+	defaultEscalationBatchSize = 100
+)
+
+// InitTasks 所有角色的后台任务，一个实例运行所有组件时使用
+func InitTasks(svc service.DataRetentionService,
+	statsSvc service.StatisticsService,
+	templateUsageSvc service.TemplateUsageService,
+	sloSvc service.SLOService,
+	notificationRepo repository.NotificationRepository,
+	exportRepo repository.ExportRepository,
+	inboxRepo repository.InboxRepository,
+	readReceiptRepo repository.ReadReceiptRepository,
+	callbackLogRepo repository.CallbackLogRepository,
+	callbackClient callback.Client,
+	callbackDeadLetterSvc service.CallbackDeadLetterService,
+	pushHandler *push.Handler,
+	escalationSvc service.EscalationService,
+	digestSvc service.DigestService,
+	localTimeSvc service.LocalTimeService,
+	templateReviewSvc service.TemplateReviewService,
+	vendorBalanceSvc service.VendorBalanceService,
+	quotaRepo repository.QuotaRepository,
+	quotaReservationRepo repository.QuotaReservationRepository,
+	scheduler *service.Scheduler,
+	lock distribute_lock.Client,
+	clk clock.Clock,
+	flagWatcher *featureflag.EtcdWatcher,
+	watchBus eventbus.WatchBus,
+) []Task {
+	tasks := InitAPITasks(flagWatcher, watchBus, pushHandler)
+	tasks = append(tasks, schedulerTasks(scheduler, svc, statsSvc, templateUsageSvc, sloSvc, notificationRepo, exportRepo, inboxRepo,
+		escalationSvc, digestSvc, localTimeSvc, templateReviewSvc, vendorBalanceSvc, quotaRepo, quotaReservationRepo, lock, clk)...)
+	return append(tasks, callbackTasks(callbackLogRepo, notificationRepo, readReceiptRepo, callbackClient, callbackDeadLetterSvc, lock, clk)...)
+}
+
+// InitAPITasks 接口角色的后台任务，只有每个实例都要运行的任务
+func InitAPITasks(flagWatcher *featureflag.EtcdWatcher, watchBus eventbus.WatchBus, pushHandler *push.Handler) []Task {
+	var tasks []Task
+	if flagWatcher != nil {
+		tasks = append(tasks, flagWatcher)
+	}
+	if watchBus != nil {
+		// 每个实例定期同步观察注册表，决定发布哪些通知的变化
+		tasks = append(tasks, watchBus)
+	}
+	if pushHandler != nil {
+		// 推送网关订阅事件总线，退出时关闭所有长连接
+		tasks = append(tasks, pushHandler)
+	}
+	return tasks
+}
+
+// InitQueryTasks 只读查询角色的后台任务
+func InitQueryTasks(flagWatcher *featureflag.EtcdWatcher) []Task {
+	if flagWatcher == nil {
+		return nil
+	}
+	return []Task{flagWatcher}
+}
+
+// InitSchedulerTasks 定时任务角色的后台任务，包括分区调度发送，定时任务修改通知状态，需要同步观察注册表
+func InitSchedulerTasks(svc service.DataRetentionService,
+	statsSvc service.StatisticsService,
+	templateUsageSvc service.TemplateUsageService,
+	sloSvc service.SLOService,
+	notificationRepo repository.NotificationRepository,
+	exportRepo repository.ExportRepository,
+	inboxRepo repository.InboxRepository,
+	escalationSvc service.EscalationService,
+	digestSvc service.DigestService,
+	localTimeSvc service.LocalTimeService,
+	templateReviewSvc service.TemplateReviewService,
+	vendorBalanceSvc service.VendorBalanceService,
+	quotaRepo repository.QuotaRepository,
+	quotaReservationRepo repository.QuotaReservationRepository,
+	scheduler *service.Scheduler,
+	lock distribute_lock.Client,
+	clk clock.Clock,
+	flagWatcher *featureflag.EtcdWatcher,
+	watchBus eventbus.WatchBus,
+) []Task {
+	tasks := InitAPITasks(flagWatcher, watchBus, nil)
+	return append(tasks, schedulerTasks(scheduler, svc, statsSvc, templateUsageSvc, sloSvc, notificationRepo, exportRepo, inboxRepo,
+		escalationSvc, digestSvc, localTimeSvc, templateReviewSvc, vendorBalanceSvc, quotaRepo, quotaReservationRepo, lock, clk)...)
+}
+
+// InitCallbackWorkerTasks 回调角色的后台任务
+func InitCallbackWorkerTasks(callbackLogRepo repository.CallbackLogRepository,
+	notificationRepo repository.NotificationRepository,
+	readReceiptRepo repository.ReadReceiptRepository,
+	callbackClient callback.Client,
+	callbackDeadLetterSvc service.CallbackDeadLetterService,
+	lock distribute_lock.Client,
+	clk clock.Clock,
+	flagWatcher *featureflag.EtcdWatcher,
+) []Task {
+	tasks := InitQueryTasks(flagWatcher)
+	return append(tasks, callbackTasks(callbackLogRepo, notificationRepo, readReceiptRepo, callbackClient, callbackDeadLetterSvc, lock, clk)...)
+}
+
+func schedulerTasks(scheduler *service.Scheduler,
+	svc service.DataRetentionService,
+	statsSvc service.StatisticsService,
+	templateUsageSvc service.TemplateUsageService,
+	sloSvc service.SLOService,
+	notificationRepo repository.NotificationRepository,
+	exportRepo repository.ExportRepository,
+	inboxRepo repository.InboxRepository,
+	escalationSvc service.EscalationService,
+	digestSvc service.DigestService,
+	localTimeSvc service.LocalTimeService,
+	templateReviewSvc service.TemplateReviewService,
+	vendorBalanceSvc service.VendorBalanceService,
+	quotaRepo repository.QuotaRepository,
+	quotaReservationRepo repository.QuotaReservationRepository,
+	lock distribute_lock.Client,
+	clk clock.Clock,
+) []Task {
+	// 分区调度器扫描到期的通知并发送
+	tasks := []Task{scheduler}
+	if conf := loadQuotaConfig(); conf.Warmup {
+		tasks = append(tasks, service.NewQuotaWarmupTask(quotaRepo, conf.WarmupBatchSize))
+	}
+	if conf := loadQuotaConfig(); conf.Reservation.Enabled {
+		tasks = append(tasks, service.NewQuotaReservationRepairTask(quotaReservationRepo,
+			conf.Reservation.Interval, conf.Reservation.Grace, conf.Reservation.BatchSize, clk))
+	}
+	if conf := loadRetentionConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewRetentionTask(svc, lock, conf.Interval))
+	}
+	if conf := loadExpiryConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewExpirySweepTask(notificationRepo, conf.Interval, conf.BatchSize))
+	}
+	if conf := loadSendingTimeoutConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewSendingTimeoutSweepTask(notificationRepo, lock, conf.Interval, conf.BatchSize))
+	}
+	if conf := loadStatsConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewStatsRollupTask(statsSvc, templateUsageSvc, lock, conf.Interval, conf.Lookback))
+	}
+	if conf := loadSLOConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewSLOExportTask(sloSvc, conf.Interval))
+	}
+	if task := initExportTask(exportRepo, lock); task != nil {
+		tasks = append(tasks, task)
+	}
+	if task := initKafkaExportTask(exportRepo, lock); task != nil {
+		tasks = append(tasks, task)
+	}
+	if task := initInboxProjectionTask(exportRepo, inboxRepo, lock); task != nil {
+		tasks = append(tasks, task)
+	}
+	if conf := loadEscalationConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewEscalationTask(escalationSvc, lock, conf.Interval, conf.BatchSize))
+	}
+	if conf := loadDigestConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewDigestTask(digestSvc, lock, conf.Interval, conf.BatchSize))
+	}
+	if conf := loadLocalTimeConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewLocalTimeTask(localTimeSvc, lock, conf.Interval, conf.BatchSize))
+	}
+	if conf := loadTemplateReviewConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewTemplateReviewTask(templateReviewSvc, lock, conf.Interval, conf.BatchSize))
+	}
+	if conf := loadVendorBalanceConfig(); conf.Enabled {
+		tasks = append(tasks, service.NewVendorBalanceTask(vendorBalanceSvc, lock, conf.Interval))
+	}
+	return tasks
+}
+
+func callbackTasks(callbackLogRepo repository.CallbackLogRepository,
+	notificationRepo repository.NotificationRepository,
+	readReceiptRepo repository.ReadReceiptRepository,
+	callbackClient callback.Client,
+	callbackDeadLetterSvc service.CallbackDeadLetterService,
+	lock distribute_lock.Client,
+	clk clock.Clock,
+) []Task {
+	var tasks []Task
+	if task := initNotificationCallbackTask(callbackLogRepo, notificationRepo, callbackClient, lock, clk); task != nil {
+		tasks = append(tasks, task)
+	}
+	if task := initCallbackDeadLetterSummaryTask(callbackDeadLetterSvc, lock); task != nil {
+		tasks = append(tasks, task)
+	}
+	if task := initReadReceiptCallbackTask(readReceiptRepo, callbackClient, lock); task != nil {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+func loadStatsConfig() config.StatsConfig {
+	conf := config.StatsConfig{}
+	if err := viper.UnmarshalKey("stats", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultStatsInterval
+	}
+	if conf.Lookback <= 0 {
+		conf.Lookback = defaultStatsLookback
+	}
+	return conf
+}
+
+func loadExpiryConfig() config.ExpiryConfig {
+	conf := config.ExpiryConfig{}
+	if err := viper.UnmarshalKey("expiry", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultExpiryInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultExpiryBatchSize
+	}
+	return conf
+}
+
+func loadSendingTimeoutConfig() config.SendingTimeoutConfig {
+	conf := config.SendingTimeoutConfig{}
+	if err := viper.UnmarshalKey("sending-timeout", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultSendingTimeoutInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultSendingTimeoutBatchSize
+	}
+	return conf
+}
+
+func loadEscalationConfig() config.EscalationConfig {
+	conf := config.EscalationConfig{}
+	if err := viper.UnmarshalKey("escalation", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultEscalationInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultEscalationBatchSize
+	}
+	return conf
+}
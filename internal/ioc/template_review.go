@@ -0,0 +1,98 @@
+package ioc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/api/audit"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/httpclient"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultTemplateReviewInterval     = 30 * time.Second
+	defaultTemplateReviewBatchSize    = 50
+	defaultTemplateReviewPollInterval = 5 * time.Minute
+	defaultTemplateReviewTimeout      = 10 * time.Second
+)
+
+func loadTemplateReviewConfig() config.TemplateReviewConfig {
+	conf := config.TemplateReviewConfig{}
+	if err := viper.UnmarshalKey("template-review", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultTemplateReviewInterval
+	}
+	if conf.BatchSize <= 0 {
+		conf.BatchSize = defaultTemplateReviewBatchSize
+	}
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = defaultTemplateReviewPollInterval
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultTemplateReviewTimeout
+	}
+	return conf
+}
+
+// InitTemplateReviewService 模板审核，sms-vendors 里的每个短信供应商都需要审核
+func InitTemplateReviewService(repo repository.ChannelTemplateRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc service.ChannelTemplateService,
+	idGenerator idgen.Generator, clk clock.Clock, clients *httpclient.Factory,
+) service.TemplateReviewService {
+	conf := loadTemplateReviewConfig()
+	vendors := loadSMSVendors(clients, conf.Timeout)
+	reviewers := make([]service.NamedTemplateReviewer, 0, len(vendors))
+	for _, v := range vendors {
+		reviewers = append(reviewers, service.NamedTemplateReviewer{
+			Name:     v.conf.Name,
+			Channel:  domain.ChannelSMS,
+			Reviewer: v.client,
+		})
+	}
+	return service.NewTemplateReviewService(repo, notificationRepo, templateSvc, idGenerator, clk,
+		reviewers, conf.PollInterval, templateReviewNotice(conf.Notify))
+}
+
+// templateReviewNotice 审核结果通知，没有配置模板时返回 nil
+func templateReviewNotice(conf config.TemplateReviewNotifyConfig) *domain.TemplateReviewNotice {
+	if conf.TemplateID <= 0 {
+		return nil
+	}
+	channel := domain.Channel(conf.Channel)
+	if conf.BizID <= 0 || !channel.IsValid() {
+		panic(fmt.Errorf("模板审核结果通知的 biz-id 或 channel 不合法: %d %q", conf.BizID, conf.Channel))
+	}
+	receivers := make(map[int64][]string, len(conf.Receivers))
+	for _, r := range conf.Receivers {
+		receivers[r.OwnerID] = append(receivers[r.OwnerID], r.Receivers...)
+	}
+	return &domain.TemplateReviewNotice{
+		BizID:      conf.BizID,
+		Channel:    channel,
+		TemplateID: conf.TemplateID,
+		Receivers:  receivers,
+	}
+}
+
+// InitTemplateAuditHandler 接收供应商推送的审核结果，没有供应商配置推送令牌时返回 nil
+func InitTemplateAuditHandler(svc service.TemplateReviewService, clients *httpclient.Factory) *audit.Handler {
+	tokens := make(map[string]string)
+	for _, v := range loadSMSVendors(clients, defaultTemplateReviewTimeout) {
+		if v.conf.CallbackToken != "" {
+			tokens[v.conf.Name] = v.conf.CallbackToken
+		}
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	return audit.NewHandler(svc, tokens)
+}
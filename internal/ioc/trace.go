@@ -2,8 +2,13 @@ package ioc
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -11,9 +16,22 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
 )
 
+const (
+	defaultTraceShutdownTimeout = 5 * time.Second
+	// OTLP 导出器的默认重试参数
+	defaultTraceRetryInitialInterval = 5 * time.Second
+	defaultTraceRetryMaxInterval     = 30 * time.Second
+	defaultTraceRetryMaxElapsedTime  = time.Minute
+)
+
 func InitJeagerTracer() *trace.TracerProvider {
+	conf := config.TraceConfig{}
+	if err := viper.UnmarshalKey("trace", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+
 	// 创建资源信息
-	res, err := newResource()
+	res, err := newResource(conf.Jeager)
 	if err != nil {
 		panic(err)
 	}
@@ -22,7 +40,7 @@ func InitJeagerTracer() *trace.TracerProvider {
 	otel.SetTextMapPropagator(newPropagator())
 
 	// 初始化 tracer provider
-	tp, err := newTracerProvider(res)
+	tp, err := newTracerProvider(conf, res)
 	if err != nil {
 		panic(err)
 	}
@@ -33,34 +51,142 @@ func InitJeagerTracer() *trace.TracerProvider {
 	return tp
 }
 
+// ShutdownTracer 上报剩余的 span，退出时调用
+func ShutdownTracer(tp *trace.TracerProvider) error {
+	timeout := viper.GetDuration("trace.shutdown-timeout")
+	if timeout <= 0 {
+		timeout = defaultTraceShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return tp.Shutdown(ctx)
+}
+
 // newResource 创建 OpenTelemetry 资源
-func newResource() (*resource.Resource, error) {
-	serviceName := viper.GetString("trace.jeager.serviceName")
+func newResource(conf config.JeagerConfig) (*resource.Resource, error) {
 	serviceVersion := "v0.0.1"
 
 	return resource.Merge(
 		resource.Default(),
 		resource.NewWithAttributes(
 			semconv.SchemaURL,
-			semconv.ServiceName(serviceName),
+			semconv.ServiceName(conf.ServiceName),
 			semconv.ServiceVersion(serviceVersion),
 		),
 	)
 }
 
-// newTracerProvider 创建 tracer provider
-func newTracerProvider(res *resource.Resource) (*trace.TracerProvider, error) {
-	// 从配置读取 jeager 端点地址
-	jeagerEndpoint := viper.GetString("trace.jeager.endpoint")
-	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpoint(jeagerEndpoint))
+// newTracerProvider 创建 tracer provider，没有配置上报地址时只生成链路不上报
+func newTracerProvider(conf config.TraceConfig, res *resource.Resource) (*trace.TracerProvider, error) {
+	sampler, err := newSampler(conf.Sampler)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	// 创建 tracer provider
-	return trace.NewTracerProvider(
-		trace.WithBatcher(exporter),
+	opts := []trace.TracerProviderOption{
+		trace.WithSampler(sampler),
 		trace.WithResource(res),
-	), nil
+	}
+	if conf.Jeager.Endpoint != "" {
+		exporter, err := otlptracehttp.New(context.Background(), exporterOptions(conf.Jeager.Endpoint, conf.Exporter)...)
+		if err != nil {
+			return nil, err
+		}
+		var batchOpts []trace.BatchSpanProcessorOption
+		if conf.Exporter.BatchTimeout > 0 {
+			batchOpts = append(batchOpts, trace.WithBatchTimeout(conf.Exporter.BatchTimeout))
+		}
+		if conf.Exporter.MaxQueueSize > 0 {
+			batchOpts = append(batchOpts, trace.WithMaxQueueSize(conf.Exporter.MaxQueueSize))
+		}
+		opts = append(opts, trace.WithBatcher(exporter, batchOpts...))
+	}
+	return trace.NewTracerProvider(opts...), nil
+}
+
+func exporterOptions(endpoint string, conf config.TraceExporterConfig) []otlptracehttp.Option {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if conf.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if conf.Timeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(conf.Timeout))
+	}
+	retry := otlptracehttp.RetryConfig{
+		Enabled:         !conf.Retry.Disabled,
+		InitialInterval: defaultTraceRetryInitialInterval,
+		MaxInterval:     defaultTraceRetryMaxInterval,
+		MaxElapsedTime:  defaultTraceRetryMaxElapsedTime,
+	}
+	if conf.Retry.InitialInterval > 0 {
+		retry.InitialInterval = conf.Retry.InitialInterval
+	}
+	if conf.Retry.MaxInterval > 0 {
+		retry.MaxInterval = conf.Retry.MaxInterval
+	}
+	if conf.Retry.MaxElapsedTime > 0 {
+		retry.MaxElapsedTime = conf.Retry.MaxElapsedTime
+	}
+	return append(opts, otlptracehttp.WithRetry(retry))
+}
+
+// newSampler 默认跟随上游的采样结果，只有链路的第一个 span 按配置采样
+func newSampler(conf config.TraceSamplerConfig) (trace.Sampler, error) {
+	root, err := ratioSampler(conf.Type, conf.Ratio)
+	if err != nil {
+		return nil, err
+	}
+	if len(conf.Methods) > 0 {
+		methods := make(map[string]trace.Sampler, len(conf.Methods))
+		for _, m := range conf.Methods {
+			methods[m.Method] = trace.TraceIDRatioBased(m.Ratio)
+		}
+		root = &methodSampler{methods: methods, fallback: root}
+	}
+	if conf.IgnoreParent {
+		return root, nil
+	}
+	return trace.ParentBased(root), nil
+}
+
+func ratioSampler(typ string, ratio float64) (trace.Sampler, error) {
+	switch typ {
+	case "", config.SamplerAlways:
+		return trace.AlwaysSample(), nil
+	case config.SamplerNever:
+		return trace.NeverSample(), nil
+	case config.SamplerRatio:
+		return trace.TraceIDRatioBased(ratio), nil
+	default:
+		return nil, fmt.Errorf("trace.sampler.type 不合法: %q", typ)
+	}
+}
+
+// methodSampler 按 gRPC 方法选择采样比例，方法名来自 tracing 拦截器设置的 rpc.service、rpc.method 属性
+type methodSampler struct {
+	methods  map[string]trace.Sampler
+	fallback trace.Sampler
+}
+
+func (s *methodSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	var service, method string
+	for _, attr := range p.Attributes {
+		switch attr.Key {
+		case attribute.Key("rpc.service"):
+			service = attr.Value.AsString()
+		case attribute.Key("rpc.method"):
+			method = attr.Value.AsString()
+		}
+	}
+	if service != "" && method != "" {
+		if sampler, ok := s.methods["/"+service+"/"+method]; ok {
+			return sampler.ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *methodSampler) Description() string {
+	return fmt.Sprintf("MethodSampler{methods:%d,fallback:%s}", len(s.methods), s.fallback.Description())
 }
 
 // newPropagator 创建上下文传播器
@@ -0,0 +1,88 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/api/unsubscribe"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/httpclient"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"github.com/spf13/viper"
+)
+
+const defaultUnsubscribeTokenTTL = 365 * 24 * time.Hour
+
+var defaultStopKeywords = []string{"TD", "T", "N", "STOP", "UNSUBSCRIBE", "退订"}
+
+func loadUnsubscribeConfig() config.UnsubscribeConfig {
+	conf := config.UnsubscribeConfig{}
+	if err := viper.UnmarshalKey("unsubscribe", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.TokenTTL <= 0 {
+		conf.TokenTTL = defaultUnsubscribeTokenTTL
+	}
+	if len(conf.StopKeywords) == 0 {
+		conf.StopKeywords = defaultStopKeywords
+	}
+	return conf
+}
+
+// InitUnsubscribeTokenSigner 退订凭证签发，没有开启退订时返回 nil
+func InitUnsubscribeTokenSigner() *unsubscribe.TokenSigner {
+	conf := loadUnsubscribeConfig()
+	if !conf.Enabled {
+		return nil
+	}
+	if conf.TokenKey == "" || conf.BaseURL == "" {
+		panic("开启退订时必须配置 unsubscribe.token-key 和 unsubscribe.base-url")
+	}
+	gatewayConf := config.GatewayConfig{}
+	if err := viper.UnmarshalKey("gateway", &gatewayConf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if !gatewayConf.Enabled {
+		panic("开启 unsubscribe 时必须开启 gateway")
+	}
+	return unsubscribe.NewTokenSigner([]byte(conf.TokenKey), conf.TokenTTL, conf.BaseURL)
+}
+
+// InitUnsubscribeService 退订，所有短信供应商账号推送的上行短信都可以处理退订关键字
+func InitUnsubscribeService(repo repository.OptOutRepository, clients *httpclient.Factory,
+	logger log.LoggerInterface,
+) service.UnsubscribeService {
+	parsers := make(map[string]provider.SMSReplyParser)
+	// 只解析推送，不调用供应商接口
+	for _, v := range loadSMSVendors(clients, 0) {
+		parsers[v.conf.Name] = v.client
+	}
+	return service.NewUnsubscribeService(repo, parsers, loadUnsubscribeConfig().StopKeywords, logger)
+}
+
+// InitUnsubscribeHandler 退订链接，没有开启退订时返回 nil
+func InitUnsubscribeHandler(signer *unsubscribe.TokenSigner, svc service.UnsubscribeService) *unsubscribe.Handler {
+	if signer == nil {
+		return nil
+	}
+	return unsubscribe.NewHandler(signer, svc)
+}
+
+// InitSMSReplyHandler 接收供应商推送的上行短信，没有开启退订或者没有供应商配置推送令牌时返回 nil
+func InitSMSReplyHandler(svc service.UnsubscribeService, clients *httpclient.Factory) *unsubscribe.SMSReplyHandler {
+	if !loadUnsubscribeConfig().Enabled {
+		return nil
+	}
+	tokens := make(map[string]string)
+	for _, v := range loadSMSVendors(clients, 0) {
+		if v.conf.CallbackToken != "" {
+			tokens[v.conf.Name] = v.conf.CallbackToken
+		}
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	return unsubscribe.NewSMSReplyHandler(svc, tokens)
+}
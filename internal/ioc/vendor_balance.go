@@ -0,0 +1,63 @@
+package ioc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/anomaly"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/httpclient"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultVendorBalanceInterval  = 10 * time.Minute
+	defaultVendorBalanceTimeout   = 10 * time.Second
+	defaultVendorBalanceCooldown  = time.Hour
+	defaultVendorBalanceRetention = 30 * 24 * time.Hour
+)
+
+func loadVendorBalanceConfig() config.VendorBalanceConfig {
+	conf := config.VendorBalanceConfig{}
+	if err := viper.UnmarshalKey("vendor-balance", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = defaultVendorBalanceInterval
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = defaultVendorBalanceTimeout
+	}
+	if conf.Cooldown <= 0 {
+		conf.Cooldown = defaultVendorBalanceCooldown
+	}
+	if conf.Retention <= 0 {
+		conf.Retention = defaultVendorBalanceRetention
+	}
+	return conf
+}
+
+// InitVendorBalanceService 供应商余额查询和告警
+func InitVendorBalanceService(repo repository.VendorBalanceRepository, clients *httpclient.Factory) service.VendorBalanceService {
+	conf := loadVendorBalanceConfig()
+	vendors := loadSMSVendors(clients, conf.Timeout)
+	queriers := make([]service.NamedBalanceQuerier, 0, len(vendors))
+	for _, v := range vendors {
+		queriers = append(queriers, service.NamedBalanceQuerier{
+			Name:       v.conf.Name,
+			Querier:    v.client,
+			AlertBelow: v.conf.BalanceAlertBelow,
+		})
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	var alerters []anomaly.TextAlerter
+	if conf.IMBotURL != "" {
+		alerters = append(alerters, anomaly.NewIMBotTextAlerter(conf.IMBotURL, client))
+	}
+	if conf.WebhookURL != "" {
+		alerters = append(alerters, anomaly.NewWebhookTextAlerter(conf.WebhookURL, client))
+	}
+	return service.NewVendorBalanceService(repo, queriers, alerters, conf.Cooldown, conf.Retention)
+}
@@ -0,0 +1,65 @@
+package ioc
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultWatchChannel         = "notification:watch"
+	defaultWatchRefreshInterval = time.Second
+	defaultWatchPollInterval    = 10 * time.Second
+	defaultWatchMaxDuration     = 30 * time.Minute
+	defaultWatchMaxConcurrent   = 20
+)
+
+func loadWatchConfig() config.WatchConfig {
+	conf := config.WatchConfig{}
+	if err := viper.UnmarshalKey("watch", &conf, config.TagName("yaml")); err != nil {
+		panic(err)
+	}
+	if conf.Channel == "" {
+		conf.Channel = defaultWatchChannel
+	}
+	if conf.RefreshInterval <= 0 {
+		conf.RefreshInterval = defaultWatchRefreshInterval
+	}
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = defaultWatchPollInterval
+	}
+	if conf.MaxDuration <= 0 {
+		conf.MaxDuration = defaultWatchMaxDuration
+	}
+	if conf.MaxConcurrent <= 0 {
+		conf.MaxConcurrent = defaultWatchMaxConcurrent
+	}
+	return conf
+}
+
+// InitWatchBus 通知变化总线，通知仓储发布，WatchNotification 订阅，没有开启 watch 时返回 nil
+func InitWatchBus(client *redis.Client) eventbus.WatchBus {
+	conf := loadWatchConfig()
+	if !conf.Enabled {
+		return nil
+	}
+	return eventbus.NewRedisWatchBus(client, conf.Channel, conf.RefreshInterval)
+}
+
+// InitNotificationWatchService 没有开启 watch 时返回 nil
+func InitNotificationWatchService(repo repository.NotificationRepository, bus eventbus.WatchBus) service.NotificationWatchService {
+	if bus == nil {
+		return nil
+	}
+	conf := loadWatchConfig()
+	return service.NewNotificationWatchService(repo, bus, service.NotificationWatchOptions{
+		PollInterval:  conf.PollInterval,
+		MaxDuration:   conf.MaxDuration,
+		MaxConcurrent: conf.MaxConcurrent,
+	})
+}
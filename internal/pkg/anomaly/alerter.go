@@ -0,0 +1,125 @@
+package anomaly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+)
+
+const defaultAlertTimeout = 5 * time.Second
+
+// Alerter 告警通道
+type Alerter interface {
+	Alert(ctx context.Context, alert Alert) error
+}
+
+// AlertHandler 把异常发送到告警通道，发送失败只记录日志
+func AlertHandler(alerter Alerter, logger log.LoggerInterface) Handler {
+	return func(ctx context.Context, alert Alert) {
+		ctx, cancel := context.WithTimeout(ctx, defaultAlertTimeout)
+		defer cancel()
+		if err := alerter.Alert(ctx, alert); err != nil {
+			logger.WithContext(ctx).Error("发送失败率异常告警失败", zap.Error(err),
+				zap.String("dimension", alert.Dimension),
+				zap.String("key", alert.Key))
+		}
+	}
+}
+
+// TextAlerter 其他场景（比如供应商余额不足）复用同样的告警通道，发送标题和正文
+type TextAlerter interface {
+	AlertText(ctx context.Context, title, content string) error
+}
+
+var (
+	_ Alerter     = (*webhookAlerter)(nil)
+	_ Alerter     = (*imBotAlerter)(nil)
+	_ TextAlerter = (*webhookAlerter)(nil)
+	_ TextAlerter = (*imBotAlerter)(nil)
+)
+
+// NewWebhookAlerter 把告警以 JSON 的形式 POST 到回调地址
+func NewWebhookAlerter(url string, client *http.Client) Alerter {
+	return &webhookAlerter{url: url, client: client}
+}
+
+type webhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+func (a *webhookAlerter) Alert(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, a.client, a.url, alert)
+}
+
+// NewWebhookTextAlerter 把文本告警以 JSON 的形式 POST 到回调地址，请求体是 title、content、time
+func NewWebhookTextAlerter(url string, client *http.Client) TextAlerter {
+	return &webhookAlerter{url: url, client: client}
+}
+
+func (a *webhookAlerter) AlertText(ctx context.Context, title, content string) error {
+	return postJSON(ctx, a.client, a.url, map[string]any{
+		"title":   title,
+		"content": content,
+		"time":    time.Now(),
+	})
+}
+
+// NewIMBotAlerter 发送到群机器人，消息格式兼容钉钉和企业微信的文本消息
+func NewIMBotAlerter(url string, client *http.Client) Alerter {
+	return &imBotAlerter{url: url, client: client}
+}
+
+type imBotAlerter struct {
+	url    string
+	client *http.Client
+}
+
+func (a *imBotAlerter) Alert(ctx context.Context, alert Alert) error {
+	content := fmt.Sprintf("[通知平台] 发送失败率异常\n维度: %s\n对象: %s\n当前失败率: %.2f%%\n基线: %.2f%%\n样本数: %d\n时间: %s",
+		alert.Dimension, alert.Key, alert.FailureRate*100, alert.Baseline*100, alert.Samples,
+		alert.Time.Format(time.DateTime))
+	return postJSON(ctx, a.client, a.url, map[string]any{
+		"msgtype": "text",
+		"text":    map[string]string{"content": content},
+	})
+}
+
+// NewIMBotTextAlerter 发送文本告警到群机器人
+func NewIMBotTextAlerter(url string, client *http.Client) TextAlerter {
+	return &imBotAlerter{url: url, client: client}
+}
+
+func (a *imBotAlerter) AlertText(ctx context.Context, title, content string) error {
+	return postJSON(ctx, a.client, a.url, map[string]any{
+		"msgtype": "text",
+		"text":    map[string]string{"content": "[通知平台] " + title + "\n" + content},
+	})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("告警回调返回 %d", resp.StatusCode)
+	}
+	return nil
+}
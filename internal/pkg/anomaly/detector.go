@@ -0,0 +1,215 @@
+package anomaly
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DimensionProvider 按供应商统计
+	DimensionProvider = "provider"
+	// DimensionBiz 按业务方统计
+	DimensionBiz = "biz"
+)
+
+var (
+	failureRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "send_failure_rate",
+			Help: "Send failure rate in the current sliding window",
+		},
+		[]string{"dimension", "key"},
+	)
+	alertCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "send_failure_anomaly_total",
+			Help: "Total number of send failure rate anomalies detected",
+		},
+		[]string{"dimension", "key"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(failureRateGauge, alertCounter)
+}
+
+// Options 异常检测参数，零值使用默认值
+type Options struct {
+	// Window 滑动窗口长度，默认 1 分钟
+	Window time.Duration
+	// Buckets 窗口分桶数量，默认 6
+	Buckets int
+	// MinSamples 窗口内样本数少于这个值不做判断，默认 20
+	MinSamples int64
+	// MinFailureRate 失败率低于这个值不告警，默认 0.2
+	MinFailureRate float64
+	// SpikeRatio 失败率达到基线的多少倍算异常，默认 3
+	SpikeRatio float64
+	// BaselineAlpha 基线的指数加权系数，越大基线跟得越快，默认 0.1
+	BaselineAlpha float64
+	// Cooldown 同一个对象两次告警的最小间隔，默认 5 分钟
+	Cooldown time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.Window <= 0 {
+		o.Window = time.Minute
+	}
+	if o.Buckets <= 0 {
+		o.Buckets = 6
+	}
+	if o.MinSamples <= 0 {
+		o.MinSamples = 20
+	}
+	if o.MinFailureRate <= 0 {
+		o.MinFailureRate = 0.2
+	}
+	if o.SpikeRatio <= 0 {
+		o.SpikeRatio = 3
+	}
+	if o.BaselineAlpha <= 0 || o.BaselineAlpha > 1 {
+		o.BaselineAlpha = 0.1
+	}
+	if o.Cooldown <= 0 {
+		o.Cooldown = 5 * time.Minute
+	}
+	return o
+}
+
+// Alert 失败率异常
+type Alert struct {
+	Dimension   string    `json:"dimension"`
+	Key         string    `json:"key"`
+	FailureRate float64   `json:"failureRate"`
+	Baseline    float64   `json:"baseline"`
+	Samples     int64     `json:"samples"`
+	Time        time.Time `json:"time"`
+}
+
+// Handler 发现异常时的处理，在独立的 goroutine 中执行，不阻塞发送
+type Handler func(ctx context.Context, alert Alert)
+
+// Detector 滑动窗口失败率异常检测
+// 当前窗口的失败率同时满足：样本数足够、超过最低失败率、达到基线的 SpikeRatio 倍，就认为是异常
+// 滑出窗口的分桶按照指数加权并入基线，所以基线反映的是最近一段时间的正常水平
+type Detector struct {
+	opts       Options
+	bucketSize time.Duration
+	handlers   []Handler
+
+	mu     sync.Mutex
+	series map[seriesKey]*series
+	now    func() time.Time
+}
+
+func NewDetector(opts Options, handlers ...Handler) *Detector {
+	opts = opts.withDefaults()
+	return &Detector{
+		opts:       opts,
+		bucketSize: opts.Window / time.Duration(opts.Buckets),
+		handlers:   handlers,
+		series:     make(map[seriesKey]*series),
+		now:        time.Now,
+	}
+}
+
+type seriesKey struct {
+	dimension string
+	key       string
+}
+
+type bucket struct {
+	idx    int64
+	total  int64
+	failed int64
+}
+
+type series struct {
+	buckets       []bucket
+	baseline      float64
+	baselineReady bool
+	lastAlert     time.Time
+}
+
+// Record 记录一次发送结果
+func (d *Detector) Record(ctx context.Context, dimension, key string, failed bool) {
+	now := d.now()
+	alert, ok := d.record(seriesKey{dimension: dimension, key: key}, now, failed)
+	if !ok {
+		return
+	}
+	alertCounter.WithLabelValues(dimension, key).Inc()
+	for _, h := range d.handlers {
+		go h(context.WithoutCancel(ctx), alert)
+	}
+}
+
+func (d *Detector) record(k seriesKey, now time.Time, failed bool) (Alert, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s, ok := d.series[k]
+	if !ok {
+		s = &series{buckets: make([]bucket, d.opts.Buckets)}
+		d.series[k] = s
+	}
+	idx := now.UnixNano() / int64(d.bucketSize)
+	pos := int(idx % int64(len(s.buckets)))
+	if s.buckets[pos].idx != idx {
+		d.fold(s, s.buckets[pos])
+		s.buckets[pos] = bucket{idx: idx}
+	}
+	s.buckets[pos].total++
+	if failed {
+		s.buckets[pos].failed++
+	}
+
+	var total, failedCnt int64
+	for i := range s.buckets {
+		b := s.buckets[i]
+		if b.idx <= idx-int64(len(s.buckets)) {
+			// 长时间没有流量，已经滑出窗口但是还没被覆盖的分桶
+			d.fold(s, b)
+			s.buckets[i] = bucket{}
+			continue
+		}
+		total += b.total
+		failedCnt += b.failed
+	}
+	rate := float64(failedCnt) / float64(total)
+	failureRateGauge.WithLabelValues(k.dimension, k.key).Set(rate)
+
+	if total < d.opts.MinSamples || rate < d.opts.MinFailureRate {
+		return Alert{}, false
+	}
+	if s.baselineReady && rate < s.baseline*d.opts.SpikeRatio {
+		return Alert{}, false
+	}
+	if now.Sub(s.lastAlert) < d.opts.Cooldown {
+		return Alert{}, false
+	}
+	s.lastAlert = now
+	return Alert{
+		Dimension:   k.dimension,
+		Key:         k.key,
+		FailureRate: rate,
+		Baseline:    s.baseline,
+		Samples:     total,
+		Time:        now,
+	}, true
+}
+
+// fold 把滑出窗口的分桶并入基线
+func (d *Detector) fold(s *series, b bucket) {
+	if b.total == 0 {
+		return
+	}
+	rate := float64(b.failed) / float64(b.total)
+	if !s.baselineReady {
+		s.baseline, s.baselineReady = rate, true
+		return
+	}
+	s.baseline = d.opts.BaselineAlpha*rate + (1-d.opts.BaselineAlpha)*s.baseline
+}
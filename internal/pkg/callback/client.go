@@ -0,0 +1,105 @@
+package callback
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	sdkcallback "github.com/serendipityConfusion/notification-platform/sdk/callback"
+)
+
+// SecretProvider 业务方当前参与签名的密钥
+type SecretProvider interface {
+	ActiveSecrets(ctx context.Context, bizID int64) ([]string, error)
+}
+
+// Client 回调业务方的 HTTP 接口，请求体使用业务方的密钥签名
+type Client interface {
+	// Post headers 是额外带上的请求头，不会覆盖 Content-Type 和签名相关的请求头
+	Post(ctx context.Context, bizID int64, url string, body []byte, headers map[string]string) error
+}
+
+var _ Client = (*client)(nil)
+
+func NewClient(httpClient *http.Client, secrets SecretProvider) Client {
+	return &client{
+		httpClient: httpClient,
+		secrets:    secrets,
+		now:        time.Now,
+	}
+}
+
+type client struct {
+	httpClient *http.Client
+	secrets    SecretProvider
+	now        func() time.Time
+}
+
+func (c *client) Post(ctx context.Context, bizID int64, url string, body []byte, headers map[string]string) error {
+	secrets, err := c.secrets.ActiveSecrets(ctx, bizID)
+	if err != nil {
+		return fmt.Errorf("获取回调签名密钥失败: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	ts := c.now().UnixMilli()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(sdkcallback.TimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(sdkcallback.SignatureHeader, sdkcallback.SignatureHeaderValue(secrets, ts, body))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("回调返回 %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// TLSOptions 回调的 TLS 配置，CertFile 和 KeyFile 都不为空时使用 mTLS 向业务方证明平台身份
+type TLSOptions struct {
+	CertFile string
+	KeyFile  string
+	// CAFile 校验业务方服务端证书的 CA，为空使用系统 CA
+	CAFile string
+}
+
+// NewHTTPClient 回调使用的 HTTP 客户端
+func NewHTTPClient(opts TLSOptions, timeout time.Duration) (*http.Client, error) {
+	tlsConf := &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载回调客户端证书失败: %w", err)
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取回调 CA 证书失败: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("回调 CA 证书 %s 中没有合法的证书", opts.CAFile)
+		}
+		tlsConf.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConf
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
@@ -0,0 +1,232 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// EndpointState 回调地址的熔断状态
+type EndpointState string
+
+const (
+	// EndpointStateClosed 正常回调
+	EndpointStateClosed EndpointState = "CLOSED"
+	// EndpointStateOpen 暂停回调，到了探测时间放一个请求过去
+	EndpointStateOpen EndpointState = "OPEN"
+	// EndpointStateHalfOpen 探测请求进行中，其余请求依旧暂停
+	EndpointStateHalfOpen EndpointState = "HALF_OPEN"
+)
+
+var endpointScoreGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "callback_endpoint_health_score",
+		Help: "Exponentially weighted success rate of business callback endpoints",
+	},
+	[]string{"endpoint"},
+)
+
+func init() {
+	prometheus.MustRegister(endpointScoreGauge)
+}
+
+// HealthOptions 健康度和熔断参数，零值使用默认值
+type HealthOptions struct {
+	// ScoreAlpha 健康分的指数加权系数，默认 0.1
+	ScoreAlpha float64
+	// MinScore 健康分低于这个值熔断，默认 0.5
+	MinScore float64
+	// MinSamples 样本数少于这个值不按健康分熔断，默认 20
+	MinSamples int64
+	// FailureThreshold 连续失败多少次熔断，默认 5
+	FailureThreshold int64
+	// BaseBackoff 第一次熔断后多久探测，默认 10 秒，之后每次探测失败翻倍
+	BaseBackoff time.Duration
+	// MaxBackoff 探测间隔的上限，默认 10 分钟
+	MaxBackoff time.Duration
+}
+
+func (o HealthOptions) withDefaults() HealthOptions {
+	if o.ScoreAlpha <= 0 || o.ScoreAlpha > 1 {
+		o.ScoreAlpha = 0.1
+	}
+	if o.MinScore <= 0 {
+		o.MinScore = 0.5
+	}
+	if o.MinSamples <= 0 {
+		o.MinSamples = 20
+	}
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = 5
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 10 * time.Second
+	}
+	if o.MaxBackoff < o.BaseBackoff {
+		o.MaxBackoff = max(10*time.Minute, o.BaseBackoff)
+	}
+	return o
+}
+
+// EndpointHealth 回调地址的健康状况
+type EndpointHealth struct {
+	Endpoint            string
+	Score               float64
+	State               EndpointState
+	ConsecutiveFailures int64
+	Total               int64
+	Failed              int64
+	// NextProbeTime 熔断状态下下一次探测的时间
+	NextProbeTime   time.Time
+	LastFailureTime time.Time
+	LastError       string
+}
+
+// HealthTracker 统计每个回调地址的成功率，不健康的地址暂停回调，按指数退避探测恢复
+type HealthTracker struct {
+	opts HealthOptions
+
+	mu        sync.Mutex
+	endpoints map[string]*endpoint
+	now       func() time.Time
+}
+
+func NewHealthTracker(opts HealthOptions) *HealthTracker {
+	return &HealthTracker{
+		opts:      opts.withDefaults(),
+		endpoints: make(map[string]*endpoint),
+		now:       time.Now,
+	}
+}
+
+type endpoint struct {
+	EndpointHealth
+	backoff time.Duration
+}
+
+// Allow 是否可以回调，熔断状态下到了探测时间只放行一个请求
+func (t *HealthTracker) Allow(url string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.endpoints[url]
+	if !ok {
+		return true
+	}
+	switch e.State {
+	case EndpointStateOpen:
+		if t.now().Before(e.NextProbeTime) {
+			return false
+		}
+		e.State = EndpointStateHalfOpen
+		return true
+	case EndpointStateHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// Record 记录一次回调结果
+func (t *HealthTracker) Record(url string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	e, ok := t.endpoints[url]
+	if !ok {
+		e = &endpoint{EndpointHealth: EndpointHealth{Endpoint: url, Score: 1, State: EndpointStateClosed}}
+		t.endpoints[url] = e
+	}
+	e.Total++
+	outcome := 1.0
+	if err != nil {
+		outcome = 0
+		e.Failed++
+		e.ConsecutiveFailures++
+		e.LastFailureTime = now
+		e.LastError = err.Error()
+	} else {
+		e.ConsecutiveFailures = 0
+	}
+	e.Score = t.opts.ScoreAlpha*outcome + (1-t.opts.ScoreAlpha)*e.Score
+	endpointScoreGauge.WithLabelValues(url).Set(e.Score)
+
+	if e.State == EndpointStateHalfOpen {
+		if err == nil {
+			// 探测成功，恢复回调，健康分重新开始累计，避免刚恢复又因为历史分数被熔断
+			e.State, e.backoff, e.Score = EndpointStateClosed, 0, 1
+			return
+		}
+		e.backoff = min(e.backoff*2, t.opts.MaxBackoff)
+		e.State, e.NextProbeTime = EndpointStateOpen, now.Add(e.backoff)
+		return
+	}
+	if e.State == EndpointStateClosed && err != nil &&
+		(e.ConsecutiveFailures >= t.opts.FailureThreshold ||
+			(e.Total >= t.opts.MinSamples && e.Score < t.opts.MinScore)) {
+		e.backoff = t.opts.BaseBackoff
+		e.State, e.NextProbeTime = EndpointStateOpen, now.Add(e.backoff)
+	}
+}
+
+// Snapshot 所有回调地址的健康状况，按地址排序
+func (t *HealthTracker) Snapshot() []EndpointHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	res := make([]EndpointHealth, 0, len(t.endpoints))
+	for _, e := range t.endpoints {
+		res = append(res, e.EndpointHealth)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Endpoint < res[j].Endpoint })
+	return res
+}
+
+var _ Client = (*breakerClient)(nil)
+
+// NewBreakerClient 回调前检查地址健康状况，熔断中的地址直接返回 domain.ErrCircuitBreaker，由调用方稍后重试
+func NewBreakerClient(c Client, tracker *HealthTracker) Client {
+	return &breakerClient{client: c, tracker: tracker}
+}
+
+type breakerClient struct {
+	client  Client
+	tracker *HealthTracker
+}
+
+func (c *breakerClient) Post(ctx context.Context, bizID int64, url string, body []byte, headers map[string]string) error {
+	endpoint := endpointOf(url)
+	if !c.tracker.Allow(endpoint) {
+		return fmt.Errorf("%w: 回调地址 %s 不健康，暂停回调", domain.ErrCircuitBreaker, endpoint)
+	}
+	err := c.client.Post(ctx, bizID, url, body, headers)
+	if ctx.Err() != nil {
+		// 调用方自己取消的请求不代表地址不健康
+		c.tracker.releaseProbe(endpoint)
+		return err
+	}
+	c.tracker.Record(endpoint, err)
+	return err
+}
+
+// endpointOf 按 scheme://host 统计健康度，发送时可以指定回调地址，按完整地址统计时指标会无限增长
+func endpointOf(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// releaseProbe 探测请求被调用方取消时放弃这次探测，下一个请求重新探测
+func (t *HealthTracker) releaseProbe(url string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.endpoints[url]; ok && e.State == EndpointStateHalfOpen {
+		e.State = EndpointStateOpen
+	}
+}
@@ -0,0 +1,51 @@
+// Package clock 时间来源，依赖当前时间的逻辑通过 Clock 获取时间和定时器，测试时注入 Fake 控制时间
+package clock
+
+import "time"
+
+// Clock 时间来源
+type Clock interface {
+	// Now 当前时间
+	Now() time.Time
+	// Since 从 t 到现在经过的时间
+	Since(t time.Time) time.Duration
+	// NewTimer 创建 d 之后触发的定时器
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer 定时器，语义和 time.Timer 一样
+type Timer interface {
+	// C 到期时收到到期的时间
+	C() <-chan time.Time
+	// Stop 停止定时器，定时器还没有触发时返回 true
+	Stop() bool
+	// Reset 重新设置为 d 之后触发，定时器还没有触发时返回 true
+	Reset(d time.Duration) bool
+}
+
+// Real 系统时钟
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{Timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
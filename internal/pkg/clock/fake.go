@@ -0,0 +1,116 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+var _ Clock = (*Fake)(nil)
+
+// Fake 手动推进的时钟，只有调用 Advance、Set 时时间才会变化，到期的定时器随之触发
+type Fake struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake 创建从 now 开始的时钟
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1)}
+	f.timers = append(f.timers, t)
+	t.reset(d)
+	return t
+}
+
+// Advance 把时间往后推进 d，触发所有到期的定时器
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.fire()
+}
+
+// Set 把时间设置为 now，触发所有到期的定时器，往回拨不会让已经触发的定时器重新触发
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+	f.fire()
+}
+
+// Timers 还没有触发的定时器数量，测试用它等待被测代码创建或者重置定时器
+func (f *Fake) Timers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, t := range f.timers {
+		if t.active {
+			n++
+		}
+	}
+	return n
+}
+
+// fire 调用方持有锁
+func (f *Fake) fire() {
+	for _, t := range f.timers {
+		if t.active && !t.deadline.After(f.now) {
+			t.active = false
+			// 和 time.Timer 一样，上一次的到期时间没有被取走时丢弃这一次
+			select {
+			case t.c <- f.now:
+			default:
+			}
+		}
+	}
+}
+
+type fakeTimer struct {
+	clock    *Fake
+	c        chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	t.active = false
+	return active
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.reset(d)
+}
+
+// reset 调用方持有锁
+func (t *fakeTimer) reset(d time.Duration) bool {
+	active := t.active
+	t.deadline = t.clock.now.Add(d)
+	t.active = true
+	t.clock.fire()
+	return active
+}
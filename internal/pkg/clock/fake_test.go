@@ -0,0 +1,75 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	if !f.Now().Equal(start) {
+		t.Fatalf("起始时间是 %v", f.Now())
+	}
+
+	f.Advance(time.Minute)
+	if got := f.Since(start); got != time.Minute {
+		t.Fatalf("Advance 之后经过了 %v", got)
+	}
+	later := start.Add(time.Hour)
+	f.Set(later)
+	if !f.Now().Equal(later) {
+		t.Fatalf("Set 之后的时间是 %v", f.Now())
+	}
+
+	timer := f.NewTimer(10 * time.Second)
+	stopped := f.NewTimer(10 * time.Second)
+	if f.Timers() != 2 {
+		t.Fatalf("还没有触发的定时器有 %d 个", f.Timers())
+	}
+	if !stopped.Stop() {
+		t.Fatal("还没有触发的定时器 Stop 应该返回 true")
+	}
+	f.Advance(9 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("没有到期的定时器触发了")
+	default:
+	}
+	f.Advance(time.Second)
+	select {
+	case got := <-timer.C():
+		if !got.Equal(later.Add(10 * time.Second)) {
+			t.Fatalf("定时器收到的时间是 %v", got)
+		}
+	default:
+		t.Fatal("到期的定时器没有触发")
+	}
+	select {
+	case <-stopped.C():
+		t.Fatal("停止的定时器触发了")
+	default:
+	}
+	if f.Timers() != 0 {
+		t.Fatalf("触发之后还有 %d 个定时器", f.Timers())
+	}
+
+	// 往回拨不会让已经触发的定时器重新触发，Reset 之后按新的时间触发
+	f.Set(later)
+	if timer.Reset(time.Second) {
+		t.Fatal("已经触发的定时器 Reset 应该返回 false")
+	}
+	f.Set(later.Add(time.Second))
+	select {
+	case <-timer.C():
+	default:
+		t.Fatal("Set 到到期时间之后定时器没有触发")
+	}
+
+	// 时长不大于 0 的定时器创建时立即触发
+	select {
+	case <-f.NewTimer(0).C():
+	default:
+		t.Fatal("时长为 0 的定时器没有立即触发")
+	}
+}
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// AnomalyConfig 发送失败率异常检测配置，零值使用默认值
+type AnomalyConfig struct {
+	Window         time.Duration `json:"window" yaml:"window"`
+	Buckets        int           `json:"buckets" yaml:"buckets"`
+	MinSamples     int64         `json:"min-samples" yaml:"min-samples"`
+	MinFailureRate float64       `json:"min-failure-rate" yaml:"min-failure-rate"`
+	SpikeRatio     float64       `json:"spike-ratio" yaml:"spike-ratio"`
+	Cooldown       time.Duration `json:"cooldown" yaml:"cooldown"`
+	// IMBotURL 群机器人地址，为空不发送
+	IMBotURL string `json:"im-bot-url" yaml:"im-bot-url"`
+	// WebhookURL 告警回调地址，为空不回调
+	WebhookURL string `json:"webhook-url" yaml:"webhook-url"`
+	// TripBreaker 供应商失败率异常时是否自动熔断
+	TripBreaker bool `json:"trip-breaker" yaml:"trip-breaker"`
+	// BreakerDuration 自动熔断时长，默认 1 分钟
+	BreakerDuration time.Duration `json:"breaker-duration" yaml:"breaker-duration"`
+}
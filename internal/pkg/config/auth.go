@@ -0,0 +1,11 @@
+package config
+
+// AuthConfig 调用方鉴权配置
+type AuthConfig struct {
+	// Enabled 关闭时不校验凭证，所有请求都使用默认业务方，仅用于本地开发
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// JWTKey HS256 签名密钥
+	JWTKey string `json:"jwt-key" yaml:"jwt-key"`
+	// BootstrapAdmins 固定的平台管理员，用于初始化角色分配
+	BootstrapAdmins []string `json:"bootstrap-admins" yaml:"bootstrap-admins"`
+}
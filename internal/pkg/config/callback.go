@@ -0,0 +1,80 @@
+package config
+
+import "time"
+
+// CallbackConfig 回调业务方的 HTTP 客户端配置
+type CallbackConfig struct {
+	Timeout time.Duration     `json:"timeout" yaml:"timeout"`
+	TLS     CallbackTLSConfig `json:"tls" yaml:"tls"`
+	// Breaker 回调地址熔断，零值使用默认值
+	Breaker CallbackBreakerConfig `json:"breaker" yaml:"breaker"`
+	// Endpoints 业务方接收发送结果的默认地址，发送时可以单独指定
+	Endpoints []CallbackEndpointConfig `json:"endpoints" yaml:"endpoints"`
+	// Delivery 发送结果回调任务，零值使用默认值
+	Delivery CallbackDeliveryConfig `json:"delivery" yaml:"delivery"`
+	// DeadLetter 重试次数用完的回调进入死信之后的汇总告警
+	DeadLetter CallbackDeadLetterConfig `json:"dead-letter" yaml:"dead-letter"`
+}
+
+// CallbackDeadLetterConfig 每个周期按业务方汇总上个周期进入死信的回调，告警给平台运维，并通知业务方配置的接收者
+type CallbackDeadLetterConfig struct {
+	// SummaryEnabled 是否开启汇总任务，没有开启时只能通过 ListDeadLetterCallbacks 查看
+	SummaryEnabled  bool          `json:"summary-enabled" yaml:"summary-enabled"`
+	SummaryInterval time.Duration `json:"summary-interval" yaml:"summary-interval"`
+	// SummaryLimit 每次汇总最多查询的死信记录数量
+	SummaryLimit int `json:"summary-limit" yaml:"summary-limit"`
+	// IMBotURL 群机器人地址，为空不发送
+	IMBotURL string `json:"im-bot-url" yaml:"im-bot-url"`
+	// WebhookURL 告警回调地址，为空不回调
+	WebhookURL string `json:"webhook-url" yaml:"webhook-url"`
+	// Notify 平台用自己的业务方和模板通知业务方，template-id 为 0 时不通知
+	Notify CallbackDeadLetterNotifyConfig `json:"notify" yaml:"notify"`
+}
+
+// CallbackDeadLetterNotifyConfig 模板参数是 count、notification_ids、date
+type CallbackDeadLetterNotifyConfig struct {
+	BizID      int64                              `json:"biz-id" yaml:"biz-id"`
+	Channel    string                             `json:"channel" yaml:"channel"`
+	TemplateID int64                              `json:"template-id" yaml:"template-id"`
+	Receivers  []CallbackDeadLetterReceiverConfig `json:"receivers" yaml:"receivers"`
+}
+
+// CallbackDeadLetterReceiverConfig 业务方接收死信汇总通知的接收者
+type CallbackDeadLetterReceiverConfig struct {
+	OwnerID   int64    `json:"owner-id" yaml:"owner-id"`
+	Receivers []string `json:"receivers" yaml:"receivers"`
+}
+
+type CallbackEndpointConfig struct {
+	BizID int64  `json:"biz-id" yaml:"biz-id"`
+	URL   string `json:"url" yaml:"url"`
+}
+
+// CallbackDeliveryConfig 发送结果回调任务
+type CallbackDeliveryConfig struct {
+	Enabled     bool          `json:"enabled" yaml:"enabled"`
+	Interval    time.Duration `json:"interval" yaml:"interval"`
+	BatchSize   int           `json:"batch-size" yaml:"batch-size"`
+	MaxRetries  int32         `json:"max-retries" yaml:"max-retries"`
+	BaseBackoff time.Duration `json:"base-backoff" yaml:"base-backoff"`
+	MaxBackoff  time.Duration `json:"max-backoff" yaml:"max-backoff"`
+	// AllowedHosts 发送时指定的回调地址只能是这些域名，为空不限制
+	AllowedHosts []string `json:"allowed-hosts" yaml:"allowed-hosts"`
+}
+
+// CallbackBreakerConfig 回调地址健康度和熔断配置
+type CallbackBreakerConfig struct {
+	MinScore         float64       `json:"min-score" yaml:"min-score"`
+	MinSamples       int64         `json:"min-samples" yaml:"min-samples"`
+	FailureThreshold int64         `json:"failure-threshold" yaml:"failure-threshold"`
+	BaseBackoff      time.Duration `json:"base-backoff" yaml:"base-backoff"`
+	MaxBackoff       time.Duration `json:"max-backoff" yaml:"max-backoff"`
+}
+
+// CallbackTLSConfig cert-file 和 key-file 都配置时使用 mTLS，业务方可以用客户端证书确认回调来自平台
+type CallbackTLSConfig struct {
+	CertFile string `json:"cert-file" yaml:"cert-file"`
+	KeyFile  string `json:"key-file" yaml:"key-file"`
+	// CAFile 校验业务方服务端证书的 CA，为空使用系统 CA
+	CAFile string `json:"ca-file" yaml:"ca-file"`
+}
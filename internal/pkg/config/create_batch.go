@@ -0,0 +1,14 @@
+package config
+
+import "time"
+
+// CreateBatchConfig 把并发的单条创建在 wait 内合并成一次批量插入，用几毫秒的延迟换吞吐
+type CreateBatchConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Wait 收集一批的等待时间
+	Wait time.Duration `json:"wait" yaml:"wait"`
+	// MaxSize 一批最多多少条，满了立刻写入
+	MaxSize int `json:"max-size" yaml:"max-size"`
+	// Timeout 写入一批的超时时间
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+}
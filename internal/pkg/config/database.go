@@ -0,0 +1,52 @@
+package config
+
+import "time"
+
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+)
+
+type DatabaseConfig struct {
+	// Driver 数据库驱动，mysql 或 postgres，默认 mysql
+	Driver string `json:"driver" yaml:"driver"`
+	DSN    string `json:"dsn" yaml:"dsn"`
+	// AutoMigrate 启动时自动执行迁移，只建议在开发环境开启，生产环境使用 migrate 子命令
+	AutoMigrate bool `json:"auto-migrate" yaml:"auto-migrate"`
+	// BatchQuery 按ID批量查询的分片配置
+	BatchQuery BatchQueryConfig `json:"batch-query" yaml:"batch-query"`
+	// Tracing 链路里记录 SQL 语句的方式
+	Tracing DatabaseTracingConfig `json:"tracing" yaml:"tracing"`
+	// Failover 连接错误的处理和备用地址
+	Failover DatabaseFailoverConfig `json:"failover" yaml:"failover"`
+}
+
+// DatabaseFailoverConfig dsn 连不上或者变成只读时按顺序切换到备用地址，事务之外的只读语句遇到连接错误时重试
+type DatabaseFailoverConfig struct {
+	// DSNs 备用地址，为空时只连接 dsn；数据库迁移只使用 dsn
+	DSNs []string `json:"dsns" yaml:"dsns"`
+	// ReadRetries 只读语句遇到连接错误时最多重试几次，默认 2，小于 0 不重试
+	ReadRetries int `json:"read-retries" yaml:"read-retries"`
+	// RetryBackoff 第 n 次重试之前等待 n 倍，默认 50ms
+	RetryBackoff time.Duration `json:"retry-backoff" yaml:"retry-backoff"`
+	// DegradedWindow 最近一次连接错误之后多久内报告降级，默认 1m
+	DegradedWindow time.Duration `json:"degraded-window" yaml:"degraded-window"`
+}
+
+// DatabaseTracingConfig 数据库 span 上记录的 SQL 语句和参数
+type DatabaseTracingConfig struct {
+	// DisableStatement 不记录 SQL 语句和参数
+	DisableStatement bool `json:"disable-statement" yaml:"disable-statement"`
+	// MaxStatementLength SQL 语句和每个参数最多记录的字节数，默认 2048
+	MaxStatementLength int `json:"max-statement-length" yaml:"max-statement-length"`
+	// Args 参数的记录方式：none 不记录（默认），redacted 字符串只记录长度，raw 原样记录（只在开发环境使用）
+	Args string `json:"args" yaml:"args"`
+	// SlowThreshold 大于 0 时只有慢语句记录 SQL
+	SlowThreshold time.Duration `json:"slow-threshold" yaml:"slow-threshold"`
+}
+
+// BatchQueryConfig 每片最多 chunk-size 个ID，最多 concurrency 片同时查询，为 0 使用默认值
+type BatchQueryConfig struct {
+	ChunkSize   int `json:"chunk-size" yaml:"chunk-size"`
+	Concurrency int `json:"concurrency" yaml:"concurrency"`
+}
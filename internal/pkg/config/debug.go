@@ -0,0 +1,12 @@
+package config
+
+// DebugServerConfig 调试用的 HTTP 服务，提供 pprof 和运行时指标
+type DebugServerConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Addr 监听地址，默认 127.0.0.1:6060
+	Addr string `json:"addr" yaml:"addr"`
+	// AllowNonLoopback 允许监听在本机回环地址之外，开启时必须配置 AllowedCIDRs
+	AllowNonLoopback bool `json:"allow-non-loopback" yaml:"allow-non-loopback"`
+	// AllowedCIDRs 允许访问的来源地址，支持单个 IP 和 CIDR，为空时只允许本机访问
+	AllowedCIDRs []string `json:"allowed-cidrs" yaml:"allowed-cidrs"`
+}
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// DigestConfig 合并发送，业务方按渠道配置合并窗口和摘要模板，没有配置的渠道不能合并发送
+type DigestConfig struct {
+	// Enabled 是否开启摘要消息发送任务，没有开启时进入合并窗口的通知不会发出去
+	Enabled   bool                 `json:"enabled" yaml:"enabled"`
+	Interval  time.Duration        `json:"interval" yaml:"interval"`
+	BatchSize int                  `json:"batch-size" yaml:"batch-size"`
+	Policies  []DigestPolicyConfig `json:"policies" yaml:"policies"`
+}
+
+type DigestPolicyConfig struct {
+	BizID   int64         `json:"biz-id" yaml:"biz-id"`
+	Channel string        `json:"channel" yaml:"channel"`
+	Window  time.Duration `json:"window" yaml:"window"`
+	// TemplateID 摘要模板，参数是 count 和 items
+	TemplateID int64 `json:"template-id" yaml:"template-id"`
+}
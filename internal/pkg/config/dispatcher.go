@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// DispatcherConfig 发送协程池配置，没有配置的渠道使用默认大小
+type DispatcherConfig struct {
+	Channels []ChannelPoolConfig `json:"channels" yaml:"channels"`
+	// Providers 单独给某些供应商配置协程池，没有配置的供应商使用渠道的协程池
+	Providers []ProviderPoolConfig `json:"providers" yaml:"providers"`
+	// SendTimeout 协程池里单条通知发送的超时时间，包括调用供应商和更新状态
+	SendTimeout time.Duration `json:"send-timeout" yaml:"send-timeout"`
+}
+
+type ChannelPoolConfig struct {
+	Channel   string `json:"channel" yaml:"channel"`
+	Workers   int    `json:"workers" yaml:"workers"`
+	QueueSize int    `json:"queue-size" yaml:"queue-size"`
+}
+
+type ProviderPoolConfig struct {
+	Provider  string `json:"provider" yaml:"provider"`
+	Workers   int    `json:"workers" yaml:"workers"`
+	QueueSize int    `json:"queue-size" yaml:"queue-size"`
+}
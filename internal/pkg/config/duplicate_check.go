@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// DuplicateCheckConfig 创建通知前用 Redis 布隆过滤器预判 (bizID, key) 是否已经存在
+// 判断可能存在时再查数据库确认，误判只多一次查询，不会拒绝新的通知
+type DuplicateCheckConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Window 每个过滤器覆盖的时间段，检查当前和上一个时间段，key 在 Window 到 2*Window 之后过期
+	Window time.Duration `json:"window" yaml:"window"`
+	// Bits 每个过滤器的位数，最大 2^32
+	Bits uint64 `json:"bits" yaml:"bits"`
+	// Hashes 每个 key 设置的位数
+	Hashes int `json:"hashes" yaml:"hashes"`
+}
@@ -0,0 +1,18 @@
+package config
+
+// EncryptionConfig 接收者、模板参数等敏感字段的加密配置
+type EncryptionConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// ActiveKey 当前用于加密的密钥ID
+	ActiveKey string `json:"active-key" yaml:"active-key"`
+	// Keys 全部密钥，轮换时追加新密钥并修改 ActiveKey，旧密钥保留用于解密
+	Keys []EncryptionKeyConfig `json:"keys" yaml:"keys"`
+	// BlindIndexKey 盲索引密钥，base64 编码，上线后不能修改
+	BlindIndexKey string `json:"blind-index-key" yaml:"blind-index-key"`
+}
+
+type EncryptionKeyConfig struct {
+	ID string `json:"id" yaml:"id"`
+	// Secret base64 编码的 AES 密钥，16、24 或 32 字节
+	Secret string `json:"secret" yaml:"secret"`
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+// EscalationConfig 跨渠道升级链推进任务，没有开启时升级链只会发送第一步
+type EscalationConfig struct {
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+}
@@ -7,4 +7,29 @@ type EtcdConfig struct {
 	DialTimeout time.Duration `json:"dial-timeout" yaml:"dial-timeout"`
 	Username    string        `json:"username" yaml:"username"`
 	Password    string        `json:"password" yaml:"password"`
+	// TLS 配置了 ca-file 或者客户端证书时使用 TLS 连接
+	TLS EtcdTLSConfig `json:"tls" yaml:"tls"`
+	// AutoSyncInterval 定期从集群同步最新的端点列表，集群扩缩容后不需要改配置，0 不同步
+	AutoSyncInterval time.Duration `json:"auto-sync-interval" yaml:"auto-sync-interval"`
+	// KeepaliveTime 连接空闲这么久后发送 ping，尽早发现断开的连接，0 不发送
+	KeepaliveTime time.Duration `json:"keepalive-time" yaml:"keepalive-time"`
+	// KeepaliveTimeout 发送 ping 后等待这么久没有响应就关闭连接
+	KeepaliveTimeout time.Duration `json:"keepalive-timeout" yaml:"keepalive-timeout"`
+	// RequestTimeout 服务注册和发现的每个请求的超时时间，0 不限制
+	RequestTimeout time.Duration `json:"request-timeout" yaml:"request-timeout"`
+}
+
+// EtcdTLSConfig etcd 的 TLS 配置，cert-file 和 key-file 都配置时使用客户端证书认证
+type EtcdTLSConfig struct {
+	CertFile string `json:"cert-file" yaml:"cert-file"`
+	KeyFile  string `json:"key-file" yaml:"key-file"`
+	// CAFile 校验 etcd 服务端证书的 CA，为空使用系统 CA
+	CAFile string `json:"ca-file" yaml:"ca-file"`
+	// ServerName 校验服务端证书的名称，为空使用端点的主机名
+	ServerName string `json:"server-name" yaml:"server-name"`
+}
+
+// Enabled 配置了 CA 或者客户端证书
+func (c EtcdTLSConfig) Enabled() bool {
+	return c.CAFile != "" || c.CertFile != "" || c.KeyFile != ""
 }
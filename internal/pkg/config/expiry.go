@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// ExpiryConfig 过期通知清理配置
+type ExpiryConfig struct {
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+}
+
+// SendingTimeoutConfig 发送超时通知清理配置
+type SendingTimeoutConfig struct {
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+}
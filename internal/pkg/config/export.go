@@ -0,0 +1,51 @@
+package config
+
+import "time"
+
+// ExportConfig 导出通知生命周期事件到分析库
+type ExportConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Name 导出进度的名称，默认 clickhouse
+	Name      string        `json:"name" yaml:"name"`
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+	// Delay 只导出这个时间之前的变化，默认 5 秒
+	Delay      time.Duration    `json:"delay" yaml:"delay"`
+	ClickHouse ClickHouseConfig `json:"clickhouse" yaml:"clickhouse"`
+	// Kafka 每次状态变化发布到 Kafka，和分析库导出分别开关，共用 interval、batch-size、delay
+	Kafka ExportKafkaConfig `json:"kafka" yaml:"kafka"`
+	// Stream 业务方通过 ExportNotifications 流式导出通知，和分析库导出无关，零值使用默认值
+	Stream ExportStreamConfig `json:"stream" yaml:"stream"`
+}
+
+// ExportStreamConfig 流式导出通知
+type ExportStreamConfig struct {
+	BatchSize int `json:"batch-size" yaml:"batch-size"`
+	// RowsPerSecond 每个导出每秒最多导出的行数，负数不限制
+	RowsPerSecond int `json:"rows-per-second" yaml:"rows-per-second"`
+	// MaxConcurrent 每个实例同时进行的导出数
+	MaxConcurrent int `json:"max-concurrent" yaml:"max-concurrent"`
+}
+
+// ExportKafkaConfig 通过 Kafka REST Proxy 发布通知生命周期事件
+type ExportKafkaConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Name 发布进度的名称，默认 kafka
+	Name string `json:"name" yaml:"name"`
+	// Endpoint REST Proxy 地址
+	Endpoint string        `json:"endpoint" yaml:"endpoint"`
+	Topic    string        `json:"topic" yaml:"topic"`
+	Username string        `json:"username" yaml:"username"`
+	Password string        `json:"password" yaml:"password"`
+	Timeout  time.Duration `json:"timeout" yaml:"timeout"`
+}
+
+// ClickHouseConfig 通过 HTTP 接口访问 ClickHouse
+type ClickHouseConfig struct {
+	Endpoint string        `json:"endpoint" yaml:"endpoint"`
+	Database string        `json:"database" yaml:"database"`
+	Table    string        `json:"table" yaml:"table"`
+	Username string        `json:"username" yaml:"username"`
+	Password string        `json:"password" yaml:"password"`
+	Timeout  time.Duration `json:"timeout" yaml:"timeout"`
+}
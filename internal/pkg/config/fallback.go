@@ -0,0 +1,12 @@
+package config
+
+// FallbackConfig 渠道降级分组，只有配置了降级顺序的业务方可以使用
+type FallbackConfig struct {
+	Policies []FallbackPolicyConfig `json:"policies" yaml:"policies"`
+}
+
+// FallbackPolicyConfig 业务方的渠道降级顺序，排在前面的渠道先发送
+type FallbackPolicyConfig struct {
+	BizID    int64    `json:"biz-id" yaml:"biz-id"`
+	Channels []string `json:"channels" yaml:"channels"`
+}
@@ -0,0 +1,22 @@
+package config
+
+// FeatureFlagConfig 功能开关，flags 按开关名称配置放量规则，没有配置的开关使用代码里的默认值
+type FeatureFlagConfig struct {
+	Flags map[string]FeatureFlagRuleConfig `json:"flags" yaml:"flags"`
+	// Etcd 开启后监听 etcd 里的规则，同名时覆盖 flags，修改不需要重启
+	Etcd FeatureFlagEtcdConfig `json:"etcd" yaml:"etcd"`
+}
+
+// FeatureFlagRuleConfig 一个开关的放量规则：deny-biz-ids 关闭，enabled 全部开启，allow-biz-ids 开启，其余按 percent 放量
+type FeatureFlagRuleConfig struct {
+	Enabled     bool    `json:"enabled" yaml:"enabled"`
+	Percent     int     `json:"percent" yaml:"percent"`
+	AllowBizIDs []int64 `json:"allow-biz-ids" yaml:"allow-biz-ids"`
+	DenyBizIDs  []int64 `json:"deny-biz-ids" yaml:"deny-biz-ids"`
+}
+
+// FeatureFlagEtcdConfig key 是 prefix 加开关名称，value 是 JSON 格式的规则，字段和 flags 一样
+type FeatureFlagEtcdConfig struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Prefix  string `json:"prefix" yaml:"prefix"`
+}
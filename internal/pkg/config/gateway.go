@@ -0,0 +1,7 @@
+package config
+
+// GatewayConfig HTTP/JSON 网关配置
+type GatewayConfig struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Addr    string `json:"addr" yaml:"addr"`
+}
@@ -0,0 +1,6 @@
+package config
+
+// GraphQLConfig 只读 GraphQL 接口配置，挂载在 HTTP/JSON 网关的 /graphql 上
+type GraphQLConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+}
@@ -1,6 +1,80 @@
 package config
 
+import "time"
+
 type GrpcConfig struct {
 	Addr string `json:"addr" yaml:"addr"`
 	Name string `json:"name" yaml:"name"`
+	// Timeout 服务端超时，调用方没有传截止时间时也不会无限等待
+	Timeout GrpcTimeoutConfig `json:"timeout" yaml:"timeout"`
+	// MaxRecvMsgSize 单个请求的最大字节数，批量发送的请求比较大，0 使用 gRPC 默认的 4MB
+	MaxRecvMsgSize int `json:"max-recv-msg-size" yaml:"max-recv-msg-size"`
+	// MaxSendMsgSize 单个响应的最大字节数，0 不限制
+	MaxSendMsgSize int `json:"max-send-msg-size" yaml:"max-send-msg-size"`
+	// MaxConcurrentStreams 每个连接同时处理的请求数，0 不限制
+	MaxConcurrentStreams uint32 `json:"max-concurrent-streams" yaml:"max-concurrent-streams"`
+	// Keepalive 连接保活和连接寿命
+	Keepalive GrpcKeepaliveConfig `json:"keepalive" yaml:"keepalive"`
+	// Debug 线上排查问题用的调试服务
+	Debug GrpcDebugConfig `json:"debug" yaml:"debug"`
+	// QueryLimits 查询接口按业务方限流和限制单次查询条数，和发送额度分开
+	QueryLimits []GrpcQueryLimitConfig `json:"query-limits" yaml:"query-limits"`
+	// Interceptors 拦截器及其顺序，为空时使用默认顺序
+	// 可选值: request-context, timeout, metrics, log, tracing, auth, query-limit；request-context 必须排第一，timeout 必须配置，开启鉴权时 auth 必须配置
+	Interceptors []string `json:"interceptors" yaml:"interceptors"`
+}
+
+// GrpcDebugConfig 调试服务，开启鉴权时只有平台管理员可以调用
+type GrpcDebugConfig struct {
+	// Reflection 开启 gRPC 反射，grpcurl 不需要 proto 文件就可以调用
+	Reflection bool `json:"reflection" yaml:"reflection"`
+	// Channelz 开启 channelz，查询连接和请求的统计
+	Channelz bool `json:"channelz" yaml:"channelz"`
+}
+
+// GrpcKeepaliveConfig 连接保活配置，没有配置的字段使用 gRPC 的默认值
+type GrpcKeepaliveConfig struct {
+	// Time 连接空闲这么久后服务端发送 ping 探测
+	Time time.Duration `json:"time" yaml:"time"`
+	// Timeout 发送 ping 后等待这么久没有响应就关闭连接
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// MinTime 客户端两次 ping 的最小间隔，更频繁的客户端会被断开
+	MinTime time.Duration `json:"min-time" yaml:"min-time"`
+	// PermitWithoutStream 是否允许客户端在没有请求时 ping
+	PermitWithoutStream bool `json:"permit-without-stream" yaml:"permit-without-stream"`
+	// MaxConnectionIdle 连接空闲这么久后关闭
+	MaxConnectionIdle time.Duration `json:"max-connection-idle" yaml:"max-connection-idle"`
+	// MaxConnectionAge 连接最长存活时间，到期后发送 GOAWAY，客户端重新建连，扩容后流量能重新均衡
+	MaxConnectionAge time.Duration `json:"max-connection-age" yaml:"max-connection-age"`
+	// MaxConnectionAgeGrace 发送 GOAWAY 后等待进行中的请求完成的时间，不配置时使用最长的接口超时时间
+	MaxConnectionAgeGrace time.Duration `json:"max-connection-age-grace" yaml:"max-connection-age-grace"`
+}
+
+// GrpcTimeoutConfig 服务端超时配置，调用方的截止时间更早时以调用方为准
+type GrpcTimeoutConfig struct {
+	// Default 没有单独配置的方法使用这个超时时间，不配置时 5s，小于 0 不设置超时
+	Default time.Duration `json:"default" yaml:"default"`
+	// Methods 单独配置某些方法，例如批量发送、导出这类慢接口
+	Methods []GrpcMethodTimeoutConfig `json:"methods" yaml:"methods"`
+}
+
+// GrpcQueryLimitConfig 一个查询接口的限制，为 0 的限制不生效
+type GrpcQueryLimitConfig struct {
+	// Method 完整方法名，例如 /notification.v1.NotificationQueryService/BatchQueryNotifications
+	Method string `json:"method" yaml:"method"`
+	// BizID 为 0 对所有业务方生效，单独配置了业务方的优先
+	BizID int64 `json:"biz-id" yaml:"biz-id"`
+	// Rate 每个业务方在每个实例上每秒的请求数
+	Rate float64 `json:"rate" yaml:"rate"`
+	// Burst 允许的突发请求数，为 0 时使用 Rate 向上取整
+	Burst int `json:"burst" yaml:"burst"`
+	// MaxItems 单个请求最多查询的条数，例如批量查询的 key 数量、分页大小
+	MaxItems int `json:"max-items" yaml:"max-items"`
+}
+
+type GrpcMethodTimeoutConfig struct {
+	// Method 完整方法名，例如 /notification.v1.NotificationService/SendNotification
+	Method string `json:"method" yaml:"method"`
+	// Timeout 小于等于 0 表示这个方法不设置超时
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
 }
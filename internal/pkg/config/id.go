@@ -0,0 +1,33 @@
+package config
+
+import "time"
+
+const (
+	MachineIDAllocatorEtcd  = "etcd"
+	MachineIDAllocatorRedis = "redis"
+)
+
+type IDGeneratorConfig struct {
+	// Allocator 机器ID分配方式，etcd 或 redis，默认 etcd
+	Allocator    string        `json:"allocator" yaml:"allocator"`
+	Prefix       string        `json:"prefix" yaml:"prefix"`
+	TTL          time.Duration `json:"ttl" yaml:"ttl"`
+	MaxMachineID uint16        `json:"max-machine-id" yaml:"max-machine-id"`
+	// Epoch 雪花算法起始时间，RFC3339 格式，上线后不能修改
+	Epoch string `json:"epoch" yaml:"epoch"`
+	// ClockSkew 时钟回拨处理
+	ClockSkew ClockSkewConfig `json:"clock-skew" yaml:"clock-skew"`
+	// Fallback 雪花算法不可用时是否使用 Redis 计数器降级
+	Fallback FallbackIDConfig `json:"fallback" yaml:"fallback"`
+}
+
+type ClockSkewConfig struct {
+	// Policy wait 或 fail，默认 wait
+	Policy  string        `json:"policy" yaml:"policy"`
+	MaxWait time.Duration `json:"max-wait" yaml:"max-wait"`
+}
+
+type FallbackIDConfig struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+	Key     string `json:"key" yaml:"key"`
+}
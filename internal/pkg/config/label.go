@@ -0,0 +1,8 @@
+package config
+
+// LabelMetricsConfig 按通知标签统计，只统计列出的标签键
+type LabelMetricsConfig struct {
+	Keys []string `json:"keys" yaml:"keys"`
+	// MaxValues 每个标签键最多统计多少个不同的值，超过的记为 __other__
+	MaxValues int `json:"max-values" yaml:"max-values"`
+}
@@ -1,9 +1,16 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"time"
 
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/secret"
 	"github.com/spf13/viper"
 )
 
@@ -23,6 +30,9 @@ type ConfigLoader interface {
 
 	// GetDuration 获取时间间隔配置
 	GetDuration(key string) time.Duration
+
+	// ResolveSecrets 把配置里的密钥引用替换成密钥，之后读取到的都是替换之后的值
+	ResolveSecrets(ctx context.Context, resolvers secret.Resolvers) error
 }
 
 // ViperConfigLoader 基于 Viper 的配置加载器
@@ -44,7 +54,7 @@ func NewViperConfigLoaderWithViper(v *viper.Viper) *ViperConfigLoader {
 
 // Load 加载配置到指定的结构体
 func (l *ViperConfigLoader) Load(key string, target interface{}) error {
-	err := l.v.UnmarshalKey(key, target, viper.DecodeHook(viper.DecoderConfigOption(TagName("yaml"))))
+	err := l.v.UnmarshalKey(key, target, TagName("yaml"))
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal config key %s: %w", key, err)
 	}
@@ -71,29 +81,124 @@ func (l *ViperConfigLoader) GetDuration(key string) time.Duration {
 	return l.v.GetDuration(key)
 }
 
-// InitViperConfig 初始化 Viper 配置（辅助函数）
-func InitViperConfig(configPaths ...string) error {
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
+// ResolveSecrets 替换后的值通过 Set 覆盖，只影响这个 Viper 实例，不会写回配置文件
+func (l *ViperConfigLoader) ResolveSecrets(ctx context.Context, resolvers secret.Resolvers) error {
+	var errs []error
+	for key, value := range l.v.AllSettings() {
+		if resolved, ok := expandSecrets(ctx, resolvers, key, value, &errs); ok {
+			l.v.Set(key, resolved)
+		}
+	}
+	return errors.Join(errs...)
+}
 
-	// 添加配置文件搜索路径
-	if len(configPaths) == 0 {
-		configPaths = []string{
-			"./config/platform",
-			"../../config/platform",
-			".",
+// expandSecrets 返回替换了所有密钥引用的副本和是否有替换，错误带着完整的配置路径
+func expandSecrets(ctx context.Context, resolvers secret.Resolvers, key string, value any, errs *[]error) (any, bool) {
+	switch val := value.(type) {
+	case string:
+		if !secret.HasRef(val) {
+			return val, false
+		}
+		resolved, err := resolvers.Expand(ctx, val)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", key, err))
+			return val, false
 		}
+		return resolved, true
+	case map[string]any:
+		resolved := maps.Clone(val)
+		changed := false
+		for k, item := range val {
+			if v, ok := expandSecrets(ctx, resolvers, key+"."+k, item, errs); ok {
+				resolved[k], changed = v, true
+			}
+		}
+		return resolved, changed
+	case []any:
+		resolved := slices.Clone(val)
+		changed := false
+		for i, item := range val {
+			if v, ok := expandSecrets(ctx, resolvers, fmt.Sprintf("%s[%d]", key, i), item, errs); ok {
+				resolved[i], changed = v, true
+			}
+		}
+		return resolved, changed
+	default:
+		return value, false
 	}
+}
+
+// LayerOptions 分层配置：基础配置、环境配置、本地配置、显式指定的配置依次合并，后面的覆盖前面的
+type LayerOptions struct {
+	// Files 显式指定的配置文件，第一个是基础配置，其余的最后合并
+	// 为空时在 SearchPaths 里查找 config.yaml 作为基础配置
+	Files []string
+	// Env 运行环境，例如 dev、staging、prod，基础配置同目录下的 config.<env>.yaml 覆盖基础配置
+	Env string
+	// SearchPaths 查找基础配置的目录
+	SearchPaths []string
+}
+
+// localConfigName 本地覆盖配置，不提交到代码仓库
+const localConfigName = "config.local.yaml"
 
-	for _, path := range configPaths {
-		viper.AddConfigPath(path)
+// InitViperConfig 初始化 Viper 配置（辅助函数），返回按合并顺序读取的配置文件
+// 环境配置和本地配置不存在时跳过，显式指定的配置文件不存在时返回错误
+func InitViperConfig(opts LayerOptions) ([]string, error) {
+	viper.SetConfigType("yaml")
+	if len(opts.Files) > 0 {
+		viper.SetConfigFile(opts.Files[0])
+	} else {
+		viper.SetConfigName("config")
+		// 添加配置文件搜索路径
+		if len(opts.SearchPaths) == 0 {
+			opts.SearchPaths = []string{
+				"./config/platform",
+				"../../config/platform",
+				".",
+			}
+		}
+		for _, path := range opts.SearchPaths {
+			viper.AddConfigPath(path)
+		}
 	}
 
-	// 读取配置文件
+	// 读取基础配置
 	if err := viper.ReadInConfig(); err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	base := viper.ConfigFileUsed()
+	loaded := []string{base}
+
+	dir := filepath.Dir(base)
+	var overlays []string
+	if opts.Env != "" {
+		overlays = append(overlays, filepath.Join(dir, "config."+opts.Env+".yaml"))
+	}
+	overlays = append(overlays, filepath.Join(dir, localConfigName))
+	for _, f := range overlays {
+		if _, err := os.Stat(f); err != nil {
+			continue
+		}
+		if err := mergeConfigFile(f); err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, f)
+	}
+	for _, f := range opts.Files[min(1, len(opts.Files)):] {
+		if err := mergeConfigFile(f); err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, f)
 	}
+	return loaded, nil
+}
 
+func mergeConfigFile(file string) error {
+	viper.SetConfigFile(file)
+	if err := viper.MergeInConfig(); err != nil {
+		return fmt.Errorf("failed to merge config file %s: %w", file, err)
+	}
 	return nil
 }
 
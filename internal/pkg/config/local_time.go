@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// LocalTimeConfig 按接收者本地时间发送
+type LocalTimeConfig struct {
+	// Enabled 是否开启创建通知的任务，没有开启时按本地时间发送的通知不会发出去
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+	// Ahead 提前多久创建通知，要大于 Interval
+	Ahead  time.Duration        `json:"ahead" yaml:"ahead"`
+	Lookup TimezoneLookupConfig `json:"lookup" yaml:"lookup"`
+}
+
+// TimezoneLookupConfig 查询接收者时区的用户资料服务，URL 为空时不查询
+type TimezoneLookupConfig struct {
+	URL     string        `json:"url" yaml:"url"`
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+}
@@ -0,0 +1,25 @@
+package config
+
+type LogConfig struct {
+	// Level 全局日志级别，debug、info、warn、error，默认 info
+	Level string `json:"level" yaml:"level"`
+	// Modules 单独设置级别的模块，例如 grpc.notification: debug，运行时可以通过 AdminService 调整
+	Modules map[string]string `json:"modules" yaml:"modules"`
+	// File 除了标准输出之外再写一份到文件，不配置 path 时不写文件
+	File LogFileConfig `json:"file" yaml:"file"`
+	// ErrorFile error 及以上级别的日志单独再写一份，方便告警和排查
+	ErrorFile LogFileConfig `json:"error-file" yaml:"error-file"`
+}
+
+// LogFileConfig 日志文件按大小滚动，旧文件按天数和个数清理
+type LogFileConfig struct {
+	Path string `json:"path" yaml:"path"`
+	// MaxSize 单个文件的最大大小（MB），默认 100
+	MaxSize int `json:"max-size" yaml:"max-size"`
+	// MaxAge 旧文件保留的天数，0 不按时间清理
+	MaxAge int `json:"max-age" yaml:"max-age"`
+	// MaxBackups 旧文件保留的个数，0 不按个数清理
+	MaxBackups int `json:"max-backups" yaml:"max-backups"`
+	// Compress 旧文件是否 gzip 压缩
+	Compress bool `json:"compress" yaml:"compress"`
+}
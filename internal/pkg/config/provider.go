@@ -0,0 +1,76 @@
+package config
+
+import "time"
+
+// ProviderRoutingConfig 供应商路由配置
+type ProviderRoutingConfig struct {
+	Routes []ProviderRouteConfig `json:"routes" yaml:"routes"`
+	// ShadowTimeout 影子请求超时时间，默认 10 秒
+	ShadowTimeout time.Duration `json:"shadow-timeout" yaml:"shadow-timeout"`
+	// ErrorCodes 供应商错误码到统一分类的映射，优先于内置规则
+	ErrorCodes []ProviderErrorCodeConfig `json:"error-codes" yaml:"error-codes"`
+	// Blacklist 供应商拒收的接收者自动加入黑名单
+	Blacklist ProviderBlacklistConfig `json:"blacklist" yaml:"blacklist"`
+	// Health 按最近的延迟和错误率调整供应商顺序
+	Health ProviderHealthConfig `json:"health" yaml:"health"`
+	// Mock 沙箱通知使用的模拟供应商
+	Mock ProviderMockConfig `json:"mock" yaml:"mock"`
+}
+
+// ProviderMockConfig 模拟供应商，默认立刻返回成功
+type ProviderMockConfig struct {
+	// FailPercent 返回失败的比例，0-100
+	FailPercent int `json:"fail-percent" yaml:"fail-percent"`
+	// FailCategory 失败时的错误分类，默认 VENDOR_UNAVAILABLE
+	FailCategory string `json:"fail-category" yaml:"fail-category"`
+	// Delay 每次调用的耗时
+	Delay time.Duration `json:"delay" yaml:"delay"`
+}
+
+// ProviderHealthConfig 健康度路由，关闭时依旧统计，只是不调整顺序
+type ProviderHealthConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Alpha 错误率和平均延迟的指数加权系数，默认 0.1
+	Alpha float64 `json:"alpha" yaml:"alpha"`
+	// Window 计算 p95 延迟的最近样本数，默认 200
+	Window int `json:"window" yaml:"window"`
+	// MinSamples 样本数少于这个值不参与调整顺序，默认 20
+	MinSamples int64 `json:"min-samples" yaml:"min-samples"`
+	// MaxErrorRate 错误率超过这个值排到健康的供应商后面，默认 0.3
+	MaxErrorRate float64 `json:"max-error-rate" yaml:"max-error-rate"`
+	// LatencyTolerance p95 延迟低这么多比例才换到更快的供应商，默认 0.2
+	LatencyTolerance float64 `json:"latency-tolerance" yaml:"latency-tolerance"`
+	// StaleAfter 超过这么久没有样本时统计作废，默认 5 分钟
+	StaleAfter time.Duration `json:"stale-after" yaml:"stale-after"`
+}
+
+// ProviderBlacklistConfig 接收者黑名单
+type ProviderBlacklistConfig struct {
+	// Disabled 关闭之后不检查黑名单，也不自动加入
+	Disabled bool `json:"disabled" yaml:"disabled"`
+	// TTL 自动加入的接收者保留多久，默认 30 天
+	TTL time.Duration `json:"ttl" yaml:"ttl"`
+	// Categories 哪些错误分类自动加入黑名单，默认 VENDOR_BLACKLISTED、VENDOR_INVALID_RECEIVER
+	Categories []string `json:"categories" yaml:"categories"`
+}
+
+// ProviderErrorCodeConfig 一条错误码映射
+type ProviderErrorCodeConfig struct {
+	// Vendor 供应商类型，例如 aliyun、tencent
+	Vendor string `json:"vendor" yaml:"vendor"`
+	// Code 供应商的错误码，以 * 结尾时按前缀匹配
+	Code string `json:"code" yaml:"code"`
+	// Category 统一分类，例如 VENDOR_BLACKLISTED
+	Category string `json:"category" yaml:"category"`
+}
+
+// ProviderRouteConfig 一个渠道的路由
+type ProviderRouteConfig struct {
+	Channel string `json:"channel" yaml:"channel"`
+	// Providers 供应商名称，按优先级排列
+	Providers []string `json:"providers" yaml:"providers"`
+	// Shadow 影子供应商名称，为空不复制流量
+	Shadow string `json:"shadow" yaml:"shadow"`
+	// ShadowPercent 复制流量的比例，0-100
+	ShadowPercent uint64 `json:"shadow-percent" yaml:"shadow-percent"`
+}
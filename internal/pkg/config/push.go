@@ -0,0 +1,28 @@
+package config
+
+import "time"
+
+// PushConfig 站内信推送网关，WebSocket 挂载在网关的 /v1/push/ws 上，开启时必须同时开启 gateway
+type PushConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// TokenKey 推送凭证的 HS256 签名密钥，不要和 auth.jwt-key 相同
+	TokenKey string        `json:"token-key" yaml:"token-key"`
+	TokenTTL time.Duration `json:"token-ttl" yaml:"token-ttl"`
+	// Channel 站内信事件总线使用的 Redis 频道
+	Channel             string        `json:"channel" yaml:"channel"`
+	MaxConnsPerReceiver int           `json:"max-conns-per-receiver" yaml:"max-conns-per-receiver"`
+	SendBuffer          int           `json:"send-buffer" yaml:"send-buffer"`
+	ReplayLimit         int           `json:"replay-limit" yaml:"replay-limit"`
+	PingInterval        time.Duration `json:"ping-interval" yaml:"ping-interval"`
+	// Inbox 站内信收件箱投影，开启后用户可以用推送凭证分页查询自己的收件箱
+	Inbox PushInboxConfig `json:"inbox" yaml:"inbox"`
+}
+
+// PushInboxConfig 收件箱投影按导出任务的方式增量读取通知变化，Name 是保存进度的名称
+type PushInboxConfig struct {
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	Name      string        `json:"name" yaml:"name"`
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+	Delay     time.Duration `json:"delay" yaml:"delay"`
+}
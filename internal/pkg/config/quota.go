@@ -0,0 +1,42 @@
+package config
+
+import "time"
+
+// QuotaConfig 额度配置
+type QuotaConfig struct {
+	// Warmup 启动时把数据库里的额度加载到 Redis
+	Warmup          bool `json:"warmup" yaml:"warmup"`
+	WarmupBatchSize int  `json:"warmup-batch-size" yaml:"warmup-batch-size"`
+	// Overdraft 业务方额度用完之后的透支策略，没有配置的业务方直接拒绝
+	Overdraft []QuotaOverdraftConfig `json:"overdraft" yaml:"overdraft"`
+	// Degraded Redis 不可用时怎么扣减额度
+	Degraded QuotaDegradedConfig `json:"degraded" yaml:"degraded"`
+	// Reservation 扣减额度时记录扣减意图，修复进程在扣减之后、写入通知之前退出时没有归还的额度
+	Reservation QuotaReservationConfig `json:"reservation" yaml:"reservation"`
+}
+
+// QuotaReservationConfig 扣减意图和修复任务
+type QuotaReservationConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Interval 定时任务角色检查的间隔，启动时先检查一次
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// Grace 意图记录之后多久还没有确认才核对，需要比写入通知的最长耗时更长，否则会归还正在写入的通知的额度
+	Grace     time.Duration `json:"grace" yaml:"grace"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+}
+
+// QuotaDegradedConfig Redis 不可用时的降级策略
+type QuotaDegradedConfig struct {
+	// Mode reject（默认）、db 或 allow
+	Mode          string        `json:"mode" yaml:"mode"`
+	ProbeInterval time.Duration `json:"probe-interval" yaml:"probe-interval"`
+}
+
+// QuotaOverdraftConfig 透支策略，channel 为空表示所有渠道
+type QuotaOverdraftConfig struct {
+	BizID   int64  `json:"biz-id" yaml:"biz-id"`
+	Channel string `json:"channel" yaml:"channel"`
+	// Mode REJECT、BURST、BILL_LATER 或 DEGRADE_MARKETING
+	Mode         string `json:"mode" yaml:"mode"`
+	BurstPercent int    `json:"burst-percent" yaml:"burst-percent"`
+}
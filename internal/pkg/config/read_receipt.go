@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// ReadReceiptConfig 站内信已读回执，配置了推送地址的业务方会收到已读事件
+type ReadReceiptConfig struct {
+	// Endpoints 业务方接收已读事件的地址，请求体使用业务方的回调密钥签名
+	Endpoints []ReadReceiptEndpointConfig `json:"endpoints" yaml:"endpoints"`
+	Callback  ReadReceiptCallbackConfig   `json:"callback" yaml:"callback"`
+}
+
+type ReadReceiptEndpointConfig struct {
+	BizID int64  `json:"biz-id" yaml:"biz-id"`
+	URL   string `json:"url" yaml:"url"`
+}
+
+// ReadReceiptCallbackConfig 已读事件推送任务，零值使用默认值
+type ReadReceiptCallbackConfig struct {
+	Interval    time.Duration `json:"interval" yaml:"interval"`
+	BatchSize   int           `json:"batch-size" yaml:"batch-size"`
+	MaxRetries  int32         `json:"max-retries" yaml:"max-retries"`
+	BaseBackoff time.Duration `json:"base-backoff" yaml:"base-backoff"`
+	MaxBackoff  time.Duration `json:"max-backoff" yaml:"max-backoff"`
+}
@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+// RetentionConfig 数据保留策略配置
+type RetentionConfig struct {
+	// Enabled 是否定时执行保留策略，关闭时依旧可以调用擦除接口
+	Enabled   bool                    `json:"enabled" yaml:"enabled"`
+	Interval  time.Duration           `json:"interval" yaml:"interval"`
+	BatchSize int                     `json:"batch-size" yaml:"batch-size"`
+	Policies  []RetentionPolicyConfig `json:"policies" yaml:"policies"`
+	// CallbackLogDays 已结束的回调记录保留天数，0 表示不清理
+	CallbackLogDays int `json:"callback-log-days" yaml:"callback-log-days"`
+}
+
+// RetentionPolicyConfig 保留策略，biz-id 为 0 表示所有业务，channel 为空表示所有渠道
+type RetentionPolicyConfig struct {
+	BizID   int64  `json:"biz-id" yaml:"biz-id"`
+	Channel string `json:"channel" yaml:"channel"`
+	Days    int    `json:"days" yaml:"days"`
+	// Action ANONYMIZE 或 PURGE
+	Action string `json:"action" yaml:"action"`
+}
@@ -0,0 +1,34 @@
+package config
+
+import "time"
+
+// SchedulerConfig 调度配置
+type SchedulerConfig struct {
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+	// BatchTimeout 每一批查询和分发的超时时间，数据库变慢时调度器不会卡在一次查询上
+	BatchTimeout time.Duration `json:"batch-timeout" yaml:"batch-timeout"`
+	// Adaptive 根据处理耗时和错误自动调整批次大小和扫描间隔，开启后 Interval、BatchSize 只作为初始值
+	Adaptive SchedulerAdaptiveConfig `json:"adaptive" yaml:"adaptive"`
+	// Partition 多实例按照通知ID分区调度
+	Partition SchedulerPartitionConfig `json:"partition" yaml:"partition"`
+}
+
+// SchedulerPartitionConfig 分区成员关系配置，实例通过 etcd 注册，按实例数平分通知ID
+type SchedulerPartitionConfig struct {
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// InstanceID 实例唯一标识，为空使用 主机名-进程号
+	InstanceID string `json:"instance-id" yaml:"instance-id"`
+	// TTL 实例宕机后最多经过这个时间完成重新分配
+	TTL time.Duration `json:"ttl" yaml:"ttl"`
+}
+
+// SchedulerAdaptiveConfig 自适应批次配置
+type SchedulerAdaptiveConfig struct {
+	Enabled       bool          `json:"enabled" yaml:"enabled"`
+	MinBatchSize  int           `json:"min-batch-size" yaml:"min-batch-size"`
+	MaxBatchSize  int           `json:"max-batch-size" yaml:"max-batch-size"`
+	MinInterval   time.Duration `json:"min-interval" yaml:"min-interval"`
+	MaxInterval   time.Duration `json:"max-interval" yaml:"max-interval"`
+	TargetLatency time.Duration `json:"target-latency" yaml:"target-latency"`
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// SecretsConfig 配置里 ${env:NAME}、${vault:path#field} 形式的密钥引用的解析参数
+type SecretsConfig struct {
+	Vault VaultConfig `json:"vault" yaml:"vault"`
+}
+
+// VaultConfig 没有配置 addr 时不能使用 vault 引用
+type VaultConfig struct {
+	Addr string `json:"addr" yaml:"addr"`
+	// Token 访问令牌，可以写成 ${env:VAULT_TOKEN}，为空时读取环境变量 VAULT_TOKEN
+	Token string `json:"token" yaml:"token"`
+	// Mount KV 引擎挂载路径，默认 secret
+	Mount string `json:"mount" yaml:"mount"`
+	// KVVersion KV 引擎版本，1 或 2，默认 2
+	KVVersion int           `json:"kv-version" yaml:"kv-version"`
+	Timeout   time.Duration `json:"timeout" yaml:"timeout"`
+	// CacheTTL 同一个路径的密钥缓存多久
+	CacheTTL time.Duration `json:"cache-ttl" yaml:"cache-ttl"`
+	// RenewInterval 令牌续期间隔，0 不续期
+	RenewInterval time.Duration `json:"renew-interval" yaml:"renew-interval"`
+}
@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// SLOConfig 服务等级目标，定时计算达标率和错误预算消耗速度并导出为指标
+type SLOConfig struct {
+	Enabled  bool          `json:"enabled" yaml:"enabled"`
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// Period SLO 周期，错误预算按这个周期计算剩余比例，默认 7 天
+	Period time.Duration `json:"period" yaml:"period"`
+	// Windows 计算消耗速度的时间窗口，默认 1h、6h、24h、72h
+	// 发送和供应商读取小时统计表，窗口会向前取整到小时，数据比实时晚 stats.interval
+	Windows []time.Duration `json:"windows" yaml:"windows"`
+	// SendTarget 生产环境通知最终发送成功率的目标，按业务方统计，默认 0.99
+	SendTarget float64 `json:"send-target" yaml:"send-target"`
+	// ProviderTarget 供应商受理发送尝试的比例目标，按供应商统计，默认 0.995
+	ProviderTarget float64 `json:"provider-target" yaml:"provider-target"`
+	// CallbackTarget 回调业务方的成功率目标，默认 0.99
+	CallbackTarget float64 `json:"callback-target" yaml:"callback-target"`
+}
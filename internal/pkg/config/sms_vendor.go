@@ -0,0 +1,47 @@
+package config
+
+import "time"
+
+// SMSVendorConfig 短信供应商账号，用于模板审核、接收审核结果推送和查询余额
+type SMSVendorConfig struct {
+	// Name 供应商名称，和供应商路由里的名称一致
+	Name string `json:"name" yaml:"name"`
+	// Type aliyun 或者 tencent
+	Type string `json:"type" yaml:"type"`
+	// Endpoint 短信接口地址，为空使用默认地址
+	Endpoint        string `json:"endpoint" yaml:"endpoint"`
+	AccessKeyID     string `json:"access-key-id" yaml:"access-key-id"`
+	AccessKeySecret string `json:"access-key-secret" yaml:"access-key-secret"`
+	// Region 腾讯云必填
+	Region string `json:"region" yaml:"region"`
+	// SdkAppID 腾讯云短信应用ID，发送短信和查询套餐包余量时必填
+	SdkAppID string `json:"sdk-app-id" yaml:"sdk-app-id"`
+	// TemplateType 阿里云是模板类型（0 验证码，1 短信通知，2 推广短信），腾讯云是短信类型（0 普通短信，1 营销短信）
+	TemplateType int `json:"template-type" yaml:"template-type"`
+	// CallbackToken 接收供应商推送审核结果的令牌，为空时不接收推送，只轮询
+	CallbackToken string `json:"callback-token" yaml:"callback-token"`
+	// BalanceAlertBelow 余额低于这个值时告警，阿里云是账户可用额度（分），腾讯云是套餐包剩余条数，0 不告警
+	BalanceAlertBelow int64 `json:"balance-alert-below" yaml:"balance-alert-below"`
+	// HTTP 调用供应商接口的连接池和超时参数
+	HTTP VendorHTTPConfig `json:"http" yaml:"http"`
+}
+
+// VendorHTTPConfig 供应商的 HTTP 客户端，同一个供应商的模板审核、余额查询等调用共用一个连接池，零值使用默认值
+type VendorHTTPConfig struct {
+	// Timeout 整个请求的超时时间，调用方（例如 template-review.timeout）配置了超时时间时以调用方为准，默认 10 秒
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// DialTimeout 建立连接的超时时间，默认 3 秒
+	DialTimeout time.Duration `json:"dial-timeout" yaml:"dial-timeout"`
+	// TLSHandshakeTimeout 默认 5 秒
+	TLSHandshakeTimeout time.Duration `json:"tls-handshake-timeout" yaml:"tls-handshake-timeout"`
+	// ResponseHeaderTimeout 等待响应头的超时时间，默认 10 秒
+	ResponseHeaderTimeout time.Duration `json:"response-header-timeout" yaml:"response-header-timeout"`
+	// IdleConnTimeout 空闲连接保留多久，默认 90 秒
+	IdleConnTimeout time.Duration `json:"idle-conn-timeout" yaml:"idle-conn-timeout"`
+	// MaxIdleConns 最多保留的空闲连接数，默认 32
+	MaxIdleConns int `json:"max-idle-conns" yaml:"max-idle-conns"`
+	// MaxConns 最多的连接数，0 不限制
+	MaxConns int `json:"max-conns" yaml:"max-conns"`
+	// Proxy 代理地址，为空时使用 HTTP_PROXY 等环境变量
+	Proxy string `json:"proxy" yaml:"proxy"`
+}
@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// StartupConfig 启动时等待数据库、Redis、etcd 就绪
+type StartupConfig struct {
+	// MaxWait 最多等待多久，0 表示只尝试一次，不可用时直接退出
+	MaxWait time.Duration `json:"max-wait" yaml:"max-wait"`
+	// InitialBackoff 第一次重试的间隔，之后每次翻倍，默认 500ms
+	InitialBackoff time.Duration `json:"initial-backoff" yaml:"initial-backoff"`
+	// MaxBackoff 重试间隔的上限，默认 5 秒
+	MaxBackoff time.Duration `json:"max-backoff" yaml:"max-backoff"`
+	// AttemptTimeout 每次探测的超时时间，默认 5 秒
+	AttemptTimeout time.Duration `json:"attempt-timeout" yaml:"attempt-timeout"`
+}
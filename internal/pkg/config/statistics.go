@@ -0,0 +1,12 @@
+package config
+
+import "time"
+
+// StatsConfig 发送统计任务配置
+type StatsConfig struct {
+	// Enabled 是否定时统计，关闭时统计接口只能查到之前统计过的数据
+	Enabled  bool          `json:"enabled" yaml:"enabled"`
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// Lookback 每次重新统计最近多长时间的数据，需要覆盖通知从创建到发送结束的时间，默认 3 小时
+	Lookback time.Duration `json:"lookback" yaml:"lookback"`
+}
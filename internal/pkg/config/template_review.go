@@ -0,0 +1,32 @@
+package config
+
+import "time"
+
+// TemplateReviewConfig 模板审核，内部审核通过后自动提交给 sms-vendors 的每个短信供应商审核，定时轮询审核结果
+type TemplateReviewConfig struct {
+	// Enabled 是否开启提交和轮询任务，没有开启时内部审核通过的版本不会提交给供应商
+	Enabled   bool          `json:"enabled" yaml:"enabled"`
+	Interval  time.Duration `json:"interval" yaml:"interval"`
+	BatchSize int           `json:"batch-size" yaml:"batch-size"`
+	// PollInterval 提交之后查询审核结果的间隔，失败时按这个间隔指数退避
+	PollInterval time.Duration `json:"poll-interval" yaml:"poll-interval"`
+	// Timeout 调用供应商接口的超时时间
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// Notify 供应商审核有结果之后通知模板所属的业务方，template-id 为 0 时不通知
+	Notify TemplateReviewNotifyConfig `json:"notify" yaml:"notify"`
+}
+
+// TemplateReviewNotifyConfig 平台用自己的业务方和模板发送审核结果通知
+// 模板参数是 template_name、version_name、provider、status、reason
+type TemplateReviewNotifyConfig struct {
+	BizID      int64                          `json:"biz-id" yaml:"biz-id"`
+	Channel    string                         `json:"channel" yaml:"channel"`
+	TemplateID int64                          `json:"template-id" yaml:"template-id"`
+	Receivers  []TemplateReviewReceiverConfig `json:"receivers" yaml:"receivers"`
+}
+
+// TemplateReviewReceiverConfig 模板所属的业务方接收审核结果通知的接收者
+type TemplateReviewReceiverConfig struct {
+	OwnerID   int64    `json:"owner-id" yaml:"owner-id"`
+	Receivers []string `json:"receivers" yaml:"receivers"`
+}
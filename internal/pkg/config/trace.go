@@ -0,0 +1,61 @@
+package config
+
+import "time"
+
+// 采样方式
+const (
+	SamplerAlways = "always"
+	SamplerNever  = "never"
+	SamplerRatio  = "ratio"
+)
+
+type TraceConfig struct {
+	Jeager   JeagerConfig        `json:"jeager" yaml:"jeager"`
+	Sampler  TraceSamplerConfig  `json:"sampler" yaml:"sampler"`
+	Exporter TraceExporterConfig `json:"exporter" yaml:"exporter"`
+	// ShutdownTimeout 退出时等待剩余 span 上报的时间，默认 5s
+	ShutdownTimeout time.Duration `json:"shutdown-timeout" yaml:"shutdown-timeout"`
+}
+
+type JeagerConfig struct {
+	ServiceName string `json:"serviceName" yaml:"serviceName"`
+	// Endpoint OTLP HTTP 地址，例如 localhost:4318，不配置时只在进程内生成链路（日志里有 trace_id），不上报
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+}
+
+type TraceSamplerConfig struct {
+	// Type always、never、ratio，默认 always
+	Type string `json:"type" yaml:"type"`
+	// Ratio type 为 ratio 时的采样比例，0 到 1
+	Ratio float64 `json:"ratio" yaml:"ratio"`
+	// IgnoreParent 不跟随上游的采样结果，默认上游采样了这里也采样
+	IgnoreParent bool `json:"ignore-parent" yaml:"ignore-parent"`
+	// Methods 单独配置某些方法的采样比例，例如 BatchSend 这类调用量大的接口
+	Methods []TraceMethodSamplerConfig `json:"methods" yaml:"methods"`
+}
+
+type TraceMethodSamplerConfig struct {
+	// Method 完整方法名，例如 /notification.v1.NotificationService/BatchSendNotifications
+	Method string  `json:"method" yaml:"method"`
+	Ratio  float64 `json:"ratio" yaml:"ratio"`
+}
+
+type TraceExporterConfig struct {
+	// Timeout 单次上报的超时时间，默认 10s
+	Timeout  time.Duration    `json:"timeout" yaml:"timeout"`
+	Insecure bool             `json:"insecure" yaml:"insecure"`
+	Retry    TraceRetryConfig `json:"retry" yaml:"retry"`
+	// BatchTimeout 攒批的最长时间，默认 5s
+	BatchTimeout time.Duration `json:"batch-timeout" yaml:"batch-timeout"`
+	// MaxQueueSize 等待上报的 span 上限，超过后丢弃，默认 2048
+	MaxQueueSize int `json:"max-queue-size" yaml:"max-queue-size"`
+}
+
+// TraceRetryConfig 上报失败后的重试，不配置时使用 OTLP 的默认值
+type TraceRetryConfig struct {
+	Disabled        bool          `json:"disabled" yaml:"disabled"`
+	InitialInterval time.Duration `json:"initial-interval" yaml:"initial-interval"`
+	MaxInterval     time.Duration `json:"max-interval" yaml:"max-interval"`
+	// MaxElapsedTime 一批 span 最多重试多久，超过后丢弃
+	MaxElapsedTime time.Duration `json:"max-elapsed-time" yaml:"max-elapsed-time"`
+}
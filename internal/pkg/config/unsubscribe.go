@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+// UnsubscribeConfig 退订链接和短信退订关键字，退订链接挂载在网关的 /v1/unsubscribe 上，开启时必须同时开启 gateway
+type UnsubscribeConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// TokenKey 退订凭证的 HS256 签名密钥，不要和其他凭证的密钥相同
+	TokenKey string `json:"token-key" yaml:"token-key"`
+	// TokenTTL 退订链接的有效期，邮件可能很久之后才被打开，默认一年
+	TokenTTL time.Duration `json:"token-ttl" yaml:"token-ttl"`
+	// BaseURL 网关对外的地址，例如 https://notify.example.com，拼在退订链接前面
+	BaseURL string `json:"base-url" yaml:"base-url"`
+	// StopKeywords 短信退订关键字，不区分大小写，默认 TD、T、N、STOP、UNSUBSCRIBE、退订
+	StopKeywords []string `json:"stop-keywords" yaml:"stop-keywords"`
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+// Problem 一处配置错误，Key 是完整的配置路径，例如 database.driver
+type Problem struct {
+	Key     string
+	Message string
+}
+
+// ValidationError 校验发现的所有配置错误
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "配置有 %d 处错误:", len(e.Problems))
+	for _, p := range e.Problems {
+		fmt.Fprintf(&b, "\n  %s: %s", p.Key, p.Message)
+	}
+	return b.String()
+}
+
+// Report 收集配置错误，遇到错误不停止，最后一次性返回
+type Report struct {
+	problems []Problem
+}
+
+func (r *Report) Add(key, format string, args ...any) {
+	r.problems = append(r.problems, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+// Required 必填的字符串配置
+func (r *Report) Required(key, value string) {
+	if value == "" {
+		r.Add(key, "必须配置")
+	}
+}
+
+// OneOf 枚举配置，allowed 里包含空字符串时表示可以不配置
+func (r *Report) OneOf(key, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		r.Add(key, "取值 %q 不合法，可选值: %s", value, strings.Join(slices.DeleteFunc(slices.Clone(allowed), func(s string) bool {
+			return s == ""
+		}), ", "))
+	}
+}
+
+// Decode 严格解析一段配置，类型错误和没有定义的配置项（通常是拼写错误）都记录下来
+// 出错的字段保持零值，其他字段照常解析
+func (r *Report) Decode(v *viper.Viper, key string, target any) {
+	err := v.UnmarshalKey(key, target, TagName("yaml"), func(c *mapstructure.DecoderConfig) {
+		c.ErrorUnused = true
+	})
+	if err != nil {
+		r.addDecodeError(key, err)
+	}
+}
+
+func (r *Report) addDecodeError(key string, err error) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			r.addDecodeError(key, e)
+		}
+		return
+	}
+	var de *mapstructure.DecodeError
+	if errors.As(err, &de) {
+		if name := de.Name(); strings.HasPrefix(name, "[") {
+			key += name
+		} else if name != "" {
+			key += "." + name
+		}
+		if unused, ok := strings.CutPrefix(de.Unwrap().Error(), "has invalid keys: "); ok {
+			for _, k := range strings.Split(unused, ", ") {
+				r.Add(key+"."+k, "未知的配置项")
+			}
+			return
+		}
+		r.Add(key, "%s", de.Unwrap())
+		return
+	}
+	r.Add(key, "%s", err)
+}
+
+// Err 没有错误时返回 nil，否则返回 *ValidationError
+func (r *Report) Err() error {
+	if len(r.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: slices.Clone(r.problems)}
+}
@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+// VendorBalanceConfig 定时查询 sms-vendors 的账户余额或者套餐包余量，低于 balance-alert-below 时告警
+type VendorBalanceConfig struct {
+	Enabled  bool          `json:"enabled" yaml:"enabled"`
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// Timeout 调用供应商接口的超时时间
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// Cooldown 同一个供应商两次告警的最小间隔
+	Cooldown time.Duration `json:"cooldown" yaml:"cooldown"`
+	// Retention 快照保留时长
+	Retention time.Duration `json:"retention" yaml:"retention"`
+	// IMBotURL 群机器人地址，为空不发送
+	IMBotURL string `json:"im-bot-url" yaml:"im-bot-url"`
+	// WebhookURL 告警回调地址，为空不回调
+	WebhookURL string `json:"webhook-url" yaml:"webhook-url"`
+}
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// WatchConfig 运维观察通知，AdminService.WatchNotification 实时推送某个业务 key 的通知状态变化
+type WatchConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Channel 通知变化使用的 Redis 频道，观察注册表是 channel 加上 :registry
+	Channel string `json:"channel" yaml:"channel"`
+	// RefreshInterval 每个实例同步注册表的间隔，开始观察之后其他实例最多这么久开始发布
+	RefreshInterval time.Duration `json:"refresh-interval" yaml:"refresh-interval"`
+	// PollInterval 观察期间重新查询通知的间隔，兜底丢失的变化
+	PollInterval time.Duration `json:"poll-interval" yaml:"poll-interval"`
+	MaxDuration  time.Duration `json:"max-duration" yaml:"max-duration"`
+	// MaxConcurrent 每个实例同时进行的观察数
+	MaxConcurrent int `json:"max-concurrent" yaml:"max-concurrent"`
+}
@@ -0,0 +1,82 @@
+// Package ctxkit 跨层传递的上下文数据，所有 key 都是私有类型，避免和其他包冲突
+package ctxkit
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+type (
+	bizIDKey     struct{}
+	priorityKey  struct{}
+	requestIDKey struct{}
+	callerKey    struct{}
+)
+
+// Priority 请求优先级，高优先级请求走核心库
+type Priority string
+
+const (
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+)
+
+// IsValid 是否是合法的优先级
+func (p Priority) IsValid() bool {
+	return p == PriorityHigh || p == PriorityNormal
+}
+
+// WithBizID 设置业务方ID
+func WithBizID(ctx context.Context, bizID int64) context.Context {
+	return context.WithValue(ctx, bizIDKey{}, bizID)
+}
+
+// BizIDFromContext 获取业务方ID
+func BizIDFromContext(ctx context.Context) (int64, bool) {
+	bizID, ok := ctx.Value(bizIDKey{}).(int64)
+	return bizID, ok
+}
+
+// WithPriority 设置请求优先级
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext 获取请求优先级，没有设置时是 PriorityNormal
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// WithRequestID 设置请求ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext 获取请求ID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// WithCaller 设置鉴权通过的调用方，同时设置业务方ID
+func WithCaller(ctx context.Context, p domain.Principal) context.Context {
+	return WithBizID(context.WithValue(ctx, callerKey{}, p), p.BizID)
+}
+
+// CallerFromContext 获取鉴权通过的调用方
+func CallerFromContext(ctx context.Context) (domain.Principal, bool) {
+	p, ok := ctx.Value(callerKey{}).(domain.Principal)
+	return p, ok
+}
+
+// EnvironmentFromContext 调用方凭证所属的环境，没有鉴权时是生产环境
+func EnvironmentFromContext(ctx context.Context) domain.Environment {
+	if p, ok := CallerFromContext(ctx); ok && p.Environment.IsSandbox() {
+		return domain.EnvironmentSandbox
+	}
+	return domain.EnvironmentProduction
+}
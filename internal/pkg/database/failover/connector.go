@@ -0,0 +1,180 @@
+package failover
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+)
+
+var _ driver.Connector = (*Connector)(nil)
+
+// Connector 按顺序连接多个地址，托管数据库主从切换时不用改配置重启
+// 新连接先连当前地址，失败时依次尝试后面的地址，成功之后后续的新连接都使用这个地址；
+// 连上的实例是只读的时候切换到下一个地址。连接池里连着旧地址的连接在下次使用之前被丢弃，不会自动切回主地址
+// 连接失败的错误由使用连接的 Pool 记录
+type Connector struct {
+	connectors []driver.Connector
+	health     *Health
+	logger     log.LoggerInterface
+}
+
+// NewConnector connectors 第一个是主地址，health 的 dsnCount 需要和 connectors 的数量一致
+func NewConnector(connectors []driver.Connector, health *Health) *Connector {
+	return &Connector{
+		connectors: connectors,
+		health:     health,
+		logger:     log.Named(log.DefaultLogger(), "database.failover"),
+	}
+}
+
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	start := c.health.Active()
+	var lastErr error
+	for i := range c.connectors {
+		idx := (start + i) % len(c.connectors)
+		dc, err := c.connectors[idx].Connect(ctx)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		if c.health.switchActive(start, idx, lastErr) {
+			c.logger.Warn("数据库地址连接失败，切换到备用地址", zap.Int("from", start), zap.Int("to", idx), zap.Error(lastErr))
+		}
+		return &conn{Conn: dc, idx: idx, owner: c}, nil
+	}
+	return nil, fmt.Errorf("所有数据库地址都连接失败: %w", lastErr)
+}
+
+func (c *Connector) Driver() driver.Driver {
+	return c.connectors[0].Driver()
+}
+
+// readOnly 连接的实例变成只读，新连接从下一个地址开始尝试
+func (c *Connector) readOnly(idx int, err error) {
+	next := (idx + 1) % len(c.connectors)
+	if c.health.switchActive(idx, next, err) {
+		c.logger.Warn("数据库实例只读，切换到下一个地址", zap.Int("from", idx), zap.Int("to", next), zap.Error(err))
+	}
+}
+
+// conn 记住连接的地址，当前地址变化之后不再使用
+type conn struct {
+	driver.Conn
+	idx   int
+	owner *Connector
+}
+
+var (
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+	_ driver.SessionResetter    = (*conn)(nil)
+	_ driver.Validator          = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+)
+
+func (c *conn) stale() bool {
+	return c.idx != c.owner.health.Active()
+}
+
+// check 只读实例上的语句在执行之前就被拒绝，返回 driver.ErrBadConn 让 database/sql 换一个连接重试
+// 其他连接错误由 Pool 记录
+func (c *conn) check(err error) error {
+	if !IsReadOnlyError(err) {
+		return err
+	}
+	c.owner.health.RecordError(err)
+	c.owner.readOnly(c.idx, err)
+	return fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if c.stale() {
+		return nil, driver.ErrBadConn
+	}
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err := p.PrepareContext(ctx, query)
+		return stmt, c.check(err)
+	}
+	stmt, err := c.Conn.Prepare(query)
+	return stmt, c.check(err)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.stale() {
+		return nil, driver.ErrBadConn
+	}
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err := b.BeginTx(ctx, opts)
+		return tx, c.check(err)
+	}
+	//nolint:staticcheck // 驱动没有实现 ConnBeginTx 时只能使用 Begin
+	tx, err := c.Conn.Begin()
+	return tx, c.check(err)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.stale() {
+		return nil, driver.ErrBadConn
+	}
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	res, err := e.ExecContext(ctx, query, args)
+	return res, c.check(err)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.stale() {
+		return nil, driver.ErrBadConn
+	}
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := q.QueryContext(ctx, query, args)
+	return rows, c.check(err)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return c.check(p.Ping(ctx))
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if c.stale() {
+		return driver.ErrBadConn
+	}
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if c.stale() {
+		return false
+	}
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
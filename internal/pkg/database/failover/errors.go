@@ -0,0 +1,92 @@
+package failover
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// 服务端返回的连接类和只读错误码
+const (
+	mysqlServerShutdown   = 1053
+	mysqlConnectionKilled = 1927
+	mysqlServerGone       = 2006
+	mysqlLostConnection   = 2013
+	mysqlOptionPrevents   = 1290
+	mysqlReadOnlyTx       = 1792
+	mysqlReadOnlyMode     = 1836
+	postgresReadOnlyTx    = "25006"
+	postgresAdminShutdown = "57P01"
+	postgresCrashShutdown = "57P02"
+	postgresCannotConnect = "57P03"
+	postgresConnectionExc = "08"
+)
+
+// IsConnectionError 连接断开、服务端重启、网络不通这类换一个连接可能成功的错误
+// 超时和取消是调用方的选择，不算连接错误
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case mysqlServerShutdown, mysqlConnectionKilled, mysqlServerGone, mysqlLostConnection:
+			return true
+		}
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case postgresAdminShutdown, postgresCrashShutdown, postgresCannotConnect:
+			return true
+		}
+		return strings.HasPrefix(pgErr.Code, postgresConnectionExc)
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) || pgconn.SafeToRetry(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsReadOnlyError 连接的实例是只读的，托管数据库主从切换之后旧的主库通常变成只读
+// 语句在执行之前就被拒绝，换一个地址重试是安全的
+func IsReadOnlyError(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case mysqlReadOnlyTx, mysqlReadOnlyMode:
+			return true
+		case mysqlOptionPrevents:
+			// 1290 也用于 secure-file-priv 等其他选项
+			return strings.Contains(myErr.Message, "read-only") || strings.Contains(myErr.Message, "read only")
+		}
+		return false
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == postgresReadOnlyTx
+}
+
+// isRead 只读语句，事务之外重试不会产生副作用
+func isRead(query string) bool {
+	query = strings.TrimLeft(query, " \t\r\n(")
+	for _, prefix := range []string{"SELECT", "SHOW"} {
+		if len(query) >= len(prefix) && strings.EqualFold(query[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
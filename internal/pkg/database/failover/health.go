@@ -0,0 +1,174 @@
+package failover
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	connectionErrorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_connection_errors_total",
+		Help: "Total number of database connection errors (server gone, invalid connection, read-only instance), by database",
+	}, []string{"name"})
+	failoversCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_failovers_total",
+		Help: "Total number of switches to another DSN, by database",
+	}, []string{"name"})
+	readRetriesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_read_retries_total",
+		Help: "Total number of read statements retried after a connection error, by database and result",
+	}, []string{"name", "result"})
+	degradedDesc = prometheus.NewDesc("db_degraded",
+		"Whether the database recently had connection errors or is running on a standby DSN (1) or not (0)",
+		[]string{"name"}, nil)
+	activeDSNDesc = prometheus.NewDesc("db_active_dsn",
+		"Index of the DSN new connections go to, 0 is database.dsn",
+		[]string{"name"}, nil)
+)
+
+func init() {
+	prometheus.MustRegister(connectionErrorsCounter, failoversCounter, readRetriesCounter, stateCollector{})
+}
+
+// registry 注册过的数据库，用于指标和运维接口
+var registry = struct {
+	sync.Mutex
+	healths map[string]*Health
+}{healths: make(map[string]*Health)}
+
+// Register 注册之后出现在 Snapshot 和 db_degraded 指标里，同名的覆盖
+func Register(h *Health) {
+	registry.Lock()
+	registry.healths[h.name] = h
+	registry.Unlock()
+}
+
+// Snapshot 所有注册过的数据库的状态，按名称排序
+func Snapshot() []State {
+	registry.Lock()
+	defer registry.Unlock()
+	res := make([]State, 0, len(registry.healths))
+	for _, h := range registry.healths {
+		res = append(res, h.State())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// State 数据库连接状态
+type State struct {
+	Name string
+	// Degraded 最近 window 内有连接错误，或者正在使用备用地址
+	Degraded bool
+	// ActiveDSN 新连接使用的地址序号，0 是主地址
+	ActiveDSN        int
+	DSNCount         int
+	Failovers        uint64
+	ConnectionErrors uint64
+	LastError        string
+	// LastErrorTime 没有错误时是零值
+	LastErrorTime time.Time
+}
+
+// Health 记录一个数据库的连接错误和正在使用的地址
+type Health struct {
+	name     string
+	dsnCount int
+	window   time.Duration
+
+	active    atomic.Int32
+	errors    atomic.Uint64
+	failovers atomic.Uint64
+
+	mu            sync.Mutex
+	lastError     string
+	lastErrorTime time.Time
+}
+
+// NewHealth dsnCount 是主地址加备用地址的数量，window 是最近一次连接错误之后报告降级的时间
+func NewHealth(name string, dsnCount int, window time.Duration) *Health {
+	return &Health{name: name, dsnCount: max(dsnCount, 1), window: window}
+}
+
+// RecordError 记录连接错误，其他错误忽略，返回是否记录
+func (h *Health) RecordError(err error) bool {
+	if !IsConnectionError(err) && !IsReadOnlyError(err) {
+		return false
+	}
+	h.errors.Add(1)
+	connectionErrorsCounter.WithLabelValues(h.name).Inc()
+	h.setLastError(err)
+	return true
+}
+
+func (h *Health) setLastError(err error) {
+	h.mu.Lock()
+	h.lastError, h.lastErrorTime = err.Error(), time.Now()
+	h.mu.Unlock()
+}
+
+// Active 新连接使用的地址序号
+func (h *Health) Active() int {
+	return int(h.active.Load())
+}
+
+// switchActive 当前地址还是 from 时切换到 to，多个连接同时发现失败时只切换一次，cause 是切换的原因
+func (h *Health) switchActive(from, to int, cause error) bool {
+	if from == to || !h.active.CompareAndSwap(int32(from), int32(to)) {
+		return false
+	}
+	if cause != nil {
+		h.setLastError(cause)
+	}
+	h.failovers.Add(1)
+	failoversCounter.WithLabelValues(h.name).Inc()
+	return true
+}
+
+// Degraded 最近 window 内有连接错误，或者正在使用备用地址
+func (h *Health) Degraded() bool {
+	if h.Active() != 0 {
+		return true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return !h.lastErrorTime.IsZero() && time.Since(h.lastErrorTime) < h.window
+}
+
+func (h *Health) State() State {
+	s := State{
+		Name:             h.name,
+		Degraded:         h.Degraded(),
+		ActiveDSN:        h.Active(),
+		DSNCount:         h.dsnCount,
+		Failovers:        h.failovers.Load(),
+		ConnectionErrors: h.errors.Load(),
+	}
+	h.mu.Lock()
+	s.LastError, s.LastErrorTime = h.lastError, h.lastErrorTime
+	h.mu.Unlock()
+	return s
+}
+
+// stateCollector 降级状态随时间变化，采集时计算
+type stateCollector struct{}
+
+func (stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- degradedDesc
+	ch <- activeDSNDesc
+}
+
+func (stateCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range Snapshot() {
+		degraded := 0.0
+		if s.Degraded {
+			degraded = 1
+		}
+		ch <- prometheus.MustNewConstMetric(degradedDesc, prometheus.GaugeValue, degraded, s.Name)
+		ch <- prometheus.MustNewConstMetric(activeDSNDesc, prometheus.GaugeValue, float64(s.ActiveDSN), s.Name)
+	}
+}
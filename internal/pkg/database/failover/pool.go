@@ -0,0 +1,105 @@
+package failover
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	_ gorm.ConnPool       = (*Pool)(nil)
+	_ gorm.TxBeginner     = (*Pool)(nil)
+	_ gorm.GetDBConnector = (*Pool)(nil)
+)
+
+// PoolOptions 读语句重试参数
+type PoolOptions struct {
+	// ReadRetries 只读语句遇到连接错误时最多重试几次，0 不重试
+	ReadRetries int
+	// RetryBackoff 第 n 次重试之前等待 n 倍
+	RetryBackoff time.Duration
+}
+
+// Pool 替换 gorm 的连接池，记录连接错误，事务之外的只读语句遇到连接错误时换一个连接重试
+// 写语句不重试，连接断开时不知道语句有没有执行；事务里的语句使用 *sql.Tx，不经过 Pool
+type Pool struct {
+	db     *sql.DB
+	health *Health
+	opts   PoolOptions
+}
+
+func NewPool(db *sql.DB, health *Health, opts PoolOptions) *Pool {
+	return &Pool{db: db, health: health, opts: opts}
+}
+
+func (p *Pool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	stmt, err := p.db.PrepareContext(ctx, query)
+	p.health.RecordError(err)
+	return stmt, err
+}
+
+func (p *Pool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	res, err := p.db.ExecContext(ctx, query, args...)
+	p.health.RecordError(err)
+	return res, err
+}
+
+func (p *Pool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := p.retry(ctx, query, func() error {
+		var err error
+		rows, err = p.db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// QueryRowContext *sql.Row 的查询错误在 Scan 之前就可以通过 Err 拿到
+func (p *Pool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	var row *sql.Row
+	_ = p.retry(ctx, query, func() error {
+		row = p.db.QueryRowContext(ctx, query, args...)
+		return row.Err()
+	})
+	return row
+}
+
+func (p *Pool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	tx, err := p.db.BeginTx(ctx, opts)
+	p.health.RecordError(err)
+	return tx, err
+}
+
+func (p *Pool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+// Ping gorm.Open 检查连接
+func (p *Pool) Ping() error {
+	return p.db.Ping()
+}
+
+func (p *Pool) retry(ctx context.Context, query string, fn func() error) error {
+	err := fn()
+	if !p.health.RecordError(err) || !IsConnectionError(err) || p.opts.ReadRetries <= 0 || !isRead(query) {
+		return err
+	}
+	for i := 1; i <= p.opts.ReadRetries; i++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(i) * p.opts.RetryBackoff):
+		}
+		if err = fn(); err == nil {
+			readRetriesCounter.WithLabelValues(p.health.name, "success").Inc()
+			return nil
+		}
+		if !p.health.RecordError(err) || !IsConnectionError(err) {
+			break
+		}
+	}
+	readRetriesCounter.WithLabelValues(p.health.name, "failed").Inc()
+	return err
+}
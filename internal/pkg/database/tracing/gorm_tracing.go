@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -24,14 +25,19 @@ const (
 type GormTracingPlugin struct {
 	// 可选的追踪器，如果为nil则使用全局追踪器
 	tracer trace.Tracer
+	opts   StatementOptions
 }
 
 var _ gorm.Plugin = &GormTracingPlugin{}
 
-// NewGormTracingPlugin 创建一个新的GORM追踪插件
-func NewGormTracingPlugin() *GormTracingPlugin {
+// NewGormTracingPlugin 创建一个新的GORM追踪插件，opts 控制 SQL 语句和参数怎么记录到 span 上
+func NewGormTracingPlugin(opts StatementOptions) *GormTracingPlugin {
+	if opts.MaxLength <= 0 {
+		opts.MaxLength = defaultMaxStatementLength
+	}
 	return &GormTracingPlugin{
 		tracer: otel.GetTracerProvider().Tracer(instrumentationName),
+		opts:   opts,
 	}
 }
 
@@ -94,10 +100,10 @@ func extractContext(db *gorm.DB) context.Context {
 }
 
 // 辅助函数：设置span的通用属性
-func setSpanAttributes(span trace.Span, db *gorm.DB) {
+func (p *GormTracingPlugin) setSpanAttributes(span trace.Span, db *gorm.DB) {
 	// 设置一些基本的数据库属性
 	attributes := []attribute.KeyValue{
-		attribute.String("db.system", "mysql"), // 或其他数据库类型
+		attribute.String("db.system", db.Dialector.Name()),
 		attribute.String("db.name", db.Dialector.Name()),
 	}
 
@@ -122,13 +128,13 @@ func setSpanAttributes(span trace.Span, db *gorm.DB) {
 	}
 	attributes = append(attributes, attribute.String("db.operation", opType))
 
-	// 当SQL语句准备好时添加
-	if db.Statement.SQL.String() != "" {
-		attributes = append(attributes, attribute.String("db.statement", db.Statement.SQL.String()))
+	// 当SQL语句准备好时添加，只记录慢语句时快的语句不带 SQL
+	if db.Statement.SQL.String() != "" && p.captureStatement(db) {
+		attributes = append(attributes, p.statementAttributes(db)...)
 	}
 
-	// 添加影响的行数
-	if db.Statement.RowsAffected > 0 {
+	// 添加影响的行数，查询时是返回的行数
+	if db.Error == nil {
 		attributes = append(attributes, attribute.Int64("db.rows_affected", db.Statement.RowsAffected))
 	}
 
@@ -148,6 +154,7 @@ func (p *GormTracingPlugin) beforeQuery(db *gorm.DB) {
 	// 存储span以便在afterQuery中使用
 	db.Statement.Context = ctx
 	db.Set("tracing:span", span)
+	db.Set(startKey, time.Now())
 }
 
 func (p *GormTracingPlugin) afterQuery(db *gorm.DB) {
@@ -159,7 +166,7 @@ func (p *GormTracingPlugin) afterQuery(db *gorm.DB) {
 	if span, ok := spanValue.(trace.Span); ok {
 		defer span.End()
 
-		setSpanAttributes(span, db)
+		p.setSpanAttributes(span, db)
 
 		// 记录错误（如果有）
 		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
@@ -183,6 +190,7 @@ func (p *GormTracingPlugin) beforeCreate(db *gorm.DB) {
 	// 存储span以便在afterCreate中使用
 	db.Statement.Context = ctx
 	db.Set("tracing:span", span)
+	db.Set(startKey, time.Now())
 }
 
 func (p *GormTracingPlugin) afterCreate(db *gorm.DB) {
@@ -194,7 +202,7 @@ func (p *GormTracingPlugin) afterCreate(db *gorm.DB) {
 	if span, ok := spanValue.(trace.Span); ok {
 		defer span.End()
 
-		setSpanAttributes(span, db)
+		p.setSpanAttributes(span, db)
 
 		// 记录错误（如果有）
 		if db.Error != nil {
@@ -218,6 +226,7 @@ func (p *GormTracingPlugin) beforeUpdate(db *gorm.DB) {
 	// 存储span以便在afterUpdate中使用
 	db.Statement.Context = ctx
 	db.Set("tracing:span", span)
+	db.Set(startKey, time.Now())
 }
 
 func (p *GormTracingPlugin) afterUpdate(db *gorm.DB) {
@@ -229,7 +238,7 @@ func (p *GormTracingPlugin) afterUpdate(db *gorm.DB) {
 	if span, ok := spanValue.(trace.Span); ok {
 		defer span.End()
 
-		setSpanAttributes(span, db)
+		p.setSpanAttributes(span, db)
 
 		// 记录错误（如果有）
 		if db.Error != nil {
@@ -253,6 +262,7 @@ func (p *GormTracingPlugin) beforeDelete(db *gorm.DB) {
 	// 存储span以便在afterDelete中使用
 	db.Statement.Context = ctx
 	db.Set("tracing:span", span)
+	db.Set(startKey, time.Now())
 }
 
 func (p *GormTracingPlugin) afterDelete(db *gorm.DB) {
@@ -264,7 +274,7 @@ func (p *GormTracingPlugin) afterDelete(db *gorm.DB) {
 	if span, ok := spanValue.(trace.Span); ok {
 		defer span.End()
 
-		setSpanAttributes(span, db)
+		p.setSpanAttributes(span, db)
 
 		// 记录错误（如果有）
 		if db.Error != nil {
@@ -292,6 +302,7 @@ func (p *GormTracingPlugin) beforeRaw(db *gorm.DB) {
 	// 存储span以便在afterRaw中使用
 	db.Statement.Context = ctx
 	db.Set("tracing:span", span)
+	db.Set(startKey, time.Now())
 }
 
 func (p *GormTracingPlugin) afterRaw(db *gorm.DB) {
@@ -303,7 +314,7 @@ func (p *GormTracingPlugin) afterRaw(db *gorm.DB) {
 	if span, ok := spanValue.(trace.Span); ok {
 		defer span.End()
 
-		setSpanAttributes(span, db)
+		p.setSpanAttributes(span, db)
 
 		// 记录错误（如果有）
 		if db.Error != nil {
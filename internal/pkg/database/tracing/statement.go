@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultMaxStatementLength = 2048
+	startKey                  = "tracing:start"
+	redactedValue             = "?"
+)
+
+// 参数的记录方式
+const (
+	// ArgsNone 不记录参数
+	ArgsNone = "none"
+	// ArgsRedacted 数字、布尔、时间照常记录，字符串和二进制只记录长度，避免接收者、模板参数这类数据进入链路系统
+	ArgsRedacted = "redacted"
+	// ArgsRaw 原样记录，只在开发环境使用
+	ArgsRaw = "raw"
+)
+
+// StatementOptions SQL 语句怎么记录到 span 上
+type StatementOptions struct {
+	// Disabled 不记录 SQL 语句和参数，只记录表名、操作类型和行数
+	Disabled bool
+	// MaxLength SQL 语句和每个参数最多记录的字节数，超过的部分截断，默认 2048
+	MaxLength int
+	// Args 参数的记录方式，ArgsNone、ArgsRedacted、ArgsRaw，默认 ArgsNone
+	Args string
+	// SlowThreshold 大于 0 时只有执行时间超过这个值的语句才记录 SQL
+	SlowThreshold time.Duration
+}
+
+func (p *GormTracingPlugin) captureStatement(db *gorm.DB) bool {
+	if p.opts.Disabled {
+		return false
+	}
+	if p.opts.SlowThreshold <= 0 {
+		return true
+	}
+	v, ok := db.Get(startKey)
+	if !ok {
+		return true
+	}
+	start, ok := v.(time.Time)
+	return !ok || time.Since(start) >= p.opts.SlowThreshold
+}
+
+func (p *GormTracingPlugin) statementAttributes(db *gorm.DB) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("db.statement", truncate(db.Statement.SQL.String(), p.opts.MaxLength)),
+	}
+	switch p.opts.Args {
+	case ArgsRedacted, ArgsRaw:
+		args := make([]string, 0, len(db.Statement.Vars))
+		for _, v := range db.Statement.Vars {
+			args = append(args, truncate(formatArg(v, p.opts.Args == ArgsRedacted), p.opts.MaxLength))
+		}
+		attrs = append(attrs, attribute.StringSlice("db.statement.args", args))
+	}
+	return attrs
+}
+
+// formatArg redact 为 true 时字符串和二进制只保留长度
+func formatArg(v any, redact bool) string {
+	if valuer, ok := v.(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err != nil {
+			return redactedValue
+		}
+		v = val
+	}
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case string:
+		if redact {
+			return fmt.Sprintf("<string len=%d>", len(val))
+		}
+		return val
+	case []byte:
+		if redact {
+			return fmt.Sprintf("<bytes len=%d>", len(val))
+		}
+		return string(val)
+	default:
+		// IN 查询的切片等复杂类型，脱敏时只记录类型
+		if redact {
+			return fmt.Sprintf("<%T>", val)
+		}
+		return fmt.Sprint(val)
+	}
+}
+
+// truncate 按字符截断，不会截断半个 UTF-8 字符
+func truncate(s string, n int) string {
+	if n <= 0 || len(s) <= n {
+		return s
+	}
+	i := n
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return s[:i] + "...(truncated)"
+}
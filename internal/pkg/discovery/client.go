@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/registry"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -18,19 +19,54 @@ type ServiceDiscovery struct {
 	mu     sync.RWMutex
 	// 缓存服务地址列表
 	serviceCache map[string][]string
+	// requestTimeout 调用方没有设置截止时间时每个查询的超时时间，0 不限制
+	requestTimeout time.Duration
+}
+
+// Option 服务发现选项
+type Option func(sd *ServiceDiscovery)
+
+// WithRequestTimeout 调用方没有设置截止时间时每个查询的超时时间，监听不受影响
+func WithRequestTimeout(d time.Duration) Option {
+	return func(sd *ServiceDiscovery) {
+		sd.requestTimeout = d
+	}
 }
 
 // NewServiceDiscovery 创建服务发现客户端
-func NewServiceDiscovery(client *clientv3.Client) *ServiceDiscovery {
-	return &ServiceDiscovery{
+func NewServiceDiscovery(client *clientv3.Client, opts ...Option) *ServiceDiscovery {
+	sd := &ServiceDiscovery{
 		client:       client,
 		prefix:       "/services/",
 		serviceCache: make(map[string][]string),
 	}
+	for _, opt := range opts {
+		opt(sd)
+	}
+	return sd
+}
+
+// NewServiceDiscoveryWithConfig 按注册器同样的配置（TLS、端点同步、保活、请求超时）创建服务发现客户端
+func NewServiceDiscoveryWithConfig(cfg *registry.EtcdConfig) (*ServiceDiscovery, error) {
+	client, err := registry.NewEtcdClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewServiceDiscovery(client, WithRequestTimeout(cfg.RequestTimeout)), nil
+}
+
+// requestContext 调用方没有设置截止时间时加上 requestTimeout
+func (sd *ServiceDiscovery) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || sd.requestTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, sd.requestTimeout)
 }
 
 // GetService 获取指定服务的地址（返回第一个可用的）
 func (sd *ServiceDiscovery) GetService(ctx context.Context, serviceName string) (string, error) {
+	ctx, cancel := sd.requestContext(ctx)
+	defer cancel()
 	key := sd.prefix + serviceName
 	resp, err := sd.client.Get(ctx, key, clientv3.WithPrefix())
 	if err != nil {
@@ -47,6 +83,8 @@ func (sd *ServiceDiscovery) GetService(ctx context.Context, serviceName string)
 
 // GetServiceList 获取指定服务的所有实例地址
 func (sd *ServiceDiscovery) GetServiceList(ctx context.Context, serviceName string) ([]string, error) {
+	ctx, cancel := sd.requestContext(ctx)
+	defer cancel()
 	key := sd.prefix + serviceName
 	resp, err := sd.client.Get(ctx, key, clientv3.WithPrefix())
 	if err != nil {
@@ -86,6 +124,8 @@ func (sd *ServiceDiscovery) WatchService(ctx context.Context, serviceName string
 
 // GetAllServices 获取所有注册的服务
 func (sd *ServiceDiscovery) GetAllServices(ctx context.Context) (map[string][]string, error) {
+	ctx, cancel := sd.requestContext(ctx)
+	defer cancel()
 	resp, err := sd.client.Get(ctx, sd.prefix, clientv3.WithPrefix())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all services from etcd: %w", err)
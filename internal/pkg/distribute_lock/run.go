@@ -0,0 +1,31 @@
+package distribute_lock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+)
+
+// RunLocked 立即执行一次，之后每隔 interval 执行一次，阻塞运行直到 ctx 被取消
+// 多个实例通过分布式锁保证每个周期只有一个实例执行 fn，锁的有效期是一个周期，不主动释放，到期自动失效
+func RunLocked(ctx context.Context, lock Client, key string, interval time.Duration, fn func(ctx context.Context)) {
+	logger := log.Named(log.DefaultLogger(), "distribute_lock")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		mutex := lock.NewLock(ctx, key, NewLockerOption(interval, 0, time.Second))
+		if err := mutex.Lock(); err == nil {
+			fn(ctx)
+		} else if !errors.Is(err, ErrLockFailed) {
+			logger.WithContext(ctx).Error("获取任务锁失败", zap.String("key", key), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package encrypt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// BlindIndexer 盲索引，对明文做带密钥的哈希，加密后的字段依旧可以做等值查询
+// 盲索引密钥和加密密钥分开，而且不能轮换，轮换就意味着所有索引都要重建
+type BlindIndexer interface {
+	Index(value string) string
+}
+
+var _ BlindIndexer = (*hmacBlindIndexer)(nil)
+
+func NewHMACBlindIndexer(key []byte) BlindIndexer {
+	return &hmacBlindIndexer{key: key}
+}
+
+type hmacBlindIndexer struct {
+	key []byte
+}
+
+// Index 返回 HMAC-SHA256 的十六进制，计算之前去掉首尾空白并转小写，邮箱大小写不同也能查到
+func (b *hmacBlindIndexer) Index(value string) string {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
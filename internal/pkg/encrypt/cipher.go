@@ -0,0 +1,127 @@
+package encrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const (
+	// prefix 密文前缀，没有这个前缀的数据视为加密上线前写入的明文
+	prefix    = "enc:v1"
+	separator = ":"
+)
+
+// Cipher 字段级加解密
+type Cipher interface {
+	// Encrypt 加密，返回 enc:v1:<密钥ID>:<base64(nonce+密文)>
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+	// Decrypt 解密，不是密文格式的数据原样返回
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+var (
+	_ Cipher = (*aesGCMCipher)(nil)
+	_ Cipher = (*noopCipher)(nil)
+)
+
+// NewAESGCMCipher AES-GCM 加密，密钥ID会作为附加数据参与认证，防止密文被挪到别的密钥下
+func NewAESGCMCipher(keys KeyProvider) Cipher {
+	return &aesGCMCipher{keys: keys}
+}
+
+type aesGCMCipher struct {
+	keys KeyProvider
+}
+
+func (c *aesGCMCipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	key, err := c.keys.ActiveKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(key.ID))
+	return strings.Join([]string{prefix, key.ID, base64.StdEncoding.EncodeToString(sealed)}, separator), nil
+}
+
+func (c *aesGCMCipher) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	if !IsEncrypted(ciphertext) {
+		return ciphertext, nil
+	}
+	keyID, payload, ok := strings.Cut(strings.TrimPrefix(ciphertext, prefix+separator), separator)
+	if !ok {
+		return "", fmt.Errorf("%w: 缺少密钥ID", ErrMalformedCiphertext)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMalformedCiphertext, err)
+	}
+	key, err := c.keys.KeyByID(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: 长度不足", ErrMalformedCiphertext)
+	}
+	nonce, data := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, data, []byte(key.ID))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMalformedCiphertext, err)
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key Key) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsEncrypted 判断数据是不是本包生成的密文
+func IsEncrypted(data string) bool {
+	return strings.HasPrefix(data, prefix+separator)
+}
+
+// KeyIDOf 返回密文使用的密钥ID，明文返回空字符串，可以用来找出还没有轮换到新密钥的数据
+func KeyIDOf(data string) string {
+	if !IsEncrypted(data) {
+		return ""
+	}
+	keyID, _, _ := strings.Cut(strings.TrimPrefix(data, prefix+separator), separator)
+	return keyID
+}
+
+// NewNoopCipher 不加密，未开启加密时使用，读到已经加密的数据会报错
+func NewNoopCipher() Cipher {
+	return &noopCipher{}
+}
+
+type noopCipher struct{}
+
+func (c *noopCipher) Encrypt(_ context.Context, plaintext string) (string, error) {
+	return plaintext, nil
+}
+
+func (c *noopCipher) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	if IsEncrypted(ciphertext) {
+		return "", fmt.Errorf("%w: 未开启加密，无法解密", ErrKeyNotFound)
+	}
+	return ciphertext, nil
+}
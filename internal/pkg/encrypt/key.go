@@ -0,0 +1,75 @@
+package encrypt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrKeyNotFound         = errors.New("加密密钥不存在")
+	ErrInvalidKey          = errors.New("加密密钥不合法")
+	ErrMalformedCiphertext = errors.New("密文格式错误")
+)
+
+// Key 数据加密密钥，ID 会写进密文，用于轮换后找到解密用的密钥
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// KeyProvider 密钥提供者
+// 加密总是使用 ActiveKey，解密按照密文里的密钥ID查找，所以轮换密钥时旧密钥要保留到数据全部重新加密为止
+type KeyProvider interface {
+	// ActiveKey 当前用于加密的密钥
+	ActiveKey(ctx context.Context) (Key, error)
+	// KeyByID 根据密钥ID获取密钥
+	KeyByID(ctx context.Context, id string) (Key, error)
+}
+
+var _ KeyProvider = (*staticKeyProvider)(nil)
+
+// NewStaticKeyProvider 使用固定的密钥集合，activeID 必须在 keys 里
+// AES 密钥长度必须是 16、24 或 32 字节
+func NewStaticKeyProvider(activeID string, keys []Key) (KeyProvider, error) {
+	p := &staticKeyProvider{
+		activeID: activeID,
+		keys:     make(map[string]Key, len(keys)),
+	}
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, separator) {
+			return nil, fmt.Errorf("%w: 密钥ID不能为空也不能包含 %q", ErrInvalidKey, separator)
+		}
+		switch len(key.Secret) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("%w: 密钥 %s 长度为 %d 字节", ErrInvalidKey, key.ID, len(key.Secret))
+		}
+		if _, ok := p.keys[key.ID]; ok {
+			return nil, fmt.Errorf("%w: 密钥ID %s 重复", ErrInvalidKey, key.ID)
+		}
+		p.keys[key.ID] = key
+	}
+	if _, ok := p.keys[activeID]; !ok {
+		return nil, fmt.Errorf("%w: 当前密钥 %s", ErrKeyNotFound, activeID)
+	}
+	return p, nil
+}
+
+type staticKeyProvider struct {
+	activeID string
+	keys     map[string]Key
+}
+
+func (p *staticKeyProvider) ActiveKey(_ context.Context) (Key, error) {
+	return p.keys[p.activeID], nil
+}
+
+func (p *staticKeyProvider) KeyByID(_ context.Context, id string) (Key, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return Key{}, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+	return key, nil
+}
@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// InAppBus 站内信投递事件总线，发送流程发布，所有实例上的推送网关都会收到，再推给自己持有的长连接
+// 事件不落盘，没有实例在线时直接丢弃，离线用户重新连接时从收件箱补发
+type InAppBus interface {
+	Publish(ctx context.Context, msg domain.InAppMessage) error
+	// Subscribe 阻塞接收事件，直到 ctx 被取消或者订阅断开，handle 不能阻塞
+	Subscribe(ctx context.Context, handle func(domain.InAppMessage)) error
+}
+
+var _ InAppBus = (*redisInAppBus)(nil)
+
+// NewRedisInAppBus 基于 Redis 发布订阅，channel 是 Redis 频道名称
+func NewRedisInAppBus(client *redis.Client, channel string) InAppBus {
+	return &redisInAppBus{client: client, channel: channel}
+}
+
+type redisInAppBus struct {
+	client  *redis.Client
+	channel string
+}
+
+func (b *redisInAppBus) Publish(ctx context.Context, msg domain.InAppMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+func (b *redisInAppBus) Subscribe(ctx context.Context, handle func(domain.InAppMessage)) error {
+	sub := b.client.Subscribe(ctx, b.channel)
+	defer sub.Close()
+	// 等订阅确认之后再接收，订阅失败直接返回
+	if _, err := sub.Receive(ctx); err != nil {
+		return fmt.Errorf("订阅站内信事件失败: %w", err)
+	}
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case m, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("站内信事件订阅已断开")
+			}
+			var msg domain.InAppMessage
+			if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+				// 格式不对的事件直接跳过，不影响后面的事件
+				continue
+			}
+			handle(msg)
+		}
+	}
+}
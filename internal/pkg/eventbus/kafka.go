@@ -0,0 +1,174 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"google.golang.org/protobuf/proto"
+)
+
+// kafkaBinaryContentType Kafka REST Proxy v2 的二进制消息格式，key 和 value 都是 base64
+const kafkaBinaryContentType = "application/vnd.kafka.binary.v2+json"
+
+var kafkaEventsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_lifecycle_kafka_events_total",
+	Help: "Total number of notification lifecycle events published to Kafka, by result",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(kafkaEventsCounter)
+}
+
+// KafkaOptions 通过 Kafka REST Proxy 发布消息
+type KafkaOptions struct {
+	// Endpoint REST Proxy 地址，比如 http://localhost:8082
+	Endpoint string
+	Topic    string
+	Username string
+	Password string
+}
+
+// LifecyclePublisher 把通知生命周期事件发布到 Kafka，消息是 protobuf 编码的 NotificationLifecycleEvent
+// 消息的 key 是业务方ID，同一个业务方的事件按 Kafka 的默认分区规则落在同一个分区，保证顺序
+// 实现了导出任务的分析库接口，由导出任务按 (utime, id) 增量读取通知变化，至少发布一次
+type LifecyclePublisher struct {
+	httpClient *http.Client
+	opts       KafkaOptions
+	topicURL   string
+}
+
+func NewLifecyclePublisher(httpClient *http.Client, opts KafkaOptions) (*LifecyclePublisher, error) {
+	if opts.Endpoint == "" || opts.Topic == "" {
+		return nil, fmt.Errorf("%w: Kafka REST Proxy 地址和 topic 不能为空", domain.ErrInvalidParameter)
+	}
+	return &LifecyclePublisher{
+		httpClient: httpClient,
+		opts:       opts,
+		topicURL:   strings.TrimRight(opts.Endpoint, "/") + "/topics/" + url.PathEscape(opts.Topic),
+	}, nil
+}
+
+// EnsureSchema 检查 topic 是否存在，topic 由运维创建，不自动创建
+func (p *LifecyclePublisher) EnsureSchema(ctx context.Context) error {
+	resp, err := p.do(ctx, http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+type kafkaRecord struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		Partition int32   `json:"partition"`
+		Offset    int64   `json:"offset"`
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Write 一批事件一个请求，有任何一条发布失败时返回错误，导出任务下次重新发布整批
+func (p *LifecyclePublisher) Write(ctx context.Context, events []domain.NotificationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	records := make([]kafkaRecord, 0, len(events))
+	for _, e := range events {
+		value, err := proto.Marshal(newLifecycleEvent(e))
+		if err != nil {
+			return err
+		}
+		records = append(records, kafkaRecord{
+			Key:   []byte(strconv.FormatInt(e.BizID, 10)),
+			Value: value,
+		})
+	}
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return err
+	}
+	resp, err := p.do(ctx, http.MethodPost, body)
+	if err != nil {
+		kafkaEventsCounter.WithLabelValues("failed").Add(float64(len(events)))
+		return err
+	}
+	defer resp.Body.Close()
+	var res kafkaProduceResponse
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		kafkaEventsCounter.WithLabelValues("failed").Add(float64(len(events)))
+		return fmt.Errorf("解析 Kafka REST Proxy 响应失败: %w", err)
+	}
+	failed := 0
+	var firstErr string
+	for _, o := range res.Offsets {
+		if o.ErrorCode != nil || o.Error != nil {
+			failed++
+			if firstErr == "" && o.Error != nil {
+				firstErr = *o.Error
+			}
+		}
+	}
+	kafkaEventsCounter.WithLabelValues("success").Add(float64(len(events) - failed))
+	if failed > 0 {
+		kafkaEventsCounter.WithLabelValues("failed").Add(float64(failed))
+		return fmt.Errorf("%d 条事件发布到 Kafka 失败: %s", failed, firstErr)
+	}
+	return nil
+}
+
+// do 返回 2xx 的响应，其他状态码读出错误信息后返回错误
+func (p *LifecyclePublisher) do(ctx context.Context, method string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.topicURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if body != nil {
+		req.Header.Set("Content-Type", kafkaBinaryContentType)
+	}
+	if p.opts.Username != "" {
+		req.SetBasicAuth(p.opts.Username, p.opts.Password)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Kafka REST Proxy 返回 %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+func newLifecycleEvent(e domain.NotificationEvent) *notificationpb.NotificationLifecycleEvent {
+	return &notificationpb.NotificationLifecycleEvent{
+		NotificationId: e.NotificationID,
+		BizId:          e.BizID,
+		Key:            e.Key,
+		Channel:        notificationpb.Channel(notificationpb.Channel_value[e.Channel.String()]),
+		TemplateId:     e.TemplateID,
+		Status:         notificationpb.SendStatus(notificationpb.SendStatus_value[e.Status.String()]),
+		FailReason:     e.FailReason.String(),
+		Version:        int32(e.Version),
+		Ctime:          e.Ctime,
+		Utime:          e.Utime,
+		Sandbox:        e.Environment == domain.EnvironmentSandbox,
+	}
+}
@@ -0,0 +1,154 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+)
+
+// WatchBus 运维观察某个业务 key 时使用的通知变化总线
+// 观察者先注册 (bizID, key)，每个实例定期同步注册表，发送流程只发布有人观察的变化，没有观察者时不访问 Redis
+// 事件不落盘，发布订阅断开时会丢失，观察者需要定期重新查询兜底
+type WatchBus interface {
+	// Register 注册观察 (bizID, key)，ttl 之后过期，观察期间需要定期续期
+	// 当前实例立即开始发布，其他实例最多一个同步周期之后开始发布
+	Register(ctx context.Context, bizID int64, key string, ttl time.Duration) error
+	// Publish 没有人观察这个通知时直接返回
+	Publish(ctx context.Context, change domain.NotificationChange) error
+	// Subscribe 阻塞接收所有实例发布的变化，直到 ctx 被取消或者订阅断开，handle 不能阻塞
+	Subscribe(ctx context.Context, handle func(domain.NotificationChange)) error
+	// Start 定期同步注册表，阻塞运行直到 ctx 被取消
+	Start(ctx context.Context)
+}
+
+var _ WatchBus = (*redisWatchBus)(nil)
+
+// NewRedisWatchBus channel 是发布变化的 Redis 频道，注册表是 channel 加上 :registry 的有序集合，分数是过期时间
+func NewRedisWatchBus(client *redis.Client, channel string, refreshInterval time.Duration) WatchBus {
+	return &redisWatchBus{
+		client:          client,
+		channel:         channel,
+		registry:        channel + ":registry",
+		refreshInterval: refreshInterval,
+		watched:         make(map[string]int64),
+		logger:          log.Named(log.DefaultLogger(), "eventbus.watch"),
+	}
+}
+
+type redisWatchBus struct {
+	client          *redis.Client
+	channel         string
+	registry        string
+	refreshInterval time.Duration
+	logger          log.LoggerInterface
+
+	mu sync.RWMutex
+	// watched 有人观察的 bizID:key 和过期时间（毫秒）
+	watched map[string]int64
+}
+
+func watchMember(bizID int64, key string) string {
+	return strconv.FormatInt(bizID, 10) + ":" + key
+}
+
+func (b *redisWatchBus) Register(ctx context.Context, bizID int64, key string, ttl time.Duration) error {
+	member := watchMember(bizID, key)
+	expire := time.Now().Add(ttl).UnixMilli()
+	// GT 只延长过期时间，同一个 key 有多个观察者时不会被较早的续期缩短
+	if err := b.client.ZAddGT(ctx, b.registry, redis.Z{Score: float64(expire), Member: member}).Err(); err != nil {
+		return fmt.Errorf("注册观察失败: %w", err)
+	}
+	b.mu.Lock()
+	b.watched[member] = max(b.watched[member], expire)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *redisWatchBus) watching(change domain.NotificationChange) bool {
+	b.mu.RLock()
+	expire, ok := b.watched[watchMember(change.BizID, change.Key)]
+	b.mu.RUnlock()
+	return ok && expire > time.Now().UnixMilli()
+}
+
+func (b *redisWatchBus) Publish(ctx context.Context, change domain.NotificationChange) error {
+	if !b.watching(change) {
+		return nil
+	}
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+func (b *redisWatchBus) Subscribe(ctx context.Context, handle func(domain.NotificationChange)) error {
+	sub := b.client.Subscribe(ctx, b.channel)
+	defer sub.Close()
+	// 等订阅确认之后再接收，订阅失败直接返回
+	if _, err := sub.Receive(ctx); err != nil {
+		return fmt.Errorf("订阅通知变化失败: %w", err)
+	}
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case m, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("通知变化订阅已断开")
+			}
+			var change domain.NotificationChange
+			if err := json.Unmarshal([]byte(m.Payload), &change); err != nil {
+				// 格式不对的事件直接跳过，不影响后面的事件
+				continue
+			}
+			handle(change)
+		}
+	}
+}
+
+func (b *redisWatchBus) Start(ctx context.Context) {
+	ticker := time.NewTicker(b.refreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := b.refresh(ctx); err != nil && ctx.Err() == nil {
+			// 同步失败时保留上一次的注册表，已经过期的观察在 watching 里判断
+			b.logger.WithContext(ctx).Warn("同步观察注册表失败", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *redisWatchBus) refresh(ctx context.Context) error {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := b.client.ZRemRangeByScore(ctx, b.registry, "-inf", "("+now).Err(); err != nil {
+		return err
+	}
+	members, err := b.client.ZRangeByScoreWithScores(ctx, b.registry, &redis.ZRangeBy{Min: now, Max: "+inf"}).Result()
+	if err != nil {
+		return err
+	}
+	watched := make(map[string]int64, len(members))
+	for _, m := range members {
+		if member, ok := m.Member.(string); ok {
+			watched[member] = int64(m.Score)
+		}
+	}
+	b.mu.Lock()
+	b.watched = watched
+	b.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,92 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// EtcdWatcher 监听 etcd 里 prefix 下的规则，key 是 prefix 加开关名称，value 是 JSON 格式的 Rule
+// 规则变化后整体替换，删除 key 之后回到配置文件的规则；解析失败或者开关不存在的 key 记录日志后忽略
+type EtcdWatcher struct {
+	client *clientv3.Client
+	prefix string
+	flags  *Flags
+	logger log.LoggerInterface
+}
+
+func NewEtcdWatcher(client *clientv3.Client, prefix string, flags *Flags) *EtcdWatcher {
+	return &EtcdWatcher{
+		client: client,
+		prefix: prefix,
+		flags:  flags,
+		logger: log.Named(log.DefaultLogger(), "featureflag"),
+	}
+}
+
+// Start 阻塞监听，直到 ctx 被取消，监听中断时保留当前的规则，稍后重新监听
+func (w *EtcdWatcher) Start(ctx context.Context) {
+	for {
+		if err := w.watch(ctx); err != nil {
+			w.logger.WithContext(ctx).Error("监听功能开关失败，稍后重试", zap.Error(err), zap.String("prefix", w.prefix))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (w *EtcdWatcher) watch(ctx context.Context) error {
+	resp, err := w.client.Get(ctx, w.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+	w.reload(ctx, resp.Kvs)
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watch := w.client.Watch(wctx, w.prefix, clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+	for wresp := range watch {
+		if err = wresp.Err(); err != nil {
+			return err
+		}
+		// 和调度分区一样重新拉取完整列表，不会漏事件
+		resp, err = w.client.Get(ctx, w.prefix, clientv3.WithPrefix())
+		if err != nil {
+			return err
+		}
+		w.reload(ctx, resp.Kvs)
+	}
+	return nil
+}
+
+func (w *EtcdWatcher) reload(ctx context.Context, kvs []*mvccpb.KeyValue) {
+	rules := make(map[string]Rule, len(kvs))
+	for _, kv := range kvs {
+		name := strings.TrimPrefix(string(kv.Key), w.prefix)
+		if !slices.ContainsFunc(Known(), func(f Flag) bool { return f.Name == name }) {
+			w.logger.WithContext(ctx).Warn("忽略不存在的功能开关", zap.String("key", string(kv.Key)))
+			continue
+		}
+		var rule Rule
+		if err := json.Unmarshal(kv.Value, &rule); err != nil {
+			w.logger.WithContext(ctx).Warn("忽略解析失败的功能开关", zap.String("key", string(kv.Key)), zap.Error(err))
+			continue
+		}
+		if err := rule.Validate(); err != nil {
+			w.logger.WithContext(ctx).Warn("忽略不合法的功能开关", zap.String("key", string(kv.Key)), zap.Error(err))
+			continue
+		}
+		rules[name] = rule
+	}
+	w.flags.replaceWatched(rules)
+	w.logger.WithContext(ctx).Info("功能开关已更新", zap.Int("rules", len(rules)))
+}
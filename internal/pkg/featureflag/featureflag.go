@@ -0,0 +1,134 @@
+// Package featureflag 进程内的功能开关，新功能按业务方定向或者按比例逐步放量
+// 开关在代码里定义，规则来自配置文件，开启 etcd 时 etcd 里的规则覆盖配置文件
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+)
+
+// Flag 功能开关，Default 是没有配置规则时的取值
+type Flag struct {
+	Name    string
+	Default bool
+}
+
+var (
+	// ShadowRouting 按 provider.routes 的 shadow-percent 复制影子流量
+	ShadowRouting = Flag{Name: "shadow-routing", Default: true}
+	// CreateBatch 开启 create-batch 时合并这个业务方的单条创建
+	CreateBatch = Flag{Name: "create-batch", Default: true}
+	// AccessLogPayload 访问日志记录请求和响应的内容
+	AccessLogPayload = Flag{Name: "access-log-payload", Default: true}
+)
+
+// Known 代码里定义的所有开关，规则里出现其他名称时校验报错
+func Known() []Flag {
+	return []Flag{ShadowRouting, CreateBatch, AccessLogPayload}
+}
+
+// Rule 开关的放量规则，依次判断：
+// DenyBizIDs 里的业务方关闭；Enabled 时对所有业务方开启；AllowBizIDs 里的业务方开启；
+// 其余业务方按开关名称和业务方ID哈希，落在 Percent 以内的开启
+type Rule struct {
+	Enabled     bool    `json:"enabled" yaml:"enabled"`
+	Percent     int     `json:"percent" yaml:"percent"`
+	AllowBizIDs []int64 `json:"allow-biz-ids" yaml:"allow-biz-ids"`
+	DenyBizIDs  []int64 `json:"deny-biz-ids" yaml:"deny-biz-ids"`
+}
+
+// Validate 校验规则本身，不校验开关名称
+func (r Rule) Validate() error {
+	if r.Percent < 0 || r.Percent > 100 {
+		return fmt.Errorf("percent 必须在 0 到 100 之间: %d", r.Percent)
+	}
+	return nil
+}
+
+func (r Rule) evaluate(name string, bizID int64) bool {
+	if slices.Contains(r.DenyBizIDs, bizID) {
+		return false
+	}
+	if r.Enabled {
+		return true
+	}
+	if slices.Contains(r.AllowBizIDs, bizID) {
+		return true
+	}
+	return r.Percent > 0 && bucket(name, bizID) < r.Percent
+}
+
+// bucket 同一个业务方在不同开关里落在不同的桶，避免总是同一批业务方先放量
+func bucket(name string, bizID int64) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write(strconv.AppendInt(nil, bizID, 10))
+	return int(h.Sum32() % 100)
+}
+
+// Flags 所有开关当前的规则，规则整体替换，读取不加锁
+// nil 的 Flags 所有开关都取 Default
+type Flags struct {
+	static map[string]Rule
+	rules  atomic.Pointer[map[string]Rule]
+}
+
+// New static 是配置文件里的规则
+func New(static map[string]Rule) *Flags {
+	f := &Flags{static: maps.Clone(static)}
+	f.rules.Store(&f.static)
+	return f
+}
+
+// Enabled 开关对业务方是否开启
+func (f *Flags) Enabled(flag Flag, bizID int64) bool {
+	if f == nil {
+		return flag.Default
+	}
+	rule, ok := (*f.rules.Load())[flag.Name]
+	if !ok {
+		return flag.Default
+	}
+	return rule.evaluate(flag.Name, bizID)
+}
+
+// EnabledContext 开关对上下文里的业务方是否开启，上下文里没有业务方时只看 Enabled，不参与定向和比例放量
+func (f *Flags) EnabledContext(ctx context.Context, flag Flag) bool {
+	if bizID, ok := ctxkit.BizIDFromContext(ctx); ok {
+		return f.Enabled(flag, bizID)
+	}
+	if f == nil {
+		return flag.Default
+	}
+	rule, ok := (*f.rules.Load())[flag.Name]
+	if !ok {
+		return flag.Default
+	}
+	return rule.Enabled
+}
+
+// Rules 当前生效的规则，用于排查
+func (f *Flags) Rules() map[string]Rule {
+	if f == nil {
+		return nil
+	}
+	return maps.Clone(*f.rules.Load())
+}
+
+// replaceWatched 替换 etcd 里的规则，和配置文件的规则合并之后生效，同名时 etcd 的优先
+func (f *Flags) replaceWatched(watched map[string]Rule) {
+	merged := maps.Clone(f.static)
+	if merged == nil {
+		merged = make(map[string]Rule, len(watched))
+	}
+	maps.Copy(merged, watched)
+	f.rules.Store(&merged)
+}
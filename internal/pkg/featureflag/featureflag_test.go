@@ -0,0 +1,42 @@
+package featureflag
+
+import "testing"
+
+func TestFlags_Enabled(t *testing.T) {
+	var nilFlags *Flags
+	if !nilFlags.Enabled(CreateBatch, 1) {
+		t.Fatal("nil 的 Flags 应该使用默认值")
+	}
+	f := New(map[string]Rule{
+		CreateBatch.Name:   {Percent: 30, AllowBizIDs: []int64{1}, DenyBizIDs: []int64{2}},
+		ShadowRouting.Name: {Enabled: true, DenyBizIDs: []int64{3}},
+	})
+	if !f.Enabled(CreateBatch, 1) || f.Enabled(CreateBatch, 2) {
+		t.Fatal("定向的业务方没有生效")
+	}
+	if !f.Enabled(ShadowRouting, 4) || f.Enabled(ShadowRouting, 3) {
+		t.Fatal("全部开启时只有 deny-biz-ids 关闭")
+	}
+	if !f.Enabled(AccessLogPayload, 5) {
+		t.Fatal("没有配置规则的开关应该使用默认值")
+	}
+
+	enabled := 0
+	for bizID := int64(100); bizID < 10100; bizID++ {
+		if f.Enabled(CreateBatch, bizID) {
+			enabled++
+		}
+	}
+	// 哈希分桶大致均匀
+	if enabled < 2700 || enabled > 3300 {
+		t.Fatalf("30%% 放量实际开启了 %d / 10000", enabled)
+	}
+
+	f.replaceWatched(map[string]Rule{CreateBatch.Name: {}})
+	if f.Enabled(CreateBatch, 1) {
+		t.Fatal("etcd 的规则应该覆盖配置文件的规则")
+	}
+	if !f.Enabled(ShadowRouting, 4) {
+		t.Fatal("etcd 里没有的规则应该保留配置文件的规则")
+	}
+}
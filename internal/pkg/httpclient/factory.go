@@ -0,0 +1,179 @@
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "internal/pkg/httpclient"
+
+var (
+	requestCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vendor_http_requests_total",
+		Help: "Total number of outbound HTTP requests to vendors, by vendor and status code (error when no response)",
+	}, []string{"vendor", "code"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vendor_http_request_duration_seconds",
+		Help:    "Duration of outbound HTTP requests to vendors until response headers are received",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"vendor"})
+)
+
+func init() {
+	prometheus.MustRegister(requestCounter, requestDuration)
+}
+
+// Options 一个供应商的连接池和超时参数，零值使用默认值
+type Options struct {
+	// Timeout 整个请求（包括读取响应）的超时时间，调用方没有指定时使用，默认 10 秒
+	Timeout time.Duration
+	// DialTimeout 建立 TCP 连接的超时时间，默认 3 秒
+	DialTimeout time.Duration
+	// TLSHandshakeTimeout 默认 5 秒
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout 发送完请求之后等待响应头的超时时间，默认 10 秒
+	ResponseHeaderTimeout time.Duration
+	// IdleConnTimeout 空闲连接保留多久，默认 90 秒
+	IdleConnTimeout time.Duration
+	// MaxIdleConnsPerHost 每个地址最多保留的空闲连接数，默认 32
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost 每个地址最多的连接数，0 不限制
+	MaxConnsPerHost int
+	// Proxy 代理地址，例如 http://proxy.internal:3128，为空时使用 HTTP_PROXY 等环境变量
+	Proxy string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Second
+	}
+	if o.DialTimeout <= 0 {
+		o.DialTimeout = 3 * time.Second
+	}
+	if o.TLSHandshakeTimeout <= 0 {
+		o.TLSHandshakeTimeout = 5 * time.Second
+	}
+	if o.ResponseHeaderTimeout <= 0 {
+		o.ResponseHeaderTimeout = 10 * time.Second
+	}
+	if o.IdleConnTimeout <= 0 {
+		o.IdleConnTimeout = 90 * time.Second
+	}
+	if o.MaxIdleConnsPerHost <= 0 {
+		o.MaxIdleConnsPerHost = 32
+	}
+	return o
+}
+
+// Factory 按供应商创建 HTTP 客户端，同一个供应商的客户端共用一个连接池
+type Factory struct {
+	mu         sync.Mutex
+	transports map[string]*vendorTransport
+}
+
+func NewFactory() *Factory {
+	return &Factory{transports: make(map[string]*vendorTransport)}
+}
+
+type vendorTransport struct {
+	transport http.RoundTripper
+	timeout   time.Duration
+}
+
+// Client 供应商 vendor 的客户端，第一次调用时按 opts 创建连接池，之后同一个供应商复用，opts 不再生效
+// timeout 大于 0 时覆盖 Options.Timeout，不同的调用方可以使用不同的整体超时时间
+func (f *Factory) Client(vendor string, opts Options, timeout time.Duration) (*http.Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.transports[vendor]
+	if !ok {
+		var err error
+		t, err = newVendorTransport(vendor, opts.withDefaults())
+		if err != nil {
+			return nil, err
+		}
+		f.transports[vendor] = t
+	}
+	if timeout <= 0 {
+		timeout = t.timeout
+	}
+	return &http.Client{Transport: t.transport, Timeout: timeout}, nil
+}
+
+func newVendorTransport(vendor string, opts Options) (*vendorTransport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("供应商 %s 的代理地址 %q 不合法", vendor, opts.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	transport.DialContext = (&net.Dialer{
+		Timeout:   opts.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+	transport.IdleConnTimeout = opts.IdleConnTimeout
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	transport.MaxIdleConns = max(transport.MaxIdleConns, opts.MaxIdleConnsPerHost)
+	transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	return &vendorTransport{
+		transport: &instrumentedTransport{
+			vendor: vendor,
+			next:   transport,
+			tracer: otel.GetTracerProvider().Tracer(instrumentationName),
+		},
+		timeout: opts.Timeout,
+	}, nil
+}
+
+// instrumentedTransport 统计每个供应商的请求数和耗时，每个请求记录一个 client span
+// 不向供应商透传链路上下文，供应商的签名和网关可能不接受多余的请求头
+type instrumentedTransport struct {
+	vendor string
+	next   http.RoundTripper
+	tracer trace.Tracer
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// 查询参数里可能有签名和密钥，只记录路径
+	ctx, span := t.tracer.Start(req.Context(), fmt.Sprintf("HTTP %s %s", req.Method, t.vendor),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("vendor.name", t.vendor),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	requestDuration.WithLabelValues(t.vendor).Observe(time.Since(start).Seconds())
+	code := "error"
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		code = strconv.Itoa(resp.StatusCode)
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	requestCounter.WithLabelValues(t.vendor, code).Inc()
+	return resp, err
+}
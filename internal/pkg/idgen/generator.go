@@ -0,0 +1,180 @@
+package idgen
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/sony/sonyflake"
+)
+
+var (
+	ErrClockMovedBackwards = errors.New("系统时钟回拨")
+	ErrInvalidSettings     = errors.New("ID生成器配置错误")
+)
+
+// ClockSkewPolicy 时钟回拨时的处理策略
+type ClockSkewPolicy string
+
+const (
+	// ClockSkewPolicyWait 回拨幅度不超过 MaxWait 时等待时钟追上，超过则走降级或者报错
+	ClockSkewPolicyWait ClockSkewPolicy = "wait"
+	// ClockSkewPolicyFail 发现回拨直接走降级或者报错
+	ClockSkewPolicyFail ClockSkewPolicy = "fail"
+)
+
+var (
+	clockSkewCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "idgen_clock_skew_total",
+			Help: "Total number of clock regressions detected by the ID generator",
+		},
+		[]string{"action"},
+	)
+	clockSkewSeconds = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "idgen_last_clock_skew_seconds",
+			Help: "Size of the last clock regression detected by the ID generator in seconds",
+		},
+	)
+	fallbackCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "idgen_fallback_total",
+			Help: "Total number of IDs generated by the fallback generator",
+		},
+		[]string{"status"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(clockSkewCounter, clockSkewSeconds, fallbackCounter)
+}
+
+// Generator ID生成器
+type Generator interface {
+	NextID() (uint64, error)
+}
+
+// Settings 雪花ID生成器配置
+type Settings struct {
+	// Epoch 固定的起始时间，所有实例、每次重启都必须一致，否则不同进程生成的ID可能重复
+	Epoch     time.Time
+	MachineID uint16
+	Policy    ClockSkewPolicy
+	// MaxWait 等待策略下允许等待的最大回拨幅度
+	MaxWait time.Duration
+	// Fallback 无法通过雪花算法生成ID时使用的降级生成器，可以为空
+	Fallback Generator
+	// Clock 检测回拨和等待用的时间来源，为空时使用系统时钟
+	Clock clock.Clock
+}
+
+// SnowflakeGenerator 带时钟回拨保护的雪花ID生成器
+type SnowflakeGenerator struct {
+	sf       *sonyflake.Sonyflake
+	policy   ClockSkewPolicy
+	maxWait  time.Duration
+	fallback Generator
+	clock    clock.Clock
+
+	// mu 保护 lastTime，等待时钟追上时不持有
+	mu       sync.Mutex
+	lastTime time.Time
+}
+
+// NewSnowflakeGenerator 创建雪花ID生成器
+func NewSnowflakeGenerator(settings Settings) (*SnowflakeGenerator, error) {
+	if settings.Clock == nil {
+		settings.Clock = clock.Real()
+	}
+	if settings.Epoch.IsZero() || settings.Epoch.After(settings.Clock.Now()) {
+		return nil, fmt.Errorf("%w: 起始时间必须是过去的固定时间", ErrInvalidSettings)
+	}
+	switch settings.Policy {
+	case "":
+		settings.Policy = ClockSkewPolicyWait
+	case ClockSkewPolicyWait, ClockSkewPolicyFail:
+	default:
+		return nil, fmt.Errorf("%w: 不支持的时钟回拨策略 %s", ErrInvalidSettings, settings.Policy)
+	}
+	machineID := settings.MachineID
+	sf, err := sonyflake.New(sonyflake.Settings{
+		StartTime: settings.Epoch,
+		MachineID: func() (uint16, error) {
+			return machineID, nil
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+	}
+	return &SnowflakeGenerator{
+		sf:       sf,
+		policy:   settings.Policy,
+		maxWait:  settings.MaxWait,
+		fallback: settings.Fallback,
+		clock:    settings.Clock,
+	}, nil
+}
+
+// NextID 生成ID
+// sonyflake 在进程内遇到回拨会借用未来的时间片，ID 不会重复，但是会持续偏离真实时间，
+// 所以这里先检测回拨，按照策略等待或者降级
+func (g *SnowflakeGenerator) NextID() (uint64, error) {
+	if err := g.checkClock(); err != nil {
+		return g.nextFallbackID(err)
+	}
+	id, err := g.sf.NextID()
+	if err != nil {
+		return g.nextFallbackID(err)
+	}
+	return id, nil
+}
+
+// checkClock 回拨不超过 MaxWait 时不持有锁等待，其他调用方不会排队等锁，等待结束后重新检查，期间可能再次回拨
+func (g *SnowflakeGenerator) checkClock() error {
+	for {
+		wait, err := g.observeClock()
+		if err != nil || wait <= 0 {
+			return err
+		}
+		<-g.clock.NewTimer(wait).C()
+	}
+}
+
+// observeClock 没有回拨时记录当前时间，回拨时返回需要等待的时长，不能等待时返回错误
+func (g *SnowflakeGenerator) observeClock() (time.Duration, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.clock.Now()
+	if !now.Before(g.lastTime) {
+		g.lastTime = now
+		return 0, nil
+	}
+
+	skew := g.lastTime.Sub(now)
+	clockSkewSeconds.Set(skew.Seconds())
+	if g.policy == ClockSkewPolicyWait && skew <= g.maxWait {
+		clockSkewCounter.WithLabelValues("wait").Inc()
+		return skew, nil
+	}
+	clockSkewCounter.WithLabelValues("reject").Inc()
+	return 0, fmt.Errorf("%w: 回拨 %v", ErrClockMovedBackwards, skew)
+}
+
+func (g *SnowflakeGenerator) nextFallbackID(cause error) (uint64, error) {
+	if g.fallback == nil {
+		return 0, cause
+	}
+	id, err := g.fallback.NextID()
+	if err != nil {
+		fallbackCounter.WithLabelValues("error").Inc()
+		return 0, fmt.Errorf("降级生成ID失败: %w, 原因: %w", err, cause)
+	}
+	fallbackCounter.WithLabelValues("success").Inc()
+	return id, nil
+}
+
+var _ Generator = (*SnowflakeGenerator)(nil)
@@ -0,0 +1,48 @@
+package idgen
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultRedisKey = "id_generator:fallback"
+	// fallbackFlag 降级ID的标志位
+	// 雪花ID的时间戳占 39 位，以 10ms 为单位，最高位 (第 62 位) 要在起始时间之后约 87 年才会被用到，
+	// 降级ID固定设置这一位，保证不会和雪花ID重复，同时仍然是正的 int64
+	fallbackFlag = uint64(1) << 62
+	redisTimeout = time.Second
+)
+
+// RedisGenerator 基于 Redis INCR 的降级ID生成器
+// 所有实例共用同一个计数器，不依赖本地时钟
+type RedisGenerator struct {
+	client redis.Cmdable
+	key    string
+}
+
+// NewRedisGenerator 创建基于 Redis 的降级ID生成器
+func NewRedisGenerator(client redis.Cmdable, key string) *RedisGenerator {
+	if key == "" {
+		key = defaultRedisKey
+	}
+	return &RedisGenerator{
+		client: client,
+		key:    key,
+	}
+}
+
+func (g *RedisGenerator) NextID() (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	seq, err := g.client.Incr(ctx, g.key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("从Redis获取ID失败: %w", err)
+	}
+	return fallbackFlag | uint64(seq), nil
+}
+
+var _ Generator = (*RedisGenerator)(nil)
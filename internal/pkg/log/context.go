@@ -0,0 +1,45 @@
+package log
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ContextFields 从 ctx 里取出链路和请求信息，日志可以按 trace_id 和 Jaeger 里的链路对应起来
+func ContextFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []zap.Field
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		)
+	}
+	if requestID, ok := ctxkit.RequestIDFromContext(ctx); ok {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if bizID, ok := ctxkit.BizIDFromContext(ctx); ok {
+		fields = append(fields, zap.Int64("biz_id", bizID))
+	}
+	if scoped, ok := ctx.Value(fieldsKey{}).([]zap.Field); ok {
+		fields = append(without(fields, keys(scoped)), scoped...)
+	}
+	return fields
+}
+
+type fieldsKey struct{}
+
+// ContextWithFields 把字段放进 ctx，之后用这个 ctx 调用 WithContext 的日志都会带上，和已有的同名字段以后放的为准
+// 适合在处理一条通知、一个任务的入口放进 notification_id 这类字段，下游的组件不用再逐个传
+func ContextWithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	prev, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	return context.WithValue(ctx, fieldsKey{}, append(without(prev, keys(fields)), fields...))
+}
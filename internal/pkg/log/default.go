@@ -0,0 +1,73 @@
+package log
+
+import (
+	"slices"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultCore 进程默认的输出，DefaultLogger 返回的 logger 每次写日志时都从这里取
+// 启动时是标准输出的 production 配置，ioc 初始化 logger 后换成配置的级别、编码和文件输出
+var defaultCore atomic.Pointer[zapcore.Core]
+
+func init() {
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	logger, _ := config.Build(defaultLevels.Option())
+	SetDefaultCore(logger.Core())
+}
+
+// SetDefaultCore 替换进程默认的输出，之前和之后通过 DefaultLogger 创建的 logger 都会写到新的 core
+func SetDefaultCore(core zapcore.Core) {
+	defaultCore.Store(&core)
+}
+
+// DefaultLogger 没有注入 logger 的组件使用，输出和级别跟随 SetDefaultCore 设置的 core
+func DefaultLogger() LoggerInterface {
+	logger := zap.New(&swapCore{},
+		zap.AddCaller(),
+		zap.AddCallerSkip(1),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+	)
+	return &Logger{Logger: logger}
+}
+
+// swapCore 把日志转发给当前的默认 core，With 的字段先记下来，写日志时再加到当前的 core 上
+type swapCore struct {
+	fields []zapcore.Field
+}
+
+func (c *swapCore) current() zapcore.Core {
+	core := *defaultCore.Load()
+	if len(c.fields) == 0 {
+		return core
+	}
+	return core.With(c.fields)
+}
+
+func (c *swapCore) Enabled(level zapcore.Level) bool {
+	return (*defaultCore.Load()).Enabled(level)
+}
+
+func (c *swapCore) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+	return &swapCore{fields: append(slices.Clone(c.fields), fields...)}
+}
+
+func (c *swapCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.current().Check(ent, ce)
+}
+
+func (c *swapCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.current().Write(ent, fields)
+}
+
+func (c *swapCore) Sync() error {
+	return (*defaultCore.Load()).Sync()
+}
+
+var _ zapcore.Core = (*swapCore)(nil)
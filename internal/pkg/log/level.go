@@ -0,0 +1,129 @@
+package log
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels 运行时可以调整的日志级别，模块是 zap 的 logger 名称，例如 grpc.notification
+// 模块没有单独设置时按前缀继承，grpc.notification 继承 grpc，都没有时使用全局级别
+type Levels struct {
+	mu      sync.RWMutex
+	root    zapcore.Level
+	modules map[string]zapcore.Level
+	// min 全局和所有模块里最低的级别，低于它的日志直接丢弃，不用查模块
+	min atomic.Int32
+}
+
+func NewLevels(root zapcore.Level) *Levels {
+	l := &Levels{root: root, modules: make(map[string]zapcore.Level)}
+	l.min.Store(int32(root))
+	return l
+}
+
+var defaultLevels = NewLevels(zapcore.InfoLevel)
+
+// DefaultLevels 进程内所有 logger 共用的日志级别
+func DefaultLevels() *Levels {
+	return defaultLevels
+}
+
+// SetLevel module 为空时设置全局级别
+func (l *Levels) SetLevel(module string, level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if module == "" {
+		l.root = level
+	} else {
+		l.modules[module] = level
+	}
+	l.updateMin()
+}
+
+// ResetLevel 删除模块单独设置的级别，恢复继承
+func (l *Levels) ResetLevel(module string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.modules, module)
+	l.updateMin()
+}
+
+func (l *Levels) updateMin() {
+	m := l.root
+	for _, level := range l.modules {
+		m = min(m, level)
+	}
+	l.min.Store(int32(m))
+}
+
+// Snapshot 全局级别和单独设置了级别的模块
+func (l *Levels) Snapshot() (zapcore.Level, map[string]zapcore.Level) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.root, maps.Clone(l.modules)
+}
+
+// Enabled module 下 level 级别的日志是否输出
+func (l *Levels) Enabled(module string, level zapcore.Level) bool {
+	if int32(level) < l.min.Load() {
+		return false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for name := module; name != ""; {
+		if m, ok := l.modules[name]; ok {
+			return level >= m
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return level >= l.root
+}
+
+// Core 用这组级别过滤 core 的输出，core 本身的级别要设置成最低
+func (l *Levels) Core(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core, levels: l}
+}
+
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return int32(level) >= c.levels.min.Load()
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.Enabled(ent.LoggerName, ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// Named 按模块命名的 logger，模块的日志级别可以单独调整
+func Named(l LoggerInterface, module string) LoggerInterface {
+	if zl, ok := l.(*Logger); ok {
+		return &Logger{Logger: zl.Logger.Named(module), ctxFields: zl.ctxFields, bound: zl.bound}
+	}
+	return l
+}
+
+// Option 给 zap.Config.Build 使用，Build 时 zap.Config.Level 要设置成最低
+func (l *Levels) Option() zap.Option {
+	return zap.WrapCore(l.Core)
+}
+
+var _ zapcore.Core = (*levelCore)(nil)
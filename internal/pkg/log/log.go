@@ -1,30 +1,90 @@
 package log
 
 import (
+	"context"
+	"slices"
+
 	"go.uber.org/zap"
 )
 
 type LoggerInterface interface {
+	Debug(msg string, fields ...zap.Field)
 	Error(msg string, fields ...zap.Field)
 	Info(msg string, fields ...zap.Field)
 	Warn(msg string, fields ...zap.Field)
+	// With 返回带上 fields 的子 logger，之后的每条日志都有这些字段，原来的 logger 不受影响
+	With(fields ...zap.Field) LoggerInterface
+	// WithContext 带上 ctx 里的 trace_id、span_id、request_id、biz_id 和 ContextWithFields 放进去的字段，请求链路上的日志都应该使用
+	WithContext(ctx context.Context) LoggerInterface
 }
 
 var _ LoggerInterface = (*Logger)(nil)
 
 type Logger struct {
 	*zap.Logger
+	// ctxFields WithContext 取出的字段，和调用方传入的字段重名时以调用方的为准
+	ctxFields []zap.Field
+	// bound With 绑定的字段名，ctx 里的同名字段不再输出
+	bound []string
+}
+
+func (l *Logger) Debug(msg string, fields ...zap.Field) {
+	l.Logger.Debug(msg, l.fields(fields)...)
 }
 
 func (l *Logger) Error(msg string, fields ...zap.Field) {
-	l.Logger.Error(msg, fields...)
+	l.Logger.Error(msg, l.fields(fields)...)
+}
+
+func (l *Logger) Info(msg string, fields ...zap.Field) {
+	l.Logger.Info(msg, l.fields(fields)...)
 }
 
 func (l *Logger) Warn(msg string, fields ...zap.Field) {
-	l.Logger.Warn(msg, fields...)
+	l.Logger.Warn(msg, l.fields(fields)...)
+}
+
+func (l *Logger) With(fields ...zap.Field) LoggerInterface {
+	if len(fields) == 0 {
+		return l
+	}
+	bound := append(slices.Clone(l.bound), keys(fields)...)
+	return &Logger{Logger: l.Logger.With(fields...), ctxFields: without(l.ctxFields, bound), bound: bound}
+}
+
+func (l *Logger) WithContext(ctx context.Context) LoggerInterface {
+	fields := without(ContextFields(ctx), l.bound)
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{Logger: l.Logger, ctxFields: fields, bound: l.bound}
+}
+
+func (l *Logger) fields(fields []zap.Field) []zap.Field {
+	if len(l.ctxFields) == 0 {
+		return fields
+	}
+	res := make([]zap.Field, 0, len(l.ctxFields)+len(fields))
+	for _, f := range l.ctxFields {
+		if !slices.ContainsFunc(fields, func(x zap.Field) bool { return x.Key == f.Key }) {
+			res = append(res, f)
+		}
+	}
+	return append(res, fields...)
+}
+
+func keys(fields []zap.Field) []string {
+	res := make([]string, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, f.Key)
+	}
+	return res
 }
 
-func DefaultLogger() LoggerInterface {
-	logger, _ := zap.NewProduction()
-	return &Logger{Logger: logger}
+// without 去掉名字在 names 里的字段
+func without(fields []zap.Field, names []string) []zap.Field {
+	if len(names) == 0 {
+		return fields
+	}
+	return slices.DeleteFunc(slices.Clone(fields), func(f zap.Field) bool { return slices.Contains(names, f.Key) })
 }
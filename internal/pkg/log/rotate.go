@@ -0,0 +1,270 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSizeMB  = 100
+	backupTimeFormat  = "2006-01-02T15-04-05.000"
+	compressSuffix    = ".gz"
+	megabyte          = 1024 * 1024
+	defaultFileMode   = 0o644
+	defaultFolderMode = 0o755
+)
+
+// RotateOptions 日志文件的滚动参数
+type RotateOptions struct {
+	Filename string
+	// MaxSize 单个文件的最大大小（MB），超过后滚动，默认 100
+	MaxSize int
+	// MaxAge 旧文件保留的天数，0 不按时间删除
+	MaxAge int
+	// MaxBackups 旧文件保留的个数，0 不按个数删除
+	MaxBackups int
+	// Compress 旧文件是否用 gzip 压缩
+	Compress bool
+}
+
+// RotateWriter 按大小滚动的日志文件，旧文件改名为 name-时间.ext，按保留天数和个数清理
+type RotateWriter struct {
+	opts RotateOptions
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+
+	// cleanCh 滚动后通知后台清理旧文件，同一时间只有一个清理任务
+	cleanCh   chan struct{}
+	cleanOnce sync.Once
+}
+
+// NewRotateWriter 创建目录并打开日志文件，打不开时直接返回错误
+func NewRotateWriter(opts RotateOptions) (*RotateWriter, error) {
+	if opts.Filename == "" {
+		return nil, fmt.Errorf("日志文件路径不能为空")
+	}
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = defaultMaxSizeMB
+	}
+	w := &RotateWriter{opts: opts}
+	if err := w.openExisting(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		if err := w.openExisting(); err != nil {
+			return 0, err
+		}
+	}
+	if w.size+int64(len(p)) > w.maxBytes() && w.size > 0 {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *RotateWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+func (w *RotateWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closeFile()
+}
+
+// Rotate 立即滚动，可以配合 SIGHUP 或者外部的定时任务使用
+func (w *RotateWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotate()
+}
+
+func (w *RotateWriter) maxBytes() int64 {
+	return int64(w.opts.MaxSize) * megabyte
+}
+
+// openExisting 文件已经存在时追加写入
+func (w *RotateWriter) openExisting() error {
+	if err := os.MkdirAll(filepath.Dir(w.opts.Filename), defaultFolderMode); err != nil {
+		return fmt.Errorf("创建日志目录失败: %w", err)
+	}
+	f, err := os.OpenFile(w.opts.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, defaultFileMode)
+	if err != nil {
+		return fmt.Errorf("打开日志文件失败: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("打开日志文件失败: %w", err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+func (w *RotateWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *RotateWriter) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(w.opts.Filename); err == nil {
+		if err := os.Rename(w.opts.Filename, w.backupName(time.Now())); err != nil {
+			return fmt.Errorf("滚动日志文件失败: %w", err)
+		}
+	}
+	if err := w.openExisting(); err != nil {
+		return err
+	}
+	w.startClean()
+	return nil
+}
+
+func (w *RotateWriter) backupName(t time.Time) string {
+	dir := filepath.Dir(w.opts.Filename)
+	base := filepath.Base(w.opts.Filename)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext)
+	return filepath.Join(dir, fmt.Sprintf("%s-%s%s", prefix, t.Format(backupTimeFormat), ext))
+}
+
+func (w *RotateWriter) startClean() {
+	w.cleanOnce.Do(func() {
+		w.cleanCh = make(chan struct{}, 1)
+		go func() {
+			for range w.cleanCh {
+				w.clean()
+			}
+		}()
+	})
+	select {
+	case w.cleanCh <- struct{}{}:
+	default:
+	}
+}
+
+type backupFile struct {
+	path string
+	t    time.Time
+}
+
+// clean 压缩、删除旧文件，出错时跳过，下次滚动再处理
+func (w *RotateWriter) clean() {
+	backups := w.backups()
+	// 新的在前
+	slices.SortFunc(backups, func(a, b backupFile) int {
+		return b.t.Compare(a.t)
+	})
+	var remove []backupFile
+	if w.opts.MaxBackups > 0 && len(backups) > w.opts.MaxBackups {
+		remove = append(remove, backups[w.opts.MaxBackups:]...)
+		backups = backups[:w.opts.MaxBackups]
+	}
+	if w.opts.MaxAge > 0 {
+		cutoff := time.Now().Add(-time.Duration(w.opts.MaxAge) * 24 * time.Hour)
+		backups = slices.DeleteFunc(backups, func(b backupFile) bool {
+			if b.t.Before(cutoff) {
+				remove = append(remove, b)
+				return true
+			}
+			return false
+		})
+	}
+	for _, b := range remove {
+		_ = os.Remove(b.path)
+	}
+	if !w.opts.Compress {
+		return
+	}
+	for _, b := range backups {
+		if !strings.HasSuffix(b.path, compressSuffix) {
+			_ = compressFile(b.path)
+		}
+	}
+}
+
+func (w *RotateWriter) backups() []backupFile {
+	dir := filepath.Dir(w.opts.Filename)
+	base := filepath.Base(w.opts.Filename)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var res []backupFile
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name, ok := strings.CutPrefix(e.Name(), prefix)
+		if !ok {
+			continue
+		}
+		name = strings.TrimSuffix(name, compressSuffix)
+		ts, ok := strings.CutSuffix(name, ext)
+		if !ok {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, ts, time.Local)
+		if err != nil {
+			continue
+		}
+		res = append(res, backupFile{path: filepath.Join(dir, e.Name()), t: t})
+	}
+	return res
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, defaultFileMode)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err == nil {
+		err = gz.Close()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path + compressSuffix)
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,52 @@
+package machineid
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoAvailableMachineID = errors.New("没有可用的机器ID")
+	ErrNotAllocated         = errors.New("机器ID尚未分配")
+	ErrMachineIDConflict    = errors.New("机器ID被其他实例占用")
+)
+
+const (
+	defaultTTL          = 10 * time.Second
+	defaultMaxMachineID = 1023
+)
+
+// Allocator 机器ID分配器，保证同一时刻每个实例持有的机器ID全局唯一
+type Allocator interface {
+	// Allocate 分配机器ID，分配成功后会在后台续约直到 Release
+	Allocate(ctx context.Context) (uint16, error)
+	// Release 释放机器ID，应用退出时调用
+	Release(ctx context.Context) error
+	// Lost 机器ID的所有权丢失（续约失败且被其他实例抢占）时会关闭该 channel
+	Lost() <-chan struct{}
+}
+
+// Options 分配器配置
+type Options struct {
+	// Prefix 存储机器ID占用记录的 key 前缀
+	Prefix string
+	// TTL 占用记录的过期时间，实例异常退出后超过 TTL 机器ID会被回收
+	TTL time.Duration
+	// MaxMachineID 可分配的最大机器ID（包含）
+	MaxMachineID uint16
+	// Owner 占用者标识，默认使用 hostname + pid
+	Owner string
+}
+
+func (o *Options) setDefaults() {
+	if o.TTL <= 0 {
+		o.TTL = defaultTTL
+	}
+	if o.MaxMachineID == 0 {
+		o.MaxMachineID = defaultMaxMachineID
+	}
+	if o.Owner == "" {
+		o.Owner = defaultOwner()
+	}
+}
@@ -0,0 +1,197 @@
+package machineid
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const defaultEtcdPrefix = "/notification/machine-id"
+
+// EtcdAllocator 基于 etcd 租约的机器ID分配器
+// 每个机器ID对应一个 key，通过事务 CreateRevision == 0 保证只有一个实例能创建成功，
+// key 绑定租约，实例宕机后租约过期 key 自动删除，机器ID得以回收
+type EtcdAllocator struct {
+	client *clientv3.Client
+	opts   Options
+	logger log.LoggerInterface
+
+	mu        sync.Mutex
+	id        uint16
+	allocated bool
+	leaseID   clientv3.LeaseID
+	cancel    context.CancelFunc
+	lost      chan struct{}
+	lostOnce  sync.Once
+}
+
+// NewEtcdAllocator 创建基于 etcd 的机器ID分配器
+func NewEtcdAllocator(client *clientv3.Client, opts Options) *EtcdAllocator {
+	if opts.Prefix == "" {
+		opts.Prefix = defaultEtcdPrefix
+	}
+	opts.setDefaults()
+	return &EtcdAllocator{
+		client: client,
+		opts:   opts,
+		logger: log.Named(log.DefaultLogger(), "machineid"),
+		lost:   make(chan struct{}),
+	}
+}
+
+// Allocate 从 0 开始依次尝试占用机器ID
+func (a *EtcdAllocator) Allocate(ctx context.Context) (uint16, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.allocated {
+		return a.id, nil
+	}
+
+	leaseResp, err := a.client.Grant(ctx, int64(a.opts.TTL.Seconds()))
+	if err != nil {
+		return 0, fmt.Errorf("创建租约失败: %w", err)
+	}
+
+	for id := 0; id <= int(a.opts.MaxMachineID); id++ {
+		ok, err := a.tryAcquire(ctx, uint16(id), leaseResp.ID)
+		if err != nil {
+			_, _ = a.client.Revoke(context.Background(), leaseResp.ID)
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+
+		keepAliveCtx, cancel := context.WithCancel(context.Background())
+		keepAliveCh, err := a.client.KeepAlive(keepAliveCtx, leaseResp.ID)
+		if err != nil {
+			// 撤销租约，占用的 key 随之删除，机器ID不会一直被占用到租约过期
+			cancel()
+			_, _ = a.client.Revoke(context.Background(), leaseResp.ID)
+			return 0, fmt.Errorf("租约续期失败: %w", err)
+		}
+		a.id = uint16(id)
+		a.leaseID = leaseResp.ID
+		a.allocated = true
+		a.cancel = cancel
+		go a.watchKeepAlive(keepAliveCtx, keepAliveCh)
+
+		a.logger.WithContext(ctx).Info("从 etcd 分配机器ID", zap.Uint16("machine_id", a.id), zap.String("owner", a.opts.Owner))
+		return a.id, nil
+	}
+
+	_, _ = a.client.Revoke(context.Background(), leaseResp.ID)
+	return 0, fmt.Errorf("%w: 最大机器ID %d", ErrNoAvailableMachineID, a.opts.MaxMachineID)
+}
+
+func (a *EtcdAllocator) tryAcquire(ctx context.Context, id uint16, leaseID clientv3.LeaseID) (bool, error) {
+	key := a.key(id)
+	resp, err := a.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, a.opts.Owner, clientv3.WithLease(leaseID))).
+		Commit()
+	if err != nil {
+		return false, fmt.Errorf("抢占机器ID %d 失败: %w", id, err)
+	}
+	return resp.Succeeded, nil
+}
+
+// watchKeepAlive 续约中断时尝试重新占用原来的机器ID，被其他实例占用则认为发生冲突
+func (a *EtcdAllocator) watchKeepAlive(ctx context.Context, ch <-chan *clientv3.LeaseKeepAliveResponse) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ka, ok := <-ch:
+			if ok && ka != nil {
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			a.logger.WithContext(ctx).Warn("机器ID续约中断，尝试重新占用", zap.Uint16("machine_id", a.id))
+			if err := a.reclaim(ctx); err != nil {
+				a.logger.WithContext(ctx).Error("重新占用机器ID失败", zap.Uint16("machine_id", a.id), zap.Error(err))
+				a.markLost()
+				return
+			}
+			newCh, err := a.client.KeepAlive(ctx, a.leaseID)
+			if err != nil {
+				a.logger.WithContext(ctx).Error("机器ID租约续期失败", zap.Uint16("machine_id", a.id), zap.Error(err))
+				a.markLost()
+				return
+			}
+			ch = newCh
+		}
+	}
+}
+
+// reclaim 用新的租约重新占用当前机器ID，key 仍属于自己或者已经过期删除都可以重新占用
+func (a *EtcdAllocator) reclaim(ctx context.Context) error {
+	leaseResp, err := a.client.Grant(ctx, int64(a.opts.TTL.Seconds()))
+	if err != nil {
+		return err
+	}
+	key := a.key(a.id)
+	resp, err := a.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, a.opts.Owner, clientv3.WithLease(leaseResp.ID))).
+		Else(clientv3.OpGet(key)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		kvs := resp.Responses[0].GetResponseRange().GetKvs()
+		if len(kvs) == 0 || string(kvs[0].Value) != a.opts.Owner {
+			_, _ = a.client.Revoke(ctx, leaseResp.ID)
+			return ErrMachineIDConflict
+		}
+		// 依旧是自己持有，换绑到新租约
+		if _, err = a.client.Put(ctx, key, a.opts.Owner, clientv3.WithLease(leaseResp.ID)); err != nil {
+			return err
+		}
+	}
+	a.mu.Lock()
+	a.leaseID = leaseResp.ID
+	a.mu.Unlock()
+	return nil
+}
+
+// Release 撤销租约，机器ID对应的 key 随之删除
+func (a *EtcdAllocator) Release(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.allocated {
+		return ErrNotAllocated
+	}
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.allocated = false
+	if _, err := a.client.Revoke(ctx, a.leaseID); err != nil {
+		return fmt.Errorf("释放机器ID %d 失败: %w", a.id, err)
+	}
+	a.logger.WithContext(ctx).Info("释放机器ID", zap.Uint16("machine_id", a.id))
+	return nil
+}
+
+func (a *EtcdAllocator) Lost() <-chan struct{} {
+	return a.lost
+}
+
+func (a *EtcdAllocator) markLost() {
+	a.lostOnce.Do(func() {
+		close(a.lost)
+	})
+}
+
+func (a *EtcdAllocator) key(id uint16) string {
+	return fmt.Sprintf("%s/%d", a.opts.Prefix, id)
+}
+
+var _ Allocator = (*EtcdAllocator)(nil)
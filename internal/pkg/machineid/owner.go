@@ -0,0 +1,14 @@
+package machineid
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// defaultOwner 生成实例标识，带上随机串避免容器内 hostname、pid 相同
+func defaultOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.NewString()[:8])
+}
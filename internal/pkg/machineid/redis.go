@@ -0,0 +1,161 @@
+package machineid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+)
+
+const defaultRedisPrefix = "machine_id"
+
+var (
+	// 只有自己持有时才续期
+	luaRenew = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("pexpire", KEYS[1], ARGV[2]) else return 0 end`
+	// 只有自己持有时才删除
+	luaRelease = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+)
+
+// RedisAllocator 基于 Redis 的机器ID分配器
+// 通过 INCR 计数器决定起始探测位置，SET NX 带过期时间占用机器ID，后台定期续期
+type RedisAllocator struct {
+	client *redis.Client
+	opts   Options
+	logger log.LoggerInterface
+
+	mu        sync.Mutex
+	id        uint16
+	allocated bool
+	cancel    context.CancelFunc
+	lost      chan struct{}
+	lostOnce  sync.Once
+}
+
+// NewRedisAllocator 创建基于 Redis 的机器ID分配器
+func NewRedisAllocator(client *redis.Client, opts Options) *RedisAllocator {
+	if opts.Prefix == "" {
+		opts.Prefix = defaultRedisPrefix
+	}
+	opts.setDefaults()
+	return &RedisAllocator{
+		client: client,
+		opts:   opts,
+		logger: log.Named(log.DefaultLogger(), "machineid"),
+		lost:   make(chan struct{}),
+	}
+}
+
+func (a *RedisAllocator) Allocate(ctx context.Context) (uint16, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.allocated {
+		return a.id, nil
+	}
+
+	total := int64(a.opts.MaxMachineID) + 1
+	seq, err := a.client.Incr(ctx, a.opts.Prefix+":seq").Result()
+	if err != nil {
+		return 0, fmt.Errorf("获取机器ID序列失败: %w", err)
+	}
+	// 从计数器位置开始探测一圈，避免所有实例都从 0 开始争抢
+	for i := int64(0); i < total; i++ {
+		id := uint16((seq + i) % total)
+		ok, err := a.client.SetNX(ctx, a.key(id), a.opts.Owner, a.opts.TTL).Result()
+		if err != nil {
+			return 0, fmt.Errorf("抢占机器ID %d 失败: %w", id, err)
+		}
+		if !ok {
+			continue
+		}
+		a.id = id
+		a.allocated = true
+		renewCtx, cancel := context.WithCancel(context.Background())
+		a.cancel = cancel
+		go a.renewLoop(renewCtx)
+		a.logger.WithContext(ctx).Info("从 Redis 分配机器ID", zap.Uint16("machine_id", a.id), zap.String("owner", a.opts.Owner))
+		return a.id, nil
+	}
+	return 0, fmt.Errorf("%w: 最大机器ID %d", ErrNoAvailableMachineID, a.opts.MaxMachineID)
+}
+
+// renewLoop 每 TTL/3 续期一次，续期失败说明 key 已过期，尝试重新占用
+func (a *RedisAllocator) renewLoop(ctx context.Context) {
+	const renewDivisor = 3
+	ticker := time.NewTicker(a.opts.TTL / renewDivisor)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.renew(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				a.logger.WithContext(ctx).Error("机器ID续期失败", zap.Uint16("machine_id", a.id), zap.Error(err))
+				if errors.Is(err, ErrMachineIDConflict) {
+					a.markLost()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (a *RedisAllocator) renew(ctx context.Context) error {
+	key := a.key(a.id)
+	res, err := a.client.Eval(ctx, luaRenew, []string{key}, a.opts.Owner, a.opts.TTL.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if res == 1 {
+		return nil
+	}
+	// key 已经过期，尝试重新占用
+	ok, err := a.client.SetNX(ctx, key, a.opts.Owner, a.opts.TTL).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrMachineIDConflict
+	}
+	return nil
+}
+
+func (a *RedisAllocator) Release(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.allocated {
+		return ErrNotAllocated
+	}
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.allocated = false
+	if err := a.client.Eval(ctx, luaRelease, []string{a.key(a.id)}, a.opts.Owner).Err(); err != nil {
+		return fmt.Errorf("释放机器ID %d 失败: %w", a.id, err)
+	}
+	a.logger.WithContext(ctx).Info("释放机器ID", zap.Uint16("machine_id", a.id))
+	return nil
+}
+
+func (a *RedisAllocator) Lost() <-chan struct{} {
+	return a.lost
+}
+
+func (a *RedisAllocator) markLost() {
+	a.lostOnce.Do(func() {
+		close(a.lost)
+	})
+}
+
+func (a *RedisAllocator) key(id uint16) string {
+	return fmt.Sprintf("%s:%d", a.opts.Prefix, id)
+}
+
+var _ Allocator = (*RedisAllocator)(nil)
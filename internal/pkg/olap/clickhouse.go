@@ -0,0 +1,178 @@
+// Package olap 把通知生命周期事件导出到分析库
+package olap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// Sink 分析库，导出任务保证至少一次，同一个事件可能重复写入，实现方需要能去重
+type Sink interface {
+	// EnsureSchema 建表并补齐缺少的字段，启动导出之前调用
+	EnsureSchema(ctx context.Context) error
+	Write(ctx context.Context, events []domain.NotificationEvent) error
+}
+
+// column ClickHouse 表字段，新增字段只能加在末尾，EnsureSchema 会给已有的表补上
+type column struct {
+	name string
+	typ  string
+}
+
+var columns = []column{
+	{name: "notification_id", typ: "UInt64"},
+	{name: "biz_id", typ: "Int64"},
+	{name: "key", typ: "String"},
+	{name: "channel", typ: "LowCardinality(String)"},
+	{name: "template_id", typ: "Int64"},
+	{name: "status", typ: "LowCardinality(String)"},
+	{name: "fail_reason", typ: "LowCardinality(String)"},
+	{name: "version", typ: "Int32"},
+	{name: "ctime", typ: "DateTime64(3, 'UTC')"},
+	{name: "utime", typ: "DateTime64(3, 'UTC')"},
+	{name: "environment", typ: "LowCardinality(String)"},
+}
+
+// clickHouseTimeLayout JSONEachRow 写入 DateTime64 使用的格式
+const clickHouseTimeLayout = "2006-01-02 15:04:05.000"
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ClickHouseOptions 通过 HTTP 接口访问 ClickHouse
+type ClickHouseOptions struct {
+	// Endpoint HTTP 接口地址，比如 http://localhost:8123
+	Endpoint string
+	Database string
+	Table    string
+	Username string
+	Password string
+}
+
+var _ Sink = (*clickHouseSink)(nil)
+
+// NewClickHouseSink 表使用 ReplacingMergeTree，重复导出的同一个事件在合并时去重
+func NewClickHouseSink(httpClient *http.Client, opts ClickHouseOptions) (Sink, error) {
+	if opts.Endpoint == "" {
+		return nil, fmt.Errorf("%w: ClickHouse 地址不能为空", domain.ErrInvalidParameter)
+	}
+	for _, name := range []string{opts.Database, opts.Table} {
+		if !identifierPattern.MatchString(name) {
+			return nil, fmt.Errorf("%w: ClickHouse 库名或表名 %q 不合法", domain.ErrInvalidParameter, name)
+		}
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	return &clickHouseSink{
+		httpClient: httpClient,
+		opts:       opts,
+		table:      opts.Database + "." + opts.Table,
+	}, nil
+}
+
+type clickHouseSink struct {
+	httpClient *http.Client
+	opts       ClickHouseOptions
+	table      string
+}
+
+func (s *clickHouseSink) EnsureSchema(ctx context.Context) error {
+	defs := make([]string, 0, len(columns))
+	for _, c := range columns {
+		defs = append(defs, fmt.Sprintf("`%s` %s", c.name, c.typ))
+	}
+	statements := []string{
+		fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", s.opts.Database),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s) ENGINE = ReplacingMergeTree "+
+			"PARTITION BY toYYYYMM(utime) ORDER BY (biz_id, notification_id, version, utime)",
+			s.table, strings.Join(defs, ", ")),
+	}
+	for _, c := range columns {
+		statements = append(statements,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS `%s` %s", s.table, c.name, c.typ))
+	}
+	for _, stmt := range statements {
+		if err := s.exec(ctx, stmt, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type eventRow struct {
+	NotificationID uint64 `json:"notification_id"`
+	BizID          int64  `json:"biz_id"`
+	Key            string `json:"key"`
+	Channel        string `json:"channel"`
+	TemplateID     int64  `json:"template_id"`
+	Status         string `json:"status"`
+	FailReason     string `json:"fail_reason"`
+	Version        int    `json:"version"`
+	Ctime          string `json:"ctime"`
+	Utime          string `json:"utime"`
+	Environment    string `json:"environment"`
+}
+
+func (s *clickHouseSink) Write(ctx context.Context, events []domain.NotificationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range events {
+		err := enc.Encode(eventRow{
+			NotificationID: e.NotificationID,
+			BizID:          e.BizID,
+			Key:            e.Key,
+			Channel:        e.Channel.String(),
+			TemplateID:     e.TemplateID,
+			Status:         e.Status.String(),
+			FailReason:     e.FailReason.String(),
+			Version:        e.Version,
+			Ctime:          time.UnixMilli(e.Ctime).UTC().Format(clickHouseTimeLayout),
+			Utime:          time.UnixMilli(e.Utime).UTC().Format(clickHouseTimeLayout),
+			Environment:    e.Environment.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return s.exec(ctx, fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", s.table), &body)
+}
+
+// exec 没有请求体时 SQL 放在请求体里，有请求体时 SQL 放在 query 参数里
+func (s *clickHouseSink) exec(ctx context.Context, query string, data io.Reader) error {
+	endpoint := s.opts.Endpoint
+	if data == nil {
+		data = strings.NewReader(query)
+	} else {
+		endpoint += "/?" + url.Values{"query": {query}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, data)
+	if err != nil {
+		return err
+	}
+	if s.opts.Username != "" {
+		req.Header.Set("X-ClickHouse-User", s.opts.Username)
+		req.Header.Set("X-ClickHouse-Key", s.opts.Password)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("ClickHouse 返回 %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
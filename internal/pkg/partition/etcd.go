@@ -0,0 +1,168 @@
+package partition
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const defaultTTL = 10 * time.Second
+
+var errLeaseExpired = errors.New("调度分区租约失效")
+
+// Membership 调度实例的分区成员关系
+type Membership interface {
+	// Start 加入集群并监听成员变化，阻塞运行直到 ctx 被取消，退出时主动离开集群
+	Start(ctx context.Context)
+	// Partition 当前实例负责的分区，还没有加入集群时 ok 为 false，此时不应该调度
+	Partition() (p domain.Partition, ok bool)
+}
+
+var _ Membership = (*etcdMembership)(nil)
+
+// etcdMembership 实例在 prefix 下注册带租约的 key，所有实例按照 instanceID 排序，
+// 第 i 个实例负责分区 i，分区总数就是实例数。实例加入或者离开时（租约过期）重新计算。
+// 重新分配的瞬间不同实例看到的成员列表可能不一致，会有短暂的重复或者遗漏，
+// 重复由发送前 PENDING 到 SENDING 的状态 CAS 兜底，遗漏的通知下一轮扫描会补上
+type etcdMembership struct {
+	client     *clientv3.Client
+	prefix     string
+	instanceID string
+	ttl        time.Duration
+	logger     log.LoggerInterface
+
+	mu        sync.RWMutex
+	partition domain.Partition
+	joined    bool
+}
+
+// NewEtcdMembership ttl 是租约时长，实例宕机后最多 ttl 时间完成重新分配，小于等于 0 使用默认值
+func NewEtcdMembership(client *clientv3.Client, prefix, instanceID string, ttl time.Duration) Membership {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &etcdMembership{
+		client:     client,
+		prefix:     prefix,
+		instanceID: instanceID,
+		ttl:        ttl,
+		logger:     log.Named(log.DefaultLogger(), "partition"),
+	}
+}
+
+func (m *etcdMembership) Partition() (domain.Partition, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.partition, m.joined
+}
+
+func (m *etcdMembership) Start(ctx context.Context) {
+	for {
+		if err := m.session(ctx); err != nil {
+			m.logger.WithContext(ctx).Error("调度分区成员关系异常，稍后重新加入", zap.Error(err),
+				zap.String("instance", m.instanceID))
+		}
+		m.setPartition(domain.Partition{}, false)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// session 一次注册的生命周期，租约失效或者监听中断时返回，由 Start 重新注册
+func (m *etcdMembership) session(ctx context.Context) error {
+	lease, err := m.client.Grant(ctx, int64(m.ttl/time.Second))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// ctx 可能已经取消，用独立的超时时间主动离开，其他实例不用等到租约过期
+		rctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer cancel()
+		_, _ = m.client.Revoke(rctx, lease.ID)
+	}()
+
+	if _, err = m.client.Put(ctx, m.prefix+m.instanceID, m.instanceID, clientv3.WithLease(lease.ID)); err != nil {
+		return err
+	}
+	keepAlive, err := m.client.KeepAlive(ctx, lease.ID)
+	if err != nil {
+		return err
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	resp, err := m.client.Get(ctx, m.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+	m.rebalance(resp.Kvs)
+	watch := m.client.Watch(wctx, m.prefix, clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-keepAlive:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errLeaseExpired
+			}
+		case wresp, ok := <-watch:
+			if !ok {
+				return nil
+			}
+			if err = wresp.Err(); err != nil {
+				return err
+			}
+			// 不根据事件增量计算，直接重新拉取完整列表，逻辑简单也不会漏事件
+			resp, err = m.client.Get(ctx, m.prefix, clientv3.WithPrefix())
+			if err != nil {
+				return err
+			}
+			m.rebalance(resp.Kvs)
+		}
+	}
+}
+
+func (m *etcdMembership) rebalance(kvs []*mvccpb.KeyValue) {
+	members := make([]string, 0, len(kvs))
+	for _, kv := range kvs {
+		members = append(members, strings.TrimPrefix(string(kv.Key), m.prefix))
+	}
+	slices.Sort(members)
+	slot := slices.Index(members, m.instanceID)
+	if slot < 0 {
+		// 自己的 key 不在了，说明租约已经失效，等 keepAlive 通道关闭后重新注册
+		m.setPartition(domain.Partition{}, false)
+		return
+	}
+	p := domain.Partition{Slot: slot, Total: len(members)}
+	if old, joined := m.Partition(); !joined || old != p {
+		m.logger.Info("调度分区重新分配", zap.String("instance", m.instanceID),
+			zap.Int("slot", p.Slot), zap.Int("total", p.Total))
+	}
+	m.setPartition(p, true)
+}
+
+func (m *etcdMembership) setPartition(p domain.Partition, joined bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.partition, m.joined = p, joined
+}
@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// TimezoneLookup 查询接收者所在的时区，业务方的用户资料服务提供
+type TimezoneLookup interface {
+	// Timezones 返回接收者到 IANA 时区名称，查不到的接收者不在结果里
+	Timezones(ctx context.Context, bizID int64, receivers []string) (map[string]string, error)
+}
+
+var (
+	_ TimezoneLookup = (*httpTimezoneLookup)(nil)
+	_ TimezoneLookup = nopTimezoneLookup{}
+)
+
+// NewHTTPTimezoneLookup POST {"bizId": 1, "receivers": [...]} 到 url，返回 {"timezones": {"接收者": "时区"}}
+func NewHTTPTimezoneLookup(httpClient *http.Client, url string) TimezoneLookup {
+	return &httpTimezoneLookup{
+		httpClient: httpClient,
+		url:        url,
+	}
+}
+
+type httpTimezoneLookup struct {
+	httpClient *http.Client
+	url        string
+}
+
+type timezoneRequest struct {
+	BizID     int64    `json:"bizId"`
+	Receivers []string `json:"receivers"`
+}
+
+type timezoneResponse struct {
+	Timezones map[string]string `json:"timezones"`
+}
+
+func (l *httpTimezoneLookup) Timezones(ctx context.Context, bizID int64, receivers []string) (map[string]string, error) {
+	body, _ := json.Marshal(timezoneRequest{BizID: bizID, Receivers: receivers})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("时区查询返回 %d", resp.StatusCode)
+	}
+	var res timezoneResponse
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("解析时区查询结果失败: %w", err)
+	}
+	return res.Timezones, nil
+}
+
+// NewNopTimezoneLookup 没有配置用户资料服务时使用，什么也查不到
+func NewNopTimezoneLookup() TimezoneLookup {
+	return nopTimezoneLookup{}
+}
+
+type nopTimezoneLookup struct{}
+
+func (nopTimezoneLookup) Timezones(context.Context, int64, []string) (map[string]string, error) {
+	return nil, nil
+}
@@ -198,5 +198,45 @@ func (h *Hook) DialHook(next redis.DialHook) redis.DialHook {
 // WithMetrics 为Redis客户端添加指标收集功能
 func WithMetrics(client *redis.Client) *redis.Client {
 	client.AddHook(NewMetricsHook())
+	// 同一个进程只统计第一个客户端的连接池，压测时用来判断连接池是否打满
+	_ = prometheus.Register(newPoolCollector(client))
 	return client
 }
+
+// poolCollector 采集时读取连接池统计
+type poolCollector struct {
+	client   *redis.Client
+	total    *prometheus.Desc
+	idle     *prometheus.Desc
+	hits     *prometheus.Desc
+	misses   *prometheus.Desc
+	timeouts *prometheus.Desc
+}
+
+func newPoolCollector(client *redis.Client) *poolCollector {
+	return &poolCollector{
+		client:   client,
+		total:    prometheus.NewDesc("redis_pool_connections", "Number of connections in the Redis pool", nil, nil),
+		idle:     prometheus.NewDesc("redis_pool_idle_connections", "Number of idle connections in the Redis pool", nil, nil),
+		hits:     prometheus.NewDesc("redis_pool_hits_total", "Number of times a free connection was found in the pool", nil, nil),
+		misses:   prometheus.NewDesc("redis_pool_misses_total", "Number of times a free connection was not found in the pool", nil, nil),
+		timeouts: prometheus.NewDesc("redis_pool_timeouts_total", "Number of times waiting for a pool connection timed out", nil, nil),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.total
+	ch <- c.idle
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(s.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(s.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(s.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(s.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(s.Timeouts))
+}
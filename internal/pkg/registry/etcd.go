@@ -19,6 +19,8 @@ type EtcdRegistry struct {
 	registered  map[string]*ServiceInfo // 已注册的服务
 	closeOnce   sync.Once
 	closeCh     chan struct{}
+	// requestTimeout 调用方没有设置截止时间时每个 etcd 请求的超时时间，0 不限制
+	requestTimeout time.Duration
 }
 
 // EtcdConfig etcd 注册器配置
@@ -28,40 +30,65 @@ type EtcdConfig struct {
 	Username    string        // 认证用户名（可选）
 	Password    string        // 认证密码（可选）
 	Namespace   string        // 服务命名空间前缀，默认 "/services"
+	// TLS 为 nil 时不使用 TLS
+	TLS *EtcdTLSConfig
+	// AutoSyncInterval 定期从集群同步最新的端点列表，0 不同步
+	AutoSyncInterval time.Duration
+	// KeepaliveTime 连接空闲这么久后发送 ping，0 不发送
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout 发送 ping 后等待这么久没有响应就关闭连接
+	KeepaliveTimeout time.Duration
+	// RequestTimeout 每个 etcd 请求的超时时间，0 不限制
+	RequestTimeout time.Duration
+}
+
+// Option 注册器选项
+type Option func(r *EtcdRegistry)
+
+// WithRequestTimeout 调用方没有设置截止时间时每个 etcd 请求的超时时间
+func WithRequestTimeout(d time.Duration) Option {
+	return func(r *EtcdRegistry) {
+		r.requestTimeout = d
+	}
 }
 
 // NewEtcdRegistry 创建 etcd 服务注册器
-func NewEtcdRegistry(client *clientv3.Client) *EtcdRegistry {
-	return &EtcdRegistry{
+func NewEtcdRegistry(client *clientv3.Client, opts ...Option) *EtcdRegistry {
+	r := &EtcdRegistry{
 		client:     client,
 		registered: make(map[string]*ServiceInfo),
 		closeCh:    make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // NewEtcdRegistryWithConfig 通过配置创建 etcd 服务注册器
 func NewEtcdRegistryWithConfig(cfg *EtcdConfig) (*EtcdRegistry, error) {
-	if cfg.DialTimeout == 0 {
-		cfg.DialTimeout = 5 * time.Second
-	}
-
-	client, err := clientv3.New(clientv3.Config{
-		Endpoints:   cfg.Endpoints,
-		DialTimeout: cfg.DialTimeout,
-		Username:    cfg.Username,
-		Password:    cfg.Password,
-	})
+	client, err := NewEtcdClient(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create etcd client: %w", err)
+		return nil, err
 	}
 
-	return NewEtcdRegistry(client), nil
+	return NewEtcdRegistry(client, WithRequestTimeout(cfg.RequestTimeout)), nil
+}
+
+// requestContext 调用方没有设置截止时间时加上 requestTimeout
+func (r *EtcdRegistry) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || r.requestTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, r.requestTimeout)
 }
 
 // Register 注册服务
 func (r *EtcdRegistry) Register(ctx context.Context, info *ServiceInfo) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	ctx, cancel := r.requestContext(ctx)
+	defer cancel()
 
 	// 设置默认值
 	if info.TTL == 0 {
@@ -111,6 +138,8 @@ func (r *EtcdRegistry) Register(ctx context.Context, info *ServiceInfo) error {
 func (r *EtcdRegistry) Deregister(ctx context.Context, info *ServiceInfo) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	ctx, cancel := r.requestContext(ctx)
+	defer cancel()
 
 	serviceKey := r.buildServiceKey(info)
 
@@ -167,6 +196,8 @@ func (r *EtcdRegistry) Close() error {
 
 // GetService 获取服务地址
 func (r *EtcdRegistry) GetService(ctx context.Context, name string) (string, error) {
+	ctx, cancel := r.requestContext(ctx)
+	defer cancel()
 	key := fmt.Sprintf("/services/%s", name)
 	resp, err := r.client.Get(ctx, key, clientv3.WithPrefix(), clientv3.WithLimit(1))
 	if err != nil {
@@ -182,6 +213,8 @@ func (r *EtcdRegistry) GetService(ctx context.Context, name string) (string, err
 
 // GetServiceList 获取服务的所有实例
 func (r *EtcdRegistry) GetServiceList(ctx context.Context, name string) ([]string, error) {
+	ctx, cancel := r.requestContext(ctx)
+	defer cancel()
 	key := fmt.Sprintf("/services/%s", name)
 	resp, err := r.client.Get(ctx, key, clientv3.WithPrefix())
 	if err != nil {
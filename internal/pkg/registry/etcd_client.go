@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// EtcdTLSConfig etcd 的 TLS 配置，CertFile 和 KeyFile 都不为空时使用客户端证书认证
+type EtcdTLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// CAFile 校验 etcd 服务端证书的 CA，为空使用系统 CA
+	CAFile string
+	// ServerName 校验服务端证书的名称，为空使用端点的主机名
+	ServerName string
+}
+
+// NewEtcdClient 按配置创建 etcd 客户端，注册器和服务发现共用
+func NewEtcdClient(cfg *EtcdConfig) (*clientv3.Client, error) {
+	if cfg.DialTimeout == 0 {
+		cfg.DialTimeout = 5 * time.Second
+	}
+	clientConf := clientv3.Config{
+		Endpoints:            cfg.Endpoints,
+		DialTimeout:          cfg.DialTimeout,
+		Username:             cfg.Username,
+		Password:             cfg.Password,
+		AutoSyncInterval:     cfg.AutoSyncInterval,
+		DialKeepAliveTime:    cfg.KeepaliveTime,
+		DialKeepAliveTimeout: cfg.KeepaliveTimeout,
+		PermitWithoutStream:  cfg.KeepaliveTime > 0,
+	}
+	if cfg.TLS != nil {
+		tlsConf, err := newEtcdTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		clientConf.TLS = tlsConf
+	}
+	client, err := clientv3.New(clientConf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create etcd client: %w", err)
+	}
+	return client, nil
+}
+
+func newEtcdTLSConfig(c *EtcdTLSConfig) (*tls.Config, error) {
+	tlsConf := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: c.ServerName}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load etcd client certificate: %w", err)
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read etcd CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificate in etcd CA file %s", c.CAFile)
+		}
+		tlsConf.RootCAs = pool
+	}
+	return tlsConf, nil
+}
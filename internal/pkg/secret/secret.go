@@ -0,0 +1,75 @@
+// Package secret 解析配置里的密钥引用，密码、供应商密钥这类配置不用明文写在配置文件里
+//
+// 引用的格式是 ${scheme:ref}，可以是整个配置值，也可以嵌在配置值里，例如
+//
+//	redis:
+//	  password: ${env:REDIS_PASSWORD}
+//	database:
+//	  dsn: root:${vault:notification/mysql#password}@tcp(localhost:3306)/notification
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	SchemeEnv   = "env"
+	SchemeVault = "vault"
+)
+
+// ErrSecretNotFound 引用的密钥不存在
+var ErrSecretNotFound = errors.New("密钥不存在")
+
+// Resolver 按引用读取密钥，引用的格式由实现决定
+type Resolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// Resolvers 按 scheme 选择 Resolver
+type Resolvers map[string]Resolver
+
+var refPattern = regexp.MustCompile(`\$\{([a-z]+):([^}]+)\}`)
+
+// HasRef s 里是否有密钥引用
+func HasRef(s string) bool {
+	return refPattern.MatchString(s)
+}
+
+// Expand 把 s 里的所有密钥引用替换成密钥，没有引用时原样返回
+func (rs Resolvers) Expand(ctx context.Context, s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var errs []error
+	expanded := refPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := refPattern.FindStringSubmatch(m)
+		r, ok := rs[sub[1]]
+		if !ok {
+			errs = append(errs, fmt.Errorf("不支持的密钥引用 %s", m))
+			return m
+		}
+		v, err := r.Resolve(ctx, sub[2])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("解析密钥引用 %s 失败: %w", m, err))
+			return m
+		}
+		return v
+	})
+	return expanded, errors.Join(errs...)
+}
+
+// EnvResolver 从环境变量读取密钥，引用是环境变量名
+type EnvResolver struct{}
+
+func (EnvResolver) Resolve(_ context.Context, ref string) (string, error) {
+	v, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("%w: 环境变量 %s 没有设置", ErrSecretNotFound, ref)
+	}
+	return v, nil
+}
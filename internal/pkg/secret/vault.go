@@ -0,0 +1,179 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultVaultMount    = "secret"
+	defaultVaultCacheTTL = 5 * time.Minute
+)
+
+// VaultOptions HashiCorp Vault KV 引擎的访问参数
+type VaultOptions struct {
+	// Addr 例如 https://vault.internal:8200
+	Addr  string
+	Token string
+	// Mount KV 引擎挂载路径，默认 secret
+	Mount string
+	// KVVersion KV 引擎版本，1 或 2，默认 2
+	KVVersion int
+	// CacheTTL 同一个路径的密钥缓存多久，默认 5m
+	CacheTTL time.Duration
+	// RenewInterval 令牌续期间隔，0 不续期
+	RenewInterval time.Duration
+}
+
+// VaultResolver 从 Vault KV 引擎读取密钥，引用格式是 path#field，例如 notification/mysql#password
+// 同一个路径下的所有字段一次读取并缓存，多个配置引用同一个路径时只请求一次
+type VaultResolver struct {
+	client *http.Client
+	opts   VaultOptions
+
+	mu    sync.Mutex
+	cache map[string]vaultEntry
+}
+
+type vaultEntry struct {
+	data    map[string]string
+	expires time.Time
+}
+
+func NewVaultResolver(client *http.Client, opts VaultOptions) *VaultResolver {
+	if opts.Mount == "" {
+		opts.Mount = defaultVaultMount
+	}
+	if opts.KVVersion == 0 {
+		opts.KVVersion = 2
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = defaultVaultCacheTTL
+	}
+	opts.Addr = strings.TrimSuffix(opts.Addr, "/")
+	return &VaultResolver{
+		client: client,
+		opts:   opts,
+		cache:  make(map[string]vaultEntry),
+	}
+}
+
+func (r *VaultResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || field == "" {
+		return "", fmt.Errorf("vault 引用的格式是 path#field: %q", ref)
+	}
+	data, err := r.read(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	v, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("%w: vault 路径 %s 没有字段 %s", ErrSecretNotFound, path, field)
+	}
+	return v, nil
+}
+
+func (r *VaultResolver) read(ctx context.Context, path string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.cache[path]; ok && time.Now().Before(e.expires) {
+		return e.data, nil
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/%s", r.opts.Addr, r.opts.Mount, strings.TrimPrefix(path, "/"))
+	if r.opts.KVVersion == 2 {
+		url = fmt.Sprintf("%s/v1/%s/data/%s", r.opts.Addr, r.opts.Mount, strings.TrimPrefix(path, "/"))
+	}
+	var resp struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := r.do(ctx, http.MethodGet, url, &resp); err != nil {
+		return nil, fmt.Errorf("读取 vault 路径 %s 失败: %w", path, err)
+	}
+	raw := resp.Data
+	// KV v2 的字段在 data.data 里，外层还有版本信息
+	if r.opts.KVVersion == 2 {
+		var inner struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &inner); err != nil {
+			return nil, fmt.Errorf("解析 vault 路径 %s 失败: %w", path, err)
+		}
+		raw = inner.Data
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("解析 vault 路径 %s 失败: %w", path, err)
+	}
+	data := make(map[string]string, len(fields))
+	for k, v := range fields {
+		if s, ok := v.(string); ok {
+			data[k] = s
+		} else {
+			data[k] = fmt.Sprint(v)
+		}
+	}
+	r.cache[path] = vaultEntry{data: data, expires: time.Now().Add(r.opts.CacheTTL)}
+	return data, nil
+}
+
+// StartRenewal 后台按间隔续期令牌，ctx 取消时停止，续期失败只记录日志，下一轮重试
+func (r *VaultResolver) StartRenewal(ctx context.Context) {
+	if r.opts.RenewInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(r.opts.RenewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.renew(ctx); err != nil {
+					log.Printf("[Secret] Failed to renew vault token: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (r *VaultResolver) renew(ctx context.Context) error {
+	var resp struct {
+		Auth struct {
+			LeaseDuration int `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := r.do(ctx, http.MethodPost, r.opts.Addr+"/v1/auth/token/renew-self", &resp); err != nil {
+		return err
+	}
+	log.Printf("[Secret] Renewed vault token, lease %ds", resp.Auth.LeaseDuration)
+	return nil
+}
+
+func (r *VaultResolver) do(ctx context.Context, method, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", r.opts.Token)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrSecretNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("vault 返回状态码 %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
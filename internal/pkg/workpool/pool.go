@@ -0,0 +1,187 @@
+package workpool
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var ErrPoolClosed = errors.New("协程池已关闭")
+
+var (
+	queueDepthGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "workpool_queue_depth",
+			Help: "Number of tasks waiting in the pool queue",
+		},
+		[]string{"pool"},
+	)
+	queueCapacityGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "workpool_queue_capacity",
+			Help: "Capacity of the pool queue",
+		},
+		[]string{"pool"},
+	)
+	workersGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "workpool_workers",
+			Help: "Number of workers in the pool",
+		},
+		[]string{"pool"},
+	)
+	busyWorkersGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "workpool_busy_workers",
+			Help: "Number of workers currently running a task",
+		},
+		[]string{"pool"},
+	)
+	waitHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "workpool_task_wait_seconds",
+			Help:    "Time a task spent in the queue before a worker picked it up",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"pool"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(queueDepthGauge, queueCapacityGauge, workersGauge, busyWorkersGauge, waitHistogram)
+}
+
+// Task 任务，ctx 是提交时的 ctx 去掉取消信号之后的结果，任务一旦入队就会执行完
+type Task func(ctx context.Context)
+
+type queued struct {
+	ctx      context.Context
+	task     Task
+	enqueued time.Time
+}
+
+// registry 还没有关闭的协程池，用于运维接口查看队列情况
+var registry = struct {
+	sync.Mutex
+	pools map[*Pool]struct{}
+}{pools: make(map[*Pool]struct{})}
+
+// Stats 协程池当前的状态
+type Stats struct {
+	Name          string
+	Workers       int
+	BusyWorkers   int64
+	QueueDepth    int
+	QueueCapacity int
+}
+
+// Snapshot 所有还没有关闭的协程池的状态，按名称排序
+func Snapshot() []Stats {
+	registry.Lock()
+	defer registry.Unlock()
+	res := make([]Stats, 0, len(registry.pools))
+	for p := range registry.pools {
+		res = append(res, p.Stats())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// Pool 固定数量协程和有界队列的协程池
+type Pool struct {
+	name    string
+	queue   chan queued
+	workers int
+	busy    atomic.Int64
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// New 创建并启动协程池，workers 和 queueSize 小于等于 0 时分别按 1 和 0 处理
+func New(name string, workers, queueSize int) *Pool {
+	workers = max(workers, 1)
+	queueSize = max(queueSize, 0)
+	p := &Pool{
+		name:    name,
+		queue:   make(chan queued, queueSize),
+		workers: workers,
+	}
+	queueCapacityGauge.WithLabelValues(name).Set(float64(queueSize))
+	workersGauge.WithLabelValues(name).Set(float64(workers))
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	registry.Lock()
+	registry.pools[p] = struct{}{}
+	registry.Unlock()
+	return p
+}
+
+// Stats 协程池当前的状态
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Name:          p.name,
+		Workers:       p.workers,
+		BusyWorkers:   p.busy.Load(),
+		QueueDepth:    len(p.queue),
+		QueueCapacity: cap(p.queue),
+	}
+}
+
+// Name 协程池名称
+func (p *Pool) Name() string {
+	return p.name
+}
+
+// Submit 提交任务，队列满时阻塞，直到有空位、ctx 被取消或者协程池关闭
+func (p *Pool) Submit(ctx context.Context, task Task) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	select {
+	case p.queue <- queued{ctx: context.WithoutCancel(ctx), task: task, enqueued: time.Now()}:
+		queueDepthGauge.WithLabelValues(p.name).Set(float64(len(p.queue)))
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close 不再接受新任务，等待已经入队的任务执行完
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+	registry.Lock()
+	delete(registry.pools, p)
+	registry.Unlock()
+	p.wg.Wait()
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for q := range p.queue {
+		queueDepthGauge.WithLabelValues(p.name).Set(float64(len(p.queue)))
+		waitHistogram.WithLabelValues(p.name).Observe(time.Since(q.enqueued).Seconds())
+		busyWorkersGauge.WithLabelValues(p.name).Inc()
+		p.busy.Add(1)
+		q.task(q.ctx)
+		p.busy.Add(-1)
+		busyWorkersGauge.WithLabelValues(p.name).Dec()
+	}
+}
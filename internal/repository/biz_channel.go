@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// BizChannelRepository 业务方渠道开关，先查 Redis，没有时从数据库加载
+type BizChannelRepository interface {
+	// Get 业务方设置过的所有渠道开关，没有设置过时返回空的 Settings
+	Get(ctx context.Context, bizID int64) (domain.BizChannels, error)
+	// Set 先写数据库再删除缓存，所有实例下一次检查就使用新的开关
+	Set(ctx context.Context, setting domain.BizChannelSetting) error
+}
+
+var _ BizChannelRepository = (*bizChannelRepository)(nil)
+
+func NewBizChannelRepository(c cache.BizChannelCache, d dao.BizChannelDAO) BizChannelRepository {
+	return &bizChannelRepository{
+		cache:  c,
+		dao:    d,
+		logger: log.Named(log.DefaultLogger(), "repository.biz_channel"),
+	}
+}
+
+type bizChannelRepository struct {
+	cache  cache.BizChannelCache
+	dao    dao.BizChannelDAO
+	logger log.LoggerInterface
+}
+
+func (r *bizChannelRepository) Get(ctx context.Context, bizID int64) (domain.BizChannels, error) {
+	channels, err := r.cache.Get(ctx, bizID)
+	if err == nil {
+		return channels, nil
+	}
+	if !errors.Is(err, cache.ErrKeyNotFound) {
+		// Redis 不可用时直接查数据库
+		r.logger.WithContext(ctx).Warn("查询渠道开关缓存失败", zap.Error(err), zap.Int64("biz_id", bizID))
+	}
+	entities, err := r.dao.FindByBizID(ctx, bizID)
+	if err != nil {
+		return domain.BizChannels{}, err
+	}
+	channels = domain.BizChannels{BizID: bizID, Settings: make([]domain.BizChannelSetting, 0, len(entities))}
+	for _, e := range entities {
+		channels.Settings = append(channels.Settings, r.toDomain(e))
+	}
+	if err = r.cache.Set(ctx, channels); err != nil {
+		r.logger.WithContext(ctx).Warn("回写渠道开关缓存失败", zap.Error(err), zap.Int64("biz_id", bizID))
+	}
+	return channels, nil
+}
+
+func (r *bizChannelRepository) Set(ctx context.Context, setting domain.BizChannelSetting) error {
+	err := r.dao.Upsert(ctx, dao.BizChannelSetting{
+		BizID:    setting.BizID,
+		Channel:  setting.Channel.String(),
+		Enabled:  setting.Enabled,
+		Operator: setting.Operator,
+		Reason:   setting.Reason,
+	})
+	if err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, setting.BizID)
+}
+
+func (r *bizChannelRepository) toDomain(e dao.BizChannelSetting) domain.BizChannelSetting {
+	return domain.BizChannelSetting{
+		BizID:    e.BizID,
+		Channel:  domain.Channel(e.Channel),
+		Enabled:  e.Enabled,
+		Operator: e.Operator,
+		Reason:   e.Reason,
+		Ctime:    e.Ctime,
+		Utime:    e.Utime,
+	}
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// BizKeyPolicyRepository 业务方通知 key 的规则，先查 Redis，没有时从数据库加载
+type BizKeyPolicyRepository interface {
+	// Get 业务方没有设置过时返回只有 BizID 的空规则
+	Get(ctx context.Context, bizID int64) (domain.NotificationKeyPolicy, error)
+	// Set 先写数据库再删除缓存，所有实例下一次检查就使用新的规则
+	Set(ctx context.Context, policy domain.NotificationKeyPolicy) error
+}
+
+var _ BizKeyPolicyRepository = (*bizKeyPolicyRepository)(nil)
+
+func NewBizKeyPolicyRepository(c cache.BizKeyPolicyCache, d dao.BizKeyPolicyDAO) BizKeyPolicyRepository {
+	return &bizKeyPolicyRepository{
+		cache:  c,
+		dao:    d,
+		logger: log.Named(log.DefaultLogger(), "repository.biz_key_policy"),
+	}
+}
+
+type bizKeyPolicyRepository struct {
+	cache  cache.BizKeyPolicyCache
+	dao    dao.BizKeyPolicyDAO
+	logger log.LoggerInterface
+}
+
+func (r *bizKeyPolicyRepository) Get(ctx context.Context, bizID int64) (domain.NotificationKeyPolicy, error) {
+	policy, err := r.cache.Get(ctx, bizID)
+	if err == nil {
+		return policy, nil
+	}
+	if !errors.Is(err, cache.ErrKeyNotFound) {
+		// Redis 不可用时直接查数据库
+		r.logger.WithContext(ctx).Warn("查询 key 规则缓存失败", zap.Error(err), zap.Int64("biz_id", bizID))
+	}
+	entity, err := r.dao.FindByBizID(ctx, bizID)
+	if err != nil {
+		return domain.NotificationKeyPolicy{}, err
+	}
+	policy = r.toDomain(entity)
+	if err = r.cache.Set(ctx, policy); err != nil {
+		r.logger.WithContext(ctx).Warn("回写 key 规则缓存失败", zap.Error(err), zap.Int64("biz_id", bizID))
+	}
+	return policy, nil
+}
+
+func (r *bizKeyPolicyRepository) Set(ctx context.Context, policy domain.NotificationKeyPolicy) error {
+	err := r.dao.Upsert(ctx, dao.BizKeyPolicy{
+		BizID:          policy.BizID,
+		MaxLength:      policy.MaxLength,
+		Charset:        policy.Charset.String(),
+		RequiredPrefix: policy.RequiredPrefix,
+		Operator:       policy.Operator,
+	})
+	if err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, policy.BizID)
+}
+
+func (r *bizKeyPolicyRepository) toDomain(e dao.BizKeyPolicy) domain.NotificationKeyPolicy {
+	return domain.NotificationKeyPolicy{
+		BizID:          e.BizID,
+		MaxLength:      e.MaxLength,
+		Charset:        domain.KeyCharset(e.Charset),
+		RequiredPrefix: e.RequiredPrefix,
+		Operator:       e.Operator,
+		Ctime:          e.Ctime,
+		Utime:          e.Utime,
+	}
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// BlacklistRepository 渠道接收者黑名单，接收者在这一层加解密，按盲索引查询
+type BlacklistRepository interface {
+	// Add 加入黑名单，已经在黑名单里时更新原因、来源和过期时间
+	Add(ctx context.Context, entry domain.BlacklistEntry) error
+	// Blacklisted receivers 里在渠道黑名单里并且没有过期的接收者
+	Blacklisted(ctx context.Context, channel domain.Channel, receivers []string) ([]string, error)
+	// List 按ID倒序分页查询，接收者已经解密
+	List(ctx context.Context, filter domain.BlacklistFilter) ([]domain.BlacklistEntry, error)
+	// Delete 从渠道黑名单里删除接收者，不在黑名单里时返回 domain.ErrBlacklistEntryNotFound
+	Delete(ctx context.Context, channel domain.Channel, receiver string) error
+}
+
+var _ BlacklistRepository = (*blacklistRepository)(nil)
+
+func NewBlacklistRepository(d dao.BlacklistDAO, cipher encrypt.Cipher, indexer encrypt.BlindIndexer) BlacklistRepository {
+	return &blacklistRepository{
+		dao:     d,
+		cipher:  cipher,
+		indexer: indexer,
+	}
+}
+
+type blacklistRepository struct {
+	dao     dao.BlacklistDAO
+	cipher  encrypt.Cipher
+	indexer encrypt.BlindIndexer
+}
+
+func (r *blacklistRepository) Add(ctx context.Context, entry domain.BlacklistEntry) error {
+	receiver, err := r.cipher.Encrypt(ctx, entry.Receiver)
+	if err != nil {
+		return fmt.Errorf("加密接收者失败: %w", err)
+	}
+	return r.dao.Upsert(ctx, dao.BlacklistEntry{
+		Channel:        entry.Channel.String(),
+		ReceiverIndex:  r.indexer.Index(entry.Receiver),
+		Receiver:       receiver,
+		Category:       entry.Category.String(),
+		Vendor:         entry.Vendor,
+		Code:           entry.Code,
+		NotificationID: entry.NotificationID,
+		ExpireTime:     entry.ExpireTime.UnixMilli(),
+	})
+}
+
+func (r *blacklistRepository) Blacklisted(ctx context.Context, channel domain.Channel, receivers []string) ([]string, error) {
+	// 盲索引到接收者，不需要解密
+	indexes := make(map[string]string, len(receivers))
+	for _, receiver := range receivers {
+		indexes[r.indexer.Index(receiver)] = receiver
+	}
+	keys := make([]string, 0, len(indexes))
+	for idx := range indexes {
+		keys = append(keys, idx)
+	}
+	entries, err := r.dao.FindActive(ctx, channel.String(), keys, time.Now().UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, indexes[e.ReceiverIndex])
+	}
+	return res, nil
+}
+
+func (r *blacklistRepository) List(ctx context.Context, filter domain.BlacklistFilter) ([]domain.BlacklistEntry, error) {
+	f := dao.BlacklistFilter{
+		Channel:  filter.Channel.String(),
+		BeforeID: filter.BeforeID,
+		Limit:    filter.Limit,
+	}
+	if filter.Receiver != "" {
+		f.ReceiverIndex = r.indexer.Index(filter.Receiver)
+	}
+	if !filter.IncludeExpired {
+		f.ExpireAfter = time.Now().UnixMilli()
+	}
+	entities, err := r.dao.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.BlacklistEntry, 0, len(entities))
+	for _, e := range entities {
+		receiver, err := r.cipher.Decrypt(ctx, e.Receiver)
+		if err != nil {
+			return nil, fmt.Errorf("解密接收者失败: id = %d: %w", e.ID, err)
+		}
+		res = append(res, domain.BlacklistEntry{
+			ID:             e.ID,
+			Channel:        domain.Channel(e.Channel),
+			Receiver:       receiver,
+			Category:       domain.VendorErrorCategory(e.Category),
+			Vendor:         e.Vendor,
+			Code:           e.Code,
+			NotificationID: e.NotificationID,
+			ExpireTime:     time.UnixMilli(e.ExpireTime),
+			Ctime:          e.Ctime,
+			Utime:          e.Utime,
+		})
+	}
+	return res, nil
+}
+
+func (r *blacklistRepository) Delete(ctx context.Context, channel domain.Channel, receiver string) error {
+	deleted, err := r.dao.Delete(ctx, channel.String(), r.indexer.Index(receiver))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: channel = %s", domain.ErrBlacklistEntryNotFound, channel)
+	}
+	return nil
+}
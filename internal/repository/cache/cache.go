@@ -2,20 +2,87 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"github.com/serendipityConfusion/notification-platform/internal/domain"
 )
 
+// ErrKeyNotFound 缓存里没有，调用方需要从数据库加载
+var ErrKeyNotFound = errors.New("缓存不存在")
+
 type IncrItem struct {
 	BizID   int64
 	Channel domain.Channel
-	Val     int32
+	// Category 扣减时按透支策略决定额度用完之后是否允许发送，归还时忽略
+	Category domain.NotificationCategory
+	Val      int32
+	// Ctime 扣减额度的时间，归还时抵扣扣减当月的透支，为零值时按当前时间
+	Ctime time.Time
+}
+
+// QuotaReservation 一次扣减的意图，和扣减在同一个脚本里写入，通知写入数据库之后删除
+// 进程在扣减之后、写入数据库之前崩溃时意图留下来，修复任务按通知是否已经写入数据库决定删除意图还是归还额度
+type QuotaReservation struct {
+	ID string
+	// NotificationIDs 这次扣减的通知
+	NotificationIDs []uint64
+	Items           []IncrItem
+	// Ctime 记录意图的时间
+	Ctime time.Time
 }
 
 type QuotaCache interface {
 	CreateOrUpdate(ctx context.Context, quota ...domain.Quota) error
+	// SetIfAbsent 只写入还不存在的额度，已经存在的是正在扣减的剩余额度，不能覆盖
+	SetIfAbsent(ctx context.Context, quota ...domain.Quota) error
 	Find(ctx context.Context, bizID int64, channel domain.Channel) (domain.Quota, error)
 	Incr(ctx context.Context, bizID int64, channel domain.Channel, quota int32) error
-	Decr(ctx context.Context, bizID int64, channel domain.Channel, quota int32) error
+	// Decr 扣减额度，额度用完时按业务方的透支策略决定是否允许透支
+	Decr(ctx context.Context, bizID int64, channel domain.Channel, category domain.NotificationCategory, quota int32) error
 	MutiIncr(ctx context.Context, items []IncrItem) error
 	MutiDecr(ctx context.Context, items []IncrItem) error
+	// ForceDecr 不校验额度直接扣减，扣到 0 以下的部分计入本月透支，用于 Redis 恢复之后补扣不可用期间发送的通知
+	ForceDecr(ctx context.Context, items []IncrItem) error
+	// Check 按扣减的规则检查额度够不够扣减 quota，不扣减，返回当前的剩余额度
+	// 额度不足时返回 domain.ErrNoQuota
+	Check(ctx context.Context, bizID int64, channel domain.Channel, category domain.NotificationCategory, quota int32) (domain.Quota, error)
+
+	// ReserveDecr 和 MutiDecr 一样扣减 r.Items，同时原子地记录扣减意图，返回是否记录了意图
+	// 降级时扣减不经过 Redis，不记录意图，调用方按 MutiIncr 归还
+	ReserveDecr(ctx context.Context, r QuotaReservation) (bool, error)
+	// CommitReservation 通知已经写入数据库，删除扣减意图
+	CommitReservation(ctx context.Context, id string) error
+	// RefundReservation 意图还在时归还 r.Items 并删除意图，返回是否归还
+	// 意图已经被确认或者归还过时什么都不做，修复任务和发送流程同时归还也只归还一次
+	RefundReservation(ctx context.Context, r QuotaReservation) (bool, error)
+	// ListReservations 按记录时间从早到晚返回 before 之前记录的扣减意图
+	ListReservations(ctx context.Context, before time.Time, limit int) ([]QuotaReservation, error)
+}
+
+// NotificationKeyCache 最近创建过的通知 (bizID, key)，用来在写数据库之前预判重复发送
+// 可能误判存在，不会误判不存在；调用方在判断存在时需要查数据库确认
+type NotificationKeyCache interface {
+	// MayExist 和 keys 一一对应，false 表示一定没有创建过，true 表示可能创建过
+	MayExist(ctx context.Context, bizID int64, keys ...string) ([]bool, error)
+	// Add 记录已经创建的 key
+	Add(ctx context.Context, bizID int64, keys ...string) error
+}
+
+// BizChannelCache 业务方的渠道开关，发送时每条通知都要检查，修改时直接删除
+type BizChannelCache interface {
+	// Get 没有缓存时返回 ErrKeyNotFound，缓存了业务方没有设置过任何渠道时返回空的 Settings
+	Get(ctx context.Context, bizID int64) (domain.BizChannels, error)
+	Set(ctx context.Context, channels domain.BizChannels) error
+	// Del 删除之后所有实例下一次检查都从数据库加载
+	Del(ctx context.Context, bizID int64) error
+}
+
+// BizKeyPolicyCache 业务方通知 key 的规则，发送时每条通知都要检查，修改时直接删除
+type BizKeyPolicyCache interface {
+	// Get 没有缓存时返回 ErrKeyNotFound，缓存了业务方没有设置过规则时返回空规则
+	Get(ctx context.Context, bizID int64) (domain.NotificationKeyPolicy, error)
+	Set(ctx context.Context, policy domain.NotificationKeyPolicy) error
+	// Del 删除之后所有实例下一次检查都从数据库加载
+	Del(ctx context.Context, bizID int64) error
 }
@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+)
+
+const (
+	bizChannelKeyPrefix = "biz:channels"
+	// bizChannelExpiration 修改时会删除缓存，过期时间只是兜底删除失败的情况
+	bizChannelExpiration = 10 * time.Minute
+)
+
+var _ cache.BizChannelCache = (*bizChannelCache)(nil)
+
+type bizChannelCache struct {
+	client redis.Cmdable
+}
+
+// NewBizChannelCache 每个业务方一个 key，值是设置过的渠道开关 JSON
+func NewBizChannelCache(client redis.Cmdable) cache.BizChannelCache {
+	return &bizChannelCache{client: client}
+}
+
+func (c *bizChannelCache) Get(ctx context.Context, bizID int64) (domain.BizChannels, error) {
+	val, err := c.client.Get(ctx, c.key(bizID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.BizChannels{}, fmt.Errorf("%w: 业务方 %d 渠道开关", cache.ErrKeyNotFound, bizID)
+	}
+	if err != nil {
+		return domain.BizChannels{}, err
+	}
+	res := domain.BizChannels{BizID: bizID}
+	if err = json.Unmarshal(val, &res.Settings); err != nil {
+		return domain.BizChannels{}, err
+	}
+	return res, nil
+}
+
+func (c *bizChannelCache) Set(ctx context.Context, channels domain.BizChannels) error {
+	settings := channels.Settings
+	if settings == nil {
+		// 没有设置过任何渠道也要缓存，避免每次都查数据库
+		settings = []domain.BizChannelSetting{}
+	}
+	val, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(channels.BizID), val, bizChannelExpiration).Err()
+}
+
+func (c *bizChannelCache) Del(ctx context.Context, bizID int64) error {
+	return c.client.Del(ctx, c.key(bizID)).Err()
+}
+
+func (c *bizChannelCache) key(bizID int64) string {
+	return fmt.Sprintf("%s:%d", bizChannelKeyPrefix, bizID)
+}
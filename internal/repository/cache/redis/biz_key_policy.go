@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+)
+
+const (
+	bizKeyPolicyKeyPrefix = "biz:key-policy"
+	// bizKeyPolicyExpiration 修改时会删除缓存，过期时间只是兜底删除失败的情况
+	bizKeyPolicyExpiration = 10 * time.Minute
+)
+
+var _ cache.BizKeyPolicyCache = (*bizKeyPolicyCache)(nil)
+
+type bizKeyPolicyCache struct {
+	client redis.Cmdable
+}
+
+// NewBizKeyPolicyCache 每个业务方一个 key，值是规则 JSON，没有设置过规则的业务方缓存空规则
+func NewBizKeyPolicyCache(client redis.Cmdable) cache.BizKeyPolicyCache {
+	return &bizKeyPolicyCache{client: client}
+}
+
+func (c *bizKeyPolicyCache) Get(ctx context.Context, bizID int64) (domain.NotificationKeyPolicy, error) {
+	val, err := c.client.Get(ctx, c.key(bizID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.NotificationKeyPolicy{}, fmt.Errorf("%w: 业务方 %d key 规则", cache.ErrKeyNotFound, bizID)
+	}
+	if err != nil {
+		return domain.NotificationKeyPolicy{}, err
+	}
+	var res domain.NotificationKeyPolicy
+	if err = json.Unmarshal(val, &res); err != nil {
+		return domain.NotificationKeyPolicy{}, err
+	}
+	res.BizID = bizID
+	return res, nil
+}
+
+func (c *bizKeyPolicyCache) Set(ctx context.Context, policy domain.NotificationKeyPolicy) error {
+	val, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(policy.BizID), val, bizKeyPolicyExpiration).Err()
+}
+
+func (c *bizKeyPolicyCache) Del(ctx context.Context, bizID int64) error {
+	return c.client.Del(ctx, c.key(bizID)).Err()
+}
+
+func (c *bizKeyPolicyCache) key(bizID int64) string {
+	return fmt.Sprintf("%s:%d", bizKeyPolicyKeyPrefix, bizID)
+}
@@ -1,20 +1,44 @@
--- 遍历所有键值对进行阈值检查
-for i = 1, #KEYS do
-    local key = KEYS[i]
-    local threshold = tonumber(ARGV[i])
-    local current = tonumber(redis.call('GET', key) or 0)
+-- 每个额度三个 KEY：剩余额度、配置的额度、本月透支的条数
+-- 每个额度两个参数：扣减数量、允许透支配置额度的百分比（-1 表示不限制），之后一个参数是透支计数的过期时间（秒）
+-- 同一个额度可能出现多次（不同类别的通知），校验时累计前面的扣减
+-- 记录扣减意图时多两个 KEY：意图的有序集合和内容，多三个参数：记录时间（毫秒）、意图ID、意图内容
+-- 额度不足时返回不足的 KEY，扣减成功时按顺序返回每个额度透支的条数
+local n = math.floor(#KEYS / 3)
+local remaining = {}
+for i = 0, n - 1 do
+    local key = KEYS[i * 3 + 1]
+    local delta = tonumber(ARGV[i * 2 + 1])
+    local percent = tonumber(ARGV[i * 2 + 2])
+    local current = remaining[key] or tonumber(redis.call('GET', key) or 0)
+    if percent >= 0 then
+        local total = tonumber(redis.call('GET', KEYS[i * 3 + 2]) or 0)
+        -- 额度不足时立即返回失败，不做任何扣减
+        if current - delta < -math.floor(total * percent / 100) then
+            return key
+        end
+    end
+    remaining[key] = current - delta
+end
 
-    -- 值不足时立即返回失败
-    if current < threshold then
-        return key
+-- 全部校验通过后执行扣减，扣到 0 以下的部分计入本月透支
+local ttl = tonumber(ARGV[n * 2 + 1])
+local overdraft = {}
+for i = 0, n - 1 do
+    local delta = tonumber(ARGV[i * 2 + 1])
+    local after = redis.call('DECRBY', KEYS[i * 3 + 1], delta)
+    local before = after + delta
+    local over = math.max(0, -after) - math.max(0, -before)
+    if over > 0 then
+        redis.call('INCRBY', KEYS[i * 3 + 3], over)
+        redis.call('EXPIRE', KEYS[i * 3 + 3], ttl)
     end
+    overdraft[i + 1] = over
 end
 
--- 全部校验通过后执行扣减
-for i = 1, #KEYS do
-    local key = KEYS[i]
-    local delta = tonumber(ARGV[i])
-    redis.call('DECRBY', key, delta)
+-- 和扣减一起写入，进程在通知写入数据库之前崩溃时由修复任务归还
+if #KEYS > n * 3 then
+    redis.call('ZADD', KEYS[n * 3 + 1], ARGV[n * 2 + 2], ARGV[n * 2 + 3])
+    redis.call('HSET', KEYS[n * 3 + 2], ARGV[n * 2 + 3], ARGV[n * 2 + 4])
 end
 
-return ""
\ No newline at end of file
+return overdraft
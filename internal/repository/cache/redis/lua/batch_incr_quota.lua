@@ -1,16 +1,18 @@
--- 遍历所有键值对
-for i = 1, #KEYS do
-    local key = KEYS[i]
-    local param = tonumber(ARGV[i])
-    local current = tonumber(redis.call('GET', key) or 0)  -- 处理键不存在的情况
-
-    if current < 0 then
-        -- 值小于0时设置为参数值
-        redis.call('SET', key, param)
-    else
-        -- 值≥0时增加参数值（原子操作）
-        redis.call('INCRBY', key, param)
+-- 每个额度两个 KEY：剩余额度、扣减当月透支的条数；每个额度一个参数：归还数量
+-- 剩余额度小于 0 说明在透支，归还的部分先抵扣扣减当月的透支
+for i = 0, #KEYS / 2 - 1 do
+    local key = KEYS[i * 2 + 1]
+    local delta = tonumber(ARGV[i + 1])
+    local before = tonumber(redis.call('GET', key) or 0)
+    redis.call('INCRBY', key, delta)
+    if before < 0 then
+        local overdraftKey = KEYS[i * 2 + 2]
+        local overdraft = tonumber(redis.call('GET', overdraftKey) or 0)
+        local back = math.min(delta, -before, overdraft)
+        if back > 0 then
+            redis.call('DECRBY', overdraftKey, back)
+        end
     end
 end
 
-return 1  -- 返回成功标志
\ No newline at end of file
+return 1
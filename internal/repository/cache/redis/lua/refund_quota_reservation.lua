@@ -0,0 +1,25 @@
+-- KEYS[1]、KEYS[2] 是扣减意图的有序集合和内容，之后每个额度两个 KEY：剩余额度、记录意图当月透支的条数
+-- ARGV[1] 是意图ID，之后每个额度一个参数：归还数量
+-- 意图已经不存在说明已经确认或者归还过，什么都不做，保证只归还一次
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+    return 0
+end
+redis.call('HDEL', KEYS[2], ARGV[1])
+
+-- 和 batch_incr_quota.lua 一样，剩余额度小于 0 说明在透支，归还的部分先抵扣记录意图当月的透支
+for i = 0, (#KEYS - 2) / 2 - 1 do
+    local key = KEYS[i * 2 + 3]
+    local delta = tonumber(ARGV[i + 2])
+    local before = tonumber(redis.call('GET', key) or 0)
+    redis.call('INCRBY', key, delta)
+    if before < 0 then
+        local overdraftKey = KEYS[i * 2 + 4]
+        local overdraft = tonumber(redis.call('GET', overdraftKey) or 0)
+        local back = math.min(delta, -before, overdraft)
+        if back > 0 then
+            redis.call('DECRBY', overdraftKey, back)
+        end
+    end
+end
+
+return 1
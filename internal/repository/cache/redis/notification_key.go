@@ -0,0 +1,135 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+)
+
+const notificationKeyBloomPrefix = "notification:key:bloom"
+
+// NotificationKeyBloomOptions 布隆过滤器参数，零值使用默认值
+type NotificationKeyBloomOptions struct {
+	// Window 每个时间段一个过滤器，默认 1 小时
+	Window time.Duration
+	// Bits 每个过滤器的位数，默认 2^24（2MB），每个时间段一百万个 key 时误判率大约 0.06%
+	Bits uint64
+	// Hashes 每个 key 设置的位数，默认 7
+	Hashes int
+}
+
+func (o NotificationKeyBloomOptions) withDefaults() NotificationKeyBloomOptions {
+	if o.Window <= 0 {
+		o.Window = time.Hour
+	}
+	if o.Bits == 0 {
+		o.Bits = 1 << 24
+	}
+	// SETBIT 的偏移量不能超过 2^32-1
+	o.Bits = min(o.Bits, 1<<32)
+	if o.Hashes <= 0 {
+		o.Hashes = 7
+	}
+	return o
+}
+
+// notificationKeyBloom 用 SETBIT/GETBIT 实现的布隆过滤器，不依赖 RedisBloom 模块
+// 按时间段轮换过滤器，旧的过滤器随 key 过期一起删除，过滤器不会一直变满
+type notificationKeyBloom struct {
+	client redis.Cmdable
+	opts   NotificationKeyBloomOptions
+	clock  clock.Clock
+}
+
+// NewNotificationKeyBloom 最近创建过的 (bizID, key)
+func NewNotificationKeyBloom(client redis.Cmdable, opts NotificationKeyBloomOptions, clk clock.Clock) cache.NotificationKeyCache {
+	return &notificationKeyBloom{client: client, opts: opts.withDefaults(), clock: clk}
+}
+
+func (b *notificationKeyBloom) MayExist(ctx context.Context, bizID int64, keys ...string) ([]bool, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	window := b.window()
+	filters := []string{b.filterKey(window), b.filterKey(window - 1)}
+	pipe := b.client.Pipeline()
+	cmds := make([][]*redis.IntCmd, 0, len(keys)*len(filters))
+	for _, key := range keys {
+		offsets := b.offsets(bizID, key)
+		for _, f := range filters {
+			fc := make([]*redis.IntCmd, 0, len(offsets))
+			for _, off := range offsets {
+				fc = append(fc, pipe.GetBit(ctx, f, off))
+			}
+			cmds = append(cmds, fc)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	res := make([]bool, len(keys))
+	for i := range keys {
+		for j := range filters {
+			if allSet(cmds[i*len(filters)+j]) {
+				res[i] = true
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+func allSet(cmds []*redis.IntCmd) bool {
+	for _, c := range cmds {
+		if c.Val() != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *notificationKeyBloom) Add(ctx context.Context, bizID int64, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	filter := b.filterKey(b.window())
+	pipe := b.client.Pipeline()
+	for _, key := range keys {
+		for _, off := range b.offsets(bizID, key) {
+			pipe.SetBit(ctx, filter, off, 1)
+		}
+	}
+	// 上一个时间段结束之后还要被检查一个 Window
+	pipe.Expire(ctx, filter, 2*b.opts.Window)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (b *notificationKeyBloom) window() int64 {
+	return b.clock.Now().UnixNano() / int64(b.opts.Window)
+}
+
+func (b *notificationKeyBloom) filterKey(window int64) string {
+	return fmt.Sprintf("%s:%d", notificationKeyBloomPrefix, window)
+}
+
+// offsets 双重哈希生成 Hashes 个位置
+func (b *notificationKeyBloom) offsets(bizID int64, key string) []int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strconv.FormatInt(bizID, 10)))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	sum := h.Sum64()
+	h1, h2 := sum&0xffffffff, sum>>32|1
+	res := make([]int64, 0, b.opts.Hashes)
+	for i := range uint64(b.opts.Hashes) {
+		res = append(res, int64((h1+i*h2)%b.opts.Bits))
+	}
+	return res
+}
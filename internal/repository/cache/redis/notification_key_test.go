@@ -0,0 +1,34 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+)
+
+// 过滤器按注入的时钟轮换，加入之后的当前和下一个时间段都能查到，再往后就查不到了
+func TestNotificationKeyBloom_WindowFollowsClock(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC))
+	bloom := NewNotificationKeyBloom(client, NotificationKeyBloomOptions{Window: time.Hour, Bits: 1 << 16}, clk)
+	ctx := context.Background()
+
+	if err := bloom.Add(ctx, 7, "k"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []bool{true, true, false} {
+		got, err := bloom.MayExist(ctx, 7, "k")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[0] != want {
+			t.Fatalf("%s 查询结果 %v, 应该是 %v", clk.Now(), got[0], want)
+		}
+		clk.Advance(time.Hour)
+	}
+}
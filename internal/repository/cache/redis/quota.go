@@ -1,12 +1,21 @@
 package redis
 
 import (
+	"cmp"
 	"context"
 	_ "embed"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
 	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
 	"go.uber.org/zap"
@@ -14,116 +23,347 @@ import (
 
 var (
 	ErrQuotaLessThenZero = errors.New("额度小于0")
-	//go:embed lua/quota.lua
-	quotaScript string
 	//go:embed lua/batch_decr_quota.lua
 	batchDecrQuotaScript string
 	//go:embed lua/batch_incr_quota.lua
 	batchIncrQuotaScript string
+	//go:embed lua/refund_quota_reservation.lua
+	refundQuotaReservationScript string
+
+	overdraftCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "quota_overdraft_total",
+		Help: "Total number of notifications sent on overdraft after the quota ran out",
+	}, []string{"channel"})
+)
+
+func init() {
+	prometheus.MustRegister(overdraftCounter)
+}
+
+// overdraftTTL 透支计数按月保存，保留到下一年对账之后
+const overdraftTTL = 400 * 24 * time.Hour
+
+const (
+	// reservationsKey 扣减意图的ID，分数是记录时间的毫秒时间戳
+	reservationsKey = "quota:reservations"
+	// reservationDataKey 扣减意图的内容
+	reservationDataKey = "quota:reservations:data"
 )
 
 type quotaCache struct {
-	client *redis.Client
+	client   *redis.Client
+	policies domain.OverdraftPolicies
+	// clock 决定透支计入哪个月
+	clock  clock.Clock
 	logger log.LoggerInterface
 }
 
-func NewQuotaCache(client *redis.Client) cache.QuotaCache {
+// NewQuotaCache policies 为空时额度用完直接拒绝
+func NewQuotaCache(client *redis.Client, policies domain.OverdraftPolicies, clk clock.Clock) cache.QuotaCache {
 	return &quotaCache{
-		client: client,
-		logger: log.DefaultLogger(),
+		client:   client,
+		policies: policies,
+		clock:    clk,
+		logger:   log.Named(log.DefaultLogger(), "cache.quota"),
 	}
 }
 
+// MutiIncr 归还的额度抵扣扣减当月的透支，跨月归还不会抵扣到归还当月
 func (q *quotaCache) MutiIncr(ctx context.Context, items []cache.IncrItem) error {
 	if len(items) == 0 {
 		return nil
 	}
-	keys, quotas := q.getKeysAndQuotas(items)
-	err := q.client.Eval(ctx, batchIncrQuotaScript, keys, quotas).Err()
-	if err != nil {
-		return err
+	now := q.clock.Now()
+	keys := make([]string, 0, 2*len(items))
+	vals := make([]any, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, q.key(item.BizID, item.Channel), q.overdraftKey(item.BizID, item.Channel, cmp.Or(item.Ctime, now)))
+		vals = append(vals, item.Val)
 	}
-	return nil
+	return q.client.Eval(ctx, batchIncrQuotaScript, keys, vals...).Err()
 }
 
-func (q *quotaCache) getKeysAndQuotas(items []cache.IncrItem) (keys []string, quotas []any) {
-	keys = make([]string, 0, len(items))
-	quotas = make([]any, 0, len(items))
-	for idx := range items {
-		item := items[idx]
-		keys = append(keys, q.key(domain.Quota{
-			BizID:   item.BizID,
-			Channel: item.Channel,
-		}))
-		quotas = append(quotas, item.Val)
+// MutiDecr 全部额度都够才扣减，营销类排在前面先校验，避免事务类先透支导致营销类校验失败
+func (q *quotaCache) MutiDecr(ctx context.Context, items []cache.IncrItem) error {
+	return q.decr(ctx, items, false, nil)
+}
+
+func (q *quotaCache) ForceDecr(ctx context.Context, items []cache.IncrItem) error {
+	return q.decr(ctx, items, true, nil)
+}
+
+func (q *quotaCache) ReserveDecr(ctx context.Context, r cache.QuotaReservation) (bool, error) {
+	if len(r.Items) == 0 {
+		return false, nil
+	}
+	if err := q.decr(ctx, r.Items, false, &r); err != nil {
+		return false, err
 	}
-	return keys, quotas
+	return true, nil
 }
 
-func (q *quotaCache) MutiDecr(ctx context.Context, items []cache.IncrItem) error {
-	keys, quotas := q.getKeysAndQuotas(items)
-	res, err := q.client.Eval(ctx, batchDecrQuotaScript, keys, quotas).Result()
+// reservationData 扣减意图在 Redis 里的内容，ID 和记录时间在有序集合里
+type reservationData struct {
+	NotificationIDs []uint64          `json:"ids"`
+	Items           []reservationItem `json:"items"`
+}
+
+type reservationItem struct {
+	BizID   int64  `json:"biz_id"`
+	Channel string `json:"channel"`
+	Val     int32  `json:"val"`
+}
+
+// decr force 为 true 时按不限制透支扣减，r 不为空时同时记录扣减意图
+func (q *quotaCache) decr(ctx context.Context, items []cache.IncrItem, force bool, r *cache.QuotaReservation) error {
+	if len(items) == 0 {
+		return nil
+	}
+	items = slices.Clone(items)
+	slices.SortStableFunc(items, func(a, b cache.IncrItem) int {
+		return cmp.Compare(q.allowanceOrder(a), q.allowanceOrder(b))
+	})
+	// 记录意图时透支计入意图的月份，和归还意图时抵扣的月份一致
+	month := q.clock.Now()
+	if r != nil {
+		month = cmp.Or(r.Ctime, month)
+	}
+	keys := make([]string, 0, 3*len(items))
+	vals := make([]any, 0, 2*len(items)+1)
+	for _, item := range items {
+		keys = append(keys, q.key(item.BizID, item.Channel), q.totalKey(item.BizID, item.Channel),
+			q.overdraftKey(item.BizID, item.Channel, month))
+		allowance := q.policies.Find(item.BizID, item.Channel).Allowance(item.Category)
+		if force {
+			allowance = domain.UnlimitedOverdraft
+		}
+		vals = append(vals, item.Val, allowance)
+	}
+	vals = append(vals, int64(overdraftTTL.Seconds()))
+	if r != nil {
+		data := reservationData{NotificationIDs: r.NotificationIDs, Items: make([]reservationItem, 0, len(r.Items))}
+		for _, item := range r.Items {
+			data.Items = append(data.Items, reservationItem{BizID: item.BizID, Channel: item.Channel.String(), Val: item.Val})
+		}
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, reservationsKey, reservationDataKey)
+		vals = append(vals, month.UnixMilli(), r.ID, payload)
+	}
+	res, err := q.client.Eval(ctx, batchDecrQuotaScript, keys, vals...).Result()
 	if err != nil {
 		return err
 	}
-	resStr, ok := res.(string)
-	if !ok {
+	switch v := res.(type) {
+	case string:
+		return fmt.Errorf("%w: %s不足 %w", domain.ErrNoQuota, v, ErrQuotaLessThenZero)
+	case []any:
+		if len(v) != len(items) {
+			return errors.New("返回值不正确")
+		}
+		for i, over := range v {
+			if n, ok := over.(int64); ok && n > 0 {
+				q.recordOverdraft(ctx, items[i], n)
+			}
+		}
+		return nil
+	default:
 		return errors.New("返回值不正确")
 	}
-	if resStr != "" {
-		return fmt.Errorf("%s不足 %w", resStr, ErrQuotaLessThenZero)
-	}
-	return nil
 }
 
-func (q *quotaCache) Incr(ctx context.Context, bizID int64, channel domain.Channel, quota int32) error {
-	return q.client.Eval(ctx, quotaScript, []string{q.key(domain.Quota{
-		BizID:   bizID,
-		Channel: channel,
-	})}, quota).Err()
+func (q *quotaCache) CommitReservation(ctx context.Context, id string) error {
+	pipe := q.client.TxPipeline()
+	pipe.ZRem(ctx, reservationsKey, id)
+	pipe.HDel(ctx, reservationDataKey, id)
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
-func (q *quotaCache) Decr(ctx context.Context, bizID int64, channel domain.Channel, quota int32) error {
-	res, err := q.client.DecrBy(ctx, q.key(domain.Quota{
-		BizID:   bizID,
-		Channel: channel,
-	}), int64(quota)).Result()
+// RefundReservation 扣减和记录意图在同一个脚本里，归还的额度抵扣记录意图当月的透支
+func (q *quotaCache) RefundReservation(ctx context.Context, r cache.QuotaReservation) (bool, error) {
+	month := cmp.Or(r.Ctime, q.clock.Now())
+	keys := make([]string, 0, 2+2*len(r.Items))
+	vals := make([]any, 0, 1+len(r.Items))
+	keys = append(keys, reservationsKey, reservationDataKey)
+	vals = append(vals, r.ID)
+	for _, item := range r.Items {
+		keys = append(keys, q.key(item.BizID, item.Channel), q.overdraftKey(item.BizID, item.Channel, month))
+		vals = append(vals, item.Val)
+	}
+	refunded, err := q.client.Eval(ctx, refundQuotaReservationScript, keys, vals...).Int()
+	return refunded == 1, err
+}
+
+func (q *quotaCache) ListReservations(ctx context.Context, before time.Time, limit int) ([]cache.QuotaReservation, error) {
+	members, err := q.client.ZRangeByScoreWithScores(ctx, reservationsKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.Member.(string))
+	}
+	payloads, err := q.client.HMGet(ctx, reservationDataKey, ids...).Result()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if res < 0 {
-		q.logger.Error("库存不足", zap.Int("biz_id", int(bizID)), zap.String("channel", channel.String()))
-		return ErrQuotaLessThenZero
+	res := make([]cache.QuotaReservation, 0, len(members))
+	for i, m := range members {
+		r := cache.QuotaReservation{ID: ids[i], Ctime: time.UnixMilli(int64(m.Score))}
+		// 没有内容的意图照常返回，归还时只删除
+		if payload, ok := payloads[i].(string); ok {
+			var data reservationData
+			if err = json.Unmarshal([]byte(payload), &data); err != nil {
+				return nil, fmt.Errorf("扣减意图 %s 的内容不正确: %w", r.ID, err)
+			}
+			r.NotificationIDs = data.NotificationIDs
+			for _, item := range data.Items {
+				r.Items = append(r.Items, cache.IncrItem{BizID: item.BizID, Channel: domain.Channel(item.Channel), Val: item.Val})
+			}
+		}
+		res = append(res, r)
 	}
-	return nil
+	return res, nil
 }
 
+// allowanceOrder 允许透支越少越靠前，不限制透支的排在最后
+func (q *quotaCache) allowanceOrder(item cache.IncrItem) int {
+	allowance := q.policies.Find(item.BizID, item.Channel).Allowance(item.Category)
+	if allowance == domain.UnlimitedOverdraft {
+		return math.MaxInt
+	}
+	return allowance
+}
+
+// recordOverdraft 按扣减的业务方、渠道和类别记录透支的条数
+func (q *quotaCache) recordOverdraft(ctx context.Context, item cache.IncrItem, overdraft int64) {
+	overdraftCounter.WithLabelValues(item.Channel.String()).Add(float64(overdraft))
+	q.logger.WithContext(ctx).Warn("额度已用完，透支发送", zap.Int64("biz_id", item.BizID),
+		zap.String("channel", item.Channel.String()), zap.String("category", item.Category.String()),
+		zap.Int64("overdraft", overdraft))
+}
+
+func (q *quotaCache) Incr(ctx context.Context, bizID int64, channel domain.Channel, quota int32) error {
+	return q.MutiIncr(ctx, []cache.IncrItem{{BizID: bizID, Channel: channel, Val: quota}})
+}
+
+func (q *quotaCache) Decr(ctx context.Context, bizID int64, channel domain.Channel, category domain.NotificationCategory, quota int32) error {
+	err := q.MutiDecr(ctx, []cache.IncrItem{{BizID: bizID, Channel: channel, Category: category, Val: quota}})
+	if errors.Is(err, ErrQuotaLessThenZero) {
+		q.logger.WithContext(ctx).Error("库存不足", zap.Int("biz_id", int(bizID)), zap.String("channel", channel.String()))
+	}
+	return err
+}
+
+// CreateOrUpdate 剩余额度重置成配置的额度，同时保存配置的额度用来计算允许透支的上限
 func (q *quotaCache) CreateOrUpdate(ctx context.Context, quotas ...domain.Quota) error {
 	const (
-		number = 2
+		number = 4
 	)
 	vals := make([]any, 0, number*len(quotas))
 	for _, quota := range quotas {
-		vals = append(vals, q.key(quota), quota.Quota)
+		vals = append(vals,
+			q.key(quota.BizID, quota.Channel), quota.Quota,
+			q.totalKey(quota.BizID, quota.Channel), quota.Quota)
 	}
 	return q.client.MSet(ctx, vals...).Err()
 }
 
+func (q *quotaCache) SetIfAbsent(ctx context.Context, quotas ...domain.Quota) error {
+	if len(quotas) == 0 {
+		return nil
+	}
+	pipe := q.client.Pipeline()
+	for _, quota := range quotas {
+		pipe.SetNX(ctx, q.key(quota.BizID, quota.Channel), quota.Quota, 0)
+		// 配置的额度不是计数，直接覆盖
+		pipe.Set(ctx, q.totalKey(quota.BizID, quota.Channel), quota.Quota, 0)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Find 剩余额度和本月透支的条数，透支时剩余额度是负数
 func (q *quotaCache) Find(ctx context.Context, bizID int64, channel domain.Channel) (domain.Quota, error) {
-	quota, err := q.client.Get(ctx, q.key(domain.Quota{
-		BizID:   bizID,
-		Channel: channel,
-	})).Int()
+	res, err := q.client.MGet(ctx, q.key(bizID, channel), q.overdraftKey(bizID, channel, q.clock.Now())).Result()
+	if err != nil {
+		return domain.Quota{}, err
+	}
+	if res[0] == nil {
+		return domain.Quota{}, fmt.Errorf("%w: 业务方 %d 渠道 %s", domain.ErrQuotaNotFound, bizID, channel)
+	}
+	remaining, err := parseInt32(res[0])
 	if err != nil {
 		return domain.Quota{}, err
 	}
+	var overdraft int32
+	if res[1] != nil {
+		if overdraft, err = parseInt32(res[1]); err != nil {
+			return domain.Quota{}, err
+		}
+	}
 	return domain.Quota{
-		BizID:   bizID,
-		Channel: channel,
-		Quota:   int32(quota),
+		BizID:     bizID,
+		Channel:   channel,
+		Quota:     remaining,
+		Overdraft: overdraft,
 	}, nil
 }
 
-func (q *quotaCache) key(quota domain.Quota) string {
-	return fmt.Sprintf("quota:%d:%s", quota.BizID, quota.Channel)
+// Check 和 batch_decr_quota.lua 的校验规则一致，没有原子性，只用于试运行
+func (q *quotaCache) Check(ctx context.Context, bizID int64, channel domain.Channel,
+	category domain.NotificationCategory, quota int32,
+) (domain.Quota, error) {
+	res, err := q.client.MGet(ctx, q.key(bizID, channel), q.totalKey(bizID, channel),
+		q.overdraftKey(bizID, channel, q.clock.Now())).Result()
+	if err != nil {
+		return domain.Quota{}, err
+	}
+	values := make([]int32, len(res))
+	for i, v := range res {
+		if v == nil {
+			continue
+		}
+		if values[i], err = parseInt32(v); err != nil {
+			return domain.Quota{}, err
+		}
+	}
+	current := domain.Quota{BizID: bizID, Channel: channel, Quota: values[0], Overdraft: values[2]}
+	percent := q.policies.Find(bizID, channel).Allowance(category)
+	if percent != domain.UnlimitedOverdraft &&
+		int64(values[0])-int64(quota) < -int64(values[1])*int64(percent)/100 {
+		return current, fmt.Errorf("%w: 业务方 %d 渠道 %s 剩余 %d", domain.ErrNoQuota, bizID, channel, values[0])
+	}
+	return current, nil
+}
+
+func parseInt32(v any) (int32, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("额度的值不是字符串: %v", v)
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("额度的值不是整数: %w", err)
+	}
+	return int32(n), nil
+}
+
+func (q *quotaCache) key(bizID int64, channel domain.Channel) string {
+	return fmt.Sprintf("quota:%d:%s", bizID, channel)
+}
+
+func (q *quotaCache) totalKey(bizID int64, channel domain.Channel) string {
+	return fmt.Sprintf("quota:total:%d:%s", bizID, channel)
+}
+
+func (q *quotaCache) overdraftKey(bizID int64, channel domain.Channel, month time.Time) string {
+	return fmt.Sprintf("quota:overdraft:%d:%s:%s", bizID, channel, month.Format("200601"))
 }
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+)
+
+// benchRedisAddrEnv 额度扣减和归还是 Lua 脚本，需要真实的 Redis，例如 127.0.0.1:6379（deploy/docker-compose.yaml 启动的 Redis）
+// 压测会写入 biz_id 从 benchBizID 开始的额度，不要指向线上的 Redis
+const benchRedisAddrEnv = "NOTIFICATION_BENCH_REDIS_ADDR"
+
+const benchBizID = 9_000_000_000
+
+var benchBatchSizes = []int{100, 1000, 10000}
+
+func BenchmarkQuotaCache_MutiDecr(b *testing.B) {
+	benchmarkQuota(b, func(ctx context.Context, q cache.QuotaCache, items []cache.IncrItem) error {
+		return q.MutiDecr(ctx, items)
+	})
+}
+
+func BenchmarkQuotaCache_MutiIncr(b *testing.B) {
+	benchmarkQuota(b, func(ctx context.Context, q cache.QuotaCache, items []cache.IncrItem) error {
+		return q.MutiIncr(ctx, items)
+	})
+}
+
+// benchmarkQuota 每个元素是不同的业务方，脚本要读写的 key 数和批量大小成正比
+func benchmarkQuota(b *testing.B, call func(ctx context.Context, q cache.QuotaCache, items []cache.IncrItem) error) {
+	addr := os.Getenv(benchRedisAddrEnv)
+	if addr == "" {
+		b.Skipf("没有配置 %s", benchRedisAddrEnv)
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	b.Cleanup(func() { _ = client.Close() })
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		b.Fatal(err)
+	}
+	q := NewQuotaCache(client, domain.OverdraftPolicies{}, clock.Real())
+	for _, size := range benchBatchSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			items := make([]cache.IncrItem, 0, size)
+			quotas := make([]domain.Quota, 0, size)
+			for i := range size {
+				bizID := int64(benchBizID + i)
+				items = append(items, cache.IncrItem{BizID: bizID, Channel: domain.ChannelSMS, Val: 1})
+				// 额度足够整个压测扣减，不会因为额度不足提前返回
+				quotas = append(quotas, domain.Quota{BizID: bizID, Channel: domain.ChannelSMS, Quota: 1 << 30})
+			}
+			if err := q.CreateOrUpdate(ctx, quotas...); err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := call(ctx, q, items); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*size), "ns/item")
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+)
+
+// 额度用完之后按透支策略决定是否允许扣减，允许透支的部分计入本月透支
+func TestQuotaCache_OverdraftPolicies(t *testing.T) {
+	type step struct {
+		category domain.NotificationCategory
+		val      int32
+		wantErr  error
+	}
+	testCases := []struct {
+		name          string
+		policy        *domain.OverdraftPolicy
+		steps         []step
+		wantRemaining int32
+		wantOverdraft int32
+	}{
+		{
+			name:          "默认拒绝",
+			steps:         []step{{val: 1, wantErr: domain.ErrNoQuota}},
+			wantRemaining: 0,
+		},
+		{
+			name:   "BURST",
+			policy: &domain.OverdraftPolicy{Mode: domain.OverdraftModeBurst, BurstPercent: 10},
+			steps: []step{
+				{val: 6},
+				{val: 4},
+				// 已经透支配置额度的 10%，整批拒绝，不会扣到上限
+				{val: 2, wantErr: domain.ErrNoQuota},
+			},
+			wantRemaining: -10,
+			wantOverdraft: 10,
+		},
+		{
+			name:          "BILL_LATER",
+			policy:        &domain.OverdraftPolicy{Mode: domain.OverdraftModeBillLater},
+			steps:         []step{{val: 500}, {category: domain.NotificationCategoryMarketing, val: 20}},
+			wantRemaining: -520,
+			wantOverdraft: 520,
+		},
+		{
+			name:   "DEGRADE_MARKETING",
+			policy: &domain.OverdraftPolicy{Mode: domain.OverdraftModeDegradeMarketing},
+			steps: []step{
+				{category: domain.NotificationCategoryMarketing, val: 1, wantErr: domain.ErrNoQuota},
+				{category: domain.NotificationCategoryTransactional, val: 50},
+				{category: domain.NotificationCategoryMarketing, val: 1, wantErr: domain.ErrNoQuota},
+			},
+			wantRemaining: -50,
+			wantOverdraft: 50,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var policies []domain.OverdraftPolicy
+			if tc.policy != nil {
+				p := *tc.policy
+				p.BizID = 1
+				policies = append(policies, p)
+			}
+			q, _ := newTestQuotaCache(t, policies, clock.NewFake(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+			ctx := context.Background()
+			if err := q.CreateOrUpdate(ctx, domain.Quota{BizID: 1, Channel: domain.ChannelSMS, Quota: 100}); err != nil {
+				t.Fatal(err)
+			}
+			// 先用完配置的额度，没有透支
+			if err := q.Decr(ctx, 1, domain.ChannelSMS, domain.NotificationCategoryMarketing, 100); err != nil {
+				t.Fatalf("额度内扣减失败: %v", err)
+			}
+			for i, s := range tc.steps {
+				if err := q.Decr(ctx, 1, domain.ChannelSMS, s.category, s.val); !errors.Is(err, s.wantErr) {
+					t.Fatalf("第 %d 次扣减返回 %v, 应该是 %v", i+1, err, s.wantErr)
+				}
+			}
+			got, err := q.Find(ctx, 1, domain.ChannelSMS)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Quota != tc.wantRemaining || got.Overdraft != tc.wantOverdraft {
+				t.Fatalf("剩余 %d 透支 %d, 应该是 %d 和 %d", got.Quota, got.Overdraft, tc.wantRemaining, tc.wantOverdraft)
+			}
+		})
+	}
+}
+
+// 一批扣减里每个额度透支的条数分别记录，不能都记到第一个额度上
+func TestQuotaCache_OverdraftPerItem(t *testing.T) {
+	q, _ := newTestQuotaCache(t, []domain.OverdraftPolicy{
+		{BizID: 1, Mode: domain.OverdraftModeBillLater},
+		{BizID: 2, Mode: domain.OverdraftModeBillLater},
+	}, clock.NewFake(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	err := q.CreateOrUpdate(ctx,
+		domain.Quota{BizID: 1, Channel: domain.ChannelSMS, Quota: 10},
+		domain.Quota{BizID: 2, Channel: domain.ChannelEmail, Quota: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	smsBefore := testutil.ToFloat64(overdraftCounter.WithLabelValues(domain.ChannelSMS.String()))
+	emailBefore := testutil.ToFloat64(overdraftCounter.WithLabelValues(domain.ChannelEmail.String()))
+
+	err = q.MutiDecr(ctx, []cache.IncrItem{
+		{BizID: 1, Channel: domain.ChannelSMS, Val: 4},
+		{BizID: 2, Channel: domain.ChannelEmail, Val: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(overdraftCounter.WithLabelValues(domain.ChannelSMS.String())) - smsBefore; got != 0 {
+		t.Fatalf("短信没有透支，透支指标增加了 %v", got)
+	}
+	if got := testutil.ToFloat64(overdraftCounter.WithLabelValues(domain.ChannelEmail.String())) - emailBefore; got != 2 {
+		t.Fatalf("邮件透支指标增加了 %v, 应该是 2", got)
+	}
+	for _, want := range []domain.Quota{
+		{BizID: 1, Channel: domain.ChannelSMS, Quota: 6},
+		{BizID: 2, Channel: domain.ChannelEmail, Quota: -2, Overdraft: 2},
+	} {
+		got, err := q.Find(ctx, want.BizID, want.Channel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("额度是 %+v, 应该是 %+v", got, want)
+		}
+	}
+}
+
+// 归还的额度先抵扣扣减当月的透支，跨月归还不能抵扣归还当月的透支
+func TestQuotaCache_RefundOffsetsOverdraft(t *testing.T) {
+	jan := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(jan)
+	q, mr := newTestQuotaCache(t, []domain.OverdraftPolicy{{BizID: 1, Mode: domain.OverdraftModeBillLater}}, clk)
+	ctx := context.Background()
+	if err := q.CreateOrUpdate(ctx, domain.Quota{BizID: 1, Channel: domain.ChannelSMS, Quota: 1}); err != nil {
+		t.Fatal(err)
+	}
+	item := cache.IncrItem{BizID: 1, Channel: domain.ChannelSMS, Val: 3}
+	if err := q.MutiDecr(ctx, []cache.IncrItem{item}); err != nil {
+		t.Fatal(err)
+	}
+	// 一月透支 2 条，同时记录一个一月的扣减意图，透支 1 条
+	reservation := cache.QuotaReservation{
+		ID:              "100",
+		NotificationIDs: []uint64{100},
+		Items:           []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 1}},
+		Ctime:           jan,
+	}
+	if _, err := q.ReserveDecr(ctx, reservation); err != nil {
+		t.Fatal(err)
+	}
+	assertOverdraft(t, mr, "202401", 3)
+
+	clk.Set(jan.Add(2 * time.Hour))
+	if err := q.MutiDecr(ctx, []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	assertOverdraft(t, mr, "202402", 1)
+
+	// 二月归还一月扣减的额度，抵扣一月的透支
+	if err := q.MutiIncr(ctx, []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 1, Ctime: jan}}); err != nil {
+		t.Fatal(err)
+	}
+	assertOverdraft(t, mr, "202401", 2)
+	assertOverdraft(t, mr, "202402", 1)
+
+	// 意图按记录的月份归还，只归还一次
+	for range 2 {
+		if _, err := q.RefundReservation(ctx, reservation); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertOverdraft(t, mr, "202401", 1)
+	assertOverdraft(t, mr, "202402", 1)
+
+	// 没有扣减时间的按当前月份归还，透支抵扣完之后归还到剩余额度
+	if err := q.MutiIncr(ctx, []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 3}}); err != nil {
+		t.Fatal(err)
+	}
+	assertOverdraft(t, mr, "202401", 1)
+	assertOverdraft(t, mr, "202402", 0)
+	got, err := q.Find(ctx, 1, domain.ChannelSMS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Quota != 1 || got.Overdraft != 0 {
+		t.Fatalf("剩余 %d 透支 %d, 应该是 1 和 0", got.Quota, got.Overdraft)
+	}
+}
+
+func newTestQuotaCache(t *testing.T, policies []domain.OverdraftPolicy, clk clock.Clock) (cache.QuotaCache, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	overdraft, err := domain.NewOverdraftPolicies(policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewQuotaCache(client, overdraft, clk), mr
+}
+
+// assertOverdraft 业务方 1 短信渠道在 month 月透支的条数
+func assertOverdraft(t *testing.T, mr *miniredis.Miniredis, month string, want int) {
+	t.Helper()
+	got := 0
+	if v, err := mr.Get("quota:overdraft:1:SMS:" + month); err == nil {
+		if got, err = strconv.Atoi(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got != want {
+		t.Fatalf("%s 透支 %d 条, 应该是 %d", month, got, want)
+	}
+}
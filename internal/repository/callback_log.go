@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// CallbackLogRepository 回调记录仓储
+type CallbackLogRepository interface {
+	// FindByNotificationIDs 查询通知的回调记录，返回的 Notification 只有ID
+	FindByNotificationIDs(ctx context.Context, notificationIDs []uint64) ([]domain.CallbackLog, error)
+	// FindRetryable 到了重试时间的待回调记录，返回的 Notification 只有ID
+	FindRetryable(ctx context.Context, batchSize int) ([]domain.CallbackLog, error)
+	// Update 更新回调记录的重试次数、下次重试时间、状态和失败原因，Notification 有业务方时一起更新
+	Update(ctx context.Context, logs []domain.CallbackLog) error
+	// FindDeadLetters 按ID升序查询死信记录，返回的 Notification 只有ID和业务方
+	FindDeadLetters(ctx context.Context, filter domain.CallbackDeadLetterFilter) ([]domain.CallbackLog, error)
+	// ResendDeadLetters 业务方的死信记录重新开始回调，重试次数清零，notificationIDs 为空时重新回调全部，返回重新回调的数量
+	ResendDeadLetters(ctx context.Context, bizID int64, notificationIDs []uint64) (int64, error)
+}
+
+var _ CallbackLogRepository = (*callbackLogRepository)(nil)
+
+func NewCallbackLogRepository(d dao.CallbackLogDAO, clk clock.Clock) CallbackLogRepository {
+	return &callbackLogRepository{dao: d, clock: clk}
+}
+
+type callbackLogRepository struct {
+	dao   dao.CallbackLogDAO
+	clock clock.Clock
+}
+
+func (r *callbackLogRepository) FindByNotificationIDs(ctx context.Context, notificationIDs []uint64) ([]domain.CallbackLog, error) {
+	if len(notificationIDs) == 0 {
+		return nil, nil
+	}
+	logs, err := r.dao.FindByNotificationIDs(ctx, notificationIDs)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(logs), nil
+}
+
+func (r *callbackLogRepository) FindRetryable(ctx context.Context, batchSize int) ([]domain.CallbackLog, error) {
+	logs, _, err := r.dao.Find(ctx, r.clock.Now().UnixMilli(), int64(batchSize), 0)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(logs), nil
+}
+
+func (r *callbackLogRepository) Update(ctx context.Context, logs []domain.CallbackLog) error {
+	entities := make([]dao.CallbackLog, 0, len(logs))
+	for _, l := range logs {
+		entities = append(entities, dao.CallbackLog{
+			ID:            l.ID,
+			BizID:         l.Notification.BizID,
+			RetryCount:    l.RetryCount,
+			NextRetryTime: l.NextRetryTime,
+			Status:        l.Status.String(),
+			LastError:     l.LastError,
+		})
+	}
+	return r.dao.Update(ctx, entities)
+}
+
+func (r *callbackLogRepository) FindDeadLetters(ctx context.Context, filter domain.CallbackDeadLetterFilter) ([]domain.CallbackLog, error) {
+	logs, err := r.dao.FindDeadLetters(ctx, filter.BizID, filter.UpdatedSince, filter.AfterID, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(logs), nil
+}
+
+func (r *callbackLogRepository) ResendDeadLetters(ctx context.Context, bizID int64, notificationIDs []uint64) (int64, error) {
+	return r.dao.ResendDeadLetters(ctx, bizID, notificationIDs, r.clock.Now().UnixMilli())
+}
+
+func (r *callbackLogRepository) toDomains(logs []dao.CallbackLog) []domain.CallbackLog {
+	res := make([]domain.CallbackLog, 0, len(logs))
+	for _, l := range logs {
+		res = append(res, domain.CallbackLog{
+			ID:            l.ID,
+			Notification:  domain.Notification{ID: l.NotificationID, BizID: l.BizID},
+			RetryCount:    l.RetryCount,
+			NextRetryTime: l.NextRetryTime,
+			Status:        domain.CallbackLogStatus(l.Status),
+			Callback:      toCallbackOptionsDomain(l.CallbackOptions),
+			LastError:     l.LastError,
+			Utime:         l.Utime,
+		})
+	}
+	return res
+}
+
+func toCallbackOptionsEntity(o domain.CallbackOptions) (dao.CallbackOptions, error) {
+	headers, err := o.MarshalHeaders()
+	if err != nil {
+		return dao.CallbackOptions{}, fmt.Errorf("序列化回调请求头失败: %w", err)
+	}
+	statuses := make([]string, 0, len(o.OnStatus))
+	for _, s := range o.OnStatus {
+		statuses = append(statuses, s.String())
+	}
+	return dao.CallbackOptions{
+		URL:      o.URL,
+		Headers:  headers,
+		OnStatus: strings.Join(statuses, ","),
+	}, nil
+}
+
+func toCallbackOptionsDomain(o dao.CallbackOptions) domain.CallbackOptions {
+	res := domain.CallbackOptions{URL: o.URL}
+	if o.Headers != "" {
+		_ = json.Unmarshal([]byte(o.Headers), &res.Headers)
+	}
+	if o.OnStatus != "" {
+		for _, s := range strings.Split(o.OnStatus, ",") {
+			res.OnStatus = append(res.OnStatus, domain.SendStatus(s))
+		}
+	}
+	return res
+}
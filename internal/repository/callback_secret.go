@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// CallbackSecretRepository 回调签名密钥仓储，密钥加密后落库
+type CallbackSecretRepository interface {
+	// Rotate 换成新密钥，当前密钥在 previousExpireAt 之前继续参与签名
+	Rotate(ctx context.Context, bizID int64, secret string, previousExpireAt time.Time) error
+	// Get 密钥不存在时返回 domain.ErrCallbackSecretNotFound
+	Get(ctx context.Context, bizID int64) (domain.CallbackSecret, error)
+}
+
+var _ CallbackSecretRepository = (*callbackSecretRepository)(nil)
+
+func NewCallbackSecretRepository(d dao.CallbackSecretDAO, cipher encrypt.Cipher) CallbackSecretRepository {
+	return &callbackSecretRepository{dao: d, cipher: cipher}
+}
+
+type callbackSecretRepository struct {
+	dao    dao.CallbackSecretDAO
+	cipher encrypt.Cipher
+}
+
+func (r *callbackSecretRepository) Rotate(ctx context.Context, bizID int64, secret string, previousExpireAt time.Time) error {
+	encrypted, err := r.cipher.Encrypt(ctx, secret)
+	if err != nil {
+		return err
+	}
+	return r.dao.Rotate(ctx, bizID, encrypted, previousExpireAt.UnixMilli())
+}
+
+func (r *callbackSecretRepository) Get(ctx context.Context, bizID int64) (domain.CallbackSecret, error) {
+	s, err := r.dao.Get(ctx, bizID)
+	if err != nil {
+		return domain.CallbackSecret{}, err
+	}
+	secret, err := r.cipher.Decrypt(ctx, s.Secret)
+	if err != nil {
+		return domain.CallbackSecret{}, err
+	}
+	var previous string
+	if s.PreviousSecret != "" {
+		if previous, err = r.cipher.Decrypt(ctx, s.PreviousSecret); err != nil {
+			return domain.CallbackSecret{}, err
+		}
+	}
+	return domain.CallbackSecret{
+		BizID:            s.BizID,
+		Secret:           secret,
+		PreviousSecret:   previous,
+		PreviousExpireAt: time.UnixMilli(s.PreviousExpireTime),
+		Utime:            time.UnixMilli(s.Utime),
+	}, nil
+}
@@ -0,0 +1,62 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BizChannelSetting 业务方渠道开关表，同一个业务方的同一个渠道只有一行，没有记录的渠道默认允许
+type BizChannelSetting struct {
+	ID       int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID    int64  `gorm:"NOT NULL;uniqueIndex:idx_biz_channel_settings_biz_channel,priority:1;comment:'业务配表ID'"`
+	Channel  string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:idx_biz_channel_settings_biz_channel,priority:2;comment:'发送渠道'"`
+	Enabled  bool   `gorm:"NOT NULL;comment:'是否允许使用这个渠道发送'"`
+	Operator string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'最后一次修改的操作人'"`
+	Reason   string `gorm:"type:VARCHAR(256);NOT NULL;DEFAULT:'';comment:'最后一次修改的原因'"`
+	Ctime    int64
+	Utime    int64
+}
+
+// TableName 重命名表
+func (BizChannelSetting) TableName() string {
+	return "biz_channel_settings"
+}
+
+// BizChannelDAO 业务方渠道开关
+type BizChannelDAO interface {
+	// FindByBizID 业务方设置过的所有渠道开关
+	FindByBizID(ctx context.Context, bizID int64) ([]BizChannelSetting, error)
+	// Upsert 已经设置过时更新开关、操作人和原因
+	Upsert(ctx context.Context, setting BizChannelSetting) error
+}
+
+var _ BizChannelDAO = (*bizChannelDAO)(nil)
+
+type bizChannelDAO struct {
+	db *gorm.DB
+}
+
+func NewBizChannelDAO(db *gorm.DB) BizChannelDAO {
+	return &bizChannelDAO{db: db}
+}
+
+func (d *bizChannelDAO) FindByBizID(ctx context.Context, bizID int64) ([]BizChannelSetting, error) {
+	var settings []BizChannelSetting
+	err := d.db.WithContext(ctx).
+		Where("biz_id = ?", bizID).
+		Order("channel").
+		Find(&settings).Error
+	return settings, err
+}
+
+func (d *bizChannelDAO) Upsert(ctx context.Context, setting BizChannelSetting) error {
+	now := time.Now().UnixMilli()
+	setting.Ctime, setting.Utime = now, now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "biz_id"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "operator", "reason", "utime"}),
+	}).Create(&setting).Error
+}
@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BizKeyPolicy 业务方通知 key 的规则，每个业务方一行，没有记录的业务方只限制长度
+type BizKeyPolicy struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID          int64  `gorm:"NOT NULL;uniqueIndex:idx_biz_key_policies_biz_id;comment:'业务配表ID'"`
+	MaxLength      int    `gorm:"NOT NULL;comment:'key 的最大字符数，0 表示 256'"`
+	Charset        string `gorm:"type:VARCHAR(16);NOT NULL;comment:'key 允许的字符集'"`
+	RequiredPrefix string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'key 必须使用的前缀'"`
+	Operator       string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'最后一次修改的操作人'"`
+	Ctime          int64
+	Utime          int64
+}
+
+// TableName 重命名表
+func (BizKeyPolicy) TableName() string {
+	return "biz_key_policies"
+}
+
+// BizKeyPolicyDAO 业务方通知 key 的规则
+type BizKeyPolicyDAO interface {
+	// FindByBizID 业务方没有设置过时返回只有 BizID 的空规则
+	FindByBizID(ctx context.Context, bizID int64) (BizKeyPolicy, error)
+	// Upsert 已经设置过时覆盖整条规则
+	Upsert(ctx context.Context, policy BizKeyPolicy) error
+}
+
+var _ BizKeyPolicyDAO = (*bizKeyPolicyDAO)(nil)
+
+type bizKeyPolicyDAO struct {
+	db *gorm.DB
+}
+
+func NewBizKeyPolicyDAO(db *gorm.DB) BizKeyPolicyDAO {
+	return &bizKeyPolicyDAO{db: db}
+}
+
+func (d *bizKeyPolicyDAO) FindByBizID(ctx context.Context, bizID int64) (BizKeyPolicy, error) {
+	var policy BizKeyPolicy
+	err := d.db.WithContext(ctx).Where("biz_id = ?", bizID).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return BizKeyPolicy{BizID: bizID}, nil
+	}
+	return policy, err
+}
+
+func (d *bizKeyPolicyDAO) Upsert(ctx context.Context, policy BizKeyPolicy) error {
+	now := time.Now().UnixMilli()
+	policy.Ctime, policy.Utime = now, now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "biz_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"max_length", "charset", "required_prefix", "operator", "utime"}),
+	}).Create(&policy).Error
+}
@@ -0,0 +1,109 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BlacklistEntry 渠道接收者黑名单表，同一个渠道的同一个接收者只有一行
+type BlacklistEntry struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	Channel        string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:idx_blacklist_channel_receiver,priority:1;comment:'发送渠道'"`
+	ReceiverIndex  string `gorm:"type:CHAR(64);NOT NULL;uniqueIndex:idx_blacklist_channel_receiver,priority:2;comment:'接收者盲索引'"`
+	Receiver       string `gorm:"type:TEXT;NOT NULL;comment:'接收者，和通知的接收者一样加密存储'"`
+	Category       string `gorm:"type:VARCHAR(32);NOT NULL;comment:'加入黑名单的原因，供应商错误分类'"`
+	Vendor         string `gorm:"type:VARCHAR(32);NOT NULL;DEFAULT:'';comment:'返回拒收的供应商'"`
+	Code           string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'供应商原始错误码'"`
+	NotificationID uint64 `gorm:"NOT NULL;DEFAULT:0;comment:'触发加入黑名单的通知ID'"`
+	ExpireTime     int64  `gorm:"NOT NULL;index:idx_blacklist_expire_time;comment:'过期时间'"`
+	Ctime          int64
+	Utime          int64
+}
+
+// TableName 重命名表
+func (BlacklistEntry) TableName() string {
+	return "receiver_blacklist"
+}
+
+// BlacklistFilter 查询黑名单的条件，为空的条件不过滤
+type BlacklistFilter struct {
+	Channel       string
+	ReceiverIndex string
+	// ExpireAfter 大于 0 时只查询在这之后过期的记录
+	ExpireAfter int64
+	BeforeID    int64
+	Limit       int
+}
+
+// BlacklistDAO 渠道接收者黑名单
+type BlacklistDAO interface {
+	// Upsert 已经在黑名单里时更新原因、来源和过期时间
+	Upsert(ctx context.Context, entry BlacklistEntry) error
+	// FindActive 渠道里在 now 之后才过期的接收者
+	FindActive(ctx context.Context, channel string, receiverIndexes []string, now int64) ([]BlacklistEntry, error)
+	// List 按ID倒序分页查询
+	List(ctx context.Context, filter BlacklistFilter) ([]BlacklistEntry, error)
+	// Delete 删除一个接收者，返回是否删除了记录
+	Delete(ctx context.Context, channel, receiverIndex string) (bool, error)
+}
+
+var _ BlacklistDAO = (*blacklistDAO)(nil)
+
+type blacklistDAO struct {
+	db *gorm.DB
+}
+
+func NewBlacklistDAO(db *gorm.DB) BlacklistDAO {
+	return &blacklistDAO{db: db}
+}
+
+func (d *blacklistDAO) Upsert(ctx context.Context, entry BlacklistEntry) error {
+	now := time.Now().UnixMilli()
+	entry.Ctime, entry.Utime = now, now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "channel"}, {Name: "receiver_index"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"receiver", "category", "vendor", "code", "notification_id", "expire_time", "utime",
+		}),
+	}).Create(&entry).Error
+}
+
+func (d *blacklistDAO) FindActive(ctx context.Context, channel string, receiverIndexes []string, now int64) ([]BlacklistEntry, error) {
+	var entries []BlacklistEntry
+	if len(receiverIndexes) == 0 {
+		return entries, nil
+	}
+	err := d.db.WithContext(ctx).
+		Where("channel = ? AND receiver_index IN ? AND expire_time > ?", channel, receiverIndexes, now).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (d *blacklistDAO) List(ctx context.Context, filter BlacklistFilter) ([]BlacklistEntry, error) {
+	var entries []BlacklistEntry
+	query := d.db.WithContext(ctx).Model(&BlacklistEntry{})
+	if filter.Channel != "" {
+		query = query.Where("channel = ?", filter.Channel)
+	}
+	if filter.ReceiverIndex != "" {
+		query = query.Where("receiver_index = ?", filter.ReceiverIndex)
+	}
+	if filter.ExpireAfter > 0 {
+		query = query.Where("expire_time > ?", filter.ExpireAfter)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&entries).Error
+	return entries, err
+}
+
+func (d *blacklistDAO) Delete(ctx context.Context, channel, receiverIndex string) (bool, error) {
+	res := d.db.WithContext(ctx).
+		Where("channel = ? AND receiver_index = ?", channel, receiverIndex).
+		Delete(&BlacklistEntry{})
+	return res.RowsAffected > 0, res.Error
+}
@@ -2,20 +2,37 @@ package dao
 
 import (
 	"context"
+
 	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
 	"gorm.io/gorm"
-	"time"
 )
 
+// maxCallbackLastErrorLength last_error 列的长度
+const maxCallbackLastErrorLength = 512
+
 // CallbackLog 只有同步立刻发送会缺乏这条记录
 type CallbackLog struct {
 	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'回调记录ID'"`
 	NotificationID uint64 `gorm:"column:notification_id;NOT NULL;uniqueIndex:idx_notification_id;comment:'待回调通知ID'"`
-	RetryCount     int32  `gorm:"type:TINYINT;NOT NULL;DEFAULT:0;comment:'重试次数'"`
-	NextRetryTime  int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'下一次重试的时间戳'"`
-	Status         string `gorm:"type:ENUM('INIT','PENDING','SUCCEEDED','FAILED');NOT NULL;DEFAULT:'INIT';index:idx_status;comment:'回调状态'"`
+	BizID          int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;index:idx_callback_logs_status_biz_id,priority:2;comment:'业务方ID，回调任务处理时补齐'"`
+	RetryCount     int32  `gorm:"type:SMALLINT;NOT NULL;DEFAULT:0;comment:'重试次数'"`
+	NextRetryTime  int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;index:idx_callback_logs_status_next_retry_time,priority:2;comment:'下一次重试的时间戳'"`
+	Status         string `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'INIT';check:chk_callback_logs_status,status IN ('INIT','PENDING','SUCCEEDED','FAILED','DEAD_LETTER');index:idx_callback_logs_status_next_retry_time,priority:1;index:idx_callback_logs_status_utime,priority:1;index:idx_callback_logs_status_biz_id,priority:1;comment:'回调状态'"`
+	LastError      string `gorm:"type:VARCHAR(512);NOT NULL;DEFAULT:'';comment:'最后一次回调失败的原因'"`
 	Ctime          int64
-	Utime          int64
+	Utime          int64 `gorm:"index:idx_callback_logs_status_utime,priority:2"`
+
+	CallbackOptions `gorm:"embedded"`
+}
+
+// CallbackOptions 调用方在发送时指定的回调设置，都为空时发送成功后回调业务方的默认地址
+type CallbackOptions struct {
+	URL string `gorm:"column:url;type:VARCHAR(512);NOT NULL;DEFAULT:'';comment:'回调地址，为空使用业务方的默认地址'"`
+	// Headers 回调时额外带上的请求头，JSON
+	Headers string `gorm:"column:headers;type:VARCHAR(4096);NOT NULL;DEFAULT:'';comment:'回调时额外带上的请求头，JSON'"`
+	// OnStatus 需要回调的发送结果，逗号分隔，为空只回调 SUCCEEDED
+	OnStatus string `gorm:"column:on_status;type:VARCHAR(32);NOT NULL;DEFAULT:'';comment:'需要回调的发送结果'"`
 }
 
 // TableName 重命名表
@@ -26,27 +43,27 @@ func (CallbackLog) TableName() string {
 type CallbackLogDAO interface {
 	Find(ctx context.Context, startTime, batchSize, startID int64) (logs []CallbackLog, nextStartID int64, err error)
 	FindByNotificationIDs(ctx context.Context, notificationIDs []uint64) ([]CallbackLog, error)
+	// Update 更新重试次数、下次重试时间、状态和失败原因，BizID 为 0 时不更新业务方
 	Update(ctx context.Context, logs []CallbackLog) error
+	// FindDeadLetters 死信记录，bizID 为 0 时不过滤业务方，按 ID 升序
+	FindDeadLetters(ctx context.Context, bizID, updatedSince, afterID int64, limit int) ([]CallbackLog, error)
+	// ResendDeadLetters 业务方的死信记录重新开始回调，notificationIDs 为空时重新回调这个业务方的全部死信，返回更新的数量
+	ResendDeadLetters(ctx context.Context, bizID int64, notificationIDs []uint64, now int64) (int64, error)
 }
 
 type callbackLogDAO struct {
-	db *gorm.DB
+	db    *gorm.DB
+	clock clock.Clock
 }
 
-func NewCallbackLogDAO(db *gorm.DB) CallbackLogDAO {
-	return &callbackLogDAO{db: db}
+func NewCallbackLogDAO(db *gorm.DB, clk clock.Clock) CallbackLogDAO {
+	return &callbackLogDAO{db: db, clock: clk}
 }
 
 func (c *callbackLogDAO) Find(ctx context.Context, startTime, batchSize, startID int64) (logs []CallbackLog, nextStartID int64, err error) {
 	nextStartID = 0
 
-	result := c.db.WithContext(ctx).Model(&CallbackLog{}).
-		Where("next_retry_time <= ?", startTime).
-		Where("status = ?", domain.CallbackLogStatusPending).
-		Where("id > ?", startID).
-		Order("id ASC").
-		Limit(int(batchSize)).
-		Find(&logs)
+	result := findRetryable(c.db.WithContext(ctx), startTime, batchSize, startID).Find(&logs)
 
 	if result.Error != nil {
 		return logs, nextStartID, result.Error
@@ -59,6 +76,17 @@ func (c *callbackLogDAO) Find(ctx context.Context, startTime, batchSize, startID
 	return logs, nextStartID, nil
 }
 
+// findRetryable 待重试的回调记录，走 idx_callback_logs_status_next_retry_time 索引
+// 待重试的记录只占很小一部分，按索引范围扫描之后再按 id 排序
+func findRetryable(db *gorm.DB, startTime, batchSize, startID int64) *gorm.DB {
+	return db.Model(&CallbackLog{}).
+		Where("status = ?", domain.CallbackLogStatusPending).
+		Where("next_retry_time <= ?", startTime).
+		Where("id > ?", startID).
+		Order("id ASC").
+		Limit(int(batchSize))
+}
+
 func (c *callbackLogDAO) FindByNotificationIDs(ctx context.Context, notificationIDs []uint64) ([]CallbackLog, error) {
 	var logs []CallbackLog
 	err := c.db.WithContext(ctx).Where("notification_id IN (?)", notificationIDs).Find(&logs).Error
@@ -69,16 +97,21 @@ func (c *callbackLogDAO) Update(ctx context.Context, logs []CallbackLog) error {
 	if len(logs) == 0 {
 		return nil
 	}
-	utime := time.Now().UnixMilli()
+	utime := c.clock.Now().UnixMilli()
 	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		for _, log := range logs {
-			result := tx.Model(&CallbackLog{ID: log.ID}).
-				Updates(map[string]any{
-					"retry_count":     log.RetryCount,
-					"next_retry_time": log.NextRetryTime,
-					"status":          log.Status,
-					"utime":           utime,
-				})
+			updates := map[string]any{
+				"retry_count":     log.RetryCount,
+				"next_retry_time": log.NextRetryTime,
+				"status":          log.Status,
+				"last_error":      truncateRunes(log.LastError, maxCallbackLastErrorLength),
+				"utime":           utime,
+			}
+			// 通知已经被清理时不知道业务方
+			if log.BizID != 0 {
+				updates["biz_id"] = log.BizID
+			}
+			result := tx.Model(&CallbackLog{ID: log.ID}).Updates(updates)
 			if result.Error != nil {
 				return result.Error
 			}
@@ -86,3 +119,54 @@ func (c *callbackLogDAO) Update(ctx context.Context, logs []CallbackLog) error {
 		return nil
 	})
 }
+
+func (c *callbackLogDAO) FindDeadLetters(ctx context.Context, bizID, updatedSince, afterID int64, limit int) ([]CallbackLog, error) {
+	query := c.db.WithContext(ctx).
+		Where("status = ? AND id > ?", domain.CallbackLogStatusDeadLetter.String(), afterID)
+	if bizID != 0 {
+		// 走 idx_callback_logs_status_biz_id 索引
+		query = query.Where("biz_id = ?", bizID)
+	}
+	if updatedSince > 0 {
+		// 没有指定业务方时走 idx_callback_logs_status_utime 索引
+		query = query.Where("utime >= ?", updatedSince)
+	}
+	var logs []CallbackLog
+	err := query.Order("id ASC").Limit(limit).Find(&logs).Error
+	return logs, err
+}
+
+func (c *callbackLogDAO) ResendDeadLetters(ctx context.Context, bizID int64, notificationIDs []uint64, now int64) (int64, error) {
+	query := c.db.WithContext(ctx).Model(&CallbackLog{}).
+		Where("status = ? AND biz_id = ?", domain.CallbackLogStatusDeadLetter.String(), bizID)
+	if len(notificationIDs) > 0 {
+		query = query.Where("notification_id IN ?", notificationIDs)
+	}
+	result := query.Updates(map[string]any{
+		"status":          domain.CallbackLogStatusPending.String(),
+		"retry_count":     0,
+		"next_retry_time": now,
+		"utime":           now,
+	})
+	return result.RowsAffected, result.Error
+}
+
+// markCallbackPending 通知发送结束后，订阅了这个发送结果的回调记录可以开始回调了
+// on_status 为空的只订阅了 SUCCEEDED
+func markCallbackPending(tx *gorm.DB, notificationIDs []uint64, status domain.SendStatus, now int64) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+	query := tx.Model(&CallbackLog{}).
+		Where("notification_id IN ? AND status = ?", notificationIDs, domain.CallbackLogStatusInit.String())
+	if status == domain.SendStatusSucceeded {
+		query = query.Where("(on_status = '' OR on_status LIKE ?)", "%"+status.String()+"%")
+	} else {
+		query = query.Where("on_status LIKE ?", "%"+status.String()+"%")
+	}
+	return query.Updates(map[string]any{
+		"status":          domain.CallbackLogStatusPending.String(),
+		"next_retry_time": now,
+		"utime":           now,
+	}).Error
+}
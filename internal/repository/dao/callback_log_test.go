@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao/migrations"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// callbackLogBenchDSNEnv 基准测试使用的 MySQL，会执行迁移并写入测试数据，不要指向生产库
+	callbackLogBenchDSNEnv = "NOTIFICATION_BENCH_MYSQL_DSN"
+	// callbackLogBenchBaseID 测试数据的通知ID从这里开始，结束后按这个范围删除
+	callbackLogBenchBaseID = uint64(9_000_000_000_000)
+	callbackLogBenchRows   = 50000
+	// callbackLogBenchPending 待重试的记录只占一小部分，和线上的分布一致
+	callbackLogBenchPending = 500
+)
+
+// BenchmarkCallbackLogDAO_Find 回调重试扫描必须走 (status, next_retry_time) 索引，
+// 已结束的回调记录越积越多时扫描耗时不能跟着增长
+func BenchmarkCallbackLogDAO_Find(b *testing.B) {
+	db := newCallbackLogBenchDB(b)
+	now := time.Now().UnixMilli()
+
+	stmt := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var logs []CallbackLog
+		return findRetryable(tx, now, 100, 0).Find(&logs)
+	})
+	plan := explain(b, db, stmt)
+	b.Logf("EXPLAIN %s\n%v", stmt, plan)
+	if key := plan["key"]; key != "idx_callback_logs_status_next_retry_time" {
+		b.Fatalf("回调重试扫描没有使用 idx_callback_logs_status_next_retry_time 索引: key = %q", key)
+	}
+
+	d := NewCallbackLogDAO(db, clock.Real())
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logs, _, err := d.Find(ctx, now, 100, 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(logs) == 0 {
+			b.Fatal("没有查到待重试的回调记录")
+		}
+	}
+}
+
+func newCallbackLogBenchDB(b *testing.B) *gorm.DB {
+	b.Helper()
+	dsn := os.Getenv(callbackLogBenchDSNEnv)
+	if dsn == "" {
+		b.Skipf("没有设置 %s，跳过", callbackLogBenchDSNEnv)
+	}
+	migrator, err := migrations.New(config.DatabaseConfig{Driver: config.DriverMySQL, DSN: dsn})
+	if err != nil {
+		b.Fatal(err)
+	}
+	err = migrator.Up()
+	_ = migrator.Close()
+	if err != nil {
+		b.Fatal(err)
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		b.Fatal(err)
+	}
+	cleanup := func() {
+		db.Where("notification_id >= ?", callbackLogBenchBaseID).Delete(&CallbackLog{})
+	}
+	cleanup()
+	b.Cleanup(cleanup)
+
+	now := time.Now().UnixMilli()
+	logs := make([]CallbackLog, 0, callbackLogBenchRows)
+	for i := 0; i < callbackLogBenchRows; i++ {
+		status := domain.CallbackLogStatusSuccess
+		switch {
+		case i%(callbackLogBenchRows/callbackLogBenchPending) == 0:
+			status = domain.CallbackLogStatusPending
+		case i%10 == 0:
+			status = domain.CallbackLogStatusFailed
+		}
+		logs = append(logs, CallbackLog{
+			NotificationID: callbackLogBenchBaseID + uint64(i),
+			NextRetryTime:  now - int64(i),
+			Status:         status.String(),
+			Ctime:          now,
+			Utime:          now,
+		})
+	}
+	if err = db.CreateInBatches(logs, 1000).Error; err != nil {
+		b.Fatal(err)
+	}
+	// 更新统计信息，让优化器看到真实的数据分布
+	if err = db.Exec("ANALYZE TABLE callback_logs").Error; err != nil {
+		b.Fatal(err)
+	}
+	return db
+}
+
+// explain 返回执行计划第一行，列名到值
+func explain(b *testing.B, db *gorm.DB, stmt string) map[string]string {
+	b.Helper()
+	rows, err := db.Raw("EXPLAIN " + stmt).Rows()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if !rows.Next() {
+		b.Fatal("EXPLAIN 没有返回结果")
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		b.Fatal(err)
+	}
+	plan := make(map[string]string, len(columns))
+	for i, c := range columns {
+		plan[c] = values[i].String
+	}
+	if err = rows.Err(); err != nil {
+		b.Fatal(err)
+	}
+	return plan
+}
@@ -0,0 +1,73 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CallbackSecret 回调签名密钥表，每个业务方一条，密钥以密文存储
+type CallbackSecret struct {
+	BizID              int64  `gorm:"primaryKey;autoIncrement:false;comment:'业务配表ID'"`
+	Secret             string `gorm:"type:VARCHAR(512);NOT NULL;comment:'当前密钥（密文）'"`
+	PreviousSecret     string `gorm:"type:VARCHAR(512);NOT NULL;DEFAULT:'';comment:'轮换前的密钥（密文）'"`
+	PreviousExpireTime int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'旧密钥停止签名的时间戳'"`
+	Ctime              int64
+	Utime              int64
+}
+
+// TableName 重命名表
+func (CallbackSecret) TableName() string {
+	return "callback_secrets"
+}
+
+type CallbackSecretDAO interface {
+	// Rotate 换成新密钥，当前密钥变成旧密钥，在 previousExpireTime 之前继续参与签名
+	Rotate(ctx context.Context, bizID int64, secret string, previousExpireTime int64) error
+	Get(ctx context.Context, bizID int64) (CallbackSecret, error)
+}
+
+type callbackSecretDAO struct {
+	db *gorm.DB
+}
+
+func NewCallbackSecretDAO(db *gorm.DB) CallbackSecretDAO {
+	return &callbackSecretDAO{db: db}
+}
+
+func (d *callbackSecretDAO) Rotate(ctx context.Context, bizID int64, secret string, previousExpireTime int64) error {
+	now := time.Now().UnixMilli()
+	// 在一条语句里把当前密钥挪到旧密钥，并发轮换也不会丢失中间的密钥
+	// MySQL 按顺序赋值，PostgreSQL 使用更新前的值，previous_secret 写在前面两者结果一致
+	result := d.db.WithContext(ctx).Exec(
+		"UPDATE callback_secrets SET previous_secret = secret, secret = ?, previous_expire_time = ?, utime = ? WHERE biz_id = ?",
+		secret, previousExpireTime, now, bizID)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	// 第一次生成密钥，没有旧密钥
+	err := d.db.WithContext(ctx).Create(&CallbackSecret{
+		BizID:  bizID,
+		Secret: secret,
+		Ctime:  now,
+		Utime:  now,
+	}).Error
+	if isUniqueConstraintError(err) {
+		// 并发生成，对方已经插入，重新走轮换
+		return d.Rotate(ctx, bizID, secret, previousExpireTime)
+	}
+	return err
+}
+
+func (d *callbackSecretDAO) Get(ctx context.Context, bizID int64) (CallbackSecret, error) {
+	var secret CallbackSecret
+	err := d.db.WithContext(ctx).Where("biz_id = ?", bizID).First(&secret).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return CallbackSecret{}, fmt.Errorf("%w: biz_id=%d", domain.ErrCallbackSecretNotFound, bizID)
+	}
+	return secret, err
+}
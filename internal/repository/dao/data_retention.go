@@ -0,0 +1,166 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"gorm.io/gorm"
+)
+
+const (
+	// 匿名化之后的接收者、模板参数和审计快照，明文存储，不需要解密
+	erasedReceivers      = "[]"
+	erasedTemplateParams = "{}"
+	erasedSnapshot       = "{}"
+)
+
+// ReceiverErasure 接收者数据擦除记录表
+type ReceiverErasure struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement;comment:'擦除记录ID'"`
+	BizID         int64  `gorm:"type:BIGINT;NOT NULL;index:idx_erasures_biz_id_receiver_index,priority:1;comment:'业务配表ID'"`
+	ReceiverIndex string `gorm:"type:CHAR(64);NOT NULL;index:idx_erasures_biz_id_receiver_index,priority:2;comment:'接收者盲索引，不保存接收者本身'"`
+	Operator      string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'操作人'"`
+	Reason        string `gorm:"type:VARCHAR(256);NOT NULL;DEFAULT:'';comment:'擦除原因'"`
+	Affected      int64  `gorm:"NOT NULL;DEFAULT:0;comment:'被擦除的通知数量'"`
+	Ctime         int64
+}
+
+// TableName 重命名表
+func (ReceiverErasure) TableName() string {
+	return "receiver_erasures"
+}
+
+// DataRetentionDAO 数据保留策略和擦除记录
+type DataRetentionDAO interface {
+	// FindExpiredIDs 查找保留策略生效范围内、创建时间早于 cutoff 的已结束通知
+	// 匿名化策略会跳过已经擦除过的通知
+	FindExpiredIDs(ctx context.Context, scope domain.RetentionScope, cutoff int64, limit int) ([]uint64, error)
+	// Anonymize 清空接收者、模板参数、接收者索引、已读回执和审计快照，保留通知记录
+	Anonymize(ctx context.Context, ids []uint64) (int64, error)
+	// Purge 删除通知以及关联的接收者索引、已读回执、回调记录、发送尝试和审计日志
+	Purge(ctx context.Context, ids []uint64) (int64, error)
+	// CreateErasure 记录一次接收者数据擦除
+	CreateErasure(ctx context.Context, erasure ReceiverErasure) (ReceiverErasure, error)
+	// DeleteFinishedCallbackLogs 删除最后更新时间早于 cutoff 的已结束（成功、失败或者死信）回调记录，返回删除的数量
+	DeleteFinishedCallbackLogs(ctx context.Context, cutoff int64, limit int) (int64, error)
+}
+
+type dataRetentionDAO struct {
+	db    *gorm.DB
+	clock clock.Clock
+}
+
+func NewDataRetentionDAO(db *gorm.DB, clk clock.Clock) DataRetentionDAO {
+	return &dataRetentionDAO{db: db, clock: clk}
+}
+
+func (d *dataRetentionDAO) FindExpiredIDs(ctx context.Context, scope domain.RetentionScope, cutoff int64, limit int) ([]uint64, error) {
+	query := d.db.WithContext(ctx).Model(&Notification{}).
+		Select("id").
+		Where("ctime < ? AND status IN ?", cutoff, []string{
+			domain.SendStatusSucceeded.String(),
+			domain.SendStatusFailed.String(),
+			domain.SendStatusCanceled.String(),
+		})
+	if scope.Policy.Action == domain.RetentionActionAnonymize {
+		query = query.Where("erase_time = 0")
+	}
+	query = withRetentionScope(query, scope.Policy)
+	for _, ex := range scope.Excludes {
+		query = query.Not(withRetentionScope(d.db.Session(&gorm.Session{NewDB: true}), ex))
+	}
+	var ids []uint64
+	err := query.Order("id").Limit(limit).Find(&ids).Error
+	return ids, err
+}
+
+// withRetentionScope BizID 为 0、Channel 为空的维度不做限制
+func withRetentionScope(db *gorm.DB, policy domain.RetentionPolicy) *gorm.DB {
+	if policy.BizID != 0 {
+		db = db.Where("biz_id = ?", policy.BizID)
+	}
+	if policy.Channel != "" {
+		db = db.Where("channel = ?", policy.Channel.String())
+	}
+	return db
+}
+
+func (d *dataRetentionDAO) Anonymize(ctx context.Context, ids []uint64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	now := d.clock.Now().UnixMilli()
+	var affected int64
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Notification{}).
+			Where("id IN ? AND erase_time = 0", ids).
+			Updates(map[string]any{
+				"receivers":       erasedReceivers,
+				"template_params": erasedTemplateParams,
+				"receiver_params": nil,
+				"erase_time":      now,
+				"version":         gorm.Expr("version + 1"),
+				"utime":           now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		// 已读回执里有接收者，和接收者索引一起删除，已读率的分子分母同时减少；收件箱按接收者索引查询，也一起删除
+		for _, table := range []any{&NotificationReceiver{}, &NotificationRead{}, &NotificationInbox{}} {
+			if err := tx.Where("notification_id IN ?", ids).Delete(table).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&NotificationAuditLog{}).
+			Where("notification_id IN ?", ids).
+			Updates(map[string]any{
+				"before": erasedSnapshot,
+				"after":  erasedSnapshot,
+			}).Error
+	})
+	return affected, err
+}
+
+func (d *dataRetentionDAO) Purge(ctx context.Context, ids []uint64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	var affected int64
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []any{&NotificationReceiver{}, &NotificationInbox{}, &NotificationLabel{}, &NotificationRead{}, &CallbackLog{}, &SendAttempt{}, &NotificationAuditLog{}} {
+			if err := tx.Where("notification_id IN ?", ids).Delete(table).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Where("id IN ?", ids).Delete(&Notification{})
+		affected = result.RowsAffected
+		return result.Error
+	})
+	return affected, err
+}
+
+func (d *dataRetentionDAO) CreateErasure(ctx context.Context, erasure ReceiverErasure) (ReceiverErasure, error) {
+	erasure.Ctime = d.clock.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Create(&erasure).Error
+	return erasure, err
+}
+
+func (d *dataRetentionDAO) DeleteFinishedCallbackLogs(ctx context.Context, cutoff int64, limit int) (int64, error) {
+	var ids []int64
+	// 走 idx_callback_logs_status_utime 索引，不排序，避免在大量已结束的记录上排序
+	err := d.db.WithContext(ctx).Model(&CallbackLog{}).
+		Where("status IN ? AND utime < ?", []string{
+			domain.CallbackLogStatusSuccess.String(),
+			domain.CallbackLogStatusFailed.String(),
+			domain.CallbackLogStatusDeadLetter.String(),
+		}, cutoff).
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	result := d.db.WithContext(ctx).Where("id IN ?", ids).Delete(&CallbackLog{})
+	return result.RowsAffected, result.Error
+}
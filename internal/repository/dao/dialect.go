@@ -0,0 +1,34 @@
+package dao
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	mysqlUniqueIndexErrNo     uint16 = 1062
+	postgresUniqueViolationNo        = "23505"
+)
+
+// isUniqueConstraintError 检查是否是唯一索引冲突错误，兼容 MySQL 和 Postgres
+func isUniqueConstraintError(err error) bool {
+	if err == nil {
+		return false
+	}
+	// 开启了 TranslateError 的情况下，驱动已经翻译好了
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	me := new(mysql.MySQLError)
+	if errors.As(err, &me) {
+		return me.Number == mysqlUniqueIndexErrNo
+	}
+	pe := new(pgconn.PgError)
+	if errors.As(err, &pe) {
+		return pe.Code == postgresUniqueViolationNo
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DigestGroup 合并分组表，同一个接收者在同一个合并窗口内只有一行
+type DigestGroup struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID          int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_digest_groups_window,priority:1;comment:'业务配表ID'"`
+	Channel        string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:idx_digest_groups_window,priority:2;comment:'发送渠道'"`
+	ReceiverIndex  string `gorm:"type:CHAR(64);NOT NULL;uniqueIndex:idx_digest_groups_window,priority:3;comment:'接收者盲索引'"`
+	WindowStart    int64  `gorm:"NOT NULL;uniqueIndex:idx_digest_groups_window,priority:4;comment:'合并窗口开始时间'"`
+	WindowEnd      int64  `gorm:"NOT NULL;index:idx_digest_groups_due,priority:2;comment:'合并窗口结束时间'"`
+	TemplateID     int64  `gorm:"type:BIGINT;NOT NULL;comment:'摘要模板ID'"`
+	Receiver       string `gorm:"type:TEXT;NOT NULL;comment:'接收者，加密存储，发送之后清空'"`
+	Status         string `gorm:"type:VARCHAR(16);NOT NULL;index:idx_digest_groups_due,priority:1;comment:'状态'"`
+	ItemCount      int    `gorm:"type:INT;NOT NULL;DEFAULT:0;comment:'合并的通知数量'"`
+	NotificationID uint64 `gorm:"NOT NULL;DEFAULT:0;comment:'摘要消息的通知ID'"`
+	Ctime          int64
+	Utime          int64
+}
+
+// TableName 重命名表
+func (DigestGroup) TableName() string {
+	return "digest_groups"
+}
+
+// DigestItem 进入合并窗口的通知，摘要消息发送之后删除
+type DigestItem struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	GroupID       int64  `gorm:"type:BIGINT;NOT NULL;index:idx_digest_items_group_id;comment:'合并分组ID'"`
+	BizID         int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_digest_items_key,priority:1;comment:'业务配表ID'"`
+	Key           string `gorm:"type:VARCHAR(256);NOT NULL;uniqueIndex:idx_digest_items_key,priority:2;comment:'业务内唯一标识'"`
+	ReceiverIndex string `gorm:"type:CHAR(64);NOT NULL;uniqueIndex:idx_digest_items_key,priority:3;comment:'接收者盲索引'"`
+	Summary       string `gorm:"type:TEXT;NOT NULL;comment:'摘要，加密存储'"`
+	Ctime         int64
+}
+
+// TableName 重命名表
+func (DigestItem) TableName() string {
+	return "digest_items"
+}
+
+// DigestEntry 一条通知的一个接收者，加入 Group 对应的合并分组
+type DigestEntry struct {
+	Group DigestGroup
+	Item  DigestItem
+}
+
+// DigestDAO 合并发送
+type DigestDAO interface {
+	// AddItems 在同一个事务里加入合并分组，分组不存在时创建
+	// (biz_id, key, receiver_index) 冲突时整体回滚并返回 domain.ErrNotificationDuplicate
+	AddItems(ctx context.Context, entries []DigestEntry) error
+	// FindDueGroups 合并窗口在 before 之前结束还没有发送的分组
+	FindDueGroups(ctx context.Context, before int64, limit int) ([]DigestGroup, error)
+	// FindItems 分组内最早的 limit 条通知
+	FindItems(ctx context.Context, groupID int64, limit int) ([]DigestItem, error)
+	// MarkSent 标记分组已发送，清空接收者并删除分组内的通知
+	MarkSent(ctx context.Context, groupID int64, notificationID uint64) error
+}
+
+var _ DigestDAO = (*digestDAO)(nil)
+
+type digestDAO struct {
+	db    *gorm.DB
+	clock clock.Clock
+}
+
+func NewDigestDAO(db *gorm.DB, clk clock.Clock) DigestDAO {
+	return &digestDAO{db: db, clock: clk}
+}
+
+func (d *digestDAO) AddItems(ctx context.Context, entries []DigestEntry) error {
+	now := d.clock.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			group := entry.Group
+			group.Status = domain.DigestGroupStatusOpen.String()
+			group.Ctime, group.Utime = now, now
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&group).Error; err != nil {
+				return err
+			}
+			var existing DigestGroup
+			err := tx.Where("biz_id = ? AND channel = ? AND receiver_index = ? AND window_start = ?",
+				group.BizID, group.Channel, group.ReceiverIndex, group.WindowStart).
+				First(&existing).Error
+			if err != nil {
+				return err
+			}
+			if existing.Status != domain.DigestGroupStatusOpen.String() {
+				return fmt.Errorf("合并窗口已经发送: groupID = %d", existing.ID)
+			}
+			item := entry.Item
+			item.GroupID = existing.ID
+			item.Ctime = now
+			if err = tx.Create(&item).Error; err != nil {
+				if isUniqueConstraintError(err) {
+					return fmt.Errorf("%w: bizID = %d, key = %s", domain.ErrNotificationDuplicate, item.BizID, item.Key)
+				}
+				return err
+			}
+			err = tx.Model(&DigestGroup{}).Where("id = ?", existing.ID).
+				Updates(map[string]any{
+					"item_count": gorm.Expr("item_count + 1"),
+					"utime":      now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *digestDAO) FindDueGroups(ctx context.Context, before int64, limit int) ([]DigestGroup, error) {
+	var groups []DigestGroup
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND window_end <= ?", domain.DigestGroupStatusOpen.String(), before).
+		Order("window_end").
+		Limit(limit).
+		Find(&groups).Error
+	return groups, err
+}
+
+func (d *digestDAO) FindItems(ctx context.Context, groupID int64, limit int) ([]DigestItem, error) {
+	var items []DigestItem
+	err := d.db.WithContext(ctx).
+		Where("group_id = ?", groupID).
+		Order("id").
+		Limit(limit).
+		Find(&items).Error
+	return items, err
+}
+
+func (d *digestDAO) MarkSent(ctx context.Context, groupID int64, notificationID uint64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&DigestGroup{}).
+			Where("id = ? AND status = ?", groupID, domain.DigestGroupStatusOpen.String()).
+			Updates(map[string]any{
+				"status":          domain.DigestGroupStatusSent.String(),
+				"notification_id": notificationID,
+				"receiver":        "",
+				"utime":           d.clock.Now().UnixMilli(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("group_id = ?", groupID).Delete(&DigestItem{}).Error
+	})
+}
@@ -0,0 +1,110 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"gorm.io/gorm"
+)
+
+// Escalation 跨渠道升级链表
+type Escalation struct {
+	ID    int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_escalations_biz_id_key,priority:1;comment:'业务配表ID'"`
+	Key   string `gorm:"type:VARCHAR(256);NOT NULL;uniqueIndex:idx_escalations_biz_id_key,priority:2;comment:'业务内唯一标识'"`
+	// Steps 步骤的 JSON，里面有接收者，整体加密存储
+	Steps        string `gorm:"type:TEXT;NOT NULL;comment:'升级步骤，加密的JSON'"`
+	StepCount    int    `gorm:"type:INT;NOT NULL;comment:'步骤数量'"`
+	CurrentStep  int    `gorm:"type:INT;NOT NULL;DEFAULT:-1;comment:'已经发送的最后一步，-1 表示还没有发送'"`
+	Status       string `gorm:"type:VARCHAR(16);NOT NULL;index:idx_escalations_due,priority:1;comment:'状态'"`
+	NextStepTime int64  `gorm:"NOT NULL;index:idx_escalations_due,priority:2;comment:'进入下一步的时间'"`
+	AckTime      int64  `gorm:"NOT NULL;DEFAULT:0;comment:'确认时间'"`
+	AckBy        string `gorm:"type:VARCHAR(256);NOT NULL;DEFAULT:'';comment:'确认人'"`
+	Version      int    `gorm:"type:INT;NOT NULL;DEFAULT:1;comment:'版本号，用于CAS操作'"`
+	Ctime        int64
+	Utime        int64
+}
+
+// TableName 重命名表
+func (Escalation) TableName() string {
+	return "escalations"
+}
+
+// EscalationDAO 跨渠道升级链
+type EscalationDAO interface {
+	// Create 创建升级链，(biz_id, key) 冲突时返回 domain.ErrEscalationDuplicate
+	Create(ctx context.Context, e Escalation) (Escalation, error)
+	GetByKey(ctx context.Context, bizID int64, key string) (Escalation, error)
+	// FindDue 到了进入下一步时间的进行中的升级链
+	FindDue(ctx context.Context, now int64, limit int) ([]Escalation, error)
+	// Update 按版本号更新进度和状态，只能更新进行中的升级链，版本不一致时返回 domain.ErrEscalationChanged
+	Update(ctx context.Context, e Escalation) error
+}
+
+var _ EscalationDAO = (*escalationDAO)(nil)
+
+type escalationDAO struct {
+	db    *gorm.DB
+	clock clock.Clock
+}
+
+func NewEscalationDAO(db *gorm.DB, clk clock.Clock) EscalationDAO {
+	return &escalationDAO{db: db, clock: clk}
+}
+
+func (d *escalationDAO) Create(ctx context.Context, e Escalation) (Escalation, error) {
+	now := d.clock.Now().UnixMilli()
+	e.Ctime, e.Utime = now, now
+	e.Version = 1
+	if err := d.db.WithContext(ctx).Create(&e).Error; err != nil {
+		if isUniqueConstraintError(err) {
+			return Escalation{}, fmt.Errorf("%w: bizID = %d, key = %s", domain.ErrEscalationDuplicate, e.BizID, e.Key)
+		}
+		return Escalation{}, err
+	}
+	return e, nil
+}
+
+func (d *escalationDAO) GetByKey(ctx context.Context, bizID int64, key string) (Escalation, error) {
+	var e Escalation
+	err := d.db.WithContext(ctx).Where(map[string]any{"biz_id": bizID, "key": key}).First(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Escalation{}, fmt.Errorf("%w: bizID = %d, key = %s", domain.ErrEscalationNotFound, bizID, key)
+	}
+	return e, err
+}
+
+func (d *escalationDAO) FindDue(ctx context.Context, now int64, limit int) ([]Escalation, error) {
+	var res []Escalation
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND next_step_time <= ?", domain.EscalationStatusActive.String(), now).
+		Order("next_step_time").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *escalationDAO) Update(ctx context.Context, e Escalation) error {
+	result := d.db.WithContext(ctx).Model(&Escalation{}).
+		Where("id = ? AND version = ? AND status = ?", e.ID, e.Version, domain.EscalationStatusActive.String()).
+		Updates(map[string]any{
+			"steps":          e.Steps,
+			"current_step":   e.CurrentStep,
+			"status":         e.Status,
+			"next_step_time": e.NextStepTime,
+			"ack_time":       e.AckTime,
+			"ack_by":         e.AckBy,
+			"version":        gorm.Expr("version + 1"),
+			"utime":          d.clock.Now().UnixMilli(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id = %d, version = %d", domain.ErrEscalationChanged, e.ID, e.Version)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExportCheckpoint 导出进度表，每个导出任务一行
+type ExportCheckpoint struct {
+	Name     string `gorm:"type:VARCHAR(64);primaryKey;comment:'导出任务名称'"`
+	LastTime int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'已经导出的最后一条通知的更新时间'"`
+	LastID   uint64 `gorm:"type:BIGINT UNSIGNED;NOT NULL;DEFAULT:0;comment:'已经导出的最后一条通知的ID'"`
+	Ctime    int64
+	Utime    int64
+}
+
+// TableName 重命名表
+func (ExportCheckpoint) TableName() string {
+	return "export_checkpoints"
+}
+
+// ExportDAO 按更新时间增量读取通知，并记录导出进度
+type ExportDAO interface {
+	// FindChanged 查询 (utime, id) 在 (lastTime, lastID) 之后、utime 早于 until 的通知，按 (utime, id) 升序
+	FindChanged(ctx context.Context, lastTime int64, lastID uint64, until int64, limit int) ([]Notification, error)
+	// GetCheckpoint 没有记录时返回零值
+	GetCheckpoint(ctx context.Context, name string) (ExportCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, cp ExportCheckpoint) error
+}
+
+var _ ExportDAO = (*exportDAO)(nil)
+
+type exportDAO struct {
+	db *gorm.DB
+}
+
+func NewExportDAO(db *gorm.DB) ExportDAO {
+	return &exportDAO{db: db}
+}
+
+func (d *exportDAO) FindChanged(ctx context.Context, lastTime int64, lastID uint64, until int64, limit int) ([]Notification, error) {
+	var notifications []Notification
+	// 展开成 OR 而不是行比较，MySQL 和 Postgres 都能用上 (utime, id) 索引
+	err := d.db.WithContext(ctx).
+		Where("(utime > ? OR (utime = ? AND id > ?)) AND utime < ?", lastTime, lastTime, lastID, until).
+		Order("utime, id").
+		Limit(limit).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+func (d *exportDAO) GetCheckpoint(ctx context.Context, name string) (ExportCheckpoint, error) {
+	var cp ExportCheckpoint
+	err := d.db.WithContext(ctx).Where("name = ?", name).First(&cp).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ExportCheckpoint{Name: name}, nil
+	}
+	return cp, err
+}
+
+func (d *exportDAO) SaveCheckpoint(ctx context.Context, cp ExportCheckpoint) error {
+	now := time.Now().UnixMilli()
+	cp.Ctime, cp.Utime = now, now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_time", "last_id", "utime"}),
+	}).Create(&cp).Error
+}
@@ -1,12 +0,0 @@
-package dao
-
-import "gorm.io/gorm"
-
-func InitTable(db *gorm.DB) {
-	// todo AutoMigrate all tables
-	db.AutoMigrate(
-		Notification{},
-		CallbackLog{},
-		Quota{},
-	)
-}
@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"gorm.io/gorm"
+)
+
+// LocalTimeCohort 按本地时间发送的时区分组表，一条通知的每个时区一行
+type LocalTimeCohort struct {
+	ID             int64          `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID          int64          `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_local_time_cohorts_key,priority:1;comment:'业务配表ID'"`
+	Key            string         `gorm:"type:VARCHAR(256);NOT NULL;uniqueIndex:idx_local_time_cohorts_key,priority:2;comment:'业务内唯一标识'"`
+	Timezone       string         `gorm:"type:VARCHAR(64);NOT NULL;uniqueIndex:idx_local_time_cohorts_key,priority:3;comment:'IANA 时区名称'"`
+	SendTime       int64          `gorm:"NOT NULL;index:idx_local_time_cohorts_due,priority:2;comment:'本地发送时间'"`
+	EndTime        int64          `gorm:"NOT NULL;comment:'发送窗口结束时间'"`
+	Receivers      string         `gorm:"type:TEXT;NOT NULL;comment:'接收者，JSON 数组，加密存储，创建通知之后清空'"`
+	ReceiverCount  int32          `gorm:"NOT NULL;comment:'接收者数量'"`
+	Channel        string         `gorm:"type:VARCHAR(16);NOT NULL;comment:'发送渠道'"`
+	TemplateID     int64          `gorm:"type:BIGINT;NOT NULL;comment:'模板ID'"`
+	TemplateParams string         `gorm:"type:TEXT;NOT NULL;comment:'模板参数，加密存储，创建通知之后清空'"`
+	Category       string         `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'';comment:'通知类别'"`
+	Labels         sql.NullString `gorm:"type:JSON;comment:'标签，JSON 对象'"`
+	BizPayload     string         `gorm:"type:VARCHAR(1024);NOT NULL;DEFAULT:'';comment:'业务方透传数据'"`
+	Environment    string         `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'PRODUCTION';comment:'环境'"`
+	Status         string         `gorm:"type:VARCHAR(16);NOT NULL;index:idx_local_time_cohorts_due,priority:1;comment:'状态'"`
+	NotificationID uint64         `gorm:"NOT NULL;DEFAULT:0;comment:'创建的通知ID'"`
+	Ctime          int64
+	Utime          int64
+
+	RetryPolicy `gorm:"embedded"`
+}
+
+// TableName 重命名表
+func (LocalTimeCohort) TableName() string {
+	return "local_time_cohorts"
+}
+
+// LocalTimeDAO 按本地时间发送
+type LocalTimeDAO interface {
+	// Create 在同一个事务里创建一条通知的所有时区分组，(biz_id, key, timezone) 冲突时返回 domain.ErrNotificationDuplicate
+	Create(ctx context.Context, cohorts []LocalTimeCohort) error
+	// FindByKey 一条通知的所有时区分组，按发送时间升序
+	FindByKey(ctx context.Context, bizID int64, key string) ([]LocalTimeCohort, error)
+	// FindDue 发送时间在 before 之前还没有创建通知的分组，按发送时间升序
+	FindDue(ctx context.Context, before int64, limit int) ([]LocalTimeCohort, error)
+	// MarkCreated 标记分组已经创建了通知，清空接收者和模板参数
+	MarkCreated(ctx context.Context, id int64, notificationID uint64) error
+}
+
+var _ LocalTimeDAO = (*localTimeDAO)(nil)
+
+type localTimeDAO struct {
+	db    *gorm.DB
+	clock clock.Clock
+}
+
+func NewLocalTimeDAO(db *gorm.DB, clk clock.Clock) LocalTimeDAO {
+	return &localTimeDAO{db: db, clock: clk}
+}
+
+func (d *localTimeDAO) Create(ctx context.Context, cohorts []LocalTimeCohort) error {
+	now := d.clock.Now().UnixMilli()
+	for i := range cohorts {
+		cohorts[i].Status = domain.LocalTimeCohortStatusPending.String()
+		cohorts[i].Ctime, cohorts[i].Utime = now, now
+	}
+	err := d.db.WithContext(ctx).Create(&cohorts).Error
+	if isUniqueConstraintError(err) {
+		return fmt.Errorf("%w: bizID = %d, key = %s", domain.ErrNotificationDuplicate, cohorts[0].BizID, cohorts[0].Key)
+	}
+	return err
+}
+
+func (d *localTimeDAO) FindByKey(ctx context.Context, bizID int64, key string) ([]LocalTimeCohort, error) {
+	var cohorts []LocalTimeCohort
+	err := d.db.WithContext(ctx).
+		Where(map[string]any{"biz_id": bizID, "key": key}).
+		Order("send_time").
+		Find(&cohorts).Error
+	return cohorts, err
+}
+
+func (d *localTimeDAO) FindDue(ctx context.Context, before int64, limit int) ([]LocalTimeCohort, error) {
+	var cohorts []LocalTimeCohort
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND send_time <= ?", domain.LocalTimeCohortStatusPending.String(), before).
+		Order("send_time").
+		Limit(limit).
+		Find(&cohorts).Error
+	return cohorts, err
+}
+
+func (d *localTimeDAO) MarkCreated(ctx context.Context, id int64, notificationID uint64) error {
+	return d.db.WithContext(ctx).Model(&LocalTimeCohort{}).
+		Where("id = ? AND status = ?", id, domain.LocalTimeCohortStatusPending.String()).
+		Updates(map[string]any{
+			"status":          domain.LocalTimeCohortStatusCreated.String(),
+			"notification_id": notificationID,
+			"receivers":       "",
+			"template_params": "",
+			"utime":           d.clock.Now().UnixMilli(),
+		}).Error
+}
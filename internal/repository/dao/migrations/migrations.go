@@ -0,0 +1,188 @@
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/config"
+)
+
+// 迁移文件按驱动分目录存放，命名规则为 {version}_{title}.{up|down}.sql
+// 新增迁移时两个目录都要加，版本号保持一致
+//
+//go:embed mysql/*.sql postgres/*.sql
+var files embed.FS
+
+var (
+	ErrSchemaDirty    = errors.New("数据库结构处于脏状态，上一次迁移没有执行完")
+	ErrSchemaOutdated = errors.New("数据库结构版本落后于应用")
+)
+
+// Migrator 数据库结构迁移
+type Migrator struct {
+	m      *migrate.Migrate
+	db     *sql.DB
+	latest uint
+}
+
+// New 根据数据库配置创建迁移器，使用独立的连接，用完需要调用 Close
+func New(conf config.DatabaseConfig) (*Migrator, error) {
+	driverName := conf.Driver
+	if driverName == "" {
+		driverName = config.DriverMySQL
+	}
+	src, err := iofs.New(files, driverName)
+	if err != nil {
+		return nil, fmt.Errorf("不支持的数据库驱动 %s: %w", driverName, err)
+	}
+	latest, err := latestVersion(src)
+	if err != nil {
+		return nil, err
+	}
+
+	db, driver, err := openDatabase(driverName, conf.DSN)
+	if err != nil {
+		_ = src.Close()
+		return nil, err
+	}
+	m, err := migrate.NewWithInstance("iofs", src, driverName, driver)
+	if err != nil {
+		_ = src.Close()
+		_ = db.Close()
+		return nil, fmt.Errorf("初始化迁移器失败: %w", err)
+	}
+	return &Migrator{m: m, db: db, latest: latest}, nil
+}
+
+func openDatabase(driverName, dsn string) (*sql.DB, database.Driver, error) {
+	switch driverName {
+	case config.DriverMySQL:
+		// 迁移文件里有多条语句，必须开启 multiStatements
+		cfg, err := mysqldriver.ParseDSN(dsn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("解析数据库DSN失败: %w", err)
+		}
+		cfg.MultiStatements = true
+		db, err := sql.Open("mysql", cfg.FormatDSN())
+		if err != nil {
+			return nil, nil, err
+		}
+		driver, err := migratemysql.WithInstance(db, &migratemysql.Config{})
+		if err != nil {
+			_ = db.Close()
+			return nil, nil, fmt.Errorf("连接数据库失败: %w", err)
+		}
+		return db, driver, nil
+	case config.DriverPostgres:
+		db, err := sql.Open("pgx", dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		driver, err := migratepgx.WithInstance(db, &migratepgx.Config{})
+		if err != nil {
+			_ = db.Close()
+			return nil, nil, fmt.Errorf("连接数据库失败: %w", err)
+		}
+		return db, driver, nil
+	default:
+		return nil, nil, fmt.Errorf("不支持的数据库驱动: %s", driverName)
+	}
+}
+
+// latestVersion 找出迁移文件中最大的版本号
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("读取迁移文件失败: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("读取迁移文件失败: %w", err)
+		}
+		version = next
+	}
+}
+
+// Up 执行所有未执行的迁移
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down 回滚 steps 个版本
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("回滚步数必须大于0: %d", steps)
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+// Goto 迁移到指定版本，可以向上也可以向下
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+// Force 强制设置版本号并清除脏状态，不执行任何迁移，用于人工修复失败的迁移之后
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Version 返回数据库当前版本，还没有执行过迁移时返回 0
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// LatestVersion 应用内置迁移文件的最新版本
+func (m *Migrator) LatestVersion() uint {
+	return m.latest
+}
+
+// Check 校验数据库结构版本和应用是否匹配
+// 数据库版本比应用新是允许的，滚动发布时先执行迁移，旧实例仍然要能继续运行
+func (m *Migrator) Check() error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return fmt.Errorf("查询数据库结构版本失败: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("%w: 版本 %d", ErrSchemaDirty, version)
+	}
+	if version < m.latest {
+		return fmt.Errorf("%w: 数据库版本 %d, 应用需要 %d", ErrSchemaOutdated, version, m.latest)
+	}
+	if version > m.latest {
+		log.Printf("[Migrate] Schema version %d is newer than application version %d", version, m.latest)
+	}
+	return nil
+}
+
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr, m.db.Close())
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS `quota`;
+DROP TABLE IF EXISTS `callback_logs`;
+DROP TABLE IF EXISTS `notifications`;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS `notifications` (
+    `id`                  BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '雪花算法ID',
+    `biz_id`              BIGINT          NOT NULL COMMENT '业务配表ID，业务方可能有多个业务每个业务配置不同',
+    `key`                 VARCHAR(256)    NOT NULL COMMENT '业务内唯一标识，区分同一个业务内的不同通知',
+    `receivers`           TEXT            NOT NULL COMMENT '接收者(手机/邮箱/用户ID)，JSON数组',
+    `channel`             VARCHAR(16)     NOT NULL COMMENT '发送渠道',
+    `template_id`         BIGINT          NOT NULL COMMENT '模板ID',
+    `template_version_id` BIGINT          NOT NULL COMMENT '模板版本ID',
+    `template_params`     LONGTEXT        NOT NULL COMMENT '模版参数',
+    `status`              VARCHAR(16)     NOT NULL DEFAULT 'PENDING' COMMENT '发送状态',
+    `scheduled_stime`     BIGINT COMMENT '计划发送开始时间',
+    `scheduled_etime`     BIGINT COMMENT '计划发送结束时间',
+    `version`             INT             NOT NULL DEFAULT 1 COMMENT '版本号，用于CAS操作',
+    `ctime`               BIGINT,
+    `utime`               BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_biz_id_key` (`biz_id`, `key`),
+    KEY `idx_biz_id_status` (`biz_id`, `status`),
+    KEY `idx_scheduled` (`scheduled_stime`, `scheduled_etime`, `status`),
+    CONSTRAINT `chk_notifications_channel` CHECK (`channel` IN ('SMS', 'EMAIL', 'IN_APP')),
+    CONSTRAINT `chk_notifications_status` CHECK (`status` IN ('PREPARE', 'CANCELED', 'PENDING', 'SENDING', 'SUCCEEDED', 'FAILED'))
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `callback_logs` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT '回调记录ID',
+    `notification_id` BIGINT UNSIGNED NOT NULL COMMENT '待回调通知ID',
+    `retry_count`     SMALLINT        NOT NULL DEFAULT 0 COMMENT '重试次数',
+    `next_retry_time` BIGINT          NOT NULL DEFAULT 0 COMMENT '下一次重试的时间戳',
+    `status`          VARCHAR(16)     NOT NULL DEFAULT 'INIT' COMMENT '回调状态',
+    `ctime`           BIGINT,
+    `utime`           BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_notification_id` (`notification_id`),
+    KEY `idx_status` (`status`),
+    CONSTRAINT `chk_callback_logs_status` CHECK (`status` IN ('INIT', 'PENDING', 'SUCCEEDED', 'FAILED'))
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `quota` (
+    `id`      BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '雪花算法ID',
+    `biz_id`  BIGINT          NOT NULL COMMENT '业务配表ID，业务方可能有多个业务每个业务配置不同',
+    `channel` VARCHAR(16)     NOT NULL COMMENT '发送渠道',
+    `quota`   INT,
+    `utime`   BIGINT,
+    `ctime`   BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `biz_id_channel` (`biz_id`, `channel`),
+    CONSTRAINT `chk_quota_channel` CHECK (`channel` IN ('SMS', 'EMAIL', 'IN_APP'))
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
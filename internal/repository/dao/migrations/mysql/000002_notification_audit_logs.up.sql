@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `notification_audit_logs` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT '审计日志ID',
+    `notification_id` BIGINT UNSIGNED NOT NULL COMMENT '通知ID',
+    `biz_id`          BIGINT          NOT NULL COMMENT '业务配表ID',
+    `action`          VARCHAR(16)     NOT NULL COMMENT '操作类型',
+    `operator`        VARCHAR(64)     NOT NULL DEFAULT '' COMMENT '操作人',
+    `before`          TEXT            NOT NULL COMMENT '修改前的内容，JSON',
+    `after`           TEXT            NOT NULL COMMENT '修改后的内容，JSON',
+    `ctime`           BIGINT,
+    PRIMARY KEY (`id`),
+    KEY `idx_notification_id_ctime` (`notification_id`, `ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
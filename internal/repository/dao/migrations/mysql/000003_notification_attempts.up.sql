@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `notification_attempts` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT '发送尝试ID',
+    `notification_id` BIGINT UNSIGNED NOT NULL COMMENT '通知ID',
+    `attempt`         INT             NOT NULL COMMENT '第几次尝试',
+    `idempotency_key` VARCHAR(64)     NOT NULL COMMENT '幂等键，通知ID+尝试次数',
+    `status`          VARCHAR(16)     NOT NULL COMMENT '发送状态',
+    `message_id`      VARCHAR(128)    NOT NULL DEFAULT '' COMMENT '供应商返回的消息ID',
+    `ctime`           BIGINT,
+    `utime`           BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_idempotency_key` (`idempotency_key`),
+    KEY `idx_attempts_notification_id` (`notification_id`),
+    CONSTRAINT `chk_notification_attempts_status` CHECK (`status` IN ('DISPATCHING', 'DISPATCHED', 'FAILED'))
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `channel_template_versions`;
+DROP TABLE IF EXISTS `channel_templates`;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS `channel_templates` (
+    `id`                BIGINT       NOT NULL AUTO_INCREMENT COMMENT '渠道模版ID',
+    `owner_id`          BIGINT       NOT NULL COMMENT '业务方ID',
+    `name`              VARCHAR(128) NOT NULL COMMENT '模板名称',
+    `description`       VARCHAR(512) NOT NULL DEFAULT '' COMMENT '模板描述',
+    `channel`           VARCHAR(16)  NOT NULL COMMENT '渠道',
+    `active_version_id` BIGINT       NOT NULL DEFAULT 0 COMMENT '当前生效的版本ID，0表示没有生效的版本',
+    `ctime`             BIGINT,
+    `utime`             BIGINT,
+    PRIMARY KEY (`id`),
+    KEY `idx_owner_id` (`owner_id`),
+    CONSTRAINT `chk_channel_templates_channel` CHECK (`channel` IN ('SMS', 'EMAIL', 'IN_APP'))
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `channel_template_versions` (
+    `id`                  BIGINT      NOT NULL AUTO_INCREMENT COMMENT '渠道模版版本ID',
+    `channel_template_id` BIGINT      NOT NULL COMMENT '渠道模版ID',
+    `name`                VARCHAR(32) NOT NULL COMMENT '版本名称',
+    `signature`           VARCHAR(64) NOT NULL DEFAULT '' COMMENT '签名',
+    `content`             TEXT        NOT NULL COMMENT '模版内容',
+    `remark`              TEXT        NOT NULL COMMENT '备注',
+    `param_schema`        TEXT        NOT NULL COMMENT '模板参数定义，JSON数组',
+    `ctime`               BIGINT,
+    `utime`               BIGINT,
+    PRIMARY KEY (`id`),
+    KEY `idx_channel_template_id` (`channel_template_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
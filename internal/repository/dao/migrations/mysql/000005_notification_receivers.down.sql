@@ -0,0 +1,3 @@
+ALTER TABLE `notifications` MODIFY COLUMN `receivers` TEXT NOT NULL COMMENT '接收者(手机/邮箱/用户ID)，JSON数组';
+
+DROP TABLE IF EXISTS `notification_receivers`;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `notification_receivers` (
+    `id`              BIGINT   NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `notification_id` BIGINT UNSIGNED NOT NULL COMMENT '通知ID',
+    `biz_id`          BIGINT   NOT NULL COMMENT '业务配表ID',
+    `receiver_index`  CHAR(64) NOT NULL COMMENT '接收者盲索引，HMAC-SHA256',
+    `ctime`           BIGINT,
+    PRIMARY KEY (`id`),
+    KEY `idx_receivers_notification_id` (`notification_id`),
+    KEY `idx_biz_id_receiver_index` (`biz_id`, `receiver_index`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+ALTER TABLE `notifications` MODIFY COLUMN `receivers` TEXT NOT NULL COMMENT '接收者(手机/邮箱/用户ID)，JSON数组，开启加密后为密文';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS `receiver_erasures`;
+
+ALTER TABLE `notifications`
+    DROP KEY `idx_notifications_ctime`,
+    DROP COLUMN `erase_time`;
@@ -0,0 +1,15 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `erase_time` BIGINT NOT NULL DEFAULT 0 COMMENT '接收者数据擦除时间，0表示未擦除',
+    ADD KEY `idx_notifications_ctime` (`ctime`);
+
+CREATE TABLE IF NOT EXISTS `receiver_erasures` (
+    `id`             BIGINT       NOT NULL AUTO_INCREMENT COMMENT '擦除记录ID',
+    `biz_id`         BIGINT       NOT NULL COMMENT '业务配表ID',
+    `receiver_index` CHAR(64)     NOT NULL COMMENT '接收者盲索引，不保存接收者本身',
+    `operator`       VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '操作人',
+    `reason`         VARCHAR(256) NOT NULL DEFAULT '' COMMENT '擦除原因',
+    `affected`       BIGINT       NOT NULL DEFAULT 0 COMMENT '被擦除的通知数量',
+    `ctime`          BIGINT,
+    PRIMARY KEY (`id`),
+    KEY `idx_erasures_biz_id_receiver_index` (`biz_id`, `receiver_index`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `role_assignments` (
+    `id`        BIGINT       NOT NULL AUTO_INCREMENT COMMENT '角色分配ID',
+    `principal` VARCHAR(128) NOT NULL COMMENT '调用方唯一标识',
+    `biz_id`    BIGINT       NOT NULL COMMENT '业务配表ID，平台管理员为0',
+    `role`      VARCHAR(32)  NOT NULL COMMENT '角色',
+    `operator`  VARCHAR(128) NOT NULL DEFAULT '' COMMENT '分配人',
+    `ctime`     BIGINT,
+    `utime`     BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_principal_biz_id` (`principal`, `biz_id`),
+    KEY `idx_role_assignments_biz_id` (`biz_id`),
+    CONSTRAINT `chk_role_assignments_role` CHECK (`role` IN ('PLATFORM_ADMIN', 'BIZ_ADMIN', 'READ_ONLY'))
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `callback_secrets` (
+    `biz_id`               BIGINT       NOT NULL COMMENT '业务配表ID',
+    `secret`               VARCHAR(512) NOT NULL COMMENT '当前密钥（密文）',
+    `previous_secret`      VARCHAR(512) NOT NULL DEFAULT '' COMMENT '轮换前的密钥（密文）',
+    `previous_expire_time` BIGINT       NOT NULL DEFAULT 0 COMMENT '旧密钥停止签名的时间戳',
+    `ctime`                BIGINT,
+    `utime`                BIGINT,
+    PRIMARY KEY (`biz_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,2 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `fail_reason` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '失败原因，发送失败时为空';
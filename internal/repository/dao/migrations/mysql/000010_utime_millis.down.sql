@@ -0,0 +1,2 @@
+-- 回填前哪些行是秒已经无从区分，回滚不做任何修改
+SELECT 1;
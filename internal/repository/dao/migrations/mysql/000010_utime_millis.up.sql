@@ -0,0 +1,4 @@
+-- CASStatus、UpdateStatus、BatchUpdateStatusSucceededOrFailed 曾经按秒写入 utime，统一改成毫秒
+-- 小于 100000000000 的值按毫秒算是 1973 年，按秒算是 5138 年，只可能是秒
+UPDATE `notifications` SET `utime` = `utime` * 1000 WHERE `utime` > 0 AND `utime` < 100000000000;
+UPDATE `callback_logs` SET `utime` = `utime` * 1000 WHERE `utime` > 0 AND `utime` < 100000000000;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS `provider_hourly_stats`;
+DROP TABLE IF EXISTS `notification_hourly_stats`;
+
+ALTER TABLE `notification_attempts`
+    DROP KEY `idx_attempts_ctime`,
+    DROP COLUMN `provider`;
@@ -0,0 +1,33 @@
+ALTER TABLE `notification_attempts`
+    ADD COLUMN `provider` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '供应商名称，用于统计成功率',
+    ADD KEY `idx_attempts_ctime` (`ctime`);
+
+CREATE TABLE IF NOT EXISTS `notification_hourly_stats` (
+    `id`          BIGINT      NOT NULL AUTO_INCREMENT COMMENT '统计ID',
+    `biz_id`      BIGINT      NOT NULL COMMENT '业务配表ID',
+    `stat_hour`   BIGINT      NOT NULL COMMENT '小时开始的毫秒时间戳',
+    `channel`     VARCHAR(16) NOT NULL COMMENT '发送渠道',
+    `status`      VARCHAR(16) NOT NULL COMMENT '发送状态',
+    `template_id` BIGINT      NOT NULL COMMENT '模板ID',
+    `cnt`         BIGINT      NOT NULL DEFAULT 0 COMMENT '通知数量',
+    `ctime`       BIGINT,
+    `utime`       BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_notification_hourly_stats` (`biz_id`, `stat_hour`, `channel`, `status`, `template_id`),
+    KEY `idx_notification_hourly_stats_stat_hour` (`stat_hour`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '通知小时统计，由统计任务根据通知表重新计算';
+
+CREATE TABLE IF NOT EXISTS `provider_hourly_stats` (
+    `id`         BIGINT      NOT NULL AUTO_INCREMENT COMMENT '统计ID',
+    `biz_id`     BIGINT      NOT NULL COMMENT '业务配表ID',
+    `stat_hour`  BIGINT      NOT NULL COMMENT '小时开始的毫秒时间戳',
+    `channel`    VARCHAR(16) NOT NULL COMMENT '发送渠道',
+    `provider`   VARCHAR(64) NOT NULL COMMENT '供应商名称',
+    `dispatched` BIGINT      NOT NULL DEFAULT 0 COMMENT '供应商受理的发送尝试数量',
+    `failed`     BIGINT      NOT NULL DEFAULT 0 COMMENT '供应商明确失败的发送尝试数量',
+    `ctime`      BIGINT,
+    `utime`      BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_provider_hourly_stats` (`biz_id`, `stat_hour`, `channel`, `provider`),
+    KEY `idx_provider_hourly_stats_stat_hour` (`stat_hour`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '供应商小时统计，由统计任务根据发送尝试表重新计算';
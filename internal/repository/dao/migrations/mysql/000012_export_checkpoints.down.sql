@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `export_checkpoints`;
+
+ALTER TABLE `notifications`
+    DROP KEY `idx_notifications_utime_id`;
@@ -0,0 +1,11 @@
+ALTER TABLE `notifications`
+    ADD KEY `idx_notifications_utime_id` (`utime`, `id`);
+
+CREATE TABLE IF NOT EXISTS `export_checkpoints` (
+    `name`      VARCHAR(64)     NOT NULL COMMENT '导出任务名称',
+    `last_time` BIGINT          NOT NULL DEFAULT 0 COMMENT '已经导出的最后一条通知的更新时间',
+    `last_id`   BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '已经导出的最后一条通知的ID',
+    `ctime`     BIGINT,
+    `utime`     BIGINT,
+    PRIMARY KEY (`name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '导出到分析库的进度';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `notification_reads` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `notification_id` BIGINT UNSIGNED NOT NULL COMMENT '通知ID',
+    `receiver_index`  CHAR(64)        NOT NULL COMMENT '接收者盲索引',
+    `biz_id`          BIGINT          NOT NULL COMMENT '业务配表ID',
+    `key`             VARCHAR(256)    NOT NULL COMMENT '业务内唯一标识',
+    `template_id`     BIGINT          NOT NULL COMMENT '模板ID',
+    `receiver`        TEXT            NOT NULL COMMENT '接收者，和通知的接收者一样加密存储，推送已读事件时使用',
+    `read_time`       BIGINT          NOT NULL COMMENT '第一次阅读的时间',
+    `callback_status` VARCHAR(16)     NOT NULL DEFAULT 'NONE' COMMENT '已读事件推送状态',
+    `retry_count`     INT             NOT NULL DEFAULT 0 COMMENT '推送重试次数',
+    `next_retry_time` BIGINT          NOT NULL DEFAULT 0 COMMENT '下一次推送时间',
+    `ctime`           BIGINT,
+    `utime`           BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_reads_notification_receiver` (`notification_id`, `receiver_index`),
+    KEY `idx_reads_biz_id_template_id` (`biz_id`, `template_id`),
+    KEY `idx_reads_callback` (`callback_status`, `next_retry_time`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '站内信已读回执';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS `escalations` (
+    `id`             BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`         BIGINT       NOT NULL COMMENT '业务配表ID',
+    `key`            VARCHAR(256) NOT NULL COMMENT '业务内唯一标识',
+    `steps`          TEXT         NOT NULL COMMENT '升级步骤，加密的JSON',
+    `step_count`     INT          NOT NULL COMMENT '步骤数量',
+    `current_step`   INT          NOT NULL DEFAULT -1 COMMENT '已经发送的最后一步，-1 表示还没有发送',
+    `status`         VARCHAR(16)  NOT NULL COMMENT '状态',
+    `next_step_time` BIGINT       NOT NULL COMMENT '进入下一步的时间',
+    `ack_time`       BIGINT       NOT NULL DEFAULT 0 COMMENT '确认时间',
+    `ack_by`         VARCHAR(256) NOT NULL DEFAULT '' COMMENT '确认人',
+    `version`        INT          NOT NULL DEFAULT 1 COMMENT '版本号，用于CAS操作',
+    `ctime`          BIGINT,
+    `utime`          BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_escalations_biz_id_key` (`biz_id`, `key`),
+    KEY `idx_escalations_due` (`status`, `next_step_time`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '跨渠道升级链';
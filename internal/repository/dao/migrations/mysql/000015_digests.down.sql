@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `digest_items`;
+DROP TABLE IF EXISTS `digest_groups`;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS `digest_groups` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`          BIGINT          NOT NULL COMMENT '业务配表ID',
+    `channel`         VARCHAR(16)     NOT NULL COMMENT '发送渠道',
+    `receiver_index`  CHAR(64)        NOT NULL COMMENT '接收者盲索引',
+    `window_start`    BIGINT          NOT NULL COMMENT '合并窗口开始时间',
+    `window_end`      BIGINT          NOT NULL COMMENT '合并窗口结束时间',
+    `template_id`     BIGINT          NOT NULL COMMENT '摘要模板ID',
+    `receiver`        TEXT            NOT NULL COMMENT '接收者，加密存储，发送之后清空',
+    `status`          VARCHAR(16)     NOT NULL COMMENT '状态',
+    `item_count`      INT             NOT NULL DEFAULT 0 COMMENT '合并的通知数量',
+    `notification_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '摘要消息的通知ID',
+    `ctime`           BIGINT,
+    `utime`           BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_digest_groups_window` (`biz_id`, `channel`, `receiver_index`, `window_start`),
+    KEY `idx_digest_groups_due` (`status`, `window_end`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '合并发送分组';
+
+CREATE TABLE IF NOT EXISTS `digest_items` (
+    `id`             BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `group_id`       BIGINT       NOT NULL COMMENT '合并分组ID',
+    `biz_id`         BIGINT       NOT NULL COMMENT '业务配表ID',
+    `key`            VARCHAR(256) NOT NULL COMMENT '业务内唯一标识',
+    `receiver_index` CHAR(64)     NOT NULL COMMENT '接收者盲索引',
+    `summary`        TEXT         NOT NULL COMMENT '摘要，加密存储',
+    `ctime`          BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_digest_items_key` (`biz_id`, `key`, `receiver_index`),
+    KEY `idx_digest_items_group_id` (`group_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '进入合并窗口的通知';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS `channel_template_providers`;
+
+ALTER TABLE `channel_template_versions`
+    DROP COLUMN `audit_status`,
+    DROP COLUMN `reject_reason`;
@@ -0,0 +1,23 @@
+ALTER TABLE `channel_template_versions`
+    ADD COLUMN `audit_status`  VARCHAR(16)  NOT NULL DEFAULT 'PENDING' COMMENT '内部审核状态',
+    ADD COLUMN `reject_reason` VARCHAR(512) NOT NULL DEFAULT '' COMMENT '审核不通过的原因';
+
+-- 已有的版本在引入审核流程之前就在使用，视为审核通过
+UPDATE `channel_template_versions` SET `audit_status` = 'APPROVED';
+
+CREATE TABLE IF NOT EXISTS `channel_template_providers` (
+    `id`                   BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `template_id`          BIGINT       NOT NULL COMMENT '渠道模版ID',
+    `template_version_id`  BIGINT       NOT NULL COMMENT '渠道模版版本ID',
+    `provider_name`        VARCHAR(64)  NOT NULL COMMENT '供应商名称',
+    `provider_template_id` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '供应商返回的模板ID',
+    `audit_status`         VARCHAR(16)  NOT NULL COMMENT '供应商审核状态',
+    `reject_reason`        VARCHAR(512) NOT NULL DEFAULT '' COMMENT '审核不通过的原因',
+    `retry_count`          INT          NOT NULL DEFAULT 0 COMMENT '连续失败次数',
+    `next_check_time`      BIGINT       NOT NULL DEFAULT 0 COMMENT '下一次提交或者查询审核结果的时间',
+    `ctime`                BIGINT,
+    `utime`                BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_template_providers_version_provider` (`template_version_id`, `provider_name`),
+    KEY `idx_template_providers_check` (`audit_status`, `next_check_time`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '模板版本的供应商审核记录';
@@ -0,0 +1,2 @@
+ALTER TABLE `channel_template_providers`
+    DROP KEY `idx_template_providers_provider_template`;
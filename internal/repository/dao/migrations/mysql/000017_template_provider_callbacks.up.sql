@@ -0,0 +1,3 @@
+-- 供应商推送审核结果时只带供应商的模板ID
+ALTER TABLE `channel_template_providers`
+    ADD KEY `idx_template_providers_provider_template` (`provider_name`, `provider_template_id`);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `vendor_balance_snapshots`;
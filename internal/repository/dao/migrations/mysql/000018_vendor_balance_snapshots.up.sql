@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `vendor_balance_snapshots` (
+    `id`        BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `provider`  VARCHAR(64) NOT NULL COMMENT '供应商名称',
+    `unit`      VARCHAR(16) NOT NULL COMMENT '余额单位，CENT 或者 MESSAGE',
+    `remaining` BIGINT      NOT NULL COMMENT '剩余的金额（分）或者条数',
+    `total`     BIGINT      NOT NULL DEFAULT 0 COMMENT '套餐包的总条数',
+    `ctime`     BIGINT,
+    PRIMARY KEY (`id`),
+    KEY `idx_vendor_balance_provider_ctime` (`provider`, `ctime`),
+    KEY `idx_vendor_balance_ctime` (`ctime`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '供应商余额快照';
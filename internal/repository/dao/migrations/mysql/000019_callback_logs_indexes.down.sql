@@ -0,0 +1,4 @@
+ALTER TABLE `callback_logs`
+    DROP KEY `idx_callback_logs_status_utime`,
+    DROP KEY `idx_callback_logs_status_next_retry_time`,
+    ADD KEY `idx_status` (`status`);
@@ -0,0 +1,5 @@
+-- 回调重试按 status + next_retry_time 查询，清理按 status + utime 查询，都以 status 开头，替换原来的 idx_status
+ALTER TABLE `callback_logs`
+    DROP KEY `idx_status`,
+    ADD KEY `idx_callback_logs_status_next_retry_time` (`status`, `next_retry_time`),
+    ADD KEY `idx_callback_logs_status_utime` (`status`, `utime`);
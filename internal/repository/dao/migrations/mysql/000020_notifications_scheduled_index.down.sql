@@ -0,0 +1,3 @@
+ALTER TABLE `notifications`
+    DROP KEY `idx_notifications_status_scheduled`,
+    ADD KEY `idx_scheduled` (`scheduled_stime`, `scheduled_etime`, `status`);
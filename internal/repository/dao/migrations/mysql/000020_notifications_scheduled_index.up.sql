@@ -0,0 +1,4 @@
+-- 调度器按 status + 计划发送时间过滤、按 id 游标分页，status 是等值条件放在最前面，id 放在最后覆盖游标条件
+ALTER TABLE `notifications`
+    DROP KEY `idx_scheduled`,
+    ADD KEY `idx_notifications_status_scheduled` (`status`, `scheduled_stime`, `scheduled_etime`, `id`);
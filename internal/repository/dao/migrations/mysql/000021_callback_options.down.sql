@@ -0,0 +1,4 @@
+ALTER TABLE `callback_logs`
+    DROP COLUMN `on_status`,
+    DROP COLUMN `headers`,
+    DROP COLUMN `url`;
@@ -0,0 +1,5 @@
+-- 单条通知的回调设置，为空时回调业务方配置的默认地址，只在发送成功后回调
+ALTER TABLE `callback_logs`
+    ADD COLUMN `url`       VARCHAR(512)  NOT NULL DEFAULT '' COMMENT '回调地址，为空使用业务方的默认地址',
+    ADD COLUMN `headers`   VARCHAR(4096) NOT NULL DEFAULT '' COMMENT '回调时额外带上的请求头，JSON',
+    ADD COLUMN `on_status` VARCHAR(32)   NOT NULL DEFAULT '' COMMENT '需要回调的发送结果，逗号分隔，为空只回调 SUCCEEDED';
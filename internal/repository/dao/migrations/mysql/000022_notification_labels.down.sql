@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `notification_labels`;
+
+ALTER TABLE `notifications`
+    DROP COLUMN `labels`;
@@ -0,0 +1,15 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `labels` JSON NULL COMMENT '标签，JSON 对象';
+
+-- 按标签查询通知，每个标签一行
+CREATE TABLE IF NOT EXISTS `notification_labels` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `notification_id` BIGINT UNSIGNED NOT NULL COMMENT '通知ID',
+    `biz_id`          BIGINT          NOT NULL COMMENT '业务配表ID',
+    `label_key`       VARCHAR(63)     NOT NULL COMMENT '标签键',
+    `label_value`     VARCHAR(128)    NOT NULL COMMENT '标签值',
+    `ctime`           BIGINT,
+    PRIMARY KEY (`id`),
+    KEY `idx_notification_labels_biz_key_value` (`biz_id`, `label_key`, `label_value`, `notification_id`),
+    KEY `idx_notification_labels_notification_id` (`notification_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '通知标签索引';
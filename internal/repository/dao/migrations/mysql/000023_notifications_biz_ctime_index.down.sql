@@ -0,0 +1,2 @@
+ALTER TABLE `notifications`
+    DROP KEY `idx_notifications_biz_id_ctime`;
@@ -0,0 +1,3 @@
+-- 按业务方导出一段时间内创建的通知，按 (ctime, id) 游标分页
+ALTER TABLE `notifications`
+    ADD KEY `idx_notifications_biz_id_ctime` (`biz_id`, `ctime`, `id`);
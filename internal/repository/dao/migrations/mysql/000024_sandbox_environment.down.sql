@@ -0,0 +1,8 @@
+DELETE FROM `notification_hourly_stats` WHERE `environment` <> 'PRODUCTION';
+ALTER TABLE `notification_hourly_stats`
+    DROP KEY `idx_notification_hourly_stats`,
+    DROP COLUMN `environment`,
+    ADD UNIQUE KEY `idx_notification_hourly_stats` (`biz_id`, `stat_hour`, `channel`, `status`, `template_id`);
+
+ALTER TABLE `notifications`
+    DROP COLUMN `environment`;
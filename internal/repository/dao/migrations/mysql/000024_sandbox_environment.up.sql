@@ -0,0 +1,8 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `environment` VARCHAR(16) NOT NULL DEFAULT 'PRODUCTION' COMMENT '环境，PRODUCTION 或 SANDBOX';
+
+-- 沙箱和生产环境的通知分开统计
+ALTER TABLE `notification_hourly_stats`
+    ADD COLUMN `environment` VARCHAR(16) NOT NULL DEFAULT 'PRODUCTION' COMMENT '环境',
+    DROP KEY `idx_notification_hourly_stats`,
+    ADD UNIQUE KEY `idx_notification_hourly_stats` (`biz_id`, `stat_hour`, `channel`, `status`, `template_id`, `environment`);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS `local_time_cohorts` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`          BIGINT          NOT NULL COMMENT '业务配表ID',
+    `key`             VARCHAR(256)    NOT NULL COMMENT '业务内唯一标识',
+    `timezone`        VARCHAR(64)     NOT NULL COMMENT 'IANA 时区名称',
+    `send_time`       BIGINT          NOT NULL COMMENT '本地发送时间',
+    `end_time`        BIGINT          NOT NULL COMMENT '发送窗口结束时间',
+    `receivers`       TEXT            NOT NULL COMMENT '接收者，JSON 数组，加密存储，创建通知之后清空',
+    `receiver_count`  INT             NOT NULL COMMENT '接收者数量',
+    `channel`         VARCHAR(16)     NOT NULL COMMENT '发送渠道',
+    `template_id`     BIGINT          NOT NULL COMMENT '模板ID',
+    `template_params` TEXT            NOT NULL COMMENT '模板参数，加密存储，创建通知之后清空',
+    `category`        VARCHAR(16)     NOT NULL DEFAULT '' COMMENT '通知类别',
+    `labels`          JSON            NULL COMMENT '标签，JSON 对象',
+    `environment`     VARCHAR(16)     NOT NULL DEFAULT 'PRODUCTION' COMMENT '环境',
+    `status`          VARCHAR(16)     NOT NULL COMMENT '状态',
+    `notification_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '创建的通知ID',
+    `ctime`           BIGINT,
+    `utime`           BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_local_time_cohorts_key` (`biz_id`, `key`, `timezone`),
+    KEY `idx_local_time_cohorts_due` (`status`, `send_time`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '按本地时间发送的时区分组';
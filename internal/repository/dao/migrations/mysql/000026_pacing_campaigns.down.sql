@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `pacing_campaigns`;
+
+ALTER TABLE `notifications`
+    DROP COLUMN `campaign`;
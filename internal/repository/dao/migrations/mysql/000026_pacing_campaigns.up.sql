@@ -0,0 +1,17 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `campaign` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '限速发送的活动';
+
+CREATE TABLE IF NOT EXISTS `pacing_campaigns` (
+    `id`          BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`      BIGINT      NOT NULL COMMENT '业务配表ID',
+    `campaign`    VARCHAR(64) NOT NULL COMMENT '活动名称',
+    `per_minute`  INT         NOT NULL COMMENT '每分钟最多发送的数量',
+    `next_slot`   BIGINT      NOT NULL COMMENT '下一条通知的发送时间',
+    `sent_minute` BIGINT      NOT NULL DEFAULT 0 COMMENT '调度器放行数量所在的分钟',
+    `sent_count`  INT         NOT NULL DEFAULT 0 COMMENT '这一分钟已经放行的数量',
+    `sent_total`  BIGINT      NOT NULL DEFAULT 0 COMMENT '一共放行的数量',
+    `ctime`       BIGINT,
+    `utime`       BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_pacing_campaigns_campaign` (`biz_id`, `campaign`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '限速发送的活动进度';
@@ -0,0 +1,3 @@
+DROP INDEX `idx_notifications_biz_id_fallback_group` ON `notifications`;
+ALTER TABLE `notifications`
+    DROP COLUMN `fallback_group`;
@@ -0,0 +1,3 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `fallback_group` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '渠道降级分组';
+CREATE INDEX `idx_notifications_biz_id_fallback_group` ON `notifications` (`biz_id`, `fallback_group`);
@@ -0,0 +1,7 @@
+ALTER TABLE `notification_attempts`
+    DROP KEY `idx_attempts_provider_ctime`,
+    DROP KEY `idx_attempts_message_id`,
+    DROP COLUMN `error`,
+    DROP COLUMN `response_code`,
+    DROP COLUMN `latency_ms`,
+    DROP COLUMN `request_digest`;
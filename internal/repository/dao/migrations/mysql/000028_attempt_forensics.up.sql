@@ -0,0 +1,7 @@
+ALTER TABLE `notification_attempts`
+    ADD COLUMN `request_digest` VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '发送内容摘要，SHA-256',
+    ADD COLUMN `latency_ms`     BIGINT       NOT NULL DEFAULT 0 COMMENT '调用供应商耗时（毫秒）',
+    ADD COLUMN `response_code`  VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '供应商返回的状态码',
+    ADD COLUMN `error`          VARCHAR(512) NOT NULL DEFAULT '' COMMENT '调用失败的原因',
+    ADD KEY `idx_attempts_message_id` (`message_id`),
+    ADD KEY `idx_attempts_provider_ctime` (`provider`, `ctime`);
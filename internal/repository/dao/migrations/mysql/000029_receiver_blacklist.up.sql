@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `receiver_blacklist` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `channel`         VARCHAR(16)     NOT NULL COMMENT '发送渠道',
+    `receiver_index`  CHAR(64)        NOT NULL COMMENT '接收者盲索引',
+    `receiver`        TEXT            NOT NULL COMMENT '接收者，和通知的接收者一样加密存储',
+    `category`        VARCHAR(32)     NOT NULL COMMENT '加入黑名单的原因，供应商错误分类',
+    `vendor`          VARCHAR(32)     NOT NULL DEFAULT '' COMMENT '返回拒收的供应商',
+    `code`            VARCHAR(64)     NOT NULL DEFAULT '' COMMENT '供应商原始错误码',
+    `notification_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '触发加入黑名单的通知ID',
+    `expire_time`     BIGINT          NOT NULL COMMENT '过期时间',
+    `ctime`           BIGINT,
+    `utime`           BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_blacklist_channel_receiver` (`channel`, `receiver_index`),
+    KEY `idx_blacklist_expire_time` (`expire_time`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '渠道接收者黑名单';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `receiver_opt_outs` (
+    `id`             BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`         BIGINT      NOT NULL COMMENT '业务配表ID，0 对所有业务方生效',
+    `channel`        VARCHAR(16) NOT NULL COMMENT '发送渠道',
+    `receiver_index` CHAR(64)    NOT NULL COMMENT '接收者盲索引',
+    `receiver`       TEXT        NOT NULL COMMENT '接收者，和通知的接收者一样加密存储',
+    `source`         VARCHAR(32) NOT NULL COMMENT '退订来源',
+    `ctime`          BIGINT,
+    `utime`          BIGINT,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_opt_outs_channel_receiver` (`channel`, `receiver_index`, `biz_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '接收者退订营销类通知';
@@ -0,0 +1,4 @@
+ALTER TABLE `local_time_cohorts`
+    DROP COLUMN `biz_payload`;
+ALTER TABLE `notifications`
+    DROP COLUMN `biz_payload`;
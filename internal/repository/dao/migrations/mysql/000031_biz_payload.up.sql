@@ -0,0 +1,4 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `biz_payload` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '业务方透传数据，回调和查询时原样返回';
+ALTER TABLE `local_time_cohorts`
+    ADD COLUMN `biz_payload` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '业务方透传数据';
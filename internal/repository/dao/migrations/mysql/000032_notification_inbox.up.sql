@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `notification_inbox` (
+    `id`              BIGINT          NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`          BIGINT          NOT NULL COMMENT '业务配表ID',
+    `receiver_index`  CHAR(64)        NOT NULL COMMENT '接收者盲索引',
+    `notification_id` BIGINT UNSIGNED NOT NULL COMMENT '通知ID',
+    `ctime`           BIGINT          NOT NULL COMMENT '通知的创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_inbox_receiver_notification` (`biz_id`, `receiver_index`, `notification_id`),
+    KEY `idx_inbox_notification_id` (`notification_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '站内信收件箱，由通知变化投影生成';
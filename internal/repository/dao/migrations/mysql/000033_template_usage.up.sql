@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `template_usage` (
+    `id`             BIGINT NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `template_id`    BIGINT NOT NULL COMMENT '渠道模版ID',
+    `biz_id`         BIGINT NOT NULL COMMENT '业务配表ID',
+    `send_count`     BIGINT NOT NULL DEFAULT 0 COMMENT '生产环境累计创建的通知数量',
+    `last_used_time` BIGINT NOT NULL DEFAULT 0 COMMENT '最后一次创建生产环境通知的时间',
+    `ctime`          BIGINT NOT NULL,
+    `utime`          BIGINT NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_template_usage_template_id` (`template_id`),
+    KEY `idx_template_usage_biz_id` (`biz_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '模板使用情况，由统计任务汇总生成';
@@ -0,0 +1,9 @@
+ALTER TABLE `local_time_cohorts`
+    DROP COLUMN `retry_max_attempts`,
+    DROP COLUMN `retry_initial_backoff`,
+    DROP COLUMN `retry_max_backoff`;
+ALTER TABLE `notifications`
+    DROP COLUMN `retry_max_attempts`,
+    DROP COLUMN `retry_initial_backoff`,
+    DROP COLUMN `retry_max_backoff`,
+    DROP COLUMN `attempts`;
@@ -0,0 +1,9 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `retry_max_attempts` INT NOT NULL DEFAULT 0 COMMENT '最多发送次数，包括第一次，0 使用平台默认值',
+    ADD COLUMN `retry_initial_backoff` BIGINT NOT NULL DEFAULT 0 COMMENT '第一次重试前等待的毫秒数，0 使用平台默认值',
+    ADD COLUMN `retry_max_backoff` BIGINT NOT NULL DEFAULT 0 COMMENT '重试等待的毫秒数上限，0 使用平台默认值',
+    ADD COLUMN `attempts` INT NOT NULL DEFAULT 0 COMMENT '已经失败的发送次数';
+ALTER TABLE `local_time_cohorts`
+    ADD COLUMN `retry_max_attempts` INT NOT NULL DEFAULT 0 COMMENT '最多发送次数，包括第一次，0 使用平台默认值',
+    ADD COLUMN `retry_initial_backoff` BIGINT NOT NULL DEFAULT 0 COMMENT '第一次重试前等待的毫秒数，0 使用平台默认值',
+    ADD COLUMN `retry_max_backoff` BIGINT NOT NULL DEFAULT 0 COMMENT '重试等待的毫秒数上限，0 使用平台默认值';
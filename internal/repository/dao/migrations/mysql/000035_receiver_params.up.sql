@@ -0,0 +1,2 @@
+ALTER TABLE `notifications`
+    ADD COLUMN `receiver_params` MEDIUMTEXT NULL COMMENT '按接收者覆盖的模板参数，JSON 对象，加密存储，没有时为 NULL';
@@ -0,0 +1,4 @@
+ALTER TABLE `channel_template_versions`
+    DROP COLUMN `reviewer`,
+    DROP COLUMN `review_time`,
+    DROP COLUMN `forked_from_version_id`;
@@ -0,0 +1,4 @@
+ALTER TABLE `channel_template_versions`
+    ADD COLUMN `reviewer`               VARCHAR(128) NOT NULL DEFAULT '' COMMENT '内部审核人，调用方唯一标识',
+    ADD COLUMN `review_time`            BIGINT       NOT NULL DEFAULT 0 COMMENT '内部审核时间，毫秒时间戳，0 表示还没有审核',
+    ADD COLUMN `forked_from_version_id` BIGINT       NOT NULL DEFAULT 0 COMMENT '回滚时从哪个版本拷贝，0 表示不是回滚创建的';
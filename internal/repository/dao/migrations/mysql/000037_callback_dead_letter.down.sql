@@ -0,0 +1,8 @@
+UPDATE `callback_logs` SET `status` = 'FAILED' WHERE `status` = 'DEAD_LETTER';
+ALTER TABLE `callback_logs`
+    DROP CHECK `chk_callback_logs_status`;
+ALTER TABLE `callback_logs`
+    DROP KEY `idx_callback_logs_status_biz_id`,
+    DROP COLUMN `last_error`,
+    DROP COLUMN `biz_id`,
+    ADD CONSTRAINT `chk_callback_logs_status` CHECK (`status` IN ('INIT', 'PENDING', 'SUCCEEDED', 'FAILED'));
@@ -0,0 +1,8 @@
+-- 重试次数用完的回调记录进入死信（DEAD_LETTER），管理员按业务方查询和重新回调
+ALTER TABLE `callback_logs`
+    DROP CHECK `chk_callback_logs_status`;
+ALTER TABLE `callback_logs`
+    ADD COLUMN `biz_id`     BIGINT       NOT NULL DEFAULT 0 COMMENT '业务方ID，回调任务处理时补齐',
+    ADD COLUMN `last_error` VARCHAR(512) NOT NULL DEFAULT '' COMMENT '最后一次回调失败的原因',
+    ADD CONSTRAINT `chk_callback_logs_status` CHECK (`status` IN ('INIT', 'PENDING', 'SUCCEEDED', 'FAILED', 'DEAD_LETTER')),
+    ADD KEY `idx_callback_logs_status_biz_id` (`status`, `biz_id`);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `biz_channel_settings` (
+    `id`       BIGINT       NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`   BIGINT       NOT NULL COMMENT '业务配表ID',
+    `channel`  VARCHAR(16)  NOT NULL COMMENT '发送渠道',
+    `enabled`  TINYINT(1)   NOT NULL DEFAULT 1 COMMENT '是否允许使用这个渠道发送',
+    `operator` VARCHAR(64)  NOT NULL DEFAULT '' COMMENT '最后一次修改的操作人',
+    `reason`   VARCHAR(256) NOT NULL DEFAULT '' COMMENT '最后一次修改的原因',
+    `ctime`    BIGINT       NOT NULL,
+    `utime`    BIGINT       NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_biz_channel_settings_biz_channel` (`biz_id`, `channel`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '业务方渠道开关，没有记录的渠道默认允许';
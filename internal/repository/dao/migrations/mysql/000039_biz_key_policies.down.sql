@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `biz_key_policies`;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `biz_key_policies` (
+    `id`              BIGINT      NOT NULL AUTO_INCREMENT COMMENT 'ID',
+    `biz_id`          BIGINT      NOT NULL COMMENT '业务配表ID',
+    `max_length`      INT         NOT NULL DEFAULT 0 COMMENT 'key 的最大字符数，0 表示 256',
+    `charset`         VARCHAR(16) NOT NULL DEFAULT 'ANY' COMMENT 'key 允许的字符集：ANY、PRINTABLE、SAFE',
+    `required_prefix` VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'key 必须使用的前缀',
+    `operator`        VARCHAR(64) NOT NULL DEFAULT '' COMMENT '最后一次修改的操作人',
+    `ctime`           BIGINT      NOT NULL,
+    `utime`           BIGINT      NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_biz_key_policies_biz_id` (`biz_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT '业务方通知 key 的规则，没有记录的业务方只限制长度';
@@ -0,0 +1,52 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id                  BIGSERIAL    PRIMARY KEY,
+    biz_id              BIGINT       NOT NULL,
+    "key"               VARCHAR(256) NOT NULL,
+    receivers           TEXT         NOT NULL,
+    channel             VARCHAR(16)  NOT NULL,
+    template_id         BIGINT       NOT NULL,
+    template_version_id BIGINT       NOT NULL,
+    template_params     TEXT         NOT NULL,
+    status              VARCHAR(16)  NOT NULL DEFAULT 'PENDING',
+    scheduled_stime     BIGINT,
+    scheduled_etime     BIGINT,
+    version             INT          NOT NULL DEFAULT 1,
+    ctime               BIGINT,
+    utime               BIGINT,
+    CONSTRAINT chk_notifications_channel CHECK (channel IN ('SMS', 'EMAIL', 'IN_APP')),
+    CONSTRAINT chk_notifications_status CHECK (status IN ('PREPARE', 'CANCELED', 'PENDING', 'SENDING', 'SUCCEEDED', 'FAILED'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_biz_id_key ON notifications (biz_id, "key");
+CREATE INDEX IF NOT EXISTS idx_biz_id_status ON notifications (biz_id, status);
+CREATE INDEX IF NOT EXISTS idx_scheduled ON notifications (scheduled_stime, scheduled_etime, status);
+COMMENT ON COLUMN notifications.id IS '雪花算法ID';
+COMMENT ON COLUMN notifications.biz_id IS '业务配表ID，业务方可能有多个业务每个业务配置不同';
+COMMENT ON COLUMN notifications."key" IS '业务内唯一标识，区分同一个业务内的不同通知';
+COMMENT ON COLUMN notifications.receivers IS '接收者(手机/邮箱/用户ID)，JSON数组';
+COMMENT ON COLUMN notifications.channel IS '发送渠道';
+COMMENT ON COLUMN notifications.status IS '发送状态';
+COMMENT ON COLUMN notifications.version IS '版本号，用于CAS操作';
+
+CREATE TABLE IF NOT EXISTS callback_logs (
+    id              BIGSERIAL   PRIMARY KEY,
+    notification_id BIGINT      NOT NULL,
+    retry_count     SMALLINT    NOT NULL DEFAULT 0,
+    next_retry_time BIGINT      NOT NULL DEFAULT 0,
+    status          VARCHAR(16) NOT NULL DEFAULT 'INIT',
+    ctime           BIGINT,
+    utime           BIGINT,
+    CONSTRAINT chk_callback_logs_status CHECK (status IN ('INIT', 'PENDING', 'SUCCEEDED', 'FAILED'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_id ON callback_logs (notification_id);
+CREATE INDEX IF NOT EXISTS idx_status ON callback_logs (status);
+
+CREATE TABLE IF NOT EXISTS quota (
+    id      BIGSERIAL   PRIMARY KEY,
+    biz_id  BIGINT      NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    quota   INT,
+    utime   BIGINT,
+    ctime   BIGINT,
+    CONSTRAINT chk_quota_channel CHECK (channel IN ('SMS', 'EMAIL', 'IN_APP'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS biz_id_channel ON quota (biz_id, channel);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS notification_audit_logs (
+    id              BIGSERIAL   PRIMARY KEY,
+    notification_id BIGINT      NOT NULL,
+    biz_id          BIGINT      NOT NULL,
+    action          VARCHAR(16) NOT NULL,
+    operator        VARCHAR(64) NOT NULL DEFAULT '',
+    before          TEXT        NOT NULL,
+    after           TEXT        NOT NULL,
+    ctime           BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_notification_id_ctime ON notification_audit_logs (notification_id, ctime);
+COMMENT ON TABLE notification_audit_logs IS '通知变更审计日志';
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS notification_attempts (
+    id              BIGSERIAL    PRIMARY KEY,
+    notification_id BIGINT       NOT NULL,
+    attempt         INT          NOT NULL,
+    idempotency_key VARCHAR(64)  NOT NULL,
+    status          VARCHAR(16)  NOT NULL,
+    message_id      VARCHAR(128) NOT NULL DEFAULT '',
+    ctime           BIGINT,
+    utime           BIGINT,
+    CONSTRAINT chk_notification_attempts_status CHECK (status IN ('DISPATCHING', 'DISPATCHED', 'FAILED'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_key ON notification_attempts (idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_attempts_notification_id ON notification_attempts (notification_id);
+COMMENT ON TABLE notification_attempts IS '通知发送尝试';
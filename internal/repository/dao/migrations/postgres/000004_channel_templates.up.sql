@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS channel_templates (
+    id                BIGSERIAL    PRIMARY KEY,
+    owner_id          BIGINT       NOT NULL,
+    name              VARCHAR(128) NOT NULL,
+    description       VARCHAR(512) NOT NULL DEFAULT '',
+    channel           VARCHAR(16)  NOT NULL,
+    active_version_id BIGINT       NOT NULL DEFAULT 0,
+    ctime             BIGINT,
+    utime             BIGINT,
+    CONSTRAINT chk_channel_templates_channel CHECK (channel IN ('SMS', 'EMAIL', 'IN_APP'))
+);
+CREATE INDEX IF NOT EXISTS idx_owner_id ON channel_templates (owner_id);
+COMMENT ON TABLE channel_templates IS '渠道模板';
+
+CREATE TABLE IF NOT EXISTS channel_template_versions (
+    id                  BIGSERIAL   PRIMARY KEY,
+    channel_template_id BIGINT      NOT NULL,
+    name                VARCHAR(32) NOT NULL,
+    signature           VARCHAR(64) NOT NULL DEFAULT '',
+    content             TEXT        NOT NULL,
+    remark              TEXT        NOT NULL,
+    param_schema        TEXT        NOT NULL,
+    ctime               BIGINT,
+    utime               BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_channel_template_id ON channel_template_versions (channel_template_id);
+COMMENT ON COLUMN channel_template_versions.param_schema IS '模板参数定义，JSON数组';
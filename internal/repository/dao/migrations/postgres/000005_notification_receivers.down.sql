@@ -0,0 +1,3 @@
+COMMENT ON COLUMN notifications.receivers IS '接收者(手机/邮箱/用户ID)，JSON数组';
+
+DROP TABLE IF EXISTS notification_receivers;
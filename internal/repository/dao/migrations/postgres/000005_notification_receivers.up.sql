@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS notification_receivers (
+    id              BIGSERIAL PRIMARY KEY,
+    notification_id BIGINT    NOT NULL,
+    biz_id          BIGINT    NOT NULL,
+    receiver_index  CHAR(64)  NOT NULL,
+    ctime           BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_receivers_notification_id ON notification_receivers (notification_id);
+CREATE INDEX IF NOT EXISTS idx_biz_id_receiver_index ON notification_receivers (biz_id, receiver_index);
+COMMENT ON TABLE notification_receivers IS '通知接收者盲索引';
+
+COMMENT ON COLUMN notifications.receivers IS '接收者(手机/邮箱/用户ID)，JSON数组，开启加密后为密文';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS receiver_erasures;
+
+DROP INDEX IF EXISTS idx_notifications_ctime;
+ALTER TABLE notifications DROP COLUMN IF EXISTS erase_time;
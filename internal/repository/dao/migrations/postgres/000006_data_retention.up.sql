@@ -0,0 +1,16 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS erase_time BIGINT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN notifications.erase_time IS '接收者数据擦除时间，0表示未擦除';
+CREATE INDEX IF NOT EXISTS idx_notifications_ctime ON notifications (ctime);
+
+CREATE TABLE IF NOT EXISTS receiver_erasures (
+    id             BIGSERIAL    PRIMARY KEY,
+    biz_id         BIGINT       NOT NULL,
+    receiver_index CHAR(64)     NOT NULL,
+    operator       VARCHAR(64)  NOT NULL DEFAULT '',
+    reason         VARCHAR(256) NOT NULL DEFAULT '',
+    affected       BIGINT       NOT NULL DEFAULT 0,
+    ctime          BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_erasures_biz_id_receiver_index ON receiver_erasures (biz_id, receiver_index);
+COMMENT ON TABLE receiver_erasures IS '接收者数据擦除记录';
+COMMENT ON COLUMN receiver_erasures.receiver_index IS '接收者盲索引，不保存接收者本身';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS role_assignments (
+    id        BIGSERIAL    PRIMARY KEY,
+    principal VARCHAR(128) NOT NULL,
+    biz_id    BIGINT       NOT NULL,
+    role      VARCHAR(32)  NOT NULL,
+    operator  VARCHAR(128) NOT NULL DEFAULT '',
+    ctime     BIGINT,
+    utime     BIGINT,
+    CONSTRAINT chk_role_assignments_role CHECK (role IN ('PLATFORM_ADMIN', 'BIZ_ADMIN', 'READ_ONLY'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_principal_biz_id ON role_assignments (principal, biz_id);
+CREATE INDEX IF NOT EXISTS idx_role_assignments_biz_id ON role_assignments (biz_id);
+COMMENT ON TABLE role_assignments IS '角色分配，平台管理员的 biz_id 为 0';
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS callback_secrets (
+    biz_id               BIGINT       PRIMARY KEY,
+    secret               VARCHAR(512) NOT NULL,
+    previous_secret      VARCHAR(512) NOT NULL DEFAULT '',
+    previous_expire_time BIGINT       NOT NULL DEFAULT 0,
+    ctime                BIGINT,
+    utime                BIGINT
+);
+COMMENT ON TABLE callback_secrets IS '业务方回调签名密钥，密钥以密文存储';
@@ -0,0 +1 @@
+ALTER TABLE notifications DROP COLUMN IF EXISTS fail_reason;
@@ -0,0 +1,2 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS fail_reason VARCHAR(32) NOT NULL DEFAULT '';
+COMMENT ON COLUMN notifications.fail_reason IS '失败原因，发送失败时为空';
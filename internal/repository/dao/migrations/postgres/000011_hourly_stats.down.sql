@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS provider_hourly_stats;
+DROP TABLE IF EXISTS notification_hourly_stats;
+DROP INDEX IF EXISTS idx_attempts_ctime;
+ALTER TABLE notification_attempts DROP COLUMN IF EXISTS provider;
@@ -0,0 +1,35 @@
+ALTER TABLE notification_attempts ADD COLUMN IF NOT EXISTS provider VARCHAR(64) NOT NULL DEFAULT '';
+COMMENT ON COLUMN notification_attempts.provider IS '供应商名称，用于统计成功率';
+CREATE INDEX IF NOT EXISTS idx_attempts_ctime ON notification_attempts (ctime);
+
+CREATE TABLE IF NOT EXISTS notification_hourly_stats (
+    id          BIGSERIAL   PRIMARY KEY,
+    biz_id      BIGINT      NOT NULL,
+    stat_hour   BIGINT      NOT NULL,
+    channel     VARCHAR(16) NOT NULL,
+    status      VARCHAR(16) NOT NULL,
+    template_id BIGINT      NOT NULL,
+    cnt         BIGINT      NOT NULL DEFAULT 0,
+    ctime       BIGINT,
+    utime       BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_hourly_stats
+    ON notification_hourly_stats (biz_id, stat_hour, channel, status, template_id);
+CREATE INDEX IF NOT EXISTS idx_notification_hourly_stats_stat_hour ON notification_hourly_stats (stat_hour);
+COMMENT ON TABLE notification_hourly_stats IS '通知小时统计，由统计任务根据通知表重新计算';
+
+CREATE TABLE IF NOT EXISTS provider_hourly_stats (
+    id         BIGSERIAL   PRIMARY KEY,
+    biz_id     BIGINT      NOT NULL,
+    stat_hour  BIGINT      NOT NULL,
+    channel    VARCHAR(16) NOT NULL,
+    provider   VARCHAR(64) NOT NULL,
+    dispatched BIGINT      NOT NULL DEFAULT 0,
+    failed     BIGINT      NOT NULL DEFAULT 0,
+    ctime      BIGINT,
+    utime      BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_provider_hourly_stats
+    ON provider_hourly_stats (biz_id, stat_hour, channel, provider);
+CREATE INDEX IF NOT EXISTS idx_provider_hourly_stats_stat_hour ON provider_hourly_stats (stat_hour);
+COMMENT ON TABLE provider_hourly_stats IS '供应商小时统计，由统计任务根据发送尝试表重新计算';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS export_checkpoints;
+DROP INDEX IF EXISTS idx_notifications_utime_id;
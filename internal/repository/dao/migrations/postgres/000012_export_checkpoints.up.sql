@@ -0,0 +1,10 @@
+CREATE INDEX IF NOT EXISTS idx_notifications_utime_id ON notifications (utime, id);
+
+CREATE TABLE IF NOT EXISTS export_checkpoints (
+    name      VARCHAR(64) PRIMARY KEY,
+    last_time BIGINT      NOT NULL DEFAULT 0,
+    last_id   BIGINT      NOT NULL DEFAULT 0,
+    ctime     BIGINT,
+    utime     BIGINT
+);
+COMMENT ON TABLE export_checkpoints IS '导出到分析库的进度';
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS notification_reads (
+    id              BIGSERIAL    PRIMARY KEY,
+    notification_id BIGINT       NOT NULL,
+    receiver_index  CHAR(64)     NOT NULL,
+    biz_id          BIGINT       NOT NULL,
+    "key"           VARCHAR(256) NOT NULL,
+    template_id     BIGINT       NOT NULL,
+    receiver        TEXT         NOT NULL,
+    read_time       BIGINT       NOT NULL,
+    callback_status VARCHAR(16)  NOT NULL DEFAULT 'NONE',
+    retry_count     INT          NOT NULL DEFAULT 0,
+    next_retry_time BIGINT       NOT NULL DEFAULT 0,
+    ctime           BIGINT,
+    utime           BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reads_notification_receiver ON notification_reads (notification_id, receiver_index);
+CREATE INDEX IF NOT EXISTS idx_reads_biz_id_template_id ON notification_reads (biz_id, template_id);
+CREATE INDEX IF NOT EXISTS idx_reads_callback ON notification_reads (callback_status, next_retry_time);
+COMMENT ON TABLE notification_reads IS '站内信已读回执';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS escalations (
+    id             BIGSERIAL    PRIMARY KEY,
+    biz_id         BIGINT       NOT NULL,
+    "key"          VARCHAR(256) NOT NULL,
+    steps          TEXT         NOT NULL,
+    step_count     INT          NOT NULL,
+    current_step   INT          NOT NULL DEFAULT -1,
+    status         VARCHAR(16)  NOT NULL,
+    next_step_time BIGINT       NOT NULL,
+    ack_time       BIGINT       NOT NULL DEFAULT 0,
+    ack_by         VARCHAR(256) NOT NULL DEFAULT '',
+    version        INT          NOT NULL DEFAULT 1,
+    ctime          BIGINT,
+    utime          BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_escalations_biz_id_key ON escalations (biz_id, "key");
+CREATE INDEX IF NOT EXISTS idx_escalations_due ON escalations (status, next_step_time);
+COMMENT ON TABLE escalations IS '跨渠道升级链';
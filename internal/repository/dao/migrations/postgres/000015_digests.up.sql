@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS digest_groups (
+    id              BIGSERIAL   PRIMARY KEY,
+    biz_id          BIGINT      NOT NULL,
+    channel         VARCHAR(16) NOT NULL,
+    receiver_index  CHAR(64)    NOT NULL,
+    window_start    BIGINT      NOT NULL,
+    window_end      BIGINT      NOT NULL,
+    template_id     BIGINT      NOT NULL,
+    receiver        TEXT        NOT NULL,
+    status          VARCHAR(16) NOT NULL,
+    item_count      INT         NOT NULL DEFAULT 0,
+    notification_id BIGINT      NOT NULL DEFAULT 0,
+    ctime           BIGINT,
+    utime           BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digest_groups_window ON digest_groups (biz_id, channel, receiver_index, window_start);
+CREATE INDEX IF NOT EXISTS idx_digest_groups_due ON digest_groups (status, window_end);
+COMMENT ON TABLE digest_groups IS '合并发送分组';
+
+CREATE TABLE IF NOT EXISTS digest_items (
+    id             BIGSERIAL    PRIMARY KEY,
+    group_id       BIGINT       NOT NULL,
+    biz_id         BIGINT       NOT NULL,
+    "key"          VARCHAR(256) NOT NULL,
+    receiver_index CHAR(64)     NOT NULL,
+    summary        TEXT         NOT NULL,
+    ctime          BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digest_items_key ON digest_items (biz_id, "key", receiver_index);
+CREATE INDEX IF NOT EXISTS idx_digest_items_group_id ON digest_items (group_id);
+COMMENT ON TABLE digest_items IS '进入合并窗口的通知';
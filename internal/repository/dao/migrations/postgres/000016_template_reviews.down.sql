@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS channel_template_providers;
+ALTER TABLE channel_template_versions DROP COLUMN IF EXISTS audit_status;
+ALTER TABLE channel_template_versions DROP COLUMN IF EXISTS reject_reason;
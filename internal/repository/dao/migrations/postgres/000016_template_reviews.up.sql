@@ -0,0 +1,24 @@
+ALTER TABLE channel_template_versions ADD COLUMN IF NOT EXISTS audit_status VARCHAR(16) NOT NULL DEFAULT 'PENDING';
+ALTER TABLE channel_template_versions ADD COLUMN IF NOT EXISTS reject_reason VARCHAR(512) NOT NULL DEFAULT '';
+COMMENT ON COLUMN channel_template_versions.audit_status IS '内部审核状态';
+COMMENT ON COLUMN channel_template_versions.reject_reason IS '审核不通过的原因';
+
+-- 已有的版本在引入审核流程之前就在使用，视为审核通过
+UPDATE channel_template_versions SET audit_status = 'APPROVED';
+
+CREATE TABLE IF NOT EXISTS channel_template_providers (
+    id                   BIGSERIAL    PRIMARY KEY,
+    template_id          BIGINT       NOT NULL,
+    template_version_id  BIGINT       NOT NULL,
+    provider_name        VARCHAR(64)  NOT NULL,
+    provider_template_id VARCHAR(128) NOT NULL DEFAULT '',
+    audit_status         VARCHAR(16)  NOT NULL,
+    reject_reason        VARCHAR(512) NOT NULL DEFAULT '',
+    retry_count          INT          NOT NULL DEFAULT 0,
+    next_check_time      BIGINT       NOT NULL DEFAULT 0,
+    ctime                BIGINT,
+    utime                BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_template_providers_version_provider ON channel_template_providers (template_version_id, provider_name);
+CREATE INDEX IF NOT EXISTS idx_template_providers_check ON channel_template_providers (audit_status, next_check_time);
+COMMENT ON TABLE channel_template_providers IS '模板版本的供应商审核记录';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_template_providers_provider_template;
@@ -0,0 +1,2 @@
+-- 供应商推送审核结果时只带供应商的模板ID
+CREATE INDEX IF NOT EXISTS idx_template_providers_provider_template ON channel_template_providers (provider_name, provider_template_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS vendor_balance_snapshots (
+    id        BIGSERIAL   PRIMARY KEY,
+    provider  VARCHAR(64) NOT NULL,
+    unit      VARCHAR(16) NOT NULL,
+    remaining BIGINT      NOT NULL,
+    total     BIGINT      NOT NULL DEFAULT 0,
+    ctime     BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_vendor_balance_provider_ctime ON vendor_balance_snapshots (provider, ctime);
+CREATE INDEX IF NOT EXISTS idx_vendor_balance_ctime ON vendor_balance_snapshots (ctime);
+COMMENT ON TABLE vendor_balance_snapshots IS '供应商余额快照';
@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_status ON callback_logs (status);
+DROP INDEX IF EXISTS idx_callback_logs_status_utime;
+DROP INDEX IF EXISTS idx_callback_logs_status_next_retry_time;
@@ -0,0 +1,4 @@
+-- 回调重试按 status + next_retry_time 查询，清理按 status + utime 查询，都以 status 开头，替换原来的 idx_status
+CREATE INDEX IF NOT EXISTS idx_callback_logs_status_next_retry_time ON callback_logs (status, next_retry_time);
+CREATE INDEX IF NOT EXISTS idx_callback_logs_status_utime ON callback_logs (status, utime);
+DROP INDEX IF EXISTS idx_status;
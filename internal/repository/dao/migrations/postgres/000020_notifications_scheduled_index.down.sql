@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_scheduled ON notifications (scheduled_stime, scheduled_etime, status);
+DROP INDEX IF EXISTS idx_notifications_status_scheduled;
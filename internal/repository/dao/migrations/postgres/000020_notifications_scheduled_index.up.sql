@@ -0,0 +1,3 @@
+-- 调度器按 status + 计划发送时间过滤、按 id 游标分页，status 是等值条件放在最前面，id 放在最后覆盖游标条件
+CREATE INDEX IF NOT EXISTS idx_notifications_status_scheduled ON notifications (status, scheduled_stime, scheduled_etime, id);
+DROP INDEX IF EXISTS idx_scheduled;
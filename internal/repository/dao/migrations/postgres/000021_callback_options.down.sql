@@ -0,0 +1,3 @@
+ALTER TABLE callback_logs DROP COLUMN IF EXISTS on_status;
+ALTER TABLE callback_logs DROP COLUMN IF EXISTS headers;
+ALTER TABLE callback_logs DROP COLUMN IF EXISTS url;
@@ -0,0 +1,4 @@
+-- 单条通知的回调设置，为空时回调业务方配置的默认地址，只在发送成功后回调
+ALTER TABLE callback_logs ADD COLUMN IF NOT EXISTS url VARCHAR(512) NOT NULL DEFAULT '';
+ALTER TABLE callback_logs ADD COLUMN IF NOT EXISTS headers VARCHAR(4096) NOT NULL DEFAULT '';
+ALTER TABLE callback_logs ADD COLUMN IF NOT EXISTS on_status VARCHAR(32) NOT NULL DEFAULT '';
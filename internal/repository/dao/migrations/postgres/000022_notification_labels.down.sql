@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_labels;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS labels;
@@ -0,0 +1,14 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS labels JSONB;
+
+-- 按标签查询通知，每个标签一行
+CREATE TABLE IF NOT EXISTS notification_labels (
+    id              BIGSERIAL    PRIMARY KEY,
+    notification_id BIGINT       NOT NULL,
+    biz_id          BIGINT       NOT NULL,
+    label_key       VARCHAR(63)  NOT NULL,
+    label_value     VARCHAR(128) NOT NULL,
+    ctime           BIGINT
+);
+CREATE INDEX IF NOT EXISTS idx_notification_labels_biz_key_value ON notification_labels (biz_id, label_key, label_value, notification_id);
+CREATE INDEX IF NOT EXISTS idx_notification_labels_notification_id ON notification_labels (notification_id);
+COMMENT ON TABLE notification_labels IS '通知标签索引';
@@ -0,0 +1,2 @@
+-- 按业务方导出一段时间内创建的通知，按 (ctime, id) 游标分页
+CREATE INDEX IF NOT EXISTS idx_notifications_biz_id_ctime ON notifications (biz_id, ctime, id);
@@ -0,0 +1,7 @@
+DELETE FROM notification_hourly_stats WHERE environment <> 'PRODUCTION';
+DROP INDEX IF EXISTS idx_notification_hourly_stats;
+ALTER TABLE notification_hourly_stats DROP COLUMN IF EXISTS environment;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_hourly_stats
+    ON notification_hourly_stats (biz_id, stat_hour, channel, status, template_id);
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS environment;
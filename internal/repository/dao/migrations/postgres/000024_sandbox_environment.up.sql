@@ -0,0 +1,8 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS environment VARCHAR(16) NOT NULL DEFAULT 'PRODUCTION';
+COMMENT ON COLUMN notifications.environment IS '环境，PRODUCTION 或 SANDBOX';
+
+-- 沙箱和生产环境的通知分开统计
+ALTER TABLE notification_hourly_stats ADD COLUMN IF NOT EXISTS environment VARCHAR(16) NOT NULL DEFAULT 'PRODUCTION';
+DROP INDEX IF EXISTS idx_notification_hourly_stats;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_hourly_stats
+    ON notification_hourly_stats (biz_id, stat_hour, channel, status, template_id, environment);
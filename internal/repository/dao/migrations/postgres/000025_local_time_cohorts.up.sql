@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS local_time_cohorts (
+    id              BIGSERIAL    PRIMARY KEY,
+    biz_id          BIGINT       NOT NULL,
+    "key"           VARCHAR(256) NOT NULL,
+    timezone        VARCHAR(64)  NOT NULL,
+    send_time       BIGINT       NOT NULL,
+    end_time        BIGINT       NOT NULL,
+    receivers       TEXT         NOT NULL,
+    receiver_count  INT          NOT NULL,
+    channel         VARCHAR(16)  NOT NULL,
+    template_id     BIGINT       NOT NULL,
+    template_params TEXT         NOT NULL,
+    category        VARCHAR(16)  NOT NULL DEFAULT '',
+    labels          JSONB,
+    environment     VARCHAR(16)  NOT NULL DEFAULT 'PRODUCTION',
+    status          VARCHAR(16)  NOT NULL,
+    notification_id BIGINT       NOT NULL DEFAULT 0,
+    ctime           BIGINT,
+    utime           BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_local_time_cohorts_key ON local_time_cohorts (biz_id, "key", timezone);
+CREATE INDEX IF NOT EXISTS idx_local_time_cohorts_due ON local_time_cohorts (status, send_time);
+COMMENT ON TABLE local_time_cohorts IS '按本地时间发送的时区分组';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS pacing_campaigns;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS campaign;
@@ -0,0 +1,17 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS campaign VARCHAR(64) NOT NULL DEFAULT '';
+COMMENT ON COLUMN notifications.campaign IS '限速发送的活动';
+
+CREATE TABLE IF NOT EXISTS pacing_campaigns (
+    id          BIGSERIAL   PRIMARY KEY,
+    biz_id      BIGINT      NOT NULL,
+    campaign    VARCHAR(64) NOT NULL,
+    per_minute  INT         NOT NULL,
+    next_slot   BIGINT      NOT NULL,
+    sent_minute BIGINT      NOT NULL DEFAULT 0,
+    sent_count  INT         NOT NULL DEFAULT 0,
+    sent_total  BIGINT      NOT NULL DEFAULT 0,
+    ctime       BIGINT,
+    utime       BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pacing_campaigns_campaign ON pacing_campaigns (biz_id, campaign);
+COMMENT ON TABLE pacing_campaigns IS '限速发送的活动进度';
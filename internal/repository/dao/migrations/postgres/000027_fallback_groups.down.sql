@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notifications_biz_id_fallback_group;
+ALTER TABLE notifications DROP COLUMN IF EXISTS fallback_group;
@@ -0,0 +1,3 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS fallback_group VARCHAR(128) NOT NULL DEFAULT '';
+COMMENT ON COLUMN notifications.fallback_group IS '渠道降级分组';
+CREATE INDEX IF NOT EXISTS idx_notifications_biz_id_fallback_group ON notifications (biz_id, fallback_group);
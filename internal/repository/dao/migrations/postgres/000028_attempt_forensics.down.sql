@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_attempts_provider_ctime;
+DROP INDEX IF EXISTS idx_attempts_message_id;
+ALTER TABLE notification_attempts DROP COLUMN IF EXISTS error;
+ALTER TABLE notification_attempts DROP COLUMN IF EXISTS response_code;
+ALTER TABLE notification_attempts DROP COLUMN IF EXISTS latency_ms;
+ALTER TABLE notification_attempts DROP COLUMN IF EXISTS request_digest;
@@ -0,0 +1,10 @@
+ALTER TABLE notification_attempts ADD COLUMN IF NOT EXISTS request_digest VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE notification_attempts ADD COLUMN IF NOT EXISTS latency_ms BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE notification_attempts ADD COLUMN IF NOT EXISTS response_code VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE notification_attempts ADD COLUMN IF NOT EXISTS error VARCHAR(512) NOT NULL DEFAULT '';
+COMMENT ON COLUMN notification_attempts.request_digest IS '发送内容摘要，SHA-256';
+COMMENT ON COLUMN notification_attempts.latency_ms IS '调用供应商耗时（毫秒）';
+COMMENT ON COLUMN notification_attempts.response_code IS '供应商返回的状态码';
+COMMENT ON COLUMN notification_attempts.error IS '调用失败的原因';
+CREATE INDEX IF NOT EXISTS idx_attempts_message_id ON notification_attempts (message_id);
+CREATE INDEX IF NOT EXISTS idx_attempts_provider_ctime ON notification_attempts (provider, ctime);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS receiver_blacklist;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS receiver_blacklist (
+    id              BIGSERIAL   PRIMARY KEY,
+    channel         VARCHAR(16) NOT NULL,
+    receiver_index  CHAR(64)    NOT NULL,
+    receiver        TEXT        NOT NULL,
+    category        VARCHAR(32) NOT NULL,
+    vendor          VARCHAR(32) NOT NULL DEFAULT '',
+    code            VARCHAR(64) NOT NULL DEFAULT '',
+    notification_id BIGINT      NOT NULL DEFAULT 0,
+    expire_time     BIGINT      NOT NULL,
+    ctime           BIGINT,
+    utime           BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blacklist_channel_receiver ON receiver_blacklist (channel, receiver_index);
+CREATE INDEX IF NOT EXISTS idx_blacklist_expire_time ON receiver_blacklist (expire_time);
+COMMENT ON TABLE receiver_blacklist IS '渠道接收者黑名单';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS receiver_opt_outs;
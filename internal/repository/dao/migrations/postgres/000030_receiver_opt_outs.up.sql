@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS receiver_opt_outs (
+    id             BIGSERIAL   PRIMARY KEY,
+    biz_id         BIGINT      NOT NULL,
+    channel        VARCHAR(16) NOT NULL,
+    receiver_index CHAR(64)    NOT NULL,
+    receiver       TEXT        NOT NULL,
+    source         VARCHAR(32) NOT NULL,
+    ctime          BIGINT,
+    utime          BIGINT
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_opt_outs_channel_receiver ON receiver_opt_outs (channel, receiver_index, biz_id);
+COMMENT ON TABLE receiver_opt_outs IS '接收者退订营销类通知';
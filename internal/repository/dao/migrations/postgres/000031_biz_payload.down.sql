@@ -0,0 +1,2 @@
+ALTER TABLE local_time_cohorts DROP COLUMN IF EXISTS biz_payload;
+ALTER TABLE notifications DROP COLUMN IF EXISTS biz_payload;
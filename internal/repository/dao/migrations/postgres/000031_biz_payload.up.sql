@@ -0,0 +1,4 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS biz_payload VARCHAR(1024) NOT NULL DEFAULT '';
+COMMENT ON COLUMN notifications.biz_payload IS '业务方透传数据，回调和查询时原样返回';
+ALTER TABLE local_time_cohorts ADD COLUMN IF NOT EXISTS biz_payload VARCHAR(1024) NOT NULL DEFAULT '';
+COMMENT ON COLUMN local_time_cohorts.biz_payload IS '业务方透传数据';
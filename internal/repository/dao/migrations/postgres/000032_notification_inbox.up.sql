@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS notification_inbox (
+    id              BIGSERIAL PRIMARY KEY,
+    biz_id          BIGINT    NOT NULL,
+    receiver_index  CHAR(64)  NOT NULL,
+    notification_id BIGINT    NOT NULL,
+    ctime           BIGINT    NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_inbox_receiver_notification ON notification_inbox (biz_id, receiver_index, notification_id);
+CREATE INDEX IF NOT EXISTS idx_inbox_notification_id ON notification_inbox (notification_id);
+COMMENT ON TABLE notification_inbox IS '站内信收件箱，由通知变化投影生成';
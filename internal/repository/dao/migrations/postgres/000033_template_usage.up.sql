@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS template_usage (
+    id             BIGSERIAL PRIMARY KEY,
+    template_id    BIGINT NOT NULL,
+    biz_id         BIGINT NOT NULL,
+    send_count     BIGINT NOT NULL DEFAULT 0,
+    last_used_time BIGINT NOT NULL DEFAULT 0,
+    ctime          BIGINT NOT NULL,
+    utime          BIGINT NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_template_usage_template_id ON template_usage (template_id);
+CREATE INDEX IF NOT EXISTS idx_template_usage_biz_id ON template_usage (biz_id);
+COMMENT ON TABLE template_usage IS '模板使用情况，由统计任务汇总生成';
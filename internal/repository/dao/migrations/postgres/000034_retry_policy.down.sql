@@ -0,0 +1,7 @@
+ALTER TABLE local_time_cohorts DROP COLUMN IF EXISTS retry_max_backoff;
+ALTER TABLE local_time_cohorts DROP COLUMN IF EXISTS retry_initial_backoff;
+ALTER TABLE local_time_cohorts DROP COLUMN IF EXISTS retry_max_attempts;
+ALTER TABLE notifications DROP COLUMN IF EXISTS attempts;
+ALTER TABLE notifications DROP COLUMN IF EXISTS retry_max_backoff;
+ALTER TABLE notifications DROP COLUMN IF EXISTS retry_initial_backoff;
+ALTER TABLE notifications DROP COLUMN IF EXISTS retry_max_attempts;
@@ -0,0 +1,14 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS retry_max_attempts INT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN notifications.retry_max_attempts IS '最多发送次数，包括第一次，0 使用平台默认值';
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS retry_initial_backoff BIGINT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN notifications.retry_initial_backoff IS '第一次重试前等待的毫秒数，0 使用平台默认值';
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS retry_max_backoff BIGINT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN notifications.retry_max_backoff IS '重试等待的毫秒数上限，0 使用平台默认值';
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN notifications.attempts IS '已经失败的发送次数';
+ALTER TABLE local_time_cohorts ADD COLUMN IF NOT EXISTS retry_max_attempts INT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN local_time_cohorts.retry_max_attempts IS '最多发送次数，包括第一次，0 使用平台默认值';
+ALTER TABLE local_time_cohorts ADD COLUMN IF NOT EXISTS retry_initial_backoff BIGINT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN local_time_cohorts.retry_initial_backoff IS '第一次重试前等待的毫秒数，0 使用平台默认值';
+ALTER TABLE local_time_cohorts ADD COLUMN IF NOT EXISTS retry_max_backoff BIGINT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN local_time_cohorts.retry_max_backoff IS '重试等待的毫秒数上限，0 使用平台默认值';
@@ -0,0 +1,2 @@
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS receiver_params TEXT NULL;
+COMMENT ON COLUMN notifications.receiver_params IS '按接收者覆盖的模板参数，JSON 对象，加密存储，没有时为 NULL';
@@ -0,0 +1,3 @@
+ALTER TABLE channel_template_versions DROP COLUMN IF EXISTS reviewer;
+ALTER TABLE channel_template_versions DROP COLUMN IF EXISTS review_time;
+ALTER TABLE channel_template_versions DROP COLUMN IF EXISTS forked_from_version_id;
@@ -0,0 +1,6 @@
+ALTER TABLE channel_template_versions ADD COLUMN IF NOT EXISTS reviewer VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE channel_template_versions ADD COLUMN IF NOT EXISTS review_time BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE channel_template_versions ADD COLUMN IF NOT EXISTS forked_from_version_id BIGINT NOT NULL DEFAULT 0;
+COMMENT ON COLUMN channel_template_versions.reviewer IS '内部审核人，调用方唯一标识';
+COMMENT ON COLUMN channel_template_versions.review_time IS '内部审核时间，毫秒时间戳，0 表示还没有审核';
+COMMENT ON COLUMN channel_template_versions.forked_from_version_id IS '回滚时从哪个版本拷贝，0 表示不是回滚创建的';
@@ -0,0 +1,6 @@
+UPDATE callback_logs SET status = 'FAILED' WHERE status = 'DEAD_LETTER';
+DROP INDEX IF EXISTS idx_callback_logs_status_biz_id;
+ALTER TABLE callback_logs DROP COLUMN IF EXISTS last_error;
+ALTER TABLE callback_logs DROP COLUMN IF EXISTS biz_id;
+ALTER TABLE callback_logs DROP CONSTRAINT IF EXISTS chk_callback_logs_status;
+ALTER TABLE callback_logs ADD CONSTRAINT chk_callback_logs_status CHECK (status IN ('INIT', 'PENDING', 'SUCCEEDED', 'FAILED'));
@@ -0,0 +1,8 @@
+-- 重试次数用完的回调记录进入死信（DEAD_LETTER），管理员按业务方查询和重新回调
+ALTER TABLE callback_logs DROP CONSTRAINT IF EXISTS chk_callback_logs_status;
+ALTER TABLE callback_logs ADD CONSTRAINT chk_callback_logs_status CHECK (status IN ('INIT', 'PENDING', 'SUCCEEDED', 'FAILED', 'DEAD_LETTER'));
+ALTER TABLE callback_logs ADD COLUMN IF NOT EXISTS biz_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE callback_logs ADD COLUMN IF NOT EXISTS last_error VARCHAR(512) NOT NULL DEFAULT '';
+COMMENT ON COLUMN callback_logs.biz_id IS '业务方ID，回调任务处理时补齐';
+COMMENT ON COLUMN callback_logs.last_error IS '最后一次回调失败的原因';
+CREATE INDEX IF NOT EXISTS idx_callback_logs_status_biz_id ON callback_logs (status, biz_id);
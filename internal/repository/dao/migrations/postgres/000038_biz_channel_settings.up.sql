@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS biz_channel_settings (
+    id       BIGSERIAL PRIMARY KEY,
+    biz_id   BIGINT       NOT NULL,
+    channel  VARCHAR(16)  NOT NULL,
+    enabled  BOOLEAN      NOT NULL DEFAULT TRUE,
+    operator VARCHAR(64)  NOT NULL DEFAULT '',
+    reason   VARCHAR(256) NOT NULL DEFAULT '',
+    ctime    BIGINT       NOT NULL,
+    utime    BIGINT       NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_biz_channel_settings_biz_channel ON biz_channel_settings (biz_id, channel);
+COMMENT ON TABLE biz_channel_settings IS '业务方渠道开关，没有记录的渠道默认允许';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS biz_key_policies (
+    id              BIGSERIAL PRIMARY KEY,
+    biz_id          BIGINT      NOT NULL,
+    max_length      INT         NOT NULL DEFAULT 0,
+    charset         VARCHAR(16) NOT NULL DEFAULT 'ANY',
+    required_prefix VARCHAR(64) NOT NULL DEFAULT '',
+    operator        VARCHAR(64) NOT NULL DEFAULT '',
+    ctime           BIGINT      NOT NULL,
+    utime           BIGINT      NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_biz_key_policies_biz_id ON biz_key_policies (biz_id);
+COMMENT ON TABLE biz_key_policies IS '业务方通知 key 的规则，没有记录的业务方只限制长度';
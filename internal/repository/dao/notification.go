@@ -2,11 +2,16 @@ package dao
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	"github.com/go-sql-driver/mysql"
 	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/ctxkit"
+	"golang.org/x/sync/errgroup"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
 	"strings"
 	"time"
 )
@@ -32,38 +37,95 @@ type NotificationDAO interface {
 
 	// GetByKeys 根据业务ID和业务内唯一标识获取通知列表
 	GetByKeys(ctx context.Context, bizID int64, keys ...string) ([]Notification, error)
+	// FindByReceiverIndex 根据接收者盲索引查询通知，按ID倒序
+	FindByReceiverIndex(ctx context.Context, bizID int64, receiverIndex string, limit int) ([]Notification, error)
+	// ListByBiz 按业务方分页查询通知，按ID倒序，用上一页最后一条的ID翻页
+	ListByBiz(ctx context.Context, filter domain.NotificationFilter) ([]Notification, error)
+	// ListForExport 查询业务方在 [start, end) 内创建、在 after 之后的通知，按 (ctime, id) 升序
+	ListForExport(ctx context.Context, bizID int64, start, end int64, after domain.ExportCursor, limit int) ([]Notification, error)
+	// FindByFallbackGroups 查询业务方这些渠道降级分组里的通知，只查状态相关的列，按ID升序
+	FindByFallbackGroups(ctx context.Context, bizID int64, groups []string) ([]Notification, error)
 
 	// CASStatus 更新通知状态
 	CASStatus(ctx context.Context, notification Notification) error
 	UpdateStatus(ctx context.Context, notification Notification) error
+	// CancelPending 将 PENDING 状态的通知CAS更新为 CANCELED，同时写入取消原因
+	CancelPending(ctx context.Context, notification Notification) error
+	// UpdatePending 修改 PENDING 状态通知的接收者、模板参数和发送时间，同时写入审计日志
+	UpdatePending(ctx context.Context, notification Notification, auditLog NotificationAuditLog) (Notification, error)
+	// Reschedule CAS 修改 PENDING 状态通知的计划发送时间，限速发送推迟超出速度的通知时使用
+	Reschedule(ctx context.Context, notification Notification) error
+	// ScheduleRetry 发送失败之后把 SENDING 状态的通知 CAS 改回 PENDING，更新计划发送开始时间，失败次数加一
+	ScheduleRetry(ctx context.Context, notification Notification) error
+	// EraseReceiver 擦除部分接收者，更新接收者、模板参数、状态和接收者索引，并清空审计日志里的快照
+	EraseReceiver(ctx context.Context, notification Notification) error
 
 	// BatchUpdateStatusSucceededOrFailed 批量更新通知状态为成功或失败，使用乐观锁控制并发
 	// successNotifications: 更新为成功状态的通知列表，包含ID、Version和重试次数
 	// failedNotifications: 更新为失败状态的通知列表，包含ID、Version和重试次数
-	BatchUpdateStatusSucceededOrFailed(ctx context.Context, successNotifications, failedNotifications []Notification) error
-
-	FindReadyNotifications(ctx context.Context, offset, limit int) ([]Notification, error)
-	MarkSuccess(ctx context.Context, entity Notification) error
-	MarkFailed(ctx context.Context, entity Notification) error
-	MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) (int64, error)
+	// 返回版本号不匹配、没有更新的通知ID
+	BatchUpdateStatusSucceededOrFailed(ctx context.Context, successNotifications, failedNotifications []Notification) (conflicted []uint64, err error)
+
+	// FindReadyNotifications 查找分区内到了发送时间、ID 大于 afterID 的通知，按 ID 升序
+	FindReadyNotifications(ctx context.Context, partition domain.Partition, afterID uint64, limit int) ([]Notification, error)
+	// MarkSuccess、MarkFailed 只更新 SENDING 且版本号匹配的通知，返回更新的行数，
+	// 超时清理或者其他实例已经给出结果时返回 0，调用方据此决定要不要归还额度
+	MarkSuccess(ctx context.Context, entity Notification) (int64, error)
+	MarkFailed(ctx context.Context, entity Notification) (int64, error)
+	// MarkTimeoutSendingAsFailed 将超过一分钟依旧 SENDING 的通知标记为失败，返回被标记的通知（只有ID、业务方和渠道）
+	MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) ([]Notification, error)
+	// MarkExpiredAsFailed 将过了计划发送结束时间依旧 PENDING 的通知标记为失败，返回被标记的通知（只有ID、业务方和渠道）
+	MarkExpiredAsFailed(ctx context.Context, batchSize int) ([]Notification, error)
 }
 
 // Notification 通知记录表
 type Notification struct {
-	ID                uint64 `gorm:"primaryKey;comment:'雪花算法ID'"`
-	BizID             int64  `gorm:"type:BIGINT;NOT NULL;index:idx_biz_id_status,priority:1;uniqueIndex:idx_biz_id_key,priority:1;comment:'业务配表ID，业务方可能有多个业务每个业务配置不同'"`
+	ID                uint64 `gorm:"primaryKey;index:idx_notifications_utime_id,priority:2;index:idx_notifications_status_scheduled,priority:4;index:idx_notifications_biz_id_ctime,priority:3;comment:'雪花算法ID'"`
+	BizID             int64  `gorm:"type:BIGINT;NOT NULL;index:idx_biz_id_status,priority:1;index:idx_notifications_biz_id_fallback_group,priority:1;index:idx_notifications_biz_id_ctime,priority:1;uniqueIndex:idx_biz_id_key,priority:1;comment:'业务配表ID，业务方可能有多个业务每个业务配置不同'"`
 	Key               string `gorm:"type:VARCHAR(256);NOT NULL;uniqueIndex:idx_biz_id_key,priority:2;comment:'业务内唯一标识，区分同一个业务内的不同通知'"`
 	Receivers         string `gorm:"type:TEXT;NOT NULL;comment:'接收者(手机/邮箱/用户ID)，JSON数组'"`
-	Channel           string `gorm:"type:ENUM('SMS','EMAIL','IN_APP');NOT NULL;comment:'发送渠道'"`
+	Channel           string `gorm:"type:VARCHAR(16);NOT NULL;check:chk_notifications_channel,channel IN ('SMS','EMAIL','IN_APP');comment:'发送渠道'"`
 	TemplateID        int64  `gorm:"type:BIGINT;NOT NULL;comment:'模板ID'"`
 	TemplateVersionID int64  `gorm:"type:BIGINT;NOT NULL;comment:'模板版本ID'"`
 	TemplateParams    string `gorm:"NOT NULL;comment:'模版参数'"`
-	Status            string `gorm:"type:ENUM('PREPARE','CANCELED','PENDING','SENDING','SUCCEEDED','FAILED');DEFAULT:'PENDING';index:idx_biz_id_status,priority:2;index:idx_scheduled,priority:3;comment:'发送状态'"`
-	ScheduledSTime    int64  `gorm:"column:scheduled_stime;index:idx_scheduled,priority:1;comment:'计划发送开始时间'"`
-	ScheduledETime    int64  `gorm:"column:scheduled_etime;index:idx_scheduled,priority:2;comment:'计划发送结束时间'"`
-	Version           int    `gorm:"type:INT;NOT NULL;DEFAULT:1;comment:'版本号，用于CAS操作'"`
-	Ctime             int64
-	Utime             int64
+	// ReceiverParams 按接收者覆盖的模板参数，JSON 对象，和模板参数一样加密，没有时为 NULL
+	ReceiverParams sql.NullString `gorm:"type:TEXT;comment:'按接收者覆盖的模板参数'"`
+	Status         string         `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'PENDING';check:chk_notifications_status,status IN ('PREPARE','CANCELED','PENDING','SENDING','SUCCEEDED','FAILED');index:idx_biz_id_status,priority:2;index:idx_notifications_status_scheduled,priority:1;comment:'发送状态'"`
+	ScheduledSTime int64          `gorm:"column:scheduled_stime;index:idx_notifications_status_scheduled,priority:2;comment:'计划发送开始时间'"`
+	ScheduledETime int64          `gorm:"column:scheduled_etime;index:idx_notifications_status_scheduled,priority:3;comment:'计划发送结束时间'"`
+	Version        int            `gorm:"type:INT;NOT NULL;DEFAULT:1;comment:'版本号，用于CAS操作'"`
+	EraseTime      int64          `gorm:"NOT NULL;DEFAULT:0;comment:'接收者数据擦除时间，0表示未擦除'"`
+	FailReason     string         `gorm:"type:VARCHAR(32);NOT NULL;DEFAULT:'';comment:'失败原因，发送失败时为空'"`
+	// Labels 标签，JSON 对象，没有标签时为 NULL
+	Labels sql.NullString `gorm:"type:JSON;comment:'标签，JSON 对象'"`
+	// Environment 沙箱通知不扣减额度，统计时和生产环境分开
+	Environment string `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'PRODUCTION';comment:'环境，PRODUCTION 或 SANDBOX'"`
+	// Campaign 限速发送的活动，不限速时为空
+	Campaign string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'限速发送的活动'"`
+	// FallbackGroup 渠道降级分组，不分组时为空
+	FallbackGroup string `gorm:"type:VARCHAR(128);NOT NULL;DEFAULT:'';index:idx_notifications_biz_id_fallback_group,priority:2;comment:'渠道降级分组'"`
+	// BizPayload 业务方透传数据，明文保存
+	BizPayload string `gorm:"type:VARCHAR(1024);NOT NULL;DEFAULT:'';comment:'业务方透传数据，回调和查询时原样返回'"`
+	// Attempts 已经失败的发送次数，重新排期时加一
+	Attempts int32 `gorm:"type:INT;NOT NULL;DEFAULT:0;comment:'已经失败的发送次数'"`
+	// Ctime、Utime 都是毫秒时间戳，MarkTimeoutSendingAsFailed 直接用 utime 判断超时
+	Ctime int64 `gorm:"index:idx_notifications_ctime;index:idx_notifications_biz_id_ctime,priority:2"`
+	Utime int64 `gorm:"index:idx_notifications_utime_id,priority:1"`
+
+	// ReceiverIndexes 接收者盲索引，写入 notification_receivers 表
+	// 为 nil 时修改操作不会动索引
+	ReceiverIndexes []string `gorm:"-"`
+	// Callback 回调设置，创建回调记录时写入 callback_logs 表
+	Callback CallbackOptions `gorm:"-"`
+
+	RetryPolicy `gorm:"embedded"`
+}
+
+// RetryPolicy 业务方指定的重试策略，都为 0 时使用平台默认值，等待时间是毫秒
+type RetryPolicy struct {
+	RetryMaxAttempts    int32 `gorm:"column:retry_max_attempts;type:INT;NOT NULL;DEFAULT:0;comment:'最多发送次数，包括第一次，0 使用平台默认值'"`
+	RetryInitialBackoff int64 `gorm:"column:retry_initial_backoff;type:BIGINT;NOT NULL;DEFAULT:0;comment:'第一次重试前等待的毫秒数，0 使用平台默认值'"`
+	RetryMaxBackoff     int64 `gorm:"column:retry_max_backoff;type:BIGINT;NOT NULL;DEFAULT:0;comment:'重试等待的毫秒数上限，0 使用平台默认值'"`
 }
 
 // CheckErrIsIDDuplicate 判断是否是主键冲突
@@ -76,11 +138,18 @@ type notificationDAO struct {
 
 	coreDB     *gorm.DB
 	noneCoreDB *gorm.DB
+
+	// chunkSize 按ID批量查询时每条 SQL 最多带的ID数量，chunkConcurrency 同时执行的 SQL 数量
+	chunkSize        int
+	chunkConcurrency int
+
+	// clock 写入 ctime、utime 和判断超时使用的时间
+	clock clock.Clock
 }
 
 //nolint:unused // 这是我的演示代码
 func (d *notificationDAO) selectDB(ctx context.Context) *gorm.DB {
-	if ctx.Value("Priority") == "high" {
+	if ctxkit.PriorityFromContext(ctx) == ctxkit.PriorityHigh {
 		return d.coreDB
 	}
 	return d.noneCoreDB
@@ -92,13 +161,34 @@ func NewNotificationDAOV1(coreDB *gorm.DB,
 	return &notificationDAO{
 		coreDB:     coreDB,
 		noneCoreDB: noneCoreDB,
+		clock:      clock.Real(),
 	}
 }
 
-// NewNotificationDAO 创建通知DAO实例
+const (
+	DefaultChunkSize        = 500
+	DefaultChunkConcurrency = 4
+)
+
+// NewNotificationDAO 创建通知DAO实例，使用系统时钟，按ID批量查询使用默认的分片大小和并发数
 func NewNotificationDAO(db *gorm.DB) NotificationDAO {
+	return NewNotificationDAOWithChunk(db, clock.Real(), DefaultChunkSize, DefaultChunkConcurrency)
+}
+
+// NewNotificationDAOWithChunk 按ID批量查询时每 chunkSize 个ID一条 SQL，最多 concurrency 条同时执行，
+// 避免几千个ID放进一个 IN 超过 max_allowed_packet
+func NewNotificationDAOWithChunk(db *gorm.DB, clk clock.Clock, chunkSize, concurrency int) NotificationDAO {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultChunkConcurrency
+	}
 	return &notificationDAO{
-		db: db,
+		db:               db,
+		chunkSize:        chunkSize,
+		chunkConcurrency: concurrency,
+		clock:            clk,
 	}
 }
 
@@ -114,13 +204,13 @@ func (d *notificationDAO) CreateWithCallbackLog(ctx context.Context, data Notifi
 
 //nolint:unused // 演示使用本地事务完成额度扣减
 func (d *notificationDAO) createV1(ctx context.Context, db *gorm.DB, data Notification, createCallbackLog bool) (Notification, error) {
-	now := time.Now().UnixMilli()
+	now := d.clock.Now().UnixMilli()
 	data.Ctime, data.Utime = now, now
 	data.Version = 1
 
 	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&data).Error; err != nil {
-			if d.isUniqueConstraintError(err) {
+			if isUniqueConstraintError(err) {
 				return fmt.Errorf("%w", domain.ErrNotificationDuplicate)
 			}
 			return err
@@ -139,8 +229,11 @@ func (d *notificationDAO) createV1(ctx context.Context, db *gorm.DB, data Notifi
 		if createCallbackLog {
 			if err := tx.Create(&CallbackLog{
 				NotificationID: data.ID,
+				BizID:          data.BizID,
 				Status:         domain.CallbackLogStatusInit.String(),
 				NextRetryTime:  now,
+				Ctime:          now,
+				Utime:          now,
 			}).Error; err != nil {
 				return fmt.Errorf("%w", domain.ErrCreateCallbackLogFailed)
 			}
@@ -152,22 +245,36 @@ func (d *notificationDAO) createV1(ctx context.Context, db *gorm.DB, data Notifi
 }
 
 func (d *notificationDAO) create(ctx context.Context, db *gorm.DB, data Notification, createCallbackLog bool) (Notification, error) {
-	now := time.Now().UnixMilli()
+	now := d.clock.Now().UnixMilli()
 	data.Ctime, data.Utime = now, now
 	data.Version = 1
 
 	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(&data).Error; err != nil {
-			if d.isUniqueConstraintError(err) {
+			if isUniqueConstraintError(err) {
 				return fmt.Errorf("%w", domain.ErrNotificationDuplicate)
 			}
 			return err
 		}
+		if rows := receiverRows(data, now); len(rows) > 0 {
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		if rows := labelRows(data, now); len(rows) > 0 {
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+		}
 		if createCallbackLog {
 			if err := tx.Create(&CallbackLog{
-				NotificationID: data.ID,
-				Status:         domain.CallbackLogStatusInit.String(),
-				NextRetryTime:  now,
+				NotificationID:  data.ID,
+				BizID:           data.BizID,
+				Status:          domain.CallbackLogStatusInit.String(),
+				NextRetryTime:   now,
+				Ctime:           now,
+				Utime:           now,
+				CallbackOptions: data.Callback,
 			}).Error; err != nil {
 				return fmt.Errorf("%w", domain.ErrCreateCallbackLogFailed)
 			}
@@ -178,19 +285,6 @@ func (d *notificationDAO) create(ctx context.Context, db *gorm.DB, data Notifica
 	return data, err
 }
 
-// isUniqueConstraintError 检查是否是唯一索引冲突错误
-func (d *notificationDAO) isUniqueConstraintError(err error) bool {
-	if err == nil {
-		return false
-	}
-	me := new(mysql.MySQLError)
-	if ok := errors.As(err, &me); ok {
-		const uniqueIndexErrNo uint16 = 1062
-		return me.Number == uniqueIndexErrNo
-	}
-	return false
-}
-
 // BatchCreate 批量创建通知记录，但不创建对应的回调记录
 func (d *notificationDAO) BatchCreate(ctx context.Context, datas []Notification) ([]Notification, error) {
 	return d.batchCreate(ctx, datas, false)
@@ -208,7 +302,7 @@ func (d *notificationDAO) batchCreate(ctx context.Context, datas []Notification,
 	}
 
 	const batchSize = 100
-	now := time.Now().UnixMilli()
+	now := d.clock.Now().UnixMilli()
 	for i := range datas {
 		datas[i].Ctime, datas[i].Utime = now, now
 		datas[i].Version = 1
@@ -218,21 +312,43 @@ func (d *notificationDAO) batchCreate(ctx context.Context, datas []Notification,
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 创建通知记录 - 真正的批量插入
 		if err := tx.CreateInBatches(datas, batchSize).Error; err != nil {
-			if d.isUniqueConstraintError(err) {
+			if isUniqueConstraintError(err) {
 				return fmt.Errorf("%w", domain.ErrNotificationDuplicate)
 			}
 			return err
 		}
 
+		var receivers []NotificationReceiver
+		for i := range datas {
+			receivers = append(receivers, receiverRows(datas[i], now)...)
+		}
+		if len(receivers) > 0 {
+			if err := tx.CreateInBatches(receivers, batchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		var labels []NotificationLabel
+		for i := range datas {
+			labels = append(labels, labelRows(datas[i], now)...)
+		}
+		if len(labels) > 0 {
+			if err := tx.CreateInBatches(labels, batchSize).Error; err != nil {
+				return err
+			}
+		}
+
 		if createCallbackLog {
 			// 创建回调记录
 			var callbackLogs []CallbackLog
 			for i := range datas {
 				callbackLogs = append(callbackLogs, CallbackLog{
-					NotificationID: datas[i].ID,
-					NextRetryTime:  now,
-					Ctime:          now,
-					Utime:          now,
+					NotificationID:  datas[i].ID,
+					BizID:           datas[i].BizID,
+					NextRetryTime:   now,
+					Ctime:           now,
+					Utime:           now,
+					CallbackOptions: datas[i].Callback,
 				})
 			}
 			if err := tx.CreateInBatches(callbackLogs, batchSize).Error; err != nil {
@@ -258,23 +374,40 @@ func (d *notificationDAO) GetByID(ctx context.Context, id uint64) (Notification,
 	return notification, nil
 }
 
+// BatchGetByIDs 重复的ID只查一次，ID 太多时分片并发查询，任意一片失败返回错误
 func (d *notificationDAO) BatchGetByIDs(ctx context.Context, ids []uint64) (map[uint64]Notification, error) {
-	var notifications []Notification
-	err := d.db.WithContext(ctx).
-		Where("id in (?)", ids).
-		Find(&notifications).Error
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	chunks := slices.Collect(slices.Chunk(ids, d.chunkSize))
+	results := make([][]Notification, len(chunks))
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(d.chunkConcurrency)
+	for i, chunk := range chunks {
+		eg.Go(func() error {
+			return d.db.WithContext(egCtx).
+				Where("id in (?)", chunk).
+				Find(&results[i]).Error
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
 	notificationMap := make(map[uint64]Notification, len(ids))
-	for idx := range notifications {
-		notification := notifications[idx]
-		notificationMap[notification.ID] = notification
+	for _, notifications := range results {
+		for idx := range notifications {
+			notification := notifications[idx]
+			notificationMap[notification.ID] = notification
+		}
 	}
-	return notificationMap, err
+	return notificationMap, nil
 }
 
 func (d *notificationDAO) GetByKey(ctx context.Context, bizID int64, key string) (Notification, error) {
 	var not Notification
-	err := d.db.WithContext(ctx).Where("biz_id = ? AND `key` = ?", bizID, key).First(&not).Error
+	err := d.db.WithContext(ctx).Where(map[string]any{"biz_id": bizID, "key": key}).First(&not).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Notification{}, fmt.Errorf("%w: bizID=%d, key=%s", domain.ErrNotificationNotFound, bizID, key)
+		}
 		return Notification{}, fmt.Errorf("查询通知列表失败:bizID: %d, key %s %w", bizID, key, err)
 	}
 	return not, nil
@@ -283,19 +416,78 @@ func (d *notificationDAO) GetByKey(ctx context.Context, bizID int64, key string)
 // GetByKeys 根据业务ID和业务内唯一标识获取通知列表
 func (d *notificationDAO) GetByKeys(ctx context.Context, bizID int64, keys ...string) ([]Notification, error) {
 	var notifications []Notification
-	err := d.db.WithContext(ctx).Where("biz_id = ? AND `key` IN ?", bizID, keys).Find(&notifications).Error
+	err := d.db.WithContext(ctx).Where(map[string]any{"biz_id": bizID, "key": keys}).Find(&notifications).Error
 	if err != nil {
 		return nil, fmt.Errorf("查询通知列表失败: %w", err)
 	}
 	return notifications, nil
 }
 
+// FindByReceiverIndex 根据接收者盲索引查询通知，按ID倒序
+func (d *notificationDAO) FindByReceiverIndex(ctx context.Context, bizID int64, receiverIndex string, limit int) ([]Notification, error) {
+	var notifications []Notification
+	sub := d.db.Model(&NotificationReceiver{}).
+		Select("notification_id").
+		Where("biz_id = ? AND receiver_index = ?", bizID, receiverIndex)
+	err := d.db.WithContext(ctx).
+		Where("id IN (?)", sub).
+		Order("id DESC").
+		Limit(limit).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, fmt.Errorf("根据接收者查询通知失败: %w", err)
+	}
+	return notifications, nil
+}
+
+func (d *notificationDAO) ListByBiz(ctx context.Context, filter domain.NotificationFilter) ([]Notification, error) {
+	var notifications []Notification
+	query := d.db.WithContext(ctx).Where("biz_id = ?", filter.BizID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status.String())
+	}
+	if filter.Channel != "" {
+		query = query.Where("channel = ?", filter.Channel.String())
+	}
+	if len(filter.Labels) > 0 {
+		query = whereLabels(query, filter.BizID, filter.Labels)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&notifications).Error
+	return notifications, err
+}
+
+func (d *notificationDAO) ListForExport(ctx context.Context, bizID int64, start, end int64, after domain.ExportCursor, limit int) ([]Notification, error) {
+	var notifications []Notification
+	query := d.db.WithContext(ctx).Where("biz_id = ? AND ctime >= ? AND ctime < ?", bizID, start, end)
+	if !after.IsZero() {
+		query = query.Where("ctime > ? OR (ctime = ? AND id > ?)", after.Ctime, after.Ctime, after.ID)
+	}
+	err := query.Order("ctime ASC, id ASC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+func (d *notificationDAO) FindByFallbackGroups(ctx context.Context, bizID int64, groups []string) ([]Notification, error) {
+	var notifications []Notification
+	err := d.db.WithContext(ctx).
+		Select("id", "biz_id", "key", "channel", "status", "version", "fail_reason", "labels", "environment", "fallback_group", "biz_payload").
+		Where("biz_id = ? AND fallback_group IN (?)", bizID, groups).
+		Order("id ASC").
+		Find(&notifications).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询渠道降级分组失败: %w", err)
+	}
+	return notifications, nil
+}
+
 // CASStatus 更新通知状态
 func (d *notificationDAO) CASStatus(ctx context.Context, notification Notification) error {
 	updates := map[string]any{
 		"status":  notification.Status,
 		"version": gorm.Expr("version + 1"),
-		"utime":   time.Now().Unix(),
+		"utime":   d.clock.Now().UnixMilli(),
 	}
 
 	result := d.db.WithContext(ctx).Model(&Notification{}).
@@ -318,105 +510,259 @@ func (d *notificationDAO) UpdateStatus(ctx context.Context, notification Notific
 		Updates(map[string]any{
 			"status":  notification.Status,
 			"version": gorm.Expr("version + 1"),
-			"utime":   time.Now().Unix(),
+			"utime":   d.clock.Now().UnixMilli(),
 		}).Error
 }
 
-// BatchUpdateStatusSucceededOrFailed 批量更新通知状态为成功或失败，使用乐观锁控制并发
-// successNotifications: 更新为成功状态的通知列表，包含ID、Version和重试次数
-// failedNotifications: 更新为失败状态的通知列表，包含ID、Version和重试次数
-func (d *notificationDAO) BatchUpdateStatusSucceededOrFailed(ctx context.Context, successNotifications, failedNotifications []Notification) error {
-	if len(successNotifications) == 0 && len(failedNotifications) == 0 {
-		return nil
+// CancelPending 将 PENDING 状态的通知CAS更新为 CANCELED
+// 同时校验状态和版本号，已经被调度器取走（SENDING）或者已经有终态的通知不会被更新
+func (d *notificationDAO) CancelPending(ctx context.Context, notification Notification) error {
+	result := d.db.WithContext(ctx).Model(&Notification{}).
+		Where("id = ? AND version = ? AND status = ?", notification.ID, notification.Version, domain.SendStatusPending.String()).
+		Updates(map[string]any{
+			"status":      domain.SendStatusCanceled.String(),
+			"fail_reason": notification.FailReason,
+			"version":     gorm.Expr("version + 1"),
+			"utime":       d.clock.Now().UnixMilli(),
+		})
+	if result.Error != nil {
+		return result.Error
 	}
-	successIDs := make([]uint64, 0, len(successNotifications))
-	failedIDs := make([]uint64, 0, len(failedNotifications))
-	for i := range successNotifications {
-		successIDs = append(successIDs, successNotifications[i].ID)
+	if result.RowsAffected < 1 {
+		return fmt.Errorf("并发竞争失败 %w, id %d", domain.ErrNotificationVersionMismatch, notification.ID)
 	}
-	for i := range failedNotifications {
-		failedIDs = append(failedIDs, failedNotifications[i].ID)
+	return nil
+}
+
+func (d *notificationDAO) Reschedule(ctx context.Context, notification Notification) error {
+	result := d.db.WithContext(ctx).Model(&Notification{}).
+		Where("id = ? AND version = ? AND status = ?", notification.ID, notification.Version, domain.SendStatusPending.String()).
+		Updates(map[string]any{
+			"scheduled_stime": notification.ScheduledSTime,
+			"scheduled_etime": notification.ScheduledETime,
+			"version":         gorm.Expr("version + 1"),
+			"utime":           d.clock.Now().UnixMilli(),
+		})
+	if result.Error != nil {
+		return result.Error
 	}
-	// 开启事务
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if len(successIDs) != 0 {
-			err := d.batchMarkSuccess(tx, successIDs)
-			if err != nil {
+	if result.RowsAffected < 1 {
+		return fmt.Errorf("并发竞争失败 %w, id %d", domain.ErrNotificationVersionMismatch, notification.ID)
+	}
+	return nil
+}
+
+func (d *notificationDAO) ScheduleRetry(ctx context.Context, notification Notification) error {
+	result := d.db.WithContext(ctx).Model(&Notification{}).
+		Where("id = ? AND version = ? AND status = ?", notification.ID, notification.Version, domain.SendStatusSending.String()).
+		Updates(map[string]any{
+			"status":          domain.SendStatusPending.String(),
+			"scheduled_stime": notification.ScheduledSTime,
+			"attempts":        gorm.Expr("attempts + 1"),
+			"version":         gorm.Expr("version + 1"),
+			"utime":           d.clock.Now().UnixMilli(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < 1 {
+		return fmt.Errorf("并发竞争失败 %w, id %d", domain.ErrNotificationVersionMismatch, notification.ID)
+	}
+	return nil
+}
+
+// UpdatePending 修改 PENDING 状态通知的接收者、模板参数和发送时间，同时写入审计日志
+// 模板参数修改后会重新确定模板版本，所以模板版本ID也一并更新
+// notification.Version 是修改前的版本号，修改成功后返回的版本号加一
+func (d *notificationDAO) UpdatePending(ctx context.Context, notification Notification, auditLog NotificationAuditLog) (Notification, error) {
+	now := d.clock.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Notification{}).
+			Where("id = ? AND version = ? AND status = ?", notification.ID, notification.Version, domain.SendStatusPending.String()).
+			Updates(map[string]any{
+				"receivers":           notification.Receivers,
+				"template_params":     notification.TemplateParams,
+				"receiver_params":     notification.ReceiverParams,
+				"template_version_id": notification.TemplateVersionID,
+				"scheduled_stime":     notification.ScheduledSTime,
+				"scheduled_etime":     notification.ScheduledETime,
+				"version":             gorm.Expr("version + 1"),
+				"utime":               now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return fmt.Errorf("并发竞争失败 %w, id %d", domain.ErrNotificationVersionMismatch, notification.ID)
+		}
+		if notification.ReceiverIndexes != nil {
+			if err := tx.Where("notification_id = ?", notification.ID).Delete(&NotificationReceiver{}).Error; err != nil {
 				return err
 			}
+			if rows := receiverRows(notification, now); len(rows) > 0 {
+				if err := tx.Create(&rows).Error; err != nil {
+					return err
+				}
+			}
 		}
+		auditLog.Ctime = now
+		return tx.Create(&auditLog).Error
+	})
+	if err != nil {
+		return Notification{}, err
+	}
+	notification.Version++
+	notification.Utime = now
+	return notification, nil
+}
 
-		if len(failedIDs) != 0 {
-			now := time.Now().Unix()
-			return tx.Model(&Notification{}).
-				Where("id IN ?", failedIDs).
-				Updates(map[string]any{
-					"version": gorm.Expr("version + 1"),
-					"utime":   now,
-					"status":  domain.SendStatusFailed.String(),
-				}).Error
+// EraseReceiver 擦除部分接收者，更新接收者、模板参数、状态和接收者索引，并清空审计日志里的快照
+// 审计快照里包含修改前后的接收者，没办法只去掉其中一个，所以整个清空
+func (d *notificationDAO) EraseReceiver(ctx context.Context, notification Notification) error {
+	now := d.clock.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{
+			"receivers":       notification.Receivers,
+			"template_params": notification.TemplateParams,
+			"receiver_params": notification.ReceiverParams,
+			"status":          notification.Status,
+			"version":         gorm.Expr("version + 1"),
+			"utime":           now,
 		}
-		return nil
+		if notification.EraseTime > 0 {
+			updates["erase_time"] = notification.EraseTime
+		}
+		result := tx.Model(&Notification{}).
+			Where("id = ? AND version = ?", notification.ID, notification.Version).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < 1 {
+			return fmt.Errorf("并发竞争失败 %w, id %d", domain.ErrNotificationVersionMismatch, notification.ID)
+		}
+		if err := tx.Where("notification_id = ?", notification.ID).Delete(&NotificationReceiver{}).Error; err != nil {
+			return err
+		}
+		// 收件箱等下一次投影按剩下的接收者重新生成
+		if err := tx.Where("notification_id = ?", notification.ID).Delete(&NotificationInbox{}).Error; err != nil {
+			return err
+		}
+		if rows := receiverRows(notification, now); len(rows) > 0 {
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&NotificationAuditLog{}).
+			Where("notification_id = ?", notification.ID).
+			Updates(map[string]any{
+				"before": erasedSnapshot,
+				"after":  erasedSnapshot,
+			}).Error
 	})
 }
 
-func (d *notificationDAO) batchMarkSuccess(tx *gorm.DB, successIDs []uint64) error {
-	now := time.Now().Unix()
-	err := tx.Model(&Notification{}).
-		Where("id IN ?", successIDs).
-		Updates(map[string]any{
-			"version": gorm.Expr("version + 1"),
-			"utime":   now,
-			"status":  domain.SendStatusSucceeded.String(),
-		}).Error
+// BatchUpdateStatusSucceededOrFailed 批量更新通知状态为成功或失败，使用乐观锁控制并发
+// 每一行都按照 ID 和 Version 更新，版本号不匹配的行保持不变，返回这些行的ID，由调用方决定如何处理
+// 所有更新在同一个事务内执行，订阅了发送结果的回调记录同时标记为可以发送
+func (d *notificationDAO) BatchUpdateStatusSucceededOrFailed(ctx context.Context, successNotifications, failedNotifications []Notification) ([]uint64, error) {
+	if len(successNotifications) == 0 && len(failedNotifications) == 0 {
+		return nil, nil
+	}
+	var conflicted []uint64
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		conflicted = conflicted[:0]
+		now := d.clock.Now().UnixMilli()
+		successIDs := make([]uint64, 0, len(successNotifications))
+		for i := range successNotifications {
+			ok, err := d.casStatusInTx(tx, successNotifications[i], domain.SendStatusSucceeded, now)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				conflicted = append(conflicted, successNotifications[i].ID)
+				continue
+			}
+			successIDs = append(successIDs, successNotifications[i].ID)
+		}
+		failedIDs := make([]uint64, 0, len(failedNotifications))
+		for i := range failedNotifications {
+			ok, err := d.casStatusInTx(tx, failedNotifications[i], domain.SendStatusFailed, now)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				conflicted = append(conflicted, failedNotifications[i].ID)
+				continue
+			}
+			failedIDs = append(failedIDs, failedNotifications[i].ID)
+		}
+		// 要更新 callback log 了
+		if err := markCallbackPending(tx, successIDs, domain.SendStatusSucceeded, now); err != nil {
+			return err
+		}
+		return markCallbackPending(tx, failedIDs, domain.SendStatusFailed, now)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return conflicted, nil
+}
 
-	// 要更新 callback log 了
-	return tx.Model(&CallbackLog{}).
-		Where("notification_id IN ? ", successIDs).
+// casStatusInTx 版本号匹配时更新状态，返回是否更新成功
+func (d *notificationDAO) casStatusInTx(tx *gorm.DB, notification Notification, status domain.SendStatus, now int64) (bool, error) {
+	result := tx.Model(&Notification{}).
+		Where("id = ? AND version = ?", notification.ID, notification.Version).
 		Updates(map[string]any{
-			"status": domain.CallbackLogStatusPending.String(),
-			"utime":  now,
-		}).Error
+			"version": gorm.Expr("version + 1"),
+			"utime":   now,
+			"status":  status.String(),
+		})
+	return result.RowsAffected > 0, result.Error
 }
 
-func (d *notificationDAO) FindReadyNotifications(ctx context.Context, offset, limit int) ([]Notification, error) {
+// FindReadyNotifications 按 ID 做游标分页，不用 offset，扫描量不会随着翻页增加
+// 过滤条件和游标都在 idx_notifications_status_scheduled 索引里，过滤时不需要回表
+func (d *notificationDAO) FindReadyNotifications(ctx context.Context, partition domain.Partition, afterID uint64, limit int) ([]Notification, error) {
 	var res []Notification
-	now := time.Now().UnixMilli()
-	err := d.db.WithContext(ctx).
-		Where("scheduled_stime <=? AND scheduled_etime >= ? AND status=?", now, now, domain.SendStatusPending.String()).
-		Limit(limit).Offset(offset).
-		Find(&res).Error
+	now := d.clock.Now().UnixMilli()
+	query := d.db.WithContext(ctx).
+		Where("status = ? AND scheduled_stime <= ? AND scheduled_etime >= ? AND id > ?",
+			domain.SendStatusPending.String(), now, now, afterID)
+	if partition.Partitioned() {
+		// 等价于 domain.Partition.Owns 的 (id >> 16) % Total == Slot，
+		// 只用整数取模，MySQL 和 PostgreSQL 的除法语义不同，这里避开除法
+		mod, lower, upper := partition.Bounds()
+		query = query.Where("MOD(id, ?) >= ? AND MOD(id, ?) < ?", mod, lower, mod, upper)
+	}
+	err := query.Order("id").Limit(limit).Find(&res).Error
 	return res, err
 }
 
-func (d *notificationDAO) MarkSuccess(ctx context.Context, notification Notification) error {
-	now := time.Now().UnixMilli()
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := tx.Model(&Notification{}).
-			Where("id = ?", notification.ID).
+func (d *notificationDAO) MarkSuccess(ctx context.Context, notification Notification) (int64, error) {
+	now := d.clock.Now().UnixMilli()
+	var rowsAffected int64
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Notification{}).
+			Where("id = ? AND status = ? AND version = ?", notification.ID, domain.SendStatusSending.String(), notification.Version).
 			Updates(map[string]any{
 				"status":  notification.Status,
 				"utime":   now,
 				"version": gorm.Expr("version + 1"),
-			}).Error
-		if err != nil {
-			return err
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
 		}
+		rowsAffected = res.RowsAffected
 		// 要把 callback log 标记为可以发送了
-		return tx.Model(&CallbackLog{}).Where("notification_id = ?", notification.ID).Updates(map[string]any{
-			// 标记为可以发送回调了
-			"status": domain.CallbackLogStatusPending,
-			"utime":  now,
-		}).Error
+		return markCallbackPending(tx, []uint64{notification.ID}, domain.SendStatusSucceeded, now)
 	})
+	return rowsAffected, err
 }
 
 // 使用本地事务实现额度的扣减
 func (d *notificationDAO) MarkFailedV1(ctx context.Context, notification Notification) error {
-	now := time.Now().UnixMilli()
+	now := d.clock.Now().UnixMilli()
 	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		err := tx.Model(&Notification{}).
 			Where("id = ?", notification.ID).
@@ -437,54 +783,99 @@ func (d *notificationDAO) MarkFailedV1(ctx context.Context, notification Notific
 	})
 }
 
-func (d *notificationDAO) MarkFailed(ctx context.Context, notification Notification) error {
-	now := time.Now().UnixMilli()
-	return d.db.WithContext(ctx).Model(&Notification{}).
-		Where("id = ?", notification.ID).
-		Updates(map[string]any{
-			"status":  notification.Status,
-			"utime":   now,
-			"version": gorm.Expr("version + 1"),
-		}).Error
+func (d *notificationDAO) MarkFailed(ctx context.Context, notification Notification) (int64, error) {
+	now := d.clock.Now().UnixMilli()
+	var rowsAffected int64
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Notification{}).
+			Where("id = ? AND status = ? AND version = ?", notification.ID, domain.SendStatusSending.String(), notification.Version).
+			Updates(map[string]any{
+				"status":      notification.Status,
+				"fail_reason": notification.FailReason,
+				"utime":       now,
+				"version":     gorm.Expr("version + 1"),
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		rowsAffected = res.RowsAffected
+		return markCallbackPending(tx, []uint64{notification.ID}, domain.SendStatusFailed, now)
+	})
+	return rowsAffected, err
 }
 
-func (d *notificationDAO) MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) (int64, error) {
-	now := time.Now()
+func (d *notificationDAO) MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) ([]Notification, error) {
+	now := d.clock.Now()
 	ddl := now.Add(-time.Minute).UnixMilli()
-	var rowsAffected int64
-
+	var timeout []Notification
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var idsToUpdate []uint64
-
-		// 查询需要更新的 ID
+		// 和 MarkExpiredAsFailed 一样锁住要标记的记录，发送方同时写入结果时只有一方能更新，额度只归还一次
 		err := tx.Model(&Notification{}).
-			Select("id").
+			Select("id", "biz_id", "key", "channel", "environment", "ctime").
 			Where("status = ? AND utime <= ?", domain.SendStatusSending.String(), ddl).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
 			Limit(batchSize).
-			Find(&idsToUpdate).Error
-
-		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			Find(&timeout).Error
+		if err != nil || len(timeout) == 0 {
 			return err
 		}
-
-		// 没有找到需要更新的记录，直接成功返回 (事务将提交)
-		if len(idsToUpdate) == 0 {
-			rowsAffected = 0
-			return nil
+		ids := make([]uint64, 0, len(timeout))
+		for i := range timeout {
+			ids = append(ids, timeout[i].ID)
 		}
-
-		// 根据查询到的 ID 集合更新记录
-		res := tx.Model(&Notification{}).
-			Where("id IN ?", idsToUpdate).
+		err = tx.Model(&Notification{}).
+			Where("id IN ? AND status = ?", ids, domain.SendStatusSending.String()).
 			Updates(map[string]any{
-				"status":  domain.SendStatusFailed.String(),
-				"version": gorm.Expr("version + 1"),
-				"utime":   now.UnixMilli(),
-			})
-
-		rowsAffected = res.RowsAffected
-		return res.Error
+				"status":      domain.SendStatusFailed.String(),
+				"fail_reason": domain.FailReasonSendTimeout.String(),
+				"version":     gorm.Expr("version + 1"),
+				"utime":       now.UnixMilli(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return markCallbackPending(tx, ids, domain.SendStatusFailed, now.UnixMilli())
 	})
+	if err != nil {
+		return nil, err
+	}
+	return timeout, nil
+}
 
-	return rowsAffected, err
+func (d *notificationDAO) MarkExpiredAsFailed(ctx context.Context, batchSize int) ([]Notification, error) {
+	now := d.clock.Now().UnixMilli()
+	var expired []Notification
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁住要标记的记录，SKIP LOCKED 让多个实例可以同时清理不同的记录，
+		// 同时保证返回的就是真正被标记的通知，不会重复归还额度
+		err := tx.Model(&Notification{}).
+			Select("id", "biz_id", "key", "channel", "environment", "ctime").
+			Where("scheduled_stime <= ? AND scheduled_etime < ? AND status = ?", now, now, domain.SendStatusPending.String()).
+			Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Limit(batchSize).
+			Find(&expired).Error
+		if err != nil || len(expired) == 0 {
+			return err
+		}
+		ids := make([]uint64, 0, len(expired))
+		for i := range expired {
+			ids = append(ids, expired[i].ID)
+		}
+		err = tx.Model(&Notification{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"status":      domain.SendStatusFailed.String(),
+				"fail_reason": domain.FailReasonExpired.String(),
+				"version":     gorm.Expr("version + 1"),
+				"utime":       now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return markCallbackPending(tx, ids, domain.SendStatusFailed, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
 }
@@ -0,0 +1,18 @@
+package dao
+
+// NotificationAuditLog 通知变更审计日志表
+type NotificationAuditLog struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'审计日志ID'"`
+	NotificationID uint64 `gorm:"column:notification_id;NOT NULL;index:idx_notification_id_ctime,priority:1;comment:'通知ID'"`
+	BizID          int64  `gorm:"type:BIGINT;NOT NULL;comment:'业务配表ID'"`
+	Action         string `gorm:"type:VARCHAR(16);NOT NULL;comment:'操作类型'"`
+	Operator       string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'操作人'"`
+	Before         string `gorm:"type:TEXT;NOT NULL;comment:'修改前的内容，JSON'"`
+	After          string `gorm:"type:TEXT;NOT NULL;comment:'修改后的内容，JSON'"`
+	Ctime          int64  `gorm:"index:idx_notification_id_ctime,priority:2"`
+}
+
+// TableName 重命名表
+func (NotificationAuditLog) TableName() string {
+	return "notification_audit_logs"
+}
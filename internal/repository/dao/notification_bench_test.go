@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// benchMySQLDSNEnv 配置之后同时压测真实的 MySQL，库里需要先执行 migrate up，
+// 例如 root:root@tcp(127.0.0.1:13316)/notification?parseTime=true（deploy/docker-compose.yaml 启动的 MySQL）
+const benchMySQLDSNEnv = "NOTIFICATION_BENCH_MYSQL_DSN"
+
+var benchBatchSizes = []int{100, 1000, 10000}
+
+// BenchmarkNotificationDAO_BatchCreate 不连数据库，只衡量拼装通知、接收者、标签、回调记录和 SQL 的开销
+func BenchmarkNotificationDAO_BatchCreate(b *testing.B) {
+	d := NewNotificationDAO(newRecordingDB(b, nil))
+	for _, size := range benchBatchSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			benchmarkBatchCreate(b, d, size)
+		})
+	}
+}
+
+// BenchmarkNotificationDAO_BatchCreate_MySQL 包括网络往返和 MySQL 写入的开销
+func BenchmarkNotificationDAO_BatchCreate_MySQL(b *testing.B) {
+	dsn := os.Getenv(benchMySQLDSNEnv)
+	if dsn == "" {
+		b.Skipf("没有配置 %s", benchMySQLDSNEnv)
+	}
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	if err != nil {
+		b.Fatal(err)
+	}
+	d := NewNotificationDAO(db)
+	for _, size := range benchBatchSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			benchmarkBatchCreate(b, d, size)
+		})
+	}
+}
+
+func benchmarkBatchCreate(b *testing.B, d NotificationDAO, size int) {
+	ctx := context.Background()
+	// ID 和 key 每次都不一样，重复执行压测不会触发唯一索引冲突
+	base := uint64(time.Now().UnixNano())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		datas := benchNotifications(base+uint64(i*size), size)
+		b.StartTimer()
+		if _, err := d.BatchCreateWithCallbackLog(ctx, datas); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*size), "ns/row")
+}
+
+func benchNotifications(firstID uint64, size int) []Notification {
+	res := make([]Notification, 0, size)
+	for i := range size {
+		id := firstID + uint64(i)
+		receiver := fmt.Sprintf("138%08d", i)
+		res = append(res, Notification{
+			ID:                id,
+			BizID:             1,
+			Key:               fmt.Sprintf("bench-%d", id),
+			Receivers:         fmt.Sprintf(`[%q]`, receiver),
+			Channel:           domain.ChannelSMS.String(),
+			TemplateID:        1,
+			TemplateVersionID: 1,
+			TemplateParams:    `{"code":"123456"}`,
+			Status:            domain.SendStatusPending.String(),
+			ScheduledSTime:    time.Now().UnixMilli(),
+			ScheduledETime:    time.Now().Add(time.Hour).UnixMilli(),
+			Labels:            sql.NullString{String: `{"campaign":"bench"}`, Valid: true},
+			ReceiverIndexes:   []string{receiver},
+		})
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationInbox 站内信收件箱，每个接收者每条发送成功的站内信一行
+// 由通知变化增量投影生成，按 (biz_id, receiver_index, notification_id) 翻页，不用扫描通知表
+type NotificationInbox struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID          int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_inbox_receiver_notification,priority:1;comment:'业务配表ID'"`
+	ReceiverIndex  string `gorm:"type:CHAR(64);NOT NULL;uniqueIndex:idx_inbox_receiver_notification,priority:2;comment:'接收者盲索引'"`
+	NotificationID uint64 `gorm:"NOT NULL;uniqueIndex:idx_inbox_receiver_notification,priority:3;index:idx_inbox_notification_id;comment:'通知ID'"`
+	Ctime          int64  `gorm:"NOT NULL;comment:'通知的创建时间'"`
+}
+
+// TableName 重命名表
+func (NotificationInbox) TableName() string {
+	return "notification_inbox"
+}
+
+// InboxDAO 站内信收件箱投影
+type InboxDAO interface {
+	// FindReceiverIndexes 查询通知的接收者盲索引
+	FindReceiverIndexes(ctx context.Context, notificationIDs []uint64) (map[uint64][]string, error)
+	// Replace 在同一个事务里删除这些通知在收件箱里的所有行，再写入 rows，重复执行结果一样
+	Replace(ctx context.Context, notificationIDs []uint64, rows []NotificationInbox) error
+	// ListByReceiver 按通知ID倒序查询接收者的收件箱，beforeID 大于 0 时只查询比它小的
+	ListByReceiver(ctx context.Context, bizID int64, receiverIndex string, beforeID uint64, limit int) ([]NotificationInbox, error)
+}
+
+var _ InboxDAO = (*inboxDAO)(nil)
+
+type inboxDAO struct {
+	db *gorm.DB
+}
+
+func NewInboxDAO(db *gorm.DB) InboxDAO {
+	return &inboxDAO{db: db}
+}
+
+func (d *inboxDAO) FindReceiverIndexes(ctx context.Context, notificationIDs []uint64) (map[uint64][]string, error) {
+	res := make(map[uint64][]string, len(notificationIDs))
+	if len(notificationIDs) == 0 {
+		return res, nil
+	}
+	var rows []NotificationReceiver
+	err := d.db.WithContext(ctx).
+		Select("notification_id", "receiver_index").
+		Where("notification_id IN ?", notificationIDs).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询通知接收者失败: %w", err)
+	}
+	for _, row := range rows {
+		res[row.NotificationID] = append(res[row.NotificationID], row.ReceiverIndex)
+	}
+	return res, nil
+}
+
+func (d *inboxDAO) Replace(ctx context.Context, notificationIDs []uint64, rows []NotificationInbox) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("notification_id IN ?", notificationIDs).Delete(&NotificationInbox{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		// 并发投影同一条通知时后写入的忽略，下一次变化会重新投影
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 500).Error
+	})
+}
+
+func (d *inboxDAO) ListByReceiver(ctx context.Context, bizID int64, receiverIndex string, beforeID uint64, limit int) ([]NotificationInbox, error) {
+	var rows []NotificationInbox
+	db := d.db.WithContext(ctx).Where("biz_id = ? AND receiver_index = ?", bizID, receiverIndex)
+	if beforeID > 0 {
+		db = db.Where("notification_id < ?", beforeID)
+	}
+	err := db.Order("notification_id DESC").Limit(limit).Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询收件箱失败: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// NotificationLabel 通知标签索引表，每个标签一行，按标签查询通知时使用
+type NotificationLabel struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	NotificationID uint64 `gorm:"column:notification_id;NOT NULL;index:idx_notification_labels_notification_id;index:idx_notification_labels_biz_key_value,priority:4;comment:'通知ID'"`
+	BizID          int64  `gorm:"type:BIGINT;NOT NULL;index:idx_notification_labels_biz_key_value,priority:1;comment:'业务配表ID'"`
+	LabelKey       string `gorm:"type:VARCHAR(63);NOT NULL;index:idx_notification_labels_biz_key_value,priority:2;comment:'标签键'"`
+	LabelValue     string `gorm:"type:VARCHAR(128);NOT NULL;index:idx_notification_labels_biz_key_value,priority:3;comment:'标签值'"`
+	Ctime          int64
+}
+
+// TableName 重命名表
+func (NotificationLabel) TableName() string {
+	return "notification_labels"
+}
+
+// labelRows 生成通知的标签索引行
+func labelRows(n Notification, now int64) []NotificationLabel {
+	if !n.Labels.Valid {
+		return nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(n.Labels.String), &labels); err != nil {
+		return nil
+	}
+	rows := make([]NotificationLabel, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		rows = append(rows, NotificationLabel{
+			NotificationID: n.ID,
+			BizID:          n.BizID,
+			LabelKey:       k,
+			LabelValue:     labels[k],
+			Ctime:          now,
+		})
+	}
+	return rows
+}
+
+// whereLabels 必须包含所有标签，每个标签一个子查询，走 idx_notification_labels_biz_key_value 索引
+func whereLabels(db *gorm.DB, bizID int64, labels map[string]string) *gorm.DB {
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		sub := db.Session(&gorm.Session{NewDB: true}).Model(&NotificationLabel{}).
+			Select("notification_id").
+			Where("biz_id = ? AND label_key = ? AND label_value = ?", bizID, k, labels[k])
+		db = db.Where("id IN (?)", sub)
+	}
+	return db
+}
@@ -0,0 +1,34 @@
+package dao
+
+// NotificationReceiver 通知接收者盲索引表，接收者加密存储之后通过这张表按接收者查询通知
+type NotificationReceiver struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	NotificationID uint64 `gorm:"column:notification_id;NOT NULL;index:idx_receivers_notification_id;comment:'通知ID'"`
+	BizID          int64  `gorm:"type:BIGINT;NOT NULL;index:idx_biz_id_receiver_index,priority:1;comment:'业务配表ID'"`
+	ReceiverIndex  string `gorm:"type:CHAR(64);NOT NULL;index:idx_biz_id_receiver_index,priority:2;comment:'接收者盲索引，HMAC-SHA256'"`
+	Ctime          int64
+}
+
+// TableName 重命名表
+func (NotificationReceiver) TableName() string {
+	return "notification_receivers"
+}
+
+// receiverRows 生成通知的接收者索引行，同一个通知里重复的接收者只保留一行
+func receiverRows(n Notification, now int64) []NotificationReceiver {
+	rows := make([]NotificationReceiver, 0, len(n.ReceiverIndexes))
+	seen := make(map[string]struct{}, len(n.ReceiverIndexes))
+	for _, idx := range n.ReceiverIndexes {
+		if _, ok := seen[idx]; ok {
+			continue
+		}
+		seen[idx] = struct{}{}
+		rows = append(rows, NotificationReceiver{
+			NotificationID: n.ID,
+			BizID:          n.BizID,
+			ReceiverIndex:  idx,
+			Ctime:          now,
+		})
+	}
+	return rows
+}
@@ -0,0 +1,624 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 所有写 notifications 和 callback_logs 的路径都必须写毫秒时间戳，
+// MarkTimeoutSendingAsFailed 按毫秒比较 utime，混入秒会让 SENDING 的通知立刻被判定超时
+func TestNotificationDAO_WritesMillisecondTimestamps(t *testing.T) {
+	n := Notification{
+		ID:              1,
+		BizID:           2,
+		Key:             "k",
+		Receivers:       `["r"]`,
+		Channel:         domain.ChannelSMS.String(),
+		TemplateID:      3,
+		Status:          domain.SendStatusPending.String(),
+		Version:         1,
+		ReceiverIndexes: []string{"idx"},
+	}
+	testCases := []struct {
+		name string
+		call func(ctx context.Context, d NotificationDAO) error
+	}{
+		{name: "Create", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.Create(ctx, n)
+			return err
+		}},
+		{name: "CreateWithCallbackLog", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.CreateWithCallbackLog(ctx, n)
+			return err
+		}},
+		{name: "BatchCreateWithCallbackLog", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.BatchCreateWithCallbackLog(ctx, []Notification{n})
+			return err
+		}},
+		{name: "CASStatus", call: func(ctx context.Context, d NotificationDAO) error {
+			return d.CASStatus(ctx, n)
+		}},
+		{name: "UpdateStatus", call: func(ctx context.Context, d NotificationDAO) error {
+			return d.UpdateStatus(ctx, n)
+		}},
+		{name: "CancelPending", call: func(ctx context.Context, d NotificationDAO) error {
+			return d.CancelPending(ctx, n)
+		}},
+		{name: "UpdatePending", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.UpdatePending(ctx, n, NotificationAuditLog{NotificationID: n.ID})
+			return err
+		}},
+		{name: "EraseReceiver", call: func(ctx context.Context, d NotificationDAO) error {
+			return d.EraseReceiver(ctx, n)
+		}},
+		{name: "BatchUpdateStatusSucceededOrFailed", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.BatchUpdateStatusSucceededOrFailed(ctx, []Notification{n}, []Notification{n})
+			return err
+		}},
+		{name: "MarkSuccess", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.MarkSuccess(ctx, n)
+			return err
+		}},
+		{name: "MarkFailed", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.MarkFailed(ctx, n)
+			return err
+		}},
+		{name: "MarkTimeoutSendingAsFailed", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.MarkTimeoutSendingAsFailed(ctx, 10)
+			return err
+		}},
+		{name: "MarkExpiredAsFailed", call: func(ctx context.Context, d NotificationDAO) error {
+			_, err := d.MarkExpiredAsFailed(ctx, 10)
+			return err
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := &recorder{}
+			before := time.Now().UnixMilli()
+			if err := tc.call(context.Background(), NewNotificationDAO(newRecordingDB(t, rec))); err != nil {
+				t.Fatalf("调用失败: %v", err)
+			}
+			after := time.Now().UnixMilli()
+
+			stmts := rec.statements()
+			checked := 0
+			for _, s := range stmts {
+				for _, v := range timestampArgs(s) {
+					checked++
+					if v < before || v > after {
+						t.Errorf("时间戳 %d 不是毫秒, SQL: %s", v, s.query)
+					}
+				}
+			}
+			if checked == 0 {
+				t.Fatalf("没有写入任何时间戳, SQL: %v", stmts)
+			}
+		})
+	}
+}
+
+// 注入的时钟决定写入的时间戳，测试可以用 Fake 固定时间
+func TestNotificationDAO_UsesInjectedClock(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := &recorder{}
+	d := NewNotificationDAOWithChunk(newRecordingDB(t, rec), clock.NewFake(now), 0, 0)
+	if _, err := d.MarkTimeoutSendingAsFailed(context.Background(), 10); err != nil {
+		t.Fatalf("调用失败: %v", err)
+	}
+	checked := 0
+	for _, s := range rec.statements() {
+		for _, v := range timestampArgs(s) {
+			checked++
+			if v != now.UnixMilli() {
+				t.Errorf("时间戳 %d 不是注入的时间 %d, SQL: %s", v, now.UnixMilli(), s.query)
+			}
+		}
+	}
+	if checked == 0 {
+		t.Fatal("没有写入任何时间戳")
+	}
+}
+
+// timestampColumns 需要检查的时间戳字段
+var timestampColumns = map[string]bool{"ctime": true, "utime": true}
+
+var (
+	updateColumnPattern = regexp.MustCompile("`(\\w+)`=\\?")
+	insertColumnPattern = regexp.MustCompile("^INSERT INTO `\\w+` \\(([^)]*)\\)")
+)
+
+// timestampArgs 按占位符位置找出 ctime、utime 对应的参数
+// UPDATE 语句 SET 里的字段在 WHERE 之前，直接按出现顺序对应；INSERT 语句按列数循环对应多行
+func timestampArgs(s statement) []int64 {
+	var columns []string
+	if m := insertColumnPattern.FindStringSubmatch(s.query); m != nil {
+		for _, c := range strings.Split(m[1], ",") {
+			columns = append(columns, strings.Trim(c, "` "))
+		}
+	} else if strings.HasPrefix(s.query, "UPDATE") {
+		set := s.query
+		if i := strings.Index(set, " WHERE "); i >= 0 {
+			set = set[:i]
+		}
+		for _, m := range updateColumnPattern.FindAllStringSubmatch(set, -1) {
+			columns = append(columns, m[1])
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	var res []int64
+	for i, arg := range s.args {
+		if i >= len(columns) && !strings.HasPrefix(s.query, "INSERT") {
+			break
+		}
+		if !timestampColumns[columns[i%len(columns)]] {
+			continue
+		}
+		if v, ok := arg.(int64); ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+type statement struct {
+	query string
+	args  []driver.Value
+}
+
+// recorder 记录执行过的写语句，所有写操作都返回影响一行
+type recorder struct {
+	mu    sync.Mutex
+	stmts []statement
+}
+
+func (r *recorder) statements() []statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]statement(nil), r.stmts...)
+}
+
+// newRecordingDB rec 为 nil 时不记录语句，压测时避免内存一直增长
+func newRecordingDB(t testing.TB, rec *recorder) *gorm.DB {
+	t.Helper()
+	sqlDB := sql.OpenDB(recordingConnector{rec: rec})
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+type recordingConnector struct {
+	rec *recorder
+}
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{rec: c.rec}, nil
+}
+
+func (c recordingConnector) Driver() driver.Driver {
+	return nil
+}
+
+type recordingConn struct {
+	rec *recorder
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *recordingConn) Commit() error {
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	return nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.rec == nil {
+		return recordingResult{}, nil
+	}
+	values := make([]driver.Value, 0, len(args))
+	for _, a := range args {
+		values = append(values, a.Value)
+	}
+	c.rec.mu.Lock()
+	c.rec.stmts = append(c.rec.stmts, statement{query: query, args: values})
+	c.rec.mu.Unlock()
+	return recordingResult{}, nil
+}
+
+// QueryContext 所有查询都返回一行 id = 1，让先查后改的方法继续执行到更新
+func (c *recordingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &idRows{}, nil
+}
+
+type recordingResult struct{}
+
+func (recordingResult) LastInsertId() (int64, error) {
+	return 1, nil
+}
+
+func (recordingResult) RowsAffected() (int64, error) {
+	return 1, nil
+}
+
+type idRows struct {
+	done bool
+}
+
+func (r *idRows) Columns() []string {
+	return []string{"id"}
+}
+
+func (r *idRows) Close() error {
+	return nil
+}
+
+func (r *idRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+// MarkSuccess、MarkFailed 只更新还是这个版本的 SENDING 通知，超时清理已经处理过的通知不能被覆盖，回调也不能重复触发
+func TestNotificationDAO_MarkResultGuardsSendingVersion(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name   string
+		stored Notification
+		mark   func(d NotificationDAO, ctx context.Context, n Notification) (int64, error)
+		status domain.SendStatus
+		want   int64
+	}{
+		{name: "MarkSuccess", stored: sqliteNotification(1, domain.SendStatusSending, 2),
+			mark: NotificationDAO.MarkSuccess, status: domain.SendStatusSucceeded, want: 1},
+		{name: "MarkFailed", stored: sqliteNotification(1, domain.SendStatusSending, 2),
+			mark: NotificationDAO.MarkFailed, status: domain.SendStatusFailed, want: 1},
+		{name: "MarkFailed 已经超时", stored: sqliteNotification(1, domain.SendStatusFailed, 3),
+			mark: NotificationDAO.MarkFailed, status: domain.SendStatusFailed},
+		{name: "MarkSuccess 已经超时", stored: sqliteNotification(1, domain.SendStatusFailed, 3),
+			mark: NotificationDAO.MarkSuccess, status: domain.SendStatusSucceeded},
+		{name: "MarkFailed 版本号不匹配", stored: sqliteNotification(1, domain.SendStatusSending, 4),
+			mark: NotificationDAO.MarkFailed, status: domain.SendStatusFailed},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newSQLiteDB(t)
+			ctx := context.Background()
+			if err := db.Create(&tc.stored).Error; err != nil {
+				t.Fatal(err)
+			}
+			pending := CallbackLog{NotificationID: tc.stored.ID, Status: domain.CallbackLogStatusInit.String(),
+				CallbackOptions: CallbackOptions{OnStatus: "SUCCEEDED,FAILED"}}
+			if err := db.Create(&pending).Error; err != nil {
+				t.Fatal(err)
+			}
+			d := NewNotificationDAOWithChunk(db, clock.NewFake(now), 0, 0)
+
+			// 调度时 CAS 成 SENDING 之后的版本号是 2
+			mark := Notification{ID: tc.stored.ID, Status: tc.status.String(), Version: 2, FailReason: domain.FailReasonRetryExhausted.String()}
+			got, err := tc.mark(d, ctx, mark)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("更新了 %d 行, 应该是 %d", got, tc.want)
+			}
+
+			var stored Notification
+			if err := db.First(&stored, tc.stored.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			var callback CallbackLog
+			if err := db.Where("notification_id = ?", tc.stored.ID).First(&callback).Error; err != nil {
+				t.Fatal(err)
+			}
+			if tc.want == 0 {
+				if stored.Status != tc.stored.Status || stored.Version != tc.stored.Version || stored.Utime != tc.stored.Utime {
+					t.Fatalf("没有更新的通知被改了: %+v", stored)
+				}
+				if callback.Status != domain.CallbackLogStatusInit.String() {
+					t.Fatalf("没有更新的通知触发了回调: %s", callback.Status)
+				}
+				return
+			}
+			if stored.Status != tc.status.String() || stored.Version != 3 || stored.Utime != now.UnixMilli() {
+				t.Fatalf("状态 %s 版本 %d 更新时间 %d", stored.Status, stored.Version, stored.Utime)
+			}
+			if callback.Status != domain.CallbackLogStatusPending.String() {
+				t.Fatalf("回调状态 %s, 应该是 PENDING", callback.Status)
+			}
+		})
+	}
+}
+
+func sqliteNotification(id uint64, status domain.SendStatus, version int) Notification {
+	return Notification{
+		ID:         id,
+		BizID:      1,
+		Key:        fmt.Sprintf("key-%d", id),
+		Receivers:  `["r"]`,
+		Channel:    domain.ChannelSMS.String(),
+		TemplateID: 1,
+		Status:     status.String(),
+		Version:    version,
+		Ctime:      1,
+		Utime:      1,
+	}
+}
+
+// newSQLiteDB 内存里的 SQLite，用来验证 SQL 条件的实际效果，每个测试一个库
+func newSQLiteDB(t testing.TB) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	if err := db.AutoMigrate(&Notification{}, &CallbackLog{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// 只有超时的 SENDING 通知被标记为失败并返回，返回的通知就是需要归还额度的通知
+func TestNotificationDAO_MarkTimeoutSendingAsFailed(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := newSQLiteDB(t)
+	stale := now.Add(-2 * time.Minute).UnixMilli()
+	rows := []Notification{
+		sqliteNotification(1, domain.SendStatusSending, 2),
+		sqliteNotification(2, domain.SendStatusSending, 2),
+		sqliteNotification(3, domain.SendStatusPending, 1),
+		sqliteNotification(4, domain.SendStatusSucceeded, 3),
+	}
+	rows[0].Utime, rows[2].Utime, rows[3].Utime = stale, stale, stale
+	// 2 刚刚进入 SENDING，还没有超时
+	rows[1].Utime = now.Add(-10 * time.Second).UnixMilli()
+	// 归还额度时按创建时间抵扣创建当月的透支
+	rows[0].Ctime = now.Add(-time.Hour).UnixMilli()
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	d := NewNotificationDAOWithChunk(db, clock.NewFake(now), 0, 0)
+
+	timeout, err := d.MarkTimeoutSendingAsFailed(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeout) != 1 || timeout[0].ID != 1 || timeout[0].BizID != 1 || timeout[0].Channel != domain.ChannelSMS.String() ||
+		timeout[0].Ctime != rows[0].Ctime {
+		t.Fatalf("标记了 %+v, 应该只有通知 1", timeout)
+	}
+	var stored []Notification
+	if err := db.Order("id").Find(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		status  domain.SendStatus
+		version int
+	}{
+		{domain.SendStatusFailed, 3},
+		{domain.SendStatusSending, 2},
+		{domain.SendStatusPending, 1},
+		{domain.SendStatusSucceeded, 3},
+	}
+	for i, n := range stored {
+		if n.Status != want[i].status.String() || n.Version != want[i].version {
+			t.Fatalf("通知 %d 状态 %s 版本 %d, 应该是 %s 和 %d", n.ID, n.Status, n.Version, want[i].status, want[i].version)
+		}
+	}
+	if stored[0].FailReason != domain.FailReasonSendTimeout.String() {
+		t.Fatalf("失败原因 %s", stored[0].FailReason)
+	}
+
+	// 已经标记过的通知不会再被返回，额度不会重复归还
+	timeout, err = d.MarkTimeoutSendingAsFailed(context.Background(), 10)
+	if err != nil || len(timeout) != 0 {
+		t.Fatalf("第二次标记了 %+v, err %v", timeout, err)
+	}
+}
+
+// 只有版本号匹配的 PENDING 通知可以取消，已经被调度器取走的通知保持不变
+func TestNotificationDAO_CancelPending(t *testing.T) {
+	testCases := []struct {
+		name    string
+		stored  Notification
+		wantErr error
+	}{
+		{name: "PENDING", stored: sqliteNotification(1, domain.SendStatusPending, 1)},
+		{name: "SENDING", stored: sqliteNotification(1, domain.SendStatusSending, 1), wantErr: domain.ErrNotificationVersionMismatch},
+		{name: "版本号不匹配", stored: sqliteNotification(1, domain.SendStatusPending, 2), wantErr: domain.ErrNotificationVersionMismatch},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newSQLiteDB(t)
+			if err := db.Create(&tc.stored).Error; err != nil {
+				t.Fatal(err)
+			}
+			d := NewNotificationDAO(db)
+			err := d.CancelPending(context.Background(), Notification{ID: 1, Version: 1, FailReason: "业务方取消"})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("返回 %v, 应该是 %v", err, tc.wantErr)
+			}
+			var stored Notification
+			if err := db.First(&stored, 1).Error; err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantErr != nil {
+				if stored.Status != tc.stored.Status || stored.Version != tc.stored.Version {
+					t.Fatalf("取消失败的通知被改成了 %s 版本 %d", stored.Status, stored.Version)
+				}
+				return
+			}
+			if stored.Status != domain.SendStatusCanceled.String() || stored.Version != 2 || stored.FailReason != "业务方取消" {
+				t.Fatalf("状态 %s 版本 %d 原因 %s", stored.Status, stored.Version, stored.FailReason)
+			}
+		})
+	}
+}
+
+func TestNotificationDAO_GetByKeyNotFound(t *testing.T) {
+	d := NewNotificationDAO(newSQLiteDB(t))
+	if _, err := d.GetByKey(context.Background(), 1, "missing"); !errors.Is(err, domain.ErrNotificationNotFound) {
+		t.Fatalf("不存在的通知返回 %v", err)
+	}
+}
+
+// 版本号过期的通知放进返回的冲突ID，数据库里保持原样，其他通知照常更新
+func TestNotificationDAO_BatchUpdateStatusSucceededOrFailedConflict(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := newSQLiteDB(t)
+	rows := []Notification{
+		sqliteNotification(1, domain.SendStatusSending, 2),
+		sqliteNotification(2, domain.SendStatusSending, 3),
+		sqliteNotification(3, domain.SendStatusSending, 2),
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		t.Fatal(err)
+	}
+	d := NewNotificationDAOWithChunk(db, clock.NewFake(now), 0, 0)
+
+	// 2 已经被其他实例更新过，调用方手里还是版本 2
+	conflicted, err := d.BatchUpdateStatusSucceededOrFailed(context.Background(),
+		[]Notification{{ID: 1, Version: 2}, {ID: 2, Version: 2}},
+		[]Notification{{ID: 3, Version: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicted) != 1 || conflicted[0] != 2 {
+		t.Fatalf("冲突ID %v, 应该是 [2]", conflicted)
+	}
+
+	var stored []Notification
+	if err := db.Order("id").Find(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored[1].Status != domain.SendStatusSending.String() || stored[1].Version != 3 || stored[1].Utime != rows[1].Utime {
+		t.Fatalf("冲突的通知被改成了 %s 版本 %d 更新时间 %d", stored[1].Status, stored[1].Version, stored[1].Utime)
+	}
+	if stored[0].Status != domain.SendStatusSucceeded.String() || stored[0].Version != 3 {
+		t.Fatalf("通知 1 状态 %s 版本 %d", stored[0].Status, stored[0].Version)
+	}
+	if stored[2].Status != domain.SendStatusFailed.String() || stored[2].Version != 3 {
+		t.Fatalf("通知 3 状态 %s 版本 %d", stored[2].Status, stored[2].Version)
+	}
+}
+
+// 到期的 PENDING 通知按ID升序分页，下一页从上一页最后一条之后开始，分区时只返回分区内的通知
+func TestNotificationDAO_FindReadyNotifications(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := newSQLiteDB(t)
+	// 低 16 位是机器ID，ID >> 16 是奇数的属于 2 个分区里的分区 1；最后两条的机器ID是 0，落在分区的边界上
+	var rows []Notification
+	var ready []uint64
+	for k := range uint64(8) {
+		machineID := uint64(7)
+		if k >= 6 {
+			machineID = 0
+		}
+		n := sqliteNotification(k<<16|machineID, domain.SendStatusPending, 1)
+		n.ScheduledSTime = now.Add(-time.Minute).UnixMilli()
+		n.ScheduledETime = now.Add(time.Hour).UnixMilli()
+		rows = append(rows, n)
+		ready = append(ready, n.ID)
+	}
+	notReady := []Notification{
+		sqliteNotification(100<<16, domain.SendStatusSending, 2),
+		sqliteNotification(101<<16, domain.SendStatusPending, 1),
+		sqliteNotification(102<<16, domain.SendStatusPending, 1),
+	}
+	notReady[0].ScheduledSTime, notReady[0].ScheduledETime = rows[0].ScheduledSTime, rows[0].ScheduledETime
+	// 还没有到计划发送时间
+	notReady[1].ScheduledSTime, notReady[1].ScheduledETime = now.Add(time.Minute).UnixMilli(), now.Add(time.Hour).UnixMilli()
+	// 计划发送时间已经结束
+	notReady[2].ScheduledSTime, notReady[2].ScheduledETime = now.Add(-time.Hour).UnixMilli(), now.Add(-time.Minute).UnixMilli()
+	if err := db.Create(append(rows, notReady...)).Error; err != nil {
+		t.Fatal(err)
+	}
+	d := NewNotificationDAOWithChunk(db, clock.NewFake(now), 0, 0)
+
+	testCases := []struct {
+		name      string
+		partition domain.Partition
+		want      []uint64
+	}{
+		{name: "不分区", partition: domain.AllPartitions(), want: ready},
+		{name: "分区 0", partition: domain.Partition{Slot: 0, Total: 2}, want: []uint64{ready[0], ready[2], ready[4], ready[6]}},
+		{name: "分区 1", partition: domain.Partition{Slot: 1, Total: 2}, want: []uint64{ready[1], ready[3], ready[5], ready[7]}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			const limit = 2
+			var got []uint64
+			var afterID uint64
+			for page := 0; ; page++ {
+				if page > len(rows) {
+					t.Fatalf("翻页没有结束, 已经查到 %v", got)
+				}
+				res, err := d.FindReadyNotifications(context.Background(), tc.partition, afterID, limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(res) > limit {
+					t.Fatalf("一页返回了 %d 条", len(res))
+				}
+				for _, n := range res {
+					if n.ID <= afterID {
+						t.Fatalf("第 %d 页返回了 %d, 不大于上一页最后一条 %d", page+1, n.ID, afterID)
+					}
+					got = append(got, n.ID)
+					afterID = n.ID
+				}
+				if len(res) < limit {
+					break
+				}
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("查到 %v, 应该是 %v", got, tc.want)
+			}
+		})
+	}
+}
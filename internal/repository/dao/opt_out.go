@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OptOut 接收者退订表，同一个业务方、渠道的同一个接收者只有一行，biz_id 为 0 对所有业务方生效
+type OptOut struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID         int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_opt_outs_channel_receiver,priority:3;comment:'业务配表ID，0 对所有业务方生效'"`
+	Channel       string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:idx_opt_outs_channel_receiver,priority:1;comment:'发送渠道'"`
+	ReceiverIndex string `gorm:"type:CHAR(64);NOT NULL;uniqueIndex:idx_opt_outs_channel_receiver,priority:2;comment:'接收者盲索引'"`
+	Receiver      string `gorm:"type:TEXT;NOT NULL;comment:'接收者，和通知的接收者一样加密存储'"`
+	Source        string `gorm:"type:VARCHAR(32);NOT NULL;comment:'退订来源'"`
+	Ctime         int64
+	Utime         int64
+}
+
+// TableName 重命名表
+func (OptOut) TableName() string {
+	return "receiver_opt_outs"
+}
+
+// OptOutDAO 接收者退订
+type OptOutDAO interface {
+	// Create 已经退订过时什么都不做
+	Create(ctx context.Context, optOut OptOut) error
+	// FindByReceivers 渠道里退订了业务方或者所有业务方的接收者
+	FindByReceivers(ctx context.Context, bizID int64, channel string, receiverIndexes []string) ([]OptOut, error)
+}
+
+var _ OptOutDAO = (*optOutDAO)(nil)
+
+type optOutDAO struct {
+	db *gorm.DB
+}
+
+func NewOptOutDAO(db *gorm.DB) OptOutDAO {
+	return &optOutDAO{db: db}
+}
+
+func (d *optOutDAO) Create(ctx context.Context, optOut OptOut) error {
+	now := time.Now().UnixMilli()
+	optOut.Ctime, optOut.Utime = now, now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&optOut).Error
+}
+
+func (d *optOutDAO) FindByReceivers(ctx context.Context, bizID int64, channel string, receiverIndexes []string) ([]OptOut, error) {
+	var optOuts []OptOut
+	if len(receiverIndexes) == 0 {
+		return optOuts, nil
+	}
+	err := d.db.WithContext(ctx).
+		Where("channel = ? AND receiver_index IN ? AND biz_id IN ?", channel, receiverIndexes, []int64{bizID, 0}).
+		Find(&optOuts).Error
+	return optOuts, err
+}
@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PacingCampaign 限速发送的活动进度表
+type PacingCampaign struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	BizID     int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_pacing_campaigns_campaign,priority:1;comment:'业务配表ID'"`
+	Campaign  string `gorm:"type:VARCHAR(64);NOT NULL;uniqueIndex:idx_pacing_campaigns_campaign,priority:2;comment:'活动名称'"`
+	PerMinute int32  `gorm:"NOT NULL;comment:'每分钟最多发送的数量'"`
+	NextSlot  int64  `gorm:"NOT NULL;comment:'下一条通知的发送时间'"`
+	// SentMinute、SentCount 调度器当前这一分钟已经放行的数量
+	SentMinute int64 `gorm:"NOT NULL;DEFAULT:0;comment:'调度器放行数量所在的分钟'"`
+	SentCount  int32 `gorm:"NOT NULL;DEFAULT:0;comment:'这一分钟已经放行的数量'"`
+	SentTotal  int64 `gorm:"NOT NULL;DEFAULT:0;comment:'一共放行的数量'"`
+	Ctime      int64
+	Utime      int64
+}
+
+// TableName 重命名表
+func (PacingCampaign) TableName() string {
+	return "pacing_campaigns"
+}
+
+// PacingDAO 限速发送的活动进度，同一个活动的操作通过行锁串行
+type PacingDAO interface {
+	// Reserve 为活动的 n 条通知按 interval 依次分配发送时间，返回第一条的发送时间，不早于 now
+	// 活动不存在时创建，perMinute 覆盖原来的速度
+	Reserve(ctx context.Context, bizID int64, campaign string, perMinute int32, now int64, n int) (int64, error)
+	// Admit 调度器在 now 所在的这一分钟放行活动的 want 条通知，返回实际放行的数量和活动的速度
+	// 活动不存在时全部放行，速度返回 0
+	Admit(ctx context.Context, bizID int64, campaign string, now int64, want int) (int, int32, error)
+}
+
+var _ PacingDAO = (*pacingDAO)(nil)
+
+type pacingDAO struct {
+	db *gorm.DB
+}
+
+func NewPacingDAO(db *gorm.DB) PacingDAO {
+	return &pacingDAO{db: db}
+}
+
+func (d *pacingDAO) Reserve(ctx context.Context, bizID int64, campaign string, perMinute int32, now int64, n int) (int64, error) {
+	var first int64
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := PacingCampaign{
+			BizID:     bizID,
+			Campaign:  campaign,
+			PerMinute: perMinute,
+			NextSlot:  now,
+			Ctime:     now,
+			Utime:     now,
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+			return err
+		}
+		var c PacingCampaign
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("biz_id = ? AND campaign = ?", bizID, campaign).
+			First(&c).Error
+		if err != nil {
+			return err
+		}
+		first = max(c.NextSlot, now)
+		interval := domain.Pacing{PerMinute: perMinute}.Interval().Milliseconds()
+		return tx.Model(&PacingCampaign{}).Where("id = ?", c.ID).
+			Updates(map[string]any{
+				"per_minute": perMinute,
+				"next_slot":  first + int64(n)*interval,
+				"utime":      now,
+			}).Error
+	})
+	return first, err
+}
+
+func (d *pacingDAO) Admit(ctx context.Context, bizID int64, campaign string, now int64, want int) (int, int32, error) {
+	var (
+		granted   int
+		perMinute int32
+	)
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var c PacingCampaign
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("biz_id = ? AND campaign = ?", bizID, campaign).
+			First(&c).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			granted = want
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		perMinute = c.PerMinute
+		minute := now - now%time.Minute.Milliseconds()
+		if c.SentMinute != minute {
+			c.SentMinute, c.SentCount = minute, 0
+		}
+		granted = max(0, min(want, int(c.PerMinute-c.SentCount)))
+		if granted == 0 {
+			return nil
+		}
+		return tx.Model(&PacingCampaign{}).Where("id = ?", c.ID).
+			Updates(map[string]any{
+				"sent_minute": c.SentMinute,
+				"sent_count":  c.SentCount + int32(granted),
+				"sent_total":  gorm.Expr("sent_total + ?", granted),
+				"utime":       now,
+			}).Error
+	})
+	return granted, perMinute, err
+}
@@ -5,16 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
-	"time"
 )
 
 type Quota struct {
 	ID uint64 `gorm:"primaryKey;comment:'雪花算法ID'"`
 	// 构成一个唯一索引
 	BizID   int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:biz_id_channel,priority:1;comment:'业务配表ID，业务方可能有多个业务每个业务配置不同'"`
-	Channel string `gorm:"type:ENUM('SMS','EMAIL','IN_APP');NOT NULL;uniqueIndex:biz_id_channel,priority:2;comment:'发送渠道'"`
+	Channel string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:biz_id_channel,priority:2;check:chk_quota_channel,channel IN ('SMS','EMAIL','IN_APP');comment:'发送渠道'"`
 	// 每个月的 quota
 	// 如果你要分开控制不同渠道的 Quota，那么就加一个 Channel 列
 	// 确保不同 Channel 使用不同的 Quota 来规避更新的锁竞争（CAS 等）
@@ -30,23 +30,32 @@ type Quota struct {
 type QuotaDAO interface {
 	CreateOrUpdate(ctx context.Context, quota ...Quota) error
 	Find(ctx context.Context, bizID int64, channel string) (Quota, error)
+	// List 按ID分页查询所有额度配置
+	List(ctx context.Context, afterID uint64, limit int) ([]Quota, error)
+	// Decr 直接在数据库里扣减额度，不够扣减时返回 domain.ErrNoQuota，只在 Redis 不可用时使用
+	Decr(ctx context.Context, bizID int64, channel string, n int32) error
+	// Incr 归还 Decr 扣减的额度
+	Incr(ctx context.Context, bizID int64, channel string, n int32) error
 }
 
 type quotaDAO struct {
-	db *gorm.DB
+	db    *gorm.DB
+	clock clock.Clock
 }
 
-func NewQuotaDAO(db *gorm.DB) QuotaDAO {
-	return &quotaDAO{db: db}
+func NewQuotaDAO(db *gorm.DB, clk clock.Clock) QuotaDAO {
+	return &quotaDAO{db: db, clock: clk}
 }
 
 func (d *quotaDAO) CreateOrUpdate(ctx context.Context, quota ...Quota) error {
-	now := time.Now().UnixMilli()
+	now := d.clock.Now().UnixMilli()
 	for i := range quota {
 		quota[i].Ctime = now
 		quota[i].Utime = now
 	}
+	// Postgres 的 ON CONFLICT 必须指定冲突列，MySQL 会忽略 Columns
 	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "biz_id"}, {Name: "channel"}},
 		DoUpdates: clause.AssignmentColumns([]string{"quota", "utime"}),
 	}).Create(&quota).Error
 }
@@ -59,3 +68,34 @@ func (d *quotaDAO) Find(ctx context.Context, bizID int64, channel string) (Quota
 	}
 	return q, err
 }
+
+func (d *quotaDAO) Decr(ctx context.Context, bizID int64, channel string, n int32) error {
+	res := d.db.WithContext(ctx).Model(&Quota{}).
+		Where("biz_id = ? AND channel = ? AND quota >= ?", bizID, channel, n).
+		Updates(map[string]any{
+			"quota": gorm.Expr("quota - ?", n),
+			"utime": d.clock.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: 业务方 %d 渠道 %s", domain.ErrNoQuota, bizID, channel)
+	}
+	return nil
+}
+
+func (d *quotaDAO) Incr(ctx context.Context, bizID int64, channel string, n int32) error {
+	return d.db.WithContext(ctx).Model(&Quota{}).
+		Where("biz_id = ? AND channel = ?", bizID, channel).
+		Updates(map[string]any{
+			"quota": gorm.Expr("quota + ?", n),
+			"utime": d.clock.Now().UnixMilli(),
+		}).Error
+}
+
+func (d *quotaDAO) List(ctx context.Context, afterID uint64, limit int) ([]Quota, error) {
+	var res []Quota
+	err := d.db.WithContext(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&res).Error
+	return res, err
+}
@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+)
+
+// 额度的 ctime、utime 来自注入的时钟，和扣减额度的 Redis 缓存使用同一条时间线
+func TestQuotaDAO_UsesInjectedClock(t *testing.T) {
+	now := time.Date(2025, 1, 31, 23, 59, 0, 0, time.UTC)
+	rec := &recorder{}
+	d := NewQuotaDAO(newRecordingDB(t, rec), clock.NewFake(now))
+	if err := d.CreateOrUpdate(context.Background(), Quota{BizID: 7, Channel: "SMS", Quota: 100}); err != nil {
+		t.Fatalf("调用失败: %v", err)
+	}
+	checked := 0
+	for _, s := range rec.statements() {
+		for _, v := range timestampArgs(s) {
+			checked++
+			if v != now.UnixMilli() {
+				t.Errorf("时间戳 %d 不是注入的时间 %d, SQL: %s", v, now.UnixMilli(), s.query)
+			}
+		}
+	}
+	if checked != 2 {
+		t.Fatalf("写入了 %d 个时间戳, 应该是 ctime 和 utime 两个, SQL: %v", checked, rec.statements())
+	}
+}
@@ -0,0 +1,136 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRead 站内信已读回执表，同一个接收者对同一条通知只有一行
+type NotificationRead struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	NotificationID uint64 `gorm:"NOT NULL;uniqueIndex:idx_reads_notification_receiver,priority:1;comment:'通知ID'"`
+	ReceiverIndex  string `gorm:"type:CHAR(64);NOT NULL;uniqueIndex:idx_reads_notification_receiver,priority:2;comment:'接收者盲索引'"`
+	BizID          int64  `gorm:"type:BIGINT;NOT NULL;index:idx_reads_biz_id_template_id,priority:1;comment:'业务配表ID'"`
+	Key            string `gorm:"type:VARCHAR(256);NOT NULL;comment:'业务内唯一标识'"`
+	TemplateID     int64  `gorm:"type:BIGINT;NOT NULL;index:idx_reads_biz_id_template_id,priority:2;comment:'模板ID'"`
+	Receiver       string `gorm:"type:TEXT;NOT NULL;comment:'接收者，和通知的接收者一样加密存储，推送已读事件时使用'"`
+	ReadTime       int64  `gorm:"NOT NULL;comment:'第一次阅读的时间'"`
+	CallbackStatus string `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'NONE';index:idx_reads_callback,priority:1;comment:'已读事件推送状态'"`
+	RetryCount     int32  `gorm:"type:INT;NOT NULL;DEFAULT:0;comment:'推送重试次数'"`
+	NextRetryTime  int64  `gorm:"NOT NULL;DEFAULT:0;index:idx_reads_callback,priority:2;comment:'下一次推送时间'"`
+	Ctime          int64
+	Utime          int64
+}
+
+// TableName 重命名表
+func (NotificationRead) TableName() string {
+	return "notification_reads"
+}
+
+// ReadStats 已读统计
+type ReadStats struct {
+	Receivers int64
+	Reads     int64
+}
+
+// ReadReceiptDAO 站内信已读回执
+type ReadReceiptDAO interface {
+	// IsReceiver 接收者是不是通知的接收者
+	IsReceiver(ctx context.Context, notificationID uint64, receiverIndex string) (bool, error)
+	// Create 记录已读，已经读过时返回第一次的记录，created 为 false
+	Create(ctx context.Context, read NotificationRead) (res NotificationRead, created bool, err error)
+	// NotificationStats 一条通知的已读统计
+	NotificationStats(ctx context.Context, notificationID uint64) (ReadStats, error)
+	// TemplateStats 模板在 [start, end) 内创建并发送成功的站内信的已读统计
+	TemplateStats(ctx context.Context, bizID, templateID, start, end int64) (ReadStats, error)
+	// FindPendingCallbacks 到了推送时间还没有推送成功的已读回执
+	FindPendingCallbacks(ctx context.Context, now int64, limit int) ([]NotificationRead, error)
+	UpdateCallback(ctx context.Context, read NotificationRead) error
+}
+
+var _ ReadReceiptDAO = (*readReceiptDAO)(nil)
+
+type readReceiptDAO struct {
+	db *gorm.DB
+}
+
+func NewReadReceiptDAO(db *gorm.DB) ReadReceiptDAO {
+	return &readReceiptDAO{db: db}
+}
+
+func (d *readReceiptDAO) IsReceiver(ctx context.Context, notificationID uint64, receiverIndex string) (bool, error) {
+	var cnt int64
+	err := d.db.WithContext(ctx).Model(&NotificationReceiver{}).
+		Where("notification_id = ? AND receiver_index = ?", notificationID, receiverIndex).
+		Count(&cnt).Error
+	return cnt > 0, err
+}
+
+func (d *readReceiptDAO) Create(ctx context.Context, read NotificationRead) (NotificationRead, bool, error) {
+	now := time.Now().UnixMilli()
+	read.Ctime, read.Utime = now, now
+	result := d.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&read)
+	if result.Error != nil {
+		return NotificationRead{}, false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return read, true, nil
+	}
+	var existing NotificationRead
+	err := d.db.WithContext(ctx).
+		Where("notification_id = ? AND receiver_index = ?", read.NotificationID, read.ReceiverIndex).
+		First(&existing).Error
+	return existing, false, err
+}
+
+func (d *readReceiptDAO) NotificationStats(ctx context.Context, notificationID uint64) (ReadStats, error) {
+	var stats ReadStats
+	db := d.db.WithContext(ctx)
+	err := db.Model(&NotificationReceiver{}).Where("notification_id = ?", notificationID).Count(&stats.Receivers).Error
+	if err != nil {
+		return ReadStats{}, err
+	}
+	err = db.Model(&NotificationRead{}).Where("notification_id = ?", notificationID).Count(&stats.Reads).Error
+	return stats, err
+}
+
+func (d *readReceiptDAO) TemplateStats(ctx context.Context, bizID, templateID, start, end int64) (ReadStats, error) {
+	var stats ReadStats
+	// 分子分母都只统计发送成功的站内信，已读率不会因为失败的通知被拉低
+	inScope := func(table string) *gorm.DB {
+		return d.db.WithContext(ctx).Table(table+" AS t").
+			Joins("JOIN notifications n ON n.id = t.notification_id").
+			Where("n.biz_id = ? AND n.template_id = ? AND n.channel = ? AND n.status = ? AND n.ctime >= ? AND n.ctime < ?",
+				bizID, templateID, domain.ChannelInApp.String(), domain.SendStatusSucceeded.String(), start, end)
+	}
+	if err := inScope(NotificationReceiver{}.TableName()).Count(&stats.Receivers).Error; err != nil {
+		return ReadStats{}, err
+	}
+	err := inScope(NotificationRead{}.TableName()).Count(&stats.Reads).Error
+	return stats, err
+}
+
+func (d *readReceiptDAO) FindPendingCallbacks(ctx context.Context, now int64, limit int) ([]NotificationRead, error) {
+	var reads []NotificationRead
+	err := d.db.WithContext(ctx).
+		Where("callback_status = ? AND next_retry_time <= ?", domain.ReadCallbackStatusPending.String(), now).
+		Order("next_retry_time").
+		Limit(limit).
+		Find(&reads).Error
+	return reads, err
+}
+
+func (d *readReceiptDAO) UpdateCallback(ctx context.Context, read NotificationRead) error {
+	return d.db.WithContext(ctx).Model(&NotificationRead{}).
+		Where("id = ?", read.ID).
+		Updates(map[string]any{
+			"callback_status": read.CallbackStatus,
+			"retry_count":     read.RetryCount,
+			"next_retry_time": read.NextRetryTime,
+			"utime":           time.Now().UnixMilli(),
+		}).Error
+}
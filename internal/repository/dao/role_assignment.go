@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RoleAssignment 角色分配表，一个调用方在一个业务方下只有一个角色，平台管理员的 biz_id 为 0
+type RoleAssignment struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement;comment:'角色分配ID'"`
+	Principal string `gorm:"type:VARCHAR(128);NOT NULL;uniqueIndex:idx_principal_biz_id,priority:1;comment:'调用方唯一标识'"`
+	BizID     int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_principal_biz_id,priority:2;index:idx_role_assignments_biz_id;comment:'业务配表ID，平台管理员为0'"`
+	Role      string `gorm:"type:VARCHAR(32);NOT NULL;check:chk_role_assignments_role,role IN ('PLATFORM_ADMIN','BIZ_ADMIN','READ_ONLY');comment:'角色'"`
+	Operator  string `gorm:"type:VARCHAR(128);NOT NULL;DEFAULT:'';comment:'分配人'"`
+	Ctime     int64
+	Utime     int64
+}
+
+// TableName 重命名表
+func (RoleAssignment) TableName() string {
+	return "role_assignments"
+}
+
+type RoleAssignmentDAO interface {
+	// Upsert 分配角色，已经有角色时覆盖
+	Upsert(ctx context.Context, assignment RoleAssignment) error
+	// Delete 撤销角色，返回是否真的删除了记录
+	Delete(ctx context.Context, principal string, bizID int64) (bool, error)
+	Get(ctx context.Context, principal string, bizID int64) (RoleAssignment, error)
+	// FindByPrincipal 调用方在所有业务方下的角色
+	FindByPrincipal(ctx context.Context, principal string) ([]RoleAssignment, error)
+	ListByBizID(ctx context.Context, bizID int64) ([]RoleAssignment, error)
+}
+
+type roleAssignmentDAO struct {
+	db *gorm.DB
+}
+
+func NewRoleAssignmentDAO(db *gorm.DB) RoleAssignmentDAO {
+	return &roleAssignmentDAO{db: db}
+}
+
+func (d *roleAssignmentDAO) Upsert(ctx context.Context, assignment RoleAssignment) error {
+	now := time.Now().UnixMilli()
+	assignment.Ctime, assignment.Utime = now, now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "principal"}, {Name: "biz_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "operator", "utime"}),
+	}).Create(&assignment).Error
+}
+
+func (d *roleAssignmentDAO) Delete(ctx context.Context, principal string, bizID int64) (bool, error) {
+	result := d.db.WithContext(ctx).
+		Where("principal = ? AND biz_id = ?", principal, bizID).
+		Delete(&RoleAssignment{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (d *roleAssignmentDAO) Get(ctx context.Context, principal string, bizID int64) (RoleAssignment, error) {
+	var assignment RoleAssignment
+	err := d.db.WithContext(ctx).
+		Where("principal = ? AND biz_id = ?", principal, bizID).
+		First(&assignment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return RoleAssignment{}, fmt.Errorf("%w: principal=%s biz_id=%d", domain.ErrRoleAssignmentNotFound, principal, bizID)
+	}
+	return assignment, err
+}
+
+func (d *roleAssignmentDAO) FindByPrincipal(ctx context.Context, principal string) ([]RoleAssignment, error) {
+	var assignments []RoleAssignment
+	err := d.db.WithContext(ctx).Where("principal = ?", principal).Find(&assignments).Error
+	return assignments, err
+}
+
+func (d *roleAssignmentDAO) ListByBizID(ctx context.Context, bizID int64) ([]RoleAssignment, error) {
+	var assignments []RoleAssignment
+	err := d.db.WithContext(ctx).Where("biz_id = ?", bizID).Order("id").Find(&assignments).Error
+	return assignments, err
+}
@@ -0,0 +1,150 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+)
+
+// SendAttempt 通知发送尝试表，调用供应商之前落库，用于防止超时重试导致重复发送
+type SendAttempt struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement;comment:'发送尝试ID'"`
+	NotificationID uint64 `gorm:"column:notification_id;NOT NULL;index:idx_attempts_notification_id;comment:'通知ID'"`
+	Attempt        int    `gorm:"type:INT;NOT NULL;comment:'第几次尝试'"`
+	IdempotencyKey string `gorm:"type:VARCHAR(64);NOT NULL;uniqueIndex:idx_idempotency_key;comment:'幂等键，通知ID+尝试次数'"`
+	Status         string `gorm:"type:VARCHAR(16);NOT NULL;check:chk_notification_attempts_status,status IN ('DISPATCHING','DISPATCHED','FAILED');comment:'发送状态'"`
+	MessageID      string `gorm:"type:VARCHAR(128);NOT NULL;DEFAULT:'';index:idx_attempts_message_id;comment:'供应商返回的消息ID'"`
+	Provider       string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';index:idx_attempts_provider_ctime,priority:1;comment:'供应商名称，用于统计成功率'"`
+	RequestDigest  string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'发送内容摘要，SHA-256'"`
+	LatencyMs      int64  `gorm:"NOT NULL;DEFAULT:0;comment:'调用供应商耗时（毫秒）'"`
+	ResponseCode   string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'供应商返回的状态码'"`
+	Error          string `gorm:"type:VARCHAR(512);NOT NULL;DEFAULT:'';comment:'调用失败的原因'"`
+	Ctime          int64  `gorm:"index:idx_attempts_ctime;index:idx_attempts_provider_ctime,priority:2"`
+	Utime          int64
+}
+
+// SendAttemptResult 调用供应商的结果
+type SendAttemptResult struct {
+	MessageID    string
+	ResponseCode string
+	LatencyMs    int64
+	Error        string
+}
+
+// maxAttemptErrorLength error 列的长度
+const maxAttemptErrorLength = 512
+
+// TableName 重命名表
+func (SendAttempt) TableName() string {
+	return "notification_attempts"
+}
+
+type SendAttemptDAO interface {
+	// Create 创建发送尝试，幂等键冲突时返回 domain.ErrSendAttemptDuplicate
+	Create(ctx context.Context, attempt SendAttempt) (SendAttempt, error)
+	GetByIdempotencyKey(ctx context.Context, key string) (SendAttempt, error)
+	// CASStatus 状态为 from 时才更新为 to，同时记录调用结果，result 里为空的字段不更新
+	CASStatus(ctx context.Context, id int64, from, to string, result SendAttemptResult) error
+	// List 按条件查询发送尝试，按ID倒序
+	List(ctx context.Context, filter domain.SendAttemptFilter) ([]SendAttempt, error)
+}
+
+type sendAttemptDAO struct {
+	db *gorm.DB
+}
+
+func NewSendAttemptDAO(db *gorm.DB) SendAttemptDAO {
+	return &sendAttemptDAO{db: db}
+}
+
+func (d *sendAttemptDAO) Create(ctx context.Context, attempt SendAttempt) (SendAttempt, error) {
+	now := time.Now().UnixMilli()
+	attempt.Ctime, attempt.Utime = now, now
+	err := d.db.WithContext(ctx).Create(&attempt).Error
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return SendAttempt{}, fmt.Errorf("%w: %s", domain.ErrSendAttemptDuplicate, attempt.IdempotencyKey)
+		}
+		return SendAttempt{}, err
+	}
+	return attempt, nil
+}
+
+func (d *sendAttemptDAO) GetByIdempotencyKey(ctx context.Context, key string) (SendAttempt, error) {
+	var attempt SendAttempt
+	err := d.db.WithContext(ctx).Where("idempotency_key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return SendAttempt{}, fmt.Errorf("%w: %s", domain.ErrSendAttemptNotFound, key)
+	}
+	return attempt, err
+}
+
+func (d *sendAttemptDAO) CASStatus(ctx context.Context, id int64, from, to string, result SendAttemptResult) error {
+	updates := map[string]any{
+		"status": to,
+		"utime":  time.Now().UnixMilli(),
+	}
+	if result.MessageID != "" {
+		updates["message_id"] = result.MessageID
+	}
+	if result.ResponseCode != "" {
+		updates["response_code"] = result.ResponseCode
+	}
+	if result.LatencyMs > 0 {
+		updates["latency_ms"] = result.LatencyMs
+	}
+	if result.Error != "" {
+		updates["error"] = truncateRunes(result.Error, maxAttemptErrorLength)
+	}
+	res := d.db.WithContext(ctx).Model(&SendAttempt{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected < 1 {
+		return fmt.Errorf("并发竞争失败 %w, id %d", domain.ErrSendAttemptStatusChanged, id)
+	}
+	return nil
+}
+
+func (d *sendAttemptDAO) List(ctx context.Context, filter domain.SendAttemptFilter) ([]SendAttempt, error) {
+	var attempts []SendAttempt
+	query := d.db.WithContext(ctx).Model(&SendAttempt{})
+	if filter.NotificationID > 0 {
+		query = query.Where("notification_id = ?", filter.NotificationID)
+	}
+	if filter.Provider != "" {
+		query = query.Where("provider = ?", filter.Provider)
+	}
+	if filter.MessageID != "" {
+		query = query.Where("message_id = ?", filter.MessageID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status.String())
+	}
+	if filter.StartTime > 0 {
+		query = query.Where("ctime >= ?", filter.StartTime)
+	}
+	if filter.EndTime > 0 {
+		query = query.Where("ctime < ?", filter.EndTime)
+	}
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&attempts).Error
+	return attempts, err
+}
+
+// truncateRunes 按字符截断，VARCHAR 的长度是字符数
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
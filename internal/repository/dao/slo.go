@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+)
+
+// BizSLOCount 一个业务方的发送结果数量
+type BizSLOCount struct {
+	BizID int64
+	Good  int64
+	Total int64
+}
+
+// ProviderSLOCount 一个供应商的发送尝试结果数量
+type ProviderSLOCount struct {
+	Provider string
+	Good     int64
+	Total    int64
+}
+
+// SLODAO 服务等级目标的事件数量，发送和供应商读取小时统计表，回调读取回调记录表
+type SLODAO interface {
+	// SendCounts stat_hour >= start 的生产环境通知按业务方统计，成功是好事件，成功和失败是总事件
+	SendCounts(ctx context.Context, start int64) ([]BizSLOCount, error)
+	// ProviderCounts stat_hour >= start 的发送尝试按供应商统计，受理是好事件，受理和失败是总事件
+	ProviderCounts(ctx context.Context, start int64) ([]ProviderSLOCount, error)
+	// CallbackCounts utime >= start 结束回调的记录，回调成功是好事件
+	CallbackCounts(ctx context.Context, start int64) (good, total int64, err error)
+}
+
+var _ SLODAO = (*sloDAO)(nil)
+
+type sloDAO struct {
+	db *gorm.DB
+}
+
+func NewSLODAO(db *gorm.DB) SLODAO {
+	return &sloDAO{db: db}
+}
+
+func (d *sloDAO) SendCounts(ctx context.Context, start int64) ([]BizSLOCount, error) {
+	var res []BizSLOCount
+	err := d.db.WithContext(ctx).Model(&NotificationHourlyStat{}).
+		Select("biz_id, SUM(CASE WHEN status = ? THEN cnt ELSE 0 END) AS good, SUM(cnt) AS total",
+			domain.SendStatusSucceeded.String()).
+		Where("stat_hour >= ? AND environment = ? AND status IN ?", start, domain.EnvironmentProduction.String(),
+			[]string{domain.SendStatusSucceeded.String(), domain.SendStatusFailed.String()}).
+		Group("biz_id").
+		Scan(&res).Error
+	return res, err
+}
+
+func (d *sloDAO) ProviderCounts(ctx context.Context, start int64) ([]ProviderSLOCount, error) {
+	var res []ProviderSLOCount
+	err := d.db.WithContext(ctx).Model(&ProviderHourlyStat{}).
+		Select("provider, SUM(dispatched) AS good, SUM(dispatched + failed) AS total").
+		Where("stat_hour >= ?", start).
+		Group("provider").
+		Scan(&res).Error
+	return res, err
+}
+
+func (d *sloDAO) CallbackCounts(ctx context.Context, start int64) (good, total int64, err error) {
+	var res struct {
+		Good  int64
+		Total int64
+	}
+	// 走 idx_callback_logs_status_utime 索引，没有记录时 SUM 是 NULL
+	err = d.db.WithContext(ctx).Model(&CallbackLog{}).
+		Select("COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0) AS good, COUNT(*) AS total",
+			domain.CallbackLogStatusSuccess.String()).
+		Where("status IN ? AND utime >= ?",
+			[]string{
+				domain.CallbackLogStatusSuccess.String(),
+				domain.CallbackLogStatusFailed.String(),
+				domain.CallbackLogStatusDeadLetter.String(),
+			}, start).
+		Scan(&res).Error
+	return res.Good, res.Total, err
+}
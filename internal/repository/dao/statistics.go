@@ -0,0 +1,142 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+)
+
+// rollupBatchSize 写统计表时每批插入的行数
+const rollupBatchSize = 500
+
+// NotificationHourlyStat 通知小时统计表，由统计任务根据通知表重新计算
+type NotificationHourlyStat struct {
+	ID          int64  `gorm:"primaryKey;autoIncrement;comment:'统计ID'"`
+	BizID       int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_notification_hourly_stats,priority:1;comment:'业务配表ID'"`
+	StatHour    int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_notification_hourly_stats,priority:2;index:idx_notification_hourly_stats_stat_hour;comment:'小时开始的毫秒时间戳'"`
+	Channel     string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:idx_notification_hourly_stats,priority:3;comment:'发送渠道'"`
+	Status      string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:idx_notification_hourly_stats,priority:4;comment:'发送状态'"`
+	TemplateID  int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_notification_hourly_stats,priority:5;comment:'模板ID'"`
+	Environment string `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'PRODUCTION';uniqueIndex:idx_notification_hourly_stats,priority:6;comment:'环境'"`
+	Cnt         int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'通知数量'"`
+	Ctime       int64
+	Utime       int64
+}
+
+// TableName 重命名表
+func (NotificationHourlyStat) TableName() string {
+	return "notification_hourly_stats"
+}
+
+// ProviderHourlyStat 供应商小时统计表，由统计任务根据发送尝试表重新计算
+type ProviderHourlyStat struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement;comment:'统计ID'"`
+	BizID      int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_provider_hourly_stats,priority:1;comment:'业务配表ID'"`
+	StatHour   int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_provider_hourly_stats,priority:2;index:idx_provider_hourly_stats_stat_hour;comment:'小时开始的毫秒时间戳'"`
+	Channel    string `gorm:"type:VARCHAR(16);NOT NULL;uniqueIndex:idx_provider_hourly_stats,priority:3;comment:'发送渠道'"`
+	Provider   string `gorm:"type:VARCHAR(64);NOT NULL;uniqueIndex:idx_provider_hourly_stats,priority:4;comment:'供应商名称'"`
+	Dispatched int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'供应商受理的发送尝试数量'"`
+	Failed     int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'供应商明确失败的发送尝试数量'"`
+	Ctime      int64
+	Utime      int64
+}
+
+// TableName 重命名表
+func (ProviderHourlyStat) TableName() string {
+	return "provider_hourly_stats"
+}
+
+// StatisticsDAO 发送统计，查询只读统计表，不查询通知表
+type StatisticsDAO interface {
+	// RollupHour 重新统计 [hour, hour+1小时) 内创建的通知和发送尝试，覆盖这个小时已有的统计
+	RollupHour(ctx context.Context, hour int64) error
+	// FindSendStats 查询 [start, end) 内某个环境的通知小时统计，channel 为空不过滤
+	FindSendStats(ctx context.Context, bizID, start, end int64, channel, environment string) ([]NotificationHourlyStat, error)
+	// FindProviderStats 查询 [start, end) 内的供应商小时统计，只统计生产环境
+	FindProviderStats(ctx context.Context, bizID, start, end int64) ([]ProviderHourlyStat, error)
+}
+
+var _ StatisticsDAO = (*statisticsDAO)(nil)
+
+type statisticsDAO struct {
+	db *gorm.DB
+}
+
+func NewStatisticsDAO(db *gorm.DB) StatisticsDAO {
+	return &statisticsDAO{db: db}
+}
+
+func (d *statisticsDAO) RollupHour(ctx context.Context, hour int64) error {
+	end := hour + time.Hour.Milliseconds()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sendStats []NotificationHourlyStat
+		err := tx.Model(&Notification{}).
+			Select("biz_id, channel, status, template_id, environment, COUNT(*) AS cnt").
+			Where("ctime >= ? AND ctime < ?", hour, end).
+			Group("biz_id, channel, status, template_id, environment").
+			Scan(&sendStats).Error
+		if err != nil {
+			return err
+		}
+		var providerStats []ProviderHourlyStat
+		err = tx.Table("notification_attempts AS a").
+			Joins("JOIN notifications AS n ON n.id = a.notification_id").
+			Select("n.biz_id, n.channel, a.provider, "+
+				"SUM(CASE WHEN a.status = ? THEN 1 ELSE 0 END) AS dispatched, "+
+				"SUM(CASE WHEN a.status = ? THEN 1 ELSE 0 END) AS failed",
+				domain.SendAttemptStatusDispatched.String(), domain.SendAttemptStatusFailed.String()).
+			// 沙箱通知都发给模拟供应商，不计入供应商成功率
+			Where("a.ctime >= ? AND a.ctime < ? AND a.provider <> '' AND n.environment = ?",
+				hour, end, domain.EnvironmentProduction.String()).
+			Group("n.biz_id, n.channel, a.provider").
+			Scan(&providerStats).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UnixMilli()
+		if err = tx.Where("stat_hour = ?", hour).Delete(&NotificationHourlyStat{}).Error; err != nil {
+			return err
+		}
+		if err = tx.Where("stat_hour = ?", hour).Delete(&ProviderHourlyStat{}).Error; err != nil {
+			return err
+		}
+		for i := range sendStats {
+			sendStats[i].StatHour, sendStats[i].Ctime, sendStats[i].Utime = hour, now, now
+		}
+		for i := range providerStats {
+			providerStats[i].StatHour, providerStats[i].Ctime, providerStats[i].Utime = hour, now, now
+		}
+		if len(sendStats) > 0 {
+			if err = tx.CreateInBatches(sendStats, rollupBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(providerStats) > 0 {
+			return tx.CreateInBatches(providerStats, rollupBatchSize).Error
+		}
+		return nil
+	})
+}
+
+func (d *statisticsDAO) FindSendStats(ctx context.Context, bizID, start, end int64, channel, environment string) ([]NotificationHourlyStat, error) {
+	query := d.db.WithContext(ctx).
+		Where("biz_id = ? AND stat_hour >= ? AND stat_hour < ? AND environment = ?", bizID, start, end, environment)
+	if channel != "" {
+		query = query.Where("channel = ?", channel)
+	}
+	var stats []NotificationHourlyStat
+	err := query.Order("stat_hour").Find(&stats).Error
+	return stats, err
+}
+
+func (d *statisticsDAO) FindProviderStats(ctx context.Context, bizID, start, end int64) ([]ProviderHourlyStat, error) {
+	var stats []ProviderHourlyStat
+	err := d.db.WithContext(ctx).
+		Where("biz_id = ? AND stat_hour >= ? AND stat_hour < ?", bizID, start, end).
+		Order("stat_hour").
+		Find(&stats).Error
+	return stats, err
+}
@@ -0,0 +1,256 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"gorm.io/gorm"
+)
+
+// ChannelTemplate 渠道模板表
+type ChannelTemplate struct {
+	ID              int64  `gorm:"primaryKey;autoIncrement;comment:'渠道模版ID'"`
+	OwnerID         int64  `gorm:"type:BIGINT;NOT NULL;index:idx_owner_id;comment:'业务方ID'"`
+	Name            string `gorm:"type:VARCHAR(128);NOT NULL;comment:'模板名称'"`
+	Description     string `gorm:"type:VARCHAR(512);NOT NULL;DEFAULT:'';comment:'模板描述'"`
+	Channel         string `gorm:"type:VARCHAR(16);NOT NULL;check:chk_channel_templates_channel,channel IN ('SMS','EMAIL','IN_APP');comment:'渠道'"`
+	ActiveVersionID int64  `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'当前生效的版本ID，0表示没有生效的版本'"`
+	Ctime           int64
+	Utime           int64
+}
+
+// ChannelTemplateVersion 渠道模板版本表
+type ChannelTemplateVersion struct {
+	ID                int64  `gorm:"primaryKey;autoIncrement;comment:'渠道模版版本ID'"`
+	ChannelTemplateID int64  `gorm:"type:BIGINT;NOT NULL;index:idx_channel_template_id;comment:'渠道模版ID'"`
+	Name              string `gorm:"type:VARCHAR(32);NOT NULL;comment:'版本名称'"`
+	Signature         string `gorm:"type:VARCHAR(64);NOT NULL;DEFAULT:'';comment:'签名'"`
+	Content           string `gorm:"type:TEXT;NOT NULL;comment:'模版内容'"`
+	Remark            string `gorm:"type:TEXT;NOT NULL;comment:'备注'"`
+	ParamSchema       string `gorm:"type:TEXT;NOT NULL;comment:'模板参数定义，JSON数组'"`
+	AuditStatus       string `gorm:"type:VARCHAR(16);NOT NULL;DEFAULT:'PENDING';comment:'内部审核状态'"`
+	RejectReason      string `gorm:"type:VARCHAR(512);NOT NULL;DEFAULT:'';comment:'审核不通过的原因'"`
+	Reviewer          string `gorm:"type:VARCHAR(128);NOT NULL;DEFAULT:'';comment:'内部审核人，调用方唯一标识'"`
+	ReviewTime        int64  `gorm:"NOT NULL;DEFAULT:0;comment:'内部审核时间，毫秒时间戳，0 表示还没有审核'"`
+	// ForkedFromVersionID 回滚时从哪个版本拷贝
+	ForkedFromVersionID int64 `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'回滚时从哪个版本拷贝，0 表示不是回滚创建的'"`
+	Ctime               int64
+	Utime               int64
+}
+
+// ChannelTemplateProvider 模板版本在供应商的审核记录表
+type ChannelTemplateProvider struct {
+	ID                 int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	TemplateID         int64  `gorm:"type:BIGINT;NOT NULL;comment:'渠道模版ID'"`
+	TemplateVersionID  int64  `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_template_providers_version_provider,priority:1;comment:'渠道模版版本ID'"`
+	ProviderName       string `gorm:"type:VARCHAR(64);NOT NULL;uniqueIndex:idx_template_providers_version_provider,priority:2;index:idx_template_providers_provider_template,priority:1;comment:'供应商名称'"`
+	ProviderTemplateID string `gorm:"type:VARCHAR(128);NOT NULL;DEFAULT:'';index:idx_template_providers_provider_template,priority:2;comment:'供应商返回的模板ID'"`
+	AuditStatus        string `gorm:"type:VARCHAR(16);NOT NULL;index:idx_template_providers_check,priority:1;comment:'供应商审核状态'"`
+	RejectReason       string `gorm:"type:VARCHAR(512);NOT NULL;DEFAULT:'';comment:'审核不通过的原因'"`
+	RetryCount         int32  `gorm:"type:INT;NOT NULL;DEFAULT:0;comment:'连续失败次数'"`
+	NextCheckTime      int64  `gorm:"NOT NULL;DEFAULT:0;index:idx_template_providers_check,priority:2;comment:'下一次提交或者查询审核结果的时间'"`
+	Ctime              int64
+	Utime              int64
+}
+
+// TableName 重命名表
+func (ChannelTemplateProvider) TableName() string {
+	return "channel_template_providers"
+}
+
+type ChannelTemplateDAO interface {
+	// GetTemplateByID 根据ID获取模板
+	GetTemplateByID(ctx context.Context, id int64) (ChannelTemplate, error)
+	// GetVersionsByTemplateIDs 获取模板的所有版本
+	GetVersionsByTemplateIDs(ctx context.Context, templateIDs []int64) ([]ChannelTemplateVersion, error)
+	// GetProvidersByVersionIDs 获取版本的供应商审核记录
+	GetProvidersByVersionIDs(ctx context.Context, versionIDs []int64) ([]ChannelTemplateProvider, error)
+	// ReviewVersion 内部审核，只能审核待审核的版本，同时创建供应商审核记录
+	ReviewVersion(ctx context.Context, version ChannelTemplateVersion, providers []ChannelTemplateProvider) error
+	// ForkVersion 创建拷贝的版本和供应商审核记录，并设置为生效版本
+	// 模板的生效版本已经不是 activeVersionID 时返回 domain.ErrForkVersionFailed
+	ForkVersion(ctx context.Context, version ChannelTemplateVersion, providers []ChannelTemplateProvider, activeVersionID int64) (ChannelTemplateVersion, error)
+	// FindProvidersToCheck 到了提交或者查询时间的供应商审核记录
+	FindProvidersToCheck(ctx context.Context, now int64, limit int) ([]ChannelTemplateProvider, error)
+	// UpdateProvider 更新供应商审核记录，只能更新还没有结果的记录
+	UpdateProvider(ctx context.Context, provider ChannelTemplateProvider) error
+	// GetProviderByProviderTemplateID 按供应商返回的模板ID查找审核记录
+	GetProviderByProviderTemplateID(ctx context.Context, providerName, providerTemplateID string) (ChannelTemplateProvider, error)
+	// CreateWithActiveVersion 创建模板和它生效的版本，业务方已经有同名模板时返回已有的模板，本地开发初始化数据时使用
+	CreateWithActiveVersion(ctx context.Context, template ChannelTemplate, version ChannelTemplateVersion) (ChannelTemplate, error)
+}
+
+type channelTemplateDAO struct {
+	db    *gorm.DB
+	clock clock.Clock
+}
+
+func NewChannelTemplateDAO(db *gorm.DB, clk clock.Clock) ChannelTemplateDAO {
+	return &channelTemplateDAO{db: db, clock: clk}
+}
+
+func (d *channelTemplateDAO) GetTemplateByID(ctx context.Context, id int64) (ChannelTemplate, error) {
+	var template ChannelTemplate
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ChannelTemplate{}, fmt.Errorf("%w: id=%d", domain.ErrTemplateNotFound, id)
+	}
+	return template, err
+}
+
+func (d *channelTemplateDAO) GetVersionsByTemplateIDs(ctx context.Context, templateIDs []int64) ([]ChannelTemplateVersion, error) {
+	if len(templateIDs) == 0 {
+		return []ChannelTemplateVersion{}, nil
+	}
+	var versions []ChannelTemplateVersion
+	err := d.db.WithContext(ctx).Where("channel_template_id IN ?", templateIDs).Find(&versions).Error
+	return versions, err
+}
+
+func (d *channelTemplateDAO) GetProvidersByVersionIDs(ctx context.Context, versionIDs []int64) ([]ChannelTemplateProvider, error) {
+	if len(versionIDs) == 0 {
+		return []ChannelTemplateProvider{}, nil
+	}
+	var providers []ChannelTemplateProvider
+	err := d.db.WithContext(ctx).Where("template_version_id IN ?", versionIDs).Order("id").Find(&providers).Error
+	return providers, err
+}
+
+func (d *channelTemplateDAO) ReviewVersion(ctx context.Context, version ChannelTemplateVersion, providers []ChannelTemplateProvider) error {
+	now := d.clock.Now().UnixMilli()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ChannelTemplateVersion{}).
+			Where("id = ? AND audit_status = ?", version.ID, domain.AuditStatusPending.String()).
+			Updates(map[string]any{
+				"audit_status":  version.AuditStatus,
+				"reject_reason": version.RejectReason,
+				"reviewer":      version.Reviewer,
+				"review_time":   version.ReviewTime,
+				"utime":         now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: 版本 %d 不是待审核状态", domain.ErrUpdateTemplateVersionAuditStatusFailed, version.ID)
+		}
+		if len(providers) == 0 {
+			return nil
+		}
+		for i := range providers {
+			providers[i].Ctime, providers[i].Utime = now, now
+		}
+		return tx.Create(&providers).Error
+	})
+}
+
+func (d *channelTemplateDAO) ForkVersion(ctx context.Context, version ChannelTemplateVersion,
+	providers []ChannelTemplateProvider, activeVersionID int64,
+) (ChannelTemplateVersion, error) {
+	now := d.clock.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version.Ctime, version.Utime = now, now
+		if err := tx.Create(&version).Error; err != nil {
+			return err
+		}
+		// 并发回滚时只有一个能成功，另一个拷贝的版本随事务回滚
+		result := tx.Model(&ChannelTemplate{}).
+			Where("id = ? AND active_version_id = ?", version.ChannelTemplateID, activeVersionID).
+			Updates(map[string]any{
+				"active_version_id": version.ID,
+				"utime":             now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: 模板 %d 的生效版本已经不是 %d", domain.ErrForkVersionFailed, version.ChannelTemplateID, activeVersionID)
+		}
+		if len(providers) == 0 {
+			return nil
+		}
+		for i := range providers {
+			providers[i].TemplateVersionID = version.ID
+			providers[i].Ctime, providers[i].Utime = now, now
+		}
+		return tx.Create(&providers).Error
+	})
+	if err != nil {
+		return ChannelTemplateVersion{}, err
+	}
+	return version, nil
+}
+
+func (d *channelTemplateDAO) CreateWithActiveVersion(ctx context.Context, template ChannelTemplate, version ChannelTemplateVersion) (ChannelTemplate, error) {
+	now := d.clock.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing ChannelTemplate
+		err := tx.Where("owner_id = ? AND name = ?", template.OwnerID, template.Name).First(&existing).Error
+		if err == nil {
+			template = existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		template.Ctime, template.Utime = now, now
+		if err = tx.Create(&template).Error; err != nil {
+			return err
+		}
+		version.ChannelTemplateID = template.ID
+		version.Ctime, version.Utime = now, now
+		if err = tx.Create(&version).Error; err != nil {
+			return err
+		}
+		template.ActiveVersionID = version.ID
+		return tx.Model(&ChannelTemplate{}).Where("id = ?", template.ID).
+			Update("active_version_id", version.ID).Error
+	})
+	return template, err
+}
+
+func (d *channelTemplateDAO) FindProvidersToCheck(ctx context.Context, now int64, limit int) ([]ChannelTemplateProvider, error) {
+	var providers []ChannelTemplateProvider
+	err := d.db.WithContext(ctx).
+		Where("audit_status IN ? AND next_check_time <= ?",
+			[]string{domain.AuditStatusPending.String(), domain.AuditStatusInReview.String()}, now).
+		Order("next_check_time").
+		Limit(limit).
+		Find(&providers).Error
+	return providers, err
+}
+
+func (d *channelTemplateDAO) UpdateProvider(ctx context.Context, provider ChannelTemplateProvider) error {
+	result := d.db.WithContext(ctx).Model(&ChannelTemplateProvider{}).
+		Where("id = ? AND audit_status IN ?", provider.ID,
+			[]string{domain.AuditStatusPending.String(), domain.AuditStatusInReview.String()}).
+		Updates(map[string]any{
+			"provider_template_id": provider.ProviderTemplateID,
+			"audit_status":         provider.AuditStatus,
+			"reject_reason":        provider.RejectReason,
+			"retry_count":          provider.RetryCount,
+			"next_check_time":      provider.NextCheckTime,
+			"utime":                d.clock.Now().UnixMilli(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id = %d", domain.ErrUpdateTemplateProviderAuditStatusFailed, provider.ID)
+	}
+	return nil
+}
+
+func (d *channelTemplateDAO) GetProviderByProviderTemplateID(ctx context.Context, providerName, providerTemplateID string) (ChannelTemplateProvider, error) {
+	var provider ChannelTemplateProvider
+	err := d.db.WithContext(ctx).
+		Where("provider_name = ? AND provider_template_id = ?", providerName, providerTemplateID).
+		First(&provider).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ChannelTemplateProvider{}, fmt.Errorf("%w: %s %s", domain.ErrTemplateProviderNotFound, providerName, providerTemplateID)
+	}
+	return provider, err
+}
@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TemplateUsage 模板使用情况表，由统计任务根据通知表和通知小时统计表汇总
+type TemplateUsage struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	TemplateID   int64 `gorm:"type:BIGINT;NOT NULL;uniqueIndex:idx_template_usage_template_id;comment:'渠道模版ID'"`
+	BizID        int64 `gorm:"type:BIGINT;NOT NULL;index:idx_template_usage_biz_id;comment:'业务配表ID'"`
+	SendCount    int64 `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'生产环境累计创建的通知数量'"`
+	LastUsedTime int64 `gorm:"type:BIGINT;NOT NULL;DEFAULT:0;comment:'最后一次创建生产环境通知的时间'"`
+	Ctime        int64
+	Utime        int64
+}
+
+// TableName 重命名表
+func (TemplateUsage) TableName() string {
+	return "template_usage"
+}
+
+// UnusedTemplate 没有用过的模板和它的使用情况，从没用过时 SendCount、LastUsedTime 为 0
+type UnusedTemplate struct {
+	ChannelTemplate `gorm:"embedded"`
+	SendCount       int64
+	LastUsedTime    int64
+}
+
+// TemplateUsageDAO 模板使用情况
+type TemplateUsageDAO interface {
+	// Rollup 重新汇总 since 之后用过的模板，返回汇总的模板数
+	// 发送次数取通知小时统计的累计值，最后使用时间只增不减，重复执行结果一样
+	Rollup(ctx context.Context, since int64) (int, error)
+	// FindByTemplateID 查询业务方模板的使用情况，没有用过时返回 SendCount、LastUsedTime 为 0 的记录
+	FindByTemplateID(ctx context.Context, bizID, templateID int64) (TemplateUsage, error)
+	// FindUnused 业务方在 before 之前创建、并且 before 之后没有用过的模板，按模板ID升序，只查询ID大于 afterID 的
+	FindUnused(ctx context.Context, bizID, before, afterID int64, limit int) ([]UnusedTemplate, error)
+}
+
+var _ TemplateUsageDAO = (*templateUsageDAO)(nil)
+
+type templateUsageDAO struct {
+	db *gorm.DB
+}
+
+func NewTemplateUsageDAO(db *gorm.DB) TemplateUsageDAO {
+	return &templateUsageDAO{db: db}
+}
+
+func (d *templateUsageDAO) Rollup(ctx context.Context, since int64) (int, error) {
+	var used []TemplateUsage
+	err := d.db.WithContext(ctx).Model(&Notification{}).
+		Select("biz_id, template_id, MAX(ctime) AS last_used_time").
+		// 沙箱通知不计入模板的使用情况
+		Where("ctime >= ? AND environment = ?", since, domain.EnvironmentProduction.String()).
+		Group("biz_id, template_id").
+		Scan(&used).Error
+	if err != nil {
+		return 0, err
+	}
+	for batch := range slices.Chunk(used, rollupBatchSize) {
+		if err = d.rollupBatch(ctx, batch); err != nil {
+			return 0, err
+		}
+	}
+	return len(used), nil
+}
+
+func (d *templateUsageDAO) rollupBatch(ctx context.Context, batch []TemplateUsage) error {
+	templateIDs := make([]int64, 0, len(batch))
+	for i := range batch {
+		templateIDs = append(templateIDs, batch[i].TemplateID)
+	}
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var counts []TemplateUsage
+		err := tx.Model(&NotificationHourlyStat{}).
+			Select("template_id, SUM(cnt) AS send_count").
+			Where("template_id IN ? AND environment = ?", templateIDs, domain.EnvironmentProduction.String()).
+			Group("template_id").
+			Scan(&counts).Error
+		if err != nil {
+			return err
+		}
+		var existing []TemplateUsage
+		if err = tx.Where("template_id IN ?", templateIDs).Find(&existing).Error; err != nil {
+			return err
+		}
+		sendCounts := make(map[int64]int64, len(counts))
+		for _, c := range counts {
+			sendCounts[c.TemplateID] = c.SendCount
+		}
+		lastUsed := make(map[int64]int64, len(existing))
+		for _, e := range existing {
+			lastUsed[e.TemplateID] = e.LastUsedTime
+		}
+		now := time.Now().UnixMilli()
+		for i := range batch {
+			batch[i].SendCount = sendCounts[batch[i].TemplateID]
+			// 回看窗口里最新的通知可能已经被删除（比如擦除接收者），最后使用时间不能改小
+			batch[i].LastUsedTime = max(batch[i].LastUsedTime, lastUsed[batch[i].TemplateID])
+			batch[i].Ctime, batch[i].Utime = now, now
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "template_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"send_count", "last_used_time", "utime"}),
+		}).Create(&batch).Error
+	})
+}
+
+func (d *templateUsageDAO) FindByTemplateID(ctx context.Context, bizID, templateID int64) (TemplateUsage, error) {
+	var usage TemplateUsage
+	err := d.db.WithContext(ctx).Where("biz_id = ? AND template_id = ?", bizID, templateID).First(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return TemplateUsage{BizID: bizID, TemplateID: templateID}, nil
+	}
+	return usage, err
+}
+
+func (d *templateUsageDAO) FindUnused(ctx context.Context, bizID, before, afterID int64, limit int) ([]UnusedTemplate, error) {
+	var res []UnusedTemplate
+	err := d.db.WithContext(ctx).Table("channel_templates AS t").
+		Select("t.*, COALESCE(u.send_count, 0) AS send_count, COALESCE(u.last_used_time, 0) AS last_used_time").
+		Joins("LEFT JOIN template_usage AS u ON u.template_id = t.id").
+		Where("t.owner_id = ? AND t.ctime < ? AND COALESCE(u.last_used_time, 0) < ? AND t.id > ?",
+			bizID, before, before, afterID).
+		Order("t.id").
+		Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VendorBalanceSnapshot 供应商余额快照表
+type VendorBalanceSnapshot struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement;comment:'ID'"`
+	Provider  string `gorm:"type:VARCHAR(64);NOT NULL;index:idx_vendor_balance_provider_ctime,priority:1;comment:'供应商名称'"`
+	Unit      string `gorm:"type:VARCHAR(16);NOT NULL;comment:'余额单位，CENT 或者 MESSAGE'"`
+	Remaining int64  `gorm:"NOT NULL;comment:'剩余的金额（分）或者条数'"`
+	Total     int64  `gorm:"NOT NULL;DEFAULT:0;comment:'套餐包的总条数'"`
+	Ctime     int64  `gorm:"index:idx_vendor_balance_provider_ctime,priority:2;index:idx_vendor_balance_ctime"`
+}
+
+// TableName 重命名表
+func (VendorBalanceSnapshot) TableName() string {
+	return "vendor_balance_snapshots"
+}
+
+// VendorBalanceDAO 供应商余额快照
+type VendorBalanceDAO interface {
+	Create(ctx context.Context, s VendorBalanceSnapshot) error
+	// DeleteBefore 删除 ctime 之前的快照，每次最多删除 limit 条，返回删除的数量
+	DeleteBefore(ctx context.Context, ctime int64, limit int) (int64, error)
+}
+
+var _ VendorBalanceDAO = (*vendorBalanceDAO)(nil)
+
+type vendorBalanceDAO struct {
+	db *gorm.DB
+}
+
+func NewVendorBalanceDAO(db *gorm.DB) VendorBalanceDAO {
+	return &vendorBalanceDAO{db: db}
+}
+
+func (d *vendorBalanceDAO) Create(ctx context.Context, s VendorBalanceSnapshot) error {
+	s.Ctime = time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Create(&s).Error
+}
+
+func (d *vendorBalanceDAO) DeleteBefore(ctx context.Context, ctime int64, limit int) (int64, error) {
+	// 先查ID再删除，MySQL 和 PostgreSQL 都支持
+	var ids []int64
+	err := d.db.WithContext(ctx).Model(&VendorBalanceSnapshot{}).
+		Where("ctime < ?", ctime).
+		Order("ctime").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	result := d.db.WithContext(ctx).Where("id IN ?", ids).Delete(&VendorBalanceSnapshot{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// DataRetentionRepository 数据保留策略执行和擦除记录
+type DataRetentionRepository interface {
+	// FindExpiredIDs 查找保留策略生效范围内已经过期的通知ID
+	FindExpiredIDs(ctx context.Context, scope domain.RetentionScope, cutoff time.Time, limit int) ([]uint64, error)
+	// Apply 按照策略的处理方式匿名化或者删除通知，返回处理的通知数量
+	Apply(ctx context.Context, action domain.RetentionAction, ids []uint64) (int64, error)
+	// CreateErasure 记录一次接收者数据擦除
+	CreateErasure(ctx context.Context, erasure domain.ReceiverErasure) (domain.ReceiverErasure, error)
+	// DeleteFinishedCallbackLogs 删除 cutoff 之前已经结束的回调记录，返回删除的数量
+	DeleteFinishedCallbackLogs(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+}
+
+var _ DataRetentionRepository = (*dataRetentionRepository)(nil)
+
+func NewDataRetentionRepository(d dao.DataRetentionDAO) DataRetentionRepository {
+	return &dataRetentionRepository{dao: d}
+}
+
+type dataRetentionRepository struct {
+	dao dao.DataRetentionDAO
+}
+
+func (r *dataRetentionRepository) FindExpiredIDs(ctx context.Context, scope domain.RetentionScope, cutoff time.Time, limit int) ([]uint64, error) {
+	return r.dao.FindExpiredIDs(ctx, scope, cutoff.UnixMilli(), limit)
+}
+
+func (r *dataRetentionRepository) Apply(ctx context.Context, action domain.RetentionAction, ids []uint64) (int64, error) {
+	if action == domain.RetentionActionPurge {
+		return r.dao.Purge(ctx, ids)
+	}
+	return r.dao.Anonymize(ctx, ids)
+}
+
+func (r *dataRetentionRepository) CreateErasure(ctx context.Context, erasure domain.ReceiverErasure) (domain.ReceiverErasure, error) {
+	created, err := r.dao.CreateErasure(ctx, dao.ReceiverErasure{
+		BizID:         erasure.BizID,
+		ReceiverIndex: erasure.ReceiverIndex,
+		Operator:      erasure.Operator,
+		Reason:        erasure.Reason,
+		Affected:      erasure.AffectedNotifications,
+	})
+	if err != nil {
+		return domain.ReceiverErasure{}, err
+	}
+	erasure.ID = created.ID
+	erasure.Ctime = time.UnixMilli(created.Ctime)
+	return erasure, nil
+}
+
+func (r *dataRetentionRepository) DeleteFinishedCallbackLogs(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	return r.dao.DeleteFinishedCallbackLogs(ctx, cutoff.UnixMilli(), limit)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// DigestRepository 合并发送，接收者和摘要在这一层加解密
+type DigestRepository interface {
+	// AddItems 把一条通知的每个接收者加入各自的合并分组，重复加入返回 domain.ErrNotificationDuplicate
+	// groups 和 items 一一对应，分组只需要填写 ID 以外的字段
+	AddItems(ctx context.Context, groups []domain.DigestGroup, items []domain.DigestItem) error
+	// FindDueGroups 合并窗口在 before 之前结束还没有发送的分组，接收者已经解密
+	FindDueGroups(ctx context.Context, before time.Time, limit int) ([]domain.DigestGroup, error)
+	// FindItems 分组内最早的 limit 条通知，摘要已经解密
+	FindItems(ctx context.Context, groupID int64, limit int) ([]domain.DigestItem, error)
+	// MarkSent 标记分组已发送，清空接收者并删除分组内的通知
+	MarkSent(ctx context.Context, groupID int64, notificationID uint64) error
+}
+
+var _ DigestRepository = (*digestRepository)(nil)
+
+func NewDigestRepository(d dao.DigestDAO, cipher encrypt.Cipher, indexer encrypt.BlindIndexer) DigestRepository {
+	return &digestRepository{
+		dao:     d,
+		cipher:  cipher,
+		indexer: indexer,
+	}
+}
+
+type digestRepository struct {
+	dao     dao.DigestDAO
+	cipher  encrypt.Cipher
+	indexer encrypt.BlindIndexer
+}
+
+func (r *digestRepository) AddItems(ctx context.Context, groups []domain.DigestGroup, items []domain.DigestItem) error {
+	entries := make([]dao.DigestEntry, 0, len(items))
+	for i, item := range items {
+		group := groups[i]
+		idx := r.indexer.Index(group.Receiver)
+		receiver, err := r.cipher.Encrypt(ctx, group.Receiver)
+		if err != nil {
+			return fmt.Errorf("加密接收者失败: %w", err)
+		}
+		summary, err := r.cipher.Encrypt(ctx, item.Summary)
+		if err != nil {
+			return fmt.Errorf("加密摘要失败: %w", err)
+		}
+		entries = append(entries, dao.DigestEntry{
+			Group: dao.DigestGroup{
+				BizID:         group.BizID,
+				Channel:       group.Channel.String(),
+				ReceiverIndex: idx,
+				WindowStart:   group.WindowStart.UnixMilli(),
+				WindowEnd:     group.WindowEnd.UnixMilli(),
+				TemplateID:    group.TemplateID,
+				Receiver:      receiver,
+			},
+			Item: dao.DigestItem{
+				BizID:         item.BizID,
+				Key:           item.Key,
+				ReceiverIndex: idx,
+				Summary:       summary,
+			},
+		})
+	}
+	return r.dao.AddItems(ctx, entries)
+}
+
+func (r *digestRepository) FindDueGroups(ctx context.Context, before time.Time, limit int) ([]domain.DigestGroup, error) {
+	groups, err := r.dao.FindDueGroups(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.DigestGroup, 0, len(groups))
+	for _, g := range groups {
+		receiver, err := r.cipher.Decrypt(ctx, g.Receiver)
+		if err != nil {
+			return nil, fmt.Errorf("解密接收者失败: id = %d: %w", g.ID, err)
+		}
+		res = append(res, domain.DigestGroup{
+			ID:             g.ID,
+			BizID:          g.BizID,
+			Channel:        domain.Channel(g.Channel),
+			TemplateID:     g.TemplateID,
+			Receiver:       receiver,
+			WindowStart:    time.UnixMilli(g.WindowStart),
+			WindowEnd:      time.UnixMilli(g.WindowEnd),
+			Status:         domain.DigestGroupStatus(g.Status),
+			ItemCount:      g.ItemCount,
+			NotificationID: g.NotificationID,
+		})
+	}
+	return res, nil
+}
+
+func (r *digestRepository) FindItems(ctx context.Context, groupID int64, limit int) ([]domain.DigestItem, error) {
+	items, err := r.dao.FindItems(ctx, groupID, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.DigestItem, 0, len(items))
+	for _, item := range items {
+		summary, err := r.cipher.Decrypt(ctx, item.Summary)
+		if err != nil {
+			return nil, fmt.Errorf("解密摘要失败: id = %d: %w", item.ID, err)
+		}
+		res = append(res, domain.DigestItem{
+			ID:      item.ID,
+			GroupID: item.GroupID,
+			BizID:   item.BizID,
+			Key:     item.Key,
+			Summary: summary,
+		})
+	}
+	return res, nil
+}
+
+func (r *digestRepository) MarkSent(ctx context.Context, groupID int64, notificationID uint64) error {
+	return r.dao.MarkSent(ctx, groupID, notificationID)
+}
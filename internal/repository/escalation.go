@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// EscalationRepository 跨渠道升级链，步骤里有接收者，在这一层加解密
+type EscalationRepository interface {
+	// Create 创建升级链，(bizID, key) 冲突时返回 domain.ErrEscalationDuplicate
+	Create(ctx context.Context, e domain.Escalation) (domain.Escalation, error)
+	GetByKey(ctx context.Context, bizID int64, key string) (domain.Escalation, error)
+	// FindDue 到了进入下一步时间的进行中的升级链
+	FindDue(ctx context.Context, limit int) ([]domain.Escalation, error)
+	// Update 按版本号更新，版本不一致或者升级链已经结束时返回 domain.ErrEscalationChanged
+	Update(ctx context.Context, e domain.Escalation) error
+}
+
+var _ EscalationRepository = (*escalationRepository)(nil)
+
+func NewEscalationRepository(d dao.EscalationDAO, cipher encrypt.Cipher, clk clock.Clock) EscalationRepository {
+	return &escalationRepository{
+		dao:    d,
+		cipher: cipher,
+		clock:  clk,
+	}
+}
+
+type escalationRepository struct {
+	dao    dao.EscalationDAO
+	cipher encrypt.Cipher
+	clock  clock.Clock
+}
+
+func (r *escalationRepository) Create(ctx context.Context, e domain.Escalation) (domain.Escalation, error) {
+	entity, err := r.toEntity(ctx, e)
+	if err != nil {
+		return domain.Escalation{}, err
+	}
+	entity, err = r.dao.Create(ctx, entity)
+	if err != nil {
+		return domain.Escalation{}, err
+	}
+	e.ID = entity.ID
+	e.Version = entity.Version
+	return e, nil
+}
+
+func (r *escalationRepository) GetByKey(ctx context.Context, bizID int64, key string) (domain.Escalation, error) {
+	entity, err := r.dao.GetByKey(ctx, bizID, key)
+	if err != nil {
+		return domain.Escalation{}, err
+	}
+	return r.toDomain(ctx, entity)
+}
+
+func (r *escalationRepository) FindDue(ctx context.Context, limit int) ([]domain.Escalation, error) {
+	entities, err := r.dao.FindDue(ctx, r.clock.Now().UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.Escalation, 0, len(entities))
+	for _, entity := range entities {
+		e, err := r.toDomain(ctx, entity)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, nil
+}
+
+func (r *escalationRepository) Update(ctx context.Context, e domain.Escalation) error {
+	entity, err := r.toEntity(ctx, e)
+	if err != nil {
+		return err
+	}
+	return r.dao.Update(ctx, entity)
+}
+
+func (r *escalationRepository) toEntity(ctx context.Context, e domain.Escalation) (dao.Escalation, error) {
+	data, err := json.Marshal(e.Steps)
+	if err != nil {
+		return dao.Escalation{}, fmt.Errorf("序列化升级步骤失败: %w", err)
+	}
+	steps, err := r.cipher.Encrypt(ctx, string(data))
+	if err != nil {
+		return dao.Escalation{}, fmt.Errorf("加密升级步骤失败: %w", err)
+	}
+	entity := dao.Escalation{
+		ID:           e.ID,
+		BizID:        e.BizID,
+		Key:          e.Key,
+		Steps:        steps,
+		StepCount:    len(e.Steps),
+		CurrentStep:  e.CurrentStep,
+		Status:       e.Status.String(),
+		NextStepTime: e.NextStepTime.UnixMilli(),
+		AckBy:        e.AckBy,
+		Version:      e.Version,
+	}
+	if !e.AckTime.IsZero() {
+		entity.AckTime = e.AckTime.UnixMilli()
+	}
+	return entity, nil
+}
+
+func (r *escalationRepository) toDomain(ctx context.Context, entity dao.Escalation) (domain.Escalation, error) {
+	data, err := r.cipher.Decrypt(ctx, entity.Steps)
+	if err != nil {
+		return domain.Escalation{}, fmt.Errorf("解密升级步骤失败: id = %d: %w", entity.ID, err)
+	}
+	var steps []domain.EscalationStep
+	if err := json.Unmarshal([]byte(data), &steps); err != nil {
+		return domain.Escalation{}, fmt.Errorf("反序列化升级步骤失败: id = %d: %w", entity.ID, err)
+	}
+	e := domain.Escalation{
+		ID:           entity.ID,
+		BizID:        entity.BizID,
+		Key:          entity.Key,
+		Steps:        steps,
+		CurrentStep:  entity.CurrentStep,
+		Status:       domain.EscalationStatus(entity.Status),
+		NextStepTime: time.UnixMilli(entity.NextStepTime),
+		AckBy:        entity.AckBy,
+		Version:      entity.Version,
+	}
+	if entity.AckTime > 0 {
+		e.AckTime = time.UnixMilli(entity.AckTime)
+	}
+	return e, nil
+}
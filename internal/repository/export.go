@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// ExportRepository 增量读取通知变化，导出到分析库
+type ExportRepository interface {
+	// FindEvents 查询 checkpoint 之后、until 之前发生的通知变化，按发生顺序排列
+	FindEvents(ctx context.Context, checkpoint domain.ExportCheckpoint, until time.Time, limit int) ([]domain.NotificationEvent, error)
+	// GetCheckpoint 没有导出过时返回从头开始的进度
+	GetCheckpoint(ctx context.Context, name string) (domain.ExportCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, checkpoint domain.ExportCheckpoint) error
+}
+
+var _ ExportRepository = (*exportRepository)(nil)
+
+func NewExportRepository(d dao.ExportDAO) ExportRepository {
+	return &exportRepository{dao: d}
+}
+
+type exportRepository struct {
+	dao dao.ExportDAO
+}
+
+func (r *exportRepository) FindEvents(ctx context.Context, checkpoint domain.ExportCheckpoint, until time.Time, limit int) ([]domain.NotificationEvent, error) {
+	notifications, err := r.dao.FindChanged(ctx, checkpoint.Utime, checkpoint.ID, until.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]domain.NotificationEvent, 0, len(notifications))
+	for _, n := range notifications {
+		events = append(events, domain.NotificationEvent{
+			NotificationID: n.ID,
+			BizID:          n.BizID,
+			Key:            n.Key,
+			Channel:        domain.Channel(n.Channel),
+			TemplateID:     n.TemplateID,
+			Status:         domain.SendStatus(n.Status),
+			FailReason:     domain.FailReason(n.FailReason),
+			Version:        n.Version,
+			Ctime:          n.Ctime,
+			Utime:          n.Utime,
+			Environment:    domain.Environment(n.Environment),
+		})
+	}
+	return events, nil
+}
+
+func (r *exportRepository) GetCheckpoint(ctx context.Context, name string) (domain.ExportCheckpoint, error) {
+	cp, err := r.dao.GetCheckpoint(ctx, name)
+	if err != nil {
+		return domain.ExportCheckpoint{}, err
+	}
+	return domain.ExportCheckpoint{Name: cp.Name, Utime: cp.LastTime, ID: cp.LastID}, nil
+}
+
+func (r *exportRepository) SaveCheckpoint(ctx context.Context, checkpoint domain.ExportCheckpoint) error {
+	return r.dao.SaveCheckpoint(ctx, dao.ExportCheckpoint{
+		Name:     checkpoint.Name,
+		LastTime: checkpoint.Utime,
+		LastID:   checkpoint.ID,
+	})
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// InboxRepository 站内信收件箱投影，接收者在这一层转换成盲索引
+type InboxRepository interface {
+	// Project 按通知的最新状态更新收件箱，发送成功的站内信出现在每个接收者的收件箱里，其余的从收件箱移除
+	// 同一批事件重复投影结果一样
+	Project(ctx context.Context, events []domain.NotificationEvent) error
+	// List 按通知ID倒序查询接收者的收件箱
+	List(ctx context.Context, query domain.InboxQuery) ([]domain.InboxEntry, error)
+}
+
+var _ InboxRepository = (*inboxRepository)(nil)
+
+func NewInboxRepository(d dao.InboxDAO, indexer encrypt.BlindIndexer) InboxRepository {
+	return &inboxRepository{dao: d, indexer: indexer}
+}
+
+type inboxRepository struct {
+	dao     dao.InboxDAO
+	indexer encrypt.BlindIndexer
+}
+
+func (r *inboxRepository) Project(ctx context.Context, events []domain.NotificationEvent) error {
+	ids := make([]uint64, 0, len(events))
+	var delivered []uint64
+	for _, e := range events {
+		if e.Channel != domain.ChannelInApp {
+			continue
+		}
+		ids = append(ids, e.NotificationID)
+		if e.Status == domain.SendStatusSucceeded {
+			delivered = append(delivered, e.NotificationID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	indexes, err := r.dao.FindReceiverIndexes(ctx, delivered)
+	if err != nil {
+		return err
+	}
+	var rows []dao.NotificationInbox
+	for _, e := range events {
+		if e.Channel != domain.ChannelInApp || e.Status != domain.SendStatusSucceeded {
+			continue
+		}
+		for _, idx := range indexes[e.NotificationID] {
+			rows = append(rows, dao.NotificationInbox{
+				BizID:          e.BizID,
+				ReceiverIndex:  idx,
+				NotificationID: e.NotificationID,
+				Ctime:          e.Ctime,
+			})
+		}
+	}
+	return r.dao.Replace(ctx, ids, rows)
+}
+
+func (r *inboxRepository) List(ctx context.Context, query domain.InboxQuery) ([]domain.InboxEntry, error) {
+	rows, err := r.dao.ListByReceiver(ctx, query.BizID, r.indexer.Index(query.Receiver), query.BeforeID, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]domain.InboxEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, domain.InboxEntry{
+			NotificationID: row.NotificationID,
+			BizID:          row.BizID,
+			Receiver:       query.Receiver,
+			Ctime:          time.UnixMilli(row.Ctime),
+		})
+	}
+	return entries, nil
+}
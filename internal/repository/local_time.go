@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// LocalTimeRepository 按本地时间发送的时区分组，接收者和模板参数在这一层加解密
+type LocalTimeRepository interface {
+	// Create 保存一条通知的所有时区分组，重复创建返回 domain.ErrNotificationDuplicate
+	Create(ctx context.Context, cohorts []domain.LocalTimeCohort) error
+	// FindByKey 一条通知的所有时区分组，不解密接收者和模板参数，只有接收者数量
+	FindByKey(ctx context.Context, bizID int64, key string) ([]domain.LocalTimeCohort, error)
+	// FindDue 发送时间在 before 之前还没有创建通知的分组，接收者和模板参数已经解密
+	FindDue(ctx context.Context, before time.Time, limit int) ([]domain.LocalTimeCohort, error)
+	// MarkCreated 标记分组已经创建了通知，清空接收者和模板参数
+	MarkCreated(ctx context.Context, id int64, notificationID uint64) error
+}
+
+var _ LocalTimeRepository = (*localTimeRepository)(nil)
+
+func NewLocalTimeRepository(d dao.LocalTimeDAO, cipher encrypt.Cipher) LocalTimeRepository {
+	return &localTimeRepository{
+		dao:    d,
+		cipher: cipher,
+	}
+}
+
+type localTimeRepository struct {
+	dao    dao.LocalTimeDAO
+	cipher encrypt.Cipher
+}
+
+func (r *localTimeRepository) Create(ctx context.Context, cohorts []domain.LocalTimeCohort) error {
+	entities := make([]dao.LocalTimeCohort, 0, len(cohorts))
+	for _, c := range cohorts {
+		rawReceivers, _ := json.Marshal(c.Receivers)
+		receivers, err := r.cipher.Encrypt(ctx, string(rawReceivers))
+		if err != nil {
+			return fmt.Errorf("加密接收者失败: %w", err)
+		}
+		rawParams, _ := json.Marshal(c.TemplateParams)
+		params, err := r.cipher.Encrypt(ctx, string(rawParams))
+		if err != nil {
+			return fmt.Errorf("加密模板参数失败: %w", err)
+		}
+		entity := dao.LocalTimeCohort{
+			BizID:          c.BizID,
+			Key:            c.Key,
+			Timezone:       c.Timezone,
+			SendTime:       c.SendTime.UnixMilli(),
+			EndTime:        c.EndTime.UnixMilli(),
+			Receivers:      receivers,
+			ReceiverCount:  int32(len(c.Receivers)),
+			Channel:        c.Channel.String(),
+			TemplateID:     c.TemplateID,
+			TemplateParams: params,
+			Category:       c.Category.String(),
+			BizPayload:     c.BizPayload,
+			Environment:    cmp.Or(c.Environment, domain.EnvironmentProduction).String(),
+			RetryPolicy:    toRetryPolicyEntity(c.RetryPolicy),
+		}
+		if len(c.Labels) > 0 {
+			labels, _ := json.Marshal(c.Labels)
+			entity.Labels = sql.NullString{String: string(labels), Valid: true}
+		}
+		entities = append(entities, entity)
+	}
+	return r.dao.Create(ctx, entities)
+}
+
+func (r *localTimeRepository) FindByKey(ctx context.Context, bizID int64, key string) ([]domain.LocalTimeCohort, error) {
+	entities, err := r.dao.FindByKey(ctx, bizID, key)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.LocalTimeCohort, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, r.toDomain(e))
+	}
+	return res, nil
+}
+
+func (r *localTimeRepository) FindDue(ctx context.Context, before time.Time, limit int) ([]domain.LocalTimeCohort, error) {
+	entities, err := r.dao.FindDue(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.LocalTimeCohort, 0, len(entities))
+	for _, e := range entities {
+		c := r.toDomain(e)
+		rawReceivers, err := r.cipher.Decrypt(ctx, e.Receivers)
+		if err != nil {
+			return nil, fmt.Errorf("解密接收者失败: id = %d: %w", e.ID, err)
+		}
+		_ = json.Unmarshal([]byte(rawReceivers), &c.Receivers)
+		rawParams, err := r.cipher.Decrypt(ctx, e.TemplateParams)
+		if err != nil {
+			return nil, fmt.Errorf("解密模板参数失败: id = %d: %w", e.ID, err)
+		}
+		_ = json.Unmarshal([]byte(rawParams), &c.TemplateParams)
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+func (r *localTimeRepository) MarkCreated(ctx context.Context, id int64, notificationID uint64) error {
+	return r.dao.MarkCreated(ctx, id, notificationID)
+}
+
+// toDomain 不包括接收者和模板参数
+func (r *localTimeRepository) toDomain(e dao.LocalTimeCohort) domain.LocalTimeCohort {
+	return domain.LocalTimeCohort{
+		ID:             e.ID,
+		BizID:          e.BizID,
+		Key:            e.Key,
+		Timezone:       e.Timezone,
+		SendTime:       time.UnixMilli(e.SendTime),
+		EndTime:        time.UnixMilli(e.EndTime),
+		ReceiverCount:  e.ReceiverCount,
+		Channel:        domain.Channel(e.Channel),
+		TemplateID:     e.TemplateID,
+		Category:       domain.NotificationCategory(e.Category),
+		Labels:         labelsFromEntity(e.Labels),
+		BizPayload:     e.BizPayload,
+		Environment:    domain.Environment(e.Environment),
+		Status:         domain.LocalTimeCohortStatus(e.Status),
+		NotificationID: e.NotificationID,
+		RetryPolicy:    retryPolicyFromEntity(e.RetryPolicy),
+	}
+}
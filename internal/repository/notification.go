@@ -1,14 +1,19 @@
 package repository
 
 import (
+	"cmp"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
 	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
 	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
 	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
 	"go.uber.org/zap"
+	"strconv"
 	"time"
 )
 
@@ -31,19 +36,42 @@ type NotificationRepository interface {
 	GetByKey(ctx context.Context, bizID int64, key string) (domain.Notification, error)
 	// GetByKeys 根据业务ID和业务内唯一标识获取通知列表
 	GetByKeys(ctx context.Context, bizID int64, keys ...string) ([]domain.Notification, error)
+	// FindByReceiver 根据接收者查询通知，接收者加密存储，通过盲索引匹配
+	FindByReceiver(ctx context.Context, bizID int64, receiver string, limit int) ([]domain.Notification, error)
+	// ListByBiz 按业务方分页查询通知，按ID倒序
+	ListByBiz(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, error)
+	// ListForExport 按 (创建时间, ID) 升序查询 after 之后的一批待导出通知
+	ListForExport(ctx context.Context, query domain.NotificationExportQuery, after domain.ExportCursor, limit int) ([]domain.ExportedNotification, error)
+	// FindFallbackGroups 查询业务方的渠道降级分组，分组里的通知只有状态，没有接收者和模板参数，没有通知的分组不返回
+	FindFallbackGroups(ctx context.Context, bizID int64, groups ...string) (map[string]domain.FallbackGroup, error)
 
 	// CASStatus 更新通知状态
 	CASStatus(ctx context.Context, notification domain.Notification) error
 	UpdateStatus(ctx context.Context, notification domain.Notification) error
-
-	// BatchUpdateStatusSucceededOrFailed 批量更新通知状态为成功或失败
-	BatchUpdateStatusSucceededOrFailed(ctx context.Context, succeededNotifications, failedNotifications []domain.Notification) error
-
-	FindReadyNotifications(ctx context.Context, offset int, limit int) ([]domain.Notification, error)
+	// CancelPending 取消待发送的通知，并归还额度
+	CancelPending(ctx context.Context, notification domain.Notification) error
+	// UpdatePending 修改待发送的通知并记录审计日志，返回修改后的通知
+	UpdatePending(ctx context.Context, notification domain.Notification, auditLog domain.NotificationAuditLog) (domain.Notification, error)
+	// Reschedule 修改待发送通知的计划发送时间，版本号不匹配返回 domain.ErrNotificationVersionMismatch
+	Reschedule(ctx context.Context, notification domain.Notification) error
+	// ScheduleRetry 发送失败的通知按 ScheduledSTime 重新排期，失败次数加一，版本号不匹配返回 domain.ErrNotificationVersionMismatch
+	ScheduleRetry(ctx context.Context, notification domain.Notification) error
+	// EraseReceiver 从通知中擦除指定接收者，通知因此被取消时归还额度
+	EraseReceiver(ctx context.Context, notification domain.Notification, receiver string) error
+
+	// BatchUpdateStatusSucceededOrFailed 批量更新通知状态为成功或失败，按版本号 CAS，返回版本号不匹配、没有更新的通知ID
+	BatchUpdateStatusSucceededOrFailed(ctx context.Context, succeededNotifications, failedNotifications []domain.Notification) (conflicted []uint64, err error)
+
+	// FindReadyNotifications 查找分区内到了发送时间、ID 大于 afterID 的通知，按 ID 升序
+	FindReadyNotifications(ctx context.Context, partition domain.Partition, afterID uint64, limit int) ([]domain.Notification, error)
+	// MarkSuccess、MarkFailed 按版本号把 SENDING 的通知标记为发送结果，MarkFailed 同时归还额度
+	// 通知已经不是这个版本的 SENDING（超时清理或者其他实例已经给出结果）时返回 domain.ErrNotificationVersionMismatch
 	MarkSuccess(ctx context.Context, entity domain.Notification) error
 	MarkFailed(ctx context.Context, notification domain.Notification) error
-	// MarkTimeoutSendingAsFailed 将超时的 SENDING 状态的通知都标记为失败
-	MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) (int64, error)
+	// MarkTimeoutSendingAsFailed 将超时的 SENDING 通知标记为失败并归还额度，返回被标记的通知
+	MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) ([]domain.Notification, error)
+	// MarkExpiredAsFailed 将过期的 PENDING 通知标记为失败并归还额度，返回被标记的通知
+	MarkExpiredAsFailed(ctx context.Context, batchSize int) ([]domain.Notification, error)
 }
 
 const (
@@ -51,108 +79,327 @@ const (
 )
 
 // notificationRepository 通知仓储实现
+// 接收者和模板参数在这一层加解密，DAO 层只看到密文
 type notificationRepository struct {
 	dao        dao.NotificationDAO
 	quotaCache cache.QuotaCache
-	logger     log.LoggerInterface
+	cipher     encrypt.Cipher
+	indexer    encrypt.BlindIndexer
+	// reserve 扣减额度时记录扣减意图，写入成功之后删除，进程在两者之间退出时由 QuotaReservationRepository 修复
+	reserve bool
+	clock   clock.Clock
+	logger  log.LoggerInterface
 }
 
 // NewNotificationRepository 创建通知仓储实例
-func NewNotificationRepository(d dao.NotificationDAO, quotaCache cache.QuotaCache) NotificationRepository {
+func NewNotificationRepository(d dao.NotificationDAO, quotaCache cache.QuotaCache,
+	cipher encrypt.Cipher, indexer encrypt.BlindIndexer, clk clock.Clock,
+) NotificationRepository {
 	return &notificationRepository{
 		dao:        d,
 		quotaCache: quotaCache,
-		logger:     log.DefaultLogger(),
+		cipher:     cipher,
+		indexer:    indexer,
+		clock:      clk,
+		logger:     log.Named(log.DefaultLogger(), "repository.notification"),
 	}
 }
 
+// NewNotificationRepositoryWithReservation 扣减额度时和扣减一起记录扣减意图
+// 需要同时运行 QuotaReservationRepository 的修复任务，否则写入失败又没能归还的意图会一直留在 Redis 里
+func NewNotificationRepositoryWithReservation(d dao.NotificationDAO, quotaCache cache.QuotaCache,
+	cipher encrypt.Cipher, indexer encrypt.BlindIndexer, clk clock.Clock,
+) NotificationRepository {
+	r := NewNotificationRepository(d, quotaCache, cipher, indexer, clk).(*notificationRepository)
+	r.reserve = true
+	return r
+}
+
 // Create 创建单条通知记录，但不创建对应的回调记录
 func (r *notificationRepository) Create(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
-	// 扣减额度
-	err := r.quotaCache.Decr(ctx, notification.BizID, notification.Channel, defaultQuotaNumber)
+	entity, err := r.toEntity(ctx, notification)
 	if err != nil {
 		return domain.Notification{}, err
 	}
-	ds, err := r.dao.Create(ctx, r.toEntity(notification))
+	if r.reserve {
+		return r.createReserved(ctx, notification, entity, r.dao.Create)
+	}
+	if err = r.decrQuota(ctx, notification); err != nil {
+		return domain.Notification{}, err
+	}
+	ds, err := r.dao.Create(ctx, entity)
 	if err != nil {
 		// 创建没成功把额度还回去
-		qerr := r.quotaCache.Incr(ctx, notification.BizID, notification.Channel, defaultQuotaNumber)
-		if qerr != nil {
-			r.logger.Error("额度归还失败", zap.Any("error", err),
-				zap.Int64("biz_id", notification.BizID),
-				zap.String("channel", notification.Channel.String()),
-			)
-		}
+		r.returnQuota(ctx, notification)
+		return domain.Notification{}, err
+	}
+	return r.toDomain(ctx, ds)
+}
+
+// createReserved 扣减额度并记录扣减意图，写入成功之后确认，失败时归还
+func (r *notificationRepository) createReserved(ctx context.Context, notification domain.Notification, entity dao.Notification,
+	create func(ctx context.Context, data dao.Notification) (dao.Notification, error),
+) (domain.Notification, error) {
+	reservation, recorded, err := r.reserveQuota(ctx, []domain.Notification{notification})
+	if err != nil {
 		return domain.Notification{}, err
 	}
-	return r.toDomain(ds), nil
+	ds, err := create(ctx, entity)
+	if err != nil {
+		r.refundReservation(ctx, reservation, recorded)
+		return domain.Notification{}, err
+	}
+	r.commitReservation(ctx, reservation, recorded)
+	return r.toDomain(ctx, ds)
+}
+
+// reserveQuota 扣减额度，扣减意图以第一条占用额度的通知ID为ID，recorded 表示意图是否已经记录
+// 全是沙箱通知时不扣减；有通知没有ID时无法核对，只扣减不记录
+func (r *notificationRepository) reserveQuota(ctx context.Context, notifications []domain.Notification) (cache.QuotaReservation, bool, error) {
+	reservation := cache.QuotaReservation{Items: r.getItems(notifications), Ctime: r.clock.Now()}
+	if len(reservation.Items) == 0 {
+		return reservation, false, nil
+	}
+	for i := range notifications {
+		if notifications[i].IsSandbox() {
+			continue
+		}
+		if notifications[i].ID == 0 {
+			return reservation, false, r.quotaCache.MutiDecr(ctx, reservation.Items)
+		}
+		reservation.NotificationIDs = append(reservation.NotificationIDs, notifications[i].ID)
+	}
+	reservation.ID = strconv.FormatUint(reservation.NotificationIDs[0], 10)
+	recorded, err := r.quotaCache.ReserveDecr(ctx, reservation)
+	return reservation, recorded, err
+}
+
+// commitReservation 通知已经写入，删除扣减意图，失败时由修复任务核对之后删除
+func (r *notificationRepository) commitReservation(ctx context.Context, reservation cache.QuotaReservation, recorded bool) {
+	if !recorded {
+		return
+	}
+	if err := r.quotaCache.CommitReservation(ctx, reservation.ID); err != nil {
+		r.logger.WithContext(ctx).Warn("删除扣减意图失败，等待修复任务处理", zap.Error(err),
+			zap.String("reservation", reservation.ID))
+	}
+}
+
+// refundReservation 写入失败，归还额度，记录过意图时通过意图归还，保证和修复任务只归还一次
+func (r *notificationRepository) refundReservation(ctx context.Context, reservation cache.QuotaReservation, recorded bool) {
+	if len(reservation.Items) == 0 {
+		return
+	}
+	var err error
+	if recorded {
+		_, err = r.quotaCache.RefundReservation(ctx, reservation)
+	} else {
+		err = r.quotaCache.MutiIncr(ctx, reservation.Items)
+	}
+	if err != nil {
+		r.logger.WithContext(ctx).Error("发送失败，归还额度失败", zap.Error(err),
+			zap.String("reservation", reservation.ID), zap.Bool("recorded", recorded))
+	}
+}
+
+// decrQuota 扣减额度，沙箱通知不占用额度
+func (r *notificationRepository) decrQuota(ctx context.Context, notification domain.Notification) error {
+	if notification.IsSandbox() {
+		return nil
+	}
+	return r.quotaCache.Decr(ctx, notification.BizID, notification.Channel, notification.Category, defaultQuotaNumber)
+}
+
+// returnQuota 归还额度，失败只记录日志
+func (r *notificationRepository) returnQuota(ctx context.Context, notification domain.Notification) {
+	if notification.IsSandbox() {
+		return
+	}
+	err := r.quotaCache.Incr(ctx, notification.BizID, notification.Channel, defaultQuotaNumber)
+	if err != nil {
+		r.logger.WithContext(ctx).Error("额度归还失败", zap.Error(err),
+			zap.Int64("biz_id", notification.BizID),
+			zap.String("channel", notification.Channel.String()),
+		)
+	}
 }
 
-// toEntity 将领域对象转换为DAO实体
-func (r *notificationRepository) toEntity(notification domain.Notification) dao.Notification {
+// toEntity 将领域对象转换为DAO实体，接收者和模板参数加密，同时计算接收者盲索引
+func (r *notificationRepository) toEntity(ctx context.Context, notification domain.Notification) (dao.Notification, error) {
 	templateParams, _ := notification.MarshalTemplateParams()
 	receivers, _ := notification.MarshalReceivers()
+	entity := r.toStateEntity(notification)
+
+	var err error
+	entity.Receivers, err = r.cipher.Encrypt(ctx, receivers)
+	if err != nil {
+		return dao.Notification{}, fmt.Errorf("加密接收者失败: %w", err)
+	}
+	entity.TemplateParams, err = r.cipher.Encrypt(ctx, templateParams)
+	if err != nil {
+		return dao.Notification{}, fmt.Errorf("加密模板参数失败: %w", err)
+	}
+	if receiverParams, _ := notification.MarshalReceiverParams(); receiverParams != "" {
+		encrypted, err := r.cipher.Encrypt(ctx, receiverParams)
+		if err != nil {
+			return dao.Notification{}, fmt.Errorf("加密接收者模板参数失败: %w", err)
+		}
+		entity.ReceiverParams = sql.NullString{String: encrypted, Valid: true}
+	}
+	entity.ReceiverIndexes = make([]string, 0, len(notification.Receivers))
+	for _, receiver := range notification.Receivers {
+		entity.ReceiverIndexes = append(entity.ReceiverIndexes, r.indexer.Index(receiver))
+	}
+	entity.Callback, err = toCallbackOptionsEntity(notification.Callback)
+	if err != nil {
+		return dao.Notification{}, err
+	}
+	if len(notification.Labels) > 0 {
+		labels, _ := json.Marshal(notification.Labels)
+		entity.Labels = sql.NullString{String: string(labels), Valid: true}
+	}
+	entity.Environment = cmp.Or(notification.Environment, domain.EnvironmentProduction).String()
+	entity.Campaign = notification.Campaign
+	entity.FallbackGroup = notification.FallbackGroup
+	entity.BizPayload = notification.BizPayload
+	entity.RetryPolicy = toRetryPolicyEntity(notification.RetryPolicy)
+	return entity, nil
+}
+
+func toRetryPolicyEntity(p domain.RetryPolicy) dao.RetryPolicy {
+	return dao.RetryPolicy{
+		RetryMaxAttempts:    p.MaxAttempts,
+		RetryInitialBackoff: p.InitialBackoff.Milliseconds(),
+		RetryMaxBackoff:     p.MaxBackoff.Milliseconds(),
+	}
+}
+
+func retryPolicyFromEntity(p dao.RetryPolicy) domain.RetryPolicy {
+	return domain.RetryPolicy{
+		MaxAttempts:    p.RetryMaxAttempts,
+		InitialBackoff: time.Duration(p.RetryInitialBackoff) * time.Millisecond,
+		MaxBackoff:     time.Duration(p.RetryMaxBackoff) * time.Millisecond,
+	}
+}
+
+// toStateEntity 只转换状态流转用到的字段，不涉及敏感数据，更新状态时使用
+func (r *notificationRepository) toStateEntity(notification domain.Notification) dao.Notification {
 	return dao.Notification{
 		ID:                notification.ID,
 		BizID:             notification.BizID,
 		Key:               notification.Key,
-		Receivers:         receivers,
 		Channel:           notification.Channel.String(),
 		TemplateID:        notification.Template.ID,
 		TemplateVersionID: notification.Template.VersionID,
-		TemplateParams:    templateParams,
 		Status:            notification.Status.String(),
 		ScheduledSTime:    notification.ScheduledSTime.UnixMilli(),
 		ScheduledETime:    notification.ScheduledETime.UnixMilli(),
 		Version:           notification.Version,
+		FailReason:        notification.FailReason.String(),
 	}
 }
 
-// toDomain 将DAO实体转换为领域对象
-func (r *notificationRepository) toDomain(n dao.Notification) domain.Notification {
+// toDomain 将DAO实体转换为领域对象，解密接收者和模板参数
+func (r *notificationRepository) toDomain(ctx context.Context, n dao.Notification) (domain.Notification, error) {
+	rawParams, err := r.cipher.Decrypt(ctx, n.TemplateParams)
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf("解密模板参数失败 id=%d: %w", n.ID, err)
+	}
 	var templateParams map[string]string
-	_ = json.Unmarshal([]byte(n.TemplateParams), &templateParams)
+	_ = json.Unmarshal([]byte(rawParams), &templateParams)
 
+	var receiverParams map[string]map[string]string
+	if n.ReceiverParams.Valid {
+		raw, err := r.cipher.Decrypt(ctx, n.ReceiverParams.String)
+		if err != nil {
+			return domain.Notification{}, fmt.Errorf("解密接收者模板参数失败 id=%d: %w", n.ID, err)
+		}
+		_ = json.Unmarshal([]byte(raw), &receiverParams)
+	}
+
+	rawReceivers, err := r.cipher.Decrypt(ctx, n.Receivers)
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf("解密接收者失败 id=%d: %w", n.ID, err)
+	}
 	var receivers []string
-	_ = json.Unmarshal([]byte(n.Receivers), &receivers)
+	_ = json.Unmarshal([]byte(rawReceivers), &receivers)
 
 	return domain.Notification{
 		ID:        n.ID,
 		BizID:     n.BizID,
 		Key:       n.Key,
+		Ctime:     timeFromMilli(n.Ctime),
 		Receivers: receivers,
 		Channel:   domain.Channel(n.Channel),
 		Template: domain.Template{
-			ID:        n.TemplateID,
-			VersionID: n.TemplateVersionID,
-			Params:    templateParams,
+			ID:             n.TemplateID,
+			VersionID:      n.TemplateVersionID,
+			Params:         templateParams,
+			ReceiverParams: receiverParams,
 		},
 		Status:         domain.SendStatus(n.Status),
 		ScheduledSTime: time.UnixMilli(n.ScheduledSTime),
 		ScheduledETime: time.UnixMilli(n.ScheduledETime),
 		Version:        n.Version,
+		FailReason:     domain.FailReason(n.FailReason),
+		Labels:         labelsFromEntity(n.Labels),
+		Environment:    domain.Environment(n.Environment),
+		Campaign:       n.Campaign,
+		FallbackGroup:  n.FallbackGroup,
+		BizPayload:     n.BizPayload,
+		RetryPolicy:    retryPolicyFromEntity(n.RetryPolicy),
+		Attempts:       n.Attempts,
+	}, nil
+}
+
+// timeFromMilli 毫秒时间戳转换为时间，没有查询的时间戳保持零值
+func timeFromMilli(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+func labelsFromEntity(s sql.NullString) domain.Labels {
+	if !s.Valid {
+		return nil
 	}
+	var labels domain.Labels
+	_ = json.Unmarshal([]byte(s.String), &labels)
+	return labels
+}
+
+// toDomains 批量转换，有一条解密失败就返回错误
+func (r *notificationRepository) toDomains(ctx context.Context, ns []dao.Notification) ([]domain.Notification, error) {
+	result := make([]domain.Notification, 0, len(ns))
+	for i := range ns {
+		n, err := r.toDomain(ctx, ns[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+	return result, nil
 }
 
 // CreateWithCallbackLog 创建单条通知记录，同时创建对应的回调记录
 func (r *notificationRepository) CreateWithCallbackLog(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
-	// 扣减额度
-	err := r.quotaCache.Decr(ctx, notification.BizID, notification.Channel, defaultQuotaNumber)
+	entity, err := r.toEntity(ctx, notification)
 	if err != nil {
 		return domain.Notification{}, err
 	}
-	ds, err := r.dao.CreateWithCallbackLog(ctx, r.toEntity(notification))
+	if r.reserve {
+		return r.createReserved(ctx, notification, entity, r.dao.CreateWithCallbackLog)
+	}
+	if err = r.decrQuota(ctx, notification); err != nil {
+		return domain.Notification{}, err
+	}
+	ds, err := r.dao.CreateWithCallbackLog(ctx, entity)
 	if err != nil {
-		qerr := r.quotaCache.Incr(ctx, notification.BizID, notification.Channel, defaultQuotaNumber)
-		if qerr != nil {
-			r.logger.Error("额度归还失败", zap.Any("error", err),
-				zap.Int64("biz_id", notification.BizID),
-				zap.String("channel", notification.Channel.String()),
-			)
-		}
+		r.returnQuota(ctx, notification)
 		return domain.Notification{}, err
 	}
-	return r.toDomain(ds), nil
+	return r.toDomain(ctx, ds)
 }
 
 // BatchCreate 批量创建通知记录，但不创建对应的回调记录
@@ -167,7 +414,29 @@ func (r *notificationRepository) batchCreate(ctx context.Context, notifications
 
 	var daoNotifications []dao.Notification
 	for i := range notifications {
-		daoNotifications = append(daoNotifications, r.toEntity(notifications[i]))
+		entity, err := r.toEntity(ctx, notifications[i])
+		if err != nil {
+			return nil, err
+		}
+		daoNotifications = append(daoNotifications, entity)
+	}
+
+	if r.reserve {
+		create := r.dao.BatchCreate
+		if createCallbackLog {
+			create = r.dao.BatchCreateWithCallbackLog
+		}
+		reservation, recorded, err := r.reserveQuota(ctx, notifications)
+		if err != nil {
+			return nil, err
+		}
+		createdNotifications, err := create(ctx, daoNotifications)
+		if err != nil {
+			r.refundReservation(ctx, reservation, recorded)
+			return nil, err
+		}
+		r.commitReservation(ctx, reservation, recorded)
+		return r.toDomains(ctx, createdNotifications)
 	}
 
 	var createdNotifications []dao.Notification
@@ -182,7 +451,7 @@ func (r *notificationRepository) batchCreate(ctx context.Context, notifications
 		if err != nil {
 			eerr := r.mutiIncr(ctx, notifications)
 			if eerr != nil {
-				r.logger.Error("发送失败，归还额度失败", zap.Any("error", eerr))
+				r.logger.WithContext(ctx).Error("发送失败，归还额度失败", zap.Any("error", eerr))
 			}
 			return nil, err
 		}
@@ -191,16 +460,12 @@ func (r *notificationRepository) batchCreate(ctx context.Context, notifications
 		if err != nil {
 			eerr := r.mutiIncr(ctx, notifications)
 			if eerr != nil {
-				r.logger.Error("发送失败，归还额度失败", zap.Any("error", eerr))
+				r.logger.WithContext(ctx).Error("发送失败，归还额度失败", zap.Any("error", eerr))
 			}
 			return nil, err
 		}
 	}
-	var ans []domain.Notification
-	for i := range createdNotifications {
-		ans = append(ans, r.toDomain(createdNotifications[i]))
-	}
-	return ans, nil
+	return r.toDomains(ctx, createdNotifications)
 }
 
 func (r *notificationRepository) mutiDecr(ctx context.Context, notifications []domain.Notification) error {
@@ -215,12 +480,18 @@ func (r *notificationRepository) getItems(notifications []domain.Notification) [
 	notiMap := make(map[string]cache.IncrItem)
 	for idx := range notifications {
 		d := notifications[idx]
-		key := fmt.Sprintf("%d-%s", d.BizID, d.Channel.String())
+		if d.IsSandbox() {
+			continue
+		}
+		// 归还时按创建的月份抵扣透支，不同月份创建的通知分开归还
+		key := fmt.Sprintf("%d-%s-%s-%s", d.BizID, d.Channel.String(), d.Category.String(), d.Ctime.Format("200601"))
 		item, ok := notiMap[key]
 		if !ok {
 			item = cache.IncrItem{
-				BizID:   d.BizID,
-				Channel: d.Channel,
+				BizID:    d.BizID,
+				Channel:  d.Channel,
+				Category: d.Category,
+				Ctime:    d.Ctime,
 			}
 		}
 		item.Val++
@@ -244,7 +515,7 @@ func (r *notificationRepository) GetByID(ctx context.Context, id uint64) (domain
 	if err != nil {
 		return domain.Notification{}, err
 	}
-	return r.toDomain(n), nil
+	return r.toDomain(ctx, n)
 }
 
 func (r *notificationRepository) BatchGetByIDs(ctx context.Context, ids []uint64) (map[uint64]domain.Notification, error) {
@@ -256,14 +527,20 @@ func (r *notificationRepository) BatchGetByIDs(ctx context.Context, ids []uint64
 	domainNotificationMap := make(map[uint64]domain.Notification, len(notificationMap))
 	for id := range notificationMap {
 		notification := notificationMap[id]
-		domainNotificationMap[id] = r.toDomain(notification)
+		domainNotificationMap[id], err = r.toDomain(ctx, notification)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return domainNotificationMap, nil
 }
 
 func (r *notificationRepository) GetByKey(ctx context.Context, bizID int64, key string) (domain.Notification, error) {
 	not, err := r.dao.GetByKey(ctx, bizID, key)
-	return r.toDomain(not), err
+	if err != nil {
+		return domain.Notification{}, err
+	}
+	return r.toDomain(ctx, not)
 }
 
 // GetByKeys 根据业务ID和业务内唯一标识获取通知列表
@@ -272,70 +549,294 @@ func (r *notificationRepository) GetByKeys(ctx context.Context, bizID int64, key
 	if err != nil {
 		return nil, fmt.Errorf("查询通知列表失败: %w", err)
 	}
-	result := make([]domain.Notification, len(notifications))
+	return r.toDomains(ctx, notifications)
+}
+
+// FindByReceiver 根据接收者查询通知，接收者加密存储，通过盲索引匹配
+func (r *notificationRepository) FindByReceiver(ctx context.Context, bizID int64, receiver string, limit int) ([]domain.Notification, error) {
+	notifications, err := r.dao.FindByReceiverIndex(ctx, bizID, r.indexer.Index(receiver), limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(ctx, notifications)
+}
+
+func (r *notificationRepository) ListByBiz(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, error) {
+	notifications, err := r.dao.ListByBiz(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(ctx, notifications)
+}
+
+func (r *notificationRepository) ListForExport(ctx context.Context, query domain.NotificationExportQuery,
+	after domain.ExportCursor, limit int,
+) ([]domain.ExportedNotification, error) {
+	notifications, err := r.dao.ListForExport(ctx, query.BizID,
+		query.Start.UnixMilli(), query.End.UnixMilli(), after, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.ExportedNotification, 0, len(notifications))
 	for i := range notifications {
-		result[i] = r.toDomain(notifications[i])
+		n, err := r.toDomain(ctx, notifications[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, domain.ExportedNotification{
+			Notification: n,
+			Ctime:        notifications[i].Ctime,
+			Utime:        notifications[i].Utime,
+		})
+	}
+	return result, nil
+}
+
+func (r *notificationRepository) FindFallbackGroups(ctx context.Context, bizID int64, groups ...string) (map[string]domain.FallbackGroup, error) {
+	notifications, err := r.dao.FindByFallbackGroups(ctx, bizID, groups)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]domain.FallbackGroup, len(groups))
+	for _, n := range notifications {
+		g := result[n.FallbackGroup]
+		g.BizID, g.Group = bizID, n.FallbackGroup
+		// 只查了状态相关的列，不需要解密
+		g.Members = append(g.Members, domain.Notification{
+			ID:            n.ID,
+			BizID:         n.BizID,
+			Key:           n.Key,
+			Channel:       domain.Channel(n.Channel),
+			Status:        domain.SendStatus(n.Status),
+			Version:       n.Version,
+			FailReason:    domain.FailReason(n.FailReason),
+			Labels:        labelsFromEntity(n.Labels),
+			Environment:   domain.Environment(n.Environment),
+			FallbackGroup: n.FallbackGroup,
+			BizPayload:    n.BizPayload,
+		})
+		result[n.FallbackGroup] = g
 	}
 	return result, nil
 }
 
 // CASStatus 更新通知状态
 func (r *notificationRepository) CASStatus(ctx context.Context, notification domain.Notification) error {
-	return r.dao.CASStatus(ctx, r.toEntity(notification))
+	return r.dao.CASStatus(ctx, r.toStateEntity(notification))
 }
 
 func (r *notificationRepository) UpdateStatus(ctx context.Context, notification domain.Notification) error {
-	return r.dao.UpdateStatus(ctx, r.toEntity(notification))
+	return r.dao.UpdateStatus(ctx, r.toStateEntity(notification))
+}
+
+// CancelPending 取消待发送的通知，并归还额度
+func (r *notificationRepository) CancelPending(ctx context.Context, notification domain.Notification) error {
+	err := r.dao.CancelPending(ctx, r.toStateEntity(notification))
+	if err != nil || notification.IsSandbox() {
+		return err
+	}
+	err = r.quotaCache.MutiIncr(ctx, r.getItems([]domain.Notification{notification}))
+	if err != nil {
+		r.logger.WithContext(ctx).Error("取消通知，归还额度失败", zap.Error(err),
+			zap.Uint64("notification_id", notification.ID),
+			zap.Int64("biz_id", notification.BizID),
+			zap.String("channel", notification.Channel.String()),
+		)
+	}
+	return nil
+}
+
+// UpdatePending 修改待发送的通知并记录审计日志，返回修改后的通知
+func (r *notificationRepository) UpdatePending(ctx context.Context, notification domain.Notification, auditLog domain.NotificationAuditLog) (domain.Notification, error) {
+	entity, err := r.toEntity(ctx, notification)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+	// 快照里有接收者和模板参数，和通知一样加密存储
+	before, err := r.encryptSnapshot(ctx, auditLog.Before)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+	after, err := r.encryptSnapshot(ctx, auditLog.After)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+	updated, err := r.dao.UpdatePending(ctx, entity, dao.NotificationAuditLog{
+		NotificationID: auditLog.NotificationID,
+		BizID:          auditLog.BizID,
+		Action:         auditLog.Action.String(),
+		Operator:       auditLog.Operator,
+		Before:         before,
+		After:          after,
+	})
+	if err != nil {
+		return domain.Notification{}, err
+	}
+	return r.toDomain(ctx, updated)
+}
+
+func (r *notificationRepository) encryptSnapshot(ctx context.Context, snapshot domain.NotificationSnapshot) (string, error) {
+	data, err := snapshot.Marshal()
+	if err != nil {
+		return "", err
+	}
+	data, err = r.cipher.Encrypt(ctx, data)
+	if err != nil {
+		return "", fmt.Errorf("加密审计快照失败: %w", err)
+	}
+	return data, nil
+}
+
+func (r *notificationRepository) Reschedule(ctx context.Context, notification domain.Notification) error {
+	return r.dao.Reschedule(ctx, r.toStateEntity(notification))
+}
+
+func (r *notificationRepository) ScheduleRetry(ctx context.Context, notification domain.Notification) error {
+	return r.dao.ScheduleRetry(ctx, r.toStateEntity(notification))
+}
+
+// EraseReceiver 从通知中擦除指定接收者，通知因此被取消时归还额度
+func (r *notificationRepository) EraseReceiver(ctx context.Context, notification domain.Notification, receiver string) error {
+	index := r.indexer.Index(receiver)
+	canceled := notification.EraseReceivers(func(rc string) bool {
+		return r.indexer.Index(rc) == index
+	})
+	entity, err := r.toEntity(ctx, notification)
+	if err != nil {
+		return err
+	}
+	if len(notification.Receivers) == 0 {
+		entity.EraseTime = r.clock.Now().UnixMilli()
+	}
+	if err = r.dao.EraseReceiver(ctx, entity); err != nil {
+		return err
+	}
+	if !canceled || notification.IsSandbox() {
+		return nil
+	}
+	// 按创建的月份归还，抵扣扣减那个月的透支
+	err = r.quotaCache.MutiIncr(ctx, r.getItems([]domain.Notification{notification}))
+	if err != nil {
+		r.logger.WithContext(ctx).Error("擦除接收者取消通知，归还额度失败", zap.Error(err),
+			zap.Uint64("notification_id", notification.ID),
+			zap.Int64("biz_id", notification.BizID),
+			zap.String("channel", notification.Channel.String()),
+		)
+	}
+	return nil
 }
 
 // BatchUpdateStatusSucceededOrFailed 批量更新通知状态为成功或失败
-func (r *notificationRepository) BatchUpdateStatusSucceededOrFailed(ctx context.Context, succeededNotifications, failedNotifications []domain.Notification) error {
+// 只给真正更新为失败的通知归还额度，版本号不匹配的通知已经被其他实例处理过了
+func (r *notificationRepository) BatchUpdateStatusSucceededOrFailed(ctx context.Context, succeededNotifications, failedNotifications []domain.Notification) ([]uint64, error) {
 	// 转换成功的通知为DAO层的实体
 	successItems := make([]dao.Notification, len(succeededNotifications))
 	for i := range succeededNotifications {
-		successItems[i] = r.toEntity(succeededNotifications[i])
+		successItems[i] = r.toStateEntity(succeededNotifications[i])
 	}
 
 	// 转换失败的通知为DAO层的实体
 	failedItems := make([]dao.Notification, len(failedNotifications))
 	for i := range failedNotifications {
-		failedItems[i] = r.toEntity(failedNotifications[i])
+		failedItems[i] = r.toStateEntity(failedNotifications[i])
 	}
 
-	err := r.dao.BatchUpdateStatusSucceededOrFailed(ctx, successItems, failedItems)
+	conflicted, err := r.dao.BatchUpdateStatusSucceededOrFailed(ctx, successItems, failedItems)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	items := r.getItems(failedNotifications)
-	eerr := r.quotaCache.MutiIncr(ctx, items)
-	if eerr != nil {
-		r.logger.Error("发送失败，归还额度失败", zap.Any("error", eerr))
+	failed := failedNotifications
+	if len(conflicted) > 0 {
+		lost := make(map[uint64]struct{}, len(conflicted))
+		for _, id := range conflicted {
+			lost[id] = struct{}{}
+		}
+		failed = make([]domain.Notification, 0, len(failedNotifications))
+		for _, n := range failedNotifications {
+			if _, ok := lost[n.ID]; !ok {
+				failed = append(failed, n)
+			}
+		}
 	}
-	return nil
+	if len(failed) > 0 {
+		eerr := r.quotaCache.MutiIncr(ctx, r.getItems(failed))
+		if eerr != nil {
+			r.logger.WithContext(ctx).Error("发送失败，归还额度失败", zap.Any("error", eerr))
+		}
+	}
+	return conflicted, nil
 }
 
-func (r *notificationRepository) FindReadyNotifications(ctx context.Context, offset, limit int) ([]domain.Notification, error) {
-	nos, err := r.dao.FindReadyNotifications(ctx, offset, limit)
-	var ans []domain.Notification
-	for i := range nos {
-		ans = append(ans, r.toDomain(nos[i]))
+func (r *notificationRepository) FindReadyNotifications(ctx context.Context, partition domain.Partition, afterID uint64, limit int) ([]domain.Notification, error) {
+	nos, err := r.dao.FindReadyNotifications(ctx, partition, afterID, limit)
+	if err != nil {
+		return nil, err
 	}
-	return ans, err
+	return r.toDomains(ctx, nos)
 }
 
 func (r *notificationRepository) MarkSuccess(ctx context.Context, notification domain.Notification) error {
-	return r.dao.MarkSuccess(ctx, r.toEntity(notification))
+	affected, err := r.dao.MarkSuccess(ctx, r.toStateEntity(notification))
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return fmt.Errorf("%w, id %d", domain.ErrNotificationVersionMismatch, notification.ID)
+	}
+	return nil
 }
 
 func (r *notificationRepository) MarkFailed(ctx context.Context, notification domain.Notification) error {
-	err := r.dao.MarkFailed(ctx, r.toEntity(notification))
+	affected, err := r.dao.MarkFailed(ctx, r.toStateEntity(notification))
 	if err != nil {
 		return err
 	}
-	return r.quotaCache.Incr(ctx, notification.BizID, notification.Channel, defaultQuotaNumber)
+	// 只有真正把这条通知标记为失败的调用方归还额度，超时清理已经处理过的不能重复归还
+	if affected != 1 {
+		return fmt.Errorf("%w, id %d", domain.ErrNotificationVersionMismatch, notification.ID)
+	}
+	return r.quotaCache.MutiIncr(ctx, r.getItems([]domain.Notification{notification}))
 }
 
-func (r *notificationRepository) MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) (int64, error) {
-	return r.dao.MarkTimeoutSendingAsFailed(ctx, batchSize)
+func (r *notificationRepository) MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) ([]domain.Notification, error) {
+	timeout, err := r.dao.MarkTimeoutSendingAsFailed(ctx, batchSize)
+	if err != nil || len(timeout) == 0 {
+		return nil, err
+	}
+	result := r.toFailedDomains(timeout, domain.FailReasonSendTimeout)
+	if eerr := r.quotaCache.MutiIncr(ctx, r.getItems(result)); eerr != nil {
+		r.logger.WithContext(ctx).Error("通知发送超时，归还额度失败", zap.Error(eerr), zap.Int("count", len(result)))
+	}
+	return result, nil
+}
+
+func (r *notificationRepository) MarkExpiredAsFailed(ctx context.Context, batchSize int) ([]domain.Notification, error) {
+	expired, err := r.dao.MarkExpiredAsFailed(ctx, batchSize)
+	if err != nil || len(expired) == 0 {
+		return nil, err
+	}
+	result := r.toFailedDomains(expired, domain.FailReasonExpired)
+	if eerr := r.quotaCache.MutiIncr(ctx, r.getItems(result)); eerr != nil {
+		r.logger.WithContext(ctx).Error("通知过期，归还额度失败", zap.Error(eerr), zap.Int("count", len(result)))
+	}
+	return result, nil
+}
+
+// toFailedDomains 批量标记为失败的通知，DAO 只查询了ID、业务方、渠道和创建时间
+func (r *notificationRepository) toFailedDomains(notifications []dao.Notification, reason domain.FailReason) []domain.Notification {
+	result := make([]domain.Notification, 0, len(notifications))
+	for i := range notifications {
+		result = append(result, domain.Notification{
+			ID:          notifications[i].ID,
+			BizID:       notifications[i].BizID,
+			Key:         notifications[i].Key,
+			Channel:     domain.Channel(notifications[i].Channel),
+			Status:      domain.SendStatusFailed,
+			FailReason:  reason,
+			Environment: domain.Environment(notifications[i].Environment),
+			Ctime:       timeFromMilli(notifications[i].Ctime),
+		})
+	}
+	return result
 }
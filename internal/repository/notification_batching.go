@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCreateBatchWait     = 2 * time.Millisecond
+	defaultCreateBatchMaxSize  = 100
+	defaultCreateBatchTimeout  = 3 * time.Second
+	createBatchResultBatched   = "batched"
+	createBatchResultFallback  = "fallback"
+	createBatchResultSingleton = "single"
+)
+
+var (
+	createBatchSizeHistogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "notification_create_batch_size",
+		Help:    "Number of single notification creates merged into one batch insert",
+		Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200},
+	})
+	createBatchCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_create_batches_total",
+		Help: "Total number of merged notification create batches, by how they were written",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(createBatchSizeHistogram, createBatchCounter)
+}
+
+// CreateBatchOptions 合并单条创建的参数，零值使用默认值
+type CreateBatchOptions struct {
+	// Wait 收集一批的等待时间，也是单条创建最多多等的时间，默认 2ms
+	Wait time.Duration
+	// MaxSize 一批最多多少条，满了立刻写入，默认 100
+	MaxSize int
+	// Timeout 写入一批的超时时间，默认 3s
+	Timeout time.Duration
+}
+
+func (o CreateBatchOptions) withDefaults() CreateBatchOptions {
+	if o.Wait <= 0 {
+		o.Wait = defaultCreateBatchWait
+	}
+	if o.MaxSize <= 0 {
+		o.MaxSize = defaultCreateBatchMaxSize
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaultCreateBatchTimeout
+	}
+	return o
+}
+
+var _ NotificationRepository = (*batchingNotificationRepository)(nil)
+
+// NewBatchingNotificationRepository 把并发的单条 Create、CreateWithCallbackLog 在 Wait 内合并成一次批量插入
+// 批量插入在同一个事务里，一条失败（重复、额度不足等）整批回滚，这时并发逐条重新创建，每个调用方拿到的都是自己那条的结果
+// 一批在第一个调用方的 ctx 上执行，但不跟随它取消，只受 Timeout 限制，逐条重新创建时每条重新计算 Timeout；
+// 调用方取消时直接返回，通知可能已经创建
+// featureflag.CreateBatch 没有对其开启的业务方直接单条创建，flags 为 nil 时对所有业务方开启
+func NewBatchingNotificationRepository(repo NotificationRepository, opts CreateBatchOptions,
+	flags *featureflag.Flags,
+) NotificationRepository {
+	opts = opts.withDefaults()
+	logger := log.Named(log.DefaultLogger(), "repository.notification.batching")
+	return &batchingNotificationRepository{
+		NotificationRepository: repo,
+		flags:                  flags,
+		plain:                  newCreateBatcher(opts, logger, repo.Create, repo.BatchCreate),
+		withCallbackLog:        newCreateBatcher(opts, logger, repo.CreateWithCallbackLog, repo.BatchCreateWithCallbackLog),
+	}
+}
+
+type batchingNotificationRepository struct {
+	NotificationRepository
+	flags           *featureflag.Flags
+	plain           *createBatcher
+	withCallbackLog *createBatcher
+}
+
+func (r *batchingNotificationRepository) Create(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	if !r.flags.Enabled(featureflag.CreateBatch, notification.BizID) {
+		return r.NotificationRepository.Create(ctx, notification)
+	}
+	return r.plain.create(ctx, notification)
+}
+
+func (r *batchingNotificationRepository) CreateWithCallbackLog(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	if !r.flags.Enabled(featureflag.CreateBatch, notification.BizID) {
+		return r.NotificationRepository.CreateWithCallbackLog(ctx, notification)
+	}
+	return r.withCallbackLog.create(ctx, notification)
+}
+
+type createFunc func(ctx context.Context, notification domain.Notification) (domain.Notification, error)
+
+type batchCreateFunc func(ctx context.Context, notifications []domain.Notification) ([]domain.Notification, error)
+
+// createBatcher 收集单条创建，等待时间到了或者满了就一起写入
+type createBatcher struct {
+	opts          CreateBatchOptions
+	logger        log.LoggerInterface
+	createFn      createFunc
+	batchCreateFn batchCreateFunc
+
+	mu    sync.Mutex
+	batch *createBatch
+}
+
+type createBatch struct {
+	ctx     context.Context
+	items   []domain.Notification
+	results []*createResult
+}
+
+type createResult struct {
+	done         chan struct{}
+	notification domain.Notification
+	err          error
+}
+
+func newCreateBatcher(opts CreateBatchOptions, logger log.LoggerInterface, create createFunc, batchCreate batchCreateFunc) *createBatcher {
+	return &createBatcher{opts: opts, logger: logger, createFn: create, batchCreateFn: batchCreate}
+}
+
+func (b *createBatcher) create(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	r := &createResult{done: make(chan struct{})}
+	b.mu.Lock()
+	if b.batch == nil {
+		b.batch = &createBatch{ctx: context.WithoutCancel(ctx)}
+		batch := b.batch
+		time.AfterFunc(b.opts.Wait, func() { b.dispatch(batch) })
+	}
+	b.batch.items = append(b.batch.items, notification)
+	b.batch.results = append(b.batch.results, r)
+	if len(b.batch.items) >= b.opts.MaxSize {
+		batch := b.batch
+		b.batch = nil
+		go b.run(batch)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.notification, r.err
+	case <-ctx.Done():
+		return domain.Notification{}, ctx.Err()
+	}
+}
+
+// dispatch 等待时间到了，如果这一批还没有因为满了被写入，就写入它
+func (b *createBatcher) dispatch(batch *createBatch) {
+	b.mu.Lock()
+	if b.batch != batch {
+		b.mu.Unlock()
+		return
+	}
+	b.batch = nil
+	b.mu.Unlock()
+	b.run(batch)
+}
+
+func (b *createBatcher) run(batch *createBatch) {
+	createBatchSizeHistogram.Observe(float64(len(batch.items)))
+	if len(batch.items) == 1 {
+		createBatchCounter.WithLabelValues(createBatchResultSingleton).Inc()
+		b.createOne(batch, 0)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(batch.ctx, b.opts.Timeout)
+	defer cancel()
+	created, err := b.batchCreateFn(ctx, batch.items)
+	if err == nil {
+		createBatchCounter.WithLabelValues(createBatchResultBatched).Inc()
+		for i, r := range batch.results {
+			r.notification = created[i]
+			close(r.done)
+		}
+		return
+	}
+	// 整批已经回滚，并发逐条创建，把各自的错误返回给各自的调用方
+	// 批量插入可能已经用完了 Timeout（例如超时失败），每条重新计算，不能让后面的调用方直接超时
+	createBatchCounter.WithLabelValues(createBatchResultFallback).Inc()
+	b.logger.WithContext(ctx).Info("合并创建通知失败，逐条创建",
+		zap.Int("size", len(batch.items)), zap.Error(err))
+	var wg sync.WaitGroup
+	for i := range batch.items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.createOne(batch, i)
+		}()
+	}
+	wg.Wait()
+}
+
+func (b *createBatcher) createOne(batch *createBatch, i int) {
+	ctx, cancel := context.WithTimeout(batch.ctx, b.opts.Timeout)
+	defer cancel()
+	r := batch.results[i]
+	r.notification, r.err = b.createFn(ctx, batch.items[i])
+	close(r.done)
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// 并发的单条创建合并成一次批量插入，满了立刻写入，每个调用方拿到自己那条
+func TestBatchingNotificationRepository_MergesUntilMaxSize(t *testing.T) {
+	repo := &batchCreateRepo{}
+	// 等待时间足够长，只有满了才会写入
+	r := NewBatchingNotificationRepository(repo, CreateBatchOptions{Wait: time.Hour, MaxSize: 3}, nil)
+
+	results := make([]domain.Notification, 3)
+	errs := make([]error, 3)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = r.Create(context.Background(), domain.Notification{Key: fmt.Sprintf("key-%d", i)})
+		}()
+	}
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("第 %d 个调用方返回 %v", i, errs[i])
+		}
+		if want := fmt.Sprintf("key-%d", i); results[i].Key != want || results[i].ID == 0 {
+			t.Fatalf("第 %d 个调用方拿到 %+v, 应该是 %s 创建后的结果", i, results[i], want)
+		}
+	}
+	if got := repo.batchSizes(); !slices.Equal(got, []int{3}) {
+		t.Fatalf("批量插入了 %v 次, 应该是一次 3 条", got)
+	}
+	if got := repo.singles(); len(got) != 0 {
+		t.Fatalf("合并成功时不应该单条创建 %v", got)
+	}
+}
+
+// 没有满的一批等待时间到了就写入，只有一条时直接单条创建
+func TestBatchingNotificationRepository_FlushesAfterWait(t *testing.T) {
+	repo := &batchCreateRepo{}
+	r := NewBatchingNotificationRepository(repo, CreateBatchOptions{Wait: 10 * time.Millisecond, MaxSize: 100}, nil)
+
+	got, err := r.Create(context.Background(), domain.Notification{Key: "key-0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Key != "key-0" || got.ID == 0 {
+		t.Fatalf("拿到 %+v", got)
+	}
+	if sizes := repo.batchSizes(); len(sizes) != 0 {
+		t.Fatalf("只有一条时不应该批量插入 %v", sizes)
+	}
+	if singles := repo.singles(); !slices.Equal(singles, []string{"key-0"}) {
+		t.Fatalf("单条创建了 %v", singles)
+	}
+}
+
+// 整批失败之后逐条创建，一条的错误只返回给它自己的调用方；批量插入超时之后逐条创建重新计算超时
+func TestBatchingNotificationRepository_FallbackIsolatesErrors(t *testing.T) {
+	errDuplicate := fmt.Errorf("%w: key-1", domain.ErrNotificationDuplicate)
+	repo := &batchCreateRepo{
+		// 批量插入一直等到超时
+		batchErr:  context.DeadlineExceeded,
+		blockBulk: true,
+		createErr: map[string]error{"key-1": errDuplicate},
+	}
+	r := NewBatchingNotificationRepository(repo,
+		CreateBatchOptions{Wait: time.Hour, MaxSize: 3, Timeout: 50 * time.Millisecond}, nil)
+
+	results := make([]domain.Notification, 3)
+	errs := make([]error, 3)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = r.Create(context.Background(), domain.Notification{Key: fmt.Sprintf("key-%d", i)})
+		}()
+	}
+	wg.Wait()
+
+	for i := range results {
+		if i == 1 {
+			if !errors.Is(errs[i], domain.ErrNotificationDuplicate) {
+				t.Fatalf("重复的通知返回 %v", errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil || results[i].Key != fmt.Sprintf("key-%d", i) {
+			t.Fatalf("第 %d 个调用方拿到 %+v, %v", i, results[i], errs[i])
+		}
+	}
+	singles := repo.singles()
+	slices.Sort(singles)
+	if !slices.Equal(singles, []string{"key-0", "key-1", "key-2"}) {
+		t.Fatalf("逐条创建了 %v", singles)
+	}
+	if expired := repo.expiredSingles(); expired != 0 {
+		t.Fatalf("%d 条逐条创建拿到的是已经超时的 ctx", expired)
+	}
+}
+
+// 调用方取消时直接返回，这一批不跟随取消，照常写入
+func TestBatchingNotificationRepository_CallerCancel(t *testing.T) {
+	repo := &batchCreateRepo{}
+	r := NewBatchingNotificationRepository(repo, CreateBatchOptions{Wait: time.Hour, MaxSize: 2}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := r.Create(ctx, domain.Notification{Key: "key-0"})
+		canceled <- err
+	}()
+	// 等第一条进入这一批之后再取消
+	deadline := time.Now().Add(5 * time.Second)
+	for r.(*batchingNotificationRepository).plain.pending() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("第一条没有进入这一批")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case err := <-canceled:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("取消的调用方返回 %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("取消的调用方没有返回")
+	}
+
+	got, err := r.Create(context.Background(), domain.Notification{Key: "key-1"})
+	if err != nil || got.Key != "key-1" {
+		t.Fatalf("拿到 %+v, %v", got, err)
+	}
+	if sizes := repo.batchSizes(); !slices.Equal(sizes, []int{2}) {
+		t.Fatalf("批量插入了 %v, 取消的那条也应该写入", sizes)
+	}
+	if repo.canceledBulk() {
+		t.Fatal("调用方取消不应该取消这一批")
+	}
+}
+
+// batchCreateRepo 记录单条和批量创建，创建成功时按调用顺序分配ID
+type batchCreateRepo struct {
+	NotificationRepository
+	batchErr  error
+	blockBulk bool
+	createErr map[string]error
+
+	mu       sync.Mutex
+	nextID   uint64
+	sizes    []int
+	created  []string
+	expired  int
+	canceled bool
+}
+
+func (r *batchCreateRepo) Create(ctx context.Context, n domain.Notification) (domain.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.created = append(r.created, n.Key)
+	if ctx.Err() != nil {
+		r.expired++
+		return domain.Notification{}, ctx.Err()
+	}
+	if err := r.createErr[n.Key]; err != nil {
+		return domain.Notification{}, err
+	}
+	r.nextID++
+	n.ID = r.nextID
+	return n, nil
+}
+
+func (r *batchCreateRepo) BatchCreate(ctx context.Context, ns []domain.Notification) ([]domain.Notification, error) {
+	if r.blockBulk {
+		<-ctx.Done()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sizes = append(r.sizes, len(ns))
+	r.canceled = r.canceled || errors.Is(ctx.Err(), context.Canceled)
+	if r.batchErr != nil {
+		return nil, r.batchErr
+	}
+	res := make([]domain.Notification, 0, len(ns))
+	for _, n := range ns {
+		r.nextID++
+		n.ID = r.nextID
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+func (r *batchCreateRepo) batchSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.sizes)
+}
+
+func (r *batchCreateRepo) singles() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.created)
+}
+
+func (r *batchCreateRepo) expiredSingles() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.expired
+}
+
+func (r *batchCreateRepo) canceledBulk() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.canceled
+}
+
+// pending 正在收集的这一批有多少条
+func (b *createBatcher) pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.batch == nil {
+		return 0
+	}
+	return len(b.batch.items)
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"go.uber.org/zap"
+)
+
+// 创建前预判重复的结果
+const (
+	// duplicatePrecheckMiss 过滤器判断一定不存在，直接插入
+	duplicatePrecheckMiss = "miss"
+	// duplicatePrecheckHit 过滤器判断可能存在，数据库里确实存在，没有插入
+	duplicatePrecheckHit = "hit"
+	// duplicatePrecheckFalsePositive 过滤器判断可能存在，数据库里不存在，继续插入
+	duplicatePrecheckFalsePositive = "false_positive"
+	// duplicatePrecheckError 访问过滤器或者数据库出错，直接插入，由唯一索引兜底
+	duplicatePrecheckError = "error"
+)
+
+var duplicatePrecheckCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_duplicate_precheck_total",
+	Help: "Total number of notification keys pre-checked for duplicates before insert, by result",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(duplicatePrecheckCounter)
+}
+
+var _ NotificationRepository = (*duplicateCheckNotificationRepository)(nil)
+
+// NewDuplicateCheckNotificationRepository 创建通知之前先用 keys 预判 (bizID, key) 是否已经创建过
+// 可能存在时查数据库确认，确实存在直接返回 domain.ErrNotificationDuplicate，不扣额度也不插入
+// 过滤器误判或者出错都继续插入，最终以数据库的唯一索引为准
+func NewDuplicateCheckNotificationRepository(repo NotificationRepository, keys cache.NotificationKeyCache) NotificationRepository {
+	return &duplicateCheckNotificationRepository{
+		NotificationRepository: repo,
+		keys:                   keys,
+		logger:                 log.Named(log.DefaultLogger(), "repository.notification.duplicate"),
+	}
+}
+
+type duplicateCheckNotificationRepository struct {
+	NotificationRepository
+	keys   cache.NotificationKeyCache
+	logger log.LoggerInterface
+}
+
+func (r *duplicateCheckNotificationRepository) Create(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	return r.create(ctx, notification, r.NotificationRepository.Create)
+}
+
+func (r *duplicateCheckNotificationRepository) CreateWithCallbackLog(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	return r.create(ctx, notification, r.NotificationRepository.CreateWithCallbackLog)
+}
+
+func (r *duplicateCheckNotificationRepository) BatchCreate(ctx context.Context, notifications []domain.Notification) ([]domain.Notification, error) {
+	return r.batchCreate(ctx, notifications, r.NotificationRepository.BatchCreate)
+}
+
+func (r *duplicateCheckNotificationRepository) BatchCreateWithCallbackLog(ctx context.Context, notifications []domain.Notification) ([]domain.Notification, error) {
+	return r.batchCreate(ctx, notifications, r.NotificationRepository.BatchCreateWithCallbackLog)
+}
+
+func (r *duplicateCheckNotificationRepository) create(ctx context.Context, notification domain.Notification,
+	create func(ctx context.Context, notification domain.Notification) (domain.Notification, error),
+) (domain.Notification, error) {
+	if r.exists(ctx, notification.BizID, notification.Key)[notification.Key] {
+		return domain.Notification{}, fmt.Errorf("%w: bizID = %d, key = %s",
+			domain.ErrNotificationDuplicate, notification.BizID, notification.Key)
+	}
+	created, err := create(ctx, notification)
+	// 唯一索引冲突说明 key 已经存在，只是不在过滤器里（比如已经过期），同样记下来
+	if err == nil || errors.Is(err, domain.ErrNotificationDuplicate) {
+		r.add(ctx, notification.BizID, notification.Key)
+	}
+	return created, err
+}
+
+// batchCreate 批量插入在同一个事务里，有一条已经存在整批都会失败，预判出来就不必再访问数据库
+func (r *duplicateCheckNotificationRepository) batchCreate(ctx context.Context, notifications []domain.Notification,
+	create func(ctx context.Context, notifications []domain.Notification) ([]domain.Notification, error),
+) ([]domain.Notification, error) {
+	groups := groupKeysByBiz(notifications)
+	for bizID, keys := range groups {
+		for key, ok := range r.exists(ctx, bizID, keys...) {
+			if ok {
+				return nil, fmt.Errorf("%w: bizID = %d, key = %s", domain.ErrNotificationDuplicate, bizID, key)
+			}
+		}
+	}
+	created, err := create(ctx, notifications)
+	if err == nil {
+		for bizID, keys := range groups {
+			r.add(ctx, bizID, keys...)
+		}
+	}
+	return created, err
+}
+
+func groupKeysByBiz(notifications []domain.Notification) map[int64][]string {
+	groups := make(map[int64][]string)
+	for i := range notifications {
+		groups[notifications[i].BizID] = append(groups[notifications[i].BizID], notifications[i].Key)
+	}
+	return groups
+}
+
+// exists 返回数据库里确认已经存在的 key，出错按不存在处理
+func (r *duplicateCheckNotificationRepository) exists(ctx context.Context, bizID int64, keys ...string) map[string]bool {
+	mayExist, err := r.keys.MayExist(ctx, bizID, keys...)
+	if err != nil {
+		r.logger.WithContext(ctx).Warn("预判通知是否重复失败", zap.Int64("biz_id", bizID), zap.Error(err))
+		duplicatePrecheckCounter.WithLabelValues(duplicatePrecheckError).Add(float64(len(keys)))
+		return nil
+	}
+	candidates := make([]string, 0, len(keys))
+	for i, ok := range mayExist {
+		if ok {
+			candidates = append(candidates, keys[i])
+		}
+	}
+	duplicatePrecheckCounter.WithLabelValues(duplicatePrecheckMiss).Add(float64(len(keys) - len(candidates)))
+	if len(candidates) == 0 {
+		return nil
+	}
+	found, err := r.NotificationRepository.GetByKeys(ctx, bizID, candidates...)
+	if err != nil {
+		r.logger.WithContext(ctx).Warn("确认通知是否重复失败", zap.Int64("biz_id", bizID), zap.Error(err))
+		duplicatePrecheckCounter.WithLabelValues(duplicatePrecheckError).Add(float64(len(candidates)))
+		return nil
+	}
+	res := make(map[string]bool, len(found))
+	for i := range found {
+		res[found[i].Key] = true
+	}
+	duplicatePrecheckCounter.WithLabelValues(duplicatePrecheckHit).Add(float64(len(res)))
+	duplicatePrecheckCounter.WithLabelValues(duplicatePrecheckFalsePositive).Add(float64(len(candidates) - len(res)))
+	return res
+}
+
+// add 记录已经创建的 key，失败只记录日志，下次重复创建由唯一索引兜底
+func (r *duplicateCheckNotificationRepository) add(ctx context.Context, bizID int64, keys ...string) {
+	if err := r.keys.Add(ctx, bizID, keys...); err != nil {
+		r.logger.WithContext(ctx).Warn("记录通知 key 失败", zap.Int64("biz_id", bizID), zap.Error(err))
+	}
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// 过滤器只是预判，数据库确认存在才拒绝；过滤器误判、出错都继续插入，由唯一索引兜底
+func TestDuplicateCheckNotificationRepository_Create(t *testing.T) {
+	testCases := []struct {
+		name      string
+		filter    map[string]bool
+		filterErr error
+		stored    map[string]bool
+		getErr    error
+		createErr error
+
+		wantErr     error
+		wantCreated bool
+		wantLookup  bool
+		wantAdded   bool
+	}{
+		{name: "一定不存在", wantCreated: true, wantAdded: true},
+		{name: "误判", filter: map[string]bool{"k": true},
+			wantCreated: true, wantLookup: true, wantAdded: true},
+		{name: "确认重复", filter: map[string]bool{"k": true}, stored: map[string]bool{"k": true},
+			wantErr: domain.ErrNotificationDuplicate, wantLookup: true},
+		{name: "过滤器出错", filterErr: errors.New("redis 不可用"), stored: map[string]bool{"k": true},
+			wantCreated: true, wantAdded: true},
+		{name: "数据库确认出错", filter: map[string]bool{"k": true}, getErr: errors.New("数据库不可用"),
+			wantCreated: true, wantLookup: true, wantAdded: true},
+		// 过滤器里没有（比如已经过期），唯一索引冲突之后补记到过滤器
+		{name: "唯一索引冲突", createErr: domain.ErrNotificationDuplicate,
+			wantErr: domain.ErrNotificationDuplicate, wantCreated: true, wantAdded: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := &fakeKeyCache{mayExist: tc.filter, err: tc.filterErr}
+			repo := &keyedNotificationRepo{stored: tc.stored, getErr: tc.getErr, createErr: tc.createErr}
+			r := NewDuplicateCheckNotificationRepository(repo, keys)
+
+			_, err := r.Create(context.Background(), domain.Notification{BizID: 1, Key: "k"})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("返回 %v, 应该是 %v", err, tc.wantErr)
+			}
+			if got := len(repo.created) > 0; got != tc.wantCreated {
+				t.Fatalf("插入了 %v", repo.created)
+			}
+			if got := len(repo.lookups) > 0; got != tc.wantLookup {
+				t.Fatalf("查询数据库 %v", repo.lookups)
+			}
+			if got := slices.Equal(keys.added, []string{"k"}); got != tc.wantAdded {
+				t.Fatalf("记录到过滤器的 key %v", keys.added)
+			}
+		})
+	}
+}
+
+// 批量插入只查询过滤器判断可能存在的 key，有一条确认重复整批拒绝
+func TestDuplicateCheckNotificationRepository_BatchCreate(t *testing.T) {
+	notifications := []domain.Notification{{BizID: 1, Key: "a"}, {BizID: 1, Key: "b"}, {BizID: 1, Key: "c"}}
+	testCases := []struct {
+		name        string
+		stored      map[string]bool
+		wantErr     error
+		wantCreated []string
+		wantAdded   []string
+	}{
+		{name: "误判", wantCreated: []string{"a", "b", "c"}, wantAdded: []string{"a", "b", "c"}},
+		{name: "确认重复", stored: map[string]bool{"b": true}, wantErr: domain.ErrNotificationDuplicate},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := &fakeKeyCache{mayExist: map[string]bool{"b": true}}
+			repo := &keyedNotificationRepo{stored: tc.stored}
+			r := NewDuplicateCheckNotificationRepository(repo, keys)
+
+			_, err := r.BatchCreate(context.Background(), notifications)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("返回 %v, 应该是 %v", err, tc.wantErr)
+			}
+			if !slices.Equal(repo.lookups, []string{"b"}) {
+				t.Fatalf("查询数据库的 key %v, 应该只查询过滤器判断可能存在的 b", repo.lookups)
+			}
+			if !slices.Equal(repo.created, tc.wantCreated) {
+				t.Fatalf("插入了 %v, 应该是 %v", repo.created, tc.wantCreated)
+			}
+			if !slices.Equal(keys.added, tc.wantAdded) {
+				t.Fatalf("记录到过滤器的 key %v, 应该是 %v", keys.added, tc.wantAdded)
+			}
+		})
+	}
+}
+
+// fakeKeyCache mayExist 里的 key 判断为可能存在，err 不为空时 MayExist 返回 err
+type fakeKeyCache struct {
+	mayExist map[string]bool
+	err      error
+	added    []string
+}
+
+func (c *fakeKeyCache) MayExist(_ context.Context, _ int64, keys ...string) ([]bool, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	res := make([]bool, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, c.mayExist[key])
+	}
+	return res, nil
+}
+
+func (c *fakeKeyCache) Add(_ context.Context, _ int64, keys ...string) error {
+	c.added = append(c.added, keys...)
+	return nil
+}
+
+// keyedNotificationRepo stored 里的 key 已经在数据库里，记录查询和插入的 key
+type keyedNotificationRepo struct {
+	NotificationRepository
+	stored    map[string]bool
+	getErr    error
+	createErr error
+	lookups   []string
+	created   []string
+}
+
+func (r *keyedNotificationRepo) GetByKeys(_ context.Context, bizID int64, keys ...string) ([]domain.Notification, error) {
+	r.lookups = append(r.lookups, keys...)
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	var res []domain.Notification
+	for _, key := range keys {
+		if r.stored[key] {
+			res = append(res, domain.Notification{BizID: bizID, Key: key})
+		}
+	}
+	return res, nil
+}
+
+func (r *keyedNotificationRepo) Create(_ context.Context, n domain.Notification) (domain.Notification, error) {
+	r.created = append(r.created, n.Key)
+	return n, r.createErr
+}
+
+func (r *keyedNotificationRepo) BatchCreate(_ context.Context, ns []domain.Notification) ([]domain.Notification, error) {
+	for _, n := range ns {
+		r.created = append(r.created, n.Key)
+	}
+	return ns, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// 取消成功才归还额度，沙箱通知没有扣过额度也不归还；按创建时间归还，抵扣创建当月的透支
+func TestNotificationRepository_CancelPendingRefund(t *testing.T) {
+	ctime := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		env         domain.Environment
+		cancelErr   error
+		wantErr     error
+		wantRefunds []quotaCall
+	}{
+		{name: "取消成功", env: domain.EnvironmentProduction,
+			wantRefunds: []quotaCall{{bizID: 7, channel: domain.ChannelSMS, val: 1, ctime: ctime}}},
+		{name: "已经被调度", env: domain.EnvironmentProduction,
+			cancelErr: domain.ErrNotificationVersionMismatch, wantErr: domain.ErrNotificationVersionMismatch},
+		{name: "沙箱", env: domain.EnvironmentSandbox},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &stubNotificationDAO{cancelErr: tc.cancelErr}
+			quota := &recordingQuotaCache{}
+			r := NewNotificationRepository(d, quota, nil, nil, clock.Real())
+			n := domain.Notification{ID: 1, BizID: 7, Channel: domain.ChannelSMS, Status: domain.SendStatusPending,
+				Version: 1, Environment: tc.env, Ctime: ctime}
+			if err := r.CancelPending(context.Background(), n); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("返回 %v, 应该是 %v", err, tc.wantErr)
+			}
+			if d.canceled != 1 {
+				t.Fatalf("取消了 %d 次", d.canceled)
+			}
+			if got := quota.incrs(); !slices.Equal(got, tc.wantRefunds) {
+				t.Fatalf("归还了 %v, 应该是 %v", got, tc.wantRefunds)
+			}
+		})
+	}
+}
+
+// 发送失败只在真正把通知从 SENDING 改成 FAILED 时归还额度，超时清理已经归还过的不能再归还
+func TestNotificationRepository_MarkFailedRefundsOnce(t *testing.T) {
+	ctime := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		rows        int64
+		wantErr     error
+		wantRefunds []quotaCall
+	}{
+		{name: "标记成功", rows: 1, wantRefunds: []quotaCall{{bizID: 7, channel: domain.ChannelSMS, val: 1, ctime: ctime}}},
+		{name: "已经被超时清理", rows: 0, wantErr: domain.ErrNotificationVersionMismatch},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &stubNotificationDAO{markFailedRows: tc.rows}
+			quota := &recordingQuotaCache{}
+			r := NewNotificationRepository(d, quota, nil, nil, clock.Real())
+			n := domain.Notification{ID: 1, BizID: 7, Channel: domain.ChannelSMS, Status: domain.SendStatusFailed,
+				Version: 2, Environment: domain.EnvironmentProduction, Ctime: ctime}
+			if err := r.MarkFailed(context.Background(), n); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("返回 %v, 应该是 %v", err, tc.wantErr)
+			}
+			if got := quota.incrs(); !slices.Equal(got, tc.wantRefunds) {
+				t.Fatalf("归还了 %v, 应该是 %v", got, tc.wantRefunds)
+			}
+		})
+	}
+}
+
+// 擦除全部接收者取消通知时按创建的月份归还额度，上个月创建的通知抵扣上个月的透支
+func TestNotificationRepository_EraseReceiverRefund(t *testing.T) {
+	ctime := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		receivers   []string
+		status      domain.SendStatus
+		wantRefunds []quotaCall
+	}{
+		{name: "上个月创建的待发送通知", receivers: []string{"13800000000"}, status: domain.SendStatusPending,
+			wantRefunds: []quotaCall{{bizID: 7, channel: domain.ChannelSMS, val: 1, ctime: ctime}}},
+		{name: "还有其他接收者", receivers: []string{"13800000000", "13900000000"}, status: domain.SendStatusPending},
+		{name: "已经发送", receivers: []string{"13800000000"}, status: domain.SendStatusSucceeded},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &stubNotificationDAO{}
+			quota := &recordingQuotaCache{}
+			r := NewNotificationRepository(d, quota, encrypt.NewNoopCipher(), encrypt.NewHMACBlindIndexer([]byte("key")), clock.Real())
+			n := domain.Notification{ID: 1, BizID: 7, Channel: domain.ChannelSMS, Receivers: tc.receivers,
+				Status: tc.status, Version: 1, Environment: domain.EnvironmentProduction, Ctime: ctime}
+			if err := r.EraseReceiver(context.Background(), n, "13800000000"); err != nil {
+				t.Fatal(err)
+			}
+			if d.erased != 1 {
+				t.Fatalf("擦除了 %d 次", d.erased)
+			}
+			if got := quota.incrs(); !slices.Equal(got, tc.wantRefunds) {
+				t.Fatalf("归还了 %v, 应该是 %v", got, tc.wantRefunds)
+			}
+		})
+	}
+}
+
+type quotaCall struct {
+	bizID   int64
+	channel domain.Channel
+	val     int32
+	ctime   time.Time
+}
+
+// recordingQuotaCache 记录归还的额度，MutiIncr 按元素展开记录
+type recordingQuotaCache struct {
+	cache.QuotaCache
+	mu      sync.Mutex
+	refunds []quotaCall
+}
+
+func (c *recordingQuotaCache) Incr(_ context.Context, bizID int64, channel domain.Channel, quota int32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refunds = append(c.refunds, quotaCall{bizID: bizID, channel: channel, val: quota})
+	return nil
+}
+
+func (c *recordingQuotaCache) MutiIncr(_ context.Context, items []cache.IncrItem) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, item := range items {
+		c.refunds = append(c.refunds, quotaCall{bizID: item.BizID, channel: item.Channel, val: item.Val, ctime: item.Ctime})
+	}
+	return nil
+}
+
+func (c *recordingQuotaCache) incrs() []quotaCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]quotaCall(nil), c.refunds...)
+}
+
+// stubNotificationDAO 只实现测试用到的状态修改
+type stubNotificationDAO struct {
+	dao.NotificationDAO
+	cancelErr      error
+	canceled       int
+	markFailedRows int64
+	erased         int
+}
+
+func (d *stubNotificationDAO) CancelPending(context.Context, dao.Notification) error {
+	d.canceled++
+	return d.cancelErr
+}
+
+func (d *stubNotificationDAO) MarkFailed(context.Context, dao.Notification) (int64, error) {
+	return d.markFailedRows, nil
+}
+
+func (d *stubNotificationDAO) EraseReceiver(context.Context, dao.Notification) error {
+	d.erased++
+	return nil
+}
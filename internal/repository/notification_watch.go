@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"go.uber.org/zap"
+)
+
+var _ NotificationRepository = (*watchedNotificationRepository)(nil)
+
+// NewWatchedNotificationRepository 通知创建和状态变化成功之后发布到 bus，运维观察某个业务 key 时实时收到
+// 超时清理这类只返回数量的批量变化不发布，由观察者定期重新查询兜底；发布失败只记录日志，不影响发送流程
+func NewWatchedNotificationRepository(repo NotificationRepository, bus eventbus.WatchBus) NotificationRepository {
+	return &watchedNotificationRepository{
+		NotificationRepository: repo,
+		bus:                    bus,
+		logger:                 log.Named(log.DefaultLogger(), "repository.notification.watch"),
+	}
+}
+
+type watchedNotificationRepository struct {
+	NotificationRepository
+	bus    eventbus.WatchBus
+	logger log.LoggerInterface
+}
+
+func (r *watchedNotificationRepository) publish(ctx context.Context, notifications ...domain.Notification) {
+	for _, n := range notifications {
+		if err := r.bus.Publish(ctx, n.Change()); err != nil {
+			r.logger.WithContext(ctx).Warn("发布通知变化失败", zap.Error(err), zap.Uint64("notification_id", n.ID))
+		}
+	}
+}
+
+func (r *watchedNotificationRepository) Create(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	created, err := r.NotificationRepository.Create(ctx, notification)
+	if err == nil {
+		r.publish(ctx, created)
+	}
+	return created, err
+}
+
+func (r *watchedNotificationRepository) CreateWithCallbackLog(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	created, err := r.NotificationRepository.CreateWithCallbackLog(ctx, notification)
+	if err == nil {
+		r.publish(ctx, created)
+	}
+	return created, err
+}
+
+func (r *watchedNotificationRepository) BatchCreate(ctx context.Context, notifications []domain.Notification) ([]domain.Notification, error) {
+	created, err := r.NotificationRepository.BatchCreate(ctx, notifications)
+	if err == nil {
+		r.publish(ctx, created...)
+	}
+	return created, err
+}
+
+func (r *watchedNotificationRepository) BatchCreateWithCallbackLog(ctx context.Context, notifications []domain.Notification) ([]domain.Notification, error) {
+	created, err := r.NotificationRepository.BatchCreateWithCallbackLog(ctx, notifications)
+	if err == nil {
+		r.publish(ctx, created...)
+	}
+	return created, err
+}
+
+func (r *watchedNotificationRepository) CASStatus(ctx context.Context, notification domain.Notification) error {
+	return r.update(ctx, notification, r.NotificationRepository.CASStatus)
+}
+
+func (r *watchedNotificationRepository) UpdateStatus(ctx context.Context, notification domain.Notification) error {
+	return r.update(ctx, notification, r.NotificationRepository.UpdateStatus)
+}
+
+func (r *watchedNotificationRepository) CancelPending(ctx context.Context, notification domain.Notification) error {
+	return r.update(ctx, notification, r.NotificationRepository.CancelPending)
+}
+
+func (r *watchedNotificationRepository) Reschedule(ctx context.Context, notification domain.Notification) error {
+	return r.update(ctx, notification, r.NotificationRepository.Reschedule)
+}
+
+func (r *watchedNotificationRepository) ScheduleRetry(ctx context.Context, notification domain.Notification) error {
+	return r.update(ctx, notification, r.NotificationRepository.ScheduleRetry)
+}
+
+func (r *watchedNotificationRepository) MarkSuccess(ctx context.Context, notification domain.Notification) error {
+	return r.update(ctx, notification, r.NotificationRepository.MarkSuccess)
+}
+
+func (r *watchedNotificationRepository) MarkFailed(ctx context.Context, notification domain.Notification) error {
+	return r.update(ctx, notification, r.NotificationRepository.MarkFailed)
+}
+
+func (r *watchedNotificationRepository) update(ctx context.Context, notification domain.Notification,
+	update func(ctx context.Context, notification domain.Notification) error,
+) error {
+	err := update(ctx, notification)
+	if err == nil {
+		r.publish(ctx, notification)
+	}
+	return err
+}
+
+func (r *watchedNotificationRepository) UpdatePending(ctx context.Context, notification domain.Notification, auditLog domain.NotificationAuditLog) (domain.Notification, error) {
+	updated, err := r.NotificationRepository.UpdatePending(ctx, notification, auditLog)
+	if err == nil {
+		r.publish(ctx, updated)
+	}
+	return updated, err
+}
+
+func (r *watchedNotificationRepository) BatchUpdateStatusSucceededOrFailed(ctx context.Context, succeededNotifications, failedNotifications []domain.Notification) ([]uint64, error) {
+	conflicted, err := r.NotificationRepository.BatchUpdateStatusSucceededOrFailed(ctx, succeededNotifications, failedNotifications)
+	if err != nil {
+		return conflicted, err
+	}
+	lost := make(map[uint64]struct{}, len(conflicted))
+	for _, id := range conflicted {
+		lost[id] = struct{}{}
+	}
+	for _, notifications := range [][]domain.Notification{succeededNotifications, failedNotifications} {
+		for _, n := range notifications {
+			if _, ok := lost[n.ID]; !ok {
+				r.publish(ctx, n)
+			}
+		}
+	}
+	return conflicted, nil
+}
+
+func (r *watchedNotificationRepository) MarkTimeoutSendingAsFailed(ctx context.Context, batchSize int) ([]domain.Notification, error) {
+	timeout, err := r.NotificationRepository.MarkTimeoutSendingAsFailed(ctx, batchSize)
+	if err == nil {
+		r.publish(ctx, timeout...)
+	}
+	return timeout, err
+}
+
+func (r *watchedNotificationRepository) MarkExpiredAsFailed(ctx context.Context, batchSize int) ([]domain.Notification, error) {
+	expired, err := r.NotificationRepository.MarkExpiredAsFailed(ctx, batchSize)
+	if err == nil {
+		r.publish(ctx, expired...)
+	}
+	return expired, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// OptOutRepository 接收者退订，接收者在这一层加密，按盲索引查询
+type OptOutRepository interface {
+	// Add 记录退订，已经退订过时什么都不做
+	Add(ctx context.Context, optOut domain.OptOut) error
+	// OptedOut receivers 里退订了业务方在这个渠道的营销类通知的接收者
+	OptedOut(ctx context.Context, bizID int64, channel domain.Channel, receivers []string) ([]string, error)
+}
+
+var _ OptOutRepository = (*optOutRepository)(nil)
+
+func NewOptOutRepository(d dao.OptOutDAO, cipher encrypt.Cipher, indexer encrypt.BlindIndexer) OptOutRepository {
+	return &optOutRepository{
+		dao:     d,
+		cipher:  cipher,
+		indexer: indexer,
+	}
+}
+
+type optOutRepository struct {
+	dao     dao.OptOutDAO
+	cipher  encrypt.Cipher
+	indexer encrypt.BlindIndexer
+}
+
+func (r *optOutRepository) Add(ctx context.Context, optOut domain.OptOut) error {
+	receiver, err := r.cipher.Encrypt(ctx, optOut.Receiver)
+	if err != nil {
+		return fmt.Errorf("加密接收者失败: %w", err)
+	}
+	return r.dao.Create(ctx, dao.OptOut{
+		BizID:         optOut.BizID,
+		Channel:       optOut.Channel.String(),
+		ReceiverIndex: r.indexer.Index(optOut.Receiver),
+		Receiver:      receiver,
+		Source:        optOut.Source.String(),
+	})
+}
+
+func (r *optOutRepository) OptedOut(ctx context.Context, bizID int64, channel domain.Channel, receivers []string) ([]string, error) {
+	// 盲索引到接收者，不需要解密
+	indexes := make(map[string]string, len(receivers))
+	for _, receiver := range receivers {
+		indexes[r.indexer.Index(receiver)] = receiver
+	}
+	keys := make([]string, 0, len(indexes))
+	for idx := range indexes {
+		keys = append(keys, idx)
+	}
+	optOuts, err := r.dao.FindByReceivers(ctx, bizID, channel.String(), keys)
+	if err != nil {
+		return nil, err
+	}
+	// 同一个接收者可能同时退订了业务方和所有业务方
+	seen := make(map[string]struct{}, len(optOuts))
+	res := make([]string, 0, len(optOuts))
+	for _, o := range optOuts {
+		if _, ok := seen[o.ReceiverIndex]; ok {
+			continue
+		}
+		seen[o.ReceiverIndex] = struct{}{}
+		res = append(res, indexes[o.ReceiverIndex])
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// PacingRepository 限速发送的活动进度
+type PacingRepository interface {
+	// Reserve 为活动的 n 条通知按活动速度依次分配发送时间，不早于现在
+	Reserve(ctx context.Context, bizID int64, campaign string, perMinute int32, n int) ([]time.Time, error)
+	// Admit 调度器在当前这一分钟放行活动的 want 条通知，返回实际放行的数量和活动的速度
+	// 活动不存在时全部放行，速度返回 0
+	Admit(ctx context.Context, bizID int64, campaign string, want int) (int, int32, error)
+}
+
+var _ PacingRepository = (*pacingRepository)(nil)
+
+func NewPacingRepository(d dao.PacingDAO, clk clock.Clock) PacingRepository {
+	return &pacingRepository{dao: d, clock: clk}
+}
+
+type pacingRepository struct {
+	dao   dao.PacingDAO
+	clock clock.Clock
+}
+
+func (r *pacingRepository) Reserve(ctx context.Context, bizID int64, campaign string, perMinute int32, n int) ([]time.Time, error) {
+	first, err := r.dao.Reserve(ctx, bizID, campaign, perMinute, r.clock.Now().UnixMilli(), n)
+	if err != nil {
+		return nil, err
+	}
+	interval := domain.Pacing{PerMinute: perMinute}.Interval()
+	slots := make([]time.Time, 0, n)
+	for i := range n {
+		slots = append(slots, time.UnixMilli(first).Add(time.Duration(i)*interval))
+	}
+	return slots, nil
+}
+
+func (r *pacingRepository) Admit(ctx context.Context, bizID int64, campaign string, want int) (int, int32, error) {
+	return r.dao.Admit(ctx, bizID, campaign, r.clock.Now().UnixMilli(), want)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// 分配发送时间和放行都按注入的时钟取当前时间
+func TestPacingRepository_UsesClock(t *testing.T) {
+	now := time.Date(2024, 1, 31, 23, 59, 30, 0, time.UTC)
+	d := &nowPacingDAO{}
+	r := NewPacingRepository(d, clock.NewFake(now))
+
+	slots, err := r.Reserve(context.Background(), 7, "campaign", 60, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []time.Time{now, now.Add(time.Second)}; !slices.EqualFunc(slots, want, time.Time.Equal) {
+		t.Fatalf("发送时间 %v, 应该是 %v", slots, want)
+	}
+	if _, _, err = r.Admit(context.Background(), 7, "campaign", 1); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{now.UnixMilli(), now.UnixMilli()}; !slices.Equal(d.nows, want) {
+		t.Fatalf("传给 DAO 的当前时间 %v, 应该是 %v", d.nows, want)
+	}
+}
+
+// nowPacingDAO 记录传入的当前时间，第一条的发送时间就是当前时间
+type nowPacingDAO struct {
+	dao.PacingDAO
+	nows []int64
+}
+
+func (d *nowPacingDAO) Reserve(_ context.Context, _ int64, _ string, _ int32, now int64, _ int) (int64, error) {
+	d.nows = append(d.nows, now)
+	return now, nil
+}
+
+func (d *nowPacingDAO) Admit(_ context.Context, _ int64, _ string, now int64, want int) (int, int32, error) {
+	d.nows = append(d.nows, now)
+	return want, 60, nil
+}
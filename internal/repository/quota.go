@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// QuotaRepository 额度查询和配置，发送时的扣减和归还在 NotificationRepository 里
+// 数据库保存配置的额度，Redis 保存剩余额度
+type QuotaRepository interface {
+	// Find 业务方在某个渠道上的剩余额度，Redis 里没有时从数据库加载，都没有时返回 domain.ErrQuotaNotFound
+	Find(ctx context.Context, bizID int64, channel domain.Channel) (domain.Quota, error)
+	// Check 检查额度够不够扣减 count 条，不扣减，返回当前的剩余额度，额度不足时返回 domain.ErrNoQuota
+	Check(ctx context.Context, bizID int64, channel domain.Channel, category domain.NotificationCategory, count int32) (domain.Quota, error)
+	// CreateOrUpdate 先写数据库再写 Redis，Redis 里的剩余额度会被重置成配置的额度
+	CreateOrUpdate(ctx context.Context, quotas ...domain.Quota) error
+	// Warmup 把数据库里的额度加载到 Redis，已经存在的不覆盖，返回加载的额度配置数量
+	Warmup(ctx context.Context, batchSize int) (int, error)
+}
+
+var _ QuotaRepository = (*quotaRepository)(nil)
+
+func NewQuotaRepository(quotaCache cache.QuotaCache, d dao.QuotaDAO) QuotaRepository {
+	return &quotaRepository{cache: quotaCache, dao: d, logger: log.Named(log.DefaultLogger(), "repository.quota")}
+}
+
+type quotaRepository struct {
+	cache  cache.QuotaCache
+	dao    dao.QuotaDAO
+	logger log.LoggerInterface
+}
+
+func (r *quotaRepository) Find(ctx context.Context, bizID int64, channel domain.Channel) (domain.Quota, error) {
+	quota, err := r.cache.Find(ctx, bizID, channel)
+	if !errors.Is(err, domain.ErrQuotaNotFound) {
+		return quota, err
+	}
+	entity, err := r.dao.Find(ctx, bizID, channel.String())
+	if err != nil {
+		return domain.Quota{}, err
+	}
+	quota = r.toDomain(entity)
+	if err = r.cache.SetIfAbsent(ctx, quota); err != nil {
+		r.logger.WithContext(ctx).Error("回写额度缓存失败", zap.Error(err),
+			zap.Int64("biz_id", bizID), zap.String("channel", channel.String()))
+		return quota, nil
+	}
+	// 并发加载或者扣减时 Redis 里的值可能已经不是配置的额度，以 Redis 为准
+	if cached, cerr := r.cache.Find(ctx, bizID, channel); cerr == nil {
+		return cached, nil
+	}
+	return quota, nil
+}
+
+func (r *quotaRepository) Check(ctx context.Context, bizID int64, channel domain.Channel,
+	category domain.NotificationCategory, count int32,
+) (domain.Quota, error) {
+	// 先保证 Redis 里有额度，和扣减看到的一致
+	if _, err := r.Find(ctx, bizID, channel); err != nil && !errors.Is(err, domain.ErrQuotaNotFound) {
+		return domain.Quota{}, err
+	}
+	return r.cache.Check(ctx, bizID, channel, category, count)
+}
+
+func (r *quotaRepository) CreateOrUpdate(ctx context.Context, quotas ...domain.Quota) error {
+	if len(quotas) == 0 {
+		return nil
+	}
+	entities := make([]dao.Quota, 0, len(quotas))
+	for _, q := range quotas {
+		entities = append(entities, dao.Quota{
+			BizID:   q.BizID,
+			Channel: q.Channel.String(),
+			Quota:   q.Quota,
+		})
+	}
+	if err := r.dao.CreateOrUpdate(ctx, entities...); err != nil {
+		return err
+	}
+	return r.cache.CreateOrUpdate(ctx, quotas...)
+}
+
+func (r *quotaRepository) Warmup(ctx context.Context, batchSize int) (int, error) {
+	var (
+		afterID uint64
+		total   int
+	)
+	for {
+		entities, err := r.dao.List(ctx, afterID, batchSize)
+		if err != nil {
+			return total, err
+		}
+		if len(entities) == 0 {
+			return total, nil
+		}
+		quotas := make([]domain.Quota, 0, len(entities))
+		for _, e := range entities {
+			quotas = append(quotas, r.toDomain(e))
+		}
+		if err = r.cache.SetIfAbsent(ctx, quotas...); err != nil {
+			return total, err
+		}
+		total += len(entities)
+		afterID = entities[len(entities)-1].ID
+		if len(entities) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+func (r *quotaRepository) toDomain(q dao.Quota) domain.Quota {
+	return domain.Quota{
+		BizID:   q.BizID,
+		Channel: domain.Channel(q.Channel),
+		Quota:   q.Quota,
+	}
+}
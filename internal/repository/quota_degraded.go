@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// Redis 不可用时扣减额度的策略
+const (
+	// QuotaDegradedReject 直接拒绝，不再访问 Redis，直到 Redis 恢复
+	QuotaDegradedReject = "reject"
+	// QuotaDegradedDB 在数据库的额度上扣减，和 createV1 一样，Redis 恢复后把扣减的数量补扣到 Redis 并还给数据库
+	QuotaDegradedDB = "db"
+	// QuotaDegradedAllow 不校验额度直接放行，Redis 恢复后补扣，超出的部分计入透支
+	QuotaDegradedAllow = "allow"
+)
+
+var (
+	quotaDegradedGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "quota_store_degraded",
+		Help: "Whether quota deduction is running in degraded mode because Redis is unavailable (1) or not (0)",
+	})
+	quotaDegradedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "quota_degraded_decisions_total",
+		Help: "Total number of quota deductions decided in degraded mode, by mode and result",
+	}, []string{"mode", "result"})
+	quotaReconciledCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "quota_degraded_reconciles_total",
+		Help: "Total number of attempts to reconcile quota deducted in degraded mode back to Redis, by result",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(quotaDegradedGauge, quotaDegradedCounter, quotaReconciledCounter)
+}
+
+// DegradedQuotaOptions Redis 不可用时的降级参数
+type DegradedQuotaOptions struct {
+	// Mode QuotaDegradedReject、QuotaDegradedDB 或 QuotaDegradedAllow
+	Mode string
+	// ProbeInterval 降级期间探测 Redis 的间隔
+	ProbeInterval time.Duration
+	// Ping 探测 Redis 是否恢复
+	Ping func(ctx context.Context) error
+}
+
+type quotaKey struct {
+	bizID   int64
+	channel domain.Channel
+}
+
+var _ cache.QuotaCache = (*degradedQuotaCache)(nil)
+
+// NewDegradedQuotaCache 扣减、归还额度时 Redis 出错（不是额度不足）就进入降级模式，按 Mode 处理，不再访问 Redis
+// 后台按 ProbeInterval 探测，Redis 恢复后先把降级期间的净扣减量补到 Redis，成功之后才退出降级模式
+// 降级期间的扣减量只在内存里，实例在恢复之前退出时丢失，这部分额度不会补扣
+// 查询、检查、写入额度不降级，Redis 不可用时照常返回错误；Close 停止探测
+func NewDegradedQuotaCache(c cache.QuotaCache, quotaDAO dao.QuotaDAO, opts DegradedQuotaOptions) cache.QuotaCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &degradedQuotaCache{
+		QuotaCache: c,
+		dao:        quotaDAO,
+		opts:       opts,
+		pending:    make(map[quotaKey]int32),
+		ctx:        ctx,
+		cancel:     cancel,
+		logger:     log.Named(log.DefaultLogger(), "cache.quota"),
+	}
+}
+
+// errQuotaRecovered 降级扣减、归还的过程中 Redis 已经恢复，调用方改为访问 Redis
+var errQuotaRecovered = errors.New("额度存储已经恢复")
+
+type degradedQuotaCache struct {
+	cache.QuotaCache
+	dao  dao.QuotaDAO
+	opts DegradedQuotaOptions
+
+	degraded atomic.Bool
+	// mu 只保护 pending，持有锁时不访问 Redis 和数据库；退出降级模式也在锁内，之后不会再记入 pending
+	mu sync.Mutex
+	// pending 降级期间每个额度的净扣减量，恢复时补扣到 Redis
+	pending map[quotaKey]int32
+	// ctx 探测的生命周期，Close 时取消
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger log.LoggerInterface
+}
+
+// Close 停止探测，降级期间没有补扣的额度不再补扣
+func (q *degradedQuotaCache) Close() {
+	q.cancel()
+}
+
+func (q *degradedQuotaCache) Decr(ctx context.Context, bizID int64, channel domain.Channel,
+	category domain.NotificationCategory, quota int32,
+) error {
+	return q.MutiDecr(ctx, []cache.IncrItem{{BizID: bizID, Channel: channel, Category: category, Val: quota}})
+}
+
+func (q *degradedQuotaCache) Incr(ctx context.Context, bizID int64, channel domain.Channel, quota int32) error {
+	return q.MutiIncr(ctx, []cache.IncrItem{{BizID: bizID, Channel: channel, Val: quota}})
+}
+
+func (q *degradedQuotaCache) MutiDecr(ctx context.Context, items []cache.IncrItem) error {
+	if !q.degraded.Load() {
+		err := q.QuotaCache.MutiDecr(ctx, items)
+		if !storeUnavailable(ctx, err) {
+			return err
+		}
+		q.enterDegraded(ctx, err)
+	}
+	err := q.degradedDecr(ctx, items)
+	if errors.Is(err, errQuotaRecovered) {
+		return q.QuotaCache.MutiDecr(ctx, items)
+	}
+	result := "allowed"
+	if err != nil {
+		result = "rejected"
+	}
+	quotaDegradedCounter.WithLabelValues(q.opts.Mode, result).Add(float64(len(items)))
+	return err
+}
+
+// ReserveDecr 降级期间按 Mode 扣减，不记录扣减意图，返回 false 由调用方失败时直接归还
+func (q *degradedQuotaCache) ReserveDecr(ctx context.Context, r cache.QuotaReservation) (bool, error) {
+	if !q.degraded.Load() {
+		recorded, err := q.QuotaCache.ReserveDecr(ctx, r)
+		if !storeUnavailable(ctx, err) {
+			return recorded, err
+		}
+		q.enterDegraded(ctx, err)
+	}
+	return false, q.MutiDecr(ctx, r.Items)
+}
+
+// degradedDecr 按 Mode 扣减之后记入 pending，记入时 Redis 已经恢复就撤销数据库的扣减，返回 errQuotaRecovered
+func (q *degradedQuotaCache) degradedDecr(ctx context.Context, items []cache.IncrItem) error {
+	switch q.opts.Mode {
+	case QuotaDegradedAllow:
+	case QuotaDegradedDB:
+		for i, item := range items {
+			if err := q.dao.Decr(ctx, item.BizID, item.Channel.String(), item.Val); err != nil {
+				// 全部扣减成功才算成功，前面扣减的还回去
+				q.incrDB(ctx, items[:i], 1)
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: 拒绝扣减额度", domain.ErrQuotaStoreUnavailable)
+	}
+	if q.addPending(items, 1) {
+		return nil
+	}
+	if q.opts.Mode == QuotaDegradedDB {
+		q.incrDB(ctx, items, 1)
+	}
+	return errQuotaRecovered
+}
+
+// MutiIncr 降级期间的归还抵扣净扣减量，reject 模式下降级期间没有扣减，归还的是之前在 Redis 扣减的，同样等恢复后补到 Redis
+func (q *degradedQuotaCache) MutiIncr(ctx context.Context, items []cache.IncrItem) error {
+	if !q.degraded.Load() {
+		err := q.QuotaCache.MutiIncr(ctx, items)
+		if !storeUnavailable(ctx, err) {
+			return err
+		}
+		q.enterDegraded(ctx, err)
+	}
+	var err error
+	done := items
+	if q.opts.Mode == QuotaDegradedDB {
+		for i, item := range items {
+			if err = q.dao.Incr(ctx, item.BizID, item.Channel.String(), item.Val); err != nil {
+				done = items[:i]
+				break
+			}
+		}
+	}
+	if q.addPending(done, -1) {
+		return err
+	}
+	// Redis 已经恢复，撤销数据库的归还，全部归还到 Redis
+	if q.opts.Mode == QuotaDegradedDB {
+		q.incrDB(ctx, done, -1)
+	}
+	return q.QuotaCache.MutiIncr(ctx, items)
+}
+
+// addPending 把扣减（sign 为 1）或者归还（sign 为 -1）记入 pending，已经退出降级模式时不记入，返回 false
+func (q *degradedQuotaCache) addPending(items []cache.IncrItem, sign int32) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.degraded.Load() {
+		return false
+	}
+	for _, item := range items {
+		q.pending[quotaKey{bizID: item.BizID, channel: item.Channel}] += sign * item.Val
+	}
+	return true
+}
+
+// incrDB 撤销数据库里的扣减（sign 为 1）或者归还（sign 为 -1），失败只记录日志
+func (q *degradedQuotaCache) incrDB(ctx context.Context, items []cache.IncrItem, sign int32) {
+	for _, item := range items {
+		if err := q.dao.Incr(ctx, item.BizID, item.Channel.String(), sign*item.Val); err != nil {
+			q.logger.WithContext(ctx).Error("撤销数据库额度失败", zap.Error(err),
+				zap.Int64("biz_id", item.BizID), zap.String("channel", item.Channel.String()),
+				zap.Int32("delta", sign*item.Val))
+		}
+	}
+}
+
+func (q *degradedQuotaCache) enterDegraded(ctx context.Context, cause error) {
+	if !q.degraded.CompareAndSwap(false, true) {
+		return
+	}
+	quotaDegradedGauge.Set(1)
+	q.logger.WithContext(ctx).Error("Redis 不可用，额度扣减进入降级模式", zap.Error(cause), zap.String("mode", q.opts.Mode))
+	go q.probe(q.ctx)
+}
+
+// probe 探测到 Redis 恢复并且补扣成功之后退出降级模式，ctx 取消时停止
+func (q *degradedQuotaCache) probe(ctx context.Context) {
+	ticker := time.NewTicker(q.opts.ProbeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, q.opts.ProbeInterval)
+		recovered := q.recover(probeCtx)
+		cancel()
+		if recovered {
+			return
+		}
+	}
+}
+
+// recover 返回 true 表示这个探测协程可以退出
+func (q *degradedQuotaCache) recover(ctx context.Context) bool {
+	if err := q.opts.Ping(ctx); err != nil {
+		return false
+	}
+	// 先补扣，补扣期间降级扣减的记入新的 pending
+	if err := q.reconcile(ctx); err != nil {
+		quotaReconciledCounter.WithLabelValues("failed").Inc()
+		q.logger.WithContext(ctx).Error("Redis 已恢复，补扣降级期间的额度失败", zap.Error(err))
+		return false
+	}
+	// 退出降级模式之后不会再记入 pending，再补扣一次补扣期间新增的
+	q.mu.Lock()
+	q.degraded.Store(false)
+	q.mu.Unlock()
+	quotaDegradedGauge.Set(0)
+	if err := q.reconcile(ctx); err != nil {
+		quotaReconciledCounter.WithLabelValues("failed").Inc()
+		q.logger.WithContext(ctx).Error("Redis 已恢复，补扣降级期间的额度失败", zap.Error(err))
+		// 其他调用已经重新进入降级模式时由它的探测协程补扣
+		if !q.degraded.CompareAndSwap(false, true) {
+			return true
+		}
+		quotaDegradedGauge.Set(1)
+		return false
+	}
+	quotaReconciledCounter.WithLabelValues("success").Inc()
+	q.logger.WithContext(ctx).Info("Redis 已恢复，额度扣减退出降级模式")
+	return true
+}
+
+// reconcile 取出 pending 补扣到 Redis，补扣时不持有锁，没有补扣的放回 pending 下次探测重试
+func (q *degradedQuotaCache) reconcile(ctx context.Context) error {
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = make(map[quotaKey]int32)
+	q.mu.Unlock()
+
+	for key, delta := range pending {
+		item := cache.IncrItem{BizID: key.bizID, Channel: key.channel}
+		var err error
+		switch {
+		case delta > 0:
+			item.Val = delta
+			err = q.QuotaCache.ForceDecr(ctx, []cache.IncrItem{item})
+		case delta < 0:
+			item.Val = -delta
+			err = q.QuotaCache.MutiIncr(ctx, []cache.IncrItem{item})
+		}
+		if err != nil {
+			q.restorePending(pending)
+			return err
+		}
+		// 数据库里扣减的额度只是降级期间的上限，补到 Redis 之后还给数据库，数据库里保存的依旧是配置的额度
+		if q.opts.Mode == QuotaDegradedDB && delta != 0 {
+			if err = q.dao.Incr(ctx, key.bizID, key.channel.String(), delta); err != nil {
+				// Redis 已经补扣，数据库没还回去，不能再补扣一次，只记录日志
+				q.logger.WithContext(ctx).Error("归还数据库额度失败", zap.Error(err),
+					zap.Int64("biz_id", key.bizID), zap.String("channel", key.channel.String()),
+					zap.Int32("delta", delta))
+			}
+		}
+		delete(pending, key)
+	}
+	return nil
+}
+
+// restorePending 没有补扣的净扣减量加回 pending，和取出之后新记入的合并
+func (q *degradedQuotaCache) restorePending(pending map[quotaKey]int32) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for key, delta := range pending {
+		q.pending[key] += delta
+	}
+}
+
+// storeUnavailable 额度不足是正常的业务错误，调用方取消的请求不算，其余错误都当作 Redis 不可用
+func storeUnavailable(ctx context.Context, err error) bool {
+	return err != nil && !errors.Is(err, domain.ErrNoQuota) && ctx.Err() == nil
+}
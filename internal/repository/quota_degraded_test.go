@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// Redis 出错之后进入降级模式，不再访问 Redis，按 Mode 决定扣减结果
+func TestDegradedQuotaCache_Modes(t *testing.T) {
+	item := cache.IncrItem{BizID: 1, Channel: domain.ChannelSMS, Val: 1}
+	testCases := []struct {
+		name string
+		mode string
+		// 进入降级模式之后连续扣减两次的结果，数据库里只有 1 条额度
+		wantErrs []error
+	}{
+		{name: "reject", mode: QuotaDegradedReject,
+			wantErrs: []error{domain.ErrQuotaStoreUnavailable, domain.ErrQuotaStoreUnavailable}},
+		{name: "db", mode: QuotaDegradedDB, wantErrs: []error{nil, domain.ErrNoQuota}},
+		{name: "allow", mode: QuotaDegradedAllow, wantErrs: []error{nil, nil}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			redis := &flakyQuotaCache{err: errors.New("redis: connection refused")}
+			db := &memQuotaDAO{quotas: map[quotaKey]int32{{bizID: 1, channel: domain.ChannelSMS}: 1}}
+			q := newTestDegradedQuotaCache(t, redis, db, tc.mode, time.Hour, func(context.Context) error {
+				return errors.New("redis 不可用")
+			})
+			ctx := context.Background()
+
+			for i, wantErr := range tc.wantErrs {
+				if err := q.MutiDecr(ctx, []cache.IncrItem{item}); !errors.Is(err, wantErr) {
+					t.Fatalf("第 %d 次扣减返回 %v, 应该是 %v", i+1, err, wantErr)
+				}
+			}
+			if !q.degraded.Load() {
+				t.Fatal("Redis 出错之后应该进入降级模式")
+			}
+			// 只有第一次访问了 Redis
+			if got := redis.callCount(); got != 1 {
+				t.Fatalf("访问了 Redis %d 次, 应该只有进入降级模式之前的 1 次", got)
+			}
+		})
+	}
+}
+
+// 额度不足和调用方取消不是 Redis 不可用，不进入降级模式
+func TestDegradedQuotaCache_NotDegradedOnBusinessError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		err  error
+	}{
+		{name: "额度不足", ctx: context.Background(), err: domain.ErrNoQuota},
+		{name: "调用方取消", ctx: ctx, err: context.Canceled},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := newTestDegradedQuotaCache(t, &flakyQuotaCache{err: tc.err}, &memQuotaDAO{}, QuotaDegradedAllow, time.Hour,
+				func(context.Context) error { return nil })
+			err := q.MutiDecr(tc.ctx, []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 1}})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("返回 %v, 应该是 %v", err, tc.err)
+			}
+			if q.degraded.Load() {
+				t.Fatal("不应该进入降级模式")
+			}
+		})
+	}
+}
+
+// Redis 恢复后把降级期间的净扣减量补扣到 Redis，数据库里扣减的额度还回去，之后的扣减重新访问 Redis
+func TestDegradedQuotaCache_ReconcilesOnRecovery(t *testing.T) {
+	redis := &flakyQuotaCache{err: errors.New("redis: connection refused")}
+	sms := quotaKey{bizID: 1, channel: domain.ChannelSMS}
+	email := quotaKey{bizID: 2, channel: domain.ChannelEmail}
+	db := &memQuotaDAO{quotas: map[quotaKey]int32{sms: 10, email: 10}}
+	var healthy atomic.Bool
+	q := newTestDegradedQuotaCache(t, redis, db, QuotaDegradedDB, 5*time.Millisecond, func(context.Context) error {
+		if !healthy.Load() {
+			return errors.New("redis 不可用")
+		}
+		return nil
+	})
+	ctx := context.Background()
+
+	steps := []struct {
+		decr bool
+		item cache.IncrItem
+	}{
+		{decr: true, item: cache.IncrItem{BizID: 1, Channel: domain.ChannelSMS, Val: 3}},
+		{decr: true, item: cache.IncrItem{BizID: 1, Channel: domain.ChannelSMS, Val: 2}},
+		{decr: false, item: cache.IncrItem{BizID: 1, Channel: domain.ChannelSMS, Val: 1}},
+		// 降级期间只有归还，恢复后归还到 Redis
+		{decr: false, item: cache.IncrItem{BizID: 2, Channel: domain.ChannelEmail, Val: 2}},
+	}
+	for i, s := range steps {
+		var err error
+		if s.decr {
+			err = q.MutiDecr(ctx, []cache.IncrItem{s.item})
+		} else {
+			err = q.MutiIncr(ctx, []cache.IncrItem{s.item})
+		}
+		if err != nil {
+			t.Fatalf("第 %d 步返回 %v", i+1, err)
+		}
+	}
+	if got := db.quota(sms); got != 6 {
+		t.Fatalf("降级期间数据库里的额度 %d, 应该是 6", got)
+	}
+
+	redis.recover()
+	healthy.Store(true)
+	deadline := time.Now().Add(5 * time.Second)
+	for q.degraded.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("Redis 恢复之后没有退出降级模式")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if got, want := redis.forced(), []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 4}}; !slices.Equal(got, want) {
+		t.Fatalf("补扣了 %v, 应该是 %v", got, want)
+	}
+	if got, want := redis.incrs(), []cache.IncrItem{{BizID: 2, Channel: domain.ChannelEmail, Val: 2}}; !slices.Equal(got, want) {
+		t.Fatalf("归还了 %v, 应该是 %v", got, want)
+	}
+	if db.quota(sms) != 10 || db.quota(email) != 10 {
+		t.Fatalf("补扣之后数据库里的额度是 %d 和 %d, 应该还原成 10", db.quota(sms), db.quota(email))
+	}
+	if pending := q.pendingCount(); pending != 0 {
+		t.Fatalf("补扣之后还有 %d 个额度没有补扣", pending)
+	}
+
+	calls := redis.callCount()
+	if err := q.MutiDecr(ctx, []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if redis.callCount() != calls+1 || db.quota(sms) != 10 {
+		t.Fatal("退出降级模式之后应该在 Redis 上扣减")
+	}
+}
+
+// Close 之后探测协程退出，不再探测
+func TestDegradedQuotaCache_CloseStopsProbe(t *testing.T) {
+	var pings atomic.Int32
+	q := newTestDegradedQuotaCache(t, &flakyQuotaCache{err: errors.New("redis: connection refused")}, &memQuotaDAO{},
+		QuotaDegradedAllow, time.Millisecond, func(context.Context) error {
+			pings.Add(1)
+			return errors.New("redis 不可用")
+		})
+	if err := q.MutiDecr(context.Background(), []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for pings.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("进入降级模式之后没有探测")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	q.Close()
+	// 正在进行的一次探测可能还没有返回
+	time.Sleep(10 * time.Millisecond)
+	stopped := pings.Load()
+	time.Sleep(20 * time.Millisecond)
+	if got := pings.Load(); got != stopped {
+		t.Fatalf("Close 之后又探测了 %d 次", got-stopped)
+	}
+}
+
+func newTestDegradedQuotaCache(t *testing.T, c cache.QuotaCache, quotaDAO dao.QuotaDAO, mode string,
+	probeInterval time.Duration, ping func(ctx context.Context) error,
+) *degradedQuotaCache {
+	t.Helper()
+	q := NewDegradedQuotaCache(c, quotaDAO, DegradedQuotaOptions{Mode: mode, ProbeInterval: probeInterval, Ping: ping}).(*degradedQuotaCache)
+	t.Cleanup(q.Close)
+	return q
+}
+
+func (q *degradedQuotaCache) pendingCount() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// flakyQuotaCache err 不为空时所有扣减和归还都返回 err，恢复之后记录补扣和归还
+type flakyQuotaCache struct {
+	cache.QuotaCache
+	mu     sync.Mutex
+	err    error
+	calls  int
+	force  []cache.IncrItem
+	refund []cache.IncrItem
+}
+
+func (c *flakyQuotaCache) MutiDecr(context.Context, []cache.IncrItem) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return c.err
+}
+
+func (c *flakyQuotaCache) ForceDecr(_ context.Context, items []cache.IncrItem) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.force = append(c.force, items...)
+	return nil
+}
+
+func (c *flakyQuotaCache) MutiIncr(_ context.Context, items []cache.IncrItem) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.refund = append(c.refund, items...)
+	return nil
+}
+
+func (c *flakyQuotaCache) recover() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = nil
+}
+
+func (c *flakyQuotaCache) callCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func (c *flakyQuotaCache) forced() []cache.IncrItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.force)
+}
+
+func (c *flakyQuotaCache) incrs() []cache.IncrItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.refund)
+}
+
+// memQuotaDAO 内存里的数据库额度，和 DAO 一样不够扣减时返回 domain.ErrNoQuota
+type memQuotaDAO struct {
+	dao.QuotaDAO
+	mu     sync.Mutex
+	quotas map[quotaKey]int32
+}
+
+func (d *memQuotaDAO) Decr(_ context.Context, bizID int64, channel string, n int32) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := quotaKey{bizID: bizID, channel: domain.Channel(channel)}
+	if d.quotas[key] < n {
+		return domain.ErrNoQuota
+	}
+	d.quotas[key] -= n
+	return nil
+}
+
+func (d *memQuotaDAO) Incr(_ context.Context, bizID int64, channel string, n int32) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.quotas[quotaKey{bizID: bizID, channel: domain.Channel(channel)}] += n
+	return nil
+}
+
+func (d *memQuotaDAO) quota(key quotaKey) int32 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.quotas[key]
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+	"go.uber.org/zap"
+)
+
+// QuotaReservationRepository 修复扣减意图
+// 扣减额度时和扣减一起记录意图，通知写入数据库之后删除；进程在两者之间退出，或者写入失败之后归还也失败时意图留在 Redis 里
+type QuotaReservationRepository interface {
+	// Repair 核对记录时间早于 before 的一批扣减意图，通知已经写入的删除意图，没有写入的归还额度
+	Repair(ctx context.Context, before time.Time, limit int) (committed, refunded int, err error)
+}
+
+var _ QuotaReservationRepository = (*quotaReservationRepository)(nil)
+
+func NewQuotaReservationRepository(quotaCache cache.QuotaCache, notificationDAO dao.NotificationDAO) QuotaReservationRepository {
+	return &quotaReservationRepository{
+		cache:  quotaCache,
+		dao:    notificationDAO,
+		logger: log.Named(log.DefaultLogger(), "repository.quota_reservation"),
+	}
+}
+
+type quotaReservationRepository struct {
+	cache  cache.QuotaCache
+	dao    dao.NotificationDAO
+	logger log.LoggerInterface
+}
+
+// Repair 批量写入在一个事务里，意图里的通知有一条存在就说明写入成功
+// 确认和归还都是幂等的，和请求里的确认、归还或者另一个实例并发执行也只会处理一次
+func (r *quotaReservationRepository) Repair(ctx context.Context, before time.Time, limit int) (int, int, error) {
+	reservations, err := r.cache.ListReservations(ctx, before, limit)
+	if err != nil || len(reservations) == 0 {
+		return 0, 0, err
+	}
+	ids := make([]uint64, 0, len(reservations))
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.NotificationIDs...)
+	}
+	found, err := r.dao.BatchGetByIDs(ctx, ids)
+	if err != nil {
+		return 0, 0, err
+	}
+	var committed, refunded int
+	for _, reservation := range reservations {
+		if len(reservation.Items) == 0 || r.anyCreated(reservation, found) {
+			if err = r.cache.CommitReservation(ctx, reservation.ID); err != nil {
+				return committed, refunded, err
+			}
+			committed++
+			continue
+		}
+		ok, err := r.cache.RefundReservation(ctx, reservation)
+		if err != nil {
+			return committed, refunded, err
+		}
+		if ok {
+			refunded++
+			r.logger.WithContext(ctx).Warn("通知没有写入，归还扣减意图的额度",
+				zap.String("reservation", reservation.ID),
+				zap.Uint64s("notification_ids", reservation.NotificationIDs),
+				zap.Time("ctime", reservation.Ctime))
+		}
+	}
+	return committed, refunded, nil
+}
+
+func (r *quotaReservationRepository) anyCreated(reservation cache.QuotaReservation, found map[uint64]dao.Notification) bool {
+	for _, id := range reservation.NotificationIDs {
+		if _, ok := found[id]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/cache"
+	rediscache "github.com/serendipityConfusion/notification-platform/internal/repository/cache/redis"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// 通知已经写入的只删除意图，没有写入的归还额度，再次修复不会重复处理
+func TestQuotaReservationRepository_Repair(t *testing.T) {
+	testCases := []struct {
+		name          string
+		created       map[uint64]bool
+		wantCommitted int
+		wantRefunded  int
+		wantRemaining int32
+	}{
+		// 批量写入在一个事务里，有一条存在就说明写入成功
+		{name: "通知已经写入", created: map[uint64]bool{2: true}, wantCommitted: 1, wantRemaining: 8},
+		{name: "通知没有写入", wantRefunded: 1, wantRemaining: 10},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quotaCache, now := newReservedQuotaCache(t, cache.QuotaReservation{
+				ID:              "1",
+				NotificationIDs: []uint64{1, 2},
+				Items:           []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 2}},
+			})
+			r := NewQuotaReservationRepository(quotaCache, &createdNotificationDAO{created: tc.created})
+			ctx := context.Background()
+
+			committed, refunded, err := r.Repair(ctx, now, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if committed != tc.wantCommitted || refunded != tc.wantRefunded {
+				t.Fatalf("确认 %d 归还 %d, 应该是 %d 和 %d", committed, refunded, tc.wantCommitted, tc.wantRefunded)
+			}
+			assertRemaining(t, quotaCache, tc.wantRemaining)
+
+			// 意图已经处理，再次修复什么都不做
+			committed, refunded, err = r.Repair(ctx, now, 10)
+			if err != nil || committed != 0 || refunded != 0 {
+				t.Fatalf("再次修复确认 %d 归还 %d, err %v", committed, refunded, err)
+			}
+			assertRemaining(t, quotaCache, tc.wantRemaining)
+		})
+	}
+}
+
+// 多个实例同时修复同一个意图，额度只归还一次
+func TestQuotaReservationRepository_ConcurrentRepair(t *testing.T) {
+	quotaCache, now := newReservedQuotaCache(t, cache.QuotaReservation{
+		ID:              "1",
+		NotificationIDs: []uint64{1},
+		Items:           []cache.IncrItem{{BizID: 1, Channel: domain.ChannelSMS, Val: 2}},
+	})
+	const instances = 2
+	repos := make([]QuotaReservationRepository, instances)
+	for i := range repos {
+		repos[i] = NewQuotaReservationRepository(quotaCache, &createdNotificationDAO{})
+	}
+
+	refunded := make([]int, instances)
+	errs := make([]error, instances)
+	var wg sync.WaitGroup
+	for i, r := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, refunded[i], errs[i] = r.Repair(context.Background(), now, 10)
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for i := range repos {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		total += refunded[i]
+	}
+	if total != 1 {
+		t.Fatalf("一共归还了 %d 次, 应该只归还一次", total)
+	}
+	assertRemaining(t, quotaCache, 10)
+}
+
+// newReservedQuotaCache 业务方 1 的短信额度是 10，扣减并记录意图之后返回修复时用的截止时间
+func newReservedQuotaCache(t *testing.T, reservation cache.QuotaReservation) (cache.QuotaCache, time.Time) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	quotaCache := rediscache.NewQuotaCache(client, domain.OverdraftPolicies{}, clock.NewFake(start))
+
+	ctx := context.Background()
+	if err := quotaCache.CreateOrUpdate(ctx, domain.Quota{BizID: 1, Channel: domain.ChannelSMS, Quota: 10}); err != nil {
+		t.Fatal(err)
+	}
+	reservation.Ctime = start
+	recorded, err := quotaCache.ReserveDecr(ctx, reservation)
+	if err != nil || !recorded {
+		t.Fatalf("记录扣减意图 %v, err %v", recorded, err)
+	}
+	return quotaCache, start.Add(time.Minute)
+}
+
+func assertRemaining(t *testing.T, quotaCache cache.QuotaCache, want int32) {
+	t.Helper()
+	got, err := quotaCache.Find(context.Background(), 1, domain.ChannelSMS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Quota != want {
+		t.Fatalf("剩余额度 %d, 应该是 %d", got.Quota, want)
+	}
+}
+
+// createdNotificationDAO created 里的通知已经写入数据库
+type createdNotificationDAO struct {
+	dao.NotificationDAO
+	created map[uint64]bool
+}
+
+func (d *createdNotificationDAO) BatchGetByIDs(_ context.Context, ids []uint64) (map[uint64]dao.Notification, error) {
+	found := make(map[uint64]dao.Notification, len(ids))
+	for _, id := range ids {
+		if d.created[id] {
+			found[id] = dao.Notification{ID: id}
+		}
+	}
+	return found, nil
+}
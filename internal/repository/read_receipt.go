@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// ReadReceiptRepository 站内信已读回执，接收者在这一层加解密
+type ReadReceiptRepository interface {
+	// Create 记录已读，接收者不是通知的接收者时返回 domain.ErrReceiverNotInNotification
+	// 已经读过时返回第一次的记录，created 为 false
+	Create(ctx context.Context, receipt domain.ReadReceipt) (res domain.ReadReceipt, created bool, err error)
+	NotificationStats(ctx context.Context, notificationID uint64) (domain.ReadStats, error)
+	// TemplateStats 模板在查询时间范围内创建并发送成功的站内信的已读统计
+	TemplateStats(ctx context.Context, query domain.StatsQuery, templateID int64) (domain.ReadStats, error)
+	// FindPendingCallbacks 到了推送时间还没有推送成功的已读回执，接收者已经解密
+	FindPendingCallbacks(ctx context.Context, limit int) ([]domain.ReadReceipt, error)
+	// UpdateCallback 只更新推送状态、重试次数和下一次推送时间
+	UpdateCallback(ctx context.Context, receipt domain.ReadReceipt) error
+}
+
+var _ ReadReceiptRepository = (*readReceiptRepository)(nil)
+
+func NewReadReceiptRepository(d dao.ReadReceiptDAO, cipher encrypt.Cipher, indexer encrypt.BlindIndexer) ReadReceiptRepository {
+	return &readReceiptRepository{
+		dao:     d,
+		cipher:  cipher,
+		indexer: indexer,
+	}
+}
+
+type readReceiptRepository struct {
+	dao     dao.ReadReceiptDAO
+	cipher  encrypt.Cipher
+	indexer encrypt.BlindIndexer
+}
+
+func (r *readReceiptRepository) Create(ctx context.Context, receipt domain.ReadReceipt) (domain.ReadReceipt, bool, error) {
+	idx := r.indexer.Index(receipt.Receiver)
+	ok, err := r.dao.IsReceiver(ctx, receipt.NotificationID, idx)
+	if err != nil {
+		return domain.ReadReceipt{}, false, err
+	}
+	if !ok {
+		return domain.ReadReceipt{}, false, fmt.Errorf("%w: notificationID = %d", domain.ErrReceiverNotInNotification, receipt.NotificationID)
+	}
+	receiver, err := r.cipher.Encrypt(ctx, receipt.Receiver)
+	if err != nil {
+		return domain.ReadReceipt{}, false, fmt.Errorf("加密接收者失败: %w", err)
+	}
+	entity, created, err := r.dao.Create(ctx, dao.NotificationRead{
+		NotificationID: receipt.NotificationID,
+		ReceiverIndex:  idx,
+		BizID:          receipt.BizID,
+		Key:            receipt.Key,
+		TemplateID:     receipt.TemplateID,
+		Receiver:       receiver,
+		ReadTime:       receipt.ReadTime.UnixMilli(),
+		CallbackStatus: receipt.CallbackStatus.String(),
+		NextRetryTime:  receipt.NextRetryTime,
+	})
+	if err != nil {
+		return domain.ReadReceipt{}, false, err
+	}
+	res := r.toDomain(entity)
+	// 接收者就是请求里的接收者，不需要解密
+	res.Receiver = receipt.Receiver
+	return res, created, nil
+}
+
+func (r *readReceiptRepository) NotificationStats(ctx context.Context, notificationID uint64) (domain.ReadStats, error) {
+	stats, err := r.dao.NotificationStats(ctx, notificationID)
+	return domain.ReadStats(stats), err
+}
+
+func (r *readReceiptRepository) TemplateStats(ctx context.Context, query domain.StatsQuery, templateID int64) (domain.ReadStats, error) {
+	stats, err := r.dao.TemplateStats(ctx, query.BizID, templateID, query.Start.UnixMilli(), query.End.UnixMilli())
+	return domain.ReadStats(stats), err
+}
+
+func (r *readReceiptRepository) FindPendingCallbacks(ctx context.Context, limit int) ([]domain.ReadReceipt, error) {
+	reads, err := r.dao.FindPendingCallbacks(ctx, time.Now().UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ReadReceipt, 0, len(reads))
+	for _, read := range reads {
+		receipt := r.toDomain(read)
+		receipt.Receiver, err = r.cipher.Decrypt(ctx, read.Receiver)
+		if err != nil {
+			return nil, fmt.Errorf("解密接收者失败: id = %d: %w", read.ID, err)
+		}
+		res = append(res, receipt)
+	}
+	return res, nil
+}
+
+func (r *readReceiptRepository) UpdateCallback(ctx context.Context, receipt domain.ReadReceipt) error {
+	return r.dao.UpdateCallback(ctx, dao.NotificationRead{
+		ID:             receipt.ID,
+		CallbackStatus: receipt.CallbackStatus.String(),
+		RetryCount:     receipt.RetryCount,
+		NextRetryTime:  receipt.NextRetryTime,
+	})
+}
+
+func (r *readReceiptRepository) toDomain(read dao.NotificationRead) domain.ReadReceipt {
+	return domain.ReadReceipt{
+		ID:             read.ID,
+		NotificationID: read.NotificationID,
+		BizID:          read.BizID,
+		Key:            read.Key,
+		TemplateID:     read.TemplateID,
+		ReadTime:       time.UnixMilli(read.ReadTime),
+		CallbackStatus: domain.ReadCallbackStatus(read.CallbackStatus),
+		RetryCount:     read.RetryCount,
+		NextRetryTime:  read.NextRetryTime,
+	}
+}
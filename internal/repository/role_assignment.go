@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// RoleAssignmentRepository 角色分配仓储
+type RoleAssignmentRepository interface {
+	// Assign 分配角色，已经有角色时覆盖
+	Assign(ctx context.Context, assignment domain.RoleAssignment) error
+	// Revoke 撤销角色，记录不存在时返回 domain.ErrRoleAssignmentNotFound
+	Revoke(ctx context.Context, principal string, bizID int64) error
+	Get(ctx context.Context, principal string, bizID int64) (domain.RoleAssignment, error)
+	FindByPrincipal(ctx context.Context, principal string) ([]domain.RoleAssignment, error)
+	ListByBizID(ctx context.Context, bizID int64) ([]domain.RoleAssignment, error)
+}
+
+var _ RoleAssignmentRepository = (*roleAssignmentRepository)(nil)
+
+func NewRoleAssignmentRepository(d dao.RoleAssignmentDAO) RoleAssignmentRepository {
+	return &roleAssignmentRepository{dao: d}
+}
+
+type roleAssignmentRepository struct {
+	dao dao.RoleAssignmentDAO
+}
+
+func (r *roleAssignmentRepository) Assign(ctx context.Context, assignment domain.RoleAssignment) error {
+	return r.dao.Upsert(ctx, dao.RoleAssignment{
+		Principal: assignment.Principal,
+		BizID:     assignment.BizID,
+		Role:      assignment.Role.String(),
+		Operator:  assignment.Operator,
+	})
+}
+
+func (r *roleAssignmentRepository) Revoke(ctx context.Context, principal string, bizID int64) error {
+	deleted, err := r.dao.Delete(ctx, principal, bizID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: principal=%s biz_id=%d", domain.ErrRoleAssignmentNotFound, principal, bizID)
+	}
+	return nil
+}
+
+func (r *roleAssignmentRepository) Get(ctx context.Context, principal string, bizID int64) (domain.RoleAssignment, error) {
+	a, err := r.dao.Get(ctx, principal, bizID)
+	if err != nil {
+		return domain.RoleAssignment{}, err
+	}
+	return r.toDomain(a), nil
+}
+
+func (r *roleAssignmentRepository) FindByPrincipal(ctx context.Context, principal string) ([]domain.RoleAssignment, error) {
+	as, err := r.dao.FindByPrincipal(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(as), nil
+}
+
+func (r *roleAssignmentRepository) ListByBizID(ctx context.Context, bizID int64) ([]domain.RoleAssignment, error) {
+	as, err := r.dao.ListByBizID(ctx, bizID)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomains(as), nil
+}
+
+func (r *roleAssignmentRepository) toDomains(as []dao.RoleAssignment) []domain.RoleAssignment {
+	result := make([]domain.RoleAssignment, 0, len(as))
+	for i := range as {
+		result = append(result, r.toDomain(as[i]))
+	}
+	return result
+}
+
+func (r *roleAssignmentRepository) toDomain(a dao.RoleAssignment) domain.RoleAssignment {
+	return domain.RoleAssignment{
+		ID:        a.ID,
+		Principal: a.Principal,
+		BizID:     a.BizID,
+		Role:      domain.Role(a.Role),
+		Operator:  a.Operator,
+		Ctime:     time.UnixMilli(a.Ctime),
+		Utime:     time.UnixMilli(a.Utime),
+	}
+}
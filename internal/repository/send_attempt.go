@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// SendAttemptRepository 发送尝试仓储接口
+type SendAttemptRepository interface {
+	// Create 创建发送尝试，幂等键已存在时返回 domain.ErrSendAttemptDuplicate
+	Create(ctx context.Context, attempt domain.SendAttempt) (domain.SendAttempt, error)
+	GetByIdempotencyKey(ctx context.Context, key string) (domain.SendAttempt, error)
+	// CASStatus 状态为 from 时才更新为 to，同时记录调用结果，result 里为空的字段不更新
+	CASStatus(ctx context.Context, id int64, from, to domain.SendAttemptStatus, result domain.SendAttemptResult) error
+	// List 按条件查询发送尝试，排查投递问题和核对供应商账单使用
+	List(ctx context.Context, filter domain.SendAttemptFilter) ([]domain.SendAttempt, error)
+}
+
+type sendAttemptRepository struct {
+	dao dao.SendAttemptDAO
+}
+
+func NewSendAttemptRepository(d dao.SendAttemptDAO) SendAttemptRepository {
+	return &sendAttemptRepository{dao: d}
+}
+
+func (r *sendAttemptRepository) Create(ctx context.Context, attempt domain.SendAttempt) (domain.SendAttempt, error) {
+	created, err := r.dao.Create(ctx, dao.SendAttempt{
+		NotificationID: attempt.NotificationID,
+		Attempt:        attempt.Attempt,
+		IdempotencyKey: attempt.IdempotencyKey,
+		Status:         attempt.Status.String(),
+		MessageID:      attempt.MessageID,
+		Provider:       attempt.Provider,
+		RequestDigest:  attempt.RequestDigest,
+	})
+	if err != nil {
+		return domain.SendAttempt{}, err
+	}
+	return r.toDomain(created), nil
+}
+
+func (r *sendAttemptRepository) GetByIdempotencyKey(ctx context.Context, key string) (domain.SendAttempt, error) {
+	attempt, err := r.dao.GetByIdempotencyKey(ctx, key)
+	if err != nil {
+		return domain.SendAttempt{}, err
+	}
+	return r.toDomain(attempt), nil
+}
+
+func (r *sendAttemptRepository) CASStatus(ctx context.Context, id int64, from, to domain.SendAttemptStatus, result domain.SendAttemptResult) error {
+	return r.dao.CASStatus(ctx, id, from.String(), to.String(), dao.SendAttemptResult{
+		MessageID:    result.MessageID,
+		ResponseCode: result.ResponseCode,
+		LatencyMs:    result.Latency.Milliseconds(),
+		Error:        result.Error,
+	})
+}
+
+func (r *sendAttemptRepository) List(ctx context.Context, filter domain.SendAttemptFilter) ([]domain.SendAttempt, error) {
+	attempts, err := r.dao.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.SendAttempt, 0, len(attempts))
+	for _, a := range attempts {
+		res = append(res, r.toDomain(a))
+	}
+	return res, nil
+}
+
+func (r *sendAttemptRepository) toDomain(a dao.SendAttempt) domain.SendAttempt {
+	return domain.SendAttempt{
+		ID:             a.ID,
+		NotificationID: a.NotificationID,
+		Attempt:        a.Attempt,
+		IdempotencyKey: a.IdempotencyKey,
+		Status:         domain.SendAttemptStatus(a.Status),
+		MessageID:      a.MessageID,
+		Provider:       a.Provider,
+		RequestDigest:  a.RequestDigest,
+		ResponseCode:   a.ResponseCode,
+		Latency:        time.Duration(a.LatencyMs) * time.Millisecond,
+		Error:          a.Error,
+		Ctime:          a.Ctime,
+		Utime:          a.Utime,
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// sloCallbackKey 回调不区分业务方，整体作为一个统计对象
+const sloCallbackKey = "all"
+
+// SLORepository 服务等级目标的事件数量
+type SLORepository interface {
+	// FindCounts 统计 since 之后 kind 对应的事件数量，发送和供应商按小时预聚合，since 会向前取整到小时
+	FindCounts(ctx context.Context, kind domain.SLOKind, since time.Time) ([]domain.SLOCount, error)
+}
+
+var _ SLORepository = (*sloRepository)(nil)
+
+func NewSLORepository(d dao.SLODAO) SLORepository {
+	return &sloRepository{dao: d}
+}
+
+type sloRepository struct {
+	dao dao.SLODAO
+}
+
+func (r *sloRepository) FindCounts(ctx context.Context, kind domain.SLOKind, since time.Time) ([]domain.SLOCount, error) {
+	switch kind {
+	case domain.SLOKindSend:
+		counts, err := r.dao.SendCounts(ctx, since.Truncate(time.Hour).UnixMilli())
+		if err != nil {
+			return nil, err
+		}
+		res := make([]domain.SLOCount, 0, len(counts))
+		for _, c := range counts {
+			res = append(res, domain.SLOCount{Key: strconv.FormatInt(c.BizID, 10), Good: c.Good, Total: c.Total})
+		}
+		return res, nil
+	case domain.SLOKindProvider:
+		counts, err := r.dao.ProviderCounts(ctx, since.Truncate(time.Hour).UnixMilli())
+		if err != nil {
+			return nil, err
+		}
+		res := make([]domain.SLOCount, 0, len(counts))
+		for _, c := range counts {
+			res = append(res, domain.SLOCount{Key: c.Provider, Good: c.Good, Total: c.Total})
+		}
+		return res, nil
+	case domain.SLOKindCallback:
+		good, total, err := r.dao.CallbackCounts(ctx, since.UnixMilli())
+		if err != nil {
+			return nil, err
+		}
+		return []domain.SLOCount{{Key: sloCallbackKey, Good: good, Total: total}}, nil
+	default:
+		return nil, nil
+	}
+}
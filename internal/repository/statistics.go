@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// StatisticsRepository 按小时预聚合的发送统计
+type StatisticsRepository interface {
+	// RollupHour 重新统计 hour 所在小时内创建的通知和发送尝试
+	RollupHour(ctx context.Context, hour time.Time) error
+	// FindSendStats 查询时间范围内的通知小时统计
+	FindSendStats(ctx context.Context, query domain.StatsQuery) ([]domain.SendStat, error)
+	// FindProviderStats 查询时间范围内的供应商小时统计
+	FindProviderStats(ctx context.Context, query domain.StatsQuery) ([]domain.ProviderStat, error)
+}
+
+var _ StatisticsRepository = (*statisticsRepository)(nil)
+
+func NewStatisticsRepository(d dao.StatisticsDAO) StatisticsRepository {
+	return &statisticsRepository{dao: d}
+}
+
+type statisticsRepository struct {
+	dao dao.StatisticsDAO
+}
+
+func (r *statisticsRepository) RollupHour(ctx context.Context, hour time.Time) error {
+	return r.dao.RollupHour(ctx, hour.Truncate(time.Hour).UnixMilli())
+}
+
+func (r *statisticsRepository) FindSendStats(ctx context.Context, query domain.StatsQuery) ([]domain.SendStat, error) {
+	env := cmp.Or(query.Environment, domain.EnvironmentProduction)
+	stats, err := r.dao.FindSendStats(ctx, query.BizID, query.Start.UnixMilli(), query.End.UnixMilli(),
+		query.Channel.String(), env.String())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.SendStat, 0, len(stats))
+	for _, s := range stats {
+		res = append(res, domain.SendStat{
+			BizID:      s.BizID,
+			Hour:       time.UnixMilli(s.StatHour),
+			Channel:    domain.Channel(s.Channel),
+			Status:     domain.SendStatus(s.Status),
+			TemplateID: s.TemplateID,
+			Count:      s.Cnt,
+		})
+	}
+	return res, nil
+}
+
+func (r *statisticsRepository) FindProviderStats(ctx context.Context, query domain.StatsQuery) ([]domain.ProviderStat, error) {
+	if query.Environment.IsSandbox() {
+		return nil, nil
+	}
+	stats, err := r.dao.FindProviderStats(ctx, query.BizID, query.Start.UnixMilli(), query.End.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ProviderStat, 0, len(stats))
+	for _, s := range stats {
+		res = append(res, domain.ProviderStat{
+			BizID:      s.BizID,
+			Hour:       time.UnixMilli(s.StatHour),
+			Channel:    domain.Channel(s.Channel),
+			Provider:   s.Provider,
+			Dispatched: s.Dispatched,
+			Failed:     s.Failed,
+		})
+	}
+	return res, nil
+}
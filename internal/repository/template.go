@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// ChannelTemplateRepository 渠道模板仓储接口
+type ChannelTemplateRepository interface {
+	// GetByID 获取模板及其所有版本，版本带上供应商审核记录
+	GetByID(ctx context.Context, templateID int64) (domain.ChannelTemplate, error)
+	// ReviewVersion 内部审核，只能审核待审核的版本，审核通过时同时创建供应商审核记录
+	ReviewVersion(ctx context.Context, version domain.ChannelTemplateVersion) error
+	// ForkVersion 保存 domain.ChannelTemplate.Fork 拷贝的版本并设置为生效版本，返回带上ID的版本
+	// 模板的生效版本已经不是 activeVersionID 时返回 domain.ErrForkVersionFailed
+	ForkVersion(ctx context.Context, version domain.ChannelTemplateVersion, activeVersionID int64) (domain.ChannelTemplateVersion, error)
+	// FindProvidersToCheck 到了提交或者查询时间的供应商审核记录
+	FindProvidersToCheck(ctx context.Context, limit int) ([]domain.ChannelTemplateProvider, error)
+	// UpdateProvider 更新供应商审核记录，只能更新还没有结果的记录
+	UpdateProvider(ctx context.Context, provider domain.ChannelTemplateProvider) error
+	// GetProviderByProviderTemplateID 按供应商返回的模板ID查找审核记录
+	GetProviderByProviderTemplateID(ctx context.Context, providerName, providerTemplateID string) (domain.ChannelTemplateProvider, error)
+}
+
+type channelTemplateRepository struct {
+	dao dao.ChannelTemplateDAO
+}
+
+func NewChannelTemplateRepository(d dao.ChannelTemplateDAO) ChannelTemplateRepository {
+	return &channelTemplateRepository{dao: d}
+}
+
+func (r *channelTemplateRepository) GetByID(ctx context.Context, templateID int64) (domain.ChannelTemplate, error) {
+	template, err := r.dao.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return domain.ChannelTemplate{}, err
+	}
+	versions, err := r.dao.GetVersionsByTemplateIDs(ctx, []int64{templateID})
+	if err != nil {
+		return domain.ChannelTemplate{}, fmt.Errorf("查询模板版本失败: %w", err)
+	}
+	versionIDs := make([]int64, 0, len(versions))
+	for i := range versions {
+		versionIDs = append(versionIDs, versions[i].ID)
+	}
+	providers, err := r.dao.GetProvidersByVersionIDs(ctx, versionIDs)
+	if err != nil {
+		return domain.ChannelTemplate{}, fmt.Errorf("查询模板供应商审核记录失败: %w", err)
+	}
+	providersByVersion := make(map[int64][]domain.ChannelTemplateProvider, len(versions))
+	for _, p := range providers {
+		providersByVersion[p.TemplateVersionID] = append(providersByVersion[p.TemplateVersionID], r.toDomainProvider(p))
+	}
+	res := r.toDomainTemplate(template)
+	res.Versions = make([]domain.ChannelTemplateVersion, 0, len(versions))
+	for i := range versions {
+		version, err := r.toDomainVersion(versions[i])
+		if err != nil {
+			return domain.ChannelTemplate{}, err
+		}
+		version.Providers = providersByVersion[version.ID]
+		res.Versions = append(res.Versions, version)
+	}
+	return res, nil
+}
+
+func (r *channelTemplateRepository) ReviewVersion(ctx context.Context, version domain.ChannelTemplateVersion) error {
+	providers := make([]dao.ChannelTemplateProvider, 0, len(version.Providers))
+	for _, p := range version.Providers {
+		providers = append(providers, r.toEntityProvider(p))
+	}
+	return r.dao.ReviewVersion(ctx, dao.ChannelTemplateVersion{
+		ID:           version.ID,
+		AuditStatus:  version.AuditStatus.String(),
+		RejectReason: version.RejectReason,
+		Reviewer:     version.Reviewer,
+		ReviewTime:   version.ReviewTime,
+	}, providers)
+}
+
+func (r *channelTemplateRepository) ForkVersion(ctx context.Context, version domain.ChannelTemplateVersion,
+	activeVersionID int64,
+) (domain.ChannelTemplateVersion, error) {
+	// 没有参数定义时保存空字符串，和原版本一样不校验参数
+	var schema []byte
+	if len(version.ParamSchema) > 0 {
+		var err error
+		if schema, err = json.Marshal(version.ParamSchema); err != nil {
+			return domain.ChannelTemplateVersion{}, fmt.Errorf("%w: %w", domain.ErrForkVersionFailed, err)
+		}
+	}
+	providers := make([]dao.ChannelTemplateProvider, 0, len(version.Providers))
+	for _, p := range version.Providers {
+		providers = append(providers, r.toEntityProvider(p))
+	}
+	created, err := r.dao.ForkVersion(ctx, dao.ChannelTemplateVersion{
+		ChannelTemplateID:   version.ChannelTemplateID,
+		Name:                version.Name,
+		Signature:           version.Signature,
+		Content:             version.Content,
+		Remark:              version.Remark,
+		ParamSchema:         string(schema),
+		AuditStatus:         version.AuditStatus.String(),
+		RejectReason:        version.RejectReason,
+		Reviewer:            version.Reviewer,
+		ReviewTime:          version.ReviewTime,
+		ForkedFromVersionID: version.ForkedFromVersionID,
+	}, providers, activeVersionID)
+	if err != nil {
+		return domain.ChannelTemplateVersion{}, err
+	}
+	res, err := r.toDomainVersion(created)
+	if err != nil {
+		return domain.ChannelTemplateVersion{}, err
+	}
+	res.Providers = make([]domain.ChannelTemplateProvider, 0, len(providers))
+	for _, p := range providers {
+		res.Providers = append(res.Providers, r.toDomainProvider(p))
+	}
+	return res, nil
+}
+
+func (r *channelTemplateRepository) FindProvidersToCheck(ctx context.Context, limit int) ([]domain.ChannelTemplateProvider, error) {
+	providers, err := r.dao.FindProvidersToCheck(ctx, time.Now().UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ChannelTemplateProvider, 0, len(providers))
+	for _, p := range providers {
+		res = append(res, r.toDomainProvider(p))
+	}
+	return res, nil
+}
+
+func (r *channelTemplateRepository) UpdateProvider(ctx context.Context, provider domain.ChannelTemplateProvider) error {
+	return r.dao.UpdateProvider(ctx, r.toEntityProvider(provider))
+}
+
+func (r *channelTemplateRepository) GetProviderByProviderTemplateID(ctx context.Context, providerName, providerTemplateID string) (domain.ChannelTemplateProvider, error) {
+	p, err := r.dao.GetProviderByProviderTemplateID(ctx, providerName, providerTemplateID)
+	if err != nil {
+		return domain.ChannelTemplateProvider{}, err
+	}
+	return r.toDomainProvider(p), nil
+}
+
+func (r *channelTemplateRepository) toDomainProvider(p dao.ChannelTemplateProvider) domain.ChannelTemplateProvider {
+	return domain.ChannelTemplateProvider{
+		ID:                 p.ID,
+		TemplateID:         p.TemplateID,
+		TemplateVersionID:  p.TemplateVersionID,
+		ProviderName:       p.ProviderName,
+		ProviderTemplateID: p.ProviderTemplateID,
+		AuditStatus:        domain.AuditStatus(p.AuditStatus),
+		RejectReason:       p.RejectReason,
+		RetryCount:         p.RetryCount,
+		NextCheckTime:      p.NextCheckTime,
+		Ctime:              p.Ctime,
+		Utime:              p.Utime,
+	}
+}
+
+func (r *channelTemplateRepository) toEntityProvider(p domain.ChannelTemplateProvider) dao.ChannelTemplateProvider {
+	return dao.ChannelTemplateProvider{
+		ID:                 p.ID,
+		TemplateID:         p.TemplateID,
+		TemplateVersionID:  p.TemplateVersionID,
+		ProviderName:       p.ProviderName,
+		ProviderTemplateID: p.ProviderTemplateID,
+		AuditStatus:        p.AuditStatus.String(),
+		RejectReason:       p.RejectReason,
+		RetryCount:         p.RetryCount,
+		NextCheckTime:      p.NextCheckTime,
+	}
+}
+
+func (r *channelTemplateRepository) toDomainTemplate(t dao.ChannelTemplate) domain.ChannelTemplate {
+	return domain.ChannelTemplate{
+		ID:              t.ID,
+		OwnerID:         t.OwnerID,
+		Name:            t.Name,
+		Description:     t.Description,
+		Channel:         domain.Channel(t.Channel),
+		ActiveVersionID: t.ActiveVersionID,
+		Ctime:           t.Ctime,
+		Utime:           t.Utime,
+	}
+}
+
+func (r *channelTemplateRepository) toDomainVersion(v dao.ChannelTemplateVersion) (domain.ChannelTemplateVersion, error) {
+	var schema domain.TemplateParamSchema
+	if v.ParamSchema != "" {
+		if err := json.Unmarshal([]byte(v.ParamSchema), &schema); err != nil {
+			return domain.ChannelTemplateVersion{}, fmt.Errorf("解析模板版本 %d 参数定义失败: %w", v.ID, err)
+		}
+	}
+	return domain.ChannelTemplateVersion{
+		ID:                  v.ID,
+		ChannelTemplateID:   v.ChannelTemplateID,
+		Name:                v.Name,
+		Signature:           v.Signature,
+		Content:             v.Content,
+		Remark:              v.Remark,
+		ParamSchema:         schema,
+		AuditStatus:         domain.AuditStatus(v.AuditStatus),
+		RejectReason:        v.RejectReason,
+		Reviewer:            v.Reviewer,
+		ReviewTime:          v.ReviewTime,
+		ForkedFromVersionID: v.ForkedFromVersionID,
+		Ctime:               v.Ctime,
+		Utime:               v.Utime,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// TemplateUsageRepository 模板使用情况
+type TemplateUsageRepository interface {
+	// Rollup 重新汇总 since 之后用过的模板的发送次数和最后使用时间，返回汇总的模板数
+	Rollup(ctx context.Context, since time.Time) (int, error)
+	// GetUsage 查询业务方模板的使用情况，没有用过时返回零值
+	GetUsage(ctx context.Context, bizID, templateID int64) (domain.TemplateUsage, error)
+	// FindUnused 业务方在 before 之前创建、并且 before 之后没有用过的模板
+	FindUnused(ctx context.Context, bizID int64, before time.Time, afterID int64, limit int) ([]domain.UnusedTemplate, error)
+}
+
+var _ TemplateUsageRepository = (*templateUsageRepository)(nil)
+
+func NewTemplateUsageRepository(d dao.TemplateUsageDAO) TemplateUsageRepository {
+	return &templateUsageRepository{dao: d}
+}
+
+type templateUsageRepository struct {
+	dao dao.TemplateUsageDAO
+}
+
+func (r *templateUsageRepository) Rollup(ctx context.Context, since time.Time) (int, error) {
+	return r.dao.Rollup(ctx, since.UnixMilli())
+}
+
+func (r *templateUsageRepository) GetUsage(ctx context.Context, bizID, templateID int64) (domain.TemplateUsage, error) {
+	usage, err := r.dao.FindByTemplateID(ctx, bizID, templateID)
+	if err != nil {
+		return domain.TemplateUsage{}, err
+	}
+	return domain.TemplateUsage{
+		TemplateID:   usage.TemplateID,
+		SendCount:    usage.SendCount,
+		LastUsedTime: usage.LastUsedTime,
+	}, nil
+}
+
+func (r *templateUsageRepository) FindUnused(ctx context.Context, bizID int64, before time.Time, afterID int64, limit int) ([]domain.UnusedTemplate, error) {
+	templates, err := r.dao.FindUnused(ctx, bizID, before.UnixMilli(), afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.UnusedTemplate, 0, len(templates))
+	for _, t := range templates {
+		res = append(res, domain.UnusedTemplate{
+			Template: domain.ChannelTemplate{
+				ID:              t.ID,
+				OwnerID:         t.OwnerID,
+				Name:            t.Name,
+				Description:     t.Description,
+				Channel:         domain.Channel(t.Channel),
+				ActiveVersionID: t.ActiveVersionID,
+				Ctime:           t.Ctime,
+				Utime:           t.Utime,
+			},
+			Usage: domain.TemplateUsage{
+				TemplateID:   t.ID,
+				SendCount:    t.SendCount,
+				LastUsedTime: t.LastUsedTime,
+			},
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository/dao"
+)
+
+// VendorBalanceRepository 供应商余额快照
+type VendorBalanceRepository interface {
+	Save(ctx context.Context, b domain.VendorBalance) error
+	// DeleteBefore 删除 t 之前的快照，每次最多删除 limit 条，返回删除的数量
+	DeleteBefore(ctx context.Context, t time.Time, limit int) (int64, error)
+}
+
+var _ VendorBalanceRepository = (*vendorBalanceRepository)(nil)
+
+func NewVendorBalanceRepository(d dao.VendorBalanceDAO) VendorBalanceRepository {
+	return &vendorBalanceRepository{dao: d}
+}
+
+type vendorBalanceRepository struct {
+	dao dao.VendorBalanceDAO
+}
+
+func (r *vendorBalanceRepository) Save(ctx context.Context, b domain.VendorBalance) error {
+	return r.dao.Create(ctx, dao.VendorBalanceSnapshot{
+		Provider:  b.Provider,
+		Unit:      b.Unit.String(),
+		Remaining: b.Remaining,
+		Total:     b.Total,
+	})
+}
+
+func (r *vendorBalanceRepository) DeleteBefore(ctx context.Context, t time.Time, limit int) (int64, error) {
+	return r.dao.DeleteBefore(ctx, t.UnixMilli(), limit)
+}
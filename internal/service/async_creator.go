@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+// asyncCreator 平台自己生成的通知（升级链的每一步、合并发送的摘要消息）按异步发送的方式创建，由调度器发送
+type asyncCreator struct {
+	notificationRepo repository.NotificationRepository
+	templateSvc      ChannelTemplateService
+	idGenerator      idgen.Generator
+	clock            clock.Clock
+}
+
+// create 校验模板和参数后创建通知，key 已经存在时返回已有的通知
+func (c asyncCreator) create(ctx context.Context, n domain.Notification) (domain.Notification, error) {
+	if err := c.templateSvc.PrepareTemplate(ctx, &n); err != nil {
+		return domain.Notification{}, err
+	}
+	id, err := c.idGenerator.NextID()
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf("生成通知ID失败: %w", err)
+	}
+	n.ID = id
+	now := c.clock.Now()
+	if err = n.Validate(now); err != nil {
+		return domain.Notification{}, err
+	}
+	n.ReplaceAsyncImmediate(now)
+	n.SetSendTime(now)
+	n.Status = domain.SendStatusPending
+	created, err := c.notificationRepo.Create(ctx, n)
+	if !errors.Is(err, domain.ErrNotificationDuplicate) {
+		return created, err
+	}
+	// 之前已经创建过，上次更新进度失败
+	existing, gerr := c.notificationRepo.GetByKeys(ctx, n.BizID, n.Key)
+	if gerr != nil {
+		return domain.Notification{}, gerr
+	}
+	if len(existing) == 0 {
+		return domain.Notification{}, err
+	}
+	return existing[0], nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	schedulerBatchSizeGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "scheduler_batch_size",
+		Help: "Current batch size used by the scheduler to find ready notifications",
+	})
+	schedulerIntervalGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "scheduler_poll_interval_seconds",
+		Help: "Current interval between scheduler polls",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(schedulerBatchSizeGauge, schedulerIntervalGauge)
+}
+
+// BatchController 决定调度器每批查询多少条通知、多久扫描一次
+type BatchController interface {
+	BatchSize() int
+	Interval() time.Duration
+	// Record 记录一批的处理结果，found 是查到的通知数，latency 是查询加分发的耗时
+	Record(found int, latency time.Duration, err error)
+}
+
+var _ BatchController = (*fixedBatchController)(nil)
+
+// NewFixedBatchController 固定的批次大小和扫描间隔
+func NewFixedBatchController(batchSize int, interval time.Duration) BatchController {
+	schedulerBatchSizeGauge.Set(float64(batchSize))
+	schedulerIntervalGauge.Set(interval.Seconds())
+	return &fixedBatchController{batchSize: batchSize, interval: interval}
+}
+
+type fixedBatchController struct {
+	batchSize int
+	interval  time.Duration
+}
+
+func (c *fixedBatchController) BatchSize() int {
+	return c.batchSize
+}
+
+func (c *fixedBatchController) Interval() time.Duration {
+	return c.interval
+}
+
+func (c *fixedBatchController) Record(int, time.Duration, error) {}
+
+// AdaptiveBatchOptions 自适应批次参数
+type AdaptiveBatchOptions struct {
+	MinBatchSize int
+	MaxBatchSize int
+	MinInterval  time.Duration
+	MaxInterval  time.Duration
+	// TargetLatency 一批的期望处理耗时，超过就缩小批次
+	TargetLatency time.Duration
+}
+
+var _ BatchController = (*adaptiveBatchController)(nil)
+
+// adaptiveBatchController 加性增、乘性减：
+// 批次满且耗时低于目标时逐步加大批次，耗时超过目标缩小四分之一，出错直接减半并拉长扫描间隔；
+// 批次不满说明积压已经处理完，逐步拉长扫描间隔，批次满了说明有积压，扫描间隔回到下限
+type adaptiveBatchController struct {
+	opts AdaptiveBatchOptions
+	step int
+
+	mu        sync.Mutex
+	batchSize int
+	interval  time.Duration
+}
+
+// NewAdaptiveBatchController 批次大小和扫描间隔在上下限之间调整，初始值 batchSize、interval 会被限制在上下限内
+func NewAdaptiveBatchController(opts AdaptiveBatchOptions, batchSize int, interval time.Duration) BatchController {
+	c := &adaptiveBatchController{
+		opts: opts,
+		// 从下限加到上限大约需要 20 批
+		step: max((opts.MaxBatchSize-opts.MinBatchSize)/20, 1),
+	}
+	c.set(batchSize, interval)
+	return c
+}
+
+func (c *adaptiveBatchController) BatchSize() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.batchSize
+}
+
+func (c *adaptiveBatchController) Interval() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.interval
+}
+
+func (c *adaptiveBatchController) Record(found int, latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	batchSize, interval := c.batchSize, c.interval
+	switch {
+	case err != nil:
+		batchSize, interval = batchSize/2, interval*2
+	case latency > c.opts.TargetLatency:
+		batchSize -= batchSize / 4
+	case found >= batchSize:
+		batchSize, interval = batchSize+c.step, c.opts.MinInterval
+	default:
+		interval += interval / 2
+	}
+	c.set(batchSize, interval)
+}
+
+func (c *adaptiveBatchController) set(batchSize int, interval time.Duration) {
+	c.batchSize = min(max(batchSize, c.opts.MinBatchSize), c.opts.MaxBatchSize)
+	c.interval = min(max(interval, c.opts.MinInterval), c.opts.MaxInterval)
+	schedulerBatchSizeGauge.Set(float64(c.batchSize))
+	schedulerIntervalGauge.Set(c.interval.Seconds())
+}
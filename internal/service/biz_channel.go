@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var bizChannelRejectedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_channel_disabled_total",
+	Help: "Total number of notifications rejected because the channel is disabled for the business",
+}, []string{"channel"})
+
+func init() {
+	prometheus.MustRegister(bizChannelRejectedCounter)
+}
+
+// BizChannelService 业务方渠道开关，平台运维可以关闭某个业务方的渠道，关闭之后这个渠道的通知在校验时被拒绝
+type BizChannelService interface {
+	// Check 渠道被关闭时返回 domain.ErrChannelDisabled；查询开关失败时放行，不因为开关不可用影响发送
+	Check(ctx context.Context, bizID int64, channel domain.Channel) error
+	// List 业务方所有渠道的开关，没有设置过的渠道也返回，默认允许
+	List(ctx context.Context, bizID int64) ([]domain.BizChannelSetting, error)
+	// SetEnabled 打开或者关闭渠道，立即对所有实例生效
+	SetEnabled(ctx context.Context, setting domain.BizChannelSetting) error
+}
+
+var _ BizChannelService = (*bizChannelService)(nil)
+
+func NewBizChannelService(repo repository.BizChannelRepository) BizChannelService {
+	return &bizChannelService{
+		repo:   repo,
+		logger: log.Named(log.DefaultLogger(), "service.biz_channel"),
+	}
+}
+
+type bizChannelService struct {
+	repo   repository.BizChannelRepository
+	logger log.LoggerInterface
+}
+
+func (s *bizChannelService) Check(ctx context.Context, bizID int64, channel domain.Channel) error {
+	channels, err := s.repo.Get(ctx, bizID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("查询渠道开关失败，放行", zap.Error(err), zap.Int64("biz_id", bizID))
+		return nil
+	}
+	err = channels.Check(channel)
+	if errors.Is(err, domain.ErrChannelDisabled) {
+		bizChannelRejectedCounter.WithLabelValues(channel.String()).Inc()
+	}
+	return err
+}
+
+func (s *bizChannelService) List(ctx context.Context, bizID int64) ([]domain.BizChannelSetting, error) {
+	if bizID <= 0 {
+		return nil, fmt.Errorf("%w: biz_id = %d", domain.ErrInvalidParameter, bizID)
+	}
+	channels, err := s.repo.Get(ctx, bizID)
+	if err != nil {
+		return nil, err
+	}
+	all := []domain.Channel{domain.ChannelSMS, domain.ChannelEmail, domain.ChannelInApp}
+	res := make([]domain.BizChannelSetting, 0, len(all))
+	for _, c := range all {
+		res = append(res, channels.Setting(c))
+	}
+	return res, nil
+}
+
+func (s *bizChannelService) SetEnabled(ctx context.Context, setting domain.BizChannelSetting) error {
+	if setting.BizID <= 0 {
+		return fmt.Errorf("%w: biz_id = %d", domain.ErrInvalidParameter, setting.BizID)
+	}
+	if !setting.Channel.IsValid() {
+		return fmt.Errorf("%w: channel = %q", domain.ErrInvalidParameter, setting.Channel)
+	}
+	if len(setting.Reason) > 256 {
+		return fmt.Errorf("%w: 原因不能超过 256 个字符", domain.ErrInvalidParameter)
+	}
+	if err := s.repo.Set(ctx, setting); err != nil {
+		return err
+	}
+	s.logger.WithContext(ctx).Info("修改渠道开关", zap.Int64("biz_id", setting.BizID),
+		zap.String("channel", setting.Channel.String()), zap.Bool("enabled", setting.Enabled),
+		zap.String("operator", setting.Operator), zap.String("reason", setting.Reason))
+	return nil
+}
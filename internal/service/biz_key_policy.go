@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// MaxGeneratedKeys 一次最多生成的 key 数量
+const MaxGeneratedKeys = 100
+
+var keyRejectedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_key_rejected_total",
+	Help: "Total number of notifications rejected because the key violates the business key policy",
+}, []string{"rule"})
+
+func init() {
+	prometheus.MustRegister(keyRejectedCounter)
+}
+
+// BizKeyPolicyService 业务方通知 key 的规则，发送时校验长度、字符集和前缀，不想自己管理 key 的业务方可以让平台生成
+type BizKeyPolicyService interface {
+	// Check key 不符合规则时返回 domain.ErrInvalidParameter；查询规则失败时放行，不因为规则不可用影响发送
+	Check(ctx context.Context, bizID int64, key string) error
+	// Get 业务方生效的规则，没有设置过时返回默认规则
+	Get(ctx context.Context, bizID int64) (domain.NotificationKeyPolicy, error)
+	// Set 修改规则，立即对所有实例生效，已经创建的通知不受影响
+	Set(ctx context.Context, policy domain.NotificationKeyPolicy) error
+	// GenerateKeys 生成 count 个符合规则的唯一 key，平台不记录生成过的 key
+	GenerateKeys(ctx context.Context, bizID int64, count int) ([]string, error)
+}
+
+var _ BizKeyPolicyService = (*bizKeyPolicyService)(nil)
+
+func NewBizKeyPolicyService(repo repository.BizKeyPolicyRepository, idGenerator idgen.Generator) BizKeyPolicyService {
+	return &bizKeyPolicyService{
+		repo:        repo,
+		idGenerator: idGenerator,
+		logger:      log.Named(log.DefaultLogger(), "service.biz_key_policy"),
+	}
+}
+
+type bizKeyPolicyService struct {
+	repo        repository.BizKeyPolicyRepository
+	idGenerator idgen.Generator
+	logger      log.LoggerInterface
+}
+
+func (s *bizKeyPolicyService) Check(ctx context.Context, bizID int64, key string) error {
+	policy, err := s.repo.Get(ctx, bizID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("查询 key 规则失败，放行", zap.Error(err), zap.Int64("biz_id", bizID))
+		return nil
+	}
+	if rule := policy.Violation(key); rule != "" {
+		keyRejectedCounter.WithLabelValues(rule).Inc()
+		return policy.Check(key)
+	}
+	return nil
+}
+
+func (s *bizKeyPolicyService) Get(ctx context.Context, bizID int64) (domain.NotificationKeyPolicy, error) {
+	if bizID <= 0 {
+		return domain.NotificationKeyPolicy{}, fmt.Errorf("%w: biz_id = %d", domain.ErrInvalidParameter, bizID)
+	}
+	policy, err := s.repo.Get(ctx, bizID)
+	if err != nil {
+		return domain.NotificationKeyPolicy{}, err
+	}
+	return s.effective(policy), nil
+}
+
+func (s *bizKeyPolicyService) Set(ctx context.Context, policy domain.NotificationKeyPolicy) error {
+	if policy.BizID <= 0 {
+		return fmt.Errorf("%w: biz_id = %d", domain.ErrInvalidParameter, policy.BizID)
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	policy = s.effective(policy)
+	if err := s.repo.Set(ctx, policy); err != nil {
+		return err
+	}
+	s.logger.WithContext(ctx).Info("修改 key 规则", zap.Int64("biz_id", policy.BizID),
+		zap.Int("max_length", policy.MaxLength), zap.String("charset", policy.Charset.String()),
+		zap.String("required_prefix", policy.RequiredPrefix), zap.String("operator", policy.Operator))
+	return nil
+}
+
+func (s *bizKeyPolicyService) GenerateKeys(ctx context.Context, bizID int64, count int) ([]string, error) {
+	if bizID <= 0 {
+		return nil, fmt.Errorf("%w: biz_id = %d", domain.ErrInvalidParameter, bizID)
+	}
+	if count <= 0 || count > MaxGeneratedKeys {
+		return nil, fmt.Errorf("%w: 一次生成的 key 数量必须在 1 到 %d 之间", domain.ErrInvalidParameter, MaxGeneratedKeys)
+	}
+	policy, err := s.repo.Get(ctx, bizID)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, count)
+	for range count {
+		id, err := s.idGenerator.NextID()
+		if err != nil {
+			return nil, err
+		}
+		// 前缀和长度上限在修改规则时校验过，这里出错说明数据库里的规则被直接修改过
+		key, err := policy.GenerateKey(id)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// effective 把默认值补全，返回给调用方的规则和校验时使用的一致
+func (s *bizKeyPolicyService) effective(policy domain.NotificationKeyPolicy) domain.NotificationKeyPolicy {
+	policy.MaxLength = policy.Limit()
+	if policy.Charset == "" {
+		policy.Charset = domain.KeyCharsetAny
+	}
+	return policy
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/anomaly"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// CallbackDeadLetterService 重试次数用完的发送结果回调进入死信，不会再自动回调
+// 平台管理员排查之后重新回调，每天按业务方汇总告警，保证发送结果不会悄悄丢失
+type CallbackDeadLetterService interface {
+	// List 按ID升序查询死信记录，返回的 Notification 只有ID和业务方
+	List(ctx context.Context, filter domain.CallbackDeadLetterFilter) ([]domain.CallbackLog, error)
+	// Resend 业务方的死信记录重新开始回调，notificationIDs 为空时重新回调全部，返回重新回调的数量
+	Resend(ctx context.Context, bizID int64, notificationIDs []uint64) (int64, error)
+	// Summarize 按业务方汇总 since 之后进入死信的记录并告警，返回告警的业务方数量
+	Summarize(ctx context.Context, since time.Time) (int, error)
+}
+
+var _ CallbackDeadLetterService = (*callbackDeadLetterService)(nil)
+
+const (
+	// maxCallbackResendIDs 一次最多指定多少条通知重新回调
+	maxCallbackResendIDs = 1000
+	// callbackDeadLetterAlertLines 告警正文最多列出多少条记录
+	callbackDeadLetterAlertLines = 20
+)
+
+// NewCallbackDeadLetterService limit 是每次汇总最多查询的死信记录数量，notice 为 nil 时不通知业务方
+func NewCallbackDeadLetterService(repo repository.CallbackLogRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc ChannelTemplateService,
+	idGenerator idgen.Generator,
+	clk clock.Clock,
+	alerters []anomaly.TextAlerter,
+	notice *domain.CallbackDeadLetterNotice,
+	limit int,
+) CallbackDeadLetterService {
+	return &callbackDeadLetterService{
+		repo: repo,
+		creator: asyncCreator{
+			notificationRepo: notificationRepo,
+			templateSvc:      templateSvc,
+			idGenerator:      idGenerator,
+			clock:            clk,
+		},
+		alerters: alerters,
+		notice:   notice,
+		limit:    limit,
+		logger:   log.Named(log.DefaultLogger(), "service.callback_dead_letter"),
+	}
+}
+
+type callbackDeadLetterService struct {
+	repo     repository.CallbackLogRepository
+	creator  asyncCreator
+	alerters []anomaly.TextAlerter
+	notice   *domain.CallbackDeadLetterNotice
+	limit    int
+	logger   log.LoggerInterface
+}
+
+func (s *callbackDeadLetterService) List(ctx context.Context, filter domain.CallbackDeadLetterFilter) ([]domain.CallbackLog, error) {
+	return s.repo.FindDeadLetters(ctx, filter)
+}
+
+func (s *callbackDeadLetterService) Resend(ctx context.Context, bizID int64, notificationIDs []uint64) (int64, error) {
+	if bizID <= 0 {
+		return 0, fmt.Errorf("%w: bizID = %d", domain.ErrInvalidParameter, bizID)
+	}
+	if len(notificationIDs) > maxCallbackResendIDs {
+		return 0, fmt.Errorf("%w: 一次最多重新回调 %d 条通知", domain.ErrInvalidParameter, maxCallbackResendIDs)
+	}
+	return s.repo.ResendDeadLetters(ctx, bizID, notificationIDs)
+}
+
+func (s *callbackDeadLetterService) Summarize(ctx context.Context, since time.Time) (int, error) {
+	logs, err := s.repo.FindDeadLetters(ctx, domain.CallbackDeadLetterFilter{
+		UpdatedSince: since.UnixMilli(),
+		Limit:        s.limit,
+	})
+	if err != nil {
+		return 0, err
+	}
+	byBiz := make(map[int64][]domain.CallbackLog)
+	var bizIDs []int64
+	for _, l := range logs {
+		bizID := l.Notification.BizID
+		if _, ok := byBiz[bizID]; !ok {
+			bizIDs = append(bizIDs, bizID)
+		}
+		byBiz[bizID] = append(byBiz[bizID], l)
+	}
+	date := since.Format(time.DateOnly)
+	for _, bizID := range bizIDs {
+		s.alert(ctx, bizID, byBiz[bizID], len(logs) >= s.limit)
+		s.notify(ctx, bizID, date, byBiz[bizID])
+	}
+	return len(bizIDs), nil
+}
+
+// alert 发送给平台运维，truncated 表示超过了每次汇总的数量上限，实际的数量更多
+func (s *callbackDeadLetterService) alert(ctx context.Context, bizID int64, logs []domain.CallbackLog, truncated bool) {
+	if len(s.alerters) == 0 {
+		return
+	}
+	count := strconv.Itoa(len(logs))
+	if truncated {
+		count = "至少 " + count
+	}
+	title := fmt.Sprintf("业务方 %d 有 %s 条发送结果没有回调成功", bizID, count)
+	var b strings.Builder
+	for _, l := range logs[:min(len(logs), callbackDeadLetterAlertLines)] {
+		fmt.Fprintf(&b, "通知 %d：%s\n", l.Notification.ID, l.LastError)
+	}
+	if len(logs) > callbackDeadLetterAlertLines {
+		fmt.Fprintf(&b, "……共 %s 条，通过 ListDeadLetterCallbacks 查看全部，排查之后调用 ResendDeadLetterCallbacks 重新回调\n", count)
+	}
+	for _, a := range s.alerters {
+		if err := a.AlertText(ctx, title, b.String()); err != nil {
+			s.logger.WithContext(ctx).Error("发送回调死信告警失败", zap.Error(err), zap.Int64("biz_id", bizID))
+		}
+	}
+}
+
+// notify 用平台自己的模板通知业务方配置的接收者，同一天只通知一次
+func (s *callbackDeadLetterService) notify(ctx context.Context, bizID int64, date string, logs []domain.CallbackLog) {
+	if s.notice == nil {
+		return
+	}
+	n, ok := s.notice.Notification(bizID, date, logs)
+	if !ok {
+		return
+	}
+	if _, err := s.creator.create(ctx, n); err != nil {
+		s.logger.WithContext(ctx).Error("通知业务方回调死信失败", zap.Error(err), zap.Int64("biz_id", bizID))
+	}
+}
+
+// CallbackDeadLetterSummaryTask 每个周期汇总一次上个周期进入死信的回调记录
+type CallbackDeadLetterSummaryTask struct {
+	svc      CallbackDeadLetterService
+	lock     distribute_lock.Client
+	interval time.Duration
+	logger   log.LoggerInterface
+}
+
+func NewCallbackDeadLetterSummaryTask(svc CallbackDeadLetterService, lock distribute_lock.Client, interval time.Duration) *CallbackDeadLetterSummaryTask {
+	return &CallbackDeadLetterSummaryTask{
+		svc:      svc,
+		lock:     lock,
+		interval: interval,
+		logger:   log.Named(log.DefaultLogger(), "service.callback_dead_letter"),
+	}
+}
+
+const callbackDeadLetterSummaryLockKey = "notification:callback-dead-letter-summary:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *CallbackDeadLetterSummaryTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, callbackDeadLetterSummaryLockKey, t.interval, t.runOnce)
+}
+
+func (t *CallbackDeadLetterSummaryTask) runOnce(ctx context.Context) {
+	if _, err := t.svc.Summarize(ctx, time.Now().Add(-t.interval)); err != nil {
+		t.logger.WithContext(ctx).Error("汇总回调死信失败", zap.Error(err))
+	}
+}
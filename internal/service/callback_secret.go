@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+const (
+	callbackSecretBytes = 32
+	// DefaultCallbackSecretGracePeriod 轮换后旧密钥默认继续签名的时间
+	DefaultCallbackSecretGracePeriod = 24 * time.Hour
+	maxCallbackSecretGracePeriod     = 30 * 24 * time.Hour
+)
+
+// CallbackSecretService 业务方回调签名密钥
+type CallbackSecretService interface {
+	// Rotate 生成新密钥，旧密钥在宽限期内继续参与签名，返回值里的新密钥明文只会返回这一次
+	Rotate(ctx context.Context, bizID int64, gracePeriod time.Duration) (domain.CallbackSecret, error)
+	// ActiveSecrets 当前参与签名的密钥，业务方没有生成过密钥时返回 domain.ErrCallbackSecretNotFound
+	ActiveSecrets(ctx context.Context, bizID int64) ([]string, error)
+}
+
+var _ CallbackSecretService = &callbackSecretService{}
+
+func NewCallbackSecretService(repo repository.CallbackSecretRepository) CallbackSecretService {
+	return &callbackSecretService{repo: repo}
+}
+
+type callbackSecretService struct {
+	repo repository.CallbackSecretRepository
+}
+
+func (s *callbackSecretService) Rotate(ctx context.Context, bizID int64, gracePeriod time.Duration) (domain.CallbackSecret, error) {
+	if bizID <= 0 {
+		return domain.CallbackSecret{}, fmt.Errorf("%w: biz_id = %d", domain.ErrInvalidParameter, bizID)
+	}
+	if gracePeriod < 0 || gracePeriod > maxCallbackSecretGracePeriod {
+		return domain.CallbackSecret{}, fmt.Errorf("%w: 宽限期必须在 0 到 %s 之间", domain.ErrInvalidParameter, maxCallbackSecretGracePeriod)
+	}
+	buf := make([]byte, callbackSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return domain.CallbackSecret{}, err
+	}
+	secret := hex.EncodeToString(buf)
+	expireAt := time.Now().Add(gracePeriod)
+	if err := s.repo.Rotate(ctx, bizID, secret, expireAt); err != nil {
+		return domain.CallbackSecret{}, err
+	}
+	return domain.CallbackSecret{
+		BizID:            bizID,
+		Secret:           secret,
+		PreviousExpireAt: expireAt,
+	}, nil
+}
+
+func (s *callbackSecretService) ActiveSecrets(ctx context.Context, bizID int64) ([]string, error) {
+	secret, err := s.repo.Get(ctx, bizID)
+	if err != nil {
+		return nil, err
+	}
+	return secret.ActiveSecrets(time.Now()), nil
+}
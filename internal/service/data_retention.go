@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/encrypt"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRetentionBatchSize = 100
+	// maxEraseRounds 擦除时最多查询的轮数，防止并发修改导致一直擦不完
+	maxEraseRounds = 1000
+)
+
+// DataRetentionService 数据保留和接收者数据擦除
+// 站内信收件箱目前直接读取 IN_APP 渠道的通知，擦除通知也就擦除了收件箱里的记录
+type DataRetentionService interface {
+	// EraseReceiverData 擦除业务方名下某个接收者（手机号/邮箱）的全部数据，并记录本次擦除
+	EraseReceiverData(ctx context.Context, bizID int64, receiver, operator, reason string) (domain.ReceiverErasure, error)
+	// ApplyRetentionPolicies 执行一轮保留策略，返回处理的通知数量
+	ApplyRetentionPolicies(ctx context.Context) (int64, error)
+	// PruneCallbackLogs 删除超过保留时长的已结束回调记录，返回删除的数量
+	PruneCallbackLogs(ctx context.Context) (int64, error)
+}
+
+var _ DataRetentionService = &dataRetentionService{}
+
+// NewDataRetentionService policies 为空时不会清理任何通知，callbackLogRetention 为 0 时不清理回调记录
+func NewDataRetentionService(notificationRepo repository.NotificationRepository,
+	repo repository.DataRetentionRepository,
+	indexer encrypt.BlindIndexer,
+	policies []domain.RetentionPolicy,
+	batchSize int,
+	callbackLogRetention time.Duration,
+	clk clock.Clock,
+) (DataRetentionService, error) {
+	scopes, err := domain.BuildRetentionScopes(policies)
+	if err != nil {
+		return nil, err
+	}
+	if batchSize <= 0 {
+		batchSize = defaultRetentionBatchSize
+	}
+	return &dataRetentionService{
+		notificationRepo: notificationRepo,
+		repo:             repo,
+		indexer:          indexer,
+		scopes:           scopes,
+		batchSize:        batchSize,
+		callbackLogTTL:   callbackLogRetention,
+		clock:            clk,
+		logger:           log.Named(log.DefaultLogger(), "service.data_retention"),
+	}, nil
+}
+
+type dataRetentionService struct {
+	notificationRepo repository.NotificationRepository
+	repo             repository.DataRetentionRepository
+	indexer          encrypt.BlindIndexer
+	scopes           []domain.RetentionScope
+	batchSize        int
+	callbackLogTTL   time.Duration
+	clock            clock.Clock
+	logger           log.LoggerInterface
+}
+
+func (s *dataRetentionService) EraseReceiverData(ctx context.Context, bizID int64, receiver, operator, reason string) (domain.ReceiverErasure, error) {
+	if bizID <= 0 {
+		return domain.ReceiverErasure{}, fmt.Errorf("%w: BizID = %d", domain.ErrInvalidParameter, bizID)
+	}
+	if strings.TrimSpace(receiver) == "" {
+		return domain.ReceiverErasure{}, fmt.Errorf("%w: 接收者不能为空", domain.ErrInvalidParameter)
+	}
+	var affected int64
+	for round := 0; ; round++ {
+		if round >= maxEraseRounds {
+			return domain.ReceiverErasure{}, fmt.Errorf("擦除接收者数据超过 %d 轮仍未完成", maxEraseRounds)
+		}
+		// 擦除后接收者索引会被删掉，所以每轮都从头查
+		notifications, err := s.notificationRepo.FindByReceiver(ctx, bizID, receiver, s.batchSize)
+		if err != nil {
+			return domain.ReceiverErasure{}, err
+		}
+		if len(notifications) == 0 {
+			break
+		}
+		for i := range notifications {
+			err = s.notificationRepo.EraseReceiver(ctx, notifications[i], receiver)
+			if errors.Is(err, domain.ErrNotificationVersionMismatch) {
+				// 通知刚好被修改了，下一轮重新查出来再擦除
+				continue
+			}
+			if err != nil {
+				return domain.ReceiverErasure{}, err
+			}
+			affected++
+		}
+	}
+	return s.repo.CreateErasure(ctx, domain.ReceiverErasure{
+		BizID:                 bizID,
+		ReceiverIndex:         s.indexer.Index(receiver),
+		Operator:              operator,
+		Reason:                reason,
+		AffectedNotifications: affected,
+	})
+}
+
+func (s *dataRetentionService) ApplyRetentionPolicies(ctx context.Context) (int64, error) {
+	var total int64
+	now := s.clock.Now()
+	for _, scope := range s.scopes {
+		cutoff := scope.Policy.Cutoff(now)
+		for {
+			ids, err := s.repo.FindExpiredIDs(ctx, scope, cutoff, s.batchSize)
+			if err != nil {
+				return total, err
+			}
+			if len(ids) == 0 {
+				break
+			}
+			n, err := s.repo.Apply(ctx, scope.Policy.Action, ids)
+			if err != nil {
+				return total, err
+			}
+			total += n
+			if len(ids) < s.batchSize {
+				break
+			}
+		}
+	}
+	return total, nil
+}
+
+func (s *dataRetentionService) PruneCallbackLogs(ctx context.Context) (int64, error) {
+	if s.callbackLogTTL <= 0 {
+		return 0, nil
+	}
+	var total int64
+	cutoff := s.clock.Now().Add(-s.callbackLogTTL)
+	for {
+		n, err := s.repo.DeleteFinishedCallbackLogs(ctx, cutoff, s.batchSize)
+		total += n
+		if err != nil || n < int64(s.batchSize) {
+			return total, err
+		}
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+}
+
+// RetentionTask 定时执行数据保留策略
+type RetentionTask struct {
+	svc      DataRetentionService
+	lock     distribute_lock.Client
+	interval time.Duration
+	logger   log.LoggerInterface
+}
+
+func NewRetentionTask(svc DataRetentionService, lock distribute_lock.Client, interval time.Duration) *RetentionTask {
+	return &RetentionTask{
+		svc:      svc,
+		lock:     lock,
+		interval: interval,
+		logger:   log.Named(log.DefaultLogger(), "service.data_retention"),
+	}
+}
+
+const retentionLockKey = "notification:retention:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *RetentionTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, retentionLockKey, t.interval, t.runOnce)
+}
+
+func (t *RetentionTask) runOnce(ctx context.Context) {
+	n, err := t.svc.ApplyRetentionPolicies(ctx)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("执行数据保留策略失败", zap.Error(err), zap.Int64("processed", n))
+	} else if n > 0 {
+		t.logger.WithContext(ctx).Info("执行数据保留策略", zap.Int64("processed", n))
+	}
+	n, err = t.svc.PruneCallbackLogs(ctx)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("清理回调记录失败", zap.Error(err), zap.Int64("deleted", n))
+	} else if n > 0 {
+		t.logger.WithContext(ctx).Info("清理回调记录", zap.Int64("deleted", n))
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// DigestService 合并发送，同一个接收者在一个合并窗口内的可合并通知，窗口结束后合并成一条摘要消息，
+// 使用业务方配置的摘要模板发送，减少对用户的打扰和供应商费用
+type DigestService interface {
+	// Add 把可合并的通知加入每个接收者当前的合并窗口，重复加入返回 domain.ErrNotificationDuplicate
+	Add(ctx context.Context, n domain.Notification) error
+	// Flush 发送合并窗口已经结束的分组，返回处理的分组数量
+	Flush(ctx context.Context, limit int) (int, error)
+}
+
+var _ DigestService = (*digestService)(nil)
+
+// digestFlushDelay 合并窗口结束之后再等一会儿才发送，窗口结束前开始的 Add 有时间提交
+const digestFlushDelay = 10 * time.Second
+
+type digestPolicyKey struct {
+	bizID   int64
+	channel domain.Channel
+}
+
+func NewDigestService(repo repository.DigestRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc ChannelTemplateService,
+	idGenerator idgen.Generator,
+	clk clock.Clock,
+	policies []domain.DigestPolicy,
+) DigestService {
+	m := make(map[digestPolicyKey]domain.DigestPolicy, len(policies))
+	for _, p := range policies {
+		m[digestPolicyKey{bizID: p.BizID, channel: p.Channel}] = p
+	}
+	return &digestService{
+		repo:        repo,
+		templateSvc: templateSvc,
+		creator: asyncCreator{
+			notificationRepo: notificationRepo,
+			templateSvc:      templateSvc,
+			idGenerator:      idGenerator,
+			clock:            clk,
+		},
+		policies: m,
+		clock:    clk,
+		logger:   log.Named(log.DefaultLogger(), "service.digest"),
+	}
+}
+
+type digestService struct {
+	repo        repository.DigestRepository
+	templateSvc ChannelTemplateService
+	creator     asyncCreator
+	policies    map[digestPolicyKey]domain.DigestPolicy
+	clock       clock.Clock
+	logger      log.LoggerInterface
+}
+
+func (s *digestService) Add(ctx context.Context, n domain.Notification) error {
+	if !n.IsDigest() {
+		return fmt.Errorf("%w: 通知不是可合并的", domain.ErrInvalidParameter)
+	}
+	policy, ok := s.policies[digestPolicyKey{bizID: n.BizID, channel: n.Channel}]
+	if !ok {
+		return fmt.Errorf("%w: 业务方 %d 没有配置 %s 渠道的合并策略", domain.ErrInvalidParameter, n.BizID, n.Channel)
+	}
+	start, end := policy.WindowOf(s.clock.Now())
+	receivers := slices.Compact(slices.Sorted(slices.Values(n.Receivers)))
+	groups := make([]domain.DigestGroup, 0, len(receivers))
+	items := make([]domain.DigestItem, 0, len(receivers))
+	for _, receiver := range receivers {
+		groups = append(groups, domain.DigestGroup{
+			BizID:       n.BizID,
+			Channel:     n.Channel,
+			TemplateID:  policy.TemplateID,
+			Receiver:    receiver,
+			WindowStart: start,
+			WindowEnd:   end,
+		})
+		items = append(items, domain.DigestItem{
+			BizID:    n.BizID,
+			Key:      n.Key,
+			Receiver: receiver,
+			Summary:  n.Digest.Summary,
+		})
+	}
+	// 按一条通知的摘要校验摘要模板，避免窗口结束时才发现发不出去
+	sample := domain.NewDigestNotification(groups[0], 1, items[:1])
+	if err := s.templateSvc.PrepareTemplate(ctx, &sample); err != nil {
+		return fmt.Errorf("摘要模板 %d 不可用: %w", policy.TemplateID, err)
+	}
+	return s.repo.AddItems(ctx, groups, items)
+}
+
+func (s *digestService) Flush(ctx context.Context, limit int) (int, error) {
+	groups, err := s.repo.FindDueGroups(ctx, s.clock.Now().Add(-digestFlushDelay), limit)
+	if err != nil {
+		return 0, err
+	}
+	for i, group := range groups {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		if err := s.send(ctx, group); err != nil {
+			s.logger.WithContext(ctx).Error("发送摘要消息失败", zap.Error(err),
+				zap.Int64("biz_id", group.BizID), zap.Int64("group_id", group.ID))
+		}
+	}
+	return len(groups), nil
+}
+
+func (s *digestService) send(ctx context.Context, group domain.DigestGroup) error {
+	items, err := s.repo.FindItems(ctx, group.ID, domain.MaxDigestLines)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return s.repo.MarkSent(ctx, group.ID, 0)
+	}
+	// 摘要消息的 key 由分组ID生成，上次创建成功但没有标记已发送时不会重复发送
+	n, err := s.creator.create(ctx, domain.NewDigestNotification(group, group.ItemCount, items))
+	if err != nil {
+		return err
+	}
+	return s.repo.MarkSent(ctx, group.ID, n.ID)
+}
+
+// DigestTask 定时发送合并窗口已经结束的摘要消息
+type DigestTask struct {
+	svc       DigestService
+	lock      distribute_lock.Client
+	interval  time.Duration
+	batchSize int
+	logger    log.LoggerInterface
+}
+
+func NewDigestTask(svc DigestService, lock distribute_lock.Client, interval time.Duration, batchSize int) *DigestTask {
+	return &DigestTask{
+		svc:       svc,
+		lock:      lock,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.Named(log.DefaultLogger(), "service.digest"),
+	}
+}
+
+const digestLockKey = "notification:digest:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *DigestTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, digestLockKey, t.interval, t.runOnce)
+}
+
+func (t *DigestTask) runOnce(ctx context.Context) {
+	if _, err := t.svc.Flush(ctx, t.batchSize); err != nil {
+		t.logger.WithContext(ctx).Error("发送摘要消息失败", zap.Error(err))
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/workpool"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"go.uber.org/zap"
+)
+
+var windowClosedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_send_window_closed_total",
+	Help: "Total number of notifications failed before calling a provider because their scheduled send window had closed",
+}, []string{"channel"})
+
+var sendRetryCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_send_retry_total",
+	Help: "Total number of failed sends handled by the retry policy, result is scheduled or exhausted",
+}, []string{"channel", "result"})
+
+func init() {
+	prometheus.MustRegister(windowClosedCounter, sendRetryCounter)
+}
+
+var _ Dispatcher = (*PooledDispatcher)(nil)
+
+// PooledDispatcher 按渠道（可选按供应商）把通知分配到不同的协程池，慢渠道不会占满快渠道的协程
+// 通知入队前先 CAS 成 SENDING，队列满时阻塞调度器，起到背压的作用
+type PooledDispatcher struct {
+	repo          repository.NotificationRepository
+	sender        NotificationSender
+	selector      provider.Selector
+	channelPools  map[domain.Channel]*workpool.Pool
+	providerPools map[string]*workpool.Pool
+	sendTimeout   time.Duration
+	clock         clock.Clock
+	logger        log.LoggerInterface
+}
+
+// NewPooledDispatcher 每个渠道都必须有协程池；selector 为 nil 或者选中的供应商没有单独的协程池时使用渠道的协程池
+// sendTimeout 是单条通知发送的超时时间，协程池里的任务不带调度器的取消信号，必须有自己的截止时间
+func NewPooledDispatcher(repo repository.NotificationRepository, sender NotificationSender,
+	selector provider.Selector,
+	channelPools map[domain.Channel]*workpool.Pool,
+	providerPools map[string]*workpool.Pool,
+	sendTimeout time.Duration,
+	clk clock.Clock,
+) *PooledDispatcher {
+	return &PooledDispatcher{
+		repo:          repo,
+		sender:        sender,
+		selector:      selector,
+		channelPools:  channelPools,
+		providerPools: providerPools,
+		sendTimeout:   sendTimeout,
+		clock:         clk,
+		logger:        log.Named(log.DefaultLogger(), "service.dispatcher"),
+	}
+}
+
+type dispatchTask struct {
+	notification domain.Notification
+	provider     provider.Named
+}
+
+func (d *PooledDispatcher) Dispatch(ctx context.Context, notifications []domain.Notification) error {
+	groups := make(map[*workpool.Pool][]dispatchTask)
+	for _, n := range notifications {
+		pool, task, err := d.route(ctx, n)
+		if err != nil {
+			// 选不出供应商或者渠道没有配置，留在 PENDING 等下一轮，其他通知照常调度
+			d.logger.WithContext(ctx).Warn("通知无法调度", zap.Uint64("notification_id", n.ID),
+				zap.String("channel", n.Channel.String()), zap.Error(err))
+			continue
+		}
+		groups[pool] = append(groups[pool], task)
+	}
+
+	// 每个协程池单独提交，一个协程池的队列满了不影响其他协程池
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for pool, tasks := range groups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.submit(ctx, pool, tasks); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (d *PooledDispatcher) route(ctx context.Context, n domain.Notification) (*workpool.Pool, dispatchTask, error) {
+	task := dispatchTask{notification: n}
+	if d.selector != nil && len(d.providerPools) > 0 {
+		p, err := d.selector.Select(ctx, n)
+		if err != nil {
+			return nil, task, err
+		}
+		task.provider = p
+		if pool, ok := d.providerPools[p.Name]; ok {
+			return pool, task, nil
+		}
+	}
+	pool, ok := d.channelPools[n.Channel]
+	if !ok {
+		return nil, task, fmt.Errorf("%w: 渠道 %s 没有配置协程池", domain.ErrNoAvailableChannel, n.Channel)
+	}
+	return pool, task, nil
+}
+
+func (d *PooledDispatcher) submit(ctx context.Context, pool *workpool.Pool, tasks []dispatchTask) error {
+	for _, t := range tasks {
+		n := t.notification
+		n.Status = domain.SendStatusSending
+		if err := d.repo.CASStatus(ctx, n); err != nil {
+			if errors.Is(err, domain.ErrNotificationVersionMismatch) {
+				// 被其他实例调度或者被取消了
+				continue
+			}
+			return fmt.Errorf("协程池 %s 调度通知失败: %w", pool.Name(), err)
+		}
+		n.Version++
+		p := t.provider
+		err := pool.Submit(ctx, func(ctx context.Context) {
+			ctx, cancel := context.WithTimeout(ctx, d.sendTimeout)
+			defer cancel()
+			// 发送链路上的日志都带上通知ID，供应商、黑名单这些组件不用单独传
+			ctx = log.ContextWithFields(ctx, zap.Uint64("notification_id", n.ID))
+			// 在队列里等太久，计划发送时间已经结束就不再发送
+			if n.SendWindowClosed(d.clock.Now()) {
+				d.failWindowClosed(ctx, n)
+				return
+			}
+			if err := d.sender.Send(ctx, n, p); err != nil {
+				d.logger.WithContext(ctx).Error("发送通知失败", zap.Error(err))
+				if retryable(err) {
+					d.retry(ctx, n, err)
+				}
+				return
+			}
+			d.logger.WithContext(ctx).Debug("通知已发送", zap.String("channel", n.Channel.String()))
+		})
+		if err != nil {
+			// 已经是 SENDING 但是没有入队，由 MarkTimeoutSendingAsFailed 兜底
+			return fmt.Errorf("协程池 %s 提交任务失败: %w", pool.Name(), err)
+		}
+	}
+	return nil
+}
+
+// failWindowClosed 计划发送时间已经结束的通知标记为失败，归还额度并回调业务方，ctx 里带着通知ID
+func (d *PooledDispatcher) failWindowClosed(ctx context.Context, n domain.Notification) {
+	windowClosedCounter.WithLabelValues(n.Channel.String()).Inc()
+	n.Status = domain.SendStatusFailed
+	n.FailReason = domain.FailReasonWindowClosed
+	if err := d.repo.MarkFailed(ctx, n); err != nil {
+		if errors.Is(err, domain.ErrNotificationVersionMismatch) {
+			// 已经被超时清理标记为失败，额度也归还过了
+			return
+		}
+		// 依旧是 SENDING，由 MarkTimeoutSendingAsFailed 兜底
+		d.logger.WithContext(ctx).Error("标记计划发送时间已经结束的通知失败", zap.Error(err))
+		return
+	}
+	d.logger.WithContext(ctx).Warn("计划发送时间已经结束，放弃发送", zap.Time("scheduled_end_time", n.ScheduledETime))
+}
+
+// retryable 发送方已经按失败处理的错误之外，供应商没有明确拒绝的都按重试策略处理
+func retryable(err error) bool {
+	if errors.Is(err, domain.ErrSendWindowClosed) || errors.Is(err, domain.ErrReceiverBlacklisted) ||
+		errors.Is(err, domain.ErrReceiverOptedOut) {
+		return false
+	}
+	return provider.Retryable(err)
+}
+
+// retry 按通知的重试策略重新排期，用完发送次数或者等不到下一次时标记为失败，ctx 里带着通知ID
+func (d *PooledDispatcher) retry(ctx context.Context, n domain.Notification, sendErr error) {
+	next, ok := n.NextRetry(d.clock.Now())
+	if !ok {
+		sendRetryCounter.WithLabelValues(n.Channel.String(), "exhausted").Inc()
+		n.Status = domain.SendStatusFailed
+		n.FailReason = cmp.Or(provider.FailReason(sendErr), domain.FailReasonRetryExhausted)
+		if err := d.repo.MarkFailed(ctx, n); err != nil && !errors.Is(err, domain.ErrNotificationVersionMismatch) {
+			// 依旧是 SENDING，由 MarkTimeoutSendingAsFailed 兜底；版本号不匹配说明已经被超时清理标记过了
+			d.logger.WithContext(ctx).Error("标记重试用完的通知失败", zap.Error(err))
+		}
+		return
+	}
+	n.ScheduledSTime = next
+	if err := d.repo.ScheduleRetry(ctx, n); err != nil {
+		if errors.Is(err, domain.ErrNotificationVersionMismatch) {
+			// 发送方已经更新了状态
+			return
+		}
+		d.logger.WithContext(ctx).Error("通知重新排期失败", zap.Error(err))
+		return
+	}
+	sendRetryCounter.WithLabelValues(n.Channel.String(), "scheduled").Inc()
+	d.logger.WithContext(ctx).Info("通知稍后重试", zap.Int32("attempts", n.Attempts+1), zap.Time("next_time", next))
+}
+
+// Close 等待所有协程池中已经入队的通知发送完
+func (d *PooledDispatcher) Close() {
+	for _, p := range d.channelPools {
+		p.Close()
+	}
+	for _, p := range d.providerPools {
+		p.Close()
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/workpool"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+)
+
+// 用完发送次数的通知按 CAS 之后的版本号标记为失败，已经被超时清理标记过的通知保持不变
+func TestPooledDispatcher_RetryExhausted(t *testing.T) {
+	testCases := []struct {
+		name string
+		// swept 发送期间超时清理已经把通知标记为失败
+		swept       bool
+		wantStatus  domain.SendStatus
+		wantVersion int
+		wantMarked  int
+	}{
+		{name: "还在发送中", wantStatus: domain.SendStatusFailed, wantVersion: 3, wantMarked: 1},
+		{name: "已经被超时清理", swept: true, wantStatus: domain.SendStatusFailed, wantVersion: 3},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			n := testReadyNotification(1, start)
+			n.RetryPolicy = domain.RetryPolicy{MaxAttempts: 1}
+			repo := newCASNotificationRepo(n)
+			sender := &failingSender{err: errors.New("供应商超时")}
+			if tc.swept {
+				sender.before = func() {
+					repo.mu.Lock()
+					defer repo.mu.Unlock()
+					rec := repo.records[n.ID]
+					repo.records[n.ID] = casRecord{status: domain.SendStatusFailed, version: rec.version + 1}
+				}
+			}
+			pool := workpool.New(fmt.Sprintf("test.retry.%d", i), 1, 1)
+			d := NewPooledDispatcher(repo, sender, nil, map[domain.Channel]*workpool.Pool{domain.ChannelSMS: pool},
+				nil, time.Second, clock.NewFake(start))
+			if err := d.Dispatch(context.Background(), []domain.Notification{n}); err != nil {
+				t.Fatal(err)
+			}
+			d.Close()
+
+			status, version := repo.state(n.ID)
+			if status != tc.wantStatus || version != tc.wantVersion {
+				t.Fatalf("状态 %s 版本 %d, 应该是 %s 和 %d", status, version, tc.wantStatus, tc.wantVersion)
+			}
+			if repo.marked != tc.wantMarked {
+				t.Fatalf("标记失败成功了 %d 次, 应该是 %d 次", repo.marked, tc.wantMarked)
+			}
+		})
+	}
+}
+
+type failingSender struct {
+	err    error
+	before func()
+}
+
+func (s *failingSender) Send(context.Context, domain.Notification, provider.Named) error {
+	if s.before != nil {
+		s.before()
+	}
+	return s.err
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+)
+
+// DryRunService 试运行发送：校验模板和参数、渲染模板、检查额度、选择供应商，不保存也不发送
+type DryRunService interface {
+	// DryRun 按顺序试运行一批通知，额度按这一批累计检查，返回和 notifications 一一对应
+	DryRun(ctx context.Context, notifications []domain.Notification) []DryRunOutcome
+}
+
+// DryRunOutcome 一条通知的试运行结果，Err 是真正发送时会遇到的错误
+type DryRunOutcome struct {
+	Result domain.DryRunResult
+	Err    error
+}
+
+var _ DryRunService = (*dryRunService)(nil)
+
+// NewDryRunService routes 是每个渠道按优先级排列的供应商名称
+func NewDryRunService(repo repository.NotificationRepository,
+	templateSvc ChannelTemplateService,
+	quotaRepo repository.QuotaRepository,
+	routes map[domain.Channel][]string,
+	clk clock.Clock,
+) DryRunService {
+	return &dryRunService{
+		repo:        repo,
+		templateSvc: templateSvc,
+		quotaRepo:   quotaRepo,
+		routes:      routes,
+		clock:       clk,
+	}
+}
+
+type dryRunService struct {
+	repo        repository.NotificationRepository
+	templateSvc ChannelTemplateService
+	quotaRepo   repository.QuotaRepository
+	routes      map[domain.Channel][]string
+	clock       clock.Clock
+}
+
+func (s *dryRunService) DryRun(ctx context.Context, notifications []domain.Notification) []DryRunOutcome {
+	outcomes := make([]DryRunOutcome, len(notifications))
+	// 前面已经通过的通知占用的额度
+	used := make(map[domain.Channel]int32)
+	for i, n := range notifications {
+		res, err := s.dryRun(ctx, n, used)
+		outcomes[i] = DryRunOutcome{Result: res, Err: err}
+	}
+	return outcomes
+}
+
+func (s *dryRunService) dryRun(ctx context.Context, n domain.Notification, used map[domain.Channel]int32) (domain.DryRunResult, error) {
+	now := s.clock.Now()
+	if err := n.Validate(now); err != nil {
+		return domain.DryRunResult{}, err
+	}
+	existing, err := s.repo.GetByKeys(ctx, n.BizID, n.Key)
+	if err != nil {
+		return domain.DryRunResult{}, err
+	}
+	if len(existing) > 0 {
+		return domain.DryRunResult{Existing: &existing[0]}, nil
+	}
+
+	template, err := s.templateSvc.GetTemplateByID(ctx, n.BizID, n.Template.ID)
+	if err != nil {
+		return domain.DryRunResult{}, err
+	}
+	if err = s.templateSvc.PrepareTemplate(ctx, &n); err != nil {
+		return domain.DryRunResult{}, err
+	}
+	version, err := template.ActiveVersion()
+	if err != nil {
+		return domain.DryRunResult{}, err
+	}
+	// 有按接收者覆盖的参数时按第一个接收者渲染
+	content, err := version.Render(n.Template.ParamsFor(n.Receivers[0]))
+	if err != nil {
+		return domain.DryRunResult{}, err
+	}
+	n.SetSendTime(now)
+	res := domain.DryRunResult{
+		TemplateVersionID: version.ID,
+		Signature:         version.Signature,
+		Content:           content,
+		ScheduledSTime:    n.ScheduledSTime,
+		ScheduledETime:    n.ScheduledETime,
+	}
+
+	// 沙箱通知只发给模拟供应商，不占用额度
+	if n.IsSandbox() {
+		res.Providers = []string{provider.MockProviderName}
+		return res, nil
+	}
+	if res.Providers, err = s.providers(n.Channel, version); err != nil {
+		return res, err
+	}
+
+	// 同一个渠道共用额度，透支的上限按这条通知的类别
+	quota, err := s.quotaRepo.Check(ctx, n.BizID, n.Channel, n.Category, used[n.Channel]+1)
+	res.QuotaRemaining = quota.Quota - used[n.Channel]
+	if err != nil {
+		return res, err
+	}
+	used[n.Channel]++
+	return res, nil
+}
+
+// providers 渠道配置的供应商里模板审核通过的那些，不考虑熔断
+func (s *dryRunService) providers(channel domain.Channel, version domain.ChannelTemplateVersion) ([]string, error) {
+	configured := s.routes[channel]
+	approved := version.ApprovedProviders()
+	if approved == nil {
+		if len(configured) == 0 {
+			return nil, fmt.Errorf("%w: 渠道 %s 没有配置供应商", domain.ErrNoAvailableProvider, channel)
+		}
+		return slices.Clone(configured), nil
+	}
+	providers := make([]string, 0, len(configured))
+	for _, p := range configured {
+		if slices.Contains(approved, p) {
+			providers = append(providers, p)
+		}
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("%w: 渠道 %s 没有审核通过模板版本 %d 的供应商", domain.ErrNoAvailableProvider, channel, version.ID)
+	}
+	return providers, nil
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// EscalationService 跨渠道升级链，按顺序发送每一步的通知，每一步等待一段时间，
+// 在此期间没有人确认就进入下一步，直到确认或者所有步骤都发送完
+type EscalationService interface {
+	// Create 创建升级链并立即发送第一步，重复创建返回已有的升级链，created 为 false
+	Create(ctx context.Context, e domain.Escalation) (res domain.Escalation, created bool, err error)
+	// Acknowledge 确认，之后不再升级，重复确认返回第一次确认的结果
+	Acknowledge(ctx context.Context, bizID int64, key, by string) (domain.Escalation, error)
+	Get(ctx context.Context, bizID int64, key string) (domain.Escalation, error)
+	// Advance 推进到期的升级链，返回处理的数量
+	Advance(ctx context.Context, limit int) (int, error)
+}
+
+var _ EscalationService = (*escalationService)(nil)
+
+// escalationRetryDelay 发送某一步失败之后，过这么久再重试
+const escalationRetryDelay = 30 * time.Second
+
+func NewEscalationService(repo repository.EscalationRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc ChannelTemplateService,
+	idGenerator idgen.Generator,
+	clk clock.Clock,
+) EscalationService {
+	return &escalationService{
+		repo:        repo,
+		templateSvc: templateSvc,
+		creator: asyncCreator{
+			notificationRepo: notificationRepo,
+			templateSvc:      templateSvc,
+			idGenerator:      idGenerator,
+			clock:            clk,
+		},
+		clock:  clk,
+		logger: log.Named(log.DefaultLogger(), "service.escalation"),
+	}
+}
+
+type escalationService struct {
+	repo        repository.EscalationRepository
+	templateSvc ChannelTemplateService
+	creator     asyncCreator
+	clock       clock.Clock
+	logger      log.LoggerInterface
+}
+
+func (s *escalationService) Create(ctx context.Context, e domain.Escalation) (domain.Escalation, bool, error) {
+	if err := e.Validate(); err != nil {
+		return domain.Escalation{}, false, err
+	}
+	// 创建时校验所有步骤的模板和参数，避免升级到一半才发现发不出去
+	for i := range e.Steps {
+		n := e.StepNotification(i)
+		if err := s.templateSvc.PrepareTemplate(ctx, &n); err != nil {
+			return domain.Escalation{}, false, fmt.Errorf("Steps[%d]: %w", i, err)
+		}
+	}
+	e.CurrentStep = -1
+	e.Status = domain.EscalationStatusActive
+	e.NextStepTime = s.clock.Now()
+	created, err := s.repo.Create(ctx, e)
+	if errors.Is(err, domain.ErrEscalationDuplicate) {
+		existing, gerr := s.repo.GetByKey(ctx, e.BizID, e.Key)
+		return existing, false, gerr
+	}
+	if err != nil {
+		return domain.Escalation{}, false, err
+	}
+	if err := s.advance(ctx, &created); err != nil {
+		// 第一步没有发出去，由定时任务重试
+		s.logger.WithContext(ctx).Error("发送升级链第一步失败", zap.Error(err),
+			zap.Int64("biz_id", created.BizID), zap.String("key", created.Key))
+	}
+	return created, true, nil
+}
+
+func (s *escalationService) Acknowledge(ctx context.Context, bizID int64, key, by string) (domain.Escalation, error) {
+	const maxAttempts = 3
+	for i := 0; ; i++ {
+		e, err := s.Get(ctx, bizID, key)
+		if err != nil {
+			return domain.Escalation{}, err
+		}
+		switch e.Status {
+		case domain.EscalationStatusAcknowledged:
+			return e, nil
+		case domain.EscalationStatusExhausted:
+			return domain.Escalation{}, fmt.Errorf("%w: status = %s", domain.ErrEscalationFinished, e.Status)
+		}
+		e.Finish(domain.EscalationStatusAcknowledged)
+		e.AckTime = s.clock.Now()
+		e.AckBy = by
+		err = s.repo.Update(ctx, e)
+		if err == nil {
+			e.Version++
+			return e, nil
+		}
+		// 和推进任务同时修改了，重新读一次
+		if !errors.Is(err, domain.ErrEscalationChanged) || i+1 >= maxAttempts {
+			return domain.Escalation{}, err
+		}
+	}
+}
+
+func (s *escalationService) Get(ctx context.Context, bizID int64, key string) (domain.Escalation, error) {
+	if key == "" {
+		return domain.Escalation{}, fmt.Errorf("%w: key 不能为空", domain.ErrInvalidParameter)
+	}
+	return s.repo.GetByKey(ctx, bizID, key)
+}
+
+func (s *escalationService) Advance(ctx context.Context, limit int) (int, error) {
+	escalations, err := s.repo.FindDue(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	for i := range escalations {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		e := escalations[i]
+		if err := s.advance(ctx, &e); err != nil && !errors.Is(err, domain.ErrEscalationChanged) {
+			s.logger.WithContext(ctx).Error("推进升级链失败", zap.Error(err),
+				zap.Int64("biz_id", e.BizID), zap.String("key", e.Key), zap.Int("step", e.CurrentStep+1))
+		}
+	}
+	return len(escalations), nil
+}
+
+// advance 发送下一步，最后一步的等待时间也过了就标记为耗尽，成功后 e 更新为最新的状态
+func (s *escalationService) advance(ctx context.Context, e *domain.Escalation) error {
+	updated := *e
+	updated.Steps = slices.Clone(e.Steps)
+	next := e.CurrentStep + 1
+	if next >= len(e.Steps) {
+		updated.Finish(domain.EscalationStatusExhausted)
+	} else {
+		if _, err := s.creator.create(ctx, e.StepNotification(next)); err != nil {
+			// 推迟重试，避免一直排在待处理的最前面
+			updated.NextStepTime = s.clock.Now().Add(escalationRetryDelay)
+			if uerr := s.repo.Update(ctx, updated); uerr != nil {
+				return errors.Join(err, uerr)
+			}
+			return err
+		}
+		updated.CurrentStep = next
+		updated.NextStepTime = s.clock.Now().Add(e.Steps[next].Wait)
+	}
+	// 和确认同时发生时这里会失败，这一步的通知可能已经发出去了
+	if err := s.repo.Update(ctx, updated); err != nil {
+		return err
+	}
+	updated.Version++
+	*e = updated
+	return nil
+}
+
+// EscalationTask 定时推进到期的升级链
+type EscalationTask struct {
+	svc       EscalationService
+	lock      distribute_lock.Client
+	interval  time.Duration
+	batchSize int
+	logger    log.LoggerInterface
+}
+
+func NewEscalationTask(svc EscalationService, lock distribute_lock.Client, interval time.Duration, batchSize int) *EscalationTask {
+	return &EscalationTask{
+		svc:       svc,
+		lock:      lock,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.Named(log.DefaultLogger(), "service.escalation"),
+	}
+}
+
+const escalationLockKey = "notification:escalation:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *EscalationTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, escalationLockKey, t.interval, t.runOnce)
+}
+
+func (t *EscalationTask) runOnce(ctx context.Context) {
+	if _, err := t.svc.Advance(ctx, t.batchSize); err != nil {
+		t.logger.WithContext(ctx).Error("推进升级链失败", zap.Error(err))
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var expiredCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "notification_expired_total",
+		Help: "Total number of pending notifications marked as failed because their send window expired",
+	},
+	[]string{"channel"},
+)
+
+func init() {
+	prometheus.MustRegister(expiredCounter)
+}
+
+// ExpirySweepTask 定时把过了计划发送结束时间依旧 PENDING 的通知标记为失败（原因 EXPIRED），并归还额度
+// DAO 使用 SKIP LOCKED 加锁，多个实例同时执行也不会重复处理，所以不需要分布式锁
+type ExpirySweepTask struct {
+	repo      repository.NotificationRepository
+	interval  time.Duration
+	batchSize int
+	logger    log.LoggerInterface
+}
+
+func NewExpirySweepTask(repo repository.NotificationRepository, interval time.Duration, batchSize int) *ExpirySweepTask {
+	return &ExpirySweepTask{
+		repo:      repo,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.Named(log.DefaultLogger(), "service.expiry"),
+	}
+}
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *ExpirySweepTask) Start(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		t.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep 一直处理到没有过期的通知为止
+func (t *ExpirySweepTask) sweep(ctx context.Context) {
+	for ctx.Err() == nil {
+		expired, err := t.repo.MarkExpiredAsFailed(ctx, t.batchSize)
+		if err != nil {
+			t.logger.WithContext(ctx).Error("标记过期通知失败", zap.Error(err))
+			return
+		}
+		if len(expired) == 0 {
+			return
+		}
+		for _, n := range expired {
+			expiredCounter.WithLabelValues(n.Channel.String()).Inc()
+		}
+		t.logger.WithContext(ctx).Info("过期通知已标记为失败", zap.Int("count", len(expired)))
+		if len(expired) < t.batchSize {
+			return
+		}
+	}
+}
+
+// SendingTimeoutSweepTask 定时把长时间停在 SENDING 的通知标记为失败（原因 SEND_TIMEOUT），并归还额度
+// 发送方崩溃、入队失败、标记结果失败都会让通知停在 SENDING，只能靠这个任务兜底
+type SendingTimeoutSweepTask struct {
+	repo      repository.NotificationRepository
+	lock      distribute_lock.Client
+	interval  time.Duration
+	batchSize int
+	logger    log.LoggerInterface
+}
+
+func NewSendingTimeoutSweepTask(repo repository.NotificationRepository, lock distribute_lock.Client,
+	interval time.Duration, batchSize int,
+) *SendingTimeoutSweepTask {
+	return &SendingTimeoutSweepTask{
+		repo:      repo,
+		lock:      lock,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.Named(log.DefaultLogger(), "service.sending_timeout"),
+	}
+}
+
+const sendingTimeoutLockKey = "notification:sending_timeout:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *SendingTimeoutSweepTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, sendingTimeoutLockKey, t.interval, t.sweep)
+}
+
+// sweep 一直处理到没有超时的通知为止
+func (t *SendingTimeoutSweepTask) sweep(ctx context.Context) {
+	for ctx.Err() == nil {
+		timeout, err := t.repo.MarkTimeoutSendingAsFailed(ctx, t.batchSize)
+		if err != nil {
+			t.logger.WithContext(ctx).Error("标记发送超时的通知失败", zap.Error(err))
+			return
+		}
+		if len(timeout) == 0 {
+			return
+		}
+		t.logger.WithContext(ctx).Info("发送超时的通知已标记为失败", zap.Int("count", len(timeout)))
+		if len(timeout) < t.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/olap"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	exportedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "notification_export_events_total",
+		Help: "Total number of notification lifecycle events written to the analytics sink",
+	})
+	exportLagGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "notification_export_lag_seconds",
+		Help: "Age of the latest notification change exported to the analytics sink",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(exportedCounter, exportLagGauge)
+}
+
+// ExportOptions 导出任务参数
+type ExportOptions struct {
+	// Name 导出进度的名称，换一个分析库时换一个名称重新导出
+	Name      string
+	Interval  time.Duration
+	BatchSize int
+	// Delay 只导出 Delay 之前的变化，等待同一毫秒内还没提交的事务，避免进度越过它们
+	Delay time.Duration
+}
+
+// ExportTask 按 (utime, id) 增量读取通知变化写入分析库，写入成功后才保存进度，保证至少一次
+// 两次读取之间被多次修改的通知只会导出最后一个版本
+type ExportTask struct {
+	repo   repository.ExportRepository
+	sink   olap.Sink
+	lock   distribute_lock.Client
+	opts   ExportOptions
+	logger log.LoggerInterface
+}
+
+func NewExportTask(repo repository.ExportRepository, sink olap.Sink, lock distribute_lock.Client, opts ExportOptions) *ExportTask {
+	return &ExportTask{
+		repo:   repo,
+		sink:   sink,
+		lock:   lock,
+		opts:   opts,
+		logger: log.Named(log.DefaultLogger(), "service.export"),
+	}
+}
+
+// Start 阻塞运行，直到 ctx 被取消，分析库建表失败时每个周期重试
+func (t *ExportTask) Start(ctx context.Context) {
+	schemaReady := false
+	distribute_lock.RunLocked(ctx, t.lock, "notification:export:lock:"+t.opts.Name, t.opts.Interval, func(ctx context.Context) {
+		if !schemaReady {
+			if err := t.sink.EnsureSchema(ctx); err != nil {
+				t.logger.WithContext(ctx).Error("初始化分析库表结构失败", zap.Error(err))
+				return
+			}
+			schemaReady = true
+		}
+		t.runOnce(ctx)
+	})
+}
+
+func (t *ExportTask) runOnce(ctx context.Context) {
+	n, err := t.export(ctx)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("导出通知事件失败", zap.Error(err), zap.Int("exported", n))
+		return
+	}
+	if n > 0 {
+		t.logger.WithContext(ctx).Info("导出通知事件", zap.Int("exported", n))
+	}
+}
+
+// export 一直导出到追上 Delay 之前的变化为止，返回导出的事件数量
+// 最多执行一个周期，在锁到期之前结束，积压的数据下个周期继续导出
+func (t *ExportTask) export(ctx context.Context) (int, error) {
+	start := time.Now()
+	cp, err := t.repo.GetCheckpoint(ctx, t.opts.Name)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for ctx.Err() == nil && time.Since(start) < t.opts.Interval {
+		events, err := t.repo.FindEvents(ctx, cp, time.Now().Add(-t.opts.Delay), t.opts.BatchSize)
+		if err != nil {
+			return total, err
+		}
+		if len(events) == 0 {
+			exportLagGauge.Set(0)
+			return total, nil
+		}
+		if err = t.sink.Write(ctx, events); err != nil {
+			return total, err
+		}
+		last := events[len(events)-1]
+		cp.Utime, cp.ID = last.Utime, last.NotificationID
+		// 进度保存失败下次会重复导出这一批，分析库负责去重
+		if err = t.repo.SaveCheckpoint(ctx, cp); err != nil {
+			return total, err
+		}
+		total += len(events)
+		exportedCounter.Add(float64(len(events)))
+		exportLagGauge.Set(time.Since(time.UnixMilli(last.Utime)).Seconds())
+		if len(events) < t.opts.BatchSize {
+			return total, nil
+		}
+	}
+	return total, ctx.Err()
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var fallbackSuppressedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_fallback_suppressed_total",
+	Help: "Total number of notifications canceled because another channel in their fallback group already succeeded",
+}, []string{"channel"})
+
+func init() {
+	prometheus.MustRegister(fallbackSuppressedCounter)
+}
+
+// FallbackService 渠道降级分组，同一个事件通过多个渠道发送时，按业务方声明的渠道顺序依次发送，
+// 一个渠道发送成功之后，分组里其余还没有发送的通知被取消
+type FallbackService interface {
+	// Validate 加入分组的通知，业务方必须配置了降级顺序，渠道必须在降级顺序里
+	Validate(n domain.Notification) error
+	// Filter 返回现在可以发送的通知，保持原来的顺序；分组里已经有渠道发送成功的通知被取消，
+	// 排在前面的渠道还没有结果的通知留给下一轮调度
+	Filter(ctx context.Context, notifications []domain.Notification) []domain.Notification
+	// Group 查询分组，分组里没有通知时返回 domain.ErrNotificationNotFound
+	Group(ctx context.Context, bizID int64, group string) (domain.FallbackGroup, error)
+}
+
+var _ FallbackService = (*fallbackService)(nil)
+
+// NewFallbackService policies 是每个业务方的降级顺序
+func NewFallbackService(repo repository.NotificationRepository, policies map[int64]domain.FallbackPolicy) FallbackService {
+	return &fallbackService{
+		repo:     repo,
+		policies: policies,
+		logger:   log.Named(log.DefaultLogger(), "service.fallback"),
+	}
+}
+
+type fallbackService struct {
+	repo     repository.NotificationRepository
+	policies map[int64]domain.FallbackPolicy
+	logger   log.LoggerInterface
+}
+
+func (s *fallbackService) Validate(n domain.Notification) error {
+	if n.FallbackGroup == "" {
+		return nil
+	}
+	policy, ok := s.policies[n.BizID]
+	if !ok {
+		return fmt.Errorf("%w: 业务方 %d 没有配置渠道降级顺序", domain.ErrInvalidParameter, n.BizID)
+	}
+	if _, ok = policy.Rank(n.Channel); !ok {
+		return fmt.Errorf("%w: 渠道 %s 不在业务方的渠道降级顺序里", domain.ErrInvalidParameter, n.Channel)
+	}
+	return nil
+}
+
+func (s *fallbackService) Filter(ctx context.Context, notifications []domain.Notification) []domain.Notification {
+	groups := make(map[int64][]string)
+	for _, n := range notifications {
+		if n.FallbackGroup != "" {
+			groups[n.BizID] = append(groups[n.BizID], n.FallbackGroup)
+		}
+	}
+	if len(groups) == 0 {
+		return notifications
+	}
+	found := make(map[int64]map[string]domain.FallbackGroup, len(groups))
+	for bizID, names := range groups {
+		g, err := s.repo.FindFallbackGroups(ctx, bizID, names...)
+		if err != nil {
+			// 这个业务方分组里的通知都不发送，留给下一轮调度
+			s.logger.WithContext(ctx).Error("查询渠道降级分组失败", zap.Error(err), zap.Int64("biz_id", bizID))
+			continue
+		}
+		found[bizID] = g
+	}
+
+	result := make([]domain.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		if n.FallbackGroup == "" {
+			result = append(result, n)
+			continue
+		}
+		g, ok := found[n.BizID]
+		if !ok {
+			continue
+		}
+		switch g[n.FallbackGroup].Decide(n, s.policies[n.BizID]) {
+		case domain.FallbackSend:
+			result = append(result, n)
+		case domain.FallbackSuppress:
+			s.suppress(ctx, n)
+		case domain.FallbackWait:
+		}
+	}
+	return result
+}
+
+// suppress 取消通知并归还额度，CAS 失败说明通知已经被修改或者取消了，忽略
+func (s *fallbackService) suppress(ctx context.Context, n domain.Notification) {
+	n.FailReason = domain.FailReasonSuppressed
+	err := s.repo.CancelPending(ctx, n)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotificationVersionMismatch) {
+			s.logger.WithContext(ctx).Warn("取消渠道降级分组里的通知失败", zap.Error(err),
+				zap.Int64("biz_id", n.BizID), zap.Uint64("notification_id", n.ID))
+		}
+		return
+	}
+	fallbackSuppressedCounter.WithLabelValues(n.Channel.String()).Inc()
+}
+
+func (s *fallbackService) Group(ctx context.Context, bizID int64, group string) (domain.FallbackGroup, error) {
+	groups, err := s.repo.FindFallbackGroups(ctx, bizID, group)
+	if err != nil {
+		return domain.FallbackGroup{}, err
+	}
+	g, ok := groups[group]
+	if !ok {
+		return domain.FallbackGroup{}, fmt.Errorf("%w: 渠道降级分组 %s", domain.ErrNotificationNotFound, group)
+	}
+	return g, nil
+}
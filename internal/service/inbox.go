@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/olap"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+const (
+	defaultInboxLimit = 20
+	maxInboxLimit     = 100
+)
+
+// InboxService 用户的站内信收件箱，只读收件箱投影，不扫描通知表
+// 投影由导出任务按通知变化增量生成，刚发送成功的站内信要过几秒才出现在收件箱里
+type InboxService interface {
+	// List 按通知ID倒序翻页查询，Limit 小于等于 0 使用默认值，最多 100 条
+	List(ctx context.Context, query domain.InboxQuery) (domain.InboxPage, error)
+}
+
+var _ InboxService = (*inboxService)(nil)
+
+func NewInboxService(repo repository.InboxRepository, notificationRepo repository.NotificationRepository) InboxService {
+	return &inboxService{repo: repo, notificationRepo: notificationRepo}
+}
+
+type inboxService struct {
+	repo             repository.InboxRepository
+	notificationRepo repository.NotificationRepository
+}
+
+func (s *inboxService) List(ctx context.Context, query domain.InboxQuery) (domain.InboxPage, error) {
+	if query.Receiver == "" {
+		return domain.InboxPage{}, fmt.Errorf("%w: receiver 不能为空", domain.ErrInvalidParameter)
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultInboxLimit
+	}
+	query.Limit = min(query.Limit, maxInboxLimit)
+	entries, err := s.repo.List(ctx, query)
+	if err != nil {
+		return domain.InboxPage{}, err
+	}
+	ids := make([]uint64, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.NotificationID)
+	}
+	found, err := s.notificationRepo.BatchGetByIDs(ctx, ids)
+	if err != nil {
+		return domain.InboxPage{}, err
+	}
+	page := domain.InboxPage{Notifications: make([]domain.Notification, 0, len(entries))}
+	for _, id := range ids {
+		// 投影之后通知被清理的跳过，下一次投影会移除
+		if n, ok := found[id]; ok {
+			page.Notifications = append(page.Notifications, n)
+		}
+	}
+	if len(entries) == query.Limit {
+		page.NextBeforeID = entries[len(entries)-1].NotificationID
+	}
+	return page, nil
+}
+
+var _ olap.Sink = (*InboxProjection)(nil)
+
+// InboxProjection 作为导出任务的目的地，把通知变化投影到收件箱，和其他导出各自保存进度
+type InboxProjection struct {
+	repo repository.InboxRepository
+}
+
+func NewInboxProjection(repo repository.InboxRepository) *InboxProjection {
+	return &InboxProjection{repo: repo}
+}
+
+// EnsureSchema 收件箱表由数据库迁移创建
+func (p *InboxProjection) EnsureSchema(context.Context) error {
+	return nil
+}
+
+func (p *InboxProjection) Write(ctx context.Context, events []domain.NotificationEvent) error {
+	return p.repo.Project(ctx, events)
+}
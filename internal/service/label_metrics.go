@@ -0,0 +1,85 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+var notificationLabelCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_label_events_total",
+	Help: "Total number of notification status events by configured label",
+}, []string{"status", "label", "value"})
+
+func init() {
+	prometheus.MustRegister(notificationLabelCounter)
+}
+
+// labelValueOther 超过上限的标签值统一记为 other
+const labelValueOther = "__other__"
+
+// LabelMetrics 按标签统计通知数量，只统计配置的标签键，每个键最多 maxValues 个不同的值，
+// 之后出现的新值都记为 other，避免业务方随意打标签撑爆指标的基数
+type LabelMetrics struct {
+	keys      map[string]struct{}
+	maxValues int
+
+	mu     sync.RWMutex
+	values map[string]map[string]struct{}
+}
+
+// NewLabelMetrics keys 为空时返回 nil，nil 的 LabelMetrics 不统计
+func NewLabelMetrics(keys []string, maxValues int) *LabelMetrics {
+	if len(keys) == 0 {
+		return nil
+	}
+	m := &LabelMetrics{
+		keys:      make(map[string]struct{}, len(keys)),
+		maxValues: maxValues,
+		values:    make(map[string]map[string]struct{}, len(keys)),
+	}
+	for _, k := range keys {
+		m.keys[k] = struct{}{}
+		m.values[k] = make(map[string]struct{}, maxValues)
+	}
+	return m
+}
+
+// Observe 记录一次状态变化
+func (m *LabelMetrics) Observe(status domain.SendStatus, labels domain.Labels) {
+	if m == nil {
+		return
+	}
+	for k, v := range labels {
+		if _, ok := m.keys[k]; !ok {
+			continue
+		}
+		notificationLabelCounter.WithLabelValues(status.String(), k, m.value(k, v)).Inc()
+	}
+}
+
+// value 已经见过的值原样返回，没见过的值在没超过上限时记下来
+func (m *LabelMetrics) value(key, v string) string {
+	m.mu.RLock()
+	seen := m.values[key]
+	_, ok := seen[v]
+	full := len(seen) >= m.maxValues
+	m.mu.RUnlock()
+	if ok {
+		return v
+	}
+	if full {
+		return labelValueOther
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok = seen[v]; ok {
+		return v
+	}
+	if len(seen) >= m.maxValues {
+		return labelValueOther
+	}
+	seen[v] = struct{}{}
+	return v
+}
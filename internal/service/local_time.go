@@ -0,0 +1,226 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/profile"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// LocalTimeService 按接收者所在时区的本地时间发送，一条通知按时区拆成多个分组，
+// 每个分组快到本地发送时间时创建成一条时间窗口发送的通知，由调度器发送
+type LocalTimeService interface {
+	// Add 按时区拆分通知并保存，返回每个时区的分组，重复添加返回 domain.ErrNotificationDuplicate
+	// 有分组的发送窗口已经结束时返回 domain.ErrInvalidParameter
+	Add(ctx context.Context, n domain.Notification) ([]domain.LocalTimeCohort, error)
+	// Cohorts 已经添加的通知的时区分组，没有时返回空
+	Cohorts(ctx context.Context, bizID int64, key string) ([]domain.LocalTimeCohort, error)
+	// Materialize 为快到发送时间的分组创建通知，返回处理的分组数量
+	Materialize(ctx context.Context, limit int) (int, error)
+}
+
+var _ LocalTimeService = (*localTimeService)(nil)
+
+// NewLocalTimeService ahead 提前多久创建通知，要大于调度任务的周期
+func NewLocalTimeService(repo repository.LocalTimeRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc ChannelTemplateService,
+	idGenerator idgen.Generator,
+	clk clock.Clock,
+	lookup profile.TimezoneLookup,
+	ahead time.Duration,
+) LocalTimeService {
+	return &localTimeService{
+		repo:        repo,
+		templateSvc: templateSvc,
+		creator: asyncCreator{
+			notificationRepo: notificationRepo,
+			templateSvc:      templateSvc,
+			idGenerator:      idGenerator,
+			clock:            clk,
+		},
+		lookup: lookup,
+		ahead:  ahead,
+		clock:  clk,
+		logger: log.Named(log.DefaultLogger(), "service.local_time"),
+	}
+}
+
+type localTimeService struct {
+	repo        repository.LocalTimeRepository
+	templateSvc ChannelTemplateService
+	creator     asyncCreator
+	lookup      profile.TimezoneLookup
+	ahead       time.Duration
+	clock       clock.Clock
+	logger      log.LoggerInterface
+}
+
+func (s *localTimeService) Add(ctx context.Context, n domain.Notification) ([]domain.LocalTimeCohort, error) {
+	if !n.IsLocalTime() {
+		return nil, fmt.Errorf("%w: 通知不是按本地时间发送的", domain.ErrInvalidParameter)
+	}
+	now := s.clock.Now()
+	if err := n.SendStrategyConfig.Validate(now); err != nil {
+		return nil, err
+	}
+	conf := *n.SendStrategyConfig.LocalTime
+	timezones, err := s.timezones(ctx, n.BizID, n.Receivers, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]string)
+	for _, receiver := range slices.Compact(slices.Sorted(slices.Values(n.Receivers))) {
+		tz := timezones[receiver]
+		groups[tz] = append(groups[tz], receiver)
+	}
+	cohorts := make([]domain.LocalTimeCohort, 0, len(groups))
+	for _, tz := range slices.Sorted(maps.Keys(groups)) {
+		start, end, err := conf.SendTimeIn(tz)
+		if err != nil {
+			return nil, err
+		}
+		if !end.After(now) {
+			return nil, fmt.Errorf("%w: 时区 %s 的发送窗口已经结束", domain.ErrInvalidParameter, tz)
+		}
+		cohorts = append(cohorts, domain.LocalTimeCohort{
+			BizID:          n.BizID,
+			Key:            n.Key,
+			Timezone:       tz,
+			SendTime:       start,
+			EndTime:        end,
+			Receivers:      groups[tz],
+			ReceiverCount:  int32(len(groups[tz])),
+			Channel:        n.Channel,
+			TemplateID:     n.Template.ID,
+			TemplateParams: n.Template.Params,
+			Category:       n.Category,
+			Labels:         n.Labels,
+			BizPayload:     n.BizPayload,
+			Environment:    n.Environment,
+			Status:         domain.LocalTimeCohortStatusPending,
+			RetryPolicy:    n.RetryPolicy,
+		})
+	}
+	// 按第一个分组校验模板和参数，避免到了发送时间才发现发不出去
+	sample := cohorts[0].Notification()
+	if err = s.templateSvc.PrepareTemplate(ctx, &sample); err != nil {
+		return nil, err
+	}
+	if err = s.repo.Create(ctx, cohorts); err != nil {
+		return nil, err
+	}
+	return cohorts, nil
+}
+
+// timezones 每个接收者的时区，优先使用通知里指定的，其次查询用户资料服务，都没有时使用默认时区
+// 查询失败时没有指定时区的接收者使用默认时区，不影响发送
+func (s *localTimeService) timezones(ctx context.Context, bizID int64, receivers []string,
+	conf domain.LocalTimeSend,
+) (map[string]string, error) {
+	res := make(map[string]string, len(receivers))
+	unknown := make([]string, 0, len(receivers))
+	for _, r := range receivers {
+		if tz, ok := conf.Timezones[r]; ok {
+			res[r] = tz
+			continue
+		}
+		unknown = append(unknown, r)
+	}
+	defaultTZ := cmp.Or(conf.DefaultTimezone, time.UTC.String())
+	if len(unknown) == 0 {
+		return res, nil
+	}
+	found, err := s.lookup.Timezones(ctx, bizID, unknown)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("查询接收者时区失败，使用默认时区", zap.Error(err),
+			zap.Int64("biz_id", bizID), zap.String("default_timezone", defaultTZ))
+	}
+	for _, r := range unknown {
+		tz, ok := found[r]
+		if ok {
+			if _, lerr := time.LoadLocation(tz); lerr != nil || tz == "" {
+				s.logger.WithContext(ctx).Warn("用户资料服务返回的时区不存在，使用默认时区",
+					zap.Int64("biz_id", bizID), zap.String("timezone", tz))
+				ok = false
+			}
+		}
+		if !ok {
+			tz = defaultTZ
+		}
+		res[r] = tz
+	}
+	return res, nil
+}
+
+func (s *localTimeService) Cohorts(ctx context.Context, bizID int64, key string) ([]domain.LocalTimeCohort, error) {
+	return s.repo.FindByKey(ctx, bizID, key)
+}
+
+func (s *localTimeService) Materialize(ctx context.Context, limit int) (int, error) {
+	cohorts, err := s.repo.FindDue(ctx, s.clock.Now().Add(s.ahead), limit)
+	if err != nil {
+		return 0, err
+	}
+	for i, c := range cohorts {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		// 通知的 key 由分组生成，上次创建成功但没有标记时不会重复创建
+		n, err := s.creator.create(ctx, c.Notification())
+		if err != nil {
+			s.logger.WithContext(ctx).Error("创建按本地时间发送的通知失败", zap.Error(err),
+				zap.Int64("biz_id", c.BizID), zap.Int64("cohort_id", c.ID))
+			continue
+		}
+		if err = s.repo.MarkCreated(ctx, c.ID, n.ID); err != nil {
+			s.logger.WithContext(ctx).Error("标记时区分组失败", zap.Error(err),
+				zap.Int64("biz_id", c.BizID), zap.Int64("cohort_id", c.ID))
+		}
+	}
+	return len(cohorts), nil
+}
+
+// LocalTimeTask 定时为快到本地发送时间的时区分组创建通知
+type LocalTimeTask struct {
+	svc       LocalTimeService
+	lock      distribute_lock.Client
+	interval  time.Duration
+	batchSize int
+	logger    log.LoggerInterface
+}
+
+func NewLocalTimeTask(svc LocalTimeService, lock distribute_lock.Client, interval time.Duration, batchSize int) *LocalTimeTask {
+	return &LocalTimeTask{
+		svc:       svc,
+		lock:      lock,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.Named(log.DefaultLogger(), "service.local_time"),
+	}
+}
+
+const localTimeLockKey = "notification:local_time:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *LocalTimeTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, localTimeLockKey, t.interval, t.runOnce)
+}
+
+func (t *LocalTimeTask) runOnce(ctx context.Context) {
+	if _, err := t.svc.Materialize(ctx, t.batchSize); err != nil {
+		t.logger.WithContext(ctx).Error("创建按本地时间发送的通知失败", zap.Error(err))
+	}
+}
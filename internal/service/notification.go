@@ -9,8 +9,8 @@ import (
 
 //go:generate mockgen -source=./notification.go -destination=./mocks/notification.mock.go -package=notificationmocks -typed Service
 type Service interface {
-	// FindReadyNotifications 准备好调度发送的通知
-	FindReadyNotifications(ctx context.Context, offset, limit int) ([]domain.Notification, error)
+	// FindReadyNotifications 分区内准备好调度发送的通知，ID 大于 afterID，按 ID 升序
+	FindReadyNotifications(ctx context.Context, partition domain.Partition, afterID uint64, limit int) ([]domain.Notification, error)
 	// GetByKeys 根据业务ID和业务内唯一标识获取通知列表
 	GetByKeys(ctx context.Context, bizID int64, keys ...string) ([]domain.Notification, error)
 }
@@ -27,9 +27,9 @@ type notificationService struct {
 	repo repository.NotificationRepository
 }
 
-// FindReadyNotifications 准备好调度发送的通知
-func (s *notificationService) FindReadyNotifications(ctx context.Context, offset, limit int) ([]domain.Notification, error) {
-	return s.repo.FindReadyNotifications(ctx, offset, limit)
+// FindReadyNotifications 分区内准备好调度发送的通知
+func (s *notificationService) FindReadyNotifications(ctx context.Context, partition domain.Partition, afterID uint64, limit int) ([]domain.Notification, error) {
+	return s.repo.FindReadyNotifications(ctx, partition, afterID, limit)
 }
 
 // GetByKeys 根据业务ID和业务内唯一标识获取通知列表
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/callback"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var callbackDeadLetterCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_callback_dead_letter_total",
+	Help: "Total number of notification callbacks moved to the dead-letter state, reason is retries_exhausted or no_target",
+}, []string{"reason"})
+
+func init() {
+	prometheus.MustRegister(callbackDeadLetterCounter)
+}
+
+// NotificationCallbackOptions 发送结果回调参数
+type NotificationCallbackOptions struct {
+	Interval  time.Duration
+	BatchSize int
+	// MaxRetries 超过之后不再回调，进入死信，等平台管理员重新回调
+	MaxRetries int32
+	// BaseBackoff 第 n 次重试等待 BaseBackoff * 2^(n-1)，最多 MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// AllowedHosts 发送时指定的回调地址只能是这些域名，为空不限制
+	AllowedHosts []string
+}
+
+// NotificationCallbackTask 定时把发送结果回调给业务方，至少一次，业务方按 notificationId 去重
+// 回调地址优先使用发送时指定的地址，没有指定时使用业务方配置的默认地址
+type NotificationCallbackTask struct {
+	repo             repository.CallbackLogRepository
+	notificationRepo repository.NotificationRepository
+	client           callback.Client
+	endpoints        map[int64]string
+	allowedHosts     map[string]struct{}
+	lock             distribute_lock.Client
+	opts             NotificationCallbackOptions
+	clock            clock.Clock
+	logger           log.LoggerInterface
+}
+
+func NewNotificationCallbackTask(repo repository.CallbackLogRepository,
+	notificationRepo repository.NotificationRepository,
+	client callback.Client,
+	endpoints map[int64]string,
+	lock distribute_lock.Client,
+	opts NotificationCallbackOptions,
+	clk clock.Clock,
+) *NotificationCallbackTask {
+	allowedHosts := make(map[string]struct{}, len(opts.AllowedHosts))
+	for _, h := range opts.AllowedHosts {
+		allowedHosts[h] = struct{}{}
+	}
+	return &NotificationCallbackTask{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		client:           client,
+		endpoints:        endpoints,
+		allowedHosts:     allowedHosts,
+		lock:             lock,
+		opts:             opts,
+		clock:            clk,
+		logger:           log.Named(log.DefaultLogger(), "service.notification_callback"),
+	}
+}
+
+const notificationCallbackLockKey = "notification:callback:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *NotificationCallbackTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, notificationCallbackLockKey, t.opts.Interval, t.runOnce)
+}
+
+func (t *NotificationCallbackTask) runOnce(ctx context.Context) {
+	logs, err := t.repo.FindRetryable(ctx, t.opts.BatchSize)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("查询待回调的记录失败", zap.Error(err))
+		return
+	}
+	if len(logs) == 0 {
+		return
+	}
+	ids := make([]uint64, 0, len(logs))
+	for _, l := range logs {
+		ids = append(ids, l.Notification.ID)
+	}
+	notifications, err := t.notificationRepo.BatchGetByIDs(ctx, ids)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("查询待回调的通知失败", zap.Error(err))
+		return
+	}
+	updated := make([]domain.CallbackLog, 0, len(logs))
+	for _, l := range logs {
+		if ctx.Err() != nil {
+			break
+		}
+		n, ok := notifications[l.Notification.ID]
+		if !ok {
+			// 通知已经被清理了
+			l.Status = domain.CallbackLogStatusFailed
+			updated = append(updated, l)
+			continue
+		}
+		l.Notification = n
+		if t.push(ctx, &l) {
+			updated = append(updated, l)
+		}
+	}
+	if err = t.repo.Update(ctx, updated); err != nil {
+		// 没更新成功下个周期会重复回调
+		t.logger.WithContext(ctx).Error("更新回调记录失败", zap.Error(err))
+	}
+}
+
+// push 回调一条通知，返回回调记录是否需要更新
+func (t *NotificationCallbackTask) push(ctx context.Context, l *domain.CallbackLog) bool {
+	n := l.Notification
+	logger := t.logger.WithContext(ctx).With(zap.Int64("biz_id", n.BizID), zap.Uint64("notification_id", n.ID))
+	target, ok := t.target(*l)
+	if !ok {
+		logger.Warn("通知没有可用的回调地址", zap.String("url", l.Callback.URL))
+		// 业务方补上默认地址或者允许的域名之后可以重新回调
+		l.Status = domain.CallbackLogStatusDeadLetter
+		l.LastError = "没有可用的回调地址"
+		callbackDeadLetterCounter.WithLabelValues("no_target").Inc()
+		return true
+	}
+	body, _ := json.Marshal(domain.NotificationCallbackEvent{
+		Event:          domain.NotificationCallbackEventName,
+		NotificationID: n.ID,
+		Key:            n.Key,
+		Status:         n.Status.String(),
+		FailReason:     n.FailReason.String(),
+		Labels:         n.Labels,
+		BizPayload:     n.BizPayload,
+	})
+	err := t.client.Post(ctx, n.BizID, target, body, l.Callback.Headers)
+	switch {
+	case err == nil:
+		l.Status = domain.CallbackLogStatusSuccess
+		l.LastError = ""
+		logger.Debug("回调发送结果成功", zap.String("url", target))
+	case errors.Is(err, domain.ErrCircuitBreaker):
+		// 地址熔断中，不算重试次数，下个周期再看
+		return false
+	default:
+		l.RetryCount++
+		l.LastError = err.Error()
+		if l.RetryCount >= t.opts.MaxRetries {
+			l.Status = domain.CallbackLogStatusDeadLetter
+			callbackDeadLetterCounter.WithLabelValues("retries_exhausted").Inc()
+		}
+		l.NextRetryTime = t.clock.Now().Add(t.backoff(l.RetryCount)).UnixMilli()
+		logger.Warn("回调发送结果失败", zap.Error(err), zap.Int32("retry_count", l.RetryCount))
+	}
+	return true
+}
+
+// target 回调地址，发送时指定的地址必须在允许的域名里
+func (t *NotificationCallbackTask) target(l domain.CallbackLog) (string, bool) {
+	if l.Callback.URL == "" {
+		u, ok := t.endpoints[l.Notification.BizID]
+		return u, ok
+	}
+	if len(t.allowedHosts) == 0 {
+		return l.Callback.URL, true
+	}
+	u, err := url.Parse(l.Callback.URL)
+	if err != nil {
+		return "", false
+	}
+	_, ok := t.allowedHosts[u.Hostname()]
+	return l.Callback.URL, ok
+}
+
+func (t *NotificationCallbackTask) backoff(retryCount int32) time.Duration {
+	d := t.opts.BaseBackoff
+	for i := int32(1); i < retryCount && d < t.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, t.opts.MaxBackoff)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+var notificationExportRowsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "notification_export_rows_total",
+	Help: "Total number of notifications streamed to callers by ExportNotifications",
+}, []string{"format"})
+
+func init() {
+	prometheus.MustRegister(notificationExportRowsCounter)
+}
+
+// NotificationExportService 按时间范围流式导出业务方的通知，给合规审计用
+type NotificationExportService interface {
+	// Export 每查询一批通知编码成一块交给 emit，emit 返回错误时停止导出
+	// 同时进行的导出超过上限返回 domain.ErrTooManyExports
+	Export(ctx context.Context, query domain.NotificationExportQuery, emit func(domain.ExportChunk) error) error
+}
+
+// NotificationExportOptions 流式导出参数
+type NotificationExportOptions struct {
+	// BatchSize 每块的行数
+	BatchSize int
+	// RowsPerSecond 每个导出每秒最多导出的行数，0 不限制
+	RowsPerSecond int
+	// MaxConcurrent 每个实例同时进行的导出数
+	MaxConcurrent int
+}
+
+var _ NotificationExportService = (*notificationExportService)(nil)
+
+func NewNotificationExportService(repo repository.NotificationRepository, opts NotificationExportOptions) NotificationExportService {
+	return &notificationExportService{
+		repo: repo,
+		opts: opts,
+		sem:  make(chan struct{}, opts.MaxConcurrent),
+	}
+}
+
+type notificationExportService struct {
+	repo repository.NotificationRepository
+	opts NotificationExportOptions
+	sem  chan struct{}
+}
+
+// exportColumns CSV 的列，JSONL 的字段名和它一致
+var exportColumns = []string{
+	"id", "key", "channel", "template_id", "template_version_id", "status", "fail_reason",
+	"receivers", "template_params", "labels", "biz_payload", "scheduled_start_time", "scheduled_end_time", "ctime", "utime",
+}
+
+func (s *notificationExportService) Export(ctx context.Context, query domain.NotificationExportQuery,
+	emit func(domain.ExportChunk) error,
+) error {
+	if err := query.Validate(); err != nil {
+		return err
+	}
+	select {
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	default:
+		return fmt.Errorf("%w: 最多 %d 个", domain.ErrTooManyExports, s.opts.MaxConcurrent)
+	}
+
+	cursor := query.Cursor
+	// 从头导出时 CSV 带上表头，继续导出时不重复
+	header := cursor.IsZero() && query.Format == domain.ExportFormatCSV
+	for {
+		notifications, err := s.repo.ListForExport(ctx, query, cursor, s.opts.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+		last := notifications[len(notifications)-1]
+		cursor = domain.ExportCursor{Ctime: last.Ctime, ID: last.ID}
+		data, err := encodeExportRows(notifications, query.Format, query.Redaction, header)
+		if err != nil {
+			return err
+		}
+		header = false
+		if err = emit(domain.ExportChunk{Data: data, Rows: len(notifications), Cursor: cursor.Encode()}); err != nil {
+			return err
+		}
+		notificationExportRowsCounter.WithLabelValues(string(query.Format)).Add(float64(len(notifications)))
+		if len(notifications) < s.opts.BatchSize {
+			return nil
+		}
+		if err = s.pace(ctx, len(notifications)); err != nil {
+			return err
+		}
+	}
+}
+
+// pace 按每秒行数限速，导出了 rows 行之后等待对应的时间
+func (s *notificationExportService) pace(ctx context.Context, rows int) error {
+	if s.opts.RowsPerSecond <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(time.Duration(rows) * time.Second / time.Duration(s.opts.RowsPerSecond))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// exportRow 导出的一行，脱敏之后的数据
+type exportRow struct {
+	ID                 uint64            `json:"id"`
+	Key                string            `json:"key"`
+	Channel            string            `json:"channel"`
+	TemplateID         int64             `json:"template_id"`
+	TemplateVersionID  int64             `json:"template_version_id"`
+	Status             string            `json:"status"`
+	FailReason         string            `json:"fail_reason,omitempty"`
+	Receivers          []string          `json:"receivers,omitempty"`
+	TemplateParams     map[string]string `json:"template_params,omitempty"`
+	Labels             map[string]string `json:"labels,omitempty"`
+	BizPayload         string            `json:"biz_payload,omitempty"`
+	ScheduledStartTime int64             `json:"scheduled_start_time"`
+	ScheduledEndTime   int64             `json:"scheduled_end_time"`
+	Ctime              int64             `json:"ctime"`
+	Utime              int64             `json:"utime"`
+}
+
+func newExportRow(n domain.ExportedNotification, redaction domain.ExportRedaction) exportRow {
+	row := exportRow{
+		ID:                 n.ID,
+		Key:                n.Key,
+		Channel:            n.Channel.String(),
+		TemplateID:         n.Template.ID,
+		TemplateVersionID:  n.Template.VersionID,
+		Status:             n.Status.String(),
+		FailReason:         n.FailReason.String(),
+		Labels:             n.Labels,
+		BizPayload:         n.BizPayload,
+		ScheduledStartTime: n.ScheduledSTime.UnixMilli(),
+		ScheduledEndTime:   n.ScheduledETime.UnixMilli(),
+		Ctime:              n.Ctime,
+		Utime:              n.Utime,
+	}
+	switch redaction {
+	case domain.ExportRedactionNone:
+		row.Receivers = n.Receivers
+		row.TemplateParams = n.Template.Params
+	case domain.ExportRedactionMask:
+		row.Receivers = make([]string, 0, len(n.Receivers))
+		for _, r := range n.Receivers {
+			row.Receivers = append(row.Receivers, domain.MaskReceiver(r))
+		}
+		row.TemplateParams = maps.Clone(n.Template.Params)
+		for k := range row.TemplateParams {
+			row.TemplateParams[k] = "***"
+		}
+	}
+	return row
+}
+
+func encodeExportRows(notifications []domain.ExportedNotification, format domain.ExportFormat,
+	redaction domain.ExportRedaction, header bool,
+) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if format == domain.ExportFormatJSONL {
+		enc := json.NewEncoder(buf)
+		for _, n := range notifications {
+			if err := enc.Encode(newExportRow(n, redaction)); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+	}
+	w := csv.NewWriter(buf)
+	if header {
+		_ = w.Write(exportColumns)
+	}
+	for _, n := range notifications {
+		row := newExportRow(n, redaction)
+		_ = w.Write([]string{
+			strconv.FormatUint(row.ID, 10), row.Key, row.Channel,
+			strconv.FormatInt(row.TemplateID, 10), strconv.FormatInt(row.TemplateVersionID, 10),
+			row.Status, row.FailReason,
+			jsonOrEmpty(row.Receivers), jsonOrEmpty(row.TemplateParams), jsonOrEmpty(row.Labels), row.BizPayload,
+			strconv.FormatInt(row.ScheduledStartTime, 10), strconv.FormatInt(row.ScheduledEndTime, 10),
+			strconv.FormatInt(row.Ctime, 10), strconv.FormatInt(row.Utime, 10),
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// jsonOrEmpty CSV 里的数组和对象用 JSON 表示，没有值时为空
+func jsonOrEmpty[T []string | map[string]string](v T) string {
+	if len(v) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var notificationWatchersGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "notification_watchers",
+	Help: "Number of WatchNotification streams currently open on this instance",
+})
+
+func init() {
+	prometheus.MustRegister(notificationWatchersGauge)
+}
+
+// NotificationWatchService 运维实时观察某个业务 key 的通知
+type NotificationWatchService interface {
+	// Watch 先发送通知当前的状态，之后每次变化发送一次，通知还没有创建时等创建之后再发送
+	// 直到 ctx 被取消、send 返回错误或者超过最长观察时间，超过最长观察时间时返回 nil
+	// 同时进行的观察超过上限返回 domain.ErrTooManyWatchers
+	Watch(ctx context.Context, bizID int64, key string, send func(domain.Notification) error) error
+}
+
+// NotificationWatchOptions 观察参数
+type NotificationWatchOptions struct {
+	// PollInterval 重新查询通知和续期注册的间隔，兜底发布订阅丢失的变化和不发布的批量变化
+	PollInterval time.Duration
+	// MaxDuration 每次观察最长多久，到期之后结束，需要继续观察时重新调用
+	MaxDuration time.Duration
+	// MaxConcurrent 每个实例同时进行的观察数，每个观察占用一个 Redis 订阅连接
+	MaxConcurrent int
+}
+
+var _ NotificationWatchService = (*notificationWatchService)(nil)
+
+func NewNotificationWatchService(repo repository.NotificationRepository, bus eventbus.WatchBus, opts NotificationWatchOptions) NotificationWatchService {
+	return &notificationWatchService{
+		repo:   repo,
+		bus:    bus,
+		opts:   opts,
+		sem:    make(chan struct{}, opts.MaxConcurrent),
+		logger: log.Named(log.DefaultLogger(), "service.notification_watch"),
+	}
+}
+
+type notificationWatchService struct {
+	repo   repository.NotificationRepository
+	bus    eventbus.WatchBus
+	opts   NotificationWatchOptions
+	sem    chan struct{}
+	logger log.LoggerInterface
+}
+
+func (s *notificationWatchService) Watch(ctx context.Context, bizID int64, key string, send func(domain.Notification) error) error {
+	if bizID <= 0 || key == "" {
+		return fmt.Errorf("%w: bizID 和 key 不能为空", domain.ErrInvalidParameter)
+	}
+	select {
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	default:
+		return fmt.Errorf("%w: 最多 %d 个", domain.ErrTooManyWatchers, s.opts.MaxConcurrent)
+	}
+	notificationWatchersGauge.Inc()
+	defer notificationWatchersGauge.Dec()
+
+	watchCtx, cancel := context.WithTimeout(ctx, s.opts.MaxDuration)
+	defer cancel()
+	// 注册的有效期是几个查询周期，观察结束之后不续期，自动过期
+	ttl := 3 * s.opts.PollInterval
+	if err := s.bus.Register(watchCtx, bizID, key, ttl); err != nil {
+		return err
+	}
+	changed := make(chan struct{}, 1)
+	subErr := make(chan error, 1)
+	go func() {
+		subErr <- s.bus.Subscribe(watchCtx, func(c domain.NotificationChange) {
+			if c.BizID != bizID || c.Key != key {
+				return
+			}
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+	var last domain.Notification
+	for {
+		notifications, err := s.repo.GetByKeys(watchCtx, bizID, key)
+		if err != nil && watchCtx.Err() == nil {
+			return err
+		}
+		if len(notifications) > 0 {
+			n := notifications[0]
+			// 状态变化都会增加版本号，同一个 key 的通知被删除之后重新创建时ID不同
+			if n.ID != last.ID || n.Version != last.Version || n.Status != last.Status {
+				if err = send(n); err != nil {
+					return err
+				}
+				last = n
+			}
+		}
+		select {
+		case <-watchCtx.Done():
+			// 调用方取消时返回原因，到了最长观察时间正常结束
+			return ctx.Err()
+		case err = <-subErr:
+			if watchCtx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		case <-changed:
+		case <-ticker.C:
+			if err = s.bus.Register(watchCtx, bizID, key, ttl); err != nil {
+				s.logger.WithContext(ctx).Warn("续期观察注册失败", zap.Error(err), zap.Int64("biz_id", bizID))
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var pacingDeferredCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "notification_pacing_deferred_total",
+	Help: "Total number of paced notifications deferred by the scheduler because their campaign reached its rate",
+})
+
+func init() {
+	prometheus.MustRegister(pacingDeferredCounter)
+}
+
+// PacingService 按活动限速发送，创建时按活动进度分配发送时间，调度器发送前再按活动限速一次，
+// 停机或者调度落后之后积压的通知不会一下子全部发出去
+type PacingService interface {
+	// Assign 为限速发送的通知按活动进度依次分配发送时间，设置 Campaign，其他通知不变
+	Assign(ctx context.Context, notifications []domain.Notification) error
+	// Admit 返回可以发送的通知，保持原来的顺序；超出活动速度的通知推迟到活动之后的发送时间
+	Admit(ctx context.Context, notifications []domain.Notification) []domain.Notification
+}
+
+var _ PacingService = (*pacingService)(nil)
+
+func NewPacingService(repo repository.PacingRepository, notificationRepo repository.NotificationRepository) PacingService {
+	return &pacingService{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		logger:           log.Named(log.DefaultLogger(), "service.pacing"),
+	}
+}
+
+type pacingService struct {
+	repo             repository.PacingRepository
+	notificationRepo repository.NotificationRepository
+	logger           log.LoggerInterface
+}
+
+type campaignKey struct {
+	bizID    int64
+	campaign string
+}
+
+// groupByCampaign 按活动分组，返回每个活动的通知下标和活动出现的顺序
+func groupByCampaign(notifications []domain.Notification, campaign func(domain.Notification) string,
+) (map[campaignKey][]int, []campaignKey) {
+	groups := make(map[campaignKey][]int)
+	var keys []campaignKey
+	for i, n := range notifications {
+		c := campaign(n)
+		if c == "" {
+			continue
+		}
+		k := campaignKey{bizID: n.BizID, campaign: c}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+	return groups, keys
+}
+
+func (s *pacingService) Assign(ctx context.Context, notifications []domain.Notification) error {
+	groups, keys := groupByCampaign(notifications, func(n domain.Notification) string {
+		if !n.IsPaced() {
+			return ""
+		}
+		return n.SendStrategyConfig.Pacing.Campaign
+	})
+	for _, k := range keys {
+		indexes := groups[k]
+		// 同一批里速度不一致时以最后一条为准
+		perMinute := notifications[indexes[len(indexes)-1]].SendStrategyConfig.Pacing.PerMinute
+		slots, err := s.repo.Reserve(ctx, k.bizID, k.campaign, perMinute, len(indexes))
+		if err != nil {
+			return err
+		}
+		for j, i := range indexes {
+			notifications[i].Campaign = k.campaign
+			notifications[i].ScheduledSTime = slots[j]
+			notifications[i].ScheduledETime = slots[j].Add(domain.PacingSlotWindow)
+		}
+	}
+	return nil
+}
+
+func (s *pacingService) Admit(ctx context.Context, notifications []domain.Notification) []domain.Notification {
+	groups, keys := groupByCampaign(notifications, func(n domain.Notification) string {
+		return n.Campaign
+	})
+	if len(keys) == 0 {
+		return notifications
+	}
+	rejected := make(map[int]struct{})
+	for _, k := range keys {
+		indexes := groups[k]
+		granted, perMinute, err := s.repo.Admit(ctx, k.bizID, k.campaign, len(indexes))
+		if err != nil {
+			// 这一批都不发送，留给下一轮调度
+			s.logger.WithContext(ctx).Error("限速发送放行失败", zap.Error(err),
+				zap.Int64("biz_id", k.bizID), zap.String("campaign", k.campaign))
+			for _, i := range indexes {
+				rejected[i] = struct{}{}
+			}
+			continue
+		}
+		deferred := indexes[granted:]
+		for _, i := range deferred {
+			rejected[i] = struct{}{}
+		}
+		if len(deferred) > 0 {
+			s.deferNotifications(ctx, k, perMinute, notifications, deferred)
+		}
+	}
+	admitted := make([]domain.Notification, 0, len(notifications)-len(rejected))
+	for i, n := range notifications {
+		if _, ok := rejected[i]; !ok {
+			admitted = append(admitted, n)
+		}
+	}
+	return admitted
+}
+
+// deferNotifications 超出速度的通知重新分配发送时间，失败的留给下一轮调度
+func (s *pacingService) deferNotifications(ctx context.Context, k campaignKey, perMinute int32,
+	notifications []domain.Notification, indexes []int,
+) {
+	pacingDeferredCounter.Add(float64(len(indexes)))
+	slots, err := s.repo.Reserve(ctx, k.bizID, k.campaign, perMinute, len(indexes))
+	if err != nil {
+		s.logger.WithContext(ctx).Error("推迟限速发送的通知失败", zap.Error(err),
+			zap.Int64("biz_id", k.bizID), zap.String("campaign", k.campaign))
+		return
+	}
+	for j, i := range indexes {
+		n := notifications[i]
+		n.ScheduledSTime = slots[j]
+		n.ScheduledETime = slots[j].Add(domain.PacingSlotWindow)
+		if err = s.notificationRepo.Reschedule(ctx, n); err != nil {
+			s.logger.WithContext(ctx).Warn("推迟限速发送的通知失败", zap.Error(err),
+				zap.Int64("biz_id", k.bizID), zap.Uint64("notification_id", n.ID))
+		}
+	}
+}
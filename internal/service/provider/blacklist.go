@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var _ Provider = (*blacklistGuard)(nil)
+
+// BlacklistOptions 自动加入黑名单的规则
+type BlacklistOptions struct {
+	// TTL 自动加入的接收者保留多久，默认 domain.DefaultBlacklistTTL
+	TTL time.Duration
+	// Categories 哪些错误分类自动加入黑名单，默认拉黑和接收者不存在
+	Categories []domain.VendorErrorCategory
+}
+
+// NewBlacklistGuard 调用供应商之前去掉渠道黑名单里的接收者，全部在黑名单里时返回 domain.ErrReceiverBlacklisted，不调用供应商
+// 供应商按 Categories 拒收时把接收者加入黑名单，避免一直花钱发送注定失败的请求
+// 供应商不会告诉我们是哪个接收者被拒收，所以只有一个接收者的通知才自动加入
+// 查询黑名单失败时照常发送
+func NewBlacklistGuard(p Provider, blacklist repository.BlacklistRepository, opts BlacklistOptions, logger log.LoggerInterface) Provider {
+	if opts.TTL <= 0 {
+		opts.TTL = domain.DefaultBlacklistTTL
+	}
+	if len(opts.Categories) == 0 {
+		opts.Categories = []domain.VendorErrorCategory{domain.VendorErrorBlacklisted, domain.VendorErrorInvalidReceiver}
+	}
+	return &blacklistGuard{
+		provider:  p,
+		blacklist: blacklist,
+		opts:      opts,
+		logger:    log.Named(logger, "provider.blacklist"),
+		now:       time.Now,
+	}
+}
+
+type blacklistGuard struct {
+	provider  Provider
+	blacklist repository.BlacklistRepository
+	opts      BlacklistOptions
+	logger    log.LoggerInterface
+	now       func() time.Time
+}
+
+func (g *blacklistGuard) Send(ctx context.Context, req Request) (Response, error) {
+	n := req.Notification
+	blocked, err := g.blacklist.Blacklisted(ctx, n.Channel, n.Receivers)
+	if err != nil {
+		g.logger.WithContext(ctx).Warn("查询接收者黑名单失败，照常发送",
+			zap.Uint64("notification_id", n.ID),
+			zap.Error(err))
+	}
+	if len(blocked) > 0 {
+		receivers := slices.DeleteFunc(slices.Clone(n.Receivers), func(r string) bool {
+			return slices.Contains(blocked, r)
+		})
+		if len(receivers) == 0 {
+			return Response{}, fmt.Errorf("%w: 通知 %d 的接收者都在 %s 渠道的黑名单里",
+				domain.ErrReceiverBlacklisted, n.ID, n.Channel)
+		}
+		g.logger.WithContext(ctx).Info("跳过黑名单里的接收者",
+			zap.Uint64("notification_id", n.ID),
+			zap.Int("skipped", len(n.Receivers)-len(receivers)))
+		req.Notification.Receivers = receivers
+	}
+
+	resp, err := g.provider.Send(ctx, req)
+	if err != nil {
+		g.addRejected(ctx, req.Notification, err)
+	}
+	return resp, err
+}
+
+// addRejected 供应商拒收唯一的接收者时加入黑名单，失败只记录日志
+func (g *blacklistGuard) addRejected(ctx context.Context, n domain.Notification, err error) {
+	ve, ok := AsVendorError(err)
+	if !ok || !slices.Contains(g.opts.Categories, ve.Category) || len(n.Receivers) != 1 {
+		return
+	}
+	entry := domain.BlacklistEntry{
+		Channel:        n.Channel,
+		Receiver:       n.Receivers[0],
+		Category:       ve.Category,
+		Vendor:         ve.Vendor,
+		Code:           ve.Code,
+		NotificationID: n.ID,
+		ExpireTime:     g.now().Add(g.opts.TTL),
+	}
+	// 发送的 ctx 可能已经超时
+	if aerr := g.blacklist.Add(context.WithoutCancel(ctx), entry); aerr != nil {
+		g.logger.WithContext(ctx).Error("接收者加入黑名单失败",
+			zap.Uint64("notification_id", n.ID),
+			zap.Error(aerr))
+		return
+	}
+	g.logger.WithContext(ctx).Info("供应商拒收，接收者加入黑名单",
+		zap.Uint64("notification_id", n.ID),
+		zap.String("channel", n.Channel.String()),
+		zap.String("vendor", ve.Vendor),
+		zap.String("code", ve.Code),
+		zap.Time("expire_time", entry.ExpireTime))
+}
+
+// SupportsIdempotencyKey 透传被包装供应商的幂等能力
+func (g *blacklistGuard) SupportsIdempotencyKey() bool {
+	return supportsIdempotencyKey(g.provider)
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// exactlyOnceProvider 防止超时重试导致重复发送
+//
+// 调用供应商之前先按照幂等键（通知ID+尝试次数）落一条发送尝试记录：
+//   - 供应商支持幂等键：直接透传幂等键，重复调用由供应商去重
+//   - 供应商不支持幂等键：已经受理的尝试直接返回上次的结果，
+//     结果未知（上次调用超时）的尝试不再发送，返回 domain.ErrSendAttemptUncertain
+type exactlyOnceProvider struct {
+	name       string
+	provider   Provider
+	attempts   repository.SendAttemptRepository
+	idempotent bool
+	logger     log.LoggerInterface
+}
+
+// NewExactlyOnceProvider 包装供应商，保证同一次尝试最多发送一次，发送尝试上记录供应商名称用于统计成功率
+func NewExactlyOnceProvider(p Named, attempts repository.SendAttemptRepository, logger log.LoggerInterface) Provider {
+	return &exactlyOnceProvider{
+		name:       p.Name,
+		provider:   p.Provider,
+		attempts:   attempts,
+		idempotent: supportsIdempotencyKey(p.Provider),
+		logger:     log.Named(logger, "provider.exactly_once"),
+	}
+}
+
+func (p *exactlyOnceProvider) Send(ctx context.Context, req Request) (Response, error) {
+	if req.Attempt <= 0 {
+		req.Attempt = 1
+	}
+	req.IdempotencyKey = IdempotencyKey(req.Notification.ID, req.Attempt)
+
+	attempt, err := p.attempts.Create(ctx, domain.SendAttempt{
+		NotificationID: req.Notification.ID,
+		Attempt:        req.Attempt,
+		IdempotencyKey: req.IdempotencyKey,
+		Status:         domain.SendAttemptStatusDispatching,
+		Provider:       p.name,
+		RequestDigest:  RequestDigest(req.Notification),
+	})
+	if errors.Is(err, domain.ErrSendAttemptDuplicate) {
+		return p.resend(ctx, req)
+	}
+	if err != nil {
+		return Response{}, fmt.Errorf("记录发送尝试失败: %w", err)
+	}
+	return p.dispatch(ctx, req, attempt)
+}
+
+// resend 同一次尝试再次发送
+func (p *exactlyOnceProvider) resend(ctx context.Context, req Request) (Response, error) {
+	attempt, err := p.attempts.GetByIdempotencyKey(ctx, req.IdempotencyKey)
+	if err != nil {
+		return Response{}, fmt.Errorf("查询发送尝试失败: %w", err)
+	}
+	switch attempt.Status {
+	case domain.SendAttemptStatusDispatched:
+		p.logger.WithContext(ctx).Info("发送尝试已经被供应商受理，跳过发送",
+			zap.Uint64("notification_id", attempt.NotificationID),
+			zap.String("idempotency_key", attempt.IdempotencyKey))
+		return Response{MessageID: attempt.MessageID}, nil
+	case domain.SendAttemptStatusDispatching:
+		if !p.idempotent {
+			return Response{}, fmt.Errorf("%w: %s", domain.ErrSendAttemptUncertain, attempt.IdempotencyKey)
+		}
+		// 供应商会按照幂等键去重，可以放心重发
+		return p.dispatch(ctx, req, attempt)
+	case domain.SendAttemptStatusFailed:
+		// 供应商明确返回失败，说明没有发出去，重新发送
+		err = p.attempts.CASStatus(ctx, attempt.ID, domain.SendAttemptStatusFailed, domain.SendAttemptStatusDispatching, domain.SendAttemptResult{})
+		if err != nil {
+			return Response{}, err
+		}
+		attempt.Status = domain.SendAttemptStatusDispatching
+		return p.dispatch(ctx, req, attempt)
+	default:
+		return Response{}, fmt.Errorf("%w: 未知的发送尝试状态 %s", domain.ErrInvalidOperation, attempt.Status)
+	}
+}
+
+func (p *exactlyOnceProvider) dispatch(ctx context.Context, req Request, attempt domain.SendAttempt) (Response, error) {
+	start := time.Now()
+	resp, err := p.provider.Send(ctx, req)
+	result := domain.SendAttemptResult{
+		MessageID:    resp.MessageID,
+		ResponseCode: resp.Code,
+		Latency:      time.Since(start),
+	}
+	// ctx 可能已经超时，更新状态使用独立的 context
+	updateCtx := context.WithoutCancel(ctx)
+	logger := p.logger.WithContext(ctx).With(zap.String("idempotency_key", attempt.IdempotencyKey))
+	if err != nil {
+		result.Error = err.Error()
+		if ve, ok := AsVendorError(err); ok {
+			result.ResponseCode = ve.Code
+		}
+		if isUncertain(err) {
+			// 结果未知，保持 DISPATCHING，不支持幂等的供应商后续不会再发送，只记录耗时和错误
+			logger.Warn("调用供应商结果未知", zap.Error(err))
+			if uerr := p.attempts.CASStatus(updateCtx, attempt.ID, domain.SendAttemptStatusDispatching,
+				domain.SendAttemptStatusDispatching, result); uerr != nil {
+				logger.Error("记录发送尝试结果失败", zap.Error(uerr))
+			}
+			return Response{}, err
+		}
+		if uerr := p.attempts.CASStatus(updateCtx, attempt.ID, domain.SendAttemptStatusDispatching,
+			domain.SendAttemptStatusFailed, result); uerr != nil {
+			logger.Error("更新发送尝试状态失败", zap.Error(uerr))
+		}
+		return Response{}, err
+	}
+	if uerr := p.attempts.CASStatus(updateCtx, attempt.ID, domain.SendAttemptStatusDispatching,
+		domain.SendAttemptStatusDispatched, result); uerr != nil {
+		// 已经发出去了，状态没更新成功只会导致重试时再次询问供应商或者被拦截，不影响本次结果
+		logger.Error("更新发送尝试状态失败", zap.Error(uerr))
+	}
+	return resp, nil
+}
+
+// isUncertain 判断调用供应商的错误是否意味着结果未知，比如超时，请求可能已经被供应商处理了
+func isUncertain(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+var _ Provider = (*exactlyOnceProvider)(nil)
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	providerLatencyGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "provider_health_p95_latency_seconds",
+			Help: "P95 latency of recent provider calls used for health-aware routing",
+		},
+		[]string{"provider"},
+	)
+	providerErrorRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "provider_health_error_rate",
+			Help: "Exponentially weighted error rate of provider calls used for health-aware routing",
+		},
+		[]string{"provider"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(providerLatencyGauge, providerErrorRateGauge)
+}
+
+// HealthOptions 供应商健康度参数，零值使用默认值
+type HealthOptions struct {
+	// Alpha 错误率和平均延迟的指数加权系数，默认 0.1
+	Alpha float64
+	// Window 计算 p95 延迟的最近样本数，默认 200
+	Window int
+	// MinSamples 样本数少于这个值不参与调整顺序，默认 20
+	MinSamples int64
+	// MaxErrorRate 错误率超过这个值认为不健康，排到健康的供应商后面，默认 0.3
+	MaxErrorRate float64
+	// LatencyTolerance 后面的供应商 p95 延迟比第一个健康的供应商低这么多比例才优先使用，避免来回切换，默认 0.2
+	LatencyTolerance float64
+	// StaleAfter 超过这么久没有样本时统计作废，按配置的优先级重新尝试，默认 5 分钟
+	StaleAfter time.Duration
+}
+
+func (o HealthOptions) withDefaults() HealthOptions {
+	if o.Alpha <= 0 || o.Alpha > 1 {
+		o.Alpha = 0.1
+	}
+	if o.Window <= 0 {
+		o.Window = 200
+	}
+	if o.MinSamples <= 0 {
+		o.MinSamples = 20
+	}
+	if o.MaxErrorRate <= 0 || o.MaxErrorRate > 1 {
+		o.MaxErrorRate = 0.3
+	}
+	if o.LatencyTolerance <= 0 || o.LatencyTolerance >= 1 {
+		o.LatencyTolerance = 0.2
+	}
+	if o.StaleAfter <= 0 {
+		o.StaleAfter = 5 * time.Minute
+	}
+	return o
+}
+
+// ProviderHealth 供应商最近的调用情况
+type ProviderHealth struct {
+	Name string
+	// Samples 统计作废之后累计的样本数
+	Samples int64
+	// ErrorRate 指数加权的错误率，只统计供应商自身的错误
+	ErrorRate   float64
+	LatencyEWMA time.Duration
+	// LatencyP95 最近 Window 个样本的 p95 延迟
+	LatencyP95     time.Duration
+	LastSampleTime time.Time
+	// Healthy 样本不足或者统计作废时也是 true
+	Healthy bool
+	// Stale 超过 StaleAfter 没有样本，不参与调整顺序
+	Stale bool
+}
+
+// HealthTracker 统计每个供应商的延迟和错误率，选择供应商时优先健康、更快的供应商
+type HealthTracker struct {
+	opts HealthOptions
+
+	mu        sync.Mutex
+	providers map[string]*providerStats
+	now       func() time.Time
+}
+
+func NewHealthTracker(opts HealthOptions) *HealthTracker {
+	return &HealthTracker{
+		opts:      opts.withDefaults(),
+		providers: make(map[string]*providerStats),
+		now:       time.Now,
+	}
+}
+
+type providerStats struct {
+	name        string
+	samples     int64
+	errorRate   float64
+	latencyEWMA float64
+	lastSample  time.Time
+	// latencies 最近 Window 个样本的环形缓冲
+	latencies []time.Duration
+	next      int
+}
+
+// Record 记录一次供应商调用
+func (t *HealthTracker) Record(name string, latency time.Duration, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	s, ok := t.providers[name]
+	if !ok || now.Sub(s.lastSample) > t.opts.StaleAfter {
+		// 很久没有调用的供应商重新开始统计，避免降级之后一直因为历史数据排在后面
+		s = &providerStats{name: name, latencies: make([]time.Duration, 0, t.opts.Window)}
+		t.providers[name] = s
+	}
+	outcome := 0.0
+	if failed {
+		outcome = 1
+	}
+	if s.samples == 0 {
+		s.errorRate, s.latencyEWMA = outcome, float64(latency)
+	} else {
+		s.errorRate = t.opts.Alpha*outcome + (1-t.opts.Alpha)*s.errorRate
+		s.latencyEWMA = t.opts.Alpha*float64(latency) + (1-t.opts.Alpha)*s.latencyEWMA
+	}
+	s.samples++
+	s.lastSample = now
+	if len(s.latencies) < t.opts.Window {
+		s.latencies = append(s.latencies, latency)
+	} else {
+		s.latencies[s.next] = latency
+		s.next = (s.next + 1) % t.opts.Window
+	}
+	providerErrorRateGauge.WithLabelValues(name).Set(s.errorRate)
+	providerLatencyGauge.WithLabelValues(name).Set(s.p95().Seconds())
+}
+
+func (s *providerStats) p95() time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(s.latencies)
+	slices.Sort(sorted)
+	return sorted[(len(sorted)*95-1)/100]
+}
+
+// health 调用方持有 mu
+func (t *HealthTracker) health(s *providerStats) ProviderHealth {
+	h := ProviderHealth{
+		Name:           s.name,
+		Samples:        s.samples,
+		ErrorRate:      s.errorRate,
+		LatencyEWMA:    time.Duration(s.latencyEWMA),
+		LatencyP95:     s.p95(),
+		LastSampleTime: s.lastSample,
+		Stale:          t.now().Sub(s.lastSample) > t.opts.StaleAfter,
+	}
+	h.Healthy = !t.trusted(h) || h.ErrorRate <= t.opts.MaxErrorRate
+	return h
+}
+
+// trusted 样本足够并且没有作废的统计才用来调整顺序
+func (t *HealthTracker) trusted(h ProviderHealth) bool {
+	return !h.Stale && h.Samples >= t.opts.MinSamples
+}
+
+// Rank 按健康度调整供应商顺序：不健康的排到最后，健康的供应商里 p95 延迟明显更低的排到第一个，其余保持配置的优先级
+func (t *HealthTracker) Rank(providers []Named) []Named {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	healthy := make([]Named, 0, len(providers))
+	stats := make([]ProviderHealth, 0, len(providers))
+	var unhealthy []Named
+	for _, p := range providers {
+		h := ProviderHealth{Name: p.Name, Healthy: true}
+		if s, ok := t.providers[p.Name]; ok {
+			h = t.health(s)
+		}
+		if !h.Healthy {
+			unhealthy = append(unhealthy, p)
+			continue
+		}
+		healthy = append(healthy, p)
+		stats = append(stats, h)
+	}
+	best := 0
+	for i := 1; i < len(healthy); i++ {
+		if t.trusted(stats[i]) && t.trusted(stats[best]) &&
+			float64(stats[i].LatencyP95) < float64(stats[best].LatencyP95)*(1-t.opts.LatencyTolerance) {
+			best = i
+		}
+	}
+	if best == 0 && len(unhealthy) == 0 {
+		return providers
+	}
+	res := make([]Named, 0, len(providers))
+	res = append(res, healthy[best:best+1]...)
+	res = append(res, healthy[:best]...)
+	res = append(res, healthy[best+1:]...)
+	return append(res, unhealthy...)
+}
+
+// Snapshot 所有供应商的健康状况，按名称排序
+func (t *HealthTracker) Snapshot() []ProviderHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	res := make([]ProviderHealth, 0, len(t.providers))
+	for _, s := range t.providers {
+		res = append(res, t.health(s))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+var _ Provider = (*healthRecorder)(nil)
+
+// NewHealthRecorder 记录每次调用供应商的延迟和结果，接收者、模板这类不是供应商自身的问题不算失败
+func NewHealthRecorder(name string, p Provider, tracker *HealthTracker) Provider {
+	return &healthRecorder{name: name, provider: p, tracker: tracker}
+}
+
+type healthRecorder struct {
+	name     string
+	provider Provider
+	tracker  *HealthTracker
+}
+
+func (r *healthRecorder) Send(ctx context.Context, req Request) (Response, error) {
+	start := time.Now()
+	resp, err := r.provider.Send(ctx, req)
+	if ctx.Err() != nil {
+		// 调用方自己取消的请求不代表供应商不健康
+		return resp, err
+	}
+	failed := err != nil
+	if ve, ok := AsVendorError(err); ok && !ve.Category.ProviderFault() {
+		failed = false
+	}
+	r.tracker.Record(r.name, time.Since(start), failed)
+	return resp, err
+}
+
+// SupportsIdempotencyKey 透传被包装供应商的幂等能力
+func (r *healthRecorder) SupportsIdempotencyKey() bool {
+	return supportsIdempotencyKey(r.provider)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/eventbus"
+)
+
+// InAppPushProviderName 站内信推送供应商在供应商路由里的名称
+const InAppPushProviderName = "push"
+
+var _ Provider = (*inAppPushProvider)(nil)
+
+// NewInAppPushProvider 站内信供应商，把每个接收者的消息发布到事件总线，由推送网关实时推给在线用户
+// 通知本身就在收件箱里，发布成功即发送成功，不在线的用户重新连接时补发
+func NewInAppPushProvider(bus eventbus.InAppBus) Provider {
+	return &inAppPushProvider{bus: bus}
+}
+
+type inAppPushProvider struct {
+	bus eventbus.InAppBus
+}
+
+func (p *inAppPushProvider) Send(ctx context.Context, req Request) (Response, error) {
+	n := req.Notification
+	if n.Channel != domain.ChannelInApp {
+		return Response{}, fmt.Errorf("%w: 站内信推送不支持渠道 %s", domain.ErrUnknownChannel, n.Channel)
+	}
+	if req.DryRun {
+		return Response{}, nil
+	}
+	for _, receiver := range n.Receivers {
+		if err := p.bus.Publish(ctx, domain.NewInAppMessage(n, receiver)); err != nil {
+			return Response{}, fmt.Errorf("发布站内信事件失败: %w", err)
+		}
+	}
+	return Response{MessageID: strconv.FormatUint(n.ID, 10)}, nil
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// MockProviderName 模拟供应商在供应商路由里的名称
+const MockProviderName = "mock"
+
+var _ Provider = (*mockProvider)(nil)
+
+// MockOptions 模拟供应商的行为，零值表示立刻返回成功
+type MockOptions struct {
+	// FailPercent 返回失败的比例，0-100，按通知ID和第几次尝试哈希，同一次尝试重复调用结果一样
+	FailPercent int
+	// FailCategory 失败时的错误分类，为空是 domain.VendorErrorUnavailable，可以重试
+	FailCategory domain.VendorErrorCategory
+	// Delay 每次调用的耗时
+	Delay time.Duration
+}
+
+// NewMockProvider 模拟供应商，不真正投递，按 opts 模拟耗时和失败，沙箱通知都发给它
+func NewMockProvider(opts MockOptions) Provider {
+	if opts.FailCategory == "" {
+		opts.FailCategory = domain.VendorErrorUnavailable
+	}
+	return &mockProvider{opts: opts}
+}
+
+type mockProvider struct {
+	opts MockOptions
+}
+
+func (p *mockProvider) Send(ctx context.Context, req Request) (Response, error) {
+	if p.opts.Delay > 0 {
+		select {
+		case <-ctx.Done():
+			return Response{}, ctx.Err()
+		case <-time.After(p.opts.Delay):
+		}
+	}
+	if p.opts.FailPercent > 0 && mockBucket(req) < p.opts.FailPercent {
+		return Response{}, &VendorError{
+			Vendor:   MockProviderName,
+			Code:     "MOCK_" + p.opts.FailCategory.String(),
+			Message:  "模拟失败",
+			Category: p.opts.FailCategory,
+		}
+	}
+	return Response{MessageID: fmt.Sprintf("%s-%d-%d", MockProviderName, req.Notification.ID, req.Attempt)}, nil
+}
+
+func mockBucket(req Request) int {
+	h := fnv.New32a()
+	_, _ = h.Write(strconv.AppendUint(nil, req.Notification.ID, 10))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write(strconv.AppendInt(nil, int64(req.Attempt), 10))
+	return int(h.Sum32() % 100)
+}
+
+// SupportsIdempotencyKey 消息ID和是否失败都由通知ID和第几次尝试决定，重复调用结果一样
+func (p *mockProvider) SupportsIdempotencyKey() bool {
+	return true
+}
+
+var _ Selector = (*sandboxSelector)(nil)
+
+// NewSandboxSelector 沙箱通知都选择模拟供应商，生产环境的通知交给 next
+func NewSandboxSelector(next Selector, mock Named) Selector {
+	return &sandboxSelector{next: next, mock: mock}
+}
+
+type sandboxSelector struct {
+	next Selector
+	mock Named
+}
+
+func (s *sandboxSelector) Select(ctx context.Context, notification domain.Notification) (Named, error) {
+	if notification.IsSandbox() {
+		return s.mock, nil
+	}
+	return s.next.Select(ctx, notification)
+}
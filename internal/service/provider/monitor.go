@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/anomaly"
+)
+
+// Breaker 供应商熔断器，熔断期间该供应商的发送直接失败，由上层换供应商或者稍后重试
+type Breaker struct {
+	mu        sync.RWMutex
+	openUntil map[string]time.Time
+}
+
+func NewBreaker() *Breaker {
+	return &Breaker{openUntil: make(map[string]time.Time)}
+}
+
+// Trip 熔断供应商 d 时间，熔断期间再次熔断以更晚的结束时间为准
+func (b *Breaker) Trip(name string, d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	until := time.Now().Add(d)
+	if until.After(b.openUntil[name]) {
+		b.openUntil[name] = until
+	}
+}
+
+// Allow 供应商当前是否可用
+func (b *Breaker) Allow(name string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return time.Now().After(b.openUntil[name])
+}
+
+// TripBreakerHandler 供应商失败率异常时自动熔断，业务方维度的异常不影响供应商
+func TripBreakerHandler(breaker *Breaker, d time.Duration) anomaly.Handler {
+	return func(_ context.Context, alert anomaly.Alert) {
+		if alert.Dimension == anomaly.DimensionProvider {
+			breaker.Trip(alert.Key, d)
+		}
+	}
+}
+
+// monitoredProvider 统计供应商和业务方的发送结果，用于失败率异常检测
+type monitoredProvider struct {
+	name     string
+	provider Provider
+	detector *anomaly.Detector
+	breaker  *Breaker
+}
+
+// NewMonitoredProvider 包装供应商，breaker 为 nil 时不熔断
+func NewMonitoredProvider(name string, p Provider, detector *anomaly.Detector, breaker *Breaker) Provider {
+	return &monitoredProvider{
+		name:     name,
+		provider: p,
+		detector: detector,
+		breaker:  breaker,
+	}
+}
+
+func (p *monitoredProvider) Send(ctx context.Context, req Request) (Response, error) {
+	if p.breaker != nil && !p.breaker.Allow(p.name) {
+		return Response{}, fmt.Errorf("%w: 供应商 %s", domain.ErrCircuitBreaker, p.name)
+	}
+	resp, err := p.provider.Send(ctx, req)
+	failed := err != nil
+	// 接收者、模板这类不是供应商自身的问题不计入供应商失败率，避免一批错误号码把供应商熔断
+	providerFailed := failed
+	if ve, ok := AsVendorError(err); ok && !ve.Category.ProviderFault() {
+		providerFailed = false
+	}
+	p.detector.Record(ctx, anomaly.DimensionProvider, p.name, providerFailed)
+	p.detector.Record(ctx, anomaly.DimensionBiz, strconv.FormatInt(req.Notification.BizID, 10), failed)
+	return resp, err
+}
+
+// SupportsIdempotencyKey 透传被包装供应商的幂等能力
+func (p *monitoredProvider) SupportsIdempotencyKey() bool {
+	return supportsIdempotencyKey(p.provider)
+}
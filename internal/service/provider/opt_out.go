@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var _ Provider = (*optOutGuard)(nil)
+
+// NewOptOutGuard 营销类通知调用供应商之前去掉已经退订的接收者，全部退订时返回 domain.ErrReceiverOptedOut，不调用供应商
+// 事务类通知不检查；查询退订失败时照常发送
+func NewOptOutGuard(p Provider, optOuts repository.OptOutRepository, logger log.LoggerInterface) Provider {
+	return &optOutGuard{
+		provider: p,
+		optOuts:  optOuts,
+		logger:   log.Named(logger, "provider.opt_out"),
+	}
+}
+
+type optOutGuard struct {
+	provider Provider
+	optOuts  repository.OptOutRepository
+	logger   log.LoggerInterface
+}
+
+func (g *optOutGuard) Send(ctx context.Context, req Request) (Response, error) {
+	n := req.Notification
+	if n.Category != domain.NotificationCategoryMarketing {
+		return g.provider.Send(ctx, req)
+	}
+	optedOut, err := g.optOuts.OptedOut(ctx, n.BizID, n.Channel, n.Receivers)
+	if err != nil {
+		g.logger.WithContext(ctx).Warn("查询接收者退订失败，照常发送",
+			zap.Uint64("notification_id", n.ID),
+			zap.Error(err))
+	}
+	if len(optedOut) > 0 {
+		receivers := slices.DeleteFunc(slices.Clone(n.Receivers), func(r string) bool {
+			return slices.Contains(optedOut, r)
+		})
+		if len(receivers) == 0 {
+			return Response{}, fmt.Errorf("%w: 通知 %d 的接收者都退订了 %s 渠道的营销类通知",
+				domain.ErrReceiverOptedOut, n.ID, n.Channel)
+		}
+		g.logger.WithContext(ctx).Info("跳过已经退订的接收者",
+			zap.Uint64("notification_id", n.ID),
+			zap.Int("skipped", len(n.Receivers)-len(receivers)))
+		req.Notification.Receivers = receivers
+	}
+	return g.provider.Send(ctx, req)
+}
+
+// SupportsIdempotencyKey 透传被包装供应商的幂等能力
+func (g *optOutGuard) SupportsIdempotencyKey() bool {
+	return supportsIdempotencyKey(g.provider)
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// Provider 供应商
+type Provider interface {
+	// Send 调用供应商发送通知
+	Send(ctx context.Context, req Request) (Response, error)
+}
+
+// IdempotencyKeySupporter 供应商可选实现，返回 true 表示供应商会按照 Request.IdempotencyKey 去重，
+// 同一个幂等键重复调用只会真正发送一次
+type IdempotencyKeySupporter interface {
+	SupportsIdempotencyKey() bool
+}
+
+// Request 发送请求
+type Request struct {
+	Notification domain.Notification
+	// Attempt 第几次尝试，从 1 开始
+	Attempt int
+	// IdempotencyKey 幂等键，支持幂等的供应商需要透传给供应商
+	IdempotencyKey string
+	// DryRun 影子流量，供应商不能真正投递，只校验请求或者以测试消息的方式提交
+	DryRun bool
+	// Template 通知使用的模板版本，发送方调用供应商之前查好，短信供应商使用它的签名和供应商模板ID
+	Template domain.ChannelTemplateVersion
+}
+
+// Response 发送响应
+type Response struct {
+	// MessageID 供应商返回的消息ID
+	MessageID string
+	// Code 供应商返回的状态码，记录到发送尝试上，没有时为空
+	Code string
+}
+
+// IdempotencyKey 生成确定性的幂等键，同一个通知的同一次尝试无论重试多少次都一样
+func IdempotencyKey(notificationID uint64, attempt int) string {
+	return fmt.Sprintf("%d-%d", notificationID, attempt)
+}
+
+// RequestDigest 发送内容的 SHA-256，渠道、接收者、模板版本和参数相同时摘要相同
+func RequestDigest(n domain.Notification) string {
+	// map 序列化时按 key 排序，结果是确定的
+	b, _ := json.Marshal(struct {
+		Channel   domain.Channel    `json:"channel"`
+		Receivers []string          `json:"receivers"`
+		Template  int64             `json:"template"`
+		Version   int64             `json:"version"`
+		Params    map[string]string `json:"params"`
+		// 没有按接收者覆盖的参数时不出现，摘要和之前一样
+		ReceiverParams map[string]map[string]string `json:"receiverParams,omitempty"`
+	}{n.Channel, n.Receivers, n.Template.ID, n.Template.VersionID, n.Template.Params, n.Template.ReceiverParams})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func supportsIdempotencyKey(p Provider) bool {
+	s, ok := p.(IdempotencyKeySupporter)
+	return ok && s.SupportsIdempotencyKey()
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/featureflag"
+)
+
+// Named 带名称的供应商，名称用于熔断、统计和影子流量报告
+type Named struct {
+	Name     string
+	Provider Provider
+}
+
+// Route 一个渠道的路由
+type Route struct {
+	// Providers 按优先级排列，优先使用没有熔断的第一个，开启健康度路由时先按健康度调整顺序
+	Providers []Named
+	// Shadow 影子供应商，为 nil 不复制流量
+	Shadow *ShadowRoute
+}
+
+// ShadowRoute 新接入的供应商，复制一部分真实流量以 DryRun 的方式发过去，只用于对比，不影响真实发送结果
+type ShadowRoute struct {
+	Provider Named
+	// Percent 复制流量的比例，0-100，按照通知ID取模，同一条通知的重试要么都复制要么都不复制
+	// 只复制 featureflag.ShadowRouting 对其开启的业务方的通知
+	Percent uint64
+}
+
+// Selector 供应商选择器
+type Selector interface {
+	// Select 为通知选择供应商，所有供应商都熔断时返回 domain.ErrNoAvailableProvider
+	Select(ctx context.Context, notification domain.Notification) (Named, error)
+}
+
+var _ Selector = (*selector)(nil)
+
+// NewSelector breaker、reporter 和 health 可以为 nil，分别表示不考虑熔断、不复制影子流量、只按配置的优先级选择
+// flags 为 nil 时影子流量对所有业务方开启
+func NewSelector(routes map[domain.Channel]Route, breaker *Breaker, reporter *ShadowReporter, health *HealthTracker,
+	flags *featureflag.Flags,
+) Selector {
+	return &selector{
+		routes:   routes,
+		breaker:  breaker,
+		reporter: reporter,
+		health:   health,
+		flags:    flags,
+	}
+}
+
+type selector struct {
+	routes   map[domain.Channel]Route
+	breaker  *Breaker
+	reporter *ShadowReporter
+	health   *HealthTracker
+	flags    *featureflag.Flags
+}
+
+func (s *selector) Select(_ context.Context, notification domain.Notification) (Named, error) {
+	route, ok := s.routes[notification.Channel]
+	if !ok {
+		return Named{}, fmt.Errorf("%w: 渠道 %s", domain.ErrNoAvailableProvider, notification.Channel)
+	}
+	providers := route.Providers
+	if s.health != nil {
+		providers = s.health.Rank(providers)
+	}
+	for _, p := range providers {
+		if s.breaker != nil && !s.breaker.Allow(p.Name) {
+			continue
+		}
+		if route.Shadow != nil && s.reporter != nil && notification.ID%100 < route.Shadow.Percent &&
+			s.flags.Enabled(featureflag.ShadowRouting, notification.BizID) {
+			return Named{
+				Name:     p.Name,
+				Provider: newShadowProvider(p, route.Shadow.Provider, s.reporter),
+			}, nil
+		}
+		return p, nil
+	}
+	return Named{}, fmt.Errorf("%w: 渠道 %s 的供应商都已熔断", domain.ErrNoAvailableProvider, notification.Channel)
+}
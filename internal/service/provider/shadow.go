@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	shadowRolePrimary = "primary"
+	shadowRoleShadow  = "shadow"
+
+	defaultShadowTimeout = 10 * time.Second
+)
+
+var (
+	shadowLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "provider_shadow_latency_seconds",
+			Help:    "Send latency of primary and shadow providers for mirrored requests",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"primary", "shadow", "role"},
+	)
+	shadowResultCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "provider_shadow_result_total",
+			Help: "Total number of mirrored requests by provider role and result",
+		},
+		[]string{"primary", "shadow", "role", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(shadowLatency, shadowResultCounter)
+}
+
+// ShadowComparison 主供应商和影子供应商的对比报告
+type ShadowComparison struct {
+	Primary string `json:"primary"`
+	Shadow  string `json:"shadow"`
+	// Samples 复制的请求数
+	Samples int64 `json:"samples"`
+	// PrimaryAcceptRate 主供应商受理率
+	PrimaryAcceptRate float64 `json:"primaryAcceptRate"`
+	// ShadowAcceptRate 影子供应商受理率
+	ShadowAcceptRate float64 `json:"shadowAcceptRate"`
+	// AgreementRate 两边结果一致（都受理或者都拒绝）的比例
+	AgreementRate float64 `json:"agreementRate"`
+	// PrimaryAvgLatency 主供应商平均耗时
+	PrimaryAvgLatency time.Duration `json:"primaryAvgLatency"`
+	// ShadowAvgLatency 影子供应商平均耗时
+	ShadowAvgLatency time.Duration `json:"shadowAvgLatency"`
+	// ShadowErrors 影子供应商按错误信息统计的次数，用于排查接入问题
+	ShadowErrors map[string]int64 `json:"shadowErrors,omitempty"`
+}
+
+// ShadowReporter 汇总影子流量的对比结果，指标同时上报到 prometheus
+type ShadowReporter struct {
+	timeout time.Duration
+
+	mu    sync.Mutex
+	pairs map[shadowPair]*shadowStats
+}
+
+// NewShadowReporter timeout 是影子请求的超时时间，小于等于 0 使用默认值
+func NewShadowReporter(timeout time.Duration) *ShadowReporter {
+	if timeout <= 0 {
+		timeout = defaultShadowTimeout
+	}
+	return &ShadowReporter{
+		timeout: timeout,
+		pairs:   make(map[shadowPair]*shadowStats),
+	}
+}
+
+type shadowPair struct {
+	primary string
+	shadow  string
+}
+
+type shadowStats struct {
+	samples         int64
+	primaryAccepted int64
+	shadowAccepted  int64
+	agreed          int64
+	primaryLatency  time.Duration
+	shadowLatency   time.Duration
+	shadowErrors    map[string]int64
+}
+
+// maxShadowErrorKinds 每对供应商最多记录多少种错误，防止错误信息里带变量导致内存无限增长
+const maxShadowErrorKinds = 50
+
+type shadowResult struct {
+	latency time.Duration
+	err     error
+}
+
+func (r *ShadowReporter) record(pair shadowPair, primary, shadow shadowResult) {
+	r.observe(pair, shadowRolePrimary, primary)
+	r.observe(pair, shadowRoleShadow, shadow)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.pairs[pair]
+	if !ok {
+		s = &shadowStats{shadowErrors: make(map[string]int64)}
+		r.pairs[pair] = s
+	}
+	s.samples++
+	s.primaryLatency += primary.latency
+	s.shadowLatency += shadow.latency
+	if primary.err == nil {
+		s.primaryAccepted++
+	}
+	if shadow.err == nil {
+		s.shadowAccepted++
+	} else {
+		msg := shadow.err.Error()
+		if _, exists := s.shadowErrors[msg]; exists || len(s.shadowErrors) < maxShadowErrorKinds {
+			s.shadowErrors[msg]++
+		}
+	}
+	if (primary.err == nil) == (shadow.err == nil) {
+		s.agreed++
+	}
+}
+
+func (r *ShadowReporter) observe(pair shadowPair, role string, res shadowResult) {
+	result := "accepted"
+	if res.err != nil {
+		result = "rejected"
+	}
+	shadowLatency.WithLabelValues(pair.primary, pair.shadow, role).Observe(res.latency.Seconds())
+	shadowResultCounter.WithLabelValues(pair.primary, pair.shadow, role, result).Inc()
+}
+
+// Report 当前的对比报告，按主供应商、影子供应商排序
+func (r *ShadowReporter) Report() []ShadowComparison {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]ShadowComparison, 0, len(r.pairs))
+	for pair, s := range r.pairs {
+		errs := make(map[string]int64, len(s.shadowErrors))
+		for k, v := range s.shadowErrors {
+			errs[k] = v
+		}
+		n := float64(s.samples)
+		res = append(res, ShadowComparison{
+			Primary:           pair.primary,
+			Shadow:            pair.shadow,
+			Samples:           s.samples,
+			PrimaryAcceptRate: float64(s.primaryAccepted) / n,
+			ShadowAcceptRate:  float64(s.shadowAccepted) / n,
+			AgreementRate:     float64(s.agreed) / n,
+			PrimaryAvgLatency: s.primaryLatency / time.Duration(s.samples),
+			ShadowAvgLatency:  s.shadowLatency / time.Duration(s.samples),
+			ShadowErrors:      errs,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Primary != res[j].Primary {
+			return res[i].Primary < res[j].Primary
+		}
+		return res[i].Shadow < res[j].Shadow
+	})
+	return res
+}
+
+// Reset 清空对比结果，一般在影子供应商配置调整之后重新统计
+func (r *ShadowReporter) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pairs = make(map[shadowPair]*shadowStats)
+}
+
+// shadowProvider 真实请求发给主供应商，发送完成后异步以 DryRun 的方式复制给影子供应商
+// 影子供应商的结果只用于对比，不影响返回值
+type shadowProvider struct {
+	primary  Named
+	shadow   Named
+	reporter *ShadowReporter
+}
+
+func newShadowProvider(primary, shadow Named, reporter *ShadowReporter) Provider {
+	return &shadowProvider{
+		primary:  primary,
+		shadow:   shadow,
+		reporter: reporter,
+	}
+}
+
+func (p *shadowProvider) Send(ctx context.Context, req Request) (Response, error) {
+	start := time.Now()
+	resp, err := p.primary.Provider.Send(ctx, req)
+	primary := shadowResult{latency: time.Since(start), err: err}
+
+	shadowReq := req
+	shadowReq.DryRun = true
+	if req.IdempotencyKey != "" {
+		shadowReq.IdempotencyKey = "shadow-" + req.IdempotencyKey
+	}
+	go p.mirror(context.WithoutCancel(ctx), shadowReq, primary)
+	return resp, err
+}
+
+func (p *shadowProvider) mirror(ctx context.Context, req Request, primary shadowResult) {
+	ctx, cancel := context.WithTimeout(ctx, p.reporter.timeout)
+	defer cancel()
+	start := time.Now()
+	_, err := p.shadow.Provider.Send(ctx, req)
+	p.reporter.record(shadowPair{primary: p.primary.Name, shadow: p.shadow.Name},
+		primary, shadowResult{latency: time.Since(start), err: err})
+}
+
+// SupportsIdempotencyKey 透传主供应商的幂等能力
+func (p *shadowProvider) SupportsIdempotencyKey() bool {
+	return supportsIdempotencyKey(p.primary.Provider)
+}
@@ -0,0 +1,372 @@
+// Package sms 短信供应商
+package sms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+)
+
+const (
+	defaultAliyunEndpoint    = "https://dysmsapi.aliyuncs.com"
+	defaultAliyunBSSEndpoint = "https://business.aliyuncs.com"
+	aliyunAPIVersion         = "2017-05-25"
+	aliyunBSSAPIVersion      = "2017-12-14"
+)
+
+// AliyunOptions 阿里云短信
+type AliyunOptions struct {
+	// Endpoint 短信接口地址，为空时使用 https://dysmsapi.aliyuncs.com
+	Endpoint string
+	// BSSEndpoint 费用中心接口地址，为空时使用 https://business.aliyuncs.com
+	BSSEndpoint     string
+	AccessKeyID     string
+	AccessKeySecret string
+	// TemplateType 模板类型：0 验证码，1 短信通知，2 推广短信
+	TemplateType int
+	// Errors 错误码映射，为空时只使用内置规则
+	Errors *provider.ErrorCodeMapper
+	// Name 配置里的账号名称，发送时按它查找模板在这个账号下审核通过的 TemplateCode
+	Name string
+}
+
+// AliyunVendor 阿里云在错误码映射里的供应商名称
+const AliyunVendor = "aliyun"
+
+var (
+	_ provider.Provider                    = (*AliyunClient)(nil)
+	_ provider.TemplateReviewer            = (*AliyunClient)(nil)
+	_ provider.TemplateAuditCallbackParser = (*AliyunClient)(nil)
+	_ provider.SMSReplyParser              = (*AliyunClient)(nil)
+	_ provider.BalanceQuerier              = (*AliyunClient)(nil)
+)
+
+// AliyunClient 阿里云短信账号：发送短信和管理接口（模板审核、审核结果推送、账户余额），模板内容的变量使用 ${name}
+type AliyunClient struct {
+	httpClient *http.Client
+	opts       AliyunOptions
+}
+
+func NewAliyunClient(httpClient *http.Client, opts AliyunOptions) (*AliyunClient, error) {
+	if opts.AccessKeyID == "" || opts.AccessKeySecret == "" {
+		return nil, fmt.Errorf("%w: 阿里云 AccessKey 不能为空", domain.ErrInvalidParameter)
+	}
+	if opts.Errors == nil {
+		opts.Errors = provider.NewErrorCodeMapper(nil)
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = defaultAliyunEndpoint
+	}
+	if opts.BSSEndpoint == "" {
+		opts.BSSEndpoint = defaultAliyunBSSEndpoint
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	opts.BSSEndpoint = strings.TrimRight(opts.BSSEndpoint, "/")
+	return &AliyunClient{httpClient: httpClient, opts: opts}, nil
+}
+
+type aliyunResponse struct {
+	RequestID string `json:"RequestId"`
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+	// AddSmsTemplate
+	TemplateCode string `json:"TemplateCode"`
+	// QuerySmsTemplate：0 审核中，1 审核通过，2 审核失败，10 取消审核
+	TemplateStatus int    `json:"TemplateStatus"`
+	Reason         string `json:"Reason"`
+	// SendSms、SendBatchSms 的回执ID
+	BizID string `json:"BizId"`
+}
+
+// Send 所有接收者使用同样的参数时调用 SendSms，有按接收者覆盖的参数时调用 SendBatchSms，每个号码使用自己的参数
+// 阿里云没有测试发送，影子流量只校验模板在这个账号下审核通过，不调用接口
+func (r *AliyunClient) Send(ctx context.Context, req provider.Request) (provider.Response, error) {
+	code, err := templateCode(AliyunVendor, r.opts.Name, req.Template)
+	if err != nil || req.DryRun {
+		return provider.Response{}, err
+	}
+	n := req.Notification
+	action := "SendSms"
+	params := map[string]string{"TemplateCode": code}
+	if len(n.Template.ReceiverParams) == 0 {
+		params["PhoneNumbers"] = strings.Join(n.Receivers, ",")
+		params["SignName"] = req.Template.Signature
+		params["OutId"] = req.IdempotencyKey
+		if len(n.Template.Params) > 0 {
+			b, _ := json.Marshal(n.Template.Params)
+			params["TemplateParam"] = string(b)
+		}
+	} else {
+		action = "SendBatchSms"
+		signs := make([]string, 0, len(n.Receivers))
+		templateParams := make([]map[string]string, 0, len(n.Receivers))
+		for _, receiver := range n.Receivers {
+			signs = append(signs, req.Template.Signature)
+			templateParams = append(templateParams, n.Template.ParamsFor(receiver))
+		}
+		phones, _ := json.Marshal(n.Receivers)
+		signNames, _ := json.Marshal(signs)
+		paramsJSON, _ := json.Marshal(templateParams)
+		params["PhoneNumberJson"] = string(phones)
+		params["SignNameJson"] = string(signNames)
+		params["TemplateParamJson"] = string(paramsJSON)
+	}
+	resp, err := r.callSMS(ctx, action, params)
+	if err != nil {
+		return provider.Response{}, err
+	}
+	return provider.Response{MessageID: resp.BizID, Code: resp.Code}, nil
+}
+
+func (r *AliyunClient) SubmitTemplate(ctx context.Context, template domain.ChannelTemplate, version domain.ChannelTemplateVersion) (string, error) {
+	resp, err := r.callSMS(ctx, "AddSmsTemplate", map[string]string{
+		"TemplateType":    strconv.Itoa(r.opts.TemplateType),
+		"TemplateName":    template.Name,
+		"TemplateContent": version.Content,
+		"Remark":          reviewRemark(template, version),
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.TemplateCode == "" {
+		return "", fmt.Errorf("%w: 阿里云没有返回 TemplateCode, RequestId = %s", domain.ErrExternalServiceError, resp.RequestID)
+	}
+	return resp.TemplateCode, nil
+}
+
+func (r *AliyunClient) QueryTemplateReview(ctx context.Context, providerTemplateID string) (provider.TemplateReviewResult, error) {
+	resp, err := r.callSMS(ctx, "QuerySmsTemplate", map[string]string{"TemplateCode": providerTemplateID})
+	if err != nil {
+		return provider.TemplateReviewResult{}, err
+	}
+	switch resp.TemplateStatus {
+	case 0:
+		return provider.TemplateReviewResult{Status: domain.AuditStatusInReview}, nil
+	case 1:
+		return provider.TemplateReviewResult{Status: domain.AuditStatusApproved}, nil
+	case 10:
+		return provider.TemplateReviewResult{Status: domain.AuditStatusRejected, Reason: "在阿里云控制台取消了审核"}, nil
+	default:
+		return provider.TemplateReviewResult{Status: domain.AuditStatusRejected, Reason: resp.Reason}, nil
+	}
+}
+
+// aliyunAuditMessage 阿里云 SmsTemplateAudit 消息（HTTP 批量推送），请求体是 JSON 数组
+type aliyunAuditMessage struct {
+	TemplateCode string `json:"template_code"`
+	// AuditState AUDIT_STATE_INIT 审核中，AUDIT_STATE_PASS 审核通过，AUDIT_STATE_NOT_PASS 审核未通过，AUDIT_SATE_CANCEL 取消审核
+	AuditState string `json:"audit_state"`
+	Reason     string `json:"reason"`
+}
+
+func (r *AliyunClient) ParseAuditCallback(body []byte) ([]provider.TemplateAuditCallback, error) {
+	var msgs []aliyunAuditMessage
+	if err := json.Unmarshal(body, &msgs); err != nil {
+		return nil, fmt.Errorf("%w: 阿里云模板审核消息格式错误: %w", domain.ErrInvalidParameter, err)
+	}
+	res := make([]provider.TemplateAuditCallback, 0, len(msgs))
+	for _, m := range msgs {
+		if m.TemplateCode == "" {
+			return nil, fmt.Errorf("%w: 阿里云模板审核消息缺少 template_code", domain.ErrInvalidParameter)
+		}
+		cb := provider.TemplateAuditCallback{ProviderTemplateID: m.TemplateCode}
+		switch m.AuditState {
+		case "AUDIT_STATE_INIT":
+			cb.Status = domain.AuditStatusInReview
+		case "AUDIT_STATE_PASS":
+			cb.Status = domain.AuditStatusApproved
+		case "AUDIT_STATE_NOT_PASS":
+			cb.Status, cb.Reason = domain.AuditStatusRejected, m.Reason
+		case "AUDIT_SATE_CANCEL", "AUDIT_STATE_CANCEL":
+			cb.Status, cb.Reason = domain.AuditStatusRejected, "在阿里云控制台取消了审核"
+		default:
+			return nil, fmt.Errorf("%w: 阿里云模板审核状态 %q", domain.ErrInvalidParameter, m.AuditState)
+		}
+		res = append(res, cb)
+	}
+	return res, nil
+}
+
+func (r *AliyunClient) AuditCallbackAck() []byte {
+	return []byte(`{"code":0,"msg":"成功"}`)
+}
+
+// aliyunReplyMessage 阿里云 SmsUp 上行短信消息（HTTP 批量推送），请求体是 JSON 数组
+type aliyunReplyMessage struct {
+	PhoneNumber string `json:"phone_number"`
+	Content     string `json:"content"`
+}
+
+func (r *AliyunClient) ParseSMSReply(body []byte) ([]provider.SMSReply, error) {
+	var msgs []aliyunReplyMessage
+	if err := json.Unmarshal(body, &msgs); err != nil {
+		return nil, fmt.Errorf("%w: 阿里云上行短信消息格式错误: %w", domain.ErrInvalidParameter, err)
+	}
+	res := make([]provider.SMSReply, 0, len(msgs))
+	for _, m := range msgs {
+		if m.PhoneNumber == "" {
+			return nil, fmt.Errorf("%w: 阿里云上行短信消息缺少 phone_number", domain.ErrInvalidParameter)
+		}
+		res = append(res, provider.SMSReply{Mobile: m.PhoneNumber, Content: m.Content})
+	}
+	return res, nil
+}
+
+func (r *AliyunClient) SMSReplyAck() []byte {
+	return r.AuditCallbackAck()
+}
+
+type aliyunBalanceResponse struct {
+	RequestID string `json:"RequestId"`
+	Code      string `json:"Code"`
+	Message   string `json:"Message"`
+	Success   bool   `json:"Success"`
+	Data      struct {
+		// AvailableAmount 可用额度，带千分位，比如 "1,234.56"
+		AvailableAmount string `json:"AvailableAmount"`
+		Currency        string `json:"Currency"`
+	} `json:"Data"`
+}
+
+// QueryBalance 阿里云短信按量后付费从账户余额扣费，查询费用中心的可用额度
+func (r *AliyunClient) QueryBalance(ctx context.Context) (domain.VendorBalance, error) {
+	body, err := r.call(ctx, r.opts.BSSEndpoint, aliyunBSSAPIVersion, "QueryAccountBalance", nil)
+	if err != nil {
+		return domain.VendorBalance{}, err
+	}
+	var resp aliyunBalanceResponse
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return domain.VendorBalance{}, fmt.Errorf("%w: 阿里云 QueryAccountBalance 响应不是 JSON", domain.ErrExternalServiceError)
+	}
+	if !resp.Success {
+		return domain.VendorBalance{}, r.vendorError("QueryAccountBalance", resp.Code, resp.Message, resp.RequestID)
+	}
+	cents, err := parseCents(resp.Data.AvailableAmount)
+	if err != nil {
+		return domain.VendorBalance{}, fmt.Errorf("%w: 阿里云可用额度 %q: %w", domain.ErrExternalServiceError, resp.Data.AvailableAmount, err)
+	}
+	return domain.VendorBalance{Unit: domain.VendorBalanceUnitCent, Remaining: cents}, nil
+}
+
+// parseCents 把带千分位的金额转换成分
+func parseCents(amount string) (int64, error) {
+	f, err := strconv.ParseFloat(strings.ReplaceAll(amount, ",", ""), 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(math.Round(f * 100)), nil
+}
+
+// callSMS 调用短信接口，Code 不是 OK 时返回错误
+func (r *AliyunClient) callSMS(ctx context.Context, action string, params map[string]string) (aliyunResponse, error) {
+	body, err := r.call(ctx, r.opts.Endpoint, aliyunAPIVersion, action, params)
+	if err != nil {
+		return aliyunResponse{}, err
+	}
+	var resp aliyunResponse
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return aliyunResponse{}, fmt.Errorf("%w: 阿里云 %s 响应不是 JSON", domain.ErrExternalServiceError, action)
+	}
+	if resp.Code != "OK" {
+		return aliyunResponse{}, r.vendorError(action, resp.Code, resp.Message, resp.RequestID)
+	}
+	return resp, nil
+}
+
+// vendorError 阿里云明确返回的失败，错误码映射成统一的分类
+func (r *AliyunClient) vendorError(action, code, message, requestID string) error {
+	return r.opts.Errors.Error(AliyunVendor, code, fmt.Sprintf("%s %s, RequestId = %s", action, message, requestID))
+}
+
+// call 按 RPC 风格签名（HMAC-SHA1）调用，参数都放在 query 里，返回响应体
+func (r *AliyunClient) call(ctx context.Context, endpoint, version, action string, params map[string]string) ([]byte, error) {
+	query := map[string]string{
+		"Action":           action,
+		"Format":           "JSON",
+		"Version":          version,
+		"AccessKeyId":      r.opts.AccessKeyID,
+		"SignatureMethod":  "HMAC-SHA1",
+		"SignatureVersion": "1.0",
+		"SignatureNonce":   nonce(),
+		"Timestamp":        time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	for k, v := range params {
+		query[k] = v
+	}
+	canonical := aliyunCanonicalQuery(query)
+	signature := aliyunSign(r.opts.AccessKeySecret, http.MethodGet, canonical)
+	u := endpoint + "/?Signature=" + aliyunEncode(signature) + "&" + canonical
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 调用阿里云 %s 失败: %w", domain.ErrExternalServiceError, action, err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("%w: 读取阿里云 %s 响应失败: %w", domain.ErrExternalServiceError, action, err)
+	}
+	return body, nil
+}
+
+func aliyunCanonicalQuery(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, aliyunEncode(k)+"="+aliyunEncode(query[k]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+func aliyunSign(secret, method, canonical string) string {
+	stringToSign := method + "&" + aliyunEncode("/") + "&" + aliyunEncode(canonical)
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// aliyunEncode 阿里云要求的 RFC 3986 编码
+func aliyunEncode(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	return strings.ReplaceAll(s, "%7E", "~")
+}
+
+func nonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// reviewRemark 提交审核时的备注，供应商要求说明使用场景
+func reviewRemark(template domain.ChannelTemplate, version domain.ChannelTemplateVersion) string {
+	if version.Remark != "" {
+		return version.Remark
+	}
+	return template.Description
+}
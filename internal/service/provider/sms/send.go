@@ -0,0 +1,24 @@
+package sms
+
+import (
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+)
+
+// templateCode 模板在账号 account 下审核通过的供应商模板ID
+// 没有审核通过时按模板不匹配处理，重试也不会通过，不再重试
+func templateCode(vendor, account string, version domain.ChannelTemplateVersion) (string, error) {
+	for _, p := range version.Providers {
+		if p.ProviderName == account && p.AuditStatus == domain.AuditStatusApproved && p.ProviderTemplateID != "" {
+			return p.ProviderTemplateID, nil
+		}
+	}
+	return "", &provider.VendorError{
+		Vendor:   vendor,
+		Code:     "TEMPLATE_NOT_APPROVED",
+		Message:  fmt.Sprintf("模板版本 %d 在账号 %s 下没有审核通过", version.ID, account),
+		Category: domain.VendorErrorTemplateMismatch,
+	}
+}
@@ -0,0 +1,463 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+)
+
+const (
+	defaultTencentEndpoint = "https://sms.tencentcloudapi.com"
+	tencentAPIVersion      = "2021-01-11"
+	tencentService         = "sms"
+)
+
+// TencentOptions 腾讯云短信
+type TencentOptions struct {
+	// Endpoint 为空时使用 https://sms.tencentcloudapi.com
+	Endpoint  string
+	SecretID  string
+	SecretKey string
+	Region    string
+	// SdkAppID 短信应用ID，发送短信和查询套餐包余量时必填
+	SdkAppID string
+	// SmsType 短信类型：0 普通短信，1 营销短信
+	SmsType int
+	// Errors 错误码映射，为空时只使用内置规则
+	Errors *provider.ErrorCodeMapper
+	// Name 配置里的账号名称，发送时按它查找模板在这个账号下审核通过的 TemplateId
+	Name string
+}
+
+// TencentVendor 腾讯云在错误码映射里的供应商名称
+const TencentVendor = "tencent"
+
+var (
+	_ provider.Provider                    = (*TencentClient)(nil)
+	_ provider.TemplateReviewer            = (*TencentClient)(nil)
+	_ provider.TemplateAuditCallbackParser = (*TencentClient)(nil)
+	_ provider.SMSReplyParser              = (*TencentClient)(nil)
+	_ provider.BalanceQuerier              = (*TencentClient)(nil)
+)
+
+// TencentClient 腾讯云短信账号（API 3.0）：发送短信和管理接口（模板审核、审核结果推送、套餐包余量）
+// 腾讯云的模板变量是 {1}、{2}，提交时把 ${name} 按第一次出现的顺序替换成序号
+type TencentClient struct {
+	httpClient *http.Client
+	opts       TencentOptions
+	host       string
+}
+
+func NewTencentClient(httpClient *http.Client, opts TencentOptions) (*TencentClient, error) {
+	if opts.SecretID == "" || opts.SecretKey == "" {
+		return nil, fmt.Errorf("%w: 腾讯云 SecretId 和 SecretKey 不能为空", domain.ErrInvalidParameter)
+	}
+	if opts.Region == "" {
+		return nil, fmt.Errorf("%w: 腾讯云地域不能为空", domain.ErrInvalidParameter)
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = defaultTencentEndpoint
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	u, err := url.Parse(opts.Endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%w: 腾讯云地址不合法: %q", domain.ErrInvalidParameter, opts.Endpoint)
+	}
+	if opts.Errors == nil {
+		opts.Errors = provider.NewErrorCodeMapper(nil)
+	}
+	return &TencentClient{httpClient: httpClient, opts: opts, host: u.Host}, nil
+}
+
+// vendorError 腾讯云明确返回的失败，错误码映射成统一的分类
+func (r *TencentClient) vendorError(action string, e *tencentError, requestID string) error {
+	return r.opts.Errors.Error(TencentVendor, e.Code, fmt.Sprintf("%s %s, RequestId = %s", action, e.Message, requestID))
+}
+
+type tencentError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+type tencentAddTemplateResponse struct {
+	Response struct {
+		Error             *tencentError `json:"Error"`
+		RequestID         string        `json:"RequestId"`
+		AddTemplateStatus struct {
+			TemplateID string `json:"TemplateId"`
+		} `json:"AddTemplateStatus"`
+	} `json:"Response"`
+}
+
+type tencentSendResponse struct {
+	Response struct {
+		Error         *tencentError `json:"Error"`
+		RequestID     string        `json:"RequestId"`
+		SendStatusSet []struct {
+			SerialNo    string `json:"SerialNo"`
+			PhoneNumber string `json:"PhoneNumber"`
+			// Code 成功时是 Ok
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"SendStatusSet"`
+	} `json:"Response"`
+}
+
+type tencentDescribeTemplateResponse struct {
+	Response struct {
+		Error                     *tencentError `json:"Error"`
+		RequestID                 string        `json:"RequestId"`
+		DescribeTemplateStatusSet []struct {
+			TemplateID uint64 `json:"TemplateId"`
+			// StatusCode 0 审核通过，1 审核中，-1 审核未通过或者审核失败，2 已关闭
+			StatusCode  int    `json:"StatusCode"`
+			ReviewReply string `json:"ReviewReply"`
+		} `json:"DescribeTemplateStatusSet"`
+	} `json:"Response"`
+}
+
+func (r *TencentClient) SubmitTemplate(ctx context.Context, template domain.ChannelTemplate, version domain.ChannelTemplateVersion) (string, error) {
+	var resp tencentAddTemplateResponse
+	err := r.call(ctx, "AddSmsTemplate", map[string]any{
+		"TemplateName":    template.Name,
+		"TemplateContent": tencentContent(version.Content),
+		"SmsType":         r.opts.SmsType,
+		"International":   0,
+		"Remark":          reviewRemark(template, version),
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+	if e := resp.Response.Error; e != nil {
+		return "", r.vendorError("AddSmsTemplate", e, resp.Response.RequestID)
+	}
+	if resp.Response.AddTemplateStatus.TemplateID == "" {
+		return "", fmt.Errorf("%w: 腾讯云没有返回 TemplateId, RequestId = %s", domain.ErrExternalServiceError, resp.Response.RequestID)
+	}
+	return resp.Response.AddTemplateStatus.TemplateID, nil
+}
+
+func (r *TencentClient) QueryTemplateReview(ctx context.Context, providerTemplateID string) (provider.TemplateReviewResult, error) {
+	id, err := strconv.ParseUint(providerTemplateID, 10, 64)
+	if err != nil {
+		return provider.TemplateReviewResult{}, fmt.Errorf("%w: 腾讯云模板ID %q", domain.ErrInvalidParameter, providerTemplateID)
+	}
+	var resp tencentDescribeTemplateResponse
+	err = r.call(ctx, "DescribeSmsTemplateList", map[string]any{
+		"International": 0,
+		"TemplateIdSet": []uint64{id},
+	}, &resp)
+	if err != nil {
+		return provider.TemplateReviewResult{}, err
+	}
+	if e := resp.Response.Error; e != nil {
+		return provider.TemplateReviewResult{}, r.vendorError("DescribeSmsTemplateList", e, resp.Response.RequestID)
+	}
+	for _, s := range resp.Response.DescribeTemplateStatusSet {
+		if s.TemplateID != id {
+			continue
+		}
+		switch s.StatusCode {
+		case 0:
+			return provider.TemplateReviewResult{Status: domain.AuditStatusApproved}, nil
+		case 1:
+			return provider.TemplateReviewResult{Status: domain.AuditStatusInReview}, nil
+		case 2:
+			return provider.TemplateReviewResult{Status: domain.AuditStatusRejected, Reason: "模板已在腾讯云关闭"}, nil
+		default:
+			return provider.TemplateReviewResult{Status: domain.AuditStatusRejected, Reason: s.ReviewReply}, nil
+		}
+	}
+	return provider.TemplateReviewResult{}, fmt.Errorf("%w: 腾讯云没有返回模板 %d 的审核状态", domain.ErrExternalServiceError, id)
+}
+
+// tencentAuditMessage 腾讯云模板审核状态回调，状态码和 DescribeSmsTemplateList 一致
+type tencentAuditMessage struct {
+	TemplateID  uint64 `json:"template_id"`
+	StatusCode  *int   `json:"status_code"`
+	ReviewReply string `json:"review_reply"`
+}
+
+func (r *TencentClient) ParseAuditCallback(body []byte) ([]provider.TemplateAuditCallback, error) {
+	var msg tencentAuditMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("%w: 腾讯云模板审核回调格式错误: %w", domain.ErrInvalidParameter, err)
+	}
+	if msg.TemplateID == 0 || msg.StatusCode == nil {
+		return nil, fmt.Errorf("%w: 腾讯云模板审核回调缺少 template_id 或者 status_code", domain.ErrInvalidParameter)
+	}
+	cb := provider.TemplateAuditCallback{ProviderTemplateID: strconv.FormatUint(msg.TemplateID, 10)}
+	switch *msg.StatusCode {
+	case 0:
+		cb.Status = domain.AuditStatusApproved
+	case 1:
+		cb.Status = domain.AuditStatusInReview
+	case 2:
+		cb.Status, cb.Reason = domain.AuditStatusRejected, "模板已在腾讯云关闭"
+	default:
+		cb.Status, cb.Reason = domain.AuditStatusRejected, msg.ReviewReply
+	}
+	return []provider.TemplateAuditCallback{cb}, nil
+}
+
+func (r *TencentClient) AuditCallbackAck() []byte {
+	return []byte(`{"result":0,"errmsg":"OK"}`)
+}
+
+// tencentReplyMessage 腾讯云短信回复回调，一次一条
+type tencentReplyMessage struct {
+	Mobile     string `json:"mobile"`
+	NationCode string `json:"nationcode"`
+	Text       string `json:"text"`
+}
+
+func (r *TencentClient) ParseSMSReply(body []byte) ([]provider.SMSReply, error) {
+	var msg tencentReplyMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("%w: 腾讯云短信回复回调格式错误: %w", domain.ErrInvalidParameter, err)
+	}
+	if msg.Mobile == "" {
+		return nil, fmt.Errorf("%w: 腾讯云短信回复回调缺少 mobile", domain.ErrInvalidParameter)
+	}
+	mobile := msg.Mobile
+	// 国内号码保持不带区号，其他国家和地区转成 E.164 格式
+	if msg.NationCode != "" && msg.NationCode != "86" {
+		mobile = "+" + msg.NationCode + msg.Mobile
+	}
+	return []provider.SMSReply{{Mobile: mobile, Content: msg.Text}}, nil
+}
+
+func (r *TencentClient) SMSReplyAck() []byte {
+	return r.AuditCallbackAck()
+}
+
+type tencentPackagesResponse struct {
+	Response struct {
+		Error                    *tencentError `json:"Error"`
+		RequestID                string        `json:"RequestId"`
+		SmsPackagesStatisticsSet []struct {
+			PackageID              uint64 `json:"PackageId"`
+			PackageAmount          int64  `json:"PackageAmount"`
+			CurrentUsage           int64  `json:"CurrentUsage"`
+			PackageExpiredUnixTime int64  `json:"PackageExpiredUnixTime"`
+		} `json:"SmsPackagesStatisticsSet"`
+	} `json:"Response"`
+}
+
+const (
+	tencentPackagesPageSize = 100
+	// tencentPackagesLookback 只统计最近三年购买的套餐包，更早的都过期了
+	tencentPackagesLookback = 3 * 365 * 24 * time.Hour
+)
+
+// QueryBalance 腾讯云短信从套餐包扣减条数，汇总所有未过期套餐包的剩余条数
+func (r *TencentClient) QueryBalance(ctx context.Context) (domain.VendorBalance, error) {
+	if r.opts.SdkAppID == "" {
+		return domain.VendorBalance{}, fmt.Errorf("%w: 查询腾讯云套餐包需要 SdkAppId", domain.ErrInvalidParameter)
+	}
+	now := time.Now()
+	res := domain.VendorBalance{Unit: domain.VendorBalanceUnitMessage}
+	for offset := 0; ; offset += tencentPackagesPageSize {
+		var resp tencentPackagesResponse
+		err := r.call(ctx, "SmsPackagesStatistics", map[string]any{
+			"SmsSdkAppId": r.opts.SdkAppID,
+			"Limit":       tencentPackagesPageSize,
+			"Offset":      offset,
+			"BeginTime":   now.Add(-tencentPackagesLookback).Format("2006010215"),
+			"EndTime":     now.Format("2006010215"),
+		}, &resp)
+		if err != nil {
+			return domain.VendorBalance{}, err
+		}
+		if e := resp.Response.Error; e != nil {
+			return domain.VendorBalance{}, r.vendorError("SmsPackagesStatistics", e, resp.Response.RequestID)
+		}
+		for _, p := range resp.Response.SmsPackagesStatisticsSet {
+			if p.PackageExpiredUnixTime > 0 && p.PackageExpiredUnixTime < now.Unix() {
+				continue
+			}
+			res.Total += p.PackageAmount
+			res.Remaining += max(p.PackageAmount-p.CurrentUsage, 0)
+		}
+		if len(resp.Response.SmsPackagesStatisticsSet) < tencentPackagesPageSize {
+			return res, nil
+		}
+	}
+}
+
+// Send 调用 SendSms，模板参数按变量在模板内容里第一次出现的顺序排列，和提交审核时的序号一致
+// 有按接收者覆盖的参数时每个接收者单独调用；腾讯云按号码返回结果，只有所有号码都失败时才返回错误，
+// 部分号码失败时按成功处理，避免重试时成功的号码再收到一次
+// 腾讯云没有测试发送，影子流量只校验模板在这个账号下审核通过，不调用接口
+func (r *TencentClient) Send(ctx context.Context, req provider.Request) (provider.Response, error) {
+	templateID, err := templateCode(TencentVendor, r.opts.Name, req.Template)
+	if err != nil || req.DryRun {
+		return provider.Response{}, err
+	}
+	if r.opts.SdkAppID == "" {
+		return provider.Response{}, fmt.Errorf("%w: 腾讯云账号没有配置 SdkAppId，不能发送短信", domain.ErrInvalidParameter)
+	}
+	n := req.Notification
+	groups := [][]string{n.Receivers}
+	if len(n.Template.ReceiverParams) > 0 {
+		groups = make([][]string, 0, len(n.Receivers))
+		for _, receiver := range n.Receivers {
+			groups = append(groups, []string{receiver})
+		}
+	}
+	names := tencentParamNames(req.Template.Content)
+	var (
+		serials  []string
+		firstErr error
+	)
+	for _, phones := range groups {
+		params := n.Template.ParamsFor(phones[0])
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			values = append(values, params[name])
+		}
+		var resp tencentSendResponse
+		err = r.call(ctx, "SendSms", map[string]any{
+			"PhoneNumberSet":   phones,
+			"SmsSdkAppId":      r.opts.SdkAppID,
+			"SignName":         req.Template.Signature,
+			"TemplateId":       templateID,
+			"TemplateParamSet": values,
+			"SessionContext":   req.IdempotencyKey,
+		}, &resp)
+		if err == nil && resp.Response.Error != nil {
+			err = r.vendorError("SendSms", resp.Response.Error, resp.Response.RequestID)
+		}
+		if err != nil {
+			if len(serials) > 0 {
+				// 前面的号码已经发出去了，不能让整条通知重试
+				continue
+			}
+			return provider.Response{}, err
+		}
+		for _, status := range resp.Response.SendStatusSet {
+			if status.Code == "Ok" {
+				serials = append(serials, status.SerialNo)
+			} else if firstErr == nil {
+				firstErr = r.vendorError("SendSms", &tencentError{Code: status.Code, Message: status.Message}, resp.Response.RequestID)
+			}
+		}
+	}
+	if len(serials) == 0 && firstErr != nil {
+		return provider.Response{}, firstErr
+	}
+	return provider.Response{MessageID: strings.Join(serials, ","), Code: "Ok"}, nil
+}
+
+// call 按 TC3-HMAC-SHA256 签名调用
+func (r *TencentClient) call(ctx context.Context, action string, params map[string]any, res any) error {
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.opts.Endpoint+"/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	const contentType = "application/json; charset=utf-8"
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Host", r.host)
+	req.Header.Set("X-TC-Action", action)
+	req.Header.Set("X-TC-Version", tencentAPIVersion)
+	req.Header.Set("X-TC-Region", r.opts.Region)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set("Authorization", tencentAuthorization(r.opts.SecretID, r.opts.SecretKey, r.host, contentType, payload, now))
+
+	httpResp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: 调用腾讯云 %s 失败: %w", domain.ErrExternalServiceError, action, err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("%w: 读取腾讯云 %s 响应失败: %w", domain.ErrExternalServiceError, action, err)
+	}
+	if err = json.Unmarshal(body, res); err != nil {
+		return fmt.Errorf("%w: 腾讯云 %s 响应不是 JSON, 状态码 %d", domain.ErrExternalServiceError, action, httpResp.StatusCode)
+	}
+	return nil
+}
+
+func tencentAuthorization(secretID, secretKey, host, contentType string, payload []byte, now time.Time) string {
+	date := now.UTC().Format(time.DateOnly)
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:" + contentType + "\nhost:" + host + "\n",
+		"content-type;host",
+		sha256Hex(payload),
+	}, "\n")
+	scope := date + "/" + tencentService + "/tc3_request"
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		strconv.FormatInt(now.Unix(), 10),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, tencentService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+	return fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		secretID, scope, signature)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, msg string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msg))
+	return mac.Sum(nil)
+}
+
+var namedVariable = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+
+// tencentParamNames 变量按第一次出现的顺序去重，和 tencentContent 的序号一一对应
+func tencentParamNames(content string) []string {
+	var names []string
+	for _, m := range namedVariable.FindAllStringSubmatch(content, -1) {
+		if !slices.Contains(names, m[1]) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// tencentContent 把 ${name} 替换成 {1}、{2}，同名变量使用同一个序号
+func tencentContent(content string) string {
+	indexes := make(map[string]int)
+	return namedVariable.ReplaceAllStringFunc(content, func(m string) string {
+		name := namedVariable.FindStringSubmatch(m)[1]
+		idx, ok := indexes[name]
+		if !ok {
+			idx = len(indexes) + 1
+			indexes[name] = idx
+		}
+		return "{" + strconv.Itoa(idx) + "}"
+	})
+}
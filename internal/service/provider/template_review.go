@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// TemplateReviewer 模板需要供应商预先审核的供应商实现，比如短信供应商
+type TemplateReviewer interface {
+	// SubmitTemplate 提交模板版本给供应商审核，返回供应商的模板ID
+	SubmitTemplate(ctx context.Context, template domain.ChannelTemplate, version domain.ChannelTemplateVersion) (string, error)
+	// QueryTemplateReview 查询审核结果，还在审核时返回 domain.AuditStatusInReview
+	QueryTemplateReview(ctx context.Context, providerTemplateID string) (TemplateReviewResult, error)
+}
+
+// TemplateReviewResult 供应商审核结果
+type TemplateReviewResult struct {
+	Status domain.AuditStatus
+	// Reason 审核不通过的原因
+	Reason string
+}
+
+// TemplateAuditCallbackParser 支持主动推送审核结果的供应商实现，推送地址是 domain.Provider.AuditCallbackURL
+type TemplateAuditCallbackParser interface {
+	// ParseAuditCallback 解析推送的请求体，一次推送可能包含多个模板的审核结果
+	ParseAuditCallback(body []byte) ([]TemplateAuditCallback, error)
+	// AuditCallbackAck 处理成功后返回给供应商的响应体，否则供应商会重复推送
+	AuditCallbackAck() []byte
+}
+
+// TemplateAuditCallback 供应商推送的一个模板的审核结果
+type TemplateAuditCallback struct {
+	ProviderTemplateID string
+	TemplateReviewResult
+}
+
+// SMSReplyParser 支持推送上行短信（用户回复的短信）的供应商实现，推送地址是 /v1/providers/{provider}/sms-reply
+type SMSReplyParser interface {
+	// ParseSMSReply 解析推送的请求体，一次推送可能包含多条回复
+	ParseSMSReply(body []byte) ([]SMSReply, error)
+	// SMSReplyAck 处理成功后返回给供应商的响应体，否则供应商会重复推送
+	SMSReplyAck() []byte
+}
+
+// SMSReply 用户回复的一条短信
+type SMSReply struct {
+	// Mobile 回复的手机号，和发送时的接收者格式一致
+	Mobile  string
+	Content string
+}
+
+// BalanceQuerier 可以查询账户余额或者套餐包余量的供应商实现
+type BalanceQuerier interface {
+	// QueryBalance 查询当前的余额，返回值的 Provider 由调用方填充
+	QueryBalance(ctx context.Context) (domain.VendorBalance, error)
+}
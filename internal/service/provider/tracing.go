@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "internal/service/provider"
+
+var _ Provider = (*tracingProvider)(nil)
+
+// NewTracingProvider 每次调用供应商创建一个 client span，记录通知、供应商返回的消息ID和错误分类
+// 供应商内部的 HTTP 请求由 httpclient 记录成子 span
+func NewTracingProvider(name string, p Provider) Provider {
+	return &tracingProvider{
+		name:     name,
+		provider: p,
+		tracer:   otel.GetTracerProvider().Tracer(instrumentationName),
+	}
+}
+
+type tracingProvider struct {
+	name     string
+	provider Provider
+	tracer   trace.Tracer
+}
+
+func (p *tracingProvider) Send(ctx context.Context, req Request) (Response, error) {
+	ctx, span := p.tracer.Start(ctx, fmt.Sprintf("Provider %s Send", p.name),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("provider.name", p.name),
+			attribute.Int64("notification.id", int64(req.Notification.ID)),
+			attribute.Int64("notification.biz_id", req.Notification.BizID),
+			attribute.String("notification.channel", req.Notification.Channel.String()),
+			attribute.Int("provider.attempt", req.Attempt),
+			attribute.Bool("provider.dry_run", req.DryRun),
+		))
+	defer span.End()
+
+	resp, err := p.provider.Send(ctx, req)
+	if resp.MessageID != "" {
+		span.SetAttributes(attribute.String("provider.message_id", resp.MessageID))
+	}
+	if resp.Code != "" {
+		span.SetAttributes(attribute.String("provider.code", resp.Code))
+	}
+	if err != nil {
+		if ve, ok := AsVendorError(err); ok {
+			span.SetAttributes(
+				attribute.String("provider.code", ve.Code),
+				attribute.String("provider.error_category", ve.Category.String()),
+			)
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// SupportsIdempotencyKey 透传被包装供应商的幂等能力
+func (p *tracingProvider) SupportsIdempotencyKey() bool {
+	return supportsIdempotencyKey(p.provider)
+}
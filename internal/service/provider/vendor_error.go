@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+)
+
+// VendorError 供应商明确返回的失败，Code 是供应商原始的错误码，Category 是映射之后的统一分类
+type VendorError struct {
+	Vendor   string
+	Code     string
+	Message  string
+	Category domain.VendorErrorCategory
+}
+
+func (e *VendorError) Error() string {
+	return fmt.Sprintf("供应商 %s 返回失败 %s(%s): %s", e.Vendor, e.Code, e.Category, e.Message)
+}
+
+// Unwrap 供应商错误都是外部服务错误
+func (e *VendorError) Unwrap() error {
+	return domain.ErrExternalServiceError
+}
+
+// AsVendorError 从调用供应商的错误里取出 VendorError
+func AsVendorError(err error) (*VendorError, bool) {
+	var ve *VendorError
+	ok := errors.As(err, &ve)
+	return ve, ok
+}
+
+// FailReason 调用供应商失败时通知的失败原因，不是供应商明确返回的失败时为空
+func FailReason(err error) domain.FailReason {
+	if ve, ok := AsVendorError(err); ok {
+		return ve.Category.FailReason()
+	}
+	return ""
+}
+
+// Retryable 调用供应商失败之后是否值得重试，不是供应商明确返回的失败（网络错误等）都重试
+func Retryable(err error) bool {
+	if ve, ok := AsVendorError(err); ok {
+		return ve.Category.Retryable()
+	}
+	return true
+}
+
+// ErrorCodeRule 一条错误码映射，Code 以 * 结尾时按前缀匹配
+type ErrorCodeRule struct {
+	Vendor   string
+	Code     string
+	Category domain.VendorErrorCategory
+}
+
+// defaultErrorCodeRules 内置的阿里云和腾讯云短信错误码，配置里的同名规则优先
+var defaultErrorCodeRules = []ErrorCodeRule{
+	{Vendor: "aliyun", Code: "isv.BLACK_KEY_CONTROL_LIMIT", Category: domain.VendorErrorBlacklisted},
+	{Vendor: "aliyun", Code: "isv.MOBILE_NUMBER_ILLEGAL", Category: domain.VendorErrorInvalidReceiver},
+	{Vendor: "aliyun", Code: "isv.MOBILE_COUNT_OVER_LIMIT", Category: domain.VendorErrorInvalidReceiver},
+	{Vendor: "aliyun", Code: "isv.SMS_TEMPLATE_ILLEGAL", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "aliyun", Code: "isv.SMS_SIGNATURE_ILLEGAL", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "aliyun", Code: "isv.TEMPLATE_MISSING_PARAMETERS", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "aliyun", Code: "isv.INVALID_PARAMETERS", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "aliyun", Code: "isv.AMOUNT_NOT_ENOUGH", Category: domain.VendorErrorInsufficientBalance},
+	{Vendor: "aliyun", Code: "isv.OUT_OF_SERVICE", Category: domain.VendorErrorInsufficientBalance},
+	{Vendor: "aliyun", Code: "isv.BUSINESS_LIMIT_CONTROL", Category: domain.VendorErrorRateLimited},
+	{Vendor: "aliyun", Code: "isv.DAY_LIMIT_CONTROL", Category: domain.VendorErrorRateLimited},
+	{Vendor: "aliyun", Code: "Throttling*", Category: domain.VendorErrorRateLimited},
+	{Vendor: "aliyun", Code: "InvalidAccessKeyId*", Category: domain.VendorErrorAuth},
+	{Vendor: "aliyun", Code: "SignatureDoesNotMatch", Category: domain.VendorErrorAuth},
+	{Vendor: "aliyun", Code: "isp.RAM_PERMISSION_DENY", Category: domain.VendorErrorAuth},
+	{Vendor: "aliyun", Code: "isp.SYSTEM_ERROR", Category: domain.VendorErrorUnavailable},
+	{Vendor: "aliyun", Code: "ServiceUnavailable", Category: domain.VendorErrorUnavailable},
+
+	{Vendor: "tencent", Code: "FailedOperation.PhoneNumberInBlacklist", Category: domain.VendorErrorBlacklisted},
+	{Vendor: "tencent", Code: "InvalidParameterValue.IncorrectPhoneNumber", Category: domain.VendorErrorInvalidReceiver},
+	{Vendor: "tencent", Code: "UnsupportedOperation.ContainDomesticAndInternationalPhoneNumber", Category: domain.VendorErrorInvalidReceiver},
+	{Vendor: "tencent", Code: "FailedOperation.TemplateIncorrectOrUnapproved", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "tencent", Code: "FailedOperation.SignatureIncorrectOrUnapproved", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "tencent", Code: "InvalidParameterValue.TemplateParameterFormatError", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "tencent", Code: "InvalidParameterValue.TemplateParameterLengthLimit", Category: domain.VendorErrorTemplateMismatch},
+	{Vendor: "tencent", Code: "FailedOperation.InsufficientBalanceInSmsPackage", Category: domain.VendorErrorInsufficientBalance},
+	{Vendor: "tencent", Code: "LimitExceeded.*", Category: domain.VendorErrorRateLimited},
+	{Vendor: "tencent", Code: "RequestLimitExceeded", Category: domain.VendorErrorRateLimited},
+	{Vendor: "tencent", Code: "AuthFailure.*", Category: domain.VendorErrorAuth},
+	{Vendor: "tencent", Code: "UnauthorizedOperation.*", Category: domain.VendorErrorAuth},
+	{Vendor: "tencent", Code: "InternalError.*", Category: domain.VendorErrorUnavailable},
+}
+
+// ErrorCodeMapper 把各个供应商的错误码映射成统一的分类
+type ErrorCodeMapper struct {
+	exact  map[string]map[string]domain.VendorErrorCategory
+	prefix map[string][]ErrorCodeRule
+}
+
+// NewErrorCodeMapper rules 在内置规则之前匹配，可以覆盖内置规则
+func NewErrorCodeMapper(rules []ErrorCodeRule) *ErrorCodeMapper {
+	m := &ErrorCodeMapper{
+		exact:  make(map[string]map[string]domain.VendorErrorCategory),
+		prefix: make(map[string][]ErrorCodeRule),
+	}
+	for _, r := range append(append([]ErrorCodeRule{}, rules...), defaultErrorCodeRules...) {
+		if code, ok := strings.CutSuffix(r.Code, "*"); ok {
+			m.prefix[r.Vendor] = append(m.prefix[r.Vendor], ErrorCodeRule{Vendor: r.Vendor, Code: code, Category: r.Category})
+			continue
+		}
+		if m.exact[r.Vendor] == nil {
+			m.exact[r.Vendor] = make(map[string]domain.VendorErrorCategory)
+		}
+		// 先加入的规则优先
+		if _, ok := m.exact[r.Vendor][r.Code]; !ok {
+			m.exact[r.Vendor][r.Code] = r.Category
+		}
+	}
+	return m
+}
+
+// Category 精确匹配优先，再按顺序匹配前缀，都没有时是 domain.VendorErrorUnknown
+func (m *ErrorCodeMapper) Category(vendor, code string) domain.VendorErrorCategory {
+	if c, ok := m.exact[vendor][code]; ok {
+		return c
+	}
+	for _, r := range m.prefix[vendor] {
+		if strings.HasPrefix(code, r.Code) {
+			return r.Category
+		}
+	}
+	return domain.VendorErrorUnknown
+}
+
+// Error 供应商返回失败时构造 VendorError
+func (m *ErrorCodeMapper) Error(vendor, code, message string) *VendorError {
+	return &VendorError{Vendor: vendor, Code: code, Message: message, Category: m.Category(vendor, code)}
+}
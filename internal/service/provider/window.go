@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+)
+
+var _ Provider = (*windowGuard)(nil)
+
+// NewWindowGuard 调用供应商之前检查通知的计划发送时间，已经结束时返回 domain.ErrSendWindowClosed，不调用供应商
+// 比如验证码在队列里等太久，过期之后再送达已经没有意义
+func NewWindowGuard(p Provider, clk clock.Clock) Provider {
+	return &windowGuard{provider: p, clock: clk}
+}
+
+type windowGuard struct {
+	provider Provider
+	clock    clock.Clock
+}
+
+func (g *windowGuard) Send(ctx context.Context, req Request) (Response, error) {
+	n := req.Notification
+	if n.SendWindowClosed(g.clock.Now()) {
+		return Response{}, fmt.Errorf("%w: 通知 %d 的计划发送结束时间是 %s",
+			domain.ErrSendWindowClosed, n.ID, n.ScheduledETime.Format(time.RFC3339))
+	}
+	return g.provider.Send(ctx, req)
+}
+
+// SupportsIdempotencyKey 透传被包装供应商的幂等能力
+func (g *windowGuard) SupportsIdempotencyKey() bool {
+	return supportsIdempotencyKey(g.provider)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// QuotaWarmupTask 启动时把数据库里配置的额度加载到 Redis，避免 Redis 清空或者新建之后发送因为没有额度被拒绝
+// 已经存在的额度不会被覆盖，多个实例同时执行也没有问题，执行一次就退出
+type QuotaWarmupTask struct {
+	repo      repository.QuotaRepository
+	batchSize int
+	logger    log.LoggerInterface
+}
+
+func NewQuotaWarmupTask(repo repository.QuotaRepository, batchSize int) *QuotaWarmupTask {
+	return &QuotaWarmupTask{
+		repo:      repo,
+		batchSize: batchSize,
+		logger:    log.Named(log.DefaultLogger(), "service.quota"),
+	}
+}
+
+func (t *QuotaWarmupTask) Start(ctx context.Context) {
+	n, err := t.repo.Warmup(ctx, t.batchSize)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("预热额度缓存失败", zap.Error(err), zap.Int("loaded", n))
+		return
+	}
+	t.logger.WithContext(ctx).Info("预热额度缓存", zap.Int("loaded", n))
+}
+
+var quotaReservationsRepairedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "quota_reservations_repaired_total",
+	Help: "Total number of stale quota reservations repaired, by result (committed: notification was stored, refunded: quota returned)",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(quotaReservationsRepairedCounter)
+}
+
+// QuotaReservationRepairTask 启动时和之后每隔 interval 核对超过 grace 还没有确认的扣减意图，通知没有写入的归还额度
+// 确认和归还都是幂等的，多个实例同时执行也只处理一次，所以不需要分布式锁
+type QuotaReservationRepairTask struct {
+	repo      repository.QuotaReservationRepository
+	interval  time.Duration
+	grace     time.Duration
+	batchSize int
+	clock     clock.Clock
+	logger    log.LoggerInterface
+}
+
+func NewQuotaReservationRepairTask(repo repository.QuotaReservationRepository,
+	interval, grace time.Duration, batchSize int, clk clock.Clock,
+) *QuotaReservationRepairTask {
+	return &QuotaReservationRepairTask{
+		repo:      repo,
+		interval:  interval,
+		grace:     grace,
+		batchSize: batchSize,
+		clock:     clk,
+		logger:    log.Named(log.DefaultLogger(), "service.quota"),
+	}
+}
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *QuotaReservationRepairTask) Start(ctx context.Context) {
+	timer := t.clock.NewTimer(t.interval)
+	defer timer.Stop()
+	for {
+		t.repair(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+		timer.Reset(t.interval)
+	}
+}
+
+// repair 一直处理到没有过期的意图为止，截止时间在开始时确定，处理期间新记录的意图留到下一轮
+func (t *QuotaReservationRepairTask) repair(ctx context.Context) {
+	before := t.clock.Now().Add(-t.grace)
+	for ctx.Err() == nil {
+		committed, refunded, err := t.repo.Repair(ctx, before, t.batchSize)
+		quotaReservationsRepairedCounter.WithLabelValues("committed").Add(float64(committed))
+		quotaReservationsRepairedCounter.WithLabelValues("refunded").Add(float64(refunded))
+		if err != nil {
+			t.logger.WithContext(ctx).Error("修复扣减意图失败", zap.Error(err))
+			return
+		}
+		if committed+refunded > 0 {
+			t.logger.WithContext(ctx).Info("修复扣减意图", zap.Int("committed", committed), zap.Int("refunded", refunded))
+		}
+		if committed+refunded < t.batchSize {
+			return
+		}
+	}
+}
+
+// QuotaPrecheckService 批量发送前只读 Redis 粗略检查额度，额度明显用完时整批拒绝，省掉转换、校验和注定失败的扣减
+type QuotaPrecheckService interface {
+	// Precheck 有一个渠道和类别按透支策略连一条都不能扣减时返回 domain.ErrNoQuota
+	// 没有原子性，通过预检的请求扣减时依旧可能额度不足；查询出错时放行，由扣减兜底
+	Precheck(ctx context.Context, bizID int64, demands []domain.QuotaDemand) error
+}
+
+var _ QuotaPrecheckService = (*quotaPrecheckService)(nil)
+
+func NewQuotaPrecheckService(repo repository.QuotaRepository) QuotaPrecheckService {
+	return &quotaPrecheckService{
+		repo:   repo,
+		logger: log.Named(log.DefaultLogger(), "service.quota"),
+	}
+}
+
+type quotaPrecheckService struct {
+	repo   repository.QuotaRepository
+	logger log.LoggerInterface
+}
+
+func (s *quotaPrecheckService) Precheck(ctx context.Context, bizID int64, demands []domain.QuotaDemand) error {
+	for _, d := range demands {
+		_, err := s.repo.Check(ctx, bizID, d.Channel, d.Category, 1)
+		if errors.Is(err, domain.ErrNoQuota) {
+			return err
+		}
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("额度预检失败，放行", zap.Error(err),
+				zap.Int64("biz_id", bizID), zap.String("channel", d.Channel.String()))
+		}
+	}
+	return nil
+}
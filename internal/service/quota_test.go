@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+// 修复任务按注入的时钟计算截止时间和执行间隔，和扣减意图记录的时间来自同一个时钟
+func TestQuotaReservationRepairTask_UsesClock(t *testing.T) {
+	start := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	repo := &cutoffReservationRepo{cutoffs: make(chan time.Time, 2)}
+	task := NewQuotaReservationRepairTask(repo, time.Minute, 30*time.Second, 10, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		task.Start(ctx)
+		close(done)
+	}()
+	wants := []time.Time{start.Add(-30 * time.Second), start.Add(30 * time.Second)}
+	for i, want := range wants {
+		if i > 0 {
+			waitTimers(t, clk, 1)
+			clk.Advance(time.Minute)
+		}
+		select {
+		case got := <-repo.cutoffs:
+			if !got.Equal(want) {
+				t.Fatalf("第 %d 轮截止时间 %s, 应该是 %s", i+1, got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("第 %d 轮没有执行", i+1)
+		}
+	}
+	cancel()
+	<-done
+}
+
+type cutoffReservationRepo struct {
+	repository.QuotaReservationRepository
+	cutoffs chan time.Time
+}
+
+func (r *cutoffReservationRepo) Repair(_ context.Context, before time.Time, _ int) (int, int, error) {
+	r.cutoffs <- before
+	return 0, 0, nil
+}
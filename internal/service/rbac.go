@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+// RBACService 角色和权限
+type RBACService interface {
+	// Resolve 确定调用方在 bizID 下的角色，没有任何角色时返回 domain.ErrPermissionDenied
+	Resolve(ctx context.Context, subject string, bizID int64) (domain.Principal, error)
+	// AssignRole operator 给别人分配角色
+	AssignRole(ctx context.Context, operator domain.Principal, assignment domain.RoleAssignment) error
+	// RevokeRole operator 撤销别人的角色
+	RevokeRole(ctx context.Context, operator domain.Principal, principal string, bizID int64) error
+	// ListRoleAssignments 业务方下的全部角色分配
+	ListRoleAssignments(ctx context.Context, operator domain.Principal, bizID int64) ([]domain.RoleAssignment, error)
+}
+
+var _ RBACService = &rbacService{}
+
+// NewRBACService bootstrapAdmins 是配置里固定的平台管理员，用于在没有任何角色分配时完成初始化
+func NewRBACService(repo repository.RoleAssignmentRepository, bootstrapAdmins []string) RBACService {
+	admins := make(map[string]struct{}, len(bootstrapAdmins))
+	for _, a := range bootstrapAdmins {
+		admins[a] = struct{}{}
+	}
+	return &rbacService{
+		repo:            repo,
+		bootstrapAdmins: admins,
+	}
+}
+
+type rbacService struct {
+	repo            repository.RoleAssignmentRepository
+	bootstrapAdmins map[string]struct{}
+}
+
+func (s *rbacService) Resolve(ctx context.Context, subject string, bizID int64) (domain.Principal, error) {
+	principal := domain.Principal{Subject: subject, BizID: bizID}
+	if _, ok := s.bootstrapAdmins[subject]; ok {
+		principal.Role = domain.RolePlatformAdmin
+		return principal, nil
+	}
+	assignments, err := s.repo.FindByPrincipal(ctx, subject)
+	if err != nil {
+		return domain.Principal{}, err
+	}
+	for _, a := range assignments {
+		// 平台管理员的角色优先于业务方内的角色
+		if a.Role == domain.RolePlatformAdmin {
+			principal.Role = a.Role
+			return principal, nil
+		}
+		if a.BizID == bizID && bizID > 0 {
+			principal.Role = a.Role
+		}
+	}
+	if principal.Role == "" {
+		return domain.Principal{}, fmt.Errorf("%w: %s 在业务方 %d 下没有角色", domain.ErrPermissionDenied, subject, bizID)
+	}
+	return principal, nil
+}
+
+func (s *rbacService) AssignRole(ctx context.Context, operator domain.Principal, assignment domain.RoleAssignment) error {
+	if err := assignment.Validate(); err != nil {
+		return err
+	}
+	if !operator.CanManage(assignment.BizID, assignment.Role) {
+		return fmt.Errorf("%w: %s 不能在业务方 %d 下分配 %s", domain.ErrPermissionDenied,
+			operator.Subject, assignment.BizID, assignment.Role)
+	}
+	assignment.Operator = operator.Subject
+	return s.repo.Assign(ctx, assignment)
+}
+
+func (s *rbacService) RevokeRole(ctx context.Context, operator domain.Principal, principal string, bizID int64) error {
+	existing, err := s.repo.Get(ctx, principal, bizID)
+	if err != nil {
+		return err
+	}
+	if !operator.CanManage(bizID, existing.Role) {
+		return fmt.Errorf("%w: %s 不能撤销业务方 %d 下的 %s", domain.ErrPermissionDenied,
+			operator.Subject, bizID, existing.Role)
+	}
+	return s.repo.Revoke(ctx, principal, bizID)
+}
+
+func (s *rbacService) ListRoleAssignments(ctx context.Context, operator domain.Principal, bizID int64) ([]domain.RoleAssignment, error) {
+	if !operator.IsPlatformAdmin() && operator.BizID != bizID {
+		return nil, fmt.Errorf("%w: %s 不能查看业务方 %d 的角色", domain.ErrPermissionDenied, operator.Subject, bizID)
+	}
+	return s.repo.ListByBizID(ctx, bizID)
+}
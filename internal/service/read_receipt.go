@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/callback"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+// ReadReceiptService 站内信已读回执，第一次阅读时记录，并异步推送已读事件给配置了推送地址的业务方
+// 同一个模板发出的站内信视为一次活动，按模板统计活动的已读率
+type ReadReceiptService interface {
+	// MarkRead 标记已读，重复标记返回第一次的记录，firstRead 为 false
+	MarkRead(ctx context.Context, bizID int64, key, receiver string) (receipt domain.ReadReceipt, firstRead bool, err error)
+	NotificationReadStats(ctx context.Context, bizID int64, key string) (domain.ReadStats, error)
+	// TemplateReadStats 模板在查询时间范围内创建并发送成功的站内信的已读统计
+	TemplateReadStats(ctx context.Context, query domain.StatsQuery, templateID int64) (domain.ReadStats, error)
+}
+
+var _ ReadReceiptService = (*readReceiptService)(nil)
+
+// NewReadReceiptService endpoints 是业务方接收已读事件的地址，没有配置的业务方只记录不推送
+func NewReadReceiptService(repo repository.ReadReceiptRepository,
+	notificationRepo repository.NotificationRepository,
+	endpoints map[int64]string,
+) ReadReceiptService {
+	return &readReceiptService{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		endpoints:        endpoints,
+	}
+}
+
+type readReceiptService struct {
+	repo             repository.ReadReceiptRepository
+	notificationRepo repository.NotificationRepository
+	endpoints        map[int64]string
+}
+
+func (s *readReceiptService) getNotification(ctx context.Context, bizID int64, key string) (domain.Notification, error) {
+	if key == "" {
+		return domain.Notification{}, fmt.Errorf("%w: key 不能为空", domain.ErrInvalidParameter)
+	}
+	notifications, err := s.notificationRepo.GetByKeys(ctx, bizID, key)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+	if len(notifications) == 0 {
+		return domain.Notification{}, fmt.Errorf("%w: bizID = %d, key = %s", domain.ErrNotificationNotFound, bizID, key)
+	}
+	return notifications[0], nil
+}
+
+func (s *readReceiptService) MarkRead(ctx context.Context, bizID int64, key, receiver string) (domain.ReadReceipt, bool, error) {
+	if receiver == "" {
+		return domain.ReadReceipt{}, false, fmt.Errorf("%w: receiver 不能为空", domain.ErrInvalidParameter)
+	}
+	n, err := s.getNotification(ctx, bizID, key)
+	if err != nil {
+		return domain.ReadReceipt{}, false, err
+	}
+	if n.Channel != domain.ChannelInApp || n.Status != domain.SendStatusSucceeded {
+		return domain.ReadReceipt{}, false, fmt.Errorf("%w: channel = %s, status = %s",
+			domain.ErrNotificationNotReadable, n.Channel, n.Status)
+	}
+	receipt := domain.ReadReceipt{
+		NotificationID: n.ID,
+		BizID:          n.BizID,
+		Key:            n.Key,
+		TemplateID:     n.Template.ID,
+		Receiver:       receiver,
+		ReadTime:       time.Now(),
+		CallbackStatus: domain.ReadCallbackStatusNone,
+	}
+	if _, ok := s.endpoints[n.BizID]; ok {
+		receipt.CallbackStatus = domain.ReadCallbackStatusPending
+	}
+	return s.repo.Create(ctx, receipt)
+}
+
+func (s *readReceiptService) NotificationReadStats(ctx context.Context, bizID int64, key string) (domain.ReadStats, error) {
+	n, err := s.getNotification(ctx, bizID, key)
+	if err != nil {
+		return domain.ReadStats{}, err
+	}
+	return s.repo.NotificationStats(ctx, n.ID)
+}
+
+func (s *readReceiptService) TemplateReadStats(ctx context.Context, query domain.StatsQuery, templateID int64) (domain.ReadStats, error) {
+	if err := query.Validate(); err != nil {
+		return domain.ReadStats{}, err
+	}
+	if templateID <= 0 {
+		return domain.ReadStats{}, fmt.Errorf("%w: templateID = %d", domain.ErrInvalidParameter, templateID)
+	}
+	return s.repo.TemplateStats(ctx, query, templateID)
+}
+
+// ReadReceiptCallbackOptions 已读事件推送参数
+type ReadReceiptCallbackOptions struct {
+	Interval  time.Duration
+	BatchSize int
+	// MaxRetries 超过之后不再推送，标记为失败
+	MaxRetries int32
+	// BaseBackoff 第 n 次重试等待 BaseBackoff * 2^(n-1)，最多 MaxBackoff
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// ReadReceiptCallbackTask 定时把已读事件推送给业务方，至少一次，业务方按 notificationId 和 receiver 去重
+type ReadReceiptCallbackTask struct {
+	repo      repository.ReadReceiptRepository
+	client    callback.Client
+	endpoints map[int64]string
+	lock      distribute_lock.Client
+	opts      ReadReceiptCallbackOptions
+	logger    log.LoggerInterface
+}
+
+func NewReadReceiptCallbackTask(repo repository.ReadReceiptRepository,
+	client callback.Client,
+	endpoints map[int64]string,
+	lock distribute_lock.Client,
+	opts ReadReceiptCallbackOptions,
+) *ReadReceiptCallbackTask {
+	return &ReadReceiptCallbackTask{
+		repo:      repo,
+		client:    client,
+		endpoints: endpoints,
+		lock:      lock,
+		opts:      opts,
+		logger:    log.Named(log.DefaultLogger(), "service.read_receipt"),
+	}
+}
+
+const readReceiptCallbackLockKey = "notification:read-receipt:callback:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *ReadReceiptCallbackTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, readReceiptCallbackLockKey, t.opts.Interval, t.runOnce)
+}
+
+func (t *ReadReceiptCallbackTask) runOnce(ctx context.Context) {
+	receipts, err := t.repo.FindPendingCallbacks(ctx, t.opts.BatchSize)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("查询待推送的已读回执失败", zap.Error(err))
+		return
+	}
+	for _, receipt := range receipts {
+		if ctx.Err() != nil {
+			return
+		}
+		t.push(ctx, receipt)
+	}
+}
+
+func (t *ReadReceiptCallbackTask) push(ctx context.Context, receipt domain.ReadReceipt) {
+	url, ok := t.endpoints[receipt.BizID]
+	if !ok {
+		// 推送地址被删掉了，不再推送
+		receipt.CallbackStatus = domain.ReadCallbackStatusNone
+		t.update(ctx, receipt)
+		return
+	}
+	body, _ := json.Marshal(domain.ReadReceiptEvent{
+		Event:          domain.ReadReceiptEventName,
+		NotificationID: receipt.NotificationID,
+		Key:            receipt.Key,
+		Receiver:       receipt.Receiver,
+		ReadTime:       receipt.ReadTime.UnixMilli(),
+	})
+	err := t.client.Post(ctx, receipt.BizID, url, body, nil)
+	switch {
+	case err == nil:
+		receipt.CallbackStatus = domain.ReadCallbackStatusSucceeded
+	case errors.Is(err, domain.ErrCircuitBreaker):
+		// 地址熔断中，不算重试次数，下个周期再看
+		return
+	default:
+		receipt.RetryCount++
+		if receipt.RetryCount >= t.opts.MaxRetries {
+			receipt.CallbackStatus = domain.ReadCallbackStatusFailed
+		}
+		receipt.NextRetryTime = time.Now().Add(t.backoff(receipt.RetryCount)).UnixMilli()
+		t.logger.WithContext(ctx).Warn("推送已读回执失败", zap.Error(err),
+			zap.Int64("biz_id", receipt.BizID),
+			zap.Uint64("notification_id", receipt.NotificationID),
+			zap.Int32("retry_count", receipt.RetryCount))
+	}
+	t.update(ctx, receipt)
+}
+
+func (t *ReadReceiptCallbackTask) backoff(retryCount int32) time.Duration {
+	d := t.opts.BaseBackoff
+	for i := int32(1); i < retryCount && d < t.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, t.opts.MaxBackoff)
+}
+
+func (t *ReadReceiptCallbackTask) update(ctx context.Context, receipt domain.ReadReceipt) {
+	if err := t.repo.UpdateCallback(ctx, receipt); err != nil {
+		// 没更新成功下个周期会重复推送
+		t.logger.WithContext(ctx).Error("更新已读回执推送状态失败", zap.Error(err), zap.Int64("id", receipt.ID))
+	}
+}
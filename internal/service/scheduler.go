@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/partition"
+	"go.uber.org/zap"
+)
+
+var (
+	schedulerScanDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scheduler_scan_duration_seconds",
+		Help:    "Latency of a single scheduler query for ready notifications",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"result"})
+	schedulerScannedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "scheduler_scanned_notifications_total",
+		Help: "Total number of ready notifications returned by scheduler queries",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(schedulerScanDuration, schedulerScannedCounter)
+}
+
+// Dispatcher 调度器把到了发送时间的通知交给发送方
+// 实现方必须先把状态从 PENDING CAS 成 SENDING 再真正发送，分区重新分配时同一条通知可能被两个实例扫到
+// Close 在调度器退出时调用，等已经交出去的通知发送完再返回
+type Dispatcher interface {
+	Dispatch(ctx context.Context, notifications []domain.Notification) error
+	Close()
+}
+
+// Scheduler 分区调度，每个实例只扫描自己负责的分区
+type Scheduler struct {
+	svc        Service
+	membership partition.Membership
+	dispatcher Dispatcher
+	controller BatchController
+	// fallback 渠道降级分组里的通知按渠道顺序发送
+	fallback FallbackService
+	// pacer 限速发送的通知按活动限速之后再分发
+	pacer PacingService
+	// batchTimeout 每一批查询和分发的超时时间
+	batchTimeout time.Duration
+	// clock 扫描间隔的定时器和耗时统计使用的时间
+	clock  clock.Clock
+	logger log.LoggerInterface
+}
+
+func NewScheduler(svc Service, membership partition.Membership, dispatcher Dispatcher,
+	controller BatchController, fallback FallbackService, pacer PacingService, batchTimeout time.Duration,
+	clk clock.Clock,
+) *Scheduler {
+	return &Scheduler{
+		svc:          svc,
+		membership:   membership,
+		dispatcher:   dispatcher,
+		controller:   controller,
+		fallback:     fallback,
+		pacer:        pacer,
+		batchTimeout: batchTimeout,
+		clock:        clk,
+		logger:       log.Named(log.DefaultLogger(), "service.scheduler"),
+	}
+}
+
+// Start 阻塞运行，直到 ctx 被取消，成员关系随调度器一起启动和退出
+// 退出前关闭发送方，已经 CAS 成 SENDING 的通知都发送完才返回
+func (s *Scheduler) Start(ctx context.Context) {
+	go s.membership.Start(ctx)
+	defer s.dispatcher.Close()
+	// 扫描间隔会动态调整，每轮重新设置定时器
+	timer := s.clock.NewTimer(s.controller.Interval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+		timer.Reset(s.controller.Interval())
+		p, ok := s.membership.Partition()
+		if !ok {
+			continue
+		}
+		s.scheduleOnce(ctx, p)
+	}
+}
+
+// scheduleOnce 一直调度到分区内没有到期的通知为止
+// 每轮从头开始，轮内按 ID 往后翻，分发失败或者 CAS 失败还是 PENDING 的通知留给下一轮
+func (s *Scheduler) scheduleOnce(ctx context.Context, p domain.Partition) {
+	var lastID uint64
+	for ctx.Err() == nil {
+		batchSize := s.controller.BatchSize()
+		found, err := s.scheduleBatch(ctx, p, &lastID, batchSize)
+		if err != nil || found < batchSize {
+			return
+		}
+	}
+}
+
+// scheduleBatch 查询并分发一批通知，整批共用一个截止时间，返回查到的数量
+func (s *Scheduler) scheduleBatch(ctx context.Context, p domain.Partition, lastID *uint64, batchSize int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.batchTimeout)
+	defer cancel()
+	start := s.clock.Now()
+	notifications, err := s.svc.FindReadyNotifications(ctx, p, *lastID, batchSize)
+	observeScan(s.clock.Since(start), len(notifications), err)
+	if err != nil {
+		s.controller.Record(0, s.clock.Since(start), err)
+		s.logger.WithContext(ctx).Error("查找待调度通知失败", zap.Error(err),
+			zap.Int("slot", p.Slot), zap.Int("total", p.Total))
+		return 0, err
+	}
+	if len(notifications) > 0 {
+		*lastID = notifications[len(notifications)-1].ID
+		// 翻页按查到的最后一条，等待降级、被限速推迟的通知不影响翻页
+		ready := s.fallback.Filter(ctx, notifications)
+		if admitted := s.pacer.Admit(ctx, ready); len(admitted) > 0 {
+			err = s.dispatcher.Dispatch(ctx, admitted)
+		}
+	}
+	s.controller.Record(len(notifications), s.clock.Since(start), err)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("调度通知失败", zap.Error(err),
+			zap.Int("slot", p.Slot), zap.Int("total", p.Total))
+		return len(notifications), err
+	}
+	return len(notifications), nil
+}
+
+func observeScan(latency time.Duration, found int, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	schedulerScanDuration.WithLabelValues(result).Observe(latency.Seconds())
+	schedulerScannedCounter.Add(float64(found))
+}
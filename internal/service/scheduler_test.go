@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/workpool"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+)
+
+// 调度器扫到的通知先 CAS 成 SENDING 再交给发送方，被其他实例抢先调度的通知不能重复发送
+func TestScheduler_DispatchesAfterCAS(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	p := domain.Partition{Slot: 1, Total: 2}
+	ready := []domain.Notification{
+		testReadyNotification(1<<16, start),
+		testReadyNotification(3<<16, start),
+		testReadyNotification(5<<16, start),
+	}
+	repo := newCASNotificationRepo(ready...)
+	// 3<<16 已经被其他实例调度了，版本号变了
+	repo.bump(3 << 16)
+	sender := &recordingSender{sent: make(chan domain.Notification, len(ready))}
+	dispatcher := NewPooledDispatcher(repo, sender, nil,
+		map[domain.Channel]*workpool.Pool{domain.ChannelSMS: workpool.New("test.scheduler.sms", 2, len(ready))},
+		nil, time.Second, clk)
+	svc := &readyService{notifications: ready}
+	s := NewScheduler(svc, staticMembership{p: p}, dispatcher, NewFixedBatchController(2, time.Second),
+		passthroughFallback{}, passthroughPacer{}, time.Second, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(done)
+	}()
+	waitTimers(t, clk, 1)
+	clk.Advance(time.Second)
+
+	got := map[uint64]domain.Notification{}
+	for range 2 {
+		select {
+		case n := <-sender.sent:
+			got[n.ID] = n
+		case <-time.After(5 * time.Second):
+			t.Fatalf("只发送了 %d 条通知", len(got))
+		}
+	}
+	cancel()
+	<-done
+
+	for _, id := range []uint64{1 << 16, 5 << 16} {
+		n, ok := got[id]
+		if !ok {
+			t.Fatalf("通知 %d 没有发送", id)
+		}
+		if n.Status != domain.SendStatusSending || n.Version != 2 {
+			t.Fatalf("通知 %d 交给发送方时状态 %s 版本 %d，应该是 CAS 之后的 SENDING 和 2", id, n.Status, n.Version)
+		}
+	}
+	select {
+	case n := <-sender.sent:
+		t.Fatalf("通知 %d 被其他实例调度过，不应该再发送", n.ID)
+	default:
+	}
+	// 分区内 ID 大于上一页最后一条的通知才会被查询
+	if want := [][2]uint64{{0, 2}, {3 << 16, 2}}; !slices.Equal(svc.pages(), want) {
+		t.Fatalf("翻页查询 %v, 应该是 %v", svc.pages(), want)
+	}
+	if svc.partition != p {
+		t.Fatalf("查询的分区 %+v, 应该是 %+v", svc.partition, p)
+	}
+}
+
+// CAS 出错的协程池停止提交，其他状态不变的通知留给下一轮；已经入队的通知关闭时都会发送完
+func TestPooledDispatcher_CASError(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	first, second := testReadyNotification(1, start), testReadyNotification(2, start)
+	repo := newCASNotificationRepo(first, second)
+	repo.casErr[second.ID] = errors.New("数据库不可用")
+	sender := &recordingSender{sent: make(chan domain.Notification, 2)}
+	d := NewPooledDispatcher(repo, sender, nil,
+		map[domain.Channel]*workpool.Pool{domain.ChannelSMS: workpool.New("test.dispatcher.sms", 1, 2)},
+		nil, time.Second, clock.NewFake(start))
+
+	if err := d.Dispatch(context.Background(), []domain.Notification{first, second}); err == nil {
+		t.Fatal("CAS 出错应该返回错误")
+	}
+	d.Close()
+	close(sender.sent)
+	var sent []uint64
+	for n := range sender.sent {
+		sent = append(sent, n.ID)
+	}
+	if len(sent) != 1 || sent[0] != first.ID {
+		t.Fatalf("发送了 %v, 应该只发送 CAS 成功的通知 %d", sent, first.ID)
+	}
+	if status, _ := repo.state(second.ID); status != domain.SendStatusPending {
+		t.Fatalf("CAS 出错的通知状态 %s, 应该还是 PENDING", status)
+	}
+}
+
+func testReadyNotification(id uint64, now time.Time) domain.Notification {
+	return domain.Notification{
+		ID:             id,
+		BizID:          1,
+		Channel:        domain.ChannelSMS,
+		Status:         domain.SendStatusPending,
+		Version:        1,
+		ScheduledSTime: now.Add(-time.Minute),
+		ScheduledETime: now.Add(time.Hour),
+	}
+}
+
+func waitTimers(t *testing.T, clk *clock.Fake, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for clk.Timers() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("等待 %d 个定时器超时", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type casRecord struct {
+	status  domain.SendStatus
+	version int
+}
+
+// casNotificationRepo 只实现调度和发送用到的状态 CAS，marked 是 MarkFailed 真正更新的次数
+type casNotificationRepo struct {
+	repository.NotificationRepository
+	mu      sync.Mutex
+	records map[uint64]casRecord
+	casErr  map[uint64]error
+	marked  int
+}
+
+func newCASNotificationRepo(notifications ...domain.Notification) *casNotificationRepo {
+	r := &casNotificationRepo{records: map[uint64]casRecord{}, casErr: map[uint64]error{}}
+	for _, n := range notifications {
+		r.records[n.ID] = casRecord{status: n.Status, version: n.Version}
+	}
+	return r
+}
+
+func (r *casNotificationRepo) bump(id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.records[id]
+	rec.version++
+	r.records[id] = rec
+}
+
+func (r *casNotificationRepo) state(id uint64) (domain.SendStatus, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.records[id].status, r.records[id].version
+}
+
+func (r *casNotificationRepo) CASStatus(_ context.Context, n domain.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.casErr[n.ID]; err != nil {
+		return err
+	}
+	rec, ok := r.records[n.ID]
+	if !ok || rec.version != n.Version {
+		return domain.ErrNotificationVersionMismatch
+	}
+	r.records[n.ID] = casRecord{status: n.Status, version: rec.version + 1}
+	return nil
+}
+
+// MarkFailed 和 DAO 一样只更新还是这个版本的 SENDING 通知
+func (r *casNotificationRepo) MarkFailed(_ context.Context, n domain.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.records[n.ID]
+	if rec.status != domain.SendStatusSending || rec.version != n.Version {
+		return domain.ErrNotificationVersionMismatch
+	}
+	r.records[n.ID] = casRecord{status: n.Status, version: rec.version + 1}
+	r.marked++
+	return nil
+}
+
+type recordingSender struct {
+	sent chan domain.Notification
+}
+
+func (s *recordingSender) Send(_ context.Context, n domain.Notification, _ provider.Named) error {
+	s.sent <- n
+	return nil
+}
+
+// readyService 按 ID 升序分页返回到期的通知，记录每次查询的 afterID 和 limit
+type readyService struct {
+	Service
+	notifications []domain.Notification
+	mu            sync.Mutex
+	partition     domain.Partition
+	queries       [][2]uint64
+}
+
+func (s *readyService) FindReadyNotifications(_ context.Context, p domain.Partition, afterID uint64, limit int) ([]domain.Notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partition = p
+	s.queries = append(s.queries, [2]uint64{afterID, uint64(limit)})
+	var res []domain.Notification
+	for _, n := range s.notifications {
+		if n.ID > afterID && p.Owns(n.ID) && len(res) < limit {
+			res = append(res, n)
+		}
+	}
+	return res, nil
+}
+
+func (s *readyService) pages() [][2]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.queries
+}
+
+type staticMembership struct {
+	p domain.Partition
+}
+
+func (staticMembership) Start(context.Context) {}
+
+func (m staticMembership) Partition() (domain.Partition, bool) {
+	return m.p, true
+}
+
+type passthroughFallback struct {
+	FallbackService
+}
+
+func (passthroughFallback) Filter(_ context.Context, notifications []domain.Notification) []domain.Notification {
+	return notifications
+}
+
+type passthroughPacer struct {
+	PacingService
+}
+
+func (passthroughPacer) Admit(_ context.Context, notifications []domain.Notification) []domain.Notification {
+	return notifications
+}
@@ -1 +1,107 @@
 package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"go.uber.org/zap"
+)
+
+// NotificationSender 发送单条通知，调用时通知已经是 SENDING 状态
+// p 是调度时选好的供应商，为空时由发送方自己选择
+// 供应商返回 domain.ErrSendWindowClosed 时按 domain.FailReasonWindowClosed 标记失败，不再重试
+// 供应商返回 domain.ErrReceiverBlacklisted 时按 domain.FailReasonBlacklisted 标记失败，不再重试
+// 供应商返回 domain.ErrReceiverOptedOut 时按 domain.FailReasonOptedOut 标记失败，不再重试
+// 供应商明确返回失败（*provider.VendorError）时按 provider.Retryable 决定是否重试，失败原因记录为 provider.FailReason
+// 值得重试的错误不要修改通知状态，调度器按通知的 RetryPolicy 重新排期，用完发送次数时标记失败
+type NotificationSender interface {
+	Send(ctx context.Context, notification domain.Notification, p provider.Named) error
+}
+
+var _ NotificationSender = (*providerSender)(nil)
+
+// NewNotificationSender 按 NotificationSender 的约定调用供应商并更新通知状态
+// selector 只在调度时没有选好供应商时使用
+func NewNotificationSender(repo repository.NotificationRepository, templateSvc ChannelTemplateService,
+	selector provider.Selector,
+) NotificationSender {
+	return &providerSender{
+		repo:        repo,
+		templateSvc: templateSvc,
+		selector:    selector,
+		logger:      log.Named(log.DefaultLogger(), "service.sender"),
+	}
+}
+
+type providerSender struct {
+	repo        repository.NotificationRepository
+	templateSvc ChannelTemplateService
+	selector    provider.Selector
+	logger      log.LoggerInterface
+}
+
+func (s *providerSender) Send(ctx context.Context, n domain.Notification, p provider.Named) error {
+	if p.Provider == nil {
+		var err error
+		if p, err = s.selector.Select(ctx, n); err != nil {
+			return err
+		}
+	}
+	version, err := s.templateVersion(ctx, n)
+	if err != nil {
+		return err
+	}
+	attempt := int(n.Attempts) + 1
+	_, err = p.Provider.Send(ctx, provider.Request{
+		Notification:   n,
+		Attempt:        attempt,
+		IdempotencyKey: provider.IdempotencyKey(n.ID, attempt),
+		Template:       version,
+	})
+	if err == nil {
+		n.Status = domain.SendStatusSucceeded
+		if err = s.repo.MarkSuccess(ctx, n); err != nil {
+			// 已经发出去了，不能返回错误让调度器重试，依旧是 SENDING，由 MarkTimeoutSendingAsFailed 兜底
+			s.logger.WithContext(ctx).Error("标记通知发送成功失败", zap.String("provider", p.Name), zap.Error(err))
+		}
+		return nil
+	}
+	if reason := s.failReason(err); reason != "" {
+		n.Status = domain.SendStatusFailed
+		n.FailReason = reason
+		if markErr := s.repo.MarkFailed(ctx, n); markErr != nil {
+			s.logger.WithContext(ctx).Error("标记通知发送失败失败", zap.String("provider", p.Name), zap.Error(markErr))
+		}
+	}
+	return fmt.Errorf("供应商 %s 发送失败: %w", p.Name, err)
+}
+
+// templateVersion 通知创建时确定的模板版本
+func (s *providerSender) templateVersion(ctx context.Context, n domain.Notification) (domain.ChannelTemplateVersion, error) {
+	template, err := s.templateSvc.GetTemplateByID(ctx, n.BizID, n.Template.ID)
+	if err != nil {
+		return domain.ChannelTemplateVersion{}, fmt.Errorf("查询通知的模板失败: %w", err)
+	}
+	return template.FindVersion(n.Template.VersionID)
+}
+
+// failReason 不再重试的错误对应的失败原因，值得重试的错误返回空
+func (s *providerSender) failReason(err error) domain.FailReason {
+	switch {
+	case errors.Is(err, domain.ErrSendWindowClosed):
+		return domain.FailReasonWindowClosed
+	case errors.Is(err, domain.ErrReceiverBlacklisted):
+		return domain.FailReasonBlacklisted
+	case errors.Is(err, domain.ErrReceiverOptedOut):
+		return domain.FailReasonOptedOut
+	case !provider.Retryable(err):
+		return cmp.Or(provider.FailReason(err), domain.FailReasonRetryExhausted)
+	}
+	return ""
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	sloTargetGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "notification_slo_target_ratio",
+		Help: "Configured target ratio of good events for each SLO",
+	}, []string{"slo"})
+	sloEventsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "notification_slo_window_events",
+		Help: "Number of events counted by an SLO in the window, useful to ignore low-traffic alerts",
+	}, []string{"slo", "key", "window"})
+	sloComplianceGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "notification_slo_compliance_ratio",
+		Help: "Ratio of good events in the window, 1 when the window has no events",
+	}, []string{"slo", "key", "window"})
+	sloBurnRateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "notification_slo_burn_rate",
+		Help: "Error budget burn rate in the window, 1 means the budget is exhausted exactly at the end of the SLO period",
+	}, []string{"slo", "key", "window"})
+	sloBudgetRemainingGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "notification_slo_error_budget_remaining_ratio",
+		Help: "Remaining ratio of the error budget over the SLO period, negative when the budget is overspent",
+	}, []string{"slo", "key"})
+)
+
+func init() {
+	prometheus.MustRegister(sloTargetGauge, sloEventsGauge, sloComplianceGauge, sloBurnRateGauge, sloBudgetRemainingGauge)
+}
+
+// SLOService 按配置的服务等级目标计算每个时间窗口的达标率和错误预算消耗速度
+type SLOService interface {
+	// Objectives 配置的服务等级目标
+	Objectives() []domain.SLOObjective
+	// Period SLO 周期，错误预算按这个周期计算
+	Period() time.Duration
+	// Evaluate 计算每个目标、统计对象在每个时间窗口（包括整个周期）的达标情况
+	Evaluate(ctx context.Context) ([]domain.SLOReport, error)
+}
+
+var _ SLOService = (*sloService)(nil)
+
+type sloService struct {
+	repo       repository.SLORepository
+	objectives []domain.SLOObjective
+	windows    []time.Duration
+	period     time.Duration
+}
+
+// NewSLOService windows 是计算消耗速度的时间窗口，period 也会作为一个窗口计算
+func NewSLOService(repo repository.SLORepository, objectives []domain.SLOObjective,
+	windows []time.Duration, period time.Duration,
+) SLOService {
+	windows = append(slices.Clone(windows), period)
+	slices.Sort(windows)
+	return &sloService{
+		repo:       repo,
+		objectives: objectives,
+		windows:    slices.Compact(windows),
+		period:     period,
+	}
+}
+
+func (s *sloService) Objectives() []domain.SLOObjective {
+	return s.objectives
+}
+
+func (s *sloService) Period() time.Duration {
+	return s.period
+}
+
+func (s *sloService) Evaluate(ctx context.Context) ([]domain.SLOReport, error) {
+	now := time.Now()
+	var reports []domain.SLOReport
+	for _, o := range s.objectives {
+		for _, w := range s.windows {
+			counts, err := s.repo.FindCounts(ctx, o.Kind, now.Add(-w))
+			if err != nil {
+				return nil, fmt.Errorf("统计 %s 最近 %s 的事件失败: %w", o.Kind, formatSLOWindow(w), err)
+			}
+			for _, c := range counts {
+				reports = append(reports, domain.SLOReport{
+					Kind:   o.Kind,
+					Key:    c.Key,
+					Window: w,
+					Target: o.Target,
+					Good:   c.Good,
+					Total:  c.Total,
+				})
+			}
+		}
+	}
+	return reports, nil
+}
+
+// formatSLOWindow 整天的窗口写成 3d，整小时写成 6h，作为指标的 window 标签
+func formatSLOWindow(w time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case w%day == 0:
+		return strconv.FormatInt(int64(w/day), 10) + "d"
+	case w%time.Hour == 0:
+		return strconv.FormatInt(int64(w/time.Hour), 10) + "h"
+	default:
+		return w.String()
+	}
+}
+
+// SLOExportTask 定时计算服务等级目标并导出为指标，告警规则和大盘直接使用这些指标
+// 每个实例都会计算，查询的都是预聚合的统计表或者走索引的计数，聚合时按 max 去重即可
+type SLOExportTask struct {
+	svc      SLOService
+	interval time.Duration
+	logger   log.LoggerInterface
+
+	// exported 上一次导出的标签，统计对象消失时删除对应的指标
+	exported map[sloSeries]struct{}
+}
+
+type sloSeries struct {
+	kind   domain.SLOKind
+	key    string
+	window string
+}
+
+func NewSLOExportTask(svc SLOService, interval time.Duration) *SLOExportTask {
+	return &SLOExportTask{
+		svc:      svc,
+		interval: interval,
+		logger:   log.Named(log.DefaultLogger(), "service.slo"),
+		exported: make(map[sloSeries]struct{}),
+	}
+}
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *SLOExportTask) Start(ctx context.Context) {
+	for _, o := range t.svc.Objectives() {
+		sloTargetGauge.WithLabelValues(o.Kind.String()).Set(o.Target)
+	}
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		t.runOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *SLOExportTask) runOnce(ctx context.Context) {
+	reports, err := t.svc.Evaluate(ctx)
+	if err != nil {
+		// 保留上一次导出的值，由告警规则里的数据缺失或者过期判断兜底
+		t.logger.WithContext(ctx).Error("计算服务等级目标失败", zap.Error(err))
+		return
+	}
+	period := t.svc.Period()
+	current := make(map[sloSeries]struct{}, len(reports))
+	for _, r := range reports {
+		series := sloSeries{kind: r.Kind, key: r.Key, window: formatSLOWindow(r.Window)}
+		current[series] = struct{}{}
+		sloEventsGauge.WithLabelValues(r.Kind.String(), r.Key, series.window).Set(float64(r.Total))
+		sloComplianceGauge.WithLabelValues(r.Kind.String(), r.Key, series.window).Set(r.Compliance())
+		sloBurnRateGauge.WithLabelValues(r.Kind.String(), r.Key, series.window).Set(r.BurnRate())
+		if r.Window == period {
+			sloBudgetRemainingGauge.WithLabelValues(r.Kind.String(), r.Key).Set(1 - r.BurnRate())
+		}
+	}
+	for series := range t.exported {
+		if _, ok := current[series]; ok {
+			continue
+		}
+		sloEventsGauge.DeleteLabelValues(series.kind.String(), series.key, series.window)
+		sloComplianceGauge.DeleteLabelValues(series.kind.String(), series.key, series.window)
+		sloBurnRateGauge.DeleteLabelValues(series.kind.String(), series.key, series.window)
+		if series.window == formatSLOWindow(period) {
+			sloBudgetRemainingGauge.DeleteLabelValues(series.kind.String(), series.key)
+		}
+	}
+	t.exported = current
+}
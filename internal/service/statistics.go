@@ -0,0 +1,234 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTopFailingTemplates = 10
+	maxTopFailingTemplates     = 100
+)
+
+// StatisticsService 发送统计，只读取统计任务预聚合的小时统计，不查询通知表
+// 开始时间会向下取整到小时，统计的粒度是小时
+type StatisticsService interface {
+	// DailySendCounts 按天、渠道、状态统计通知数量，日期按服务端时区划分
+	DailySendCounts(ctx context.Context, query domain.StatsQuery) ([]domain.DailySendStat, error)
+	// TopFailingTemplates 失败数量最多的模板，limit 小于等于 0 时默认 10
+	TopFailingTemplates(ctx context.Context, query domain.StatsQuery, limit int) ([]domain.TemplateFailureStat, error)
+	// ProviderSuccessRates 各个供应商在各个渠道上的成功率
+	ProviderSuccessRates(ctx context.Context, query domain.StatsQuery) ([]domain.ProviderSuccessRate, error)
+	// HourlyTrend 按小时、渠道、状态统计通知数量
+	HourlyTrend(ctx context.Context, query domain.StatsQuery) ([]domain.HourlySendStat, error)
+	// Rollup 重新统计最近 lookback 时间内每个小时的数据，返回统计的小时数
+	Rollup(ctx context.Context, lookback time.Duration) (int, error)
+}
+
+var _ StatisticsService = (*statisticsService)(nil)
+
+func NewStatisticsService(repo repository.StatisticsRepository) StatisticsService {
+	return &statisticsService{repo: repo}
+}
+
+type statisticsService struct {
+	repo repository.StatisticsRepository
+}
+
+func (s *statisticsService) findSendStats(ctx context.Context, query domain.StatsQuery) ([]domain.SendStat, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	query.Start = query.Start.Truncate(time.Hour)
+	return s.repo.FindSendStats(ctx, query)
+}
+
+func (s *statisticsService) DailySendCounts(ctx context.Context, query domain.StatsQuery) ([]domain.DailySendStat, error) {
+	stats, err := s.findSendStats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		date    string
+		channel domain.Channel
+		status  domain.SendStatus
+	}
+	counts := make(map[key]int64)
+	for _, st := range stats {
+		counts[key{date: st.Hour.In(time.Local).Format(time.DateOnly), channel: st.Channel, status: st.Status}] += st.Count
+	}
+	res := make([]domain.DailySendStat, 0, len(counts))
+	for k, c := range counts {
+		res = append(res, domain.DailySendStat{Date: k.date, Channel: k.channel, Status: k.status, Count: c})
+	}
+	slices.SortFunc(res, func(a, b domain.DailySendStat) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.Channel, b.Channel), cmp.Compare(a.Status, b.Status))
+	})
+	return res, nil
+}
+
+func (s *statisticsService) TopFailingTemplates(ctx context.Context, query domain.StatsQuery, limit int) ([]domain.TemplateFailureStat, error) {
+	if limit <= 0 {
+		limit = defaultTopFailingTemplates
+	}
+	if limit > maxTopFailingTemplates {
+		return nil, fmt.Errorf("%w: limit 不能超过 %d", domain.ErrInvalidParameter, maxTopFailingTemplates)
+	}
+	stats, err := s.findSendStats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[int64]*domain.TemplateFailureStat)
+	for _, st := range stats {
+		t, ok := templates[st.TemplateID]
+		if !ok {
+			t = &domain.TemplateFailureStat{TemplateID: st.TemplateID}
+			templates[st.TemplateID] = t
+		}
+		t.Total += st.Count
+		if st.Status == domain.SendStatusFailed {
+			t.Failed += st.Count
+		}
+	}
+	res := make([]domain.TemplateFailureStat, 0, len(templates))
+	for _, t := range templates {
+		if t.Failed > 0 {
+			res = append(res, *t)
+		}
+	}
+	// 失败数量相同时失败率高的在前
+	slices.SortFunc(res, func(a, b domain.TemplateFailureStat) int {
+		return cmp.Or(cmp.Compare(b.Failed, a.Failed), cmp.Compare(b.FailureRate(), a.FailureRate()),
+			cmp.Compare(a.TemplateID, b.TemplateID))
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (s *statisticsService) ProviderSuccessRates(ctx context.Context, query domain.StatsQuery) ([]domain.ProviderSuccessRate, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	query.Start = query.Start.Truncate(time.Hour)
+	stats, err := s.repo.FindProviderStats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		provider string
+		channel  domain.Channel
+	}
+	rates := make(map[key]*domain.ProviderSuccessRate)
+	for _, st := range stats {
+		k := key{provider: st.Provider, channel: st.Channel}
+		r, ok := rates[k]
+		if !ok {
+			r = &domain.ProviderSuccessRate{Provider: st.Provider, Channel: st.Channel}
+			rates[k] = r
+		}
+		r.Dispatched += st.Dispatched
+		r.Failed += st.Failed
+	}
+	res := make([]domain.ProviderSuccessRate, 0, len(rates))
+	for _, r := range rates {
+		res = append(res, *r)
+	}
+	slices.SortFunc(res, func(a, b domain.ProviderSuccessRate) int {
+		return cmp.Or(cmp.Compare(a.Channel, b.Channel), cmp.Compare(a.Provider, b.Provider))
+	})
+	return res, nil
+}
+
+func (s *statisticsService) HourlyTrend(ctx context.Context, query domain.StatsQuery) ([]domain.HourlySendStat, error) {
+	stats, err := s.findSendStats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		hour    int64
+		channel domain.Channel
+		status  domain.SendStatus
+	}
+	counts := make(map[key]int64)
+	for _, st := range stats {
+		counts[key{hour: st.Hour.UnixMilli(), channel: st.Channel, status: st.Status}] += st.Count
+	}
+	res := make([]domain.HourlySendStat, 0, len(counts))
+	for k, c := range counts {
+		res = append(res, domain.HourlySendStat{Hour: time.UnixMilli(k.hour), Channel: k.channel, Status: k.status, Count: c})
+	}
+	slices.SortFunc(res, func(a, b domain.HourlySendStat) int {
+		return cmp.Or(a.Hour.Compare(b.Hour), cmp.Compare(a.Channel, b.Channel), cmp.Compare(a.Status, b.Status))
+	})
+	return res, nil
+}
+
+// Rollup 通知状态在创建之后还会变化，所以每次都重新统计整个回看窗口，而不是只统计新的小时
+func (s *statisticsService) Rollup(ctx context.Context, lookback time.Duration) (int, error) {
+	now := time.Now()
+	hours := 0
+	for hour := now.Add(-lookback).Truncate(time.Hour); !hour.After(now); hour = hour.Add(time.Hour) {
+		if err := s.repo.RollupHour(ctx, hour); err != nil {
+			return hours, fmt.Errorf("统计 %s 失败: %w", hour.Format(time.DateTime), err)
+		}
+		hours++
+	}
+	return hours, nil
+}
+
+// StatsRollupTask 定时重新统计最近一段时间的发送数据，统计完之后汇总模板的使用情况
+type StatsRollupTask struct {
+	svc      StatisticsService
+	usageSvc TemplateUsageService
+	lock     distribute_lock.Client
+	interval time.Duration
+	lookback time.Duration
+	logger   log.LoggerInterface
+}
+
+func NewStatsRollupTask(svc StatisticsService, usageSvc TemplateUsageService, lock distribute_lock.Client,
+	interval, lookback time.Duration,
+) *StatsRollupTask {
+	return &StatsRollupTask{
+		svc:      svc,
+		usageSvc: usageSvc,
+		lock:     lock,
+		interval: interval,
+		lookback: lookback,
+		logger:   log.Named(log.DefaultLogger(), "service.statistics"),
+	}
+}
+
+const statsRollupLockKey = "notification:stats:rollup:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *StatsRollupTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, statsRollupLockKey, t.interval, t.runOnce)
+}
+
+func (t *StatsRollupTask) runOnce(ctx context.Context) {
+	start := time.Now()
+	hours, err := t.svc.Rollup(ctx, t.lookback)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("统计发送数据失败", zap.Error(err), zap.Int("hours", hours))
+		return
+	}
+	templates, err := t.usageSvc.Rollup(ctx, t.lookback)
+	if err != nil {
+		t.logger.WithContext(ctx).Error("汇总模板使用情况失败", zap.Error(err))
+		return
+	}
+	t.logger.WithContext(ctx).Info("统计发送数据", zap.Int("hours", hours), zap.Int("templates", templates),
+		zap.Duration("cost", time.Since(start)))
+}
@@ -1 +1,118 @@
 package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+// ChannelTemplateService 渠道模板服务
+type ChannelTemplateService interface {
+	// GetTemplateByID 获取业务方自己的模板
+	GetTemplateByID(ctx context.Context, bizID, templateID int64) (domain.ChannelTemplate, error)
+	// PrepareTemplate 发送前校验模板归属和渠道、生效版本是否审核通过，填充生效的版本ID，并按照版本的参数定义校验参数
+	PrepareTemplate(ctx context.Context, notification *domain.Notification) error
+	// DiffVersions 比较业务方自己模板的两个版本
+	DiffVersions(ctx context.Context, bizID, templateID, fromVersionID, toVersionID int64) (domain.TemplateVersionDiff, error)
+	// RollbackVersion 拷贝审核通过的 versionID 作为新的生效版本，返回新的版本
+	// 并发回滚或者拷贝失败时返回 domain.ErrForkVersionFailed
+	RollbackVersion(ctx context.Context, bizID, templateID, versionID int64) (domain.ChannelTemplateVersion, error)
+}
+
+var _ ChannelTemplateService = &channelTemplateService{}
+
+func NewChannelTemplateService(repo repository.ChannelTemplateRepository) ChannelTemplateService {
+	return &channelTemplateService{
+		repo: repo,
+	}
+}
+
+type channelTemplateService struct {
+	repo repository.ChannelTemplateRepository
+}
+
+func (s *channelTemplateService) GetTemplateByID(ctx context.Context, bizID, templateID int64) (domain.ChannelTemplate, error) {
+	if templateID <= 0 {
+		return domain.ChannelTemplate{}, fmt.Errorf("%w: 模板ID = %d", domain.ErrInvalidParameter, templateID)
+	}
+	template, err := s.repo.GetByID(ctx, templateID)
+	if err != nil {
+		return domain.ChannelTemplate{}, err
+	}
+	// 不暴露其他业务方的模板
+	if template.OwnerID != bizID {
+		return domain.ChannelTemplate{}, fmt.Errorf("%w: id=%d", domain.ErrTemplateNotFound, templateID)
+	}
+	return template, nil
+}
+
+// checkParams 有按接收者覆盖的参数时，每个接收者实际使用的参数都要符合参数定义
+func checkParams(version domain.ChannelTemplateVersion, t domain.Template) error {
+	if len(t.ReceiverParams) == 0 {
+		return version.ParamSchema.Check(t.Params)
+	}
+	for receiver := range t.ReceiverParams {
+		if err := version.ParamSchema.Check(t.ParamsFor(receiver)); err != nil {
+			return fmt.Errorf("接收者 %s: %w", receiver, err)
+		}
+	}
+	return nil
+}
+
+func (s *channelTemplateService) PrepareTemplate(ctx context.Context, notification *domain.Notification) error {
+	template, err := s.GetTemplateByID(ctx, notification.BizID, notification.Template.ID)
+	if err != nil {
+		return err
+	}
+	if template.Channel != notification.Channel {
+		return fmt.Errorf("%w: 模板 %d 属于 %s 渠道，不能用于 %s 渠道", domain.ErrInvalidParameter,
+			template.ID, template.Channel, notification.Channel)
+	}
+	version, err := template.ActiveVersion()
+	if err != nil {
+		return err
+	}
+	if err = version.CheckApproved(); err != nil {
+		return err
+	}
+	if err = checkParams(version, notification.Template); err != nil {
+		return err
+	}
+	notification.Template.VersionID = version.ID
+	return nil
+}
+
+func (s *channelTemplateService) DiffVersions(ctx context.Context, bizID, templateID, fromVersionID, toVersionID int64) (domain.TemplateVersionDiff, error) {
+	template, err := s.GetTemplateByID(ctx, bizID, templateID)
+	if err != nil {
+		return domain.TemplateVersionDiff{}, err
+	}
+	from, err := template.FindVersion(fromVersionID)
+	if err != nil {
+		return domain.TemplateVersionDiff{}, err
+	}
+	to, err := template.FindVersion(toVersionID)
+	if err != nil {
+		return domain.TemplateVersionDiff{}, err
+	}
+	return domain.DiffVersions(from, to), nil
+}
+
+func (s *channelTemplateService) RollbackVersion(ctx context.Context, bizID, templateID, versionID int64) (domain.ChannelTemplateVersion, error) {
+	template, err := s.GetTemplateByID(ctx, bizID, templateID)
+	if err != nil {
+		return domain.ChannelTemplateVersion{}, err
+	}
+	fork, err := template.Fork(versionID)
+	if err != nil {
+		return domain.ChannelTemplateVersion{}, err
+	}
+	version, err := s.repo.ForkVersion(ctx, fork, template.ActiveVersionID)
+	if err != nil && !errors.Is(err, domain.ErrForkVersionFailed) {
+		return domain.ChannelTemplateVersion{}, fmt.Errorf("%w: %w", domain.ErrForkVersionFailed, err)
+	}
+	return version, err
+}
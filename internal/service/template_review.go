@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/clock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/idgen"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"go.uber.org/zap"
+)
+
+// TemplateReviewService 模板审核流程：平台内部审核 → 自动提交给渠道的供应商审核 → 轮询供应商的审核结果
+// 内部审核通过、并且至少一个供应商审核通过的版本才能用于发送
+type TemplateReviewService interface {
+	// ReviewVersion 内部审核待审核的版本，审核通过时为渠道配置的每个供应商创建待提交的审核记录
+	// reviewer 是审核人的唯一标识，没有开启鉴权时为空
+	ReviewVersion(ctx context.Context, templateID, versionID int64, reviewer string, approved bool, reason string) (domain.ChannelTemplateVersion, error)
+	// Sync 提交待提交的审核记录，查询审核中的记录，返回处理的数量
+	Sync(ctx context.Context, limit int) (int, error)
+	// HandleAuditCallback 处理供应商推送的审核结果，返回给供应商的响应体
+	// 供应商不支持推送时返回 domain.ErrProviderNotFound，请求体格式错误时返回 domain.ErrInvalidParameter
+	HandleAuditCallback(ctx context.Context, providerName string, body []byte) ([]byte, error)
+}
+
+var _ TemplateReviewService = (*templateReviewService)(nil)
+
+const (
+	// templateReviewMaxBackoff 提交或者查询连续失败时，重试间隔最长一小时
+	templateReviewMaxBackoff = time.Hour
+	maxRejectReasonLen       = 512
+)
+
+// NamedTemplateReviewer 供应商名称和它的模板审核接口
+type NamedTemplateReviewer struct {
+	Name     string
+	Channel  domain.Channel
+	Reviewer provider.TemplateReviewer
+}
+
+// NewTemplateReviewService notice 为 nil 时不通知模板所属的业务方
+func NewTemplateReviewService(repo repository.ChannelTemplateRepository,
+	notificationRepo repository.NotificationRepository,
+	templateSvc ChannelTemplateService,
+	idGenerator idgen.Generator,
+	clk clock.Clock,
+	reviewers []NamedTemplateReviewer,
+	pollInterval time.Duration,
+	notice *domain.TemplateReviewNotice,
+) TemplateReviewService {
+	byName := make(map[string]provider.TemplateReviewer, len(reviewers))
+	byChannel := make(map[domain.Channel][]string, len(reviewers))
+	for _, r := range reviewers {
+		byName[r.Name] = r.Reviewer
+		byChannel[r.Channel] = append(byChannel[r.Channel], r.Name)
+	}
+	return &templateReviewService{
+		repo:      repo,
+		reviewers: byName,
+		channels:  byChannel,
+		creator: asyncCreator{
+			notificationRepo: notificationRepo,
+			templateSvc:      templateSvc,
+			idGenerator:      idGenerator,
+			clock:            clk,
+		},
+		pollInterval: pollInterval,
+		notice:       notice,
+		logger:       log.Named(log.DefaultLogger(), "service.template_review"),
+	}
+}
+
+type templateReviewService struct {
+	repo repository.ChannelTemplateRepository
+	// reviewers 供应商名称 → 模板审核接口
+	reviewers map[string]provider.TemplateReviewer
+	// channels 渠道 → 需要提交审核的供应商名称
+	channels     map[domain.Channel][]string
+	creator      asyncCreator
+	pollInterval time.Duration
+	notice       *domain.TemplateReviewNotice
+	logger       log.LoggerInterface
+}
+
+func (s *templateReviewService) ReviewVersion(ctx context.Context, templateID, versionID int64,
+	reviewer string, approved bool, reason string,
+) (domain.ChannelTemplateVersion, error) {
+	if len(reason) > maxRejectReasonLen || (!approved && reason == "") {
+		return domain.ChannelTemplateVersion{}, fmt.Errorf("%w: 审核不通过时必须填写原因，最长 %d", domain.ErrInvalidParameter, maxRejectReasonLen)
+	}
+	template, err := s.repo.GetByID(ctx, templateID)
+	if err != nil {
+		return domain.ChannelTemplateVersion{}, err
+	}
+	version, err := template.FindVersion(versionID)
+	if err != nil {
+		return domain.ChannelTemplateVersion{}, err
+	}
+	if version.AuditStatus != domain.AuditStatusPending {
+		return domain.ChannelTemplateVersion{}, fmt.Errorf("%w: 版本 %d 审核状态 %s",
+			domain.ErrUpdateTemplateVersionAuditStatusFailed, versionID, version.AuditStatus)
+	}
+	if approved {
+		if err = checkTemplateContent(template.Channel, version); err != nil {
+			return domain.ChannelTemplateVersion{}, err
+		}
+	}
+	now := time.Now().UnixMilli()
+	version.RejectReason = reason
+	version.Reviewer = reviewer
+	version.ReviewTime = now
+	version.Providers = nil
+	if !approved {
+		version.AuditStatus = domain.AuditStatusRejected
+	} else {
+		version.AuditStatus = domain.AuditStatusApproved
+		for _, name := range s.channels[template.Channel] {
+			version.Providers = append(version.Providers, domain.ChannelTemplateProvider{
+				TemplateID:        template.ID,
+				TemplateVersionID: version.ID,
+				ProviderName:      name,
+				AuditStatus:       domain.AuditStatusPending,
+				NextCheckTime:     now,
+			})
+		}
+	}
+	if err = s.repo.ReviewVersion(ctx, version); err != nil {
+		return domain.ChannelTemplateVersion{}, err
+	}
+	return version, nil
+}
+
+// checkTemplateContent 短信模板由供应商替换 ${name}，不能使用 text/template 语法
+func checkTemplateContent(channel domain.Channel, version domain.ChannelTemplateVersion) error {
+	if channel == domain.ChannelSMS && version.UsesTextTemplate() {
+		return fmt.Errorf("%w: 短信模板不支持 {{ }} 语法", domain.ErrInvalidParameter)
+	}
+	return version.CheckSyntax()
+}
+
+func (s *templateReviewService) Sync(ctx context.Context, limit int) (int, error) {
+	records, err := s.repo.FindProvidersToCheck(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	for i, record := range records {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		updated, err := s.check(ctx, record)
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("同步供应商模板审核状态失败", zap.Error(err),
+				zap.String("provider", record.ProviderName),
+				zap.Int64("template_version_id", record.TemplateVersionID),
+				zap.Int32("retry_count", record.RetryCount))
+			updated = record
+			updated.RetryCount++
+			updated.NextCheckTime = time.Now().Add(s.backoff(updated.RetryCount)).UnixMilli()
+		}
+		// 推送的审核结果先到了，这里会更新失败
+		if err := s.save(ctx, updated); err != nil &&
+			!errors.Is(err, domain.ErrUpdateTemplateProviderAuditStatusFailed) {
+			s.logger.WithContext(ctx).Error("更新供应商模板审核记录失败", zap.Error(err), zap.Int64("id", record.ID))
+		}
+	}
+	return len(records), nil
+}
+
+func (s *templateReviewService) HandleAuditCallback(ctx context.Context, providerName string, body []byte) ([]byte, error) {
+	parser, ok := s.reviewers[providerName].(provider.TemplateAuditCallbackParser)
+	if !ok {
+		return nil, fmt.Errorf("%w: 供应商 %s 不支持推送模板审核结果", domain.ErrProviderNotFound, providerName)
+	}
+	callbacks, err := parser.ParseAuditCallback(body)
+	if err != nil {
+		return nil, err
+	}
+	for _, cb := range callbacks {
+		if cb.Status != domain.AuditStatusApproved && cb.Status != domain.AuditStatusRejected {
+			continue
+		}
+		record, err := s.repo.GetProviderByProviderTemplateID(ctx, providerName, cb.ProviderTemplateID)
+		if errors.Is(err, domain.ErrTemplateProviderNotFound) {
+			// 不是通过平台提交的模板，比如直接在供应商控制台创建的
+			s.logger.WithContext(ctx).Warn("忽略未知模板的审核结果",
+				zap.String("provider", providerName), zap.String("provider_template_id", cb.ProviderTemplateID))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if record.AuditStatus == cb.Status {
+			continue
+		}
+		record.AuditStatus = cb.Status
+		record.RejectReason = cb.Reason
+		record.RetryCount = 0
+		// 供应商重复推送，或者轮询已经拿到了结果
+		if err = s.save(ctx, record); err != nil && !errors.Is(err, domain.ErrUpdateTemplateProviderAuditStatusFailed) {
+			return nil, err
+		}
+	}
+	return parser.AuditCallbackAck(), nil
+}
+
+// save 更新审核记录，第一次得到最终结果时通知模板所属的业务方
+func (s *templateReviewService) save(ctx context.Context, record domain.ChannelTemplateProvider) error {
+	if err := s.repo.UpdateProvider(ctx, record); err != nil {
+		return err
+	}
+	if record.AuditStatus == domain.AuditStatusApproved || record.AuditStatus == domain.AuditStatusRejected {
+		if err := s.notify(ctx, record); err != nil {
+			s.logger.WithContext(ctx).Error("通知模板审核结果失败", zap.Error(err),
+				zap.Int64("template_id", record.TemplateID), zap.Int64("template_version_id", record.TemplateVersionID))
+		}
+	}
+	return nil
+}
+
+func (s *templateReviewService) notify(ctx context.Context, record domain.ChannelTemplateProvider) error {
+	if s.notice == nil {
+		return nil
+	}
+	template, err := s.repo.GetByID(ctx, record.TemplateID)
+	if err != nil {
+		return err
+	}
+	version, err := template.FindVersion(record.TemplateVersionID)
+	if err != nil {
+		return err
+	}
+	n, ok := s.notice.Notification(template, version, record)
+	if !ok {
+		return nil
+	}
+	_, err = s.creator.create(ctx, n)
+	return err
+}
+
+// check 待提交的记录提交给供应商，审核中的记录查询审核结果
+func (s *templateReviewService) check(ctx context.Context, record domain.ChannelTemplateProvider) (domain.ChannelTemplateProvider, error) {
+	reviewer, ok := s.reviewers[record.ProviderName]
+	if !ok {
+		return record, fmt.Errorf("%w: 供应商 %s 没有配置模板审核", domain.ErrProviderNotFound, record.ProviderName)
+	}
+	if record.AuditStatus == domain.AuditStatusPending {
+		template, err := s.repo.GetByID(ctx, record.TemplateID)
+		if err != nil {
+			return record, err
+		}
+		version, err := template.FindVersion(record.TemplateVersionID)
+		if err != nil {
+			return record, err
+		}
+		providerTemplateID, err := reviewer.SubmitTemplate(ctx, template, version)
+		if err != nil {
+			return record, err
+		}
+		record.ProviderTemplateID = providerTemplateID
+		record.AuditStatus = domain.AuditStatusInReview
+	} else {
+		res, err := reviewer.QueryTemplateReview(ctx, record.ProviderTemplateID)
+		if err != nil {
+			return record, err
+		}
+		record.AuditStatus = res.Status
+		record.RejectReason = res.Reason
+	}
+	record.RetryCount = 0
+	record.NextCheckTime = time.Now().Add(s.pollInterval).UnixMilli()
+	return record, nil
+}
+
+func (s *templateReviewService) backoff(retryCount int32) time.Duration {
+	d := s.pollInterval
+	for i := int32(1); i < retryCount && d < templateReviewMaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, templateReviewMaxBackoff)
+}
+
+// TemplateReviewTask 定时向供应商提交模板、查询审核结果
+type TemplateReviewTask struct {
+	svc       TemplateReviewService
+	lock      distribute_lock.Client
+	interval  time.Duration
+	batchSize int
+	logger    log.LoggerInterface
+}
+
+func NewTemplateReviewTask(svc TemplateReviewService, lock distribute_lock.Client, interval time.Duration, batchSize int) *TemplateReviewTask {
+	return &TemplateReviewTask{
+		svc:       svc,
+		lock:      lock,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log.Named(log.DefaultLogger(), "service.template_review"),
+	}
+}
+
+const templateReviewLockKey = "notification:template-review:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *TemplateReviewTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, templateReviewLockKey, t.interval, t.runOnce)
+}
+
+func (t *TemplateReviewTask) runOnce(ctx context.Context) {
+	if _, err := t.svc.Sync(ctx, t.batchSize); err != nil {
+		t.logger.WithContext(ctx).Error("同步供应商模板审核状态失败", zap.Error(err))
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+)
+
+// TemplateUsageService 模板使用情况，帮助业务方找出长期不用的模板，清理模板、回收供应商的模板名额
+type TemplateUsageService interface {
+	// Rollup 重新汇总最近 lookback 时间内用过的模板，返回汇总的模板数
+	Rollup(ctx context.Context, lookback time.Duration) (int, error)
+	// GetUsage 查询业务方模板的使用情况，调用方负责校验模板归属
+	GetUsage(ctx context.Context, bizID, templateID int64) (domain.TemplateUsage, error)
+	// FindUnused 查询业务方超过 query.UnusedDays 天没有用过的模板，UnusedDays 为 0 时默认 90 天
+	FindUnused(ctx context.Context, query domain.UnusedTemplateQuery) ([]domain.UnusedTemplate, error)
+}
+
+var _ TemplateUsageService = (*templateUsageService)(nil)
+
+func NewTemplateUsageService(repo repository.TemplateUsageRepository) TemplateUsageService {
+	return &templateUsageService{repo: repo}
+}
+
+type templateUsageService struct {
+	repo repository.TemplateUsageRepository
+}
+
+// Rollup 发送次数取自通知小时统计，所以要在统计任务重新统计之后执行
+func (s *templateUsageService) Rollup(ctx context.Context, lookback time.Duration) (int, error) {
+	return s.repo.Rollup(ctx, time.Now().Add(-lookback).Truncate(time.Hour))
+}
+
+func (s *templateUsageService) GetUsage(ctx context.Context, bizID, templateID int64) (domain.TemplateUsage, error) {
+	return s.repo.GetUsage(ctx, bizID, templateID)
+}
+
+func (s *templateUsageService) FindUnused(ctx context.Context, query domain.UnusedTemplateQuery) ([]domain.UnusedTemplate, error) {
+	if query.UnusedDays == 0 {
+		query.UnusedDays = domain.DefaultTemplateUnusedDays
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.FindUnused(ctx, query.BizID, query.Before(time.Now()), query.AfterID, query.Limit)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"go.uber.org/zap"
+)
+
+// UnsubscribeService 接收者退订营销类通知，退订之后发送时跳过这个接收者
+type UnsubscribeService interface {
+	// Unsubscribe 记录退订，重复退订不报错
+	Unsubscribe(ctx context.Context, optOut domain.OptOut) error
+	// HandleSMSReply 处理供应商推送的上行短信，内容是退订关键字时退订所有业务方的营销短信，返回给供应商的响应体
+	HandleSMSReply(ctx context.Context, providerName string, body []byte) ([]byte, error)
+}
+
+var _ UnsubscribeService = (*unsubscribeService)(nil)
+
+// NewUnsubscribeService parsers 是供应商名称到上行短信解析器，keywords 是退订关键字，不区分大小写
+func NewUnsubscribeService(repo repository.OptOutRepository, parsers map[string]provider.SMSReplyParser,
+	keywords []string, logger log.LoggerInterface,
+) UnsubscribeService {
+	set := make(map[string]struct{}, len(keywords))
+	for _, k := range keywords {
+		set[strings.ToUpper(strings.TrimSpace(k))] = struct{}{}
+	}
+	return &unsubscribeService{
+		repo:     repo,
+		parsers:  parsers,
+		keywords: set,
+		logger:   log.Named(logger, "service.unsubscribe"),
+	}
+}
+
+type unsubscribeService struct {
+	repo     repository.OptOutRepository
+	parsers  map[string]provider.SMSReplyParser
+	keywords map[string]struct{}
+	logger   log.LoggerInterface
+}
+
+func (s *unsubscribeService) Unsubscribe(ctx context.Context, optOut domain.OptOut) error {
+	if optOut.Receiver == "" || !optOut.Channel.IsValid() {
+		return fmt.Errorf("%w: 接收者和渠道不能为空", domain.ErrInvalidParameter)
+	}
+	if err := s.repo.Add(ctx, optOut); err != nil {
+		return err
+	}
+	s.logger.WithContext(ctx).Info("接收者退订营销类通知",
+		zap.Int64("biz_id", optOut.BizID),
+		zap.String("channel", optOut.Channel.String()),
+		zap.String("source", optOut.Source.String()))
+	return nil
+}
+
+func (s *unsubscribeService) HandleSMSReply(ctx context.Context, providerName string, body []byte) ([]byte, error) {
+	parser, ok := s.parsers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("%w: 供应商 %s 不支持推送上行短信", domain.ErrProviderNotFound, providerName)
+	}
+	replies, err := parser.ParseSMSReply(body)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range replies {
+		if !s.isStop(r.Content) {
+			continue
+		}
+		// 上行短信不知道回复的是哪个业务方的短信，退订所有业务方
+		err = s.Unsubscribe(ctx, domain.OptOut{
+			Channel:  domain.ChannelSMS,
+			Receiver: r.Mobile,
+			Source:   domain.OptOutSourceSMSStop,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parser.SMSReplyAck(), nil
+}
+
+func (s *unsubscribeService) isStop(content string) bool {
+	_, ok := s.keywords[strings.ToUpper(strings.TrimSpace(content))]
+	return ok
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/serendipityConfusion/notification-platform/internal/domain"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/anomaly"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/distribute_lock"
+	"github.com/serendipityConfusion/notification-platform/internal/pkg/log"
+	"github.com/serendipityConfusion/notification-platform/internal/repository"
+	"github.com/serendipityConfusion/notification-platform/internal/service/provider"
+	"go.uber.org/zap"
+)
+
+var (
+	vendorBalanceGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vendor_balance_remaining",
+		Help: "Remaining vendor account balance in cents or package messages, by unit",
+	}, []string{"provider", "unit"})
+	vendorBalanceErrorCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "vendor_balance_query_errors_total",
+		Help: "Total number of failed vendor balance queries",
+	}, []string{"provider"})
+)
+
+func init() {
+	prometheus.MustRegister(vendorBalanceGauge, vendorBalanceErrorCounter)
+}
+
+// VendorBalanceService 查询供应商的账户余额或者套餐包余量并保存快照，快用完时告警，避免余额耗尽后发送悄悄失败
+type VendorBalanceService interface {
+	// Check 查询所有供应商的余额，返回查询成功的数量
+	Check(ctx context.Context) (int, error)
+	// Prune 删除保留期之前的快照，返回删除的数量
+	Prune(ctx context.Context) (int64, error)
+}
+
+// NamedBalanceQuerier 供应商名称、余额查询接口和告警阈值
+type NamedBalanceQuerier struct {
+	Name    string
+	Querier provider.BalanceQuerier
+	// AlertBelow 余额低于这个值时告警，单位和供应商返回的余额单位一致，0 不告警
+	AlertBelow int64
+}
+
+var _ VendorBalanceService = (*vendorBalanceService)(nil)
+
+// vendorBalancePruneBatch 每次最多删除的快照数量
+const vendorBalancePruneBatch = 1000
+
+func NewVendorBalanceService(repo repository.VendorBalanceRepository,
+	queriers []NamedBalanceQuerier,
+	alerters []anomaly.TextAlerter,
+	cooldown, retention time.Duration,
+) VendorBalanceService {
+	return &vendorBalanceService{
+		repo:        repo,
+		queriers:    queriers,
+		alerters:    alerters,
+		cooldown:    cooldown,
+		retention:   retention,
+		lastAlerted: make(map[string]time.Time, len(queriers)),
+		logger:      log.Named(log.DefaultLogger(), "service.vendor_balance"),
+	}
+}
+
+type vendorBalanceService struct {
+	repo      repository.VendorBalanceRepository
+	queriers  []NamedBalanceQuerier
+	alerters  []anomaly.TextAlerter
+	cooldown  time.Duration
+	retention time.Duration
+	// lastAlerted 供应商上次告警的时间，只由任务所在的 goroutine 访问
+	lastAlerted map[string]time.Time
+	logger      log.LoggerInterface
+}
+
+func (s *vendorBalanceService) Check(ctx context.Context) (int, error) {
+	succeeded := 0
+	for _, q := range s.queriers {
+		if ctx.Err() != nil {
+			return succeeded, ctx.Err()
+		}
+		// 这个供应商相关的日志都带上供应商名称
+		ctx := log.ContextWithFields(ctx, zap.String("provider", q.Name))
+		b, err := q.Querier.QueryBalance(ctx)
+		if err != nil {
+			vendorBalanceErrorCounter.WithLabelValues(q.Name).Inc()
+			s.logger.WithContext(ctx).Error("查询供应商余额失败", zap.Error(err))
+			continue
+		}
+		succeeded++
+		b.Provider = q.Name
+		vendorBalanceGauge.WithLabelValues(q.Name, b.Unit.String()).Set(float64(b.Remaining))
+		s.logger.WithContext(ctx).Debug("查询供应商余额", zap.Int64("remaining", b.Remaining), zap.String("unit", b.Unit.String()))
+		if err = s.repo.Save(ctx, b); err != nil {
+			s.logger.WithContext(ctx).Error("保存供应商余额快照失败", zap.Error(err))
+		}
+		s.alertIfLow(ctx, b, q.AlertBelow)
+	}
+	return succeeded, nil
+}
+
+// alertIfLow 余额低于阈值时告警，冷却时间内不重复告警，余额恢复后重新计算，ctx 里带着供应商名称
+func (s *vendorBalanceService) alertIfLow(ctx context.Context, b domain.VendorBalance, threshold int64) {
+	if !b.Below(threshold) {
+		delete(s.lastAlerted, b.Provider)
+		return
+	}
+	now := time.Now()
+	if last, ok := s.lastAlerted[b.Provider]; ok && now.Sub(last) < s.cooldown {
+		return
+	}
+	s.lastAlerted[b.Provider] = now
+	title := fmt.Sprintf("供应商 %s 余额不足", b.Provider)
+	content := fmt.Sprintf("剩余: %s\n告警阈值: %s\n时间: %s",
+		formatBalance(b.Unit, b.Remaining), formatBalance(b.Unit, threshold), now.Format(time.DateTime))
+	s.logger.WithContext(ctx).Warn(title, zap.Int64("remaining", b.Remaining), zap.Int64("threshold", threshold))
+	for _, a := range s.alerters {
+		if err := a.AlertText(ctx, title, content); err != nil {
+			s.logger.WithContext(ctx).Error("发送供应商余额告警失败", zap.Error(err))
+		}
+	}
+}
+
+func formatBalance(unit domain.VendorBalanceUnit, v int64) string {
+	if unit == domain.VendorBalanceUnitCent {
+		return fmt.Sprintf("%.2f 元", float64(v)/100)
+	}
+	return fmt.Sprintf("%d 条", v)
+}
+
+func (s *vendorBalanceService) Prune(ctx context.Context) (int64, error) {
+	return s.repo.DeleteBefore(ctx, time.Now().Add(-s.retention), vendorBalancePruneBatch)
+}
+
+// VendorBalanceTask 定时查询供应商余额，顺便清理过期的快照
+type VendorBalanceTask struct {
+	svc      VendorBalanceService
+	lock     distribute_lock.Client
+	interval time.Duration
+	logger   log.LoggerInterface
+}
+
+func NewVendorBalanceTask(svc VendorBalanceService, lock distribute_lock.Client, interval time.Duration) *VendorBalanceTask {
+	return &VendorBalanceTask{
+		svc:      svc,
+		lock:     lock,
+		interval: interval,
+		logger:   log.Named(log.DefaultLogger(), "service.vendor_balance"),
+	}
+}
+
+const vendorBalanceLockKey = "notification:vendor-balance:lock"
+
+// Start 阻塞运行，直到 ctx 被取消
+func (t *VendorBalanceTask) Start(ctx context.Context) {
+	distribute_lock.RunLocked(ctx, t.lock, vendorBalanceLockKey, t.interval, t.runOnce)
+}
+
+func (t *VendorBalanceTask) runOnce(ctx context.Context) {
+	if _, err := t.svc.Check(ctx); err != nil {
+		t.logger.WithContext(ctx).Error("查询供应商余额失败", zap.Error(err))
+	}
+	if _, err := t.svc.Prune(ctx); err != nil {
+		t.logger.WithContext(ctx).Error("清理供应商余额快照失败", zap.Error(err))
+	}
+}
@@ -0,0 +1,102 @@
+// Package callback 业务方接收通知平台回调时使用的签名校验工具
+//
+// 通知平台回调时在请求头里带上时间戳和签名：
+//
+//	X-Notification-Timestamp: 1700000000000
+//	X-Notification-Signature: v1=<hex>,v1=<hex>
+//
+// 签名是 HMAC-SHA256(secret, "<timestamp>.<body>") 的十六进制，密钥轮换的宽限期内平台会用新旧两个密钥分别签名，
+// 业务方只要任意一个签名能通过校验即可，所以可以在宽限期内从容地更新自己的密钥
+package callback
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TimestampHeader 签名时间戳，毫秒
+	TimestampHeader = "X-Notification-Timestamp"
+	// SignatureHeader 签名，可能有多个，用逗号分隔
+	SignatureHeader = "X-Notification-Signature"
+
+	signatureVersion = "v1="
+
+	// DefaultTolerance 默认允许的时间偏差，超过认为是重放
+	DefaultTolerance = 5 * time.Minute
+)
+
+var (
+	ErrMissingSignature  = errors.New("缺少回调签名")
+	ErrInvalidTimestamp  = errors.New("回调签名时间戳不合法")
+	ErrTimestampExpired  = errors.New("回调签名时间戳超出允许范围")
+	ErrSignatureMismatch = errors.New("回调签名不匹配")
+)
+
+// Sign 计算一个签名，返回值带版本前缀，可以直接放进 SignatureHeader
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeaderValue 用多个密钥签名，拼成 SignatureHeader 的值
+func SignatureHeaderValue(secrets []string, timestamp int64, body []byte) string {
+	sigs := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		sigs = append(sigs, Sign(s, timestamp, body))
+	}
+	return strings.Join(sigs, ",")
+}
+
+// Verify 校验签名，secrets 是业务方当前持有的密钥，轮换期间可以同时传新旧密钥
+// tolerance 小于等于 0 时使用 DefaultTolerance
+func Verify(secrets []string, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	if diff := now.Sub(time.UnixMilli(ts)); diff > tolerance || diff < -tolerance {
+		return ErrTimestampExpired
+	}
+	for _, secret := range secrets {
+		expected := Sign(secret, ts, body)
+		for _, sig := range strings.Split(signature, ",") {
+			if hmac.Equal([]byte(strings.TrimSpace(sig)), []byte(expected)) {
+				return nil
+			}
+		}
+	}
+	return ErrSignatureMismatch
+}
+
+// VerifyRequest 校验 HTTP 回调请求，返回请求体，请求体读取之后会重新放回 r.Body
+func VerifyRequest(r *http.Request, secrets []string, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	err = Verify(secrets, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body, tolerance, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// hedgedMethods 发送对冲请求的接口，只有查询单条通知，重复请求的代价小
+var hedgedMethods = map[string]bool{
+	notificationpb.NotificationQueryService_QueryNotification_FullMethodName: true,
+}
+
+type hedgeResult struct {
+	reply proto.Message
+	err   error
+}
+
+// hedgeInterceptor 每隔 hedgeDelay 没有结果就再发一个请求，第一个成功的结果写入 reply，其余请求被取消
+// 遇到可以重试的错误时立即发送下一个请求，遇到其他错误直接返回
+func hedgeInterceptor(o *options) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption,
+	) error {
+		msg, ok := reply.(proto.Message)
+		if o.hedgeDelay <= 0 || o.hedgeMaxAttempts <= 1 || !hedgedMethods[method] || !ok {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		results := make(chan hedgeResult, o.hedgeMaxAttempts)
+		launched, inflight := 0, 0
+		launch := func() {
+			launched++
+			inflight++
+			// 并发的请求各自解码到自己的响应里
+			r := proto.Clone(msg)
+			go func() {
+				results <- hedgeResult{reply: r, err: invoker(ctx, method, req, r, cc, callOpts...)}
+			}()
+		}
+
+		launch()
+		timer := time.NewTimer(o.hedgeDelay)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-timer.C:
+				if launched < o.hedgeMaxAttempts {
+					launch()
+					o.metrics.hedged(method)
+					timer.Reset(o.hedgeDelay)
+				}
+			case res := <-results:
+				inflight--
+				if res.err == nil {
+					proto.Reset(msg)
+					proto.Merge(msg, res.reply)
+					return nil
+				}
+				if !o.retryable[status.Code(res.err)] {
+					return res.err
+				}
+				if launched < o.hedgeMaxAttempts {
+					launch()
+					o.metrics.hedged(method)
+					timer.Reset(o.hedgeDelay)
+				} else if inflight == 0 {
+					return res.err
+				}
+			}
+		}
+	}
+}
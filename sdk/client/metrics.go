@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// clientMetrics 客户端指标，method 是完整的方法名
+type clientMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	hedges   *prometheus.CounterVec
+}
+
+func newClientMetrics(reg prometheus.Registerer) *clientMetrics {
+	return &clientMetrics{
+		requests: register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "notification_client_requests_total",
+			Help: "Total number of calls to the notification platform, by method and final gRPC code",
+		}, []string{"method", "code"})),
+		duration: register(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "notification_client_request_duration_seconds",
+			Help:    "Latency of calls to the notification platform including retries and hedged requests",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+		}, []string{"method"})),
+		retries: register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "notification_client_retries_total",
+			Help: "Total number of retried calls to the notification platform",
+		}, []string{"method"})),
+		hedges: register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "notification_client_hedged_requests_total",
+			Help: "Total number of hedged requests sent to the notification platform",
+		}, []string{"method"})),
+	}
+}
+
+// register 已经注册过时使用已有的指标，多个连接可以共用同一个 Registerer
+func register[T prometheus.Collector](reg prometheus.Registerer, c T) T {
+	if err := reg.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(T); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return c
+}
+
+func (m *clientMetrics) retried(method string) {
+	if m != nil {
+		m.retries.WithLabelValues(method).Inc()
+	}
+}
+
+func (m *clientMetrics) hedged(method string) {
+	if m != nil {
+		m.hedges.WithLabelValues(method).Inc()
+	}
+}
+
+func metricsInterceptor(m *clientMetrics) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption,
+	) error {
+		if m == nil {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		m.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		m.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+		return err
+	}
+}
@@ -0,0 +1,170 @@
+// Package client 业务方调用通知平台 gRPC 接口时使用的客户端拦截器，通过 Option 配置：
+//
+//   - 重试：幂等的接口遇到 Unavailable 等临时错误时按指数退避自动重试，默认最多调用 3 次
+//   - 对冲请求：QueryNotification 在一定时间内没有响应时再发一个同样的请求，取先返回的结果，默认关闭
+//   - 客户端指标：调用次数、耗时、重试和对冲次数，默认关闭
+//   - 链路传播：为每次调用创建客户端 span，并把链路上下文写入 metadata 传给平台
+//
+// 使用方式：
+//
+//	conn, err := grpc.NewClient("localhost:8080",
+//		grpc.WithTransportCredentials(insecure.NewCredentials()),
+//		client.DialOption(
+//			client.WithRetry(3, 100*time.Millisecond, 2*time.Second),
+//			client.WithHedging(50*time.Millisecond, 2),
+//			client.WithMetrics(prometheus.DefaultRegisterer),
+//		))
+package client
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	notificationpb "github.com/serendipityConfusion/notification-platform/api/gen/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseBackoff = 100 * time.Millisecond
+	defaultMaxBackoff  = 2 * time.Second
+)
+
+// idempotentMethods 默认可以重试的接口
+// 查询接口没有副作用；发送接口和 TxPrepare 按 (bizId, key) 去重，重复调用返回已有的通知
+var idempotentMethods = []string{
+	notificationpb.NotificationQueryService_QueryNotification_FullMethodName,
+	notificationpb.NotificationQueryService_BatchQueryNotifications_FullMethodName,
+	notificationpb.NotificationQueryService_ListNotifications_FullMethodName,
+	notificationpb.NotificationQueryService_GetFallbackGroup_FullMethodName,
+	notificationpb.NotificationService_SendNotification_FullMethodName,
+	notificationpb.NotificationService_SendNotificationAsync_FullMethodName,
+	notificationpb.NotificationService_BatchSendNotifications_FullMethodName,
+	notificationpb.NotificationService_BatchSendNotificationsAsync_FullMethodName,
+	notificationpb.NotificationService_TxPrepare_FullMethodName,
+}
+
+// retryableCodes 默认重试的错误码，都是请求没有被处理或者可以安全重放的临时错误
+var retryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted}
+
+type options struct {
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	idempotent  map[string]bool
+	retryable   map[codes.Code]bool
+
+	// hedgeDelay 为 0 时不发送对冲请求
+	hedgeDelay       time.Duration
+	hedgeMaxAttempts int
+
+	// metrics 为 nil 时不统计
+	metrics        *clientMetrics
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option 配置客户端拦截器
+type Option func(*options)
+
+// WithRetry maxAttempts 是包括第一次在内最多调用的次数，小于等于 1 时不重试
+// 第 n 次重试前随机等待 0 到 baseBackoff * 2^(n-1)，最多 maxBackoff
+func WithRetry(maxAttempts int, baseBackoff, maxBackoff time.Duration) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+		o.baseBackoff = baseBackoff
+		o.maxBackoff = max(maxBackoff, baseBackoff)
+	}
+}
+
+// WithRetryCodes 替换默认重试的错误码，对冲请求遇到这些错误码时也会提前发送下一个请求
+func WithRetryCodes(cs ...codes.Code) Option {
+	return func(o *options) {
+		o.retryable = make(map[codes.Code]bool, len(cs))
+		for _, c := range cs {
+			o.retryable[c] = true
+		}
+	}
+}
+
+// WithIdempotentMethods 增加可以重试的接口，参数是完整的方法名，例如 notificationpb.NotificationService_TxCommit_FullMethodName
+func WithIdempotentMethods(methods ...string) Option {
+	return func(o *options) {
+		for _, m := range methods {
+			o.idempotent[m] = true
+		}
+	}
+}
+
+// WithHedging QueryNotification 超过 delay 没有响应时再发一个同样的请求，最多同时 maxAttempts 个，取第一个成功的结果
+// 对冲请求会增加平台的负载，delay 建议设置成查询耗时的 P95 左右
+func WithHedging(delay time.Duration, maxAttempts int) Option {
+	return func(o *options) {
+		o.hedgeDelay = delay
+		o.hedgeMaxAttempts = maxAttempts
+	}
+}
+
+// WithMetrics 把客户端指标注册到 reg，同一个 reg 注册多次时共用同一组指标
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(o *options) {
+		o.metrics = newClientMetrics(reg)
+	}
+}
+
+// WithTracerProvider 默认使用 otel 的全局 TracerProvider
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithPropagator 默认使用 otel 的全局 TextMapPropagator
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		o.propagator = p
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		maxAttempts:    defaultMaxAttempts,
+		baseBackoff:    defaultBaseBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		idempotent:     make(map[string]bool, len(idempotentMethods)),
+		retryable:      make(map[codes.Code]bool, len(retryableCodes)),
+		tracerProvider: otel.GetTracerProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, m := range idempotentMethods {
+		o.idempotent[m] = true
+	}
+	for _, c := range retryableCodes {
+		o.retryable[c] = true
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// UnaryInterceptors 按链路传播、指标、重试、对冲请求的顺序返回拦截器
+// 一次调用只有一个 span 和一条指标，重试和对冲请求在它们里面进行
+func UnaryInterceptors(opts ...Option) []grpc.UnaryClientInterceptor {
+	o := newOptions(opts)
+	return []grpc.UnaryClientInterceptor{
+		tracingInterceptor(o.tracerProvider, o.propagator),
+		metricsInterceptor(o.metrics),
+		retryInterceptor(o),
+		hedgeInterceptor(o),
+	}
+}
+
+// DialOption 创建连接时使用，等价于 grpc.WithChainUnaryInterceptor(UnaryInterceptors(opts...)...)
+func DialOption(opts ...Option) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(UnaryInterceptors(opts...)...)
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// retryInterceptor 幂等的接口遇到可以重试的错误时按指数退避重试，ctx 结束时返回最后一次的错误
+func retryInterceptor(o *options) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption,
+	) error {
+		if o.maxAttempts <= 1 || !o.idempotent[method] {
+			return invoker(ctx, method, req, reply, cc, callOpts...)
+		}
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, callOpts...)
+			if err == nil || attempt >= o.maxAttempts || !o.retryable[status.Code(err)] {
+				return err
+			}
+			if !sleep(ctx, o.backoff(attempt)) {
+				return err
+			}
+			o.metrics.retried(method)
+		}
+	}
+}
+
+// backoff 第 attempt 次失败之后的等待时间，随机抖动避免多个客户端同时重试
+func (o *options) backoff(attempt int) time.Duration {
+	d := o.baseBackoff
+	for i := 1; i < attempt && d < o.maxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, o.maxBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1)
+}
+
+// sleep ctx 结束时返回 false
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
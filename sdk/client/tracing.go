@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const instrumentationName = "github.com/serendipityConfusion/notification-platform/sdk/client"
+
+// tracingInterceptor 为每次调用创建客户端 span，并把链路上下文写入 outgoing metadata，平台的 span 会挂在它下面
+func tracingInterceptor(tp trace.TracerProvider, propagator propagation.TextMapPropagator) grpc.UnaryClientInterceptor {
+	tracer := tp.Tracer(instrumentationName)
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption,
+	) error {
+		service, name := splitMethod(method)
+		ctx, span := tracer.Start(ctx, service+"/"+name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.service", service),
+				attribute.String("rpc.method", name),
+			))
+		defer span.End()
+
+		md, _ := metadata.FromOutgoingContext(ctx)
+		md = md.Copy()
+		propagator.Inject(ctx, metadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		if err != nil {
+			s, _ := status.FromError(err)
+			span.SetStatus(otelcodes.Error, s.Message())
+			span.SetAttributes(attribute.Int64("rpc.grpc.status_code", int64(s.Code())))
+		}
+		return err
+	}
+}
+
+// splitMethod "/notification.v1.NotificationService/SendNotification" -> "notification.v1.NotificationService", "SendNotification"
+func splitMethod(method string) (string, string) {
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		return method[:i], method[i+1:]
+	}
+	return "unknown", method
+}
+
+// metadataCarrier 让 propagator 读写 gRPC metadata，键都是小写
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}